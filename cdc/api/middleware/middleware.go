@@ -80,10 +80,11 @@ func ErrorHandleMiddleware() gin.HandlerFunc {
 }
 
 // ForwardToOwnerMiddleware forward an request to owner if current server
-// is not owner, or handle it locally.
+// is not owner, or handle it locally. Requests forwarded by the owner to the
+// capture holding the requested data are handled locally too.
 func ForwardToOwnerMiddleware(p capture.Capture) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		if !p.IsOwner() {
+		if !p.IsOwner() && !api.IsForwardedFromOwner(ctx, p) {
 			api.ForwardToOwner(ctx, p)
 
 			// Without calling Abort(), Gin will continued to process the next handler,
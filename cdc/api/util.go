@@ -38,11 +38,17 @@ var httpBadRequestError = []*errors.Error{
 	cerror.ErrChangeFeedNotExists, cerror.ErrTargetTsBeforeStartTs, cerror.ErrTableIneligible,
 	cerror.ErrFilterRuleInvalid, cerror.ErrChangefeedUpdateRefused, cerror.ErrMySQLConnectionError,
 	cerror.ErrMySQLInvalidConfig, cerror.ErrCaptureNotExist, cerror.ErrSchedulerRequestFailed,
+	cerror.ErrSafePointBeforeGC, cerror.ErrSafePointLeaseNotFound, cerror.ErrInvalidSafePointLease,
+	cerror.ErrProcessorTableNotFound, cerror.ErrTableWithoutDispatchKey,
+	cerror.ErrTableNotReplicated, cerror.ErrTableNotPaused, cerror.ErrTableResetTsInvalid,
 }
 
 const (
 	// forwardFromCapture is a header to be set when forwarding requests to owner
 	forwardFromCapture = "TiCDC-ForwardFromCapture"
+	// forwardFromOwner is a header to be set when the owner forwards requests
+	// to the capture which holds the requested data.
+	forwardFromOwner = "TiCDC-ForwardFromOwner"
 )
 
 // IsHTTPBadRequestError check if a error is a http bad request error
@@ -150,6 +156,66 @@ func HandleOwnerScheduleTable(
 	}
 }
 
+// HandleOwnerPauseTables pauses replication of the changefeed tables
+func HandleOwnerPauseTables(
+	ctx context.Context, capture capture.Capture,
+	changefeedID model.ChangeFeedID, tableIDs []model.TableID,
+) error {
+	// Use buffered channel to prevent blocking owner.
+	done := make(chan error, 1)
+	o, err := capture.GetOwner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	o.PauseTables(changefeedID, tableIDs, done)
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case err := <-done:
+		return errors.Trace(err)
+	}
+}
+
+// HandleOwnerResumeTables resumes replication of the paused changefeed tables
+func HandleOwnerResumeTables(
+	ctx context.Context, capture capture.Capture,
+	changefeedID model.ChangeFeedID, tableIDs []model.TableID,
+) error {
+	// Use buffered channel to prevent blocking owner.
+	done := make(chan error, 1)
+	o, err := capture.GetOwner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	o.ResumeTables(changefeedID, tableIDs, done)
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case err := <-done:
+		return errors.Trace(err)
+	}
+}
+
+// HandleOwnerResetTable recreates replication of a changefeed table from ts
+func HandleOwnerResetTable(
+	ctx context.Context, capture capture.Capture,
+	changefeedID model.ChangeFeedID, tableID model.TableID, ts model.Ts,
+) error {
+	// Use buffered channel to prevent blocking owner.
+	done := make(chan error, 1)
+	o, err := capture.GetOwner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	o.ResetTable(changefeedID, tableID, ts, done)
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case err := <-done:
+		return errors.Trace(err)
+	}
+}
+
 // ForwardToOwner forwards an request to the owner
 func ForwardToOwner(c *gin.Context, p capture.Capture) {
 	ctx := c.Request.Context()
@@ -175,18 +241,53 @@ func ForwardToOwner(c *gin.Context, p capture.Capture) {
 		_ = c.Error(err)
 		return
 	}
+	forwardTo(c, owner, nil)
+}
+
+// ForwardToCapture forwards a request from the owner to the target capture,
+// which holds the data requested, for example the data of a table replicated
+// by the capture.
+func ForwardToCapture(c *gin.Context, p capture.Capture, target *model.CaptureInfo) {
+	// every request can only be forwarded by the owner one time
+	if len(c.GetHeader(forwardFromOwner)) != 0 {
+		_ = c.Error(cerror.ErrRequestForwardErr.FastGenByArgs())
+		return
+	}
+	info, err := p.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	forwardTo(c, target, http.Header{forwardFromOwner: []string{info.ID}})
+}
+
+// IsForwardedFromOwner returns true if the request is forwarded by the
+// current owner via ForwardToCapture.
+func IsForwardedFromOwner(c *gin.Context, p capture.Capture) bool {
+	from := c.GetHeader(forwardFromOwner)
+	if len(from) == 0 {
+		return false
+	}
+	owner, err := p.GetOwnerCaptureInfo(c.Request.Context())
+	if err != nil {
+		return false
+	}
+	return owner.ID == from
+}
 
+func forwardTo(c *gin.Context, target *model.CaptureInfo, header http.Header) {
+	ctx := c.Request.Context()
 	security := config.GetGlobalServerConfig().Security
 
 	// init a request
 	req, err := http.NewRequestWithContext(
-		ctx, c.Request.Method, c.Request.RequestURI, c.Request.Body)
+		ctx, c.Request.Method, c.Request.URL.RequestURI(), c.Request.Body)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	req.URL.Host = owner.AdvertiseAddr
+	req.URL.Host = target.AdvertiseAddr
 	// we should check tls config instead of security here because
 	// security will never be nil
 	if tls, _ := security.ToTLSConfigWithVerify(); tls != nil {
@@ -199,8 +300,13 @@ func ForwardToOwner(c *gin.Context, p capture.Capture) {
 			req.Header.Add(k, vv)
 		}
 	}
+	for k, v := range header {
+		for _, vv := range v {
+			req.Header.Add(k, vv)
+		}
+	}
 
-	// forward to owner
+	// forward to the target capture
 	cli, err := httputil.NewClient(security)
 	if err != nil {
 		_ = c.Error(err)
@@ -256,3 +362,24 @@ func HandleOwnerDrainCapture(
 
 	return query.Resp.(*model.DrainCaptureResp), errors.Trace(err)
 }
+
+// HandleOwnerCancelDrainCapture cancels draining the target capture
+func HandleOwnerCancelDrainCapture(
+	ctx context.Context, capture capture.Capture, captureID string,
+) error {
+	// Use buffered channel to prevent blocking owner.
+	done := make(chan error, 1)
+	o, err := capture.GetOwner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	o.CancelDrainCapture(&scheduler.Query{CaptureID: captureID}, done)
+
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	case err = <-done:
+	}
+	return errors.Trace(err)
+}
@@ -308,7 +308,7 @@ func (h *OpenAPI) CreateChangefeed(c *gin.Context) {
 
 	upstreamInfo := &model.UpstreamInfo{
 		ID:            up.ID,
-		PDEndpoints:   strings.Join(up.PdEndpoints, ","),
+		PDEndpoints:   strings.Join(up.GetPDEndpoints(), ","),
 		KeyPath:       up.SecurityConfig.KeyPath,
 		CertPath:      up.SecurityConfig.CertPath,
 		CAPath:        up.SecurityConfig.CAPath,
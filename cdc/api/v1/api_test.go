@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -104,6 +105,48 @@ func (p *mockStatusProvider) IsHealthy(ctx context.Context) (bool, error) {
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (p *mockStatusProvider) GetChangeFeedCheckpointHistory(ctx context.Context,
+	changefeedID model.ChangeFeedID, from, to time.Time,
+) ([]model.CheckpointSample, error) {
+	args := p.Called(ctx, changefeedID, from, to)
+	return args.Get(0).([]model.CheckpointSample), args.Error(1)
+}
+
+func (p *mockStatusProvider) GetSchedulerSnapshot(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) ([]byte, error) {
+	args := p.Called(ctx, changefeedID)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (p *mockStatusProvider) GetCaptureLoads(ctx context.Context) (
+	map[model.CaptureID]*model.CaptureLoad, error,
+) {
+	args := p.Called(ctx)
+	return args.Get(0).(map[model.CaptureID]*model.CaptureLoad), args.Error(1)
+}
+
+func (p *mockStatusProvider) GetChangeFeedWarnings(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) ([]model.ChangefeedWarning, error) {
+	args := p.Called(ctx, changefeedID)
+	return args.Get(0).([]model.ChangefeedWarning), args.Error(1)
+}
+
+func (p *mockStatusProvider) GetSchedulePlan(ctx context.Context,
+	changefeedID model.ChangeFeedID, rebalance bool,
+) ([]model.ScheduleOperation, error) {
+	args := p.Called(ctx, changefeedID, rebalance)
+	return args.Get(0).([]model.ScheduleOperation), args.Error(1)
+}
+
+func (p *mockStatusProvider) GetScheduleHistory(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) ([]model.ScheduleTaskRecord, error) {
+	args := p.Called(ctx, changefeedID)
+	return args.Get(0).([]model.ScheduleTaskRecord), args.Error(1)
+}
+
 func newRouter(c capture.Capture, p owner.StatusProvider) *gin.Engine {
 	router := gin.New()
 	RegisterOpenAPIRoutes(router, NewOpenAPI4Test(c, p))
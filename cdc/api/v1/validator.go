@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/entry"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/owner"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
 	"github.com/pingcap/tiflow/cdc/sink/validator"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -71,7 +72,7 @@ func verifyCreateChangefeedConfig(
 
 	// verify start-ts
 	if changefeedConfig.StartTS == 0 {
-		ts, logical, err := up.PDClient.GetTS(ctx)
+		ts, logical, err := up.TSOClient.GetTS(ctx)
 		if err != nil {
 			return nil, cerror.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client")
 		}
@@ -168,6 +169,10 @@ func verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, err
 	}
+	err = dispatcher.VerifyTables(replicaConfig, info.SinkURI, tableInfos)
+	if err != nil {
+		return nil, err
+	}
 	if !replicaConfig.ForceReplicate && !changefeedConfig.IgnoreIneligibleTable {
 		if len(ineligibleTables) != 0 {
 			return nil, cerror.ErrTableIneligible.GenWithStackByArgs(ineligibleTables)
@@ -44,8 +44,13 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	v2.Use(middleware.ErrorHandleMiddleware())
 
 	v2.GET("health", api.health)
+	v2.GET("ready", api.ready)
 	v2.GET("status", api.serverStatus)
 	v2.POST("log", api.setLogLevel)
+	v2.POST("sorter/compact", api.compactSorter)
+	v2.GET("metrics/summary", api.metricsSummary)
+	v2.GET("metrics/cluster_summary",
+		middleware.ForwardToOwnerMiddleware(api.capture), api.clusterMetricsSummary)
 
 	// changefeed apis
 	changefeedGroup := v2.Group("/changefeeds")
@@ -58,12 +63,35 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	changefeedGroup.GET("/:changefeed_id/meta_info", api.getChangeFeedMetaInfo)
 	changefeedGroup.POST("/:changefeed_id/resume", api.resumeChangefeed)
 	changefeedGroup.POST("/:changefeed_id/pause", api.pauseChangefeed)
+	changefeedGroup.POST("/:changefeed_id/tables/pause", api.pauseTables)
+	changefeedGroup.POST("/:changefeed_id/tables/resume", api.resumeTables)
+	changefeedGroup.POST("/:changefeed_id/tables/:table_id/reset", api.resetTable)
+	changefeedGroup.GET("/:changefeed_id/tables/:table_id/regions", api.listRegionSubscriptions)
+	changefeedGroup.GET("/:changefeed_id/tables/:table_id/events", api.sampleTableEvents)
+	changefeedGroup.POST("/:changefeed_id/reanchor", api.reanchorChangefeed)
 	changefeedGroup.GET("/:changefeed_id/status", api.status)
+	changefeedGroup.GET("/:changefeed_id/checkpoint_history", api.getCheckpointHistory)
+	changefeedGroup.GET("/:changefeed_id/warnings", api.listChangefeedWarnings)
+	changefeedGroup.GET("/:changefeed_id/scheduler_snapshot", api.getSchedulerSnapshot)
+	changefeedGroup.GET("/:changefeed_id/schedule_plan", api.getSchedulePlan)
+	changefeedGroup.GET("/:changefeed_id/safepoints", api.listSafePointLeases)
+	changefeedGroup.POST("/:changefeed_id/safepoints", api.registerSafePointLease)
+	changefeedGroup.PUT("/:changefeed_id/safepoints/:service_id", api.renewSafePointLease)
+	changefeedGroup.DELETE("/:changefeed_id/safepoints/:service_id", api.removeSafePointLease)
+
+	// federation apis
+	federationGroup := v2.Group("/federation")
+	federationGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
+	federationGroup.GET("/changefeeds", api.listFederatedChangefeeds)
 
 	// capture apis
 	captureGroup := v2.Group("/captures")
 	captureGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	captureGroup.POST("/:capture_id/drain", api.drainCapture)
+	captureGroup.DELETE("/:capture_id/drain", api.cancelDrainCapture)
+	captureGroup.POST("/:capture_id/graceful_drain", api.gracefulDrainCapture)
+	captureGroup.POST("/:capture_id/log", api.setCaptureLogLevel)
+	captureGroup.POST("/:capture_id/sorter/compact", api.compactCaptureSorter)
 	captureGroup.GET("", api.listCaptures)
 
 	// processor apis
@@ -72,6 +100,11 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	processorGroup.GET("/:changefeed_id/:capture_id", api.getProcessor)
 	processorGroup.GET("", api.listProcessors)
 
+	// estimate apis
+	estimateGroup := v2.Group("/estimates")
+	estimateGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
+	estimateGroup.POST("", api.estimateChangefeed)
+
 	verifyTableGroup := v2.Group("/verify_table")
 	verifyTableGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	verifyTableGroup.POST("", api.verifyTable)
@@ -28,11 +28,16 @@ import (
 	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/owner"
+	"github.com/pingcap/tiflow/cdc/scheduler"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/routing"
 	"github.com/pingcap/tiflow/cdc/sink/validator"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/version"
 	"github.com/r3labs/diff"
@@ -111,11 +116,33 @@ type APIV2Helpers interface {
 		credential *security.Credential,
 	) (tidbkv.Storage, error)
 
+	// createKeyspaceStore wraps kv.CreateKeyspaceStore to increase testability,
+	// the returned storage must be closed by the caller.
+	createKeyspaceStore(
+		ctx context.Context,
+		storage tidbkv.Storage,
+		pdAddrs []string,
+		credential *security.Credential,
+		keyspace string,
+	) (tidbkv.Storage, error)
+
 	// getVerfiedTables wraps entry.VerifyTables to increase testability
 	getVerfiedTables(replicaConfig *config.ReplicaConfig,
 		storage tidbkv.Storage, startTs uint64) (ineligibleTables,
 		eligibleTables []model.TableName, err error,
 	)
+
+	// getPhysicalTables returns IDs of the physical tables replicated by a
+	// changefeed with the replica config, partitions are returned instead of
+	// partitioned tables.
+	getPhysicalTables(replicaConfig *config.ReplicaConfig,
+		storage tidbkv.Storage, startTs uint64) ([]model.TableID, error)
+
+	// getPDAPIClient wraps pdutil.NewPDAPIClient to increase testability
+	getPDAPIClient(
+		pdClient pd.Client,
+		credential *security.Credential,
+	) (pdutil.PDAPIClient, error)
 }
 
 // APIV2HelpersImpl is an implementation of AVIV2Helpers interface
@@ -165,6 +192,8 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 
 	// verify start ts
 	if cfg.StartTs == 0 {
+		// The upstream may not be managed by the capture, so the start ts is
+		// requested from its PD instead of the shared TSO client.
 		ts, logical, err := pdClient.GetTS(ctx)
 		if err != nil {
 			return nil, cerror.ErrPDEtcdAPIError.GenWithStackByArgs(
@@ -201,10 +230,34 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
 	}
+
+	// verify ttl
+	var ttl time.Duration
+	if cfg.TTL != nil {
+		ttl = cfg.TTL.duration
+	}
+	if ttl < 0 {
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid ttl: %s", ttl)
+	}
+	if cfg.RemoveTopics {
+		if ttl == 0 {
+			return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+				"remove_topics requires the changefeed to have a ttl")
+		}
+		scheme := strings.ToLower(sinkURIParsed.Scheme)
+		if scheme != sink.KafkaScheme && scheme != sink.KafkaSSLScheme {
+			return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+				"remove_topics is only supported by kafka sinks")
+		}
+	}
 	err = replicaCfg.ValidateAndAdjust(sinkURIParsed)
 	if err != nil {
 		return nil, err
 	}
+	if err := scheduler.ValidatePolicy(replicaCfg.Scheduler.Policy); err != nil {
+		return nil, err
+	}
 
 	f, err := filter.NewFilter(replicaCfg, "")
 	if err != nil {
@@ -218,6 +271,15 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, errors.Cause(err)
 	}
+	// The dispatchers match the routed tables.
+	routedTables, err := routing.VerifyTables(replicaCfg, tableInfos)
+	if err != nil {
+		return nil, err
+	}
+	err = dispatcher.VerifyTables(replicaCfg, cfg.SinkURI, routedTables)
+	if err != nil {
+		return nil, err
+	}
 	if !replicaCfg.ForceReplicate && !cfg.ReplicaConfig.IgnoreIneligibleTable {
 		if err != nil {
 			return nil, err
@@ -240,8 +302,11 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 		ID:             cfg.ID,
 		SinkURI:        cfg.SinkURI,
 		CreateTime:     time.Now(),
+		Keyspace:       cfg.Keyspace,
 		StartTs:        cfg.StartTs,
 		TargetTs:       cfg.TargetTs,
+		TTL:            ttl,
+		RemoveTopics:   cfg.RemoveTopics,
 		Config:         replicaCfg,
 		State:          model.StateNormal,
 		CreatorVersion: version.ReleaseVersion,
@@ -295,6 +360,11 @@ func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
 	}
 
 	var configUpdated, sinkURIUpdated bool
+	if cfg.Keyspace != "" && cfg.Keyspace != oldInfo.Keyspace {
+		return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStack(
+			"can not update keyspace of a changefeed from %q to %q",
+			oldInfo.Keyspace, cfg.Keyspace)
+	}
 	if cfg.TargetTs != 0 {
 		if cfg.TargetTs <= newInfo.StartTs {
 			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStack(
@@ -327,6 +397,14 @@ func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
 		return nil, nil, cerror.ErrChangefeedUpdateRefused.
 			GenWithStackByArgs(errors.Cause(err).Error())
 	}
+	routedTables, err := routing.VerifyTables(newInfo.Config, tableInfos)
+	if err != nil {
+		return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+	}
+	err = dispatcher.VerifyTables(newInfo.Config, newInfo.SinkURI, routedTables)
+	if err != nil {
+		return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+	}
 
 	if configUpdated || sinkURIUpdated {
 		log.Info("config or sink uri updated, check the compatibility",
@@ -353,6 +431,10 @@ func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
 		if err != nil {
 			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
 		}
+		err = scheduler.ValidatePolicy(newInfo.Config.Scheduler.Policy)
+		if err != nil {
+			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+		}
 
 		if err := validator.Validate(ctx,
 			model.ChangeFeedID{Namespace: cfg.Namespace, ID: cfg.ID},
@@ -492,6 +574,39 @@ func (h APIV2HelpersImpl) createTiStore(pdAddrs []string,
 	return kv.CreateTiStore(strings.Join(pdAddrs, ","), credential)
 }
 
+// closePDClientStorage closes the PD client of a keyspace storage when the
+// storage is closed.
+type closePDClientStorage struct {
+	tidbkv.Storage
+	pdClient pd.Client
+}
+
+func (s *closePDClientStorage) Close() error {
+	err := s.Storage.Close()
+	s.pdClient.Close()
+	return err
+}
+
+func (h APIV2HelpersImpl) createKeyspaceStore(
+	ctx context.Context,
+	storage tidbkv.Storage,
+	pdAddrs []string,
+	credential *security.Credential,
+	keyspace string,
+) (tidbkv.Storage, error) {
+	pdClient, err := h.getPDClient(ctx, pdAddrs, credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keyspaceStorage, err := kv.CreateKeyspaceStore(
+		storage, pdClient, pdAddrs, credential, keyspace)
+	if err != nil {
+		pdClient.Close()
+		return nil, errors.Trace(err)
+	}
+	return &closePDClientStorage{Storage: keyspaceStorage, pdClient: pdClient}, nil
+}
+
 func (h APIV2HelpersImpl) getVerfiedTables(replicaConfig *config.ReplicaConfig,
 	storage tidbkv.Storage, startTs uint64) (ineligibleTables,
 	eligibleTables []model.TableName, err error,
@@ -504,3 +619,39 @@ func (h APIV2HelpersImpl) getVerfiedTables(replicaConfig *config.ReplicaConfig,
 		VerifyTables(f, storage, startTs)
 	return
 }
+
+// getPhysicalTables returns IDs of the physical tables replicated by a
+// changefeed with the replica config.
+func (h APIV2HelpersImpl) getPhysicalTables(replicaConfig *config.ReplicaConfig,
+	storage tidbkv.Storage, startTs uint64,
+) ([]model.TableID, error) {
+	f, err := filter.NewFilter(replicaConfig, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tableInfos, _, _, err := entry.VerifyTables(f, storage, startTs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tableIDs []model.TableID
+	for _, tableInfo := range tableInfos {
+		if tableInfo.IsView() || !tableInfo.IsEligible(replicaConfig.ForceReplicate) {
+			continue
+		}
+		if pi := tableInfo.GetPartitionInfo(); pi != nil {
+			for _, partition := range pi.Definitions {
+				tableIDs = append(tableIDs, partition.ID)
+			}
+			continue
+		}
+		tableIDs = append(tableIDs, tableInfo.ID)
+	}
+	return tableIDs, nil
+}
+
+// getPDAPIClient returns a PD API client, which must be closed by the caller.
+func (h APIV2HelpersImpl) getPDAPIClient(
+	pdClient pd.Client, credential *security.Credential,
+) (pdutil.PDAPIClient, error) {
+	return pdutil.NewPDAPIClient(pdClient, credential)
+}
@@ -14,6 +14,7 @@ import (
 	model "github.com/pingcap/tiflow/cdc/model"
 	owner "github.com/pingcap/tiflow/cdc/owner"
 	config "github.com/pingcap/tiflow/pkg/config"
+	pdutil "github.com/pingcap/tiflow/pkg/pdutil"
 	security "github.com/pingcap/tiflow/pkg/security"
 	client "github.com/tikv/pd/client"
 	v3 "go.etcd.io/etcd/client/v3"
@@ -42,6 +43,21 @@ func (m *MockAPIV2Helpers) EXPECT() *MockAPIV2HelpersMockRecorder {
 	return m.recorder
 }
 
+// createKeyspaceStore mocks base method.
+func (m *MockAPIV2Helpers) createKeyspaceStore(ctx context.Context, storage kv.Storage, pdAddrs []string, credential *security.Credential, keyspace string) (kv.Storage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createKeyspaceStore", ctx, storage, pdAddrs, credential, keyspace)
+	ret0, _ := ret[0].(kv.Storage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createKeyspaceStore indicates an expected call of createKeyspaceStore.
+func (mr *MockAPIV2HelpersMockRecorder) createKeyspaceStore(ctx, storage, pdAddrs, credential, keyspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createKeyspaceStore", reflect.TypeOf((*MockAPIV2Helpers)(nil).createKeyspaceStore), ctx, storage, pdAddrs, credential, keyspace)
+}
+
 // createTiStore mocks base method.
 func (m *MockAPIV2Helpers) createTiStore(pdAddrs []string, credential *security.Credential) (kv.Storage, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +103,36 @@ func (mr *MockAPIV2HelpersMockRecorder) getPDClient(ctx, pdAddrs, credential int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPDClient", reflect.TypeOf((*MockAPIV2Helpers)(nil).getPDClient), ctx, pdAddrs, credential)
 }
 
+// getPDAPIClient mocks base method.
+func (m *MockAPIV2Helpers) getPDAPIClient(pdClient client.Client, credential *security.Credential) (pdutil.PDAPIClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPDAPIClient", pdClient, credential)
+	ret0, _ := ret[0].(pdutil.PDAPIClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getPDAPIClient indicates an expected call of getPDAPIClient.
+func (mr *MockAPIV2HelpersMockRecorder) getPDAPIClient(pdClient, credential interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPDAPIClient", reflect.TypeOf((*MockAPIV2Helpers)(nil).getPDAPIClient), pdClient, credential)
+}
+
+// getPhysicalTables mocks base method.
+func (m *MockAPIV2Helpers) getPhysicalTables(replicaConfig *config.ReplicaConfig, storage kv.Storage, startTs uint64) ([]model.TableID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPhysicalTables", replicaConfig, storage, startTs)
+	ret0, _ := ret[0].([]model.TableID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getPhysicalTables indicates an expected call of getPhysicalTables.
+func (mr *MockAPIV2HelpersMockRecorder) getPhysicalTables(replicaConfig, storage, startTs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPhysicalTables", reflect.TypeOf((*MockAPIV2Helpers)(nil).getPhysicalTables), replicaConfig, storage, startTs)
+}
+
 // getVerfiedTables mocks base method.
 func (m *MockAPIV2Helpers) getVerfiedTables(replicaConfig *config.ReplicaConfig, storage kv.Storage, startTs uint64) ([]model.TableName, []model.TableName, error) {
 	m.ctrl.T.Helper()
@@ -104,6 +104,30 @@ func TestVerifyCreateChangefeedConfig(t *testing.T) {
 	cfg.ReplicaConfig.ForceReplicate = true
 	cfInfo, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
 	require.Error(t, cerror.ErrOldValueNotEnabled, err)
+
+	// ttl and remove topics
+	cfg.ReplicaConfig.ForceReplicate = false
+	cfg.TTL = &JSONDuration{-time.Second}
+	_, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.True(t, cerror.ErrAPIInvalidParam.Equal(err))
+	cfg.TTL = nil
+	cfg.RemoveTopics = true
+	_, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.ErrorContains(t, err, "requires the changefeed to have a ttl")
+	cfg.TTL = &JSONDuration{time.Hour}
+	_, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.ErrorContains(t, err, "only supported by kafka sinks")
+	cfg.RemoveTopics = false
+	cfInfo, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, cfInfo.TTL)
+	require.False(t, cfInfo.RemoveTopics)
+
+	// unknown scheduler policy
+	cfg.ReplicaConfig.Scheduler.Policy = "unknown"
+	_, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+	cfg.ReplicaConfig.Scheduler.Policy = ""
 }
 
 func TestVerifyUpdateChangefeedConfig(t *testing.T) {
@@ -167,4 +191,14 @@ func TestVerifyUpdateChangefeedConfig(t *testing.T) {
 	cfg.ReplicaConfig.ForceReplicate = true
 	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
 	require.Error(t, cerror.ErrOldValueNotEnabled, err)
+
+	// keyspace can not be updated
+	cfg.ReplicaConfig.ForceReplicate = false
+	cfg.Keyspace = "ks1"
+	_, _, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
+	require.True(t, cerror.ErrChangefeedUpdateRefused.Equal(err))
+	oldInfo.Keyspace = "ks1"
+	newCfInfo, _, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
+	require.NoError(t, err)
+	require.Equal(t, "ks1", newCfInfo.Keyspace)
 }
@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -68,9 +69,19 @@ type mockStatusProvider struct {
 	taskStatus         map[model.CaptureID]*model.TaskStatus
 	changefeedInfos    map[model.ChangeFeedID]*model.ChangeFeedInfo
 	changefeedStatuses map[model.ChangeFeedID]*model.ChangeFeedStatusForAPI
+	captures           []*model.CaptureInfo
+	checkpointSamples  []model.CheckpointSample
+	schedulerSnapshot  []byte
+	captureLoads       map[model.CaptureID]*model.CaptureLoad
+	scheduleTasks      []model.ScheduleTaskRecord
 	err                error
 }
 
+// GetCaptures returns the information about all captures.
+func (m *mockStatusProvider) GetCaptures(ctx context.Context) ([]*model.CaptureInfo, error) {
+	return m.captures, m.err
+}
+
 // GetChangeFeedStatus returns a changefeeds' runtime status.
 func (m *mockStatusProvider) GetChangeFeedStatus(ctx context.Context,
 	changefeedID model.ChangeFeedID,
@@ -119,3 +130,37 @@ func (m *mockStatusProvider) GetAllChangeFeedStatuses(_ context.Context) (
 ) {
 	return m.changefeedStatuses, m.err
 }
+
+// GetChangeFeedCheckpointHistory returns the mock samples between from and to.
+func (m *mockStatusProvider) GetChangeFeedCheckpointHistory(_ context.Context,
+	_ model.ChangeFeedID, from, to time.Time,
+) ([]model.CheckpointSample, error) {
+	var samples []model.CheckpointSample
+	for _, sample := range m.checkpointSamples {
+		if !sample.Time.Before(from) && !sample.Time.After(to) {
+			samples = append(samples, sample)
+		}
+	}
+	return samples, m.err
+}
+
+// GetSchedulerSnapshot returns the mock scheduler snapshot.
+func (m *mockStatusProvider) GetSchedulerSnapshot(_ context.Context,
+	_ model.ChangeFeedID,
+) ([]byte, error) {
+	return m.schedulerSnapshot, m.err
+}
+
+// GetCaptureLoads returns the mock capture loads.
+func (m *mockStatusProvider) GetCaptureLoads(_ context.Context) (
+	map[model.CaptureID]*model.CaptureLoad, error,
+) {
+	return m.captureLoads, m.err
+}
+
+// GetScheduleHistory returns the mock schedule tasks.
+func (m *mockStatusProvider) GetScheduleHistory(_ context.Context,
+	_ model.ChangeFeedID,
+) ([]model.ScheduleTaskRecord, error) {
+	return m.scheduleTasks, m.err
+}
@@ -14,35 +14,133 @@
 package v2
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/owner"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
 )
 
-const apiOpVarCaptureID = "capture_id"
+const (
+	apiOpVarCaptureID = "capture_id"
+	// apiOpVarDrainTimeout is the key of the timeout of draining a capture
+	// gracefully, after which tables are moved out of it forcibly.
+	apiOpVarDrainTimeout = "timeout"
+)
 
 // drainCapture remove all tables at the given capture.
 func (h *OpenAPIV2) drainCapture(c *gin.Context) {
-	captureID := c.Param(apiOpVarCaptureID)
+	target := c.Param(apiOpVarCaptureID)
+	ctx := c.Request.Context()
+	if err := h.checkDrainTarget(ctx, target); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resp, err := api.HandleOwnerDrainCapture(ctx, h.capture, target)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusServiceUnavailable, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// gracefulDrainCapture drains the given capture and waits until it has no
+// table, so that it can be shut down in a rolling restart. If the draining
+// does not finish in the timeout, tables are moved out of it forcibly. The
+// draining is canceled if the request is canceled.
+// @Summary Drain a capture gracefully
+// @Description drain all tables of a capture and wait until it can be shut down
+// @Tags capture,v2
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Param timeout query string false "10m"
+// @Success 200 {object} DrainCaptureProgress
+// @Failure 500,400,503 {object} model.HTTPError
+// @Router /api/v2/captures/{capture_id}/graceful_drain [post]
+func (h *OpenAPIV2) gracefulDrainCapture(c *gin.Context) {
+	target := c.Param(apiOpVarCaptureID)
+	var opts owner.DrainCaptureOptions
+	if timeout := c.Query(apiOpVarDrainTimeout); timeout != "" {
+		var err error
+		opts.Timeout, err = time.ParseDuration(timeout)
+		if err != nil {
+			_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+			return
+		}
+	}
 
 	ctx := c.Request.Context()
-	captures, err := h.capture.StatusProvider().GetCaptures(ctx)
+	if err := h.checkDrainTarget(ctx, target); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	o, err := h.capture.GetOwner()
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	progress, err := owner.DrainCaptureGracefully(ctx, o, target, opts,
+		func(progress owner.DrainProgress) {
+			log.Info("drain capture gracefully",
+				zap.String("target", progress.CaptureID),
+				zap.Int("tableCount", progress.TableCount),
+				zap.Bool("forced", progress.Forced))
+		})
+	if err != nil {
+		_ = c.AbortWithError(http.StatusServiceUnavailable, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &DrainCaptureProgress{
+		CaptureID:         progress.CaptureID,
+		CurrentTableCount: progress.TableCount,
+		Forced:            progress.Forced,
+		Ready:             progress.Ready,
+	})
+}
+
+// cancelDrainCapture stops draining the given capture, tables moved out of
+// it are not moved back.
+// @Summary Cancel draining a capture
+// @Description stop draining a capture
+// @Tags capture,v2
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400,503 {object} model.HTTPError
+// @Router /api/v2/captures/{capture_id}/drain [delete]
+func (h *OpenAPIV2) cancelDrainCapture(c *gin.Context) {
+	target := c.Param(apiOpVarCaptureID)
+	err := api.HandleOwnerCancelDrainCapture(c.Request.Context(), h.capture, target)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusServiceUnavailable, err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// checkDrainTarget returns an error if the target capture cannot be drained.
+func (h *OpenAPIV2) checkDrainTarget(ctx context.Context, target string) error {
+	captures, err := h.capture.StatusProvider().GetCaptures(ctx)
+	if err != nil {
+		return err
+	}
+
 	// drain capture only work if there is at least two alive captures,
 	// it cannot work properly if it has only one capture.
 	if len(captures) <= 1 {
-		_ = c.Error(cerror.ErrSchedulerRequestFailed.
-			GenWithStackByArgs("only one capture alive"))
-		return
+		return cerror.ErrSchedulerRequestFailed.
+			GenWithStackByArgs("only one capture alive")
 	}
 
-	target := captureID
 	checkCaptureFound := func() bool {
 		// make sure the target capture exist
 		for _, capture := range captures {
@@ -54,35 +152,26 @@ func (h *OpenAPIV2) drainCapture(c *gin.Context) {
 	}
 
 	if !checkCaptureFound() {
-		_ = c.Error(cerror.ErrCaptureNotExist.GenWithStackByArgs(target))
-		return
+		return cerror.ErrCaptureNotExist.GenWithStackByArgs(target)
 	}
 
 	// only owner handle api request, so this must be the owner.
 	ownerInfo, err := h.capture.Info()
 	if err != nil {
-		_ = c.Error(err)
-		return
+		return err
 	}
 
 	if ownerInfo.ID == target {
-		_ = c.Error(cerror.ErrSchedulerRequestFailed.
-			GenWithStackByArgs("cannot drain the owner"))
-		return
-	}
-
-	resp, err := api.HandleOwnerDrainCapture(ctx, h.capture, target)
-	if err != nil {
-		_ = c.AbortWithError(http.StatusServiceUnavailable, err)
-		return
+		return cerror.ErrSchedulerRequestFailed.
+			GenWithStackByArgs("cannot drain the owner")
 	}
-
-	c.JSON(http.StatusAccepted, resp)
+	return nil
 }
 
 // listCaptures lists all captures
 // @Summary List captures
-// @Description list all captures in cdc cluster
+// @Description list all captures in cdc cluster, with the loads reported by
+// @Description them in scheduler heartbeats
 // @Tags capture,v2
 // @Produce json
 // @Success 200 {array} Capture
@@ -90,7 +179,8 @@ func (h *OpenAPIV2) drainCapture(c *gin.Context) {
 // @Router	/api/v2/captures [get]
 func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 	ctx := c.Request.Context()
-	captureInfos, err := h.capture.StatusProvider().GetCaptures(ctx)
+	statusProvider := h.capture.StatusProvider()
+	captureInfos, err := statusProvider.GetCaptures(ctx)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -101,6 +191,11 @@ func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 		return
 	}
 	ownerID := info.ID
+	// Loads are best-effort, captures are still listed without them.
+	loads, err := statusProvider.GetCaptureLoads(ctx)
+	if err != nil {
+		log.Warn("failed to get loads of captures", zap.Error(err))
+	}
 
 	etcdClient := h.capture.GetEtcdClient()
 
@@ -113,6 +208,8 @@ func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 				IsOwner:       isOwner,
 				AdvertiseAddr: c.AdvertiseAddr,
 				ClusterID:     etcdClient.GetClusterID(),
+				Load:          loads[c.ID],
+				Labels:        c.Labels,
 			})
 	}
 	resp := &ListResponse[Capture]{
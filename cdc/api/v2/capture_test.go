@@ -24,6 +24,7 @@ import (
 	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
 	"github.com/pingcap/tiflow/cdc/model"
 	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	"github.com/pingcap/tiflow/cdc/scheduler"
 	"github.com/pingcap/tiflow/pkg/errors"
 	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
 	"github.com/stretchr/testify/require"
@@ -108,6 +109,15 @@ func TestListCaptures(t *testing.T) {
 		cp.EXPECT().Info().Return(model.CaptureInfo{
 			ID: "owner-id",
 		}, nil)
+		load := &model.CaptureLoad{
+			CPUUsage:    150,
+			MemoryBytes: 1 << 30,
+			Changefeeds: []model.ChangefeedLoad{{
+				Namespace: "default", ID: "test", TableCount: 3, SinkRowsPerSecond: 100,
+			}},
+		}
+		statusProvider.EXPECT().GetCaptureLoads(gomock.Any()).
+			Return(map[model.CaptureID]*model.CaptureLoad{"capture-id": load}, nil)
 		etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
 		etcdClient.EXPECT().GetClusterID().AnyTimes().Return("cdc-cluster-id")
 		cp.EXPECT().GetEtcdClient().AnyTimes().Return(etcdClient)
@@ -133,11 +143,84 @@ func TestListCaptures(t *testing.T) {
 				require.True(t, item.IsOwner)
 				require.Equal(t, "add1", item.AdvertiseAddr)
 				require.Equal(t, "cdc-cluster-id", item.ClusterID)
+				require.Nil(t, item.Load)
 			} else {
 				require.False(t, item.IsOwner)
 				require.Equal(t, "add2", item.AdvertiseAddr)
 				require.Equal(t, "cdc-cluster-id", item.ClusterID)
+				require.Equal(t, load, item.Load)
 			}
 		}
 	}
 }
+
+func TestGracefulDrainCapture(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	statusProvider := mock_owner.NewMockStatusProvider(ctrl)
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	statusProvider.EXPECT().GetCaptures(gomock.Any()).Return([]*model.CaptureInfo{
+		{ID: "owner-id"}, {ID: "capture-id"},
+	}, nil).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{ID: "owner-id"}, nil).AnyTimes()
+	o := mock_owner.NewMockOwner(ctrl)
+	cp.EXPECT().GetOwner().Return(o, nil).AnyTimes()
+
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	// case 1: invalid timeout.
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), "POST",
+		"/api/v2/captures/capture-id/graceful_drain?timeout=abc", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// case 2: the owner can not be drained.
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), "POST",
+		"/api/v2/captures/owner-id/graceful_drain", nil)
+	router.ServeHTTP(w, req)
+	respErr := model.HTTPError{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Error, "cannot drain the owner")
+
+	// case 3: drained forcibly after the timeout.
+	o.EXPECT().DrainCapture(gomock.Any(), gomock.Any()).
+		Do(func(query *scheduler.Query, done chan<- error) {
+			require.Equal(t, "capture-id", query.CaptureID)
+			query.Resp = &model.DrainCaptureResp{CurrentTableCount: 1}
+			close(done)
+		}).MinTimes(1)
+	o.EXPECT().ForceDrainCapture(gomock.Any(), gomock.Any()).
+		Do(func(query *scheduler.Query, done chan<- error) {
+			query.Resp = &model.DrainCaptureResp{}
+			close(done)
+		})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), "POST",
+		"/api/v2/captures/capture-id/graceful_drain?timeout=10ms", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	progress := &DrainCaptureProgress{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(progress))
+	require.Equal(t, &DrainCaptureProgress{
+		CaptureID: "capture-id", Forced: true, Ready: true,
+	}, progress)
+
+	// case 4: cancel draining.
+	o.EXPECT().CancelDrainCapture(gomock.Any(), gomock.Any()).
+		Do(func(query *scheduler.Query, done chan<- error) {
+			require.Equal(t, "capture-id", query.CaptureID)
+			close(done)
+		})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), "DELETE",
+		"/api/v2/captures/capture-id/drain", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
@@ -94,6 +94,15 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 	// We should not close kvStorage since all kvStorage in cdc is the same one.
 	// defer kvStorage.Close()
 	// TODO: We should get a kvStorage from upstream instead of creating a new one
+	if cfg.Keyspace != "" {
+		kvStorage, err = h.helpers.createKeyspaceStore(
+			timeoutCtx, kvStorage, cfg.PDAddrs, credential, cfg.Keyspace)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		defer kvStorage.Close()
+	}
 	info, err := h.helpers.verifyCreateChangefeedConfig(
 		ctx,
 		cfg,
@@ -225,17 +234,32 @@ func hasRunningImport(ctx context.Context, cli *clientv3.Client) error {
 func (h *OpenAPIV2) listChangeFeeds(c *gin.Context) {
 	ctx := c.Request.Context()
 	state := c.Query(apiOpVarChangefeedState)
-	statuses, err := h.capture.StatusProvider().GetAllChangeFeedStatuses(ctx)
+	namespace := getNamespaceValueWithDefault(c)
+	commonInfos, err := h.getChangefeedCommonInfos(ctx, namespace, state)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
-	namespace := getNamespaceValueWithDefault(c)
+	resp := &ListResponse[ChangefeedCommonInfo]{
+		Total: len(commonInfos),
+		Items: commonInfos,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
 
+// getChangefeedCommonInfos returns the common info of changefeeds in the
+// namespace whose state is needed, sorted by changefeed ID.
+func (h *OpenAPIV2) getChangefeedCommonInfos(
+	ctx context.Context, namespace string, state string,
+) ([]ChangefeedCommonInfo, error) {
+	statuses, err := h.capture.StatusProvider().GetAllChangeFeedStatuses(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	infos, err := h.capture.StatusProvider().GetAllChangeFeedInfo(ctx)
 	if err != nil {
-		_ = c.Error(err)
-		return
+		return nil, errors.Trace(err)
 	}
 
 	commonInfos := make([]ChangefeedCommonInfo, 0)
@@ -271,6 +295,7 @@ func (h *OpenAPIV2) listChangeFeeds(c *gin.Context) {
 		// return the common info only.
 		commonInfo := &ChangefeedCommonInfo{
 			UpstreamID:   cfInfo.UpstreamID,
+			Keyspace:     cfInfo.Keyspace,
 			Namespace:    cfID.Namespace,
 			ID:           cfID.ID,
 			FeedState:    cfInfo.State,
@@ -290,12 +315,7 @@ func (h *OpenAPIV2) listChangeFeeds(c *gin.Context) {
 
 		commonInfos = append(commonInfos, *commonInfo)
 	}
-	resp := &ListResponse[ChangefeedCommonInfo]{
-		Total: len(commonInfos),
-		Items: commonInfos,
-	}
-
-	c.JSON(http.StatusOK, resp)
+	return commonInfos, nil
 }
 
 func getNamespaceValueWithDefault(c *gin.Context) string {
@@ -328,6 +348,15 @@ func (h *OpenAPIV2) verifyTable(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	if cfg.Keyspace != "" {
+		kvStore, err = h.helpers.createKeyspaceStore(
+			c.Request.Context(), kvStore, cfg.PDAddrs, credential, cfg.Keyspace)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		defer kvStore.Close()
+	}
 	replicaCfg := cfg.ReplicaConfig.ToInternalReplicaConfig()
 	ineligibleTables, eligibleTables, err := h.helpers.
 		getVerfiedTables(replicaCfg, kvStore, cfg.StartTs)
@@ -449,6 +478,15 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 	if err != nil {
 		_ = c.Error(errors.Trace(err))
 	}
+	if oldCfInfo.Keyspace != "" {
+		storage, err = h.helpers.createKeyspaceStore(
+			ctx, storage, pdAddrs, credentials, oldCfInfo.Keyspace)
+		if err != nil {
+			_ = c.Error(errors.Trace(err))
+			return
+		}
+		defer storage.Close()
+	}
 	newCfInfo, newUpInfo, err := h.helpers.verifyUpdateChangefeedConfig(ctx,
 		updateCfConfig, oldCfInfo, OldUpInfo, storage, cfStatus.CheckpointTs)
 	if err != nil {
@@ -677,7 +715,7 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 		return
 	}
 
-	_, err = h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	info, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -688,6 +726,14 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
 		return
 	}
+	// The checkpoint of a rewound changefeed may be ahead of the upstream,
+	// it must be resumed from a new checkpoint.
+	if info.State == model.StateUpstreamRewound && cfg.OverwriteCheckpointTs == 0 {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"changefeed %s is in %s state, please re-anchor it or resume it "+
+				"with overwrite_checkpoint_ts", changefeedID.ID, info.State))
+		return
+	}
 
 	if len(cfg.PDAddrs) == 0 {
 		up, err := getCaptureDefaultUpstream(h.capture)
@@ -834,16 +880,46 @@ func (h *OpenAPIV2) status(c *gin.Context) {
 			Message: info.Warning.Message,
 		}
 	}
+	records, err := h.capture.StatusProvider().GetScheduleHistory(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	c.JSON(http.StatusOK, &ChangefeedStatus{
-		State:        string(info.State),
-		CheckpointTs: status.CheckpointTs,
-		ResolvedTs:   status.ResolvedTs,
-		LastError:    lastError,
-		LastWarning:  lastWarning,
+		State:         string(info.State),
+		CheckpointTs:  status.CheckpointTs,
+		ResolvedTs:    status.ResolvedTs,
+		LastError:     lastError,
+		LastWarning:   lastWarning,
+		PausedTables:  toPausedTables(status.PausedTables),
+		ScheduleTasks: toScheduleTasks(records),
 	})
 }
 
+func toScheduleTasks(records []model.ScheduleTaskRecord) []ScheduleTask {
+	if len(records) == 0 {
+		return nil
+	}
+	tasks := make([]ScheduleTask, 0, len(records))
+	for _, r := range records {
+		tasks = append(tasks, ScheduleTask{
+			ScheduleOperation: ScheduleOperation{
+				Scheduler: r.Scheduler,
+				Type:      r.Type,
+				TableID:   r.TableID,
+				Span:      r.Span,
+				From:      r.From,
+				To:        r.To,
+			},
+			State:     r.State,
+			StartTime: r.StartTime,
+			Duration:  r.Duration.Milliseconds(),
+		})
+	}
+	return tasks
+}
+
 func toAPIModel(
 	info *model.ChangeFeedInfo,
 	resolvedTs uint64,
@@ -872,16 +948,27 @@ func toAPIModel(
 		}
 	}
 
+	apiConfig := ToAPIReplicaConfig(info.Config)
+	if maskSinkURI && apiConfig.Sink != nil && apiConfig.Sink.TeeSinkURI != nil {
+		teeSinkURI, err := util.MaskSinkURI(*apiConfig.Sink.TeeSinkURI)
+		if err != nil {
+			log.Error("failed to mask tee sink URI", zap.Error(err))
+		}
+		apiConfig.Sink.TeeSinkURI = &teeSinkURI
+	}
+
 	apiInfoModel := &ChangeFeedInfo{
 		UpstreamID:     info.UpstreamID,
 		Namespace:      info.Namespace,
 		ID:             info.ID,
 		SinkURI:        sinkURI,
 		CreateTime:     info.CreateTime,
+		Keyspace:       info.Keyspace,
 		StartTs:        info.StartTs,
 		TargetTs:       info.TargetTs,
+		RemoveTopics:   info.RemoveTopics,
 		AdminJobType:   info.AdminJobType,
-		Config:         ToAPIReplicaConfig(info.Config),
+		Config:         apiConfig,
 		State:          info.State,
 		Error:          runningError,
 		CreatorVersion: info.CreatorVersion,
@@ -890,6 +977,9 @@ func toAPIModel(
 		CheckpointTime: model.JSONTime(oracle.GetTimeFromTS(checkpointTs)),
 		TaskStatus:     taskStatus,
 	}
+	if info.TTL > 0 {
+		apiInfoModel.TTL = &JSONDuration{info.TTL}
+	}
 	return apiInfoModel
 }
 
@@ -907,7 +997,7 @@ func getCaptureDefaultUpstream(cp capture.Capture) (*upstream.Upstream, error) {
 
 func getUpstreamPDConfig(up *upstream.Upstream) PDConfig {
 	return PDConfig{
-		PDAddrs:  up.PdEndpoints,
+		PDAddrs:  up.GetPDEndpoints(),
 		KeyPath:  up.SecurityConfig.KeyPath,
 		CAPath:   up.SecurityConfig.CAPath,
 		CertPath: up.SecurityConfig.CertPath,
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	tidbkv "github.com/pingcap/tidb/kv"
@@ -941,6 +942,67 @@ func TestPauseChangefeed(t *testing.T) {
 	require.Equal(t, "{}", w.Body.String())
 }
 
+func TestChangefeedStatus(t *testing.T) {
+	t.Parallel()
+
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+	start := time.Unix(1700000000, 0).UTC()
+	statusProvider := &mockStatusProvider{
+		changefeedInfo:   &model.ChangeFeedInfo{State: model.StateNormal},
+		changefeedStatus: &model.ChangeFeedStatusForAPI{CheckpointTs: 1, ResolvedTs: 2},
+		scheduleTasks: []model.ScheduleTaskRecord{{
+			ScheduleOperation: model.ScheduleOperation{
+				Scheduler: "drain-capture-scheduler",
+				Type:      model.ScheduleOperationMove,
+				TableID:   1,
+				Span:      "{table_id:1,start_key:,end_key:}",
+				From:      "a",
+				To:        "b",
+			},
+			State:     model.ScheduleTaskFinished,
+			StartTime: start,
+			Duration:  1500 * time.Millisecond,
+		}},
+	}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/changefeeds/cf/status", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ChangefeedStatus{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, "normal", resp.State)
+	require.EqualValues(t, 1, resp.CheckpointTs)
+	require.EqualValues(t, 2, resp.ResolvedTs)
+	require.Equal(t, []ScheduleTask{{
+		ScheduleOperation: ScheduleOperation{
+			Scheduler: "drain-capture-scheduler",
+			Type:      "move",
+			TableID:   1,
+			Span:      "{table_id:1,start_key:,end_key:}",
+			From:      "a",
+			To:        "b",
+		},
+		State:     "finished",
+		StartTime: start,
+		Duration:  1500,
+	}}, resp.ScheduleTasks)
+
+	// The changefeed is not running.
+	statusProvider.scheduleTasks = nil
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/changefeeds/cf/status", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "schedule_tasks")
+}
+
 func TestHasRunningImport(t *testing.T) {
 	integration.BeforeTestExternal(t)
 	testEtcdCluster := integration.NewClusterV3(
@@ -0,0 +1,97 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// apiOpVarFrom is the key of the start time of a time range in HTTP API
+	apiOpVarFrom = "from"
+	// apiOpVarTo is the key of the end time of a time range in HTTP API
+	apiOpVarTo = "to"
+)
+
+// defaultCheckpointHistoryRange is the time range of the checkpoint history
+// if the start time is not specified.
+const defaultCheckpointHistoryRange = time.Hour
+
+// getCheckpointHistory lists the checkpoint history of a changefeed
+// @Summary Get the checkpoint history of a changefeed
+// @Description get the checkpoint ts and resolved ts samples of a changefeed,
+// @Description which are recorded by the owner periodically. from and to are
+// @Description RFC3339 times, the last hour is returned by default.
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param from query string false "2006-01-02T15:04:05Z"
+// @Param to query string false "2006-01-02T15:04:05Z"
+// @Success 200 {array} CheckpointSample
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/checkpoint_history [get]
+func (h *OpenAPIV2) getCheckpointHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	to := time.Now()
+	if v := c.Query(apiOpVarTo); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+			return
+		}
+	}
+	from := to.Add(-defaultCheckpointHistoryRange)
+	if v := c.Query(apiOpVarFrom); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+			return
+		}
+	}
+	if from.After(to) {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"from %s is after to %s", from, to))
+		return
+	}
+
+	samples, err := h.capture.StatusProvider().GetChangeFeedCheckpointHistory(
+		ctx, changefeedID, from, to)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	items := make([]CheckpointSample, 0, len(samples))
+	for _, sample := range samples {
+		items = append(items, CheckpointSample{
+			Time:         sample.Time,
+			CheckpointTs: sample.CheckpointTs,
+			ResolvedTs:   sample.ResolvedTs,
+			CheckpointLag: sample.Time.Sub(
+				oracle.GetTimeFromTS(sample.CheckpointTs)).Milliseconds(),
+		})
+	}
+	c.JSON(http.StatusOK, &ListResponse[CheckpointSample]{
+		Total: len(items),
+		Items: items,
+	})
+}
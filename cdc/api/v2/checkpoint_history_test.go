@@ -0,0 +1,89 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestGetCheckpointHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second)
+	samples := []model.CheckpointSample{{
+		Time:         now.Add(-2 * time.Hour),
+		CheckpointTs: oracle.GoTimeToTS(now.Add(-2*time.Hour - time.Second)),
+		ResolvedTs:   oracle.GoTimeToTS(now.Add(-2 * time.Hour)),
+	}, {
+		Time:         now.Add(-time.Minute),
+		CheckpointTs: oracle.GoTimeToTS(now.Add(-time.Minute - 3*time.Second)),
+		ResolvedTs:   oracle.GoTimeToTS(now.Add(-time.Minute)),
+	}}
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(&mockStatusProvider{
+		checkpointSamples: samples,
+	}).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The last hour by default.
+	w := get("/api/v2/changefeeds/cf/checkpoint_history")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &ListResponse[CheckpointSample]{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, samples[1].CheckpointTs, resp.Items[0].CheckpointTs)
+	require.Equal(t, samples[1].ResolvedTs, resp.Items[0].ResolvedTs)
+	require.Equal(t, int64(3000), resp.Items[0].CheckpointLag)
+
+	from := now.Add(-3 * time.Hour).Format(time.RFC3339)
+	w = get("/api/v2/changefeeds/cf/checkpoint_history?from=" + from)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = &ListResponse[CheckpointSample]{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, 2, resp.Total)
+	require.Equal(t, int64(1000), resp.Items[0].CheckpointLag)
+
+	to := now.Add(-time.Hour).Format(time.RFC3339)
+	w = get("/api/v2/changefeeds/cf/checkpoint_history?from=" + from + "&to=" + to)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = &ListResponse[CheckpointSample]{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, 1, resp.Total)
+
+	// invalid time range
+	w = get("/api/v2/changefeeds/cf/checkpoint_history?from=abc")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = get("/api/v2/changefeeds/cf/checkpoint_history?from=" + to + "&to=" + from)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// regionHeartbeatInterval is the interval of TiKV reporting region stats to
+// PD, the written bytes and keys of a region are accumulated in it.
+const regionHeartbeatInterval = 60 * time.Second
+
+// estimateChangefeed estimates the throughput and cost of a changefeed
+// @Summary Estimate the throughput and cost of a changefeed
+// @Description estimate the events per second, the bandwidth to the sink and
+// @Description the sorter disk space of a changefeed from the recent write
+// @Description stats of upstream regions, which helps capacity planning
+// @Description before the changefeed is created.
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param estimateConfig body EstimateConfig true "estimate config"
+// @Success 200 {object} ChangefeedEstimate
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/estimates [post]
+func (h *OpenAPIV2) estimateChangefeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	cfg := getDefaultEstimateConfig()
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	window, err := time.ParseDuration(cfg.Window)
+	if err != nil || window <= 0 {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid window: %s, which must be a positive duration", cfg.Window))
+		return
+	}
+	if len(cfg.PDAddrs) == 0 {
+		up, err := getCaptureDefaultUpstream(h.capture)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		cfg.PDConfig = getUpstreamPDConfig(up)
+	}
+	credential := cfg.PDConfig.toCredential()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	pdClient, err := h.helpers.getPDClient(timeoutCtx, cfg.PDAddrs, credential)
+	if err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIGetPDClientFailed, err))
+		return
+	}
+	defer pdClient.Close()
+	kvStorage, err := h.helpers.createTiStore(cfg.PDAddrs, credential)
+	if err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrNewStore, err))
+		return
+	}
+	// The upstream may not be managed by the capture, so the snapshot ts is
+	// requested from its PD instead of the shared TSO client.
+	physical, logical, err := pdClient.GetTS(timeoutCtx)
+	if err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrInternalServerError, err))
+		return
+	}
+	replicaCfg := cfg.ReplicaConfig.ToInternalReplicaConfig()
+	tableIDs, err := h.helpers.getPhysicalTables(
+		replicaCfg, kvStorage, oracle.ComposeTS(physical, logical))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	pdAPIClient, err := h.helpers.getPDAPIClient(pdClient, credential)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer pdAPIClient.Close()
+	// Small tables share regions, each region is counted only once.
+	regions := make(map[uint64]pdutil.RegionInfo)
+	for _, tableID := range tableIDs {
+		tableRegions, err := pdAPIClient.ScanRegions(
+			timeoutCtx, spanz.TableIDToComparableSpan(tableID))
+		if err != nil {
+			_ = c.Error(cerror.WrapError(cerror.ErrInternalServerError, err))
+			return
+		}
+		for _, region := range tableRegions {
+			regions[region.ID] = region
+		}
+	}
+
+	resp := estimate(len(tableIDs), regions, window, replicaCfg.EnableOldValue)
+	resp.Window = window.String()
+	c.JSON(http.StatusOK, resp)
+}
+
+// estimate computes the estimated throughput and cost of a changefeed from
+// the write stats of the regions of replicated tables. Index keys are counted
+// as events too, so the estimate is an upper bound.
+func estimate(
+	tables int, regions map[uint64]pdutil.RegionInfo,
+	window time.Duration, enableOldValue bool,
+) *ChangefeedEstimate {
+	var writtenBytes, writtenKeys uint64
+	for _, region := range regions {
+		writtenBytes += region.WrittenBytes
+		writtenKeys += region.WrittenKeys
+	}
+	bytesPerSecond := float64(writtenBytes) / regionHeartbeatInterval.Seconds()
+	// Both old values and new values are fetched and sent if old value is
+	// enabled.
+	if enableOldValue {
+		bytesPerSecond *= 2
+	}
+	return &ChangefeedEstimate{
+		Tables:             tables,
+		Regions:            len(regions),
+		EventsPerSecond:    float64(writtenKeys) / regionHeartbeatInterval.Seconds(),
+		SinkBytesPerSecond: bytesPerSecond,
+		SorterDiskBytes:    uint64(bytesPerSecond * window.Seconds()),
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/upstream"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPDAPIClient struct {
+	pdutil.PDAPIClient
+	regions map[model.TableID][]pdutil.RegionInfo
+}
+
+func (c *mockPDAPIClient) ScanRegions(
+	_ context.Context, span tablepb.Span,
+) ([]pdutil.RegionInfo, error) {
+	return c.regions[span.TableID], nil
+}
+
+func (c *mockPDAPIClient) Close() {}
+
+func TestEstimateChangefeed(t *testing.T) {
+	t.Parallel()
+
+	estimates := &testCase{url: "/api/v2/estimates", method: "POST"}
+	pdClient := &mockPDClient{}
+	helpers := NewMockAPIV2Helpers(gomock.NewController(t))
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().GetUpstreamManager().Return(upstream.NewManager4Test(pdClient), nil).AnyTimes()
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, helpers))
+	post := func(cfg *EstimateConfig) *httptest.ResponseRecorder {
+		body, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			estimates.method, estimates.url, bytes.NewReader(body))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// invalid window
+	cfg := getDefaultEstimateConfig()
+	cfg.Window = "-1h"
+	w := post(cfg)
+	respErr := model.HTTPError{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// success, the region shared by table 1 and table 2 is counted once.
+	helpers.EXPECT().getPDClient(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pdClient, nil).AnyTimes()
+	helpers.EXPECT().createTiStore(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	helpers.EXPECT().getPhysicalTables(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]model.TableID{1, 2}, nil).Times(1)
+	helpers.EXPECT().getPDAPIClient(gomock.Any(), gomock.Any()).
+		Return(&mockPDAPIClient{regions: map[model.TableID][]pdutil.RegionInfo{
+			1: {
+				{ID: 1, WrittenBytes: 6000, WrittenKeys: 60},
+				{ID: 2, WrittenBytes: 600, WrittenKeys: 6},
+			},
+			2: {
+				{ID: 2, WrittenBytes: 600, WrittenKeys: 6},
+				{ID: 3, WrittenBytes: 0, WrittenKeys: 0},
+			},
+		}}, nil).Times(1)
+	// Old value is enabled by default, which doubles the bandwidth.
+	cfg = getDefaultEstimateConfig()
+	cfg.Window = "10m"
+	w = post(cfg)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &ChangefeedEstimate{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, &ChangefeedEstimate{
+		Tables:             2,
+		Regions:            3,
+		EventsPerSecond:    1.1,
+		SinkBytesPerSecond: 220,
+		SorterDiskBytes:    132000,
+		Window:             "10m0s",
+	}, resp)
+}
+
+func TestEstimate(t *testing.T) {
+	t.Parallel()
+
+	regions := map[uint64]pdutil.RegionInfo{
+		1: {ID: 1, WrittenBytes: 12000, WrittenKeys: 120},
+	}
+	resp := estimate(1, regions, time.Hour, false)
+	require.Equal(t, float64(2), resp.EventsPerSecond)
+	require.Equal(t, float64(200), resp.SinkBytesPerSecond)
+	require.Equal(t, uint64(720000), resp.SorterDiskBytes)
+
+	// Old values double the bandwidth and the sorter disk space.
+	resp = estimate(1, regions, time.Hour, true)
+	require.Equal(t, float64(2), resp.EventsPerSecond)
+	require.Equal(t, float64(400), resp.SinkBytesPerSecond)
+	require.Equal(t, uint64(1440000), resp.SorterDiskBytes)
+
+	resp = estimate(0, nil, time.Hour, true)
+	require.Equal(t, &ChangefeedEstimate{}, resp)
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"go.uber.org/zap"
+)
+
+// federationRequestTimeout is the timeout of requesting a federation peer.
+const federationRequestTimeout = 10 * time.Second
+
+// listFederatedChangefeeds lists changefeeds of the local cluster and all
+// federation peers.
+// @Summary List changefeeds of all clusters in the federation
+// @Description list changefeeds of the local cluster and the clusters of
+// @Description the configured federation peers. A peer that can not be reached
+// @Description is returned with an error instead of failing the request.
+// @Tags changefeed,v2
+// @Produce json
+// @Param state query string false "state"
+// @Param namespace query string false "default"
+// @Success 200 {array} FederatedCluster
+// @Failure 500 {object} model.HTTPError
+// @Router /api/v2/federation/changefeeds [get]
+func (h *OpenAPIV2) listFederatedChangefeeds(c *gin.Context) {
+	ctx := c.Request.Context()
+	state := c.Query(apiOpVarChangefeedState)
+	namespace := getNamespaceValueWithDefault(c)
+
+	info, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	changefeeds, err := h.getChangefeedCommonInfos(ctx, namespace, state)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	serverCfg := config.GetGlobalServerConfig()
+	clusters := make([]FederatedCluster, len(serverCfg.FederationPeers)+1)
+	clusters[0] = FederatedCluster{
+		Endpoint:    info.AdvertiseAddr,
+		ClusterID:   h.capture.GetEtcdClient().GetClusterID(),
+		IsLocal:     true,
+		Changefeeds: changefeeds,
+	}
+
+	client, err := httputil.NewClient(serverCfg.Security)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	client.SetTimeout(federationRequestTimeout)
+	defer client.CloseIdleConnections()
+
+	tlsEnabled := serverCfg.Security != nil && serverCfg.Security.IsTLSEnabled()
+	var wg sync.WaitGroup
+	for i, peer := range serverCfg.FederationPeers {
+		wg.Add(1)
+		go func(i int, peer string) {
+			defer wg.Done()
+			clusters[i+1] = fetchFederatedCluster(
+				ctx, client, peerURL(peer, tlsEnabled),
+				namespace, state)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	c.JSON(http.StatusOK, &ListResponse[FederatedCluster]{
+		Total: len(clusters),
+		Items: clusters,
+	})
+}
+
+// fetchFederatedCluster fetches the cluster id and changefeeds of a peer.
+// Errors are recorded in the returned FederatedCluster, so that an
+// unreachable peer does not hide the others.
+func fetchFederatedCluster(
+	ctx context.Context, client *httputil.Client,
+	endpoint string, namespace string, state string,
+) FederatedCluster {
+	cluster := FederatedCluster{Endpoint: endpoint}
+
+	status := &ServerStatus{}
+	if err := getJSON(ctx, client, endpoint+"/api/v2/status", status); err != nil {
+		log.Warn("failed to get status of federation peer",
+			zap.String("peer", endpoint), zap.Error(err))
+		cluster.Error = err.Error()
+		return cluster
+	}
+	cluster.ClusterID = status.ClusterID
+
+	query := url.Values{}
+	query.Set(apiOpVarNamespace, namespace)
+	query.Set(apiOpVarChangefeedState, state)
+	resp := &ListResponse[ChangefeedCommonInfo]{}
+	if err := getJSON(ctx, client,
+		endpoint+"/api/v2/changefeeds?"+query.Encode(), resp); err != nil {
+		log.Warn("failed to list changefeeds of federation peer",
+			zap.String("peer", endpoint), zap.Error(err))
+		cluster.Error = err.Error()
+		return cluster
+	}
+	cluster.Changefeeds = resp.Items
+	return cluster
+}
+
+func getJSON(ctx context.Context, client *httputil.Client, uri string, v any) error {
+	content, err := client.DoRequest(ctx, uri, http.MethodGet, nil, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(json.Unmarshal(content, v))
+}
+
+// peerURL returns the base url of a federation peer, the scheme is chosen by
+// the tls config of the server if the peer does not specify one.
+func peerURL(peer string, tlsEnabled bool) string {
+	peer = strings.TrimSuffix(strings.TrimSpace(peer), "/")
+	if strings.Contains(peer, "://") {
+		return peer
+	}
+	if tlsEnabled {
+		return fmt.Sprintf("https://%s", peer)
+	}
+	return fmt.Sprintf("http://%s", peer)
+}
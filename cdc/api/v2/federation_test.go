@@ -0,0 +1,112 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFederatedChangefeeds(t *testing.T) {
+	// The peer returns one changefeed in cluster "east".
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/status":
+			_ = json.NewEncoder(w).Encode(&ServerStatus{ClusterID: "east"})
+		case "/api/v2/changefeeds":
+			require.Equal(t, "all", r.URL.Query().Get(apiOpVarChangefeedState))
+			_ = json.NewEncoder(w).Encode(&ListResponse[ChangefeedCommonInfo]{
+				Total: 1,
+				Items: []ChangefeedCommonInfo{{Namespace: "default", ID: "east-cf"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer peer.Close()
+	// An unreachable peer must not fail the whole request.
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	oldCfg := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(oldCfg)
+	cfg := config.GetDefaultServerConfig()
+	cfg.FederationPeers = []string{peer.URL, down.URL}
+	config.StoreGlobalServerConfig(cfg)
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{AdvertiseAddr: "127.0.0.1:8300"}, nil)
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	etcdClient.EXPECT().GetClusterID().AnyTimes().Return("west")
+	cp.EXPECT().GetEtcdClient().AnyTimes().Return(etcdClient)
+	cp.EXPECT().StatusProvider().Return(&mockStatusProvider{
+		changefeedInfos: map[model.ChangeFeedID]*model.ChangeFeedInfo{
+			model.DefaultChangeFeedID("west-cf"): {State: model.StateNormal},
+		},
+		changefeedStatuses: map[model.ChangeFeedID]*model.ChangeFeedStatusForAPI{
+			model.DefaultChangeFeedID("west-cf"): {},
+		},
+	}).AnyTimes()
+
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/federation/changefeeds?state=all", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	resp := ListResponse[FederatedCluster]{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 3, resp.Total)
+
+	local := resp.Items[0]
+	require.True(t, local.IsLocal)
+	require.Equal(t, "west", local.ClusterID)
+	require.Equal(t, "127.0.0.1:8300", local.Endpoint)
+	require.Len(t, local.Changefeeds, 1)
+	require.Equal(t, "west-cf", local.Changefeeds[0].ID)
+
+	east := resp.Items[1]
+	require.False(t, east.IsLocal)
+	require.Equal(t, "east", east.ClusterID)
+	require.Equal(t, peer.URL, east.Endpoint)
+	require.Empty(t, east.Error)
+	require.Len(t, east.Changefeeds, 1)
+	require.Equal(t, "east-cf", east.Changefeeds[0].ID)
+
+	require.Equal(t, down.URL, resp.Items[2].Endpoint)
+	require.NotEmpty(t, resp.Items[2].Error)
+	require.Empty(t, resp.Items[2].Changefeeds)
+}
+
+func TestPeerURL(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "http://cdc:8300", peerURL("cdc:8300", false))
+	require.Equal(t, "https://cdc:8300", peerURL(" cdc:8300/ ", true))
+	require.Equal(t, "http://cdc:8300", peerURL("http://cdc:8300/", true))
+}
@@ -14,19 +14,59 @@
 package v2
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/tiflow/cdc/api/middleware"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/fsutil"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// apiOpVarDeep is the key of deep health check in HTTP API
+	apiOpVarDeep = "deep"
+	// apiOpVarMaxCheckpointLag is the key of the max checkpoint lag of a
+	// changefeed before it is considered as stalled in HTTP API
+	apiOpVarMaxCheckpointLag = "max_checkpoint_lag"
+
+	// defaultMaxCheckpointLag is the default max checkpoint lag of a
+	// changefeed before it is considered as stalled.
+	defaultMaxCheckpointLag = 10 * time.Minute
+	// minSorterDiskAvailPercentage is the min available percentage of the
+	// sorter disk before it is considered as unhealthy.
+	minSorterDiskAvailPercentage = 5
+	// healthCheckTimeout is the timeout of each deep health check.
+	healthCheckTimeout = 5 * time.Second
+	// healthCheckTSOStaleness is the max staleness of the timestamp used to
+	// estimate the lag of changefeeds, recent timestamps are reused.
+	healthCheckTSOStaleness = time.Second
+
+	healthCheckCluster     = "cluster"
+	healthCheckEtcd        = "etcd"
+	healthCheckPD          = "pd"
+	healthCheckSorterDisk  = "sorter-disk"
+	healthCheckChangefeeds = "changefeeds"
 )
 
 // @Summary Check the health status of a TiCDC cluster
-// @Description Check the health status of a TiCDC cluster
+// @Description Check the health status of a TiCDC cluster. If deep is true,
+// @Description etcd, PD, the sorter disk and changefeeds are checked as well,
+// @Description and a report of all checks is returned.
 // @Tags common,v2
 // @Produce json
+// @Param deep query bool false "deep"
+// @Param max_checkpoint_lag query string false "10m"
 // @Success 200 {object} EmptyResponse
 // @Failure 500,400 {object} model.HTTPError
+// @Failure 503 {object} HealthReport
 // @Router	/api/v2/health [get]
 func (h *OpenAPIV2) health(c *gin.Context) {
 	if !h.capture.IsOwner() {
@@ -34,6 +74,11 @@ func (h *OpenAPIV2) health(c *gin.Context) {
 		return
 	}
 
+	if c.Query(apiOpVarDeep) == "true" {
+		h.deepHealth(c)
+		return
+	}
+
 	ctx := c.Request.Context()
 	health, err := h.capture.StatusProvider().IsHealthy(ctx)
 	if err != nil {
@@ -47,3 +92,176 @@ func (h *OpenAPIV2) health(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, &EmptyResponse{})
 }
+
+// deepHealth runs all deep health checks, it responds 503 with the report
+// if any check fails.
+func (h *OpenAPIV2) deepHealth(c *gin.Context) {
+	maxLag := defaultMaxCheckpointLag
+	if lag := c.Query(apiOpVarMaxCheckpointLag); lag != "" {
+		var err error
+		maxLag, err = time.ParseDuration(lag)
+		if err != nil {
+			_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	report := &HealthReport{Healthy: true}
+	add := func(check HealthCheck) {
+		report.Healthy = report.Healthy && check.Healthy
+		report.Checks = append(report.Checks, check)
+	}
+	add(h.checkCluster(ctx))
+	add(h.checkEtcd(ctx))
+	pdCheck, currentTs := h.checkPD(ctx)
+	add(pdCheck)
+	add(checkSorterDisk(config.GetGlobalServerConfig().Sorter.SortDir))
+	add(h.checkChangefeeds(ctx, currentTs, maxLag))
+
+	if !report.Healthy {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *OpenAPIV2) checkCluster(ctx context.Context) HealthCheck {
+	check := HealthCheck{Name: healthCheckCluster}
+	health, err := h.capture.StatusProvider().IsHealthy(ctx)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	check.Healthy = health
+	if !health {
+		check.Message = "some tables are not replicated by any capture"
+	}
+	return check
+}
+
+func (h *OpenAPIV2) checkEtcd(ctx context.Context) HealthCheck {
+	check := HealthCheck{Name: healthCheckEtcd}
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if _, err := h.capture.GetEtcdClient().GetOwnerID(ctx); err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	check.Healthy = true
+	return check
+}
+
+// checkPD checks the default upstream PD, and returns the current ts of it.
+func (h *OpenAPIV2) checkPD(ctx context.Context) (HealthCheck, uint64) {
+	check := HealthCheck{Name: healthCheckPD}
+	upManager, err := h.capture.GetUpstreamManager()
+	if err != nil {
+		check.Message = err.Error()
+		return check, 0
+	}
+	up, err := upManager.GetDefaultUpstream()
+	if err != nil {
+		check.Message = err.Error()
+		return check, 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	physical, logical, _, err := up.TSOClient.GetRecentTS(ctx, healthCheckTSOStaleness)
+	if err != nil {
+		check.Message = err.Error()
+		return check, 0
+	}
+	check.Healthy = true
+	return check, oracle.ComposeTS(physical, logical)
+}
+
+// checkSorterDisk checks the sorter dir is writable and has enough space.
+func checkSorterDisk(sortDir string) HealthCheck {
+	check := HealthCheck{Name: healthCheckSorterDisk}
+	info, err := fsutil.GetDiskInfo(sortDir)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	if info.AvailPercentage < minSorterDiskAvailPercentage {
+		check.Message = fmt.Sprintf("only %.2f%% of the sorter disk is available, %s",
+			info.AvailPercentage, info)
+		return check
+	}
+	check.Healthy = true
+	return check
+}
+
+// checkChangefeeds checks whether there are normal changefeeds whose
+// checkpoint lags behind more than maxLag.
+func (h *OpenAPIV2) checkChangefeeds(
+	ctx context.Context, currentTs uint64, maxLag time.Duration,
+) HealthCheck {
+	check := HealthCheck{Name: healthCheckChangefeeds}
+	if currentTs == 0 {
+		check.Message = "skipped since the current ts can not be got from PD"
+		return check
+	}
+	statuses, err := h.capture.StatusProvider().GetAllChangeFeedStatuses(ctx)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	infos, err := h.capture.StatusProvider().GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+
+	current := oracle.GetTimeFromTS(currentTs)
+	var stalled []string
+	for id, info := range infos {
+		status, ok := statuses[id]
+		if !ok || info.State != model.StateNormal {
+			continue
+		}
+		lag := current.Sub(oracle.GetTimeFromTS(status.CheckpointTs))
+		if lag > maxLag {
+			stalled = append(stalled, fmt.Sprintf("%s/%s(lag %s)",
+				id.Namespace, id.ID, lag.Truncate(time.Second)))
+		}
+	}
+	if len(stalled) > 0 {
+		sort.Strings(stalled)
+		check.Message = "stalled changefeeds: " + strings.Join(stalled, ", ")
+		return check
+	}
+	check.Healthy = true
+	return check
+}
+
+// @Summary Check whether a TiCDC node is ready
+// @Description Check whether a TiCDC node is ready to accept tables, which
+// @Description is suitable for readiness probes. Unlike health, the request
+// @Description is not forwarded to the owner.
+// @Tags common,v2
+// @Produce json
+// @Success 200 {object} EmptyResponse
+// @Failure 503 {object} model.HTTPError
+// @Router	/api/v2/ready [get]
+func (h *OpenAPIV2) ready(c *gin.Context) {
+	notReady := func(reason string) {
+		c.IndentedJSON(http.StatusServiceUnavailable,
+			model.NewHTTPError(cerror.ErrServerIsNotReady.GenWithStack(
+				"cdc server is not ready, %s", reason)))
+	}
+	if liveness := h.capture.Liveness(); liveness != model.LivenessCaptureAlive {
+		notReady(fmt.Sprintf("capture is %s", liveness.String()))
+		return
+	}
+	// Tables are dispatched by the owner, a capture can not accept tables
+	// if it can not reach etcd or there is no owner.
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthCheckTimeout)
+	defer cancel()
+	if _, err := h.capture.GetEtcdClient().GetOwnerID(ctx); err != nil {
+		notReady(fmt.Sprintf("owner is unavailable, %s", err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
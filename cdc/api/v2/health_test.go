@@ -19,13 +19,19 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
 	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
 	"github.com/pingcap/tiflow/cdc/model"
 	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
+	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 )
 
 func TestHealth(t *testing.T) {
@@ -72,3 +78,121 @@ func TestHealth(t *testing.T) {
 	require.Equal(t, http.StatusOK, w.Code)
 	require.Equal(t, "{}", w.Body.String())
 }
+
+func TestDeepHealth(t *testing.T) {
+	oldCfg := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(oldCfg)
+	cfg := config.GetDefaultServerConfig()
+	cfg.Sorter.SortDir = t.TempDir()
+	config.StoreGlobalServerConfig(cfg)
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	etcdClient.EXPECT().GetOwnerID(gomock.Any()).Return("owner", nil).AnyTimes()
+	cp.EXPECT().GetEtcdClient().Return(etcdClient).AnyTimes()
+	now := time.Now()
+	pdClient := &mockPDClient{logicTime: oracle.GetPhysical(now)}
+	cp.EXPECT().GetUpstreamManager().
+		Return(upstream.NewManager4Test(pdClient), nil).AnyTimes()
+	statusProvider := mock_owner.NewMockStatusProvider(ctrl)
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	statusProvider.EXPECT().IsHealthy(gomock.Any()).Return(true, nil).AnyTimes()
+	statusProvider.EXPECT().GetAllChangeFeedInfo(gomock.Any()).
+		Return(map[model.ChangeFeedID]*model.ChangeFeedInfo{
+			model.DefaultChangeFeedID("normal"):  {State: model.StateNormal},
+			model.DefaultChangeFeedID("stopped"): {State: model.StateStopped},
+		}, nil).AnyTimes()
+	statusProvider.EXPECT().GetAllChangeFeedStatuses(gomock.Any()).
+		Return(map[model.ChangeFeedID]*model.ChangeFeedStatusForAPI{
+			model.DefaultChangeFeedID("normal"): {
+				CheckpointTs: oracle.GoTimeToTS(now.Add(-time.Minute)),
+			},
+			model.DefaultChangeFeedID("stopped"): {
+				CheckpointTs: oracle.GoTimeToTS(now.Add(-time.Hour)),
+			},
+		}, nil).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	// all checks pass, the stopped changefeed is ignored.
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), "GET",
+		"/api/v2/health?deep=true", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	report := &HealthReport{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(report))
+	require.True(t, report.Healthy)
+	require.Len(t, report.Checks, 5)
+
+	// the normal changefeed lags behind more than 30s.
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), "GET",
+		"/api/v2/health?deep=true&max_checkpoint_lag=30s", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	report = &HealthReport{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(report))
+	require.False(t, report.Healthy)
+	for _, check := range report.Checks {
+		if check.Name == healthCheckChangefeeds {
+			require.False(t, check.Healthy)
+			require.Contains(t, check.Message, "default/normal")
+			require.NotContains(t, check.Message, "stopped")
+		} else {
+			require.True(t, check.Healthy, check.Name)
+		}
+	}
+
+	// invalid max checkpoint lag
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), "GET",
+		"/api/v2/health?deep=true&max_checkpoint_lag=abc", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCheckSorterDisk(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, checkSorterDisk(t.TempDir()).Healthy)
+	check := checkSorterDisk("/not-exist-sorter-dir")
+	require.False(t, check.Healthy)
+	require.NotEmpty(t, check.Message)
+}
+
+func TestReady(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	cp.EXPECT().GetEtcdClient().Return(etcdClient).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+	ready := func() int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), "GET",
+			"/api/v2/ready", nil)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// the server is not ready
+	cp.EXPECT().IsReady().Return(false)
+	require.Equal(t, http.StatusServiceUnavailable, ready())
+
+	// the capture is draining
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().Liveness().Return(model.LivenessCaptureStopping)
+	require.Equal(t, http.StatusServiceUnavailable, ready())
+
+	// there is no owner
+	cp.EXPECT().Liveness().Return(model.LivenessCaptureAlive).AnyTimes()
+	etcdClient.EXPECT().GetOwnerID(gomock.Any()).Return("", errors.New("no owner"))
+	require.Equal(t, http.StatusServiceUnavailable, ready())
+
+	etcdClient.EXPECT().GetOwnerID(gomock.Any()).Return("owner", nil)
+	require.Equal(t, http.StatusOK, ready())
+}
@@ -14,13 +14,21 @@
 package v2
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
 	"github.com/pingcap/tiflow/pkg/logutil"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // SetLogLevel changes TiCDC log level dynamically.
@@ -41,12 +49,123 @@ func (h *OpenAPIV2) setLogLevel(c *gin.Context) {
 		return
 	}
 
-	err = logutil.SetLogLevel(req.Level)
+	if err := changeLogLevel(req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// setCaptureLogLevel changes the log level of a capture dynamically.
+// @Summary Change the log level of a capture
+// @Description change the log level of a capture globally or per module
+// @Description dynamically, the change can be reverted automatically
+// @Tags capture,v2
+// @Accept json
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Param log_level body LogLevelReq true "log level"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/captures/{capture_id}/log [post]
+func (h *OpenAPIV2) setCaptureLogLevel(c *gin.Context) {
+	captureID := c.Param(apiOpVarCaptureID)
+	req := &LogLevelReq{Level: "info"}
+	err := c.BindJSON(&req)
 	if err != nil {
-		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
-			"fail to change log level: %s", req.Level))
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid log level: %s", err.Error()))
+		return
+	}
+	if err := checkLogLevelReq(req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	captures, err := h.capture.StatusProvider().GetCaptures(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var target *model.CaptureInfo
+	for _, capture := range captures {
+		if capture.ID == captureID {
+			target = capture
+			break
+		}
+	}
+	if target == nil {
+		_ = c.Error(cerror.ErrCaptureNotExist.GenWithStackByArgs(captureID))
+		return
+	}
+	self, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if target.ID == self.ID {
+		if err := changeLogLevel(req); err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, &EmptyResponse{})
+		return
+	}
+
+	serverCfg := config.GetGlobalServerConfig()
+	client, err := httputil.NewClient(serverCfg.Security)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	client.SetTimeout(federationRequestTimeout)
+	defer client.CloseIdleConnections()
+	body, err := json.Marshal(req)
+	if err != nil {
+		_ = c.Error(errors.Trace(err))
+		return
+	}
+	tlsEnabled := serverCfg.Security != nil && serverCfg.Security.IsTLSEnabled()
+	uri := peerURL(target.AdvertiseAddr, tlsEnabled) + "/api/v2/log"
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if _, err := client.DoRequest(ctx, uri, http.MethodPost,
+		header, bytes.NewReader(body)); err != nil {
+		_ = c.Error(err)
 		return
 	}
-	log.Warn("log level changed", zap.String("level", req.Level))
 	c.JSON(http.StatusOK, &EmptyResponse{})
 }
+
+// checkLogLevelReq checks the log level request before it's forwarded.
+func checkLogLevelReq(req *LogLevelReq) error {
+	var lv zapcore.Level
+	if err := lv.UnmarshalText([]byte(req.Level)); err != nil {
+		return cerror.ErrAPIInvalidParam.GenWithStack("invalid log level: %s", req.Level)
+	}
+	if req.Module != "" && !logutil.IsLogModule(req.Module) {
+		return cerror.ErrAPIInvalidParam.GenWithStack("invalid log module: %s", req.Module)
+	}
+	if req.RevertAfterMinutes < 0 {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"revert_after_minutes must not be negative")
+	}
+	return nil
+}
+
+func changeLogLevel(req *LogLevelReq) error {
+	if err := checkLogLevelReq(req); err != nil {
+		return err
+	}
+	revertAfter := time.Duration(req.RevertAfterMinutes) * time.Minute
+	err := logutil.SetModuleLogLevel(req.Module, req.Level, revertAfter)
+	if err != nil {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"fail to change log level: %s", req.Level)
+	}
+	log.Warn("log level changed",
+		zap.String("level", req.Level),
+		zap.String("module", req.Module),
+		zap.Duration("revertAfter", revertAfter))
+	return nil
+}
@@ -16,13 +16,18 @@ package v2
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/logutil"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestSetLogLevel(t *testing.T) {
@@ -62,3 +67,55 @@ func TestSetLogLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestSetCaptureLogLevel(t *testing.T) {
+	t.Parallel()
+
+	var received LogLevelReq
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/log", r.URL.Path)
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer peer.Close()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{
+		ID: "owner", AdvertiseAddr: "127.0.0.1:8300",
+	}, nil).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(&mockStatusProvider{
+		captures: []*model.CaptureInfo{
+			{ID: "owner", AdvertiseAddr: "127.0.0.1:8300"},
+			{ID: "peer", AdvertiseAddr: strings.TrimPrefix(peer.URL, "http://")},
+		},
+	}).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	cases := []struct {
+		captureID string
+		body      string
+		code      int
+	}{
+		{"owner", `{`, 400},
+		{"owner", `{"log_level":"xxxx"}`, 400},
+		{"owner", `{"log_level":"debug","module":"xxxx"}`, 400},
+		{"owner", `{"log_level":"debug","revert_after_minutes":-1}`, 400},
+		{"unknown", `{"log_level":"debug"}`, 400},
+		{"owner", `{"log_level":"debug","module":"sorter"}`, 200},
+		{"peer", `{"log_level":"debug","module":"kvclient","revert_after_minutes":5}`, 200},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), "POST",
+			"/api/v2/captures/"+c.captureID+"/log", bytes.NewReader([]byte(c.body)))
+		router.ServeHTTP(w, req)
+		require.Equal(t, c.code, w.Code, c.body)
+	}
+	require.Equal(t, LogLevelReq{
+		Level: "debug", Module: logutil.ModuleKVClient, RevertAfterMinutes: 5,
+	}, received)
+	require.Equal(t, zapcore.DebugLevel, logutil.GetModuleLogLevels()[logutil.ModuleSorter])
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+)
+
+const (
+	metricCheckpointLag          = "ticdc_owner_checkpoint_ts_lag"
+	metricCheckpointLagHistogram = "ticdc_owner_checkpoint_lag_histogram"
+	metricSorterInMemoryDataSize = "ticdc_sorter_in_memory_data_size_gauge"
+	metricSorterOnDiskDataSize   = "ticdc_sorter_on_disk_data_size_gauge"
+	metricRegionCount            = "ticdc_kvclient_region_count"
+	metricTableCount             = "ticdc_processor_num_of_tables"
+	metricE2ELatency             = "ticdc_sink_table_sink_e2e_latency"
+
+	metricLabelNamespace  = "namespace"
+	metricLabelChangefeed = "changefeed"
+)
+
+// sinkFlushDurationMetrics are the histograms of the flush duration of
+// different kinds of sinks.
+var sinkFlushDurationMetrics = map[string]struct{}{
+	"ticdc_sink_txn_worker_flush_duration":       {},
+	"ticdc_sink_mq_worker_send_message_duration": {},
+}
+
+// metricsSummary returns the metrics summary of the capture which handles
+// the request.
+// @Summary Get the metrics summary of a capture
+// @Description get the key health indicators of the capture, which are
+// @Description computed from the metrics of the capture, so that no
+// @Description Prometheus is required.
+// @Tags common,v2
+// @Produce json
+// @Success 200 {object} MetricsSummary
+// @Failure 500 {object} model.HTTPError
+// @Router /api/v2/metrics/summary [get]
+func (h *OpenAPIV2) metricsSummary(c *gin.Context) {
+	summary, err := h.localMetricsSummary()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// clusterMetricsSummary returns the metrics summaries of all captures.
+// @Summary Get the metrics summaries of all captures
+// @Description get the metrics summaries of all captures in the cluster.
+// @Description A capture that can not be reached is returned with an error
+// @Description instead of failing the request.
+// @Tags common,v2
+// @Produce json
+// @Success 200 {array} MetricsSummary
+// @Failure 500 {object} model.HTTPError
+// @Router /api/v2/metrics/cluster_summary [get]
+func (h *OpenAPIV2) clusterMetricsSummary(c *gin.Context) {
+	ctx := c.Request.Context()
+	captures, err := h.capture.StatusProvider().GetCaptures(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	self, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	serverCfg := config.GetGlobalServerConfig()
+	client, err := httputil.NewClient(serverCfg.Security)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	client.SetTimeout(federationRequestTimeout)
+	defer client.CloseIdleConnections()
+
+	tlsEnabled := serverCfg.Security != nil && serverCfg.Security.IsTLSEnabled()
+	summaries := make([]MetricsSummary, len(captures))
+	var wg sync.WaitGroup
+	for i, capture := range captures {
+		if capture.ID == self.ID {
+			summary, err := h.localMetricsSummary()
+			if err != nil {
+				summary = &MetricsSummary{CaptureID: capture.ID, Error: err.Error()}
+			}
+			summaries[i] = *summary
+			continue
+		}
+		wg.Add(1)
+		go func(i int, capture *model.CaptureInfo) {
+			defer wg.Done()
+			summary := MetricsSummary{}
+			uri := peerURL(capture.AdvertiseAddr, tlsEnabled) + "/api/v2/metrics/summary"
+			if err := getJSON(ctx, client, uri, &summary); err != nil {
+				log.Warn("failed to get metrics summary of capture",
+					zap.String("captureID", capture.ID),
+					zap.String("address", capture.AdvertiseAddr),
+					zap.Error(err))
+				summary = MetricsSummary{Error: err.Error()}
+			}
+			summary.CaptureID = capture.ID
+			summary.Address = capture.AdvertiseAddr
+			summaries[i] = summary
+		}(i, capture)
+	}
+	wg.Wait()
+
+	c.JSON(http.StatusOK, &ListResponse[MetricsSummary]{
+		Total: len(summaries),
+		Items: summaries,
+	})
+}
+
+func (h *OpenAPIV2) localMetricsSummary() (*MetricsSummary, error) {
+	info, err := h.capture.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if prometheus.DefaultGatherer == nil {
+		return nil, errors.New("metrics are not registered")
+	}
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	summary := summarizeMetrics(families)
+	summary.CaptureID = info.ID
+	summary.Address = info.AdvertiseAddr
+	summary.IsOwner = h.capture.IsOwner()
+	return summary, nil
+}
+
+// summarizeMetrics computes the metrics summary from the gathered metrics.
+// The distributions are computed from the histograms, which are accumulated
+// since the capture started.
+func summarizeMetrics(families []*dto.MetricFamily) *MetricsSummary {
+	summary := &MetricsSummary{}
+	changefeeds := make(map[model.ChangeFeedID]*ChangefeedMetricsSummary)
+	getChangefeed := func(m *dto.Metric) *ChangefeedMetricsSummary {
+		id := model.ChangeFeedID{}
+		for _, label := range m.GetLabel() {
+			switch label.GetName() {
+			case metricLabelNamespace:
+				id.Namespace = label.GetValue()
+			case metricLabelChangefeed:
+				id.ID = label.GetValue()
+			}
+		}
+		if id.ID == "" {
+			return nil
+		}
+		cf, ok := changefeeds[id]
+		if !ok {
+			cf = &ChangefeedMetricsSummary{Namespace: id.Namespace, ID: id.ID}
+			changefeeds[id] = cf
+		}
+		return cf
+	}
+	// e2eLatencies are the end-to-end latency histograms of all tables of
+	// every changefeed merged together.
+	e2eLatencies := make(map[*ChangefeedMetricsSummary]*dto.Histogram)
+	getLag := func(cf *ChangefeedMetricsSummary) *LagDistribution {
+		if cf.CheckpointLag == nil {
+			cf.CheckpointLag = &LagDistribution{}
+		}
+		return cf.CheckpointLag
+	}
+
+	for _, family := range families {
+		name := family.GetName()
+		for _, m := range family.GetMetric() {
+			switch name {
+			case metricSorterInMemoryDataSize, metricSorterOnDiskDataSize:
+				summary.SorterBacklogBytes += int64(m.GetGauge().GetValue())
+				continue
+			}
+			cf := getChangefeed(m)
+			if cf == nil {
+				continue
+			}
+			switch name {
+			case metricCheckpointLag:
+				getLag(cf).Current = m.GetGauge().GetValue()
+			case metricCheckpointLagHistogram:
+				lag := getLag(cf)
+				h := m.GetHistogram()
+				lag.P50 = histogramQuantile(0.5, h)
+				lag.P90 = histogramQuantile(0.9, h)
+				lag.P99 = histogramQuantile(0.99, h)
+			case metricRegionCount:
+				cf.RegionCount += int64(m.GetGauge().GetValue())
+			case metricTableCount:
+				cf.TableCount += int64(m.GetGauge().GetValue())
+			case metricE2ELatency:
+				e2eLatencies[cf] = mergeHistogram(e2eLatencies[cf], m.GetHistogram())
+			default:
+				if _, ok := sinkFlushDurationMetrics[name]; ok {
+					cf.SinkFlushP99 = math.Max(cf.SinkFlushP99,
+						histogramQuantile(0.99, m.GetHistogram()))
+				}
+			}
+		}
+	}
+
+	for cf, h := range e2eLatencies {
+		cf.E2ELatencyP99 = histogramQuantile(0.99, h)
+	}
+
+	summary.Changefeeds = make([]ChangefeedMetricsSummary, 0, len(changefeeds))
+	for _, cf := range changefeeds {
+		summary.Changefeeds = append(summary.Changefeeds, *cf)
+	}
+	sort.Slice(summary.Changefeeds, func(i, j int) bool {
+		if summary.Changefeeds[i].Namespace != summary.Changefeeds[j].Namespace {
+			return summary.Changefeeds[i].Namespace < summary.Changefeeds[j].Namespace
+		}
+		return summary.Changefeeds[i].ID < summary.Changefeeds[j].ID
+	})
+	return summary
+}
+
+// mergeHistogram adds the samples of src to dst, the histograms must have
+// the same buckets. A copy of src is returned if dst is nil.
+func mergeHistogram(dst, src *dto.Histogram) *dto.Histogram {
+	if dst == nil {
+		dst = &dto.Histogram{SampleCount: new(uint64)}
+		for _, b := range src.GetBucket() {
+			upperBound := b.GetUpperBound()
+			dst.Bucket = append(dst.Bucket, &dto.Bucket{
+				UpperBound:      &upperBound,
+				CumulativeCount: new(uint64),
+			})
+		}
+	}
+	*dst.SampleCount += src.GetSampleCount()
+	for i, b := range src.GetBucket() {
+		if i < len(dst.Bucket) {
+			*dst.Bucket[i].CumulativeCount += b.GetCumulativeCount()
+		}
+	}
+	return dst
+}
+
+// histogramQuantile estimates the q-quantile of a histogram by linear
+// interpolation within the bucket, in the same way as the histogram_quantile
+// function of Prometheus.
+func histogramQuantile(q float64, h *dto.Histogram) float64 {
+	count := float64(h.GetSampleCount())
+	if count == 0 {
+		return 0
+	}
+	rank := q * count
+	lowerBound, lowerCount := 0.0, 0.0
+	for _, b := range h.GetBucket() {
+		upperBound, upperCount := b.GetUpperBound(), float64(b.GetCumulativeCount())
+		if upperCount >= rank {
+			if math.IsInf(upperBound, 1) || upperCount == lowerCount {
+				return lowerBound
+			}
+			return lowerBound + (upperBound-lowerBound)*(rank-lowerCount)/(upperCount-lowerCount)
+		}
+		lowerBound, lowerCount = upperBound, upperCount
+	}
+	// the rank falls in the +Inf bucket, return the largest finite bound.
+	return lowerBound
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func newSummaryTestRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	cfLabels := []string{"namespace", "changefeed"}
+
+	lag := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc", Subsystem: "owner", Name: "checkpoint_ts_lag",
+	}, cfLabels)
+	lagHistogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc", Subsystem: "owner", Name: "checkpoint_lag_histogram",
+		Buckets: []float64{1, 2, 4, 8},
+	}, cfLabels)
+	flush := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc", Subsystem: "sink", Name: "txn_worker_flush_duration",
+		Buckets: []float64{0.1, 0.2, 0.4},
+	}, cfLabels)
+	e2eLatency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc", Subsystem: "sink", Name: "table_sink_e2e_latency",
+		Buckets: []float64{1, 2, 4},
+	}, append(cfLabels, "table", "sink"))
+	regions := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc", Subsystem: "kvclient", Name: "region_count",
+	}, cfLabels)
+	sorter := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc", Subsystem: "sorter", Name: "on_disk_data_size_gauge",
+	}, []string{"id"})
+	registry.MustRegister(lag, lagHistogram, flush, e2eLatency, regions, sorter)
+
+	lag.WithLabelValues("default", "cf1").Set(3)
+	for i := 0; i < 100; i++ {
+		lagHistogram.WithLabelValues("default", "cf1").Observe(1.5)
+		flush.WithLabelValues("default", "cf2").Observe(0.15)
+		e2eLatency.WithLabelValues("default", "cf2", "test.t1", "mysql").Observe(0.5)
+	}
+	// The latency of all tables are merged, so the slow table decides p99.
+	for i := 0; i < 2; i++ {
+		e2eLatency.WithLabelValues("default", "cf2", "test.t2", "mysql").Observe(3)
+	}
+	regions.WithLabelValues("default", "cf2").Set(42)
+	sorter.WithLabelValues("0").Set(100)
+	sorter.WithLabelValues("1").Set(200)
+	return registry
+}
+
+func TestSummarizeMetrics(t *testing.T) {
+	t.Parallel()
+
+	families, err := newSummaryTestRegistry().Gather()
+	require.NoError(t, err)
+	summary := summarizeMetrics(families)
+	require.Equal(t, int64(300), summary.SorterBacklogBytes)
+	require.Len(t, summary.Changefeeds, 2)
+
+	cf1 := summary.Changefeeds[0]
+	require.Equal(t, "cf1", cf1.ID)
+	require.Equal(t, 3.0, cf1.CheckpointLag.Current)
+	require.InDelta(t, 1.5, cf1.CheckpointLag.P50, 1e-9)
+	require.InDelta(t, 1.99, cf1.CheckpointLag.P99, 1e-9)
+
+	cf2 := summary.Changefeeds[1]
+	require.Equal(t, "cf2", cf2.ID)
+	require.Nil(t, cf2.CheckpointLag)
+	require.InDelta(t, 0.199, cf2.SinkFlushP99, 1e-9)
+	require.InDelta(t, 2.98, cf2.E2ELatencyP99, 1e-9)
+	require.Equal(t, int64(42), cf2.RegionCount)
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	t.Parallel()
+
+	newHistogram := func(count uint64, bounds []float64, counts []uint64) *dto.Histogram {
+		h := &dto.Histogram{SampleCount: &count}
+		for i := range bounds {
+			h.Bucket = append(h.Bucket, &dto.Bucket{
+				UpperBound:      &bounds[i],
+				CumulativeCount: &counts[i],
+			})
+		}
+		return h
+	}
+
+	require.Equal(t, 0.0, histogramQuantile(0.99, newHistogram(0, nil, nil)))
+	h := newHistogram(10, []float64{1, 2, math.Inf(1)}, []uint64{5, 10, 10})
+	require.InDelta(t, 1.0, histogramQuantile(0.5, h), 1e-9)
+	require.InDelta(t, 1.8, histogramQuantile(0.9, h), 1e-9)
+	// samples in the +Inf bucket are bounded by the largest finite bound.
+	h = newHistogram(10, []float64{1, 2}, []uint64{5, 8})
+	require.Equal(t, 2.0, histogramQuantile(0.99, h))
+}
+
+func TestMetricsSummary(t *testing.T) {
+	oldGatherer := prometheus.DefaultGatherer
+	defer func() { prometheus.DefaultGatherer = oldGatherer }()
+	prometheus.DefaultGatherer = newSummaryTestRegistry()
+
+	// The peer capture returns a summary with one changefeed.
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/metrics/summary", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(&MetricsSummary{
+			SorterBacklogBytes: 1024,
+			Changefeeds:        []ChangefeedMetricsSummary{{Namespace: "default", ID: "cf1"}},
+		})
+	}))
+	defer peer.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{
+		ID: "owner", AdvertiseAddr: "127.0.0.1:8300",
+	}, nil).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(&mockStatusProvider{
+		captures: []*model.CaptureInfo{
+			{ID: "owner", AdvertiseAddr: "127.0.0.1:8300"},
+			{ID: "peer", AdvertiseAddr: strings.TrimPrefix(peer.URL, "http://")},
+			{ID: "down", AdvertiseAddr: strings.TrimPrefix(down.URL, "http://")},
+		},
+	}).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/metrics/summary", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	summary := &MetricsSummary{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(summary))
+	require.Equal(t, "owner", summary.CaptureID)
+	require.True(t, summary.IsOwner)
+	require.Equal(t, int64(300), summary.SorterBacklogBytes)
+	require.Len(t, summary.Changefeeds, 2)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/metrics/cluster_summary", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ListResponse[MetricsSummary]{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 3, resp.Total)
+	require.Equal(t, "owner", resp.Items[0].CaptureID)
+	require.Len(t, resp.Items[0].Changefeeds, 2)
+	require.Equal(t, "peer", resp.Items[1].CaptureID)
+	require.Equal(t, int64(1024), resp.Items[1].SorterBacklogBytes)
+	require.Empty(t, resp.Items[1].Error)
+	require.Equal(t, "down", resp.Items[2].CaptureID)
+	require.NotEmpty(t, resp.Items[2].Error)
+}
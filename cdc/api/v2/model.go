@@ -31,9 +31,47 @@ import (
 // EmptyResponse return empty {} to http client
 type EmptyResponse struct{}
 
+// HealthCheck is the result of a deep health check.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// HealthReport is the result of all deep health checks of a cluster.
+type HealthReport struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []HealthCheck `json:"checks"`
+}
+
 // LogLevelReq log level request
 type LogLevelReq struct {
 	Level string `json:"log_level"`
+	// Module is one of scheduler, sink, kvclient and sorter, whose log level
+	// is changed separately. Empty means the global log level.
+	Module string `json:"module,omitempty"`
+	// RevertAfterMinutes reverts the log level after the given minutes,
+	// 0 means never.
+	RevertAfterMinutes int `json:"revert_after_minutes,omitempty"`
+}
+
+// SorterCompactReq is the request to compact the sorter storage of a capture.
+type SorterCompactReq struct {
+	// Namespace is the namespace of ChangefeedID, "default" if it's empty.
+	Namespace string `json:"namespace,omitempty"`
+	// ChangefeedID limits the compaction to the changefeed if it's not empty.
+	ChangefeedID string `json:"changefeed_id,omitempty"`
+	// TableID limits the compaction to the table of the changefeed if it's
+	// not nil, and ChangefeedID must be set then.
+	TableID *int64 `json:"table_id,omitempty"`
+}
+
+// SorterCompactResp is the result of a sorter compaction.
+type SorterCompactResp struct {
+	CaptureID string `json:"capture_id"`
+	// ReclaimedBytes is the approximate disk space reclaimed by the
+	// compaction.
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
 }
 
 // ListResponse is the response for all List APIs
@@ -68,6 +106,7 @@ type VerifyTableConfig struct {
 	PDConfig
 	ReplicaConfig *ReplicaConfig `json:"replica_config"`
 	StartTs       uint64         `json:"start_ts"`
+	Keyspace      string         `json:"keyspace,omitempty"`
 }
 
 func getDefaultVerifyTableConfig() *VerifyTableConfig {
@@ -76,12 +115,63 @@ func getDefaultVerifyTableConfig() *VerifyTableConfig {
 	}
 }
 
+// EstimateConfig is the config to estimate the throughput and cost of a
+// changefeed before it's created.
+type EstimateConfig struct {
+	PDConfig
+	ReplicaConfig *ReplicaConfig `json:"replica_config"`
+	// Window is the duration of events the sorter buffers, e.g. how long
+	// the changefeed is expected to fall behind at most.
+	Window string `json:"window"`
+}
+
+func getDefaultEstimateConfig() *EstimateConfig {
+	return &EstimateConfig{
+		ReplicaConfig: GetDefaultReplicaConfig(),
+		Window:        "1h",
+	}
+}
+
+// ChangefeedEstimate is the estimated throughput and cost of a changefeed,
+// which is computed from the recent write stats of upstream regions.
+type ChangefeedEstimate struct {
+	Tables  int `json:"tables"`
+	Regions int `json:"regions"`
+	// EventsPerSecond is the estimated number of row changed events per second.
+	EventsPerSecond float64 `json:"events_per_second"`
+	// SinkBytesPerSecond is the estimated bandwidth to the sink.
+	SinkBytesPerSecond float64 `json:"sink_bytes_per_second"`
+	// SorterDiskBytes is the estimated disk space the sorter needs to buffer
+	// the events in the window.
+	SorterDiskBytes uint64 `json:"sorter_disk_bytes"`
+	Window          string `json:"window"`
+}
+
 // ResumeChangefeedConfig is used by resume changefeed api
 type ResumeChangefeedConfig struct {
 	PDConfig
 	OverwriteCheckpointTs uint64 `json:"overwrite_checkpoint_ts"`
 }
 
+// ReanchorChangefeedConfig is used by re-anchor changefeed api
+type ReanchorChangefeedConfig struct {
+	// StartTs is the new start ts of the changefeed, the current ts of the
+	// upstream is used if it's 0.
+	StartTs uint64 `json:"start_ts"`
+}
+
+// PausedTablesConfig is used by pause tables and resume tables api
+type PausedTablesConfig struct {
+	TableIDs []int64 `json:"table_ids"`
+}
+
+// PausedTable is a table whose replication is paused
+type PausedTable struct {
+	TableID int64 `json:"table_id"`
+	// CheckpointTs is the checkpoint ts the table is paused at.
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+}
+
 // PDConfig is a configuration used to connect to pd
 type PDConfig struct {
 	PDAddrs       []string `json:"pd_addrs,omitempty"`
@@ -94,6 +184,7 @@ type PDConfig struct {
 // ChangefeedCommonInfo holds some common usage information of a changefeed
 type ChangefeedCommonInfo struct {
 	UpstreamID     uint64              `json:"upstream_id"`
+	Keyspace       string              `json:"keyspace,omitempty"`
 	Namespace      string              `json:"namespace"`
 	ID             string              `json:"id"`
 	FeedState      model.FeedState     `json:"state"`
@@ -110,9 +201,85 @@ type ChangefeedConfig struct {
 	TargetTs      uint64         `json:"target_ts"`
 	SinkURI       string         `json:"sink_uri"`
 	ReplicaConfig *ReplicaConfig `json:"replica_config"`
+	// Keyspace scopes the changefeed to a keyspace of the upstream cluster,
+	// it can not be changed after the changefeed is created.
+	Keyspace string `json:"keyspace,omitempty"`
+	// TTL removes the changefeed automatically after it elapses, all data
+	// before then are replicated before the changefeed is removed.
+	TTL *JSONDuration `json:"ttl,omitempty" swaggertype:"string"`
+	// RemoveTopics removes the Kafka topics of the changefeed once it is
+	// finished because of TTL or target ts.
+	RemoveTopics bool `json:"remove_topics,omitempty"`
 	PDConfig
 }
 
+// SafePointLeaseConfig is used to register or renew an auxiliary GC
+// safepoint lease of a changefeed.
+type SafePointLeaseConfig struct {
+	ServiceID string `json:"service_id"`
+	// SafePoint is the checkpoint of the changefeed if it is not specified.
+	SafePoint uint64 `json:"safe_point"`
+	// TTL is the lease ttl in seconds.
+	TTL int64 `json:"ttl"`
+}
+
+// SafePointLease is an auxiliary GC safepoint lease of a changefeed.
+type SafePointLease struct {
+	ServiceID string    `json:"service_id"`
+	SafePoint uint64    `json:"safe_point"`
+	TTL       int64     `json:"ttl"`
+	ExpireAt  time.Time `json:"expire_at"`
+}
+
+// CheckpointSample is a sample of the checkpoint ts and resolved ts of a
+// changefeed recorded by the owner.
+type CheckpointSample struct {
+	Time         time.Time `json:"time"`
+	CheckpointTs uint64    `json:"checkpoint_ts"`
+	ResolvedTs   uint64    `json:"resolved_ts"`
+	// CheckpointLag is the lag of the checkpoint ts at the time of the
+	// sample in milliseconds.
+	CheckpointLag int64 `json:"checkpoint_lag"`
+}
+
+// ChangefeedWarning is a warning of a changefeed aggregated by the owner,
+// repeated warnings are counted instead of being listed repeatedly.
+type ChangefeedWarning struct {
+	Code string `json:"code"`
+	// Message and Address are the ones of the latest occurrence.
+	Message   string    `json:"message"`
+	Address   string    `json:"address"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	Count     int64     `json:"count"`
+}
+
+// ScheduleOperation is an operation planned by the scheduler of a changefeed.
+type ScheduleOperation struct {
+	Scheduler string `json:"scheduler"`
+	// Type is one of "add", "remove" and "move".
+	Type    string `json:"type"`
+	TableID int64  `json:"table_id"`
+	Span    string `json:"span"`
+	// From and To are the captures the span is moved from and to, From is
+	// empty for adding and To is empty for removing.
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ScheduleTask is a schedule task performed by the scheduler of a changefeed.
+type ScheduleTask struct {
+	// The scheduler of the operation is the one generating the task, it
+	// tells why the task is performed.
+	ScheduleOperation
+	// State is one of "running", "finished" and "aborted".
+	State     string    `json:"state"`
+	StartTime time.Time `json:"start_time"`
+	// Duration is the duration of the task in milliseconds, it's the time
+	// elapsed so far if the task is running.
+	Duration int64 `json:"duration"`
+}
+
 // ProcessorCommonInfo holds the common info of a processor
 type ProcessorCommonInfo struct {
 	Namespace    string `json:"namespace"`
@@ -125,6 +292,16 @@ type JSONDuration struct {
 	duration time.Duration
 }
 
+// NewJSONDuration wraps the duration d.
+func NewJSONDuration(d time.Duration) *JSONDuration {
+	return &JSONDuration{duration: d}
+}
+
+// Duration returns the wrapped duration.
+func (d JSONDuration) Duration() time.Duration {
+	return d.duration
+}
+
 // MarshalJSON marshal duration to string
 func (d JSONDuration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(d.duration.Nanoseconds())
@@ -172,6 +349,12 @@ type ReplicaConfig struct {
 	Consistent *ConsistentConfig          `json:"consistent,omitempty"`
 	Scheduler  *ChangefeedSchedulerConfig `json:"scheduler"`
 	Integrity  *IntegrityConfig           `json:"integrity"`
+
+	ResolvedTsIntervals []*ResolvedTsIntervalRule `json:"resolved_ts_intervals,omitempty"`
+	BootstrapDDL        *bool                     `json:"bootstrap_ddl,omitempty"`
+	Canary              *CanaryConfig             `json:"canary,omitempty"`
+	ResourceGroup       string                    `json:"resource_group,omitempty"`
+	Features            []string                  `json:"features,omitempty"`
 }
 
 // ToInternalReplicaConfig coverts *v2.ReplicaConfig into *config.ReplicaConfig
@@ -197,6 +380,12 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		res.SyncPointRetention = &c.SyncPointRetention.duration
 	}
 	res.BDRMode = c.BDRMode
+	res.BootstrapDDL = c.BootstrapDDL
+	res.ResourceGroup = c.ResourceGroup
+	res.Features = nil
+	for _, feature := range c.Features {
+		res.Features = append(res.Features, config.Feature(feature))
+	}
 
 	if c.Filter != nil {
 		var mySQLReplicationRules *filter.MySQLReplicationRules
@@ -230,11 +419,16 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				efs[i] = ef.ToInternalEventFilterRule()
 			}
 		}
+		var ofs []*config.OriginFilterRule
+		for _, of := range c.Filter.OriginFilters {
+			ofs = append(ofs, of.ToInternalOriginFilterRule())
+		}
 		res.Filter = &config.FilterConfig{
 			Rules:                 c.Filter.Rules,
 			MySQLReplicationRules: mySQLReplicationRules,
 			IgnoreTxnStartTs:      c.Filter.IgnoreTxnStartTs,
 			EventFilters:          efs,
+			OriginFilters:         ofs,
 		}
 	}
 	if c.Consistent != nil {
@@ -244,6 +438,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			FlushIntervalInMs: c.Consistent.FlushIntervalInMs,
 			Storage:           c.Consistent.Storage,
 			UseFileBackend:    c.Consistent.UseFileBackend,
+			Tables:            c.Consistent.Tables,
 		}
 	}
 	if c.Sink != nil {
@@ -254,6 +449,10 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				DispatcherRule: "",
 				PartitionRule:  rule.PartitionRule,
 				TopicRule:      rule.TopicRule,
+				Columns:        rule.Columns,
+				IndexName:      rule.IndexName,
+				HashFunction:   rule.HashFunction,
+				NoKeyStrategy:  rule.NoKeyStrategy,
 			})
 		}
 		var columnSelectors []*config.ColumnSelector
@@ -314,11 +513,14 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				SASLOAuthScopes:              c.Sink.KafkaConfig.SASLOAuthScopes,
 				SASLOAuthGrantType:           c.Sink.KafkaConfig.SASLOAuthGrantType,
 				SASLOAuthAudience:            c.Sink.KafkaConfig.SASLOAuthAudience,
+				SASLOAuthTokenProvider:       c.Sink.KafkaConfig.SASLOAuthTokenProvider,
+				SASLOAuthAWSRegion:           c.Sink.KafkaConfig.SASLOAuthAWSRegion,
 				EnableTLS:                    c.Sink.KafkaConfig.EnableTLS,
 				CA:                           c.Sink.KafkaConfig.CA,
 				Cert:                         c.Sink.KafkaConfig.Cert,
 				Key:                          c.Sink.KafkaConfig.Key,
 				InsecureSkipVerify:           c.Sink.KafkaConfig.InsecureSkipVerify,
+				AdaptiveBatching:             c.Sink.KafkaConfig.AdaptiveBatching,
 				CodecConfig:                  codeConfig,
 			}
 		}
@@ -329,6 +531,8 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				MaxTxnRow:                    c.Sink.MySQLConfig.MaxTxnRow,
 				MaxMultiUpdateRowSize:        c.Sink.MySQLConfig.MaxMultiUpdateRowSize,
 				MaxMultiUpdateRowCount:       c.Sink.MySQLConfig.MaxMultiUpdateRowCount,
+				MaxMultiInsertRowCount:       c.Sink.MySQLConfig.MaxMultiInsertRowCount,
+				WorkerCountPerTable:          c.Sink.MySQLConfig.WorkerCountPerTable,
 				TiDBTxnMode:                  c.Sink.MySQLConfig.TiDBTxnMode,
 				SSLCa:                        c.Sink.MySQLConfig.SSLCa,
 				SSLCert:                      c.Sink.MySQLConfig.SSLCert,
@@ -340,6 +544,34 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				EnableBatchDML:               c.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         c.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: c.Sink.MySQLConfig.EnableCachePreparedStatement,
+				UnsupportedDDLPolicy:         c.Sink.MySQLConfig.UnsupportedDDLPolicy,
+				AdaptiveTxnRow:               c.Sink.MySQLConfig.AdaptiveTxnRow,
+				DDLCompatibilityCheck:        c.Sink.MySQLConfig.DDLCompatibilityCheck,
+			}
+			for _, group := range c.Sink.MySQLConfig.WorkerGroups {
+				mysqlConfig.WorkerGroups = append(mysqlConfig.WorkerGroups,
+					&config.MySQLWorkerGroup{
+						Name:        group.Name,
+						Matcher:     group.Matcher,
+						WorkerCount: group.WorkerCount,
+						MaxTxnRow:   group.MaxTxnRow,
+					})
+			}
+			for _, rule := range c.Sink.MySQLConfig.AutoIncrementRules {
+				mysqlConfig.AutoIncrementRules = append(mysqlConfig.AutoIncrementRules,
+					&config.MySQLAutoIncrementRule{
+						Matcher: rule.Matcher,
+						Mode:    rule.Mode,
+						Offset:  rule.Offset,
+					})
+			}
+			mysqlConfig.SessionVariables = c.Sink.MySQLConfig.SessionVariables
+			for _, rule := range c.Sink.MySQLConfig.SessionVariableRules {
+				mysqlConfig.SessionVariableRules = append(mysqlConfig.SessionVariableRules,
+					&config.MySQLSessionVariableRule{
+						Matcher:   rule.Matcher,
+						Variables: rule.Variables,
+					})
 			}
 		}
 		var cloudStorageConfig *config.CloudStorageConfig
@@ -348,8 +580,59 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				WorkerCount:   c.Sink.CloudStorageConfig.WorkerCount,
 				FlushInterval: c.Sink.CloudStorageConfig.FlushInterval,
 				FileSize:      c.Sink.CloudStorageConfig.FileSize,
+				IndexMetadata: c.Sink.CloudStorageConfig.IndexMetadata,
+			}
+			for _, policy := range c.Sink.CloudStorageConfig.FlushPolicies {
+				cloudStorageConfig.FlushPolicies = append(cloudStorageConfig.FlushPolicies,
+					&config.CloudStorageFlushPolicy{
+						Matcher:       policy.Matcher,
+						FlushInterval: policy.FlushInterval,
+						FileSize:      policy.FileSize,
+						FileRowCount:  policy.FileRowCount,
+					})
 			}
 		}
+		var slowStartConfig *config.SlowStartConfig
+		if c.Sink.SlowStart != nil {
+			slowStartConfig = &config.SlowStartConfig{
+				Enable:          c.Sink.SlowStart.Enable,
+				StepInterval:    c.Sink.SlowStart.StepInterval,
+				MaxFlushLatency: c.Sink.SlowStart.MaxFlushLatency,
+				MinBacklog:      c.Sink.SlowStart.MinBacklog,
+			}
+		}
+		var burstAbsorptionConfig *config.BurstAbsorptionConfig
+		if c.Sink.BurstAbsorption != nil {
+			burstAbsorptionConfig = &config.BurstAbsorptionConfig{
+				Enable:             c.Sink.BurstAbsorption.Enable,
+				Ratio:              c.Sink.BurstAbsorption.Ratio,
+				MinEventsPerSecond: c.Sink.BurstAbsorption.MinEventsPerSecond,
+				Cooldown:           c.Sink.BurstAbsorption.Cooldown,
+				BatchFactor:        c.Sink.BurstAbsorption.BatchFactor,
+			}
+		}
+		var resolvedTsSuppressionConfig *config.ResolvedTsSuppressionConfig
+		if c.Sink.ResolvedTsSuppression != nil {
+			resolvedTsSuppressionConfig = &config.ResolvedTsSuppressionConfig{
+				MinInterval: c.Sink.ResolvedTsSuppression.MinInterval,
+				MinDelta:    c.Sink.ResolvedTsSuppression.MinDelta,
+			}
+		}
+		var autoCreateTableConfig *config.AutoCreateTableConfig
+		if c.Sink.AutoCreateTableConfig != nil {
+			autoCreateTableConfig = &config.AutoCreateTableConfig{
+				Engine:         c.Sink.AutoCreateTableConfig.Engine,
+				CharsetMapping: c.Sink.AutoCreateTableConfig.CharsetMapping,
+			}
+		}
+		var routes []*config.RouteRule
+		for _, rule := range c.Sink.Routes {
+			routes = append(routes, &config.RouteRule{
+				Matcher:      rule.Matcher,
+				TargetSchema: rule.TargetSchema,
+				TargetTable:  rule.TargetTable,
+			})
+		}
 
 		res.Sink = &config.SinkConfig{
 			DispatchRules:                    dispatchRules,
@@ -370,6 +653,14 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			MySQLConfig:                      mysqlConfig,
 			CloudStorageConfig:               cloudStorageConfig,
 			SafeMode:                         c.Sink.SafeMode,
+			SlowStart:                        slowStartConfig,
+			ResolvedTsSuppression:            resolvedTsSuppressionConfig,
+			TeeSinkURI:                       c.Sink.TeeSinkURI,
+			AutoCreateTable:                  c.Sink.AutoCreateTable,
+			AutoCreateTableConfig:            autoCreateTableConfig,
+			Routes:                           routes,
+			GeneratedColumnPolicy:            c.Sink.GeneratedColumnPolicy,
+			BurstAbsorption:                  burstAbsorptionConfig,
 		}
 
 		if c.Sink.TxnAtomicity != nil {
@@ -387,6 +678,17 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			EnableTableAcrossNodes: c.Scheduler.EnableTableAcrossNodes,
 			RegionThreshold:        c.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      c.Scheduler.WriteKeyThreshold,
+			Policy:                 c.Scheduler.Policy,
+			CaptureLabels:          c.Scheduler.CaptureLabels,
+			ZoneLabel:              c.Scheduler.ZoneLabel,
+		}
+		for _, rule := range c.Scheduler.Affinity {
+			res.Scheduler.Affinity = append(res.Scheduler.Affinity,
+				&config.AffinityRule{
+					Matcher:  rule.Matcher,
+					Captures: rule.Captures,
+					Labels:   rule.Labels,
+				})
 		}
 	}
 	if c.Integrity != nil {
@@ -395,6 +697,19 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			CorruptionHandleLevel: c.Integrity.CorruptionHandleLevel,
 		}
 	}
+	for _, rule := range c.ResolvedTsIntervals {
+		res.ResolvedTsIntervals = append(res.ResolvedTsIntervals,
+			&config.ResolvedTsIntervalRule{
+				Matcher:  rule.Matcher,
+				Interval: rule.Interval,
+			})
+	}
+	if c.Canary != nil {
+		res.Canary = &config.CanaryConfig{
+			Tables:      c.Canary.Tables,
+			SampleRatio: c.Canary.SampleRatio,
+		}
+	}
 	return res
 }
 
@@ -411,6 +726,11 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		CheckGCSafePoint:      cloned.CheckGCSafePoint,
 		EnableSyncPoint:       cloned.EnableSyncPoint,
 		BDRMode:               cloned.BDRMode,
+		BootstrapDDL:          cloned.BootstrapDDL,
+		ResourceGroup:         cloned.ResourceGroup,
+	}
+	for _, feature := range cloned.Features {
+		res.Features = append(res.Features, string(feature))
 	}
 
 	if cloned.SyncPointInterval != nil {
@@ -455,11 +775,17 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			}
 		}
 
+		var ofs []OriginFilterRule
+		for _, of := range cloned.Filter.OriginFilters {
+			ofs = append(ofs, ToAPIOriginFilterRule(of))
+		}
+
 		res.Filter = &FilterConfig{
 			MySQLReplicationRules: mySQLReplicationRules,
 			Rules:                 cloned.Filter.Rules,
 			IgnoreTxnStartTs:      cloned.Filter.IgnoreTxnStartTs,
 			EventFilters:          efs,
+			OriginFilters:         ofs,
 		}
 	}
 	if cloned.Sink != nil {
@@ -469,6 +795,10 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				Matcher:       rule.Matcher,
 				PartitionRule: rule.PartitionRule,
 				TopicRule:     rule.TopicRule,
+				Columns:       rule.Columns,
+				IndexName:     rule.IndexName,
+				HashFunction:  rule.HashFunction,
+				NoKeyStrategy: rule.NoKeyStrategy,
 			})
 		}
 		var columnSelectors []*ColumnSelector
@@ -529,11 +859,14 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				SASLOAuthScopes:              cloned.Sink.KafkaConfig.SASLOAuthScopes,
 				SASLOAuthGrantType:           cloned.Sink.KafkaConfig.SASLOAuthGrantType,
 				SASLOAuthAudience:            cloned.Sink.KafkaConfig.SASLOAuthAudience,
+				SASLOAuthTokenProvider:       cloned.Sink.KafkaConfig.SASLOAuthTokenProvider,
+				SASLOAuthAWSRegion:           cloned.Sink.KafkaConfig.SASLOAuthAWSRegion,
 				EnableTLS:                    cloned.Sink.KafkaConfig.EnableTLS,
 				CA:                           cloned.Sink.KafkaConfig.CA,
 				Cert:                         cloned.Sink.KafkaConfig.Cert,
 				Key:                          cloned.Sink.KafkaConfig.Key,
 				InsecureSkipVerify:           cloned.Sink.KafkaConfig.InsecureSkipVerify,
+				AdaptiveBatching:             cloned.Sink.KafkaConfig.AdaptiveBatching,
 				CodecConfig:                  codeConfig,
 			}
 		}
@@ -544,6 +877,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				MaxTxnRow:                    cloned.Sink.MySQLConfig.MaxTxnRow,
 				MaxMultiUpdateRowSize:        cloned.Sink.MySQLConfig.MaxMultiUpdateRowSize,
 				MaxMultiUpdateRowCount:       cloned.Sink.MySQLConfig.MaxMultiUpdateRowCount,
+				MaxMultiInsertRowCount:       cloned.Sink.MySQLConfig.MaxMultiInsertRowCount,
+				WorkerCountPerTable:          cloned.Sink.MySQLConfig.WorkerCountPerTable,
 				TiDBTxnMode:                  cloned.Sink.MySQLConfig.TiDBTxnMode,
 				SSLCa:                        cloned.Sink.MySQLConfig.SSLCa,
 				SSLCert:                      cloned.Sink.MySQLConfig.SSLCert,
@@ -555,6 +890,34 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				EnableBatchDML:               cloned.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         cloned.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: cloned.Sink.MySQLConfig.EnableCachePreparedStatement,
+				UnsupportedDDLPolicy:         cloned.Sink.MySQLConfig.UnsupportedDDLPolicy,
+				AdaptiveTxnRow:               cloned.Sink.MySQLConfig.AdaptiveTxnRow,
+				DDLCompatibilityCheck:        cloned.Sink.MySQLConfig.DDLCompatibilityCheck,
+			}
+			for _, group := range cloned.Sink.MySQLConfig.WorkerGroups {
+				mysqlConfig.WorkerGroups = append(mysqlConfig.WorkerGroups,
+					&MySQLWorkerGroup{
+						Name:        group.Name,
+						Matcher:     group.Matcher,
+						WorkerCount: group.WorkerCount,
+						MaxTxnRow:   group.MaxTxnRow,
+					})
+			}
+			for _, rule := range cloned.Sink.MySQLConfig.AutoIncrementRules {
+				mysqlConfig.AutoIncrementRules = append(mysqlConfig.AutoIncrementRules,
+					&MySQLAutoIncrementRule{
+						Matcher: rule.Matcher,
+						Mode:    rule.Mode,
+						Offset:  rule.Offset,
+					})
+			}
+			mysqlConfig.SessionVariables = cloned.Sink.MySQLConfig.SessionVariables
+			for _, rule := range cloned.Sink.MySQLConfig.SessionVariableRules {
+				mysqlConfig.SessionVariableRules = append(mysqlConfig.SessionVariableRules,
+					&MySQLSessionVariableRule{
+						Matcher:   rule.Matcher,
+						Variables: rule.Variables,
+					})
 			}
 		}
 		var cloudStorageConfig *CloudStorageConfig
@@ -563,8 +926,59 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				WorkerCount:   cloned.Sink.CloudStorageConfig.WorkerCount,
 				FlushInterval: cloned.Sink.CloudStorageConfig.FlushInterval,
 				FileSize:      cloned.Sink.CloudStorageConfig.FileSize,
+				IndexMetadata: cloned.Sink.CloudStorageConfig.IndexMetadata,
+			}
+			for _, policy := range cloned.Sink.CloudStorageConfig.FlushPolicies {
+				cloudStorageConfig.FlushPolicies = append(cloudStorageConfig.FlushPolicies,
+					&CloudStorageFlushPolicy{
+						Matcher:       policy.Matcher,
+						FlushInterval: policy.FlushInterval,
+						FileSize:      policy.FileSize,
+						FileRowCount:  policy.FileRowCount,
+					})
+			}
+		}
+		var slowStartConfig *SlowStartConfig
+		if cloned.Sink.SlowStart != nil {
+			slowStartConfig = &SlowStartConfig{
+				Enable:          cloned.Sink.SlowStart.Enable,
+				StepInterval:    cloned.Sink.SlowStart.StepInterval,
+				MaxFlushLatency: cloned.Sink.SlowStart.MaxFlushLatency,
+				MinBacklog:      cloned.Sink.SlowStart.MinBacklog,
 			}
 		}
+		var burstAbsorptionConfig *BurstAbsorptionConfig
+		if cloned.Sink.BurstAbsorption != nil {
+			burstAbsorptionConfig = &BurstAbsorptionConfig{
+				Enable:             cloned.Sink.BurstAbsorption.Enable,
+				Ratio:              cloned.Sink.BurstAbsorption.Ratio,
+				MinEventsPerSecond: cloned.Sink.BurstAbsorption.MinEventsPerSecond,
+				Cooldown:           cloned.Sink.BurstAbsorption.Cooldown,
+				BatchFactor:        cloned.Sink.BurstAbsorption.BatchFactor,
+			}
+		}
+		var resolvedTsSuppressionConfig *ResolvedTsSuppressionConfig
+		if cloned.Sink.ResolvedTsSuppression != nil {
+			resolvedTsSuppressionConfig = &ResolvedTsSuppressionConfig{
+				MinInterval: cloned.Sink.ResolvedTsSuppression.MinInterval,
+				MinDelta:    cloned.Sink.ResolvedTsSuppression.MinDelta,
+			}
+		}
+		var autoCreateTableConfig *AutoCreateTableConfig
+		if cloned.Sink.AutoCreateTableConfig != nil {
+			autoCreateTableConfig = &AutoCreateTableConfig{
+				Engine:         cloned.Sink.AutoCreateTableConfig.Engine,
+				CharsetMapping: cloned.Sink.AutoCreateTableConfig.CharsetMapping,
+			}
+		}
+		var routes []*RouteRule
+		for _, rule := range cloned.Sink.Routes {
+			routes = append(routes, &RouteRule{
+				Matcher:      rule.Matcher,
+				TargetSchema: rule.TargetSchema,
+				TargetTable:  rule.TargetTable,
+			})
+		}
 
 		res.Sink = &SinkConfig{
 			Protocol:                         cloned.Sink.Protocol,
@@ -585,6 +999,14 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			MySQLConfig:                      mysqlConfig,
 			CloudStorageConfig:               cloudStorageConfig,
 			SafeMode:                         cloned.Sink.SafeMode,
+			SlowStart:                        slowStartConfig,
+			ResolvedTsSuppression:            resolvedTsSuppressionConfig,
+			TeeSinkURI:                       cloned.Sink.TeeSinkURI,
+			AutoCreateTable:                  cloned.Sink.AutoCreateTable,
+			AutoCreateTableConfig:            autoCreateTableConfig,
+			Routes:                           routes,
+			GeneratedColumnPolicy:            cloned.Sink.GeneratedColumnPolicy,
+			BurstAbsorption:                  burstAbsorptionConfig,
 		}
 
 		if cloned.Sink.TxnAtomicity != nil {
@@ -598,6 +1020,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			FlushIntervalInMs: cloned.Consistent.FlushIntervalInMs,
 			Storage:           cloned.Consistent.Storage,
 			UseFileBackend:    cloned.Consistent.UseFileBackend,
+			Tables:            cloned.Consistent.Tables,
 		}
 	}
 	if cloned.Mounter != nil {
@@ -610,6 +1033,17 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			EnableTableAcrossNodes: cloned.Scheduler.EnableTableAcrossNodes,
 			RegionThreshold:        cloned.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      cloned.Scheduler.WriteKeyThreshold,
+			Policy:                 cloned.Scheduler.Policy,
+			CaptureLabels:          cloned.Scheduler.CaptureLabels,
+			ZoneLabel:              cloned.Scheduler.ZoneLabel,
+		}
+		for _, rule := range cloned.Scheduler.Affinity {
+			res.Scheduler.Affinity = append(res.Scheduler.Affinity,
+				&AffinityRule{
+					Matcher:  rule.Matcher,
+					Captures: rule.Captures,
+					Labels:   rule.Labels,
+				})
 		}
 	}
 
@@ -619,6 +1053,19 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			CorruptionHandleLevel: cloned.Integrity.CorruptionHandleLevel,
 		}
 	}
+	for _, rule := range cloned.ResolvedTsIntervals {
+		res.ResolvedTsIntervals = append(res.ResolvedTsIntervals,
+			&ResolvedTsIntervalRule{
+				Matcher:  rule.Matcher,
+				Interval: rule.Interval,
+			})
+	}
+	if cloned.Canary != nil {
+		res.Canary = &CanaryConfig{
+			Tables:      cloned.Canary.Tables,
+			SampleRatio: cloned.Canary.SampleRatio,
+		}
+	}
 
 	return res
 }
@@ -632,9 +1079,10 @@ func GetDefaultReplicaConfig() *ReplicaConfig {
 // This is a duplicate of config.FilterConfig
 type FilterConfig struct {
 	*MySQLReplicationRules
-	Rules            []string          `json:"rules,omitempty"`
-	IgnoreTxnStartTs []uint64          `json:"ignore_txn_start_ts,omitempty"`
-	EventFilters     []EventFilterRule `json:"event_filters,omitempty"`
+	Rules            []string           `json:"rules,omitempty"`
+	IgnoreTxnStartTs []uint64           `json:"ignore_txn_start_ts,omitempty"`
+	EventFilters     []EventFilterRule  `json:"event_filters,omitempty"`
+	OriginFilters    []OriginFilterRule `json:"origin_filters,omitempty"`
 }
 
 // MounterConfig represents mounter config for a changefeed
@@ -699,6 +1147,42 @@ func ToAPIEventFilterRule(er *config.EventFilterRule) EventFilterRule {
 	return res
 }
 
+// OriginFilterRule is used by origin filter
+// This is a duplicate of config.OriginFilterRule
+type OriginFilterRule struct {
+	Matcher       []string `json:"matcher"`
+	Tracker       string   `json:"tracker"`
+	SourceColumn  string   `json:"source_column"`
+	IgnoreOrigins []string `json:"ignore_origins"`
+}
+
+// ToInternalOriginFilterRule converts OriginFilterRule to *config.OriginFilterRule
+func (o OriginFilterRule) ToInternalOriginFilterRule() *config.OriginFilterRule {
+	return &config.OriginFilterRule{
+		Matcher:       o.Matcher,
+		Tracker:       o.Tracker,
+		SourceColumn:  o.SourceColumn,
+		IgnoreOrigins: o.IgnoreOrigins,
+	}
+}
+
+// ToAPIOriginFilterRule converts *config.OriginFilterRule to API OriginFilterRule
+func ToAPIOriginFilterRule(or *config.OriginFilterRule) OriginFilterRule {
+	res := OriginFilterRule{
+		Tracker:      or.Tracker,
+		SourceColumn: or.SourceColumn,
+	}
+	if len(or.Matcher) != 0 {
+		res.Matcher = make([]string, len(or.Matcher))
+		copy(res.Matcher, or.Matcher)
+	}
+	if len(or.IgnoreOrigins) != 0 {
+		res.IgnoreOrigins = make([]string, len(or.IgnoreOrigins))
+		copy(res.IgnoreOrigins, or.IgnoreOrigins)
+	}
+	return res
+}
+
 // MySQLReplicationRules is a set of rules based on MySQL's replication tableFilter.
 type MySQLReplicationRules struct {
 	// DoTables is an allowlist of tables.
@@ -723,25 +1207,33 @@ type Table struct {
 // SinkConfig represents sink config for a changefeed
 // This is a duplicate of config.SinkConfig
 type SinkConfig struct {
-	Protocol                         *string             `json:"protocol,omitempty"`
-	SchemaRegistry                   *string             `json:"schema_registry,omitempty"`
-	CSVConfig                        *CSVConfig          `json:"csv,omitempty"`
-	DispatchRules                    []*DispatchRule     `json:"dispatchers,omitempty"`
-	ColumnSelectors                  []*ColumnSelector   `json:"column_selectors,omitempty"`
-	TxnAtomicity                     *string             `json:"transaction_atomicity,omitempty"`
-	EncoderConcurrency               *int                `json:"encoder_concurrency,omitempty"`
-	Terminator                       *string             `json:"terminator,omitempty"`
-	DateSeparator                    *string             `json:"date_separator,omitempty"`
-	EnablePartitionSeparator         *bool               `json:"enable_partition_separator,omitempty"`
-	FileIndexWidth                   *int                `json:"file_index_width,omitempty"`
-	EnableKafkaSinkV2                *bool               `json:"enable_kafka_sink_v2,omitempty"`
-	OnlyOutputUpdatedColumns         *bool               `json:"only_output_updated_columns,omitempty"`
-	DeleteOnlyOutputHandleKeyColumns *bool               `json:"delete_only_output_handle_key_columns"`
-	LargeMessageOnlyHandleKeyColumns *bool               `json:"large_message_only_handle_key_columns"`
-	SafeMode                         *bool               `json:"safe_mode,omitempty"`
-	KafkaConfig                      *KafkaConfig        `json:"kafka_config,omitempty"`
-	MySQLConfig                      *MySQLConfig        `json:"mysql_config,omitempty"`
-	CloudStorageConfig               *CloudStorageConfig `json:"cloud_storage_config,omitempty"`
+	Protocol                         *string                      `json:"protocol,omitempty"`
+	SchemaRegistry                   *string                      `json:"schema_registry,omitempty"`
+	CSVConfig                        *CSVConfig                   `json:"csv,omitempty"`
+	DispatchRules                    []*DispatchRule              `json:"dispatchers,omitempty"`
+	ColumnSelectors                  []*ColumnSelector            `json:"column_selectors,omitempty"`
+	TxnAtomicity                     *string                      `json:"transaction_atomicity,omitempty"`
+	EncoderConcurrency               *int                         `json:"encoder_concurrency,omitempty"`
+	Terminator                       *string                      `json:"terminator,omitempty"`
+	DateSeparator                    *string                      `json:"date_separator,omitempty"`
+	EnablePartitionSeparator         *bool                        `json:"enable_partition_separator,omitempty"`
+	FileIndexWidth                   *int                         `json:"file_index_width,omitempty"`
+	EnableKafkaSinkV2                *bool                        `json:"enable_kafka_sink_v2,omitempty"`
+	OnlyOutputUpdatedColumns         *bool                        `json:"only_output_updated_columns,omitempty"`
+	DeleteOnlyOutputHandleKeyColumns *bool                        `json:"delete_only_output_handle_key_columns"`
+	LargeMessageOnlyHandleKeyColumns *bool                        `json:"large_message_only_handle_key_columns"`
+	SafeMode                         *bool                        `json:"safe_mode,omitempty"`
+	KafkaConfig                      *KafkaConfig                 `json:"kafka_config,omitempty"`
+	MySQLConfig                      *MySQLConfig                 `json:"mysql_config,omitempty"`
+	CloudStorageConfig               *CloudStorageConfig          `json:"cloud_storage_config,omitempty"`
+	SlowStart                        *SlowStartConfig             `json:"slow_start,omitempty"`
+	ResolvedTsSuppression            *ResolvedTsSuppressionConfig `json:"resolved_ts_suppression,omitempty"`
+	TeeSinkURI                       *string                      `json:"tee_sink_uri,omitempty"`
+	AutoCreateTable                  *bool                        `json:"auto_create_table,omitempty"`
+	AutoCreateTableConfig            *AutoCreateTableConfig       `json:"auto_create_table_config,omitempty"`
+	Routes                           []*RouteRule                 `json:"routes,omitempty"`
+	GeneratedColumnPolicy            *string                      `json:"generated_column_policy,omitempty"`
+	BurstAbsorption                  *BurstAbsorptionConfig       `json:"burst_absorption,omitempty"`
 }
 
 // CSVConfig denotes the csv config
@@ -759,6 +1251,10 @@ type DispatchRule struct {
 	Matcher       []string `json:"matcher,omitempty"`
 	PartitionRule string   `json:"partition"`
 	TopicRule     string   `json:"topic"`
+	Columns       []string `json:"columns,omitempty"`
+	IndexName     string   `json:"index,omitempty"`
+	HashFunction  string   `json:"hash_function,omitempty"`
+	NoKeyStrategy string   `json:"no_key_strategy,omitempty"`
 }
 
 // ColumnSelector represents a column selector for a table.
@@ -771,11 +1267,12 @@ type ColumnSelector struct {
 // ConsistentConfig represents replication consistency config for a changefeed
 // This is a duplicate of config.ConsistentConfig
 type ConsistentConfig struct {
-	Level             string `json:"level,omitempty"`
-	MaxLogSize        int64  `json:"max_log_size"`
-	FlushIntervalInMs int64  `json:"flush_interval"`
-	Storage           string `json:"storage,omitempty"`
-	UseFileBackend    bool   `json:"use_file_backend"`
+	Level             string   `json:"level,omitempty"`
+	MaxLogSize        int64    `json:"max_log_size"`
+	FlushIntervalInMs int64    `json:"flush_interval"`
+	Storage           string   `json:"storage,omitempty"`
+	UseFileBackend    bool     `json:"use_file_backend"`
+	Tables            []string `json:"tables,omitempty"`
 }
 
 // ChangefeedSchedulerConfig is per changefeed scheduler settings.
@@ -788,6 +1285,23 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region_threshold" json:"region_threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write_key_threshold" json:"write_key_threshold"`
+	// Policy is the name of the policy which schedules tables automatically.
+	Policy string `toml:"policy" json:"policy,omitempty"`
+	// Affinity pins the matched tables to the matched captures.
+	Affinity []*AffinityRule `toml:"affinity" json:"affinity,omitempty"`
+	// CaptureLabels constrains tables to the captures carrying the labels.
+	CaptureLabels map[string]string `toml:"capture_labels" json:"capture_labels,omitempty"`
+	// ZoneLabel is the label name of availability zones of TiKV stores and
+	// captures, tables are preferably replicated in the zone of their leaders.
+	ZoneLabel string `toml:"zone_label" json:"zone_label,omitempty"`
+}
+
+// AffinityRule pins the matched tables to the matched captures.
+// This is a duplicate of config.AffinityRule
+type AffinityRule struct {
+	Matcher  []string          `json:"matcher"`
+	Captures []string          `json:"captures,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
 }
 
 // IntegrityConfig is the config for integrity check
@@ -797,6 +1311,20 @@ type IntegrityConfig struct {
 	CorruptionHandleLevel string `json:"corruption_handle_level"`
 }
 
+// ResolvedTsIntervalRule sets the minimum resolved ts interval of matched
+// tables. This is a duplicate of config.ResolvedTsIntervalRule
+type ResolvedTsIntervalRule struct {
+	Matcher  []string `json:"matcher,omitempty"`
+	Interval string   `json:"interval"`
+}
+
+// CanaryConfig represents canary config for a changefeed.
+// This is a duplicate of config.CanaryConfig
+type CanaryConfig struct {
+	Tables      []string `json:"tables,omitempty"`
+	SampleRatio float64  `json:"sample_ratio"`
+}
+
 // EtcdData contains key/value pair of etcd data
 type EtcdData struct {
 	Key   string `json:"key,omitempty"`
@@ -817,10 +1345,13 @@ type ChangeFeedInfo struct {
 	ID         string    `json:"id,omitempty"`
 	SinkURI    string    `json:"sink_uri,omitempty"`
 	CreateTime time.Time `json:"create_time"`
+	Keyspace   string    `json:"keyspace,omitempty"`
 	// Start sync at this commit ts if `StartTs` is specify or using the CreateTime of changefeed.
 	StartTs uint64 `json:"start_ts,omitempty"`
 	// The ChangeFeed will exits until sync to timestamp TargetTs
-	TargetTs uint64 `json:"target_ts,omitempty"`
+	TargetTs     uint64        `json:"target_ts,omitempty"`
+	TTL          *JSONDuration `json:"ttl,omitempty" swaggertype:"string"`
+	RemoveTopics bool          `json:"remove_topics,omitempty"`
 	// used for admin job notification, trigger watch event in capture
 	AdminJobType   model.AdminJobType `json:"admin_job_type,omitempty"`
 	Config         *ReplicaConfig     `json:"config,omitempty"`
@@ -898,6 +1429,107 @@ type ProcessorDetail struct {
 // Liveness can only be changed from alive to stopping, and no way back.
 type Liveness int32
 
+// FederatedCluster holds the changefeeds of a TiCDC cluster in a federation.
+type FederatedCluster struct {
+	Endpoint    string                 `json:"endpoint"`
+	ClusterID   string                 `json:"cluster_id"`
+	IsLocal     bool                   `json:"is_local"`
+	Changefeeds []ChangefeedCommonInfo `json:"changefeeds"`
+	// Error is set if the changefeeds of the cluster can not be fetched.
+	Error string `json:"error,omitempty"`
+}
+
+// MetricsSummary holds the key health indicators of a capture, which are
+// computed from the metrics of the capture.
+type MetricsSummary struct {
+	CaptureID string `json:"capture_id"`
+	Address   string `json:"address"`
+	IsOwner   bool   `json:"is_owner"`
+	// SorterBacklogBytes is the size of the data buffered in the sorter,
+	// both in memory and on disk.
+	SorterBacklogBytes int64                      `json:"sorter_backlog_bytes"`
+	Changefeeds        []ChangefeedMetricsSummary `json:"changefeeds"`
+	// Error is set if the summary of the capture can not be fetched.
+	Error string `json:"error,omitempty"`
+}
+
+// ChangefeedMetricsSummary holds the key health indicators of a changefeed
+// on a capture.
+type ChangefeedMetricsSummary struct {
+	Namespace string `json:"namespace"`
+	ID        string `json:"id"`
+	// CheckpointLag is only reported by the owner.
+	CheckpointLag *LagDistribution `json:"checkpoint_lag,omitempty"`
+	SinkFlushP99  float64          `json:"sink_flush_p99_seconds"`
+	// E2ELatencyP99 is the p99 latency from the commit ts of events to
+	// being acked by the sink, of all tables of the changefeed.
+	E2ELatencyP99 float64 `json:"e2e_latency_p99_seconds"`
+	RegionCount   int64   `json:"region_count"`
+	TableCount    int64   `json:"table_count"`
+}
+
+// RegionSubscriptions holds the regions of a table subscribed by the kv
+// client of a capture.
+type RegionSubscriptions struct {
+	CaptureID string `json:"capture_id"`
+	TableID   int64  `json:"table_id"`
+	// SnapshotProgress is the percentage of regions whose incremental scan
+	// is finished, the table keeps preparing until it reaches 100.
+	SnapshotProgress float64 `json:"snapshot_progress"`
+	// Regions are sorted by resolved ts in ascending order, so the first one
+	// is the region holding back the resolved ts of the table.
+	Regions []RegionSubscription `json:"regions"`
+}
+
+// RegionSubscription holds the status of a subscribed region.
+type RegionSubscription struct {
+	RegionID         uint64     `json:"region_id"`
+	LeaderStoreID    uint64     `json:"leader_store_id"`
+	LeaderStoreAddr  string     `json:"leader_store_addr"`
+	ResolvedTs       uint64     `json:"resolved_ts"`
+	Initialized      bool       `json:"initialized"`
+	LastEventTime    *time.Time `json:"last_event_time,omitempty"`
+	PendingScanBytes uint64     `json:"pending_scan_bytes"`
+}
+
+// TableEvents holds the events of a table sampled by a capture.
+type TableEvents struct {
+	CaptureID string `json:"capture_id"`
+	TableID   int64  `json:"table_id"`
+	// Redacted is true if the column values are redacted.
+	Redacted bool         `json:"redacted"`
+	Events   []TableEvent `json:"events"`
+}
+
+// TableEvent is a row changed event which is mounted and ready to be
+// written to the downstream.
+type TableEvent struct {
+	StartTs  uint64 `json:"start_ts"`
+	CommitTs uint64 `json:"commit_ts"`
+	Schema   string `json:"schema"`
+	Table    string `json:"table"`
+	// Type is one of insert, update and delete.
+	Type       string        `json:"type"`
+	Columns    []EventColumn `json:"columns,omitempty"`
+	PreColumns []EventColumn `json:"pre_columns,omitempty"`
+}
+
+// EventColumn is a column of a row changed event.
+type EventColumn struct {
+	Name string `json:"name"`
+	// Value is nil if the value is NULL.
+	Value     *string `json:"value"`
+	HandleKey bool    `json:"handle_key"`
+}
+
+// LagDistribution is the distribution of a lag in seconds.
+type LagDistribution struct {
+	Current float64 `json:"current_seconds"`
+	P50     float64 `json:"p50_seconds"`
+	P90     float64 `json:"p90_seconds"`
+	P99     float64 `json:"p99_seconds"`
+}
+
 // ServerStatus holds some common information of a server
 type ServerStatus struct {
 	Version   string   `json:"version"`
@@ -915,6 +1547,23 @@ type Capture struct {
 	IsOwner       bool   `json:"is_owner"`
 	AdvertiseAddr string `json:"address"`
 	ClusterID     string `json:"cluster_id"`
+	// Load is the latest load reported by the capture in scheduler
+	// heartbeats, it's nil if the capture hasn't reported yet.
+	Load *model.CaptureLoad `json:"load,omitempty"`
+	// Labels are used to match the capture in table affinity rules.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// DrainCaptureProgress is the progress of draining a capture gracefully.
+type DrainCaptureProgress struct {
+	CaptureID string `json:"capture_id"`
+	// CurrentTableCount is the count of tables left on the capture.
+	CurrentTableCount int `json:"current_table_count"`
+	// Forced is true if the draining timed out and tables are moved out of
+	// the capture forcibly.
+	Forced bool `json:"forced"`
+	// Ready is true if the capture has no table and can be shut down.
+	Ready bool `json:"ready"`
 }
 
 // CodecConfig represents a MQ codec configuration
@@ -956,11 +1605,14 @@ type KafkaConfig struct {
 	SASLOAuthScopes              []string     `json:"sasl_oauth_scopes,omitempty"`
 	SASLOAuthGrantType           *string      `json:"sasl_oauth_grant_type,omitempty"`
 	SASLOAuthAudience            *string      `json:"sasl_oauth_audience,omitempty"`
+	SASLOAuthTokenProvider       *string      `json:"sasl_oauth_token_provider,omitempty"`
+	SASLOAuthAWSRegion           *string      `json:"sasl_oauth_aws_region,omitempty"`
 	EnableTLS                    *bool        `json:"enable_tls,omitempty"`
 	CA                           *string      `json:"ca,omitempty"`
 	Cert                         *string      `json:"cert,omitempty"`
 	Key                          *string      `json:"key,omitempty"`
 	InsecureSkipVerify           *bool        `json:"insecure_skip_verify,omitempty"`
+	AdaptiveBatching             *bool        `json:"adaptive_batching,omitempty"`
 	CodecConfig                  *CodecConfig `json:"codec_config,omitempty"`
 }
 
@@ -970,6 +1622,8 @@ type MySQLConfig struct {
 	MaxTxnRow                    *int    `json:"max_txn_row,omitempty"`
 	MaxMultiUpdateRowSize        *int    `json:"max_multi_update_row_size,omitempty"`
 	MaxMultiUpdateRowCount       *int    `json:"max_multi_update_row_count,omitempty"`
+	MaxMultiInsertRowCount       *int    `json:"max_multi_insert_row_count,omitempty"`
+	WorkerCountPerTable          *int    `json:"worker_count_per_table,omitempty"`
 	TiDBTxnMode                  *string `json:"tidb_txn_mode,omitempty"`
 	SSLCa                        *string `json:"ssl_ca,omitempty"`
 	SSLCert                      *string `json:"ssl_cert,omitempty"`
@@ -981,13 +1635,107 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `json:"enable_batch_dml,omitempty"`
 	EnableMultiStatement         *bool   `json:"enable_multi_statement,omitempty"`
 	EnableCachePreparedStatement *bool   `json:"enable_cache_prepared_statement,omitempty"`
+	UnsupportedDDLPolicy         *string `json:"unsupported_ddl_policy,omitempty"`
+	AdaptiveTxnRow               *bool   `json:"adaptive_txn_row,omitempty"`
+	DDLCompatibilityCheck        *string `json:"ddl_compatibility_check,omitempty"`
+
+	WorkerGroups []*MySQLWorkerGroup `json:"worker_groups,omitempty"`
+	// AutoIncrementRules decide how values of auto-increment columns of
+	// matched tables are applied.
+	AutoIncrementRules []*MySQLAutoIncrementRule `json:"auto_increment_rules,omitempty"`
+	// SessionVariables are session variables of the downstream used to write
+	// all tables.
+	SessionVariables map[string]string `json:"session_variables,omitempty"`
+	// SessionVariableRules override session variables for matched tables.
+	SessionVariableRules []*MySQLSessionVariableRule `json:"session_variable_rules,omitempty"`
+}
+
+// MySQLWorkerGroup is a group of workers dedicated to the matched tables.
+// This is a duplicate of config.MySQLWorkerGroup
+type MySQLWorkerGroup struct {
+	Name        string   `json:"name"`
+	Matcher     []string `json:"matcher,omitempty"`
+	WorkerCount *int     `json:"worker_count,omitempty"`
+	MaxTxnRow   *int     `json:"max_txn_row,omitempty"`
+}
+
+// MySQLAutoIncrementRule decides how values of auto-increment columns of the
+// matched tables are applied.
+// This is a duplicate of config.MySQLAutoIncrementRule
+type MySQLAutoIncrementRule struct {
+	Matcher []string `json:"matcher,omitempty"`
+	Mode    string   `json:"mode"`
+	Offset  int64    `json:"offset,omitempty"`
+}
+
+// MySQLSessionVariableRule overrides session variables of the downstream for
+// the matched tables.
+// This is a duplicate of config.MySQLSessionVariableRule
+type MySQLSessionVariableRule struct {
+	Matcher   []string          `json:"matcher,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
 type CloudStorageConfig struct {
-	WorkerCount   *int    `json:"worker_count,omitempty"`
-	FlushInterval *string `json:"flush_interval,omitempty"`
-	FileSize      *int    `json:"file_size,omitempty"`
+	WorkerCount   *int                       `json:"worker_count,omitempty"`
+	FlushInterval *string                    `json:"flush_interval,omitempty"`
+	FileSize      *int                       `json:"file_size,omitempty"`
+	FlushPolicies []*CloudStorageFlushPolicy `json:"flush_policies,omitempty"`
+	IndexMetadata *bool                      `json:"index_metadata,omitempty"`
+}
+
+// CloudStorageFlushPolicy overrides the flush policy of matched tables.
+// This is a duplicate of config.CloudStorageFlushPolicy
+type CloudStorageFlushPolicy struct {
+	Matcher       []string `json:"matcher,omitempty"`
+	FlushInterval *string  `json:"flush_interval,omitempty"`
+	FileSize      *int     `json:"file_size,omitempty"`
+	FileRowCount  *int     `json:"file_row_count,omitempty"`
+}
+
+// SlowStartConfig represents the slow-start configuration of a sink.
+// This is a duplicate of config.SlowStartConfig
+type SlowStartConfig struct {
+	Enable          *bool   `json:"enable,omitempty"`
+	StepInterval    *string `json:"step_interval,omitempty"`
+	MaxFlushLatency *string `json:"max_flush_latency,omitempty"`
+	MinBacklog      *string `json:"min_backlog,omitempty"`
+}
+
+// BurstAbsorptionConfig represents the burst absorption configuration of a
+// sink.
+// This is a duplicate of config.BurstAbsorptionConfig
+type BurstAbsorptionConfig struct {
+	Enable             *bool    `json:"enable,omitempty"`
+	Ratio              *float64 `json:"ratio,omitempty"`
+	MinEventsPerSecond *uint64  `json:"min_events_per_second,omitempty"`
+	Cooldown           *string  `json:"cooldown,omitempty"`
+	BatchFactor        *int     `json:"batch_factor,omitempty"`
+}
+
+// AutoCreateTableConfig represents how the MySQL sink creates downstream
+// tables.
+// This is a duplicate of config.AutoCreateTableConfig
+type AutoCreateTableConfig struct {
+	Engine         *string           `json:"engine,omitempty"`
+	CharsetMapping map[string]string `json:"charset_mapping,omitempty"`
+}
+
+// RouteRule renames the matched tables in the sink.
+// This is a duplicate of config.RouteRule
+type RouteRule struct {
+	Matcher      []string `json:"matcher,omitempty"`
+	TargetSchema string   `json:"target_schema,omitempty"`
+	TargetTable  string   `json:"target_table,omitempty"`
+}
+
+// ResolvedTsSuppressionConfig represents the resolved ts suppression
+// configuration of a MQ sink.
+// This is a duplicate of config.ResolvedTsSuppressionConfig
+type ResolvedTsSuppressionConfig struct {
+	MinInterval *string `json:"min_interval,omitempty"`
+	MinDelta    *string `json:"min_delta,omitempty"`
 }
 
 // ChangefeedStatus holds common information of a changefeed in cdc
@@ -997,4 +1745,9 @@ type ChangefeedStatus struct {
 	CheckpointTs uint64        `json:"checkpoint_ts"`
 	LastError    *RunningError `json:"last_error,omitempty"`
 	LastWarning  *RunningError `json:"last_warning,omitempty"`
+	PausedTables []PausedTable `json:"paused_tables,omitempty"`
+	// ScheduleTasks are the recently done schedule tasks of the changefeed
+	// in the order they are done, followed by the running ones. They tell
+	// why tables are added, removed or moved, and how long it takes.
+	ScheduleTasks []ScheduleTask `json:"schedule_tasks,omitempty"`
 }
@@ -151,6 +151,9 @@ func TestToAPIReplicaConfig(t *testing.T) {
 	cfg.Scheduler = &config.ChangefeedSchedulerConfig{
 		EnableTableAcrossNodes: true, RegionThreshold: 10001, WriteKeyThreshold: 10001,
 	}
+	cfg.ResolvedTsIntervals = []*config.ResolvedTsIntervalRule{
+		{Matcher: []string{"test.t1"}, Interval: "200ms"},
+	}
 	cfg2 := ToAPIReplicaConfig(cfg).ToInternalReplicaConfig()
 	require.Equal(t, "", cfg2.Sink.DispatchRules[0].DispatcherRule)
 	cfg.Sink.DispatchRules[0].DispatcherRule = ""
@@ -229,3 +232,20 @@ func TestEventFilterRuleConvert(t *testing.T) {
 		require.Equal(t, c.inRule, c.apiRule.ToInternalEventFilterRule())
 	}
 }
+
+func TestOriginFilterRuleConvert(t *testing.T) {
+	inRule := &config.OriginFilterRule{
+		Matcher:       []string{"test.*"},
+		Tracker:       config.OriginTrackerColumn,
+		SourceColumn:  "_origin",
+		IgnoreOrigins: []string{"etl", "dm"},
+	}
+	apiRule := OriginFilterRule{
+		Matcher:       []string{"test.*"},
+		Tracker:       "column",
+		SourceColumn:  "_origin",
+		IgnoreOrigins: []string{"etl", "dm"},
+	}
+	require.Equal(t, apiRule, ToAPIOriginFilterRule(inRule))
+	require.Equal(t, inRule, apiRule.ToInternalOriginFilterRule())
+}
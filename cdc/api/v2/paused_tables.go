@@ -0,0 +1,120 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// pauseTables handles pause tables request
+// @Summary Pause tables of a changefeed
+// @Description Pause replication of specific tables of a changefeed at the
+// @Description checkpoint ts of the changefeed. Paused tables are removed from
+// @Description captures, and the checkpoint ts of the changefeed does not
+// @Description advance until they are resumed.
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param pausedTablesConfig body PausedTablesConfig true "tables to pause"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/tables/pause [post]
+func (h *OpenAPIV2) pauseTables(c *gin.Context) {
+	changefeedID, tableIDs, ok := h.getPausedTablesParams(c)
+	if !ok {
+		return
+	}
+	if err := api.HandleOwnerPauseTables(
+		c.Request.Context(), h.capture, changefeedID, tableIDs); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// resumeTables handles resume tables request
+// @Summary Resume paused tables of a changefeed
+// @Description Resume replication of paused tables of a changefeed. They are
+// @Description replicated again from the checkpoint ts of the changefeed.
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param pausedTablesConfig body PausedTablesConfig true "tables to resume"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/tables/resume [post]
+func (h *OpenAPIV2) resumeTables(c *gin.Context) {
+	changefeedID, tableIDs, ok := h.getPausedTablesParams(c)
+	if !ok {
+		return
+	}
+	if err := api.HandleOwnerResumeTables(
+		c.Request.Context(), h.capture, changefeedID, tableIDs); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// getPausedTablesParams returns the changefeed and tables of pause tables and
+// resume tables requests. It returns false if the request is invalid.
+func (h *OpenAPIV2) getPausedTablesParams(
+	c *gin.Context,
+) (model.ChangeFeedID, []model.TableID, bool) {
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return changefeedID, nil, false
+	}
+	cfg := new(PausedTablesConfig)
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return changefeedID, nil, false
+	}
+	if len(cfg.TableIDs) == 0 {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("table_ids is required"))
+		return changefeedID, nil, false
+	}
+	// check if the changefeed exists
+	if _, err := h.capture.StatusProvider().GetChangeFeedStatus(
+		c.Request.Context(), changefeedID); err != nil {
+		_ = c.Error(err)
+		return changefeedID, nil, false
+	}
+	return changefeedID, cfg.TableIDs, true
+}
+
+func toPausedTables(paused map[model.TableID]model.Ts) []PausedTable {
+	if len(paused) == 0 {
+		return nil
+	}
+	tables := make([]PausedTable, 0, len(paused))
+	for tableID, ts := range paused {
+		tables = append(tables, PausedTable{TableID: tableID, CheckpointTs: ts})
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].TableID < tables[j].TableID
+	})
+	return tables
+}
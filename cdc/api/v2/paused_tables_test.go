@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseResumeTables(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	owner := mock_owner.NewMockOwner(ctrl)
+	statusProvider := &mockStatusProvider{
+		changefeedStatus: &model.ChangeFeedStatusForAPI{CheckpointTs: 10},
+	}
+	router := newRouter(NewOpenAPIV2ForTest(cp, NewMockAPIV2Helpers(ctrl)))
+
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetOwner().Return(owner, nil).AnyTimes()
+
+	do := func(url string, cfg any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(cfg)
+		require.Nil(t, err)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			"POST", url, bytes.NewReader(data))
+		router.ServeHTTP(w, req)
+		return w
+	}
+	requireErrCode := func(w *httptest.ResponseRecorder, code string) {
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		respErr := model.HTTPError{}
+		require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+		require.Contains(t, respErr.Code, code)
+	}
+
+	// case 1: no tables are specified
+	w := do("/api/v2/changefeeds/cf/tables/pause", &PausedTablesConfig{})
+	requireErrCode(w, "ErrAPIInvalidParam")
+
+	// case 2: pause tables
+	owner.EXPECT().PauseTables(model.DefaultChangeFeedID("cf"),
+		[]model.TableID{1, 2}, gomock.Any()).
+		Do(func(_ model.ChangeFeedID, _ []model.TableID, done chan<- error) {
+			close(done)
+		})
+	w = do("/api/v2/changefeeds/cf/tables/pause",
+		&PausedTablesConfig{TableIDs: []int64{1, 2}})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// case 3: resume tables that are not paused
+	owner.EXPECT().ResumeTables(model.DefaultChangeFeedID("cf"),
+		[]model.TableID{3}, gomock.Any()).
+		Do(func(cfID model.ChangeFeedID, tableIDs []model.TableID, done chan<- error) {
+			done <- cerror.ErrTableNotPaused.GenWithStackByArgs(tableIDs[0], cfID.ID)
+			close(done)
+		})
+	w = do("/api/v2/changefeeds/cf/tables/resume",
+		&PausedTablesConfig{TableIDs: []int64{3}})
+	requireErrCode(w, "ErrTableNotPaused")
+
+	// case 4: paused tables are shown in the status
+	statusProvider.changefeedInfo = &model.ChangeFeedInfo{State: model.StateNormal}
+	statusProvider.changefeedStatus.PausedTables = map[model.TableID]model.Ts{2: 10, 1: 8}
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		"GET", "/api/v2/changefeeds/cf/status", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ChangefeedStatus{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, []PausedTable{
+		{TableID: 1, CheckpointTs: 8},
+		{TableID: 2, CheckpointTs: 10},
+	}, resp.PausedTables)
+
+	// case 5: the changefeed does not exist
+	statusProvider.err = cerror.ErrChangeFeedNotExists.GenWithStackByArgs("cf")
+	w = do("/api/v2/changefeeds/cf/tables/resume",
+		&PausedTablesConfig{TableIDs: []int64{1}})
+	requireErrCode(w, "ErrChangeFeedNotExists")
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/txnutil/gc"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// reanchorChangefeed handles re-anchor changefeed request
+// @Summary Re-anchor a rewound changefeed
+// @Description Resume a changefeed stopped in the upstream-rewound state,
+// @Description e.g. after a FLASHBACK CLUSTER or a BR restore, from a new
+// @Description start ts. The current ts of the upstream is used if the
+// @Description start ts is not specified.
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param reanchorConfig body ReanchorChangefeedConfig false "re-anchor config"
+// @Success 200 {object} ReanchorChangefeedConfig
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/reanchor [post]
+func (h *OpenAPIV2) reanchorChangefeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	info, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if info.State != model.StateUpstreamRewound {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"changefeed %s is in %s state, only changefeeds in %s state "+
+				"can be re-anchored, please use resume instead",
+			changefeedID.ID, info.State, model.StateUpstreamRewound))
+		return
+	}
+
+	cfg := new(ReanchorChangefeedConfig)
+	if err := c.BindJSON(&cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+
+	upManager, err := h.capture.GetUpstreamManager()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	up, ok := upManager.Get(info.UpstreamID)
+	if !ok {
+		_ = c.Error(cerror.ErrUpstreamNotFound.GenWithStackByArgs(info.UpstreamID))
+		return
+	}
+	// The upstream is replaced by a new cluster, e.g. it's restored by BR
+	// into new PDs, the changefeed must be recreated for the new cluster.
+	if err := up.Error(); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"the upstream of changefeed %s is unavailable: %s, "+
+				"please recreate the changefeed", changefeedID.ID, err.Error()))
+		return
+	}
+	if cfg.StartTs == 0 {
+		physical, logical, err := up.TSOClient.GetTS(ctx)
+		if err != nil {
+			_ = c.Error(cerror.ErrPDEtcdAPIError.Wrap(err))
+			return
+		}
+		cfg.StartTs = oracle.ComposeTS(physical, logical)
+	}
+
+	serviceID := h.capture.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceResuming)
+	if err := h.helpers.verifyResumeChangefeedConfig(
+		ctx, up.PDClient, serviceID, changefeedID, cfg.StartTs); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	job := model.AdminJob{
+		CfID:                  changefeedID,
+		Type:                  model.AdminResume,
+		OverwriteCheckpointTs: cfg.StartTs,
+	}
+	if err := api.HandleOwnerJob(ctx, h.capture, job); err != nil {
+		if undoErr := gc.UndoEnsureChangefeedStartTsSafety(
+			ctx, up.PDClient, serviceID, changefeedID); undoErr != nil {
+			_ = c.Error(undoErr)
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, cfg)
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
+	"github.com/pingcap/tiflow/pkg/upstream"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestReanchorChangefeed(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	helpers := NewMockAPIV2Helpers(ctrl)
+	cp := mock_capture.NewMockCapture(ctrl)
+	owner := mock_owner.NewMockOwner(ctrl)
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	statusProvider := &mockStatusProvider{}
+	router := newRouter(NewOpenAPIV2ForTest(cp, helpers))
+
+	etcdClient.EXPECT().GetEnsureGCServiceID(gomock.Any()).
+		Return(etcd.GcServiceIDForTest()).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	cp.EXPECT().GetEtcdClient().Return(etcdClient).AnyTimes()
+	cp.EXPECT().GetUpstreamManager().
+		Return(upstream.NewManager4Test(&mockPDClient{logicTime: 1000}), nil).AnyTimes()
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetOwner().Return(owner, nil).AnyTimes()
+	var overwriteCheckpointTs uint64
+	owner.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		Do(func(adminJob model.AdminJob, done chan<- error) {
+			require.EqualValues(t, model.AdminResume, adminJob.Type)
+			overwriteCheckpointTs = adminJob.OverwriteCheckpointTs
+			close(done)
+		}).AnyTimes()
+	helpers.EXPECT().verifyResumeChangefeedConfig(gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	do := func(url string, cfg any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(cfg)
+		require.Nil(t, err)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			"POST", url, bytes.NewReader(data))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// case 1: the changefeed is not rewound
+	statusProvider.changefeedInfo = &model.ChangeFeedInfo{State: model.StateNormal}
+	w := do("/api/v2/changefeeds/cf/reanchor", &ReanchorChangefeedConfig{})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// case 2: re-anchor at the current ts of the upstream
+	statusProvider.changefeedInfo = &model.ChangeFeedInfo{State: model.StateUpstreamRewound}
+	w = do("/api/v2/changefeeds/cf/reanchor", &ReanchorChangefeedConfig{})
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ReanchorChangefeedConfig{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, oracle.ComposeTS(1000, 0), resp.StartTs)
+	require.Equal(t, resp.StartTs, overwriteCheckpointTs)
+
+	// case 3: re-anchor at the specified ts
+	w = do("/api/v2/changefeeds/cf/reanchor", &ReanchorChangefeedConfig{StartTs: 100})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, uint64(100), overwriteCheckpointTs)
+
+	// case 4: a rewound changefeed can't be resumed from its checkpoint
+	w = do("/api/v2/changefeeds/cf/resume", &ResumeChangefeedConfig{})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr = model.HTTPError{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+}
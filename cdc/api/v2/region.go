@@ -0,0 +1,144 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/kv"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const apiOpVarTableID = "table_id"
+
+// listRegionSubscriptions lists the regions of a table subscribed by the
+// capture which replicates the table.
+// @Summary List the subscribed regions of a table
+// @Description list the regions of a table subscribed by the kv client of
+// @Description the capture replicating the table, which can be used to find
+// @Description the region holding back the resolved ts of the table, or to
+// @Description track the progress of the initial incremental scan.
+// @Tags changefeed,v2
+// @Produce json
+// @Success 200 {object} RegionSubscriptions
+// @Failure 500,400 {object} model.HTTPError
+// @Param   changefeed_id   path    string  true  "changefeed ID"
+// @Param   namespace      query string false "default"
+// @Param   table_id   path    integer  true  "table ID"
+// @Router	/api/v2/changefeeds/{changefeed_id}/tables/{table_id}/regions [get]
+func (h *OpenAPIV2) listRegionSubscriptions(c *gin.Context) {
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	tableID, err := strconv.ParseInt(c.Param(apiOpVarTableID), 10, 64)
+	if err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid table_id: %s", c.Param(apiOpVarTableID)))
+		return
+	}
+	if h.forwardToTableCapture(c, changefeedID, tableID) {
+		return
+	}
+	info, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	subscriptions := kv.GetRegionSubscriptions(changefeedID, tableID)
+	resp := RegionSubscriptions{
+		CaptureID: info.ID,
+		TableID:   tableID,
+		Regions:   make([]RegionSubscription, 0, len(subscriptions)),
+	}
+	initialized := 0
+	for _, sub := range subscriptions {
+		if sub.Initialized {
+			initialized++
+		}
+		region := RegionSubscription{
+			RegionID:         sub.RegionID,
+			LeaderStoreID:    sub.StoreID,
+			LeaderStoreAddr:  sub.StoreAddr,
+			ResolvedTs:       sub.ResolvedTs,
+			Initialized:      sub.Initialized,
+			PendingScanBytes: sub.PendingScanBytes,
+		}
+		if !sub.LastEventTime.IsZero() {
+			lastEventTime := sub.LastEventTime
+			region.LastEventTime = &lastEventTime
+		}
+		resp.Regions = append(resp.Regions, region)
+	}
+	if len(subscriptions) > 0 {
+		resp.SnapshotProgress = float64(initialized) / float64(len(subscriptions)) * 100
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// forwardToTableCapture forwards the request to the capture replicating the
+// table, if it's not the current capture. It returns false if the request
+// should be handled locally.
+func (h *OpenAPIV2) forwardToTableCapture(
+	c *gin.Context, changefeedID model.ChangeFeedID, tableID model.TableID,
+) bool {
+	if !h.capture.IsOwner() {
+		// The request is forwarded by the owner.
+		return false
+	}
+	ctx := c.Request.Context()
+	statuses, err := h.capture.StatusProvider().GetAllTaskStatuses(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return true
+	}
+	var captureID model.CaptureID
+	for id, status := range statuses {
+		if _, ok := status.Tables[tableID]; ok {
+			captureID = id
+			break
+		}
+	}
+	if captureID == "" {
+		_ = c.Error(cerror.ErrTableNotReplicated.GenWithStackByArgs(tableID, changefeedID.ID))
+		return true
+	}
+	info, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return true
+	}
+	if captureID == info.ID {
+		return false
+	}
+	captures, err := h.capture.StatusProvider().GetCaptures(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return true
+	}
+	for _, target := range captures {
+		if target.ID == captureID {
+			api.ForwardToCapture(c, h.capture, target)
+			return true
+		}
+	}
+	_ = c.Error(cerror.ErrCaptureNotExist.GenWithStackByArgs(captureID))
+	return true
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRegionSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	// The capture replicating table 2, which the requests of table 2 are
+	// forwarded to.
+	var forwardedFrom string
+	target := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			forwardedFrom = r.Header.Get("TiCDC-ForwardFromOwner")
+			require.Equal(t, "/api/v2/changefeeds/test/tables/2/regions", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"capture_id":"target","table_id":2,"regions":[]}`))
+		}))
+	defer target.Close()
+
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{ID: captureID}, nil).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(&mockStatusProvider{
+		taskStatus: map[model.CaptureID]*model.TaskStatus{
+			captureID: {Tables: map[model.TableID]*model.TableReplicaInfo{1: {}}},
+			"target":  {Tables: map[model.TableID]*model.TableReplicaInfo{2: {}}},
+		},
+		captures: []*model.CaptureInfo{
+			{ID: captureID},
+			{ID: "target", AdvertiseAddr: strings.TrimPrefix(target.URL, "http://")},
+		},
+	}).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	request := func(changefeedID, tableID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), "GET",
+			fmt.Sprintf("/api/v2/changefeeds/%s/tables/%s/regions", changefeedID, tableID), nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// invalid changefeed id.
+	w := request("@^Invalid", "1")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// invalid table id.
+	w = request("test", "abc")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr = model.HTTPError{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// the table is not replicated.
+	w = request("test", "3")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr = model.HTTPError{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrTableNotReplicated")
+
+	// the table is replicated by the capture, no regions are subscribed.
+	w = request("test", "1")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := RegionSubscriptions{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, RegionSubscriptions{
+		CaptureID: captureID,
+		TableID:   1,
+		Regions:   []RegionSubscription{},
+	}, resp)
+
+	// the table is replicated by another capture.
+	w = request("test", "2")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, captureID, forwardedFrom)
+	resp = RegionSubscriptions{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, "target", resp.CaptureID)
+}
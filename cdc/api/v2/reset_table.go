@@ -0,0 +1,72 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/api"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// resetTable handles reset table request
+// @Summary Reset replication of a table of a changefeed
+// @Description Recreate replication of a table from the given ts. The table is
+// @Description removed from its capture, which drops its sorted events, and
+// @Description then added back from ts. The checkpoint ts of the changefeed is
+// @Description used if ts is not specified, and ts must not be smaller than it.
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param table_id  path  integer  true  "table ID"
+// @Param namespace query string false "default"
+// @Param ts query integer false "the ts to replicate the table from"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/tables/{table_id}/reset [post]
+func (h *OpenAPIV2) resetTable(c *gin.Context) {
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	tableID, err := strconv.ParseInt(c.Param(apiOpVarTableID), 10, 64)
+	if err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid table_id: %s", c.Param(apiOpVarTableID)))
+		return
+	}
+	var ts uint64
+	if s := c.Query("ts"); s != "" {
+		ts, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid ts: %s", s))
+			return
+		}
+	}
+	// check if the changefeed exists
+	if _, err := h.capture.StatusProvider().GetChangeFeedStatus(
+		c.Request.Context(), changefeedID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := api.HandleOwnerResetTable(
+		c.Request.Context(), h.capture, changefeedID, tableID, ts); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
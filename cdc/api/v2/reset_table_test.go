@@ -0,0 +1,89 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetTable(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	owner := mock_owner.NewMockOwner(ctrl)
+	statusProvider := &mockStatusProvider{
+		changefeedStatus: &model.ChangeFeedStatusForAPI{CheckpointTs: 10},
+	}
+	router := newRouter(NewOpenAPIV2ForTest(cp, NewMockAPIV2Helpers(ctrl)))
+
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetOwner().Return(owner, nil).AnyTimes()
+
+	do := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), "POST", url, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+	requireErrCode := func(w *httptest.ResponseRecorder, code string) {
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		respErr := model.HTTPError{}
+		require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+		require.Contains(t, respErr.Code, code)
+	}
+
+	// case 1: invalid params
+	w := do("/api/v2/changefeeds/cf/tables/abc/reset")
+	requireErrCode(w, "ErrAPIInvalidParam")
+	w = do("/api/v2/changefeeds/cf/tables/1/reset?ts=abc")
+	requireErrCode(w, "ErrAPIInvalidParam")
+
+	// case 2: reset the table from ts
+	owner.EXPECT().ResetTable(model.DefaultChangeFeedID("cf"),
+		model.TableID(1), model.Ts(20), gomock.Any()).
+		Do(func(_ model.ChangeFeedID, _ model.TableID, _ model.Ts, done chan<- error) {
+			close(done)
+		})
+	w = do("/api/v2/changefeeds/cf/tables/1/reset?ts=20")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// case 3: ts is smaller than the checkpoint ts
+	owner.EXPECT().ResetTable(model.DefaultChangeFeedID("cf"),
+		model.TableID(1), model.Ts(5), gomock.Any()).
+		Do(func(cfID model.ChangeFeedID, tableID model.TableID, ts model.Ts, done chan<- error) {
+			done <- cerror.ErrTableResetTsInvalid.GenWithStackByArgs(ts, tableID, 10, cfID.ID)
+			close(done)
+		})
+	w = do("/api/v2/changefeeds/cf/tables/1/reset?ts=5")
+	requireErrCode(w, "ErrTableResetTsInvalid")
+
+	// case 4: the changefeed does not exist
+	statusProvider.err = cerror.ErrChangeFeedNotExists.GenWithStackByArgs("cf")
+	w = do("/api/v2/changefeeds/cf/tables/1/reset")
+	requireErrCode(w, "ErrChangeFeedNotExists")
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/txnutil/gc"
+)
+
+// apiOpVarServiceID is the key of safepoint service ID in HTTP API
+const apiOpVarServiceID = "service_id"
+
+// listSafePointLeases lists auxiliary GC safepoint leases of a changefeed
+// @Summary List safepoint leases of a changefeed
+// @Description list auxiliary GC safepoint leases registered by external tools
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Success 200 {array} SafePointLease
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/safepoints [get]
+func (h *OpenAPIV2) listSafePointLeases(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	keeper, _, err := h.getSafePointKeeper(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	leases := keeper.List(changefeedID)
+	items := make([]SafePointLease, 0, len(leases))
+	for _, lease := range leases {
+		items = append(items, toAPISafePointLease(lease))
+	}
+	c.JSON(http.StatusOK, &ListResponse[SafePointLease]{
+		Total: len(items),
+		Items: items,
+	})
+}
+
+// registerSafePointLease registers an auxiliary GC safepoint lease
+// @Summary Register a safepoint lease of a changefeed
+// @Description register an auxiliary GC safepoint tied to a changefeed, the
+// @Description safepoint is the checkpoint of the changefeed if it is not
+// @Description specified. Registering an existing service updates the lease.
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param lease body SafePointLeaseConfig true "safepoint lease config"
+// @Success 200 {object} SafePointLease
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/safepoints [post]
+func (h *OpenAPIV2) registerSafePointLease(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfg := &SafePointLeaseConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	keeper, status, err := h.getSafePointKeeper(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	safePoint := cfg.SafePoint
+	if safePoint == 0 {
+		safePoint = status.CheckpointTs
+	}
+	lease, err := keeper.Register(ctx, changefeedID, cfg.ServiceID,
+		safePoint, time.Duration(cfg.TTL)*time.Second)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPISafePointLease(lease))
+}
+
+// renewSafePointLease renews an auxiliary GC safepoint lease
+// @Summary Renew a safepoint lease of a changefeed
+// @Description renew an auxiliary GC safepoint lease with a new ttl
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param service_id  path  string  true  "service_id"
+// @Param namespace query string false "default"
+// @Param lease body SafePointLeaseConfig true "safepoint lease config, only ttl is used"
+// @Success 200 {object} SafePointLease
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/safepoints/{service_id} [put]
+func (h *OpenAPIV2) renewSafePointLease(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfg := &SafePointLeaseConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	keeper, _, err := h.getSafePointKeeper(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	lease, err := keeper.Renew(ctx, changefeedID, c.Param(apiOpVarServiceID),
+		time.Duration(cfg.TTL)*time.Second)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPISafePointLease(lease))
+}
+
+// removeSafePointLease removes an auxiliary GC safepoint lease
+// @Summary Remove a safepoint lease of a changefeed
+// @Description remove an auxiliary GC safepoint lease
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param service_id  path  string  true  "service_id"
+// @Param namespace query string false "default"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/safepoints/{service_id} [delete]
+func (h *OpenAPIV2) removeSafePointLease(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	keeper, _, err := h.getSafePointKeeper(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := keeper.Remove(ctx, changefeedID, c.Param(apiOpVarServiceID)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// getChangefeedIDParam returns the validated changefeed id of the request.
+func getChangefeedIDParam(c *gin.Context) (model.ChangeFeedID, error) {
+	changefeedID := model.ChangeFeedID{
+		Namespace: getNamespaceValueWithDefault(c),
+		ID:        c.Param(apiOpVarChangefeedID),
+	}
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		return changefeedID, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid changefeed_id: %s", changefeedID.ID)
+	}
+	return changefeedID, nil
+}
+
+// getSafePointKeeper returns the safepoint keeper of the changefeed's
+// upstream, and the status of the changefeed.
+func (h *OpenAPIV2) getSafePointKeeper(
+	ctx context.Context, changefeedID model.ChangeFeedID,
+) (*gc.SafePointKeeper, *model.ChangeFeedStatusForAPI, error) {
+	info, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	status, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	upManager, err := h.capture.GetUpstreamManager()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	up, ok := upManager.Get(info.UpstreamID)
+	if !ok || up.SafePointKeeper == nil {
+		return nil, nil, cerror.ErrUpstreamNotFound.GenWithStackByArgs(info.UpstreamID)
+	}
+	return up.SafePointKeeper, status, nil
+}
+
+func toAPISafePointLease(lease *gc.SafePointLease) SafePointLease {
+	return SafePointLease{
+		ServiceID: lease.ServiceID,
+		SafePoint: lease.SafePoint,
+		TTL:       int64(lease.TTL / time.Second),
+		ExpireAt:  lease.ExpireAt,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/upstream"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafePointLeases(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetUpstreamManager().
+		Return(upstream.NewManager4Test(&mockPDClient{}), nil).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(&mockStatusProvider{
+		changefeedInfo:   &model.ChangeFeedInfo{UpstreamID: 0},
+		changefeedStatus: &model.ChangeFeedStatusForAPI{CheckpointTs: 100},
+	}).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+	do := func(method, url string, body any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.Nil(t, err)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			method, url, bytes.NewReader(data))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// register a lease at the checkpoint of the changefeed.
+	w := do("POST", "/api/v2/changefeeds/cf/safepoints",
+		&SafePointLeaseConfig{ServiceID: "br", TTL: 60})
+	require.Equal(t, http.StatusOK, w.Code)
+	lease := &SafePointLease{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(lease))
+	require.Equal(t, "br", lease.ServiceID)
+	require.Equal(t, uint64(100), lease.SafePoint)
+	require.Equal(t, int64(60), lease.TTL)
+
+	// register a lease at the given safepoint.
+	w = do("POST", "/api/v2/changefeeds/cf/safepoints",
+		&SafePointLeaseConfig{ServiceID: "dumpling", SafePoint: 200, TTL: 60})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// invalid ttl
+	w = do("POST", "/api/v2/changefeeds/cf/safepoints",
+		&SafePointLeaseConfig{ServiceID: "br"})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do("PUT", "/api/v2/changefeeds/cf/safepoints/br",
+		&SafePointLeaseConfig{TTL: 120})
+	require.Equal(t, http.StatusOK, w.Code)
+	lease = &SafePointLease{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(lease))
+	require.Equal(t, int64(120), lease.TTL)
+	require.Equal(t, uint64(100), lease.SafePoint)
+
+	w = do("GET", "/api/v2/changefeeds/cf/safepoints", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	leases := &ListResponse[SafePointLease]{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(leases))
+	require.Equal(t, 2, leases.Total)
+
+	w = do("DELETE", "/api/v2/changefeeds/cf/safepoints/br", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = do("DELETE", "/api/v2/changefeeds/cf/safepoints/br", nil)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("PUT", "/api/v2/changefeeds/cf/safepoints/br",
+		&SafePointLeaseConfig{TTL: 120})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
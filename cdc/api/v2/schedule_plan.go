@@ -0,0 +1,70 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// apiOpVarRebalance is the key of planning a manual rebalance in HTTP API
+	apiOpVarRebalance = "rebalance"
+)
+
+// getSchedulePlan gets the schedule operations planned for a changefeed
+// @Summary Get the schedule operations planned for a changefeed
+// @Description get the operations the scheduler of a changefeed would perform
+// @Description on its current states, the operations are not performed.
+// @Description If rebalance is true, a manual rebalance is planned as if it's
+// @Description requested, to preview its impact before triggering it.
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param rebalance query bool false "plan a manual rebalance"
+// @Success 200 {array} ScheduleOperation
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/schedule_plan [get]
+func (h *OpenAPIV2) getSchedulePlan(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	rebalance := c.Query(apiOpVarRebalance) == "true"
+
+	ops, err := h.capture.StatusProvider().GetSchedulePlan(ctx, changefeedID, rebalance)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	items := make([]ScheduleOperation, 0, len(ops))
+	for _, op := range ops {
+		items = append(items, ScheduleOperation{
+			Scheduler: op.Scheduler,
+			Type:      op.Type,
+			TableID:   op.TableID,
+			Span:      op.Span,
+			From:      op.From,
+			To:        op.To,
+		})
+	}
+	c.JSON(http.StatusOK, &ListResponse[ScheduleOperation]{
+		Total: len(items),
+		Items: items,
+	})
+}
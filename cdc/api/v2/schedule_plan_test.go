@@ -0,0 +1,77 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSchedulePlan(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	statusProvider := mock_owner.NewMockStatusProvider(ctrl)
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	statusProvider.EXPECT().
+		GetSchedulePlan(gomock.Any(), model.DefaultChangeFeedID("cf"), true).
+		Return([]model.ScheduleOperation{{
+			Scheduler: "balance-scheduler",
+			Type:      model.ScheduleOperationMove,
+			TableID:   1,
+			Span:      "{table_id:1,start_key:,end_key:}",
+			From:      "a",
+			To:        "b",
+		}}, nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), "GET",
+		"/api/v2/changefeeds/cf/schedule_plan?rebalance=true", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &ListResponse[ScheduleOperation]{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, ScheduleOperation{
+		Scheduler: "balance-scheduler",
+		Type:      "move",
+		TableID:   1,
+		Span:      "{table_id:1,start_key:,end_key:}",
+		From:      "a",
+		To:        "b",
+	}, resp.Items[0])
+
+	statusProvider.EXPECT().
+		GetSchedulePlan(gomock.Any(), model.DefaultChangeFeedID("cf"), false).
+		Return(nil, cerror.ErrSchedulerRequestFailed.GenWithStack("not ready"))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), "GET",
+		"/api/v2/changefeeds/cf/schedule_plan", nil)
+	router.ServeHTTP(w, req)
+	require.NotEqual(t, http.StatusOK, w.Code)
+}
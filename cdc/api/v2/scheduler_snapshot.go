@@ -0,0 +1,49 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getSchedulerSnapshot dumps the scheduler states of a changefeed
+// @Summary Dump the scheduler states of a changefeed
+// @Description dump a redacted snapshot of the in-memory states of the
+// @Description changefeed scheduler in the owner, including captures,
+// @Description replication sets, queued schedule requests and the
+// @Description compatibility matrix. The snapshot can be replayed offline
+// @Description by `cdc scheduler replay`.
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Success 200 {object} object
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/scheduler_snapshot [get]
+func (h *OpenAPIV2) getSchedulerSnapshot(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	data, err := h.capture.StatusProvider().GetSchedulerSnapshot(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Data(http.StatusOK, gin.MIMEJSON, data)
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"go.uber.org/zap"
+)
+
+// sorterCompactTimeout is the timeout of forwarding a sorter compaction to
+// another capture, compactions of large DBs can take minutes.
+const sorterCompactTimeout = 30 * time.Minute
+
+// compactSorter compacts the sorter storage of the capture which handles the
+// request.
+// @Summary Compact the sorter storage
+// @Description compact the sorter storage of the capture handling the request
+// @Description manually, so the disk space held by deleted events can be
+// @Description reclaimed. It can be limited to a changefeed or a table.
+// @Tags capture,v2
+// @Accept json
+// @Produce json
+// @Param compact body SorterCompactReq false "compaction scope"
+// @Success 200 {object} SorterCompactResp
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/sorter/compact [post]
+func (h *OpenAPIV2) compactSorter(c *gin.Context) {
+	req := &SorterCompactReq{}
+	if err := bindSorterCompactReq(c, req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp, err := h.compactLocalSorter(req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// compactCaptureSorter compacts the sorter storage of a capture.
+// @Summary Compact the sorter storage of a capture
+// @Description compact the sorter storage of a capture manually, so the
+// @Description disk space held by deleted events, e.g. events of removed
+// @Description changefeeds, can be reclaimed. It can be limited to a
+// @Description changefeed or a table of the changefeed.
+// @Tags capture,v2
+// @Accept json
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Param compact body SorterCompactReq false "compaction scope"
+// @Success 200 {object} SorterCompactResp
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/captures/{capture_id}/sorter/compact [post]
+func (h *OpenAPIV2) compactCaptureSorter(c *gin.Context) {
+	captureID := c.Param(apiOpVarCaptureID)
+	req := &SorterCompactReq{}
+	if err := bindSorterCompactReq(c, req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	captures, err := h.capture.StatusProvider().GetCaptures(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var target *model.CaptureInfo
+	for _, capture := range captures {
+		if capture.ID == captureID {
+			target = capture
+			break
+		}
+	}
+	if target == nil {
+		_ = c.Error(cerror.ErrCaptureNotExist.GenWithStackByArgs(captureID))
+		return
+	}
+	self, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if target.ID == self.ID {
+		resp, err := h.compactLocalSorter(req)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	serverCfg := config.GetGlobalServerConfig()
+	client, err := httputil.NewClient(serverCfg.Security)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	client.SetTimeout(sorterCompactTimeout)
+	defer client.CloseIdleConnections()
+	body, err := json.Marshal(req)
+	if err != nil {
+		_ = c.Error(errors.Trace(err))
+		return
+	}
+	tlsEnabled := serverCfg.Security != nil && serverCfg.Security.IsTLSEnabled()
+	uri := peerURL(target.AdvertiseAddr, tlsEnabled) + "/api/v2/sorter/compact"
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	respBody, err := client.DoRequest(ctx, uri, http.MethodPost,
+		header, bytes.NewReader(body))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp := &SorterCompactResp{}
+	if err := json.Unmarshal(respBody, resp); err != nil {
+		_ = c.Error(errors.Trace(err))
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// compactLocalSorter compacts the sorter storage of the current capture.
+func (h *OpenAPIV2) compactLocalSorter(req *SorterCompactReq) (*SorterCompactResp, error) {
+	info, err := h.capture.Info()
+	if err != nil {
+		return nil, err
+	}
+	resp := &SorterCompactResp{CaptureID: info.ID}
+	f := h.capture.GetSortEngineFactory()
+	if f == nil {
+		return resp, nil
+	}
+
+	var changefeedID *model.ChangeFeedID
+	if req.ChangefeedID != "" {
+		id := model.ChangeFeedID{Namespace: req.Namespace, ID: req.ChangefeedID}
+		changefeedID = &id
+	}
+	start := time.Now()
+	resp.ReclaimedBytes, err = f.Compact(changefeedID, req.TableID)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("sorter compacted manually",
+		zap.String("namespace", req.Namespace),
+		zap.String("changefeed", req.ChangefeedID),
+		zap.Int64p("tableID", req.TableID),
+		zap.Int64("reclaimedBytes", resp.ReclaimedBytes),
+		zap.Duration("duration", time.Since(start)))
+	return resp, nil
+}
+
+// bindSorterCompactReq reads and checks the request, the body is optional.
+func bindSorterCompactReq(c *gin.Context, req *SorterCompactReq) error {
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(req); err != nil {
+			return cerror.ErrAPIInvalidParam.GenWithStack(
+				"invalid sorter compact request: %s", err.Error())
+		}
+	}
+	if req.TableID != nil && req.ChangefeedID == "" {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"changefeed_id must be specified if table_id is specified")
+	}
+	if req.Namespace == "" {
+		req.Namespace = model.DefaultNamespace
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/factory"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactCaptureSorter(t *testing.T) {
+	var received SorterCompactReq
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/sorter/compact", r.URL.Path)
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte(`{"capture_id":"peer","reclaimed_bytes":1024}`))
+	}))
+	defer peer.Close()
+
+	f := factory.NewForPebble(t.TempDir(), 0, config.GetDefaultServerConfig().Debug.DB)
+	defer func() { _ = f.Close() }()
+
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{
+		ID: "owner", AdvertiseAddr: "127.0.0.1:8300",
+	}, nil).AnyTimes()
+	cp.EXPECT().GetSortEngineFactory().Return(f).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(&mockStatusProvider{
+		captures: []*model.CaptureInfo{
+			{ID: "owner", AdvertiseAddr: "127.0.0.1:8300"},
+			{ID: "peer", AdvertiseAddr: strings.TrimPrefix(peer.URL, "http://")},
+		},
+	}).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	cases := []struct {
+		captureID string
+		body      string
+		code      int
+		resp      SorterCompactResp
+	}{
+		{"owner", `{`, 400, SorterCompactResp{}},
+		{"owner", `{"table_id":1}`, 400, SorterCompactResp{}},
+		{"unknown", ``, 400, SorterCompactResp{}},
+		{"owner", `{"changefeed_id":"test"}`, 400, SorterCompactResp{}},
+		{"owner", ``, 200, SorterCompactResp{CaptureID: "owner"}},
+		{
+			"peer", `{"changefeed_id":"test","table_id":1}`, 200,
+			SorterCompactResp{CaptureID: "peer", ReclaimedBytes: 1024},
+		},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), "POST",
+			"/api/v2/captures/"+c.captureID+"/sorter/compact", bytes.NewReader([]byte(c.body)))
+		router.ServeHTTP(w, req)
+		require.Equal(t, c.code, w.Code, c.body)
+		if c.code == http.StatusOK {
+			resp := SorterCompactResp{}
+			require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+			require.Equal(t, c.resp, resp)
+		}
+	}
+	tableID := int64(1)
+	require.Equal(t, SorterCompactReq{
+		Namespace: model.DefaultNamespace, ChangefeedID: "test", TableID: &tableID,
+	}, received)
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sinkmanager"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// defaultSampleEventsLimit is the default number of events to sample.
+	defaultSampleEventsLimit = 10
+	// maxSampleEventsLimit is the max number of events to sample.
+	maxSampleEventsLimit = 1000
+	// sampleEventsTimeout is how long to wait for the events, the events
+	// sampled so far are returned after it.
+	sampleEventsTimeout = 10 * time.Second
+	// redactedValue replaces column values if they are redacted.
+	redactedValue = "?"
+)
+
+// sampleTableEvents samples the events of a table from the capture which
+// replicates the table.
+// @Summary Sample the events of a table
+// @Description return the next events of a table which are mounted and
+// @Description ready to be written to the downstream, so filters and
+// @Description transformations can be verified without consuming the
+// @Description downstream. The events are sampled from the capture
+// @Description replicating the table. Column values are redacted unless
+// @Description redact is false.
+// @Tags changefeed,v2
+// @Produce json
+// @Param   changefeed_id   path    string  true  "changefeed ID"
+// @Param   namespace      query string false "default"
+// @Param   table_id   path    integer  true  "table ID"
+// @Param   limit   query    integer  false  "max number of events, 10 by default"
+// @Param   redact   query    bool  false  "redact column values, true by default"
+// @Success 200 {object} TableEvents
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/changefeeds/{changefeed_id}/tables/{table_id}/events [get]
+func (h *OpenAPIV2) sampleTableEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	tableID, err := strconv.ParseInt(c.Param(apiOpVarTableID), 10, 64)
+	if err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid table_id: %s", c.Param(apiOpVarTableID)))
+		return
+	}
+	limit := defaultSampleEventsLimit
+	if s := c.Query("limit"); s != "" {
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit <= 0 || limit > maxSampleEventsLimit {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+				"invalid limit: %s, it must be in [1, %d]", s, maxSampleEventsLimit))
+			return
+		}
+	}
+	redact := true
+	if s := c.Query("redact"); s != "" {
+		redact, err = strconv.ParseBool(s)
+		if err != nil {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid redact: %s", s))
+			return
+		}
+	}
+	if h.forwardToTableCapture(c, changefeedID, tableID) {
+		return
+	}
+	info, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	events, err := sinkmanager.SampleEvents(
+		ctx, changefeedID, tableID, limit, sampleEventsTimeout)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	resp := TableEvents{
+		CaptureID: info.ID,
+		TableID:   tableID,
+		Redacted:  redact,
+		Events:    make([]TableEvent, 0, len(events)),
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, toAPITableEvent(e, redact))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func toAPITableEvent(e *model.RowChangedEvent, redact bool) TableEvent {
+	event := TableEvent{
+		StartTs:    e.StartTs,
+		CommitTs:   e.CommitTs,
+		Columns:    toAPIEventColumns(e.Columns, redact),
+		PreColumns: toAPIEventColumns(e.PreColumns, redact),
+	}
+	if e.Table != nil {
+		event.Schema = e.Table.Schema
+		event.Table = e.Table.Table
+	}
+	switch {
+	case e.IsInsert():
+		event.Type = "insert"
+	case e.IsUpdate():
+		event.Type = "update"
+	case e.IsDelete():
+		event.Type = "delete"
+	}
+	return event
+}
+
+func toAPIEventColumns(cols []*model.Column, redact bool) []EventColumn {
+	res := make([]EventColumn, 0, len(cols))
+	for _, col := range cols {
+		if col == nil {
+			continue
+		}
+		column := EventColumn{Name: col.Name, HandleKey: col.Flag.IsHandleKey()}
+		if col.Value != nil {
+			value := redactedValue
+			if !redact {
+				value = model.ColumnValueString(col.Value)
+			}
+			column.Value = &value
+		}
+		res = append(res, column)
+	}
+	return res
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleTableEvents(t *testing.T) {
+	t.Parallel()
+
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{ID: captureID}, nil).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(&mockStatusProvider{
+		taskStatus: map[model.CaptureID]*model.TaskStatus{
+			captureID: {Tables: map[model.TableID]*model.TableReplicaInfo{1: {}}},
+		},
+	}).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	request := func(url string) model.HTTPError {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		respErr := model.HTTPError{}
+		require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+		return respErr
+	}
+
+	for _, url := range []string{
+		"/api/v2/changefeeds/@^Invalid/tables/1/events",
+		"/api/v2/changefeeds/test/tables/abc/events",
+		"/api/v2/changefeeds/test/tables/1/events?limit=0",
+		"/api/v2/changefeeds/test/tables/1/events?limit=100000",
+		"/api/v2/changefeeds/test/tables/1/events?redact=abc",
+	} {
+		respErr := request(url)
+		require.Contains(t, respErr.Code, "ErrAPIInvalidParam", url)
+	}
+
+	// the table is not replicated by any capture.
+	respErr := request("/api/v2/changefeeds/test/tables/2/events?limit=1")
+	require.Contains(t, respErr.Code, "ErrTableNotReplicated")
+
+	// the table is replicated by the capture, but it has no table sink yet.
+	respErr = request("/api/v2/changefeeds/test/tables/1/events?limit=1")
+	require.Contains(t, respErr.Code, "ErrProcessorTableNotFound")
+}
+
+func TestToAPITableEvent(t *testing.T) {
+	t.Parallel()
+
+	e := &model.RowChangedEvent{
+		StartTs:  1,
+		CommitTs: 2,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{Name: "id", Value: int64(1), Flag: model.HandleKeyFlag},
+			{Name: "name", Value: []byte("alice")},
+			{Name: "age", Value: nil},
+		},
+		PreColumns: []*model.Column{
+			{Name: "id", Value: int64(1), Flag: model.HandleKeyFlag},
+			{Name: "name", Value: []byte("bob")},
+			{Name: "age", Value: nil},
+		},
+	}
+	value := func(s string) *string { return &s }
+
+	event := toAPITableEvent(e, true)
+	require.Equal(t, TableEvent{
+		StartTs:  1,
+		CommitTs: 2,
+		Schema:   "test",
+		Table:    "t",
+		Type:     "update",
+		Columns: []EventColumn{
+			{Name: "id", Value: value("?"), HandleKey: true},
+			{Name: "name", Value: value("?")},
+			{Name: "age"},
+		},
+		PreColumns: []EventColumn{
+			{Name: "id", Value: value("?"), HandleKey: true},
+			{Name: "name", Value: value("?")},
+			{Name: "age"},
+		},
+	}, event)
+
+	e.PreColumns = nil
+	event = toAPITableEvent(e, false)
+	require.Equal(t, "insert", event.Type)
+	require.Equal(t, []EventColumn{
+		{Name: "id", Value: value("1"), HandleKey: true},
+		{Name: "name", Value: value("alice")},
+		{Name: "age"},
+	}, event.Columns)
+	require.Empty(t, event.PreColumns)
+}
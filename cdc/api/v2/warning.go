@@ -0,0 +1,63 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listChangefeedWarnings lists the recent warnings of a changefeed
+// @Summary List the recent warnings of a changefeed
+// @Description list the warnings of a changefeed seen in the last hour, the
+// @Description most recently seen first. Repeated warnings with the same code
+// @Description and the same message except numbers are aggregated into one,
+// @Description with the first seen time, the last seen time and the count.
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Success 200 {array} ChangefeedWarning
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/warnings [get]
+func (h *OpenAPIV2) listChangefeedWarnings(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID, err := getChangefeedIDParam(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	warnings, err := h.capture.StatusProvider().GetChangeFeedWarnings(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	items := make([]ChangefeedWarning, 0, len(warnings))
+	for _, warning := range warnings {
+		items = append(items, ChangefeedWarning{
+			Code:      warning.Code,
+			Message:   warning.Message,
+			Address:   warning.Addr,
+			FirstSeen: warning.FirstSeen,
+			LastSeen:  warning.LastSeen,
+			Count:     warning.Count,
+		})
+	}
+	c.JSON(http.StatusOK, &ListResponse[ChangefeedWarning]{
+		Total: len(items),
+		Items: items,
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListChangefeedWarnings(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second)
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	statusProvider := mock_owner.NewMockStatusProvider(ctrl)
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	statusProvider.EXPECT().GetChangeFeedWarnings(gomock.Any(), model.DefaultChangeFeedID("cf")).
+		Return([]model.ChangefeedWarning{{
+			Code:      "CDC:ErrSinkFlushSlow",
+			Message:   "sink flush slow",
+			Addr:      "127.0.0.1:8300",
+			FirstSeen: now.Add(-time.Minute),
+			LastSeen:  now,
+			Count:     3,
+		}}, nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), "GET",
+		"/api/v2/changefeeds/cf/warnings", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &ListResponse[ChangefeedWarning]{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, "127.0.0.1:8300", resp.Items[0].Address)
+	require.Equal(t, int64(3), resp.Items[0].Count)
+	require.True(t, now.Add(-time.Minute).Equal(resp.Items[0].FirstSeen))
+
+	statusProvider.EXPECT().GetChangeFeedWarnings(gomock.Any(), model.DefaultChangeFeedID("cf")).
+		Return(nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs("cf"))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), "GET",
+		"/api/v2/changefeeds/cf/warnings", nil)
+	router.ServeHTTP(w, req)
+	require.NotEqual(t, http.StatusOK, w.Code)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/migrate"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/p2p"
+	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/pingcap/tiflow/pkg/version"
@@ -44,9 +45,15 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
 )
 
-const cleanMetaDuration = 10 * time.Second
+const (
+	cleanMetaDuration = 10 * time.Second
+	// drainCheckInterval is the interval of checking whether tables of a
+	// draining capture are handed off.
+	drainCheckInterval = time.Second
+)
 
 // Capture represents a Capture server, it monitors the changefeed
 // information in etcd and schedules Task on it.
@@ -66,6 +73,8 @@ type Capture interface {
 
 	GetUpstreamManager() (*upstream.Manager, error)
 	GetEtcdClient() etcd.CDCEtcdClient
+	// GetSortEngineFactory returns the factory of sort engines of the capture.
+	GetSortEngineFactory() *factory.SortEngineFactory
 	// IsReady returns if the cdc server is ready
 	// currently only check if ettcd data migration is done
 	IsReady() bool
@@ -83,6 +92,11 @@ type captureImpl struct {
 	ownerMu         sync.Mutex
 	owner           owner.Owner
 	upstreamManager *upstream.Manager
+	// clockSkewGuard is nil if the clock skew guard is disabled.
+	clockSkewGuard *pdutil.ClockSkewGuard
+	// ownerlessSince is the time the capture finds the cluster has no owner
+	// while it refuses ownership. It's only accessed by campaignOwner.
+	ownerlessSince time.Time
 
 	// session keeps alive between the capture and etcd
 	session  *concurrency.Session
@@ -179,6 +193,11 @@ func (c *captureImpl) GetEtcdClient() etcd.CDCEtcdClient {
 	return c.EtcdClient
 }
 
+// GetSortEngineFactory implements Capture interface.
+func (c *captureImpl) GetSortEngineFactory() *factory.SortEngineFactory {
+	return c.sortEngineFactory
+}
+
 // reset the capture before run it.
 func (c *captureImpl) reset(ctx context.Context) error {
 	lease, err := c.EtcdClient.GetEtcdClient().Grant(ctx, int64(c.config.CaptureSessionTTL))
@@ -198,16 +217,23 @@ func (c *captureImpl) reset(ctx context.Context) error {
 		ID:            uuid.New().String(),
 		AdvertiseAddr: c.config.AdvertiseAddr,
 		Version:       version.ReleaseVersion,
+		Labels:        c.config.Labels,
 	}
 
 	if c.upstreamManager != nil {
 		c.upstreamManager.Close()
 	}
 	c.upstreamManager = upstream.NewManager(ctx, c.EtcdClient.GetGCServiceID())
-	_, err = c.upstreamManager.AddDefaultUpstream(c.pdEndpoints, c.config.Security)
+	up, err := c.upstreamManager.AddDefaultUpstream(c.pdEndpoints, c.config.Security)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	c.clockSkewGuard = nil
+	if guardConfig := c.config.ClockSkewGuard; guardConfig.Enable {
+		c.clockSkewGuard = pdutil.NewClockSkewGuard(up.TSOClient,
+			time.Duration(guardConfig.CheckInterval),
+			time.Duration(guardConfig.MaxClockSkew), c.stopOwner)
+	}
 
 	c.processorManager = c.newProcessorManager(
 		c.info, c.upstreamManager, &c.liveness, c.config.Debug.Scheduler)
@@ -235,6 +261,11 @@ func (c *captureImpl) reset(ctx context.Context) error {
 	// node the connections are from.
 	advertiseAddr := c.config.AdvertiseAddr
 	messageClientConfig.AdvertisedAddr = advertiseAddr
+	// The gRPC config is read on each connection, so that the reloaded
+	// config takes effect once the clients reconnect.
+	messageClientConfig.DialOptions = func() []grpc.DialOption {
+		return config.GetGlobalServerConfig().GRPC.P2P.ToDialOptions()
+	}
 
 	c.MessageRouter = p2p.NewMessageRouterWithLocalClient(c.info.ID, c.config.Security, messageClientConfig)
 
@@ -360,6 +391,13 @@ func (c *captureImpl) run(stdCtx context.Context) error {
 		return c.MessageServer.Run(ctx, c.MessageRouter.GetLocalChannel())
 	})
 
+	if c.clockSkewGuard != nil {
+		g.Go(func() error {
+			c.clockSkewGuard.Run(ctx)
+			return nil
+		})
+	}
+
 	return errors.Trace(g.Wait())
 }
 
@@ -402,6 +440,11 @@ func (c *captureImpl) campaignOwner(ctx cdcContext.Context) error {
 				zap.String("captureID", c.info.ID))
 			return nil
 		}
+		// Do not campaign owner while the clock skew exceeds the threshold,
+		// the guard logs the state changes.
+		if c.refuseOwnership(ctx) {
+			continue
+		}
 		// Campaign to be the owner, it blocks until it been elected.
 		if err := c.campaign(ctx); err != nil {
 
@@ -429,6 +472,17 @@ func (c *captureImpl) campaignOwner(ctx cdcContext.Context) error {
 			}
 			return nil
 		}
+		// The clock skew may exceed the threshold during the campaign.
+		if c.refuseOwnership(ctx) {
+			log.Info("resign owner actively, clock skew exceeds the threshold",
+				zap.String("captureID", c.info.ID))
+			if resignErr := c.resign(ctx); resignErr != nil {
+				log.Warn("resign owner actively failed",
+					zap.String("captureID", c.info.ID), zap.Error(resignErr))
+				return errors.Trace(resignErr)
+			}
+			continue
+		}
 
 		ownerRev, err := c.EtcdClient.GetOwnerRevision(ctx, c.info.ID)
 		if err != nil {
@@ -475,6 +529,9 @@ func (c *captureImpl) campaignOwner(ctx cdcContext.Context) error {
 			ownerFlushInterval, util.RoleOwner.String())
 		c.owner.AsyncStop()
 		c.setOwner(nil)
+		// Other captures are waited for again before the capture campaigns,
+		// if it refuses ownership.
+		c.ownerlessSince = time.Time{}
 
 		// if owner exits, resign the owner key,
 		// use a new context to prevent the context from being cancelled.
@@ -550,6 +607,79 @@ func (c *captureImpl) setOwner(owner owner.Owner) {
 	c.owner = owner
 }
 
+// stopOwner stops the owner if the capture is the owner and there are other
+// captures to take over. The campaign loop resigns the owner key after the
+// owner exits.
+func (c *captureImpl) stopOwner() {
+	c.ownerMu.Lock()
+	isOwner := c.owner != nil
+	c.ownerMu.Unlock()
+	if !isOwner {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if hasOthers, err := c.hasOtherCaptures(ctx); err != nil || !hasOthers {
+		log.Warn("clock skew exceeds the threshold, but no other capture can "+
+			"take over, do not stop owner",
+			zap.String("captureID", c.info.ID), zap.Error(err))
+		return
+	}
+	c.ownerMu.Lock()
+	defer c.ownerMu.Unlock()
+	if c.owner != nil {
+		log.Info("stop owner, clock skew exceeds the threshold",
+			zap.String("captureID", c.info.ID))
+		c.owner.AsyncStop()
+	}
+}
+
+func (c *captureImpl) clockSkewExceeded() bool {
+	return c.clockSkewGuard != nil && c.clockSkewGuard.Exceeded()
+}
+
+// refuseOwnership returns true if the capture should not be the owner as its
+// clock skew exceeds the threshold. To never leave the cluster without an
+// owner, it does not refuse if it's the only capture, or if no other capture
+// becomes the owner within a check interval of the guard, e.g. the clocks of
+// all captures skew.
+func (c *captureImpl) refuseOwnership(ctx context.Context) bool {
+	if !c.clockSkewExceeded() {
+		c.ownerlessSince = time.Time{}
+		return false
+	}
+	hasOthers, err := c.hasOtherCaptures(ctx)
+	if err != nil {
+		log.Warn("get captures failed, do not refuse ownership",
+			zap.String("captureID", c.info.ID), zap.Error(err))
+		return false
+	}
+	if !hasOthers {
+		return false
+	}
+	ownerID, err := c.EtcdClient.GetOwnerID(ctx)
+	if err != nil && errors.Cause(err) != concurrency.ErrElectionNoLeader {
+		log.Warn("get owner failed, do not refuse ownership",
+			zap.String("captureID", c.info.ID), zap.Error(err))
+		return false
+	}
+	if err == nil && ownerID != c.info.ID {
+		c.ownerlessSince = time.Time{}
+		return true
+	}
+	if c.ownerlessSince.IsZero() {
+		c.ownerlessSince = time.Now()
+	}
+	if time.Since(c.ownerlessSince) < time.Duration(c.config.ClockSkewGuard.CheckInterval) {
+		return true
+	}
+	log.Warn("clock skew exceeds the threshold, but no other capture becomes "+
+		"the owner, do not refuse ownership",
+		zap.String("captureID", c.info.ID),
+		zap.Duration("ownerlessDuration", time.Since(c.ownerlessSince)))
+	return false
+}
+
 // GetOwner returns owner if it is the owner.
 func (c *captureImpl) GetOwner() (owner.Owner, error) {
 	c.ownerMu.Lock()
@@ -621,10 +751,15 @@ func (c *captureImpl) Close() {
 	log.Info("message router closed", zap.String("captureID", c.info.ID))
 }
 
-// Drain removes tables in the current TiCDC instance.
+// Drain removes tables in the current TiCDC instance. It does the following things:
+// 1. sets the liveness to stopping, so that owner drains tables of the capture,
+// 2. resigns the ownership if the capture is the owner,
+// 3. waits until all tables are handed off to other captures, or the
+// graceful shutdown timeout is reached.
 func (c *captureImpl) Drain() <-chan struct{} {
 	done := make(chan struct{})
 	go func() {
+		defer close(done)
 		// Set liveness stopping first, no matter is the owner or not.
 		// this is triggered by user manually stop the TiCDC instance by sent signals.
 		// It may cost a few seconds before cdc server fully stop, set it to `stopping` to prevent
@@ -635,11 +770,112 @@ func (c *captureImpl) Drain() <-chan struct{} {
 		if o, _ := c.GetOwner(); o != nil {
 			o.AsyncStop()
 		}
-		close(done)
+
+		timeout := time.Duration(c.config.GracefulShutdownTimeout)
+		if timeout <= 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		c.waitTablesHandedOff(ctx)
 	}()
 	return done
 }
 
+// waitTablesHandedOff waits until the capture has no table, or ctx is done.
+func (c *captureImpl) waitTablesHandedOff(ctx context.Context) {
+	start := time.Now()
+	captureID := c.info.ID
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	tableCount := -1
+	for {
+		count, err := c.queryTableCount(ctx)
+		if err != nil {
+			log.Warn("query table count failed when draining capture",
+				zap.String("captureID", captureID), zap.Error(err))
+		} else {
+			tableCount = count
+			drainingTableCountGauge.Set(float64(tableCount))
+			if tableCount == 0 {
+				drainDuration.Observe(time.Since(start).Seconds())
+				log.Info("all tables are handed off, capture is drained",
+					zap.String("captureID", captureID),
+					zap.Duration("duration", time.Since(start)))
+				return
+			}
+			hasOthers, err := c.hasOtherCaptures(ctx)
+			if err != nil {
+				// Keep waiting, the timeout guarantees that the capture exits.
+				log.Warn("get captures failed when draining capture",
+					zap.String("captureID", captureID), zap.Error(err))
+			} else if !hasOthers {
+				log.Warn("no other capture to hand off tables, stop draining",
+					zap.String("captureID", captureID),
+					zap.Int("tableCount", tableCount))
+				return
+			}
+			log.Info("waiting for tables to be handed off",
+				zap.String("captureID", captureID),
+				zap.Int("tableCount", tableCount),
+				zap.Duration("duration", time.Since(start)))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warn("graceful shutdown timeout, tables are not all handed off",
+				zap.String("captureID", captureID),
+				zap.Int("tableCount", tableCount),
+				zap.Duration("duration", time.Since(start)))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// queryTableCount returns the number of tables replicated by the capture.
+func (c *captureImpl) queryTableCount(ctx context.Context) (int, error) {
+	c.captureMu.Lock()
+	processorManager := c.processorManager
+	c.captureMu.Unlock()
+	if processorManager == nil {
+		return 0, nil
+	}
+
+	tableCh := make(chan int, 1)
+	done := make(chan error, 1)
+	processorManager.QueryTableCount(ctx, tableCh, done)
+	select {
+	case <-ctx.Done():
+		return 0, errors.Trace(ctx.Err())
+	case err, ok := <-done:
+		if ok && err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	select {
+	case count := <-tableCh:
+		return count, nil
+	default:
+		return 0, errors.New("table count is not received")
+	}
+}
+
+// hasOtherCaptures returns true if there are other captures in the cluster
+// which can take over tables or ownership of the capture.
+func (c *captureImpl) hasOtherCaptures(ctx context.Context) (bool, error) {
+	_, captures, err := c.EtcdClient.GetCaptures(ctx)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, info := range captures {
+		if info.ID != c.info.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Liveness returns liveness of the capture.
 func (c *captureImpl) Liveness() model.Liveness {
 	return c.liveness.Load()
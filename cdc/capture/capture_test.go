@@ -16,6 +16,7 @@ package capture
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,10 +28,13 @@ import (
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
 	"github.com/pingcap/tiflow/pkg/etcd"
 	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
+	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -150,6 +154,11 @@ func TestDrainCaptureBySignal(t *testing.T) {
 	}
 	require.Equal(t, model.LivenessCaptureAlive, cp.Liveness())
 
+	mm.EXPECT().QueryTableCount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, tableCh chan int, done chan<- error) {
+			tableCh <- 0
+			close(done)
+		})
 	done := cp.Drain()
 	select {
 	case <-done:
@@ -179,6 +188,11 @@ func TestDrainWaitsOwnerResign(t *testing.T) {
 	require.Equal(t, model.LivenessCaptureAlive, cp.Liveness())
 
 	mo.EXPECT().AsyncStop().Do(func() {}).AnyTimes()
+	mm.EXPECT().QueryTableCount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, tableCh chan int, done chan<- error) {
+			tableCh <- 0
+			close(done)
+		})
 
 	done := cp.Drain()
 	select {
@@ -189,6 +203,78 @@ func TestDrainWaitsOwnerResign(t *testing.T) {
 	}
 }
 
+func TestDrainWaitsTablesHandedOff(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mm := mock_processor.NewMockManager(ctrl)
+	me := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	cfg := config.GetDefaultServerConfig()
+	cp := &captureImpl{
+		info: &model.CaptureInfo{
+			ID:            "capture-for-test",
+			AdvertiseAddr: "127.0.0.1", Version: "test",
+		},
+		processorManager: mm,
+		config:           cfg,
+		EtcdClient:       me,
+	}
+
+	// Tables are handed off in the second check.
+	tableCounts := []int{2, 0}
+	mm.EXPECT().QueryTableCount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, tableCh chan int, done chan<- error) {
+			tableCh <- tableCounts[0]
+			tableCounts = tableCounts[1:]
+			close(done)
+		}).Times(2)
+	me.EXPECT().GetCaptures(gomock.Any()).Return(int64(0), []*model.CaptureInfo{
+		cp.info, {ID: "other"},
+	}, nil)
+	select {
+	case <-cp.Drain():
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	// Stop draining if there are no other captures.
+	mm.EXPECT().QueryTableCount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, tableCh chan int, done chan<- error) {
+			tableCh <- 2
+			close(done)
+		})
+	me.EXPECT().GetCaptures(gomock.Any()).Return(int64(0), []*model.CaptureInfo{cp.info}, nil)
+	select {
+	case <-cp.Drain():
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	// Stop draining once the timeout is reached.
+	cfg.GracefulShutdownTimeout = config.TomlDuration(100 * time.Millisecond)
+	mm.EXPECT().QueryTableCount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, tableCh chan int, done chan<- error) {
+			tableCh <- 2
+			close(done)
+		})
+	me.EXPECT().GetCaptures(gomock.Any()).Return(int64(0), []*model.CaptureInfo{
+		cp.info, {ID: "other"},
+	}, nil)
+	select {
+	case <-cp.Drain():
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	// Exit without waiting if the timeout is 0.
+	cfg.GracefulShutdownTimeout = 0
+	select {
+	case <-cp.Drain():
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout")
+	}
+}
+
 type mockElection struct {
 	campaignRequestCh chan struct{}
 	campaignGrantCh   chan struct{}
@@ -246,3 +332,140 @@ func TestCampaignLiveness(t *testing.T) {
 
 	wg.Wait()
 }
+
+type skewedPDClient struct {
+	pd.Client
+	skew atomic.Int64
+}
+
+func (c *skewedPDClient) GetTS(ctx context.Context) (int64, int64, error) {
+	return time.Now().Add(-time.Duration(c.skew.Load())).UnixMilli(), 0, nil
+}
+
+type ownerEtcdClient struct {
+	etcd.CDCEtcdClient
+	mu       sync.Mutex
+	captures []*model.CaptureInfo
+	ownerID  model.CaptureID
+}
+
+func (c *ownerEtcdClient) set(ownerID model.CaptureID, captureIDs ...model.CaptureID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ownerID = ownerID
+	c.captures = c.captures[:0]
+	for _, id := range captureIDs {
+		c.captures = append(c.captures, &model.CaptureInfo{ID: id})
+	}
+}
+
+func (c *ownerEtcdClient) GetCaptures(context.Context) (int64, []*model.CaptureInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return 0, c.captures, nil
+}
+
+func (c *ownerEtcdClient) GetOwnerID(context.Context) (model.CaptureID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ownerID == "" {
+		return "", concurrency.ErrElectionNoLeader
+	}
+	return c.ownerID, nil
+}
+
+func TestCampaignClockSkew(t *testing.T) {
+	t.Parallel()
+
+	me := &mockElection{
+		campaignRequestCh: make(chan struct{}, 1),
+		campaignGrantCh:   make(chan struct{}, 1),
+	}
+	pdClient := &skewedPDClient{}
+	pdClient.skew.Store(int64(time.Minute))
+	guard := pdutil.NewClockSkewGuard(pdutil.NewTSOClient(pdClient),
+		10*time.Millisecond, time.Second, nil)
+	etcdClient := &ownerEtcdClient{}
+	etcdClient.set("other", "test", "other")
+	cp := &captureImpl{
+		config:         config.GetDefaultServerConfig().Clone(),
+		info:           &model.CaptureInfo{ID: "test"},
+		election:       me,
+		clockSkewGuard: guard,
+		EtcdClient:     etcdClient,
+	}
+	ctx := cdcContext.NewContext4Test(context.Background(), true)
+	guardCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go guard.Run(guardCtx)
+	require.Eventually(t, guard.Exceeded, 5*time.Second, 10*time.Millisecond)
+
+	// Do not campaign while the clock skew exceeds the threshold.
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cp.campaignOwner(ctx)
+	}()
+	select {
+	case <-me.campaignRequestCh:
+		require.Fail(t, "unexpected campaign")
+	case <-time.After(1500 * time.Millisecond):
+	}
+	cp.liveness.Store(model.LivenessCaptureStopping)
+	require.Nil(t, <-errCh)
+	require.False(t, me.campaignFlag)
+
+	// Resign if the clock skew exceeds the threshold during the campaign.
+	pdClient.skew.Store(0)
+	require.Eventually(t, func() bool { return !guard.Exceeded() },
+		5*time.Second, 10*time.Millisecond)
+	cp.liveness = model.LivenessCaptureAlive
+	go func() {
+		errCh <- cp.campaignOwner(ctx)
+	}()
+	g := <-me.campaignRequestCh
+	pdClient.skew.Store(int64(time.Minute))
+	require.Eventually(t, guard.Exceeded, 5*time.Second, 10*time.Millisecond)
+	etcdClient.set("test", "test", "other")
+	me.campaignGrantCh <- g
+	// Campaign again after the clock skew recovers.
+	require.Eventually(t, func() bool { return me.resignFlag },
+		5*time.Second, 10*time.Millisecond)
+	etcdClient.set("", "test", "other")
+	pdClient.skew.Store(0)
+	g = <-me.campaignRequestCh
+	cp.liveness.Store(model.LivenessCaptureStopping)
+	me.campaignGrantCh <- g
+	require.Nil(t, <-errCh)
+	require.True(t, me.campaignFlag)
+	require.True(t, me.resignFlag)
+
+	// The only capture campaigns even if the clock skew exceeds the
+	// threshold.
+	pdClient.skew.Store(int64(time.Minute))
+	require.Eventually(t, guard.Exceeded, 5*time.Second, 10*time.Millisecond)
+	etcdClient.set("", "test")
+	cp.liveness = model.LivenessCaptureAlive
+	go func() {
+		errCh <- cp.campaignOwner(ctx)
+	}()
+	g = <-me.campaignRequestCh
+	cp.liveness.Store(model.LivenessCaptureStopping)
+	me.campaignGrantCh <- g
+	require.Nil(t, <-errCh)
+
+	// Campaign if no other capture becomes the owner within a check
+	// interval.
+	cp.config.ClockSkewGuard.CheckInterval = config.TomlDuration(500 * time.Millisecond)
+	etcdClient.set("", "test", "other")
+	cp.liveness = model.LivenessCaptureAlive
+	start := time.Now()
+	go func() {
+		errCh <- cp.campaignOwner(ctx)
+	}()
+	g = <-me.campaignRequestCh
+	require.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond)
+	etcdClient.set("test", "test", "other")
+	cp.liveness.Store(model.LivenessCaptureStopping)
+	me.campaignGrantCh <- g
+	require.Nil(t, <-errCh)
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	drainingTableCountGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "capture",
+			Name:      "draining_table_count",
+			Help:      "The number of tables not handed off yet by a shutting down capture",
+		})
+
+	drainDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "capture",
+			Name:      "drain_duration_seconds",
+			Help:      "Bucketed histogram of the time (s) of handing off tables when shutting down",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 10), // 0.5s ~ 256s
+		})
+)
+
+// InitMetrics registers all metrics in this file.
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(drainingTableCountGauge)
+	registry.MustRegister(drainDuration)
+}
@@ -12,6 +12,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/pingcap/tiflow/cdc/model"
 	owner "github.com/pingcap/tiflow/cdc/owner"
+	factory "github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/factory"
 	etcd "github.com/pingcap/tiflow/pkg/etcd"
 	upstream "github.com/pingcap/tiflow/pkg/upstream"
 )
@@ -109,6 +110,20 @@ func (mr *MockCaptureMockRecorder) GetOwnerCaptureInfo(ctx interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerCaptureInfo", reflect.TypeOf((*MockCapture)(nil).GetOwnerCaptureInfo), ctx)
 }
 
+// GetSortEngineFactory mocks base method.
+func (m *MockCapture) GetSortEngineFactory() *factory.SortEngineFactory {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSortEngineFactory")
+	ret0, _ := ret[0].(*factory.SortEngineFactory)
+	return ret0
+}
+
+// GetSortEngineFactory indicates an expected call of GetSortEngineFactory.
+func (mr *MockCaptureMockRecorder) GetSortEngineFactory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSortEngineFactory", reflect.TypeOf((*MockCapture)(nil).GetSortEngineFactory))
+}
+
 // GetUpstreamManager mocks base method.
 func (m *MockCapture) GetUpstreamManager() (*upstream.Manager, error) {
 	m.ctrl.T.Helper()
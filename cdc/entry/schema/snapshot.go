@@ -16,6 +16,7 @@ package schema
 import (
 	"fmt"
 	"math"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -813,6 +814,9 @@ func (s *snapshot) replaceTable(tbInfo *model.TableInfo, currentTs uint64) error
 
 func (s *snapshot) doCreateTable(tbInfo *model.TableInfo, currentTs uint64) {
 	tbInfo = tbInfo.Clone()
+	if prev, ok := s.physicalTableByID(tbInfo.ID); ok {
+		internTableInfo(prev, tbInfo)
+	}
 	tag := negative(currentTs)
 	vid := newVersionedID(tbInfo.ID, tag)
 	vid.target = tbInfo
@@ -1242,6 +1246,26 @@ func (s *snapshot) drop() {
 	}
 }
 
+// internTableInfo makes tbInfo share the columns and indices with the previous
+// version of the table if they are not changed, so that versions kept before
+// GC don't hold duplicated metadata. Both must be READ-ONLY after that.
+func internTableInfo(prev, tbInfo *model.TableInfo) {
+	for i, col := range tbInfo.Columns {
+		if prevCol, ok := prev.GetColumnInfo(col.ID); ok && reflect.DeepEqual(prevCol, col) {
+			tbInfo.Columns[i] = prevCol
+		}
+	}
+	prevIndices := make(map[int64]*timodel.IndexInfo, len(prev.Indices))
+	for _, idx := range prev.Indices {
+		prevIndices[idx.ID] = idx
+	}
+	for i, idx := range tbInfo.Indices {
+		if prevIdx, ok := prevIndices[idx.ID]; ok && reflect.DeepEqual(prevIdx, idx) {
+			tbInfo.Indices[i] = prevIdx
+		}
+	}
+}
+
 // Entity(schema or table) name with finish timestamp of the associated DDL job.
 type versionedEntityName struct {
 	prefix int64 // schema ID if the entity is a table, or -1 if it's a schema.
@@ -359,3 +359,97 @@ func newTbInfo(schemaID int64, schemaName string, tableID int64) *model.TableInf
 		},
 	}
 }
+
+func TestInternTableInfo(t *testing.T) {
+	snap := NewEmptySnapshot(true)
+	require.Nil(t, snap.inner.createSchema(newDBInfo(1), 100))
+
+	newColumns := func(names ...string) []*timodel.ColumnInfo {
+		cols := make([]*timodel.ColumnInfo, 0, len(names))
+		for i, name := range names {
+			cols = append(cols, &timodel.ColumnInfo{
+				ID:     int64(i + 1),
+				Name:   timodel.CIStr{O: name, L: name},
+				Offset: i,
+				State:  timodel.StatePublic,
+			})
+		}
+		return cols
+	}
+	tbInfo := newTbInfo(1, "DB_1", 11)
+	tbInfo.Columns = newColumns("a", "b")
+	tbInfo.Indices = []*timodel.IndexInfo{{ID: 1, Name: timodel.CIStr{O: "idx"}}}
+	require.Nil(t, snap.inner.createTable(model.WrapTableInfo(1, "DB_1", 100, tbInfo.TableInfo), 100))
+	v1, ok := snap.inner.physicalTableByID(11)
+	require.True(t, ok)
+
+	// Column b is renamed, column a and the index are shared with the
+	// previous version.
+	tbInfo = newTbInfo(1, "DB_1", 11)
+	tbInfo.Columns = newColumns("a", "c")
+	tbInfo.Indices = []*timodel.IndexInfo{{ID: 1, Name: timodel.CIStr{O: "idx"}}}
+	require.Nil(t, snap.inner.replaceTable(model.WrapTableInfo(1, "DB_1", 110, tbInfo.TableInfo), 110))
+	v2, ok := snap.inner.physicalTableByID(11)
+	require.True(t, ok)
+	require.NotSame(t, v1, v2)
+	require.Same(t, v1.Columns[0], v2.Columns[0])
+	require.NotSame(t, v1.Columns[1], v2.Columns[1])
+	require.Equal(t, "b", v1.Columns[1].Name.O)
+	require.Equal(t, "c", v2.Columns[1].Name.O)
+	require.Same(t, v1.Indices[0], v2.Indices[0])
+}
+
+func TestInternedTableInfoReadOnly(t *testing.T) {
+	snap := NewEmptySnapshot(true)
+	require.Nil(t, snap.inner.createSchema(newDBInfo(1), 100))
+
+	tbInfo := newTbInfo(1, "DB_1", 11).TableInfo
+	tbInfo.Partition = nil
+	tbInfo.Columns = []*timodel.ColumnInfo{
+		{ID: 1, Name: timodel.NewCIStr("a"), Offset: 0, State: timodel.StatePublic},
+		{ID: 2, Name: timodel.NewCIStr("b"), Offset: 1, State: timodel.StatePublic},
+	}
+	tbInfo.Indices = []*timodel.IndexInfo{{ID: 1, Name: timodel.NewCIStr("idx")}}
+	job := func(tp timodel.ActionType, ts uint64) *timodel.Job {
+		return &timodel.Job{
+			Type:       tp,
+			SchemaID:   1,
+			SchemaName: "DB_1",
+			TableID:    11,
+			BinlogInfo: &timodel.HistoryInfo{TableInfo: tbInfo.Clone(), FinishedTS: ts},
+		}
+	}
+
+	// Versions of the table with their deep copies taken when they're
+	// created, changes of the table must not be written to them.
+	type version struct{ info, clone *model.TableInfo }
+	var versions []version
+	apply := func(job *timodel.Job) {
+		require.Nil(t, snap.DoHandleDDL(job))
+		// The table info in the job is owned by the caller.
+		job.BinlogInfo.TableInfo.Columns[0].Comment = "changed"
+		info, ok := snap.PhysicalTableByID(11)
+		require.True(t, ok)
+		versions = append(versions, version{info: info, clone: info.Clone()})
+	}
+	apply(job(timodel.ActionCreateTable, 110))
+	tbInfo.Columns[1].Comment = "modified"
+	apply(job(timodel.ActionModifyColumn, 120))
+	tbInfo.Columns = append(tbInfo.Columns, &timodel.ColumnInfo{
+		ID: 3, Name: timodel.NewCIStr("c"), Offset: 2, State: timodel.StatePublic,
+	})
+	apply(job(timodel.ActionAddColumn, 130))
+	tbInfo.Indices[0].Name = timodel.NewCIStr("idx2")
+	apply(job(timodel.ActionRenameIndex, 140))
+
+	require.Same(t, versions[0].info.Columns[0], versions[3].info.Columns[0])
+	require.Same(t, versions[1].info.Columns[1], versions[2].info.Columns[1])
+	require.Same(t, versions[0].info.Indices[0], versions[2].info.Indices[0])
+	for _, v := range versions {
+		require.Equal(t, v.clone.TableInfo, v.info.TableInfo)
+		require.Empty(t, v.info.Columns[0].Comment)
+	}
+	require.Equal(t, "b", versions[0].info.Columns[1].Name.O)
+	require.Empty(t, versions[0].info.Columns[1].Comment)
+	require.Equal(t, "idx", versions[2].info.Indices[0].Name.O)
+}
@@ -74,4 +74,9 @@ func RegisterRoutes(
 	// Promtheus metrics API
 	prometheus.DefaultGatherer = registry
 	router.Any("/metrics", gin.WrapH(promhttp.Handler()))
+	// Metrics summary API, which is the same as /api/v2/metrics/summary
+	router.GET("/metrics/summary", func(c *gin.Context) {
+		c.Request.URL.Path = "/api/v2/metrics/summary"
+		router.HandleContext(c)
+	})
 }
@@ -54,16 +54,6 @@ const (
 	dialTimeout           = 10 * time.Second
 	tikvRequestMaxBackoff = 20000 // Maximum total sleep time(in ms)
 
-	// TiCDC may open numerous gRPC streams,
-	// with 65535 bytes window size, 10K streams takes about 27GB memory.
-	//
-	// 65535 bytes, the initial window size in http2 spec.
-	grpcInitialWindowSize = (1 << 16) - 1
-	// 8 MB The value for initial window size on a connection
-	grpcInitialConnWindowSize = 1 << 23
-	// 256 MB The maximum message size the client can receive
-	grpcMaxCallRecvMsgSize = 1 << 28
-
 	// The threshold of warning a message is too large. TiKV split events into 6MB per-message.
 	warnRecvMsgSizeThreshold = 12 * 1024 * 1024
 
@@ -248,7 +238,9 @@ func (c *CDCClient) newStream(ctx context.Context, addr string, storeID uint64)
 			zap.String("changefeed", c.changefeed.ID),
 			zap.String("addr", addr))
 		return nil
-	}, retry.WithBackoffBaseDelay(500),
+	}, retry.WithName("kv_new_stream"),
+		retry.WithBackoffBaseDelay(500),
+		retry.WithDecorrelatedJitter(),
 		retry.WithMaxTries(2),
 		retry.WithIsRetryableErr(cerror.IsRetryableError),
 	)
@@ -352,6 +344,10 @@ type eventFeedSession struct {
 	streamsLock      sync.RWMutex
 	streamsCanceller map[string]context.CancelFunc
 
+	// workers are the running region workers, one for each stream.
+	workers     map[*regionWorker]struct{}
+	workersLock sync.Mutex
+
 	// use sync.Pool to store resolved ts event only, because resolved ts event
 	// has the same size and generate cycle.
 	resolvedTsPool sync.Pool
@@ -389,6 +385,7 @@ func newEventFeedSession(
 		rangeChSizeGauge:  clientChannelSize.WithLabelValues("range"),
 		streams:           make(map[string]*eventFeedStream),
 		streamsCanceller:  make(map[string]context.CancelFunc),
+		workers:           make(map[*regionWorker]struct{}),
 		resolvedTsPool: sync.Pool{
 			New: func() any {
 				return &regionStatefulEvent{
@@ -406,8 +403,10 @@ func (s *eventFeedSession) eventFeed(ctx context.Context) error {
 	s.errCh = chann.NewAutoDrainChann[regionErrorInfo]()
 
 	eventFeedGauge.Inc()
+	registerSession(s)
 	defer func() {
 		eventFeedGauge.Dec()
+		unregisterSession(s)
 		s.regionRouter.CloseAndDrain()
 		s.regionCh.CloseAndDrain()
 		s.errCh.CloseAndDrain()
@@ -834,7 +833,9 @@ func (s *eventFeedSession) divideAndSendEventFeedToRegions(
 				return cerror.ErrRegionsNotCoverSpan.FastGenByArgs(nextSpan, metas)
 			}
 			return nil
-		}, retry.WithBackoffMaxDelay(500),
+		}, retry.WithName("kv_load_regions"),
+			retry.WithBackoffMaxDelay(500),
+			retry.WithDecorrelatedJitter(),
 			retry.WithTotalRetryDuratoin(time.Duration(s.client.config.RegionRetryDuration)))
 		if retryErr != nil {
 			log.Warn("load regions failed",
@@ -999,10 +1000,18 @@ func (s *eventFeedSession) receiveFromStream(
 
 	metricSendEventBatchResolvedSize := batchResolvedEventSize.
 		WithLabelValues(s.changefeed.Namespace, s.changefeed.ID)
+	metricRegionCount := regionCountGauge.
+		WithLabelValues(s.changefeed.Namespace, s.changefeed.ID)
+	// regions of the stream are not captured anymore after it's closed.
+	defer func() {
+		metricRegionCount.Sub(float64(tsStat.regionCount.Swap(0)))
+	}()
 
 	// always create a new region worker, because `receiveFromStream` is ensured
 	// to call exactly once from outer code logic
 	worker := newRegionWorker(s.changefeed, s, addr)
+	s.addWorker(worker)
+	defer s.deleteWorker(worker)
 
 	defer worker.evictAllRegions()
 
@@ -1106,7 +1115,8 @@ func (s *eventFeedSession) receiveFromStream(
 			// NOTE(qupeng): what if all regions are removed from the store?
 			// TiKV send resolved ts events every second by default.
 			// We check and update region count here to save CPU.
-			tsStat.regionCount.Store(uint64(worker.statesManager.regionCount()))
+			regionCount := uint64(worker.statesManager.regionCount())
+			metricRegionCount.Add(float64(regionCount) - float64(tsStat.regionCount.Swap(regionCount)))
 			tsStat.resolvedTs.Store(cevent.ResolvedTs.Ts)
 			if maxCommitTs == 0 {
 				// In case, there is no write for the table,
@@ -1279,6 +1289,18 @@ func (s *eventFeedSession) getStreamCancel(storeAddr string) (cancel context.Can
 	return
 }
 
+func (s *eventFeedSession) addWorker(worker *regionWorker) {
+	s.workersLock.Lock()
+	defer s.workersLock.Unlock()
+	s.workers[worker] = struct{}{}
+}
+
+func (s *eventFeedSession) deleteWorker(worker *regionWorker) {
+	s.workersLock.Lock()
+	defer s.workersLock.Unlock()
+	delete(s.workers, worker)
+}
+
 func assembleRowEvent(regionID uint64, entry *cdcpb.Event_Row) (model.RegionFeedEvent, error) {
 	var opType model.OpType
 	switch entry.GetOpType() {
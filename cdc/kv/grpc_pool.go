@@ -22,6 +22,9 @@ import (
 type sharedConn struct {
 	*grpc.ClientConn
 	active int64
+	// stale is true if the connection is rotated, it's not used by new
+	// streams and is closed once all its streams are released.
+	stale bool
 }
 
 // GrpcPool defines an interface that can serve as a gPRC connection pool.
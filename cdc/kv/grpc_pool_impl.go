@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/security"
 	"go.uber.org/zap"
@@ -45,6 +46,8 @@ type connArray struct {
 
 	mu    sync.Mutex
 	conns []*sharedConn
+	// stale are the rotated connections which still have active streams.
+	stale []*sharedConn
 
 	// next is used for fetching sharedConn in a round-robin way
 	next int
@@ -76,13 +79,9 @@ func createClientConn(ctx context.Context, credential *security.Credential, targ
 	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(
-		ctx,
-		target,
+	grpcConfig := config.GetGlobalServerConfig().GRPC.TiKV
+	opts := append(grpcConfig.ToDialOptions(),
 		grpcTLSOption,
-		grpc.WithInitialWindowSize(grpcInitialWindowSize),
-		grpc.WithInitialConnWindowSize(grpcInitialConnWindowSize),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcMaxCallRecvMsgSize)),
 		grpc.WithUnaryInterceptor(grpcMetrics.UnaryClientInterceptor()),
 		grpc.WithStreamInterceptor(grpcMetrics.StreamClientInterceptor()),
 		grpc.WithConnectParams(grpc.ConnectParams{
@@ -95,11 +94,12 @@ func createClientConn(ctx context.Context, credential *security.Credential, targ
 			MinConnectTimeout: 3 * time.Second,
 		}),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                10 * time.Second,
-			Timeout:             3 * time.Second,
+			Time:                time.Duration(grpcConfig.KeepAliveTime),
+			Timeout:             time.Duration(grpcConfig.KeepAliveTimeout),
 			PermitWithoutStream: true,
 		}),
 	)
+	conn, err := grpc.DialContext(ctx, target, opts...)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrGRPCDialFailed, err)
 	}
@@ -158,7 +158,43 @@ func (ca *connArray) recycle() (empty bool) {
 		ca.conns[j] = nil
 	}
 	ca.conns = ca.conns[:i]
-	return len(ca.conns) == 0
+	return len(ca.conns) == 0 && len(ca.stale) == 0
+}
+
+// rotate marks all connections as stale, so that new streams are created on
+// new connections. Idle connections are closed immediately, others are closed
+// once all their streams are released.
+func (ca *connArray) rotate() {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	for _, conn := range ca.conns {
+		if conn.active > 0 {
+			conn.stale = true
+			ca.stale = append(ca.stale, conn)
+		} else {
+			_ = conn.Close()
+		}
+	}
+	ca.conns = nil
+	ca.next = 0
+}
+
+// release decreases the active count of a connection, and closes it if it's
+// stale and idle.
+func (ca *connArray) release(sc *sharedConn) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	sc.active--
+	if !sc.stale || sc.active > 0 {
+		return
+	}
+	for i, conn := range ca.stale {
+		if conn == sc {
+			ca.stale = append(ca.stale[:i], ca.stale[i+1:]...)
+			break
+		}
+	}
+	_ = sc.Close()
 }
 
 func (ca *connArray) activeCount() (count int64) {
@@ -167,6 +203,9 @@ func (ca *connArray) activeCount() (count int64) {
 	for _, conn := range ca.conns {
 		count += conn.active
 	}
+	for _, conn := range ca.stale {
+		count += conn.active
+	}
 	return
 }
 
@@ -174,7 +213,7 @@ func (ca *connArray) activeCount() (count int64) {
 func (ca *connArray) close() {
 	ca.mu.Lock()
 	defer ca.mu.Unlock()
-	for _, conn := range ca.conns {
+	for _, conn := range append(ca.conns, ca.stale...) {
 		// tear down this grpc.ClientConn, we don't use it anymore, the returned
 		// not-nil error can be ignored
 		_ = conn.Close()
@@ -189,6 +228,8 @@ type GrpcPoolImpl struct {
 	bucketConns map[string]*connArray
 
 	credential *security.Credential
+	// grpcConfig is the gRPC config of TiKV used by the connections.
+	grpcConfig config.GRPCPeerConfig
 
 	// lifecycles of all gPRC connections are bounded to this context
 	ctx context.Context
@@ -198,6 +239,7 @@ type GrpcPoolImpl struct {
 func NewGrpcPoolImpl(ctx context.Context, credential *security.Credential) *GrpcPoolImpl {
 	return &GrpcPoolImpl{
 		credential:  credential,
+		grpcConfig:  *config.GetGlobalServerConfig().GRPC.TiKV,
 		bucketConns: make(map[string]*connArray),
 		ctx:         ctx,
 	}
@@ -220,9 +262,19 @@ func (pool *GrpcPoolImpl) ReleaseConn(sc *sharedConn, addr string) {
 	if bucket, ok := pool.bucketConns[addr]; !ok {
 		log.Warn("resource is not found in grpc pool", zap.String("addr", addr))
 	} else {
-		bucket.mu.Lock()
-		sc.active--
-		bucket.mu.Unlock()
+		bucket.release(sc)
+	}
+}
+
+// Rotate re-establishes connections gracefully, it's called after the
+// credential is changed. New streams are created on new connections, which
+// use the new credential, and existing streams are not interrupted.
+func (pool *GrpcPoolImpl) Rotate() {
+	pool.poolMu.RLock()
+	defer pool.poolMu.RUnlock()
+	for addr, bucket := range pool.bucketConns {
+		bucket.rotate()
+		log.Info("rotate connections in grpc pool", zap.String("address", addr))
 	}
 }
 
@@ -248,6 +300,7 @@ func (pool *GrpcPoolImpl) RecycleConn(ctx context.Context) {
 			}
 			pool.poolMu.Unlock()
 		case <-metricTicker.C:
+			pool.rotateIfConfigChanged()
 			pool.poolMu.RLock()
 			for addr, bucket := range pool.bucketConns {
 				grpcPoolStreamGauge.WithLabelValues(addr).Set(float64(bucket.activeCount()))
@@ -257,6 +310,22 @@ func (pool *GrpcPoolImpl) RecycleConn(ctx context.Context) {
 	}
 }
 
+// rotateIfConfigChanged rotates connections if the gRPC config of TiKV is
+// reloaded, so that new streams use the new config.
+func (pool *GrpcPoolImpl) rotateIfConfigChanged() bool {
+	grpcConfig := *config.GetGlobalServerConfig().GRPC.TiKV
+	pool.poolMu.Lock()
+	changed := grpcConfig != pool.grpcConfig
+	pool.grpcConfig = grpcConfig
+	pool.poolMu.Unlock()
+	if changed {
+		log.Info("grpc config of tikv is changed, rotate connections",
+			zap.Any("config", grpcConfig))
+		pool.Rotate()
+	}
+	return changed
+}
+
 // Close implements GrpcPool.Close
 func (pool *GrpcPoolImpl) Close() {
 	pool.poolMu.Lock()
@@ -16,9 +16,12 @@ package kv
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
 )
 
 // Use clientSuite for some special reasons, the embed etcd uses zap as the only candidate
@@ -103,3 +106,63 @@ func TestConnArrayRecycle(t *testing.T) {
 	require.True(t, empty)
 	require.Len(t, pool.bucketConns[addr].conns, 0)
 }
+
+func TestConnArrayRotate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool := NewGrpcPoolImpl(ctx, &security.Credential{})
+	defer pool.Close()
+	addr := "127.0.0.1:20161"
+
+	active, err := pool.GetConn(addr)
+	require.Nil(t, err)
+	idle, err := pool.GetConn(addr)
+	require.Nil(t, err)
+	require.NotSame(t, active, idle)
+	pool.ReleaseConn(idle, addr)
+
+	pool.Rotate()
+	bucket := pool.bucketConns[addr]
+	require.Empty(t, bucket.conns)
+	require.Equal(t, []*sharedConn{active}, bucket.stale)
+	require.True(t, active.stale)
+	require.Equal(t, int64(1), bucket.activeCount())
+
+	// New streams use new connections.
+	conn, err := pool.GetConn(addr)
+	require.Nil(t, err)
+	require.NotSame(t, active, conn)
+	require.NotSame(t, idle, conn)
+	pool.ReleaseConn(conn, addr)
+
+	// The stale connection is closed once it's released.
+	pool.ReleaseConn(active, addr)
+	require.Empty(t, bucket.stale)
+	require.Equal(t, connectivity.Shutdown, active.GetState())
+	require.True(t, bucket.recycle())
+}
+
+func TestGrpcPoolRotateIfConfigChanged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool := NewGrpcPoolImpl(ctx, &security.Credential{})
+	defer pool.Close()
+	addr := "127.0.0.1:20161"
+	conn, err := pool.GetConn(addr)
+	require.Nil(t, err)
+	require.False(t, pool.rotateIfConfigChanged())
+
+	old := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(old)
+	cfg := old.Clone()
+	cfg.GRPC.TiKV.KeepAliveTime = config.TomlDuration(time.Minute)
+	config.StoreGlobalServerConfig(cfg)
+	require.True(t, pool.rotateIfConfigChanged())
+	require.True(t, conn.stale)
+	require.False(t, pool.rotateIfConfigChanged())
+	pool.ReleaseConn(conn, addr)
+}
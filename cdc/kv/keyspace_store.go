@@ -0,0 +1,127 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"fmt"
+
+	"github.com/pingcap/errors"
+	tidbkv "github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/store/driver/txn"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	tikvconfig "github.com/tikv/client-go/v2/config"
+	"github.com/tikv/client-go/v2/tikv"
+	pd "github.com/tikv/pd/client"
+)
+
+// tikvStorage is a storage implementing both the TiDB and the TiKV storage
+// interfaces, for example a storage created by CreateTiStore.
+type tikvStorage interface {
+	tidbkv.Storage
+	tikv.Storage
+}
+
+// keyspaceStorage is a storage scoped to a keyspace of the upstream cluster.
+// Snapshots are read by a TiKV store using the API V2 codec of the keyspace,
+// other methods are served by the storage of the whole cluster.
+type keyspaceStorage struct {
+	tikvStorage
+	store  *tikv.KVStore
+	prefix []byte
+}
+
+// GetSnapshot implements tidbkv.Storage.
+func (s *keyspaceStorage) GetSnapshot(ver tidbkv.Version) tidbkv.Snapshot {
+	return txn.NewSnapshot(s.store.GetSnapshot(ver.Ver))
+}
+
+// Close closes the keyspace store only, the storage of the whole cluster
+// is shared by all changefeeds of the upstream.
+func (s *keyspaceStorage) Close() error {
+	return s.store.Close()
+}
+
+// nopClosePDClient prevents the keyspace store from closing the PD client
+// shared by all changefeeds of the upstream.
+type nopClosePDClient struct {
+	pd.Client
+}
+
+func (nopClosePDClient) Close() {}
+
+// CreateKeyspaceStore creates a storage scoped to the keyspace based on the
+// storage of the whole upstream cluster, it fails if the keyspace does not
+// exist or is not enabled.
+// The returned storage must be closed by the caller.
+func CreateKeyspaceStore(
+	storage tidbkv.Storage,
+	pdCli pd.Client,
+	pdEndpoints []string,
+	credential *security.Credential,
+	keyspace string,
+) (tidbkv.Storage, error) {
+	base, ok := storage.(tikvStorage)
+	if !ok {
+		return nil, cerror.ErrNewStore.GenWithStack(
+			"can't create keyspace store for non-tikv storage")
+	}
+	pdCli = nopClosePDClient{Client: pdCli}
+	codecCli, err := tikv.NewCodecPDClientWithKeyspace(tikv.ModeTxn, pdCli, keyspace)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrNewStore, err)
+	}
+	tlsConfig, err := credential.ToTLSConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spkv, err := tikv.NewEtcdSafePointKV(pdEndpoints, tlsConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrNewStore, err)
+	}
+	codec := codecCli.GetCodec()
+	rpcClient := tikv.NewRPCClient(
+		tikv.WithSecurity(tikvconfig.Security{
+			ClusterSSLCA:    credential.CAPath,
+			ClusterSSLCert:  credential.CertPath,
+			ClusterSSLKey:   credential.KeyPath,
+			ClusterVerifyCN: credential.CertAllowedCN,
+		}),
+		tikv.WithCodec(codec),
+	)
+	uuid := fmt.Sprintf("%s-keyspace-%d", base.UUID(), codec.GetKeyspaceID())
+	store, err := tikv.NewKVStore(uuid, codecCli, spkv, rpcClient)
+	if err != nil {
+		_ = spkv.Close()
+		_ = rpcClient.Close()
+		return nil, cerror.WrapError(cerror.ErrNewStore, err)
+	}
+	return &keyspaceStorage{
+		tikvStorage: base,
+		store:       store,
+		prefix:      codec.GetKeyspace(),
+	}, nil
+}
+
+// KeyspacePrefix returns the key prefix of the keyspace the storage is scoped
+// to, it returns nil if the storage is not scoped to a keyspace.
+func KeyspacePrefix(storage tidbkv.Storage) []byte {
+	if s, ok := storage.(*resourceGroupStorage); ok {
+		storage = s.tikvStorage
+	}
+	if s, ok := storage.(*keyspaceStorage); ok {
+		return s.prefix
+	}
+	return nil
+}
@@ -86,6 +86,13 @@ var (
 			Name:      "cached_region",
 			Help:      "cached region that has not requested to TiKV in kv client",
 		}, []string{"store", "namespace", "changefeed"})
+	regionCountGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "kvclient",
+			Name:      "region_count",
+			Help:      "The number of regions captured by kv client",
+		}, []string{"namespace", "changefeed"})
 	batchResolvedEventSize = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "ticdc",
@@ -134,6 +141,7 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(clientChannelSize)
 	registry.MustRegister(clientRegionTokenSize)
 	registry.MustRegister(cachedRegionSize)
+	registry.MustRegister(regionCountGauge)
 	registry.MustRegister(batchResolvedEventSize)
 	registry.MustRegister(grpcPoolStreamGauge)
 	registry.MustRegister(regionEventsBatchSize)
@@ -0,0 +1,115 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// RegionSubscription is a snapshot of a region subscribed by the kv client.
+type RegionSubscription struct {
+	RegionID  uint64
+	StoreID   uint64
+	StoreAddr string
+	// ResolvedTs is the resolved ts of the region, the slowest region holds
+	// back the resolved ts of the table.
+	ResolvedTs  uint64
+	Initialized bool
+	// LastEventTime is zero if no event of the region is received.
+	LastEventTime time.Time
+	// PendingScanBytes is the size of the incremental scan received so far,
+	// it's zero once the region is initialized.
+	PendingScanBytes uint64
+}
+
+// sessions are all running event feed sessions of the process.
+var sessions = struct {
+	sync.RWMutex
+	v map[*eventFeedSession]struct{}
+}{v: make(map[*eventFeedSession]struct{})}
+
+func registerSession(s *eventFeedSession) {
+	sessions.Lock()
+	defer sessions.Unlock()
+	sessions.v[s] = struct{}{}
+}
+
+func unregisterSession(s *eventFeedSession) {
+	sessions.Lock()
+	defer sessions.Unlock()
+	delete(sessions.v, s)
+}
+
+// GetRegionSubscriptions returns the regions subscribed for the table by the
+// kv clients of the process, sorted by resolved ts in ascending order.
+func GetRegionSubscriptions(
+	changefeed model.ChangeFeedID, tableID model.TableID,
+) []RegionSubscription {
+	sessions.RLock()
+	defer sessions.RUnlock()
+	regions := make([]RegionSubscription, 0)
+	for s := range sessions.v {
+		if s.changefeed != changefeed || s.totalSpan.TableID != tableID {
+			continue
+		}
+		regions = append(regions, s.regionSubscriptions()...)
+	}
+	sort.Slice(regions, func(i, j int) bool {
+		if regions[i].ResolvedTs != regions[j].ResolvedTs {
+			return regions[i].ResolvedTs < regions[j].ResolvedTs
+		}
+		return regions[i].RegionID < regions[j].RegionID
+	})
+	return regions
+}
+
+func (s *eventFeedSession) regionSubscriptions() []RegionSubscription {
+	s.workersLock.Lock()
+	defer s.workersLock.Unlock()
+	var regions []RegionSubscription
+	for worker := range s.workers {
+		for _, states := range worker.statesManager.states {
+			states.iter(func(_ uint64, state *regionFeedState) bool {
+				if !state.isStopped() {
+					regions = append(regions, state.subscription())
+				}
+				return true
+			})
+		}
+	}
+	return regions
+}
+
+func (s *regionFeedState) subscription() RegionSubscription {
+	sub := RegionSubscription{
+		RegionID:    s.getRegionID(),
+		ResolvedTs:  s.getLastResolvedTs(),
+		Initialized: s.isInitialized(),
+	}
+	if rpcCtx := s.sri.rpcCtx; rpcCtx != nil {
+		sub.StoreID = rpcCtx.Peer.GetStoreId()
+		sub.StoreAddr = rpcCtx.Addr
+	}
+	if t := s.lastEventTime.Load(); t != 0 {
+		sub.LastEventTime = time.Unix(0, t)
+	}
+	if !sub.Initialized {
+		sub.PendingScanBytes = s.scanBytes.Load()
+	}
+	return sub
+}
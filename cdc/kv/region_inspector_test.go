@@ -0,0 +1,95 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tiflow/cdc/kv/regionlock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+func TestGetRegionSubscriptions(t *testing.T) {
+	changefeed := model.DefaultChangeFeedID("test")
+	newState := func(regionID, storeID uint64, resolvedTs uint64) *regionFeedState {
+		state := newRegionFeedState(newSingleRegionInfo(
+			tikv.NewRegionVerID(regionID, 1, 1),
+			tablepb.Span{},
+			&tikv.RPCContext{
+				Addr: "store",
+				Peer: &metapb.Peer{StoreId: storeID},
+			}), regionID)
+		state.sri.lockedRange = &regionlock.LockedRange{}
+		state.updateResolvedTs(resolvedTs)
+		return state
+	}
+	newSession := func(tableID model.TableID, states ...*regionFeedState) *eventFeedSession {
+		s := &eventFeedSession{
+			changefeed: changefeed,
+			totalSpan:  tablepb.Span{TableID: tableID},
+			workers:    make(map[*regionWorker]struct{}),
+		}
+		w := &regionWorker{statesManager: newRegionStateManager(4)}
+		for _, state := range states {
+			w.setRegionState(state.getRegionID(), state)
+		}
+		s.addWorker(w)
+		return s
+	}
+
+	now := time.Now()
+	// An initialized region.
+	s1 := newState(1, 1, 10)
+	s1.setInitialized()
+	s1.onEvent(now, 100)
+	// A region in incremental scan.
+	s2 := newState(2, 2, 5)
+	s2.onEvent(now, 100)
+	s2.onEvent(now, 50)
+	// A stopped region is ignored.
+	s3 := newState(3, 1, 1)
+	s3.markStopped()
+	session1 := newSession(1, s1, s2, s3)
+	// Regions of other tables are ignored.
+	session2 := newSession(2, newState(4, 1, 1))
+	registerSession(session1)
+	registerSession(session2)
+
+	regions := GetRegionSubscriptions(changefeed, 1)
+	require.Equal(t, []RegionSubscription{{
+		RegionID:         2,
+		StoreID:          2,
+		StoreAddr:        "store",
+		ResolvedTs:       5,
+		LastEventTime:    time.Unix(0, now.UnixNano()),
+		PendingScanBytes: 150,
+	}, {
+		RegionID:      1,
+		StoreID:       1,
+		StoreAddr:     "store",
+		ResolvedTs:    10,
+		Initialized:   true,
+		LastEventTime: time.Unix(0, now.UnixNano()),
+	}}, regions)
+	require.Empty(t, GetRegionSubscriptions(model.DefaultChangeFeedID("other"), 1))
+
+	unregisterSession(session1)
+	unregisterSession(session2)
+	require.Empty(t, GetRegionSubscriptions(changefeed, 1))
+}
@@ -62,6 +62,12 @@ type regionFeedState struct {
 	startFeedTime time.Time
 
 	stopped atomic.Bool
+	// lastEventTime is the unix nano time when the last event of the region
+	// is handled.
+	lastEventTime atomic.Int64
+	// scanBytes is the size of entries received before the region is
+	// initialized, i.e. the size of the incremental scan.
+	scanBytes atomic.Uint64
 }
 
 func newRegionFeedState(sri singleRegionInfo, requestID uint64) *regionFeedState {
@@ -119,6 +125,14 @@ func (s *regionFeedState) getRegionMeta() (uint64, tablepb.Span, time.Time, stri
 	return s.sri.verID.GetID(), s.sri.span, s.startFeedTime, s.sri.rpcCtx.Addr
 }
 
+// onEvent records an event of the region which is handled at now.
+func (s *regionFeedState) onEvent(now time.Time, size int) {
+	s.lastEventTime.Store(now.UnixNano())
+	if size > 0 && !s.isInitialized() {
+		s.scanBytes.Add(uint64(size))
+	}
+}
+
 type syncRegionFeedStateMap struct {
 	mu sync.RWMutex
 	// statesInternal is an internal field and must not be accessed from outside.
@@ -360,9 +360,11 @@ func (w *regionWorker) processEvent(ctx context.Context, event *regionStatefulEv
 	}
 	var err error
 	if event.changeEvent != nil {
-		w.metrics.metricReceivedEventSize.Observe(float64(event.changeEvent.Event.Size()))
+		size := event.changeEvent.Event.Size()
+		w.metrics.metricReceivedEventSize.Observe(float64(size))
 		switch x := event.changeEvent.Event.(type) {
 		case *cdcpb.Event_Entries_:
+			event.state.onEvent(time.Now(), size)
 			err = w.handleEventEntry(ctx, x, event.state)
 			if err != nil {
 				err = w.handleSingleRegionError(err, event.state)
@@ -783,11 +785,13 @@ func (w *regionWorker) handleResolvedTs(
 	case w.rtsUpdateCh <- &rtsUpdateEvent{resolvedTs: resolvedTs, regions: regions}:
 	default:
 	}
+	now := time.Now()
 	for _, state := range revents.regions {
 		if state.isStopped() || !state.isInitialized() {
 			continue
 		}
 		state.updateResolvedTs(resolvedTs)
+		state.onEvent(now, 0)
 	}
 	// emit a resolvedTs
 	revent := model.RegionFeedEvent{Resolved: &model.ResolvedSpans{ResolvedTs: resolvedTs, Spans: resolvedSpans}}
@@ -0,0 +1,48 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	tidbkv "github.com/pingcap/tidb/kv"
+)
+
+// resourceGroupStorage tags snapshot reads with a TiDB resource group, so
+// that they are governed by resource control of the upstream cluster.
+type resourceGroupStorage struct {
+	tikvStorage
+	resourceGroup string
+}
+
+// GetSnapshot implements tidbkv.Storage.
+func (s *resourceGroupStorage) GetSnapshot(ver tidbkv.Version) tidbkv.Snapshot {
+	snap := s.tikvStorage.GetSnapshot(ver)
+	snap.SetOption(tidbkv.ResourceGroupName, s.resourceGroup)
+	return snap
+}
+
+// WithResourceGroup returns a storage whose snapshot reads are tagged with the
+// resource group. The storage is returned as is if the resource group is
+// empty or it's not a TiKV storage.
+// Note that the returned storage shares the underlying storage, so closing
+// either of them closes both.
+func WithResourceGroup(storage tidbkv.Storage, resourceGroup string) tidbkv.Storage {
+	if resourceGroup == "" {
+		return storage
+	}
+	base, ok := storage.(tikvStorage)
+	if !ok {
+		return storage
+	}
+	return &resourceGroupStorage{tikvStorage: base, resourceGroup: resourceGroup}
+}
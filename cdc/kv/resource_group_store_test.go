@@ -0,0 +1,41 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"testing"
+
+	tidbkv "github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithResourceGroup(t *testing.T) {
+	t.Parallel()
+
+	store, err := mockstore.NewMockStore()
+	require.Nil(t, err)
+	defer store.Close() //nolint:errcheck
+
+	require.Equal(t, store, WithResourceGroup(store, ""))
+
+	storage := WithResourceGroup(store, "rg1")
+	require.IsType(t, &resourceGroupStorage{}, storage)
+	require.Nil(t, KeyspacePrefix(storage))
+	snap := storage.GetSnapshot(tidbkv.MaxVersion)
+	_, err = snap.Get(tidbkv.WithInternalSourceType(
+		context.Background(), tidbkv.InternalTxnOthers), []byte("k"))
+	require.True(t, tidbkv.ErrNotExist.Equal(err))
+}
@@ -28,6 +28,8 @@ type CaptureInfo struct {
 	ID            CaptureID `json:"id"`
 	AdvertiseAddr string    `json:"address"`
 	Version       string    `json:"version"`
+	// Labels are set by the server config of the capture.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // Marshal using json.Marshal.
@@ -56,3 +58,24 @@ func ListVersionsFromCaptureInfos(captureInfos []*CaptureInfo) []string {
 
 	return captureVersions
 }
+
+// CaptureLoad is the load of a capture, which is reported by the capture in
+// scheduler heartbeats.
+type CaptureLoad struct {
+	// CPUUsage is the CPU usage of the capture process, 100 means one core
+	// is fully used.
+	CPUUsage        float64 `json:"cpu_usage"`
+	MemoryBytes     uint64  `json:"memory_bytes"`
+	SorterDiskBytes uint64  `json:"sorter_disk_bytes"`
+	// Changefeeds are sorted by namespace and ID.
+	Changefeeds []ChangefeedLoad `json:"changefeeds"`
+}
+
+// ChangefeedLoad is the load of a changefeed on a capture.
+type ChangefeedLoad struct {
+	Namespace  string `json:"namespace"`
+	ID         string `json:"id"`
+	TableCount int    `json:"table_count"`
+	// SinkRowsPerSecond is the number of rows written to the sink per second.
+	SinkRowsPerSecond float64 `json:"sink_rows_per_second"`
+}
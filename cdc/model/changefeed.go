@@ -88,6 +88,9 @@ const (
 	StateStopped  FeedState = "stopped"
 	StateRemoved  FeedState = "removed"
 	StateFinished FeedState = "finished"
+	// StateUpstreamRewound means the upstream is rewound by FLASHBACK CLUSTER
+	// or BR restore, the changefeed must be re-anchored at a new start ts.
+	StateUpstreamRewound FeedState = "upstream-rewound"
 )
 
 // ToInt return an int for each `FeedState`, only use this for metrics.
@@ -105,6 +108,8 @@ func (s FeedState) ToInt() int {
 		return 4
 	case StateRemoved:
 		return 5
+	case StateUpstreamRewound:
+		return 6
 	}
 	// -1 for unknown feed state
 	return -1
@@ -123,6 +128,8 @@ func (s FeedState) IsNeeded(need string) bool {
 			return true
 		case StateFailed:
 			return true
+		case StateUpstreamRewound:
+			return true
 		}
 	}
 	return need == string(s)
@@ -135,10 +142,21 @@ type ChangeFeedInfo struct {
 	ID         string    `json:"changefeed-id"`
 	SinkURI    string    `json:"sink-uri"`
 	CreateTime time.Time `json:"create-time"`
+	// Keyspace is the upstream keyspace the changefeed is scoped to, empty
+	// means the changefeed replicates the whole upstream cluster.
+	Keyspace string `json:"keyspace,omitempty"`
 	// Start sync at this commit ts if `StartTs` is specify or using the CreateTime of changefeed.
 	StartTs uint64 `json:"start-ts"`
 	// The ChangeFeed will exits until sync to timestamp TargetTs
 	TargetTs uint64 `json:"target-ts"`
+	// TTL is how long the changefeed lives after it is created. Once it
+	// elapses, the changefeed is finished at that time and removed. A
+	// changefeed with TTL is also removed once it reaches TargetTs.
+	// 0 means the changefeed lives until it is removed manually.
+	TTL time.Duration `json:"ttl,omitempty"`
+	// RemoveTopics removes the topics written by the changefeed once it is
+	// finished because of TTL or TargetTs, only Kafka sinks support it.
+	RemoveTopics bool `json:"remove-topics,omitempty"`
 	// used for admin job notification, trigger watch event in capture
 	AdminJobType AdminJobType `json:"admin-job-type"`
 	Engine       SortEngine   `json:"sort-engine"`
@@ -203,6 +221,13 @@ func (info *ChangeFeedInfo) String() (str string) {
 	if err != nil {
 		log.Error("failed to marshal changefeed info", zap.Error(err))
 	}
+	if clone.Config != nil && clone.Config.Sink != nil && clone.Config.Sink.TeeSinkURI != nil {
+		teeSinkURI, err := util.MaskSinkURI(*clone.Config.Sink.TeeSinkURI)
+		if err != nil {
+			log.Error("failed to marshal changefeed info", zap.Error(err))
+		}
+		clone.Config.Sink.TeeSinkURI = &teeSinkURI
+	}
 
 	str, err = clone.Marshal()
 	if err != nil {
@@ -237,6 +262,11 @@ func (info *ChangeFeedInfo) GetTargetTs() uint64 {
 	return uint64(math.MaxUint64)
 }
 
+// Expired returns true if the changefeed outlives its TTL at now.
+func (info *ChangeFeedInfo) Expired(now time.Time) bool {
+	return info.TTL > 0 && now.Sub(info.CreateTime) >= info.TTL
+}
+
 // Marshal returns the json marshal format of a ChangeFeedInfo
 func (info *ChangeFeedInfo) Marshal() (string, error) {
 	data, err := json.Marshal(info)
@@ -594,4 +624,68 @@ type ChangeFeedStatusForAPI struct {
 	// used to check whether there is a pending DDL job at the checkpointTs when
 	// initializing the changefeed.
 	MinTableBarrierTs uint64 `json:"min-table-barrier-ts"`
+	// PausedTables are tables whose replication is paused, with the
+	// checkpoint ts they are paused at.
+	PausedTables map[TableID]Ts `json:"paused-tables,omitempty"`
+}
+
+// ChangefeedWarning is a warning of a changefeed, repeated warnings with the
+// same code and similar messages are aggregated into one.
+type ChangefeedWarning struct {
+	Code string `json:"code"`
+	// Message and Addr are the ones of the latest occurrence.
+	Message   string    `json:"message"`
+	Addr      string    `json:"addr"`
+	FirstSeen time.Time `json:"first-seen"`
+	LastSeen  time.Time `json:"last-seen"`
+	Count     int64     `json:"count"`
+}
+
+// Types of schedule operations.
+const (
+	ScheduleOperationAdd    = "add"
+	ScheduleOperationRemove = "remove"
+	ScheduleOperationMove   = "move"
+)
+
+// ScheduleOperation is an operation on a table span planned by a scheduler
+// of a changefeed.
+type ScheduleOperation struct {
+	Scheduler string  `json:"scheduler"`
+	Type      string  `json:"type"`
+	TableID   TableID `json:"table-id"`
+	// Span is the span of the table in the operation.
+	Span string `json:"span"`
+	// From is the capture replicating the span, it's empty for adding.
+	From CaptureID `json:"from,omitempty"`
+	// To is the capture which the span is added or moved to, it's empty for
+	// removing.
+	To CaptureID `json:"to,omitempty"`
+}
+
+// States of schedule tasks.
+const (
+	ScheduleTaskRunning  = "running"
+	ScheduleTaskFinished = "finished"
+	// ScheduleTaskAborted means the task is done without reaching its goal,
+	// e.g., the destination capture goes offline when moving a table.
+	ScheduleTaskAborted = "aborted"
+)
+
+// ScheduleTaskRecord is a schedule task performed by the scheduler of a
+// changefeed, the scheduler in the operation is the reason of the task.
+type ScheduleTaskRecord struct {
+	ScheduleOperation
+	State     string    `json:"state"`
+	StartTime time.Time `json:"start-time"`
+	// Duration is the time elapsed so far if the task is running.
+	Duration time.Duration `json:"duration"`
+}
+
+// CheckpointSample is a sample of the checkpoint ts and resolved ts of a
+// changefeed, which is recorded by the owner periodically.
+type CheckpointSample struct {
+	Time         time.Time `json:"time"`
+	CheckpointTs uint64    `json:"checkpoint-ts"`
+	ResolvedTs   uint64    `json:"resolved-ts"`
 }
@@ -204,10 +204,11 @@ type CaptureTaskStatus struct {
 
 // Capture holds common information of a capture in cdc
 type Capture struct {
-	ID            string `json:"id"`
-	IsOwner       bool   `json:"is_owner"`
-	AdvertiseAddr string `json:"address"`
-	ClusterID     string `json:"cluster_id"`
+	ID            string       `json:"id"`
+	IsOwner       bool         `json:"is_owner"`
+	AdvertiseAddr string       `json:"address"`
+	ClusterID     string       `json:"cluster_id"`
+	Load          *CaptureLoad `json:"load,omitempty"`
 }
 
 // DrainCaptureRequest is request for manual `DrainCapture`
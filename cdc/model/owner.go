@@ -279,6 +279,28 @@ type ChangeFeedStatus struct {
 	// TODO: remove this filed after we don't use ChangeFeedStatus to
 	// control processor. This is too ambiguous.
 	AdminJobType AdminJobType `json:"admin-job-type"`
+	// PausedTables are tables whose replication is paused, with the
+	// checkpoint ts they are paused at. They are excluded from the checkpoint
+	// ts of the changefeed, and resumed from the ts they are paused at.
+	PausedTables map[TableID]Ts `json:"paused-tables,omitempty"`
+	// ResumingTables are resumed tables that have not caught up with the
+	// checkpoint ts of the changefeed, with the ts they are resumed from.
+	ResumingTables map[TableID]Ts `json:"resuming-tables,omitempty"`
+}
+
+// MinTableCheckpointTs returns the minimal checkpoint ts of tables of the
+// changefeed, including paused and resuming tables that lag behind the
+// checkpoint ts. Data and schemas after it are required by the changefeed.
+func (status *ChangeFeedStatus) MinTableCheckpointTs() uint64 {
+	minTs := status.CheckpointTs
+	for _, tables := range []map[TableID]Ts{status.PausedTables, status.ResumingTables} {
+		for _, ts := range tables {
+			if ts < minTs {
+				minTs = ts
+			}
+		}
+	}
+	return minTs
 }
 
 // Marshal returns json encoded string of ChangeFeedStatus, only contains necessary fields stored in storage
@@ -87,6 +87,17 @@ func TestChangeFeedStatusMarshal(t *testing.T) {
 	require.Equal(t, status, newStatus)
 }
 
+func TestChangeFeedStatusMinTableCheckpointTs(t *testing.T) {
+	t.Parallel()
+
+	status := &ChangeFeedStatus{CheckpointTs: 10}
+	require.Equal(t, uint64(10), status.MinTableCheckpointTs())
+	status.PausedTables = map[TableID]Ts{1: 8, 2: 12}
+	require.Equal(t, uint64(8), status.MinTableCheckpointTs())
+	status.ResumingTables = map[TableID]Ts{3: 5}
+	require.Equal(t, uint64(5), status.MinTableCheckpointTs())
+}
+
 func TestTableOperationState(t *testing.T) {
 	t.Parallel()
 
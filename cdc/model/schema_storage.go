@@ -15,6 +15,7 @@ package model
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -66,6 +67,11 @@ type TableInfo struct {
 	HandleIndexID int64
 
 	IndexColumnsOffset [][]int
+
+	// rowColInfos and rowColFieldTps are only needed to decode rows, they are
+	// initialized on the first call of GetRowColInfos, so that table infos
+	// without any events don't hold them.
+	rowColInfosOnce sync.Once
 	// rowColInfos extend the model.ColumnInfo with some extra information
 	// it's the same length and order with the model.TableInfo.Columns
 	rowColInfos    []rowcodec.ColInfo
@@ -91,20 +97,16 @@ func WrapTableInfo(schemaID int64, schemaName string, version uint64, info *mode
 		ColumnsFlag:      make(map[int64]ColumnFlagType, len(info.Columns)),
 		handleColID:      []int64{-1},
 		HandleIndexID:    HandleIndexTableIneligible,
-		rowColInfos:      make([]rowcodec.ColInfo, len(info.Columns)),
-		rowColFieldTps:   make(map[int64]*types.FieldType, len(info.Columns)),
 	}
 
 	rowColumnsCurrentOffset := 0
 
 	for i, col := range ti.Columns {
 		ti.columnsOffset[col.ID] = i
-		pkIsHandle := false
 		if IsColCDCVisible(col) {
 			ti.RowColumnsOffset[col.ID] = rowColumnsCurrentOffset
 			rowColumnsCurrentOffset++
-			pkIsHandle = (ti.PKIsHandle && mysql.HasPriKeyFlag(col.GetFlag())) || col.ID == model.ExtraHandleID
-			if pkIsHandle {
+			if ti.isPKHandleColumn(col) {
 				// pk is handle
 				ti.handleColID = []int64{col.ID}
 				ti.HandleIndexID = HandleIndexPKIsHandle
@@ -119,15 +121,7 @@ func WrapTableInfo(schemaID int64, schemaName string, version uint64, info *mode
 					ti.handleColID = append(ti.handleColID, id)
 				}
 			}
-
 		}
-		ti.rowColInfos[i] = rowcodec.ColInfo{
-			ID:            col.ID,
-			IsPKHandle:    pkIsHandle,
-			Ft:            col.FieldType.Clone(),
-			VirtualGenCol: col.IsGenerated(),
-		}
-		ti.rowColFieldTps[col.ID] = ti.rowColInfos[i].Ft
 	}
 
 	for i, idx := range ti.Indices {
@@ -258,9 +252,32 @@ func (ti *TableInfo) String() string {
 
 // GetRowColInfos returns all column infos for rowcodec
 func (ti *TableInfo) GetRowColInfos() ([]int64, map[int64]*types.FieldType, []rowcodec.ColInfo) {
+	ti.rowColInfosOnce.Do(ti.initRowColInfos)
 	return ti.handleColID, ti.rowColFieldTps, ti.rowColInfos
 }
 
+func (ti *TableInfo) initRowColInfos() {
+	if ti.TableInfo == nil {
+		return
+	}
+	ti.rowColInfos = make([]rowcodec.ColInfo, len(ti.Columns))
+	ti.rowColFieldTps = make(map[int64]*types.FieldType, len(ti.Columns))
+	for i, col := range ti.Columns {
+		ti.rowColInfos[i] = rowcodec.ColInfo{
+			ID:            col.ID,
+			IsPKHandle:    IsColCDCVisible(col) && ti.isPKHandleColumn(col),
+			Ft:            col.FieldType.Clone(),
+			VirtualGenCol: col.IsGenerated(),
+		}
+		ti.rowColFieldTps[col.ID] = ti.rowColInfos[i].Ft
+	}
+}
+
+// isPKHandleColumn returns whether the column is the handle of the table.
+func (ti *TableInfo) isPKHandleColumn(col *model.ColumnInfo) bool {
+	return (ti.PKIsHandle && mysql.HasPriKeyFlag(col.GetFlag())) || col.ID == model.ExtraHandleID
+}
+
 // IsColCDCVisible returns whether the col is visible for CDC
 func IsColCDCVisible(col *model.ColumnInfo) bool {
 	// this column is a virtual generated column
@@ -270,3 +270,52 @@ func TestTableInfoClone(t *testing.T) {
 	cloned.SchemaID = 100
 	require.Equal(t, int64(10), info.SchemaID)
 }
+
+func TestTableInfoRowColInfosLazy(t *testing.T) {
+	t.Parallel()
+
+	ft := parser_types.NewFieldType(mysql.TypeLong)
+	ft.SetFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+	tbl := timodel.TableInfo{
+		ID:         1071,
+		Name:       timodel.CIStr{O: "t1"},
+		PKIsHandle: true,
+		Columns: []*timodel.ColumnInfo{
+			{
+				ID:        1,
+				Name:      timodel.CIStr{O: "a"},
+				FieldType: *ft,
+				State:     timodel.StatePublic,
+			},
+			{
+				ID:        2,
+				Name:      timodel.CIStr{O: "b"},
+				FieldType: *parser_types.NewFieldType(mysql.TypeVarchar),
+				State:     timodel.StatePublic,
+			},
+		},
+	}
+	info := WrapTableInfo(1, "test", 0, &tbl)
+	require.Nil(t, info.rowColInfos)
+	require.Nil(t, info.rowColFieldTps)
+
+	handleColIDs, fts, colInfos := info.GetRowColInfos()
+	require.Equal(t, []int64{1}, handleColIDs)
+	require.Len(t, fts, 2)
+	require.Equal(t, mysql.TypeVarchar, fts[2].GetType())
+	require.Len(t, colInfos, 2)
+	require.True(t, colInfos[0].IsPKHandle)
+	require.False(t, colInfos[1].IsPKHandle)
+	// Field types are not shared with the table info.
+	require.NotSame(t, &tbl.Columns[0].FieldType, colInfos[0].Ft)
+
+	// The same infos are returned later.
+	_, fts2, _ := info.GetRowColInfos()
+	require.Equal(t, fts, fts2)
+	require.Same(t, fts[1], fts2[1])
+
+	// Table infos which are not wrapped return nothing.
+	_, fts, colInfos = (&TableInfo{}).GetRowColInfos()
+	require.Nil(t, fts)
+	require.Nil(t, colInfos)
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// initBootstrapDDLs prepares bootstrap DDL events if bootstrap-ddl is
+// enabled and the changefeed has not advanced since it's created. Tables are
+// taken from the schema snapshot at ddlStartTs, which is the first snapshot
+// available, and later DDLs are replayed by the DDL puller. Note that
+// bootstrap DDLs may be replayed again if the changefeed is restarted before
+// its checkpoint ts advances.
+func (c *changefeed) initBootstrapDDLs(ctx context.Context, ddlStartTs model.Ts) error {
+	checkpointTs := c.state.Status.CheckpointTs
+	if !util.GetOrZero(c.state.Info.Config.BootstrapDDL) ||
+		checkpointTs != c.state.Info.StartTs {
+		return nil
+	}
+	tables, err := c.schema.AllTables(ctx, ddlStartTs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.bootstrapDDLs, err = ddlsink.BootstrapDDLEvents(tables, checkpointTs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("replay bootstrap DDLs of changefeed",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.Uint64("checkpointTs", checkpointTs),
+		zap.Int("tables", len(tables)),
+		zap.Int("ddls", len(c.bootstrapDDLs)))
+	return nil
+}
+
+// replayBootstrapDDLs emits bootstrap DDL events to the DDL sink one by one,
+// and returns true once all of them are executed.
+func (c *changefeed) replayBootstrapDDLs(ctx context.Context) (bool, error) {
+	for len(c.bootstrapDDLs) > 0 {
+		done, err := c.ddlSink.emitDDLEvent(ctx, c.bootstrapDDLs[0])
+		if err != nil || !done {
+			return false, errors.Trace(err)
+		}
+		c.bootstrapDDLs = c.bootstrapDDLs[1:]
+		if len(c.bootstrapDDLs) == 0 {
+			log.Info("bootstrap DDLs of changefeed are replayed",
+				zap.String("namespace", c.id.Namespace),
+				zap.String("changefeed", c.id.ID))
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/entry"
+	cdcContext "github.com/pingcap/tiflow/pkg/context"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayBootstrapDDLs(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	helper.DDL2Job("create table test0.t2(id int primary key)")
+	job := helper.DDL2Job("create table test0.t1(id int primary key)")
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext4Test(context.Background(), true)
+	ctx.ChangefeedVars().Info.StartTs = startTs
+	ctx.ChangefeedVars().Info.Config.BootstrapDDL = util.AddressOf(true)
+
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	cf.upstream.KVStorage = helper.Storage()
+	defer cf.Close(ctx)
+	tick := func() {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+	// pre check and initialize
+	tick()
+	tick()
+	require.Len(t, cf.bootstrapDDLs, 3)
+	mockDDLSink := cf.ddlManager.ddlSink.(*mockDDLSink)
+	mockDDLSink.recordDDLHistory = true
+	// ddl puller resolved ts grow up
+	cf.ddlManager.ddlPuller.(*mockDDLPuller).resolvedTs = startTs
+
+	// Tables are not scheduled until bootstrap DDLs are executed.
+	tick()
+	tick()
+	require.Equal(t, "CREATE DATABASE IF NOT EXISTS `test0`", mockDDLSink.ddlExecuting.Query)
+	require.Nil(t, cf.scheduler.(*mockScheduler).currentTables)
+
+	mockDDLSink.resetDDLDone = false
+	mockDDLSink.ddlDone = true
+	mockDDLSink.ddlHistory = nil
+	tick()
+	require.Empty(t, cf.bootstrapDDLs)
+	require.Len(t, mockDDLSink.ddlHistory, 3)
+	require.Equal(t, "CREATE DATABASE IF NOT EXISTS `test0`", mockDDLSink.ddlHistory[0])
+	require.Contains(t, mockDDLSink.ddlHistory[1], "CREATE TABLE IF NOT EXISTS `test0`.`t1`")
+	require.Contains(t, mockDDLSink.ddlHistory[2], "CREATE TABLE IF NOT EXISTS `test0`.`t2`")
+	require.Len(t, cf.scheduler.(*mockScheduler).currentTables, 2)
+}
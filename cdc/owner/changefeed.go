@@ -24,7 +24,9 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/errno"
+	tidbkv "github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tiflow/cdc/entry"
+	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/puller"
 	"github.com/pingcap/tiflow/cdc/redo"
@@ -34,6 +36,7 @@ import (
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/keyspace"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	redoCfg "github.com/pingcap/tiflow/pkg/redo"
@@ -76,6 +79,9 @@ type changefeed struct {
 	upstream  *upstream.Upstream
 	cfg       *config.SchedulerConfig
 	scheduler scheduler.Scheduler
+	// resumeRequested are the resuming tables requested to the scheduler,
+	// they are requested again once the scheduler is recreated.
+	resumeRequested map[model.TableID]struct{}
 	// barriers will be created when a changefeed is initialized
 	// and will be destroyed when a changefeed is closed.
 	barriers         *barriers
@@ -90,12 +96,16 @@ type changefeed struct {
 	schema    *schemaWrap4Owner
 	ddlSink   DDLSink
 	ddlPuller puller.DDLPuller
+	// bootstrapDDLs are bootstrap DDL events that are not executed yet.
+	bootstrapDDLs []*model.DDLEvent
 	// The changefeed will start a backend goroutine in the function `initialize`
 	// for DDLPuller and redo manager. `wg` is used to manage this backend goroutine.
 	wg sync.WaitGroup
 
 	// state related fields
 	initialized bool
+	// keyspace is the keyspace the changefeed is scoped to.
+	keyspace string
 	// isRemoved is true if the changefeed is removed,
 	// which means it will be removed from memory forever
 	isRemoved bool
@@ -126,6 +136,7 @@ type changefeed struct {
 	newDDLPuller func(ctx context.Context,
 		replicaConfig *config.ReplicaConfig,
 		up *upstream.Upstream,
+		kvStorage tidbkv.Storage,
 		startTs uint64,
 		changefeed model.ChangeFeedID,
 		schemaStorage entry.SchemaStorage,
@@ -184,6 +195,7 @@ func newChangefeed4Test(
 	newDDLPuller func(ctx context.Context,
 		replicaConfig *config.ReplicaConfig,
 		up *upstream.Upstream,
+		kvStorage tidbkv.Storage,
 		startTs uint64,
 		changefeed model.ChangeFeedID,
 		schemaStorage entry.SchemaStorage,
@@ -317,7 +329,12 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 	preCheckpointTs := c.state.Info.GetCheckpointTs(c.state.Status)
 	// checkStaleCheckpointTs must be called before `feedStateManager.ShouldRunning()`
 	// to ensure all changefeeds, no matter whether they are running or not, will be checked.
-	if err := c.checkStaleCheckpointTs(ctx, preCheckpointTs); err != nil {
+	// Paused tables are checked too, since their data are required to resume them.
+	staleCheckTs := preCheckpointTs
+	if c.state.Status != nil {
+		staleCheckTs = c.state.Status.MinTableCheckpointTs()
+	}
+	if err := c.checkStaleCheckpointTs(ctx, staleCheckTs); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -335,6 +352,10 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 		return nil
 	}
 
+	if err := checkFeatureGates(c.state.Info.Config, captures); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := c.initialize(ctx); err != nil {
 		return errors.Trace(err)
 	}
@@ -345,12 +366,23 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 	default:
 	}
 
+	// Tables are not scheduled until bootstrap DDLs are executed.
+	if done, err := c.replayBootstrapDDLs(ctx); err != nil || !done {
+		return errors.Trace(err)
+	}
+
+	c.ddlManager.setPausedTables(
+		c.state.Status.PausedTables, c.state.Status.ResumingTables)
 	// TODO: pass table checkpointTs when we support concurrent process ddl
 	allPhysicalTables, barrier, err := c.ddlManager.tick(ctx, preCheckpointTs, nil)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	if err := c.finishIfExpired(barrier.GlobalBarrierTs); err != nil {
+		return errors.Trace(err)
+	}
+
 	err = c.handleBarrier(ctx, barrier)
 	if err != nil {
 		return errors.Trace(err)
@@ -372,8 +404,13 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 		return nil
 	}
 
+	if err := c.updateTableNames(ctx); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.requestResumingTables()
 	newCheckpointTs, newResolvedTs, err := c.scheduler.Tick(
-		ctx, preCheckpointTs, allPhysicalTables, captures,
+		ctx, preCheckpointTs, c.excludePausedTables(allPhysicalTables), captures,
 		barrier)
 	if err != nil {
 		return errors.Trace(err)
@@ -423,6 +460,7 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 		}
 	})
 
+	newCheckpointTs = c.finishResumingTables(newCheckpointTs)
 	c.updateStatus(newCheckpointTs, barrier.MinTableBarrierTs)
 	c.updateMetrics(currentTs, newCheckpointTs, c.resolvedTs)
 	c.tickDownstreamObserver(ctx)
@@ -537,8 +575,13 @@ LOOP2:
 	if err != nil {
 		return errors.Trace(err)
 	}
+	kvStorage, err := c.upstream.GetKVStorage(c.state.Info.Keyspace)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	kvStorage = kv.WithResourceGroup(kvStorage, c.state.Info.Config.ResourceGroup)
 	c.schema, err = newSchemaWrap4Owner(
-		c.upstream.KVStorage,
+		kvStorage,
 		ddlStartTs,
 		c.state.Info.Config,
 		c.id,
@@ -571,7 +614,7 @@ LOOP2:
 
 	c.ddlPuller, err = c.newDDLPuller(cancelCtx,
 		c.state.Info.Config,
-		c.upstream, ddlStartTs,
+		c.upstream, kvStorage, ddlStartTs,
 		c.id,
 		c.schema,
 		filter)
@@ -606,12 +649,26 @@ LOOP2:
 		}()
 	}
 
-	c.redoMetaMgr, err = redo.NewMetaManagerWithInit(cancelCtx,
+	redoMetaMgr, err := redo.NewMetaManagerWithInit(cancelCtx,
 		c.id,
 		c.state.Info.Config.Consistent, checkpointTs)
 	if err != nil {
 		return err
 	}
+	redoTables, err := redo.NewTableFilter(
+		c.state.Info.Config.Consistent, c.state.Info.Config.CaseSensitive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if redoTables != nil {
+		schema := c.schema
+		redoMetaMgr.SetTableCoverage(func(tableID model.TableID) bool {
+			info, ok := schema.GetLastSnapshot().PhysicalTableByID(tableID)
+			// Tables not found in the schema are covered to be safe.
+			return !ok || redoTables.Covers(info.TableName.Schema, info.TableName.Table)
+		})
+	}
+	c.redoMetaMgr = redoMetaMgr
 	if c.redoMetaMgr.Enabled() {
 		c.wg.Add(1)
 		go func() {
@@ -644,14 +701,21 @@ LOOP2:
 	// create scheduler
 	cfg := *c.cfg
 	cfg.ChangefeedSettings = c.state.Info.Config.Scheduler
+	cfg.KeyspacePrefix = kv.KeyspacePrefix(kvStorage)
 	epoch := c.state.Info.Epoch
 	c.scheduler, err = c.newScheduler(ctx, c.upstream, epoch, &cfg, c.redoMetaMgr)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	if err := c.initBootstrapDDLs(ctx, ddlStartTs); err != nil {
+		return errors.Trace(err)
+	}
+
 	c.initMetrics()
 
+	c.keyspace = c.state.Info.Keyspace
+	keyspace.Register(c.id, c.keyspace)
 	c.initialized = true
 	log.Info("changefeed initialized",
 		zap.String("namespace", c.state.ID.Namespace),
@@ -721,13 +785,18 @@ func (c *changefeed) releaseResources(ctx cdcContext.Context) {
 		c.scheduler.Close(ctx)
 		c.scheduler = nil
 	}
+	c.resumeRequested = nil
 	if c.downstreamObserver != nil {
 		_ = c.downstreamObserver.Close()
 	}
 
 	c.cleanupMetrics()
+	if c.initialized {
+		keyspace.Unregister(c.id, c.keyspace)
+	}
 	c.schema = nil
 	c.barriers = nil
+	c.bootstrapDDLs = nil
 	c.initialized = false
 	c.isReleased = true
 
@@ -821,6 +890,15 @@ func (c *changefeed) cleanupChangefeedServiceGCSafePoints(ctx cdcContext.Context
 				zap.String("serviceID", serviceID))
 		}
 	}
+
+	if c.upstream.SafePointKeeper != nil {
+		if err := c.upstream.SafePointKeeper.RemoveChangefeed(ctx, c.id); err != nil {
+			log.Error("failed to remove safepoint leases",
+				zap.String("namespace", c.id.Namespace),
+				zap.String("changefeed", c.id.ID),
+				zap.Error(err))
+		}
+	}
 }
 
 // preflightCheck makes sure that the metadata in Etcd is complete enough to run the tick.
@@ -880,6 +958,63 @@ func (c *changefeed) preflightCheck(captures map[model.CaptureID]*model.CaptureI
 	return
 }
 
+// updateTableNames passes names of tables to the scheduler once they change,
+// so that the scheduler pins tables to captures by the affinity rules.
+func (c *changefeed) updateTableNames(ctx context.Context) error {
+	cfg := c.state.Info.Config.Scheduler
+	if cfg == nil || len(cfg.Affinity) == 0 {
+		return nil
+	}
+	updater, ok := c.scheduler.(scheduler.TableNameUpdater)
+	if !ok {
+		return nil
+	}
+	names, updated, err := c.ddlManager.allTableNames(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if updated {
+		updater.UpdateTableNames(names)
+	}
+	return nil
+}
+
+// finishIfExpired finishes the changefeed at the current time once it
+// outlives its TTL by lowering its target ts, so that data before then are
+// flushed as usual before the changefeed is finished and removed.
+// globalBarrierTs is the upper bound of the barrier sent to processors, the
+// target ts must not fall behind it, otherwise the checkpoint may pass it.
+func (c *changefeed) finishIfExpired(globalBarrierTs model.Ts) error {
+	if !c.state.Info.Expired(time.Now()) {
+		return nil
+	}
+	pdTime, err := c.upstream.PDClock.CurrentTime()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	targetTs := oracle.GoTimeToTS(pdTime)
+	if targetTs < globalBarrierTs {
+		targetTs = globalBarrierTs
+	}
+	if c.state.Info.TargetTs != 0 && c.state.Info.TargetTs <= targetTs {
+		return nil
+	}
+	c.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil || (info.TargetTs != 0 && info.TargetTs <= targetTs) {
+			return info, false, nil
+		}
+		info.TargetTs = targetTs
+		return info, true, nil
+	})
+	c.barriers.Update(finishBarrier, targetTs)
+	log.Info("changefeed outlives its ttl, finish it at the target ts",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.Duration("ttl", c.state.Info.TTL),
+		zap.Uint64("targetTs", targetTs))
+	return nil
+}
+
 // handleBarrier calculates the barrierTs of the changefeed.
 // barrierTs is used to control the data that can be flush to downstream.
 func (c *changefeed) handleBarrier(ctx cdcContext.Context, barrier *schedulepb.BarrierWithMinTs) error {
@@ -902,6 +1037,20 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context, barrier *schedulepb.B
 			}
 			c.barriers.Update(syncPointBarrier, nextSyncPointTs)
 		case finishBarrier:
+			// Wait until the final checkpoint is written to downstream, so
+			// consumers of the downstream know the changefeed is finished.
+			if c.ddlSink.getFlushedCheckpointTs() < barrierTs {
+				log.Info("changefeed reaches target ts, wait for the final checkpoint flushed",
+					zap.String("namespace", c.id.Namespace),
+					zap.String("changefeed", c.id.ID),
+					zap.Uint64("targetTs", barrierTs))
+				break
+			}
+			if c.state.Info.TTL > 0 && c.state.Info.RemoveTopics {
+				if err := c.ddlSink.removeTopics(ctx); err != nil {
+					return errors.Trace(err)
+				}
+			}
 			c.feedStateManager.MarkFinished()
 		default:
 			log.Panic("Unknown barrier type", zap.Int("barrierType", int(barrierTp)))
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	tidbkv "github.com/pingcap/tidb/kv"
 	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tiflow/cdc/entry"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -97,6 +98,10 @@ type mockDDLSink struct {
 	}
 	syncPoint    model.Ts
 	syncPointHis []model.Ts
+	// whether emitted checkpoint ts are not flushed to downstream
+	checkpointTsNotFlushed bool
+	// whether topics are removed
+	topicsRemoved bool
 
 	wg sync.WaitGroup
 }
@@ -140,12 +145,26 @@ func (m *mockDDLSink) emitCheckpointTs(ts uint64, tables []*model.TableInfo) {
 	m.mu.currentTables = tables
 }
 
+func (m *mockDDLSink) getFlushedCheckpointTs() model.Ts {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.checkpointTsNotFlushed {
+		return 0
+	}
+	return m.mu.checkpointTs
+}
+
 func (m *mockDDLSink) getCheckpointTsAndTableNames() (uint64, []*model.TableInfo) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	return m.mu.checkpointTs, m.mu.currentTables
 }
 
+func (m *mockDDLSink) removeTopics(ctx context.Context) error {
+	m.topicsRemoved = true
+	return nil
+}
+
 func (m *mockDDLSink) close(ctx context.Context) error {
 	m.wg.Wait()
 	return nil
@@ -157,6 +176,8 @@ func (m *mockDDLSink) Barrier(ctx context.Context) error {
 
 type mockScheduler struct {
 	currentTables []model.TableID
+	resetTables   map[model.TableID]model.Ts
+	resumeTables  map[model.TableID]model.Ts
 }
 
 func (m *mockScheduler) Tick(
@@ -173,6 +194,22 @@ func (m *mockScheduler) Tick(
 // MoveTable is used to trigger manual table moves.
 func (m *mockScheduler) MoveTable(tableID model.TableID, target model.CaptureID) {}
 
+// ResetTable is used to trigger manual table reset.
+func (m *mockScheduler) ResetTable(tableID model.TableID, startTs model.Ts) {
+	if m.resetTables == nil {
+		m.resetTables = make(map[model.TableID]model.Ts)
+	}
+	m.resetTables[tableID] = startTs
+}
+
+// ResumeTable is used to resume paused tables.
+func (m *mockScheduler) ResumeTable(tableID model.TableID, startTs model.Ts) {
+	if m.resumeTables == nil {
+		m.resumeTables = make(map[model.TableID]model.Ts)
+	}
+	m.resumeTables[tableID] = startTs
+}
+
 // Rebalance is used to trigger manual workload rebalances.
 func (m *mockScheduler) Rebalance() {}
 
@@ -207,6 +244,7 @@ func createChangefeed4Test(ctx cdcContext.Context, t *testing.T,
 		func(ctx context.Context,
 			replicaConfig *config.ReplicaConfig,
 			up *upstream.Upstream,
+			kvStorage tidbkv.Storage,
 			startTs uint64,
 			changefeed model.ChangeFeedID,
 			schemaStorage entry.SchemaStorage,
@@ -511,6 +549,84 @@ func TestFinished(t *testing.T) {
 	require.Equal(t, cf.state.Info.State, model.StateFinished)
 }
 
+func TestFinishedAfterFinalCheckpointFlushed(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	ctx.ChangefeedVars().Info.TargetTs = ctx.ChangefeedVars().Info.StartTs + 1000
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	defer cf.Close(ctx)
+
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	// initialize
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	mockDDLSink := cf.ddlManager.ddlSink.(*mockDDLSink)
+	mockDDLSink.mu.Lock()
+	mockDDLSink.checkpointTsNotFlushed = true
+	mockDDLSink.mu.Unlock()
+	mockDDLPuller := cf.ddlManager.ddlPuller.(*mockDDLPuller)
+	mockDDLPuller.resolvedTs += 2000
+	for i := 0; i <= 10; i++ {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+	// The changefeed reaches the target ts, but the final checkpoint is
+	// not flushed yet.
+	require.Equal(t, cf.state.Info.TargetTs, cf.state.Status.CheckpointTs)
+	require.Equal(t, model.StateNormal, cf.state.Info.State)
+
+	mockDDLSink.mu.Lock()
+	mockDDLSink.checkpointTsNotFlushed = false
+	mockDDLSink.mu.Unlock()
+	for i := 0; i <= 2; i++ {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+	require.Equal(t, model.StateFinished, cf.state.Info.State)
+}
+
+func TestFinishedAfterTTL(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	info := ctx.ChangefeedVars().Info
+	info.CreateTime = time.Now().Add(-2 * time.Hour)
+	info.TTL = time.Hour
+	info.RemoveTopics = true
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	defer cf.Close(ctx)
+
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	// initialize
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+
+	// The changefeed is finished at the current time.
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	targetTs := cf.state.Info.TargetTs
+	require.NotZero(t, targetTs)
+	require.LessOrEqual(t, targetTs, oracle.GoTimeToTS(time.Now()))
+
+	mockDDLPuller := cf.ddlManager.ddlPuller.(*mockDDLPuller)
+	mockDDLPuller.resolvedTs = oracle.GoTimeToTS(time.Now().Add(time.Minute))
+	for i := 0; i <= 10; i++ {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+		if cf.state.Info.State == model.StateFinished {
+			break
+		}
+	}
+	require.Equal(t, targetTs, cf.state.Info.TargetTs)
+	require.Equal(t, targetTs, cf.state.Status.CheckpointTs)
+	require.Equal(t, model.StateFinished, cf.state.Info.State)
+	require.True(t, cf.ddlManager.ddlSink.(*mockDDLSink).topicsRemoved)
+}
+
 func TestRemoveChangefeed(t *testing.T) {
 	baseCtx, cancel := context.WithCancel(context.Background())
 	ctx := cdcContext.NewContext4Test(baseCtx, true)
@@ -0,0 +1,343 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+const (
+	// checkpointSegmentSize is the number of samples in a segment offloaded
+	// to the external storage.
+	checkpointSegmentSize = 60
+	// checkpointOffloadQueueSize is the number of segments waiting to be
+	// offloaded, segments are dropped if the queue is full.
+	checkpointOffloadQueueSize = 128
+	checkpointOffloadTimeout   = 30 * time.Second
+)
+
+// checkpointHistory keeps the checkpoint samples of changefeeds in memory and
+// offloads them to an external storage if it's configured.
+// It's not thread-safe, and it's only accessed in owner ticks.
+type checkpointHistory struct {
+	interval time.Duration
+	capacity int
+	rings    map[model.ChangeFeedID]*checkpointRing
+	// offloader is nil if no external storage is configured.
+	offloader *checkpointOffloader
+}
+
+func newCheckpointHistory(cfg *config.CheckpointHistoryConfig) *checkpointHistory {
+	h := &checkpointHistory{
+		interval: time.Duration(cfg.SampleInterval),
+		capacity: cfg.Capacity(),
+		rings:    make(map[model.ChangeFeedID]*checkpointRing),
+	}
+	if cfg.Storage != "" {
+		h.offloader = newCheckpointOffloader(cfg.Storage)
+	}
+	return h
+}
+
+// record adds a sample of the changefeed if the sample interval has elapsed
+// since the last one.
+func (h *checkpointHistory) record(
+	id model.ChangeFeedID, now time.Time, checkpointTs, resolvedTs model.Ts,
+) {
+	ring, ok := h.rings[id]
+	if !ok {
+		ring = newCheckpointRing(h.capacity)
+		h.rings[id] = ring
+	}
+	if last, ok := ring.last(); ok && now.Sub(last.Time) < h.interval {
+		return
+	}
+	sample := model.CheckpointSample{
+		Time:         now,
+		CheckpointTs: checkpointTs,
+		ResolvedTs:   resolvedTs,
+	}
+	ring.push(sample)
+	if h.offloader == nil {
+		return
+	}
+	ring.pending = append(ring.pending, sample)
+	if len(ring.pending) >= checkpointSegmentSize {
+		h.offloader.offload(id, ring.pending)
+		ring.pending = nil
+	}
+}
+
+// samples returns all samples of the changefeed in memory.
+func (h *checkpointHistory) samples(id model.ChangeFeedID) []model.CheckpointSample {
+	ring, ok := h.rings[id]
+	if !ok {
+		return []model.CheckpointSample{}
+	}
+	return ring.samples()
+}
+
+// remove removes the samples of a changefeed, the samples not offloaded yet
+// are offloaded.
+func (h *checkpointHistory) remove(id model.ChangeFeedID) {
+	ring, ok := h.rings[id]
+	if !ok {
+		return
+	}
+	if h.offloader != nil && len(ring.pending) > 0 {
+		h.offloader.offload(id, ring.pending)
+	}
+	delete(h.rings, id)
+}
+
+// flush offloads the samples which have not been offloaded.
+func (h *checkpointHistory) flush() {
+	if h.offloader == nil {
+		return
+	}
+	for id, ring := range h.rings {
+		if len(ring.pending) > 0 {
+			h.offloader.offload(id, ring.pending)
+			ring.pending = nil
+		}
+	}
+}
+
+// checkpointRing is a ring buffer of checkpoint samples.
+type checkpointRing struct {
+	buf   []model.CheckpointSample
+	start int
+	size  int
+	// pending are the samples which have not been offloaded.
+	pending []model.CheckpointSample
+}
+
+func newCheckpointRing(capacity int) *checkpointRing {
+	return &checkpointRing{buf: make([]model.CheckpointSample, capacity)}
+}
+
+func (r *checkpointRing) push(sample model.CheckpointSample) {
+	if r.size < len(r.buf) {
+		r.buf[(r.start+r.size)%len(r.buf)] = sample
+		r.size++
+		return
+	}
+	r.buf[r.start] = sample
+	r.start = (r.start + 1) % len(r.buf)
+}
+
+func (r *checkpointRing) last() (model.CheckpointSample, bool) {
+	if r.size == 0 {
+		return model.CheckpointSample{}, false
+	}
+	return r.buf[(r.start+r.size-1)%len(r.buf)], true
+}
+
+func (r *checkpointRing) samples() []model.CheckpointSample {
+	ret := make([]model.CheckpointSample, 0, r.size)
+	for i := 0; i < r.size; i++ {
+		ret = append(ret, r.buf[(r.start+i)%len(r.buf)])
+	}
+	return ret
+}
+
+type checkpointSegment struct {
+	id      model.ChangeFeedID
+	samples []model.CheckpointSample
+}
+
+// checkpointOffloader writes segments of samples to an external storage in
+// background, so that owner ticks are not blocked by the storage. A goroutine
+// is started on demand, and it exits once all queued segments are written.
+type checkpointOffloader struct {
+	uri string
+
+	mu      sync.Mutex
+	queue   []checkpointSegment
+	running bool
+	// extStorage is created lazily, it's only accessed by the running
+	// goroutine.
+	extStorage storage.ExternalStorage
+}
+
+func newCheckpointOffloader(uri string) *checkpointOffloader {
+	return &checkpointOffloader{uri: uri}
+}
+
+func (o *checkpointOffloader) offload(id model.ChangeFeedID, samples []model.CheckpointSample) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.queue) >= checkpointOffloadQueueSize {
+		log.Warn("checkpoint history offload queue is full, drop samples",
+			zap.String("namespace", id.Namespace),
+			zap.String("changefeed", id.ID),
+			zap.Int("samples", len(samples)))
+		return
+	}
+	o.queue = append(o.queue, checkpointSegment{id: id, samples: samples})
+	if !o.running {
+		o.running = true
+		go o.run()
+	}
+}
+
+func (o *checkpointOffloader) run() {
+	for {
+		o.mu.Lock()
+		if len(o.queue) == 0 {
+			o.running = false
+			o.mu.Unlock()
+			return
+		}
+		segment := o.queue[0]
+		o.queue = o.queue[1:]
+		o.mu.Unlock()
+
+		if err := o.write(segment); err != nil {
+			log.Warn("failed to offload checkpoint history",
+				zap.String("namespace", segment.id.Namespace),
+				zap.String("changefeed", segment.id.ID),
+				zap.Int("samples", len(segment.samples)),
+				zap.Error(err))
+		}
+	}
+}
+
+func (o *checkpointOffloader) write(segment checkpointSegment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointOffloadTimeout)
+	defer cancel()
+	if o.extStorage == nil {
+		s, err := util.GetExternalStorageFromURI(ctx, o.uri)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		o.extStorage = s
+	}
+	return writeCheckpointSegment(ctx, o.extStorage, segment.id, segment.samples)
+}
+
+// checkpointSegmentDir returns the directory of the segments of a changefeed.
+func checkpointSegmentDir(id model.ChangeFeedID) string {
+	return path.Join(id.Namespace, id.ID)
+}
+
+// checkpointSegmentName returns the name of a segment, which consists of the
+// unix milliseconds of the first and the last samples.
+func checkpointSegmentName(samples []model.CheckpointSample) string {
+	return fmt.Sprintf("%d-%d.json",
+		samples[0].Time.UnixMilli(), samples[len(samples)-1].Time.UnixMilli())
+}
+
+func parseCheckpointSegmentName(name string) (first, last time.Time, ok bool) {
+	parts := strings.Split(strings.TrimSuffix(name, ".json"), "-")
+	if len(parts) != 2 || !strings.HasSuffix(name, ".json") {
+		return time.Time{}, time.Time{}, false
+	}
+	firstMs, err1 := strconv.ParseInt(parts[0], 10, 64)
+	lastMs, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return time.UnixMilli(firstMs), time.UnixMilli(lastMs), true
+}
+
+func writeCheckpointSegment(
+	ctx context.Context, extStorage storage.ExternalStorage,
+	id model.ChangeFeedID, samples []model.CheckpointSample,
+) error {
+	data, err := json.Marshal(samples)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := path.Join(checkpointSegmentDir(id), checkpointSegmentName(samples))
+	return errors.Trace(extStorage.WriteFile(ctx, name, data))
+}
+
+// readCheckpointSegments reads the offloaded samples of a changefeed between
+// from and to.
+func readCheckpointSegments(
+	ctx context.Context, extStorage storage.ExternalStorage,
+	id model.ChangeFeedID, from, to time.Time,
+) ([]model.CheckpointSample, error) {
+	var names []string
+	err := extStorage.WalkDir(ctx, &storage.WalkOption{
+		SubDir: checkpointSegmentDir(id),
+	}, func(filePath string, _ int64) error {
+		first, last, ok := parseCheckpointSegmentName(path.Base(filePath))
+		if ok && !last.Before(from) && !first.After(to) {
+			names = append(names, path.Join(checkpointSegmentDir(id), path.Base(filePath)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var samples []model.CheckpointSample
+	for _, name := range names {
+		data, err := extStorage.ReadFile(ctx, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var segment []model.CheckpointSample
+		if err := json.Unmarshal(data, &segment); err != nil {
+			return nil, errors.Trace(err)
+		}
+		samples = append(samples, segment...)
+	}
+	return samples, nil
+}
+
+// mergeCheckpointSamples merges samples into a sorted slice without
+// duplicated samples, only the samples between from and to are kept.
+func mergeCheckpointSamples(
+	from, to time.Time, sampleSets ...[]model.CheckpointSample,
+) []model.CheckpointSample {
+	ret := make([]model.CheckpointSample, 0)
+	for _, samples := range sampleSets {
+		for _, sample := range samples {
+			if sample.Time.Before(from) || sample.Time.After(to) {
+				continue
+			}
+			ret = append(ret, sample)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Time.Before(ret[j].Time)
+	})
+	n := 0
+	for i := range ret {
+		if n > 0 && ret[n-1].Time.UnixMilli() == ret[i].Time.UnixMilli() {
+			continue
+		}
+		ret[n] = ret[i]
+		n++
+	}
+	return ret[:n]
+}
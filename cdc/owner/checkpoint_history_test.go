@@ -0,0 +1,122 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointHistoryRecord(t *testing.T) {
+	t.Parallel()
+
+	h := newCheckpointHistory(&config.CheckpointHistoryConfig{
+		SampleInterval: config.TomlDuration(time.Minute),
+		Retention:      config.TomlDuration(3 * time.Minute),
+	})
+	id := model.DefaultChangeFeedID("test")
+	start := time.Unix(1000, 0)
+	for i := 0; i < 10; i++ {
+		// Samples within the interval are ignored.
+		now := start.Add(time.Duration(i) * 30 * time.Second)
+		h.record(id, now, uint64(i), uint64(i+1))
+	}
+	// Only the latest 3 samples are kept.
+	require.Equal(t, []model.CheckpointSample{
+		{Time: start.Add(2 * time.Minute), CheckpointTs: 4, ResolvedTs: 5},
+		{Time: start.Add(3 * time.Minute), CheckpointTs: 6, ResolvedTs: 7},
+		{Time: start.Add(4 * time.Minute), CheckpointTs: 8, ResolvedTs: 9},
+	}, h.samples(id))
+	require.Empty(t, h.samples(model.DefaultChangeFeedID("other")))
+
+	h.remove(id)
+	require.Empty(t, h.samples(id))
+}
+
+func TestCheckpointHistoryOffload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	h := newCheckpointHistory(&config.CheckpointHistoryConfig{
+		SampleInterval: config.TomlDuration(time.Second),
+		Retention:      config.TomlDuration(10 * time.Second),
+		Storage:        "file://" + dir,
+	})
+	id := model.DefaultChangeFeedID("test")
+	start := time.Unix(1000, 0)
+	total := checkpointSegmentSize + 10
+	for i := 0; i < total; i++ {
+		h.record(id, start.Add(time.Duration(i)*time.Second), uint64(i), uint64(i))
+	}
+	require.Len(t, h.samples(id), 10)
+	h.flush()
+
+	ctx := context.Background()
+	extStorage, err := util.GetExternalStorageFromURI(ctx, "file://"+dir)
+	require.Nil(t, err)
+	var samples []model.CheckpointSample
+	require.Eventually(t, func() bool {
+		samples, err = readCheckpointSegments(ctx, extStorage, id,
+			start, start.Add(time.Hour))
+		require.Nil(t, err)
+		return len(samples) == total
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Segments out of the range are skipped.
+	samples, err = readCheckpointSegments(ctx, extStorage, id,
+		start.Add(time.Duration(checkpointSegmentSize)*time.Second), start.Add(time.Hour))
+	require.Nil(t, err)
+	require.Len(t, samples, 10)
+
+	// Samples in memory and the offloaded ones are merged.
+	samples, err = readCheckpointSegments(ctx, extStorage, id,
+		start, start.Add(time.Hour))
+	require.Nil(t, err)
+	merged := mergeCheckpointSamples(start.Add(5*time.Second),
+		start.Add(time.Hour), samples, h.samples(id))
+	require.Len(t, merged, total-5)
+	for i, sample := range merged {
+		require.Equal(t, uint64(i+5), sample.CheckpointTs)
+	}
+}
+
+func TestOwnerQueryCheckpointHistory(t *testing.T) {
+	t.Parallel()
+
+	id := model.DefaultChangeFeedID("test")
+	o := &ownerImpl{
+		changefeeds: map[model.ChangeFeedID]*changefeed{id: {}},
+		checkpointHistory: newCheckpointHistory(
+			config.GetDefaultServerConfig().CheckpointHistory),
+	}
+	o.checkpointHistory.record(id, time.Unix(1000, 0), 1, 2)
+
+	query := &Query{Tp: QueryCheckpointHistory, ChangeFeedID: id}
+	require.Nil(t, o.handleQueries(query))
+	require.Equal(t, []model.CheckpointSample{
+		{Time: time.Unix(1000, 0), CheckpointTs: 1, ResolvedTs: 2},
+	}, query.Data)
+
+	query = &Query{
+		Tp:           QueryCheckpointHistory,
+		ChangeFeedID: model.DefaultChangeFeedID("not-exist"),
+	}
+	require.Regexp(t, "ErrChangeFeedNotExists", o.handleQueries(query))
+}
@@ -79,6 +79,18 @@ var nonGlobalDDLs = map[timodel.ActionType]struct{}{
 	timodel.ActionAlterTTLRemove:               {},
 }
 
+// partitionDDLs are the DDLs that only change some partitions of a
+// partitioned table. Only the affected partitions are blocked by their
+// barriers, the other partitions of the table keep replicating.
+// Exchanging partitions is not one of them, it swaps physical table IDs
+// between two tables, so it's a global DDL.
+var partitionDDLs = map[timodel.ActionType]struct{}{
+	timodel.ActionAddTablePartition:      {},
+	timodel.ActionDropTablePartition:     {},
+	timodel.ActionTruncateTablePartition: {},
+	timodel.ActionReorganizePartition:    {},
+}
+
 var redoBarrierDDLs = map[timodel.ActionType]struct{}{
 	timodel.ActionCreateTable:            {},
 	timodel.ActionTruncateTable:          {},
@@ -109,6 +121,14 @@ type ddlManager struct {
 	// pendingDDLs store the pending DDL events of all tables
 	// the DDL events in the same table are ordered by commitTs.
 	pendingDDLs map[model.TableName][]*model.DDLEvent
+	// partitionBarrierTables store the physical tables that should be blocked
+	// by each partition DDL, until the DDL is executed and sent as the last
+	// barrier.
+	partitionBarrierTables map[*model.DDLEvent][]model.TableID
+	// pausedTables are the physical tables whose replication is paused or
+	// being resumed. DDLs of them block the changefeed as global barriers,
+	// and they are not executed until the tables catch up with the changefeed.
+	pausedTables map[model.TableID]struct{}
 	// executingDDL is the ddl that is currently being executed,
 	// it is nil if there is no ddl being executed.
 	executingDDL *model.DDLEvent
@@ -120,6 +140,9 @@ type ddlManager struct {
 	// The ones that have not been executed yet do not have.
 	tableInfoCache      []*model.TableInfo
 	physicalTablesCache []model.TableID
+	// tableNamesCache maps the physical tables to their names, it is built
+	// from tableInfoCache on demand.
+	tableNamesCache map[model.TableID]model.TableName
 
 	BDRMode       bool
 	sinkType      model.DownstreamType
@@ -161,6 +184,8 @@ func newDDLManager(
 		sinkType:        model.DB,
 		tableCheckpoint: make(map[model.TableName]model.Ts),
 		pendingDDLs:     make(map[model.TableName][]*model.DDLEvent),
+
+		partitionBarrierTables: make(map[*model.DDLEvent][]model.TableID),
 	}
 }
 
@@ -178,7 +203,7 @@ func (m *ddlManager) tick(
 	checkpointTs model.Ts,
 	tableCheckpoint map[model.TableName]model.Ts,
 ) ([]model.TableID, *schedulepb.BarrierWithMinTs, error) {
-	m.justSentDDL = nil
+	m.releaseJustSentDDL()
 	m.updateCheckpointTs(checkpointTs, tableCheckpoint)
 
 	currentTables, err := m.allTables(ctx)
@@ -233,6 +258,9 @@ func (m *ddlManager) tick(
 						zap.String("changefeed", m.changfeedID.ID), zap.Any("ddl", event))
 					continue
 				}
+				if isPartitionDDL(event) {
+					m.partitionBarrierTables[event] = getPartitionBarrierTables(event)
+				}
 				tableName := event.TableInfo.TableName
 				// Add all valid DDL events to the pendingDDLs.
 				m.pendingDDLs[tableName] = append(m.pendingDDLs[tableName], event)
@@ -300,6 +328,12 @@ func (m *ddlManager) tick(
 }
 
 func (m *ddlManager) shouldExecDDL(nextDDL *model.DDLEvent) bool {
+	// Data of paused tables before the DDL may not be replicated yet. A DDL
+	// that is being executed is not interrupted.
+	if m.executingDDL == nil && m.relatedToPausedTables(nextDDL) {
+		return false
+	}
+
 	// TiCDC guarantees all dml(s) that happen before a ddl was sent to
 	// downstream when this ddl is sent. So, we need to wait checkpointTs is
 	// fullyBlocked at ddl commitTs (equivalent to ddl commitTs here) before we
@@ -366,6 +400,7 @@ func (m *ddlManager) executeDDL(ctx context.Context) error {
 		// Set it to nil first to accelerate GC.
 		m.pendingDDLs[tableName][0] = nil
 		m.pendingDDLs[tableName] = m.pendingDDLs[tableName][1:]
+		m.gcPartitionBarrierTables(m.executingDDL.CommitTs)
 		m.schema.DoGC(m.executingDDL.CommitTs - 1)
 		m.justSentDDL = m.executingDDL
 		m.executingDDL = nil
@@ -374,6 +409,26 @@ func (m *ddlManager) executeDDL(ctx context.Context) error {
 	return nil
 }
 
+// releaseJustSentDDL clears the ddl that was sent in the last tick, its
+// barrier is not needed anymore.
+func (m *ddlManager) releaseJustSentDDL() {
+	if m.justSentDDL != nil {
+		delete(m.partitionBarrierTables, m.justSentDDL)
+		m.justSentDDL = nil
+	}
+}
+
+// gcPartitionBarrierTables removes the barrier tables of partition DDLs
+// committed before ts. DDLs are executed in the order of commitTs, so those
+// DDLs were skipped without being executed.
+func (m *ddlManager) gcPartitionBarrierTables(ts model.Ts) {
+	for ddl := range m.partitionBarrierTables {
+		if ddl.CommitTs < ts {
+			delete(m.partitionBarrierTables, ddl)
+		}
+	}
+}
+
 // getNextDDL returns the next ddl event to execute.
 func (m *ddlManager) getNextDDL() *model.DDLEvent {
 	if m.executingDDL != nil {
@@ -445,15 +500,20 @@ func (m *ddlManager) barrier() *schedulepb.BarrierWithMinTs {
 				barrier.RedoBarrierTs = ddl.CommitTs
 			}
 		}
-		if isGlobalDDL(ddl) {
+		if isGlobalDDL(ddl) || m.relatedToPausedTables(ddl) {
 			// When there is a global DDL, we need to wait all tables
 			// checkpointTs reach its commitTs before we can execute it.
+			// DDLs of paused tables are held as global barriers, since the
+			// paused tables do not advance.
 			if ddl.CommitTs < barrier.GlobalBarrierTs {
 				barrier.GlobalBarrierTs = ddl.CommitTs
 			}
 		} else {
 			// barrier related physical tables
-			ids := getRelatedPhysicalTableIDs(ddl)
+			ids, ok := m.partitionBarrierTables[ddl]
+			if !ok {
+				ids = getRelatedPhysicalTableIDs(ddl)
+			}
 			for _, id := range ids {
 				tableBarrierMap[id] = ddl.CommitTs
 			}
@@ -481,11 +541,43 @@ func (m *ddlManager) barrier() *schedulepb.BarrierWithMinTs {
 		tableBarriers = tableBarriers[:tableBarrierNumberLimit]
 	}
 
-	m.justSentDDL = nil
+	m.releaseJustSentDDL()
 	barrier.TableBarriers = tableBarriers
 	return barrier
 }
 
+// setPausedTables sets the tables whose replication is paused or being
+// resumed.
+func (m *ddlManager) setPausedTables(tables ...map[model.TableID]model.Ts) {
+	m.pausedTables = nil
+	for _, ts := range tables {
+		for tableID := range ts {
+			if m.pausedTables == nil {
+				m.pausedTables = make(map[model.TableID]struct{})
+			}
+			m.pausedTables[tableID] = struct{}{}
+		}
+	}
+}
+
+// relatedToPausedTables returns true if the DDL changes a paused table. Global
+// DDLs are not checked, they do not block the changefeed for paused tables.
+func (m *ddlManager) relatedToPausedTables(ddl *model.DDLEvent) bool {
+	if len(m.pausedTables) == 0 || isGlobalDDL(ddl) {
+		return false
+	}
+	ids, ok := m.partitionBarrierTables[ddl]
+	if !ok {
+		ids = getRelatedPhysicalTableIDs(ddl)
+	}
+	for _, id := range ids {
+		if _, ok := m.pausedTables[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // allTables returns all tables in the schema that
 // less or equal than the checkpointTs.
 func (m *ddlManager) allTables(ctx context.Context) ([]*model.TableInfo, error) {
@@ -532,6 +624,38 @@ func (m *ddlManager) allPhysicalTables(ctx context.Context) ([]model.TableID, er
 	return m.physicalTablesCache, nil
 }
 
+// allTableNames returns names of all physical tables in the schema that
+// less or equal than the checkpointTs, and whether the names are rebuilt
+// since the last call.
+func (m *ddlManager) allTableNames(
+	ctx context.Context,
+) (map[model.TableID]model.TableName, bool, error) {
+	if m.tableNamesCache != nil {
+		return m.tableNamesCache, false, nil
+	}
+	tables, err := m.allTables(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	names := make(map[model.TableID]model.TableName, len(tables))
+	for _, table := range tables {
+		if pi := table.GetPartitionInfo(); pi != nil {
+			for _, partition := range pi.Definitions {
+				names[partition.ID] = model.TableName{
+					Schema:      table.TableName.Schema,
+					Table:       table.TableName.Table,
+					TableID:     partition.ID,
+					IsPartition: true,
+				}
+			}
+		} else {
+			names[table.ID] = table.TableName
+		}
+	}
+	m.tableNamesCache = names
+	return names, true, nil
+}
+
 // getSnapshotTs returns the ts that we should use
 // to get the snapshot of the schema, the rules are:
 // 1. If the changefeed is just started, we use the startTs,
@@ -565,12 +689,14 @@ func (m *ddlManager) getSnapshotTs() (ts uint64) {
 	return ts
 }
 
-// cleanCache cleans the tableInfoCache and physicalTablesCache.
+// cleanCache cleans the tableInfoCache, physicalTablesCache and
+// tableNamesCache.
 // It should be called after a DDL is applied to schema or a DDL
 // is sent to downstream successfully.
 func (m *ddlManager) cleanCache() {
 	m.tableInfoCache = nil
 	m.physicalTablesCache = nil
+	m.tableNamesCache = nil
 }
 
 // getRelatedPhysicalTableIDs get all related physical table ids of a ddl event.
@@ -596,6 +722,52 @@ func getRelatedPhysicalTableIDs(ddl *model.DDLEvent) []model.TableID {
 	return res
 }
 
+// getPartitionBarrierTables returns the physical tables affected by a
+// partition DDL. Partitions which are not changed by the DDL are excluded,
+// so that they are not blocked while the DDL is waiting to be executed.
+func getPartitionBarrierTables(ddl *model.DDLEvent) []model.TableID {
+	if ddl.PreTableInfo == nil || ddl.TableInfo == nil {
+		return getRelatedPhysicalTableIDs(ddl)
+	}
+	// Partitions removed by the DDL (dropped, truncated or reorganized) must
+	// be blocked, newly created partitions are blocked too so that they are
+	// added to the changefeed after the DDL is executed.
+	ids := diffPartitionIDs(ddl.PreTableInfo, ddl.TableInfo)
+	ids = append(ids, diffPartitionIDs(ddl.TableInfo, ddl.PreTableInfo)...)
+	if len(ids) == 0 {
+		return getRelatedPhysicalTableIDs(ddl)
+	}
+	return ids
+}
+
+// diffPartitionIDs returns the partition IDs that exist in the left table
+// but not in the right table.
+func diffPartitionIDs(left, right *model.TableInfo) []model.TableID {
+	res := make([]model.TableID, 0, 1)
+	leftPartitions := left.TableInfo.GetPartitionInfo()
+	if leftPartitions == nil {
+		return res
+	}
+	rightIDs := make(map[model.TableID]struct{})
+	if rightPartitions := right.TableInfo.GetPartitionInfo(); rightPartitions != nil {
+		for _, def := range rightPartitions.Definitions {
+			rightIDs[def.ID] = struct{}{}
+		}
+	}
+	for _, def := range leftPartitions.Definitions {
+		if _, ok := rightIDs[def.ID]; !ok {
+			res = append(res, def.ID)
+		}
+	}
+	return res
+}
+
+// isPartitionDDL returns whether the ddl only changes some partitions.
+func isPartitionDDL(ddl *model.DDLEvent) bool {
+	_, ok := partitionDDLs[ddl.Type]
+	return ok
+}
+
 // isGlobalDDL returns whether the ddl is a global ddl.
 func isGlobalDDL(ddl *model.DDLEvent) bool {
 	_, ok := nonGlobalDDLs[ddl.Type]
@@ -14,6 +14,7 @@
 package owner
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -455,3 +456,141 @@ func TestIsGlobalDDL(t *testing.T) {
 		require.Equal(t, c.ret, isGlobalDDL(c.ddl))
 	}
 }
+
+func newFakePartitionTableInfo(
+	tableID int64, tableName string, partitionIDs ...int64,
+) *model.TableInfo {
+	info := &model.TableInfo{
+		TableName: model.TableName{Table: tableName, TableID: tableID},
+	}
+	info.TableInfo = &timodel.TableInfo{
+		ID:        tableID,
+		Name:      timodel.NewCIStr(tableName),
+		Partition: &timodel.PartitionInfo{Enable: true},
+	}
+	for _, id := range partitionIDs {
+		info.TableInfo.Partition.Definitions = append(
+			info.TableInfo.Partition.Definitions, timodel.PartitionDefinition{ID: id})
+	}
+	return info
+}
+
+func TestPartitionDDLBarriers(t *testing.T) {
+	dm := createDDLManagerForTest(t)
+
+	// truncate partition p1 of table t1, only p1 should be blocked.
+	truncateDDL := &model.DDLEvent{
+		Type:         timodel.ActionTruncateTablePartition,
+		CommitTs:     5,
+		PreTableInfo: newFakePartitionTableInfo(1, "t1", 11, 12, 13),
+		TableInfo:    newFakePartitionTableInfo(1, "t1", 14, 12, 13),
+	}
+	require.ElementsMatch(t, []model.TableID{11, 14}, getPartitionBarrierTables(truncateDDL))
+
+	// reorganize partition p2,p3 into p5, the other partitions are not blocked.
+	reorganizeDDL := &model.DDLEvent{
+		Type:         timodel.ActionReorganizePartition,
+		CommitTs:     6,
+		PreTableInfo: newFakePartitionTableInfo(2, "t2", 21, 22, 23),
+		TableInfo:    newFakePartitionTableInfo(2, "t2", 21, 25),
+	}
+	require.ElementsMatch(t, []model.TableID{22, 23, 25}, getPartitionBarrierTables(reorganizeDDL))
+
+	dm.partitionBarrierTables[truncateDDL] = []model.TableID{11, 14}
+	dm.pendingDDLs[truncateDDL.TableInfo.TableName] = append(
+		dm.pendingDDLs[truncateDDL.TableInfo.TableName], truncateDDL)
+	dm.ddlResolvedTs = 10
+	barrier := dm.barrier()
+	require.Equal(t, uint64(5), barrier.MinTableBarrierTs)
+	require.ElementsMatch(t, []*schedulepb.TableBarrier{
+		{TableID: 11, BarrierTs: 5},
+		{TableID: 14, BarrierTs: 5},
+	}, barrier.TableBarriers)
+
+	// The barrier tables are kept until the DDL is sent as the last barrier
+	// after it's executed.
+	delete(dm.pendingDDLs, truncateDDL.TableInfo.TableName)
+	dm.gcPartitionBarrierTables(truncateDDL.CommitTs)
+	dm.justSentDDL = truncateDDL
+	barrier = dm.barrier()
+	require.ElementsMatch(t, []*schedulepb.TableBarrier{
+		{TableID: 11, BarrierTs: 5},
+		{TableID: 14, BarrierTs: 5},
+	}, barrier.TableBarriers)
+	require.Empty(t, dm.partitionBarrierTables)
+
+	// The barrier tables of skipped DDLs are removed.
+	dm.partitionBarrierTables[reorganizeDDL] = []model.TableID{22, 23, 25}
+	dm.gcPartitionBarrierTables(reorganizeDDL.CommitTs + 1)
+	require.Empty(t, dm.partitionBarrierTables)
+}
+
+func TestAllTableNames(t *testing.T) {
+	dm := createDDLManagerForTest(t)
+	ctx := cdcContext.NewBackendContext4Test(true)
+
+	dm.tableInfoCache = []*model.TableInfo{
+		newFakeDDLEvent(1, "t1", timodel.ActionCreateTable, 1).TableInfo,
+		newFakePartitionTableInfo(2, "t2", 21, 22),
+	}
+	names, updated, err := dm.allTableNames(ctx)
+	require.Nil(t, err)
+	require.True(t, updated)
+	require.Equal(t, map[model.TableID]model.TableName{
+		1:  {Table: "t1", TableID: 1},
+		21: {Table: "t2", TableID: 21, IsPartition: true},
+		22: {Table: "t2", TableID: 22, IsPartition: true},
+	}, names)
+
+	// Names are rebuilt only after the cache is cleaned.
+	_, updated, err = dm.allTableNames(ctx)
+	require.Nil(t, err)
+	require.False(t, updated)
+	dm.cleanCache()
+	require.Nil(t, dm.tableNamesCache)
+}
+
+func TestPausedTablesDDL(t *testing.T) {
+	dm := createDDLManagerForTest(t)
+	ctx := context.Background()
+	ddlSink := dm.ddlSink.(*mockDDLSink)
+	ddlSink.ddlDone = true
+	dm.ddlPuller.(*mockDDLPuller).resolvedTs = 20
+
+	paused := model.TableName{Table: "paused", TableID: 1}
+	other := model.TableName{Table: "other", TableID: 2}
+	pausedDDL := newFakeDDLEvent(1, paused.Table, timodel.ActionAddColumn, 8)
+	otherDDL := newFakeDDLEvent(2, other.Table, timodel.ActionAddColumn, 5)
+	dm.pendingDDLs[paused] = []*model.DDLEvent{pausedDDL}
+	dm.pendingDDLs[other] = []*model.DDLEvent{otherDDL}
+	dm.setPausedTables(map[model.TableID]model.Ts{1: 3})
+
+	// The DDL of the paused table is a global barrier, the other table
+	// advances to its own DDL.
+	_, barrier, err := dm.tick(ctx, 3, nil)
+	require.Nil(t, err)
+	require.Equal(t, uint64(8), barrier.GlobalBarrierTs)
+	require.Equal(t, []*schedulepb.TableBarrier{{TableID: 2, BarrierTs: 5}},
+		barrier.TableBarriers)
+
+	// The DDL of the other table is executed while the table is paused.
+	_, _, err = dm.tick(ctx, 5, nil)
+	require.Nil(t, err)
+	require.Equal(t, otherDDL, ddlSink.ddlExecuting)
+	require.Empty(t, dm.pendingDDLs[other])
+
+	// The DDL of the paused table is not executed even if the checkpoint ts
+	// reaches it.
+	ddlSink.ddlExecuting = nil
+	_, barrier, err = dm.tick(ctx, 8, nil)
+	require.Nil(t, err)
+	require.Nil(t, ddlSink.ddlExecuting)
+	require.Equal(t, uint64(8), barrier.GlobalBarrierTs)
+
+	// It's executed once the table is resumed and catches up.
+	dm.setPausedTables()
+	_, _, err = dm.tick(ctx, 8, nil)
+	require.Nil(t, err)
+	require.Equal(t, pausedDDL, ddlSink.ddlExecuting)
+	require.Empty(t, dm.pendingDDLs[paused])
+}
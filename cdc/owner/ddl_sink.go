@@ -47,11 +47,17 @@ type DDLSink interface {
 	// this function will return after recording the checkpointTs specified in memory immediately
 	// and the recorded checkpointTs will be sent and updated to downstream data source every second
 	emitCheckpointTs(ts uint64, tables []*model.TableInfo)
+	// getFlushedCheckpointTs returns the last checkpoint Ts which is written
+	// to downstream data source
+	getFlushedCheckpointTs() model.Ts
 	// emitDDLEvent emits DDL event and return true if the DDL is executed
 	// the DDL event will be sent to another goroutine and execute to downstream
 	// the caller of this function can call again and again until a true returned
 	emitDDLEvent(ctx context.Context, ddl *model.DDLEvent) (bool, error)
 	emitSyncPoint(ctx context.Context, checkpointTs uint64) error
+	// removeTopics removes the topics written by the sink for the tables of
+	// the last flushed checkpoint, it does nothing if the sink has no topics.
+	removeTopics(ctx context.Context) error
 	// close the ddlsink, cancel running goroutine.
 	close(ctx context.Context) error
 }
@@ -65,6 +71,8 @@ type ddlSinkImpl struct {
 		sync.Mutex
 		checkpointTs  model.Ts
 		currentTables []*model.TableInfo
+		// flushedCheckpointTs is the last checkpointTs written to downstream.
+		flushedCheckpointTs model.Ts
 	}
 	// ddlSentTsMap is used to check whether a ddl event in a ddl job has been
 	// sent to `ddlCh` successfully.
@@ -194,6 +202,10 @@ func (s *ddlSinkImpl) writeCheckpointTs(ctx context.Context, lastCheckpointTs *m
 		}
 		if err == nil {
 			*lastCheckpointTs = checkpointTs
+			s.mu.Lock()
+			s.mu.flushedCheckpointTs = checkpointTs
+			s.mu.Unlock()
+			s.checkSchema(ctx, tables)
 		}
 		return
 	}
@@ -201,6 +213,28 @@ func (s *ddlSinkImpl) writeCheckpointTs(ctx context.Context, lastCheckpointTs *m
 	return s.retrySinkActionWithErrorReport(ctx, doWrite)
 }
 
+// checkSchema checks whether the schema of downstream tables drifts from
+// upstream if the sink supports it. Drifts are reported as warnings, and
+// failed checks are ignored since they don't block replication.
+func (s *ddlSinkImpl) checkSchema(ctx context.Context, tables []*model.TableInfo) {
+	checker, ok := s.sink.(ddlsink.SchemaChecker)
+	if !ok {
+		return
+	}
+	err := checker.CheckSchema(ctx, tables)
+	if err == nil {
+		return
+	}
+	if cerror.ErrDownstreamSchemaDrift.Equal(err) {
+		s.reportWarning(err)
+		return
+	}
+	log.Warn("check downstream schema failed",
+		zap.String("namespace", s.changefeedID.Namespace),
+		zap.String("changefeed", s.changefeedID.ID),
+		zap.Error(err))
+}
+
 func (s *ddlSinkImpl) writeDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
 	log.Info("begin emit ddl event",
 		zap.String("namespace", s.changefeedID.Namespace),
@@ -276,6 +310,12 @@ func (s *ddlSinkImpl) emitCheckpointTs(ts uint64, tables []*model.TableInfo) {
 	s.mu.currentTables = tables
 }
 
+func (s *ddlSinkImpl) getFlushedCheckpointTs() model.Ts {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.flushedCheckpointTs
+}
+
 // emitDDLEvent returns true if the ddl event is already executed.
 // For the `rename tables` job, the events in that job have identical StartTs
 // and CommitTs. So in emitDDLEvent, we get the DDL finished ts of an event
@@ -362,6 +402,18 @@ func (s *ddlSinkImpl) emitSyncPoint(ctx context.Context, checkpointTs uint64) (e
 	}
 }
 
+func (s *ddlSinkImpl) removeTopics(ctx context.Context) error {
+	s.mu.Lock()
+	remover, ok := s.sink.(ddlsink.TopicRemover)
+	tables := make([]*model.TableInfo, 0, len(s.mu.currentTables))
+	tables = append(tables, s.mu.currentTables...)
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return remover.RemoveTopics(ctx, tables)
+}
+
 func (s *ddlSinkImpl) close(ctx context.Context) (err error) {
 	s.cancel()
 	s.wg.Wait()
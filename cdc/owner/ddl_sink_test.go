@@ -91,6 +91,9 @@ func TestCheckpoint(t *testing.T) {
 	require.Nil(t, waitCheckpointGrowingUp(mSink, 1))
 	ddlSink.emitCheckpointTs(10, nil)
 	require.Nil(t, waitCheckpointGrowingUp(mSink, 10))
+	require.Eventually(t, func() bool {
+		return ddlSink.getFlushedCheckpointTs() == 10
+	}, 5*time.Second, 10*time.Millisecond)
 }
 
 func TestExecDDLEvents(t *testing.T) {
@@ -557,3 +560,32 @@ func TestAddSpecialComment(t *testing.T) {
 		})
 	}, "invalid ddlQuery statement size")
 }
+
+type mockSchemaCheckSink struct {
+	mockSink
+	checkErr error
+}
+
+func (m *mockSchemaCheckSink) CheckSchema(
+	ctx context.Context, tables []*model.TableInfo,
+) error {
+	return m.checkErr
+}
+
+func TestCheckSchema(t *testing.T) {
+	var warning error
+	ddlSink, _ := newDDLSink4Test(func(err error) {}, func(err error) { warning = err })
+	s := ddlSink.(*ddlSinkImpl)
+	ctx := context.Background()
+
+	// Failed checks are not reported.
+	s.sink = &mockSchemaCheckSink{checkErr: cerror.ErrMySQLQueryError.GenWithStackByArgs()}
+	s.checkSchema(ctx, nil)
+	require.Nil(t, warning)
+
+	s.sink = &mockSchemaCheckSink{
+		checkErr: cerror.ErrDownstreamSchemaDrift.GenWithStackByArgs("`test`.`t`: ..."),
+	}
+	s.checkSchema(ctx, nil)
+	require.True(t, cerror.ErrDownstreamSchemaDrift.Equal(warning))
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDrainCheckInterval = time.Second
+	// cancelDrainTimeout is how long to wait for the owner to cancel draining
+	// after the draining is canceled by the caller.
+	cancelDrainTimeout = 10 * time.Second
+)
+
+// DrainCaptureOptions controls how a capture is drained gracefully.
+type DrainCaptureOptions struct {
+	// CheckInterval is the interval of checking the draining progress.
+	CheckInterval time.Duration
+	// Timeout is how long to wait before moving tables out of the capture
+	// forcibly, zero means never.
+	Timeout time.Duration
+}
+
+// DrainProgress is the progress of draining a capture.
+type DrainProgress struct {
+	CaptureID model.CaptureID
+	// TableCount is the count of tables left on the capture.
+	TableCount int
+	// Forced is true if the draining timed out and tables are moved out of
+	// the capture forcibly.
+	Forced bool
+	// Ready is true if the capture has no table, so that it can be shut down.
+	Ready bool
+}
+
+// DrainCaptureGracefully drains the target capture across all changefeeds,
+// and waits until it has no table, e.g., before shutting the capture down
+// in a rolling restart. The owner must not be the target, it should be
+// restarted at last.
+//
+// Draining waits for all tables of a changefeed to be replicating, if it does
+// not finish in the timeout, the remaining tables are moved out forcibly.
+// onProgress is called with the progress after each check. If ctx is done
+// before the capture is drained, draining is canceled and ctx.Err() is
+// returned.
+func DrainCaptureGracefully(
+	ctx context.Context, o Owner, target model.CaptureID,
+	opts DrainCaptureOptions, onProgress func(DrainProgress),
+) (DrainProgress, error) {
+	interval := opts.CheckInterval
+	if interval <= 0 {
+		interval = defaultDrainCheckInterval
+	}
+	var deadline <-chan time.Time
+	if opts.Timeout > 0 {
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	progress := DrainProgress{CaptureID: target}
+	for {
+		drain := o.DrainCapture
+		if progress.Forced {
+			drain = o.ForceDrainCapture
+		}
+		count, err := drainCaptureOnce(ctx, drain, target)
+		if err != nil {
+			if !cerror.ErrSchedulerRequestFailed.Equal(err) {
+				cancelDrainCapture(o, target)
+				return progress, errors.Trace(err)
+			}
+			// Captures may be initializing, retry in the next round.
+			log.Warn("drain capture gracefully failed, retry later",
+				zap.String("target", target), zap.Error(err))
+		} else {
+			progress.TableCount = count
+			progress.Ready = count == 0
+		}
+		if onProgress != nil {
+			onProgress(progress)
+		}
+		if progress.Ready {
+			log.Info("capture drained, ready for shutdown",
+				zap.String("target", target), zap.Bool("forced", progress.Forced))
+			return progress, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			cancelDrainCapture(o, target)
+			return progress, errors.Trace(ctx.Err())
+		case <-deadline:
+			log.Warn("drain capture timed out, move tables out of it forcibly",
+				zap.String("target", target),
+				zap.Int("tableCount", progress.TableCount),
+				zap.Duration("timeout", opts.Timeout))
+			progress.Forced = true
+		case <-ticker.C:
+		}
+	}
+}
+
+func drainCaptureOnce(
+	ctx context.Context,
+	drain func(query *scheduler.Query, done chan<- error),
+	target model.CaptureID,
+) (int, error) {
+	// Use buffered channel to prevent blocking owner.
+	done := make(chan error, 1)
+	query := &scheduler.Query{CaptureID: target}
+	drain(query, done)
+	select {
+	case <-ctx.Done():
+		return 0, errors.Trace(ctx.Err())
+	case err := <-done:
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	return query.Resp.(*model.DrainCaptureResp).CurrentTableCount, nil
+}
+
+func cancelDrainCapture(o Owner, target model.CaptureID) {
+	ctx, cancel := context.WithTimeout(context.Background(), cancelDrainTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	o.CancelDrainCapture(&scheduler.Query{CaptureID: target}, done)
+	select {
+	case <-ctx.Done():
+		log.Warn("cancel drain capture timed out", zap.String("target", target))
+	case <-done:
+		log.Info("drain capture canceled", zap.String("target", target))
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/pingcap/tiflow/pkg/txnutil/gc"
+	"github.com/pingcap/tiflow/pkg/upstream"
+	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
+)
+
+type drainOwner struct {
+	Owner
+	drain    func() (int, error)
+	force    func() (int, error)
+	canceled []model.CaptureID
+}
+
+func (o *drainOwner) reply(
+	query *scheduler.Query, done chan<- error, f func() (int, error),
+) {
+	count, err := f()
+	query.Resp = &model.DrainCaptureResp{CurrentTableCount: count}
+	if err != nil {
+		done <- err
+	}
+	close(done)
+}
+
+func (o *drainOwner) DrainCapture(query *scheduler.Query, done chan<- error) {
+	o.reply(query, done, o.drain)
+}
+
+func (o *drainOwner) ForceDrainCapture(query *scheduler.Query, done chan<- error) {
+	o.reply(query, done, o.force)
+}
+
+func (o *drainOwner) CancelDrainCapture(query *scheduler.Query, done chan<- error) {
+	o.canceled = append(o.canceled, query.CaptureID)
+	close(done)
+}
+
+func countdown(counts ...int) func() (int, error) {
+	return func() (int, error) {
+		count := counts[0]
+		if len(counts) > 1 {
+			counts = counts[1:]
+		}
+		return count, nil
+	}
+}
+
+func TestDrainCaptureGracefully(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts := DrainCaptureOptions{CheckInterval: time.Millisecond}
+
+	// Drained without timeout, failed requests are retried.
+	failed := false
+	drain := countdown(2, 1, 0)
+	o := &drainOwner{drain: func() (int, error) {
+		if !failed {
+			failed = true
+			return 0, cerror.ErrSchedulerRequestFailed.GenWithStack("not all captures initialized")
+		}
+		return drain()
+	}}
+	var progresses []DrainProgress
+	progress, err := DrainCaptureGracefully(ctx, o, "a", opts, func(p DrainProgress) {
+		progresses = append(progresses, p)
+	})
+	require.NoError(t, err)
+	require.Equal(t, DrainProgress{CaptureID: "a", Ready: true}, progress)
+	require.Len(t, progresses, 4)
+	require.Equal(t, 2, progresses[1].TableCount)
+	require.Equal(t, 1, progresses[2].TableCount)
+	require.Empty(t, o.canceled)
+
+	// Tables are moved out forcibly after the timeout.
+	o = &drainOwner{drain: countdown(1), force: countdown(1, 0)}
+	opts.Timeout = 10 * time.Millisecond
+	progress, err = DrainCaptureGracefully(ctx, o, "a", opts, nil)
+	require.NoError(t, err)
+	require.Equal(t, DrainProgress{CaptureID: "a", Forced: true, Ready: true}, progress)
+
+	// Draining is canceled with the context.
+	o = &drainOwner{drain: countdown(1)}
+	opts.Timeout = 0
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	progress, err = DrainCaptureGracefully(cctx, o, "a", opts, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, DrainProgress{CaptureID: "a", TableCount: 1}, progress)
+	require.Equal(t, []model.CaptureID{"a"}, o.canceled)
+
+	// Draining is canceled on unexpected errors.
+	o = &drainOwner{drain: func() (int, error) {
+		return 0, errors.New("fake")
+	}}
+	_, err = DrainCaptureGracefully(ctx, o, "a", opts, nil)
+	require.ErrorContains(t, err, "fake")
+	require.Equal(t, []model.CaptureID{"a"}, o.canceled)
+}
+
+type drainControlScheduler struct {
+	mockScheduler
+	forced   []model.CaptureID
+	canceled []model.CaptureID
+}
+
+func (s *drainControlScheduler) DrainCapture(target model.CaptureID) (int, error) {
+	return 1, nil
+}
+
+func (s *drainControlScheduler) CancelDrainCapture(target model.CaptureID) bool {
+	s.canceled = append(s.canceled, target)
+	return true
+}
+
+func (s *drainControlScheduler) ForceDrainCapture(target model.CaptureID) (int, error) {
+	s.forced = append(s.forced, target)
+	return 2, nil
+}
+
+func TestHandleForceAndCancelDrainCaptures(t *testing.T) {
+	t.Parallel()
+
+	sched := &drainControlScheduler{}
+	o := &ownerImpl{
+		changefeeds: make(map[model.ChangeFeedID]*changefeed),
+		upstreamManager: upstream.NewManager4Test(&gc.MockPDClient{
+			GetAllStoresFunc: func(
+				ctx context.Context, opts ...pd.GetStoreOption,
+			) ([]*metapb.Store, error) {
+				return nil, nil
+			},
+		}),
+	}
+	o.changefeeds[model.DefaultChangeFeedID("a")] = &changefeed{
+		scheduler: sched,
+		state: &orchestrator.ChangefeedReactorState{
+			Info: &model.ChangeFeedInfo{State: model.StateNormal},
+		},
+	}
+	// Schedulers can not cancel nor force draining are drained as usual.
+	o.changefeeds[model.DefaultChangeFeedID("b")] = &changefeed{
+		scheduler: &mockScheduler{},
+		state: &orchestrator.ChangefeedReactorState{
+			Info: &model.ChangeFeedInfo{State: model.StateNormal},
+		},
+	}
+
+	ctx := context.Background()
+	query := &scheduler.Query{CaptureID: "test"}
+	done := make(chan error, 1)
+	o.handleDrainCaptures(ctx, query, done)
+	require.Nil(t, <-done)
+	require.Equal(t, 1, query.Resp.(*model.DrainCaptureResp).CurrentTableCount)
+	require.Empty(t, sched.forced)
+
+	query = &scheduler.Query{CaptureID: "test"}
+	done = make(chan error, 1)
+	o.drainCaptures(ctx, query, done, true)
+	require.Nil(t, <-done)
+	require.Equal(t, 2, query.Resp.(*model.DrainCaptureResp).CurrentTableCount)
+	require.Equal(t, []model.CaptureID{"test"}, sched.forced)
+
+	done = make(chan error, 1)
+	o.handleCancelDrainCaptures(&scheduler.Query{CaptureID: "test"}, done)
+	require.Nil(t, <-done)
+	require.Equal(t, []model.CaptureID{"test"}, sched.canceled)
+}
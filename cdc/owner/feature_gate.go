@@ -0,0 +1,59 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"sort"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/version"
+)
+
+// checkFeatureGates returns an error if any alive capture does not support
+// a feature enabled in the changefeed config. The changefeed does not run
+// until all captures are upgraded, instead of running with the feature
+// silently disabled on some captures.
+func checkFeatureGates(
+	cfg *config.ReplicaConfig, captures map[model.CaptureID]*model.CaptureInfo,
+) error {
+	if cfg == nil || len(cfg.Features) == 0 {
+		return nil
+	}
+	ids := make([]model.CaptureID, 0, len(captures))
+	for id := range captures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, feature := range cfg.Features {
+		spec, ok := config.GetFeatureSpec(feature)
+		if !ok {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				"unknown feature " + string(feature))
+		}
+		for _, id := range ids {
+			v := captures[id].Version
+			ver := &semver.Version{}
+			if v == "" || ver.Set(version.SanitizeVersion(v)) != nil ||
+				ver.Compare(*spec.MinVersion) < 0 {
+				return cerror.ErrChangefeedFeatureUnsupported.GenWithStackByArgs(
+					feature, id, v, spec.MinVersion)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFeatureGates(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*model.CaptureInfo{
+		"a": {ID: "a", Version: "v7.1.0"},
+		"b": {ID: "b", Version: "v6.6.0-alpha-12-g1234567"},
+	}
+	cfg := config.GetDefaultReplicaConfig()
+	require.Nil(t, checkFeatureGates(cfg, captures))
+	cfg.Features = []config.Feature{config.FeatureSpanReplication}
+	require.Nil(t, checkFeatureGates(cfg, captures))
+
+	// Captures of old or unknown versions do not support the feature.
+	captures["c"] = &model.CaptureInfo{ID: "c", Version: "v6.5.0"}
+	err := checkFeatureGates(cfg, captures)
+	require.True(t, cerror.ErrChangefeedFeatureUnsupported.Equal(err))
+	require.Contains(t, err.Error(), "capture c")
+	captures["c"].Version = ""
+	err = checkFeatureGates(cfg, captures)
+	require.True(t, cerror.ErrChangefeedFeatureUnsupported.Equal(err))
+
+	cfg.Features = []config.Feature{"unknown"}
+	err = checkFeatureGates(cfg, captures)
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+}
@@ -62,6 +62,9 @@ type feedStateManager struct {
 	lastErrorTime   time.Time                   // time of last error for a changefeed
 	backoffInterval time.Duration               // the interval for restarting a changefeed in 'error' state
 	errBackoff      *backoff.ExponentialBackOff // an exponential backoff for restarting a changefeed
+	// warnings aggregates warnings of the changefeed, and rate limits
+	// persisting repeated warnings.
+	warnings *warningCenter
 }
 
 // newFeedStateManager creates feedStateManager and initialize the exponential backoff
@@ -79,6 +82,7 @@ func newFeedStateManager(up *upstream.Upstream) *feedStateManager {
 
 	f.resetErrBackoff()
 	f.lastErrorTime = time.Unix(0, 0)
+	f.warnings = newWarningCenter()
 
 	return f
 }
@@ -130,8 +134,10 @@ func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) (adm
 		m.shouldBeRunning = false
 		m.shouldBeRemoved = true
 		return
-	case model.StateStopped, model.StateFailed, model.StateFinished:
+	case model.StateStopped, model.StateFailed, model.StateFinished,
+		model.StateUpstreamRewound:
 		m.shouldBeRunning = false
+		m.removeIfExpired()
 		return
 	case model.StateError:
 		if m.state.Info.Error.IsChangefeedUnRetryableError() {
@@ -139,6 +145,10 @@ func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) (adm
 			m.patchState(model.StateFailed)
 			return
 		}
+		if m.removeIfExpired() {
+			m.shouldBeRunning = false
+			return
+		}
 	}
 	errs := m.errorsReportedByProcessors()
 	m.handleError(errs...)
@@ -147,6 +157,29 @@ func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) (adm
 	return
 }
 
+// removeIfExpired removes the changefeed once it outlives its TTL, since it
+// can't be finished in the current state. A changefeed with TTL is also
+// removed once it is finished.
+func (m *feedStateManager) removeIfExpired() bool {
+	info := m.state.Info
+	if info.TTL <= 0 {
+		return false
+	}
+	if info.State != model.StateFinished && !info.Expired(time.Now()) {
+		return false
+	}
+	log.Info("remove the changefeed automatically",
+		zap.String("namespace", m.state.ID.Namespace),
+		zap.String("changefeed", m.state.ID.ID),
+		zap.String("changefeedState", string(info.State)),
+		zap.Duration("ttl", info.TTL))
+	m.pushAdminJob(&model.AdminJob{
+		CfID: m.state.ID,
+		Type: model.AdminRemove,
+	})
+	return true
+}
+
 func (m *feedStateManager) ShouldRunning() bool {
 	return m.shouldBeRunning
 }
@@ -204,7 +237,8 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 	case model.AdminRemove:
 		switch m.state.Info.State {
 		case model.StateNormal, model.StateError, model.StateFailed,
-			model.StateStopped, model.StateFinished, model.StateRemoved:
+			model.StateStopped, model.StateFinished, model.StateRemoved,
+			model.StateUpstreamRewound:
 		default:
 			log.Warn("can not remove the changefeed in the current state",
 				zap.String("namespace", m.state.ID.Namespace),
@@ -239,6 +273,16 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 	case model.AdminResume:
 		switch m.state.Info.State {
 		case model.StateFailed, model.StateError, model.StateStopped, model.StateFinished:
+		case model.StateUpstreamRewound:
+			// The checkpoint of a rewound changefeed is meaningless, so it
+			// can only be resumed at a new start ts.
+			if job.OverwriteCheckpointTs == 0 {
+				log.Warn("can not resume the rewound changefeed without a new checkpoint ts",
+					zap.String("namespace", m.state.ID.Namespace),
+					zap.String("changefeed", m.state.ID.ID),
+					zap.Any("job", job))
+				return
+			}
 		default:
 			log.Warn("can not resume the changefeed in the current state",
 				zap.String("namespace", m.state.ID.Namespace),
@@ -335,7 +379,8 @@ func (m *feedStateManager) patchState(feedState model.FeedState) {
 	case model.StateFinished:
 		adminJobType = model.AdminFinish
 		updateEpoch = true
-	case model.StateError, model.StateStopped, model.StateFailed:
+	case model.StateError, model.StateStopped, model.StateFailed,
+		model.StateUpstreamRewound:
 		adminJobType = model.AdminStop
 		updateEpoch = true
 	case model.StateRemoved:
@@ -456,6 +501,28 @@ func (m *feedStateManager) warningsReportedByProcessors() []*model.RunningError
 }
 
 func (m *feedStateManager) handleError(errs ...*model.RunningError) {
+	// if the upstream is rewound, the changefeed stops until it's re-anchored
+	// at a new start ts by users.
+	for _, err := range errs {
+		if errors.RFCErrorCode(err.Code) == cerrors.ErrUpstreamRewound.RFCCode() {
+			log.Warn("upstream of the changefeed is rewound, "+
+				"the changefeed must be re-anchored at a new start ts",
+				zap.String("namespace", m.state.ID.Namespace),
+				zap.String("changefeed", m.state.ID.ID),
+				zap.Any("error", err))
+			m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+				if info == nil {
+					return nil, false, nil
+				}
+				info.Error = err
+				return info, true, nil
+			})
+			m.shouldBeRunning = false
+			m.patchState(model.StateUpstreamRewound)
+			return
+		}
+	}
+
 	// if there are a fastFail error in errs, we can just fastFail the changefeed
 	// and no need to patch other error to the changefeed info
 	for _, err := range errs {
@@ -565,18 +632,27 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 }
 
 func (m *feedStateManager) handleWarning(errs ...*model.RunningError) {
+	// Repeated warnings are only counted, so that they do not flood etcd.
+	persisted := errs[:0:0]
+	for _, err := range errs {
+		if m.warnings.record(err) {
+			persisted = append(persisted, err)
+		}
+	}
 	m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
 		if info == nil {
 			return nil, false, nil
 		}
-		for _, err := range errs {
+		for _, err := range persisted {
 			info.Warning = err
 		}
-		return info, len(errs) > 0, nil
+		return info, len(persisted) > 0, nil
 	})
 }
 
 // GenerateChangefeedEpoch generates a unique changefeed epoch.
+// It requests PD directly, as the shared TSO client may return the same
+// timestamp to concurrent callers.
 func GenerateChangefeedEpoch(ctx context.Context, pdClient pd.Client) uint64 {
 	phyTs, logical, err := pdClient.GetTS(ctx)
 	if err != nil {
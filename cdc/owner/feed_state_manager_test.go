@@ -64,6 +64,7 @@ func newFeedStateManager4Test(
 
 	f.resetErrBackoff()
 	f.lastErrorTime = time.Unix(0, 0)
+	f.warnings = newWarningCenter()
 
 	return f
 }
@@ -252,6 +253,90 @@ func TestMarkFinished(t *testing.T) {
 	require.Equal(t, state.Status.AdminJobType, model.AdminFinish)
 }
 
+func TestRemoveExpiredChangefeed(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	setup := func(info *model.ChangeFeedInfo) (
+		*feedStateManager, *orchestrator.ChangefeedReactorState, *orchestrator.ReactorStateTester,
+	) {
+		manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+		state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+			ctx.ChangefeedVars().ID)
+		tester := orchestrator.NewReactorStateTester(t, state, nil)
+		state.PatchInfo(func(*model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+			return info, true, nil
+		})
+		state.PatchStatus(func(*model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			return &model.ChangeFeedStatus{}, true, nil
+		})
+		tester.MustApplyPatches()
+		return manager, state, tester
+	}
+
+	// A stopped changefeed is kept until it expires.
+	manager, state, tester := setup(&model.ChangeFeedInfo{
+		SinkURI:    "123",
+		Config:     &config.ReplicaConfig{},
+		State:      model.StateStopped,
+		CreateTime: time.Now(),
+		TTL:        time.Hour,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.False(t, manager.ShouldRemoved())
+	require.NotNil(t, state.Info)
+
+	// An expired stopped changefeed is removed.
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		info.CreateTime = time.Now().Add(-2 * time.Hour)
+		return info, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRemoved())
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.True(t, manager.ShouldRemoved())
+	require.Nil(t, state.Info)
+	require.Nil(t, state.Status)
+
+	// A finished changefeed with TTL is removed before it expires.
+	manager, state, tester = setup(&model.ChangeFeedInfo{
+		SinkURI:    "123",
+		Config:     &config.ReplicaConfig{},
+		CreateTime: time.Now(),
+		TTL:        time.Hour,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	manager.MarkFinished()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateFinished, state.Info.State)
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRemoved())
+	require.Nil(t, state.Info)
+
+	// A finished changefeed without TTL is kept.
+	manager, state, tester = setup(&model.ChangeFeedInfo{
+		SinkURI: "123",
+		Config:  &config.ReplicaConfig{},
+		State:   model.StateFinished,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRemoved())
+	require.NotNil(t, state.Info)
+}
+
 func TestCleanUpInfos(t *testing.T) {
 	ctx := cdcContext.NewBackendContext4Test(true)
 	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
@@ -333,6 +418,48 @@ func TestHandleError(t *testing.T) {
 	}
 }
 
+func TestHandleRepeatedWarnings(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: &config.ReplicaConfig{}}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		state.PatchTaskPosition(ctx.GlobalVars().CaptureInfo.ID,
+			func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+				return &model.TaskPosition{Warning: &model.RunningError{
+					Time:    now.Add(time.Duration(i) * time.Second),
+					Addr:    ctx.GlobalVars().CaptureInfo.AdvertiseAddr,
+					Code:    "[CDC:ErrSinkFlushSlow]",
+					Message: fmt.Sprintf("sink flush slow, table %d", i),
+				}}, true, nil
+			})
+		tester.MustApplyPatches()
+		manager.Tick(state)
+		tester.MustApplyPatches()
+		require.True(t, manager.ShouldRunning())
+	}
+	// Only the first warning is persisted, the repeated ones are counted.
+	require.Equal(t, "sink flush slow, table 0", state.Info.Warning.Message)
+	warnings := manager.warnings.list(now.Add(3 * time.Second))
+	require.Len(t, warnings, 1)
+	require.Equal(t, int64(3), warnings[0].Count)
+	require.Equal(t, "sink flush slow, table 2", warnings[0].Message)
+}
+
 func TestHandleFastFailError(t *testing.T) {
 	ctx := cdcContext.NewBackendContext4Test(true)
 	manager := newFeedStateManager4Test(0, 0, 0, 0)
@@ -366,6 +493,67 @@ func TestHandleFastFailError(t *testing.T) {
 	tester.MustApplyPatches()
 }
 
+func TestHandleUpstreamRewoundError(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(0, 0, 0, 0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: &config.ReplicaConfig{}}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+
+	// the upstream is rewound by FLASHBACK CLUSTER
+	state.PatchTaskPosition(ctx.GlobalVars().CaptureInfo.ID,
+		func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+			return &model.TaskPosition{Error: &model.RunningError{
+				Addr:    ctx.GlobalVars().CaptureInfo.AdvertiseAddr,
+				Code:    "CDC:ErrUpstreamRewound",
+				Message: "fake error for test",
+			}}, true, nil
+		})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateUpstreamRewound, state.Info.State)
+	require.Equal(t, model.AdminStop, state.Info.AdminJobType)
+	require.Equal(t, model.AdminStop, state.Status.AdminJobType)
+	require.Equal(t, "CDC:ErrUpstreamRewound", state.Info.Error.Code)
+
+	// the changefeed is not resumed from its checkpoint
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminResume,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateUpstreamRewound, state.Info.State)
+
+	// the changefeed is re-anchored at a new checkpoint
+	manager.PushAdminJob(&model.AdminJob{
+		CfID:                  ctx.ChangefeedVars().ID,
+		Type:                  model.AdminResume,
+		OverwriteCheckpointTs: 100,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	require.Equal(t, model.StateNormal, state.Info.State)
+	require.Equal(t, model.AdminNone, state.Info.AdminJobType)
+	require.Equal(t, uint64(100), state.Status.CheckpointTs)
+}
+
 func TestHandleErrorWhenChangefeedIsPaused(t *testing.T) {
 	ctx := cdcContext.NewBackendContext4Test(true)
 	manager := newFeedStateManager4Test(0, 0, 0, 0)
@@ -51,6 +51,18 @@ func (mr *MockOwnerMockRecorder) AsyncStop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AsyncStop", reflect.TypeOf((*MockOwner)(nil).AsyncStop))
 }
 
+// CancelDrainCapture mocks base method.
+func (m *MockOwner) CancelDrainCapture(query *scheduler.Query, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CancelDrainCapture", query, done)
+}
+
+// CancelDrainCapture indicates an expected call of CancelDrainCapture.
+func (mr *MockOwnerMockRecorder) CancelDrainCapture(query, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDrainCapture", reflect.TypeOf((*MockOwner)(nil).CancelDrainCapture), query, done)
+}
+
 // DrainCapture mocks base method.
 func (m *MockOwner) DrainCapture(query *scheduler.Query, done chan<- error) {
 	m.ctrl.T.Helper()
@@ -75,6 +87,30 @@ func (mr *MockOwnerMockRecorder) EnqueueJob(adminJob, done interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueJob", reflect.TypeOf((*MockOwner)(nil).EnqueueJob), adminJob, done)
 }
 
+// ForceDrainCapture mocks base method.
+func (m *MockOwner) ForceDrainCapture(query *scheduler.Query, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForceDrainCapture", query, done)
+}
+
+// ForceDrainCapture indicates an expected call of ForceDrainCapture.
+func (mr *MockOwnerMockRecorder) ForceDrainCapture(query, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDrainCapture", reflect.TypeOf((*MockOwner)(nil).ForceDrainCapture), query, done)
+}
+
+// PauseTables mocks base method.
+func (m *MockOwner) PauseTables(cfID model.ChangeFeedID, tableIDs []model.TableID, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PauseTables", cfID, tableIDs, done)
+}
+
+// PauseTables indicates an expected call of PauseTables.
+func (mr *MockOwnerMockRecorder) PauseTables(cfID, tableIDs, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseTables", reflect.TypeOf((*MockOwner)(nil).PauseTables), cfID, tableIDs, done)
+}
+
 // Query mocks base method.
 func (m *MockOwner) Query(query *owner.Query, done chan<- error) {
 	m.ctrl.T.Helper()
@@ -99,6 +135,30 @@ func (mr *MockOwnerMockRecorder) RebalanceTables(cfID, done interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebalanceTables", reflect.TypeOf((*MockOwner)(nil).RebalanceTables), cfID, done)
 }
 
+// ResetTable mocks base method.
+func (m *MockOwner) ResetTable(cfID model.ChangeFeedID, tableID model.TableID, ts model.Ts, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetTable", cfID, tableID, ts, done)
+}
+
+// ResetTable indicates an expected call of ResetTable.
+func (mr *MockOwnerMockRecorder) ResetTable(cfID, tableID, ts, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTable", reflect.TypeOf((*MockOwner)(nil).ResetTable), cfID, tableID, ts, done)
+}
+
+// ResumeTables mocks base method.
+func (m *MockOwner) ResumeTables(cfID model.ChangeFeedID, tableIDs []model.TableID, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResumeTables", cfID, tableIDs, done)
+}
+
+// ResumeTables indicates an expected call of ResumeTables.
+func (mr *MockOwnerMockRecorder) ResumeTables(cfID, tableIDs, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeTables", reflect.TypeOf((*MockOwner)(nil).ResumeTables), cfID, tableIDs, done)
+}
+
 // ScheduleTable mocks base method.
 func (m *MockOwner) ScheduleTable(cfID model.ChangeFeedID, toCapture model.CaptureID, tableID model.TableID, done chan<- error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock_owner
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/pingcap/tiflow/cdc/model"
@@ -80,6 +81,21 @@ func (mr *MockStatusProviderMockRecorder) GetAllTaskStatuses(ctx, changefeedID i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTaskStatuses", reflect.TypeOf((*MockStatusProvider)(nil).GetAllTaskStatuses), ctx, changefeedID)
 }
 
+// GetCaptureLoads mocks base method.
+func (m *MockStatusProvider) GetCaptureLoads(ctx context.Context) (map[string]*model.CaptureLoad, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCaptureLoads", ctx)
+	ret0, _ := ret[0].(map[string]*model.CaptureLoad)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCaptureLoads indicates an expected call of GetCaptureLoads.
+func (mr *MockStatusProviderMockRecorder) GetCaptureLoads(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaptureLoads", reflect.TypeOf((*MockStatusProvider)(nil).GetCaptureLoads), ctx)
+}
+
 // GetCaptures mocks base method.
 func (m *MockStatusProvider) GetCaptures(ctx context.Context) ([]*model.CaptureInfo, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +111,21 @@ func (mr *MockStatusProviderMockRecorder) GetCaptures(ctx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaptures", reflect.TypeOf((*MockStatusProvider)(nil).GetCaptures), ctx)
 }
 
+// GetChangeFeedCheckpointHistory mocks base method.
+func (m *MockStatusProvider) GetChangeFeedCheckpointHistory(ctx context.Context, changefeedID model.ChangeFeedID, from, to time.Time) ([]model.CheckpointSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeFeedCheckpointHistory", ctx, changefeedID, from, to)
+	ret0, _ := ret[0].([]model.CheckpointSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangeFeedCheckpointHistory indicates an expected call of GetChangeFeedCheckpointHistory.
+func (mr *MockStatusProviderMockRecorder) GetChangeFeedCheckpointHistory(ctx, changefeedID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedCheckpointHistory", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedCheckpointHistory), ctx, changefeedID, from, to)
+}
+
 // GetChangeFeedInfo mocks base method.
 func (m *MockStatusProvider) GetChangeFeedInfo(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangeFeedInfo, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +156,21 @@ func (mr *MockStatusProviderMockRecorder) GetChangeFeedStatus(ctx, changefeedID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedStatus", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedStatus), ctx, changefeedID)
 }
 
+// GetChangeFeedWarnings mocks base method.
+func (m *MockStatusProvider) GetChangeFeedWarnings(ctx context.Context, changefeedID model.ChangeFeedID) ([]model.ChangefeedWarning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeFeedWarnings", ctx, changefeedID)
+	ret0, _ := ret[0].([]model.ChangefeedWarning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangeFeedWarnings indicates an expected call of GetChangeFeedWarnings.
+func (mr *MockStatusProviderMockRecorder) GetChangeFeedWarnings(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedWarnings", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedWarnings), ctx, changefeedID)
+}
+
 // GetProcessors mocks base method.
 func (m *MockStatusProvider) GetProcessors(ctx context.Context) ([]*model.ProcInfoSnap, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +186,51 @@ func (mr *MockStatusProviderMockRecorder) GetProcessors(ctx interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcessors", reflect.TypeOf((*MockStatusProvider)(nil).GetProcessors), ctx)
 }
 
+// GetScheduleHistory mocks base method.
+func (m *MockStatusProvider) GetScheduleHistory(ctx context.Context, changefeedID model.ChangeFeedID) ([]model.ScheduleTaskRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleHistory", ctx, changefeedID)
+	ret0, _ := ret[0].([]model.ScheduleTaskRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleHistory indicates an expected call of GetScheduleHistory.
+func (mr *MockStatusProviderMockRecorder) GetScheduleHistory(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleHistory", reflect.TypeOf((*MockStatusProvider)(nil).GetScheduleHistory), ctx, changefeedID)
+}
+
+// GetSchedulePlan mocks base method.
+func (m *MockStatusProvider) GetSchedulePlan(ctx context.Context, changefeedID model.ChangeFeedID, rebalance bool) ([]model.ScheduleOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulePlan", ctx, changefeedID, rebalance)
+	ret0, _ := ret[0].([]model.ScheduleOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedulePlan indicates an expected call of GetSchedulePlan.
+func (mr *MockStatusProviderMockRecorder) GetSchedulePlan(ctx, changefeedID, rebalance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulePlan", reflect.TypeOf((*MockStatusProvider)(nil).GetSchedulePlan), ctx, changefeedID, rebalance)
+}
+
+// GetSchedulerSnapshot mocks base method.
+func (m *MockStatusProvider) GetSchedulerSnapshot(ctx context.Context, changefeedID model.ChangeFeedID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulerSnapshot", ctx, changefeedID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedulerSnapshot indicates an expected call of GetSchedulerSnapshot.
+func (mr *MockStatusProviderMockRecorder) GetSchedulerSnapshot(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulerSnapshot", reflect.TypeOf((*MockStatusProvider)(nil).GetSchedulerSnapshot), ctx, changefeedID)
+}
+
 // IsHealthy mocks base method.
 func (m *MockStatusProvider) IsHealthy(ctx context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -16,6 +16,8 @@ package owner
 import (
 	"context"
 	"io"
+	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -42,9 +44,14 @@ const (
 	ownerJobTypeRebalance ownerJobType = iota
 	ownerJobTypeScheduleTable
 	ownerJobTypeDrainCapture
+	ownerJobTypeCancelDrainCapture
+	ownerJobTypeForceDrainCapture
 	ownerJobTypeAdminJob
 	ownerJobTypeDebugInfo
 	ownerJobTypeQuery
+	ownerJobTypePauseTables
+	ownerJobTypeResumeTables
+	ownerJobTypeResetTable
 )
 
 // versionInconsistentLogRate represents the rate of log output when there are
@@ -58,8 +65,12 @@ type ownerJob struct {
 
 	// for ScheduleTable only
 	TargetCaptureID model.CaptureID
-	// for ScheduleTable only
+	// for ScheduleTable and ResetTable only
 	TableID model.TableID
+	// for ResetTable only
+	Ts model.Ts
+	// for PauseTables and ResumeTables only
+	TableIDs []model.TableID
 
 	// for Admin Job only
 	AdminJob *model.AdminJob
@@ -88,6 +99,17 @@ type Owner interface {
 		tableID model.TableID, done chan<- error,
 	)
 	DrainCapture(query *scheduler.Query, done chan<- error)
+	CancelDrainCapture(query *scheduler.Query, done chan<- error)
+	ForceDrainCapture(query *scheduler.Query, done chan<- error)
+	PauseTables(
+		cfID model.ChangeFeedID, tableIDs []model.TableID, done chan<- error,
+	)
+	ResumeTables(
+		cfID model.ChangeFeedID, tableIDs []model.TableID, done chan<- error,
+	)
+	ResetTable(
+		cfID model.ChangeFeedID, tableID model.TableID, ts model.Ts, done chan<- error,
+	)
 	WriteDebugInfo(w io.Writer, done chan<- error)
 	Query(query *Query, done chan<- error)
 	AsyncStop()
@@ -122,6 +144,8 @@ type ownerImpl struct {
 		cfg *config.SchedulerConfig,
 	) *changefeed
 	cfg *config.SchedulerConfig
+
+	checkpointHistory *checkpointHistory
 }
 
 // NewOwner creates a new Owner
@@ -136,6 +160,8 @@ func NewOwner(
 		newChangefeed:   newChangefeed,
 		logLimiter:      rate.NewLimiter(versionInconsistentLogRate, versionInconsistentLogRate),
 		cfg:             cfg,
+		checkpointHistory: newCheckpointHistory(
+			config.GetGlobalServerConfig().CheckpointHistory),
 	}
 }
 
@@ -199,6 +225,10 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 			ID: changefeedID,
 		})
 		cfReactor.Tick(ctx, state.Captures)
+		if changefeedState.Status != nil {
+			o.checkpointHistory.record(changefeedID, time.Now(),
+				changefeedState.Status.CheckpointTs, cfReactor.resolvedTs)
+		}
 	}
 	o.changefeedTicked = true
 
@@ -210,6 +240,7 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 			}
 			reactor.Close(ctx)
 			delete(o.changefeeds, changefeedID)
+			o.checkpointHistory.remove(changefeedID)
 		}
 	}
 
@@ -218,6 +249,7 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 		for _, reactor := range o.changefeeds {
 			reactor.Close(ctx)
 		}
+		o.checkpointHistory.flush()
 		return state, cerror.ErrReactorFinished.GenWithStackByArgs()
 	}
 
@@ -274,6 +306,67 @@ func (o *ownerImpl) DrainCapture(query *scheduler.Query, done chan<- error) {
 	})
 }
 
+// CancelDrainCapture stops draining the target capture
+// `done` must be buffered to prevent blocking owner.
+func (o *ownerImpl) CancelDrainCapture(query *scheduler.Query, done chan<- error) {
+	o.pushOwnerJob(&ownerJob{
+		Tp:            ownerJobTypeCancelDrainCapture,
+		scheduleQuery: query,
+		done:          done,
+	})
+}
+
+// ForceDrainCapture moves all tables out of the target capture without
+// waiting for other tables to be replicating.
+// `done` must be buffered to prevent blocking owner.
+func (o *ownerImpl) ForceDrainCapture(query *scheduler.Query, done chan<- error) {
+	o.pushOwnerJob(&ownerJob{
+		Tp:            ownerJobTypeForceDrainCapture,
+		scheduleQuery: query,
+		done:          done,
+	})
+}
+
+// PauseTables pauses replication of tables of the specified changefeed
+// `done` must be buffered to prevent blocking owner.
+func (o *ownerImpl) PauseTables(
+	cfID model.ChangeFeedID, tableIDs []model.TableID, done chan<- error,
+) {
+	o.pushOwnerJob(&ownerJob{
+		Tp:           ownerJobTypePauseTables,
+		ChangefeedID: cfID,
+		TableIDs:     tableIDs,
+		done:         done,
+	})
+}
+
+// ResumeTables resumes replication of paused tables of the specified changefeed
+// `done` must be buffered to prevent blocking owner.
+func (o *ownerImpl) ResumeTables(
+	cfID model.ChangeFeedID, tableIDs []model.TableID, done chan<- error,
+) {
+	o.pushOwnerJob(&ownerJob{
+		Tp:           ownerJobTypeResumeTables,
+		ChangefeedID: cfID,
+		TableIDs:     tableIDs,
+		done:         done,
+	})
+}
+
+// ResetTable recreates replication of the table of the specified changefeed
+// from ts. `done` must be buffered to prevent blocking owner.
+func (o *ownerImpl) ResetTable(
+	cfID model.ChangeFeedID, tableID model.TableID, ts model.Ts, done chan<- error,
+) {
+	o.pushOwnerJob(&ownerJob{
+		Tp:           ownerJobTypeResetTable,
+		ChangefeedID: cfID,
+		TableID:      tableID,
+		Ts:           ts,
+		done:         done,
+	})
+}
+
 // WriteDebugInfo writes debug info into the specified http writer
 func (o *ownerImpl) WriteDebugInfo(w io.Writer, done chan<- error) {
 	o.pushOwnerJob(&ownerJob{
@@ -388,6 +481,15 @@ func (o *ownerImpl) clusterVersionConsistent(captures map[model.CaptureID]*model
 }
 
 func (o *ownerImpl) handleDrainCaptures(ctx context.Context, query *scheduler.Query, done chan<- error) {
+	o.drainCaptures(ctx, query, done, false)
+}
+
+// drainCaptures drains the target capture of all normal changefeeds. If force
+// is true, tables are moved out of the target capture without waiting for
+// other tables to be replicating.
+func (o *ownerImpl) drainCaptures(
+	ctx context.Context, query *scheduler.Query, done chan<- error, force bool,
+) {
 	if err := o.upstreamManager.Visit(func(upstream *upstream.Upstream) error {
 		if err := version.CheckStoreVersion(ctx, upstream.PDClient, 0); err != nil {
 			return errors.Trace(err)
@@ -430,7 +532,16 @@ func (o *ownerImpl) handleDrainCaptures(ctx context.Context, query *scheduler.Qu
 			totalTableCount++
 			continue
 		}
-		count, e := changefeed.scheduler.DrainCapture(query.CaptureID)
+		var (
+			count int
+			e     error
+		)
+		controller, ok := changefeed.scheduler.(scheduler.CaptureDrainController)
+		if force && ok {
+			count, e = controller.ForceDrainCapture(query.CaptureID)
+		} else {
+			count, e = changefeed.scheduler.DrainCapture(query.CaptureID)
+		}
 		if e != nil {
 			err = e
 			break
@@ -455,17 +566,39 @@ func (o *ownerImpl) handleDrainCaptures(ctx context.Context, query *scheduler.Qu
 
 	log.Info("owner handle drain capture",
 		zap.String("target", query.CaptureID),
+		zap.Bool("force", force),
 		zap.Int("changefeedWithTableCount", changefeedWithTableCount),
 		zap.Int("totalTableCount", totalTableCount))
 	close(done)
 }
 
+func (o *ownerImpl) handleCancelDrainCaptures(query *scheduler.Query, done chan<- error) {
+	canceled := 0
+	for _, changefeed := range o.changefeeds {
+		// Scheduler is created lazily, it is nil before initialization.
+		controller, ok := changefeed.scheduler.(scheduler.CaptureDrainController)
+		if !ok {
+			continue
+		}
+		if controller.CancelDrainCapture(query.CaptureID) {
+			canceled++
+		}
+	}
+	log.Info("owner handle cancel drain capture",
+		zap.String("target", query.CaptureID),
+		zap.Int("canceledChangefeedCount", canceled))
+	close(done)
+}
+
 func (o *ownerImpl) handleJobs(ctx context.Context) {
 	jobs := o.takeOwnerJobs()
 	for _, job := range jobs {
 		changefeedID := job.ChangefeedID
 		cfReactor, exist := o.changefeeds[changefeedID]
-		if !exist && (job.Tp != ownerJobTypeQuery && job.Tp != ownerJobTypeDrainCapture) {
+		if !exist && (job.Tp != ownerJobTypeQuery &&
+			job.Tp != ownerJobTypeDrainCapture &&
+			job.Tp != ownerJobTypeCancelDrainCapture &&
+			job.Tp != ownerJobTypeForceDrainCapture) {
 			log.Warn("changefeed not found when handle a job", zap.Any("job", job))
 			job.done <- cerror.ErrChangeFeedNotExists.FastGenByArgs(job.ChangefeedID)
 			close(job.done)
@@ -482,11 +615,29 @@ func (o *ownerImpl) handleJobs(ctx context.Context) {
 		case ownerJobTypeDrainCapture:
 			o.handleDrainCaptures(ctx, job.scheduleQuery, job.done)
 			continue // continue here to prevent close the done channel twice
+		case ownerJobTypeForceDrainCapture:
+			o.drainCaptures(ctx, job.scheduleQuery, job.done, true)
+			continue // continue here to prevent close the done channel twice
+		case ownerJobTypeCancelDrainCapture:
+			o.handleCancelDrainCaptures(job.scheduleQuery, job.done)
+			continue // continue here to prevent close the done channel twice
 		case ownerJobTypeRebalance:
 			// Scheduler is created lazily, it is nil before initialization.
 			if cfReactor.scheduler != nil {
 				cfReactor.scheduler.Rebalance()
 			}
+		case ownerJobTypePauseTables:
+			if err := cfReactor.pauseTables(ctx, job.TableIDs); err != nil {
+				job.done <- err
+			}
+		case ownerJobTypeResumeTables:
+			if err := cfReactor.resumeTables(job.TableIDs); err != nil {
+				job.done <- err
+			}
+		case ownerJobTypeResetTable:
+			if err := cfReactor.resetTable(ctx, job.TableID, job.Ts); err != nil {
+				job.done <- err
+			}
 		case ownerJobTypeQuery:
 			job.done <- o.handleQueries(job.query)
 		case ownerJobTypeDebugInfo:
@@ -510,6 +661,12 @@ func (o *ownerImpl) handleQueries(query *Query) error {
 			}
 			ret[cfID].ResolvedTs = cfReactor.resolvedTs
 			ret[cfID].CheckpointTs = cfReactor.state.Status.CheckpointTs
+			if paused := cfReactor.state.Status.PausedTables; len(paused) != 0 {
+				ret[cfID].PausedTables = make(map[model.TableID]model.Ts, len(paused))
+				for tableID, ts := range paused {
+					ret[cfID].PausedTables[tableID] = ts
+				}
+			}
 		}
 		query.Data = ret
 	case QueryAllChangeFeedInfo:
@@ -529,6 +686,11 @@ func (o *ownerImpl) handleQueries(query *Query) error {
 			}
 		}
 		query.Data = ret
+	case QueryCheckpointHistory:
+		if _, ok := o.changefeeds[query.ChangeFeedID]; !ok {
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		query.Data = o.checkpointHistory.samples(query.ChangeFeedID)
 	case QueryAllTaskStatuses:
 		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
 		if !ok {
@@ -551,6 +713,61 @@ func (o *ownerImpl) handleQueries(query *Query) error {
 			return errors.Trace(err)
 		}
 		query.Data = ret
+	case QuerySchedulerSnapshot:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok {
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		provider := cfReactor.GetInfoProvider()
+		if provider == nil {
+			// The scheduler has not been initialized yet.
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		data, err := provider.DumpSnapshot()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		query.Data = data
+	case QueryChangefeedWarnings:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok {
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		query.Data = cfReactor.feedStateManager.warnings.list(time.Now())
+	case QuerySchedulePlan:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok {
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		if cfReactor.scheduler == nil {
+			// The scheduler has not been initialized yet.
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		planner, ok := cfReactor.scheduler.(scheduler.Planner)
+		if !ok {
+			return cerror.ErrSchedulerRequestFailed.
+				GenWithStack("scheduler does not support planning")
+		}
+		ops, err := planner.Plan(query.Rebalance)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ops == nil {
+			ops = []model.ScheduleOperation{}
+		}
+		query.Data = ops
+	case QueryScheduleHistory:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok {
+			return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.ChangeFeedID)
+		}
+		// The scheduler is nil if the changefeed is not running.
+		provider, ok := cfReactor.scheduler.(scheduler.TaskHistoryProvider)
+		if !ok {
+			query.Data = []model.ScheduleTaskRecord{}
+			return nil
+		}
+		query.Data = provider.TaskHistory()
 	case QueryProcessors:
 		var ret []*model.ProcInfoSnap
 		for cfID, cfReactor := range o.changefeeds {
@@ -579,15 +796,56 @@ func (o *ownerImpl) handleQueries(query *Query) error {
 				ID:            captureInfo.ID,
 				AdvertiseAddr: captureInfo.AdvertiseAddr,
 				Version:       captureInfo.Version,
+				Labels:        captureInfo.Labels,
 			})
 		}
 		query.Data = ret
+	case QueryCaptureLoads:
+		query.Data = o.captureLoads()
 	case QueryHealth:
 		query.Data = o.isHealthy()
 	}
 	return nil
 }
 
+// captureLoads merges the loads reported to schedulers of all changefeeds.
+func (o *ownerImpl) captureLoads() map[model.CaptureID]*model.CaptureLoad {
+	ret := make(map[model.CaptureID]*model.CaptureLoad)
+	for _, cfReactor := range o.changefeeds {
+		provider := cfReactor.GetInfoProvider()
+		if provider == nil {
+			// The scheduler has not been initialized yet.
+			continue
+		}
+		for captureID, load := range provider.GetCaptureLoads() {
+			merged, ok := ret[captureID]
+			if !ok {
+				ret[captureID] = load
+				continue
+			}
+			// The loads of the capture process are sampled by all
+			// changefeeds, the max ones are kept.
+			merged.CPUUsage = math.Max(merged.CPUUsage, load.CPUUsage)
+			if load.MemoryBytes > merged.MemoryBytes {
+				merged.MemoryBytes = load.MemoryBytes
+			}
+			if load.SorterDiskBytes > merged.SorterDiskBytes {
+				merged.SorterDiskBytes = load.SorterDiskBytes
+			}
+			merged.Changefeeds = append(merged.Changefeeds, load.Changefeeds...)
+		}
+	}
+	for _, load := range ret {
+		sort.Slice(load.Changefeeds, func(i, j int) bool {
+			if load.Changefeeds[i].Namespace != load.Changefeeds[j].Namespace {
+				return load.Changefeeds[i].Namespace < load.Changefeeds[j].Namespace
+			}
+			return load.Changefeeds[i].ID < load.Changefeeds[j].ID
+		})
+	}
+	return ret
+}
+
 func (o *ownerImpl) isHealthy() bool {
 	if !o.changefeedTicked {
 		// Owner has not yet tick changefeeds, some changefeeds may be not
@@ -677,7 +935,7 @@ func (o *ownerImpl) updateGCSafepoint(
 		if !up.IsNormal() {
 			log.Warn("upstream is not ready, skip",
 				zap.Uint64("id", up.ID),
-				zap.Strings("pd", up.PdEndpoints))
+				zap.Strings("pd", up.GetPDEndpoints()))
 			continue
 		}
 
@@ -745,6 +1003,10 @@ func (o *ownerImpl) calculateGCSafepoint(state *orchestrator.GlobalReactorState)
 		}
 
 		checkpointTs := changefeedState.Info.GetCheckpointTs(changefeedState.Status)
+		if changefeedState.Status != nil {
+			// Paused tables are resumed from the ts they are paused at.
+			checkpointTs = changefeedState.Status.MinTableCheckpointTs()
+		}
 		upstreamID := changefeedState.Info.UpstreamID
 
 		if _, exist := minCheckpointTsMap[upstreamID]; !exist {
@@ -0,0 +1,213 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// pauseTables pauses replication of the tables at the checkpoint ts of the
+// changefeed. Paused tables are removed from captures and excluded from the
+// checkpoint ts of the changefeed, so that other tables keep advancing. They
+// are resumed from the ts they are paused at later.
+func (c *changefeed) pauseTables(ctx context.Context, tableIDs []model.TableID) error {
+	if c.state.Status == nil {
+		return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(c.id.ID)
+	}
+	// Tables can be paused before the changefeed is resumed, check them only
+	// if the schema is available.
+	if c.initialized {
+		tables, err := c.ddlManager.allPhysicalTables(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		replicated := make(map[model.TableID]struct{}, len(tables))
+		for _, tableID := range tables {
+			replicated[tableID] = struct{}{}
+		}
+		for _, tableID := range tableIDs {
+			if _, ok := replicated[tableID]; !ok {
+				return cerror.ErrTableNotReplicated.GenWithStackByArgs(tableID, c.id.ID)
+			}
+		}
+	}
+
+	c.state.PatchStatus(
+		func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			if status == nil {
+				return nil, false, nil
+			}
+			changed := false
+			for _, tableID := range tableIDs {
+				if _, ok := status.PausedTables[tableID]; ok {
+					continue
+				}
+				if status.PausedTables == nil {
+					status.PausedTables = make(map[model.TableID]model.Ts)
+				}
+				// A resuming table has not caught up, it's paused at the ts
+				// it's resumed from.
+				ts := status.CheckpointTs
+				if resumeTs, ok := status.ResumingTables[tableID]; ok {
+					ts = resumeTs
+					delete(status.ResumingTables, tableID)
+				}
+				status.PausedTables[tableID] = ts
+				changed = true
+			}
+			if len(status.ResumingTables) == 0 {
+				status.ResumingTables = nil
+			}
+			return status, changed, nil
+		})
+	// Tables paused while resuming must be requested again once resumed.
+	for _, tableID := range tableIDs {
+		delete(c.resumeRequested, tableID)
+	}
+	log.Info("pause tables of changefeed",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.Int64s("tableIDs", tableIDs),
+		zap.Uint64("checkpointTs", c.state.Status.CheckpointTs))
+	return nil
+}
+
+// resumeTables resumes replication of the paused tables from the ts they are
+// paused at. They are resuming until they catch up with the checkpoint ts of
+// the changefeed, see finishResumingTables.
+func (c *changefeed) resumeTables(tableIDs []model.TableID) error {
+	if c.state.Status == nil {
+		return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(c.id.ID)
+	}
+	for _, tableID := range tableIDs {
+		if _, ok := c.state.Status.PausedTables[tableID]; !ok {
+			return cerror.ErrTableNotPaused.GenWithStackByArgs(tableID, c.id.ID)
+		}
+	}
+
+	c.state.PatchStatus(
+		func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			if status == nil {
+				return nil, false, nil
+			}
+			changed := false
+			for _, tableID := range tableIDs {
+				ts, ok := status.PausedTables[tableID]
+				if !ok {
+					continue
+				}
+				delete(status.PausedTables, tableID)
+				if status.ResumingTables == nil {
+					status.ResumingTables = make(map[model.TableID]model.Ts)
+				}
+				status.ResumingTables[tableID] = ts
+				changed = true
+			}
+			if len(status.PausedTables) == 0 {
+				status.PausedTables = nil
+			}
+			return status, changed, nil
+		})
+	log.Info("resume tables of changefeed",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.Int64s("tableIDs", tableIDs),
+		zap.Uint64("checkpointTs", c.state.Status.CheckpointTs))
+	return nil
+}
+
+// excludePausedTables returns tables that are not paused.
+func (c *changefeed) excludePausedTables(tableIDs []model.TableID) []model.TableID {
+	paused := c.state.Status.PausedTables
+	if len(paused) == 0 {
+		return tableIDs
+	}
+	// Do not modify tableIDs in place, it's cached by the ddl manager.
+	result := make([]model.TableID, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		if _, ok := paused[tableID]; !ok {
+			result = append(result, tableID)
+		}
+	}
+	return result
+}
+
+// requestResumingTables requests the scheduler to add resuming tables back
+// from the ts they are paused at. It must be called before the scheduler
+// ticks, otherwise the tables may be added at the checkpoint ts.
+func (c *changefeed) requestResumingTables() {
+	resumer, ok := c.scheduler.(scheduler.TableResumer)
+	if !ok {
+		return
+	}
+	for tableID := range c.resumeRequested {
+		if _, ok := c.state.Status.ResumingTables[tableID]; !ok {
+			delete(c.resumeRequested, tableID)
+		}
+	}
+	for tableID, ts := range c.state.Status.ResumingTables {
+		if _, ok := c.resumeRequested[tableID]; ok {
+			continue
+		}
+		if c.resumeRequested == nil {
+			c.resumeRequested = make(map[model.TableID]struct{})
+		}
+		resumer.ResumeTable(tableID, ts)
+		c.resumeRequested[tableID] = struct{}{}
+	}
+}
+
+// finishResumingTables returns the checkpoint ts to update the status. The
+// checkpoint ts in the status never regresses while resuming tables catch up,
+// they finish resuming once the checkpoint ts reaches it.
+func (c *changefeed) finishResumingTables(checkpointTs model.Ts) model.Ts {
+	if checkpointTs < c.state.Status.CheckpointTs {
+		return c.state.Status.CheckpointTs
+	}
+	caughtUp := c.state.Status.ResumingTables
+	if len(caughtUp) == 0 {
+		return checkpointTs
+	}
+	c.state.PatchStatus(
+		func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			if status == nil {
+				return nil, false, nil
+			}
+			changed := false
+			for tableID, ts := range caughtUp {
+				// Tables resumed during the tick are not checked.
+				if resumeTs, ok := status.ResumingTables[tableID]; ok && resumeTs == ts {
+					delete(status.ResumingTables, tableID)
+					changed = true
+				}
+			}
+			if len(status.ResumingTables) == 0 {
+				status.ResumingTables = nil
+			}
+			return status, changed, nil
+		})
+	log.Info("resuming tables of changefeed caught up",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.Any("tables", caughtUp),
+		zap.Uint64("checkpointTs", checkpointTs))
+	return checkpointTs
+}
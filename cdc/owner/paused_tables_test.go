@@ -0,0 +1,116 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	cdcContext "github.com/pingcap/tiflow/pkg/context"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseResumeTables(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	defer cf.Close(ctx)
+
+	// The status is not created yet.
+	err := cf.pauseTables(ctx, []model.TableID{1})
+	require.True(t, cerror.ErrChangeFeedNotExists.Equal(err))
+
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	checkpointTs := cf.state.Status.CheckpointTs
+	require.Equal(t, checkpointTs+10, cf.finishResumingTables(checkpointTs+10))
+
+	require.Nil(t, cf.pauseTables(ctx, []model.TableID{1, 2}))
+	tester.MustApplyPatches()
+	require.Equal(t, map[model.TableID]model.Ts{
+		1: checkpointTs, 2: checkpointTs,
+	}, cf.state.Status.PausedTables)
+
+	// Paused tables are not scheduled, and they do not hold the checkpoint
+	// ts, which never regresses.
+	tables := []model.TableID{1, 2, 3}
+	require.Equal(t, []model.TableID{3}, cf.excludePausedTables(tables))
+	require.Equal(t, []model.TableID{1, 2, 3}, tables)
+	require.Equal(t, checkpointTs+10, cf.finishResumingTables(checkpointTs+10))
+	require.Equal(t, checkpointTs, cf.finishResumingTables(checkpointTs-1))
+
+	// Pausing a paused table keeps its checkpoint ts.
+	cf.state.PatchStatus(
+		func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			status.CheckpointTs = checkpointTs + 5
+			return status, true, nil
+		})
+	tester.MustApplyPatches()
+	require.Nil(t, cf.pauseTables(ctx, []model.TableID{2, 3}))
+	tester.MustApplyPatches()
+	require.Equal(t, map[model.TableID]model.Ts{
+		1: checkpointTs, 2: checkpointTs, 3: checkpointTs + 5,
+	}, cf.state.Status.PausedTables)
+	require.Equal(t, checkpointTs, cf.state.Status.MinTableCheckpointTs())
+
+	// Resumed tables are added back from the ts they are paused at.
+	err = cf.resumeTables([]model.TableID{1, 4})
+	require.True(t, cerror.ErrTableNotPaused.Equal(err))
+	require.Nil(t, cf.resumeTables([]model.TableID{1, 2}))
+	tester.MustApplyPatches()
+	require.Equal(t, map[model.TableID]model.Ts{
+		3: checkpointTs + 5,
+	}, cf.state.Status.PausedTables)
+	require.Equal(t, map[model.TableID]model.Ts{
+		1: checkpointTs, 2: checkpointTs,
+	}, cf.state.Status.ResumingTables)
+	require.Equal(t, []model.TableID{1, 2}, cf.excludePausedTables([]model.TableID{1, 2, 3}))
+	mockScheduler := &mockScheduler{}
+	cf.scheduler = mockScheduler
+	cf.requestResumingTables()
+	require.Equal(t, cf.state.Status.ResumingTables, mockScheduler.resumeTables)
+	// Tables are requested only once.
+	mockScheduler.resumeTables = nil
+	cf.requestResumingTables()
+	require.Nil(t, mockScheduler.resumeTables)
+
+	// Pausing a resuming table keeps the ts it's resumed from.
+	require.Nil(t, cf.pauseTables(ctx, []model.TableID{1}))
+	tester.MustApplyPatches()
+	require.Equal(t, map[model.TableID]model.Ts{
+		1: checkpointTs, 3: checkpointTs + 5,
+	}, cf.state.Status.PausedTables)
+	require.Equal(t, map[model.TableID]model.Ts{
+		2: checkpointTs,
+	}, cf.state.Status.ResumingTables)
+
+	// Resuming tables finish once the checkpoint ts catches up.
+	require.Equal(t, checkpointTs+5, cf.finishResumingTables(checkpointTs+4))
+	tester.MustApplyPatches()
+	require.Len(t, cf.state.Status.ResumingTables, 1)
+	require.Equal(t, checkpointTs+6, cf.finishResumingTables(checkpointTs+6))
+	tester.MustApplyPatches()
+	require.Nil(t, cf.state.Status.ResumingTables)
+
+	require.Nil(t, cf.resumeTables([]model.TableID{1, 3}))
+	tester.MustApplyPatches()
+	require.Nil(t, cf.state.Status.PausedTables)
+	require.Equal(t, tables, cf.excludePausedTables(tables))
+	// The table paused and resumed again is requested again.
+	cf.requestResumingTables()
+	require.Equal(t, map[model.TableID]model.Ts{
+		1: checkpointTs, 3: checkpointTs + 5,
+	}, mockScheduler.resumeTables)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// resetTable recreates replication of the table from ts. The table is
+// removed from its capture, which drops its states, e.g. sorted events, and
+// then added back from ts. The checkpoint ts of the changefeed is used if ts
+// is 0. ts must not be smaller than the checkpoint ts of the changefeed,
+// because the schema of the table before it may be unavailable.
+func (c *changefeed) resetTable(ctx context.Context, tableID model.TableID, ts model.Ts) error {
+	if c.state.Status == nil {
+		return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(c.id.ID)
+	}
+	// Scheduler is created lazily, it is nil before initialization.
+	if !c.initialized || c.scheduler == nil {
+		return cerror.ErrSchedulerRequestFailed.GenWithStackByArgs(
+			"changefeed is not initialized")
+	}
+	if _, ok := c.state.Status.PausedTables[tableID]; ok {
+		return cerror.ErrTableNotReplicated.GenWithStackByArgs(tableID, c.id.ID)
+	}
+	// Resuming tables lag behind the checkpoint ts, they can't be reset
+	// from it.
+	if _, ok := c.state.Status.ResumingTables[tableID]; ok {
+		return cerror.ErrTableNotReplicated.GenWithStackByArgs(tableID, c.id.ID)
+	}
+	tables, err := c.ddlManager.allPhysicalTables(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	replicated := false
+	for _, id := range tables {
+		if id == tableID {
+			replicated = true
+			break
+		}
+	}
+	if !replicated {
+		return cerror.ErrTableNotReplicated.GenWithStackByArgs(tableID, c.id.ID)
+	}
+
+	checkpointTs := c.state.Status.CheckpointTs
+	if ts == 0 {
+		ts = checkpointTs
+	}
+	if ts < checkpointTs {
+		return cerror.ErrTableResetTsInvalid.GenWithStackByArgs(
+			ts, tableID, checkpointTs, c.id.ID)
+	}
+	c.scheduler.ResetTable(tableID, ts)
+	log.Info("reset table of changefeed",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.Int64("tableID", tableID),
+		zap.Uint64("ts", ts),
+		zap.Uint64("checkpointTs", checkpointTs))
+	return nil
+}
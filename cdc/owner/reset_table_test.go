@@ -0,0 +1,73 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/entry"
+	"github.com/pingcap/tiflow/cdc/model"
+	cdcContext "github.com/pingcap/tiflow/pkg/context"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetTable(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	job := helper.DDL2Job("create table test0.table0(id int primary key)")
+	tableID := job.TableID
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext4Test(context.Background(), true)
+	ctx.ChangefeedVars().Info.StartTs = startTs
+
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	cf.upstream.KVStorage = helper.Storage()
+	defer cf.Close(ctx)
+
+	// The status is not created yet.
+	err := cf.resetTable(ctx, tableID, 0)
+	require.True(t, cerror.ErrChangeFeedNotExists.Equal(err))
+
+	// pre check
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	err = cf.resetTable(ctx, tableID, 0)
+	require.True(t, cerror.ErrSchedulerRequestFailed.Equal(err))
+
+	// initialize
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	checkpointTs := cf.state.Status.CheckpointTs
+
+	err = cf.resetTable(ctx, tableID+1, 0)
+	require.True(t, cerror.ErrTableNotReplicated.Equal(err))
+	err = cf.resetTable(ctx, tableID, checkpointTs-1)
+	require.True(t, cerror.ErrTableResetTsInvalid.Equal(err))
+
+	scheduler := cf.scheduler.(*mockScheduler)
+	require.Nil(t, cf.resetTable(ctx, tableID, 0))
+	require.Equal(t, checkpointTs, scheduler.resetTables[tableID])
+	require.Nil(t, cf.resetTable(ctx, tableID, checkpointTs+10))
+	require.Equal(t, checkpointTs+10, scheduler.resetTables[tableID])
+
+	// Paused tables can't be reset.
+	require.Nil(t, cf.pauseTables(ctx, []model.TableID{tableID}))
+	tester.MustApplyPatches()
+	err = cf.resetTable(ctx, tableID, 0)
+	require.True(t, cerror.ErrTableNotReplicated.Equal(err))
+}
@@ -15,10 +15,13 @@ package owner
 
 import (
 	"context"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
 )
 
 // StatusProvider provide some func to get meta-information from owner
@@ -47,6 +50,39 @@ type StatusProvider interface {
 
 	// IsHealthy return true if the cluster is healthy
 	IsHealthy(ctx context.Context) (bool, error)
+
+	// GetChangeFeedCheckpointHistory returns the checkpoint samples of a
+	// changefeed between from and to, sorted by time.
+	GetChangeFeedCheckpointHistory(ctx context.Context,
+		changefeedID model.ChangeFeedID, from, to time.Time,
+	) ([]model.CheckpointSample, error)
+
+	// GetSchedulerSnapshot returns a redacted snapshot of the scheduler
+	// states of a changefeed in JSON.
+	GetSchedulerSnapshot(ctx context.Context,
+		changefeedID model.ChangeFeedID) ([]byte, error)
+
+	// GetCaptureLoads returns the loads of captures reported in scheduler
+	// heartbeats. Captures which haven't reported are not returned.
+	GetCaptureLoads(ctx context.Context) (map[model.CaptureID]*model.CaptureLoad, error)
+
+	// GetChangeFeedWarnings returns the aggregated recent warnings of a
+	// changefeed, the most recently seen first.
+	GetChangeFeedWarnings(ctx context.Context,
+		changefeedID model.ChangeFeedID) ([]model.ChangefeedWarning, error)
+
+	// GetSchedulePlan returns the schedule operations the scheduler of a
+	// changefeed would perform, without performing them. If rebalance is
+	// true, a manual rebalance is planned as if it's requested.
+	GetSchedulePlan(ctx context.Context,
+		changefeedID model.ChangeFeedID, rebalance bool,
+	) ([]model.ScheduleOperation, error)
+
+	// GetScheduleHistory returns the recently done schedule tasks of a
+	// changefeed in the order they are done, followed by the running ones.
+	// It returns no task if the changefeed is not running.
+	GetScheduleHistory(ctx context.Context,
+		changefeedID model.ChangeFeedID) ([]model.ScheduleTaskRecord, error)
 }
 
 // QueryType is the type of different queries.
@@ -65,12 +101,31 @@ const (
 	QueryCaptures
 	// QueryHealth is the type of query cluster health info.
 	QueryHealth
+	// QueryCheckpointHistory is the type of query checkpoint samples of a
+	// changefeed in the memory of the owner.
+	QueryCheckpointHistory
+	// QuerySchedulerSnapshot is the type of query a snapshot of the
+	// scheduler states of a changefeed.
+	QuerySchedulerSnapshot
+	// QueryCaptureLoads is the type of query loads of captures.
+	QueryCaptureLoads
+	// QueryChangefeedWarnings is the type of query aggregated warnings of a
+	// changefeed.
+	QueryChangefeedWarnings
+	// QuerySchedulePlan is the type of query schedule operations planned
+	// by the scheduler of a changefeed.
+	QuerySchedulePlan
+	// QueryScheduleHistory is the type of query schedule tasks performed by
+	// the scheduler of a changefeed.
+	QueryScheduleHistory
 )
 
 // Query wraps query command and return results.
 type Query struct {
 	Tp           QueryType
 	ChangeFeedID model.ChangeFeedID
+	// for QuerySchedulePlan only
+	Rebalance bool
 
 	Data interface{}
 }
@@ -179,6 +234,100 @@ func (p *ownerStatusProvider) IsHealthy(ctx context.Context) (bool, error) {
 	return query.Data.(bool), nil
 }
 
+func (p *ownerStatusProvider) GetChangeFeedCheckpointHistory(ctx context.Context,
+	changefeedID model.ChangeFeedID, from, to time.Time,
+) ([]model.CheckpointSample, error) {
+	query := &Query{
+		Tp:           QueryCheckpointHistory,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	samples := query.Data.([]model.CheckpointSample)
+
+	// Samples before the ones in memory may have been offloaded, they are
+	// read outside the owner to avoid blocking it.
+	uri := config.GetGlobalServerConfig().CheckpointHistory.Storage
+	if uri == "" || (len(samples) > 0 && !samples[0].Time.After(from)) {
+		return mergeCheckpointSamples(from, to, samples), nil
+	}
+	extStorage, err := util.GetExternalStorageFromURI(ctx, uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offloaded, err := readCheckpointSegments(ctx, extStorage, changefeedID, from, to)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return mergeCheckpointSamples(from, to, offloaded, samples), nil
+}
+
+func (p *ownerStatusProvider) GetSchedulerSnapshot(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) ([]byte, error) {
+	query := &Query{
+		Tp:           QuerySchedulerSnapshot,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.([]byte), nil
+}
+
+func (p *ownerStatusProvider) GetCaptureLoads(ctx context.Context) (
+	map[model.CaptureID]*model.CaptureLoad, error,
+) {
+	query := &Query{
+		Tp: QueryCaptureLoads,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.(map[model.CaptureID]*model.CaptureLoad), nil
+}
+
+func (p *ownerStatusProvider) GetChangeFeedWarnings(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) ([]model.ChangefeedWarning, error) {
+	query := &Query{
+		Tp:           QueryChangefeedWarnings,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.([]model.ChangefeedWarning), nil
+}
+
+func (p *ownerStatusProvider) GetSchedulePlan(ctx context.Context,
+	changefeedID model.ChangeFeedID, rebalance bool,
+) ([]model.ScheduleOperation, error) {
+	query := &Query{
+		Tp:           QuerySchedulePlan,
+		ChangeFeedID: changefeedID,
+		Rebalance:    rebalance,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.([]model.ScheduleOperation), nil
+}
+
+func (p *ownerStatusProvider) GetScheduleHistory(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) ([]model.ScheduleTaskRecord, error) {
+	query := &Query{
+		Tp:           QueryScheduleHistory,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.([]model.ScheduleTaskRecord), nil
+}
+
 func (p *ownerStatusProvider) sendQueryToOwner(ctx context.Context, query *Query) error {
 	doneCh := make(chan error, 1)
 	p.owner.Query(query, doneCh)
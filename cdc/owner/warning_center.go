@@ -0,0 +1,142 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+const (
+	// warningCenterCapacity is the max number of distinct warnings kept for
+	// a changefeed, the least recently seen ones are evicted.
+	warningCenterCapacity = 32
+	// warningRetention is how long a warning is kept after it's last seen.
+	warningRetention = time.Hour
+	// warningPersistInterval is the min interval of persisting a repeated
+	// warning to the changefeed info.
+	warningPersistInterval = time.Minute
+)
+
+// warningCenter aggregates warnings of a changefeed in memory. Warnings with
+// the same code and the same message except numbers, e.g., IDs of regions
+// and tables, are considered the same one, and are counted.
+// It's not thread-safe, and it's only accessed in owner ticks.
+type warningCenter struct {
+	warnings map[string]*warningEntry
+}
+
+type warningEntry struct {
+	model.ChangefeedWarning
+	lastPersisted time.Time
+}
+
+func newWarningCenter() *warningCenter {
+	return &warningCenter{warnings: make(map[string]*warningEntry)}
+}
+
+// record adds the warning, it returns true if the warning should be
+// persisted, i.e., it's new or it's not persisted recently.
+func (w *warningCenter) record(warning *model.RunningError) bool {
+	now := warning.Time
+	w.expire(now)
+
+	key := warningKey(warning)
+	entry, ok := w.warnings[key]
+	if !ok {
+		if len(w.warnings) >= warningCenterCapacity {
+			w.evict()
+		}
+		entry = &warningEntry{
+			ChangefeedWarning: model.ChangefeedWarning{
+				Code:      warning.Code,
+				FirstSeen: now,
+			},
+		}
+		w.warnings[key] = entry
+	}
+	entry.Message = warning.Message
+	entry.Addr = warning.Addr
+	entry.LastSeen = now
+	entry.Count++
+
+	if ok && now.Sub(entry.lastPersisted) < warningPersistInterval {
+		return false
+	}
+	entry.lastPersisted = now
+	return true
+}
+
+// list returns warnings seen in the retention, the most recently seen first.
+func (w *warningCenter) list(now time.Time) []model.ChangefeedWarning {
+	w.expire(now)
+	result := make([]model.ChangefeedWarning, 0, len(w.warnings))
+	for _, entry := range w.warnings {
+		result = append(result, entry.ChangefeedWarning)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].LastSeen.Equal(result[j].LastSeen) {
+			return result[i].LastSeen.After(result[j].LastSeen)
+		}
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+func (w *warningCenter) expire(now time.Time) {
+	for key, entry := range w.warnings {
+		if now.Sub(entry.LastSeen) > warningRetention {
+			delete(w.warnings, key)
+		}
+	}
+}
+
+// evict removes the least recently seen warning.
+func (w *warningCenter) evict() {
+	var (
+		oldestKey string
+		oldest    *warningEntry
+	)
+	for key, entry := range w.warnings {
+		if oldest == nil || entry.LastSeen.Before(oldest.LastSeen) {
+			oldestKey, oldest = key, entry
+		}
+	}
+	delete(w.warnings, oldestKey)
+}
+
+// warningKey replaces numbers in the message with '?', so that warnings
+// about different regions or tables are aggregated.
+func warningKey(warning *model.RunningError) string {
+	var b strings.Builder
+	b.WriteString(warning.Code)
+	b.WriteByte(':')
+	inNumber := false
+	for _, r := range warning.Message {
+		if unicode.IsDigit(r) {
+			if !inNumber {
+				b.WriteByte('?')
+			}
+			inNumber = true
+			continue
+		}
+		inNumber = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
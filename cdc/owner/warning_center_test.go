@@ -0,0 +1,74 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarningCenter(t *testing.T) {
+	t.Parallel()
+
+	w := newWarningCenter()
+	now := time.Now()
+	warning := func(at time.Duration, code, message string) *model.RunningError {
+		return &model.RunningError{
+			Time: now.Add(at), Addr: "127.0.0.1:8300", Code: code, Message: message,
+		}
+	}
+
+	// Warnings differ in numbers are aggregated.
+	require.True(t, w.record(warning(0, "A", "resolved ts stuck region 123")))
+	require.False(t, w.record(warning(time.Second, "A", "resolved ts stuck region 456")))
+	require.True(t, w.record(warning(2*time.Second, "B", "sink flush slow")))
+	// Repeated warnings are persisted once per interval.
+	require.True(t, w.record(warning(warningPersistInterval, "A", "resolved ts stuck region 7")))
+
+	warnings := w.list(now.Add(warningPersistInterval))
+	require.Equal(t, []model.ChangefeedWarning{{
+		Code:      "A",
+		Message:   "resolved ts stuck region 7",
+		Addr:      "127.0.0.1:8300",
+		FirstSeen: now,
+		LastSeen:  now.Add(warningPersistInterval),
+		Count:     3,
+	}, {
+		Code:      "B",
+		Message:   "sink flush slow",
+		Addr:      "127.0.0.1:8300",
+		FirstSeen: now.Add(2 * time.Second),
+		LastSeen:  now.Add(2 * time.Second),
+		Count:     1,
+	}}, warnings)
+
+	// Warnings are expired after the retention.
+	warnings = w.list(now.Add(2*time.Second + warningRetention + time.Second))
+	require.Len(t, warnings, 1)
+	require.Equal(t, "A", warnings[0].Code)
+	require.Empty(t, w.list(now.Add(2*warningRetention)))
+
+	// The least recently seen warnings are evicted.
+	for i := 0; i < warningCenterCapacity+1; i++ {
+		w.record(warning(time.Duration(i)*time.Second, fmt.Sprintf("C%d", i), "message"))
+	}
+	warnings = w.list(now.Add(warningCenterCapacity * time.Second))
+	require.Len(t, warnings, warningCenterCapacity)
+	require.Equal(t, fmt.Sprintf("C%d", warningCenterCapacity), warnings[0].Code)
+	require.Equal(t, "C1", warnings[warningCenterCapacity-1].Code)
+}
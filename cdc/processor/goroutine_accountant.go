@@ -0,0 +1,157 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/leakutil"
+	"go.uber.org/zap"
+)
+
+// goroutineAccountant counts the goroutines of processors by the pprof labels
+// of their changefeeds. Goroutines left after a processor is closed are
+// leaked, they're still counted in the budget of the changefeed if it's
+// resumed, so a changefeed leaking goroutines across pause/resume cycles
+// exceeds the budget eventually.
+type goroutineAccountant struct {
+	cfg       *config.GoroutineBudgetConfig
+	lastCheck time.Time
+	// closedAt records when the processors are closed, their goroutines
+	// aren't leaked until a check interval elapses.
+	closedAt map[model.ChangeFeedID]time.Time
+	// leaked records the changefeeds which have leaked goroutines.
+	leaked map[model.ChangeFeedID]struct{}
+
+	countGoroutines func(key string) (map[string]int, error)
+}
+
+func newGoroutineAccountant(cfg *config.GoroutineBudgetConfig) *goroutineAccountant {
+	return &goroutineAccountant{
+		cfg:             cfg,
+		closedAt:        make(map[model.ChangeFeedID]time.Time),
+		leaked:          make(map[model.ChangeFeedID]struct{}),
+		countGoroutines: leakutil.CountGoroutinesByLabel,
+	}
+}
+
+// do calls f with the goroutine labeled by the changefeed.
+func (a *goroutineAccountant) do(changefeedID model.ChangeFeedID, f func()) {
+	if !a.cfg.Enable {
+		f()
+		return
+	}
+	leakutil.DoWithLabel(leakutil.ChangefeedLabel, changefeedID.String(), f)
+}
+
+func (a *goroutineAccountant) onProcessorClosed(changefeedID model.ChangeFeedID, now time.Time) {
+	if !a.cfg.Enable {
+		return
+	}
+	a.closedAt[changefeedID] = now
+	processorGoroutineGauge.DeleteLabelValues(changefeedID.Namespace, changefeedID.ID)
+}
+
+// check counts goroutines if the check interval elapses. It returns the
+// number of goroutines of the processors which exceed the budget and should
+// be restarted.
+func (a *goroutineAccountant) check(
+	now time.Time, processors map[model.ChangeFeedID]*processor,
+) map[model.ChangeFeedID]int {
+	interval := time.Duration(a.cfg.CheckInterval)
+	if !a.cfg.Enable || now.Sub(a.lastCheck) < interval {
+		return nil
+	}
+	a.lastCheck = now
+	counts, err := a.countGoroutines(leakutil.ChangefeedLabel)
+	if err != nil {
+		log.Warn("failed to count goroutines of processors", zap.Error(err))
+		return nil
+	}
+
+	var exceeded map[model.ChangeFeedID]int
+	budget := a.cfg.MaxGoroutinesPerChangefeed
+	for changefeedID := range processors {
+		count := counts[changefeedID.String()]
+		processorGoroutineGauge.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID).Set(float64(count))
+		if budget <= 0 || count <= budget {
+			continue
+		}
+		log.Warn("processor exceeds the goroutine budget",
+			zap.String("namespace", changefeedID.Namespace),
+			zap.String("changefeed", changefeedID.ID),
+			zap.Int("goroutines", count),
+			zap.Int("budget", budget),
+			zap.Bool("restart", a.cfg.RestartOnExceeded))
+		processorGoroutineBudgetExceededCounter.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID).Inc()
+		if a.cfg.RestartOnExceeded {
+			if exceeded == nil {
+				exceeded = make(map[model.ChangeFeedID]int)
+			}
+			exceeded[changefeedID] = count
+		}
+	}
+
+	for label, count := range counts {
+		changefeedID, ok := parseChangefeedLabel(label)
+		if !ok {
+			continue
+		}
+		if _, ok := processors[changefeedID]; ok {
+			continue
+		}
+		if closedAt, ok := a.closedAt[changefeedID]; ok && now.Sub(closedAt) < interval {
+			continue
+		}
+		log.Warn("goroutines of the closed processor are leaked",
+			zap.String("namespace", changefeedID.Namespace),
+			zap.String("changefeed", changefeedID.ID),
+			zap.Int("goroutines", count))
+		processorGoroutineGauge.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID).Set(float64(count))
+		processorLeakedGoroutineGauge.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID).Set(float64(count))
+		a.leaked[changefeedID] = struct{}{}
+	}
+
+	for changefeedID := range a.closedAt {
+		if _, ok := processors[changefeedID]; ok || counts[changefeedID.String()] == 0 {
+			delete(a.closedAt, changefeedID)
+		}
+	}
+	for changefeedID := range a.leaked {
+		if counts[changefeedID.String()] == 0 {
+			delete(a.leaked, changefeedID)
+			processorLeakedGoroutineGauge.DeleteLabelValues(changefeedID.Namespace, changefeedID.ID)
+			if _, ok := processors[changefeedID]; !ok {
+				processorGoroutineGauge.DeleteLabelValues(changefeedID.Namespace, changefeedID.ID)
+			}
+		}
+	}
+	return exceeded
+}
+
+func parseChangefeedLabel(label string) (model.ChangeFeedID, bool) {
+	namespace, id, ok := strings.Cut(label, "/")
+	if !ok {
+		return model.ChangeFeedID{}, false
+	}
+	return model.ChangeFeedID{Namespace: namespace, ID: id}, true
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/leakutil"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoroutineAccountant(t *testing.T) {
+	cfg := config.GetDefaultServerConfig().GoroutineBudget
+	cfg.MaxGoroutinesPerChangefeed = 10
+	cfg.RestartOnExceeded = true
+	a := newGoroutineAccountant(cfg)
+	counts := map[string]int{}
+	a.countGoroutines = func(key string) (map[string]int, error) {
+		require.Equal(t, leakutil.ChangefeedLabel, key)
+		return counts, nil
+	}
+
+	running := model.ChangeFeedID4Test("ns", "running")
+	closed := model.ChangeFeedID4Test("ns", "closed")
+	processors := map[model.ChangeFeedID]*processor{running: nil, closed: nil}
+	now := time.Now()
+	interval := time.Duration(cfg.CheckInterval)
+
+	counts[running.String()] = 5
+	counts[closed.String()] = 3
+	require.Empty(t, a.check(now, processors))
+	require.Equal(t, float64(5), testutil.ToFloat64(
+		processorGoroutineGauge.WithLabelValues("ns", "running")))
+
+	// Goroutines aren't leaked before the check interval elapses.
+	delete(processors, closed)
+	a.onProcessorClosed(closed, now.Add(interval/2))
+	now = now.Add(interval)
+	require.Empty(t, a.check(now, processors))
+	require.Empty(t, a.leaked)
+	// Skip the check before the check interval elapses.
+	counts[running.String()] = 11
+	require.Empty(t, a.check(now.Add(time.Second), processors))
+
+	// Goroutines left after the check interval are leaked, and the running
+	// processor exceeds the budget.
+	now = now.Add(interval)
+	require.Equal(t, map[model.ChangeFeedID]int{running: 11}, a.check(now, processors))
+	require.Contains(t, a.leaked, closed)
+	require.Equal(t, float64(3), testutil.ToFloat64(
+		processorLeakedGoroutineGauge.WithLabelValues("ns", "closed")))
+
+	// The leaked goroutines are counted in the budget after resuming.
+	processors[closed] = nil
+	counts[closed.String()] = 13
+	counts[running.String()] = 5
+	now = now.Add(interval)
+	require.Equal(t, map[model.ChangeFeedID]int{closed: 13}, a.check(now, processors))
+	require.Contains(t, a.leaked, closed)
+	require.Empty(t, a.closedAt)
+
+	// The leaked goroutines exit.
+	delete(counts, closed.String())
+	now = now.Add(interval)
+	require.Empty(t, a.check(now, processors))
+	require.Empty(t, a.leaked)
+
+	// Only alarm if restart-on-exceeded is disabled.
+	cfg.RestartOnExceeded = false
+	counts[running.String()] = 11
+	now = now.Add(interval)
+	require.Empty(t, a.check(now, processors))
+}
+
+func TestGoroutineAccountantDisabled(t *testing.T) {
+	cfg := config.GetDefaultServerConfig().GoroutineBudget
+	cfg.Enable = false
+	a := newGoroutineAccountant(cfg)
+	a.countGoroutines = func(key string) (map[string]int, error) {
+		require.Fail(t, "unexpected call")
+		return nil, nil
+	}
+	called := false
+	a.do(model.DefaultChangeFeedID("test"), func() { called = true })
+	require.True(t, called)
+	a.onProcessorClosed(model.DefaultChangeFeedID("test"), time.Now())
+	require.Empty(t, a.closedAt)
+	require.Empty(t, a.check(time.Now(), nil))
+}
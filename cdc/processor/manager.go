@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/prometheus/client_golang/prometheus"
@@ -36,6 +37,7 @@ type commandTp int
 const (
 	commandTpUnknown commandTp = iota
 	commandTpWriteDebugInfo
+	commandTpQueryTableCount
 	processorLogsWarnDuration = 1 * time.Second
 )
 
@@ -54,6 +56,10 @@ type Manager interface {
 	Close()
 
 	WriteDebugInfo(ctx context.Context, w io.Writer, done chan<- error)
+
+	// QueryTableCount sends the number of tables replicated by all
+	// processors of the capture to tableCh.
+	QueryTableCount(ctx context.Context, tableCh chan int, done chan<- error)
 }
 
 // managerImpl is a manager of processor, which maintains the state and behavior of processors
@@ -75,6 +81,8 @@ type managerImpl struct {
 	) *processor
 	cfg *config.SchedulerConfig
 
+	goroutineAccountant *goroutineAccountant
+
 	metricProcessorCloseDuration prometheus.Observer
 }
 
@@ -94,6 +102,8 @@ func NewManager(
 		newProcessor:                 newProcessor,
 		metricProcessorCloseDuration: processorCloseDuration,
 		cfg:                          cfg,
+		goroutineAccountant: newGoroutineAccountant(
+			config.GetGlobalServerConfig().GoroutineBudget),
 	}
 }
 
@@ -138,7 +148,12 @@ func (m *managerImpl) Tick(stdCtx context.Context, state orchestrator.ReactorSta
 			m.closeProcessor(changefeedID)
 			continue
 		}
-		if err := p.Tick(ctx); err != nil {
+		// Goroutines created by the processor are labeled by the changefeed.
+		var err error
+		m.goroutineAccountant.do(changefeedID, func() {
+			err = p.Tick(ctx)
+		})
+		if err != nil {
 			// processor have already patched its error to tell the owner
 			// manager can just close the processor and continue to tick other processors
 			m.closeProcessor(changefeedID)
@@ -153,6 +168,15 @@ func (m *managerImpl) Tick(stdCtx context.Context, state orchestrator.ReactorSta
 		}
 	}
 
+	exceeded := m.goroutineAccountant.check(time.Now(), m.processors)
+	for changefeedID, count := range exceeded {
+		// Restart the changefeed by reporting a retryable error to the owner.
+		_ = m.processors[changefeedID].handleErr(
+			cerror.ErrProcessorGoroutineBudgetExceeded.GenWithStackByArgs(
+				count, m.goroutineAccountant.cfg.MaxGoroutinesPerChangefeed))
+		m.closeProcessor(changefeedID)
+	}
+
 	if err := m.upstreamManager.Tick(stdCtx, globalState); err != nil {
 		return state, errors.Trace(err)
 	}
@@ -180,6 +204,7 @@ func (m *managerImpl) closeProcessor(changefeedID model.ChangeFeedID) {
 				zap.Error(err))
 		}
 		delete(m.processors, changefeedID)
+		m.goroutineAccountant.onProcessorClosed(changefeedID, time.Now())
 	}
 }
 
@@ -203,6 +228,16 @@ func (m *managerImpl) WriteDebugInfo(
 	}
 }
 
+// QueryTableCount query the total number of tables in the manager.
+func (m *managerImpl) QueryTableCount(
+	ctx context.Context, tableCh chan int, done chan<- error,
+) {
+	err := m.sendCommand(ctx, commandTpQueryTableCount, tableCh, done)
+	if err != nil {
+		log.Warn("send command commandTpQueryTableCount failed", zap.Error(err))
+	}
+}
+
 // sendCommands sends command to manager.
 // `done` is closed upon command completion or sendCommand returns error.
 func (m *managerImpl) sendCommand(
@@ -234,6 +269,16 @@ func (m *managerImpl) handleCommand() {
 		if err != nil {
 			cmd.done <- err
 		}
+	case commandTpQueryTableCount:
+		count := 0
+		for _, p := range m.processors {
+			count += p.getTableCount()
+		}
+		// Use non-blocking send in case the caller doesn't receive it.
+		select {
+		case cmd.payload.(chan int) <- count:
+		default:
+		}
 	default:
 		log.Warn("Unknown command in processor manager", zap.Any("command", cmd))
 	}
@@ -186,6 +186,13 @@ func TestDebugInfo(t *testing.T) {
 	<-doneM
 	require.Greater(t, len(buf.String()), 0)
 
+	// The processor has no table.
+	tableCh := make(chan int, 1)
+	doneM = make(chan error, 1)
+	s.manager.QueryTableCount(ctx, tableCh, doneM)
+	<-doneM
+	require.Equal(t, 0, <-tableCh)
+
 	// Stop tick so that we can close manager safely.
 	cancel()
 	<-done
@@ -292,3 +299,41 @@ func TestManagerLiveness(t *testing.T) {
 	s.liveness.Store(model.LivenessCaptureStopping)
 	require.Equal(t, model.LivenessCaptureStopping, p.liveness.Load())
 }
+
+func TestGoroutineBudgetExceeded(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(false)
+	s := &managerTester{}
+	s.resetSuit(ctx, t)
+	cfg := config.GetDefaultServerConfig().GoroutineBudget
+	cfg.MaxGoroutinesPerChangefeed = 10
+	cfg.RestartOnExceeded = true
+	s.manager.goroutineAccountant = newGoroutineAccountant(cfg)
+	changefeedID := model.DefaultChangeFeedID("test-changefeed")
+	s.manager.goroutineAccountant.countGoroutines = func(string) (map[string]int, error) {
+		return map[string]int{changefeedID.String(): 11}, nil
+	}
+
+	s.state.Changefeeds[changefeedID] = orchestrator.NewChangefeedReactorState(
+		etcd.DefaultCDCClusterID, changefeedID)
+	s.state.Changefeeds[changefeedID].PatchInfo(
+		func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+			return &model.ChangeFeedInfo{
+				SinkURI:    "blackhole://",
+				CreateTime: time.Now(),
+				StartTs:    0,
+				TargetTs:   math.MaxUint64,
+				Config:     config.GetDefaultReplicaConfig(),
+			}, true, nil
+		})
+	s.state.Changefeeds[changefeedID].PatchStatus(
+		func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			return &model.ChangeFeedStatus{}, true, nil
+		})
+	s.tester.MustApplyPatches()
+	_, err := s.manager.Tick(ctx, s.state)
+	s.tester.MustApplyPatches()
+	require.Nil(t, err)
+	require.Len(t, s.manager.processors, 0)
+	position := s.state.Changefeeds[changefeedID].TaskPositions[ctx.GlobalVars().CaptureInfo.ID]
+	require.Equal(t, "CDC:ErrProcessorGoroutineBudgetExceeded", position.Error.Code)
+}
@@ -32,6 +32,10 @@ import (
 type MemConsumeRecord struct {
 	ResolvedTs model.ResolvedTs
 	Size       uint64
+
+	// recordedAt is when the memory is recorded, it's used to calculate
+	// how long it takes to release the memory.
+	recordedAt time.Time
 }
 
 // MemQuota is used to trace memory usage.
@@ -60,6 +64,10 @@ type MemQuota struct {
 	mu sync.Mutex
 	// tableMemory is the memory usage of each table.
 	tableMemory *spanz.HashMap[[]*MemConsumeRecord]
+
+	// releaseObserver is called with the latency between recording and
+	// releasing the oldest released record.
+	releaseObserver func(latency time.Duration)
 }
 
 // NewMemQuota creates a MemQuota instance.
@@ -102,6 +110,13 @@ func NewMemQuota(changefeedID model.ChangeFeedID, totalBytes uint64, comp string
 	return m
 }
 
+// SetReleaseObserver sets a function which is called with the release latency
+// of the recorded memory, that is how long the downstream takes to consume the
+// data. It must be called before the MemQuota is used.
+func (m *MemQuota) SetReleaseObserver(observer func(latency time.Duration)) {
+	m.releaseObserver = observer
+}
+
 // TryAcquire returns true if the memory quota is available, otherwise returns false.
 func (m *MemQuota) TryAcquire(nBytes uint64) bool {
 	for {
@@ -183,6 +198,7 @@ func (m *MemQuota) Record(span tablepb.Span, resolved model.ResolvedTs, nBytes u
 	m.tableMemory.ReplaceOrInsert(span, append(m.tableMemory.GetV(span), &MemConsumeRecord{
 		ResolvedTs: resolved,
 		Size:       nBytes,
+		recordedAt: time.Now(),
 	}))
 }
 
@@ -210,6 +226,9 @@ func (m *MemQuota) Release(span tablepb.Span, resolved model.ResolvedTs) {
 	if toRelease == 0 {
 		return
 	}
+	if m.releaseObserver != nil {
+		m.releaseObserver(time.Since(records[0].recordedAt))
+	}
 
 	usedBytes := m.usedBytes.Load()
 	if usedBytes < toRelease {
@@ -17,6 +17,7 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/spanz"
@@ -187,6 +188,31 @@ func TestMemQuotaRecordAndRelease(t *testing.T) {
 	require.True(t, m.hasAvailable(300))
 }
 
+func TestMemQuotaReleaseObserver(t *testing.T) {
+	t.Parallel()
+
+	m := NewMemQuota(model.DefaultChangeFeedID("1"), 300, "")
+	defer m.Close()
+	var latencies []time.Duration
+	m.SetReleaseObserver(func(latency time.Duration) {
+		latencies = append(latencies, latency)
+	})
+	span := spanz.TableIDToComparableSpan(1)
+	m.AddTable(span)
+
+	require.True(t, m.TryAcquire(200))
+	m.Record(span, model.NewResolvedTs(100), 100)
+	m.Record(span, model.NewResolvedTs(200), 100)
+	// nothing is released.
+	m.Release(span, model.NewResolvedTs(99))
+	require.Empty(t, latencies)
+
+	time.Sleep(10 * time.Millisecond)
+	m.Release(span, model.NewResolvedTs(201))
+	require.Len(t, latencies, 1)
+	require.GreaterOrEqual(t, latencies[0], 10*time.Millisecond)
+}
+
 func TestMemQuotaRecordAndReleaseWithBatchID(t *testing.T) {
 	t.Parallel()
 
@@ -64,6 +64,41 @@ var (
 			Name:      "memory_consumption",
 			Help:      "processor's memory consumption estimated in bytes",
 		}, []string{"namespace", "changefeed"})
+	processorSnapshotTableGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "processor",
+			Name:      "snapshot_tables",
+			Help:      "the number of tables whose initial incremental scan is not finished",
+		}, []string{"namespace", "changefeed"})
+	processorSnapshotProgressGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "processor",
+			Name:      "snapshot_progress",
+			Help:      "the percentage of scanned regions of tables whose initial incremental scan is not finished",
+		}, []string{"namespace", "changefeed"})
+	processorGoroutineGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "processor",
+			Name:      "goroutines",
+			Help:      "the number of goroutines of processors, including the leaked ones",
+		}, []string{"namespace", "changefeed"})
+	processorLeakedGoroutineGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "processor",
+			Name:      "leaked_goroutines",
+			Help:      "the number of goroutines left after processors are closed",
+		}, []string{"namespace", "changefeed"})
+	processorGoroutineBudgetExceededCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "processor",
+			Name:      "goroutine_budget_exceeded_count",
+			Help:      "the number of times that processors exceed the goroutine budget",
+		}, []string{"namespace", "changefeed"})
 )
 
 // InitMetrics registers all metrics used in processor
@@ -74,6 +109,11 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(processorTickDuration)
 	registry.MustRegister(processorCloseDuration)
 	registry.MustRegister(processorMemoryGauge)
+	registry.MustRegister(processorSnapshotTableGauge)
+	registry.MustRegister(processorSnapshotProgressGauge)
+	registry.MustRegister(processorGoroutineGauge)
+	registry.MustRegister(processorLeakedGoroutineGauge)
+	registry.MustRegister(processorGoroutineBudgetExceededCounter)
 	sinkmanager.InitMetrics(registry)
 	memquota.InitMetrics(registry)
 }
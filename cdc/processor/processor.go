@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
+	tidbkv "github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tiflow/cdc/entry"
 	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -38,6 +39,7 @@ import (
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/keyspace"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/retry"
@@ -59,11 +61,15 @@ type processor struct {
 	captureInfo  *model.CaptureInfo
 	globalVars   *cdcContext.GlobalVars
 	changefeed   *orchestrator.ChangefeedReactorState
+	// keyspace is the keyspace the changefeed is scoped to.
+	keyspace string
 
 	upstream     *upstream.Upstream
 	lastSchemaTs model.Ts
 
 	filter filter.Filter
+	// resolvedTsIntervals decides the resolved ts interval of each table.
+	resolvedTsIntervals *puller.ResolvedTsIntervalMatcher
 
 	// To manager DDL events and schema storage.
 	ddlHandler component[*ddlHandler]
@@ -93,6 +99,8 @@ type processor struct {
 	metricProcessorErrorCounter  prometheus.Counter
 	metricProcessorTickDuration  prometheus.Observer
 	metricsProcessorMemoryGauge  prometheus.Gauge
+	metricSnapshotTableGauge     prometheus.Gauge
+	metricSnapshotProgressGauge  prometheus.Gauge
 }
 
 // checkReadyForMessages checks whether all necessary Etcd keys have been established.
@@ -143,7 +151,7 @@ func (p *processor) AddTableSpan(
 			// table is `prepared`, and a `isPrepare = false` request indicate that old table should
 			// be stopped on original capture already, it's safe to start replicating data now.
 			if !isPrepare {
-				if p.redo.r.Enabled() {
+				if p.redo.r.Enabled() && p.sinkManager.r.IsRedoCovered(span) {
 					var redoResolvedTs model.Ts
 					if barrier != nil {
 						redoResolvedTs = barrier.GlobalBarrierTs
@@ -195,10 +203,21 @@ func (p *processor) AddTableSpan(
 
 	p.sinkManager.r.AddTable(
 		span, startTs, p.changefeed.Info.TargetTs)
-	if p.redo.r.Enabled() {
+	if isPrepare && config.GetGlobalServerConfig().Debug.Scheduler.WarmStandby {
+		// Events before the global barrier can be sinked at any time, so the
+		// standby is warm once its sorter reaches the barrier.
+		readyTs := startTs
+		if barrier != nil && barrier.GlobalBarrierTs > readyTs {
+			readyTs = barrier.GlobalBarrierTs
+		}
+		p.sinkManager.r.WarmUpTable(span, readyTs)
+	}
+	if p.redo.r.Enabled() && p.sinkManager.r.IsRedoCovered(span) {
 		p.redo.r.AddTable(span, startTs)
 	}
-	p.sourceManager.r.AddTable(span, p.getTableName(ctx, span.TableID), startTs)
+	p.sourceManager.r.AddTable(
+		span, p.getTableName(ctx, span.TableID), startTs,
+		p.getResolvedTsInterval(span.TableID))
 
 	return true, nil
 }
@@ -309,7 +328,7 @@ func (p *processor) IsRemoveTableSpanFinished(span tablepb.Span) (model.Ts, bool
 		return 0, false
 	}
 
-	if p.redo.r.Enabled() {
+	if p.redo.r.Enabled() && p.sinkManager.r.IsRedoCovered(span) {
 		p.redo.r.RemoveTable(span)
 	}
 	p.sinkManager.r.RemoveTable(span)
@@ -358,9 +377,11 @@ func (p *processor) getStatsFromSourceManagerAndSinkManager(
 	now, _ := p.upstream.PDClock.CurrentTime()
 
 	stats := tablepb.Stats{
-		RegionCount: pullerStats.RegionCount,
-		CurrentTs:   oracle.ComposeTS(oracle.GetPhysical(now), 0),
-		BarrierTs:   sinkStats.BarrierTs,
+		RegionCount:  pullerStats.RegionCount,
+		CurrentTs:    oracle.ComposeTS(oracle.GetPhysical(now), 0),
+		BarrierTs:    sinkStats.BarrierTs,
+		EmittedRows:  sinkStats.EmittedRows,
+		EmittedBytes: sinkStats.EmittedBytes,
 		StageCheckpoints: map[string]tablepb.Checkpoint{
 			"puller-ingress": {
 				CheckpointTs: pullerStats.CheckpointTsIngress,
@@ -418,6 +439,10 @@ func newProcessor(
 			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 		metricsProcessorMemoryGauge: processorMemoryGauge.
 			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+		metricSnapshotTableGauge: processorSnapshotTableGauge.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+		metricSnapshotProgressGauge: processorSnapshotProgressGauge.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 	}
 	p.lazyInit = p.lazyInitImpl
 	p.newAgent = p.newAgentImpl
@@ -469,7 +494,7 @@ func (p *processor) Tick(ctx cdcContext.Context) error {
 	if !p.upstream.IsNormal() {
 		log.Warn("upstream is not ready, skip",
 			zap.Uint64("id", p.upstream.ID),
-			zap.Strings("pd", p.upstream.PdEndpoints),
+			zap.Strings("pd", p.upstream.GetPDEndpoints()),
 			zap.String("namespace", p.changefeedID.Namespace),
 			zap.String("changefeed", p.changefeedID.ID))
 		return nil
@@ -597,6 +622,9 @@ func (p *processor) tick(ctx cdcContext.Context) error {
 		p.updateBarrierTs(barrier)
 	}
 	p.doGCSchemaStorage()
+	// Ingress is observed in the tick goroutine, because tables are only
+	// added and removed here.
+	p.sinkManager.r.ObserveTableIngress(time.Now())
 
 	return nil
 }
@@ -653,6 +681,11 @@ func (p *processor) lazyInitImpl(etcdCtx cdcContext.Context) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	p.resolvedTsIntervals, err = puller.NewResolvedTsIntervalMatcher(
+		p.changefeed.Info.Config.ResolvedTsIntervals, p.changefeed.Info.Config.CaseSensitive)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	if err = p.initDDLHandler(prcCtx); err != nil {
 		return err
@@ -691,8 +724,12 @@ func (p *processor) lazyInitImpl(etcdCtx cdcContext.Context) (err error) {
 		return errors.Trace(err)
 	}
 
+	kvStorage, err := p.getKVStorage()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	p.sourceManager.r = sourcemanager.New(
-		p.changefeedID, p.upstream, p.mg.r,
+		p.changefeedID, p.upstream, kvStorage, p.mg.r,
 		sortEngine, util.GetOrZero(p.changefeed.Info.Config.BDRMode))
 	p.sourceManager.name = "SourceManager"
 	p.sourceManager.changefeedID = p.changefeedID
@@ -713,6 +750,8 @@ func (p *processor) lazyInitImpl(etcdCtx cdcContext.Context) (err error) {
 		return err
 	}
 
+	p.keyspace = p.changefeed.Info.Keyspace
+	keyspace.Register(p.changefeedID, p.keyspace)
 	p.initialized = true
 	log.Info("processor initialized",
 		zap.String("capture", p.captureInfo.ID),
@@ -779,8 +818,19 @@ func (p *processor) initDDLHandler(ctx context.Context) error {
 	} else {
 		ddlStartTs = checkpointTs - 1
 	}
+	// Paused tables are resumed from the ts they are paused at, their schemas
+	// after it are required.
+	if p.changefeed.Status != nil {
+		if minTs := p.changefeed.Status.MinTableCheckpointTs(); minTs < checkpointTs {
+			ddlStartTs = minTs - 1
+		}
+	}
 
-	meta, err := kv.GetSnapshotMeta(p.upstream.KVStorage, ddlStartTs)
+	kvStorage, err := p.getKVStorage()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta, err := kv.GetSnapshotMeta(kvStorage, ddlStartTs)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -800,7 +850,7 @@ func (p *processor) initDDLHandler(ctx context.Context) error {
 		p.upstream.PDClient,
 		p.upstream.GrpcPool,
 		p.upstream.RegionCache,
-		p.upstream.KVStorage,
+		kvStorage,
 		p.upstream.PDClock,
 		ddlStartTs,
 		kvCfg,
@@ -859,8 +909,21 @@ func (p *processor) getTableName(ctx context.Context, tableID model.TableID) str
 	return tableName.QuoteString()
 }
 
+// getResolvedTsInterval returns the resolved ts interval configured for the
+// table, 0 means no interval is configured.
+func (p *processor) getResolvedTsInterval(tableID model.TableID) time.Duration {
+	if p.resolvedTsIntervals == nil {
+		return 0
+	}
+	x, ok := p.ddlHandler.r.schemaStorage.GetLastSnapshot().PhysicalTableByID(tableID)
+	if !ok {
+		return 0
+	}
+	return p.resolvedTsIntervals.Match(x.TableName.Schema, x.TableName.Table)
+}
+
 func (p *processor) removeTable(span tablepb.Span) {
-	if p.redo.r.Enabled() {
+	if p.redo.r.Enabled() && p.sinkManager.r.IsRedoCovered(span) {
 		p.redo.r.RemoveTable(span)
 	}
 	p.sinkManager.r.RemoveTable(span)
@@ -881,7 +944,8 @@ func (p *processor) doGCSchemaStorage() {
 
 	// Please refer to `unmarshalAndMountRowChanged` in cdc/entry/mounter.go
 	// for why we need -1.
-	lastSchemaTs := p.ddlHandler.r.schemaStorage.DoGC(p.changefeed.Status.CheckpointTs - 1)
+	lastSchemaTs := p.ddlHandler.r.schemaStorage.DoGC(
+		p.changefeed.Status.MinTableCheckpointTs() - 1)
 	if p.lastSchemaTs == lastSchemaTs {
 		return
 	}
@@ -902,6 +966,9 @@ func (p *processor) refreshMetrics() {
 		return
 	}
 	p.metricSyncTableNumGauge.Set(float64(p.sinkManager.r.GetAllCurrentTableSpansCount()))
+	snapshotTables, snapshotProgress := p.sourceManager.r.GetSnapshotStats()
+	p.metricSnapshotTableGauge.Set(float64(snapshotTables))
+	p.metricSnapshotProgressGauge.Set(snapshotProgress)
 }
 
 // Close closes the processor. It must be called explicitly to stop all sub-components.
@@ -913,6 +980,9 @@ func (p *processor) Close() error {
 	// clean up metrics first to avoid some metrics are not cleaned up
 	// when error occurs during closing the processor
 	p.cleanupMetrics()
+	if p.initialized {
+		keyspace.Unregister(p.changefeedID, p.keyspace)
+	}
 
 	p.sinkManager.stop()
 	p.sinkManager.r = nil
@@ -958,12 +1028,24 @@ func (p *processor) Close() error {
 	return nil
 }
 
+// getKVStorage returns the storage of the keyspace the changefeed is scoped
+// to, whose snapshot reads are tagged with the resource group of the changefeed.
+func (p *processor) getKVStorage() (tidbkv.Storage, error) {
+	kvStorage, err := p.upstream.GetKVStorage(p.changefeed.Info.Keyspace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return kv.WithResourceGroup(kvStorage, p.changefeed.Info.Config.ResourceGroup), nil
+}
+
 func (p *processor) cleanupMetrics() {
 	syncTableNumGauge.DeleteLabelValues(p.changefeedID.Namespace, p.changefeedID.ID)
 	processorErrorCounter.DeleteLabelValues(p.changefeedID.Namespace, p.changefeedID.ID)
 	processorSchemaStorageGcTsGauge.DeleteLabelValues(p.changefeedID.Namespace, p.changefeedID.ID)
 	processorTickDuration.DeleteLabelValues(p.changefeedID.Namespace, p.changefeedID.ID)
 	processorMemoryGauge.DeleteLabelValues(p.changefeedID.Namespace, p.changefeedID.ID)
+	processorSnapshotTableGauge.DeleteLabelValues(p.changefeedID.Namespace, p.changefeedID.ID)
+	processorSnapshotProgressGauge.DeleteLabelValues(p.changefeedID.Namespace, p.changefeedID.ID)
 
 	ok := puller.PullerEventCounter.DeleteLabelValues(p.changefeedID.Namespace, p.changefeedID.ID, "kv")
 	if !ok {
@@ -981,6 +1063,14 @@ func (p *processor) cleanupMetrics() {
 	}
 }
 
+// getTableCount returns the number of tables replicated by the processor.
+func (p *processor) getTableCount() int {
+	if !p.initialized {
+		return 0
+	}
+	return p.sinkManager.r.GetAllCurrentTableSpansCount()
+}
+
 // WriteDebugInfo write the debug info to Writer
 func (p *processor) WriteDebugInfo(w io.Writer) error {
 	fmt.Fprintf(w, "%+v\n", *p.changefeed)
@@ -0,0 +1,168 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// burstObserveInterval is the min interval between two ingress samples.
+	burstObserveInterval = time.Second
+	// burstBaselineWeight is the weight of a new sample in the baseline rate.
+	burstBaselineWeight = 0.2
+)
+
+// burstDetector detects sudden upstream write bursts of tables, and switches
+// the tables in a burst to the burst-absorption profile, which enlarges the
+// sink batches by batchFactor.
+type burstDetector struct {
+	changefeedID model.ChangeFeedID
+	// enabled is false if burst absorption is not configured, in which case
+	// all methods are no-ops.
+	enabled     bool
+	ratio       float64
+	minRate     float64
+	cooldown    time.Duration
+	batchFactor int
+
+	lastObserve time.Time
+
+	metricBurstTables prometheus.Gauge
+}
+
+// burstState is the ingress state of a table. It's only accessed by the
+// goroutine observing the ingress.
+type burstState struct {
+	lastEvents uint64
+	lastTime   time.Time
+	// baseline is the moving average of the rate out of bursts, in events/s.
+	// It's initialized by the first rate.
+	initialized bool
+	baseline    float64
+	inBurst     bool
+	// calmSince is the time since when the rate has been under the threshold
+	// in a burst. It's zero if the rate is above the threshold.
+	calmSince time.Time
+}
+
+func newBurstDetector(
+	changefeedID model.ChangeFeedID,
+	cfg *config.BurstAbsorptionConfig,
+) *burstDetector {
+	d := &burstDetector{
+		changefeedID: changefeedID,
+		metricBurstTables: burstAbsorptionTables.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+	}
+	if cfg != nil && util.GetOrZero(cfg.Enable) {
+		d.enabled = true
+		d.ratio = cfg.GetRatio()
+		d.minRate = float64(cfg.GetMinEventsPerSecond())
+		d.cooldown = cfg.GetCooldown()
+		d.batchFactor = cfg.GetBatchFactor()
+	}
+	return d
+}
+
+// shouldObserve returns true if it's time to take a new ingress sample.
+func (d *burstDetector) shouldObserve(now time.Time) bool {
+	if !d.enabled || now.Sub(d.lastObserve) < burstObserveInterval {
+		return false
+	}
+	d.lastObserve = now
+	return true
+}
+
+// observe updates the burst state of the table with the total number of
+// events received from the upstream.
+func (d *burstDetector) observe(t *tableSinkWrapper, events uint64, now time.Time) {
+	s := &t.burst
+	if s.lastTime.IsZero() || events < s.lastEvents || !now.After(s.lastTime) {
+		s.lastEvents, s.lastTime = events, now
+		return
+	}
+	rate := float64(events-s.lastEvents) / now.Sub(s.lastTime).Seconds()
+	s.lastEvents, s.lastTime = events, now
+	// The first rate is taken as the baseline directly.
+	if !s.initialized {
+		s.baseline = rate
+		s.initialized = true
+		return
+	}
+
+	threshold := d.ratio * s.baseline
+	if threshold < d.minRate {
+		threshold = d.minRate
+	}
+	if !s.inBurst {
+		if rate < threshold {
+			s.baseline += burstBaselineWeight * (rate - s.baseline)
+			return
+		}
+		s.inBurst = true
+		s.calmSince = time.Time{}
+		t.batchFactor.Store(int32(d.batchFactor))
+		d.metricBurstTables.Inc()
+		log.Info("upstream write burst of the table is detected, "+
+			"switch to the burst-absorption profile",
+			zap.String("namespace", d.changefeedID.Namespace),
+			zap.String("changefeed", d.changefeedID.ID),
+			zap.Stringer("span", &t.span),
+			zap.Float64("rate", rate),
+			zap.Float64("baseline", s.baseline),
+			zap.Int("batchFactor", d.batchFactor))
+		return
+	}
+
+	if rate >= threshold {
+		s.calmSince = time.Time{}
+		return
+	}
+	if s.calmSince.IsZero() {
+		s.calmSince = now
+	}
+	if now.Sub(s.calmSince) >= d.cooldown {
+		d.end(t)
+		log.Info("upstream write burst of the table is over, "+
+			"revert the burst-absorption profile",
+			zap.String("namespace", d.changefeedID.Namespace),
+			zap.String("changefeed", d.changefeedID.ID),
+			zap.Stringer("span", &t.span),
+			zap.Float64("rate", rate),
+			zap.Float64("baseline", s.baseline))
+	}
+}
+
+// end ends the burst of the table if any.
+func (d *burstDetector) end(t *tableSinkWrapper) {
+	if !t.burst.inBurst {
+		return
+	}
+	t.burst.inBurst = false
+	t.burst.calmSince = time.Time{}
+	t.batchFactor.Store(1)
+	d.metricBurstTables.Dec()
+}
+
+func (d *burstDetector) close() {
+	burstAbsorptionTables.DeleteLabelValues(d.changefeedID.Namespace, d.changefeedID.ID)
+}
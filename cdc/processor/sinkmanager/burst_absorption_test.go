@@ -0,0 +1,120 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestBurstDetectorDisabled(t *testing.T) {
+	t.Parallel()
+
+	d := newBurstDetector(model.DefaultChangeFeedID("1"), nil)
+	defer d.close()
+	require.False(t, d.shouldObserve(time.Now()))
+}
+
+func TestBurstDetector(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.BurstAbsorptionConfig{
+		Enable:             util.AddressOf(true),
+		Ratio:              util.AddressOf(4.0),
+		MinEventsPerSecond: util.AddressOf(uint64(1000)),
+		Cooldown:           util.AddressOf("3s"),
+		BatchFactor:        util.AddressOf(4),
+	}
+	changefeedID := model.DefaultChangeFeedID("1")
+	d := newBurstDetector(changefeedID, cfg)
+	defer d.close()
+	wrapper, _ := createTableSinkWrapper(changefeedID, spanz.TableIDToComparableSpan(1))
+
+	now := time.Now()
+	require.True(t, d.shouldObserve(now))
+	require.False(t, d.shouldObserve(now.Add(time.Millisecond)))
+
+	events := uint64(0)
+	step := func(rate uint64) {
+		now = now.Add(time.Second)
+		events += rate
+		d.observe(wrapper, events, now)
+	}
+	d.observe(wrapper, events, now)
+	// The first rate is the baseline.
+	step(500)
+	require.Equal(t, 500.0, wrapper.burst.baseline)
+	step(1500)
+	require.Equal(t, 1, wrapper.getBatchFactor())
+	require.Equal(t, 700.0, wrapper.burst.baseline)
+
+	// The rate exceeds 4 times the baseline, it's a burst.
+	step(10000)
+	require.Equal(t, 4, wrapper.getBatchFactor())
+	step(10000)
+	require.Equal(t, 4, wrapper.getBatchFactor())
+	// The baseline isn't updated in bursts.
+	require.Equal(t, 700.0, wrapper.burst.baseline)
+
+	// The rate falls, but the burst doesn't end before the cooldown.
+	step(100)
+	step(100)
+	step(10000)
+	step(100)
+	step(100)
+	step(100)
+	require.Equal(t, 4, wrapper.getBatchFactor())
+	step(100)
+	require.Equal(t, 1, wrapper.getBatchFactor())
+
+	// A rate under the min rate is never a burst.
+	step(1)
+	step(999)
+	require.Equal(t, 1, wrapper.getBatchFactor())
+
+	// Removing a table ends its burst.
+	step(100000)
+	require.Equal(t, 4, wrapper.getBatchFactor())
+	d.end(wrapper)
+	require.Equal(t, 1, wrapper.getBatchFactor())
+}
+
+func TestBurstAbsorptionProfile(t *testing.T) {
+	t.Parallel()
+
+	changefeedID := model.DefaultChangeFeedID("1")
+	span := spanz.TableIDToComparableSpan(1)
+	lowerBound := engine.Position{StartTs: 1, CommitTs: oracle.GoTimeToTS(time.Now())}
+	upperBound := engine.GenCommitFence(oracle.GoTimeToTS(
+		oracle.GetTimeFromTS(lowerBound.CommitTs).Add(10 * maxTaskTimeRange)))
+
+	_, newUpperBound := validateAndAdjustBound(changefeedID, &span, lowerBound, upperBound, 1)
+	require.Equal(t, maxTaskTimeRange, oracle.GetTimeFromTS(newUpperBound.CommitTs).
+		Sub(oracle.GetTimeFromTS(lowerBound.CommitTs)))
+	_, newUpperBound = validateAndAdjustBound(changefeedID, &span, lowerBound, upperBound, 4)
+	require.Equal(t, 4*maxTaskTimeRange, oracle.GetTimeFromTS(newUpperBound.CommitTs).
+		Sub(oracle.GetTimeFromTS(lowerBound.CommitTs)))
+
+	require.True(t, needEmitAndAdvance(false, maxUpdateIntervalSize, 0, 1))
+	require.False(t, needEmitAndAdvance(false, maxUpdateIntervalSize, 0, 4))
+	require.True(t, needEmitAndAdvance(false, 4*maxUpdateIntervalSize, 0, 4))
+}
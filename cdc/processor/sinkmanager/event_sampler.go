@@ -0,0 +1,201 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// managers are all sink managers of the process.
+var managers = struct {
+	sync.RWMutex
+	v map[*SinkManager]struct{}
+}{v: make(map[*SinkManager]struct{})}
+
+func registerManager(m *SinkManager) {
+	managers.Lock()
+	defer managers.Unlock()
+	managers.v[m] = struct{}{}
+}
+
+func unregisterManager(m *SinkManager) {
+	managers.Lock()
+	defer managers.Unlock()
+	delete(managers.v, m)
+}
+
+// isTableReplicated returns true if the table is replicated by a sink manager
+// of the process.
+func isTableReplicated(changefeed model.ChangeFeedID, tableID model.TableID) bool {
+	managers.RLock()
+	defer managers.RUnlock()
+	found := false
+	for m := range managers.v {
+		if m.changefeedID != changefeed {
+			continue
+		}
+		m.tableSinks.Range(func(span tablepb.Span, _ interface{}) bool {
+			found = span.TableID == tableID
+			return !found
+		})
+		if found {
+			break
+		}
+	}
+	return found
+}
+
+// eventSampler collects copies of the events of a table which are appended
+// to table sinks, i.e. the events after they are mounted and converted.
+type eventSampler struct {
+	changefeed model.ChangeFeedID
+	tableID    model.TableID
+	limit      int
+
+	mu     sync.Mutex
+	events []*model.RowChangedEvent
+	// done is closed once limit events are collected.
+	done chan struct{}
+}
+
+// samplers are all running event samplers of the process. samplerCount is
+// checked before taking the lock, so there is no overhead without samplers.
+var (
+	samplers = struct {
+		sync.RWMutex
+		v map[*eventSampler]struct{}
+	}{v: make(map[*eventSampler]struct{})}
+	samplerCount atomic.Int32
+)
+
+func registerSampler(s *eventSampler) {
+	samplers.Lock()
+	defer samplers.Unlock()
+	samplers.v[s] = struct{}{}
+	samplerCount.Add(1)
+}
+
+func unregisterSampler(s *eventSampler) {
+	samplers.Lock()
+	defer samplers.Unlock()
+	delete(samplers.v, s)
+	samplerCount.Add(-1)
+}
+
+// sampleEvents feeds the events of a table to the samplers of the table.
+func sampleEvents(
+	changefeed model.ChangeFeedID, tableID model.TableID, events []*model.RowChangedEvent,
+) {
+	if samplerCount.Load() == 0 || len(events) == 0 {
+		return
+	}
+	samplers.RLock()
+	defer samplers.RUnlock()
+	for s := range samplers.v {
+		if s.changefeed == changefeed && s.tableID == tableID {
+			s.add(events)
+		}
+	}
+}
+
+func (s *eventSampler) add(events []*model.RowChangedEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range events {
+		if len(s.events) >= s.limit {
+			return
+		}
+		// The event is copied as it may be modified by the sink.
+		s.events = append(s.events, copyRowChangedEvent(e))
+		if len(s.events) == s.limit {
+			close(s.done)
+		}
+	}
+}
+
+func copyRowChangedEvent(e *model.RowChangedEvent) *model.RowChangedEvent {
+	copyColumns := func(cols []*model.Column) []*model.Column {
+		if cols == nil {
+			return nil
+		}
+		res := make([]*model.Column, 0, len(cols))
+		for _, col := range cols {
+			if col == nil {
+				res = append(res, nil)
+				continue
+			}
+			c := *col
+			res = append(res, &c)
+		}
+		return res
+	}
+	res := &model.RowChangedEvent{
+		StartTs:    e.StartTs,
+		CommitTs:   e.CommitTs,
+		Columns:    copyColumns(e.Columns),
+		PreColumns: copyColumns(e.PreColumns),
+	}
+	if e.Table != nil {
+		table := *e.Table
+		res.Table = &table
+	}
+	return res
+}
+
+// SampleEvents returns at most limit events of the table which are appended
+// to the table sinks of the process from now on. It waits for the events
+// until the timeout, and returns the events collected so far then.
+func SampleEvents(
+	ctx context.Context,
+	changefeed model.ChangeFeedID,
+	tableID model.TableID,
+	limit int,
+	timeout time.Duration,
+) ([]*model.RowChangedEvent, error) {
+	if !isTableReplicated(changefeed, tableID) {
+		return nil, cerrors.ErrProcessorTableNotFound.GenWithStack(
+			"table %d of changefeed %s is not replicated by the capture",
+			tableID, changefeed.ID)
+	}
+	s := &eventSampler{
+		changefeed: changefeed,
+		tableID:    tableID,
+		limit:      limit,
+		done:       make(chan struct{}),
+	}
+	registerSampler(s)
+	timer := time.NewTimer(timeout)
+	var err error
+	select {
+	case <-ctx.Done():
+		err = errors.Trace(ctx.Err())
+	case <-timer.C:
+	case <-s.done:
+	}
+	timer.Stop()
+	unregisterSampler(s)
+	if err != nil {
+		return nil, err
+	}
+	// The sampler is unregistered, so no events are added any more.
+	return s.events, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func hasSampler(changefeed model.ChangeFeedID) bool {
+	samplers.RLock()
+	defer samplers.RUnlock()
+	for s := range samplers.v {
+		if s.changefeed == changefeed {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSampleEvents(t *testing.T) {
+	t.Parallel()
+
+	changefeedID := model.DefaultChangeFeedID("sample-events")
+	span := spanz.TableIDToComparableSpan(1)
+	wrapper, sink := createTableSinkWrapper(changefeedID, span)
+	m := &SinkManager{changefeedID: changefeedID}
+	m.tableSinks.Store(span, wrapper)
+	registerManager(m)
+	defer unregisterManager(m)
+	ctx := context.Background()
+
+	// The table is not replicated by the process.
+	_, err := SampleEvents(ctx, changefeedID, 2, 2, time.Second)
+	require.Regexp(t, "ErrProcessorTableNotFound", err)
+
+	type result struct {
+		events []*model.RowChangedEvent
+		err    error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		events, err := SampleEvents(ctx, changefeedID, 1, 2, time.Minute)
+		resultCh <- result{events: events, err: err}
+	}()
+	require.Eventually(t, func() bool {
+		return hasSampler(changefeedID)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	newEvent := func(commitTs uint64) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			CommitTs: commitTs,
+			Table:    &model.TableName{Schema: "test", Table: "t", TableID: 1},
+			Columns:  []*model.Column{{Name: "a", Value: int64(commitTs)}},
+		}
+	}
+	events := []*model.RowChangedEvent{newEvent(1), newEvent(2), newEvent(3)}
+	require.Nil(t, wrapper.appendRowChangedEvents(events...))
+	// The events sampled are not affected by the sink.
+	events[0].Columns[0].Value = int64(100)
+
+	res := <-resultCh
+	require.Nil(t, res.err)
+	require.Len(t, res.events, 2)
+	require.Equal(t, uint64(1), res.events[0].CommitTs)
+	require.Equal(t, int64(1), res.events[0].Columns[0].Value)
+	require.Equal(t, uint64(2), res.events[1].CommitTs)
+	require.False(t, hasSampler(changefeedID))
+
+	// The events collected so far are returned after the timeout.
+	events, err = SampleEvents(ctx, changefeedID, 1, 2, 10*time.Millisecond)
+	require.Nil(t, err)
+	require.Empty(t, events)
+
+	// The events are still written to the sink.
+	require.Nil(t, wrapper.updateResolvedTs(model.NewResolvedTs(4)))
+	require.Len(t, sink.GetEvents(), 3)
+}
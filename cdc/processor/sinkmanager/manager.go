@@ -16,6 +16,9 @@ package sinkmanager
 import (
 	"context"
 	"math"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -56,6 +59,9 @@ type TableStats struct {
 	CheckpointTs model.Ts
 	ResolvedTs   model.Ts
 	BarrierTs    model.Ts
+	// EmittedRows and EmittedBytes are accumulated since the table is added.
+	EmittedRows  uint64
+	EmittedBytes uint64
 }
 
 // SinkManager is the implementation of SinkManager.
@@ -79,6 +85,8 @@ type SinkManager struct {
 	eventCache *redoEventCache
 	// redoDMLMgr is used to report the resolved ts of the table if redo log is enabled.
 	redoDMLMgr redo.DMLManager
+	// redoTables decides which tables are covered by the redo log.
+	redoTables *redo.TableFilter
 	// sourceManager is used by the sink manager to fetch data.
 	sourceManager *sourcemanager.SourceManager
 
@@ -96,6 +104,10 @@ type SinkManager struct {
 	sinkWorkerAvailable chan struct{}
 	// sinkMemQuota is used to control the total memory usage of the table sink.
 	sinkMemQuota *memquota.MemQuota
+	// rampUp limits the sink tasks in slow-start.
+	rampUp *rampUpController
+	// burst detects upstream write bursts of tables.
+	burst *burstDetector
 
 	// redoWorkers used to pull data from source manager.
 	redoWorkers []*redoWorker
@@ -119,6 +131,9 @@ type SinkManager struct {
 
 	// Metric for table sink.
 	metricsTableSinkTotalRows prometheus.Counter
+	// sinkScheme is the scheme of the sink URI, it's the sink label of the
+	// end-to-end latency of table sinks.
+	sinkScheme string
 }
 
 // New creates a new sink manager.
@@ -145,6 +160,9 @@ func New(
 		metricsTableSinkTotalRows: tablesinkmetrics.TotalRowsCountCounter.
 			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 	}
+	if sinkURI, err := url.Parse(changefeedInfo.SinkURI); err == nil {
+		m.sinkScheme = strings.ToLower(sinkURI.Scheme)
+	}
 
 	if redoDMLMgr != nil && redoDMLMgr.Enabled() {
 		m.redoDMLMgr = redoDMLMgr
@@ -158,10 +176,24 @@ func New(
 		redoQuota := changefeedInfo.Config.MemoryQuota / 4 * 3
 		m.redoMemQuota = memquota.NewMemQuota(changefeedID, redoQuota, "redo")
 		m.eventCache = newRedoEventCache(changefeedID, redoQuota/2*1)
+
+		redoTables, err := redo.NewTableFilter(
+			changefeedInfo.Config.Consistent, changefeedInfo.Config.CaseSensitive)
+		if err != nil {
+			// The config has been validated, cover all tables to be safe.
+			log.Warn("invalid redo table filter, all tables are covered by redo log",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.Error(err))
+		}
+		m.redoTables = redoTables
 	} else {
 		m.sinkMemQuota = memquota.NewMemQuota(changefeedID, changefeedInfo.Config.MemoryQuota, "sink")
 		m.redoMemQuota = memquota.NewMemQuota(changefeedID, 0, "redo")
 	}
+	m.rampUp = newRampUpController(changefeedID, changefeedInfo.Config.Sink.SlowStart, sinkWorkerNum)
+	m.sinkMemQuota.SetReleaseObserver(m.rampUp.observeFlushLatency)
+	m.burst = newBurstDetector(changefeedID, changefeedInfo.Config.Sink.BurstAbsorption)
 
 	m.ready = make(chan struct{})
 	return m
@@ -172,7 +204,9 @@ func New(
 func (m *SinkManager) Run(ctx context.Context, warnings ...chan<- error) (err error) {
 	m.managerCtx, m.managerCancel = context.WithCancel(ctx)
 	m.wg.Add(1) // So `SinkManager.Close` will also wait the function.
+	registerManager(m)
 	defer func() {
+		unregisterManager(m)
 		m.wg.Done()
 		m.waitSubroutines()
 		log.Info("Sink manager exists",
@@ -324,7 +358,7 @@ func (m *SinkManager) clearSinkFactory() {
 func (m *SinkManager) startSinkWorkers(ctx context.Context, eg *errgroup.Group, splitTxn bool, enableOldValue bool) {
 	for i := 0; i < sinkWorkerNum; i++ {
 		w := newSinkWorker(m.changefeedID, m.sourceManager,
-			m.sinkMemQuota, m.redoMemQuota,
+			m.sinkMemQuota, m.redoMemQuota, m.rampUp,
 			m.eventCache, splitTxn, enableOldValue)
 		m.sinkWorkers = append(m.sinkWorkers, w)
 		eg.Go(func() error { return w.handleTasks(ctx, m.sinkTaskChan) })
@@ -477,8 +511,13 @@ func (m *SinkManager) generateSinkTasks(ctx context.Context) error {
 				continue
 			}
 
+			// The concurrency is limited by slow-start.
+			if !m.rampUp.acquireTask() {
+				break LOOP
+			}
 			// No available memory, skip this round directly.
 			if !m.sinkMemQuota.TryAcquire(requestMemSize) {
+				m.rampUp.releaseTask()
 				break LOOP
 			}
 
@@ -493,6 +532,8 @@ func (m *SinkManager) generateSinkTasks(ctx context.Context) error {
 				lowerBound:    lowerBound,
 				getUpperBound: getUpperBound,
 				tableSink:     tableSink,
+				maxBatchSize:  m.rampUp.batchSizeLimit(),
+				batchFactor:   tableSink.getBatchFactor(),
 				callback: func(lastWrittenPos engine.Position) {
 					p := &progress{
 						span:              tableSink.span,
@@ -520,6 +561,7 @@ func (m *SinkManager) generateSinkTasks(ctx context.Context) error {
 					zap.Any("lowerBound", lowerBound),
 					zap.Any("currentUpperBound", upperBound))
 			default:
+				m.rampUp.releaseTask()
 				m.sinkMemQuota.Refund(requestMemSize)
 				log.Debug("MemoryQuotaTracing: refund memory for table sink task",
 					zap.String("namespace", m.changefeedID.Namespace),
@@ -543,6 +585,7 @@ func (m *SinkManager) generateSinkTasks(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-taskTicker.C:
+			m.rampUp.tick(time.Now())
 			if err := dispatchTasks(); err != nil {
 				return errors.Trace(err)
 			}
@@ -743,8 +786,58 @@ func (m *SinkManager) UpdateBarrierTs(
 	})
 }
 
+// e2eLatencyTable returns the table label of the end-to-end latency
+// histogram of the table.
+func (m *SinkManager) e2eLatencyTable(span tablepb.Span) string {
+	if m.schemaStorage != nil {
+		if snap := m.schemaStorage.GetLastSnapshot(); snap != nil {
+			if info, ok := snap.PhysicalTableByID(span.TableID); ok {
+				return info.TableName.String()
+			}
+		}
+	}
+	return strconv.FormatInt(span.TableID, 10)
+}
+
+// removeE2ELatencyHistogram removes the end-to-end latency histogram of the
+// table, if no other span of the table is in the sink manager.
+func (m *SinkManager) removeE2ELatencyHistogram(table string) {
+	removed := true
+	m.tableSinks.Range(func(_ tablepb.Span, value interface{}) bool {
+		removed = value.(*tableSinkWrapper).e2eLatencyTable != table
+		return removed
+	})
+	if removed {
+		tablesinkmetrics.E2ELatencyHistogram.DeleteLabelValues(
+			m.changefeedID.Namespace, m.changefeedID.ID, table, m.sinkScheme)
+	}
+}
+
+// coveredByRedo returns true if the changes of the table are written to the
+// redo log. Tables not found in the schema storage are covered to be safe.
+func (m *SinkManager) coveredByRedo(span tablepb.Span) bool {
+	if m.redoDMLMgr == nil {
+		return false
+	}
+	if m.redoTables == nil || m.schemaStorage == nil {
+		return true
+	}
+	snap := m.schemaStorage.GetLastSnapshot()
+	if snap == nil {
+		return true
+	}
+	info, ok := snap.PhysicalTableByID(span.TableID)
+	if !ok {
+		return true
+	}
+	return m.redoTables.Covers(info.TableName.Schema, info.TableName.Table)
+}
+
 // AddTable adds a table(TableSink) to the sink manager.
 func (m *SinkManager) AddTable(span tablepb.Span, startTs model.Ts, targetTs model.Ts) {
+	e2eLatencyTable := m.e2eLatencyTable(span)
+	e2eLatency := tablesinkmetrics.E2ELatencyHistogram.WithLabelValues(
+		m.changefeedID.Namespace, m.changefeedID.ID, e2eLatencyTable, m.sinkScheme)
 	sinkWrapper := newTableSinkWrapper(
 		m.changefeedID,
 		span,
@@ -752,7 +845,8 @@ func (m *SinkManager) AddTable(span tablepb.Span, startTs model.Ts, targetTs mod
 			if m.sinkFactoryMu.TryLock() {
 				defer m.sinkFactoryMu.Unlock()
 				if m.sinkFactory != nil {
-					return m.sinkFactory.CreateTableSink(m.changefeedID, span, startTs, m.metricsTableSinkTotalRows)
+					return m.sinkFactory.CreateTableSink(m.changefeedID, span, startTs,
+						m.metricsTableSinkTotalRows, e2eLatency)
 				}
 			}
 			return nil
@@ -761,10 +855,13 @@ func (m *SinkManager) AddTable(span tablepb.Span, startTs model.Ts, targetTs mod
 		startTs,
 		targetTs,
 		func(ctx context.Context) (model.Ts, error) {
-			return genReplicateTs(ctx, m.up.PDClient)
+			return genReplicateTs(ctx, m.up.TSOClient)
 		},
 	)
 
+	sinkWrapper.redoCovered = m.coveredByRedo(span)
+	sinkWrapper.e2eLatencyTable = e2eLatencyTable
+
 	_, loaded := m.tableSinks.LoadOrStore(span, sinkWrapper)
 	if loaded {
 		log.Panic("Add an exists table sink",
@@ -775,12 +872,39 @@ func (m *SinkManager) AddTable(span tablepb.Span, startTs model.Ts, targetTs mod
 	}
 	m.sinkMemQuota.AddTable(span)
 	m.redoMemQuota.AddTable(span)
+	m.rampUp.maybeStart(time.Since(oracle.GetTimeFromTS(startTs)), time.Now())
 	log.Info("Add table sink",
 		zap.String("namespace", m.changefeedID.Namespace),
 		zap.String("changefeed", m.changefeedID.ID),
 		zap.Stringer("span", &span),
 		zap.Uint64("startTs", startTs),
-		zap.Uint64("version", sinkWrapper.version))
+		zap.Uint64("version", sinkWrapper.version),
+		zap.Bool("redoCovered", sinkWrapper.redoCovered))
+}
+
+// WarmUpTable makes a preparing table(TableSink) a warm standby. The table
+// becomes prepared only after the sorter has received all events before
+// readyTs, and its table sink is created in advance, so that the table can
+// be sinked as soon as it's started.
+func (m *SinkManager) WarmUpTable(span tablepb.Span, readyTs model.Ts) {
+	tableSink, ok := m.tableSinks.Load(span)
+	if !ok {
+		log.Panic("Table sink not found when warming up table",
+			zap.String("namespace", m.changefeedID.Namespace),
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.Stringer("span", &span))
+	}
+	wrapper := tableSink.(*tableSinkWrapper)
+	wrapper.readyTs.Store(readyTs)
+	// The table sink can be nil if the sink factory isn't ready, it will be
+	// created by sink workers later.
+	initialized := wrapper.initTableSink()
+	log.Info("Warm up table sink",
+		zap.String("namespace", m.changefeedID.Namespace),
+		zap.String("changefeed", m.changefeedID.ID),
+		zap.Stringer("span", &span),
+		zap.Uint64("readyTs", readyTs),
+		zap.Bool("tableSinkInitialized", initialized))
 }
 
 // StartTable sets the table(TableSink) state to replicating.
@@ -808,7 +932,7 @@ func (m *SinkManager) StartTable(span tablepb.Span, startTs model.Ts) error {
 		nextLowerBoundPos: engine.Position{StartTs: 0, CommitTs: startTs + 1},
 		version:           tableSink.(*tableSinkWrapper).version,
 	})
-	if m.redoDMLMgr != nil {
+	if tableSink.(*tableSinkWrapper).redoCovered {
 		m.redoProgressHeap.push(&progress{
 			span:              span,
 			nextLowerBoundPos: engine.Position{StartTs: 0, CommitTs: startTs + 1},
@@ -855,6 +979,8 @@ func (m *SinkManager) RemoveTable(span tablepb.Span) {
 			zap.Stringer("span", &span))
 	}
 	sink := value.(*tableSinkWrapper)
+	m.burst.end(sink)
+	m.removeE2ELatencyHistogram(sink.e2eLatencyTable)
 	log.Info("Remove table sink successfully",
 		zap.String("namespace", m.changefeedID.Namespace),
 		zap.String("changefeed", m.changefeedID.ID),
@@ -865,6 +991,30 @@ func (m *SinkManager) RemoveTable(span tablepb.Span) {
 	}
 }
 
+// ObserveTableIngress samples the number of events received by the sorter of
+// all tables, to detect upstream write bursts of tables. It must be called in
+// the same goroutine as AddTable and RemoveTable.
+func (m *SinkManager) ObserveTableIngress(now time.Time) {
+	if !m.burst.shouldObserve(now) {
+		return
+	}
+	m.tableSinks.Range(func(span tablepb.Span, value interface{}) bool {
+		stats := m.sourceManager.GetTableSorterStats(span)
+		m.burst.observe(value.(*tableSinkWrapper), stats.ReceivedEvents, now)
+		return true
+	})
+}
+
+// IsRedoCovered returns true if the changes of the table are written to the
+// redo log.
+func (m *SinkManager) IsRedoCovered(span tablepb.Span) bool {
+	value, ok := m.tableSinks.Load(span)
+	if !ok {
+		return false
+	}
+	return value.(*tableSinkWrapper).redoCovered
+}
+
 // GetAllCurrentTableSpans returns all spans in the sinkManager.
 func (m *SinkManager) GetAllCurrentTableSpans() []tablepb.Span {
 	var spans []tablepb.Span
@@ -927,8 +1077,9 @@ func (m *SinkManager) GetTableStats(span tablepb.Span) TableStats {
 	m.sinkMemQuota.Release(span, checkpointTs)
 	m.redoMemQuota.Release(span, checkpointTs)
 	var resolvedTs model.Ts
-	// If redo log is enabled, we have to use redo log's resolved ts to calculate processor's min resolved ts.
-	if m.redoDMLMgr != nil {
+	// If the table is covered by redo log, we have to use redo log's resolved ts
+	// to calculate processor's min resolved ts.
+	if tableSink.redoCovered {
 		resolvedTs = m.redoDMLMgr.GetResolvedTs(span)
 	} else {
 		resolvedTs = tableSink.getReceivedSorterResolvedTs()
@@ -946,6 +1097,8 @@ func (m *SinkManager) GetTableStats(span tablepb.Span) TableStats {
 		CheckpointTs: checkpointTs.ResolvedMark(),
 		ResolvedTs:   resolvedTs,
 		BarrierTs:    tableSink.barrierTs.Load(),
+		EmittedRows:  tableSink.emittedRows.Load(),
+		EmittedBytes: tableSink.emittedBytes.Load(),
 	}
 }
 
@@ -981,6 +1134,11 @@ func (m *SinkManager) Close() {
 	if m.eventCache != nil {
 		m.eventCache.clear()
 	}
+	m.rampUp.close()
+	m.burst.close()
+	tablesinkmetrics.E2ELatencyHistogram.DeletePartialMatch(prometheus.Labels{
+		"namespace": m.changefeedID.Namespace, "changefeed": m.changefeedID.ID,
+	})
 
 	log.Info("Closed sink manager",
 		zap.String("namespace", m.changefeedID.Namespace),
@@ -24,8 +24,11 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	tablesinkmetrics "github.com/pingcap/tiflow/cdc/sink/metrics/tablesink"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -127,6 +130,33 @@ func TestAddTable(t *testing.T) {
 	require.Equal(t, uint64(2), progress.nextLowerBoundPos.CommitTs)
 }
 
+func TestWarmUpTable(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changefeedInfo := getChangefeedInfo()
+	manager, _, _ := CreateManagerWithMemEngine(t, ctx, model.DefaultChangeFeedID("1"),
+		changefeedInfo, make(chan error, 1))
+	defer func() {
+		cancel()
+		manager.Close()
+	}()
+
+	span := spanz.TableIDToComparableSpan(1)
+	manager.AddTable(span, 1, 100)
+	manager.WarmUpTable(span, 10)
+	tableSink, ok := manager.tableSinks.Load(span)
+	require.True(t, ok)
+	wrapper := tableSink.(*tableSinkWrapper)
+	require.NotNil(t, wrapper.tableSink, "The table sink should be created in advance")
+
+	// The table is prepared after the sorter reaches the ready ts.
+	manager.UpdateReceivedSorterResolvedTs(span, 5)
+	require.Equal(t, tablepb.TableStatePreparing, wrapper.getState())
+	manager.UpdateReceivedSorterResolvedTs(span, 10)
+	require.Equal(t, tablepb.TableStatePrepared, wrapper.getState())
+}
+
 func TestRemoveTable(t *testing.T) {
 	t.Parallel()
 
@@ -173,6 +203,56 @@ func TestRemoveTable(t *testing.T) {
 	require.Equal(t, uint64(0), manager.sinkMemQuota.GetUsedBytes(), "After remove table, the memory usage should be 0.")
 }
 
+func TestRemoveTableE2ELatencyHistogram(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changefeedID := model.DefaultChangeFeedID("e2e-latency")
+	manager, _, _ := CreateManagerWithMemEngine(t, ctx, changefeedID,
+		getChangefeedInfo(), make(chan error, 1))
+	defer func() {
+		cancel()
+		manager.Close()
+	}()
+
+	hasE2ELatencyHistogram := func(table string) bool {
+		ch := make(chan prometheus.Metric)
+		go func() {
+			tablesinkmetrics.E2ELatencyHistogram.Collect(ch)
+			close(ch)
+		}()
+		found := false
+		for metric := range ch {
+			m := &dto.Metric{}
+			require.NoError(t, metric.Write(m))
+			labels := make(map[string]string)
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["changefeed"] == changefeedID.ID && labels["table"] == table {
+				found = true
+			}
+		}
+		return found
+	}
+
+	// Two spans of the same table share the histogram.
+	span := spanz.TableIDToComparableSpan(1)
+	span1 := tablepb.Span{TableID: 1, StartKey: span.StartKey, EndKey: []byte("m")}
+	span2 := tablepb.Span{TableID: 1, StartKey: []byte("m"), EndKey: span.EndKey}
+	manager.AddTable(span1, 1, 100)
+	manager.AddTable(span2, 1, 100)
+	tableSink, ok := manager.tableSinks.Load(span1)
+	require.True(t, ok)
+	table := tableSink.(*tableSinkWrapper).e2eLatencyTable
+	require.True(t, hasE2ELatencyHistogram(table))
+
+	manager.RemoveTable(span1)
+	require.True(t, hasE2ELatencyHistogram(table))
+	manager.RemoveTable(span2)
+	require.False(t, hasE2ELatencyHistogram(table))
+}
+
 func TestGenerateTableSinkTaskWithBarrierTs(t *testing.T) {
 	t.Parallel()
 
@@ -338,7 +418,7 @@ func TestSinkManagerRunWithErrors(t *testing.T) {
 
 	span := spanz.TableIDToComparableSpan(1)
 
-	source.AddTable(span, "test", 100)
+	source.AddTable(span, "test", 100, 0)
 	manager.AddTable(span, 100, math.MaxUint64)
 	manager.StartTable(span, 100)
 	source.Add(span, model.NewResolvedPolymorphicEvent(0, 101))
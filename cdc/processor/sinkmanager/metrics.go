@@ -50,6 +50,28 @@ var (
 		// type includes hit and miss.
 		[]string{"namespace", "changefeed", "type"})
 
+	// slowStartLevel indicates the sink concurrency level in slow-start.
+	// It's 0 if slow-start is not running.
+	slowStartLevel = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sinkmanager",
+			Name:      "slow_start_level",
+			Help:      "sink concurrency level of the changefeed in slow-start",
+		},
+		[]string{"namespace", "changefeed"})
+
+	// burstAbsorptionTables indicates the number of tables in the
+	// burst-absorption profile.
+	burstAbsorptionTables = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sinkmanager",
+			Name:      "burst_absorption_tables",
+			Help:      "number of tables of the changefeed in upstream write bursts",
+		},
+		[]string{"namespace", "changefeed"})
+
 	// outputEventCount is the metric that counts events output by the sorter.
 	outputEventCount = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "ticdc",
@@ -64,4 +86,6 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(RedoEventCache)
 	registry.MustRegister(RedoEventCacheAccess)
 	registry.MustRegister(outputEventCount)
+	registry.MustRegister(slowStartLevel)
+	registry.MustRegister(burstAbsorptionTables)
 }
@@ -0,0 +1,210 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// rampUpController implements slow-start for the table sinks. When a
+// changefeed starts with a large backlog, the number of concurrent sink tasks
+// and the size of each task are limited by a level, which is increased by one
+// every step interval if the downstream is healthy, and is halved if the
+// downstream reports errors or flushes slowly. Slow-start finishes once the
+// level reaches the max level, then the sink runs at full speed.
+type rampUpController struct {
+	changefeedID model.ChangeFeedID
+	// enabled is false if slow-start is not configured, in which case all
+	// methods are no-ops.
+	enabled         bool
+	stepInterval    time.Duration
+	maxFlushLatency time.Duration
+	minBacklog      time.Duration
+	maxLevel        int
+
+	mu sync.Mutex
+	// triggered is true once slow-start is triggered, it's only triggered
+	// once in the lifetime of the sink manager.
+	triggered bool
+	// running is true if slow-start is in progress.
+	running  bool
+	level    int
+	inflight int
+	lastStep time.Time
+	// The downstream status of the current step.
+	stepMaxLatency time.Duration
+	stepErrors     int
+
+	metricLevel prometheus.Gauge
+}
+
+func newRampUpController(
+	changefeedID model.ChangeFeedID,
+	cfg *config.SlowStartConfig,
+	maxLevel int,
+) *rampUpController {
+	c := &rampUpController{
+		changefeedID: changefeedID,
+		maxLevel:     maxLevel,
+		metricLevel:  slowStartLevel.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+	}
+	if cfg != nil && util.GetOrZero(cfg.Enable) {
+		c.enabled = true
+		c.stepInterval = cfg.GetStepInterval()
+		c.maxFlushLatency = cfg.GetMaxFlushLatency()
+		c.minBacklog = cfg.GetMinBacklog()
+	}
+	return c
+}
+
+// maybeStart starts slow-start if the backlog of a newly added table is
+// larger than the threshold.
+func (c *rampUpController) maybeStart(backlog time.Duration, now time.Time) {
+	if !c.enabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.triggered || backlog < c.minBacklog {
+		return
+	}
+	c.triggered = true
+	c.running = true
+	c.level = 1
+	c.lastStep = now
+	c.metricLevel.Set(float64(c.level))
+	log.Info("sink slow-start is started",
+		zap.String("namespace", c.changefeedID.Namespace),
+		zap.String("changefeed", c.changefeedID.ID),
+		zap.Duration("backlog", backlog),
+		zap.Duration("stepInterval", c.stepInterval),
+		zap.Duration("maxFlushLatency", c.maxFlushLatency))
+}
+
+// acquireTask returns true if a new sink task can be dispatched. A
+// successful acquire must be followed by a releaseTask.
+func (c *rampUpController) acquireTask() bool {
+	if !c.enabled {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.running && c.inflight >= c.level {
+		return false
+	}
+	c.inflight++
+	return true
+}
+
+// releaseTask is called when a sink task is finished or not dispatched.
+func (c *rampUpController) releaseTask() {
+	if !c.enabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.inflight > 0 {
+		c.inflight--
+	}
+}
+
+// batchSizeLimit returns the max bytes a sink task can fetch,
+// 0 means no limit.
+func (c *rampUpController) batchSizeLimit() uint64 {
+	if !c.enabled {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.running {
+		return 0
+	}
+	return requestMemSize * uint64(c.level)
+}
+
+// observeFlushLatency records how long the downstream takes to flush data.
+func (c *rampUpController) observeFlushLatency(latency time.Duration) {
+	if !c.enabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if latency > c.stepMaxLatency {
+		c.stepMaxLatency = latency
+	}
+}
+
+// observeSinkError records an error reported by the downstream.
+func (c *rampUpController) observeSinkError() {
+	if !c.enabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stepErrors++
+}
+
+// tick adjusts the level if a step interval has elapsed.
+func (c *rampUpController) tick(now time.Time) {
+	if !c.enabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.running || now.Sub(c.lastStep) < c.stepInterval {
+		return
+	}
+
+	oldLevel := c.level
+	if c.stepErrors > 0 || c.stepMaxLatency > c.maxFlushLatency {
+		c.level = c.level / 2
+		if c.level < 1 {
+			c.level = 1
+		}
+	} else {
+		c.level++
+	}
+	log.Info("sink slow-start level is adjusted",
+		zap.String("namespace", c.changefeedID.Namespace),
+		zap.String("changefeed", c.changefeedID.ID),
+		zap.Int("oldLevel", oldLevel),
+		zap.Int("newLevel", c.level),
+		zap.Int("errors", c.stepErrors),
+		zap.Duration("maxFlushLatency", c.stepMaxLatency))
+	c.lastStep = now
+	c.stepErrors = 0
+	c.stepMaxLatency = 0
+
+	if c.level >= c.maxLevel {
+		c.running = false
+		c.metricLevel.Set(0)
+		log.Info("sink slow-start is finished",
+			zap.String("namespace", c.changefeedID.Namespace),
+			zap.String("changefeed", c.changefeedID.ID))
+		return
+	}
+	c.metricLevel.Set(float64(c.level))
+}
+
+func (c *rampUpController) close() {
+	slowStartLevel.DeleteLabelValues(c.changefeedID.Namespace, c.changefeedID.ID)
+}
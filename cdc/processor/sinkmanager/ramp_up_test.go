@@ -0,0 +1,104 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRampUpControllerDisabled(t *testing.T) {
+	t.Parallel()
+
+	c := newRampUpController(model.DefaultChangeFeedID("1"), nil, 4)
+	c.maybeStart(time.Hour, time.Now())
+	for i := 0; i < 10; i++ {
+		require.True(t, c.acquireTask())
+	}
+	require.Equal(t, uint64(0), c.batchSizeLimit())
+}
+
+func TestRampUpController(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.SlowStartConfig{
+		Enable:          util.AddressOf(true),
+		StepInterval:    util.AddressOf("1s"),
+		MaxFlushLatency: util.AddressOf("1s"),
+		MinBacklog:      util.AddressOf("1m"),
+	}
+	c := newRampUpController(model.DefaultChangeFeedID("1"), cfg, 4)
+	defer c.close()
+
+	// The backlog is small, slow-start is not triggered.
+	now := time.Now()
+	c.maybeStart(time.Second, now)
+	require.True(t, c.acquireTask())
+	require.True(t, c.acquireTask())
+	require.Equal(t, uint64(0), c.batchSizeLimit())
+	c.releaseTask()
+	c.releaseTask()
+
+	c.maybeStart(time.Hour, now)
+	require.True(t, c.acquireTask())
+	require.False(t, c.acquireTask())
+	require.Equal(t, requestMemSize, c.batchSizeLimit())
+
+	// The level is not changed before the step interval elapses.
+	c.tick(now.Add(500 * time.Millisecond))
+	require.Equal(t, 1, c.level)
+
+	now = now.Add(time.Second)
+	c.tick(now)
+	require.Equal(t, 2, c.level)
+	require.True(t, c.acquireTask())
+	require.False(t, c.acquireTask())
+	require.Equal(t, requestMemSize*2, c.batchSizeLimit())
+
+	now = now.Add(time.Second)
+	c.tick(now)
+	require.Equal(t, 3, c.level)
+
+	// Slow flush halves the level.
+	c.observeFlushLatency(2 * time.Second)
+	now = now.Add(time.Second)
+	c.tick(now)
+	require.Equal(t, 1, c.level)
+
+	// Sink errors also halve the level, but it's at least 1.
+	c.observeSinkError()
+	now = now.Add(time.Second)
+	c.tick(now)
+	require.Equal(t, 1, c.level)
+
+	// Slow-start finishes once the max level is reached.
+	for i := 0; i < 3; i++ {
+		now = now.Add(time.Second)
+		c.tick(now)
+	}
+	require.False(t, c.running)
+	require.Equal(t, uint64(0), c.batchSizeLimit())
+	for i := 0; i < 10; i++ {
+		require.True(t, c.acquireTask())
+	}
+
+	// Slow-start is only triggered once.
+	c.maybeStart(time.Hour, now)
+	require.False(t, c.running)
+}
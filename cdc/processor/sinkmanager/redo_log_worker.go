@@ -73,6 +73,7 @@ func (w *redoWorker) handleTask(ctx context.Context, task *redoTask) (finalErr e
 		&task.span,
 		task.lowerBound,
 		task.getUpperBound(task.tableSink.getReceivedSorterResolvedTs()),
+		1,
 	)
 
 	var cache *eventAppender
@@ -84,7 +85,7 @@ func (w *redoWorker) handleTask(ctx context.Context, task *redoTask) (finalErr e
 	// The task is finished and some required memory isn't used.
 	defer advancer.cleanup()
 
-	iter := w.sourceManager.FetchByTable(task.span, lowerBound, upperBound, w.memQuota)
+	iter := w.sourceManager.FetchByTable(task.span, lowerBound, upperBound, w.memQuota, 1)
 	allEventCount := 0
 	cachedSize := uint64(0)
 
@@ -61,7 +61,7 @@ func (suite *redoLogWorkerSuite) createWorker(
 	ctx context.Context, memQuota uint64,
 ) (*redoWorker, engine.SortEngine, *mockRedoDMLManager) {
 	sortEngine := memory.New(context.Background())
-	sm := sourcemanager.New(suite.testChangefeedID, upstream.NewUpstream4Test(&MockPD{}),
+	sm := sourcemanager.New(suite.testChangefeedID, upstream.NewUpstream4Test(&MockPD{}), nil,
 		&entry.MockMountGroup{}, sortEngine, false)
 	go func() { _ = sm.Run(ctx) }()
 
@@ -178,7 +178,7 @@ func (a *tableSinkAdvancer) tryAdvanceAndAcquireMem(
 	// 2. all events are received.
 	// 3. the pending batch size exceeds maxUpdateIntervalSize;
 	if exceedAvailableMem || allFetched ||
-		needEmitAndAdvance(a.splitTxn, a.committedTxnSize, a.pendingTxnSize) {
+		needEmitAndAdvance(a.splitTxn, a.committedTxnSize, a.pendingTxnSize, a.task.batchFactor) {
 		if err := a.advance(false); err != nil {
 			return errors.Trace(err)
 		}
@@ -329,14 +329,21 @@ func advanceTableSink(
 	return t.tableSink.updateResolvedTs(resolvedTs)
 }
 
-func needEmitAndAdvance(splitTxn bool, committedTxnSize uint64, pendingTxnSize uint64) bool {
+func needEmitAndAdvance(
+	splitTxn bool, committedTxnSize uint64, pendingTxnSize uint64, batchFactor int,
+) bool {
+	// Tables in an upstream write burst advance the resolved ts less frequently.
+	updateIntervalSize := maxUpdateIntervalSize
+	if batchFactor > 1 {
+		updateIntervalSize *= uint64(batchFactor)
+	}
 	// If splitTxn is true, we can safely emit all the events in the last transaction
 	// and current transaction. So we use `committedTxnSize+pendingTxnSize`.
-	splitTxnEmitCondition := splitTxn && committedTxnSize+pendingTxnSize >= maxUpdateIntervalSize
+	splitTxnEmitCondition := splitTxn && committedTxnSize+pendingTxnSize >= updateIntervalSize
 	// If splitTxn is false, we need to emit the events when the size of the
 	// transaction is greater than maxUpdateIntervalSize.
 	// This could help to reduce the overhead of emit and advance too frequently.
-	noSplitTxnEmitCondition := !splitTxn && committedTxnSize >= maxUpdateIntervalSize
+	noSplitTxnEmitCondition := !splitTxn && committedTxnSize >= updateIntervalSize
 	return splitTxnEmitCondition ||
 		noSplitTxnEmitCondition
 }
@@ -118,7 +118,7 @@ func (suite *tableSinkAdvancerSuite) TestNeedEmitAndAdvance() {
 	} {
 		suite.Run(tc.name, func() {
 			require.Equal(suite.T(), tc.expected,
-				needEmitAndAdvance(tc.splitTxn, tc.committedTxnSize, tc.pendingTxnSize))
+				needEmitAndAdvance(tc.splitTxn, tc.committedTxnSize, tc.pendingTxnSize, 1))
 		})
 	}
 }
@@ -62,7 +62,9 @@ type sinkWorker struct {
 	sourceManager *sourcemanager.SourceManager
 	sinkMemQuota  *memquota.MemQuota
 	redoMemQuota  *memquota.MemQuota
-	eventCache    *redoEventCache
+	// rampUp is notified when a task is finished or the sink reports errors.
+	rampUp     *rampUpController
+	eventCache *redoEventCache
 	// splitTxn indicates whether to split the transaction into multiple batches.
 	splitTxn bool
 	// enableOldValue indicates whether to enable the old value feature.
@@ -81,6 +83,7 @@ func newSinkWorker(
 	sourceManager *sourcemanager.SourceManager,
 	sinkQuota *memquota.MemQuota,
 	redoQuota *memquota.MemQuota,
+	rampUp *rampUpController,
 	eventCache *redoEventCache,
 	splitTxn bool,
 	enableOldValue bool,
@@ -90,6 +93,7 @@ func newSinkWorker(
 		sourceManager:  sourceManager,
 		sinkMemQuota:   sinkQuota,
 		redoMemQuota:   redoQuota,
+		rampUp:         rampUp,
 		eventCache:     eventCache,
 		splitTxn:       splitTxn,
 		enableOldValue: enableOldValue,
@@ -107,6 +111,7 @@ func (w *sinkWorker) handleTasks(ctx context.Context, taskChan <-chan *sinkTask)
 			return ctx.Err()
 		case task := <-taskChan:
 			err := w.handleTask(ctx, task)
+			w.rampUp.releaseTask()
 			failpoint.Inject("SinkWorkerTaskError", func() {
 				err = errors.New("SinkWorkerTaskError")
 			})
@@ -128,7 +133,8 @@ func (w *sinkWorker) handleTask(ctx context.Context, task *sinkTask) (finalErr e
 		w.changefeedID,
 		&task.span,
 		task.lowerBound,
-		task.getUpperBound(task.tableSink.getUpperBoundTs()))
+		task.getUpperBound(task.tableSink.getUpperBoundTs()),
+		task.batchFactor)
 	if w.eventCache != nil {
 		drained, err := w.fetchFromCache(task, &lowerBound, &upperBound)
 		if err != nil {
@@ -145,15 +151,17 @@ func (w *sinkWorker) handleTask(ctx context.Context, task *sinkTask) (finalErr e
 	allEventSize := uint64(0)
 	allEventCount := 0
 	// lowerBound and upperBound are both closed intervals.
-	iter := w.sourceManager.FetchByTable(task.span, lowerBound, upperBound, w.sinkMemQuota)
+	iter := w.sourceManager.FetchByTable(
+		task.span, lowerBound, upperBound, w.sinkMemQuota, task.batchFactor)
 
 	defer func() {
 		// Collect metrics.
 		w.metricRedoEventCacheMiss.Add(float64(allEventSize))
 		w.metricOutputEventCountKV.Add(float64(allEventCount))
 
-		// If eventCache is nil, update sorter commit ts and range event count.
-		if w.eventCache == nil {
+		// If eventCache is nil or the table isn't covered by redo log, update
+		// sorter commit ts and range event count.
+		if w.eventCache == nil || !task.tableSink.redoCovered {
 			eventCount := newRangeEventCount(advancer.lastPos, allEventCount)
 			task.tableSink.updateRangeEventCounts(eventCount)
 		}
@@ -186,6 +194,7 @@ func (w *sinkWorker) handleTask(ctx context.Context, task *sinkTask) (finalErr e
 			// events have been reported. Then we can continue the table
 			// at the checkpoint position.
 			case tablesink.SinkInternalError:
+				w.rampUp.observeSinkError()
 				task.tableSink.clearTableSink()
 				// After the table sink is cleared all pending events are sent out or dropped.
 				// So we can re-add the table into sinkMemQuota.
@@ -250,6 +259,11 @@ func (w *sinkWorker) handleTask(ctx context.Context, task *sinkTask) (finalErr e
 		); err != nil {
 			return errors.Trace(err)
 		}
+
+		// The task is limited by slow-start, stop it at a transaction boundary.
+		if task.maxBatchSize > 0 && allEventSize >= task.maxBatchSize && pos.Valid() {
+			break
+		}
 	}
 
 	return advancer.lastTimeAdvance()
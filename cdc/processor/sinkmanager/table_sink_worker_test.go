@@ -127,7 +127,7 @@ func (suite *tableSinkWorkerSuite) createWorker(
 	ctx context.Context, memQuota uint64, splitTxn bool,
 ) (*sinkWorker, engine.SortEngine) {
 	sortEngine := memory.New(context.Background())
-	sm := sourcemanager.New(suite.testChangefeedID, upstream.NewUpstream4Test(&MockPD{}),
+	sm := sourcemanager.New(suite.testChangefeedID, upstream.NewUpstream4Test(&MockPD{}), nil,
 		&entry.MockMountGroup{}, sortEngine, false)
 	go func() { sm.Run(ctx) }()
 
@@ -137,7 +137,8 @@ func (suite *tableSinkWorkerSuite) createWorker(
 	quota.ForceAcquire(testEventSize)
 	quota.AddTable(suite.testSpan)
 
-	return newSinkWorker(suite.testChangefeedID, sm, quota, nil, nil, splitTxn, false), sortEngine
+	return newSinkWorker(suite.testChangefeedID, sm, quota, nil,
+		newRampUpController(suite.testChangefeedID, nil, sinkWorkerNum), nil, splitTxn, false), sortEngine
 }
 
 func (suite *tableSinkWorkerSuite) addEventsToSortEngine(
@@ -270,6 +271,54 @@ func (suite *tableSinkWorkerSuite) TestHandleTaskWithSplitTxnAndAbortWhenNoMemAn
 	require.Len(suite.T(), sink.GetEvents(), 3)
 }
 
+// Test Scenario:
+// worker will stop at the txn boundary when the batch size of slow-start is reached.
+func (suite *tableSinkWorkerSuite) TestHandleTaskWithMaxBatchSize() {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := []*model.PolymorphicEvent{
+		genPolymorphicEvent(1, 2, suite.testSpan),
+		genPolymorphicEvent(1, 2, suite.testSpan),
+		genPolymorphicEvent(1, 3, suite.testSpan),
+		genPolymorphicEvent(2, 4, suite.testSpan),
+		genPolymorphicResolvedEvent(4),
+	}
+
+	// Enough memory for all events.
+	eventSize := uint64(testEventSize * 10)
+	w, e := suite.createWorker(ctx, eventSize, true)
+	defer w.sinkMemQuota.Close()
+	suite.addEventsToSortEngine(events, e)
+
+	taskChan := make(chan *sinkTask)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := w.handleTasks(ctx, taskChan)
+		require.Equal(suite.T(), context.Canceled, err)
+	}()
+
+	wrapper, sink := createTableSinkWrapper(suite.testChangefeedID, suite.testSpan)
+	callback := func(lastWritePos engine.Position) {
+		require.Equal(suite.T(), engine.Position{
+			StartTs:  1,
+			CommitTs: 3,
+		}, lastWritePos, "we only write 3 events because of the batch size")
+		cancel()
+	}
+	taskChan <- &sinkTask{
+		span:          suite.testSpan,
+		lowerBound:    genLowerBound(),
+		getUpperBound: genUpperBoundGetter(4),
+		tableSink:     wrapper,
+		callback:      callback,
+		isCanceled:    func() bool { return false },
+		maxBatchSize:  testEventSize * 3,
+	}
+	wg.Wait()
+	require.Len(suite.T(), sink.GetEvents(), 3)
+}
+
 // Test Scenario:
 // worker will block when no memory quota until the mem quota is aborted.
 func (suite *tableSinkWorkerSuite) TestHandleTaskWithSplitTxnAndAbortWhenNoMemAndBlocked() {
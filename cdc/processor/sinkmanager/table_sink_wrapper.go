@@ -27,9 +27,9 @@ import (
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/sink/tablesink"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/tikv/client-go/v2/oracle"
-	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
 )
 
@@ -64,6 +64,27 @@ type tableSinkWrapper struct {
 	// receivedSorterResolvedTs is the resolved ts received from the sorter.
 	// We use this to advance the redo log.
 	receivedSorterResolvedTs atomic.Uint64
+	// readyTs is the sorter resolved ts that a preparing table must reach
+	// before it becomes prepared. Zero means any progress is enough.
+	readyTs atomic.Uint64
+	// emittedRows and emittedBytes are the accumulated number and approximate
+	// size of rows emitted to the table sink. They are reported in the table
+	// stats so that the scheduler can balance tables by throughput.
+	emittedRows  atomic.Uint64
+	emittedBytes atomic.Uint64
+
+	// redoCovered indicates whether the changes of the table are written to
+	// the redo log. It's immutable after the table is added.
+	redoCovered bool
+	// e2eLatencyTable is the table label of the end-to-end latency histogram
+	// of the table. It's immutable after the table is added.
+	e2eLatencyTable string
+
+	// burst is the upstream ingress state of the table, see burstDetector.
+	burst burstState
+	// batchFactor enlarges the batches of sink tasks of the table. It's
+	// larger than 1 only if the table is in an upstream write burst.
+	batchFactor atomic.Int32
 
 	// replicateTs is the ts that the table sink has started to replicate.
 	replicateTs    model.Ts
@@ -116,6 +137,7 @@ func newTableSinkWrapper(
 	res.tableSinkCheckpointTs = model.NewResolvedTs(startTs)
 	res.receivedSorterResolvedTs.Store(startTs)
 	res.barrierTs.Store(startTs)
+	res.batchFactor.Store(1)
 	return res
 }
 
@@ -161,7 +183,10 @@ func (t *tableSinkWrapper) appendRowChangedEvents(events ...*model.RowChangedEve
 	defer t.tableSinkMu.RUnlock()
 	// If it's nil it means it's closed.
 	if t.tableSink != nil {
+		sampleEvents(t.changefeed, t.span.TableID, events)
 		t.tableSink.AppendRowChangedEvents(events...)
+		t.emittedRows.Add(uint64(len(events)))
+		t.emittedBytes.Add(uint64(columnsBytes(events)))
 	} else {
 		// If it's nil it means it's closed.
 		return tablesink.NewSinkInternalError(errors.New("table sink cleared"))
@@ -169,6 +194,24 @@ func (t *tableSinkWrapper) appendRowChangedEvents(events ...*model.RowChangedEve
 	return nil
 }
 
+// columnsBytes returns the approximate size of column values of the events.
+func columnsBytes(events []*model.RowChangedEvent) int {
+	size := 0
+	for _, e := range events {
+		for _, col := range e.Columns {
+			if col != nil {
+				size += col.ApproximateBytes
+			}
+		}
+		for _, col := range e.PreColumns {
+			if col != nil {
+				size += col.ApproximateBytes
+			}
+		}
+	}
+	return size
+}
+
 func (t *tableSinkWrapper) updateReceivedSorterResolvedTs(ts model.Ts) {
 	for {
 		old := t.receivedSorterResolvedTs.Load()
@@ -176,7 +219,7 @@ func (t *tableSinkWrapper) updateReceivedSorterResolvedTs(ts model.Ts) {
 			return
 		}
 		if t.receivedSorterResolvedTs.CompareAndSwap(old, ts) {
-			if t.state.Load() == tablepb.TableStatePreparing {
+			if t.state.Load() == tablepb.TableStatePreparing && ts >= t.readyTs.Load() {
 				t.state.Store(tablepb.TableStatePrepared)
 			}
 			return
@@ -500,12 +543,12 @@ func splitUpdateEvent(
 	return &deleteEvent, &insertEvent, nil
 }
 
-func genReplicateTs(ctx context.Context, pdClient pd.Client) (model.Ts, error) {
+func genReplicateTs(ctx context.Context, tsoClient pdutil.TSOClient) (model.Ts, error) {
 	backoffBaseDelayInMs := int64(100)
 	totalRetryDuration := 10 * time.Second
 	var replicateTs model.Ts
 	err := retry.Do(ctx, func() error {
-		phy, logic, err := pdClient.GetTS(ctx)
+		phy, logic, err := tsoClient.GetTS(ctx)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -519,3 +562,7 @@ func genReplicateTs(ctx context.Context, pdClient pd.Client) (model.Ts, error) {
 	}
 	return replicateTs, nil
 }
+
+func (t *tableSinkWrapper) getBatchFactor() int {
+	return int(t.batchFactor.Load())
+}
@@ -82,7 +82,7 @@ func createTableSinkWrapper(
 	sink := newMockSink()
 	innerTableSink := tablesink.New[*model.RowChangedEvent](
 		changefeedID, span, model.Ts(0),
-		sink, &dmlsink.RowChangeEventAppender{}, prometheus.NewCounter(prometheus.CounterOpts{}))
+		sink, &dmlsink.RowChangeEventAppender{}, prometheus.NewCounter(prometheus.CounterOpts{}), nil)
 	wrapper := newTableSinkWrapper(
 		changefeedID,
 		span,
@@ -116,6 +116,40 @@ func TestUpdateReceivedSorterResolvedTs(t *testing.T) {
 	require.Equal(t, tablepb.TableStatePrepared, wrapper.getState())
 }
 
+func TestUpdateReceivedSorterResolvedTsWithReadyTs(t *testing.T) {
+	t.Parallel()
+
+	wrapper, _ := createTableSinkWrapper(
+		model.DefaultChangeFeedID("1"), spanz.TableIDToComparableSpan(1))
+	wrapper.readyTs.Store(100)
+	wrapper.updateReceivedSorterResolvedTs(50)
+	require.Equal(t, uint64(50), wrapper.getReceivedSorterResolvedTs())
+	require.Equal(t, tablepb.TableStatePreparing, wrapper.getState())
+	wrapper.updateReceivedSorterResolvedTs(100)
+	require.Equal(t, tablepb.TableStatePrepared, wrapper.getState())
+}
+
+func TestTableSinkWrapperEmittedStats(t *testing.T) {
+	t.Parallel()
+
+	wrapper, _ := createTableSinkWrapper(
+		model.DefaultChangeFeedID("1"), spanz.TableIDToComparableSpan(1))
+	events := []*model.RowChangedEvent{
+		{
+			CommitTs: 1,
+			Columns:  []*model.Column{{Name: "a", ApproximateBytes: 10}},
+		},
+		{
+			CommitTs:   2,
+			Columns:    []*model.Column{{Name: "a", ApproximateBytes: 10}, nil},
+			PreColumns: []*model.Column{{Name: "a", ApproximateBytes: 5}},
+		},
+	}
+	require.Nil(t, wrapper.appendRowChangedEvents(events...))
+	require.Equal(t, uint64(2), wrapper.emittedRows.Load())
+	require.Equal(t, uint64(25), wrapper.emittedBytes.Load())
+}
+
 func TestConvertNilRowChangedEvents(t *testing.T) {
 	t.Parallel()
 
@@ -66,6 +66,12 @@ type sinkTask struct {
 	tableSink     *tableSinkWrapper
 	callback      writeSuccessCallback
 	isCanceled    isCanceled
+	// maxBatchSize limits the bytes of events fetched by the task,
+	// 0 means no limit. It's used by slow-start.
+	maxBatchSize uint64
+	// batchFactor enlarges the batches of the task, it's larger than 1 only
+	// if the table is in an upstream write burst.
+	batchFactor int
 }
 
 // redoTask is a task for the redo log.
@@ -82,13 +88,18 @@ func validateAndAdjustBound(
 	changefeedID model.ChangeFeedID,
 	span *tablepb.Span,
 	lowerBound, upperBound engine.Position,
+	batchFactor int,
 ) (engine.Position, engine.Position) {
 	lowerPhs := oracle.GetTimeFromTS(lowerBound.CommitTs)
 	upperPhs := oracle.GetTimeFromTS(upperBound.CommitTs)
 	// The time range of a task should not exceed maxTaskTimeRange.
 	// This would help for reduce changefeed latency.
-	if upperPhs.Sub(lowerPhs) > maxTaskTimeRange {
-		newUpperCommitTs := oracle.GoTimeToTS(lowerPhs.Add(maxTaskTimeRange))
+	maxTimeRange := maxTaskTimeRange
+	if batchFactor > 1 {
+		maxTimeRange *= time.Duration(batchFactor)
+	}
+	if upperPhs.Sub(lowerPhs) > maxTimeRange {
+		newUpperCommitTs := oracle.GoTimeToTS(lowerPhs.Add(maxTimeRange))
 		upperBound = engine.GenCommitFence(newUpperCommitTs)
 	}
 
@@ -57,7 +57,7 @@ func TestValidateAndAdjustBound(t *testing.T) {
 			newUpperCommitTs := oracle.GoTimeToTS(lowerPhs.Add(tc.taskTimeRange))
 			upperBound := engine.GenCommitFence(newUpperCommitTs)
 			newLowerBound, newUpperBound := validateAndAdjustBound(changefeedID,
-				&span, tc.lowerBound, upperBound)
+				&span, tc.lowerBound, upperBound, 1)
 			if tc.expectAdjust {
 				lowerPhs := oracle.GetTimeFromTS(newLowerBound.CommitTs)
 				upperPhs := oracle.GetTimeFromTS(newUpperBound.CommitTs)
@@ -165,4 +165,7 @@ func (p Position) IsCommitFence() bool {
 type TableStats struct {
 	ReceivedMaxCommitTs   model.Ts
 	ReceivedMaxResolvedTs model.Ts
+	// ReceivedEvents is the accumulated number of events, except resolved
+	// events, received by the table.
+	ReceivedEvents uint64
 }
@@ -25,7 +25,10 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
 	epebble "github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/pebble"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/pebble/encoding"
 	"github.com/pingcap/tiflow/pkg/config"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/leakutil"
 	"go.uber.org/atomic"
 	"go.uber.org/multierr"
 )
@@ -54,6 +57,9 @@ type SortEngineFactory struct {
 
 	mu      sync.Mutex
 	engines map[model.ChangeFeedID]engine.SortEngine
+	// compactMu serializes manual compactions, and prevents dbs from being
+	// closed during compactions.
+	compactMu sync.Mutex
 
 	wg     sync.WaitGroup
 	closed chan struct{}
@@ -62,6 +68,7 @@ type SortEngineFactory struct {
 	pebbleConfig *config.DBConfig
 	dbs          []*pebble.DB
 	writeStalls  []writeStall
+	ioSchedulers []*epebble.IOScheduler
 
 	// dbs is also readed in the background metrics collector.
 	dbInitialized *atomic.Bool
@@ -80,13 +87,29 @@ func (f *SortEngineFactory) Create(ID model.ChangeFeedID) (e engine.SortEngine,
 			return e, nil
 		}
 		if len(f.dbs) == 0 {
-			f.dbs, f.writeStalls, err = createPebbleDBs(f.dir, f.pebbleConfig, f.memQuotaInBytes)
+			// The dbs are shared by all changefeeds, so their background
+			// goroutines shouldn't be labeled by the caller's changefeed.
+			leakutil.DoWithoutLabels(func() {
+				f.dbs, f.writeStalls, err = createPebbleDBs(f.dir, f.pebbleConfig, f.memQuotaInBytes)
+			})
 			if err != nil {
 				return
 			}
+			sorterCfg := config.GetGlobalServerConfig().Sorter
+			f.ioSchedulers = epebble.NewIOSchedulers(len(f.dbs), epebble.IOSchedulerConfig{
+				BudgetPerTick: sorterCfg.IOBudgetPerTickInMB * uint64(1<<20),
+				Deadline:      time.Duration(sorterCfg.IODeadline),
+			})
 			f.dbInitialized.Store(true)
 		}
-		e = epebble.New(ID, f.dbs)
+		sorterCfg := config.GetGlobalServerConfig().Sorter
+		e = epebble.NewWithValueCompression(ID, f.dbs, epebble.Quota{
+			DiskBytes:           sorterCfg.ChangefeedDiskQuotaInMB * uint64(1<<20),
+			WriteBytesPerSecond: sorterCfg.ChangefeedWriteRateInMB * uint64(1<<20),
+		}, f.ioSchedulers, encoding.CompressionConfig{
+			Algorithm: f.pebbleConfig.ValueCompression,
+			DictSize:  f.pebbleConfig.ValueCompressionDictSize,
+		})
 		f.engines[ID] = e
 	default:
 		log.Panic("not implemented")
@@ -107,6 +130,42 @@ func (f *SortEngineFactory) Drop(ID model.ChangeFeedID) error {
 	return engine.Close()
 }
 
+// Compact compacts the shared DBs manually, and returns the approximate
+// reclaimed bytes. Only keys of the given changefeed are compacted if
+// changefeedID is not nil, and only keys of the given table of the
+// changefeed are compacted if tableID is also not nil.
+func (f *SortEngineFactory) Compact(
+	changefeedID *model.ChangeFeedID, tableID *model.TableID,
+) (int64, error) {
+	f.mu.Lock()
+	if f.engineType != pebbleEngine || !f.dbInitialized.Load() {
+		f.mu.Unlock()
+		if changefeedID != nil {
+			return 0, cerrors.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedID.ID)
+		}
+		return 0, nil
+	}
+	var sorter *epebble.EventSorter
+	if changefeedID != nil {
+		e, exists := f.engines[*changefeedID]
+		if !exists {
+			f.mu.Unlock()
+			return 0, cerrors.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedID.ID)
+		}
+		sorter = e.(*epebble.EventSorter)
+	}
+	// Don't block creating or dropping engines during the compaction.
+	f.compactMu.Lock()
+	defer f.compactMu.Unlock()
+	dbs := f.dbs
+	f.mu.Unlock()
+
+	if sorter != nil {
+		return sorter.Compact(tableID)
+	}
+	return epebble.CompactAll(dbs)
+}
+
 // Close will close all created engines and release all resources.
 func (f *SortEngineFactory) Close() (err error) {
 	factoryMu.Lock()
@@ -119,6 +178,9 @@ func (f *SortEngineFactory) Close() (err error) {
 	close(f.closed)
 	f.wg.Wait()
 
+	f.compactMu.Lock()
+	defer f.compactMu.Unlock()
+
 	for _, engine := range f.engines {
 		err = multierr.Append(err, engine.Close())
 	}
@@ -101,6 +101,47 @@ var (
 		Name:      "block_cache_access_total",
 		Help:      "The total number of db block cache access",
 	}, []string{"id", "type"})
+
+	// sorterChangefeedDiskUsageGauge is the size of resolved events of a
+	// changefeed which are not cleaned from the shared DBs.
+	sorterChangefeedDiskUsageGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc",
+		Subsystem: "sorter",
+		Name:      "changefeed_disk_usage",
+		Help:      "The size of resolved events of a changefeed which are not cleaned",
+	}, []string{"namespace", "changefeed"})
+
+	sorterCleanedBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "sorter",
+		Name:      "cleaned_bytes_total",
+		Help:      "The size of events cleaned from the shared DBs",
+	}, []string{"namespace"})
+
+	// type includes disk and write-rate.
+	sorterThrottledDurationCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "sorter",
+		Name:      "throttled_duration_seconds_total",
+		Help:      "The time spent on waiting for the sorter quota of a changefeed",
+	}, []string{"namespace", "changefeed", "type"})
+
+	// type includes raw and compressed.
+	sorterValueCompressionBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "sorter",
+		Name:      "value_compression_bytes_total",
+		Help:      "The size of event values before and after compressed by the sorter",
+	}, []string{"namespace", "changefeed", "type"})
+
+	// type includes read and write.
+	sorterIOWaitDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "sorter",
+		Name:      "io_wait_duration_seconds",
+		Help:      "Bucketed histogram of the time spent on waiting for the I/O budget of a db",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2.0, 16),
+	}, []string{"id", "type"})
 )
 
 /* Some metrics are shared in pipeline sorter and pull-based-sink sort engine */
@@ -155,6 +196,31 @@ func BlockCacheAccess() *prometheus.GaugeVec {
 	return dbBlockCacheAccess
 }
 
+// SorterChangefeedDiskUsage returns sorterChangefeedDiskUsageGauge.
+func SorterChangefeedDiskUsage() *prometheus.GaugeVec {
+	return sorterChangefeedDiskUsageGauge
+}
+
+// SorterCleanedBytes returns sorterCleanedBytesCounter.
+func SorterCleanedBytes() *prometheus.CounterVec {
+	return sorterCleanedBytesCounter
+}
+
+// SorterThrottledDuration returns sorterThrottledDurationCounter.
+func SorterThrottledDuration() *prometheus.CounterVec {
+	return sorterThrottledDurationCounter
+}
+
+// SorterValueCompressionBytes returns sorterValueCompressionBytesCounter.
+func SorterValueCompressionBytes() *prometheus.CounterVec {
+	return sorterValueCompressionBytesCounter
+}
+
+// SorterIOWaitDuration returns sorterIOWaitDurationHistogram.
+func SorterIOWaitDuration() *prometheus.HistogramVec {
+	return sorterIOWaitDurationHistogram
+}
+
 // InitMetrics registers all metrics in this file
 func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(mountWaitDuration)
@@ -165,6 +231,11 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(inMemoryDataSizeGauge)
 	registry.MustRegister(onDiskDataSizeGauge)
 	registry.MustRegister(dbIteratorGauge)
+	registry.MustRegister(sorterChangefeedDiskUsageGauge)
+	registry.MustRegister(sorterCleanedBytesCounter)
+	registry.MustRegister(sorterThrottledDurationCounter)
+	registry.MustRegister(sorterIOWaitDurationHistogram)
+	registry.MustRegister(sorterValueCompressionBytesCounter)
 
 	// TODO: Seems these things belong to pebble instead of engine.
 	registry.MustRegister(dbLevelCount)
@@ -0,0 +1,113 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/pebble/encoding"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// compactRange is a key range [start, end) to compact in the db.
+type compactRange struct {
+	db         int
+	start, end []byte
+}
+
+// CompactAll compacts all keys in the given DBs, so the disk space held by
+// deleted events, e.g. events of removed changefeeds, can be reclaimed.
+// It returns the approximate reclaimed bytes.
+func CompactAll(dbs []*pebble.DB) (int64, error) {
+	ranges := make([]compactRange, 0, len(dbs))
+	for i := range dbs {
+		ranges = append(ranges, compactRange{
+			db:    i,
+			start: encoding.EncodeTsKey(0, 0, 0),
+			end:   encoding.EncodeTsKey(math.MaxUint32, math.MaxUint64, math.MaxUint64),
+		})
+	}
+	return compactRanges(dbs, ranges)
+}
+
+// Compact compacts keys of tables of the changefeed in the shared DBs. All
+// tables are compacted if tableID is nil. It returns the approximate
+// reclaimed bytes.
+func (s *EventSorter) Compact(tableID *model.TableID) (int64, error) {
+	var ranges []compactRange
+	found := false
+	s.mu.RLock()
+	s.tables.Range(func(span tablepb.Span, state *tableState) bool {
+		if tableID != nil && span.TableID != *tableID {
+			return true
+		}
+		found = true
+		start := encoding.EncodeTsKey(state.uniqueID, uint64(span.TableID), 0)
+		end := encoding.EncodeTsKey(state.uniqueID, uint64(span.TableID)+1, 0)
+		state.mu.RLock()
+		for _, shard := range state.shards {
+			ranges = append(ranges, compactRange{db: shard.db, start: start, end: end})
+		}
+		state.mu.RUnlock()
+		return true
+	})
+	s.mu.RUnlock()
+
+	if tableID != nil && !found {
+		return 0, cerrors.ErrProcessorTableNotFound.GenWithStack(
+			"table %d of changefeed %s is not replicated by the capture",
+			*tableID, s.changefeedID.ID)
+	}
+	return compactRanges(s.dbs, ranges)
+}
+
+// compactRanges compacts the given ranges, and returns the decreased disk
+// space usage of the compacted DBs. Obsolete files may be deleted in the
+// background after compactions, so the result is only approximate.
+func compactRanges(dbs []*pebble.DB, ranges []compactRange) (reclaimed int64, err error) {
+	before := make(map[int]uint64, len(dbs))
+	for _, r := range ranges {
+		if _, ok := before[r.db]; !ok {
+			before[r.db] = dbs[r.db].Metrics().DiskSpaceUsage()
+		}
+	}
+
+	for _, r := range ranges {
+		start := time.Now()
+		if err = dbs[r.db].Compact(r.start, r.end, true); err != nil {
+			return 0, err
+		}
+		engine.SorterCompactionDuration().WithLabelValues(strconv.Itoa(r.db + 1)).
+			Observe(time.Since(start).Seconds())
+	}
+
+	for id, usage := range before {
+		after := dbs[id].Metrics().DiskSpaceUsage()
+		if usage > after {
+			reclaimed += int64(usage - after)
+		}
+	}
+	log.Info("sorter db compaction finished",
+		zap.Int("ranges", len(ranges)),
+		zap.Int64("reclaimedBytes", reclaimed))
+	return reclaimed, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/pebble/encoding"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactAll(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), t.Name())
+	db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, nil)
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	value := make([]byte, 1024)
+	for i := uint64(0); i < 1024; i++ {
+		require.Nil(t, db.Set(encoding.EncodeTsKey(1, 1, i+1), value, pebble.NoSync))
+	}
+	require.Nil(t, db.Flush())
+	require.Nil(t, db.DeleteRange(
+		encoding.EncodeTsKey(1, 1, 0), encoding.EncodeTsKey(1, 2, 0), pebble.NoSync))
+
+	reclaimed, err := CompactAll([]*pebble.DB{db})
+	require.Nil(t, err)
+	require.Greater(t, reclaimed, int64(0))
+}
+
+func TestEventSorterCompact(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), t.Name())
+	db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, nil)
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	cf := model.ChangeFeedID{Namespace: "default", ID: "test"}
+	s := New(cf, []*pebble.DB{db})
+	defer s.Close()
+	s.AddTable(spanz.TableIDToComparableSpan(1), 1)
+
+	_, err = s.Compact(nil)
+	require.Nil(t, err)
+	tableID := model.TableID(1)
+	_, err = s.Compact(&tableID)
+	require.Nil(t, err)
+	tableID = 2
+	_, err = s.Compact(&tableID)
+	require.ErrorContains(t, err, "not replicated")
+}
@@ -105,13 +105,20 @@ func OpenPebble(
 	}
 
 	db, err = pebble.Open(dbDir, opts)
+	if err == nil && opts.DisableWAL {
+		log.Info("pebble is opened without WAL, "+
+			"all tables will be pulled from TiKV again after a crash",
+			zap.String("dir", dbDir))
+	}
 	return
 }
 
 func buildPebbleOption(cfg *config.DBConfig) (opts *pebble.Options) {
 	opts = new(pebble.Options)
+	// The data dir is always cleaned before opening a db, which means sorted
+	// data can't be reused after restarts and it's safe to disable WAL.
 	opts.ErrorIfExists = true
-	opts.DisableWAL = false // Delete range requires WAL.
+	opts.DisableWAL = cfg.DisableWAL
 	opts.MaxOpenFiles = cfg.MaxOpenFiles / cfg.Count
 	opts.MaxConcurrentCompactions = 6
 	opts.L0CompactionThreshold = cfg.CompactionL0Trigger
@@ -105,3 +105,58 @@ func TestIteratorWithTableFilter(t *testing.T) {
 		require.Equal(t, x.expectedCount, count)
 	}
 }
+
+func TestDeleteRangeWithoutWAL(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), t.Name())
+	db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1, DisableWAL: true}, nil)
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	writeOpts := &pebble.WriteOptions{Sync: false}
+	for ts := uint64(1); ts <= 4; ts++ {
+		require.Nil(t, db.Set(encoding.EncodeTsKey(1, 1, ts, 0), []byte{'x'}, writeOpts))
+	}
+	require.Nil(t, db.DeleteRange(
+		encoding.EncodeTsKey(1, 1, 0), encoding.EncodeTsKey(1, 1, 3), writeOpts))
+
+	countKeys := func() int {
+		iter := iterTable(db, 1, 1, engine.Position{}, engine.Position{CommitTs: 5})
+		defer iter.Close()
+		count := 0
+		for ; iter.Valid(); iter.Next() {
+			count++
+		}
+		return count
+	}
+	require.Equal(t, 2, countKeys())
+	// Range deletions are still applied after the memtable is flushed.
+	require.Nil(t, db.Flush())
+	require.Equal(t, 2, countKeys())
+	require.Equal(t, uint64(0), db.Metrics().WAL.BytesWritten)
+}
+
+// TestReopenDiscardsData checks sorted data never survives a restart no
+// matter whether WAL is disabled. Redo logs and consistent mode never read
+// sorted data after a restart, so disabling WAL can't affect them.
+func TestReopenDiscardsData(t *testing.T) {
+	for _, disableWAL := range []bool{false, true} {
+		dbPath := filepath.Join(t.TempDir(), t.Name())
+		cfg := &config.DBConfig{Count: 1, DisableWAL: disableWAL}
+		db, err := OpenPebble(1, dbPath, cfg, nil)
+		require.Nil(t, err)
+		writeOpts := &pebble.WriteOptions{Sync: false}
+		for ts := uint64(1); ts <= 4; ts++ {
+			require.Nil(t, db.Set(encoding.EncodeTsKey(1, 1, ts, 0), []byte{'x'}, writeOpts))
+		}
+		// Persist data in sst files, which don't depend on WAL.
+		require.Nil(t, db.Flush())
+		require.Nil(t, db.Close())
+
+		db, err = OpenPebble(1, dbPath, cfg, nil)
+		require.Nil(t, err)
+		iter := iterTable(db, 1, 1, engine.Position{}, engine.Position{CommitTs: 5})
+		require.False(t, iter.Valid(), "disableWAL: %v", disableWAL)
+		require.Nil(t, iter.Close())
+		require.Nil(t, db.Close())
+	}
+}
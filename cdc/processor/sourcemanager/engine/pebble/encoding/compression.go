@@ -0,0 +1,197 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+)
+
+// Compression algorithms of event values.
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+	CompressionZstd   = "zstd"
+)
+
+// Formats of compressed values, which is the first byte of a value.
+const (
+	// formatRaw is used if a value can't be compressed to a smaller size.
+	formatRaw byte = iota
+	formatSnappy
+	formatZstd
+	formatZstdDict
+)
+
+// dictID is the ID of all dictionaries. It's not 0 so that it's recorded in
+// frames, and it doesn't need to be unique because a dictionary is only
+// used by a table.
+const dictID = 1
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+// sharedZstd returns the zstd encoder and decoder without dictionaries, they
+// are shared by all tables because EncodeAll and DecodeAll are concurrent-safe.
+func sharedZstd() (*zstd.Encoder, *zstd.Decoder) {
+	zstdOnce.Do(func() {
+		zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+		zstdDecoder, _ = zstd.NewReader(nil)
+	})
+	return zstdEncoder, zstdDecoder
+}
+
+// CompressionConfig is the config of value compression.
+type CompressionConfig struct {
+	// Algorithm is one of CompressionNone, CompressionSnappy and CompressionZstd.
+	Algorithm string
+	// DictSize is the size of the zstd dictionary of a table, 0 means
+	// dictionaries are not used.
+	DictSize int
+}
+
+// Enabled returns true if values are compressed.
+func (c CompressionConfig) Enabled() bool {
+	return c.Algorithm == CompressionSnappy || c.Algorithm == CompressionZstd
+}
+
+// ValueCompressor compresses and decompresses the values of a table, it's
+// concurrent-safe.
+//
+// With zstd, the first DictSize bytes of values of the table are collected as
+// a raw content dictionary, and later values are compressed with it, which
+// works well for values with similar content, e.g. JSON columns.
+type ValueCompressor struct {
+	cfg CompressionConfig
+
+	mu      sync.Mutex
+	samples []byte
+	dict    atomic.Pointer[zstdDict]
+}
+
+// zstdDict is the dictionary of a table. Its encoder and decoder don't start
+// any goroutines because they only encode and decode blocks, so they don't
+// need to be closed.
+type zstdDict struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// NewValueCompressor creates a ValueCompressor, it returns nil if values are
+// not compressed.
+func NewValueCompressor(cfg CompressionConfig) *ValueCompressor {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.Algorithm != CompressionZstd {
+		cfg.DictSize = 0
+	}
+	return &ValueCompressor{cfg: cfg}
+}
+
+// Compress compresses the value, the format is prepended to the result.
+func (c *ValueCompressor) Compress(value []byte) []byte {
+	buf := make([]byte, 1, len(value)+1)
+	dict := c.dict.Load()
+	switch {
+	case c.cfg.Algorithm == CompressionSnappy:
+		buf[0] = formatSnappy
+		buf = append(buf, snappy.Encode(nil, value)...)
+	case dict != nil:
+		buf[0] = formatZstdDict
+		buf = dict.encoder.EncodeAll(value, buf)
+	default:
+		encoder, _ := sharedZstd()
+		buf[0] = formatZstd
+		buf = encoder.EncodeAll(value, buf)
+		if c.cfg.DictSize > 0 {
+			c.sample(value)
+		}
+	}
+	if len(buf) > len(value) {
+		buf = append(buf[:1], value...)
+		buf[0] = formatRaw
+	}
+	return buf
+}
+
+// Decompress decompresses a value returned by Compress.
+func (c *ValueCompressor) Decompress(value []byte) ([]byte, error) {
+	if len(value) == 0 {
+		return nil, errors.New("empty compressed value")
+	}
+	format, data := value[0], value[1:]
+	switch format {
+	case formatRaw:
+		return data, nil
+	case formatSnappy:
+		result, err := snappy.Decode(nil, data)
+		return result, errors.Trace(err)
+	case formatZstd:
+		_, decoder := sharedZstd()
+		result, err := decoder.DecodeAll(data, nil)
+		return result, errors.Trace(err)
+	case formatZstdDict:
+		dict := c.dict.Load()
+		if dict == nil {
+			return nil, errors.New("zstd dictionary of the table is not found")
+		}
+		result, err := dict.decoder.DecodeAll(data, nil)
+		return result, errors.Trace(err)
+	default:
+		return nil, errors.Errorf("unknown value format %d", format)
+	}
+}
+
+// sample collects the value for the dictionary, and builds the dictionary
+// once enough values are collected.
+func (c *ValueCompressor) sample(value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dict.Load() != nil {
+		return
+	}
+	if n := c.cfg.DictSize - len(c.samples); n < len(value) {
+		value = value[:n]
+	}
+	c.samples = append(c.samples, value...)
+	if len(c.samples) < c.cfg.DictSize {
+		return
+	}
+
+	content := c.samples
+	c.samples = nil
+	encoder, err := zstd.NewWriter(nil,
+		zstd.WithEncoderLevel(zstd.SpeedFastest),
+		zstd.WithEncoderConcurrency(1),
+		zstd.WithEncoderDictRaw(dictID, content))
+	if err != nil {
+		// Values are still compressed without the dictionary.
+		return
+	}
+	decoder, err := zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderDictRaw(dictID, content))
+	if err != nil {
+		return
+	}
+	c.dict.Store(&zstdDict{encoder: encoder, decoder: decoder})
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueCompressor(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, NewValueCompressor(CompressionConfig{Algorithm: CompressionNone}))
+	require.Nil(t, NewValueCompressor(CompressionConfig{}))
+
+	value := bytes.Repeat([]byte(`{"name":"tidb","type":"database"}`), 16)
+	for _, algorithm := range []string{CompressionSnappy, CompressionZstd} {
+		c := NewValueCompressor(CompressionConfig{Algorithm: algorithm})
+		compressed := c.Compress(value)
+		require.Less(t, len(compressed), len(value), algorithm)
+		decompressed, err := c.Decompress(compressed)
+		require.NoError(t, err)
+		require.Equal(t, value, decompressed, algorithm)
+
+		// Values which can't be compressed are kept as is.
+		compressed = c.Compress([]byte{1})
+		require.Equal(t, []byte{formatRaw, 1}, compressed)
+		decompressed, err = c.Decompress(compressed)
+		require.NoError(t, err)
+		require.Equal(t, []byte{1}, decompressed)
+	}
+}
+
+func TestValueCompressorDict(t *testing.T) {
+	t.Parallel()
+
+	newValue := func(i int) []byte {
+		return []byte(fmt.Sprintf(
+			`{"id":%d,"status":"delivered","address":"221B Baker Street, London"}`, i))
+	}
+	c := NewValueCompressor(CompressionConfig{Algorithm: CompressionZstd, DictSize: 256})
+	var compressed [][]byte
+	for i := 0; i < 10; i++ {
+		compressed = append(compressed, c.Compress(newValue(i)))
+	}
+	require.NotNil(t, c.dict.Load())
+	require.NotEqual(t, formatZstdDict, compressed[0][0])
+	last := compressed[len(compressed)-1]
+	require.Equal(t, formatZstdDict, last[0])
+	require.Less(t, len(last), len(newValue(9))/2)
+
+	for i, value := range compressed {
+		decompressed, err := c.Decompress(value)
+		require.NoError(t, err)
+		require.Equal(t, newValue(i), decompressed)
+	}
+
+	// Values compressed with the dictionary of another table can't be read.
+	other := NewValueCompressor(CompressionConfig{Algorithm: CompressionZstd, DictSize: 256})
+	_, err := other.Decompress(last)
+	require.ErrorContains(t, err, "dictionary")
+	_, err = other.Decompress([]byte{100})
+	require.ErrorContains(t, err, "unknown value format")
+}
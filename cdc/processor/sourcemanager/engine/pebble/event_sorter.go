@@ -46,6 +46,15 @@ type EventSorter struct {
 	dbs          []*pebble.DB
 	channs       []*chann.DrainableChann[eventWithTableID]
 	serde        encoding.MsgPackGenSerde
+	quota        *quotaController
+	// ioSchedulers schedule I/O of tables in every DB, it's nil if I/O is
+	// not scheduled.
+	ioSchedulers []*IOScheduler
+	compression  encoding.CompressionConfig
+	// rawBytes and compressedBytes are the size of values before and after
+	// compressed, they're nil if values are not compressed.
+	rawBytes        prometheus.Counter
+	compressedBytes prometheus.Counter
 
 	// To manage background goroutines.
 	wg     sync.WaitGroup
@@ -62,15 +71,42 @@ type EventSorter struct {
 type EventIter struct {
 	tableID  model.TableID
 	state    *tableState
-	iter     *pebble.Iterator
+	iter     kvIterator
 	headItem *model.PolymorphicEvent
 	serde    encoding.MsgPackGenSerde
+	// compressor is nil if values are not compressed.
+	compressor *encoding.ValueCompressor
 
 	nextDuration prometheus.Observer
 }
 
 // New creates an EventSorter instance.
 func New(ID model.ChangeFeedID, dbs []*pebble.DB) *EventSorter {
+	return NewWithQuota(ID, dbs, Quota{})
+}
+
+// NewWithQuota creates an EventSorter instance whose resource usage in the
+// shared DBs is limited by the given quota.
+func NewWithQuota(ID model.ChangeFeedID, dbs []*pebble.DB, quota Quota) *EventSorter {
+	return NewWithIOSchedulers(ID, dbs, quota, nil)
+}
+
+// NewWithIOSchedulers creates an EventSorter instance whose resource usage in
+// the shared DBs is limited by the given quota, and whose I/O in every DB is
+// scheduled by the given schedulers, which are shared by all changefeeds.
+func NewWithIOSchedulers(
+	ID model.ChangeFeedID, dbs []*pebble.DB, quota Quota, ioSchedulers []*IOScheduler,
+) *EventSorter {
+	return NewWithValueCompression(ID, dbs, quota, ioSchedulers, encoding.CompressionConfig{})
+}
+
+// NewWithValueCompression creates an EventSorter instance like
+// NewWithIOSchedulers, whose event values are compressed with the given
+// config before written into the shared DBs.
+func NewWithValueCompression(
+	ID model.ChangeFeedID, dbs []*pebble.DB, quota Quota, ioSchedulers []*IOScheduler,
+	compression encoding.CompressionConfig,
+) *EventSorter {
 	channs := make([]*chann.DrainableChann[eventWithTableID], 0, len(dbs))
 	for i := 0; i < len(dbs); i++ {
 		channs = append(channs, chann.NewAutoDrainChann[eventWithTableID](chann.Cap(128)))
@@ -80,9 +116,18 @@ func New(ID model.ChangeFeedID, dbs []*pebble.DB) *EventSorter {
 		changefeedID: ID,
 		dbs:          dbs,
 		channs:       channs,
+		quota:        newQuotaController(ID, quota),
+		ioSchedulers: ioSchedulers,
+		compression:  compression,
 		closed:       make(chan struct{}),
 		tables:       spanz.NewHashMap[*tableState](),
 	}
+	if compression.Enabled() {
+		metricBytes := engine.SorterValueCompressionBytes().
+			MustCurryWith(prometheus.Labels{"namespace": ID.Namespace, "changefeed": ID.ID})
+		eventSorter.rawBytes = metricBytes.WithLabelValues("raw")
+		eventSorter.compressedBytes = metricBytes.WithLabelValues("compressed")
+	}
 
 	for i := range eventSorter.dbs {
 		fetchTokens := make(chan struct{}, 1)
@@ -123,9 +168,12 @@ func (s *EventSorter) AddTable(span tablepb.Span, startTs model.Ts) {
 			zap.Stringer("span", &span))
 		return
 	}
+	shard := getDB(span, len(s.dbs))
 	state := &tableState{
-		uniqueID: genUniqueID(),
-		ch:       s.channs[getDB(span, len(s.dbs))],
+		uniqueID:   genUniqueID(),
+		ch:         s.channs[shard],
+		shards:     []tableShard{{db: shard, maxCommitTs: math.MaxUint64}},
+		compressor: encoding.NewValueCompressor(s.compression),
 	}
 	state.maxReceivedResolvedTs.Store(startTs)
 	s.tables.ReplaceOrInsert(span, state)
@@ -143,8 +191,19 @@ func (s *EventSorter) RemoveTable(span tablepb.Span) {
 			zap.Stringer("span", &span))
 		return
 	}
+	state := s.tables.GetV(span)
 	s.tables.Delete(span)
 	s.mu.Unlock()
+
+	// Clean all events of the table, otherwise they are left in the shared
+	// DBs and are never cleaned.
+	if err := s.cleanTable(state, span); err != nil {
+		log.Warn("clean removed table fails",
+			zap.String("namespace", s.changefeedID.Namespace),
+			zap.String("changefeed", s.changefeedID.ID),
+			zap.Stringer("span", &span),
+			zap.Error(err))
+	}
 }
 
 // Add implements engine.SortEngine.
@@ -160,6 +219,17 @@ func (s *EventSorter) Add(span tablepb.Span, events ...*model.PolymorphicEvent)
 			zap.Stringer("span", &span))
 	}
 
+	// Hold chMu so that the table can't be re-sharded when adding events.
+	state.chMu.Lock()
+	defer state.chMu.Unlock()
+
+	var ioScheduler *IOScheduler
+	if s.ioSchedulers != nil {
+		state.mu.RLock()
+		ioScheduler = s.ioSchedulers[state.shards[len(state.shards)-1].db]
+		state.mu.RUnlock()
+	}
+
 	maxCommitTs := state.maxReceivedCommitTs.Load()
 	maxResolvedTs := state.maxReceivedResolvedTs.Load()
 	for _, event := range events {
@@ -168,13 +238,26 @@ func (s *EventSorter) Add(span tablepb.Span, events ...*model.PolymorphicEvent)
 				maxResolvedTs = event.CRTs
 				state.maxReceivedResolvedTs.Store(maxResolvedTs)
 			}
+			s.quota.resolve(&state.usage, event.CRTs)
 		} else {
+			size := eventSize(event)
+			if !s.quota.acquire(&state.usage, size) {
+				// The sorter is closed.
+				return
+			}
+			if ioScheduler != nil {
+				ioScheduler.acquire(state.uniqueID, "write", s.closed)
+				ioScheduler.consume(state.uniqueID, size)
+			}
 			if event.CRTs > maxCommitTs {
 				maxCommitTs = event.CRTs
 				state.maxReceivedCommitTs.Store(maxCommitTs)
 			}
+			state.receivedEvents.Add(1)
+		}
+		state.ch.In() <- eventWithTableID{
+			uniqueID: state.uniqueID, span: span, event: event, compressor: state.compressor,
 		}
-		state.ch.In() <- eventWithTableID{uniqueID: state.uniqueID, span: span, event: event}
 	}
 }
 
@@ -209,19 +292,44 @@ func (s *EventSorter) FetchByTable(span tablepb.Span, lowerBound, upperBound eng
 			zap.Uint64("resolved", sortedResolved))
 	}
 
-	db := s.dbs[getDB(span, len(s.dbs))]
+	state.mu.RLock()
+	shards := state.shards
+	state.mu.RUnlock()
 	iterReadDur := engine.SorterIterReadDuration()
 
 	seekStart := time.Now()
-	iter := iterTable(db, state.uniqueID, span.TableID, lowerBound, upperBound)
+	iterShard := func(shard tableShard) kvIterator {
+		iter := iterTable(s.dbs[shard.db], state.uniqueID, span.TableID, lowerBound, upperBound)
+		if s.ioSchedulers == nil {
+			return iter
+		}
+		return &scheduledIterator{
+			kvIterator: iter,
+			scheduler:  s.ioSchedulers[shard.db],
+			uniqueID:   state.uniqueID,
+			done:       s.closed,
+		}
+	}
+	var iter kvIterator
+	if len(shards) == 1 {
+		iter = iterShard(shards[0])
+	} else {
+		// The table is re-sharded, merge events in all shards.
+		iters := make([]kvIterator, 0, len(shards))
+		for _, shard := range shards {
+			iters = append(iters, iterShard(shard))
+		}
+		iter = newMergeIterator(iters)
+	}
 	iterReadDur.WithLabelValues(s.changefeedID.Namespace, s.changefeedID.ID, "first").
 		Observe(time.Since(seekStart).Seconds())
 
 	return &EventIter{
-		tableID: span.TableID,
-		state:   state,
-		iter:    iter,
-		serde:   s.serde,
+		tableID:    span.TableID,
+		state:      state,
+		iter:       iter,
+		serde:      s.serde,
+		compressor: state.compressor,
 
 		nextDuration: iterReadDur.WithLabelValues(s.changefeedID.Namespace, s.changefeedID.ID, "next"),
 	}
@@ -235,6 +343,64 @@ func (s *EventSorter) FetchAllTables(lowerBound engine.Position) engine.EventIte
 	return nil
 }
 
+// ReshardTable moves the table to the given DB shard. Events which are
+// already written are kept in the previous shards, and are merged with
+// events in the new shard when fetching, so no data needs to be rewritten.
+func (s *EventSorter) ReshardTable(span tablepb.Span, shard int) {
+	s.mu.RLock()
+	state, exists := s.tables.Get(span)
+	s.mu.RUnlock()
+	if !exists {
+		log.Panic("reshard an non-existent table",
+			zap.String("namespace", s.changefeedID.Namespace),
+			zap.String("changefeed", s.changefeedID.ID),
+			zap.Stringer("span", &span))
+	}
+	if shard < 0 || shard >= len(s.dbs) {
+		log.Panic("reshard a table to an invalid shard",
+			zap.String("namespace", s.changefeedID.Namespace),
+			zap.String("changefeed", s.changefeedID.ID),
+			zap.Stringer("span", &span),
+			zap.Int("shard", shard))
+	}
+
+	state.chMu.Lock()
+	defer state.chMu.Unlock()
+	state.mu.RLock()
+	current := state.shards[len(state.shards)-1].db
+	state.mu.RUnlock()
+	if current == shard {
+		return
+	}
+
+	// Wait until all events in the current shard are written, otherwise
+	// the resolved ts emitted by the new shard can cover events which are
+	// not written into the previous shard.
+	flushed := make(chan struct{})
+	state.ch.In() <- eventWithTableID{uniqueID: state.uniqueID, span: span, flushed: flushed}
+	select {
+	case <-flushed:
+	case <-s.closed:
+		return
+	}
+
+	state.mu.Lock()
+	// shards is copy-on-write because it's read without lock after fetching.
+	shards := make([]tableShard, 0, len(state.shards)+1)
+	shards = append(shards, state.shards...)
+	shards[len(shards)-1].maxCommitTs = state.maxReceivedCommitTs.Load()
+	state.shards = append(shards, tableShard{db: shard, maxCommitTs: math.MaxUint64})
+	state.mu.Unlock()
+	state.ch = s.channs[shard]
+
+	log.Info("table is re-sharded",
+		zap.String("namespace", s.changefeedID.Namespace),
+		zap.String("changefeed", s.changefeedID.ID),
+		zap.Stringer("span", &span),
+		zap.Int("from", current),
+		zap.Int("to", shard))
+}
+
 // CleanByTable implements engine.SortEngine.
 func (s *EventSorter) CleanByTable(span tablepb.Span, upperBound engine.Position) error {
 	s.mu.RLock()
@@ -282,6 +448,7 @@ func (s *EventSorter) GetStatsByTable(span tablepb.Span) engine.TableStats {
 	return engine.TableStats{
 		ReceivedMaxCommitTs:   maxCommitTs,
 		ReceivedMaxResolvedTs: maxResolvedTs,
+		ReceivedEvents:        state.receivedEvents.Load(),
 	}
 }
 
@@ -295,11 +462,18 @@ func (s *EventSorter) Close() error {
 	s.isClosed = true
 	s.mu.Unlock()
 
+	// Unblock all callers which are waiting for the quota.
+	s.quota.close()
 	close(s.closed)
 	s.wg.Wait()
 	for _, ch := range s.channs {
 		ch.CloseAndDrain()
 	}
+	if s.compression.Enabled() {
+		engine.SorterValueCompressionBytes().DeletePartialMatch(prometheus.Labels{
+			"namespace": s.changefeedID.Namespace, "changefeed": s.changefeedID.ID,
+		})
+	}
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -326,6 +500,11 @@ func (s *EventIter) Next() (event *model.PolymorphicEvent, pos engine.Position,
 		value, valid = s.iter.Value(), s.iter.Next()
 		s.nextDuration.Observe(time.Since(nextStart).Seconds())
 
+		if s.compressor != nil {
+			if value, err = s.compressor.Decompress(value); err != nil {
+				return
+			}
+		}
 		event = &model.PolymorphicEvent{}
 		if _, err = s.serde.Unmarshal(event, value); err != nil {
 			return
@@ -357,19 +536,42 @@ type eventWithTableID struct {
 	uniqueID uint32
 	span     tablepb.Span
 	event    *model.PolymorphicEvent
+	// flushed is closed after all previous events in the channel are
+	// written. event is nil if it's set.
+	flushed chan struct{}
+	// compressor is nil if values are not compressed.
+	compressor *encoding.ValueCompressor
 }
 
 type tableState struct {
-	uniqueID       uint32
+	uniqueID uint32
+	// chMu protects ch.
+	chMu           sync.Mutex
 	ch             *chann.DrainableChann[eventWithTableID]
 	sortedResolved atomic.Uint64 // indicates events are ready for fetching.
 	// For statistics.
 	maxReceivedCommitTs   atomic.Uint64
 	maxReceivedResolvedTs atomic.Uint64
+	receivedEvents        atomic.Uint64
+	// usage is protected by EventSorter.quota.
+	usage tableUsage
+	// compressor compresses values of the table, it's nil if values are not
+	// compressed.
+	compressor *encoding.ValueCompressor
 
 	// Following fields are protected by mu.
 	mu      sync.RWMutex
 	cleaned engine.Position
+	// shards are the DBs which contain events of the table, the last one is
+	// the DB which new events are written into.
+	shards []tableShard
+}
+
+type tableShard struct {
+	db int
+	// maxCommitTs is the max commit ts of events in the shard. It's
+	// math.MaxUint64 for the shard which new events are written into.
+	maxCommitTs model.Ts
 }
 
 func (s *EventSorter) handleEvents(
@@ -383,8 +585,13 @@ func (s *EventSorter) handleEvents(
 	batch := db.NewBatch()
 	writeOpts := &pebble.WriteOptions{Sync: false}
 	newResolved := spanz.NewHashMap[model.Ts]()
+	var flushed []chan struct{}
 
 	handleItem := func(item eventWithTableID) {
+		if item.flushed != nil {
+			flushed = append(flushed, item.flushed)
+			return
+		}
 		if item.event.IsResolved() {
 			newResolved.ReplaceOrInsert(item.span, item.event.CRTs)
 			return
@@ -396,6 +603,11 @@ func (s *EventSorter) handleEvents(
 				zap.String("namespace", s.changefeedID.Namespace),
 				zap.String("changefeed", s.changefeedID.ID))
 		}
+		if item.compressor != nil {
+			s.rawBytes.Add(float64(len(value)))
+			value = item.compressor.Compress(value)
+			s.compressedBytes.Add(float64(len(value)))
+		}
 		if err = batch.Set(key, value, writeOpts); err != nil {
 			log.Panic("failed to update pebble batch", zap.Error(err),
 				zap.String("namespace", s.changefeedID.Namespace),
@@ -490,6 +702,10 @@ func (s *EventSorter) handleEvents(
 			return true
 		})
 		newResolved = spanz.NewHashMap[model.Ts]()
+		for _, ch := range flushed {
+			close(ch)
+		}
+		flushed = flushed[:0]
 		ioTokens <- struct{}{}
 	}
 }
@@ -507,8 +723,8 @@ func (s *EventSorter) cleanTable(
 		toClean = engine.Position{CommitTs: math.MaxUint64, StartTs: math.MaxUint64 - 1}
 	}
 
-	state.mu.RLock()
-	defer state.mu.RUnlock()
+	state.mu.Lock()
+	defer state.mu.Unlock()
 
 	if state.cleaned.Compare(toClean) >= 0 {
 		return nil
@@ -519,13 +735,26 @@ func (s *EventSorter) cleanTable(
 	end = encoding.EncodeTsKey(
 		state.uniqueID, uint64(span.TableID), toCleanNext.CommitTs, toCleanNext.StartTs)
 
-	db := s.dbs[getDB(span, len(s.dbs))]
-	err := db.DeleteRange(start, end, &pebble.WriteOptions{Sync: false})
-	if err != nil {
-		return err
+	for _, shard := range state.shards {
+		db := s.dbs[shard.db]
+		err := db.DeleteRange(start, end, &pebble.WriteOptions{Sync: false})
+		if err != nil {
+			return err
+		}
+	}
+	// Previous shards which are cleaned up can be skipped in the future.
+	if len(state.shards) > 1 {
+		shards := make([]tableShard, 0, len(state.shards))
+		for i, shard := range state.shards {
+			if i == len(state.shards)-1 || shard.maxCommitTs >= toClean.CommitTs {
+				shards = append(shards, shard)
+			}
+		}
+		state.shards = shards
 	}
 
 	state.cleaned = toClean
+	s.quota.release(&state.usage, toClean.CommitTs)
 	return nil
 }
 
@@ -541,6 +770,14 @@ func genUniqueID() uint32 {
 	return atomic.AddUint32(&uniqueIDGen, 1)
 }
 
+// eventSize returns the approximate size of an event in DBs.
+func eventSize(event *model.PolymorphicEvent) uint64 {
+	if event.RawKV == nil {
+		return 0
+	}
+	return uint64(event.RawKV.ApproximateDataSize())
+}
+
 // TODO: add test for this function.
 func getDB(span tablepb.Span, dbCount int) int {
 	h := fnv.New64()
@@ -14,17 +14,22 @@
 package pebble
 
 import (
+	"math"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/pebble/encoding"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -129,6 +134,7 @@ func TestEventFetch(t *testing.T) {
 	s.Add(span, inputEvents...)
 	s.Add(span, model.NewResolvedPolymorphicEvent(0, 4))
 	require.Equal(t, model.Ts(4), s.GetStatsByTable(span).ReceivedMaxResolvedTs)
+	require.Equal(t, uint64(len(inputEvents)), s.GetStatsByTable(span).ReceivedEvents)
 
 	sortedEvents := make([]*model.PolymorphicEvent, 0, len(inputEvents))
 	sortedPositions := make([]engine.Position, 0, len(inputEvents))
@@ -165,6 +171,58 @@ func TestEventFetch(t *testing.T) {
 	require.Equal(t, expectPositions, sortedPositions)
 }
 
+func TestEventFetchWithValueCompression(t *testing.T) {
+	for _, algorithm := range []string{encoding.CompressionSnappy, encoding.CompressionZstd} {
+		dbPath := filepath.Join(t.TempDir(), t.Name(), algorithm)
+		db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, nil)
+		require.Nil(t, err)
+
+		cf := model.ChangeFeedID{Namespace: "default", ID: "test"}
+		s := NewWithValueCompression(cf, []*pebble.DB{db}, Quota{}, nil,
+			encoding.CompressionConfig{Algorithm: algorithm, DictSize: 512})
+		span := spanz.TableIDToComparableSpan(1)
+		s.AddTable(span, 1)
+		resolvedTs := make(chan model.Ts, 1)
+		s.OnResolve(func(_ tablepb.Span, ts model.Ts) { resolvedTs <- ts })
+
+		inputEvents := make([]*model.PolymorphicEvent, 0, 64)
+		for i := 0; i < 64; i++ {
+			inputEvents = append(inputEvents, model.NewPolymorphicEvent(&model.RawKVEntry{
+				OpType:  model.OpTypePut,
+				Key:     []byte{byte(i)},
+				Value:   []byte(strings.Repeat(`{"k":"v"}`, i+1)),
+				StartTs: uint64(i + 1),
+				CRTs:    uint64(i + 2),
+			}))
+		}
+		s.Add(span, inputEvents...)
+		s.Add(span, model.NewResolvedPolymorphicEvent(0, 100))
+
+		var sortedEvents []*model.PolymorphicEvent
+		ts := <-resolvedTs
+		iter := s.FetchByTable(span, engine.Position{}, engine.Position{CommitTs: ts, StartTs: ts - 1})
+		for {
+			event, _, err := iter.Next()
+			require.Nil(t, err)
+			if event == nil {
+				break
+			}
+			sortedEvents = append(sortedEvents, event)
+		}
+		require.Nil(t, iter.Close())
+		require.Equal(t, inputEvents, sortedEvents, algorithm)
+
+		metricBytes := engine.SorterValueCompressionBytes().
+			MustCurryWith(prometheus.Labels{"namespace": "default", "changefeed": "test"})
+		raw := testutil.ToFloat64(metricBytes.WithLabelValues("raw"))
+		compressed := testutil.ToFloat64(metricBytes.WithLabelValues("compressed"))
+		require.Less(t, compressed, raw, algorithm)
+
+		require.Nil(t, s.Close())
+		require.Nil(t, db.Close())
+	}
+}
+
 func TestCleanData(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), t.Name())
 	db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, nil)
@@ -184,3 +242,80 @@ func TestCleanData(t *testing.T) {
 	})
 	require.Nil(t, s.CleanByTable(span, engine.Position{}))
 }
+
+func TestReshardTable(t *testing.T) {
+	dbs := make([]*pebble.DB, 0, 2)
+	for id := 0; id < 2; id++ {
+		dbPath := filepath.Join(t.TempDir(), t.Name())
+		db, err := OpenPebble(id, dbPath, &config.DBConfig{Count: 2}, nil)
+		require.Nil(t, err)
+		defer func() { _ = db.Close() }()
+		dbs = append(dbs, db)
+	}
+
+	cf := model.ChangeFeedID{Namespace: "default", ID: "test"}
+	s := New(cf, dbs)
+	defer s.Close()
+
+	span := spanz.TableIDToComparableSpan(1)
+	s.AddTable(span, 1)
+	resolvedTs := make(chan model.Ts, 8)
+	s.OnResolve(func(_ tablepb.Span, ts model.Ts) { resolvedTs <- ts })
+	newEvent := func(startTs, commitTs model.Ts) *model.PolymorphicEvent {
+		return model.NewPolymorphicEvent(&model.RawKVEntry{
+			OpType:  model.OpTypePut,
+			Key:     []byte{1},
+			StartTs: startTs,
+			CRTs:    commitTs,
+		})
+	}
+
+	oldShard := getDB(span, len(dbs))
+	s.Add(span, newEvent(1, 2), newEvent(3, 5))
+	s.ReshardTable(span, 1-oldShard)
+	// Events with the same commit ts can be written into both shards.
+	s.Add(span, newEvent(2, 5), newEvent(4, 6), model.NewResolvedPolymorphicEvent(0, 6))
+	state := s.tables.GetV(span)
+	require.Len(t, state.shards, 2)
+
+	timer := time.NewTimer(5 * time.Second)
+	select {
+	case ts := <-resolvedTs:
+		require.Equal(t, model.Ts(6), ts)
+	case <-timer.C:
+		panic("must get a resolved timestamp instead of timeout")
+	}
+
+	fetch := func() (positions []engine.Position) {
+		iter := s.FetchByTable(span, engine.Position{}, engine.Position{CommitTs: 6, StartTs: 5})
+		defer iter.Close()
+		for {
+			event, pos, err := iter.Next()
+			require.Nil(t, err)
+			if event == nil {
+				return
+			}
+			if pos.Valid() {
+				positions = append(positions, pos)
+			}
+		}
+	}
+	require.Equal(t, []engine.Position{
+		{StartTs: 1, CommitTs: 2},
+		{StartTs: 2, CommitTs: 5},
+		{StartTs: 3, CommitTs: 5},
+		{StartTs: 4, CommitTs: 6},
+	}, fetch())
+
+	// The previous shard is skipped once all events in it are cleaned.
+	require.Nil(t, s.CleanByTable(span, engine.Position{StartTs: 3, CommitTs: 5}))
+	require.Len(t, state.shards, 2)
+	require.Equal(t, []engine.Position{{StartTs: 4, CommitTs: 6}}, fetch())
+	require.Nil(t, s.CleanByTable(span, engine.Position{StartTs: 4, CommitTs: 6}))
+	require.Equal(t, []tableShard{{db: 1 - oldShard, maxCommitTs: math.MaxUint64}}, state.shards)
+	require.Empty(t, fetch())
+
+	// Re-shard to the current shard is a no-op.
+	s.ReshardTable(span, 1-oldShard)
+	require.Len(t, state.shards, 1)
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ioSchedulerTick is the interval that I/O budgets are refilled.
+const ioSchedulerTick = 100 * time.Millisecond
+
+// IOSchedulerConfig is the config of IOScheduler.
+type IOSchedulerConfig struct {
+	// BudgetPerTick is the bytes that can be read from or written into a DB
+	// shard in a tick of 100ms, it's shared by all tables in the shard.
+	// 0 disables the scheduler.
+	BudgetPerTick uint64
+	// Deadline is the max duration that an I/O waits for budget. An I/O
+	// which reaches the deadline is issued even if the budget is exhausted,
+	// so a table is never starved.
+	Deadline time.Duration
+}
+
+// IOScheduler shares the I/O budget of a DB shard among tables, which can
+// belong to different changefeeds. A table can use up the whole budget of a
+// tick if no other tables are waiting, otherwise it can use only its fair
+// share of the budget, so that a table scanning or writing a huge amount of
+// events can't starve other tables in the same shard.
+type IOScheduler struct {
+	cfg IOSchedulerConfig
+
+	mu        sync.Mutex
+	tickStart time.Time
+	// used is the bytes used in the current tick.
+	used uint64
+	// tables are the I/O states of tables which do I/O in the current tick
+	// or are waiting for budget, indexed by their unique IDs.
+	tables map[uint32]*tableIO
+	// waiting is the number of tables which are waiting for budget.
+	waiting int
+	// refilled is closed when the budget is refilled.
+	refilled chan struct{}
+
+	metricWaitDuration prometheus.ObserverVec
+}
+
+type tableIO struct {
+	used    uint64
+	waiters int
+}
+
+// NewIOSchedulers creates an IOScheduler for each one of count DB shards.
+// It returns nil if the scheduler is disabled.
+func NewIOSchedulers(count int, cfg IOSchedulerConfig) []*IOScheduler {
+	if cfg.BudgetPerTick == 0 {
+		return nil
+	}
+	schedulers := make([]*IOScheduler, 0, count)
+	for id := 0; id < count; id++ {
+		schedulers = append(schedulers, newIOScheduler(id, cfg))
+	}
+	return schedulers
+}
+
+func newIOScheduler(id int, cfg IOSchedulerConfig) *IOScheduler {
+	return &IOScheduler{
+		cfg:       cfg,
+		tickStart: time.Now(),
+		tables:    make(map[uint32]*tableIO),
+		refilled:  make(chan struct{}),
+
+		metricWaitDuration: engine.SorterIOWaitDuration().
+			MustCurryWith(prometheus.Labels{"id": strconv.Itoa(id + 1)}),
+	}
+}
+
+// acquire waits until the table can do an I/O. The I/O is issued without
+// waiting after the deadline, or if done is closed.
+func (s *IOScheduler) acquire(uniqueID uint32, ioType string, done <-chan struct{}) {
+	start := time.Now()
+	deadline := start.Add(s.cfg.Deadline)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		now := time.Now()
+		s.refill(now)
+		table := s.getTable(uniqueID)
+		if s.used < s.cfg.BudgetPerTick &&
+			(table.used < s.fairShare() || !s.othersWaiting(table)) {
+			break
+		}
+		if !now.Before(deadline) {
+			break
+		}
+
+		wait := s.tickStart.Add(ioSchedulerTick)
+		if deadline.Before(wait) {
+			wait = deadline
+		}
+		refilled := s.refilled
+		// Tables with waiters are kept when refilling.
+		table.waiters++
+		if table.waiters == 1 {
+			s.waiting++
+		}
+		s.mu.Unlock()
+
+		timer := time.NewTimer(wait.Sub(now))
+		closed := false
+		select {
+		case <-timer.C:
+		case <-refilled:
+		case <-done:
+			closed = true
+		}
+		timer.Stop()
+
+		s.mu.Lock()
+		table.waiters--
+		if table.waiters == 0 {
+			s.waiting--
+		}
+		if closed {
+			return
+		}
+	}
+	s.metricWaitDuration.WithLabelValues(ioType).Observe(time.Since(start).Seconds())
+}
+
+// consume charges the table for bytes of an I/O.
+func (s *IOScheduler) consume(uniqueID uint32, bytes uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.getTable(uniqueID).used += bytes
+	s.used += bytes
+}
+
+// othersWaiting returns true if tables other than the given one are waiting
+// for budget, s.mu must be held.
+func (s *IOScheduler) othersWaiting(table *tableIO) bool {
+	if table.waiters > 0 {
+		return s.waiting > 1
+	}
+	return s.waiting > 0
+}
+
+// fairShare returns the budget that every active table can use in a tick,
+// s.mu must be held.
+func (s *IOScheduler) fairShare() uint64 {
+	return s.cfg.BudgetPerTick / uint64(len(s.tables))
+}
+
+// getTable returns the I/O state of the table, s.mu must be held.
+func (s *IOScheduler) getTable(uniqueID uint32) *tableIO {
+	table, ok := s.tables[uniqueID]
+	if !ok {
+		table = &tableIO{}
+		s.tables[uniqueID] = table
+	}
+	return table
+}
+
+// refill resets the budget if a tick has passed, s.mu must be held.
+func (s *IOScheduler) refill(now time.Time) {
+	if now.Sub(s.tickStart) < ioSchedulerTick {
+		return
+	}
+	s.tickStart = now
+	s.used = 0
+	for uniqueID, table := range s.tables {
+		if table.waiters == 0 {
+			delete(s.tables, uniqueID)
+		} else {
+			table.used = 0
+		}
+	}
+	close(s.refilled)
+	s.refilled = make(chan struct{})
+}
+
+// scheduledIterator is a kvIterator whose reads are scheduled by an
+// IOScheduler.
+type scheduledIterator struct {
+	kvIterator
+	scheduler *IOScheduler
+	uniqueID  uint32
+	done      <-chan struct{}
+}
+
+func (it *scheduledIterator) Next() bool {
+	it.scheduler.acquire(it.uniqueID, "read", it.done)
+	bytes := len(it.kvIterator.Key()) + len(it.kvIterator.Value())
+	valid := it.kvIterator.Next()
+	it.scheduler.consume(it.uniqueID, uint64(bytes))
+	return valid
+}
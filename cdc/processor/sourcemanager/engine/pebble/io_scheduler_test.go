@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIOSchedulerFairShare(t *testing.T) {
+	t.Parallel()
+
+	s := newIOScheduler(0, IOSchedulerConfig{BudgetPerTick: 100, Deadline: time.Hour})
+	acquired := func(uniqueID uint32) <-chan struct{} {
+		ch := make(chan struct{})
+		go func() {
+			s.acquire(uniqueID, "read", nil)
+			close(ch)
+		}()
+		return ch
+	}
+
+	// A table can use more than its fair share if no other tables wait.
+	s.acquire(1, "read", nil)
+	s.consume(1, 80)
+	<-acquired(1)
+
+	// Table 1 is blocked until the budget is refilled once table 2 waits.
+	s.mu.Lock()
+	s.getTable(2).waiters++
+	s.waiting++
+	s.mu.Unlock()
+	ch := acquired(1)
+	select {
+	case <-ch:
+		require.FailNow(t, "acquire should be blocked")
+	case <-time.After(20 * time.Millisecond):
+	}
+	// Table 2 is under its fair share.
+	<-acquired(2)
+	<-ch
+}
+
+func TestIOSchedulerDeadline(t *testing.T) {
+	t.Parallel()
+
+	s := newIOScheduler(0, IOSchedulerConfig{BudgetPerTick: 100, Deadline: 20 * time.Millisecond})
+	s.consume(1, 100)
+	start := time.Now()
+	s.acquire(2, "write", nil)
+	require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// acquire returns once done is closed.
+	s = newIOScheduler(0, IOSchedulerConfig{BudgetPerTick: 100, Deadline: time.Hour})
+	s.consume(1, 100)
+	done := make(chan struct{})
+	close(done)
+	s.acquire(2, "write", done)
+	s.mu.Lock()
+	require.Equal(t, 0, s.waiting)
+	s.mu.Unlock()
+}
+
+func TestEventSorterWithIOSchedulers(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), t.Name())
+	db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, nil)
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	cf := model.ChangeFeedID{Namespace: "default", ID: "test"}
+	schedulers := NewIOSchedulers(1, IOSchedulerConfig{BudgetPerTick: 1024, Deadline: time.Second})
+	require.Len(t, schedulers, 1)
+	require.Nil(t, NewIOSchedulers(1, IOSchedulerConfig{}))
+	s := NewWithIOSchedulers(cf, []*pebble.DB{db}, Quota{}, schedulers)
+	defer s.Close()
+
+	span := spanz.TableIDToComparableSpan(1)
+	s.AddTable(span, 1)
+	resolvedTs := make(chan model.Ts)
+	s.OnResolve(func(_ tablepb.Span, ts model.Ts) { resolvedTs <- ts })
+
+	for i := 0; i < 10; i++ {
+		s.Add(span, model.NewPolymorphicEvent(&model.RawKVEntry{
+			OpType:  model.OpTypePut,
+			Key:     []byte{byte(i)},
+			Value:   make([]byte, 100),
+			StartTs: uint64(i + 1),
+			CRTs:    uint64(i + 2),
+		}))
+	}
+	s.Add(span, model.NewResolvedPolymorphicEvent(0, 12))
+	ts := <-resolvedTs
+
+	iter := s.FetchByTable(span, engine.Position{}, engine.Position{CommitTs: ts, StartTs: ts - 1})
+	count := 0
+	for {
+		event, _, err := iter.Next()
+		require.Nil(t, err)
+		if event == nil {
+			break
+		}
+		count++
+	}
+	require.Nil(t, iter.Close())
+	require.Equal(t, 10, count)
+
+	schedulers[0].mu.Lock()
+	require.Greater(t, schedulers[0].used, uint64(0))
+	schedulers[0].mu.Unlock()
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"bytes"
+	"container/heap"
+
+	"github.com/cockroachdb/pebble"
+	"go.uber.org/multierr"
+)
+
+// kvIterator is the subset of pebble.Iterator used by EventIter.
+type kvIterator interface {
+	Valid() bool
+	Key() []byte
+	Value() []byte
+	Next() bool
+	Close() error
+}
+
+var (
+	_ kvIterator = (*pebble.Iterator)(nil)
+	_ kvIterator = (*mergeIterator)(nil)
+)
+
+// mergeIterator merges iterators of a table in different shards. Keys of
+// a table are encoded in the order of CRTs and startTs, so the merged
+// iterator returns events in the same order as a single shard does.
+type mergeIterator struct {
+	all  []kvIterator
+	heap iterHeap
+}
+
+func newMergeIterator(iters []kvIterator) *mergeIterator {
+	m := &mergeIterator{all: iters, heap: make(iterHeap, 0, len(iters))}
+	for _, iter := range iters {
+		if iter.Valid() {
+			m.heap = append(m.heap, iter)
+		}
+	}
+	heap.Init(&m.heap)
+	return m
+}
+
+func (m *mergeIterator) Valid() bool {
+	return len(m.heap) > 0
+}
+
+func (m *mergeIterator) Key() []byte {
+	return m.heap[0].Key()
+}
+
+func (m *mergeIterator) Value() []byte {
+	return m.heap[0].Value()
+}
+
+func (m *mergeIterator) Next() bool {
+	if len(m.heap) == 0 {
+		return false
+	}
+	if m.heap[0].Next() {
+		heap.Fix(&m.heap, 0)
+	} else {
+		heap.Pop(&m.heap)
+	}
+	return len(m.heap) > 0
+}
+
+func (m *mergeIterator) Close() (err error) {
+	for _, iter := range m.all {
+		err = multierr.Append(err, iter.Close())
+	}
+	return
+}
+
+// iterHeap is a min-heap of iterators ordered by their current keys.
+type iterHeap []kvIterator
+
+func (h iterHeap) Len() int { return len(h) }
+
+func (h iterHeap) Less(i, j int) bool { return bytes.Compare(h[i].Key(), h[j].Key()) < 0 }
+
+func (h iterHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *iterHeap) Push(x interface{}) { *h = append(*h, x.(kvIterator)) }
+
+func (h *iterHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sliceIterator struct {
+	keys   []string
+	closed bool
+}
+
+func (s *sliceIterator) Valid() bool   { return len(s.keys) > 0 }
+func (s *sliceIterator) Key() []byte   { return []byte(s.keys[0]) }
+func (s *sliceIterator) Value() []byte { return []byte(s.keys[0]) }
+func (s *sliceIterator) Next() bool    { s.keys = s.keys[1:]; return s.Valid() }
+func (s *sliceIterator) Close() error  { s.closed = true; return nil }
+
+func TestMergeIterator(t *testing.T) {
+	t.Parallel()
+
+	iters := []*sliceIterator{
+		{keys: []string{"a", "d", "e"}},
+		{},
+		{keys: []string{"b", "c", "f", "g"}},
+	}
+	m := newMergeIterator([]kvIterator{iters[0], iters[1], iters[2]})
+
+	var values []string
+	for valid := m.Valid(); valid; valid = m.Next() {
+		require.Equal(t, m.Key(), m.Value())
+		values = append(values, string(m.Value()))
+	}
+	require.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g"}, values)
+	require.False(t, m.Next())
+
+	require.Nil(t, m.Close())
+	for _, iter := range iters {
+		require.True(t, iter.closed)
+	}
+}
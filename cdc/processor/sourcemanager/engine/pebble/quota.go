@@ -0,0 +1,187 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// Quota limits the resources used by a changefeed in the pebble DBs, which
+// are shared by all changefeeds on a capture.
+type Quota struct {
+	// DiskBytes limits the size of resolved events which are not cleaned yet.
+	// Once it's exceeded, adding events into tables which still have resolved
+	// events to be consumed is blocked. 0 means no limit.
+	DiskBytes uint64
+	// WriteBytesPerSecond limits the write rate. 0 means no limit.
+	WriteBytesPerSecond uint64
+}
+
+// quotaController tracks the disk usage and limits the write rate of
+// a changefeed.
+type quotaController struct {
+	changefeedID model.ChangeFeedID
+	quota        Quota
+	limiter      *rate.Limiter
+	ctx          context.Context
+	cancel       context.CancelFunc
+
+	// Following fields are protected by mu.
+	mu     sync.Mutex
+	cond   *sync.Cond
+	closed bool
+	// usage is the size of resolved events which are not cleaned.
+	usage uint64
+
+	metricUsage     prometheus.Gauge
+	metricCleaned   prometheus.Counter
+	metricThrottled *prometheus.CounterVec
+}
+
+// tableUsage is the disk usage of a table, it's protected by
+// quotaController.mu.
+type tableUsage struct {
+	// pending is the size of events which are not resolved.
+	pending uint64
+	// segments are the sizes of resolved events, in the order of resolved ts.
+	segments []usageSegment
+}
+
+type usageSegment struct {
+	resolvedTs model.Ts
+	size       uint64
+}
+
+func newQuotaController(changefeedID model.ChangeFeedID, quota Quota) *quotaController {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &quotaController{
+		changefeedID: changefeedID,
+		quota:        quota,
+		ctx:          ctx,
+		cancel:       cancel,
+
+		metricUsage: engine.SorterChangefeedDiskUsage().
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+		metricCleaned: engine.SorterCleanedBytes().WithLabelValues(changefeedID.Namespace),
+		metricThrottled: engine.SorterThrottledDuration().
+			MustCurryWith(prometheus.Labels{
+				"namespace":  changefeedID.Namespace,
+				"changefeed": changefeedID.ID,
+			}),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	if quota.WriteBytesPerSecond > 0 {
+		burst := quota.WriteBytesPerSecond
+		if burst > math.MaxInt32 {
+			burst = math.MaxInt32
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(quota.WriteBytesPerSecond), int(burst))
+	}
+	return c
+}
+
+// acquire waits until an event of the given size can be written into the
+// table. It returns false if the controller is closed.
+func (c *quotaController) acquire(usage *tableUsage, size uint64) bool {
+	if c.limiter != nil {
+		start := time.Now()
+		n := size
+		if n > uint64(c.limiter.Burst()) {
+			n = uint64(c.limiter.Burst())
+		}
+		if err := c.limiter.WaitN(c.ctx, int(n)); err != nil {
+			return false
+		}
+		c.metricThrottled.WithLabelValues("write-rate").Add(time.Since(start).Seconds())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.quota.DiskBytes > 0 {
+		start := time.Now()
+		// Tables without resolved events to be consumed are never blocked,
+		// otherwise the slowest table can block the resolved ts of the whole
+		// changefeed, and then no events can be consumed and cleaned.
+		for !c.closed && c.usage >= c.quota.DiskBytes && len(usage.segments) > 0 {
+			c.cond.Wait()
+		}
+		c.metricThrottled.WithLabelValues("disk").Add(time.Since(start).Seconds())
+	}
+	if c.closed {
+		return false
+	}
+	usage.pending += size
+	return true
+}
+
+// resolve marks all pending events of the table as resolved.
+func (c *quotaController) resolve(usage *tableUsage, resolvedTs model.Ts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if usage.pending == 0 {
+		return
+	}
+	usage.segments = append(usage.segments, usageSegment{resolvedTs: resolvedTs, size: usage.pending})
+	c.usage += usage.pending
+	usage.pending = 0
+	c.metricUsage.Set(float64(c.usage))
+}
+
+// release releases the usage of events whose commit ts are less than or
+// equal to cleanedTs. All the usage is released if cleanedTs is math.MaxUint64.
+func (c *quotaController) release(usage *tableUsage, cleanedTs model.Ts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var released, cleaned uint64
+	i := 0
+	for ; i < len(usage.segments) && usage.segments[i].resolvedTs <= cleanedTs; i++ {
+		released += usage.segments[i].size
+	}
+	usage.segments = usage.segments[i:]
+	cleaned = released
+	if cleanedTs == math.MaxUint64 {
+		cleaned += usage.pending
+		usage.pending = 0
+	}
+	if cleaned == 0 {
+		return
+	}
+	c.usage -= released
+	c.metricUsage.Set(float64(c.usage))
+	c.metricCleaned.Add(float64(cleaned))
+	c.cond.Broadcast()
+}
+
+func (c *quotaController) close() {
+	c.cancel()
+	c.mu.Lock()
+	c.closed = true
+	c.cond.Broadcast()
+	c.mu.Unlock()
+
+	labels := prometheus.Labels{
+		"namespace":  c.changefeedID.Namespace,
+		"changefeed": c.changefeedID.ID,
+	}
+	engine.SorterChangefeedDiskUsage().Delete(labels)
+	engine.SorterThrottledDuration().DeletePartialMatch(labels)
+}
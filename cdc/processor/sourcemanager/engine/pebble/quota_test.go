@@ -0,0 +1,128 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaControllerDiskQuota(t *testing.T) {
+	t.Parallel()
+
+	c := newQuotaController(model.DefaultChangeFeedID(t.Name()), Quota{DiskBytes: 100})
+	defer c.close()
+
+	var t1, t2 tableUsage
+	require.True(t, c.acquire(&t1, 60))
+	c.resolve(&t1, 10)
+	require.True(t, c.acquire(&t1, 60))
+	c.resolve(&t1, 20)
+	require.Equal(t, uint64(120), c.usage)
+
+	// Tables without resolved events are not blocked.
+	require.True(t, c.acquire(&t2, 10))
+
+	// t1 is blocked until its events are cleaned.
+	acquired := make(chan bool)
+	go func() { acquired <- c.acquire(&t1, 10) }()
+	select {
+	case <-acquired:
+		require.FailNow(t, "acquire should be blocked")
+	case <-time.After(100 * time.Millisecond):
+	}
+	c.release(&t1, 10)
+	require.True(t, <-acquired)
+	require.Equal(t, uint64(60), c.usage)
+	require.Equal(t, uint64(10), t1.pending)
+
+	// All the usage is released if the table is cleaned.
+	c.release(&t1, math.MaxUint64)
+	require.Equal(t, uint64(0), c.usage)
+	require.Equal(t, tableUsage{segments: []usageSegment{}}, t1)
+
+	// Blocked callers return false after the controller is closed.
+	c.resolve(&t2, 30)
+	require.True(t, c.acquire(&t1, 200))
+	c.resolve(&t1, 40)
+	go func() { acquired <- c.acquire(&t1, 10) }()
+	c.close()
+	require.False(t, <-acquired)
+}
+
+func TestQuotaControllerWriteRate(t *testing.T) {
+	t.Parallel()
+
+	c := newQuotaController(model.DefaultChangeFeedID(t.Name()), Quota{WriteBytesPerSecond: 100})
+	defer c.close()
+
+	var usage tableUsage
+	start := time.Now()
+	// The burst is consumed by the first event, the second one must wait.
+	require.True(t, c.acquire(&usage, 100))
+	require.True(t, c.acquire(&usage, 20))
+	require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	// Events larger than the burst are limited by the burst.
+	require.True(t, c.acquire(&usage, 1000))
+	require.Equal(t, uint64(1120), usage.pending)
+}
+
+func TestEventSorterQuotaAccounting(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), t.Name())
+	db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, nil)
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	cf := model.ChangeFeedID{Namespace: "default", ID: "test"}
+	s := NewWithQuota(cf, []*pebble.DB{db}, Quota{DiskBytes: 1 << 20})
+	defer s.Close()
+
+	span := spanz.TableIDToComparableSpan(1)
+	s.AddTable(span, 1)
+	event := model.NewPolymorphicEvent(&model.RawKVEntry{
+		OpType:  model.OpTypePut,
+		Key:     []byte{1},
+		StartTs: 1,
+		CRTs:    2,
+	})
+	size := eventSize(event)
+	require.Greater(t, size, uint64(0))
+	s.Add(span, event, model.NewResolvedPolymorphicEvent(0, 2))
+	s.Add(span, model.NewPolymorphicEvent(&model.RawKVEntry{
+		OpType:  model.OpTypePut,
+		Key:     []byte{1},
+		StartTs: 3,
+		CRTs:    4,
+	}))
+	require.Equal(t, size, s.quota.usage)
+
+	require.Nil(t, s.CleanByTable(span, engine.Position{StartTs: 1, CommitTs: 2}))
+	require.Equal(t, uint64(0), s.quota.usage)
+	require.Equal(t, size, s.tables.GetV(span).usage.pending)
+
+	// Events of a removed table are cleaned.
+	state := s.tables.GetV(span)
+	s.RemoveTable(span)
+	require.Equal(t, uint64(0), state.usage.pending)
+	require.Equal(t, uint64(math.MaxUint64), state.cleaned.CommitTs)
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/pingcap/log"
+	tidbkv "github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tiflow/cdc/entry"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/memquota"
@@ -39,6 +40,7 @@ type pullerWrapperCreator func(
 	tableName string,
 	startTs model.Ts,
 	bdrMode bool,
+	resolvedTsInterval time.Duration,
 ) pullerwrapper.Wrapper
 
 type tablePullers struct {
@@ -57,6 +59,9 @@ type SourceManager struct {
 	changefeedID model.ChangeFeedID
 	// up is the upstream of the puller.
 	up *upstream.Upstream
+	// kvStorage is the storage of the puller, it is scoped to the keyspace
+	// of the changefeed if any.
+	kvStorage tidbkv.Storage
 	// mg is the mounter group for mount the raw kv entry.
 	mg entry.MounterGroup
 	// engine is the source engine.
@@ -74,12 +79,13 @@ type SourceManager struct {
 func New(
 	changefeedID model.ChangeFeedID,
 	up *upstream.Upstream,
+	kvStorage tidbkv.Storage,
 	mg entry.MounterGroup,
 	engine engine.SortEngine,
 	bdrMode bool,
 ) *SourceManager {
 	multiplexing := config.GetGlobalServerConfig().KVClient.EnableMultiplexing
-	return newSourceManager(changefeedID, up, mg, engine, bdrMode, multiplexing, pullerwrapper.NewPullerWrapper)
+	return newSourceManager(changefeedID, up, kvStorage, mg, engine, bdrMode, multiplexing, pullerwrapper.NewPullerWrapper)
 }
 
 // NewForTest creates a new source manager for testing.
@@ -90,12 +96,13 @@ func NewForTest(
 	engine engine.SortEngine,
 	bdrMode bool,
 ) *SourceManager {
-	return newSourceManager(changefeedID, up, mg, engine, bdrMode, false, pullerwrapper.NewPullerWrapperForTest)
+	return newSourceManager(changefeedID, up, up.KVStorage, mg, engine, bdrMode, false, pullerwrapper.NewPullerWrapperForTest)
 }
 
 func newSourceManager(
 	changefeedID model.ChangeFeedID,
 	up *upstream.Upstream,
+	kvStorage tidbkv.Storage,
 	mg entry.MounterGroup,
 	engine engine.SortEngine,
 	bdrMode bool,
@@ -106,6 +113,7 @@ func newSourceManager(
 		ready:        make(chan struct{}),
 		changefeedID: changefeedID,
 		up:           up,
+		kvStorage:    kvStorage,
 		mg:           mg,
 		engine:       engine,
 		bdrMode:      bdrMode,
@@ -119,7 +127,11 @@ func newSourceManager(
 }
 
 // AddTable adds a table to the source manager. Start puller and register table to the engine.
-func (m *SourceManager) AddTable(span tablepb.Span, tableName string, startTs model.Ts) {
+// resolvedTsInterval throttles resolved ts events of the table, zero means
+// resolved ts is advanced as soon as possible.
+func (m *SourceManager) AddTable(
+	span tablepb.Span, tableName string, startTs model.Ts, resolvedTsInterval time.Duration,
+) {
 	// Add table to the engine first, so that the engine can receive the events from the puller.
 	m.engine.AddTable(span, startTs)
 
@@ -127,8 +139,9 @@ func (m *SourceManager) AddTable(span tablepb.Span, tableName string, startTs mo
 		return
 	}
 
-	p := m.tablePullers.pullerWrapperCreator(m.changefeedID, span, tableName, startTs, m.bdrMode)
-	p.Start(m.tablePullers.ctx, m.up, m.engine, m.tablePullers.errChan)
+	p := m.tablePullers.pullerWrapperCreator(
+		m.changefeedID, span, tableName, startTs, m.bdrMode, resolvedTsInterval)
+	p.Start(m.tablePullers.ctx, m.up, m.kvStorage, m.engine, m.tablePullers.errChan)
 	m.tablePullers.Store(span, p)
 }
 
@@ -151,12 +164,17 @@ func (m *SourceManager) OnResolve(action func(tablepb.Span, model.Ts)) {
 }
 
 // FetchByTable just wrap the engine's FetchByTable method.
+// batchFactor scales the mount batch size, values less than 1 are treated as 1.
 func (m *SourceManager) FetchByTable(
 	span tablepb.Span, lowerBound, upperBound engine.Position,
-	quota *memquota.MemQuota,
+	quota *memquota.MemQuota, batchFactor int,
 ) *engine.MountedEventIter {
+	if batchFactor < 1 {
+		batchFactor = 1
+	}
 	iter := m.engine.FetchByTable(span, lowerBound, upperBound)
-	return engine.NewMountedEventIter(m.changefeedID, iter, m.mg, defaultMaxBatchSize, quota)
+	return engine.NewMountedEventIter(
+		m.changefeedID, iter, m.mg, defaultMaxBatchSize*batchFactor, quota)
 }
 
 // CleanByTable just wrap the engine's CleanByTable method.
@@ -180,6 +198,32 @@ func (m *SourceManager) GetTablePullerStats(span tablepb.Span) puller.Stats {
 	return p.(pullerwrapper.Wrapper).GetStats()
 }
 
+// GetSnapshotStats returns the number of tables whose snapshot is not pulled,
+// and the percentage of regions of those tables whose incremental scan is
+// finished. The snapshot of tables is not tracked in multiplexing mode.
+func (m *SourceManager) GetSnapshotStats() (tableCount int, progress float64) {
+	if m.multiplexing {
+		return 0, 0
+	}
+	var regionCount, scannedRegionCount uint64
+	m.tablePullers.Range(func(_ tablepb.Span, value any) bool {
+		stats := value.(pullerwrapper.Wrapper).GetStats()
+		if !stats.Initialized {
+			tableCount++
+			regionCount += stats.RegionCount
+			scannedRegionCount += stats.ScannedRegionCount
+		}
+		return true
+	})
+	if tableCount == 0 {
+		return 0, 100
+	}
+	return tableCount, puller.Stats{
+		RegionCount:        regionCount,
+		ScannedRegionCount: scannedRegionCount,
+	}.SnapshotProgress()
+}
+
 // GetTableSorterStats returns the sorter stats of the table.
 func (m *SourceManager) GetTableSorterStats(span tablepb.Span) engine.TableStats {
 	return m.engine.GetStatsByTable(span)
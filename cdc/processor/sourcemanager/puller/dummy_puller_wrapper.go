@@ -15,7 +15,9 @@ package puller
 
 import (
 	"context"
+	"time"
 
+	tidbkv "github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
@@ -34,12 +36,13 @@ func NewPullerWrapperForTest(
 	tableName string,
 	startTs model.Ts,
 	bdrMode bool,
+	resolvedTsInterval time.Duration,
 ) Wrapper {
 	return &dummyPullerWrapper{}
 }
 
 func (d *dummyPullerWrapper) Start(ctx context.Context, up *upstream.Upstream,
-	eventSortEngine engine.SortEngine, errCh chan<- error) {
+	kvStorage tidbkv.Storage, eventSortEngine engine.SortEngine, errCh chan<- error) {
 }
 
 func (d *dummyPullerWrapper) GetStats() puller.Stats {
@@ -15,8 +15,10 @@ package puller
 
 import (
 	"context"
+	"time"
 
 	"github.com/pingcap/failpoint"
+	tidbkv "github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
@@ -33,6 +35,7 @@ type Wrapper interface {
 	Start(
 		ctx context.Context,
 		up *upstream.Upstream,
+		kvStorage tidbkv.Storage,
 		eventSortEngine engine.SortEngine,
 		errChan chan<- error,
 	)
@@ -48,6 +51,9 @@ type WrapperImpl struct {
 	p          puller.Puller
 	startTs    model.Ts
 	bdrMode    bool
+	// resolvedTsInterval is the minimum interval between two resolved ts
+	// events of the table.
+	resolvedTsInterval time.Duration
 
 	// cancel is used to cancel the puller when remove or close the table.
 	cancel context.CancelFunc
@@ -62,6 +68,7 @@ func NewPullerWrapper(
 	tableName string,
 	startTs model.Ts,
 	bdrMode bool,
+	resolvedTsInterval time.Duration,
 ) Wrapper {
 	return &WrapperImpl{
 		changefeed: changefeed,
@@ -69,6 +76,8 @@ func NewPullerWrapper(
 		tableName:  tableName,
 		startTs:    startTs,
 		bdrMode:    bdrMode,
+
+		resolvedTsInterval: resolvedTsInterval,
 	}
 }
 
@@ -77,6 +86,7 @@ func NewPullerWrapper(
 func (n *WrapperImpl) Start(
 	ctx context.Context,
 	up *upstream.Upstream,
+	kvStorage tidbkv.Storage,
 	eventSortEngine engine.SortEngine,
 	errChan chan<- error,
 ) {
@@ -99,7 +109,7 @@ func (n *WrapperImpl) Start(
 		up.PDClient,
 		up.GrpcPool,
 		up.RegionCache,
-		up.KVStorage,
+		kvStorage,
 		up.PDClock,
 		n.startTs,
 		[]tablepb.Span{n.span},
@@ -108,6 +118,7 @@ func (n *WrapperImpl) Start(
 		n.span.TableID,
 		n.tableName,
 		n.bdrMode,
+		n.resolvedTsInterval,
 	)
 
 	// Use errgroup to ensure all sub goroutines can exit without calling Close.
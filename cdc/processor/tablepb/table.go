@@ -71,6 +71,23 @@ func (k Key) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
 
+var _ json.Unmarshaler = (*Key)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It decodes the hex string encoded by MarshalJSON.
+func (k *Key) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*k = b
+	return nil
+}
+
 var (
 	_ encoding.TextMarshaler = Span{}
 	_ encoding.TextMarshaler = (*Span)(nil)
@@ -82,6 +99,45 @@ func (s Span) MarshalText() ([]byte, error) {
 	return []byte(s.String()), nil
 }
 
+var _ encoding.TextUnmarshaler = (*Span)(nil)
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It parses the text encoded by MarshalText.
+func (s *Span) UnmarshalText(text []byte) error {
+	str := string(text)
+	if !strings.HasPrefix(str, "{") || !strings.HasSuffix(str, "}") {
+		return fmt.Errorf("invalid span %q", str)
+	}
+	span := Span{}
+	hasTableID := false
+	for _, field := range strings.Split(str[1:len(str)-1], ",") {
+		name, value, ok := strings.Cut(field, ":")
+		if !ok {
+			return fmt.Errorf("invalid span %q", str)
+		}
+		var err error
+		switch name {
+		case "table_id":
+			span.TableID, err = strconv.ParseInt(value, 10, 64)
+			hasTableID = true
+		case "start_key":
+			span.StartKey, err = hex.DecodeString(value)
+		case "end_key":
+			span.EndKey, err = hex.DecodeString(value)
+		default:
+			err = fmt.Errorf("unknown field %q", name)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid span %q: %w", str, err)
+		}
+	}
+	if !hasTableID {
+		return fmt.Errorf("invalid span %q: table_id is missing", str)
+	}
+	*s = span
+	return nil
+}
+
 func (s *Span) String() string {
 	length := len("{table_id:,start_key:,end_key:}")
 	length += 8 // for TableID
@@ -176,6 +176,11 @@ type Stats struct {
 	StageCheckpoints map[string]Checkpoint `protobuf:"bytes,3,rep,name=stage_checkpoints,json=stageCheckpoints,proto3" json:"stage_checkpoints" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// The barrier timestamp of the table.
 	BarrierTs Ts `protobuf:"varint,4,opt,name=barrier_ts,json=barrierTs,proto3,casttype=Ts" json:"barrier_ts,omitempty"`
+	// Number of rows emitted to the table sink since the table is added.
+	EmittedRows uint64 `protobuf:"varint,5,opt,name=emitted_rows,json=emittedRows,proto3" json:"emitted_rows,omitempty"`
+	// Approximate bytes of rows emitted to the table sink since the table
+	// is added.
+	EmittedBytes uint64 `protobuf:"varint,6,opt,name=emitted_bytes,json=emittedBytes,proto3" json:"emitted_bytes,omitempty"`
 }
 
 func (m *Stats) Reset()         { *m = Stats{} }
@@ -239,6 +244,20 @@ func (m *Stats) GetBarrierTs() Ts {
 	return 0
 }
 
+func (m *Stats) GetEmittedRows() uint64 {
+	if m != nil {
+		return m.EmittedRows
+	}
+	return 0
+}
+
+func (m *Stats) GetEmittedBytes() uint64 {
+	if m != nil {
+		return m.EmittedBytes
+	}
+	return 0
+}
+
 // TableStatus is the running status of a table.
 // TODO rename to TableStatus.
 type TableStatus struct {
@@ -329,50 +348,53 @@ func init() {
 func init() { proto.RegisterFile("processor/tablepb/table.proto", fileDescriptor_ae83c9c6cf5ef75c) }
 
 var fileDescriptor_ae83c9c6cf5ef75c = []byte{
-	// 688 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xbf, 0x6f, 0xd3, 0x4e,
-	0x1c, 0xb5, 0x9d, 0x5f, 0xcd, 0x27, 0xf9, 0x7e, 0xe5, 0xde, 0xb7, 0xed, 0x37, 0x44, 0x22, 0x31,
-	0x51, 0x81, 0xaa, 0x95, 0x1c, 0x28, 0x0b, 0xea, 0xd6, 0xb4, 0x80, 0xaa, 0x0a, 0x09, 0xb9, 0x81,
-	0x81, 0x25, 0xf2, 0x8f, 0xc3, 0xb5, 0x1a, 0xce, 0x96, 0xef, 0xd2, 0x2a, 0x1b, 0x23, 0xca, 0x02,
-	0x13, 0x62, 0x89, 0xd4, 0x3f, 0xa7, 0x63, 0x47, 0x06, 0x14, 0x41, 0x2a, 0x66, 0xf6, 0x4e, 0xe8,
-	0xee, 0xdc, 0xb8, 0x09, 0x0c, 0xa1, 0x4b, 0x72, 0xbe, 0xf7, 0x3e, 0xcf, 0xef, 0xbd, 0x3b, 0x19,
-	0x6e, 0x47, 0x71, 0xe8, 0x62, 0x4a, 0xc3, 0xb8, 0xc9, 0x6c, 0xa7, 0x8b, 0x23, 0x47, 0xfe, 0x9b,
-	0x51, 0x1c, 0xb2, 0x10, 0xad, 0x46, 0x01, 0xf1, 0x5d, 0x3b, 0x32, 0x59, 0xf0, 0xa6, 0x1b, 0x9e,
-	0x98, 0xae, 0xe7, 0x9a, 0x93, 0x09, 0x33, 0x99, 0xa8, 0x2e, 0xf9, 0xa1, 0x1f, 0x8a, 0x81, 0x26,
-	0x5f, 0xc9, 0xd9, 0xc6, 0x07, 0x15, 0xb2, 0x07, 0x91, 0x4d, 0xd0, 0x43, 0x58, 0x10, 0xcc, 0x4e,
-	0xe0, 0x55, 0x54, 0x43, 0x5d, 0xcb, 0xb4, 0x56, 0xc6, 0xa3, 0x7a, 0xa1, 0xcd, 0xf7, 0xf6, 0x76,
-	0x2f, 0xd3, 0xa5, 0x55, 0x10, 0xbc, 0x3d, 0x0f, 0xad, 0x42, 0x91, 0x32, 0x3b, 0x66, 0x9d, 0x23,
-	0xdc, 0xaf, 0x68, 0x86, 0xba, 0x56, 0x6e, 0x15, 0x2e, 0x47, 0xf5, 0xcc, 0x3e, 0xee, 0x5b, 0x0b,
-	0x02, 0xd9, 0xc7, 0x7d, 0x64, 0x40, 0x01, 0x13, 0x4f, 0x70, 0x32, 0xd3, 0x9c, 0x3c, 0x26, 0xde,
-	0x3e, 0xee, 0x6f, 0x95, 0xdf, 0x9f, 0xd6, 0x95, 0xcf, 0xa7, 0x75, 0xe5, 0xdd, 0x57, 0x43, 0x69,
-	0x38, 0x00, 0x3b, 0x87, 0xd8, 0x3d, 0x8a, 0xc2, 0x80, 0x30, 0xb4, 0x01, 0xff, 0xb8, 0x93, 0xa7,
-	0x0e, 0xa3, 0xc2, 0x5b, 0xb6, 0x95, 0xbf, 0x1c, 0xd5, 0xb5, 0x36, 0xb5, 0xca, 0x29, 0xd8, 0xa6,
-	0xe8, 0x3e, 0x94, 0x62, 0x4c, 0xc3, 0xee, 0x31, 0xf6, 0x38, 0x55, 0x9b, 0xa2, 0xc2, 0x15, 0xd4,
-	0xa6, 0x8d, 0x1f, 0x1a, 0xe4, 0x0e, 0x98, 0xcd, 0x28, 0xba, 0x03, 0xe5, 0x18, 0xfb, 0x41, 0x48,
-	0x3a, 0x6e, 0xd8, 0x23, 0x4c, 0xca, 0x5b, 0x25, 0xb9, 0xb7, 0xc3, 0xb7, 0xd0, 0x5d, 0x00, 0xb7,
-	0x17, 0xc7, 0x58, 0xbe, 0x7f, 0x5a, 0xb4, 0x98, 0x20, 0x6d, 0x8a, 0x18, 0x2c, 0x52, 0x66, 0xfb,
-	0xb8, 0x93, 0x5a, 0xa2, 0x95, 0x8c, 0x91, 0x59, 0x2b, 0x6d, 0x6e, 0x9b, 0xf3, 0x9c, 0x90, 0x29,
-	0x1c, 0xf1, 0x5f, 0x1f, 0xa7, 0x0d, 0xd0, 0x27, 0x84, 0xc5, 0xfd, 0x56, 0xf6, 0x6c, 0x54, 0x57,
-	0x2c, 0x9d, 0xce, 0x80, 0xdc, 0x9c, 0x63, 0xc7, 0x71, 0x80, 0x63, 0x6e, 0x2e, 0x3b, 0x6d, 0x2e,
-	0x41, 0xda, 0xb4, 0xda, 0x83, 0xe5, 0x3f, 0xea, 0x22, 0x1d, 0x32, 0xfc, 0x64, 0x78, 0xec, 0xa2,
-	0xc5, 0x97, 0xe8, 0x29, 0xe4, 0x8e, 0xed, 0x6e, 0x0f, 0x8b, 0xa4, 0xa5, 0xcd, 0x07, 0xf3, 0x79,
-	0x4f, 0x85, 0x2d, 0x39, 0xbe, 0xa5, 0x3d, 0x56, 0x1b, 0x3f, 0x35, 0x28, 0x89, 0x6b, 0xc3, 0xa3,
-	0xf5, 0xe8, 0x4d, 0x2e, 0xd9, 0x2e, 0x64, 0x69, 0x64, 0x93, 0x4a, 0x4e, 0xb8, 0x59, 0x9f, 0xb3,
-	0xc9, 0xc8, 0x26, 0x49, 0x65, 0x62, 0x9a, 0x87, 0xa2, 0xcc, 0x66, 0x32, 0xd4, 0xbf, 0xf3, 0x86,
-	0x9a, 0x58, 0xc7, 0x96, 0x1c, 0x47, 0xaf, 0x00, 0xd2, 0xe3, 0x15, 0xf7, 0xf9, 0x06, 0x0d, 0x25,
-	0xce, 0xae, 0x29, 0xa1, 0x67, 0xd2, 0x9f, 0x3c, 0xc1, 0xd2, 0xe6, 0xc6, 0x5f, 0x5c, 0x98, 0x44,
-	0x4d, 0xce, 0xaf, 0x7f, 0xd2, 0x00, 0x52, 0xdb, 0xa8, 0x01, 0x85, 0x97, 0xe4, 0x88, 0x84, 0x27,
-	0x44, 0x57, 0xaa, 0xcb, 0x83, 0xa1, 0xb1, 0x98, 0x82, 0x09, 0x80, 0x0c, 0xc8, 0x6f, 0x3b, 0x14,
-	0x13, 0xa6, 0xab, 0xd5, 0xa5, 0xc1, 0xd0, 0xd0, 0x53, 0x8a, 0xdc, 0x47, 0xf7, 0xa0, 0xf8, 0x22,
-	0xc6, 0x91, 0x1d, 0x07, 0xc4, 0xd7, 0xb5, 0xea, 0xff, 0x83, 0xa1, 0xf1, 0x5f, 0x4a, 0x9a, 0x40,
-	0x68, 0x15, 0x16, 0xe4, 0x03, 0xf6, 0xf4, 0x4c, 0x75, 0x65, 0x30, 0x34, 0xd0, 0x2c, 0x0d, 0x7b,
-	0x68, 0x1d, 0x4a, 0x16, 0x8e, 0xba, 0x81, 0x6b, 0x33, 0xae, 0x97, 0xad, 0xde, 0x1a, 0x0c, 0x8d,
-	0xe5, 0x6b, 0x5d, 0xa7, 0x20, 0x57, 0x3c, 0x60, 0x61, 0xc4, 0xdb, 0xd0, 0x73, 0xb3, 0x8a, 0x57,
-	0x08, 0x4f, 0x29, 0xd6, 0xd8, 0xd3, 0xf3, 0xb3, 0x29, 0x13, 0xa0, 0xf5, 0xfc, 0xfc, 0x7b, 0x4d,
-	0x39, 0x1b, 0xd7, 0xd4, 0xf3, 0x71, 0x4d, 0xfd, 0x36, 0xae, 0xa9, 0x1f, 0x2f, 0x6a, 0xca, 0xf9,
-	0x45, 0x4d, 0xf9, 0x72, 0x51, 0x53, 0x5e, 0x37, 0xfd, 0x80, 0x1d, 0xf6, 0x1c, 0xd3, 0x0d, 0xdf,
-	0x36, 0x93, 0xea, 0x9b, 0xb2, 0xfa, 0xa6, 0xeb, 0xb9, 0xcd, 0xdf, 0xbe, 0xbf, 0x4e, 0x5e, 0x7c,
-	0x3e, 0x1f, 0xfd, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x80, 0x0e, 0x45, 0x99, 0x9b, 0x05, 0x00, 0x00,
+	// 725 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x3d, 0x6f, 0xdb, 0x48,
+	0x10, 0x25, 0x45, 0x7d, 0x58, 0x43, 0xf9, 0x40, 0xef, 0xd9, 0x3e, 0x9d, 0x80, 0x93, 0x78, 0x3a,
+	0xdf, 0x9d, 0x61, 0x03, 0xd4, 0x9d, 0xd2, 0x04, 0xee, 0x2c, 0x3b, 0x09, 0x0c, 0x23, 0x40, 0x40,
+	0x2b, 0x29, 0xd2, 0x08, 0xfc, 0xd8, 0xd0, 0x84, 0xe5, 0x5d, 0x82, 0xbb, 0xb2, 0xa0, 0x2e, 0x65,
+	0xa0, 0x26, 0xa9, 0x82, 0x34, 0x02, 0xfc, 0x07, 0xf2, 0x3f, 0x5c, 0xba, 0x4c, 0x11, 0x08, 0x89,
+	0xfc, 0x03, 0xd2, 0xbb, 0x0a, 0x96, 0xa4, 0x44, 0x5b, 0x4e, 0xa1, 0xb8, 0x91, 0x96, 0xf3, 0xde,
+	0x1b, 0xbc, 0x79, 0x3b, 0x58, 0xf8, 0x23, 0x08, 0xa9, 0x83, 0x19, 0xa3, 0x61, 0x83, 0x5b, 0x76,
+	0x17, 0x07, 0x76, 0xfc, 0x6f, 0x04, 0x21, 0xe5, 0x14, 0x6d, 0x04, 0x3e, 0xf1, 0x1c, 0x2b, 0x30,
+	0xb8, 0xff, 0xaa, 0x4b, 0xfb, 0x86, 0xe3, 0x3a, 0xc6, 0x4c, 0x61, 0x24, 0x8a, 0xca, 0xaa, 0x47,
+	0x3d, 0x1a, 0x09, 0x1a, 0xe2, 0x14, 0x6b, 0xeb, 0x6f, 0x65, 0xc8, 0x1e, 0x05, 0x16, 0x41, 0xff,
+	0xc3, 0x52, 0xc4, 0xec, 0xf8, 0x6e, 0x59, 0xd6, 0xe5, 0x4d, 0xa5, 0xb5, 0x3e, 0x19, 0xd7, 0x0a,
+	0x6d, 0x51, 0x3b, 0xd8, 0xbf, 0x4e, 0x8f, 0x66, 0x21, 0xe2, 0x1d, 0xb8, 0x68, 0x03, 0x8a, 0x8c,
+	0x5b, 0x21, 0xef, 0x9c, 0xe0, 0x41, 0x39, 0xa3, 0xcb, 0x9b, 0xa5, 0x56, 0xe1, 0x7a, 0x5c, 0x53,
+	0x0e, 0xf1, 0xc0, 0x5c, 0x8a, 0x90, 0x43, 0x3c, 0x40, 0x3a, 0x14, 0x30, 0x71, 0x23, 0x8e, 0x72,
+	0x9b, 0x93, 0xc7, 0xc4, 0x3d, 0xc4, 0x83, 0x9d, 0xd2, 0x9b, 0xf3, 0x9a, 0xf4, 0xe1, 0xbc, 0x26,
+	0xbd, 0xfe, 0xac, 0x4b, 0x75, 0x1b, 0x60, 0xef, 0x18, 0x3b, 0x27, 0x01, 0xf5, 0x09, 0x47, 0xdb,
+	0xb0, 0xec, 0xcc, 0xbe, 0x3a, 0x9c, 0x45, 0xde, 0xb2, 0xad, 0xfc, 0xf5, 0xb8, 0x96, 0x69, 0x33,
+	0xb3, 0x94, 0x82, 0x6d, 0x86, 0xfe, 0x05, 0x35, 0xc4, 0x8c, 0x76, 0xcf, 0xb0, 0x2b, 0xa8, 0x99,
+	0x5b, 0x54, 0x98, 0x42, 0x6d, 0x56, 0xff, 0xa8, 0x40, 0xee, 0x88, 0x5b, 0x9c, 0xa1, 0x3f, 0xa1,
+	0x14, 0x62, 0xcf, 0xa7, 0xa4, 0xe3, 0xd0, 0x1e, 0xe1, 0x71, 0x7b, 0x53, 0x8d, 0x6b, 0x7b, 0xa2,
+	0x84, 0xfe, 0x06, 0x70, 0x7a, 0x61, 0x88, 0x09, 0xbf, 0xdb, 0xb4, 0x98, 0x20, 0x6d, 0x86, 0x38,
+	0xac, 0x30, 0x6e, 0x79, 0xb8, 0x93, 0x5a, 0x62, 0x65, 0x45, 0x57, 0x36, 0xd5, 0xe6, 0xae, 0xb1,
+	0xc8, 0x0d, 0x19, 0x91, 0x23, 0xf1, 0xeb, 0xe1, 0x34, 0x01, 0xf6, 0x88, 0xf0, 0x70, 0xd0, 0xca,
+	0x5e, 0x8c, 0x6b, 0x92, 0xa9, 0xb1, 0x39, 0x50, 0x98, 0xb3, 0xad, 0x30, 0xf4, 0x71, 0x28, 0xcc,
+	0x65, 0x6f, 0x9b, 0x4b, 0x90, 0x76, 0x34, 0x26, 0x3e, 0xf5, 0x39, 0xc7, 0x6e, 0x27, 0xa4, 0x7d,
+	0x56, 0xce, 0xc5, 0x63, 0x26, 0x35, 0x93, 0xf6, 0x19, 0xfa, 0x0b, 0x96, 0xa7, 0x14, 0x7b, 0xc0,
+	0x31, 0x2b, 0xe7, 0x23, 0xce, 0x54, 0xd7, 0x12, 0xb5, 0x4a, 0x0f, 0xd6, 0x7e, 0xe8, 0x0f, 0x69,
+	0xa0, 0x88, 0x1b, 0x16, 0xf1, 0x15, 0x4d, 0x71, 0x44, 0x8f, 0x21, 0x77, 0x66, 0x75, 0x7b, 0x38,
+	0x4a, 0x4c, 0x6d, 0xfe, 0xb7, 0x58, 0x06, 0x69, 0x63, 0x33, 0x96, 0xef, 0x64, 0x1e, 0xca, 0xf5,
+	0x6f, 0x19, 0x50, 0xa3, 0xf5, 0x13, 0x11, 0xf5, 0xd8, 0x7d, 0x96, 0x75, 0x1f, 0xb2, 0x2c, 0xb0,
+	0x48, 0x34, 0xb9, 0xda, 0xdc, 0x5a, 0xf0, 0x46, 0x02, 0x8b, 0x24, 0xd1, 0x47, 0x6a, 0x31, 0x14,
+	0xe3, 0x16, 0x8f, 0x87, 0xfa, 0x65, 0xd1, 0xa1, 0x66, 0xd6, 0xb1, 0x19, 0xcb, 0xd1, 0x0b, 0x80,
+	0x74, 0x4d, 0xca, 0xca, 0xfd, 0x12, 0x4a, 0x9c, 0xdd, 0xe8, 0x84, 0x9e, 0xc4, 0xfe, 0xe2, 0x4d,
+	0x50, 0x9b, 0xdb, 0x3f, 0xb1, 0x78, 0x49, 0xb7, 0x58, 0xbf, 0xf5, 0x3e, 0x03, 0x90, 0xda, 0x46,
+	0x75, 0x28, 0x3c, 0x27, 0x27, 0x84, 0xf6, 0x89, 0x26, 0x55, 0xd6, 0x86, 0x23, 0x7d, 0x25, 0x05,
+	0x13, 0x00, 0xe9, 0x90, 0xdf, 0xb5, 0x19, 0x26, 0x5c, 0x93, 0x2b, 0xab, 0xc3, 0x91, 0xae, 0xa5,
+	0x94, 0xb8, 0x8e, 0xfe, 0x81, 0xe2, 0xb3, 0x10, 0x07, 0x56, 0xe8, 0x13, 0x4f, 0xcb, 0x54, 0x7e,
+	0x1b, 0x8e, 0xf4, 0x5f, 0x53, 0xd2, 0x0c, 0x42, 0x1b, 0xb0, 0x14, 0x7f, 0x60, 0x57, 0x53, 0x2a,
+	0xeb, 0xc3, 0x91, 0x8e, 0xe6, 0x69, 0xd8, 0x45, 0x5b, 0xa0, 0x9a, 0x38, 0xe8, 0xfa, 0x8e, 0xc5,
+	0x45, 0xbf, 0x6c, 0xe5, 0xf7, 0xe1, 0x48, 0x5f, 0xbb, 0x91, 0x75, 0x0a, 0x8a, 0x8e, 0x47, 0x9c,
+	0x06, 0x22, 0x0d, 0x2d, 0x37, 0xdf, 0x71, 0x8a, 0x88, 0x29, 0xa3, 0x33, 0x76, 0xb5, 0xfc, 0xfc,
+	0x94, 0x09, 0xd0, 0x7a, 0x7a, 0xf9, 0xb5, 0x2a, 0x5d, 0x4c, 0xaa, 0xf2, 0xe5, 0xa4, 0x2a, 0x7f,
+	0x99, 0x54, 0xe5, 0x77, 0x57, 0x55, 0xe9, 0xf2, 0xaa, 0x2a, 0x7d, 0xba, 0xaa, 0x4a, 0x2f, 0x1b,
+	0x9e, 0xcf, 0x8f, 0x7b, 0xb6, 0xe1, 0xd0, 0xd3, 0x46, 0x12, 0x7d, 0x23, 0x8e, 0xbe, 0xe1, 0xb8,
+	0x4e, 0xe3, 0xce, 0x3b, 0x6e, 0xe7, 0xa3, 0x67, 0xf8, 0xc1, 0xf7, 0x01, 0x00, 0x5f, 0xb4, 0xfe,
+	0xfb, 0xe3, 0x05, 0x00, 0x00,
 }
 
 func (m *Span) Marshal() (dAtA []byte, err error) {
@@ -470,6 +492,16 @@ func (m *Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.EmittedBytes != 0 {
+		i = encodeVarintTable(dAtA, i, uint64(m.EmittedBytes))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.EmittedRows != 0 {
+		i = encodeVarintTable(dAtA, i, uint64(m.EmittedRows))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.BarrierTs != 0 {
 		i = encodeVarintTable(dAtA, i, uint64(m.BarrierTs))
 		i--
@@ -645,6 +677,12 @@ func (m *Stats) Size() (n int) {
 	if m.BarrierTs != 0 {
 		n += 1 + sovTable(uint64(m.BarrierTs))
 	}
+	if m.EmittedRows != 0 {
+		n += 1 + sovTable(uint64(m.EmittedRows))
+	}
+	if m.EmittedBytes != 0 {
+		n += 1 + sovTable(uint64(m.EmittedBytes))
+	}
 	return n
 }
 
@@ -1115,6 +1153,44 @@ func (m *Stats) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmittedRows", wireType)
+			}
+			m.EmittedRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTable
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EmittedRows |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmittedBytes", wireType)
+			}
+			m.EmittedBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTable
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EmittedBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTable(dAtA[iNdEx:])
@@ -66,6 +66,39 @@ func TestSpanJSON(t *testing.T) {
 	require.Equal(t, `"{table_id:555555}"`, string(js))
 }
 
+func TestSpanJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	spans := []Span{
+		{TableID: 555555},
+		{TableID: 555555, StartKey: decode("7480000000000009ff89000000f8")},
+		{
+			TableID:  555555,
+			StartKey: decode("7480000000000009ff89000000f8"),
+			EndKey:   decode("748000000000000dffaa5f6980ff"),
+		},
+	}
+	for _, span := range spans {
+		js, err := json.Marshal(span)
+		require.Nil(t, err)
+		var decoded Span
+		require.Nil(t, json.Unmarshal(js, &decoded))
+		require.True(t, span.Eq(&decoded), "%s %s", span.String(), decoded.String())
+	}
+
+	var decoded Span
+	require.Error(t, json.Unmarshal([]byte(`"table_id:1"`), &decoded))
+	require.Error(t, json.Unmarshal([]byte(`"{start_key:00}"`), &decoded))
+	require.Error(t, json.Unmarshal([]byte(`"{table_id:1,start_key:zz}"`), &decoded))
+
+	key := Key(decode("7480000000000009ff89000000f8"))
+	js, err := json.Marshal(key)
+	require.Nil(t, err)
+	var decodedKey Key
+	require.Nil(t, json.Unmarshal(js, &decodedKey))
+	require.Equal(t, key, decodedKey)
+}
+
 func TestSpanProtoMarshalText(t *testing.T) {
 	t.Parallel()
 
@@ -16,6 +16,7 @@ package puller
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -350,6 +351,11 @@ func (p *ddlJobPullerImpl) handleJob(job *timodel.Job) (skip bool, err error) {
 	if p.schemaStorage == nil {
 		return false, nil
 	}
+	if job.BinlogInfo.FinishedTS > p.getResolvedTs() {
+		if err := p.checkUpstreamRewound(job); err != nil {
+			return true, errors.Trace(err)
+		}
+	}
 	snap := p.schemaStorage.GetLastSnapshot()
 	// Do this first to fill the schema name to its origin schema name.
 	if err := snap.FillSchemaName(job); err != nil {
@@ -436,6 +442,24 @@ func (p *ddlJobPullerImpl) handleJob(job *timodel.Job) (skip bool, err error) {
 	return false, nil
 }
 
+// checkUpstreamRewound returns ErrUpstreamRewound if the job shows that the
+// upstream is rewound, i.e. FLASHBACK CLUSTER is executed or the schema
+// version goes backwards, which happens after the cluster is restored by BR.
+func (p *ddlJobPullerImpl) checkUpstreamRewound(job *timodel.Job) error {
+	if job.Type == timodel.ActionFlashbackCluster {
+		return cerror.ErrUpstreamRewound.GenWithStackByArgs(
+			fmt.Sprintf("FLASHBACK CLUSTER is executed by job %d", job.ID))
+	}
+	// Jobs without schema versions, e.g. the ones cancelled, are ignored.
+	if job.BinlogInfo.SchemaVersion > 0 &&
+		job.BinlogInfo.SchemaVersion < p.schemaVersion {
+		return cerror.ErrUpstreamRewound.GenWithStackByArgs(
+			fmt.Sprintf("schema version goes backwards from %d to %d by job %d",
+				p.schemaVersion, job.BinlogInfo.SchemaVersion, job.ID))
+	}
+	return nil
+}
+
 func findDBByName(dbs []*timodel.DBInfo, name string) (*timodel.DBInfo, error) {
 	for _, db := range dbs {
 		if db.Name.L == name {
@@ -510,7 +534,7 @@ func NewDDLJobPuller(
 		jobPuller.puller.Puller = New(
 			ctx, pdCli, grpcPool, regionCache, kvStorage, pdClock,
 			checkpointTs, spans, cfg, changefeed, -1, memorysorter.DDLPullerTableName,
-			ddlPullerFilterLoop,
+			ddlPullerFilterLoop, 0,
 		)
 	}
 
@@ -548,6 +572,7 @@ type ddlPullerImpl struct {
 func NewDDLPuller(ctx context.Context,
 	replicaConfig *config.ReplicaConfig,
 	up *upstream.Upstream,
+	kvStorage tidbkv.Storage,
 	startTs uint64,
 	changefeed model.ChangeFeedID,
 	schemaStorage entry.SchemaStorage,
@@ -557,9 +582,9 @@ func NewDDLPuller(ctx context.Context,
 	var err error
 
 	// storage can be nil only in the test
-	if up.KVStorage != nil {
+	if kvStorage != nil {
 		puller, err = NewDDLJobPuller(
-			ctx, up.PDClient, up.GrpcPool, up.RegionCache, up.KVStorage, up.PDClock,
+			ctx, up.PDClient, up.GrpcPool, up.RegionCache, kvStorage, up.PDClock,
 			startTs, config.GetGlobalServerConfig().KVClient,
 			changefeed, schemaStorage, filter,
 			true, /* isOwner */
@@ -512,6 +512,22 @@ func TestHandleJob(t *testing.T) {
 		skip, err := ddlJobPullerImpl.handleJob(job)
 		require.NoError(t, err)
 		require.True(t, skip)
+
+		// the flashback job is newer than the resolved ts
+		job.BinlogInfo.FinishedTS = ddlJobPullerImpl.getResolvedTs() + 1
+		skip, err = ddlJobPullerImpl.handleJob(job)
+		require.Regexp(t, "ErrUpstreamRewound", err)
+		require.True(t, skip)
+	}
+
+	// test schema version goes backwards
+	{
+		job := helper.DDL2Job("create table test1.t3(id int primary key)")
+		job.BinlogInfo.FinishedTS = ddlJobPullerImpl.getResolvedTs() + 1
+		job.BinlogInfo.SchemaVersion = ddlJobPullerImpl.schemaVersion - 1
+		skip, err := ddlJobPullerImpl.handleJob(job)
+		require.Regexp(t, "ErrUpstreamRewound", err)
+		require.True(t, skip)
 	}
 }
 
@@ -542,7 +558,7 @@ func TestDDLPuller(t *testing.T) {
 	require.Nil(t, err)
 	p, err := NewDDLPuller(
 		ctx, ctx.ChangefeedVars().Info.Config,
-		up, startTs,
+		up, up.KVStorage, startTs,
 		ctx.ChangefeedVars().ID,
 		schemaStorage,
 		f)
@@ -672,7 +688,7 @@ func TestResolvedTsStuck(t *testing.T) {
 	require.Nil(t, err)
 	p, err := NewDDLPuller(
 		ctx, ctx.ChangefeedVars().Info.Config,
-		up, startTs,
+		up, up.KVStorage, startTs,
 		ctx.ChangefeedVars().ID,
 		schemaStorage,
 		f)
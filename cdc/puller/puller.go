@@ -14,6 +14,7 @@
 package puller
 
 import (
+	"bytes"
 	"context"
 	"sync/atomic"
 	"time"
@@ -47,6 +48,30 @@ type Stats struct {
 	ResolvedTsIngress   model.Ts
 	CheckpointTsEgress  model.Ts
 	ResolvedTsEgress    model.Ts
+	// Initialized is true once the incremental scan of every region of the
+	// table is finished, that is, the snapshot of the table is pulled.
+	Initialized bool
+	// ScannedRegionCount is the number of regions whose incremental scan is
+	// finished before the puller is initialized.
+	ScannedRegionCount uint64
+}
+
+// SnapshotProgress returns the percentage of regions whose incremental scan is
+// finished. It is 100 once the puller is initialized.
+func (s Stats) SnapshotProgress() float64 {
+	if s.Initialized {
+		return 100
+	}
+	if s.RegionCount == 0 {
+		return 0
+	}
+	// Regions may split during the scan, so the count of scanned regions can
+	// exceed the count of regions being captured.
+	progress := float64(s.ScannedRegionCount) / float64(s.RegionCount) * 100
+	if progress > 99 {
+		progress = 99
+	}
+	return progress
 }
 
 // Puller pull data from tikv and push changes into a buffer.
@@ -67,10 +92,22 @@ type pullerImpl struct {
 	checkpointTs uint64
 	// The latest resolved ts that puller has sent.
 	resolvedTs uint64
+	// initialized and scannedRegionCount track the incremental scan of the
+	// table, see Stats for details.
+	initialized        atomic.Bool
+	scannedRegionCount atomic.Uint64
 
 	changefeed model.ChangeFeedID
 	tableID    model.TableID
 	tableName  string
+
+	// resolvedTsInterval is the minimum interval between two resolved ts
+	// events sent by the puller, zero means no limit.
+	resolvedTsInterval time.Duration
+	// keyspacePrefix is the key prefix of the keyspace the puller is scoped to.
+	// It is added to spans requested from TiKV and trimmed from keys of
+	// received events, so it is transparent to downstream components.
+	keyspacePrefix []byte
 }
 
 // New create a new Puller fetch event start from checkpointTs and put into buf.
@@ -87,6 +124,7 @@ func New(ctx context.Context,
 	tableID model.TableID,
 	tableName string,
 	filterLoop bool,
+	resolvedTsInterval time.Duration,
 ) Puller {
 	tikvStorage, ok := kvStorage.(tikv.Storage)
 	if !ok {
@@ -110,6 +148,9 @@ func New(ctx context.Context,
 		changefeed:   changefeed,
 		tableID:      tableID,
 		tableName:    tableName,
+
+		resolvedTsInterval: resolvedTsInterval,
+		keyspacePrefix:     kv.KeyspacePrefix(kvStorage),
 	}
 	return p
 }
@@ -127,7 +168,8 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 		span := span
 
 		g.Go(func() error {
-			return p.kvCli.EventFeed(ctx, span, checkpointTs, lockResolver, eventCh)
+			return p.kvCli.EventFeed(ctx, spanz.AddKeyspacePrefix(p.keyspacePrefix, span),
+				checkpointTs, lockResolver, eventCh)
 		})
 	}
 
@@ -137,6 +179,7 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 		WithLabelValues(p.changefeed.Namespace, p.changefeed.ID, "resolved")
 
 	lastResolvedTs := p.checkpointTs
+	var lastResolvedTime time.Time
 	g.Go(func() error {
 		metricsTicker := time.NewTicker(15 * time.Second)
 		defer metricsTicker.Stop()
@@ -171,6 +214,9 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 
 		start := time.Now()
 		initialized := false
+		// scannedRegions are the regions which have sent resolved ts, a region
+		// only sends resolved ts after its incremental scan is finished.
+		scannedRegions := make(map[uint64]struct{})
 		for {
 			var e model.RegionFeedEvent
 			select {
@@ -181,6 +227,9 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 
 			if e.Val != nil {
 				metricPullerEventCounterKv.Inc()
+				if len(p.keyspacePrefix) != 0 {
+					e.Val.Key = bytes.TrimPrefix(e.Val.Key, p.keyspacePrefix)
+				}
 				if err := output(e.Val); err != nil {
 					return errors.Trace(err)
 				}
@@ -190,7 +239,8 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 			if e.Resolved != nil {
 				metricPullerEventCounterResolved.Add(float64(len(e.Resolved.Spans)))
 				for _, resolvedSpan := range e.Resolved.Spans {
-					if !spanz.IsSubSpan(resolvedSpan.Span, p.spans...) {
+					span := spanz.TrimKeyspacePrefix(p.keyspacePrefix, resolvedSpan.Span)
+					if !spanz.IsSubSpan(span, p.spans...) {
 						log.Panic("the resolved span is not in the total span",
 							zap.String("namespace", p.changefeed.Namespace),
 							zap.String("changefeed", p.changefeed.ID),
@@ -201,11 +251,19 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 						)
 					}
 					// Forward is called in a single thread
-					p.tsTracker.Forward(resolvedSpan.Region, resolvedSpan.Span, e.Resolved.ResolvedTs)
+					p.tsTracker.Forward(resolvedSpan.Region, span, e.Resolved.ResolvedTs)
+					if !initialized {
+						scannedRegions[resolvedSpan.Region] = struct{}{}
+					}
 				}
 				resolvedTs := p.tsTracker.Frontier()
+				if !initialized {
+					p.scannedRegionCount.Store(uint64(len(scannedRegions)))
+				}
 				if resolvedTs > 0 && !initialized {
 					initialized = true
+					scannedRegions = nil
+					p.initialized.Store(true)
 
 					spans := make([]string, 0, len(p.spans))
 					for i := range p.spans {
@@ -223,7 +281,14 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 				if !initialized || resolvedTs == lastResolvedTs {
 					continue
 				}
+				// Throttle resolved ts events. The frontier keeps the latest
+				// resolved ts and it's sent once the interval elapses.
+				if p.resolvedTsInterval > 0 &&
+					time.Since(lastResolvedTime) < p.resolvedTsInterval {
+					continue
+				}
 				lastResolvedTs = resolvedTs
+				lastResolvedTime = time.Now()
 				err := output(&model.RawKVEntry{CRTs: resolvedTs, OpType: model.OpTypeResolved, RegionID: e.RegionID})
 				if err != nil {
 					return errors.Trace(err)
@@ -246,5 +311,7 @@ func (p *pullerImpl) Stats() Stats {
 		CheckpointTsIngress: p.kvCli.CommitTs(),
 		ResolvedTsEgress:    atomic.LoadUint64(&p.resolvedTs),
 		CheckpointTsEgress:  atomic.LoadUint64(&p.checkpointTs),
+		Initialized:         p.initialized.Load(),
+		ScannedRegionCount:  p.scannedRegionCount.Load(),
 	}
 }
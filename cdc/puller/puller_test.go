@@ -20,6 +20,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pingcap/errors"
 	tidbkv "github.com/pingcap/tidb/kv"
@@ -50,7 +51,8 @@ func (mc *mockPdClientForPullerTest) GetClusterID(ctx context.Context) uint64 {
 
 type mockCDCKVClient struct {
 	kv.CDCKVClient
-	expectations chan model.RegionFeedEvent
+	expectations   chan model.RegionFeedEvent
+	requestedSpans chan tablepb.Span
 }
 
 type mockInjectedPuller struct {
@@ -71,7 +73,8 @@ func newMockCDCKVClient(
 	filterloop bool,
 ) kv.CDCKVClient {
 	return &mockCDCKVClient{
-		expectations: make(chan model.RegionFeedEvent, 1024),
+		expectations:   make(chan model.RegionFeedEvent, 1024),
+		requestedSpans: make(chan tablepb.Span, 16),
 	}
 }
 
@@ -82,6 +85,7 @@ func (mc *mockCDCKVClient) EventFeed(
 	lockResolver txnutil.LockResolver,
 	eventCh chan<- model.RegionFeedEvent,
 ) error {
+	mc.requestedSpans <- span
 	for {
 		select {
 		case <-ctx.Done():
@@ -115,6 +119,15 @@ func newPullerForTest(
 	t *testing.T,
 	spans []tablepb.Span,
 	checkpointTs uint64,
+) (*mockInjectedPuller, context.CancelFunc, *sync.WaitGroup, tidbkv.Storage) {
+	return newKeyspacePullerForTest(t, spans, checkpointTs, nil)
+}
+
+func newKeyspacePullerForTest(
+	t *testing.T,
+	spans []tablepb.Span,
+	checkpointTs uint64,
+	keyspacePrefix []byte,
 ) (*mockInjectedPuller, context.CancelFunc, *sync.WaitGroup, tidbkv.Storage) {
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
@@ -134,7 +147,8 @@ func newPullerForTest(
 		ctx, pdCli, grpcPool, regionCache, store, pdutil.NewClock4Test(),
 		checkpointTs, spans, config.GetDefaultServerConfig().KVClient,
 		model.DefaultChangeFeedID("changefeed-id-test"), 0,
-		"table-test", false)
+		"table-test", false, 0)
+	plr.(*pullerImpl).keyspacePrefix = keyspacePrefix
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -238,3 +252,100 @@ func TestPullerRawKV(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+func TestPullerKeyspace(t *testing.T) {
+	prefix := []byte{'x', 0, 0, 1}
+	spans := []tablepb.Span{spanz.ToSpan([]byte("t_a"), []byte("t_e"))}
+	checkpointTs := uint64(996)
+	plr, cancel, wg, store := newKeyspacePullerForTest(t, spans, checkpointTs, prefix)
+
+	// Spans requested from TiKV are prefixed with the keyspace prefix.
+	require.Equal(t, spanz.AddKeyspacePrefix(prefix, spans[0]), <-plr.cli.requestedSpans)
+
+	plr.cli.Returns(model.RegionFeedEvent{
+		Val: &model.RawKVEntry{
+			OpType: model.OpTypePut,
+			Key:    append([]byte{'x', 0, 0, 1}, "t_b"...),
+			Value:  []byte("test-value"),
+			CRTs:   uint64(1002),
+		},
+	})
+	plr.cli.Returns(model.RegionFeedEvent{
+		Resolved: &model.ResolvedSpans{
+			Spans: []model.RegionComparableSpan{{
+				Span: spanz.AddKeyspacePrefix(prefix, spans[0]),
+			}}, ResolvedTs: uint64(1003),
+		},
+	})
+	// The keyspace prefix is transparent to components after the puller.
+	ev := <-plr.Output()
+	require.Equal(t, model.OpTypePut, ev.OpType)
+	require.Equal(t, []byte("t_b"), ev.Key)
+	ev = <-plr.Output()
+	require.Equal(t, model.OpTypeResolved, ev.OpType)
+	require.Equal(t, uint64(1003), ev.CRTs)
+
+	store.Close()
+	cancel()
+	wg.Wait()
+}
+
+func TestPullerSnapshotProgress(t *testing.T) {
+	spans := []tablepb.Span{spanz.ToSpan([]byte("t_a"), []byte("t_e"))}
+	checkpointTs := uint64(996)
+	plr, cancel, wg, store := newPullerForTest(t, spans, checkpointTs)
+	impl := plr.Puller.(*pullerImpl)
+
+	plr.cli.Returns(model.RegionFeedEvent{
+		Resolved: &model.ResolvedSpans{
+			Spans: []model.RegionComparableSpan{{
+				Span:   spanz.ToSpan([]byte("t_a"), []byte("t_c")),
+				Region: 1,
+			}}, ResolvedTs: uint64(1001),
+		},
+	})
+	plr.cli.Returns(model.RegionFeedEvent{
+		Resolved: &model.ResolvedSpans{
+			Spans: []model.RegionComparableSpan{{
+				Span:   spanz.ToSpan([]byte("t_a"), []byte("t_c")),
+				Region: 1,
+			}}, ResolvedTs: uint64(1002),
+		},
+	})
+	require.Eventually(t, func() bool {
+		return impl.scannedRegionCount.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.False(t, impl.initialized.Load())
+
+	plr.cli.Returns(model.RegionFeedEvent{
+		Resolved: &model.ResolvedSpans{
+			Spans: []model.RegionComparableSpan{{
+				Span:   spanz.ToSpan([]byte("t_c"), []byte("t_e")),
+				Region: 2,
+			}}, ResolvedTs: uint64(1001),
+		},
+	})
+	ev := <-plr.Output()
+	require.Equal(t, model.OpTypeResolved, ev.OpType)
+	require.True(t, impl.initialized.Load())
+
+	store.Close()
+	cancel()
+	wg.Wait()
+}
+
+func TestStatsSnapshotProgress(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, float64(0), Stats{}.SnapshotProgress())
+	require.Equal(t, float64(25), Stats{
+		RegionCount: 4, ScannedRegionCount: 1,
+	}.SnapshotProgress())
+	// Regions split during the scan.
+	require.Equal(t, float64(99), Stats{
+		RegionCount: 4, ScannedRegionCount: 5,
+	}.SnapshotProgress())
+	require.Equal(t, float64(100), Stats{
+		RegionCount: 4, ScannedRegionCount: 1, Initialized: true,
+	}.SnapshotProgress())
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"time"
+
+	filter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// ResolvedTsIntervalMatcher matches tables with the `resolved-ts-intervals`
+// rules of a changefeed, which throttle resolved ts of the matched tables.
+type ResolvedTsIntervalMatcher struct {
+	rules []struct {
+		filter.Filter
+		interval time.Duration
+	}
+}
+
+// NewResolvedTsIntervalMatcher creates a ResolvedTsIntervalMatcher.
+func NewResolvedTsIntervalMatcher(
+	rules []*config.ResolvedTsIntervalRule, caseSensitive bool,
+) (*ResolvedTsIntervalMatcher, error) {
+	m := &ResolvedTsIntervalMatcher{}
+	for _, rule := range rules {
+		f, err := filter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rule.Matcher)
+		}
+		if !caseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+		interval, err := rule.GetInterval()
+		if err != nil {
+			return nil, err
+		}
+		m.rules = append(m.rules, struct {
+			filter.Filter
+			interval time.Duration
+		}{Filter: f, interval: interval})
+	}
+	return m, nil
+}
+
+// Match returns the resolved ts interval of the given table. Zero is returned
+// if no rule matches, which means resolved ts is advanced as soon as possible.
+func (m *ResolvedTsIntervalMatcher) Match(schema, table string) time.Duration {
+	if m == nil {
+		return 0
+	}
+	for _, rule := range m.rules {
+		if rule.MatchTable(schema, table) {
+			return rule.interval
+		}
+	}
+	return 0
+}
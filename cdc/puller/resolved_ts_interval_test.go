@@ -0,0 +1,45 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvedTsIntervalMatcher(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewResolvedTsIntervalMatcher([]*config.ResolvedTsIntervalRule{
+		{Matcher: []string{"test.orders"}, Interval: "200ms"},
+		{Matcher: []string{"test.*", "archive.*"}, Interval: "5s"},
+	}, false)
+	require.Nil(t, err)
+	require.Equal(t, 200*time.Millisecond, m.Match("test", "orders"))
+	require.Equal(t, 200*time.Millisecond, m.Match("TEST", "Orders"))
+	require.Equal(t, 5*time.Second, m.Match("test", "history"))
+	require.Equal(t, 5*time.Second, m.Match("archive", "t1"))
+	require.Equal(t, time.Duration(0), m.Match("other", "t1"))
+
+	var nilMatcher *ResolvedTsIntervalMatcher
+	require.Equal(t, time.Duration(0), nilMatcher.Match("test", "orders"))
+
+	_, err = NewResolvedTsIntervalMatcher([]*config.ResolvedTsIntervalRule{
+		{Matcher: []string{"test.orders"}, Interval: "abc"},
+	}, false)
+	require.Error(t, err)
+}
@@ -23,6 +23,16 @@ import (
 type LogMeta struct {
 	CheckpointTs uint64 `msg:"checkpointTs"`
 	ResolvedTs   uint64 `msg:"resolvedTs"`
+	// Tables are the tables covered by the redo log with their flushed
+	// resolved ts. It's only set if the redo log covers part of the tables,
+	// which means only these tables can be recovered by the redo log.
+	Tables []TableMeta `msg:"tables"`
+}
+
+// TableMeta is the meta of a table covered by the redo log.
+type TableMeta struct {
+	TableID    int64  `msg:"tableID"`
+	ResolvedTs uint64 `msg:"resolvedTs"`
 }
 
 // ParseMeta parses meta.
@@ -38,3 +48,17 @@ func ParseMeta(metas []*LogMeta, checkpointTs, resolvedTs *model.Ts) {
 		}
 	}
 }
+
+// ParseTableMeta returns the tables of the meta with the largest resolved ts.
+func ParseTableMeta(metas []*LogMeta) []TableMeta {
+	var latest *LogMeta
+	for _, meta := range metas {
+		if latest == nil || latest.ResolvedTs < meta.ResolvedTs {
+			latest = meta
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	return latest.Tables
+}
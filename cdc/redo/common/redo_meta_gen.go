@@ -36,6 +36,54 @@ func (z *LogMeta) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "ResolvedTs")
 				return
 			}
+		case "tables":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Tables")
+				return
+			}
+			if cap(z.Tables) >= int(zb0002) {
+				z.Tables = (z.Tables)[:zb0002]
+			} else {
+				z.Tables = make([]TableMeta, zb0002)
+			}
+			for za0001 := range z.Tables {
+				var zb0003 uint32
+				zb0003, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Tables", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Tables", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "tableID":
+						z.Tables[za0001].TableID, err = dc.ReadInt64()
+						if err != nil {
+							err = msgp.WrapError(err, "Tables", za0001, "TableID")
+							return
+						}
+					case "resolvedTs":
+						z.Tables[za0001].ResolvedTs, err = dc.ReadUint64()
+						if err != nil {
+							err = msgp.WrapError(err, "Tables", za0001, "ResolvedTs")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Tables", za0001)
+							return
+						}
+					}
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -48,10 +96,10 @@ func (z *LogMeta) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z LogMeta) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
+func (z *LogMeta) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
 	// write "checkpointTs"
-	err = en.Append(0x82, 0xac, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x73)
+	err = en.Append(0x83, 0xac, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x73)
 	if err != nil {
 		return
 	}
@@ -70,19 +118,64 @@ func (z LogMeta) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "ResolvedTs")
 		return
 	}
+	// write "tables"
+	err = en.Append(0xa6, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Tables)))
+	if err != nil {
+		err = msgp.WrapError(err, "Tables")
+		return
+	}
+	for za0001 := range z.Tables {
+		// map header, size 2
+		// write "tableID"
+		err = en.Append(0x82, 0xa7, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x44)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt64(z.Tables[za0001].TableID)
+		if err != nil {
+			err = msgp.WrapError(err, "Tables", za0001, "TableID")
+			return
+		}
+		// write "resolvedTs"
+		err = en.Append(0xaa, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x54, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteUint64(z.Tables[za0001].ResolvedTs)
+		if err != nil {
+			err = msgp.WrapError(err, "Tables", za0001, "ResolvedTs")
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z LogMeta) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *LogMeta) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 3
 	// string "checkpointTs"
-	o = append(o, 0x82, 0xac, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x73)
+	o = append(o, 0x83, 0xac, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x73)
 	o = msgp.AppendUint64(o, z.CheckpointTs)
 	// string "resolvedTs"
 	o = append(o, 0xaa, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x54, 0x73)
 	o = msgp.AppendUint64(o, z.ResolvedTs)
+	// string "tables"
+	o = append(o, 0xa6, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Tables)))
+	for za0001 := range z.Tables {
+		// map header, size 2
+		// string "tableID"
+		o = append(o, 0x82, 0xa7, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x44)
+		o = msgp.AppendInt64(o, z.Tables[za0001].TableID)
+		// string "resolvedTs"
+		o = append(o, 0xaa, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x54, 0x73)
+		o = msgp.AppendUint64(o, z.Tables[za0001].ResolvedTs)
+	}
 	return
 }
 
@@ -116,6 +209,182 @@ func (z *LogMeta) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "ResolvedTs")
 				return
 			}
+		case "tables":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Tables")
+				return
+			}
+			if cap(z.Tables) >= int(zb0002) {
+				z.Tables = (z.Tables)[:zb0002]
+			} else {
+				z.Tables = make([]TableMeta, zb0002)
+			}
+			for za0001 := range z.Tables {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Tables", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Tables", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "tableID":
+						z.Tables[za0001].TableID, bts, err = msgp.ReadInt64Bytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Tables", za0001, "TableID")
+							return
+						}
+					case "resolvedTs":
+						z.Tables[za0001].ResolvedTs, bts, err = msgp.ReadUint64Bytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Tables", za0001, "ResolvedTs")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Tables", za0001)
+							return
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *LogMeta) Msgsize() (s int) {
+	s = 1 + 13 + msgp.Uint64Size + 11 + msgp.Uint64Size + 7 + msgp.ArrayHeaderSize + (len(z.Tables) * (20 + msgp.Int64Size + msgp.Uint64Size))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *TableMeta) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "tableID":
+			z.TableID, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "TableID")
+				return
+			}
+		case "resolvedTs":
+			z.ResolvedTs, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ResolvedTs")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z TableMeta) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "tableID"
+	err = en.Append(0x82, 0xa7, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x44)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.TableID)
+	if err != nil {
+		err = msgp.WrapError(err, "TableID")
+		return
+	}
+	// write "resolvedTs"
+	err = en.Append(0xaa, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x54, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ResolvedTs)
+	if err != nil {
+		err = msgp.WrapError(err, "ResolvedTs")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z TableMeta) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "tableID"
+	o = append(o, 0x82, 0xa7, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x44)
+	o = msgp.AppendInt64(o, z.TableID)
+	// string "resolvedTs"
+	o = append(o, 0xaa, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x54, 0x73)
+	o = msgp.AppendUint64(o, z.ResolvedTs)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *TableMeta) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "tableID":
+			z.TableID, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TableID")
+				return
+			}
+		case "resolvedTs":
+			z.ResolvedTs, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ResolvedTs")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -129,7 +398,7 @@ func (z *LogMeta) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z LogMeta) Msgsize() (s int) {
-	s = 1 + 13 + msgp.Uint64Size + 11 + msgp.Uint64Size
+func (z TableMeta) Msgsize() (s int) {
+	s = 1 + 8 + msgp.Int64Size + 11 + msgp.Uint64Size
 	return
 }
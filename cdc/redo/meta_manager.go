@@ -17,7 +17,9 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pingcap/log"
@@ -41,6 +43,10 @@ type MetaManager interface {
 	redoManager
 	// UpdateMeta updates the checkpointTs and resolvedTs asynchronously.
 	UpdateMeta(checkpointTs, resolvedTs model.Ts)
+	// UpdateTableMeta updates the resolved ts of tables asynchronously. Only
+	// tables covered by the redo log are tracked, and only if the redo log
+	// covers part of the tables.
+	UpdateTableMeta(tables map[model.TableID]model.Ts)
 	// GetFlushedMeta returns the flushed meta.
 	GetFlushedMeta() common.LogMeta
 	// Cleanup deletes all redo logs, which are only called from the owner
@@ -56,6 +62,13 @@ type metaManager struct {
 	metaCheckpointTs statefulRts
 	metaResolvedTs   statefulRts
 
+	// coversTable is nil if all tables are covered by the redo log.
+	coversTable func(model.TableID) bool
+	tablesMu    sync.Mutex
+	// tables are the covered tables with their resolved ts, sorted by table ID.
+	tables        []common.TableMeta
+	tablesChanged bool
+
 	// This fields are used to process meta files and perform
 	// garbage collection of logs.
 	extStorage    storage.ExternalStorage
@@ -187,6 +200,46 @@ func (m *metaManager) UpdateMeta(checkpointTs, resolvedTs model.Ts) {
 	}
 }
 
+// SetTableCoverage sets the function to decide whether a table is covered by
+// the redo log. It must be called before the manager runs, and only if the
+// redo log covers part of the tables.
+func (m *metaManager) SetTableCoverage(coversTable func(model.TableID) bool) {
+	m.coversTable = coversTable
+}
+
+// UpdateTableMeta updates the resolved ts of the covered tables.
+func (m *metaManager) UpdateTableMeta(tables map[model.TableID]model.Ts) {
+	if m.coversTable == nil {
+		return
+	}
+	covered := make([]common.TableMeta, 0, len(tables))
+	for tableID, resolvedTs := range tables {
+		if m.coversTable(tableID) {
+			covered = append(covered, common.TableMeta{TableID: tableID, ResolvedTs: resolvedTs})
+		}
+	}
+	sort.Slice(covered, func(i, j int) bool { return covered[i].TableID < covered[j].TableID })
+
+	m.tablesMu.Lock()
+	defer m.tablesMu.Unlock()
+	if !tableMetasEqual(m.tables, covered) {
+		m.tables = covered
+		m.tablesChanged = true
+	}
+}
+
+func tableMetasEqual(a, b []common.TableMeta) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // GetFlushedMeta gets flushed meta.
 func (m *metaManager) GetFlushedMeta() common.LogMeta {
 	checkpointTs := m.metaCheckpointTs.getFlushed()
@@ -361,9 +414,14 @@ func (m *metaManager) prepareForFlushMeta() (bool, common.LogMeta) {
 	unflushed.CheckpointTs = m.metaCheckpointTs.getUnflushed()
 	unflushed.ResolvedTs = m.metaResolvedTs.getUnflushed()
 
+	m.tablesMu.Lock()
+	unflushed.Tables = m.tables
+	tablesChanged := m.tablesChanged
+	m.tablesMu.Unlock()
+
 	hasChange := false
 	if flushed.CheckpointTs < unflushed.CheckpointTs ||
-		flushed.ResolvedTs < unflushed.ResolvedTs {
+		flushed.ResolvedTs < unflushed.ResolvedTs || tablesChanged {
 		hasChange = true
 	}
 	return hasChange, unflushed
@@ -372,6 +430,13 @@ func (m *metaManager) prepareForFlushMeta() (bool, common.LogMeta) {
 func (m *metaManager) postFlushMeta(meta common.LogMeta) {
 	m.metaResolvedTs.setFlushed(meta.ResolvedTs)
 	m.metaCheckpointTs.setFlushed(meta.CheckpointTs)
+
+	m.tablesMu.Lock()
+	// The tables may be updated during the flush.
+	if tableMetasEqual(m.tables, meta.Tables) {
+		m.tablesChanged = false
+	}
+	m.tablesMu.Unlock()
 }
 
 func (m *metaManager) flush(ctx context.Context, meta common.LogMeta) error {
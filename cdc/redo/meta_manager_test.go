@@ -295,3 +295,40 @@ func TestGCAndCleanup(t *testing.T) {
 	})
 	require.Equal(t, 1, cnt)
 }
+
+func TestUpdateTableMeta(t *testing.T) {
+	t.Parallel()
+
+	m := &metaManager{enabled: true}
+	// All tables are covered, tables are not tracked.
+	m.UpdateTableMeta(map[model.TableID]model.Ts{1: 10})
+	hasChange, meta := m.prepareForFlushMeta()
+	require.False(t, hasChange)
+	require.Empty(t, meta.Tables)
+
+	m.SetTableCoverage(func(tableID model.TableID) bool { return tableID != 2 })
+	m.UpdateTableMeta(map[model.TableID]model.Ts{3: 10, 1: 10, 2: 10})
+	hasChange, meta = m.prepareForFlushMeta()
+	require.True(t, hasChange)
+	require.Equal(t, []common.TableMeta{
+		{TableID: 1, ResolvedTs: 10}, {TableID: 3, ResolvedTs: 10},
+	}, meta.Tables)
+	m.postFlushMeta(meta)
+	hasChange, _ = m.prepareForFlushMeta()
+	require.False(t, hasChange)
+
+	// Tables are flushed again only if they are changed.
+	m.UpdateTableMeta(map[model.TableID]model.Ts{1: 10, 3: 10})
+	hasChange, _ = m.prepareForFlushMeta()
+	require.False(t, hasChange)
+	m.UpdateTableMeta(map[model.TableID]model.Ts{1: 12, 3: 10})
+	hasChange, meta = m.prepareForFlushMeta()
+	require.True(t, hasChange)
+	require.Equal(t, []common.TableMeta{
+		{TableID: 1, ResolvedTs: 12}, {TableID: 3, ResolvedTs: 10},
+	}, meta.Tables)
+	require.Equal(t, meta.Tables, common.ParseTableMeta([]*common.LogMeta{
+		{CheckpointTs: 5, ResolvedTs: 8},
+		{CheckpointTs: 10, ResolvedTs: 12, Tables: meta.Tables},
+	}))
+}
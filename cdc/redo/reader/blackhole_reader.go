@@ -47,6 +47,11 @@ func (br *BlackHoleReader) ReadMeta(ctx context.Context) (checkpointTs, resolved
 	return 0, 1, nil
 }
 
+// ReadCoveredTables implements LogReader.ReadCoveredTables
+func (br *BlackHoleReader) ReadCoveredTables(ctx context.Context) (map[model.TableID]model.Ts, error) {
+	return nil, nil
+}
+
 // Close implement the Close interface
 func (br *BlackHoleReader) Close() error {
 	return nil
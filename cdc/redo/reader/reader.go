@@ -51,6 +51,10 @@ type RedoLogReader interface {
 	ReadNextDDL(ctx context.Context) (*model.DDLEvent, error)
 	// ReadMeta reads meta from redo logs and returns the latest checkpointTs and resolvedTs
 	ReadMeta(ctx context.Context) (checkpointTs, resolvedTs uint64, err error)
+	// ReadCoveredTables reads meta from redo logs and returns the tables
+	// covered by redo logs with their flushed resolved ts. It returns nil if
+	// all tables are covered.
+	ReadCoveredTables(ctx context.Context) (map[model.TableID]model.Ts, error)
 }
 
 // NewRedoLogReader creates a new redo log reader
@@ -319,7 +323,11 @@ func (l *LogReader) initMeta(ctx context.Context) error {
 			zap.Uint64("resolvedTs", resolvedTs),
 			zap.Uint64("checkpointTs", checkpointTs))
 	}
-	l.meta = &common.LogMeta{CheckpointTs: checkpointTs, ResolvedTs: resolvedTs}
+	l.meta = &common.LogMeta{
+		CheckpointTs: checkpointTs,
+		ResolvedTs:   resolvedTs,
+		Tables:       common.ParseTableMeta(metas),
+	}
 	return nil
 }
 
@@ -331,6 +339,21 @@ func (l *LogReader) ReadMeta(ctx context.Context) (checkpointTs, resolvedTs uint
 	return l.meta.CheckpointTs, l.meta.ResolvedTs, nil
 }
 
+// ReadCoveredTables implement ReadCoveredTables interface
+func (l *LogReader) ReadCoveredTables(ctx context.Context) (map[model.TableID]model.Ts, error) {
+	if l.meta == nil {
+		return nil, errors.Trace(errors.ErrRedoMetaFileNotFound.GenWithStackByArgs(l.cfg.Dir))
+	}
+	if len(l.meta.Tables) == 0 {
+		return nil, nil
+	}
+	tables := make(map[model.TableID]model.Ts, len(l.meta.Tables))
+	for _, table := range l.meta.Tables {
+		tables[table.TableID] = table.ResolvedTs
+	}
+	return tables, nil
+}
+
 type logWithIdx struct {
 	idx  int
 	data *model.RedoLog
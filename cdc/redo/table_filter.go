@@ -0,0 +1,49 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+// TableFilter decides which tables are covered by the redo log, i.e. whose
+// changes are written to the redo log. A nil TableFilter covers all tables.
+type TableFilter struct {
+	tables tfilter.Filter
+}
+
+// NewTableFilter returns nil if all tables are covered by the redo log.
+func NewTableFilter(cfg *config.ConsistentConfig, caseSensitive bool) (*TableFilter, error) {
+	if cfg == nil || len(cfg.Tables) == 0 {
+		return nil, nil
+	}
+	f, err := tfilter.Parse(cfg.Tables)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrFilterRuleInvalid, err, cfg.Tables)
+	}
+	if !caseSensitive {
+		f = tfilter.CaseInsensitive(f)
+	}
+	return &TableFilter{tables: f}, nil
+}
+
+// Covers returns true if the changes of the table are written to the redo log.
+func (f *TableFilter) Covers(schema, table string) bool {
+	if f == nil {
+		return true
+	}
+	return f.tables.MatchTable(schema, table)
+}
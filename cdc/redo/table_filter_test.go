@@ -0,0 +1,49 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableFilter(t *testing.T) {
+	t.Parallel()
+
+	f, err := NewTableFilter(nil, false)
+	require.NoError(t, err)
+	require.Nil(t, f)
+	require.True(t, f.Covers("test", "t1"))
+
+	f, err = NewTableFilter(&config.ConsistentConfig{}, false)
+	require.NoError(t, err)
+	require.Nil(t, f)
+
+	cfg := &config.ConsistentConfig{Tables: []string{"test.t*", "!test.t2"}}
+	f, err = NewTableFilter(cfg, false)
+	require.NoError(t, err)
+	require.True(t, f.Covers("test", "t1"))
+	require.True(t, f.Covers("TEST", "T1"))
+	require.False(t, f.Covers("test", "t2"))
+	require.False(t, f.Covers("test", "a1"))
+
+	f, err = NewTableFilter(cfg, true)
+	require.NoError(t, err)
+	require.False(t, f.Covers("TEST", "T1"))
+
+	_, err = NewTableFilter(&config.ConsistentConfig{Tables: []string{"[test.t1"}}, false)
+	require.Error(t, err)
+}
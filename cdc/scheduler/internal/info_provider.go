@@ -26,4 +26,12 @@ type InfoProvider interface {
 
 	// GetTaskStatuses returns the task statuses.
 	GetTaskStatuses() (map[model.CaptureID]*model.TaskStatus, error)
+
+	// GetCaptureLoads returns the loads reported by captures, which only
+	// contain the load of the changefeed of the scheduler.
+	GetCaptureLoads() map[model.CaptureID]*model.CaptureLoad
+
+	// DumpSnapshot returns a redacted snapshot of the internal states in
+	// JSON, it can be replayed offline to reproduce scheduling bugs.
+	DumpSnapshot() ([]byte, error)
 }
@@ -53,6 +53,11 @@ type Scheduler interface {
 	// It is thread-safe.
 	MoveTable(tableID model.TableID, target model.CaptureID)
 
+	// ResetTable requests that replication of a table be recreated from
+	// startTs, states of the table in processors are dropped.
+	// It is thread-safe.
+	ResetTable(tableID model.TableID, startTs model.Ts)
+
 	// Rebalance triggers a rebalance operation.
 	// It is thread-safe
 	Rebalance()
@@ -66,6 +71,57 @@ type Scheduler interface {
 	Close(ctx context.Context)
 }
 
+// TableNameUpdater is implemented by schedulers that match tables by their
+// names, e.g., to respect the table affinity rules of the changefeed.
+type TableNameUpdater interface {
+	// UpdateTableNames updates names of the tables that should be replicated.
+	// It is thread-safe.
+	UpdateTableNames(names map[model.TableID]model.TableName)
+}
+
+// TableResumer is implemented by schedulers that can resume replication of
+// paused tables from the ts they are paused at.
+type TableResumer interface {
+	// ResumeTable requests that a table be added from startTs, which may be
+	// smaller than the checkpoint ts of the changefeed. The table is removed
+	// first if it's being replicated.
+	// It is thread-safe.
+	ResumeTable(tableID model.TableID, startTs model.Ts)
+}
+
+// CaptureDrainController is implemented by schedulers that can cancel and
+// force draining captures, e.g., to orchestrate rolling restarts.
+type CaptureDrainController interface {
+	// CancelDrainCapture stops draining the target capture, it returns false
+	// if the target capture is not being drained.
+	// It is thread-safe.
+	CancelDrainCapture(target model.CaptureID) bool
+
+	// ForceDrainCapture moves tables out of the target capture without
+	// waiting for other tables to be replicating, it returns the count of
+	// tables situated at the target capture.
+	// It is thread-safe.
+	ForceDrainCapture(target model.CaptureID) (int, error)
+}
+
+// Planner is implemented by schedulers that can preview schedule operations.
+type Planner interface {
+	// Plan returns the operations that the scheduler would perform in the
+	// next schedule, without performing them. If rebalance is true, a manual
+	// rebalance is planned as if it's requested.
+	// It is thread-safe.
+	Plan(rebalance bool) ([]model.ScheduleOperation, error)
+}
+
+// TaskHistoryProvider is implemented by schedulers that record the schedule
+// tasks they perform.
+type TaskHistoryProvider interface {
+	// TaskHistory returns records of recently done schedule tasks in the
+	// order they are done, followed by records of running tasks.
+	// It is thread-safe.
+	TaskHistory() []model.ScheduleTaskRecord
+}
+
 // Query is for scheduler related owner job.
 // at the moment, only for `DrainCapture`, we can use this to handle all manual schedule task.
 // TODO: refactor `MoveTable` use Query to access the scheduler
@@ -44,12 +44,18 @@ type agent struct {
 	tableM *tableSpanManager
 
 	ownerInfo ownerInfo
+	// recvWindow tracks sequence numbers of dispatch table requests sent by
+	// the owner.
+	recvWindow transport.RecvWindow
 
 	// Liveness of the capture.
 	// It changes to LivenessCaptureStopping in following cases:
 	// 1. The capture receives a SIGTERM signal.
 	// 2. The agent receives a stopping heartbeat.
 	liveness *model.Liveness
+
+	// load collects the load reported in heartbeat responses.
+	load loadCollector
 }
 
 type agentInfo struct {
@@ -253,6 +259,7 @@ func (a *agent) handleMessage(msg []*schedulepb.Message) (
 
 		switch message.GetMsgType() {
 		case schedulepb.MsgHeartbeat:
+			a.recvWindow.Observe(header.GetSeq())
 			var reMsg *schedulepb.Message
 			reMsg, barrier, err = a.handleMessageHeartbeat(message.GetHeartbeat())
 			if err != nil {
@@ -260,6 +267,16 @@ func (a *agent) handleMessage(msg []*schedulepb.Message) (
 			}
 			result = append(result, reMsg)
 		case schedulepb.MsgDispatchTableRequest:
+			if !a.recvWindow.Receive(header.GetSeq()) {
+				log.Info("schedulerv3: agent drop dispatch table request "+
+					"out of order, wait for the owner to resend",
+					zap.String("capture", a.CaptureID),
+					zap.String("namespace", a.ChangeFeedID.Namespace),
+					zap.String("changefeed", a.ChangeFeedID.ID),
+					zap.Uint64("seq", header.GetSeq()),
+					zap.Any("request", message.DispatchTableRequest))
+				continue
+			}
 			a.handleMessageDispatchTableRequest(message.DispatchTableRequest, processorEpoch)
 		default:
 			log.Warn("schedulerv3: unknown message received",
@@ -308,6 +325,9 @@ func (a *agent) handleMessageHeartbeat(request *schedulepb.Heartbeat) (
 		Tables:   result,
 		Liveness: a.liveness.Load(),
 	}
+	if request.CollectStats {
+		response.Load = a.load.collect(a.ChangeFeedID)
+	}
 
 	message := &schedulepb.Message{
 		MsgType:           schedulepb.MsgHeartbeatResponse,
@@ -439,6 +459,7 @@ func (a *agent) handleOwnerInfo(id model.CaptureID, revision int64, version stri
 		a.ownerInfo.Revision.Revision = revision
 
 		a.resetEpoch()
+		a.recvWindow.Reset()
 
 		captureInfo := a.ownerInfo.CaptureInfo
 		a.compat.UpdateCaptureInfo(map[model.CaptureID]*model.CaptureInfo{
@@ -511,6 +532,7 @@ func (a *agent) sendMsgs(ctx context.Context, msgs []*schedulepb.Message) error
 				Epoch: a.changefeedEpoch,
 			},
 		}
+		a.recvWindow.FillHeader(m.Header)
 		m.From = a.CaptureID
 		m.To = a.ownerInfo.ID
 	}
@@ -633,6 +633,75 @@ func TestAgentHandleMessage(t *testing.T) {
 	require.Len(t, response, 0)
 }
 
+func TestAgentHandleMessageInOrder(t *testing.T) {
+	t.Parallel()
+
+	mockTableExecutor := newMockTableExecutor()
+	tableM := newTableSpanManager(model.ChangeFeedID{}, mockTableExecutor)
+	a := newAgent4Test()
+	a.tableM = tableM
+	trans := transport.NewMockTrans()
+	a.trans = trans
+
+	addTableRequest := func(tableID model.TableID, seq uint64) *schedulepb.Message {
+		return &schedulepb.Message{
+			Header: &schedulepb.Message_Header{
+				Version:        a.ownerInfo.Version,
+				OwnerRevision:  a.ownerInfo.Revision,
+				ProcessorEpoch: a.Epoch,
+				Seq:            seq,
+			},
+			MsgType: schedulepb.MsgDispatchTableRequest,
+			From:    a.ownerInfo.ID,
+			DispatchTableRequest: &schedulepb.DispatchTableRequest{
+				Request: &schedulepb.DispatchTableRequest_AddTable{
+					AddTable: &schedulepb.AddTableRequest{
+						Span:        spanz.TableIDToComparableSpan(tableID),
+						IsSecondary: true,
+					},
+				},
+			},
+		}
+	}
+
+	// The 2nd request is lost, the 3rd one is dropped.
+	_, _, _ = a.handleMessage([]*schedulepb.Message{
+		addTableRequest(1, 1), addTableRequest(3, 3),
+	})
+	require.True(t, tableM.tables.Has(spanz.TableIDToComparableSpan(1)))
+	require.False(t, tableM.tables.Has(spanz.TableIDToComparableSpan(3)))
+
+	heartbeat := &schedulepb.Message{
+		Header: &schedulepb.Message_Header{
+			Version:       a.ownerInfo.Version,
+			OwnerRevision: a.ownerInfo.Revision,
+			Seq:           3,
+		},
+		MsgType:   schedulepb.MsgHeartbeat,
+		From:      a.ownerInfo.ID,
+		Heartbeat: &schedulepb.Heartbeat{},
+	}
+	response, _, _ := a.handleMessage([]*schedulepb.Message{heartbeat})
+	require.Len(t, response, 1)
+	require.NoError(t, a.sendMsgs(context.Background(), response))
+	require.EqualValues(t, 3, trans.SendBuffer[0].Header.Seq)
+	require.EqualValues(t, 1, trans.SendBuffer[0].Header.Ack)
+
+	// The owner re-sends lost requests, duplicate requests are dropped.
+	_, _, _ = a.handleMessage([]*schedulepb.Message{
+		addTableRequest(1, 1), addTableRequest(2, 2), addTableRequest(3, 3),
+	})
+	require.True(t, tableM.tables.Has(spanz.TableIDToComparableSpan(2)))
+	require.True(t, tableM.tables.Has(spanz.TableIDToComparableSpan(3)))
+	header := &schedulepb.Message_Header{}
+	a.recvWindow.FillHeader(header)
+	require.EqualValues(t, 3, header.Ack)
+
+	// A new owner resets sequence numbers.
+	a.handleOwnerInfo("owner-2", a.ownerInfo.Revision.Revision+1, "owner-version-2")
+	require.Equal(t, transport.RecvWindow{}, a.recvWindow)
+}
+
 func TestAgentUpdateOwnerInfo(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,126 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
+	tablesinkmetrics "github.com/pingcap/tiflow/cdc/sink/metrics/tablesink"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/shirou/gopsutil/v3/process"
+	"go.uber.org/zap"
+)
+
+// processLoadTTL is how long the load of the capture process is cached, as
+// it's collected by agents of all changefeeds.
+const processLoadTTL = time.Second
+
+// processLoad is the cached load of the capture process.
+var processLoad struct {
+	sync.Mutex
+	proc        *process.Process
+	lastUpdate  time.Time
+	cpuUsage    float64
+	memoryBytes uint64
+}
+
+// getProcessLoad returns the CPU usage since the last call and the resident
+// memory of the capture process.
+func getProcessLoad() (cpuUsage float64, memoryBytes uint64) {
+	processLoad.Lock()
+	defer processLoad.Unlock()
+
+	if time.Since(processLoad.lastUpdate) < processLoadTTL {
+		return processLoad.cpuUsage, processLoad.memoryBytes
+	}
+	processLoad.lastUpdate = time.Now()
+	if processLoad.proc == nil {
+		proc, err := process.NewProcess(int32(os.Getpid()))
+		if err != nil {
+			log.Warn("schedulerv3: fail to get the capture process", zap.Error(err))
+			return 0, 0
+		}
+		processLoad.proc = proc
+	}
+	if cpuUsage, err := processLoad.proc.Percent(0); err == nil {
+		processLoad.cpuUsage = cpuUsage
+	}
+	if mem, err := processLoad.proc.MemoryInfo(); err == nil {
+		processLoad.memoryBytes = mem.RSS
+	}
+	return processLoad.cpuUsage, processLoad.memoryBytes
+}
+
+// loadCollector collects the load of the capture and the changefeed on it,
+// which is reported in heartbeat responses. The zero value is ready to use.
+type loadCollector struct {
+	lastSinkRows float64
+	lastCollect  time.Time
+}
+
+func (l *loadCollector) collect(changefeedID model.ChangeFeedID) *schedulepb.CaptureLoad {
+	load := &schedulepb.CaptureLoad{
+		SorterDiskBytes: uint64(sumMetrics(engine.OnDiskDataSize(), nil)),
+	}
+	load.CPUUsage, load.MemoryBytes = getProcessLoad()
+
+	now := time.Now()
+	sinkRows := sumMetrics(tablesinkmetrics.TotalRowsCountCounter, map[string]string{
+		"namespace": changefeedID.Namespace, "changefeed": changefeedID.ID,
+	})
+	if !l.lastCollect.IsZero() && sinkRows >= l.lastSinkRows {
+		if elapsed := now.Sub(l.lastCollect).Seconds(); elapsed > 0 {
+			load.SinkRowsPerSecond = (sinkRows - l.lastSinkRows) / elapsed
+		}
+	}
+	l.lastSinkRows = sinkRows
+	l.lastCollect = now
+	return load
+}
+
+// sumMetrics returns the sum of values of gauges and counters in the
+// collector whose labels match the given labels.
+func sumMetrics(c prometheus.Collector, labels map[string]string) float64 {
+	ch := make(chan prometheus.Metric, 16)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	var sum float64
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			continue
+		}
+		matched := 0
+		for _, label := range metric.GetLabel() {
+			if v, ok := labels[label.GetName()]; ok && v == label.GetValue() {
+				matched++
+			}
+		}
+		if matched != len(labels) {
+			continue
+		}
+		sum += metric.GetGauge().GetValue() + metric.GetCounter().GetValue()
+	}
+	return sum
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
+	tablesinkmetrics "github.com/pingcap/tiflow/cdc/sink/metrics/tablesink"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSumMetrics(t *testing.T) {
+	t.Parallel()
+
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test"},
+		[]string{"namespace", "changefeed"})
+	vec.WithLabelValues("default", "a").Set(1)
+	vec.WithLabelValues("default", "b").Set(2)
+	vec.WithLabelValues("test", "a").Set(4)
+
+	require.Equal(t, float64(7), sumMetrics(vec, nil))
+	require.Equal(t, float64(3), sumMetrics(vec, map[string]string{"namespace": "default"}))
+	require.Equal(t, float64(4), sumMetrics(vec, map[string]string{
+		"namespace": "test", "changefeed": "a",
+	}))
+	require.Equal(t, float64(0), sumMetrics(vec, map[string]string{"namespace": "unknown"}))
+}
+
+func TestAgentReportLoad(t *testing.T) {
+	t.Parallel()
+
+	a := newAgent4Test()
+	a.ChangeFeedID = model.ChangeFeedID{Namespace: "default", ID: t.Name()}
+	a.tableM = newTableSpanManager(a.ChangeFeedID, newMockTableExecutor())
+	rows := tablesinkmetrics.TotalRowsCountCounter.
+		WithLabelValues(a.ChangeFeedID.Namespace, a.ChangeFeedID.ID)
+	defer tablesinkmetrics.TotalRowsCountCounter.
+		DeleteLabelValues(a.ChangeFeedID.Namespace, a.ChangeFeedID.ID)
+
+	// The load is only reported if stats are collected.
+	msg, _, err := a.handleMessageHeartbeat(&schedulepb.Heartbeat{})
+	require.Nil(t, err)
+	require.Nil(t, msg.HeartbeatResponse.Load)
+
+	msg, _, err = a.handleMessageHeartbeat(&schedulepb.Heartbeat{CollectStats: true})
+	require.Nil(t, err)
+	load := msg.HeartbeatResponse.Load
+	require.NotNil(t, load)
+	require.Greater(t, load.MemoryBytes, uint64(0))
+	// The throughput is unknown in the first report.
+	require.Equal(t, float64(0), load.SinkRowsPerSecond)
+
+	rows.Add(1000)
+	time.Sleep(10 * time.Millisecond)
+	msg, _, err = a.handleMessageHeartbeat(&schedulepb.Heartbeat{CollectStats: true})
+	require.Nil(t, err)
+	require.Greater(t, msg.HeartbeatResponse.Load.SinkRowsPerSecond, float64(0))
+}
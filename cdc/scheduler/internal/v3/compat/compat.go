@@ -28,6 +28,9 @@ var (
 	SpanReplicationMinVersion = semver.New("6.6.0-alpha")
 	// ChangefeedEpochMinVersion is the min version that enables changefeed epoch.
 	ChangefeedEpochMinVersion = semver.New("6.7.0-alpha")
+	// MessageAckMinVersion is the min version that acknowledges sequence
+	// numbers of dispatch table requests.
+	MessageAckMinVersion = semver.New("7.4.0-alpha")
 )
 
 // Compat is a compatibility layer between span replication and table replication.
@@ -38,6 +41,7 @@ type Compat struct {
 	spanReplicationHasChecked bool
 	spanReplicationEnabled    bool
 	changefeedEpoch           map[model.CaptureID]bool
+	messageAck                map[model.CaptureID]bool
 }
 
 // New returns a new Compat.
@@ -49,6 +53,7 @@ func New(
 		config:          config.ChangefeedSettings,
 		captureInfo:     captureInfo,
 		changefeedEpoch: make(map[string]bool),
+		messageAck:      make(map[string]bool),
 	}
 }
 
@@ -61,6 +66,7 @@ func (c *Compat) UpdateCaptureInfo(
 		c.captureInfo = aliveCaptures
 		c.spanReplicationHasChecked = false
 		c.changefeedEpoch = make(map[string]bool, len(aliveCaptures))
+		c.messageAck = make(map[string]bool, len(aliveCaptures))
 		return true
 	}
 	for id, alive := range aliveCaptures {
@@ -69,6 +75,7 @@ func (c *Compat) UpdateCaptureInfo(
 			c.captureInfo = aliveCaptures
 			c.spanReplicationHasChecked = false
 			c.changefeedEpoch = make(map[string]bool, len(aliveCaptures))
+			c.messageAck = make(map[string]bool, len(aliveCaptures))
 			return true
 		}
 	}
@@ -119,6 +126,28 @@ func (c *Compat) CheckChangefeedEpochEnabled(captureID model.CaptureID) bool {
 	return isEnabled
 }
 
+// CheckMessageAckEnabled check if the capture acknowledges sequence numbers
+// of dispatch table requests.
+func (c *Compat) CheckMessageAckEnabled(captureID model.CaptureID) bool {
+	isEnabled, ok := c.messageAck[captureID]
+	if ok {
+		return isEnabled
+	}
+
+	captureInfo, ok := c.captureInfo[captureID]
+	if !ok {
+		return false
+	}
+	if len(captureInfo.Version) != 0 {
+		captureVer := semver.New(version.SanitizeVersion(captureInfo.Version))
+		isEnabled = captureVer.Compare(*MessageAckMinVersion) >= 0
+	} else {
+		isEnabled = false
+	}
+	c.messageAck[captureID] = isEnabled
+	return isEnabled
+}
+
 // BeforeTransportSend modifies messages in place before sending messages,
 // makes messages compatible with other end.
 func (c *Compat) BeforeTransportSend(msgs []*schedulepb.Message) {
@@ -327,3 +327,36 @@ func TestCheckChangefeedEpochEnabled(t *testing.T) {
 	require.False(t, c.CheckChangefeedEpochEnabled("b"))
 	require.False(t, c.CheckChangefeedEpochEnabled("c"))
 }
+
+func TestCheckMessageAckEnabled(t *testing.T) {
+	t.Parallel()
+
+	c := New(&config.SchedulerConfig{
+		ChangefeedSettings: &config.ChangefeedSchedulerConfig{
+			EnableTableAcrossNodes: true,
+			RegionThreshold:        1,
+		},
+	}, map[string]*model.CaptureInfo{})
+
+	// Unknown capture always return false
+	require.False(t, c.CheckMessageAckEnabled("unknown"))
+
+	unsupported := *MessageAckMinVersion
+	unsupported.Minor--
+	require.True(t, c.UpdateCaptureInfo(map[string]*model.CaptureInfo{
+		"a": {Version: MessageAckMinVersion.String()},
+		"b": {Version: unsupported.String()},
+		"c": {},
+	}))
+	require.True(t, c.CheckMessageAckEnabled("a"))
+	require.False(t, c.CheckMessageAckEnabled("b"))
+	require.False(t, c.CheckMessageAckEnabled("c"))
+
+	// Upgrade capture b.
+	require.True(t, c.UpdateCaptureInfo(map[string]*model.CaptureInfo{
+		"a": {Version: MessageAckMinVersion.String()},
+		"b": {Version: MessageAckMinVersion.String()},
+		"c": {},
+	}))
+	require.True(t, c.CheckMessageAckEnabled("b"))
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compat
+
+import (
+	"sort"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// CaptureFeatures is the features enabled for a capture.
+type CaptureFeatures struct {
+	ID              model.CaptureID `json:"id"`
+	Addr            string          `json:"addr"`
+	Version         string          `json:"version"`
+	ChangefeedEpoch bool            `json:"changefeed_epoch"`
+	MessageAck      bool            `json:"message_ack"`
+}
+
+// Snapshot is the compatibility matrix of alive captures.
+type Snapshot struct {
+	SpanReplication bool              `json:"span_replication"`
+	Captures        []CaptureFeatures `json:"captures"`
+}
+
+// Snapshot returns the compatibility matrix of alive captures.
+// Captures are sorted by ID.
+func (c *Compat) Snapshot() *Snapshot {
+	s := &Snapshot{
+		SpanReplication: c.CheckSpanReplicationEnabled(),
+		Captures:        make([]CaptureFeatures, 0, len(c.captureInfo)),
+	}
+	for id, info := range c.captureInfo {
+		s.Captures = append(s.Captures, CaptureFeatures{
+			ID:              id,
+			Addr:            info.AdvertiseAddr,
+			Version:         info.Version,
+			ChangefeedEpoch: c.CheckChangefeedEpochEnabled(id),
+			MessageAck:      c.CheckMessageAckEnabled(id),
+		})
+	}
+	sort.Slice(s.Captures, func(i, j int) bool {
+		return s.Captures[i].ID < s.Captures[j].ID
+	})
+	return s
+}
+
+// CaptureInfos returns the capture infos of the captures in the snapshot.
+func (s *Snapshot) CaptureInfos() map[model.CaptureID]*model.CaptureInfo {
+	infos := make(map[model.CaptureID]*model.CaptureInfo, len(s.Captures))
+	for _, capture := range s.Captures {
+		infos[capture.ID] = &model.CaptureInfo{
+			ID:            capture.ID,
+			AdvertiseAddr: capture.Addr,
+			Version:       capture.Version,
+		}
+	}
+	return infos
+}
@@ -48,9 +48,14 @@ const (
 	tickLogsWarnDuration    = 1 * time.Second
 	checkpointCannotProceed = internal.CheckpointCannotProceed
 	metricsInterval         = 10 * time.Second
+	checkInvariantsInterval = 10 * time.Second
 )
 
 var _ internal.Scheduler = (*coordinator)(nil)
+var _ internal.TableNameUpdater = (*coordinator)(nil)
+var _ internal.TableResumer = (*coordinator)(nil)
+
+var _ internal.CaptureDrainController = (*coordinator)(nil)
 
 type coordinator struct {
 	// A mutex for concurrent access of coordinator in
@@ -70,9 +75,21 @@ type coordinator struct {
 	pdClock         pdutil.Clock
 	tableRanges     replication.TableRanges
 	redoMetaManager redo.MetaManager
-
-	lastCollectTime time.Time
-	changefeedID    model.ChangeFeedID
+	sendWindow      *transport.SendWindow
+
+	cfg                     *config.SchedulerConfig
+	lastCollectTime         time.Time
+	lastCheckInvariantsTime time.Time
+	changefeedID            model.ChangeFeedID
+
+	// Inputs of the last poll, they are recorded in snapshots.
+	lastCheckpointTs model.Ts
+	lastBarrier      *schedulepb.BarrierWithMinTs
+	// lastSpans are the spans reconciled in the last schedule, they're
+	// planned by Plan. It's nil if no schedule has been performed.
+	lastSpans []tablepb.Span
+	// history records recent schedule rounds and schedule tasks.
+	history *scheduleHistory
 }
 
 // NewCoordinator returns a two phase scheduler.
@@ -93,11 +110,17 @@ func NewCoordinator(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	coord := newCoordinator(captureID, changefeedID, ownerRevision, cfg, redoMetaManager)
+	policy, err := scheduler.NewPolicy(changefeedID, cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	coord := newCoordinator(
+		captureID, changefeedID, ownerRevision, cfg, redoMetaManager, policy)
 	coord.trans = trans
 	coord.pdClock = up.PDClock
 	coord.changefeedEpoch = changefeedEpoch
-	coord.reconciler, err = keyspan.NewReconciler(changefeedID, up, cfg.ChangefeedSettings)
+	coord.reconciler, err = keyspan.NewReconciler(
+		changefeedID, up, cfg.ChangefeedSettings, cfg.KeyspacePrefix)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -110,6 +133,7 @@ func newCoordinator(
 	ownerRevision int64,
 	cfg *config.SchedulerConfig,
 	redoMetaManager redo.MetaManager,
+	policy *scheduler.Policy,
 ) *coordinator {
 	revision := schedulepb.OwnerRevision{Revision: ownerRevision}
 
@@ -120,10 +144,13 @@ func newCoordinator(
 		replicationM: replication.NewReplicationManager(
 			cfg.MaxTaskConcurrency, changefeedID),
 		captureM:        member.NewCaptureManager(captureID, changefeedID, revision, cfg),
-		schedulerM:      scheduler.NewSchedulerManager(changefeedID, cfg),
+		schedulerM:      scheduler.NewSchedulerManager(changefeedID, cfg, policy),
 		changefeedID:    changefeedID,
 		compat:          compat.New(cfg, map[model.CaptureID]*model.CaptureInfo{}),
 		redoMetaManager: redoMetaManager,
+		sendWindow:      transport.NewSendWindow(changefeedID),
+		cfg:             cfg,
+		history:         newScheduleHistory(cfg.ScheduleHistorySize),
 	}
 }
 
@@ -174,6 +201,32 @@ func (c *coordinator) MoveTable(tableID model.TableID, target model.CaptureID) {
 	c.schedulerM.MoveTable(span, target)
 }
 
+// UpdateTableNames implements the internal.TableNameUpdater interface.
+func (c *coordinator) UpdateTableNames(names map[model.TableID]model.TableName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.schedulerM.UpdateTableNames(names)
+}
+
+// ResetTable implement the scheduler interface
+func (c *coordinator) ResetTable(tableID model.TableID, startTs model.Ts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	span := spanz.TableIDToComparableSpan(tableID)
+	c.schedulerM.ResetTable(span, startTs)
+}
+
+// ResumeTable implements the internal.TableResumer interface.
+func (c *coordinator) ResumeTable(tableID model.TableID, startTs model.Ts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	span := spanz.TableIDToComparableSpan(tableID)
+	c.schedulerM.ResumeTable(span, startTs)
+}
+
 // Rebalance implement the scheduler interface
 func (c *coordinator) Rebalance() {
 	c.mu.Lock()
@@ -260,6 +313,51 @@ func (c *coordinator) DrainCapture(target model.CaptureID) (int, error) {
 	return count, nil
 }
 
+// CancelDrainCapture implements the internal.CaptureDrainController interface.
+func (c *coordinator) CancelDrainCapture(target model.CaptureID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.schedulerM.CancelDrainCapture(target) {
+		return false
+	}
+	log.Info("schedulerv3: drain capture canceled",
+		zap.String("namespace", c.changefeedID.Namespace),
+		zap.String("changefeed", c.changefeedID.ID),
+		zap.String("target", target))
+	return true
+}
+
+// ForceDrainCapture implements the internal.CaptureDrainController interface.
+func (c *coordinator) ForceDrainCapture(target model.CaptureID) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.captureM.CheckAllCaptureInitialized() {
+		log.Info("schedulerv3: force drain capture request ignored, "+
+			"since not all captures initialized",
+			zap.String("target", target),
+			zap.String("namespace", c.changefeedID.Namespace),
+			zap.String("changefeed", c.changefeedID.ID))
+		return 0, cerror.ErrSchedulerRequestFailed.
+			GenWithStack("not all captures initialized")
+	}
+
+	// Same as draining, the owner is never drained.
+	if target == c.captureID {
+		log.Warn("schedulerv3: force drain capture request ignored, "+
+			"the target is the owner",
+			zap.String("namespace", c.changefeedID.Namespace),
+			zap.String("changefeed", c.changefeedID.ID),
+			zap.String("target", target))
+		return 0, cerror.ErrSchedulerRequestFailed.
+			GenWithStack("cannot drain the owner")
+	}
+
+	return c.schedulerM.ForceDrainCapture(
+		target, c.captureM.Captures, c.replicationM.ReplicationSets()), nil
+}
+
 func (c *coordinator) Close(ctx context.Context) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -284,6 +382,7 @@ func (c *coordinator) poll(
 	aliveCaptures map[model.CaptureID]*model.CaptureInfo,
 	barrier *schedulepb.BarrierWithMinTs,
 ) (newCheckpointTs, newResolvedTs model.Ts, err error) {
+	c.lastCheckpointTs, c.lastBarrier = checkpointTs, barrier
 	c.maybeCollectMetrics()
 	if c.compat.UpdateCaptureInfo(aliveCaptures) {
 		log.Info("schedulerv3: compat update capture info",
@@ -296,6 +395,10 @@ func (c *coordinator) poll(
 		return checkpointCannotProceed, checkpointCannotProceed, errors.Trace(err)
 	}
 
+	if err := c.resendLostMsgs(ctx, recvMsgs); err != nil {
+		return checkpointCannotProceed, checkpointCannotProceed, errors.Trace(err)
+	}
+
 	var msgBuf []*schedulepb.Message
 	c.captureM.HandleMessage(recvMsgs)
 
@@ -333,6 +436,9 @@ func (c *coordinator) poll(
 
 	// Handle capture membership changes.
 	if changes := c.captureM.TakeChanges(); changes != nil {
+		for captureID := range changes.Removed {
+			c.sendWindow.RemoveCapture(captureID)
+		}
 		msgs, err = c.replicationM.HandleCaptureChanges(
 			changes.Init, changes.Removed, checkpointTs)
 		if err != nil {
@@ -346,8 +452,16 @@ func (c *coordinator) poll(
 	runningTasks := c.replicationM.RunningTasks()
 	currentSpans := c.reconciler.Reconcile(
 		ctx, &c.tableRanges, replications, c.captureM.Captures, c.compat)
+	c.lastSpans = currentSpans
+	if c.lastSpans == nil {
+		c.lastSpans = []tablepb.Span{}
+	}
+	c.schedulerM.UpdateSpanZones(c.reconciler.SpanZones())
+	c.history.begin(c.schedulerM)
 	allTasks := c.schedulerM.Schedule(
 		checkpointTs, currentSpans, c.captureM.Captures, replications, runningTasks)
+	c.history.end(c.revision.Revision, checkpointTs, currentSpans,
+		c.captureM, c.replicationM, allTasks)
 
 	// Handle generated schedule tasks.
 	msgs, err = c.replicationM.HandleTasks(allTasks)
@@ -355,6 +469,14 @@ func (c *coordinator) poll(
 		return checkpointCannotProceed, checkpointCannotProceed, errors.Trace(err)
 	}
 	msgBuf = append(msgBuf, msgs...)
+	c.history.addTasks(c.replicationM.TakeDoneTasks())
+
+	if c.maybeCheckInvariants() {
+		// Replication states are being rebuilt from heartbeat reports,
+		// the checkpoint can't proceed until all captures are initialized.
+		// Messages generated from the stale states are dropped.
+		return checkpointCannotProceed, checkpointCannotProceed, nil
+	}
 
 	// Checkpoint calculation
 	newCheckpointTs, newResolvedTs = c.replicationM.AdvanceCheckpoint(&c.tableRanges, pdTime, barrier, c.redoMetaManager)
@@ -424,11 +546,83 @@ func (c *coordinator) sendMsgs(ctx context.Context, msgs []*schedulepb.Message)
 			},
 		}
 		m.From = c.captureID
+		if c.compat.CheckMessageAckEnabled(m.To) {
+			c.sendWindow.Send(m)
+		}
 	}
 	c.compat.BeforeTransportSend(msgs)
 	return c.trans.Send(ctx, msgs)
 }
 
+// resendLostMsgs re-sends dispatch table requests that captures have not
+// received, according to acknowledgements in received messages.
+func (c *coordinator) resendLostMsgs(
+	ctx context.Context, recvMsgs []*schedulepb.Message,
+) error {
+	var lostMsgs []*schedulepb.Message
+	for _, msg := range recvMsgs {
+		lostMsgs = append(lostMsgs, c.sendWindow.Ack(msg)...)
+	}
+	if len(lostMsgs) == 0 {
+		return nil
+	}
+	log.Info("schedulerv3: resend lost dispatch table requests",
+		zap.String("namespace", c.changefeedID.Namespace),
+		zap.String("changefeed", c.changefeedID.ID),
+		zap.Int("count", len(lostMsgs)))
+	c.compat.BeforeTransportSend(lostMsgs)
+	return c.trans.Send(ctx, lostMsgs)
+}
+
+// maybeCheckInvariants checks the invariants of replication states
+// periodically. On violation, it dumps a diagnostic snapshot and resyncs the
+// replication states from heartbeat reports of all captures, the same way as
+// a new owner does. Running tasks are recorded as aborted in the schedule
+// history, and requests that are not acknowledged are dropped. It returns
+// true if it resyncs.
+func (c *coordinator) maybeCheckInvariants() bool {
+	now := time.Now()
+	if now.Sub(c.lastCheckInvariantsTime) < checkInvariantsInterval {
+		return false
+	}
+	c.lastCheckInvariantsTime = now
+
+	reported := make(map[model.CaptureID][]tablepb.TableStatus, len(c.captureM.Captures))
+	for captureID, capture := range c.captureM.Captures {
+		if capture.State != member.CaptureStateUninitialized {
+			reported[captureID] = capture.Tables
+		}
+	}
+	violations := c.replicationM.CheckInvariants(&c.tableRanges, reported)
+	if len(violations) == 0 {
+		return false
+	}
+
+	replicationSets := make([]*replication.ReplicationSet, 0, c.replicationM.ReplicationSets().Len())
+	c.replicationM.ReplicationSets().Ascend(
+		func(_ tablepb.Span, rs *replication.ReplicationSet) bool {
+			replicationSets = append(replicationSets, rs)
+			return true
+		})
+	log.Warn("schedulerv3: invariants are violated, resync replication states",
+		zap.String("namespace", c.changefeedID.Namespace),
+		zap.String("changefeed", c.changefeedID.ID),
+		zap.Any("violations", violations),
+		zap.Any("replicationSets", replicationSets),
+		zap.Any("reportedTables", reported))
+	// Running tasks are aborted, since tables are rescheduled once the states
+	// are rebuilt.
+	c.history.addTasks(c.replicationM.AbortRunningTasks())
+	c.replicationM.CleanMetrics()
+	c.captureM.CleanMetrics()
+	c.replicationM = replication.NewReplicationManager(
+		c.cfg.MaxTaskConcurrency, c.changefeedID)
+	c.captureM = member.NewCaptureManager(
+		c.captureID, c.changefeedID, c.revision, c.cfg)
+	c.sendWindow = transport.NewSendWindow(c.changefeedID)
+	return true
+}
+
 func (c *coordinator) maybeCollectMetrics() {
 	now := time.Now()
 	if now.Sub(c.lastCollectTime) < metricsInterval {
@@ -17,6 +17,7 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
@@ -202,7 +203,7 @@ func TestCoordinatorTransportCompat(t *testing.T) {
 }
 
 func newTestCoordinator(cfg *config.SchedulerConfig) (*coordinator, *transport.MockTrans) {
-	coord := newCoordinator("a", model.ChangeFeedID{}, 1, cfg, redo.NewDisabledMetaManager())
+	coord := newCoordinator("a", model.ChangeFeedID{}, 1, cfg, redo.NewDisabledMetaManager(), nil)
 	trans := transport.NewMockTrans()
 	coord.trans = trans
 	coord.reconciler = keyspan.NewReconcilerForTests(
@@ -410,12 +411,175 @@ func TestCoordinatorDrainCapture(t *testing.T) {
 	require.Equal(t, 1, count)
 
 	coord.schedulerM = scheduler.NewSchedulerManager(
-		model.ChangeFeedID{}, config.NewDefaultSchedulerConfig())
+		model.ChangeFeedID{}, config.NewDefaultSchedulerConfig(), nil)
 	count, err = coord.DrainCapture("b")
 	require.NoError(t, err)
 	require.Equal(t, 1, count)
 }
 
+func TestCoordinatorForceDrainCapture(t *testing.T) {
+	t.Parallel()
+
+	coord := coordinator{
+		version:   "6.2.0",
+		revision:  schedulepb.OwnerRevision{Revision: 3},
+		captureID: "a",
+	}
+	cfg := config.NewDefaultSchedulerConfig()
+	coord.captureM = member.NewCaptureManager("", model.ChangeFeedID{}, coord.revision, cfg)
+	coord.captureM.SetInitializedForTests(true)
+	coord.replicationM = replication.NewReplicationManager(10, model.ChangeFeedID{})
+	coord.schedulerM = scheduler.NewSchedulerManager(model.ChangeFeedID{}, cfg, nil)
+
+	coord.captureM.Captures["a"] = &member.CaptureStatus{State: member.CaptureStateUninitialized}
+	_, err := coord.ForceDrainCapture("b")
+	require.ErrorIs(t, err, cerror.ErrSchedulerRequestFailed)
+
+	for _, id := range []model.CaptureID{"a", "b", "c"} {
+		coord.captureM.Captures[id] = &member.CaptureStatus{State: member.CaptureStateInitialized}
+	}
+	_, err = coord.ForceDrainCapture("a")
+	require.ErrorIs(t, err, cerror.ErrSchedulerRequestFailed)
+
+	// Table 3 is not replicating, so that draining capture b is blocked.
+	for _, rep := range []*replication.ReplicationSet{
+		{
+			Span:     spanz.TableIDToComparableSpan(1),
+			State:    replication.ReplicationSetStateReplicating,
+			Primary:  "b",
+			Captures: map[model.CaptureID]replication.Role{"b": replication.RolePrimary},
+		},
+		{
+			Span:     spanz.TableIDToComparableSpan(2),
+			State:    replication.ReplicationSetStateReplicating,
+			Primary:  "b",
+			Captures: map[model.CaptureID]replication.Role{"b": replication.RolePrimary},
+		},
+		{
+			Span:     spanz.TableIDToComparableSpan(3),
+			State:    replication.ReplicationSetStatePrepare,
+			Primary:  "c",
+			Captures: map[model.CaptureID]replication.Role{"c": replication.RolePrimary},
+		},
+		{
+			Span:     spanz.TableIDToComparableSpan(4),
+			State:    replication.ReplicationSetStateReplicating,
+			Primary:  "c",
+			Captures: map[model.CaptureID]replication.Role{"c": replication.RolePrimary},
+		},
+	} {
+		coord.replicationM.SetReplicationSetForTests(rep)
+	}
+	currentSpans := []tablepb.Span{
+		spanz.TableIDToComparableSpan(1), spanz.TableIDToComparableSpan(2),
+		spanz.TableIDToComparableSpan(3), spanz.TableIDToComparableSpan(4),
+	}
+	count, err := coord.DrainCapture("b")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.False(t, coord.CancelDrainCapture("c"))
+	require.Equal(t, "b", coord.schedulerM.DrainingTarget())
+	tasks := coord.schedulerM.Schedule(0, currentSpans, coord.captureM.Captures,
+		coord.replicationM.ReplicationSets(), spanz.NewBtreeMap[*replication.ScheduleTask]())
+	require.Empty(t, tasks)
+
+	count, err = coord.ForceDrainCapture("b")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Empty(t, coord.schedulerM.DrainingTarget())
+	tasks = coord.schedulerM.Schedule(0, currentSpans, coord.captureM.Captures,
+		coord.replicationM.ReplicationSets(), spanz.NewBtreeMap[*replication.ScheduleTask]())
+	require.Len(t, tasks, 2)
+	// Tables are moved to the least loaded captures.
+	dests := map[model.CaptureID]int{}
+	for _, task := range tasks {
+		require.NotNil(t, task.MoveTable)
+		dests[task.MoveTable.DestCapture]++
+	}
+	require.Equal(t, map[model.CaptureID]int{"a": 2}, dests)
+
+	require.False(t, coord.CancelDrainCapture("b"))
+	_, err = coord.DrainCapture("b")
+	require.NoError(t, err)
+	require.True(t, coord.CancelDrainCapture("b"))
+	require.Empty(t, coord.schedulerM.DrainingTarget())
+}
+
+func TestCoordinatorPlan(t *testing.T) {
+	t.Parallel()
+
+	coord := coordinator{
+		version:   "6.2.0",
+		revision:  schedulepb.OwnerRevision{Revision: 3},
+		captureID: "a",
+	}
+	cfg := config.NewDefaultSchedulerConfig()
+	coord.captureM = member.NewCaptureManager("", model.ChangeFeedID{}, coord.revision, cfg)
+	coord.captureM.SetInitializedForTests(true)
+	coord.replicationM = replication.NewReplicationManager(10, model.ChangeFeedID{})
+	coord.schedulerM = scheduler.NewSchedulerManager(model.ChangeFeedID{}, cfg, nil)
+	for _, id := range []model.CaptureID{"a", "b"} {
+		coord.captureM.Captures[id] = &member.CaptureStatus{State: member.CaptureStateInitialized}
+	}
+
+	// Not scheduled yet.
+	_, err := coord.Plan(false)
+	require.ErrorIs(t, err, cerror.ErrSchedulerRequestFailed)
+
+	for i := 1; i <= 4; i++ {
+		coord.replicationM.SetReplicationSetForTests(&replication.ReplicationSet{
+			Span:     spanz.TableIDToComparableSpan(int64(i)),
+			State:    replication.ReplicationSetStateReplicating,
+			Primary:  "a",
+			Captures: map[model.CaptureID]replication.Role{"a": replication.RolePrimary},
+		})
+		coord.lastSpans = append(coord.lastSpans, spanz.TableIDToComparableSpan(int64(i)))
+	}
+	// Table 5 is added but not scheduled yet.
+	coord.lastSpans = append(coord.lastSpans, spanz.TableIDToComparableSpan(5))
+
+	// Tasks of all schedulers are planned in the priority order.
+	ops, err := coord.Plan(false)
+	require.NoError(t, err)
+	require.Len(t, ops, 3)
+	require.Equal(t, "basic-scheduler", ops[0].Scheduler)
+	require.Equal(t, model.ScheduleOperationAdd, ops[0].Type)
+	require.Equal(t, int64(5), ops[0].TableID)
+	require.Empty(t, ops[0].From)
+	for _, op := range ops[1:] {
+		require.Equal(t, "balance-scheduler", op.Scheduler)
+		require.Equal(t, model.ScheduleOperationMove, op.Type)
+		require.Equal(t, "a", op.From)
+		require.Equal(t, "b", op.To)
+	}
+
+	// Manual rebalance is premature as table 5 is not replicating.
+	ops, err = coord.Plan(true)
+	require.NoError(t, err)
+	for _, op := range ops {
+		require.NotEqual(t, "rebalance-scheduler", op.Scheduler)
+	}
+
+	coord.lastSpans = coord.lastSpans[:4]
+	ops, err = coord.Plan(true)
+	require.NoError(t, err)
+	moved := 0
+	for _, op := range ops {
+		if op.Scheduler != "rebalance-scheduler" {
+			continue
+		}
+		require.Equal(t, model.ScheduleOperationMove, op.Type)
+		require.Equal(t, "a", op.From)
+		moved++
+	}
+	require.NotZero(t, moved)
+
+	// Planning does not change the scheduler manager.
+	snapshot := coord.schedulerM.Snapshot()
+	require.False(t, snapshot.Rebalance)
+	require.Empty(t, snapshot.MoveTables)
+}
+
 func TestCoordinatorAdvanceCheckpoint(t *testing.T) {
 	t.Parallel()
 
@@ -581,3 +745,205 @@ func TestCoordinatorDropMsgIfChangefeedEpochMismatch(t *testing.T) {
 	require.EqualValues(t, "1", msgs[0].From)
 	require.EqualValues(t, "3", msgs[1].From)
 }
+
+func TestCoordinatorResendLostMsgs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	coord, trans := newTestCoordinator(&config.SchedulerConfig{
+		ChangefeedSettings: config.GetDefaultReplicaConfig().Scheduler,
+	})
+	coord.captureID = "0"
+
+	unsupported := *compat.MessageAckMinVersion
+	unsupported.Minor--
+	coord.compat.UpdateCaptureInfo(map[string]*model.CaptureInfo{
+		"1": {Version: compat.MessageAckMinVersion.String()},
+		"2": {Version: unsupported.String()},
+	})
+	addTableRequest := func(to model.CaptureID) *schedulepb.Message {
+		return &schedulepb.Message{
+			To:      to,
+			MsgType: schedulepb.MsgDispatchTableRequest,
+			DispatchTableRequest: &schedulepb.DispatchTableRequest{
+				Request: &schedulepb.DispatchTableRequest_AddTable{
+					AddTable: &schedulepb.AddTableRequest{
+						Span: spanz.TableIDToComparableSpan(1),
+					},
+				},
+			},
+		}
+	}
+	sent := []*schedulepb.Message{
+		addTableRequest("1"), addTableRequest("1"), addTableRequest("2"),
+	}
+	require.NoError(t, coord.sendMsgs(ctx, sent))
+	require.EqualValues(t, 1, sent[0].Header.Seq)
+	require.EqualValues(t, 2, sent[1].Header.Seq)
+	require.EqualValues(t, 0, sent[2].Header.Seq)
+	trans.SendBuffer = trans.SendBuffer[:0]
+
+	// Capture "1" has seen the 2nd request but not received it.
+	heartbeatResponse := func(from model.CaptureID, seq, ack uint64) *schedulepb.Message {
+		return &schedulepb.Message{
+			Header: &schedulepb.Message_Header{
+				OwnerRevision: coord.revision,
+				Seq:           seq,
+				Ack:           ack,
+			},
+			From: from, To: coord.captureID, MsgType: schedulepb.MsgHeartbeatResponse,
+			HeartbeatResponse: &schedulepb.HeartbeatResponse{},
+		}
+	}
+	require.NoError(t, coord.resendLostMsgs(ctx, []*schedulepb.Message{
+		heartbeatResponse("1", 2, 1), heartbeatResponse("2", 0, 0),
+	}))
+	require.Equal(t, sent[1:2], trans.SendBuffer)
+	trans.SendBuffer = trans.SendBuffer[:0]
+
+	// All requests are acknowledged.
+	require.NoError(t, coord.resendLostMsgs(ctx, []*schedulepb.Message{
+		heartbeatResponse("1", 2, 2),
+	}))
+	require.Empty(t, trans.SendBuffer)
+
+	// Capture "1" resets its window, the request that is not acknowledged is
+	// renumbered and re-sent through compat.
+	sent = []*schedulepb.Message{addTableRequest("1")}
+	require.NoError(t, coord.sendMsgs(ctx, sent))
+	require.EqualValues(t, 3, sent[0].Header.Seq)
+	trans.SendBuffer = trans.SendBuffer[:0]
+	coord.compat.UpdateCaptureInfo(map[string]*model.CaptureInfo{
+		"1": {Version: compat.MessageAckMinVersion.String()},
+		"2": {Version: "6.5.0"},
+	})
+	require.False(t, coord.compat.CheckSpanReplicationEnabled())
+	require.NoError(t, coord.resendLostMsgs(ctx, []*schedulepb.Message{
+		heartbeatResponse("1", 0, 0),
+	}))
+	require.Equal(t, sent, trans.SendBuffer)
+	require.EqualValues(t, 1, sent[0].Header.Seq)
+	require.EqualValues(t, 1,
+		sent[0].DispatchTableRequest.GetAddTable().TableID)
+	trans.SendBuffer = trans.SendBuffer[:0]
+
+	// Capture "1" restarts with a new processor epoch, the request sent to
+	// the old epoch is not delivered.
+	sent = []*schedulepb.Message{addTableRequest("1")}
+	require.NoError(t, coord.sendMsgs(ctx, sent))
+	require.EqualValues(t, 2, sent[0].Header.Seq)
+	trans.SendBuffer = trans.SendBuffer[:0]
+	resp := heartbeatResponse("1", 0, 0)
+	resp.Header.ProcessorEpoch = schedulepb.ProcessorEpoch{Epoch: "restarted"}
+	require.NoError(t, coord.resendLostMsgs(ctx, []*schedulepb.Message{resp}))
+	require.Empty(t, trans.SendBuffer)
+	require.Empty(t, coord.sendWindow.Ack(resp))
+}
+
+func TestCoordinatorResyncOnInvariantViolation(t *testing.T) {
+	t.Parallel()
+
+	coord, trans := newTestCoordinator(&config.SchedulerConfig{
+		HeartbeatTick:      math.MaxInt,
+		CollectStatsTick:   math.MaxInt,
+		MaxTaskConcurrency: 1,
+		AddTableBatchSize:  50,
+		ChangefeedSettings: config.GetDefaultReplicaConfig().Scheduler,
+	})
+	ctx := context.Background()
+	currentTables := []model.TableID{1, 2}
+	aliveCaptures := map[model.CaptureID]*model.CaptureInfo{"a": {}}
+	heartbeatResponse := func(tableIDs ...model.TableID) *schedulepb.Message {
+		resp := &schedulepb.HeartbeatResponse{}
+		for _, tableID := range tableIDs {
+			resp.Tables = append(resp.Tables, tablepb.TableStatus{
+				Span:       spanz.TableIDToComparableSpan(tableID),
+				State:      tablepb.TableStateReplicating,
+				Checkpoint: tablepb.Checkpoint{CheckpointTs: 2, ResolvedTs: 4},
+			})
+		}
+		return &schedulepb.Message{
+			Header: &schedulepb.Message_Header{
+				OwnerRevision: schedulepb.OwnerRevision{Revision: 1},
+			},
+			To:                "a",
+			From:              "a",
+			MsgType:           schedulepb.MsgHeartbeatResponse,
+			HeartbeatResponse: resp,
+		}
+	}
+	_, _, err := coord.poll(ctx, 0, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(0))
+	require.Nil(t, err)
+	trans.RecvBuffer = append(trans.RecvBuffer, heartbeatResponse(1, 2))
+	_, _, err = coord.poll(ctx, 0, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(5))
+	require.Nil(t, err)
+	require.Equal(t, 2, coord.replicationM.ReplicationSets().Len())
+
+	// Capture "a" no longer reports table 2, resync after the violation
+	// persists.
+	coord.captureM.Captures["a"].Tables = coord.captureM.Captures["a"].Tables[:1]
+	_, err = coord.replicationM.HandleTasks([]*replication.ScheduleTask{{
+		AddTable: &replication.AddTable{
+			Span: spanz.TableIDToComparableSpan(3), CaptureID: "a",
+		},
+		Reason: "basic-scheduler",
+	}})
+	require.Nil(t, err)
+	for i := 0; i < 2; i++ {
+		coord.lastCheckInvariantsTime = time.Time{}
+		require.False(t, coord.maybeCheckInvariants())
+	}
+	// Nothing generated from the stale states is sent on resync, e.g. the
+	// request to commit table 3 once it's prepared.
+	trans.RecvBuffer = []*schedulepb.Message{{
+		Header: &schedulepb.Message_Header{
+			OwnerRevision: schedulepb.OwnerRevision{Revision: 1},
+		},
+		To:      "a",
+		From:    "a",
+		MsgType: schedulepb.MsgDispatchTableResponse,
+		DispatchTableResponse: &schedulepb.DispatchTableResponse{
+			Response: &schedulepb.DispatchTableResponse_AddTable{
+				AddTable: &schedulepb.AddTableResponse{
+					Status: &tablepb.TableStatus{
+						Span:  spanz.TableIDToComparableSpan(3),
+						State: tablepb.TableStatePrepared,
+					},
+				},
+			},
+		},
+	}}
+	trans.SendBuffer = nil
+	sendWindow := coord.sendWindow
+	coord.lastCheckInvariantsTime = time.Time{}
+	_, _, err = coord.poll(ctx, 0, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(5))
+	require.Nil(t, err)
+	require.Empty(t, trans.SendBuffer)
+	// Requests that are not acknowledged are dropped.
+	require.NotSame(t, sendWindow, coord.sendWindow)
+	require.Equal(t, 0, coord.replicationM.ReplicationSets().Len())
+	require.False(t, coord.captureM.CheckAllCaptureInitialized())
+	// The running task is aborted and kept in the history.
+	tasks := coord.TaskHistory()
+	require.Len(t, tasks, 1)
+	require.Equal(t, model.TableID(3), tasks[0].TableID)
+	require.Equal(t, model.ScheduleTaskAborted, tasks[0].State)
+
+	// Replication states are rebuilt from heartbeat reports.
+	trans.RecvBuffer = nil
+	_, _, err = coord.poll(ctx, 0, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(5))
+	require.Nil(t, err)
+	trans.RecvBuffer = append(trans.RecvBuffer, heartbeatResponse(1))
+	_, _, err = coord.poll(ctx, 0, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(5))
+	require.Nil(t, err)
+	require.True(t, coord.captureM.CheckAllCaptureInitialized())
+	rs, ok := coord.replicationM.ReplicationSets().Get(spanz.TableIDToComparableSpan(1))
+	require.True(t, ok)
+	require.Equal(t, "a", rs.Primary)
+	// Table 2 is added again.
+	_, _, err = coord.poll(ctx, 0, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(5))
+	require.Nil(t, err)
+	rs, ok = coord.replicationM.ReplicationSets().Get(spanz.TableIDToComparableSpan(2))
+	require.True(t, ok)
+	require.Equal(t, replication.ReplicationSetStatePrepare, rs.State)
+}
@@ -0,0 +1,322 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/scheduler"
+	"github.com/pingcap/tiflow/pkg/config"
+	"go.uber.org/zap"
+)
+
+var _ internal.TaskHistoryProvider = (*coordinator)(nil)
+
+// taskHistorySize is the number of recently done schedule tasks kept by the
+// schedule history.
+const taskHistorySize = 1024
+
+// ScheduleInputs are the inputs of a schedule round.
+type ScheduleInputs struct {
+	CheckpointTs model.Ts                `json:"checkpoint_ts"`
+	Spans        []tablepb.Span          `json:"spans"`
+	Captures     []*member.CaptureStatus `json:"captures"`
+	Replications *replication.Snapshot   `json:"replications"`
+	// Scheduler is the queued requests of schedulers before the round.
+	Scheduler *scheduler.Snapshot `json:"scheduler"`
+}
+
+// digest returns the SHA-256 digest of the inputs in JSON.
+func (i *ScheduleInputs) digest() string {
+	data, err := json.Marshal(i)
+	if err != nil {
+		// It must not happen, all fields are serializable.
+		log.Panic("schedulerv3: marshal schedule inputs failed", zap.Error(err))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ScheduledTask is a serializable schedule task.
+type ScheduledTask struct {
+	Name         string                    `json:"name"`
+	MoveTable    *replication.MoveTable    `json:"move_table,omitempty"`
+	AddTable     *replication.AddTable     `json:"add_table,omitempty"`
+	RemoveTable  *replication.RemoveTable  `json:"remove_table,omitempty"`
+	BurstBalance *replication.BurstBalance `json:"burst_balance,omitempty"`
+}
+
+func newScheduledTasks(tasks []*replication.ScheduleTask) []ScheduledTask {
+	scheduled := make([]ScheduledTask, 0, len(tasks))
+	for _, task := range tasks {
+		scheduled = append(scheduled, ScheduledTask{
+			Name:         task.Name(),
+			MoveTable:    task.MoveTable,
+			AddTable:     task.AddTable,
+			RemoveTable:  task.RemoveTable,
+			BurstBalance: task.BurstBalance,
+		})
+	}
+	return scheduled
+}
+
+// ScheduleRecord is a schedule round that emits tasks.
+type ScheduleRecord struct {
+	// Round is the sequence number of the round since the coordinator is
+	// created, rounds that emit no task are not recorded.
+	Round         uint64    `json:"round"`
+	OwnerRevision int64     `json:"owner_revision"`
+	Time          time.Time `json:"time"`
+	// Seed is the seed of random sources of schedulers in the round.
+	Seed         int64           `json:"seed"`
+	InputsDigest string          `json:"inputs_digest"`
+	Inputs       *ScheduleInputs `json:"inputs"`
+	Tasks        []ScheduledTask `json:"tasks"`
+}
+
+// spans returns all spans in the record.
+func (r *ScheduleRecord) spans() []*tablepb.Span {
+	var spans []*tablepb.Span
+	inputs := r.Inputs
+	for i := range inputs.Spans {
+		spans = append(spans, &inputs.Spans[i])
+	}
+	for _, capture := range inputs.Captures {
+		for i := range capture.Tables {
+			spans = append(spans, &capture.Tables[i].Span)
+		}
+	}
+	for _, rs := range inputs.Replications.ReplicationSets {
+		spans = append(spans, &rs.Span)
+	}
+	for i := range inputs.Replications.RunningTasks {
+		spans = append(spans, &inputs.Replications.RunningTasks[i].Span)
+	}
+	for i := range inputs.Scheduler.MoveTables {
+		spans = append(spans, &inputs.Scheduler.MoveTables[i].Span)
+	}
+	for i := range r.Tasks {
+		task := &r.Tasks[i]
+		switch {
+		case task.MoveTable != nil:
+			spans = append(spans, &task.MoveTable.Span)
+		case task.AddTable != nil:
+			spans = append(spans, &task.AddTable.Span)
+		case task.RemoveTable != nil:
+			spans = append(spans, &task.RemoveTable.Span)
+		case task.BurstBalance != nil:
+			for j := range task.BurstBalance.AddTables {
+				spans = append(spans, &task.BurstBalance.AddTables[j].Span)
+			}
+			for j := range task.BurstBalance.RemoveTables {
+				spans = append(spans, &task.BurstBalance.RemoveTables[j].Span)
+			}
+			for j := range task.BurstBalance.MoveTables {
+				spans = append(spans, &task.BurstBalance.MoveTables[j].Span)
+			}
+		}
+	}
+	return spans
+}
+
+// scheduleHistory records the most recent schedule rounds that emit tasks,
+// so that regressions of scheduling can be bisected by replaying them
+// against different code versions. It also records the most recently done
+// schedule tasks, so that users can find out why a table is moved and how
+// long it takes.
+type scheduleHistory struct {
+	// size is the number of recorded rounds, rounds are not recorded if
+	// it's 0.
+	size    int
+	round   uint64
+	random  *rand.Rand
+	records []*ScheduleRecord
+
+	// Inputs of the current round.
+	seed      int64
+	scheduler *scheduler.Snapshot
+
+	// tasks are in the order they are done.
+	tasks []model.ScheduleTaskRecord
+}
+
+func newScheduleHistory(size int) *scheduleHistory {
+	return &scheduleHistory{
+		size:    size,
+		random:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		records: make([]*ScheduleRecord, 0, size),
+	}
+}
+
+// begin starts a schedule round. It takes the queued requests of schedulers
+// and reseeds their random sources, as they affect the tasks of the round.
+func (h *scheduleHistory) begin(sm *scheduler.Manager) {
+	if h.size == 0 {
+		return
+	}
+	h.round++
+	h.seed = h.random.Int63()
+	h.scheduler = sm.Snapshot()
+	sm.Seed(h.seed)
+}
+
+// end records the round if it emits tasks.
+func (h *scheduleHistory) end(
+	revision int64,
+	checkpointTs model.Ts,
+	spans []tablepb.Span,
+	captureM *member.CaptureManager,
+	replicationM *replication.Manager,
+	tasks []*replication.ScheduleTask,
+) {
+	if h.size == 0 || len(tasks) == 0 {
+		return
+	}
+	inputs := &ScheduleInputs{
+		CheckpointTs: checkpointTs,
+		Spans:        append([]tablepb.Span(nil), spans...),
+		// Schedulers do not change captures and replication sets, they are
+		// the same as the ones before the round.
+		Captures:     captureM.Snapshot().Captures,
+		Replications: replicationM.Snapshot(),
+		Scheduler:    h.scheduler,
+	}
+	record := &ScheduleRecord{
+		Round:         h.round,
+		OwnerRevision: revision,
+		Time:          time.Now(),
+		Seed:          h.seed,
+		InputsDigest:  inputs.digest(),
+		Inputs:        inputs,
+		Tasks:         newScheduledTasks(tasks),
+	}
+	if len(h.records) == h.size {
+		copy(h.records, h.records[1:])
+		h.records = h.records[:h.size-1]
+	}
+	h.records = append(h.records, record)
+}
+
+// addTasks appends done tasks, the oldest ones are dropped if there are more
+// than taskHistorySize tasks.
+func (h *scheduleHistory) addTasks(done []model.ScheduleTaskRecord) {
+	h.tasks = append(h.tasks, done...)
+	if over := len(h.tasks) - taskHistorySize; over > 0 {
+		h.tasks = append(h.tasks[:0], h.tasks[over:]...)
+	}
+}
+
+// TaskHistory implements the internal.TaskHistoryProvider interface.
+func (c *coordinator) TaskHistory() []model.ScheduleTaskRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	running := c.replicationM.RunningTaskRecords()
+	records := make([]model.ScheduleTaskRecord, 0, len(c.history.tasks)+len(running))
+	records = append(records, c.history.tasks...)
+	return append(records, running...)
+}
+
+// clone returns a deep copy of the records.
+func (h *scheduleHistory) clone() []*ScheduleRecord {
+	data, err := json.Marshal(h.records)
+	if err != nil {
+		log.Panic("schedulerv3: marshal schedule history failed", zap.Error(err))
+	}
+	var records []*ScheduleRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		log.Panic("schedulerv3: unmarshal schedule history failed", zap.Error(err))
+	}
+	return records
+}
+
+// equalTasks compares tasks in JSON, as recorded tasks are decoded from JSON.
+func equalTasks(a, b []ScheduledTask) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
+
+// ReplayedRecord is the result of replaying a schedule record.
+type ReplayedRecord struct {
+	Round         uint64 `json:"round"`
+	OwnerRevision int64  `json:"owner_revision"`
+	// DigestMatched is false if the inputs decoded by the current code
+	// version differ from the recorded ones, the replay may not be exact.
+	DigestMatched bool            `json:"digest_matched"`
+	Matched       bool            `json:"matched"`
+	Recorded      []ScheduledTask `json:"recorded"`
+	Replayed      []ScheduledTask `json:"replayed"`
+}
+
+// ReplayHistory replays the schedule history in the snapshot. Each record is
+// replayed by a new scheduler manager restored from the record, and the
+// emitted tasks are compared with the recorded ones.
+//
+// States driven by time are not recorded, e.g., the check balance interval
+// and the adaptive batch size of adding tables, so they start over in each
+// replayed round.
+func ReplayHistory(s *Snapshot) ([]ReplayedRecord, error) {
+	cfg := *s.Config
+	cfg.ChangefeedSettings = s.ChangefeedSettings
+	if cfg.ChangefeedSettings == nil {
+		cfg.ChangefeedSettings = config.GetDefaultReplicaConfig().Scheduler
+	}
+	results := make([]ReplayedRecord, 0, len(s.History))
+	for _, record := range s.History {
+		inputs := record.Inputs
+		if inputs == nil || inputs.Replications == nil || inputs.Scheduler == nil {
+			return nil, errors.Errorf(
+				"missing inputs of schedule round %d", record.Round)
+		}
+		policy, err := scheduler.NewPolicy(s.Changefeed, &cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		schedulerM := scheduler.NewSchedulerManager(s.Changefeed, &cfg, policy)
+		schedulerM.Restore(inputs.Scheduler, record.Seed)
+		replicationM := replication.NewReplicationManager(
+			cfg.MaxTaskConcurrency, s.Changefeed)
+		replicationM.Restore(inputs.Replications)
+		captures := make(map[model.CaptureID]*member.CaptureStatus, len(inputs.Captures))
+		for _, capture := range inputs.Captures {
+			captures[capture.ID] = capture
+		}
+
+		tasks := schedulerM.Schedule(inputs.CheckpointTs, inputs.Spans, captures,
+			replicationM.ReplicationSets(), replicationM.RunningTasks())
+		replayed := newScheduledTasks(tasks)
+		results = append(results, ReplayedRecord{
+			Round:         record.Round,
+			OwnerRevision: record.OwnerRevision,
+			DigestMatched: inputs.digest() == record.InputsDigest,
+			Matched:       equalTasks(replayed, record.Tasks),
+			Recorded:      record.Tasks,
+			Replayed:      replayed,
+		})
+	}
+	return results, nil
+}
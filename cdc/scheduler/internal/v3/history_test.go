@@ -0,0 +1,177 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/scheduler"
+	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinatorScheduleHistory(t *testing.T) {
+	t.Parallel()
+
+	coord, _ := newTestCoordinator(&config.SchedulerConfig{
+		HeartbeatTick:       math.MaxInt,
+		CollectStatsTick:    math.MaxInt,
+		MaxTaskConcurrency:  10,
+		AddTableBatchSize:   50,
+		ScheduleHistorySize: 2,
+		ChangefeedSettings:  config.GetDefaultReplicaConfig().Scheduler,
+	})
+	aliveCaptures := map[model.CaptureID]*model.CaptureInfo{}
+	for _, captureID := range []model.CaptureID{"a", "b", "c"} {
+		aliveCaptures[captureID] = &model.CaptureInfo{
+			ID: captureID, AdvertiseAddr: "127.0.0.1:830" + captureID,
+		}
+		coord.captureM.Captures[captureID] = &member.CaptureStatus{
+			ID:    captureID,
+			Addr:  aliveCaptures[captureID].AdvertiseAddr,
+			State: member.CaptureStateInitialized,
+		}
+	}
+	coord.captureM.SetInitializedForTests(true)
+
+	// Tables are added to random captures.
+	ctx := context.Background()
+	currentTables := []model.TableID{1, 2, 3, 4, 5, 6}
+	_, _, err := coord.poll(ctx, 1, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(1))
+	require.Nil(t, err)
+	// Tables are being added, no task is emitted.
+	_, _, err = coord.poll(ctx, 1, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(1))
+	require.Nil(t, err)
+	require.Len(t, coord.history.records, 1)
+	record := coord.history.records[0]
+	require.EqualValues(t, 1, record.Round)
+	require.EqualValues(t, 1, record.OwnerRevision)
+	require.Len(t, record.Tasks, 1)
+	require.Equal(t, "burstBalance", record.Tasks[0].Name)
+	require.Len(t, record.Tasks[0].BurstBalance.AddTables, 6)
+
+	data, err := coord.DumpSnapshot()
+	require.Nil(t, err)
+	require.NotContains(t, string(data), "127.0.0.1")
+	s, err := LoadSnapshot(data)
+	require.Nil(t, err)
+	require.Len(t, s.History, 1)
+
+	// Replays are the same as recorded.
+	records, err := ReplayHistory(s)
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	require.True(t, records[0].DigestMatched)
+	require.True(t, records[0].Matched)
+	require.Equal(t, s.History[0].Tasks, records[0].Replayed)
+
+	// Divergences are reported.
+	s.History[0].Tasks[0].BurstBalance.AddTables[0].CaptureID = "d"
+	s.History[0].Inputs.CheckpointTs = 2
+	records, err = ReplayHistory(s)
+	require.Nil(t, err)
+	require.False(t, records[0].DigestMatched)
+	require.False(t, records[0].Matched)
+
+	s.History[0].Inputs = nil
+	_, err = ReplayHistory(s)
+	require.Regexp(t, "missing inputs", err)
+}
+
+func TestScheduleHistoryBounded(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.NewDefaultSchedulerConfig()
+	changefeedID := model.DefaultChangeFeedID("test")
+	schedulerM := scheduler.NewSchedulerManager(changefeedID, cfg, nil)
+	captureM := member.NewCaptureManager(
+		"a", changefeedID, schedulepb.OwnerRevision{Revision: 1}, cfg)
+	replicationM := replication.NewReplicationManager(10, changefeedID)
+
+	h := newScheduleHistory(2)
+	for i := 0; i < 4; i++ {
+		h.begin(schedulerM)
+		var tasks []*replication.ScheduleTask
+		if i != 1 {
+			// The second round emits no task.
+			tasks = []*replication.ScheduleTask{{
+				MoveTable: &replication.MoveTable{
+					Span: spanz.TableIDToComparableSpan(1), DestCapture: "b",
+				},
+			}}
+		}
+		h.end(1, 1, nil, captureM, replicationM, tasks)
+	}
+	require.Len(t, h.records, 2)
+	require.EqualValues(t, 3, h.records[0].Round)
+	require.EqualValues(t, 4, h.records[1].Round)
+	require.Equal(t, "moveTable", h.records[1].Tasks[0].Name)
+}
+
+func TestScheduleHistoryTasks(t *testing.T) {
+	t.Parallel()
+
+	coord := coordinator{
+		replicationM: replication.NewReplicationManager(10, model.ChangeFeedID{}),
+		history:      newScheduleHistory(0),
+	}
+	require.Empty(t, coord.TaskHistory())
+
+	record := func(id model.TableID) model.ScheduleTaskRecord {
+		return model.ScheduleTaskRecord{
+			ScheduleOperation: model.ScheduleOperation{TableID: id},
+			State:             model.ScheduleTaskFinished,
+		}
+	}
+	for i := 0; i < taskHistorySize; i++ {
+		coord.history.addTasks([]model.ScheduleTaskRecord{record(1)})
+	}
+	coord.history.addTasks(nil)
+	coord.history.addTasks([]model.ScheduleTaskRecord{record(2), record(3)})
+
+	// Running tasks follow the done ones.
+	_, err := coord.replicationM.HandleTasks([]*replication.ScheduleTask{{
+		AddTable: &replication.AddTable{
+			Span: spanz.TableIDToComparableSpan(4), CaptureID: "a",
+		},
+		Reason: "basic-scheduler",
+	}})
+	require.NoError(t, err)
+
+	records := coord.TaskHistory()
+	require.Len(t, records, taskHistorySize+1)
+	for i, id := range []model.TableID{1, 2, 3, 4} {
+		require.Equal(t, id, records[taskHistorySize-3+i].TableID)
+	}
+	require.Equal(t, model.ScheduleTaskRunning, records[taskHistorySize].State)
+	require.Equal(t, "basic-scheduler", records[taskHistorySize].Scheduler)
+
+	// Rounds are not recorded as the size is 0.
+	schedulerM := scheduler.NewSchedulerManager(
+		model.ChangeFeedID{}, config.NewDefaultSchedulerConfig(), nil)
+	coord.history.begin(schedulerM)
+	coord.history.end(1, 1, nil, nil, coord.replicationM, []*replication.ScheduleTask{{
+		MoveTable: &replication.MoveTable{
+			Span: spanz.TableIDToComparableSpan(1), DestCapture: "b",
+		},
+	}})
+	require.Empty(t, coord.history.records)
+}
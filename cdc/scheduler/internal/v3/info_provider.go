@@ -15,6 +15,7 @@ package v3
 
 import (
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal"
 )
 
@@ -48,3 +49,37 @@ func (c *coordinator) GetTaskStatuses() (map[model.CaptureID]*model.TaskStatus,
 	}
 	return tasks, nil
 }
+
+// GetCaptureLoads returns the loads reported by captures.
+func (c *coordinator) GetCaptureLoads() map[model.CaptureID]*model.CaptureLoad {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loads := make(map[model.CaptureID]*model.CaptureLoad, len(c.captureM.Captures))
+	for captureID, status := range c.captureM.Captures {
+		if status.Load == nil {
+			continue
+		}
+		tables := make(map[model.TableID]struct{})
+		for _, table := range status.Tables {
+			switch table.State {
+			case tablepb.TableStatePreparing,
+				tablepb.TableStatePrepared,
+				tablepb.TableStateReplicating:
+				tables[table.Span.TableID] = struct{}{}
+			}
+		}
+		loads[captureID] = &model.CaptureLoad{
+			CPUUsage:        status.Load.CPUUsage,
+			MemoryBytes:     status.Load.MemoryBytes,
+			SorterDiskBytes: status.Load.SorterDiskBytes,
+			Changefeeds: []model.ChangefeedLoad{{
+				Namespace:         c.changefeedID.Namespace,
+				ID:                c.changefeedID.ID,
+				TableCount:        len(tables),
+				SinkRowsPerSecond: status.Load.SinkRowsPerSecond,
+			}},
+		}
+	}
+	return loads
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler/internal"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/keyspan"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/stretchr/testify/require"
 )
@@ -35,7 +36,7 @@ func TestInfoProvider(t *testing.T) {
 		MaxTaskConcurrency: 1,
 		ChangefeedSettings: config.GetDefaultReplicaConfig().Scheduler,
 	}
-	coord := newCoordinator("a", model.ChangeFeedID{}, 1, cfg, redo.NewDisabledMetaManager())
+	coord := newCoordinator("a", model.ChangeFeedID{}, 1, cfg, redo.NewDisabledMetaManager(), nil)
 	cfg.ChangefeedSettings = config.GetDefaultReplicaConfig().Scheduler
 	coord.reconciler = keyspan.NewReconcilerForTests(
 		keyspan.NewMockRegionCache(), cfg.ChangefeedSettings)
@@ -70,7 +71,7 @@ func TestInfoProviderIsInitialized(t *testing.T) {
 		HeartbeatTick:      math.MaxInt,
 		MaxTaskConcurrency: 1,
 		ChangefeedSettings: config.GetDefaultReplicaConfig().Scheduler,
-	}, redo.NewDisabledMetaManager())
+	}, redo.NewDisabledMetaManager(), nil)
 	var ip internal.InfoProvider = coord
 
 	// Has not initialized yet.
@@ -90,3 +91,54 @@ func TestInfoProviderIsInitialized(t *testing.T) {
 	coord.captureM.SetInitializedForTests(true)
 	require.True(t, ip.IsInitialized())
 }
+
+func TestInfoProviderGetCaptureLoads(t *testing.T) {
+	t.Parallel()
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	coord := newCoordinator("a", changefeedID, 1, &config.SchedulerConfig{
+		HeartbeatTick:      math.MaxInt,
+		MaxTaskConcurrency: 1,
+		ChangefeedSettings: config.GetDefaultReplicaConfig().Scheduler,
+	}, redo.NewDisabledMetaManager(), nil)
+	var ip internal.InfoProvider = coord
+
+	coord.captureM.Captures = map[model.CaptureID]*member.CaptureStatus{
+		"a": {
+			Tables: []tablepb.TableStatus{{
+				Span:  tablepb.Span{TableID: 1, StartKey: []byte{1}},
+				State: tablepb.TableStateReplicating,
+			}, {
+				Span:  tablepb.Span{TableID: 1, StartKey: []byte{2}},
+				State: tablepb.TableStateReplicating,
+			}, {
+				Span:  tablepb.Span{TableID: 2},
+				State: tablepb.TableStatePrepared,
+			}, {
+				Span:  tablepb.Span{TableID: 3},
+				State: tablepb.TableStateStopping,
+			}},
+			Load: &schedulepb.CaptureLoad{
+				CPUUsage:          50,
+				MemoryBytes:       1024,
+				SorterDiskBytes:   2048,
+				SinkRowsPerSecond: 10,
+			},
+		},
+		// Captures which haven't reported are skipped.
+		"b": {},
+	}
+	require.Equal(t, map[model.CaptureID]*model.CaptureLoad{
+		"a": {
+			CPUUsage:        50,
+			MemoryBytes:     1024,
+			SorterDiskBytes: 2048,
+			Changefeeds: []model.ChangefeedLoad{{
+				Namespace:         changefeedID.Namespace,
+				ID:                changefeedID.ID,
+				TableCount:        2,
+				SinkRowsPerSecond: 10,
+			}},
+		},
+	}, ip.GetCaptureLoads())
+}
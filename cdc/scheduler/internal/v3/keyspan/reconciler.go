@@ -52,8 +52,13 @@ type Reconciler struct {
 
 	changefeedID model.ChangeFeedID
 	config       *config.ChangefeedSchedulerConfig
+	// keyspacePrefix is added to table spans before splitting them by
+	// regions, and trimmed from the split spans.
+	keyspacePrefix []byte
 
 	splitter []splitter
+	// zones is nil if zone-aware scheduling is disabled.
+	zones *zoneResolver
 }
 
 // NewReconciler returns a Reconciler.
@@ -61,21 +66,28 @@ func NewReconciler(
 	changefeedID model.ChangeFeedID,
 	up *upstream.Upstream,
 	config *config.ChangefeedSchedulerConfig,
+	keyspacePrefix []byte,
 ) (*Reconciler, error) {
 	pdapi, err := pdutil.NewPDAPIClient(up.PDClient, up.SecurityConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &Reconciler{
-		tableSpans:   make(map[int64]splittedSpans),
-		changefeedID: changefeedID,
-		config:       config,
+	m := &Reconciler{
+		tableSpans:     make(map[int64]splittedSpans),
+		changefeedID:   changefeedID,
+		config:         config,
+		keyspacePrefix: keyspacePrefix,
 		splitter: []splitter{
 			// write splitter has the highest priority.
 			newWriteSplitter(changefeedID, pdapi),
 			newRegionCountSplitter(changefeedID, up.RegionCache),
 		},
-	}, nil
+	}
+	if config != nil && config.ZoneLabel != "" {
+		locator := &regionCacheZoneLocator{regionCache: up.RegionCache, label: config.ZoneLabel}
+		m.zones = newZoneResolver(changefeedID, locator, keyspacePrefix)
+	}
+	return m, nil
 }
 
 // Reconcile spans that need to be replicated based on current cluster status.
@@ -120,12 +132,7 @@ func (m *Reconciler) Reconcile(
 			tableSpan := spanz.TableIDToComparableSpan(tableID)
 			spans := []tablepb.Span{tableSpan}
 			if compat.CheckSpanReplicationEnabled() {
-				for _, splitter := range m.splitter {
-					spans = splitter.split(ctx, tableSpan, len(aliveCaptures), m.config)
-					if len(spans) > 1 {
-						break
-					}
-				}
+				spans = m.splitSpan(ctx, tableSpan, len(aliveCaptures))
 			}
 			m.tableSpans[tableID] = splittedSpans{
 				byAddTable: true,
@@ -205,6 +212,40 @@ func (m *Reconciler) Reconcile(
 		for _, ss := range m.tableSpans {
 			m.spanCache = append(m.spanCache, ss.spans...)
 		}
+		if m.zones != nil {
+			m.zones.retain(m.spanCache)
+		}
+	}
+	if m.zones != nil {
+		m.zones.resolve(ctx, m.spanCache)
 	}
 	return m.spanCache
 }
+
+// SpanZones returns zones of the majority of region leaders of spans, spans
+// with unknown zones are absent. It's nil if zone-aware scheduling is disabled.
+func (m *Reconciler) SpanZones() *spanz.BtreeMap[string] {
+	if m.zones == nil {
+		return nil
+	}
+	return m.zones.zones
+}
+
+// splitSpan splits a table span by the keys of the keyspace the changefeed is
+// scoped to, since regions are located by keyspace-prefixed keys.
+func (m *Reconciler) splitSpan(
+	ctx context.Context, tableSpan tablepb.Span, totalCaptures int,
+) []tablepb.Span {
+	span := spanz.AddKeyspacePrefix(m.keyspacePrefix, tableSpan)
+	spans := []tablepb.Span{span}
+	for _, splitter := range m.splitter {
+		spans = splitter.split(ctx, span, totalCaptures, m.config)
+		if len(spans) > 1 {
+			break
+		}
+	}
+	for i := range spans {
+		spans[i] = spanz.TrimKeyspacePrefix(m.keyspacePrefix, spans[i])
+	}
+	return spans
+}
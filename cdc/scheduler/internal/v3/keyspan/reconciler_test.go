@@ -259,3 +259,44 @@ func TestBatchAddRateLimit(t *testing.T) {
 	require.Equal(t, allSpan, reconciler.tableSpans[2].spans)
 	require.Equal(t, 1, len(reconciler.tableSpans))
 }
+
+func TestReconcileKeyspace(t *testing.T) {
+	t.Parallel()
+
+	// Regions are located by keyspace-prefixed keys, while spans returned by
+	// the reconciler must not contain the prefix.
+	prefix := []byte{'x', 0, 0, 1}
+	start, end := spanz.GetTableRange(1)
+	mid := append(append([]byte{}, start...), 1)
+	withPrefix := func(key []byte) tablepb.Key {
+		return spanz.ToComparableKey(append(append([]byte{}, prefix...), key...))
+	}
+	cache := NewMockRegionCache()
+	cache.regions.ReplaceOrInsert(tablepb.Span{
+		StartKey: withPrefix(start), EndKey: withPrefix(mid),
+	}, 1)
+	cache.regions.ReplaceOrInsert(tablepb.Span{
+		StartKey: withPrefix(mid), EndKey: withPrefix(end),
+	}, 2)
+
+	cfg := &config.SchedulerConfig{
+		ChangefeedSettings: &config.ChangefeedSchedulerConfig{
+			EnableTableAcrossNodes: true,
+			RegionThreshold:        1,
+		},
+	}
+	compat := compat.New(cfg, map[string]*model.CaptureInfo{})
+	captures := map[model.CaptureID]*member.CaptureStatus{"1": nil, "2": nil}
+	reconciler := NewReconcilerForTests(cache, cfg.ChangefeedSettings)
+	reconciler.keyspacePrefix = prefix
+	currentTables := &replication.TableRanges{}
+	currentTables.UpdateTables([]model.TableID{1})
+	spans := reconciler.Reconcile(
+		context.Background(), currentTables,
+		spanz.NewBtreeMap[*replication.ReplicationSet](), captures, compat)
+	require.Len(t, spans, 2)
+	require.Equal(t, spanz.ToComparableKey(start), spans[0].StartKey)
+	require.Equal(t, spanz.ToComparableKey(mid), spans[0].EndKey)
+	require.Equal(t, spanz.ToComparableKey(mid), spans[1].StartKey)
+	require.Equal(t, spanz.ToComparableKey(end), spans[1].EndKey)
+}
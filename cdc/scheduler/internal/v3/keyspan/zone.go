@@ -0,0 +1,163 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspan
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/zap"
+)
+
+const (
+	// zoneRefreshInterval is the interval of resolving zones of spans again,
+	// since region leaders may be transferred across zones.
+	zoneRefreshInterval = 5 * time.Minute
+	// zoneResolveBatchSize is the max number of spans resolved in a tick.
+	zoneResolveBatchSize = 256
+)
+
+type leaderZoneLocator interface {
+	// leaderZones returns the number of region leaders of the span in each zone.
+	leaderZones(ctx context.Context, span tablepb.Span) (map[string]int, error)
+}
+
+// regionCacheZoneLocator locates region leaders by the region cache, and
+// their zones by labels of TiKV stores.
+type regionCacheZoneLocator struct {
+	regionCache *tikv.RegionCache
+	label       string
+}
+
+func (l *regionCacheZoneLocator) leaderZones(
+	ctx context.Context, span tablepb.Span,
+) (map[string]int, error) {
+	bo := tikv.NewBackoffer(ctx, 500)
+	regions, err := l.regionCache.LoadRegionsInKeyRange(bo, span.StartKey, span.EndKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storeZones := make(map[uint64]string)
+	for _, store := range l.regionCache.GetAllStores() {
+		if zone, ok := store.GetLabelValue(l.label); ok && zone != "" {
+			storeZones[store.StoreID()] = zone
+		}
+	}
+	zones := make(map[string]int)
+	for _, region := range regions {
+		if zone, ok := storeZones[region.GetLeaderStoreID()]; ok {
+			zones[zone]++
+		}
+	}
+	return zones, nil
+}
+
+// zoneResolver resolves the zone of the majority of region leaders of each
+// span. Spans are resolved in batches, and resolved again periodically.
+type zoneResolver struct {
+	changefeedID model.ChangeFeedID
+	locator      leaderZoneLocator
+	// keyspacePrefix is added to spans before locating their regions.
+	keyspacePrefix []byte
+
+	// zones are zones of spans, spans without leaders in any zone are absent.
+	zones      *spanz.BtreeMap[string]
+	resolvedAt *spanz.HashMap[time.Time]
+	now        func() time.Time
+}
+
+func newZoneResolver(
+	changefeedID model.ChangeFeedID, locator leaderZoneLocator, keyspacePrefix []byte,
+) *zoneResolver {
+	return &zoneResolver{
+		changefeedID:   changefeedID,
+		locator:        locator,
+		keyspacePrefix: keyspacePrefix,
+		zones:          spanz.NewBtreeMap[string](),
+		resolvedAt:     spanz.NewHashMap[time.Time](),
+		now:            time.Now,
+	}
+}
+
+// retain forgets spans that are not in the given spans.
+func (r *zoneResolver) retain(spans []tablepb.Span) {
+	alive := spanz.NewHashMap[struct{}]()
+	for _, span := range spans {
+		alive.ReplaceOrInsert(span, struct{}{})
+	}
+	var removed []tablepb.Span
+	r.resolvedAt.Range(func(span tablepb.Span, _ time.Time) bool {
+		if !alive.Has(span) {
+			removed = append(removed, span)
+		}
+		return true
+	})
+	for _, span := range removed {
+		r.resolvedAt.Delete(span)
+		r.zones.Delete(span)
+	}
+}
+
+// resolve resolves zones of spans which are never resolved or resolved
+// zoneRefreshInterval ago, at most zoneResolveBatchSize spans are resolved.
+func (r *zoneResolver) resolve(ctx context.Context, spans []tablepb.Span) {
+	now := r.now()
+	count := 0
+	for _, span := range spans {
+		if count >= zoneResolveBatchSize {
+			return
+		}
+		if at, ok := r.resolvedAt.Get(span); ok && now.Sub(at) < zoneRefreshInterval {
+			continue
+		}
+		count++
+		// Spans failed to be resolved are retried in the next refresh.
+		r.resolvedAt.ReplaceOrInsert(span, now)
+		zones, err := r.locator.leaderZones(ctx, spanz.AddKeyspacePrefix(r.keyspacePrefix, span))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warn("schedulerv3: locate zones of region leaders failed",
+				zap.String("namespace", r.changefeedID.Namespace),
+				zap.String("changefeed", r.changefeedID.ID),
+				zap.String("span", span.String()),
+				zap.Error(err))
+			continue
+		}
+		if zone := majorityZone(zones); zone != "" {
+			r.zones.ReplaceOrInsert(span, zone)
+		} else {
+			r.zones.Delete(span)
+		}
+	}
+}
+
+// majorityZone returns the zone with the most leaders, ties are broken by
+// zone names so that the result is deterministic.
+func majorityZone(zones map[string]int) string {
+	majority, maxCount := "", 0
+	for zone, count := range zones {
+		if count > maxCount || (count == maxCount && zone < majority) {
+			majority, maxCount = zone, count
+		}
+	}
+	return majority
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/stretchr/testify/require"
+)
+
+type mockZoneLocator struct {
+	zones map[model.TableID]map[string]int
+	calls int
+}
+
+func (l *mockZoneLocator) leaderZones(
+	_ context.Context, span tablepb.Span,
+) (map[string]int, error) {
+	l.calls++
+	zones, ok := l.zones[span.TableID]
+	if !ok {
+		return nil, errors.New("region not found")
+	}
+	return zones, nil
+}
+
+func TestZoneResolver(t *testing.T) {
+	t.Parallel()
+
+	locator := &mockZoneLocator{zones: map[model.TableID]map[string]int{
+		1: {"z1": 3, "z2": 1},
+		2: {"z1": 2, "z2": 2},
+		3: {},
+	}}
+	r := newZoneResolver(model.ChangeFeedID{}, locator, nil)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	spans := []tablepb.Span{{TableID: 1}, {TableID: 2}, {TableID: 3}, {TableID: 4}}
+
+	r.resolve(context.Background(), spans)
+	require.Equal(t, 4, locator.calls)
+	require.Equal(t, 2, r.zones.Len())
+	require.Equal(t, "z1", r.zones.GetV(tablepb.Span{TableID: 1}))
+	// Ties are broken by zone names.
+	require.Equal(t, "z1", r.zones.GetV(tablepb.Span{TableID: 2}))
+
+	// Spans are not resolved again until the refresh interval, even if they
+	// failed to be resolved.
+	locator.zones[1] = map[string]int{"z2": 1}
+	locator.zones[4] = map[string]int{"z2": 1}
+	r.resolve(context.Background(), spans)
+	require.Equal(t, 4, locator.calls)
+	now = now.Add(zoneRefreshInterval)
+	r.resolve(context.Background(), spans)
+	require.Equal(t, 8, locator.calls)
+	require.Equal(t, "z2", r.zones.GetV(tablepb.Span{TableID: 1}))
+	require.Equal(t, "z2", r.zones.GetV(tablepb.Span{TableID: 4}))
+
+	// Removed spans are forgotten.
+	r.retain(spans[1:])
+	require.False(t, r.zones.Has(tablepb.Span{TableID: 1}))
+	require.False(t, r.resolvedAt.Has(tablepb.Span{TableID: 1}))
+	require.Equal(t, 3, r.resolvedAt.Len())
+
+	// At most zoneResolveBatchSize spans are resolved in a call.
+	spans = spans[:0]
+	for i := 0; i < zoneResolveBatchSize+10; i++ {
+		spans = append(spans, tablepb.Span{TableID: model.TableID(100 + i)})
+	}
+	locator.calls = 0
+	r.resolve(context.Background(), spans)
+	require.Equal(t, zoneResolveBatchSize, locator.calls)
+	r.resolve(context.Background(), spans)
+	require.Equal(t, zoneResolveBatchSize+10, locator.calls)
+}
@@ -64,6 +64,11 @@ type CaptureStatus struct {
 	ID       model.CaptureID
 	Addr     string
 	IsOwner  bool
+	// Labels are set by the server config of the capture.
+	Labels map[string]string
+	// Load is the latest load reported by the capture, it's nil if the
+	// capture hasn't reported yet. It's not a part of snapshots.
+	Load *schedulepb.CaptureLoad `json:"-"`
 }
 
 func newCaptureStatus(
@@ -78,6 +83,11 @@ func newCaptureStatus(
 	}
 }
 
+// MatchLabels returns true if the capture carries all the labels in selector.
+func (c *CaptureStatus) MatchLabels(selector map[string]string) bool {
+	return config.MatchLabels(selector, c.Labels)
+}
+
 func (c *CaptureStatus) handleHeartbeatResponse(
 	resp *schedulepb.HeartbeatResponse, epoch schedulepb.ProcessorEpoch,
 ) {
@@ -106,6 +116,9 @@ func (c *CaptureStatus) handleHeartbeatResponse(
 			zap.String("captureAddr", c.Addr))
 	}
 	c.Tables = resp.Tables
+	if resp.Load != nil {
+		c.Load = resp.Load
+	}
 }
 
 // CaptureChanges wraps changes of captures.
@@ -233,6 +246,7 @@ func (c *CaptureManager) HandleAliveCaptureUpdate(
 			// A new capture.
 			c.Captures[id] = newCaptureStatus(
 				c.OwnerRev, id, info.AdvertiseAddr, c.ownerID == id)
+			c.Captures[id].Labels = info.Labels
 			log.Info("schedulerv3: find a new capture",
 				zap.String("captureAddr", info.AdvertiseAddr),
 				zap.String("capture", id))
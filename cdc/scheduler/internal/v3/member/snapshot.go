@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"sort"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+)
+
+// Snapshot is a serializable copy of the states of a capture manager.
+type Snapshot struct {
+	Captures    []*CaptureStatus `json:"captures"`
+	Initialized bool             `json:"initialized"`
+	// Changes are the capture changes that have not been taken yet.
+	Changes *CaptureChanges `json:"changes,omitempty"`
+}
+
+// Snapshot returns a copy of the states of the capture manager.
+// Captures are sorted by ID.
+func (c *CaptureManager) Snapshot() *Snapshot {
+	s := &Snapshot{
+		Captures:    make([]*CaptureStatus, 0, len(c.Captures)),
+		Initialized: c.initialized,
+	}
+	for _, capture := range c.Captures {
+		cloned := *capture
+		cloned.Tables = append([]tablepb.TableStatus(nil), capture.Tables...)
+		s.Captures = append(s.Captures, &cloned)
+	}
+	sort.Slice(s.Captures, func(i, j int) bool {
+		return s.Captures[i].ID < s.Captures[j].ID
+	})
+	if c.changes != nil {
+		s.Changes = &CaptureChanges{
+			Init:    cloneCaptureTables(c.changes.Init),
+			Removed: cloneCaptureTables(c.changes.Removed),
+		}
+	}
+	return s
+}
+
+// Restore replaces the states of the capture manager with the snapshot.
+func (c *CaptureManager) Restore(s *Snapshot) {
+	c.Captures = make(map[model.CaptureID]*CaptureStatus, len(s.Captures))
+	for _, capture := range s.Captures {
+		cloned := *capture
+		cloned.Tables = append([]tablepb.TableStatus(nil), capture.Tables...)
+		c.Captures[capture.ID] = &cloned
+	}
+	c.initialized = s.Initialized
+	c.changes = nil
+	if s.Changes != nil {
+		c.changes = &CaptureChanges{
+			Init:    cloneCaptureTables(s.Changes.Init),
+			Removed: cloneCaptureTables(s.Changes.Removed),
+		}
+	}
+}
+
+func cloneCaptureTables(
+	tables map[model.CaptureID][]tablepb.TableStatus,
+) map[model.CaptureID][]tablepb.TableStatus {
+	if tables == nil {
+		return nil
+	}
+	cloned := make(map[model.CaptureID][]tablepb.TableStatus, len(tables))
+	for captureID, statuses := range tables {
+		cloned[captureID] = append([]tablepb.TableStatus(nil), statuses...)
+	}
+	return cloned
+}
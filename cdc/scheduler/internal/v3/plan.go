@@ -0,0 +1,114 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/spanz"
+)
+
+var _ internal.Planner = (*coordinator)(nil)
+
+// Plan implements the internal.Planner interface. Schedulers are run on the
+// states of the last tick, and the tasks are not dispatched to captures.
+func (c *coordinator) Plan(rebalance bool) ([]model.ScheduleOperation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.captureM.CheckAllCaptureInitialized() {
+		return nil, cerror.ErrSchedulerRequestFailed.
+			GenWithStack("not all captures initialized")
+	}
+
+	if c.lastSpans == nil {
+		return nil, cerror.ErrSchedulerRequestFailed.
+			GenWithStack("changefeed has not been scheduled yet")
+	}
+
+	replications := c.replicationM.ReplicationSets()
+	planned, err := c.schedulerM.Plan(time.Now().UnixNano(), rebalance,
+		c.lastCheckpointTs, c.lastSpans, c.captureM.Captures, replications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var ops []model.ScheduleOperation
+	for _, p := range planned {
+		for _, task := range p.Tasks {
+			ops = appendScheduleOperations(ops, p.Scheduler, task, replications)
+		}
+	}
+	return ops, nil
+}
+
+func appendScheduleOperations(
+	ops []model.ScheduleOperation,
+	scheduler string,
+	task *replication.ScheduleTask,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) []model.ScheduleOperation {
+	newOp := func(tp string, span tablepb.Span, from, to model.CaptureID) model.ScheduleOperation {
+		return model.ScheduleOperation{
+			Scheduler: scheduler,
+			Type:      tp,
+			TableID:   span.TableID,
+			Span:      span.String(),
+			From:      from,
+			To:        to,
+		}
+	}
+	primary := func(span tablepb.Span) model.CaptureID {
+		if rep, ok := replications.Get(span); ok {
+			return rep.Primary
+		}
+		return ""
+	}
+	addTable := func(t replication.AddTable) {
+		ops = append(ops, newOp(model.ScheduleOperationAdd, t.Span, "", t.CaptureID))
+	}
+	removeTable := func(t replication.RemoveTable) {
+		ops = append(ops, newOp(model.ScheduleOperationRemove, t.Span, t.CaptureID, ""))
+	}
+	moveTable := func(t replication.MoveTable) {
+		ops = append(ops, newOp(model.ScheduleOperationMove, t.Span,
+			primary(t.Span), t.DestCapture))
+	}
+
+	switch {
+	case task.AddTable != nil:
+		addTable(*task.AddTable)
+	case task.RemoveTable != nil:
+		removeTable(*task.RemoveTable)
+	case task.MoveTable != nil:
+		moveTable(*task.MoveTable)
+	case task.BurstBalance != nil:
+		for _, t := range task.BurstBalance.AddTables {
+			addTable(t)
+		}
+		for _, t := range task.BurstBalance.RemoveTables {
+			removeTable(t)
+		}
+		for _, t := range task.BurstBalance.MoveTables {
+			moveTable(t)
+		}
+	}
+	return ops
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/spanz"
+)
+
+// invariantViolationThreshold is the number of consecutive checks that a
+// violation must be found in before it's reported. Replication sets and
+// heartbeat reports may diverge temporarily while messages are in flight.
+const invariantViolationThreshold = 3
+
+// InvariantViolation is a violated invariant of the replication manager.
+type InvariantViolation struct {
+	Span   tablepb.Span `json:"span"`
+	Reason string       `json:"reason"`
+}
+
+func (v InvariantViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Span.String(), v.Reason)
+}
+
+// CheckInvariants checks the invariants of replication sets against the
+// current tables and the tables reported by captures in heartbeats:
+//  1. A span has at most one primary.
+//  2. There is no orphan span, i.e. a span must belong to a current table,
+//     and a span replicated by a capture must be known.
+//  3. The spans of a capture match the spans reported by the capture.
+//
+// Invariants 2 and 3 are not checked on spans with running tasks, since their
+// states change during scheduling. It returns the violations found in
+// invariantViolationThreshold consecutive checks.
+func (r *Manager) CheckInvariants(
+	currentTables *TableRanges,
+	reported map[model.CaptureID][]tablepb.TableStatus,
+) []InvariantViolation {
+	var found []InvariantViolation
+	addViolation := func(span tablepb.Span, format string, args ...interface{}) {
+		found = append(found, InvariantViolation{
+			Span: span, Reason: fmt.Sprintf(format, args...),
+		})
+	}
+
+	tables := make(map[model.TableID]struct{}, currentTables.Len())
+	currentTables.Iter(func(tableID model.TableID, _, _ tablepb.Span) bool {
+		tables[tableID] = struct{}{}
+		return true
+	})
+	reportedSpans := spanz.NewHashMap[map[model.CaptureID]tablepb.TableState]()
+	for captureID, statuses := range reported {
+		for _, status := range statuses {
+			states, ok := reportedSpans.Get(status.Span)
+			if !ok {
+				states = make(map[model.CaptureID]tablepb.TableState)
+				reportedSpans.ReplaceOrInsert(status.Span, states)
+			}
+			states[captureID] = status.State
+		}
+	}
+
+	r.spans.Ascend(func(span tablepb.Span, table *ReplicationSet) bool {
+		primaries := 0
+		for _, role := range table.Captures {
+			if role == RolePrimary {
+				primaries++
+			}
+		}
+		if primaries > 1 {
+			addViolation(span, "multiple primaries %v", table.Captures)
+		}
+		if table.Primary != "" && table.Captures[table.Primary] != RolePrimary {
+			addViolation(span, "primary %s is not in primary role", table.Primary)
+		}
+		if r.runningTasks.Has(span) {
+			return true
+		}
+
+		if _, ok := tables[span.TableID]; !ok {
+			addViolation(span, "orphan span of a removed table")
+		}
+
+		states := reportedSpans.GetV(span)
+		for captureID, role := range table.Captures {
+			if _, ok := reported[captureID]; !ok || role == RoleUndetermined {
+				continue
+			}
+			state, ok := states[captureID]
+			if !ok || state == tablepb.TableStateAbsent || state == tablepb.TableStateStopped {
+				addViolation(span, "capture %s in %s role doesn't report the span",
+					captureID, role)
+			}
+		}
+		for captureID, state := range states {
+			if _, ok := table.Captures[captureID]; ok {
+				continue
+			}
+			switch state {
+			case tablepb.TableStatePreparing, tablepb.TableStatePrepared,
+				tablepb.TableStateReplicating:
+				addViolation(span, "capture %s reports the span in %s state",
+					captureID, state)
+			}
+		}
+		return true
+	})
+	reportedSpans.Range(func(span tablepb.Span, states map[model.CaptureID]tablepb.TableState) bool {
+		if r.spans.Has(span) || r.runningTasks.Has(span) {
+			return true
+		}
+		for captureID, state := range states {
+			switch state {
+			case tablepb.TableStatePreparing, tablepb.TableStatePrepared,
+				tablepb.TableStateReplicating:
+				addViolation(span, "capture %s reports an unknown span in %s state",
+					captureID, state)
+			}
+		}
+		return true
+	})
+
+	// Only report violations that persist.
+	counts := make(map[string]int, len(found))
+	var violations []InvariantViolation
+	for _, v := range found {
+		key := v.String()
+		counts[key] = r.invariantViolations[key] + 1
+		if counts[key] >= invariantViolationThreshold {
+			violations = append(violations, v)
+		}
+	}
+	r.invariantViolations = counts
+	if len(violations) != 0 {
+		invariantViolationCounter.
+			WithLabelValues(r.changefeedID.Namespace, r.changefeedID.ID).Inc()
+	}
+	return violations
+}
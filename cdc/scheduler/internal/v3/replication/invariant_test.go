@@ -0,0 +1,95 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	t.Parallel()
+
+	r := NewReplicationManager(10, model.ChangeFeedID{})
+	span1 := spanz.TableIDToComparableSpan(1)
+	span2 := spanz.TableIDToComparableSpan(2)
+	span3 := spanz.TableIDToComparableSpan(3)
+	r.spans.ReplaceOrInsert(span1, &ReplicationSet{
+		Span:     span1,
+		State:    ReplicationSetStateReplicating,
+		Primary:  "1",
+		Captures: map[model.CaptureID]Role{"1": RolePrimary},
+	})
+	r.spans.ReplaceOrInsert(span2, &ReplicationSet{
+		Span:     span2,
+		State:    ReplicationSetStatePrepare,
+		Primary:  "1",
+		Captures: map[model.CaptureID]Role{"1": RolePrimary, "2": RoleSecondary},
+	})
+	currentTables := &TableRanges{}
+	currentTables.UpdateTables([]model.TableID{1, 2})
+	reported := map[model.CaptureID][]tablepb.TableStatus{
+		"1": {
+			{Span: span1, State: tablepb.TableStateReplicating},
+			{Span: span2, State: tablepb.TableStateReplicating},
+		},
+		"2": {{Span: span2, State: tablepb.TableStatePreparing}},
+	}
+
+	// No violation.
+	for i := 0; i < invariantViolationThreshold; i++ {
+		require.Empty(t, r.CheckInvariants(currentTables, reported))
+	}
+
+	// Violations are reported after they persist.
+	r.spans.GetV(span2).Captures["2"] = RolePrimary
+	currentTables.UpdateTables([]model.TableID{2})
+	reported["2"] = []tablepb.TableStatus{{Span: span3, State: tablepb.TableStateReplicating}}
+	for i := 0; i < invariantViolationThreshold-1; i++ {
+		require.Empty(t, r.CheckInvariants(currentTables, reported))
+	}
+	violations := r.CheckInvariants(currentTables, reported)
+	reasons := make(map[string]tablepb.Span)
+	for _, v := range violations {
+		reasons[v.Reason] = v.Span
+	}
+	require.Equal(t, map[string]tablepb.Span{
+		"orphan span of a removed table":                         span1,
+		"multiple primaries map[1:Primary 2:Primary]":            span2,
+		"capture 2 in Primary role doesn't report the span":      span2,
+		"capture 2 reports an unknown span in Replicating state": span3,
+	}, reasons)
+
+	// Resolved violations are no longer reported.
+	reported["2"] = []tablepb.TableStatus{{Span: span2, State: tablepb.TableStatePreparing}}
+	violations = r.CheckInvariants(currentTables, reported)
+	require.Len(t, violations, 2)
+
+	// Spans with running tasks are only checked for roles.
+	r.runningTasks.ReplaceOrInsert(span1, &ScheduleTask{})
+	r.runningTasks.ReplaceOrInsert(span3, &ScheduleTask{})
+	reported["2"] = append(reported["2"],
+		tablepb.TableStatus{Span: span3, State: tablepb.TableStateReplicating})
+	for i := 0; i < invariantViolationThreshold-1; i++ {
+		r.CheckInvariants(currentTables, reported)
+	}
+	violations = r.CheckInvariants(currentTables, reported)
+	require.Len(t, violations, 1)
+	require.Equal(t, span2, violations[0].Span)
+	require.Equal(t, "multiple primaries map[1:Primary 2:Primary]", violations[0].Reason)
+}
@@ -77,6 +77,8 @@ type ScheduleTask struct { //nolint:revive
 	RemoveTable  *RemoveTable
 	BurstBalance *BurstBalance
 
+	// Reason is the name of the scheduler that generates the task.
+	Reason string
 	Accept Callback
 }
 
@@ -101,6 +103,11 @@ type Manager struct { //nolint:revive
 	runningTasks       *spanz.BtreeMap[*ScheduleTask]
 	maxTaskConcurrency int
 
+	// taskRecords are records of running tasks, they're moved to doneTasks
+	// once the tasks are done, see TakeDoneTasks.
+	taskRecords *spanz.BtreeMap[*model.ScheduleTaskRecord]
+	doneTasks   []model.ScheduleTaskRecord
+
 	changefeedID           model.ChangeFeedID
 	slowestTableID         tablepb.Span
 	acceptAddTableTask     int
@@ -112,6 +119,10 @@ type Manager struct { //nolint:revive
 	lastLogSlowTablesTime time.Time
 	lastMissTableID       tablepb.TableID
 	lastLogMissTime       time.Time
+
+	// invariantViolations counts the consecutive checks that each violation
+	// is found in.
+	invariantViolations map[string]int
 }
 
 // NewReplicationManager returns a new replication manager.
@@ -125,6 +136,7 @@ func NewReplicationManager(
 		spans:              spanz.NewBtreeMapWithDegree[*ReplicationSet](degreeReadHeavy),
 		runningTasks:       spanz.NewBtreeMap[*ScheduleTask](),
 		maxTaskConcurrency: maxTaskConcurrency,
+		taskRecords:        spanz.NewBtreeMap[*model.ScheduleTaskRecord](),
 		changefeedID:       changefeedID,
 	}
 }
@@ -181,6 +193,7 @@ func (r *Manager) HandleCaptureChanges(
 				if affected {
 					// Cleanup its running task.
 					r.runningTasks.Delete(table.Span)
+					r.endTask(table.Span, model.ScheduleTaskAborted)
 				}
 			}
 			return true
@@ -296,28 +309,32 @@ func (r *Manager) HandleTasks(
 ) ([]*schedulepb.Message, error) {
 	// Check if a running task is finished.
 	toBeDeleted := []tablepb.Span{}
+	states := []string{}
 	r.runningTasks.Ascend(func(span tablepb.Span, task *ScheduleTask) bool {
 		if table, ok := r.spans.Get(span); ok {
 			// If table is back to Replicating or Removed,
 			// the running task is finished.
 			if table.State == ReplicationSetStateReplicating || table.hasRemoved() {
 				toBeDeleted = append(toBeDeleted, span)
+				states = append(states, r.doneTaskState(span, table))
 			}
 		} else {
 			// No table found, remove the task
 			toBeDeleted = append(toBeDeleted, span)
+			states = append(states, r.doneTaskState(span, nil))
 		}
 		return true
 	})
-	for _, span := range toBeDeleted {
+	for i, span := range toBeDeleted {
 		r.runningTasks.Delete(span)
+		r.endTask(span, states[i])
 	}
 
 	sentMsgs := make([]*schedulepb.Message, 0)
 	for _, task := range tasks {
 		// Burst balance does not affect by maxTaskConcurrency.
 		if task.BurstBalance != nil {
-			msgs, err := r.handleBurstBalanceTasks(task.BurstBalance)
+			msgs, err := r.handleBurstBalanceTasks(task.BurstBalance, task.Reason)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -364,6 +381,11 @@ func (r *Manager) HandleTasks(
 			continue
 		}
 
+		// The capture replicating the table before the task.
+		var from model.CaptureID
+		if table, ok := r.spans.Get(span); ok {
+			from = table.Primary
+		}
 		var msgs []*schedulepb.Message
 		var err error
 		if task.AddTable != nil {
@@ -378,6 +400,16 @@ func (r *Manager) HandleTasks(
 		}
 		sentMsgs = append(sentMsgs, msgs...)
 		r.runningTasks.ReplaceOrInsert(span, task)
+		if task.AddTable != nil {
+			r.startTask(span, task.Reason, model.ScheduleOperationAdd,
+				"", task.AddTable.CaptureID)
+		} else if task.RemoveTable != nil {
+			r.startTask(span, task.Reason, model.ScheduleOperationRemove,
+				task.RemoveTable.CaptureID, "")
+		} else if task.MoveTable != nil {
+			r.startTask(span, task.Reason, model.ScheduleOperationMove,
+				from, task.MoveTable.DestCapture)
+		}
 		if task.Accept != nil {
 			task.Accept()
 		}
@@ -426,7 +458,7 @@ func (r *Manager) handleMoveTableTask(
 }
 
 func (r *Manager) handleBurstBalanceTasks(
-	task *BurstBalance,
+	task *BurstBalance, reason string,
 ) ([]*schedulepb.Message, error) {
 	r.acceptBurstBalanceTask++
 	perCapture := make(map[model.CaptureID]int)
@@ -461,6 +493,8 @@ func (r *Manager) handleBurstBalanceTasks(
 		sentMsgs = append(sentMsgs, msgs...)
 		// Just for place holding.
 		r.runningTasks.ReplaceOrInsert(addTable.Span, &ScheduleTask{})
+		r.startTask(addTable.Span, reason, model.ScheduleOperationAdd,
+			"", addTable.CaptureID)
 	}
 	for i := range task.RemoveTables {
 		removeTable := task.RemoveTables[i]
@@ -475,6 +509,8 @@ func (r *Manager) handleBurstBalanceTasks(
 		sentMsgs = append(sentMsgs, msgs...)
 		// Just for place holding.
 		r.runningTasks.ReplaceOrInsert(removeTable.Span, &ScheduleTask{})
+		r.startTask(removeTable.Span, reason, model.ScheduleOperationRemove,
+			removeTable.CaptureID, "")
 	}
 	for i := range task.MoveTables {
 		moveTable := task.MoveTables[i]
@@ -482,6 +518,10 @@ func (r *Manager) handleBurstBalanceTasks(
 			// Skip add table if the table is already running a task.
 			continue
 		}
+		var from model.CaptureID
+		if table, ok := r.spans.Get(moveTable.Span); ok {
+			from = table.Primary
+		}
 		msgs, err := r.handleMoveTableTask(&moveTable)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -489,10 +529,98 @@ func (r *Manager) handleBurstBalanceTasks(
 		sentMsgs = append(sentMsgs, msgs...)
 		// Just for place holding.
 		r.runningTasks.ReplaceOrInsert(moveTable.Span, &ScheduleTask{})
+		r.startTask(moveTable.Span, reason, model.ScheduleOperationMove,
+			from, moveTable.DestCapture)
 	}
 	return sentMsgs, nil
 }
 
+// startTask records a task that starts running on the span.
+func (r *Manager) startTask(
+	span tablepb.Span, reason, tp string, from, to model.CaptureID,
+) {
+	r.taskRecords.ReplaceOrInsert(span, &model.ScheduleTaskRecord{
+		ScheduleOperation: model.ScheduleOperation{
+			Scheduler: reason,
+			Type:      tp,
+			TableID:   span.TableID,
+			Span:      span.String(),
+			From:      from,
+			To:        to,
+		},
+		State:     model.ScheduleTaskRunning,
+		StartTime: time.Now(),
+	})
+}
+
+// doneTaskState returns the state of the done task on the span, the table
+// is nil if it's not found.
+func (r *Manager) doneTaskState(span tablepb.Span, table *ReplicationSet) string {
+	record, ok := r.taskRecords.Get(span)
+	if !ok {
+		return model.ScheduleTaskFinished
+	}
+	removed := table == nil || table.hasRemoved()
+	if record.Type == model.ScheduleOperationRemove {
+		if removed {
+			return model.ScheduleTaskFinished
+		}
+		return model.ScheduleTaskAborted
+	}
+	if !removed && table.Primary == record.To {
+		return model.ScheduleTaskFinished
+	}
+	return model.ScheduleTaskAborted
+}
+
+// endTask records that the task on the span is done.
+func (r *Manager) endTask(span tablepb.Span, state string) {
+	record, ok := r.taskRecords.Get(span)
+	if !ok {
+		// Tasks restored from snapshots are not recorded.
+		return
+	}
+	r.taskRecords.Delete(span)
+	record.State = state
+	record.Duration = time.Since(record.StartTime)
+	r.doneTasks = append(r.doneTasks, *record)
+}
+
+// TakeDoneTasks returns records of tasks done since the last call, in the
+// order they are done.
+func (r *Manager) TakeDoneTasks() []model.ScheduleTaskRecord {
+	done := r.doneTasks
+	r.doneTasks = nil
+	return done
+}
+
+// AbortRunningTasks records that all running tasks are aborted, and returns
+// records of tasks done since the last call of TakeDoneTasks.
+func (r *Manager) AbortRunningTasks() []model.ScheduleTaskRecord {
+	var spans []tablepb.Span
+	r.taskRecords.Ascend(func(span tablepb.Span, _ *model.ScheduleTaskRecord) bool {
+		spans = append(spans, span)
+		return true
+	})
+	for _, span := range spans {
+		r.endTask(span, model.ScheduleTaskAborted)
+	}
+	return r.TakeDoneTasks()
+}
+
+// RunningTaskRecords returns records of running tasks, sorted by span.
+func (r *Manager) RunningTaskRecords() []model.ScheduleTaskRecord {
+	records := make([]model.ScheduleTaskRecord, 0, r.taskRecords.Len())
+	now := time.Now()
+	r.taskRecords.Ascend(func(_ tablepb.Span, record *model.ScheduleTaskRecord) bool {
+		running := *record
+		running.Duration = now.Sub(record.StartTime)
+		records = append(records, running)
+		return true
+	})
+	return records
+}
+
 // ReplicationSets return all tracking replication set
 // Caller must not modify the returned map.
 func (r *Manager) ReplicationSets() *spanz.BtreeMap[*ReplicationSet] {
@@ -516,6 +644,12 @@ func (r *Manager) AdvanceCheckpoint(
 	slowestRange := tablepb.Span{}
 	cannotProceed := false
 	lastSpan := tablepb.Span{}
+	// tableResolvedTs is the resolved ts of tables, which is tracked by the
+	// redo meta if the redo log covers part of the tables.
+	var tableResolvedTs map[model.TableID]model.Ts
+	if redoMetaManager.Enabled() {
+		tableResolvedTs = make(map[model.TableID]model.Ts, currentTables.Len())
+	}
 	currentTables.Iter(func(tableID model.TableID, tableStart, tableEnd tablepb.Span) bool {
 		tableSpanFound, tableHasHole := false, false
 		tableSpanStartFound, tableSpanEndFound := false, false
@@ -548,6 +682,11 @@ func (r *Manager) AdvanceCheckpoint(
 				if newResolvedTs > table.Checkpoint.ResolvedTs {
 					newResolvedTs = table.Checkpoint.ResolvedTs
 				}
+				if tableResolvedTs != nil {
+					if ts, ok := tableResolvedTs[tableID]; !ok || ts > table.Checkpoint.ResolvedTs {
+						tableResolvedTs[tableID] = table.Checkpoint.ResolvedTs
+					}
+				}
 				return true
 			})
 		if !tableSpanFound || !tableSpanStartFound || !tableSpanEndFound || tableHasHole {
@@ -619,6 +758,7 @@ func (r *Manager) AdvanceCheckpoint(
 			newResolvedTs = barrier.RedoBarrierTs
 		}
 		redoMetaManager.UpdateMeta(newCheckpointTs, newResolvedTs)
+		redoMetaManager.UpdateTableMeta(tableResolvedTs)
 		flushedMeta := redoMetaManager.GetFlushedMeta()
 		flushedCheckpointTs, flushedResolvedTs := flushedMeta.CheckpointTs, flushedMeta.ResolvedTs
 		log.Debug("owner gets flushed meta",
@@ -772,6 +912,7 @@ func (r *Manager) CleanMetrics() {
 	slowestTableCheckpointTsGauge.DeleteLabelValues(cf.Namespace, cf.ID)
 	slowestTableResolvedTsGauge.DeleteLabelValues(cf.Namespace, cf.ID)
 	runningScheduleTaskGauge.DeleteLabelValues(cf.Namespace, cf.ID)
+	invariantViolationCounter.DeleteLabelValues(cf.Namespace, cf.ID)
 	metricAcceptScheduleTask := acceptScheduleTaskCounter.MustCurryWith(map[string]string{
 		"namespace": cf.Namespace, "changefeed": cf.ID,
 	})
@@ -584,11 +584,16 @@ type mockRedoMetaManager struct {
 	checkpointTs model.Ts
 	resolvedTs   model.Ts
 	enable       bool
+	tables       map[model.TableID]model.Ts
 }
 
 func (m *mockRedoMetaManager) UpdateMeta(checkpointTs, resolvedTs model.Ts) {
 }
 
+func (m *mockRedoMetaManager) UpdateTableMeta(tables map[model.TableID]model.Ts) {
+	m.tables = tables
+}
+
 func (m *mockRedoMetaManager) GetFlushedMeta() common.LogMeta {
 	return common.LogMeta{
 		CheckpointTs: m.checkpointTs,
@@ -764,6 +769,7 @@ func TestReplicationManagerAdvanceCheckpoint(t *testing.T) {
 	require.Equal(t, model.Ts(9), resolved)
 	require.Equal(t, model.Ts(9), checkpoint)
 	require.Equal(t, model.Ts(9), barrier.GetGlobalBarrierTs())
+	require.Equal(t, map[model.TableID]model.Ts{4: 15}, redoMetaManager.tables)
 }
 
 func TestReplicationManagerHandleCaptureChanges(t *testing.T) {
@@ -855,6 +861,69 @@ func TestReplicationManagerHandleCaptureChangesDuringAddTable(t *testing.T) {
 	require.Equal(t, 1, <-addTableCh)
 }
 
+func TestReplicationManagerTaskRecords(t *testing.T) {
+	t.Parallel()
+
+	r := NewReplicationManager(10, model.ChangeFeedID{})
+	for _, id := range []model.TableID{1, 2} {
+		span := spanz.TableIDToComparableSpan(id)
+		tbl, err := NewReplicationSet(span, 0, map[string]*tablepb.TableStatus{
+			"1": {Span: span, State: tablepb.TableStateReplicating},
+		}, model.ChangeFeedID{})
+		require.Nil(t, err)
+		r.spans.ReplaceOrInsert(span, tbl)
+	}
+
+	_, err := r.HandleTasks([]*ScheduleTask{{
+		MoveTable: &MoveTable{Span: spanz.TableIDToComparableSpan(1), DestCapture: "2"},
+		Reason:    "balance-scheduler",
+	}, {
+		RemoveTable: &RemoveTable{Span: spanz.TableIDToComparableSpan(2), CaptureID: "1"},
+		Reason:      "basic-scheduler",
+	}, {
+		BurstBalance: &BurstBalance{AddTables: []AddTable{{
+			Span: spanz.TableIDToComparableSpan(3), CaptureID: "1",
+		}}},
+		Reason: "basic-scheduler",
+	}})
+	require.Nil(t, err)
+	running := r.RunningTaskRecords()
+	require.Len(t, running, 3)
+	span := spanz.TableIDToComparableSpan(1)
+	require.Equal(t, model.ScheduleOperation{
+		Scheduler: "balance-scheduler",
+		Type:      model.ScheduleOperationMove,
+		TableID:   1,
+		Span:      span.String(),
+		From:      "1",
+		To:        "2",
+	}, running[0].ScheduleOperation)
+	require.Equal(t, model.ScheduleOperationRemove, running[1].Type)
+	require.Equal(t, "1", running[1].From)
+	require.Equal(t, model.ScheduleOperationAdd, running[2].Type)
+	require.Equal(t, "1", running[2].To)
+	for _, record := range running {
+		require.Equal(t, model.ScheduleTaskRunning, record.State)
+	}
+	require.Empty(t, r.TakeDoneTasks())
+
+	// The destination of the move goes offline.
+	_, err = r.HandleCaptureChanges(nil, map[string][]tablepb.TableStatus{"2": {}}, 0)
+	require.Nil(t, err)
+	// The table is removed.
+	r.spans.Delete(spanz.TableIDToComparableSpan(2))
+	_, err = r.HandleTasks(nil)
+	require.Nil(t, err)
+	done := r.TakeDoneTasks()
+	require.Len(t, done, 2)
+	require.Equal(t, model.TableID(1), done[0].TableID)
+	require.Equal(t, model.ScheduleTaskAborted, done[0].State)
+	require.Equal(t, model.TableID(2), done[1].TableID)
+	require.Equal(t, model.ScheduleTaskFinished, done[1].State)
+	require.Empty(t, r.TakeDoneTasks())
+	require.Len(t, r.RunningTaskRecords(), 1)
+}
+
 func TestLogSlowTableInfo(t *testing.T) {
 	t.Parallel()
 	r := NewReplicationManager(1, model.ChangeFeedID{})
@@ -91,6 +91,21 @@ func (r ReplicationSetState) MarshalJSON() ([]byte, error) {
 	return json.Marshal(r.String())
 }
 
+// UnmarshalJSON parses the JSON encoding returned by MarshalJSON.
+func (r *ReplicationSetState) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	for state := ReplicationSetStateUnknown; state <= ReplicationSetStateRemoving; state++ {
+		if state.String() == s {
+			*r = state
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown replication set state %q", s)
+}
+
 // Role is the role of a capture.
 type Role int
 
@@ -123,6 +138,21 @@ func (r Role) MarshalJSON() ([]byte, error) {
 	return json.Marshal(r.String())
 }
 
+// UnmarshalJSON parses the JSON encoding returned by MarshalJSON.
+func (r *Role) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	for _, role := range []Role{RolePrimary, RoleSecondary, RoleUndetermined} {
+		if role.String() == s {
+			*r = role
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown role %q", s)
+}
+
 // ReplicationSet is a state machine that manages replication states.
 type ReplicationSet struct { //nolint:revive
 	Changefeed model.ChangeFeedID
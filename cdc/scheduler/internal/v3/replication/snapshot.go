@@ -0,0 +1,88 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/spanz"
+)
+
+// RunningTask is a serializable summary of a running schedule task.
+type RunningTask struct {
+	Span tablepb.Span `json:"span"`
+	Name string       `json:"name"`
+}
+
+// Snapshot is a serializable copy of the states of a replication manager.
+type Snapshot struct {
+	ReplicationSets []*ReplicationSet `json:"replication_sets"`
+	RunningTasks    []RunningTask     `json:"running_tasks"`
+}
+
+// Snapshot returns a copy of the states of the replication manager.
+// Replication sets and running tasks are sorted by span.
+func (r *Manager) Snapshot() *Snapshot {
+	s := &Snapshot{
+		ReplicationSets: make([]*ReplicationSet, 0, r.spans.Len()),
+		RunningTasks:    make([]RunningTask, 0, r.runningTasks.Len()),
+	}
+	r.spans.Ascend(func(_ tablepb.Span, rs *ReplicationSet) bool {
+		s.ReplicationSets = append(s.ReplicationSets, rs.clone())
+		return true
+	})
+	r.runningTasks.Ascend(func(span tablepb.Span, task *ScheduleTask) bool {
+		s.RunningTasks = append(s.RunningTasks, RunningTask{
+			Span: span, Name: task.Name(),
+		})
+		return true
+	})
+	return s
+}
+
+// Restore replaces the states of the replication manager with the snapshot.
+// Callbacks of running tasks are not serializable, running tasks are
+// restored as place holders, which block new tasks on their spans until
+// the replication sets are back to Replicating or removed.
+func (r *Manager) Restore(s *Snapshot) {
+	var spans []tablepb.Span
+	r.spans.Ascend(func(span tablepb.Span, _ *ReplicationSet) bool {
+		spans = append(spans, span)
+		return true
+	})
+	for _, span := range spans {
+		r.spans.Delete(span)
+	}
+	r.runningTasks = spanz.NewBtreeMap[*ScheduleTask]()
+	r.taskRecords = spanz.NewBtreeMap[*model.ScheduleTaskRecord]()
+	r.doneTasks = nil
+	for _, rs := range s.ReplicationSets {
+		cloned := rs.clone()
+		cloned.Changefeed = r.changefeedID
+		r.spans.ReplaceOrInsert(cloned.Span, cloned)
+	}
+	for _, task := range s.RunningTasks {
+		r.runningTasks.ReplaceOrInsert(task.Span, &ScheduleTask{})
+	}
+	r.invariantViolations = nil
+}
+
+func (r *ReplicationSet) clone() *ReplicationSet {
+	cloned := *r
+	cloned.Captures = make(map[model.CaptureID]Role, len(r.Captures))
+	for captureID, role := range r.Captures {
+		cloned.Captures[captureID] = role
+	}
+	return &cloned
+}
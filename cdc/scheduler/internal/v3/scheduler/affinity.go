@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/pingcap/log"
+	filter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/pkg/config"
+	"go.uber.org/zap"
+)
+
+// affinity pins tables to captures by the affinity rules of a changefeed.
+// Tables are matched by their names, which are updated by the owner.
+type affinity struct {
+	rules   []*config.AffinityRule
+	filters []filter.Filter
+	// tables maps pinned tables to their rules.
+	tables map[model.TableID]*config.AffinityRule
+}
+
+func newAffinity(
+	changefeedID model.ChangeFeedID, rules []*config.AffinityRule,
+) *affinity {
+	a := &affinity{}
+	for _, rule := range rules {
+		f, err := filter.Parse(rule.Matcher)
+		if err != nil {
+			// It should never happen, as rules are validated on creating
+			// and updating the changefeed.
+			log.Warn("schedulerv3: ignore invalid affinity rule",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.Strings("matcher", rule.Matcher),
+				zap.Error(err))
+			continue
+		}
+		a.rules = append(a.rules, rule)
+		a.filters = append(a.filters, filter.CaseInsensitive(f))
+	}
+	return a
+}
+
+func (a *affinity) updateTableNames(names map[model.TableID]model.TableName) {
+	if len(a.rules) == 0 {
+		return
+	}
+	tables := make(map[model.TableID]*config.AffinityRule)
+	for tableID, name := range names {
+		for i, f := range a.filters {
+			if f.MatchTable(name.Schema, name.Table) {
+				tables[tableID] = a.rules[i]
+				break
+			}
+		}
+	}
+	a.tables = tables
+}
+
+func (a *affinity) hasPinnedTables() bool {
+	return a != nil && len(a.tables) != 0
+}
+
+func (a *affinity) isPinned(tableID model.TableID) bool {
+	return a.hasPinnedTables() && a.tables[tableID] != nil
+}
+
+// match returns captures in captureIDs that match the affinity rule of the
+// table. It returns nil if the table is not pinned or no capture matches.
+func (a *affinity) match(
+	tableID model.TableID,
+	captureIDs []model.CaptureID,
+	captures map[model.CaptureID]*member.CaptureStatus,
+) []model.CaptureID {
+	if !a.hasPinnedTables() {
+		return nil
+	}
+	rule := a.tables[tableID]
+	if rule == nil {
+		return nil
+	}
+	var matched []model.CaptureID
+	for _, id := range captureIDs {
+		if status, ok := captures[id]; ok && rule.MatchCapture(id, status.Labels) {
+			matched = append(matched, id)
+		}
+	}
+	return matched
+}
@@ -25,7 +25,25 @@ var scheduleTaskCounter = prometheus.NewCounterVec(
 		Help:      "The total number of scheduler tasks",
 	}, []string{"namespace", "changefeed", "scheduler", "task"})
 
+var queuedMovesGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "ticdc",
+		Subsystem: "scheduler",
+		Name:      "queued_moves",
+		Help:      "The number of table moves queued for the move budget",
+	}, []string{"namespace", "changefeed"})
+
+var executedMovesCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "scheduler",
+		Name:      "executed_moves",
+		Help:      "The total number of table moves dispatched by the move budget",
+	}, []string{"namespace", "changefeed"})
+
 // InitMetrics registers all metrics used in scheduler
 func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(scheduleTaskCounter)
+	registry.MustRegister(queuedMovesGauge)
+	registry.MustRegister(executedMovesCounter)
 }
@@ -0,0 +1,168 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/zap"
+)
+
+// moveBudget rate limits table moves of balancing. Moves exceeding the budget
+// are queued, and dispatched in the following ticks once there is budget.
+type moveBudget struct {
+	changefeedID model.ChangeFeedID
+	// perTick is the max number of moves dispatched in one tick.
+	perTick int
+	// perCapture is the max number of in-flight moves from or to a capture.
+	perCapture int
+
+	// queue holds moves in the order they are planned, a span is queued at
+	// most once, the latest planned destination wins.
+	queue  []*replication.MoveTable
+	queued *spanz.HashMap[*replication.MoveTable]
+
+	// executed is the number of moves dispatched since the last collection.
+	executed int
+}
+
+func newMoveBudget(changefeedID model.ChangeFeedID, perTick, perCapture int) *moveBudget {
+	return &moveBudget{
+		changefeedID: changefeedID,
+		perTick:      perTick,
+		perCapture:   perCapture,
+		queued:       spanz.NewHashMap[*replication.MoveTable](),
+	}
+}
+
+func (b *moveBudget) enabled() bool {
+	return b.perTick > 0 || b.perCapture > 0
+}
+
+func (b *moveBudget) pending() bool {
+	return len(b.queue) != 0
+}
+
+// limit queues moves in tasks and returns the tasks within the budget. Tasks
+// other than moves are returned as is.
+func (b *moveBudget) limit(
+	tasks []*replication.ScheduleTask,
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+	runningTasks *spanz.BtreeMap[*replication.ScheduleTask],
+) []*replication.ScheduleTask {
+	if !b.enabled() || len(tasks) == 0 {
+		return tasks
+	}
+	ret := make([]*replication.ScheduleTask, 0, len(tasks))
+	for _, task := range tasks {
+		switch {
+		case task.MoveTable != nil:
+			b.enqueue(*task.MoveTable)
+		case task.BurstBalance != nil && len(task.BurstBalance.MoveTables) != 0:
+			for _, move := range task.BurstBalance.MoveTables {
+				b.enqueue(move)
+			}
+			if len(task.BurstBalance.AddTables) != 0 ||
+				len(task.BurstBalance.RemoveTables) != 0 {
+				ret = append(ret, &replication.ScheduleTask{
+					BurstBalance: &replication.BurstBalance{
+						AddTables:    task.BurstBalance.AddTables,
+						RemoveTables: task.BurstBalance.RemoveTables,
+					},
+				})
+			}
+		default:
+			ret = append(ret, task)
+			continue
+		}
+		// The request, e.g. a manual rebalance, is accepted once its moves
+		// are queued.
+		if task.Accept != nil {
+			task.Accept()
+		}
+	}
+	return append(ret, b.dispatch(captures, replications, runningTasks)...)
+}
+
+func (b *moveBudget) enqueue(move replication.MoveTable) {
+	if queued, ok := b.queued.Get(move.Span); ok {
+		queued.DestCapture = move.DestCapture
+		return
+	}
+	b.queue = append(b.queue, &move)
+	b.queued.ReplaceOrInsert(move.Span, &move)
+}
+
+// dispatch returns queued moves within the budget. Moves which are no longer
+// valid, e.g. the table is removed or the destination capture is gone, are
+// dropped.
+func (b *moveBudget) dispatch(
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+	runningTasks *spanz.BtreeMap[*replication.ScheduleTask],
+) []*replication.ScheduleTask {
+	inflight := make(map[model.CaptureID]int)
+	runningTasks.Ascend(func(span tablepb.Span, _ *replication.ScheduleTask) bool {
+		// A table is moving if it is replicated by more than one capture.
+		if rep, ok := replications.Get(span); ok && len(rep.Captures) > 1 {
+			for captureID := range rep.Captures {
+				inflight[captureID]++
+			}
+		}
+		return true
+	})
+	withinCaptureBudget := func(captureID model.CaptureID) bool {
+		return b.perCapture <= 0 || inflight[captureID] < b.perCapture
+	}
+
+	var tasks []*replication.ScheduleTask
+	remains := make([]*replication.MoveTable, 0, len(b.queue))
+	dropped := 0
+	for _, move := range b.queue {
+		rep, ok := replications.Get(move.Span)
+		capture, alive := captures[move.DestCapture]
+		if !ok || !alive || capture.State != member.CaptureStateInitialized ||
+			rep.State != replication.ReplicationSetStateReplicating ||
+			rep.Primary == move.DestCapture || runningTasks.Has(move.Span) {
+			b.queued.Delete(move.Span)
+			dropped++
+			continue
+		}
+		if (b.perTick > 0 && len(tasks) >= b.perTick) ||
+			!withinCaptureBudget(rep.Primary) || !withinCaptureBudget(move.DestCapture) {
+			remains = append(remains, move)
+			continue
+		}
+		b.queued.Delete(move.Span)
+		tasks = append(tasks, &replication.ScheduleTask{MoveTable: move})
+		inflight[rep.Primary]++
+		inflight[move.DestCapture]++
+	}
+	b.queue = remains
+	b.executed += len(tasks)
+	if len(tasks) != 0 || dropped != 0 {
+		log.Info("schedulerv3: dispatch queued moves",
+			zap.String("namespace", b.changefeedID.Namespace),
+			zap.String("changefeed", b.changefeedID.ID),
+			zap.Int("dispatched", len(tasks)),
+			zap.Int("dropped", dropped),
+			zap.Int("queued", len(b.queue)))
+	}
+	return tasks
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func moveTask(tableID model.TableID, dest model.CaptureID) *replication.ScheduleTask {
+	return &replication.ScheduleTask{MoveTable: &replication.MoveTable{
+		Span: tablepb.Span{TableID: tableID}, DestCapture: dest,
+	}}
+}
+
+func TestMoveBudgetPerTick(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {State: member.CaptureStateInitialized},
+		"b": {State: member.CaptureStateInitialized},
+	}
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{})
+	for i := model.TableID(1); i <= 5; i++ {
+		replications.ReplaceOrInsert(tablepb.Span{TableID: i}, &replication.ReplicationSet{
+			State: replication.ReplicationSetStateReplicating, Primary: "a",
+		})
+	}
+	runningTasks := mapToSpanMap(map[model.TableID]*replication.ScheduleTask{})
+
+	// Disabled budget does not limit moves.
+	b := newMoveBudget(model.ChangeFeedID{}, 0, 0)
+	tasks := []*replication.ScheduleTask{moveTask(1, "b"), moveTask(2, "b")}
+	require.Equal(t, tasks, b.limit(tasks, captures, replications, runningTasks))
+	require.False(t, b.pending())
+
+	b = newMoveBudget(model.ChangeFeedID{}, 2, 0)
+	accepted := false
+	tasks = []*replication.ScheduleTask{
+		{
+			BurstBalance: &replication.BurstBalance{MoveTables: []replication.MoveTable{
+				{Span: tablepb.Span{TableID: 1}, DestCapture: "b"},
+				{Span: tablepb.Span{TableID: 2}, DestCapture: "b"},
+				{Span: tablepb.Span{TableID: 3}, DestCapture: "b"},
+			}},
+			Accept: func() { accepted = true },
+		},
+		moveTask(4, "b"),
+		moveTask(5, "b"),
+		{AddTable: &replication.AddTable{Span: tablepb.Span{TableID: 6}, CaptureID: "a"}},
+	}
+	tasks = b.limit(tasks, captures, replications, runningTasks)
+	require.True(t, accepted)
+	require.Len(t, tasks, 3)
+	require.NotNil(t, tasks[0].AddTable)
+	require.Equal(t, int64(1), tasks[1].MoveTable.Span.TableID)
+	require.Equal(t, int64(2), tasks[2].MoveTable.Span.TableID)
+	require.Len(t, b.queue, 3)
+
+	// The latest planned destination wins.
+	b.enqueue(replication.MoveTable{Span: tablepb.Span{TableID: 3}, DestCapture: "c"})
+	require.Len(t, b.queue, 3)
+	require.Equal(t, "c", b.queue[0].DestCapture)
+
+	// Table 3 is dropped as capture c does not exist, table 5 is dropped as
+	// it is removed.
+	replications.Delete(tablepb.Span{TableID: 5})
+	tasks = b.dispatch(captures, replications, runningTasks)
+	require.Len(t, tasks, 1)
+	require.Equal(t, int64(4), tasks[0].MoveTable.Span.TableID)
+	require.False(t, b.pending())
+	require.Equal(t, 0, b.queued.Len())
+	require.Equal(t, 3, b.executed)
+}
+
+func TestMoveBudgetPerCapture(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {State: member.CaptureStateInitialized},
+		"b": {State: member.CaptureStateInitialized},
+		"c": {State: member.CaptureStateInitialized},
+		"d": {State: member.CaptureStateInitialized},
+	}
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+		// Table 4 is moving from capture c to capture d.
+		4: {
+			State: replication.ReplicationSetStatePrepare, Primary: "c",
+			Captures: map[model.CaptureID]replication.Role{
+				"c": replication.RolePrimary, "d": replication.RoleSecondary,
+			},
+		},
+	})
+	runningTasks := mapToSpanMap(map[model.TableID]*replication.ScheduleTask{
+		4: moveTask(4, "d"),
+	})
+
+	b := newMoveBudget(model.ChangeFeedID{}, 0, 1)
+	tasks := b.limit([]*replication.ScheduleTask{
+		moveTask(1, "b"), moveTask(2, "c"), moveTask(3, "d"),
+	}, captures, replications, runningTasks)
+	// Table 2 waits for capture a, table 3 waits for capture d.
+	require.Len(t, tasks, 1)
+	require.Equal(t, int64(1), tasks[0].MoveTable.Span.TableID)
+	require.Len(t, b.queue, 2)
+
+	// Moves are dispatched once the previous moves finish.
+	replications.GetV(tablepb.Span{TableID: 4}).Captures = nil
+	runningTasks.Delete(tablepb.Span{TableID: 4})
+	tasks = b.dispatch(captures, replications, runningTasks)
+	require.Len(t, tasks, 2)
+	require.False(t, b.pending())
+}
+
+func TestSchedulerManagerMoveBudget(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.NewDefaultSchedulerConfig()
+	cfg.MaxMovesPerTick = 1
+	m := NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"), cfg, nil)
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {State: member.CaptureStateInitialized},
+		"b": {State: member.CaptureStateInitialized},
+	}
+	currentSpans := []tablepb.Span{
+		{TableID: 1}, {TableID: 2}, {TableID: 3}, {TableID: 4},
+	}
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+	})
+	runningTasks := mapToSpanMap(map[model.TableID]*replication.ScheduleTask{})
+
+	// Rebalance moves 2 tables, one of them is queued.
+	m.Rebalance()
+	tasks := m.Schedule(0, currentSpans, captures, replications, runningTasks)
+	require.Len(t, tasks, 1)
+	require.NotNil(t, tasks[0].MoveTable)
+	require.False(t, m.Snapshot().Rebalance)
+	require.Len(t, m.moves.queue, 1)
+
+	// The queued move is dispatched before balancing plans new moves.
+	tasks = m.Schedule(0, currentSpans, captures, replications, runningTasks)
+	require.Len(t, tasks, 1)
+	require.NotNil(t, tasks[0].MoveTable)
+	require.False(t, m.moves.pending())
+
+	m.CollectMetrics()
+	require.Equal(t, 0, m.moves.executed)
+	m.CleanMetrics()
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+)
+
+// PlannedTasks are the tasks planned by a scheduler.
+type PlannedTasks struct {
+	Scheduler string
+	Tasks     []*replication.ScheduleTask
+}
+
+// Plan returns the tasks that schedulers would generate on the inputs,
+// without changing the manager. Schedulers are rebuilt by the policy of the
+// changefeed, with the queued requests and the states of tables copied from
+// the manager, schedulers of policies other than the default one start with
+// empty states, and periodic balance checks are planned as if they're due.
+// Unlike Schedule, which returns the tasks of the first
+// scheduler generating tasks only, tasks of all schedulers are returned in
+// the priority order. If rebalance is true, a manual rebalance is planned as
+// if it's requested.
+func (sm *Manager) Plan(
+	seed int64,
+	rebalance bool,
+	checkpointTs model.Ts,
+	currentSpans []tablepb.Span,
+	aliveCaptures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) ([]PlannedTasks, error) {
+	policy, err := NewPolicy(sm.changefeedID, sm.cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	preview := NewSchedulerManager(sm.changefeedID, sm.cfg, policy)
+	// Schedulers only read the states, they're shared with the preview.
+	*preview.affinity = *sm.affinity
+	*preview.captureLabels = *sm.captureLabels
+	if sm.zones != nil {
+		*preview.zones = *sm.zones
+	}
+	snapshot := sm.Snapshot()
+	snapshot.Rebalance = snapshot.Rebalance || rebalance
+	preview.Restore(snapshot, seed)
+
+	aliveCaptures = preview.captureLabels.filter(aliveCaptures)
+	var planned []PlannedTasks
+	for _, scheduler := range preview.schedulers {
+		tasks := scheduler.Schedule(checkpointTs, currentSpans, aliveCaptures, replications)
+		if len(tasks) == 0 {
+			continue
+		}
+		planned = append(planned, PlannedTasks{
+			Scheduler: scheduler.Name(),
+			Tasks:     tasks,
+		})
+	}
+	return planned, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// DefaultPolicy is the name of the builtin policy, which uses builtin
+// schedulers only.
+const DefaultPolicy = "default"
+
+// CaptureDrainer is a scheduler which moves all tables out of a capture, e.g.
+// a capture which is stopping or drained by the drain capture API.
+type CaptureDrainer interface {
+	Scheduler
+	// SetTarget sets the capture to drain, it returns false if another
+	// capture is being drained.
+	SetTarget(target model.CaptureID) bool
+	// ResetTarget stops draining the target capture, it returns false if the
+	// target capture is not being drained.
+	ResetTarget(target model.CaptureID) bool
+	// Target returns the capture being drained, it is empty if there is no
+	// capture being drained.
+	Target() model.CaptureID
+}
+
+// Policy decides how tables of a changefeed are scheduled automatically.
+// A nil scheduler is replaced by the builtin one. Manual requests, i.e.
+// moving tables, resetting tables and rebalancing, are always handled by
+// builtin schedulers. Table affinity rules are respected by builtin
+// schedulers only, while capture labels are respected by all schedulers, as
+// they are only given the captures carrying the labels.
+type Policy struct {
+	// Basic adds tables to and removes tables from captures. Its tasks are
+	// not limited by the max task concurrency.
+	Basic Scheduler
+	// DrainCapture moves tables out of stopping captures.
+	DrainCapture CaptureDrainer
+	// Balance balances tables among captures.
+	Balance Scheduler
+}
+
+// PolicyBuilder builds a policy for the changefeed.
+type PolicyBuilder func(
+	changefeedID model.ChangeFeedID, cfg *config.SchedulerConfig,
+) (*Policy, error)
+
+var policies = struct {
+	sync.RWMutex
+	builders map[string]PolicyBuilder
+}{
+	builders: map[string]PolicyBuilder{
+		DefaultPolicy: func(model.ChangeFeedID, *config.SchedulerConfig) (*Policy, error) {
+			return &Policy{}, nil
+		},
+	},
+}
+
+// RegisterPolicy makes a policy available by the name, changefeeds select it
+// by the `scheduler.policy` replica config. It panics if the name is
+// registered twice.
+func RegisterPolicy(name string, builder PolicyBuilder) {
+	policies.Lock()
+	defer policies.Unlock()
+	if _, ok := policies.builders[name]; ok {
+		log.Panic("schedulerv3: policy registered twice", zap.String("policy", name))
+	}
+	policies.builders[name] = builder
+}
+
+// ValidatePolicy returns an error if there is no policy registered by the
+// name. An empty name means the default policy.
+func ValidatePolicy(name string) error {
+	_, err := getPolicyBuilder(name)
+	return err
+}
+
+func getPolicyBuilder(name string) (PolicyBuilder, error) {
+	if name == "" {
+		name = DefaultPolicy
+	}
+	policies.RLock()
+	defer policies.RUnlock()
+	builder, ok := policies.builders[name]
+	if !ok {
+		return nil, cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("unknown scheduler policy %s", name))
+	}
+	return builder, nil
+}
+
+// NewPolicy builds the policy selected by the changefeed settings of cfg.
+func NewPolicy(
+	changefeedID model.ChangeFeedID, cfg *config.SchedulerConfig,
+) (*Policy, error) {
+	name := DefaultPolicy
+	if cfg.ChangefeedSettings != nil && cfg.ChangefeedSettings.Policy != "" {
+		name = cfg.ChangefeedSettings.Policy
+	}
+	builder, err := getPolicyBuilder(name)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := builder(changefeedID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("schedulerv3: scheduler policy selected",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.String("policy", name))
+	return policy, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Scheduler = &mockScheduler{}
+
+type mockScheduler struct {
+	tasks []*replication.ScheduleTask
+}
+
+func (m *mockScheduler) Name() string {
+	return "mock-scheduler"
+}
+
+func (m *mockScheduler) Schedule(
+	_ model.Ts,
+	_ []tablepb.Span,
+	_ map[model.CaptureID]*member.CaptureStatus,
+	_ *spanz.BtreeMap[*replication.ReplicationSet],
+) []*replication.ScheduleTask {
+	return m.tasks
+}
+
+var _ CaptureDrainer = &mockCaptureDrainer{}
+
+type mockCaptureDrainer struct {
+	mockScheduler
+	target model.CaptureID
+}
+
+func (m *mockCaptureDrainer) SetTarget(target model.CaptureID) bool {
+	m.target = target
+	return true
+}
+
+func (m *mockCaptureDrainer) ResetTarget(target model.CaptureID) bool {
+	if m.target != target {
+		return false
+	}
+	m.target = ""
+	return true
+}
+
+func (m *mockCaptureDrainer) Target() model.CaptureID {
+	return m.target
+}
+
+func TestPolicyRegistry(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidatePolicy(""))
+	require.NoError(t, ValidatePolicy(DefaultPolicy))
+	err := ValidatePolicy("test-policy-registry")
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+
+	var built model.ChangeFeedID
+	RegisterPolicy("test-policy-registry",
+		func(changefeedID model.ChangeFeedID, _ *config.SchedulerConfig) (*Policy, error) {
+			built = changefeedID
+			return &Policy{Balance: &mockScheduler{}}, nil
+		})
+	require.NoError(t, ValidatePolicy("test-policy-registry"))
+	require.Panics(t, func() {
+		RegisterPolicy("test-policy-registry", nil)
+	})
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	cfg := config.NewDefaultSchedulerConfig()
+	policy, err := NewPolicy(changefeedID, cfg)
+	require.NoError(t, err)
+	require.Equal(t, &Policy{}, policy)
+
+	cfg.ChangefeedSettings = &config.ChangefeedSchedulerConfig{
+		Policy: "test-policy-registry",
+	}
+	policy, err = NewPolicy(changefeedID, cfg)
+	require.NoError(t, err)
+	require.Equal(t, changefeedID, built)
+	require.IsType(t, &mockScheduler{}, policy.Balance)
+
+	cfg.ChangefeedSettings.Policy = "unknown"
+	_, err = NewPolicy(changefeedID, cfg)
+	require.True(t, cerror.ErrInvalidReplicaConfig.Equal(err))
+}
+
+func TestSchedulerManagerWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {State: member.CaptureStateInitialized},
+		"b": {State: member.CaptureStateInitialized},
+	}
+	basicTask := &replication.ScheduleTask{
+		AddTable: &replication.AddTable{Span: spanz.TableIDToComparableSpan(1)},
+	}
+	drainCapture := &mockCaptureDrainer{}
+	policy := &Policy{
+		Basic:        &mockScheduler{tasks: []*replication.ScheduleTask{basicTask}},
+		DrainCapture: drainCapture,
+	}
+	m := NewSchedulerManager(model.ChangeFeedID{}, config.NewDefaultSchedulerConfig(), policy)
+	// Schedulers not provided by the policy are builtin ones.
+	require.IsType(t, &balanceScheduler{}, m.schedulers[schedulerPriorityBalance])
+	require.IsType(t, &rebalanceScheduler{}, m.schedulers[schedulerPriorityRebalance])
+
+	tasks := m.Schedule(0, spanz.ArrayToSpan([]model.TableID{1}), captures,
+		spanz.NewBtreeMap[*replication.ReplicationSet](),
+		spanz.NewBtreeMap[*replication.ScheduleTask]())
+	require.Equal(t, []*replication.ScheduleTask{basicTask}, tasks)
+
+	// Drain capture requests are served by the policy.
+	require.True(t, m.DrainCapture("a"))
+	require.Equal(t, "a", drainCapture.target)
+	require.Equal(t, "a", m.DrainingTarget())
+
+	// Snapshot and restore work with schedulers of the policy.
+	s := m.Snapshot()
+	require.Equal(t, "a", s.DrainingTarget)
+	restored := &mockCaptureDrainer{}
+	m = NewSchedulerManager(model.ChangeFeedID{}, config.NewDefaultSchedulerConfig(),
+		&Policy{Basic: &mockScheduler{}, DrainCapture: restored})
+	m.Restore(s, 1)
+	m.Seed(1)
+	require.Equal(t, "a", restored.target)
+}
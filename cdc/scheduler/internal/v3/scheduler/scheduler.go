@@ -14,6 +14,8 @@
 package scheduler
 
 import (
+	"sort"
+
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
@@ -21,7 +23,9 @@ import (
 	"github.com/pingcap/tiflow/pkg/spanz"
 )
 
-type scheduler interface {
+// Scheduler generates schedule tasks based on the inputs. It is called by the
+// scheduler manager on every tick.
+type Scheduler interface {
 	Name() string
 	Schedule(
 		checkpointTs model.Ts,
@@ -36,12 +40,35 @@ type scheduler interface {
 type schedulerPriority int
 
 const (
-	// schedulerPriorityBasic has the highest priority.
-	schedulerPriorityBasic schedulerPriority = iota
+	// schedulerPriorityResetTable has the highest priority, so that a reset
+	// table is added back at its start ts, instead of the checkpoint ts by the
+	// basic scheduler.
+	schedulerPriorityResetTable schedulerPriority = iota
+	// schedulerPriorityBasic has the highest priority except resetting tables.
+	schedulerPriorityBasic
 	// schedulerPriorityDrainCapture has higher priority than other schedulers.
 	schedulerPriorityDrainCapture
+	// schedulerPriorityCaptureLabels has higher priority than other schedulers
+	// moving tables, so that tables are moved to the captures carrying the
+	// capture labels as soon as possible.
+	schedulerPriorityCaptureLabels
 	schedulerPriorityMoveTable
 	schedulerPriorityRebalance
+	// schedulerPriorityAffinity has higher priority than balance, so that
+	// pinned tables are moved to their captures before balancing.
+	schedulerPriorityAffinity
 	schedulerPriorityBalance
 	schedulerPriorityMax
 )
+
+// sortedCaptureIDs returns capture IDs in the map in order. Schedulers iterate
+// captures in order, so that schedule tasks only depend on their inputs and
+// random sources.
+func sortedCaptureIDs[T any](captures map[model.CaptureID]T) []model.CaptureID {
+	ids := make([]model.CaptureID, 0, len(captures))
+	for id := range captures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"math"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/zap"
+)
+
+var _ Scheduler = &affinityScheduler{}
+
+// affinityScheduler moves pinned tables to the captures matching their
+// affinity rules. Pinned tables are replicated by other captures if none of
+// the matched captures is available, e.g., they are offline or stopping, and
+// the scheduler moves them back once a matched capture is available again.
+type affinityScheduler struct {
+	affinity           *affinity
+	maxTaskConcurrency int
+	changefeedID       model.ChangeFeedID
+}
+
+func newAffinityScheduler(
+	affinity *affinity, concurrency int, changefeed model.ChangeFeedID,
+) *affinityScheduler {
+	return &affinityScheduler{
+		affinity:           affinity,
+		maxTaskConcurrency: concurrency,
+		changefeedID:       changefeed,
+	}
+}
+
+func (a *affinityScheduler) Name() string {
+	return "affinity-scheduler"
+}
+
+func (a *affinityScheduler) Schedule(
+	_ model.Ts,
+	_ []tablepb.Span,
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) []*replication.ScheduleTask {
+	if !a.affinity.hasPinnedTables() {
+		return nil
+	}
+
+	// Currently, the workload is the number of tables in a capture.
+	captureWorkload := make(map[model.CaptureID]int)
+	for id, capture := range captures {
+		if capture.State == member.CaptureStateInitialized {
+			captureWorkload[id] = 0
+		}
+	}
+	captureIDs := sortedCaptureIDs(captureWorkload)
+
+	type victim struct {
+		span       tablepb.Span
+		candidates []model.CaptureID
+	}
+	victims := make([]victim, 0)
+	replications.Ascend(func(span tablepb.Span, rep *replication.ReplicationSet) bool {
+		if rep.State != replication.ReplicationSetStateReplicating {
+			return true
+		}
+		if _, ok := captureWorkload[rep.Primary]; ok {
+			captureWorkload[rep.Primary]++
+		}
+		if len(victims) >= a.maxTaskConcurrency {
+			return true
+		}
+		matched := a.affinity.match(span.TableID, captureIDs, captures)
+		if len(matched) == 0 {
+			return true
+		}
+		for _, id := range matched {
+			if id == rep.Primary {
+				return true
+			}
+		}
+		victims = append(victims, victim{span: span, candidates: matched})
+		return true
+	})
+
+	tasks := make([]*replication.ScheduleTask, 0, len(victims))
+	for _, v := range victims {
+		target := ""
+		minWorkload := math.MaxInt64
+		for _, captureID := range v.candidates {
+			if workload := captureWorkload[captureID]; workload < minWorkload {
+				minWorkload = workload
+				target = captureID
+			}
+		}
+		log.Info("schedulerv3: move a pinned table to the matched capture",
+			zap.String("namespace", a.changefeedID.Namespace),
+			zap.String("changefeed", a.changefeedID.ID),
+			zap.String("span", v.span.String()),
+			zap.String("target", target))
+		tasks = append(tasks, &replication.ScheduleTask{
+			MoveTable: &replication.MoveTable{
+				Span:        v.span,
+				DestCapture: target,
+			},
+			Accept: (replication.Callback)(nil), // No need for accept callback here.
+		})
+		captureWorkload[target]++
+	}
+	return tasks
+}
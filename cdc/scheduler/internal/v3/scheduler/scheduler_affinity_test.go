@@ -0,0 +1,186 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAffinity() *affinity {
+	a := newAffinity(model.ChangeFeedID{}, []*config.AffinityRule{
+		{Matcher: []string{"test.t1"}, Captures: []string{"a"}},
+		{Matcher: []string{"pinned.*"}, Labels: map[string]string{"zone": "z1"}},
+		{Matcher: []string{"test.t1["}, Captures: []string{"b"}},
+	})
+	a.updateTableNames(map[model.TableID]model.TableName{
+		1: {Schema: "test", Table: "t1", TableID: 1},
+		2: {Schema: "test", Table: "t2", TableID: 2},
+		3: {Schema: "PINNED", Table: "t3", TableID: 3},
+		4: {Schema: "pinned", Table: "t4", TableID: 4, IsPartition: true},
+	})
+	return a
+}
+
+func TestAffinityMatch(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAffinity()
+	// The invalid rule is ignored.
+	require.Len(t, a.rules, 2)
+	require.True(t, a.isPinned(1))
+	require.False(t, a.isPinned(2))
+	require.True(t, a.isPinned(3))
+	require.True(t, a.isPinned(4))
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {ID: "a"},
+		"b": {ID: "b", Labels: map[string]string{"zone": "z1"}},
+		"c": {ID: "c", Labels: map[string]string{"zone": "z1", "disk": "ssd"}},
+	}
+	ids := sortedCaptureIDs(captures)
+	require.Equal(t, []model.CaptureID{"a"}, a.match(1, ids, captures))
+	require.Nil(t, a.match(2, ids, captures))
+	require.Equal(t, []model.CaptureID{"b", "c"}, a.match(3, ids, captures))
+	require.Nil(t, a.match(1, []model.CaptureID{"b", "c"}, captures))
+
+	var nilAffinity *affinity
+	require.False(t, nilAffinity.isPinned(1))
+	require.Nil(t, nilAffinity.match(1, ids, captures))
+}
+
+func TestAffinityBasicScheduler(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {ID: "a"},
+		"b": {ID: "b", Labels: map[string]string{"zone": "z1"}},
+		"c": {ID: "c"},
+	}
+	b := newBasicScheduler(10, model.ChangeFeedID{})
+	b.affinity = newTestAffinity()
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{})
+	tasks := b.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	for _, add := range tasks[0].BurstBalance.AddTables {
+		switch add.Span.TableID {
+		case 1:
+			require.Equal(t, "a", add.CaptureID)
+		case 3, 4:
+			require.Equal(t, "b", add.CaptureID)
+		}
+	}
+
+	// Pinned tables are added to other captures, if matched captures are
+	// not available.
+	captures["a"].State = member.CaptureStateStopping
+	delete(captures, "b")
+	tasks = b.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	for _, add := range tasks[0].BurstBalance.AddTables {
+		require.Equal(t, "c", add.CaptureID)
+	}
+}
+
+func TestAffinityScheduler(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAffinity()
+	sched := newAffinityScheduler(a, 1, model.ChangeFeedID{})
+	require.Equal(t, "affinity-scheduler", sched.Name())
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {ID: "a", State: member.CaptureStateStopping},
+		"b": {ID: "b", State: member.CaptureStateInitialized},
+		"c": {
+			ID: "c", State: member.CaptureStateInitialized,
+			Labels: map[string]string{"zone": "z1"},
+		},
+	}
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "c"},
+	})
+	// Table 1 is not moved, since capture "a" is stopping.
+	tasks := sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.MoveTable{
+		Span: tablepb.Span{TableID: 3}, DestCapture: "c",
+	}, tasks[0].MoveTable)
+
+	// Move table 1 back once capture "a" is available again.
+	captures["a"].State = member.CaptureStateInitialized
+	replications.GetV(tablepb.Span{TableID: 3}).Primary = "c"
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.MoveTable{
+		Span: tablepb.Span{TableID: 1}, DestCapture: "a",
+	}, tasks[0].MoveTable)
+
+	replications.GetV(tablepb.Span{TableID: 1}).Primary = "a"
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+}
+
+func TestAffinityBalance(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {ID: "a"}, "b": {ID: "b"},
+	}
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		5: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+	})
+	// Only unpinned table 5 can be moved.
+	moves := newBalanceMoveTables(
+		nil, captures, replications, newTestAffinity(), nil, 10, model.ChangeFeedID{})
+	require.Equal(t, []replication.MoveTable{{
+		Span: tablepb.Span{TableID: 5}, DestCapture: "b",
+	}}, moves)
+}
+
+func TestAffinityDrainCapture(t *testing.T) {
+	t.Parallel()
+
+	sched := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	sched.affinity = newTestAffinity()
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {ID: "a"},
+		"b": {ID: "b"},
+		"c": {ID: "c", Labels: map[string]string{"zone": "z1"}},
+	}
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "c"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+	})
+	require.True(t, sched.SetTarget("a"))
+	tasks := sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 1)
+	// Capture "c" holds more tables, but it matches the rule of table 3.
+	require.Equal(t, "c", tasks[0].MoveTable.DestCapture)
+}
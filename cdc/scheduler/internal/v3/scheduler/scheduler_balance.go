@@ -25,7 +25,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/spanz"
 )
 
-var _ scheduler = &balanceScheduler{}
+var _ Scheduler = &balanceScheduler{}
 
 // The scheduler for balancing tables among all captures.
 type balanceScheduler struct {
@@ -39,6 +39,11 @@ type balanceScheduler struct {
 	// `Schedule`.
 	// It speeds up rebalance.
 	forceBalance bool
+	// affinity pins tables to captures, pinned tables are not moved.
+	affinity *affinity
+	// zones prefers moving tables to captures in the zones of their region
+	// leaders, it is nil if zone-aware scheduling is disabled.
+	zones *zones
 
 	maxTaskConcurrency int
 }
@@ -78,7 +83,7 @@ func (b *balanceScheduler) Schedule(
 	}
 
 	tasks := buildBalanceMoveTables(
-		b.random, captures, replications, b.maxTaskConcurrency)
+		b.random, captures, replications, b.affinity, b.zones, b.maxTaskConcurrency)
 	b.forceBalance = len(tasks) != 0
 	return tasks
 }
@@ -87,10 +92,12 @@ func buildBalanceMoveTables(
 	random *rand.Rand,
 	captures map[model.CaptureID]*member.CaptureStatus,
 	replications *spanz.BtreeMap[*replication.ReplicationSet],
+	affinity *affinity,
+	zones *zones,
 	maxTaskConcurrency int,
 ) []*replication.ScheduleTask {
 	moves := newBalanceMoveTables(
-		random, captures, replications, maxTaskConcurrency, model.ChangeFeedID{})
+		random, captures, replications, affinity, zones, maxTaskConcurrency, model.ChangeFeedID{})
 	tasks := make([]*replication.ScheduleTask, 0, len(moves))
 	for i := 0; i < len(moves); i++ {
 		// No need for accept callback here.
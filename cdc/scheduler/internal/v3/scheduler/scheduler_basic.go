@@ -26,7 +26,7 @@ import (
 	"go.uber.org/zap"
 )
 
-var _ scheduler = &basicScheduler{}
+var _ Scheduler = &basicScheduler{}
 
 // The basic scheduler for adding and removing tables, it tries to keep
 // every table get replicated.
@@ -36,7 +36,16 @@ var _ scheduler = &basicScheduler{}
 // 2. DDL CREATE/DROP/TRUNCATE TABLE
 // 3. Capture offline.
 type basicScheduler struct {
-	batchSize    int
+	batchSize int
+	// batch adapts the batch size to the latency of adding tables,
+	// it is nil if the batch size is static.
+	batch *addTableBatch
+	// affinity pins tables to captures, pinned tables are added to the
+	// captures matching their rules.
+	affinity *affinity
+	// zones prefers captures in the zones of region leaders of new tables,
+	// it is nil if zone-aware scheduling is disabled.
+	zones        *zones
 	random       *rand.Rand
 	changefeedID model.ChangeFeedID
 }
@@ -63,8 +72,12 @@ func (b *basicScheduler) Schedule(
 	tablesLenEqual := len(currentSpans) == replications.Len()
 	tablesAllFind := true
 	newSpans := make([]tablepb.Span, 0)
+	batchSize := b.batchSize
+	if b.batch != nil {
+		batchSize = b.batch.available(replications)
+	}
 	for _, span := range currentSpans {
-		if len(newSpans) >= b.batchSize {
+		if len(newSpans) >= batchSize {
 			break
 		}
 		rep, ok := replications.Get(span)
@@ -83,7 +96,8 @@ func (b *basicScheduler) Schedule(
 	// Build add table tasks.
 	if len(newSpans) > 0 {
 		captureIDs := make([]model.CaptureID, 0, len(captures))
-		for captureID, status := range captures {
+		for _, captureID := range sortedCaptureIDs(captures) {
+			status := captures[captureID]
 			if status.State == member.CaptureStateStopping {
 				log.Warn("schedulerv3: capture is stopping, "+
 					"skip the capture when add new table",
@@ -106,13 +120,24 @@ func (b *basicScheduler) Schedule(
 				zap.Any("allCaptureStatus", captures))
 			return tasks
 		}
+		if b.random != nil {
+			// Shuffle captures, so that tables are not always added to the
+			// first few captures.
+			b.random.Shuffle(len(captureIDs), func(i, j int) {
+				captureIDs[i], captureIDs[j] = captureIDs[j], captureIDs[i]
+			})
+		}
 		log.Info("schedulerv3: burst add table",
 			zap.String("namespace", b.changefeedID.Namespace),
 			zap.String("changefeed", b.changefeedID.ID),
 			zap.Strings("captureIDs", captureIDs),
 			zap.Int("tableCount", len(newSpans)))
 		tasks = append(
-			tasks, newBurstAddTables(checkpointTs, newSpans, captureIDs))
+			tasks, newBurstAddTables(
+				checkpointTs, newSpans, captureIDs, captures, b.affinity, b.zones))
+		if b.batch != nil {
+			b.batch.dispatched(newSpans)
+		}
 	}
 
 	// Build remove table tasks.
@@ -152,12 +177,46 @@ func (b *basicScheduler) Schedule(
 }
 
 // newBurstAddTables add each new table to captures in a round-robin way.
+// Pinned tables are added to the captures matching their affinity rules, or
+// to any capture if none of the matched captures is available. Other tables
+// are added to the captures in the zones of their region leaders if possible.
 func newBurstAddTables(
 	checkpointTs model.Ts, newSpans []tablepb.Span, captureIDs []model.CaptureID,
+	captures map[model.CaptureID]*member.CaptureStatus, affinity *affinity, zones *zones,
 ) *replication.ScheduleTask {
 	idx := 0
+	pinnedIdx := 0
+	// Captures in a zone are given at most their even share of new tables,
+	// so that a burst of tables doesn't overload a zone with few captures.
+	zoneLimit := (len(newSpans) + len(captureIDs) - 1) / len(captureIDs)
+	zoneAdded := make(map[model.CaptureID]int)
 	tables := make([]replication.AddTable, 0, len(newSpans))
 	for _, span := range newSpans {
+		if matched := affinity.match(span.TableID, captureIDs, captures); len(matched) > 0 {
+			tables = append(tables, replication.AddTable{
+				Span:         span,
+				CaptureID:    matched[pinnedIdx%len(matched)],
+				CheckpointTs: checkpointTs,
+			})
+			pinnedIdx++
+			continue
+		}
+		target := ""
+		for _, captureID := range zones.match(span, captureIDs, captures) {
+			if zoneAdded[captureID] < zoneLimit &&
+				(target == "" || zoneAdded[captureID] < zoneAdded[target]) {
+				target = captureID
+			}
+		}
+		if target != "" {
+			tables = append(tables, replication.AddTable{
+				Span:         span,
+				CaptureID:    target,
+				CheckpointTs: checkpointTs,
+			})
+			zoneAdded[target]++
+			continue
+		}
 		tables = append(tables, replication.AddTable{
 			Span:         span,
 			CaptureID:    captureIDs[idx],
@@ -181,9 +240,8 @@ func newBurstRemoveTables(
 	for _, span := range rmSpans {
 		rep := replications.GetV(span)
 		var captureID model.CaptureID
-		for id := range rep.Captures {
-			captureID = id
-			break
+		if ids := sortedCaptureIDs(rep.Captures); len(ids) > 0 {
+			captureID = ids[0]
 		}
 		if captureID == "" {
 			log.Warn("schedulerv3: primary or secondary not found for removed table",
@@ -0,0 +1,142 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/zap"
+)
+
+// initialAddTableBatchSize is the batch size an adaptive batch starts with.
+const initialAddTableBatchSize = 4
+
+// addTableBatch adapts the batch size of adding tables to the latency of
+// adding a table, which includes the incremental scan on the capture side.
+//
+// Adding a table is considered as completed once it becomes replicating.
+// Tables that are still being added count against the batch size, so the
+// number of concurrent incremental scans is bounded by the batch size too.
+// If all tables completed within a batch are faster than the latency target,
+// the batch size doubles, otherwise it halves.
+type addTableBatch struct {
+	changefeedID  model.ChangeFeedID
+	latencyTarget time.Duration
+	maxSize       int
+	size          int
+
+	// adding records the time when each table is dispatched.
+	adding *spanz.BtreeMap[time.Time]
+	// maxLatency is the max latency of tables completed since the batch
+	// size is adjusted last time.
+	maxLatency time.Duration
+	completed  int
+
+	now func() time.Time
+}
+
+func newAddTableBatch(
+	changefeedID model.ChangeFeedID, latencyTarget time.Duration, maxSize int,
+) *addTableBatch {
+	size := initialAddTableBatchSize
+	if size > maxSize {
+		size = maxSize
+	}
+	return &addTableBatch{
+		changefeedID:  changefeedID,
+		latencyTarget: latencyTarget,
+		maxSize:       maxSize,
+		size:          size,
+		adding:        spanz.NewBtreeMap[time.Time](),
+		now:           time.Now,
+	}
+}
+
+// available observes tables being added, adjusts the batch size, and returns
+// how many tables can be added now.
+func (b *addTableBatch) available(
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) int {
+	now := b.now()
+	done := make([]tablepb.Span, 0)
+	b.adding.Ascend(func(span tablepb.Span, start time.Time) bool {
+		rep, ok := replications.Get(span)
+		if !ok || rep.State == replication.ReplicationSetStateAbsent {
+			// The table is removed, or adding the table fails.
+			done = append(done, span)
+			return true
+		}
+		if rep.State == replication.ReplicationSetStateReplicating {
+			done = append(done, span)
+			b.completed++
+			if latency := now.Sub(start); latency > b.maxLatency {
+				b.maxLatency = latency
+			}
+		}
+		return true
+	})
+	for _, span := range done {
+		b.adding.Delete(span)
+	}
+
+	// Adjust the batch size once a whole batch completes, or a single table
+	// is already too slow.
+	if b.completed >= b.size || b.maxLatency > b.latencyTarget {
+		b.adjust()
+	}
+
+	if n := b.size - b.adding.Len(); n > 0 {
+		return n
+	}
+	return 0
+}
+
+func (b *addTableBatch) adjust() {
+	old := b.size
+	if b.maxLatency <= b.latencyTarget {
+		b.size *= 2
+		if b.size > b.maxSize {
+			b.size = b.maxSize
+		}
+	} else {
+		b.size /= 2
+		if b.size < 1 {
+			b.size = 1
+		}
+	}
+	if old != b.size {
+		log.Info("schedulerv3: add table batch size adjusted",
+			zap.String("namespace", b.changefeedID.Namespace),
+			zap.String("changefeed", b.changefeedID.ID),
+			zap.Int("oldSize", old),
+			zap.Int("newSize", b.size),
+			zap.Duration("maxLatency", b.maxLatency),
+			zap.Duration("latencyTarget", b.latencyTarget))
+	}
+	b.completed = 0
+	b.maxLatency = 0
+}
+
+// dispatched records tables that are going to be added.
+func (b *addTableBatch) dispatched(spans []tablepb.Span) {
+	now := b.now()
+	for _, span := range spans {
+		b.adding.ReplaceOrInsert(span, now)
+	}
+}
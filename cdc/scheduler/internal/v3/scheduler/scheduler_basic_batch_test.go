@@ -0,0 +1,108 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTableBatchAdjust(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	b := newAddTableBatch(model.ChangeFeedID{}, 10*time.Second, 10)
+	b.now = func() time.Time { return now }
+	replications := spanz.NewBtreeMap[*replication.ReplicationSet]()
+	require.Equal(t, 4, b.available(replications))
+
+	// Tables being added occupy the batch.
+	spans := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4})
+	b.dispatched(spans)
+	for _, span := range spans {
+		replications.ReplaceOrInsert(span, &replication.ReplicationSet{
+			State: replication.ReplicationSetStatePrepare,
+		})
+	}
+	require.Equal(t, 0, b.available(replications))
+
+	// The whole batch completes under the target, the batch size doubles.
+	now = now.Add(time.Second)
+	for _, span := range spans {
+		rep, _ := replications.Get(span)
+		rep.State = replication.ReplicationSetStateReplicating
+	}
+	require.Equal(t, 8, b.available(replications))
+
+	// Doubling is capped by the max size.
+	spans = spanz.ArrayToSpan([]model.TableID{5, 6, 7, 8, 9, 10, 11, 12})
+	b.dispatched(spans)
+	for _, span := range spans {
+		replications.ReplaceOrInsert(span, &replication.ReplicationSet{
+			State: replication.ReplicationSetStateReplicating,
+		})
+	}
+	require.Equal(t, 10, b.available(replications))
+
+	// A slow table halves the batch size immediately.
+	spans = spanz.ArrayToSpan([]model.TableID{13, 14})
+	b.dispatched(spans)
+	replications.ReplaceOrInsert(spans[0], &replication.ReplicationSet{
+		State: replication.ReplicationSetStatePrepare,
+	})
+	replications.ReplaceOrInsert(spans[1], &replication.ReplicationSet{
+		State: replication.ReplicationSetStatePrepare,
+	})
+	require.Equal(t, 8, b.available(replications))
+	now = now.Add(time.Minute)
+	rep, _ := replications.Get(spans[0])
+	rep.State = replication.ReplicationSetStateReplicating
+	require.Equal(t, 4, b.available(replications))
+
+	// Removed tables no longer occupy the batch.
+	replications.Delete(spans[1])
+	require.Equal(t, 5, b.available(replications))
+}
+
+func TestSchedulerBasicAdaptiveBatch(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {State: member.CaptureStateInitialized},
+	}
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4, 5, 6, 7, 8})
+	replications := spanz.NewBtreeMap[*replication.ReplicationSet]()
+	b := newBasicScheduler(50, model.ChangeFeedID{})
+	b.batch = newAddTableBatch(model.ChangeFeedID{}, time.Minute, 50)
+
+	tasks := b.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Len(t, tasks[0].BurstBalance.AddTables, 4)
+	for _, add := range tasks[0].BurstBalance.AddTables {
+		replications.ReplaceOrInsert(add.Span, &replication.ReplicationSet{
+			State:    replication.ReplicationSetStatePrepare,
+			Captures: map[string]replication.Role{"a": replication.RoleSecondary},
+		})
+	}
+
+	// No table can be added until tables being added complete.
+	tasks = b.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+}
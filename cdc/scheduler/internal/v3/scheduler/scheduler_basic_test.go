@@ -189,7 +189,7 @@ func benchmarkSchedulerBalance(
 		currentTables []tablepb.Span,
 		captures map[model.CaptureID]*member.CaptureStatus,
 		replications *spanz.BtreeMap[*replication.ReplicationSet],
-		sched scheduler,
+		sched Scheduler,
 	),
 ) {
 	size := 16384
@@ -211,7 +211,7 @@ func BenchmarkSchedulerBasicAddTables(b *testing.B) {
 		currentTables []tablepb.Span,
 		captures map[model.CaptureID]*member.CaptureStatus,
 		replications *spanz.BtreeMap[*replication.ReplicationSet],
-		sched scheduler,
+		sched Scheduler,
 	) {
 		const captureCount = 8
 		captures = map[model.CaptureID]*member.CaptureStatus{}
@@ -235,7 +235,7 @@ func BenchmarkSchedulerBasicRemoveTables(b *testing.B) {
 		currentTables []tablepb.Span,
 		captures map[model.CaptureID]*member.CaptureStatus,
 		replications *spanz.BtreeMap[*replication.ReplicationSet],
-		sched scheduler,
+		sched Scheduler,
 	) {
 		const captureCount = 8
 		captures = map[model.CaptureID]*member.CaptureStatus{}
@@ -262,7 +262,7 @@ func BenchmarkSchedulerBasicAddRemoveTables(b *testing.B) {
 		currentTables []tablepb.Span,
 		captures map[model.CaptureID]*member.CaptureStatus,
 		replications *spanz.BtreeMap[*replication.ReplicationSet],
-		sched scheduler,
+		sched Scheduler,
 	) {
 		const captureCount = 8
 		captures = map[model.CaptureID]*member.CaptureStatus{}
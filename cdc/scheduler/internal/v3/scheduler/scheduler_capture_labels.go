@@ -0,0 +1,168 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"math"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/zap"
+)
+
+// captureLabels constrains tables of a changefeed to the captures carrying
+// the capture labels of the changefeed. Schedulers only see the matched
+// captures, unless none of the alive captures matches, then tables are
+// scheduled to all captures to keep the changefeed replicating.
+type captureLabels struct {
+	labels       map[string]string
+	fallback     bool
+	changefeedID model.ChangeFeedID
+}
+
+func newCaptureLabels(
+	changefeedID model.ChangeFeedID, labels map[string]string,
+) *captureLabels {
+	return &captureLabels{labels: labels, changefeedID: changefeedID}
+}
+
+// filter returns the captures which tables can be scheduled to.
+func (c *captureLabels) filter(
+	captures map[model.CaptureID]*member.CaptureStatus,
+) map[model.CaptureID]*member.CaptureStatus {
+	if len(c.labels) == 0 {
+		return captures
+	}
+	matched := make(map[model.CaptureID]*member.CaptureStatus, len(captures))
+	for id, capture := range captures {
+		if capture.MatchLabels(c.labels) {
+			matched[id] = capture
+		}
+	}
+	fallback := len(matched) == 0
+	if fallback != c.fallback {
+		c.fallback = fallback
+		if fallback {
+			log.Warn("schedulerv3: no capture carries the capture labels, "+
+				"schedule tables to all captures",
+				zap.String("namespace", c.changefeedID.Namespace),
+				zap.String("changefeed", c.changefeedID.ID),
+				zap.Any("labels", c.labels))
+		} else {
+			log.Info("schedulerv3: captures carrying the capture labels are available",
+				zap.String("namespace", c.changefeedID.Namespace),
+				zap.String("changefeed", c.changefeedID.ID),
+				zap.Any("labels", c.labels))
+		}
+	}
+	if fallback {
+		return captures
+	}
+	return matched
+}
+
+var _ Scheduler = &captureLabelsScheduler{}
+
+// captureLabelsScheduler moves tables out of the captures which don't carry
+// the capture labels of the changefeed. It is given the filtered captures,
+// so tables replicated by captures not in them are moved.
+type captureLabelsScheduler struct {
+	labels             *captureLabels
+	maxTaskConcurrency int
+	changefeedID       model.ChangeFeedID
+}
+
+func newCaptureLabelsScheduler(
+	labels *captureLabels, concurrency int, changefeed model.ChangeFeedID,
+) *captureLabelsScheduler {
+	return &captureLabelsScheduler{
+		labels:             labels,
+		maxTaskConcurrency: concurrency,
+		changefeedID:       changefeed,
+	}
+}
+
+func (c *captureLabelsScheduler) Name() string {
+	return "capture-labels-scheduler"
+}
+
+func (c *captureLabelsScheduler) Schedule(
+	_ model.Ts,
+	_ []tablepb.Span,
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) []*replication.ScheduleTask {
+	if len(c.labels.labels) == 0 || c.labels.fallback {
+		return nil
+	}
+
+	// Currently, the workload is the number of tables in a capture.
+	captureWorkload := make(map[model.CaptureID]int)
+	for id, capture := range captures {
+		if capture.State == member.CaptureStateInitialized {
+			captureWorkload[id] = 0
+		}
+	}
+	if len(captureWorkload) == 0 {
+		return nil
+	}
+
+	victims := make([]tablepb.Span, 0)
+	replications.Ascend(func(span tablepb.Span, rep *replication.ReplicationSet) bool {
+		if rep.State != replication.ReplicationSetStateReplicating {
+			return true
+		}
+		if _, ok := captures[rep.Primary]; ok {
+			if _, ok := captureWorkload[rep.Primary]; ok {
+				captureWorkload[rep.Primary]++
+			}
+			return true
+		}
+		if len(victims) < c.maxTaskConcurrency {
+			victims = append(victims, span)
+		}
+		return true
+	})
+
+	captureIDs := sortedCaptureIDs(captureWorkload)
+	tasks := make([]*replication.ScheduleTask, 0, len(victims))
+	for _, span := range victims {
+		target := ""
+		minWorkload := math.MaxInt64
+		for _, captureID := range captureIDs {
+			if workload := captureWorkload[captureID]; workload < minWorkload {
+				minWorkload = workload
+				target = captureID
+			}
+		}
+		log.Info("schedulerv3: move a table to the capture carrying the capture labels",
+			zap.String("namespace", c.changefeedID.Namespace),
+			zap.String("changefeed", c.changefeedID.ID),
+			zap.String("span", span.String()),
+			zap.String("target", target))
+		tasks = append(tasks, &replication.ScheduleTask{
+			MoveTable: &replication.MoveTable{
+				Span:        span,
+				DestCapture: target,
+			},
+			Accept: (replication.Callback)(nil), // No need for accept callback here.
+		})
+		captureWorkload[target]++
+	}
+	return tasks
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureLabelsFilter(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {ID: "a"},
+		"b": {ID: "b", Labels: map[string]string{"tier": "ssd"}},
+		"c": {ID: "c", Labels: map[string]string{"tier": "ssd", "zone": "z1"}},
+	}
+	c := newCaptureLabels(model.ChangeFeedID{}, nil)
+	require.Equal(t, captures, c.filter(captures))
+
+	c = newCaptureLabels(model.ChangeFeedID{}, map[string]string{"tier": "ssd"})
+	require.Equal(t, []model.CaptureID{"b", "c"}, sortedCaptureIDs(c.filter(captures)))
+	require.False(t, c.fallback)
+
+	// Tables are scheduled to all captures if none of them matches.
+	c = newCaptureLabels(model.ChangeFeedID{}, map[string]string{"tier": "hdd"})
+	require.Equal(t, captures, c.filter(captures))
+	require.True(t, c.fallback)
+	captures["a"].Labels = map[string]string{"tier": "hdd"}
+	require.Equal(t, []model.CaptureID{"a"}, sortedCaptureIDs(c.filter(captures)))
+	require.False(t, c.fallback)
+}
+
+func TestCaptureLabelsScheduler(t *testing.T) {
+	t.Parallel()
+
+	labels := newCaptureLabels(model.ChangeFeedID{}, map[string]string{"tier": "ssd"})
+	sched := newCaptureLabelsScheduler(labels, 2, model.ChangeFeedID{})
+	require.Equal(t, "capture-labels-scheduler", sched.Name())
+
+	captures := labels.filter(map[model.CaptureID]*member.CaptureStatus{
+		"a": {ID: "a", State: member.CaptureStateInitialized},
+		"b": {
+			ID: "b", State: member.CaptureStateInitialized,
+			Labels: map[string]string{"tier": "ssd"},
+		},
+		"c": {
+			ID: "c", State: member.CaptureStateInitialized,
+			Labels: map[string]string{"tier": "ssd"},
+		},
+	})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+		5: {State: replication.ReplicationSetStatePrepare, Primary: "a"},
+	})
+	// Tables on capture "a" are moved, limited by the max task concurrency.
+	tasks := sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 2)
+	require.Equal(t, &replication.MoveTable{
+		Span: tablepb.Span{TableID: 1}, DestCapture: "c",
+	}, tasks[0].MoveTable)
+	require.Equal(t, &replication.MoveTable{
+		Span: tablepb.Span{TableID: 2}, DestCapture: "b",
+	}, tasks[1].MoveTable)
+
+	replications.GetV(tablepb.Span{TableID: 1}).Primary = "c"
+	replications.GetV(tablepb.Span{TableID: 2}).Primary = "b"
+	replications.GetV(tablepb.Span{TableID: 3}).Primary = "c"
+	tasks = sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 0)
+}
+
+func TestSchedulerManagerCaptureLabels(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.NewDefaultSchedulerConfig()
+	cfg.ChangefeedSettings = &config.ChangefeedSchedulerConfig{
+		CaptureLabels: map[string]string{"tier": "ssd"},
+	}
+	m := NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"), cfg, nil)
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {ID: "a", State: member.CaptureStateInitialized},
+		"b": {
+			ID: "b", State: member.CaptureStateInitialized,
+			Labels: map[string]string{"tier": "ssd"},
+		},
+	}
+	currentSpans := spanz.ArrayToSpan([]model.TableID{1, 2})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{})
+	runningTasks := spanz.NewBtreeMap[*replication.ScheduleTask]()
+	tasks := m.Schedule(0, currentSpans, captures, replications, runningTasks)
+	require.Len(t, tasks, 1)
+	require.Len(t, tasks[0].BurstBalance.AddTables, 2)
+	for _, add := range tasks[0].BurstBalance.AddTables {
+		require.Equal(t, "b", add.CaptureID)
+	}
+
+	// Tables are moved out of captures not carrying the labels.
+	replications = mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+	})
+	tasks = m.Schedule(0, currentSpans, captures, replications, runningTasks)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.MoveTable{
+		Span: tablepb.Span{TableID: 1}, DestCapture: "b",
+	}, tasks[0].MoveTable)
+
+	// Manually moving tables to captures not carrying the labels is ignored.
+	replications.GetV(tablepb.Span{TableID: 1}).Primary = "b"
+	m.MoveTable(tablepb.Span{TableID: 1}, "a")
+	tasks = m.Schedule(0, currentSpans, captures, replications, runningTasks)
+	require.Len(t, tasks, 0)
+}
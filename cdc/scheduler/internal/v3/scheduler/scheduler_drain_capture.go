@@ -29,7 +29,7 @@ import (
 // captureIDNotDraining is the default capture ID if the drain target not set
 const captureIDNotDraining = ""
 
-var _ scheduler = &drainCaptureScheduler{}
+var _ CaptureDrainer = &drainCaptureScheduler{}
 
 type drainCaptureScheduler struct {
 	mu     sync.Mutex
@@ -37,6 +37,9 @@ type drainCaptureScheduler struct {
 
 	changefeedID       model.ChangeFeedID
 	maxTaskConcurrency int
+	// affinity pins tables to captures, pinned tables are preferably moved
+	// to the captures matching their rules.
+	affinity *affinity
 }
 
 func newDrainCaptureScheduler(
@@ -53,13 +56,13 @@ func (d *drainCaptureScheduler) Name() string {
 	return "drain-capture-scheduler"
 }
 
-func (d *drainCaptureScheduler) getTarget() model.CaptureID {
+func (d *drainCaptureScheduler) Target() model.CaptureID {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	return d.target
 }
 
-func (d *drainCaptureScheduler) setTarget(target model.CaptureID) bool {
+func (d *drainCaptureScheduler) SetTarget(target model.CaptureID) bool {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	if d.target != captureIDNotDraining {
@@ -70,6 +73,17 @@ func (d *drainCaptureScheduler) setTarget(target model.CaptureID) bool {
 	return true
 }
 
+func (d *drainCaptureScheduler) ResetTarget(target model.CaptureID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if target == captureIDNotDraining || d.target != target {
+		return false
+	}
+
+	d.target = captureIDNotDraining
+	return true
+}
+
 func (d *drainCaptureScheduler) Schedule(
 	_ model.Ts,
 	_ []tablepb.Span,
@@ -83,7 +97,8 @@ func (d *drainCaptureScheduler) Schedule(
 		// There are two ways to make a capture "stopping",
 		// 1. PUT /api/v1/capture/drain
 		// 2. kill <TiCDC_PID>
-		for id, capture := range captures {
+		for _, id := range sortedCaptureIDs(captures) {
+			capture := captures[id]
 			if capture.IsOwner {
 				// Skip draining owner.
 				continue
@@ -148,7 +163,7 @@ func (d *drainCaptureScheduler) Schedule(
 		}
 
 		// only calculate workload of other captures not the drain target.
-		if rep.Primary != d.target {
+		if _, ok := captureWorkload[rep.Primary]; ok {
 			captureWorkload[rep.Primary]++
 		}
 		return true
@@ -172,11 +187,16 @@ func (d *drainCaptureScheduler) Schedule(
 
 	// For each victim table, find the target for it
 	result := make([]*replication.ScheduleTask, 0, maxTaskConcurrency)
+	captureIDs := sortedCaptureIDs(captureWorkload)
 	for _, span := range victimSpans {
 		target := ""
 		minWorkload := math.MaxInt64
-		for captureID, workload := range captureWorkload {
-			if workload < minWorkload {
+		candidates := captureIDs
+		if matched := d.affinity.match(span.TableID, captureIDs, captures); len(matched) > 0 {
+			candidates = matched
+		}
+		for _, captureID := range candidates {
+			if workload := captureWorkload[captureID]; workload < minWorkload {
 				minWorkload = workload
 				target = captureID
 			}
@@ -38,7 +38,7 @@ func TestDrainCapture(t *testing.T) {
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 0)
 
-	ok := scheduler.setTarget("a")
+	ok := scheduler.SetTarget("a")
 	require.True(t, ok)
 
 	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
@@ -47,7 +47,7 @@ func TestDrainCapture(t *testing.T) {
 	require.Equal(t, captureIDNotDraining, scheduler.target)
 
 	captures["a"] = &member.CaptureStatus{}
-	ok = scheduler.setTarget("b")
+	ok = scheduler.SetTarget("b")
 	require.True(t, ok)
 
 	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
@@ -102,7 +102,7 @@ func TestDrainCapture(t *testing.T) {
 		},
 	})
 
-	ok = scheduler.setTarget("a")
+	ok = scheduler.SetTarget("a")
 	require.True(t, ok)
 	// not all table is replicating, skip this tick.
 	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
@@ -123,7 +123,7 @@ func TestDrainCapture(t *testing.T) {
 	require.Len(t, tasks, 3)
 
 	scheduler = newDrainCaptureScheduler(1, model.ChangeFeedID{})
-	require.True(t, scheduler.setTarget("a"))
+	require.True(t, scheduler.SetTarget("a"))
 	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Equal(t, "a", scheduler.target)
 	require.Len(t, tasks, 1)
@@ -151,7 +151,7 @@ func TestDrainStoppingCapture(t *testing.T) {
 	require.Len(t, tasks, 1)
 	require.EqualValues(t, 2, tasks[0].MoveTable.Span.TableID)
 	require.EqualValues(t, "a", tasks[0].MoveTable.DestCapture)
-	require.EqualValues(t, "b", scheduler.getTarget())
+	require.EqualValues(t, "b", scheduler.Target())
 }
 
 func TestDrainSkipOwner(t *testing.T) {
@@ -170,7 +170,7 @@ func TestDrainSkipOwner(t *testing.T) {
 	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 0)
-	require.EqualValues(t, captureIDNotDraining, scheduler.getTarget())
+	require.EqualValues(t, captureIDNotDraining, scheduler.Target())
 }
 
 func TestDrainImbalanceCluster(t *testing.T) {
@@ -187,10 +187,10 @@ func TestDrainImbalanceCluster(t *testing.T) {
 		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 	})
 	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
-	scheduler.setTarget("a")
+	scheduler.SetTarget("a")
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 2)
-	require.EqualValues(t, "a", scheduler.getTarget())
+	require.EqualValues(t, "a", scheduler.Target())
 }
 
 func TestDrainEvenlyDistributedTables(t *testing.T) {
@@ -210,7 +210,7 @@ func TestDrainEvenlyDistributedTables(t *testing.T) {
 		6: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
 	})
 	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
-	scheduler.setTarget("a")
+	scheduler.SetTarget("a")
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 3)
 	taskMap := make(map[model.CaptureID]int)
@@ -0,0 +1,289 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+var _ Scheduler = &loadBalanceScheduler{}
+
+// tableLoad is the throughput of a table, derived from the accumulated
+// counters reported in table stats.
+type tableLoad struct {
+	// primary is the capture that reports the counters. Counters are reset
+	// once a table is moved to another capture.
+	primary    model.CaptureID
+	sampleTime time.Time
+	rows       uint64
+	bytes      uint64
+
+	// ready is true if the throughput has been derived from two samples.
+	ready          bool
+	rowsPerSecond  float64
+	bytesPerSecond float64
+}
+
+// update updates the table load with a new sample of table stats.
+func (l *tableLoad) update(primary model.CaptureID, stats *tablepb.Stats) {
+	if stats.CurrentTs == 0 {
+		// Stats are not collected in the heartbeat.
+		return
+	}
+	sampleTime := oracle.GetTimeFromTS(uint64(stats.CurrentTs))
+	if l.primary != primary ||
+		stats.EmittedRows < l.rows || stats.EmittedBytes < l.bytes {
+		// The table has been moved or restarted, start over.
+		*l = tableLoad{
+			primary:    primary,
+			sampleTime: sampleTime,
+			rows:       stats.EmittedRows,
+			bytes:      stats.EmittedBytes,
+		}
+		return
+	}
+	elapsed := sampleTime.Sub(l.sampleTime).Seconds()
+	if elapsed <= 0 {
+		// It is the same sample.
+		return
+	}
+	l.rowsPerSecond = float64(stats.EmittedRows-l.rows) / elapsed
+	l.bytesPerSecond = float64(stats.EmittedBytes-l.bytes) / elapsed
+	l.ready = true
+	l.sampleTime = sampleTime
+	l.rows = stats.EmittedRows
+	l.bytes = stats.EmittedBytes
+}
+
+// loadBalanceScheduler balances tables among all captures by their
+// throughput, so that a capture holding a few hot tables is not overloaded
+// while other captures hold many cold tables.
+//
+// The load of a table is the bytes per second emitted to its table sink.
+type loadBalanceScheduler struct {
+	changefeedID         model.ChangeFeedID
+	lastRebalanceTime    time.Time
+	checkBalanceInterval time.Duration
+	// threshold is the ratio that the load of a capture may exceed the
+	// average load before its tables are moved away.
+	threshold          float64
+	maxTaskConcurrency int
+	// affinity pins tables to captures, pinned tables are not moved.
+	affinity *affinity
+
+	tables *spanz.BtreeMap[*tableLoad]
+}
+
+func newLoadBalanceScheduler(
+	interval time.Duration, threshold float64, concurrency int,
+	changefeedID model.ChangeFeedID,
+) *loadBalanceScheduler {
+	return &loadBalanceScheduler{
+		changefeedID:         changefeedID,
+		checkBalanceInterval: interval,
+		threshold:            threshold,
+		maxTaskConcurrency:   concurrency,
+		tables:               spanz.NewBtreeMap[*tableLoad](),
+	}
+}
+
+func (b *loadBalanceScheduler) Name() string {
+	return "load-balance-scheduler"
+}
+
+func (b *loadBalanceScheduler) Schedule(
+	_ model.Ts,
+	_ []tablepb.Span,
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) []*replication.ScheduleTask {
+	// Table stats are sampled on every call, as they are only collected in
+	// some of the heartbeats.
+	b.updateTableLoads(replications)
+
+	now := time.Now()
+	if now.Sub(b.lastRebalanceTime) < b.checkBalanceInterval {
+		// skip balance.
+		return nil
+	}
+	b.lastRebalanceTime = now
+
+	for _, capture := range captures {
+		if capture.State == member.CaptureStateStopping {
+			log.Debug("schedulerv3: capture is stopping, premature to balance table")
+			return nil
+		}
+	}
+
+	moves := b.buildMoveTables(captures, replications)
+	tasks := make([]*replication.ScheduleTask, 0, len(moves))
+	for i := 0; i < len(moves); i++ {
+		// No need for accept callback here.
+		tasks = append(tasks, &replication.ScheduleTask{MoveTable: &moves[i]})
+	}
+	return tasks
+}
+
+func (b *loadBalanceScheduler) updateTableLoads(
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) {
+	tables := spanz.NewBtreeMap[*tableLoad]()
+	replications.Ascend(func(span tablepb.Span, rep *replication.ReplicationSet) bool {
+		if rep.State != replication.ReplicationSetStateReplicating {
+			return true
+		}
+		load, ok := b.tables.Get(span)
+		if !ok {
+			load = &tableLoad{}
+		}
+		load.update(rep.Primary, &rep.Stats)
+		tables.ReplaceOrInsert(span, load)
+		return true
+	})
+	// Tables that are not replicating are dropped, their loads are derived
+	// again once they are replicating.
+	b.tables = tables
+}
+
+func (b *loadBalanceScheduler) buildMoveTables(
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) []replication.MoveTable {
+	if len(captures) <= 1 {
+		return nil
+	}
+	captureLoads := make(map[model.CaptureID]float64, len(captures))
+	for captureID := range captures {
+		captureLoads[captureID] = 0
+	}
+	tablesPerCapture := make(map[model.CaptureID][]tablepb.Span, len(captures))
+	premature := false
+	replications.Ascend(func(span tablepb.Span, rep *replication.ReplicationSet) bool {
+		if rep.State != replication.ReplicationSetStateReplicating {
+			// Tables are being scheduled, their loads are unknown.
+			premature = true
+			return false
+		}
+		load, ok := b.tables.Get(span)
+		if !ok || !load.ready {
+			premature = true
+			return false
+		}
+		if _, ok := captureLoads[rep.Primary]; !ok {
+			premature = true
+			return false
+		}
+		captureLoads[rep.Primary] += load.bytesPerSecond
+		tablesPerCapture[rep.Primary] = append(tablesPerCapture[rep.Primary], span)
+		return true
+	})
+	if premature {
+		log.Debug("schedulerv3: table loads are unknown, premature to balance table",
+			zap.String("namespace", b.changefeedID.Namespace),
+			zap.String("changefeed", b.changefeedID.ID))
+		return nil
+	}
+
+	moved := spanz.NewSet()
+	moveTables := make([]replication.MoveTable, 0)
+	for len(moveTables) < b.maxTaskConcurrency {
+		source, target := findMaxMinLoadCaptures(captureLoads)
+		total := 0.0
+		for _, load := range captureLoads {
+			total += load
+		}
+		avg := total / float64(len(captureLoads))
+		if total == 0 || captureLoads[source] <= avg*(1+b.threshold) {
+			break
+		}
+
+		// Moving a table whose load is in (0, gap) lowers the load of the
+		// busiest capture without overloading the idlest capture, and a table
+		// whose load is closest to the half of the gap balances them best.
+		gap := captureLoads[source] - captureLoads[target]
+		var victim *tablepb.Span
+		var victimLoad *tableLoad
+		minDiff := math.MaxFloat64
+		for i := range tablesPerCapture[source] {
+			span := &tablesPerCapture[source][i]
+			if moved.Contain(*span) || b.affinity.isPinned(span.TableID) {
+				continue
+			}
+			load, _ := b.tables.Get(*span)
+			if load.bytesPerSecond <= 0 || load.bytesPerSecond >= gap {
+				continue
+			}
+			diff := math.Abs(gap/2 - load.bytesPerSecond)
+			if diff < minDiff {
+				minDiff = diff
+				victim = span
+				victimLoad = load
+			}
+		}
+		if victim == nil {
+			break
+		}
+
+		moved.Add(*victim)
+		captureLoads[source] -= victimLoad.bytesPerSecond
+		captureLoads[target] += victimLoad.bytesPerSecond
+		moveTables = append(moveTables, replication.MoveTable{
+			Span:        *victim,
+			DestCapture: target,
+		})
+		log.Info("schedulerv3: try to move a table to balance loads",
+			zap.String("namespace", b.changefeedID.Namespace),
+			zap.String("changefeed", b.changefeedID.ID),
+			zap.Stringer("span", victim),
+			zap.String("source", source),
+			zap.String("target", target),
+			zap.Float64("rowsPerSecond", victimLoad.rowsPerSecond),
+			zap.Float64("bytesPerSecond", victimLoad.bytesPerSecond),
+			zap.Float64("sourceLoad", captureLoads[source]),
+			zap.Float64("targetLoad", captureLoads[target]))
+	}
+	return moveTables
+}
+
+// findMaxMinLoadCaptures returns captures that have the max and min load.
+// Ties are broken by capture ID so that the result is deterministic.
+func findMaxMinLoadCaptures(
+	captureLoads map[model.CaptureID]float64,
+) (maxCapture, minCapture model.CaptureID) {
+	ids := make([]model.CaptureID, 0, len(captureLoads))
+	for id := range captureLoads {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if maxCapture == "" || captureLoads[id] > captureLoads[maxCapture] {
+			maxCapture = id
+		}
+		if minCapture == "" || captureLoads[id] < captureLoads[minCapture] {
+			minCapture = id
+		}
+	}
+	return
+}
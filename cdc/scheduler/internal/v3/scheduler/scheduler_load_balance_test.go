@@ -0,0 +1,175 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func newLoadStats(now time.Time, rows, bytes uint64) tablepb.Stats {
+	return tablepb.Stats{
+		CurrentTs:    tablepb.Ts(oracle.GoTimeToTS(now)),
+		EmittedRows:  rows,
+		EmittedBytes: bytes,
+	}
+}
+
+func TestTableLoadUpdate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	load := &tableLoad{}
+	load.update("a", &tablepb.Stats{})
+	require.Equal(t, &tableLoad{}, load)
+
+	stats := newLoadStats(now, 10, 100)
+	load.update("a", &stats)
+	require.False(t, load.ready)
+
+	// Stats are not collected in the heartbeat.
+	load.update("a", &tablepb.Stats{})
+	require.False(t, load.ready)
+	// Same sample.
+	load.update("a", &stats)
+	require.False(t, load.ready)
+
+	stats = newLoadStats(now.Add(10*time.Second), 110, 1100)
+	load.update("a", &stats)
+	require.True(t, load.ready)
+	require.InDelta(t, 10, load.rowsPerSecond, 0.01)
+	require.InDelta(t, 100, load.bytesPerSecond, 0.01)
+
+	// The table is moved to another capture.
+	stats = newLoadStats(now.Add(20*time.Second), 5, 50)
+	load.update("b", &stats)
+	require.False(t, load.ready)
+	require.Equal(t, model.CaptureID("b"), load.primary)
+}
+
+func TestSchedulerLoadBalance(t *testing.T) {
+	t.Parallel()
+
+	sched := newLoadBalanceScheduler(
+		time.Duration(0), 0.2, 10, model.ChangeFeedID{})
+
+	// Capture "a" holds a few hot tables, while capture "b" holds many cold
+	// tables.
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {}, "b": {}}
+	bytesPerSecond := map[model.TableID]uint64{1: 1000, 2: 800}
+	rs := map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+	}
+	for tableID := model.TableID(3); tableID <= 8; tableID++ {
+		bytesPerSecond[tableID] = 10
+		rs[tableID] = &replication.ReplicationSet{
+			State: replication.ReplicationSetStateReplicating, Primary: "b",
+		}
+	}
+	now := time.Now()
+	for _, r := range rs {
+		r.Stats = newLoadStats(now, 0, 0)
+	}
+	replications := mapToSpanMap(rs)
+
+	// Loads are unknown.
+	tasks := sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 0)
+
+	for tableID, r := range rs {
+		r.Stats = newLoadStats(
+			now.Add(10*time.Second), bytesPerSecond[tableID], bytesPerSecond[tableID]*10)
+	}
+	// The capture is stopping.
+	captures["a"].State = member.CaptureStateStopping
+	tasks = sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// Moves table 2 to "b", which balances the loads to 1000 and 860.
+	captures["a"].State = member.CaptureStateInitialized
+	tasks = sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, model.TableID(2), tasks[0].MoveTable.Span.TableID)
+	require.Equal(t, model.CaptureID("b"), tasks[0].MoveTable.DestCapture)
+
+	// Table 2 is being moved, premature to balance.
+	rs[2].State = replication.ReplicationSetStatePrepare
+	tasks = sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// Table 2 is moved to "b", its load is unknown until the next sample.
+	rs[2].State = replication.ReplicationSetStateReplicating
+	rs[2].Primary = "b"
+	rs[2].Stats = newLoadStats(now.Add(20*time.Second), 0, 0)
+	tasks = sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// Loads are balanced.
+	rs[2].Stats = newLoadStats(now.Add(30*time.Second), 800, 8000)
+	tasks = sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// It does not pass the check balance interval.
+	sched.checkBalanceInterval = time.Hour
+	rs[3].Primary = "a"
+	rs[3].Stats = newLoadStats(now.Add(40*time.Second), 10000, 100000)
+	captures["c"] = &member.CaptureStatus{}
+	tasks = sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 0)
+}
+
+func TestSchedulerLoadBalanceTaskLimit(t *testing.T) {
+	t.Parallel()
+
+	sched := newLoadBalanceScheduler(
+		time.Duration(0), 0.2, 2, model.ChangeFeedID{})
+
+	// New captures "b" and "c" online.
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {}, "b": {}, "c": {}}
+	rs := make(map[model.TableID]*replication.ReplicationSet)
+	now := time.Now()
+	for tableID := model.TableID(1); tableID <= 6; tableID++ {
+		rs[tableID] = &replication.ReplicationSet{
+			State:   replication.ReplicationSetStateReplicating,
+			Primary: "a",
+			Stats:   newLoadStats(now, 0, 0),
+		}
+	}
+	replications := mapToSpanMap(rs)
+	tasks := sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 0)
+
+	for _, r := range rs {
+		r.Stats = newLoadStats(now.Add(time.Second), 100, 100)
+	}
+	tasks = sched.Schedule(0, nil, captures, replications)
+	require.Len(t, tasks, 2)
+	dests := map[model.CaptureID]int{}
+	moved := spanz.NewSet()
+	for _, task := range tasks {
+		dests[task.MoveTable.DestCapture]++
+		moved.Add(task.MoveTable.Span)
+	}
+	require.Equal(t, map[model.CaptureID]int{"b": 1, "c": 1}, dests)
+	require.Equal(t, 2, moved.Size())
+}
@@ -30,34 +30,93 @@ import (
 // Manager manages schedulers and generates schedule tasks.
 type Manager struct { //nolint:revive
 	changefeedID model.ChangeFeedID
+	cfg          *config.SchedulerConfig
 
-	schedulers         []scheduler
+	schedulers         []Scheduler
+	affinity           *affinity
+	captureLabels      *captureLabels
+	zones              *zones
+	moves              *moveBudget
 	tasksCounter       map[struct{ scheduler, task string }]int
 	maxTaskConcurrency int
 }
 
-// NewSchedulerManager returns a new scheduler manager.
+// NewSchedulerManager returns a new scheduler manager, which schedules tables
+// automatically by the policy. A nil policy means the default policy.
 func NewSchedulerManager(
-	changefeedID model.ChangeFeedID, cfg *config.SchedulerConfig,
+	changefeedID model.ChangeFeedID, cfg *config.SchedulerConfig, policy *Policy,
 ) *Manager {
 	sm := &Manager{
 		maxTaskConcurrency: cfg.MaxTaskConcurrency,
 		changefeedID:       changefeedID,
-		schedulers:         make([]scheduler, schedulerPriorityMax),
+		cfg:                cfg,
+		schedulers:         make([]Scheduler, schedulerPriorityMax),
 		tasksCounter: make(map[struct {
 			scheduler string
 			task      string
 		}]int),
 	}
+	if policy == nil {
+		policy = &Policy{}
+	}
+	var rules []*config.AffinityRule
+	var labels map[string]string
+	var zoneLabel string
+	if cfg.ChangefeedSettings != nil {
+		rules = cfg.ChangefeedSettings.Affinity
+		labels = cfg.ChangefeedSettings.CaptureLabels
+		zoneLabel = cfg.ChangefeedSettings.ZoneLabel
+	}
+	sm.affinity = newAffinity(changefeedID, rules)
+	sm.captureLabels = newCaptureLabels(changefeedID, labels)
+	sm.zones = newZones(zoneLabel)
+	sm.moves = newMoveBudget(changefeedID, cfg.MaxMovesPerTick, cfg.MaxMovesPerCapture)
 
-	sm.schedulers[schedulerPriorityBasic] = newBasicScheduler(
-		cfg.AddTableBatchSize, changefeedID)
-	sm.schedulers[schedulerPriorityDrainCapture] = newDrainCaptureScheduler(
-		cfg.MaxTaskConcurrency, changefeedID)
-	sm.schedulers[schedulerPriorityBalance] = newBalanceScheduler(
-		time.Duration(cfg.CheckBalanceInterval), cfg.MaxTaskConcurrency)
+	basic := policy.Basic
+	if basic == nil {
+		builtin := newBasicScheduler(cfg.AddTableBatchSize, changefeedID)
+		if target := time.Duration(cfg.AddTableLatencyTarget); target > 0 {
+			builtin.batch = newAddTableBatch(changefeedID, target, cfg.AddTableBatchSize)
+		}
+		builtin.affinity = sm.affinity
+		builtin.zones = sm.zones
+		basic = builtin
+	}
+	drainCapture := policy.DrainCapture
+	if drainCapture == nil {
+		builtin := newDrainCaptureScheduler(cfg.MaxTaskConcurrency, changefeedID)
+		builtin.affinity = sm.affinity
+		drainCapture = builtin
+	}
+	balance := policy.Balance
+	if balance == nil {
+		if cfg.BalanceStrategy == config.BalanceStrategyLoad {
+			builtin := newLoadBalanceScheduler(
+				time.Duration(cfg.CheckBalanceInterval), cfg.LoadBalanceThreshold,
+				cfg.MaxTaskConcurrency, changefeedID)
+			builtin.affinity = sm.affinity
+			balance = builtin
+		} else {
+			builtin := newBalanceScheduler(
+				time.Duration(cfg.CheckBalanceInterval), cfg.MaxTaskConcurrency)
+			builtin.affinity = sm.affinity
+			builtin.zones = sm.zones
+			balance = builtin
+		}
+	}
+	sm.schedulers[schedulerPriorityResetTable] = newResetTableScheduler(changefeedID)
+	sm.schedulers[schedulerPriorityBasic] = basic
+	sm.schedulers[schedulerPriorityDrainCapture] = drainCapture
+	sm.schedulers[schedulerPriorityBalance] = balance
 	sm.schedulers[schedulerPriorityMoveTable] = newMoveTableScheduler(changefeedID)
-	sm.schedulers[schedulerPriorityRebalance] = newRebalanceScheduler(changefeedID)
+	rebalance := newRebalanceScheduler(changefeedID)
+	rebalance.affinity = sm.affinity
+	rebalance.zones = sm.zones
+	sm.schedulers[schedulerPriorityRebalance] = rebalance
+	sm.schedulers[schedulerPriorityAffinity] = newAffinityScheduler(
+		sm.affinity, cfg.MaxTaskConcurrency, changefeedID)
+	sm.schedulers[schedulerPriorityCaptureLabels] = newCaptureLabelsScheduler(
+		sm.captureLabels, cfg.MaxTaskConcurrency, changefeedID)
 
 	return sm
 }
@@ -70,18 +129,35 @@ func (sm *Manager) Schedule(
 	replications *spanz.BtreeMap[*replication.ReplicationSet],
 	runTasking *spanz.BtreeMap[*replication.ScheduleTask],
 ) []*replication.ScheduleTask {
+	// Schedulers only see the captures carrying the capture labels.
+	aliveCaptures = sm.captureLabels.filter(aliveCaptures)
 	for sid, scheduler := range sm.schedulers {
 		// Basic scheduler bypasses max task check, because it handles the most
-		// critical scheduling, e.g. add table via CREATE TABLE DDL.
-		if sid != int(schedulerPriorityBasic) {
+		// critical scheduling, e.g. add table via CREATE TABLE DDL. Reset table
+		// scheduler bypasses it too, otherwise it would block basic scheduler.
+		if sid != int(schedulerPriorityBasic) && sid != int(schedulerPriorityResetTable) {
 			if runTasking.Len() >= sm.maxTaskConcurrency {
 				// Do not generate more scheduling tasks if there are too many
 				// running tasks.
 				return nil
 			}
 		}
-		tasks := scheduler.Schedule(checkpointTs, currentSpans, aliveCaptures, replications)
+		var tasks []*replication.ScheduleTask
+		// Moves of balancing are rate limited by the move budget.
+		budgeted := sid == int(schedulerPriorityRebalance) ||
+			sid == int(schedulerPriorityBalance)
+		if budgeted && sm.moves.pending() {
+			// Queued moves are dispatched before planning new ones, otherwise
+			// balancing would plan moves again for the imbalance they fix.
+			tasks = sm.moves.dispatch(aliveCaptures, replications, runTasking)
+		} else {
+			tasks = scheduler.Schedule(checkpointTs, currentSpans, aliveCaptures, replications)
+			if budgeted {
+				tasks = sm.moves.limit(tasks, aliveCaptures, replications, runTasking)
+			}
+		}
 		for _, t := range tasks {
+			t.Reason = scheduler.Name()
 			name := struct {
 				scheduler, task string
 			}{scheduler: scheduler.Name(), task: t.Name()}
@@ -100,6 +176,20 @@ func (sm *Manager) Schedule(
 	return nil
 }
 
+// UpdateTableNames updates names of tables, so that tables are matched
+// against the affinity rules of the changefeed.
+func (sm *Manager) UpdateTableNames(names map[model.TableID]model.TableName) {
+	sm.affinity.updateTableNames(names)
+}
+
+// UpdateSpanZones updates zones of the majority of region leaders of spans,
+// so that spans are preferably replicated by captures in the same zones.
+func (sm *Manager) UpdateSpanZones(spans *spanz.BtreeMap[string]) {
+	if sm.zones != nil {
+		sm.zones.spans = spans
+	}
+}
+
 // MoveTable moves a table to the target capture.
 func (sm *Manager) MoveTable(span tablepb.Span, target model.CaptureID) {
 	scheduler := sm.schedulers[schedulerPriorityMoveTable]
@@ -119,6 +209,38 @@ func (sm *Manager) MoveTable(span tablepb.Span, target model.CaptureID) {
 	}
 }
 
+// ResetTable recreates replication of a table from the start ts.
+func (sm *Manager) ResetTable(span tablepb.Span, startTs model.Ts) {
+	scheduler := sm.schedulers[schedulerPriorityResetTable]
+	resetTableScheduler, ok := scheduler.(*resetTableScheduler)
+	if !ok {
+		log.Panic("schedulerv3: invalid reset table scheduler found",
+			zap.String("namespace", sm.changefeedID.Namespace),
+			zap.String("changefeed", sm.changefeedID.ID))
+	}
+	if !resetTableScheduler.addTask(span, startTs) {
+		log.Info("schedulerv3: manual reset table task ignored, "+
+			"since the last triggered task not finished",
+			zap.String("namespace", sm.changefeedID.Namespace),
+			zap.String("changefeed", sm.changefeedID.ID),
+			zap.String("span", span.String()),
+			zap.Uint64("startTs", startTs))
+	}
+}
+
+// ResumeTable adds a paused table back from the start ts, which may be
+// smaller than the checkpoint ts.
+func (sm *Manager) ResumeTable(span tablepb.Span, startTs model.Ts) {
+	scheduler := sm.schedulers[schedulerPriorityResetTable]
+	resetTableScheduler, ok := scheduler.(*resetTableScheduler)
+	if !ok {
+		log.Panic("schedulerv3: invalid reset table scheduler found",
+			zap.String("namespace", sm.changefeedID.Namespace),
+			zap.String("changefeed", sm.changefeedID.ID))
+	}
+	resetTableScheduler.addResumeTask(span, startTs)
+}
+
 // Rebalance rebalance tables.
 func (sm *Manager) Rebalance() {
 	scheduler := sm.schedulers[schedulerPriorityRebalance]
@@ -134,20 +256,84 @@ func (sm *Manager) Rebalance() {
 
 // DrainCapture drains all tables in the target capture.
 func (sm *Manager) DrainCapture(target model.CaptureID) bool {
-	scheduler := sm.schedulers[schedulerPriorityDrainCapture]
-	drainCaptureScheduler, ok := scheduler.(*drainCaptureScheduler)
-	if !ok {
-		log.Panic("schedulerv3: invalid drain capture scheduler found",
+	return sm.drainCapture().SetTarget(target)
+}
+
+// CancelDrainCapture stops draining the target capture, tables moved out of
+// it are not moved back. It returns false if the target is not draining.
+func (sm *Manager) CancelDrainCapture(target model.CaptureID) bool {
+	return sm.drainCapture().ResetTarget(target)
+}
+
+// ForceDrainCapture moves all replicating tables out of the target capture
+// by manual move table tasks, which, unlike draining, do not wait for other
+// tables to be replicating. Tables are moved to the least loaded captures
+// carrying the capture labels, affinity rules are respected. It returns the
+// count of tables on the target capture.
+func (sm *Manager) ForceDrainCapture(
+	target model.CaptureID,
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) int {
+	// Tables are moved by move table tasks, stop draining to prevent them
+	// from being moved twice.
+	sm.drainCapture().ResetTarget(target)
+
+	captures = sm.captureLabels.filter(captures)
+	workload := make(map[model.CaptureID]int)
+	for id, capture := range captures {
+		if id != target && capture.State == member.CaptureStateInitialized {
+			workload[id] = 0
+		}
+	}
+	var victims []tablepb.Span
+	replications.Ascend(func(span tablepb.Span, rep *replication.ReplicationSet) bool {
+		if rep.Primary == target {
+			victims = append(victims, span)
+		} else if _, ok := workload[rep.Primary]; ok {
+			workload[rep.Primary]++
+		}
+		return true
+	})
+	if len(workload) == 0 {
+		log.Warn("schedulerv3: force drain capture ignored, "+
+			"since cannot found destination captures",
 			zap.String("namespace", sm.changefeedID.Namespace),
-			zap.String("changefeed", sm.changefeedID.ID))
+			zap.String("changefeed", sm.changefeedID.ID),
+			zap.String("target", target))
+		return len(victims)
 	}
 
-	return drainCaptureScheduler.setTarget(target)
+	captureIDs := sortedCaptureIDs(workload)
+	for _, span := range victims {
+		candidates := captureIDs
+		if matched := sm.affinity.match(span.TableID, captureIDs, captures); len(matched) > 0 {
+			candidates = matched
+		}
+		dest := candidates[0]
+		for _, id := range candidates[1:] {
+			if workload[id] < workload[dest] {
+				dest = id
+			}
+		}
+		sm.MoveTable(span, dest)
+		workload[dest]++
+	}
+	log.Info("schedulerv3: force drain capture",
+		zap.String("namespace", sm.changefeedID.Namespace),
+		zap.String("changefeed", sm.changefeedID.ID),
+		zap.String("target", target),
+		zap.Int("tableCount", len(victims)))
+	return len(victims)
 }
 
 // DrainingTarget returns a capture id that is currently been draining.
 func (sm *Manager) DrainingTarget() model.CaptureID {
-	return sm.schedulers[schedulerPriorityDrainCapture].(*drainCaptureScheduler).getTarget()
+	return sm.drainCapture().Target()
+}
+
+func (sm *Manager) drainCapture() CaptureDrainer {
+	return sm.schedulers[schedulerPriorityDrainCapture].(CaptureDrainer)
 }
 
 // CollectMetrics collects metrics.
@@ -159,6 +345,9 @@ func (sm *Manager) CollectMetrics() {
 			Add(float64(counter))
 		sm.tasksCounter[name] = 0
 	}
+	queuedMovesGauge.WithLabelValues(cf.Namespace, cf.ID).Set(float64(len(sm.moves.queue)))
+	executedMovesCounter.WithLabelValues(cf.Namespace, cf.ID).Add(float64(sm.moves.executed))
+	sm.moves.executed = 0
 }
 
 // CleanMetrics cleans metrics.
@@ -167,4 +356,6 @@ func (sm *Manager) CleanMetrics() {
 	for name := range sm.tasksCounter {
 		scheduleTaskCounter.DeleteLabelValues(cf.Namespace, cf.ID, name.scheduler, name.task)
 	}
+	queuedMovesGauge.DeleteLabelValues(cf.Namespace, cf.ID)
+	executedMovesCounter.DeleteLabelValues(cf.Namespace, cf.ID)
 }
@@ -29,13 +29,23 @@ func TestNewSchedulerManager(t *testing.T) {
 	t.Parallel()
 
 	m := NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"),
-		config.NewDefaultSchedulerConfig())
+		config.NewDefaultSchedulerConfig(), nil)
 	require.NotNil(t, m)
+	require.NotNil(t, m.schedulers[schedulerPriorityResetTable])
 	require.NotNil(t, m.schedulers[schedulerPriorityBasic])
 	require.NotNil(t, m.schedulers[schedulerPriorityBalance])
 	require.NotNil(t, m.schedulers[schedulerPriorityMoveTable])
 	require.NotNil(t, m.schedulers[schedulerPriorityRebalance])
 	require.NotNil(t, m.schedulers[schedulerPriorityDrainCapture])
+	require.IsType(t, &balanceScheduler{}, m.schedulers[schedulerPriorityBalance])
+
+	cfg := config.NewDefaultSchedulerConfig()
+	cfg.BalanceStrategy = config.BalanceStrategyLoad
+	m = NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"), cfg, nil)
+	require.IsType(t, &loadBalanceScheduler{}, m.schedulers[schedulerPriorityBalance])
+	s := m.Snapshot()
+	require.False(t, s.ForceBalance)
+	m.Restore(s, 0)
 }
 
 func TestSchedulerManagerScheduler(t *testing.T) {
@@ -43,7 +53,7 @@ func TestSchedulerManagerScheduler(t *testing.T) {
 
 	cfg := config.NewDefaultSchedulerConfig()
 	cfg.MaxTaskConcurrency = 1
-	m := NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"), cfg)
+	m := NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"), cfg, nil)
 
 	captures := map[model.CaptureID]*member.CaptureStatus{
 		"a": {State: member.CaptureStateInitialized},
@@ -80,3 +90,29 @@ func TestSchedulerManagerScheduler(t *testing.T) {
 	tasks = m.Schedule(0, currentSpans, captures, replications, runningTasks)
 	require.Len(t, tasks, 1)
 }
+
+func TestSchedulerManagerSeed(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {State: member.CaptureStateInitialized},
+		"b": {State: member.CaptureStateInitialized},
+		"c": {State: member.CaptureStateInitialized},
+	}
+	currentSpans := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4, 5, 6})
+	schedule := func(seed int64) []*replication.ScheduleTask {
+		m := NewSchedulerManager(model.ChangeFeedID{}, config.NewDefaultSchedulerConfig(), nil)
+		m.Seed(seed)
+		return m.Schedule(0, currentSpans, captures,
+			spanz.NewBtreeMap[*replication.ReplicationSet](),
+			spanz.NewBtreeMap[*replication.ScheduleTask]())
+	}
+
+	// Schedule tasks are the same with the same seed.
+	tasks := schedule(1)
+	require.Len(t, tasks, 1)
+	require.Len(t, tasks[0].BurstBalance.AddTables, 6)
+	for i := 0; i < 10; i++ {
+		require.Equal(t, tasks, schedule(1))
+	}
+}
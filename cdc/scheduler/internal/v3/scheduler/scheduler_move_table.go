@@ -25,7 +25,7 @@ import (
 	"go.uber.org/zap"
 )
 
-var _ scheduler = &moveTableScheduler{}
+var _ Scheduler = &moveTableScheduler{}
 
 type moveTableScheduler struct {
 	mu    sync.Mutex
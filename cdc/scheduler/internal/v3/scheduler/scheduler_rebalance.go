@@ -29,11 +29,16 @@ import (
 	"go.uber.org/zap"
 )
 
-var _ scheduler = &rebalanceScheduler{}
+var _ Scheduler = &rebalanceScheduler{}
 
 type rebalanceScheduler struct {
 	rebalance int32
 	random    *rand.Rand
+	// affinity pins tables to captures, pinned tables are not moved.
+	affinity *affinity
+	// zones prefers moving tables to captures in the zones of their region
+	// leaders, it is nil if zone-aware scheduling is disabled.
+	zones *zones
 
 	changefeedID model.ChangeFeedID
 }
@@ -91,7 +96,8 @@ func (r *rebalanceScheduler) Schedule(
 	}
 
 	unlimited := math.MaxInt
-	tasks := newBalanceMoveTables(r.random, captures, replications, unlimited, r.changefeedID)
+	tasks := newBalanceMoveTables(
+		r.random, captures, replications, r.affinity, r.zones, unlimited, r.changefeedID)
 	if len(tasks) == 0 {
 		return nil
 	}
@@ -111,6 +117,8 @@ func newBalanceMoveTables(
 	random *rand.Rand,
 	captures map[model.CaptureID]*member.CaptureStatus,
 	replications *spanz.BtreeMap[*replication.ReplicationSet],
+	affinity *affinity,
+	zones *zones,
 	maxTaskLimit int,
 	changefeedID model.ChangeFeedID,
 ) []replication.MoveTable {
@@ -120,8 +128,13 @@ func newBalanceMoveTables(
 	}
 
 	replications.Ascend(func(span tablepb.Span, rep *replication.ReplicationSet) bool {
-		if rep.State == replication.ReplicationSetStateReplicating {
-			tablesPerCapture[rep.Primary].Add(span)
+		if rep.State != replication.ReplicationSetStateReplicating {
+			return true
+		}
+		// Captures may be filtered, e.g. by capture labels, tables on other
+		// captures are not balanced.
+		if ts, ok := tablesPerCapture[rep.Primary]; ok {
+			ts.Add(span)
 		}
 		return true
 	})
@@ -129,8 +142,10 @@ func newBalanceMoveTables(
 	// findVictim return tables which need to be moved
 	upperLimitPerCapture := int(math.Ceil(float64(replications.Len()) / float64(len(captures))))
 
+	captureIDs := sortedCaptureIDs(tablesPerCapture)
 	victims := make([]tablepb.Span, 0)
-	for _, ts := range tablesPerCapture {
+	for _, captureID := range captureIDs {
+		ts := tablesPerCapture[captureID]
 		spans := ts.Keys()
 		if random != nil {
 			// Complexity note: Shuffle has O(n), where `n` is the number of tables.
@@ -149,6 +164,14 @@ func newBalanceMoveTables(
 				return spans[i].Less(&spans[j])
 			})
 		}
+		if zones.enabled() {
+			// Spans replicated out of the zones of their region leaders are
+			// moved first.
+			sort.SliceStable(spans, func(i, j int) bool {
+				return !zones.isLocal(spans[i], captureID, captures) &&
+					zones.isLocal(spans[j], captureID, captures)
+			})
+		}
 
 		tableNum2Remove := len(spans) - upperLimitPerCapture
 		if tableNum2Remove <= 0 {
@@ -159,6 +182,11 @@ func newBalanceMoveTables(
 			if tableNum2Remove <= 0 {
 				break
 			}
+			if affinity.isPinned(span.TableID) {
+				// Pinned tables are counted in the workload, but they are
+				// never moved to balance captures.
+				continue
+			}
 			victims = append(victims, span)
 			ts.Remove(span)
 			tableNum2Remove--
@@ -169,8 +197,9 @@ func newBalanceMoveTables(
 	}
 
 	captureWorkload := make(map[model.CaptureID]int)
-	for captureID, ts := range tablesPerCapture {
-		captureWorkload[captureID] = randomizeWorkload(random, ts.Size())
+	for _, captureID := range captureIDs {
+		captureWorkload[captureID] = randomizeWorkload(
+			random, tablesPerCapture[captureID].Size())
 	}
 	// for each victim table, find the target for it
 	moveTables := make([]replication.MoveTable, 0, len(victims))
@@ -178,12 +207,25 @@ func newBalanceMoveTables(
 		target := ""
 		minWorkload := math.MaxInt64
 
-		for captureID, workload := range captureWorkload {
-			if workload < minWorkload {
+		// Prefer captures in the zone of the span as long as they are not
+		// overloaded by the span.
+		for _, captureID := range zones.match(span, captureIDs, captures) {
+			if tablesPerCapture[captureID].Size() >= upperLimitPerCapture {
+				continue
+			}
+			if workload := captureWorkload[captureID]; workload < minWorkload {
 				minWorkload = workload
 				target = captureID
 			}
 		}
+		if target == "" {
+			for _, captureID := range captureIDs {
+				if workload := captureWorkload[captureID]; workload < minWorkload {
+					minWorkload = workload
+					target = captureID
+				}
+			}
+		}
 
 		if minWorkload == math.MaxInt64 {
 			log.Panic("schedulerv3: rebalance meet unexpected min workload "+
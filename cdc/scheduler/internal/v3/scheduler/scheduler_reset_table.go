@@ -0,0 +1,204 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/zap"
+)
+
+var _ Scheduler = &resetTableScheduler{}
+
+// resetTableTask removes a table from its capture, and adds it back at the
+// start ts once it's removed.
+type resetTableTask struct {
+	startTs model.Ts
+	// removed is true if the table has been removed from capture.
+	removed bool
+	// capture is the capture that the table is added back to if it's alive.
+	capture model.CaptureID
+	// resume is true if the task resumes a paused table, the table may not
+	// be replicated, and it's added back at the start ts even if the start ts
+	// is smaller than the checkpoint ts.
+	resume bool
+}
+
+// resetTableScheduler recreates replication of tables, so that their states
+// in processors, e.g. sorted events, are dropped and rebuilt from a start ts.
+type resetTableScheduler struct {
+	mu    sync.Mutex
+	tasks *spanz.BtreeMap[*resetTableTask]
+
+	changefeedID model.ChangeFeedID
+}
+
+func newResetTableScheduler(changefeed model.ChangeFeedID) *resetTableScheduler {
+	return &resetTableScheduler{
+		tasks:        spanz.NewBtreeMap[*resetTableTask](),
+		changefeedID: changefeed,
+	}
+}
+
+func (r *resetTableScheduler) Name() string {
+	return "reset-table-scheduler"
+}
+
+func (r *resetTableScheduler) addTask(span tablepb.Span, startTs model.Ts) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// previous triggered task not finished yet, decline the new request.
+	if ok := r.tasks.Has(span); ok {
+		return false
+	}
+	r.tasks.ReplaceOrInsert(span, &resetTableTask{startTs: startTs})
+	return true
+}
+
+func (r *resetTableScheduler) addResumeTask(span tablepb.Span, startTs model.Ts) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// A resume task replaces the reset task, it starts from an earlier ts.
+	r.tasks.ReplaceOrInsert(span, &resetTableTask{startTs: startTs, resume: true})
+}
+
+func (r *resetTableScheduler) Schedule(
+	checkpointTs model.Ts,
+	currentSpans []tablepb.Span,
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) []*replication.ScheduleTask {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*replication.ScheduleTask, 0)
+	if r.tasks.Len() == 0 {
+		return result
+	}
+
+	allSpans := spanz.NewSet()
+	for _, span := range currentSpans {
+		allSpans.Add(span)
+	}
+
+	toBeDeleted := []tablepb.Span{}
+	r.tasks.Ascend(func(span tablepb.Span, task *resetTableTask) bool {
+		// table may not in the all current tables
+		// if it was removed after reset table triggered.
+		if !allSpans.Contain(span) {
+			log.Warn("schedulerv3: reset table ignored, since the table cannot found",
+				zap.String("namespace", r.changefeedID.Namespace),
+				zap.String("changefeed", r.changefeedID.ID),
+				zap.String("span", span.String()))
+			toBeDeleted = append(toBeDeleted, span)
+			return true
+		}
+
+		rep, ok := replications.Get(span)
+		if !task.removed && !ok && task.resume {
+			// The paused table is not replicated, add it directly.
+			task.removed = true
+		}
+		if !task.removed {
+			if !ok {
+				log.Warn("schedulerv3: reset table ignored, table not found in the replication set",
+					zap.String("namespace", r.changefeedID.Namespace),
+					zap.String("changefeed", r.changefeedID.ID),
+					zap.String("span", span.String()))
+				toBeDeleted = append(toBeDeleted, span)
+				return true
+			}
+			// Wait for other tasks of the table, e.g. moving table.
+			if rep.State != replication.ReplicationSetStateReplicating {
+				return true
+			}
+			primary := rep.Primary
+			result = append(result, &replication.ScheduleTask{
+				RemoveTable: &replication.RemoveTable{Span: span, CaptureID: primary},
+				Accept: func() {
+					r.mu.Lock()
+					defer r.mu.Unlock()
+					task.removed = true
+					task.capture = primary
+				},
+			})
+			return true
+		}
+
+		// The table is still being removed.
+		if ok {
+			return true
+		}
+		target := r.pickCapture(task.capture, captures)
+		if target == "" {
+			return true
+		}
+		// The checkpoint ts never regresses, the table can't start before it.
+		startTs := task.startTs
+		if startTs < checkpointTs && !task.resume {
+			log.Info("schedulerv3: reset table starts at checkpoint ts, "+
+				"since the checkpoint ts has passed the start ts",
+				zap.String("namespace", r.changefeedID.Namespace),
+				zap.String("changefeed", r.changefeedID.ID),
+				zap.String("span", span.String()),
+				zap.Uint64("startTs", startTs),
+				zap.Uint64("checkpointTs", checkpointTs))
+			startTs = checkpointTs
+		}
+		result = append(result, &replication.ScheduleTask{
+			AddTable: &replication.AddTable{
+				Span: span, CaptureID: target, CheckpointTs: startTs,
+			},
+			Accept: func() {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				r.tasks.Delete(span)
+			},
+		})
+		return true
+	})
+	for _, span := range toBeDeleted {
+		r.tasks.Delete(span)
+	}
+	return result
+}
+
+// pickCapture returns the previous capture of the table if it's available,
+// otherwise an initialized capture. It returns an empty string if there is
+// no initialized capture.
+func (r *resetTableScheduler) pickCapture(
+	prev model.CaptureID, captures map[model.CaptureID]*member.CaptureStatus,
+) model.CaptureID {
+	if status, ok := captures[prev]; ok && status.State == member.CaptureStateInitialized {
+		return prev
+	}
+	captureIDs := make([]model.CaptureID, 0, len(captures))
+	for id, status := range captures {
+		if status.State == member.CaptureStateInitialized {
+			captureIDs = append(captureIDs, id)
+		}
+	}
+	if len(captureIDs) == 0 {
+		return ""
+	}
+	sort.Strings(captureIDs)
+	return captureIDs[0]
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerResetTable(t *testing.T) {
+	t.Parallel()
+
+	checkpointTs := model.Ts(10)
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {
+		State: member.CaptureStateInitialized,
+	}, "b": {
+		State: member.CaptureStateInitialized,
+	}}
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4})
+
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStatePrepare, Primary: "b"},
+	})
+
+	scheduler := newResetTableScheduler(model.ChangeFeedID{})
+	require.Equal(t, "reset-table-scheduler", scheduler.Name())
+
+	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// reset a not exist table
+	require.True(t, scheduler.addTask(tablepb.Span{TableID: 0}, 20))
+	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+	require.False(t, scheduler.tasks.Has(tablepb.Span{TableID: 0}))
+
+	// reset a table not in the replication set
+	require.True(t, scheduler.addTask(tablepb.Span{TableID: 2}, 20))
+	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+	require.False(t, scheduler.tasks.Has(tablepb.Span{TableID: 2}))
+
+	// reset a table not replicating, wait for it.
+	require.True(t, scheduler.addTask(tablepb.Span{TableID: 1}, 20))
+	require.False(t, scheduler.addTask(tablepb.Span{TableID: 1}, 30))
+	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+	require.True(t, scheduler.tasks.Has(tablepb.Span{TableID: 1}))
+
+	// remove the table first.
+	replications.GetV(tablepb.Span{TableID: 1}).State = replication.ReplicationSetStateReplicating
+	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.RemoveTable{
+		Span: tablepb.Span{TableID: 1}, CaptureID: "b",
+	}, tasks[0].RemoveTable)
+	tasks[0].Accept()
+
+	// the table is still being removed.
+	replications.GetV(tablepb.Span{TableID: 1}).State = replication.ReplicationSetStateRemoving
+	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// the table is removed, add it back to the previous capture at the start ts.
+	replications.Delete(tablepb.Span{TableID: 1})
+	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.AddTable{
+		Span: tablepb.Span{TableID: 1}, CaptureID: "b", CheckpointTs: 20,
+	}, tasks[0].AddTable)
+
+	// the previous capture is stopping, and the checkpoint ts has passed
+	// the start ts.
+	captures["b"].State = member.CaptureStateStopping
+	tasks = scheduler.Schedule(30, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.AddTable{
+		Span: tablepb.Span{TableID: 1}, CaptureID: "a", CheckpointTs: 30,
+	}, tasks[0].AddTable)
+	tasks[0].Accept()
+	require.False(t, scheduler.tasks.Has(tablepb.Span{TableID: 1}))
+}
+
+func TestSchedulerResumeTable(t *testing.T) {
+	t.Parallel()
+
+	checkpointTs := model.Ts(30)
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {
+		State: member.CaptureStateInitialized,
+	}}
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+	})
+	scheduler := newResetTableScheduler(model.ChangeFeedID{})
+
+	// The paused table is not replicated, it's added back at the start ts
+	// even if the checkpoint ts has passed it.
+	scheduler.addResumeTask(tablepb.Span{TableID: 1}, 20)
+	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.AddTable{
+		Span: tablepb.Span{TableID: 1}, CaptureID: "a", CheckpointTs: 20,
+	}, tasks[0].AddTable)
+	tasks[0].Accept()
+	require.False(t, scheduler.tasks.Has(tablepb.Span{TableID: 1}))
+
+	// The table is replicated, it's removed first.
+	scheduler.addResumeTask(tablepb.Span{TableID: 2}, 20)
+	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.RemoveTable{
+		Span: tablepb.Span{TableID: 2}, CaptureID: "a",
+	}, tasks[0].RemoveTable)
+	tasks[0].Accept()
+	replications.Delete(tablepb.Span{TableID: 2})
+	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, &replication.AddTable{
+		Span: tablepb.Span{TableID: 2}, CaptureID: "a", CheckpointTs: 20,
+	}, tasks[0].AddTable)
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+)
+
+// Snapshot is a serializable copy of the queued requests of a scheduler
+// manager.
+type Snapshot struct {
+	// DrainingTarget is the capture being drained, it is empty if there is
+	// no capture being drained.
+	DrainingTarget model.CaptureID `json:"draining_target,omitempty"`
+	// MoveTables are manual move table requests not accepted yet.
+	MoveTables []replication.MoveTable `json:"move_tables,omitempty"`
+	// Rebalance is true if a manual rebalance request is not handled yet.
+	Rebalance bool `json:"rebalance"`
+	// ForceBalance is true if the balance scheduler skips the interval check
+	// in the next schedule.
+	ForceBalance bool `json:"force_balance"`
+}
+
+// Snapshot returns a copy of the queued requests of the scheduler manager.
+func (sm *Manager) Snapshot() *Snapshot {
+	s := &Snapshot{
+		DrainingTarget: sm.DrainingTarget(),
+		Rebalance: atomic.LoadInt32(
+			&sm.schedulers[schedulerPriorityRebalance].(*rebalanceScheduler).rebalance) == 1,
+	}
+	// The load balance scheduler does not force balance.
+	if balance, ok := sm.schedulers[schedulerPriorityBalance].(*balanceScheduler); ok {
+		s.ForceBalance = balance.forceBalance
+	}
+	moveTable := sm.schedulers[schedulerPriorityMoveTable].(*moveTableScheduler)
+	moveTable.mu.Lock()
+	moveTable.tasks.Ascend(func(_ tablepb.Span, task *replication.ScheduleTask) bool {
+		s.MoveTables = append(s.MoveTables, *task.MoveTable)
+		return true
+	})
+	moveTable.mu.Unlock()
+	return s
+}
+
+// Restore replaces the queued requests of the scheduler manager with the
+// snapshot. All schedulers draw random numbers from sources seeded with seed,
+// so that schedule tasks are reproducible.
+func (sm *Manager) Restore(s *Snapshot, seed int64) {
+	if s.DrainingTarget != captureIDNotDraining {
+		sm.drainCapture().SetTarget(s.DrainingTarget)
+	}
+
+	moveTable := sm.schedulers[schedulerPriorityMoveTable].(*moveTableScheduler)
+	for _, task := range s.MoveTables {
+		moveTable.addTask(task.Span, task.DestCapture)
+	}
+
+	rebalance := sm.schedulers[schedulerPriorityRebalance].(*rebalanceScheduler)
+	if s.Rebalance {
+		atomic.StoreInt32(&rebalance.rebalance, 1)
+	} else {
+		atomic.StoreInt32(&rebalance.rebalance, 0)
+	}
+	rebalance.random = rand.New(rand.NewSource(seed))
+
+	if balance, ok := sm.schedulers[schedulerPriorityBalance].(*balanceScheduler); ok {
+		balance.forceBalance = s.ForceBalance
+		balance.random = rand.New(rand.NewSource(seed))
+	}
+
+	// Schedulers of policies other than the default one are not restored.
+	if basic, ok := sm.schedulers[schedulerPriorityBasic].(*basicScheduler); ok {
+		basic.random = rand.New(rand.NewSource(seed))
+	}
+}
+
+// Seed reseeds the random sources of all schedulers in place, so that the
+// schedule tasks of the next schedule are reproducible by Restore with the
+// same seed.
+func (sm *Manager) Seed(seed int64) {
+	randoms := []*rand.Rand{
+		sm.schedulers[schedulerPriorityRebalance].(*rebalanceScheduler).random,
+	}
+	if basic, ok := sm.schedulers[schedulerPriorityBasic].(*basicScheduler); ok {
+		randoms = append(randoms, basic.random)
+	}
+	if balance, ok := sm.schedulers[schedulerPriorityBalance].(*balanceScheduler); ok {
+		randoms = append(randoms, balance.random)
+	}
+	for _, random := range randoms {
+		// Random sources are nil in some tests.
+		if random != nil {
+			random.Seed(seed)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/pkg/spanz"
+)
+
+// zones locates spans and captures in availability zones, so that spans are
+// preferably replicated by captures in the zone of their region leaders.
+// Captures are located by the zone label, which is set in their server config.
+type zones struct {
+	label string
+	// spans are zones of spans, they're updated by the coordinator.
+	spans *spanz.BtreeMap[string]
+}
+
+// newZones returns nil if the zone label is empty.
+func newZones(label string) *zones {
+	if label == "" {
+		return nil
+	}
+	return &zones{label: label}
+}
+
+func (z *zones) enabled() bool {
+	return z != nil && z.spans != nil && z.spans.Len() != 0
+}
+
+func (z *zones) spanZone(span tablepb.Span) (string, bool) {
+	if !z.enabled() {
+		return "", false
+	}
+	return z.spans.Get(span)
+}
+
+// isLocal returns true if the capture is in the zone of the span, or the zone
+// of the span is unknown.
+func (z *zones) isLocal(
+	span tablepb.Span, captureID model.CaptureID,
+	captures map[model.CaptureID]*member.CaptureStatus,
+) bool {
+	zone, ok := z.spanZone(span)
+	if !ok {
+		return true
+	}
+	status, ok := captures[captureID]
+	return ok && status.Labels[z.label] == zone
+}
+
+// match returns captures in captureIDs that are in the zone of the span.
+// It returns nil if the zone of the span is unknown or no capture matches.
+func (z *zones) match(
+	span tablepb.Span,
+	captureIDs []model.CaptureID,
+	captures map[model.CaptureID]*member.CaptureStatus,
+) []model.CaptureID {
+	zone, ok := z.spanZone(span)
+	if !ok {
+		return nil
+	}
+	var matched []model.CaptureID
+	for _, id := range captureIDs {
+		if status, ok := captures[id]; ok && status.Labels[z.label] == zone {
+			matched = append(matched, id)
+		}
+	}
+	return matched
+}
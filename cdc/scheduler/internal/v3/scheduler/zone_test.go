@@ -0,0 +1,148 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestZones(spanZones map[model.TableID]string) *zones {
+	z := newZones("zone")
+	z.spans = spanz.NewBtreeMap[string]()
+	for tableID, zone := range spanZones {
+		z.spans.ReplaceOrInsert(tablepb.Span{TableID: tableID}, zone)
+	}
+	return z
+}
+
+func newTestZoneCaptures(zones map[model.CaptureID]string) map[model.CaptureID]*member.CaptureStatus {
+	captures := make(map[model.CaptureID]*member.CaptureStatus)
+	for id, zone := range zones {
+		captures[id] = &member.CaptureStatus{ID: id, Labels: map[string]string{"zone": zone}}
+	}
+	return captures
+}
+
+func TestZonesAddTables(t *testing.T) {
+	t.Parallel()
+
+	captures := newTestZoneCaptures(map[model.CaptureID]string{"a": "z1", "b": "z1", "c": "z2"})
+	captureIDs := []model.CaptureID{"a", "b", "c"}
+	addTables := func(z *zones, tableIDs ...model.TableID) []model.CaptureID {
+		spans := make([]tablepb.Span, 0, len(tableIDs))
+		for _, tableID := range tableIDs {
+			spans = append(spans, tablepb.Span{TableID: tableID})
+		}
+		task := newBurstAddTables(0, spans, captureIDs, captures, nil, z)
+		var targets []model.CaptureID
+		for _, table := range task.BurstBalance.AddTables {
+			targets = append(targets, table.CaptureID)
+		}
+		return targets
+	}
+
+	// Tables of unknown zones are added in a round-robin way.
+	require.Equal(t, []model.CaptureID{"a", "b", "c"}, addTables(nil, 1, 2, 3))
+	z := newTestZones(map[model.TableID]string{1: "z1", 2: "z1", 3: "z1", 4: "z1", 5: "z2"})
+	require.Equal(t, []model.CaptureID{"a", "b", "a", "b", "c", "a"},
+		addTables(z, 1, 2, 3, 4, 5, 6))
+
+	// Captures of a zone are not given more than their even share.
+	z = newTestZones(map[model.TableID]string{1: "z2", 2: "z2", 3: "z2", 4: "z2", 5: "z2"})
+	require.Equal(t, []model.CaptureID{"c", "c", "a", "b", "c"},
+		addTables(z, 1, 2, 3, 4, 5))
+}
+
+func TestZonesBalanceMoveTables(t *testing.T) {
+	t.Parallel()
+
+	captures := newTestZoneCaptures(map[model.CaptureID]string{"a": "z1", "b": "z1", "c": "z2"})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		5: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+		6: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+	})
+	moves := newBalanceMoveTables(
+		nil, captures, replications, nil, nil, 10, model.ChangeFeedID{})
+	require.Equal(t, []replication.MoveTable{
+		{Span: tablepb.Span{TableID: 1}, DestCapture: "c"},
+		{Span: tablepb.Span{TableID: 2}, DestCapture: "c"},
+	}, moves)
+
+	// Tables out of the zones of their leaders are moved first, and they are
+	// moved to captures in their zones.
+	z := newTestZones(map[model.TableID]string{1: "z1", 2: "z2", 3: "z1", 4: "z2"})
+	moves = newBalanceMoveTables(
+		nil, captures, replications, nil, z, 10, model.ChangeFeedID{})
+	require.Equal(t, []replication.MoveTable{
+		{Span: tablepb.Span{TableID: 2}, DestCapture: "c"},
+		{Span: tablepb.Span{TableID: 4}, DestCapture: "c"},
+	}, moves)
+
+	// Captures in the zone are preferred over captures with less workload,
+	// unless the capture would be overloaded.
+	captures = newTestZoneCaptures(map[model.CaptureID]string{"a": "z1", "b": "z2", "c": "z1"})
+	replications = mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+	})
+	z = newTestZones(map[model.TableID]string{1: "z2", 2: "z2", 3: "z2", 4: "z2"})
+	moves = newBalanceMoveTables(
+		nil, captures, replications, nil, z, 10, model.ChangeFeedID{})
+	require.Equal(t, []replication.MoveTable{
+		{Span: tablepb.Span{TableID: 1}, DestCapture: "b"},
+	}, moves)
+	z.spans.ReplaceOrInsert(tablepb.Span{TableID: 5}, "z2")
+	replications.ReplaceOrInsert(tablepb.Span{TableID: 5}, &replication.ReplicationSet{
+		State: replication.ReplicationSetStateReplicating, Primary: "b",
+	})
+	moves = newBalanceMoveTables(
+		nil, captures, replications, nil, z, 10, model.ChangeFeedID{})
+	require.Equal(t, []replication.MoveTable{
+		{Span: tablepb.Span{TableID: 1}, DestCapture: "c"},
+	}, moves)
+}
+
+func TestSchedulerManagerUpdateSpanZones(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.NewDefaultSchedulerConfig()
+	sm := NewSchedulerManager(model.ChangeFeedID{}, cfg, nil)
+	require.Nil(t, sm.zones)
+	sm.UpdateSpanZones(nil)
+
+	cfg.ChangefeedSettings = &config.ChangefeedSchedulerConfig{ZoneLabel: "zone"}
+	sm = NewSchedulerManager(model.ChangeFeedID{}, cfg, nil)
+	require.False(t, sm.zones.enabled())
+	spans := spanz.NewBtreeMap[string]()
+	spans.ReplaceOrInsert(tablepb.Span{TableID: 1}, "z1")
+	sm.UpdateSpanZones(spans)
+	require.True(t, sm.zones.enabled())
+	zone, ok := sm.zones.spanZone(tablepb.Span{TableID: 1})
+	require.True(t, ok)
+	require.Equal(t, "z1", zone)
+}
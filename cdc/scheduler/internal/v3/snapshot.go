@@ -0,0 +1,300 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/redo"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/compat"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/keyspan"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/scheduler"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/transport"
+	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/spanz"
+)
+
+const (
+	// snapshotVersion is the version of the snapshot format.
+	snapshotVersion = 1
+	// redactedAddr replaces capture addresses in snapshots.
+	redactedAddr = "redacted"
+)
+
+// Snapshot is a dump of the in-memory states of a coordinator, it is used to
+// reproduce scheduling bugs offline, see Replay.
+//
+// Snapshots are redacted: capture addresses are removed, and keys that are
+// not table boundaries are replaced by keys in the same order.
+type Snapshot struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Changefeed      model.ChangeFeedID `json:"changefeed"`
+	CaptureID       model.CaptureID    `json:"capture_id"`
+	OwnerRevision   int64              `json:"owner_revision"`
+	ChangefeedEpoch uint64             `json:"changefeed_epoch"`
+
+	Config             *config.SchedulerConfig           `json:"config"`
+	ChangefeedSettings *config.ChangefeedSchedulerConfig `json:"changefeed_settings"`
+
+	// Inputs of the last tick.
+	CheckpointTs model.Ts                     `json:"checkpoint_ts"`
+	Tables       []model.TableID              `json:"tables"`
+	Barrier      *schedulepb.BarrierWithMinTs `json:"barrier"`
+
+	Captures     *member.Snapshot      `json:"captures"`
+	Replications *replication.Snapshot `json:"replications"`
+	Scheduler    *scheduler.Snapshot   `json:"scheduler"`
+	Compat       *compat.Snapshot      `json:"compat"`
+
+	// History is the recent schedule rounds that emit tasks, it is empty if
+	// the schedule history is disabled, see ReplayHistory.
+	History []*ScheduleRecord `json:"history,omitempty"`
+}
+
+// DumpSnapshot returns a redacted snapshot of the coordinator in JSON.
+func (c *coordinator) DumpSnapshot() ([]byte, error) {
+	c.mu.Lock()
+	s := c.snapshot()
+	c.mu.Unlock()
+
+	s.redact()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+func (c *coordinator) snapshot() *Snapshot {
+	s := &Snapshot{
+		Version:            snapshotVersion,
+		CreatedAt:          time.Now(),
+		Changefeed:         c.changefeedID,
+		CaptureID:          c.captureID,
+		OwnerRevision:      c.revision.Revision,
+		ChangefeedEpoch:    c.changefeedEpoch,
+		Config:             c.cfg,
+		ChangefeedSettings: c.cfg.ChangefeedSettings,
+		CheckpointTs:       c.lastCheckpointTs,
+		Tables:             make([]model.TableID, 0, c.tableRanges.Len()),
+		Captures:           c.captureM.Snapshot(),
+		Replications:       c.replicationM.Snapshot(),
+		Scheduler:          c.schedulerM.Snapshot(),
+		Compat:             c.compat.Snapshot(),
+	}
+	c.tableRanges.Iter(func(tableID model.TableID, _, _ tablepb.Span) bool {
+		s.Tables = append(s.Tables, tableID)
+		return true
+	})
+	if c.lastBarrier != nil {
+		barrier := *c.lastBarrier
+		s.Barrier = &barrier
+	}
+	if c.history.size > 0 {
+		s.History = c.history.clone()
+	}
+	return s
+}
+
+// redact removes capture addresses and row keys from the snapshot.
+func (s *Snapshot) redact() {
+	for _, capture := range s.Captures.Captures {
+		capture.Addr = redactedAddr
+	}
+	for i := range s.Compat.Captures {
+		s.Compat.Captures[i].Addr = redactedAddr
+	}
+	for _, record := range s.History {
+		for _, capture := range record.Inputs.Captures {
+			capture.Addr = redactedAddr
+		}
+	}
+	redactSpans(s.spans())
+	// Digests are of the redacted inputs, so that they can be verified
+	// when the history is replayed.
+	for _, record := range s.History {
+		record.InputsDigest = record.Inputs.digest()
+	}
+}
+
+// spans returns all spans in the snapshot.
+func (s *Snapshot) spans() []*tablepb.Span {
+	var spans []*tablepb.Span
+	appendTables := func(tables []tablepb.TableStatus) {
+		for i := range tables {
+			spans = append(spans, &tables[i].Span)
+		}
+	}
+	for _, capture := range s.Captures.Captures {
+		appendTables(capture.Tables)
+	}
+	if changes := s.Captures.Changes; changes != nil {
+		for _, tables := range changes.Init {
+			appendTables(tables)
+		}
+		for _, tables := range changes.Removed {
+			appendTables(tables)
+		}
+	}
+	for _, rs := range s.Replications.ReplicationSets {
+		spans = append(spans, &rs.Span)
+	}
+	for i := range s.Replications.RunningTasks {
+		spans = append(spans, &s.Replications.RunningTasks[i].Span)
+	}
+	for i := range s.Scheduler.MoveTables {
+		spans = append(spans, &s.Scheduler.MoveTables[i].Span)
+	}
+	for _, record := range s.History {
+		spans = append(spans, record.spans()...)
+	}
+	return spans
+}
+
+// redactSpans replaces keys inside table ranges with surrogate keys.
+// A surrogate key is the start key of the table followed by the rank of the
+// original key among keys of the table, so spans keep their order, and
+// equal keys stay equal.
+func redactSpans(spans []*tablepb.Span) {
+	tableKeys := make(map[model.TableID][]tablepb.Key)
+	for _, span := range spans {
+		tableSpan := spanz.TableIDToComparableSpan(span.TableID)
+		for _, key := range []tablepb.Key{span.StartKey, span.EndKey} {
+			if bytes.Compare(key, tableSpan.StartKey) > 0 &&
+				bytes.Compare(key, tableSpan.EndKey) < 0 {
+				tableKeys[span.TableID] = append(tableKeys[span.TableID], key)
+			}
+		}
+	}
+
+	surrogates := make(map[string]tablepb.Key)
+	for tableID, keys := range tableKeys {
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i], keys[j]) < 0
+		})
+		prefix := spanz.TableIDToComparableSpan(tableID).StartKey
+		rank := uint64(0)
+		for i, key := range keys {
+			if i > 0 && bytes.Equal(key, keys[i-1]) {
+				continue
+			}
+			rank++
+			surrogate := make(tablepb.Key, 0, len(prefix)+8)
+			surrogate = append(surrogate, prefix...)
+			surrogate = binary.BigEndian.AppendUint64(surrogate, rank)
+			surrogates[string(key)] = surrogate
+		}
+	}
+
+	for _, span := range spans {
+		if surrogate, ok := surrogates[string(span.StartKey)]; ok {
+			span.StartKey = surrogate
+		}
+		if surrogate, ok := surrogates[string(span.EndKey)]; ok {
+			span.EndKey = surrogate
+		}
+	}
+}
+
+// LoadSnapshot decodes a snapshot returned by DumpSnapshot.
+func LoadSnapshot(data []byte) (*Snapshot, error) {
+	s := &Snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, cerror.WrapError(cerror.ErrSchedulerSnapshotInvalid, err)
+	}
+	if s.Version != snapshotVersion {
+		return nil, cerror.ErrSchedulerSnapshotInvalid.GenWithStackByArgs(
+			"unsupported version")
+	}
+	if s.Config == nil || s.Captures == nil || s.Replications == nil ||
+		s.Scheduler == nil || s.Compat == nil {
+		return nil, cerror.ErrSchedulerSnapshotInvalid.GenWithStackByArgs(
+			"missing states")
+	}
+	return s, nil
+}
+
+// ReplayTick is the outputs of a tick replayed from a snapshot.
+type ReplayTick struct {
+	Tick         int                   `json:"tick"`
+	CheckpointTs model.Ts              `json:"checkpoint_ts"`
+	ResolvedTs   model.Ts              `json:"resolved_ts"`
+	Messages     []*schedulepb.Message `json:"messages"`
+}
+
+// Replay restores a coordinator from the snapshot and ticks it with the
+// inputs of the last tick in the snapshot. Captures do not respond, so each
+// tick shows the messages the coordinator sends in the states.
+// Schedulers draw random numbers from sources seeded with seed, replays of
+// the same snapshot and seed are deterministic.
+func Replay(
+	ctx context.Context, s *Snapshot, ticks int, seed int64,
+) ([]ReplayTick, error) {
+	cfg := *s.Config
+	cfg.ChangefeedSettings = s.ChangefeedSettings
+	if cfg.ChangefeedSettings == nil {
+		cfg.ChangefeedSettings = config.GetDefaultReplicaConfig().Scheduler
+	}
+	policy, err := scheduler.NewPolicy(s.Changefeed, &cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	coord := newCoordinator(s.CaptureID, s.Changefeed, s.OwnerRevision,
+		&cfg, redo.NewDisabledMetaManager(), policy)
+	trans := transport.NewMockTrans()
+	coord.trans = trans
+	coord.changefeedEpoch = s.ChangefeedEpoch
+	coord.reconciler = keyspan.NewReconcilerForTests(
+		keyspan.NewMockRegionCache(), cfg.ChangefeedSettings)
+	aliveCaptures := s.Compat.CaptureInfos()
+	coord.compat = compat.New(&cfg, aliveCaptures)
+	coord.captureM.Restore(s.Captures)
+	coord.replicationM.Restore(s.Replications)
+	coord.schedulerM.Restore(s.Scheduler, seed)
+
+	barrier := s.Barrier
+	if barrier == nil {
+		barrier = schedulepb.NewBarrierWithMinTs(s.CheckpointTs)
+	}
+	results := make([]ReplayTick, 0, ticks)
+	for i := 0; i < ticks; i++ {
+		checkpointTs, resolvedTs, err := coord.poll(
+			ctx, s.CheckpointTs, s.Tables, aliveCaptures, barrier)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, ReplayTick{
+			Tick:         i,
+			CheckpointTs: checkpointTs,
+			ResolvedTs:   resolvedTs,
+			Messages:     trans.SendBuffer,
+		})
+		trans.SendBuffer = nil
+	}
+	return results, nil
+}
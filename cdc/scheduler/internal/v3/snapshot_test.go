@@ -0,0 +1,163 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinatorSnapshotReplay(t *testing.T) {
+	t.Parallel()
+
+	coord, trans := newTestCoordinator(&config.SchedulerConfig{
+		HeartbeatTick:      math.MaxInt,
+		CollectStatsTick:   math.MaxInt,
+		MaxTaskConcurrency: 10,
+		AddTableBatchSize:  50,
+		ChangefeedSettings: config.GetDefaultReplicaConfig().Scheduler,
+	})
+
+	// Table 1 is split into two spans, its middle key is a row key.
+	table1 := spanz.TableIDToComparableSpan(1)
+	middleKey := append(append(tablepb.Key{}, table1.StartKey...), 'r', 'o', 'w')
+	spans := []tablepb.Span{
+		{TableID: 1, StartKey: table1.StartKey, EndKey: middleKey},
+		{TableID: 1, StartKey: middleKey, EndKey: table1.EndKey},
+		spanz.TableIDToComparableSpan(2),
+		spanz.TableIDToComparableSpan(3),
+	}
+	// Capture "a" replicates table 1, "b" replicates table 3, and "c"
+	// replicates table 2.
+	captureSpans := map[model.CaptureID][]tablepb.Span{
+		"a": spans[:2], "b": spans[3:], "c": spans[2:3],
+	}
+	aliveCaptures := map[model.CaptureID]*model.CaptureInfo{}
+	init := map[model.CaptureID][]tablepb.TableStatus{}
+	for captureID, captureSpans := range captureSpans {
+		aliveCaptures[captureID] = &model.CaptureInfo{
+			ID: captureID, AdvertiseAddr: "127.0.0.1:830" + captureID,
+		}
+		tables := []tablepb.TableStatus{}
+		for _, span := range captureSpans {
+			tables = append(tables, tablepb.TableStatus{
+				Span:  span,
+				State: tablepb.TableStateReplicating,
+			})
+		}
+		coord.captureM.Captures[captureID] = &member.CaptureStatus{
+			ID:     captureID,
+			Addr:   aliveCaptures[captureID].AdvertiseAddr,
+			State:  member.CaptureStateInitialized,
+			Tables: tables,
+		}
+		init[captureID] = tables
+	}
+	coord.captureM.SetInitializedForTests(true)
+	_, err := coord.replicationM.HandleCaptureChanges(init, nil, 0)
+	require.Nil(t, err)
+
+	ctx := context.Background()
+	currentTables := []model.TableID{1, 2, 3}
+	_, _, err = coord.poll(ctx, 1, currentTables, aliveCaptures, schedulepb.NewBarrierWithMinTs(1))
+	require.Nil(t, err)
+	require.Len(t, trans.SendBuffer, 0)
+	count, err := coord.DrainCapture("c")
+	require.Nil(t, err)
+	require.Equal(t, 1, count)
+
+	data, err := coord.DumpSnapshot()
+	require.Nil(t, err)
+	require.NotContains(t, string(data), "127.0.0.1")
+	s, err := LoadSnapshot(data)
+	require.Nil(t, err)
+	require.Equal(t, "c", s.Scheduler.DrainingTarget)
+	require.Equal(t, currentTables, s.Tables)
+	require.EqualValues(t, 1, s.CheckpointTs)
+	require.Len(t, s.Captures.Captures, 3)
+	require.Equal(t, redactedAddr, s.Captures.Captures[0].Addr)
+
+	// The row key is redacted, spans keep their order and table boundaries.
+	require.Len(t, s.Replications.ReplicationSets, 4)
+	first := s.Replications.ReplicationSets[0].Span
+	second := s.Replications.ReplicationSets[1].Span
+	require.Equal(t, table1.StartKey, first.StartKey)
+	require.NotEqual(t, middleKey, first.EndKey)
+	require.Equal(t, first.EndKey, second.StartKey)
+	require.Equal(t, table1.EndKey, second.EndKey)
+	require.True(t, first.Less(&second))
+	require.Equal(t, first.EndKey, s.Captures.Captures[0].Tables[1].Span.StartKey)
+
+	// Replays are deterministic, table 2 is moved from capture "c" to "b".
+	replay := func() []ReplayTick {
+		ticks, err := Replay(ctx, s, 2, 1)
+		require.Nil(t, err)
+		require.Len(t, ticks, 2)
+		return ticks
+	}
+	ticks := replay()
+	require.Equal(t, ticks, replay())
+	require.Len(t, ticks[0].Messages, 1)
+	addTable := ticks[0].Messages[0].DispatchTableRequest.GetAddTable()
+	require.NotNil(t, addTable)
+	require.EqualValues(t, 2, addTable.Span.TableID)
+	require.Equal(t, "b", ticks[0].Messages[0].To)
+	require.Len(t, ticks[1].Messages, 0)
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadSnapshot([]byte("{"))
+	require.ErrorIs(t, err, cerror.ErrSchedulerSnapshotInvalid)
+	_, err = LoadSnapshot([]byte(`{"version":2}`))
+	require.ErrorIs(t, err, cerror.ErrSchedulerSnapshotInvalid)
+	_, err = LoadSnapshot([]byte(`{"version":1}`))
+	require.ErrorIs(t, err, cerror.ErrSchedulerSnapshotInvalid)
+}
+
+func TestRedactSpans(t *testing.T) {
+	t.Parallel()
+
+	table1 := spanz.TableIDToComparableSpan(1)
+	key := func(suffix string) tablepb.Key {
+		return append(append(tablepb.Key{}, table1.StartKey...), suffix...)
+	}
+	spans := []*tablepb.Span{
+		{TableID: 1, StartKey: key("b"), EndKey: key("c")},
+		{TableID: 1, StartKey: table1.StartKey, EndKey: key("b")},
+		{TableID: 1, StartKey: key("c"), EndKey: table1.EndKey},
+		// Keys out of the table range are kept.
+		{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")},
+	}
+	redactSpans(spans)
+	require.Equal(t, spans[1].EndKey, spans[0].StartKey)
+	require.Equal(t, spans[0].EndKey, spans[2].StartKey)
+	require.True(t, spans[1].Less(spans[0]))
+	require.True(t, spans[0].Less(spans[2]))
+	require.NotEqual(t, key("b"), spans[0].StartKey)
+	require.Equal(t, table1.StartKey, spans[1].StartKey)
+	require.Equal(t, table1.EndKey, spans[2].EndKey)
+	require.Equal(t, tablepb.Key("a"), spans[3].StartKey)
+}
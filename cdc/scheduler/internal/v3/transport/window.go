@@ -0,0 +1,177 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
+	"go.uber.org/zap"
+)
+
+// SendWindow assigns sequence numbers to dispatch table requests sent by
+// the owner, and keeps them until they are acknowledged by captures, so that
+// lost requests can be detected and re-sent.
+//
+// Captures handle dispatch table requests in order of sequence numbers.
+// Since messages between two nodes are delivered in order, a request is lost
+// if a capture has seen a larger sequence number but not acknowledged it.
+type SendWindow struct {
+	changefeed model.ChangeFeedID
+	captures   map[model.CaptureID]*sendWindow
+}
+
+type sendWindow struct {
+	// The largest sequence number sent.
+	lastSeq uint64
+	// The largest sequence number acknowledged.
+	ack     uint64
+	unacked []*schedulepb.Message
+}
+
+// NewSendWindow returns a new SendWindow.
+func NewSendWindow(changefeed model.ChangeFeedID) *SendWindow {
+	return &SendWindow{
+		changefeed: changefeed,
+		captures:   make(map[model.CaptureID]*sendWindow),
+	}
+}
+
+// Send sets the sequence number in the header of the message.
+func (s *SendWindow) Send(msg *schedulepb.Message) {
+	w, ok := s.captures[msg.To]
+	if !ok {
+		w = &sendWindow{}
+		s.captures[msg.To] = w
+	}
+	if msg.MsgType == schedulepb.MsgDispatchTableRequest {
+		w.lastSeq++
+		w.unacked = append(w.unacked, msg)
+	}
+	msg.Header.Seq = w.lastSeq
+}
+
+// Ack drops dispatch table requests acknowledged by the message from a
+// capture. If the message is a heartbeat response, it also returns requests
+// that are lost, so that a lost request is re-sent at most once per heartbeat.
+//
+// Requests sent to a previous processor epoch of the capture are dropped,
+// they must not be delivered to the new epoch. Tables of them are reconciled
+// by heartbeats, and new requests are sent if necessary.
+func (s *SendWindow) Ack(msg *schedulepb.Message) []*schedulepb.Message {
+	w, ok := s.captures[msg.From]
+	if !ok {
+		return nil
+	}
+	epoch := msg.Header.ProcessorEpoch
+	unacked := w.unacked[:0]
+	for _, m := range w.unacked {
+		if m.Header.ProcessorEpoch == epoch {
+			unacked = append(unacked, m)
+		}
+	}
+	droppedCount := len(w.unacked) - len(unacked)
+	w.unacked = unacked
+	ack := msg.Header.Ack
+	if ack < w.ack || ack > w.lastSeq || droppedCount > 0 {
+		// The capture has reset its window, or requests are dropped. Re-sync
+		// sequence numbers by renumbering requests that are not acknowledged,
+		// and re-send all of them in order.
+		log.Warn("schedulerv3: sequence numbers diverge, re-sync send window",
+			zap.String("namespace", s.changefeed.Namespace),
+			zap.String("changefeed", s.changefeed.ID),
+			zap.String("capture", msg.From),
+			zap.String("epoch", epoch.Epoch),
+			zap.Uint64("ack", ack),
+			zap.Uint64("expectedAck", w.ack),
+			zap.Uint64("lastSeq", w.lastSeq),
+			zap.Int("droppedCount", droppedCount),
+			zap.Int("resentCount", len(w.unacked)))
+		w.lastSeq, w.ack = ack, ack
+		for _, m := range w.unacked {
+			w.lastSeq++
+			m.Header.Seq = w.lastSeq
+		}
+		return append([]*schedulepb.Message(nil), w.unacked...)
+	}
+	w.ack = ack
+	i := 0
+	for i < len(w.unacked) && w.unacked[i].Header.Seq <= ack {
+		i++
+	}
+	w.unacked = w.unacked[i:]
+
+	if msg.MsgType != schedulepb.MsgHeartbeatResponse {
+		return nil
+	}
+	var lost []*schedulepb.Message
+	for _, m := range w.unacked {
+		if m.Header.Seq > msg.Header.Seq {
+			break
+		}
+		lost = append(lost, m)
+	}
+	return lost
+}
+
+// RemoveCapture drops requests sent to the capture.
+func (s *SendWindow) RemoveCapture(captureID model.CaptureID) {
+	delete(s.captures, captureID)
+}
+
+// RecvWindow tracks sequence numbers of dispatch table requests received by
+// a capture.
+type RecvWindow struct {
+	// The largest sequence number seen.
+	seen uint64
+	// The largest sequence number of requests received in order.
+	ack uint64
+}
+
+// Observe records the sequence number of a message that is not a dispatch
+// table request.
+func (w *RecvWindow) Observe(seq uint64) {
+	if seq > w.seen {
+		w.seen = seq
+	}
+}
+
+// Receive returns true if the dispatch table request should be handled.
+// Duplicate requests and requests following a lost one are dropped, the owner
+// re-sends them in order.
+func (w *RecvWindow) Receive(seq uint64) bool {
+	if seq == 0 {
+		// The owner does not support sequence numbers.
+		return true
+	}
+	w.Observe(seq)
+	if seq != w.ack+1 {
+		return false
+	}
+	w.ack = seq
+	return true
+}
+
+// FillHeader sets sequence numbers in the header of a message sent to
+// the owner.
+func (w *RecvWindow) FillHeader(header *schedulepb.Message_Header) {
+	header.Seq = w.seen
+	header.Ack = w.ack
+}
+
+// Reset resets the window, it must be called when the owner changes.
+func (w *RecvWindow) Reset() {
+	w.seen = 0
+	w.ack = 0
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendRecvWindow(t *testing.T) {
+	t.Parallel()
+
+	newMsg := func(msgType schedulepb.MessageType) *schedulepb.Message {
+		return &schedulepb.Message{
+			Header:  &schedulepb.Message_Header{},
+			MsgType: msgType,
+			From:    "owner",
+			To:      "a",
+		}
+	}
+	ownerW := NewSendWindow(model.ChangeFeedID{})
+	agentW := &RecvWindow{}
+	// reply returns a message sent by the agent.
+	reply := func(msgType schedulepb.MessageType) *schedulepb.Message {
+		msg := newMsg(msgType)
+		msg.From, msg.To = "a", "owner"
+		agentW.FillHeader(msg.Header)
+		return msg
+	}
+
+	// Send 3 requests, the 2nd is lost.
+	reqs := make([]*schedulepb.Message, 3)
+	for i := range reqs {
+		reqs[i] = newMsg(schedulepb.MsgDispatchTableRequest)
+		ownerW.Send(reqs[i])
+		require.EqualValues(t, i+1, reqs[i].Header.Seq)
+	}
+	require.True(t, agentW.Receive(reqs[0].Header.Seq))
+	require.False(t, agentW.Receive(reqs[2].Header.Seq))
+
+	// Only heartbeat responses trigger re-sending.
+	require.Nil(t, ownerW.Ack(reply(schedulepb.MsgDispatchTableResponse)))
+	lost := ownerW.Ack(reply(schedulepb.MsgHeartbeatResponse))
+	require.Equal(t, reqs[1:], lost)
+
+	// Duplicate requests are dropped.
+	require.False(t, agentW.Receive(reqs[0].Header.Seq))
+	for _, msg := range lost {
+		require.True(t, agentW.Receive(msg.Header.Seq))
+	}
+	require.Nil(t, ownerW.Ack(reply(schedulepb.MsgHeartbeatResponse)))
+	require.Empty(t, ownerW.captures["a"].unacked)
+
+	// The last request is lost, the agent finds it by a heartbeat.
+	req := newMsg(schedulepb.MsgDispatchTableRequest)
+	ownerW.Send(req)
+	heartbeat := newMsg(schedulepb.MsgHeartbeat)
+	ownerW.Send(heartbeat)
+	require.EqualValues(t, 4, heartbeat.Header.Seq)
+	agentW.Observe(heartbeat.Header.Seq)
+	require.Equal(t, []*schedulepb.Message{req},
+		ownerW.Ack(reply(schedulepb.MsgHeartbeatResponse)))
+
+	// The capture resets its window, requests that are not acknowledged are
+	// renumbered and re-sent.
+	require.True(t, agentW.Receive(req.Header.Seq))
+	require.Nil(t, ownerW.Ack(reply(schedulepb.MsgHeartbeatResponse)))
+	agentW = &RecvWindow{}
+	reqs = []*schedulepb.Message{
+		newMsg(schedulepb.MsgDispatchTableRequest),
+		newMsg(schedulepb.MsgDispatchTableRequest),
+	}
+	for _, msg := range reqs {
+		ownerW.Send(msg)
+	}
+	require.EqualValues(t, 5, reqs[0].Header.Seq)
+	require.False(t, agentW.Receive(reqs[0].Header.Seq))
+	require.Equal(t, reqs, ownerW.Ack(reply(schedulepb.MsgDispatchTableResponse)))
+	require.EqualValues(t, 1, reqs[0].Header.Seq)
+	require.EqualValues(t, 2, reqs[1].Header.Seq)
+	for _, msg := range reqs {
+		require.True(t, agentW.Receive(msg.Header.Seq))
+	}
+	require.Nil(t, ownerW.Ack(reply(schedulepb.MsgHeartbeatResponse)))
+	require.Empty(t, ownerW.captures["a"].unacked)
+	req = newMsg(schedulepb.MsgDispatchTableRequest)
+	ownerW.Send(req)
+	require.EqualValues(t, 3, req.Header.Seq)
+	require.True(t, agentW.Receive(req.Header.Seq))
+
+	// The window of the capture is removed while the capture keeps its
+	// window, sequence numbers continue from the acknowledged one.
+	ownerW.RemoveCapture("a")
+	req = newMsg(schedulepb.MsgDispatchTableRequest)
+	ownerW.Send(req)
+	require.EqualValues(t, 1, req.Header.Seq)
+	require.False(t, agentW.Receive(req.Header.Seq))
+	require.Equal(t, []*schedulepb.Message{req},
+		ownerW.Ack(reply(schedulepb.MsgHeartbeatResponse)))
+	require.EqualValues(t, 4, req.Header.Seq)
+	require.True(t, agentW.Receive(req.Header.Seq))
+
+	// The capture restarts with a new processor epoch, the request sent to
+	// the old epoch is dropped instead of being delivered to the new one.
+	req = newMsg(schedulepb.MsgDispatchTableRequest)
+	ownerW.Send(req)
+	require.EqualValues(t, 5, req.Header.Seq)
+	agentW = &RecvWindow{}
+	epoch := schedulepb.ProcessorEpoch{Epoch: "new"}
+	resp := reply(schedulepb.MsgHeartbeatResponse)
+	resp.Header.ProcessorEpoch = epoch
+	require.Empty(t, ownerW.Ack(resp))
+	require.Empty(t, ownerW.captures["a"].unacked)
+	req = newMsg(schedulepb.MsgDispatchTableRequest)
+	req.Header.ProcessorEpoch = epoch
+	ownerW.Send(req)
+	require.EqualValues(t, 1, req.Header.Seq)
+	require.True(t, agentW.Receive(req.Header.Seq))
+
+	ownerW.RemoveCapture("a")
+	require.Empty(t, ownerW.captures)
+
+	// Requests from owners that do not support sequence numbers are always
+	// handled.
+	require.True(t, agentW.Receive(0))
+	require.True(t, agentW.Receive(0))
+}
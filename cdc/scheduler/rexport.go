@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler/internal"
 	v3 "github.com/pingcap/tiflow/cdc/scheduler/internal/v3"
 	v3agent "github.com/pingcap/tiflow/cdc/scheduler/internal/v3/agent"
+	v3scheduler "github.com/pingcap/tiflow/cdc/scheduler/internal/v3/scheduler"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/etcd"
 	"github.com/pingcap/tiflow/pkg/p2p"
@@ -46,6 +47,25 @@ type Scheduler internal.Scheduler
 // We need this interface so that we can provide the information through HTTP API.
 type InfoProvider internal.InfoProvider
 
+// TableNameUpdater is implemented by schedulers that match tables by their
+// names, e.g., to respect the table affinity rules of the changefeed.
+type TableNameUpdater internal.TableNameUpdater
+
+// TableResumer is implemented by schedulers that can resume replication of
+// paused tables from the ts they are paused at.
+type TableResumer internal.TableResumer
+
+// CaptureDrainController is implemented by schedulers that can cancel and
+// force draining captures.
+type CaptureDrainController internal.CaptureDrainController
+
+// Planner is implemented by schedulers that can preview schedule operations.
+type Planner internal.Planner
+
+// TaskHistoryProvider is implemented by schedulers that record the schedule
+// tasks they perform.
+type TaskHistoryProvider internal.TaskHistoryProvider
+
 // Query is for open api can access the scheduler
 type Query internal.Query
 
@@ -96,6 +116,40 @@ func NewScheduler(
 		changefeedEpoch, up, cfg, redoMetaManager)
 }
 
+// Snapshot is a redacted dump of the in-memory states of a scheduler.
+type Snapshot = v3.Snapshot
+
+// ReplayTick is the outputs of a tick replayed from a snapshot.
+type ReplayTick = v3.ReplayTick
+
+// LoadSnapshot decodes a snapshot dumped by InfoProvider.DumpSnapshot.
+func LoadSnapshot(data []byte) (*Snapshot, error) {
+	return v3.LoadSnapshot(data)
+}
+
+// Replay restores a scheduler from the snapshot and replays ticks with the
+// inputs recorded in the snapshot.
+func Replay(
+	ctx context.Context, snapshot *Snapshot, ticks int, seed int64,
+) ([]ReplayTick, error) {
+	return v3.Replay(ctx, snapshot, ticks, seed)
+}
+
+// ReplayedRecord is the result of replaying a schedule record.
+type ReplayedRecord = v3.ReplayedRecord
+
+// ReplayHistory replays the schedule history recorded in the snapshot, and
+// compares the replayed tasks with the recorded ones.
+func ReplayHistory(snapshot *Snapshot) ([]ReplayedRecord, error) {
+	return v3.ReplayHistory(snapshot)
+}
+
+// ValidatePolicy returns an error if there is no scheduler policy registered
+// by the name.
+func ValidatePolicy(name string) error {
+	return v3scheduler.ValidatePolicy(name)
+}
+
 // InitMetrics registers all metrics used in scheduler
 func InitMetrics(registry *prometheus.Registry) {
 	v3.InitMetrics(registry)
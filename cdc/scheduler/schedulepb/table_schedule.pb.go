@@ -4,6 +4,7 @@
 package schedulepb
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
@@ -642,6 +643,9 @@ func (m *Heartbeat) GetBarrier() *Barrier {
 type HeartbeatResponse struct {
 	Tables   []tablepb.TableStatus                        `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables"`
 	Liveness github_com_pingcap_tiflow_cdc_model.Liveness `protobuf:"varint,2,opt,name=liveness,proto3,casttype=github.com/pingcap/tiflow/cdc/model.Liveness" json:"liveness,omitempty"`
+	// The load of the capture, it is only reported if collect_stats is set
+	// in the heartbeat.
+	Load *CaptureLoad `protobuf:"bytes,3,opt,name=load,proto3" json:"load,omitempty"`
 }
 
 func (m *HeartbeatResponse) Reset()         { *m = HeartbeatResponse{} }
@@ -691,6 +695,86 @@ func (m *HeartbeatResponse) GetLiveness() github_com_pingcap_tiflow_cdc_model.Li
 	return 0
 }
 
+func (m *HeartbeatResponse) GetLoad() *CaptureLoad {
+	if m != nil {
+		return m.Load
+	}
+	return nil
+}
+
+// CaptureLoad is the load of a capture and the changefeed on it.
+type CaptureLoad struct {
+	// CPU usage of the capture process, 100 means one core is fully used.
+	CPUUsage float64 `protobuf:"fixed64,1,opt,name=cpu_usage,json=cpuUsage,proto3" json:"cpu_usage,omitempty"`
+	// Resident memory of the capture process in bytes.
+	MemoryBytes uint64 `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	// Disk space used by the sorter of the capture in bytes.
+	SorterDiskBytes uint64 `protobuf:"varint,3,opt,name=sorter_disk_bytes,json=sorterDiskBytes,proto3" json:"sorter_disk_bytes,omitempty"`
+	// Rows written to the sink of the changefeed per second.
+	SinkRowsPerSecond float64 `protobuf:"fixed64,4,opt,name=sink_rows_per_second,json=sinkRowsPerSecond,proto3" json:"sink_rows_per_second,omitempty"`
+}
+
+func (m *CaptureLoad) Reset()         { *m = CaptureLoad{} }
+func (m *CaptureLoad) String() string { return proto.CompactTextString(m) }
+func (*CaptureLoad) ProtoMessage()    {}
+func (*CaptureLoad) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86eeacbf6ca5b996, []int{10}
+}
+func (m *CaptureLoad) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CaptureLoad) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CaptureLoad.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CaptureLoad) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CaptureLoad.Merge(m, src)
+}
+func (m *CaptureLoad) XXX_Size() int {
+	return m.Size()
+}
+func (m *CaptureLoad) XXX_DiscardUnknown() {
+	xxx_messageInfo_CaptureLoad.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CaptureLoad proto.InternalMessageInfo
+
+func (m *CaptureLoad) GetCPUUsage() float64 {
+	if m != nil {
+		return m.CPUUsage
+	}
+	return 0
+}
+
+func (m *CaptureLoad) GetMemoryBytes() uint64 {
+	if m != nil {
+		return m.MemoryBytes
+	}
+	return 0
+}
+
+func (m *CaptureLoad) GetSorterDiskBytes() uint64 {
+	if m != nil {
+		return m.SorterDiskBytes
+	}
+	return 0
+}
+
+func (m *CaptureLoad) GetSinkRowsPerSecond() float64 {
+	if m != nil {
+		return m.SinkRowsPerSecond
+	}
+	return 0
+}
+
 type OwnerRevision struct {
 	Revision int64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
 }
@@ -699,7 +783,7 @@ func (m *OwnerRevision) Reset()         { *m = OwnerRevision{} }
 func (m *OwnerRevision) String() string { return proto.CompactTextString(m) }
 func (*OwnerRevision) ProtoMessage()    {}
 func (*OwnerRevision) Descriptor() ([]byte, []int) {
-	return fileDescriptor_86eeacbf6ca5b996, []int{10}
+	return fileDescriptor_86eeacbf6ca5b996, []int{11}
 }
 func (m *OwnerRevision) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -743,7 +827,7 @@ func (m *ProcessorEpoch) Reset()         { *m = ProcessorEpoch{} }
 func (m *ProcessorEpoch) String() string { return proto.CompactTextString(m) }
 func (*ProcessorEpoch) ProtoMessage()    {}
 func (*ProcessorEpoch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_86eeacbf6ca5b996, []int{11}
+	return fileDescriptor_86eeacbf6ca5b996, []int{12}
 }
 func (m *ProcessorEpoch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -787,7 +871,7 @@ func (m *ChangefeedEpoch) Reset()         { *m = ChangefeedEpoch{} }
 func (m *ChangefeedEpoch) String() string { return proto.CompactTextString(m) }
 func (*ChangefeedEpoch) ProtoMessage()    {}
 func (*ChangefeedEpoch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_86eeacbf6ca5b996, []int{12}
+	return fileDescriptor_86eeacbf6ca5b996, []int{13}
 }
 func (m *ChangefeedEpoch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -838,7 +922,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_86eeacbf6ca5b996, []int{13}
+	return fileDescriptor_86eeacbf6ca5b996, []int{14}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -929,13 +1013,22 @@ type Message_Header struct {
 	OwnerRevision   OwnerRevision   `protobuf:"bytes,2,opt,name=owner_revision,json=ownerRevision,proto3" json:"owner_revision"`
 	ProcessorEpoch  ProcessorEpoch  `protobuf:"bytes,3,opt,name=processor_epoch,json=processorEpoch,proto3" json:"processor_epoch"`
 	ChangefeedEpoch ChangefeedEpoch `protobuf:"bytes,4,opt,name=changefeed_epoch,json=changefeedEpoch,proto3" json:"changefeed_epoch"`
+	// For messages sent by the owner, it is the sequence number of
+	// a dispatch table request, or the latest sequence number sent to
+	// the capture for other messages.
+	// For messages sent by a capture, it is the largest sequence number
+	// that the capture has seen.
+	Seq uint64 `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	// The largest sequence number of dispatch table requests that
+	// the capture has received in order. It is only set by captures.
+	Ack uint64 `protobuf:"varint,6,opt,name=ack,proto3" json:"ack,omitempty"`
 }
 
 func (m *Message_Header) Reset()         { *m = Message_Header{} }
 func (m *Message_Header) String() string { return proto.CompactTextString(m) }
 func (*Message_Header) ProtoMessage()    {}
 func (*Message_Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_86eeacbf6ca5b996, []int{13, 0}
+	return fileDescriptor_86eeacbf6ca5b996, []int{14, 0}
 }
 func (m *Message_Header) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -992,6 +1085,20 @@ func (m *Message_Header) GetChangefeedEpoch() ChangefeedEpoch {
 	return ChangefeedEpoch{}
 }
 
+func (m *Message_Header) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Message_Header) GetAck() uint64 {
+	if m != nil {
+		return m.Ack
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("pingcap.tiflow.cdc.scheduler.schedulepb.MessageType", MessageType_name, MessageType_value)
 	proto.RegisterType((*AddTableRequest)(nil), "pingcap.tiflow.cdc.scheduler.schedulepb.AddTableRequest")
@@ -1004,6 +1111,7 @@ func init() {
 	proto.RegisterType((*Barrier)(nil), "pingcap.tiflow.cdc.scheduler.schedulepb.Barrier")
 	proto.RegisterType((*Heartbeat)(nil), "pingcap.tiflow.cdc.scheduler.schedulepb.Heartbeat")
 	proto.RegisterType((*HeartbeatResponse)(nil), "pingcap.tiflow.cdc.scheduler.schedulepb.HeartbeatResponse")
+	proto.RegisterType((*CaptureLoad)(nil), "pingcap.tiflow.cdc.scheduler.schedulepb.CaptureLoad")
 	proto.RegisterType((*OwnerRevision)(nil), "pingcap.tiflow.cdc.scheduler.schedulepb.OwnerRevision")
 	proto.RegisterType((*ProcessorEpoch)(nil), "pingcap.tiflow.cdc.scheduler.schedulepb.ProcessorEpoch")
 	proto.RegisterType((*ChangefeedEpoch)(nil), "pingcap.tiflow.cdc.scheduler.schedulepb.ChangefeedEpoch")
@@ -1016,81 +1124,90 @@ func init() {
 }
 
 var fileDescriptor_86eeacbf6ca5b996 = []byte{
-	// 1175 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0x5f, 0x6f, 0xdb, 0x54,
-	0x14, 0x8f, 0x93, 0xb4, 0x71, 0x4e, 0xda, 0x34, 0xbb, 0x74, 0xcc, 0x0a, 0x90, 0x04, 0x23, 0xb1,
-	0xb2, 0x81, 0xb3, 0x05, 0x18, 0x63, 0x03, 0xa4, 0xa5, 0x1d, 0x6a, 0xd1, 0xaa, 0x56, 0x6e, 0x0b,
-	0x08, 0x21, 0x05, 0xc7, 0xbe, 0x75, 0xac, 0x25, 0xbe, 0xc6, 0xd7, 0x6d, 0xd5, 0xaf, 0xd0, 0x27,
-	0xbe, 0x40, 0x3f, 0x00, 0x8f, 0x20, 0x21, 0xf1, 0x30, 0x89, 0xd7, 0x49, 0xbc, 0xf4, 0x11, 0x21,
-	0x14, 0x8d, 0xf6, 0x5b, 0x94, 0x17, 0xe4, 0x7b, 0xaf, 0x9d, 0xa4, 0x4d, 0xc1, 0x29, 0x03, 0x89,
-	0x37, 0xdf, 0x73, 0x7d, 0x7e, 0xe7, 0xcf, 0xfd, 0xfd, 0xce, 0xb5, 0xe1, 0x0d, 0x6a, 0x76, 0xb0,
-	0xb5, 0xd3, 0xc5, 0x7e, 0x3d, 0x7a, 0xf2, 0xda, 0xf5, 0xc0, 0x68, 0x77, 0x71, 0x2b, 0x32, 0x68,
-	0x9e, 0x4f, 0x02, 0x82, 0xae, 0x7b, 0x8e, 0x6b, 0x9b, 0x86, 0xa7, 0x05, 0xce, 0x76, 0x97, 0xec,
-	0x69, 0xa6, 0x65, 0x6a, 0xb1, 0xb7, 0x36, 0xf0, 0x2e, 0xcf, 0xdb, 0xc4, 0x26, 0xcc, 0xa7, 0x1e,
-	0x3e, 0x71, 0xf7, 0xf2, 0x2b, 0x9e, 0x4f, 0x4c, 0x4c, 0x29, 0xf1, 0x39, 0x7c, 0x14, 0x86, 0x6f,
-	0xab, 0xdf, 0xa6, 0x61, 0xee, 0x81, 0x65, 0x6d, 0x86, 0x26, 0x1d, 0x7f, 0xbd, 0x83, 0x69, 0x80,
-	0xb6, 0x40, 0xe6, 0x99, 0x38, 0x96, 0x22, 0xd5, 0xa4, 0x85, 0x4c, 0xf3, 0xde, 0x71, 0xbf, 0x9a,
-	0x63, 0xef, 0xac, 0x2c, 0x9d, 0xf6, 0xab, 0x37, 0x6d, 0x27, 0xe8, 0xec, 0xb4, 0x35, 0x93, 0xf4,
-	0xea, 0x22, 0xbb, 0x3a, 0xcf, 0xae, 0x6e, 0x5a, 0x66, 0xbd, 0x47, 0x2c, 0xdc, 0xd5, 0xc4, 0xeb,
-	0x7a, 0x8e, 0x61, 0xad, 0x58, 0x68, 0x09, 0xb2, 0xd4, 0x33, 0x5c, 0x25, 0x5b, 0x93, 0x16, 0x0a,
-	0x8d, 0x1b, 0xda, 0x98, 0xba, 0xe2, 0x5c, 0x35, 0x91, 0xab, 0xb6, 0xe1, 0x19, 0x6e, 0x33, 0xfb,
-	0xb4, 0x5f, 0x4d, 0xe9, 0xcc, 0x1b, 0xbd, 0x0a, 0x33, 0x0e, 0x6d, 0x51, 0x6c, 0x12, 0xd7, 0x32,
-	0xfc, 0x7d, 0x25, 0x5d, 0x93, 0x16, 0x64, 0xbd, 0xe0, 0xd0, 0x8d, 0xc8, 0x84, 0x3e, 0x05, 0x30,
-	0x3b, 0xd8, 0x7c, 0xec, 0x11, 0xc7, 0x0d, 0x94, 0x0c, 0x0b, 0x77, 0x2b, 0x59, 0xb8, 0xc5, 0xd8,
-	0x4f, 0x04, 0x1d, 0x42, 0x52, 0xbf, 0x93, 0x00, 0xe9, 0xb8, 0x47, 0x76, 0xf1, 0x7f, 0xd9, 0xae,
-	0xf4, 0x3f, 0x69, 0x97, 0xfa, 0x9b, 0x04, 0xf3, 0x4b, 0x0e, 0xf5, 0x8c, 0xc0, 0xec, 0x8c, 0x64,
-	0xfd, 0x19, 0xe4, 0x0d, 0xcb, 0x6a, 0x31, 0x47, 0x96, 0x76, 0xa1, 0x71, 0x57, 0x4b, 0x48, 0x35,
-	0xed, 0x0c, 0x63, 0x96, 0x53, 0xba, 0x6c, 0x08, 0x13, 0xfa, 0x0a, 0x66, 0x7c, 0xd6, 0x24, 0x81,
-	0xcd, 0xf3, 0xbf, 0x9f, 0x18, 0xfb, 0x7c, 0x87, 0x97, 0x53, 0x7a, 0xc1, 0x1f, 0x58, 0x9b, 0x79,
-	0xc8, 0xf9, 0x7c, 0x47, 0xfd, 0x41, 0x82, 0xd2, 0x20, 0x19, 0xea, 0x11, 0x97, 0x62, 0xb4, 0x02,
-	0xd3, 0x34, 0x30, 0x82, 0x1d, 0x2a, 0xea, 0xba, 0x9d, 0xac, 0x77, 0x0c, 0x64, 0x83, 0x39, 0xea,
-	0x02, 0xe0, 0x0c, 0x95, 0xd2, 0xcf, 0x8d, 0x4a, 0x3f, 0x4a, 0xf0, 0xc2, 0x48, 0xa1, 0xff, 0x9f,
-	0xd4, 0x9f, 0x49, 0x70, 0xf5, 0x0c, 0xa3, 0x44, 0xf2, 0x9f, 0x9f, 0xa7, 0xd4, 0xfb, 0x97, 0xa0,
-	0x14, 0x47, 0x1b, 0xe1, 0x94, 0x31, 0x96, 0x53, 0x1f, 0x5c, 0x8e, 0x53, 0x31, 0xfe, 0x08, 0xa9,
-	0x00, 0x64, 0x5f, 0x6c, 0xa9, 0x4f, 0x24, 0x98, 0xe1, 0x56, 0xc3, 0xf7, 0x1d, 0xec, 0xff, 0x5b,
-	0x12, 0xdf, 0x02, 0x68, 0xf3, 0x08, 0xad, 0x80, 0xb2, 0xa2, 0xb2, 0xcd, 0x3b, 0xa7, 0xfd, 0x6a,
-	0xe3, 0xaf, 0xd1, 0xce, 0x4d, 0x74, 0x6d, 0x93, 0xea, 0x79, 0x81, 0xb4, 0x49, 0xd5, 0x9f, 0x25,
-	0xc8, 0x45, 0x99, 0x7f, 0x09, 0x45, 0x9e, 0xb9, 0xd8, 0x0e, 0x89, 0x95, 0x59, 0x28, 0x34, 0xde,
-	0x4d, 0xdc, 0xbb, 0xe1, 0x46, 0xe8, 0xb3, 0xc1, 0xd0, 0x8a, 0xa2, 0x36, 0x5c, 0xb1, 0xbb, 0xa4,
-	0x6d, 0x74, 0x5b, 0xcf, 0xad, 0x8e, 0x39, 0x0e, 0xd8, 0x8c, 0xab, 0xf9, 0x29, 0x0d, 0xf9, 0x65,
-	0x6c, 0xf8, 0x41, 0x1b, 0x1b, 0x41, 0xc8, 0xb1, 0xe8, 0x24, 0x78, 0x29, 0x99, 0xe6, 0xfd, 0xe3,
-	0x7e, 0x55, 0x16, 0xbd, 0xa5, 0x93, 0x9e, 0x85, 0x2c, 0xce, 0x82, 0xa2, 0x2a, 0x14, 0xc2, 0x8b,
-	0x25, 0x20, 0x5e, 0xe8, 0x24, 0xee, 0x15, 0x70, 0xe8, 0x86, 0xb0, 0xa0, 0x8f, 0x61, 0x2a, 0x1c,
-	0xa9, 0x54, 0xc9, 0xb0, 0x0e, 0x4e, 0x3e, 0x91, 0xb9, 0x3b, 0x7a, 0x0d, 0x66, 0x4d, 0xd2, 0xed,
-	0x62, 0x33, 0x68, 0x85, 0x52, 0xa5, 0xec, 0x42, 0x94, 0xf5, 0x19, 0x61, 0x0c, 0x65, 0x4c, 0xd1,
-	0x27, 0x90, 0x13, 0x2d, 0x55, 0xa6, 0x2e, 0x96, 0xee, 0xd8, 0x03, 0x8b, 0xce, 0x2a, 0x02, 0x50,
-	0xbf, 0x97, 0xe0, 0x4a, 0xdc, 0xc1, 0x58, 0xad, 0x6b, 0x30, 0xcd, 0x72, 0x8c, 0x18, 0x31, 0xf9,
-	0xa8, 0x11, 0x65, 0x09, 0x18, 0xf4, 0x08, 0xe4, 0xae, 0xb3, 0x8b, 0x5d, 0x4c, 0x39, 0x07, 0xa6,
-	0x9a, 0xb7, 0x4e, 0xfb, 0xd5, 0x37, 0x93, 0x9c, 0xc6, 0x23, 0xe1, 0xa7, 0xc7, 0x08, 0xea, 0x4d,
-	0x98, 0x5d, 0xdb, 0x73, 0xb1, 0xaf, 0xe3, 0x5d, 0x87, 0x3a, 0xc4, 0x45, 0xe5, 0x50, 0xa0, 0xfc,
-	0x99, 0x6b, 0x50, 0x8f, 0xd7, 0xea, 0xeb, 0x50, 0x5c, 0x8f, 0x32, 0x7d, 0xe8, 0x11, 0xb3, 0x83,
-	0xe6, 0x61, 0x0a, 0x87, 0x0f, 0xec, 0xd5, 0xbc, 0xce, 0x17, 0xea, 0x75, 0x98, 0x5b, 0xec, 0x18,
-	0xae, 0x8d, 0xb7, 0x31, 0xb6, 0xc6, 0xbc, 0x98, 0x8d, 0x5e, 0x7c, 0x22, 0x43, 0x6e, 0x15, 0x53,
-	0x6a, 0xd8, 0xac, 0x51, 0x1d, 0x6c, 0x58, 0xd8, 0x17, 0x33, 0xed, 0xbd, 0xc4, 0x27, 0x21, 0x10,
-	0xb4, 0x65, 0xe6, 0xae, 0x0b, 0x18, 0xb4, 0x06, 0x72, 0x8f, 0xda, 0xad, 0x60, 0xdf, 0xe3, 0x93,
-	0xac, 0xd8, 0x78, 0x67, 0x52, 0xc8, 0xcd, 0x7d, 0x0f, 0xeb, 0xb9, 0x1e, 0xb5, 0xc3, 0x07, 0xf4,
-	0x10, 0xb2, 0xdb, 0x3e, 0xe9, 0xb1, 0x4f, 0x9d, 0x7c, 0xf3, 0xf6, 0x69, 0xbf, 0xfa, 0x56, 0x92,
-	0xae, 0x2f, 0x1a, 0x5e, 0xb0, 0xe3, 0x87, 0x2a, 0x60, 0xee, 0xe8, 0x01, 0xa4, 0x03, 0xc2, 0xd8,
-	0x78, 0x29, 0x90, 0x74, 0x40, 0x10, 0x85, 0x17, 0x2d, 0x71, 0x37, 0xf0, 0x51, 0xdd, 0x12, 0x37,
-	0xb5, 0x60, 0xf1, 0x87, 0x89, 0x0b, 0x1d, 0xf7, 0xd1, 0xa2, 0xcf, 0x5b, 0xe3, 0x3e, 0x65, 0x76,
-	0xe1, 0xda, 0xb9, 0xa0, 0x9c, 0xe4, 0xca, 0x34, 0x8b, 0xfa, 0xd1, 0x65, 0xa3, 0x72, 0x14, 0xfd,
-	0xaa, 0x35, 0xf6, 0xbe, 0x5b, 0x87, 0x7c, 0x27, 0x92, 0x95, 0x92, 0x63, 0x91, 0x1a, 0x89, 0x23,
-	0x0d, 0x04, 0x39, 0x00, 0x41, 0x0e, 0xa0, 0x78, 0x31, 0x28, 0x42, 0x66, 0xd0, 0xf7, 0x2e, 0x01,
-	0x1d, 0x15, 0x70, 0xa5, 0x73, 0xd6, 0x54, 0xfe, 0x35, 0x0d, 0xd3, 0x9c, 0x97, 0x48, 0x81, 0xdc,
-	0x2e, 0xf6, 0x63, 0x61, 0xe5, 0xf5, 0x68, 0x89, 0x4c, 0x28, 0x92, 0x50, 0x84, 0xad, 0x58, 0x79,
-	0xfc, 0xe6, 0xbd, 0x93, 0x38, 0x97, 0x11, 0x0d, 0x8b, 0x81, 0x31, 0x4b, 0x46, 0x84, 0xbd, 0x0d,
-	0x73, 0xf1, 0x98, 0x69, 0x71, 0x2d, 0x66, 0x26, 0x14, 0xda, 0xa8, 0xf8, 0x45, 0x98, 0xa2, 0x37,
-	0x3a, 0x12, 0x1c, 0x28, 0x99, 0xb1, 0xf8, 0x45, 0xa0, 0xec, 0x84, 0x1f, 0xbe, 0x67, 0xa6, 0x87,
-	0x88, 0x34, 0x67, 0x8e, 0x9a, 0x6f, 0xfc, 0x21, 0x41, 0x61, 0x48, 0xa9, 0xa8, 0x02, 0xb0, 0x4a,
-	0xed, 0x2d, 0xf7, 0xb1, 0x4b, 0xf6, 0xdc, 0x52, 0xaa, 0x5c, 0x3c, 0x38, 0xac, 0x0d, 0x59, 0xd0,
-	0x5d, 0xb8, 0xb6, 0x4a, 0xed, 0x71, 0x94, 0x2f, 0x49, 0xe5, 0x97, 0x0e, 0x0e, 0x6b, 0x17, 0x6d,
-	0xa3, 0x7b, 0xa0, 0x9c, 0xdf, 0xe2, 0x47, 0x5c, 0x4a, 0x97, 0x5f, 0x3e, 0x38, 0xac, 0x5d, 0xb8,
-	0x8f, 0x54, 0x98, 0x59, 0xa5, 0x76, 0xcc, 0x96, 0x52, 0xa6, 0x5c, 0x3a, 0x38, 0xac, 0x8d, 0xd8,
-	0x50, 0x03, 0xe6, 0x87, 0xd7, 0x31, 0x76, 0xb6, 0xac, 0x1c, 0x1c, 0xd6, 0xc6, 0xee, 0x35, 0xd7,
-	0x8f, 0x7e, 0xaf, 0xa4, 0x9e, 0x1e, 0x57, 0xa4, 0xa3, 0xe3, 0x8a, 0xf4, 0xec, 0xb8, 0x22, 0x7d,
-	0x73, 0x52, 0x49, 0x1d, 0x9d, 0x54, 0x52, 0xbf, 0x9c, 0x54, 0x52, 0x5f, 0xfc, 0xcd, 0x47, 0xc1,
-	0xb8, 0x1f, 0xe3, 0xf6, 0x34, 0xfb, 0x59, 0x7d, 0xfb, 0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xba,
-	0x96, 0xe2, 0x0e, 0x37, 0x0f, 0x00, 0x00,
+	// 1317 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xf7, 0xda, 0x4e, 0x6c, 0x3f, 0xe7, 0x8f, 0x33, 0xa4, 0xd4, 0x32, 0x60, 0x1b, 0x23, 0xd1,
+	0xb4, 0x85, 0x75, 0x6b, 0x4a, 0x29, 0x2d, 0x20, 0xd5, 0x49, 0x51, 0x82, 0x1a, 0x25, 0x9a, 0x24,
+	0x80, 0x10, 0xd2, 0xb2, 0xde, 0x9d, 0xd8, 0xab, 0xd8, 0x3b, 0xdb, 0x99, 0x75, 0xa2, 0x7c, 0x85,
+	0x9c, 0xf8, 0x02, 0xf9, 0x00, 0x1c, 0x39, 0x20, 0x71, 0x40, 0xe2, 0x5a, 0x89, 0x4b, 0x8f, 0x1c,
+	0x90, 0x55, 0xd2, 0x6f, 0x11, 0x10, 0x42, 0x3b, 0x33, 0xbb, 0xb1, 0x13, 0x17, 0x36, 0xa1, 0x20,
+	0x71, 0x9b, 0x7d, 0x6f, 0xde, 0xef, 0xfd, 0x99, 0xdf, 0x7b, 0x33, 0x36, 0x5c, 0xe5, 0x56, 0x87,
+	0xd8, 0xfd, 0x2e, 0x61, 0xf5, 0x70, 0xe5, 0xb5, 0xea, 0xbe, 0xd9, 0xea, 0x12, 0x23, 0x14, 0xe8,
+	0x1e, 0xa3, 0x3e, 0x45, 0x57, 0x3c, 0xc7, 0x6d, 0x5b, 0xa6, 0xa7, 0xfb, 0xce, 0x76, 0x97, 0xee,
+	0xe9, 0x96, 0x6d, 0xe9, 0x91, 0xb5, 0x7e, 0x62, 0x5d, 0x9a, 0x6f, 0xd3, 0x36, 0x15, 0x36, 0xf5,
+	0x60, 0x25, 0xcd, 0x4b, 0xaf, 0x79, 0x8c, 0x5a, 0x84, 0x73, 0xca, 0x24, 0x7c, 0xe8, 0x46, 0xaa,
+	0x6b, 0xdf, 0x24, 0x61, 0xf6, 0xbe, 0x6d, 0x6f, 0x06, 0x22, 0x4c, 0x1e, 0xf5, 0x09, 0xf7, 0xd1,
+	0x16, 0x64, 0x65, 0x24, 0x8e, 0x5d, 0xd4, 0xaa, 0xda, 0x42, 0xaa, 0x79, 0xf7, 0x68, 0x50, 0xc9,
+	0x88, 0x3d, 0x2b, 0x4b, 0xc7, 0x83, 0xca, 0xf5, 0xb6, 0xe3, 0x77, 0xfa, 0x2d, 0xdd, 0xa2, 0xbd,
+	0xba, 0x8a, 0xae, 0x2e, 0xa3, 0xab, 0x5b, 0xb6, 0x55, 0xef, 0x51, 0x9b, 0x74, 0x75, 0xb5, 0x1d,
+	0x67, 0x04, 0xd6, 0x8a, 0x8d, 0x96, 0x20, 0xcd, 0x3d, 0xd3, 0x2d, 0xa6, 0xab, 0xda, 0x42, 0xbe,
+	0x71, 0x4d, 0x1f, 0x93, 0x57, 0x14, 0xab, 0xae, 0x62, 0xd5, 0x37, 0x3c, 0xd3, 0x6d, 0xa6, 0x1f,
+	0x0f, 0x2a, 0x09, 0x2c, 0xac, 0xd1, 0xeb, 0x30, 0xe5, 0x70, 0x83, 0x13, 0x8b, 0xba, 0xb6, 0xc9,
+	0xf6, 0x8b, 0xc9, 0xaa, 0xb6, 0x90, 0xc5, 0x79, 0x87, 0x6f, 0x84, 0x22, 0xf4, 0x29, 0x80, 0xd5,
+	0x21, 0xd6, 0x8e, 0x47, 0x1d, 0xd7, 0x2f, 0xa6, 0x84, 0xbb, 0x1b, 0xf1, 0xdc, 0x2d, 0x46, 0x76,
+	0xca, 0xe9, 0x10, 0x52, 0xed, 0x5b, 0x0d, 0x10, 0x26, 0x3d, 0xba, 0x4b, 0xfe, 0xcb, 0x72, 0x25,
+	0xff, 0x49, 0xb9, 0x6a, 0xbf, 0x68, 0x30, 0xbf, 0xe4, 0x70, 0xcf, 0xf4, 0xad, 0xce, 0x48, 0xd4,
+	0x9f, 0x41, 0xce, 0xb4, 0x6d, 0x43, 0x18, 0x8a, 0xb0, 0xf3, 0x8d, 0x3b, 0x7a, 0x4c, 0xaa, 0xe9,
+	0xa7, 0x18, 0xb3, 0x9c, 0xc0, 0x59, 0x53, 0x89, 0xd0, 0x57, 0x30, 0xc5, 0x44, 0x91, 0x14, 0xb6,
+	0x8c, 0xff, 0x5e, 0x6c, 0xec, 0xb3, 0x15, 0x5e, 0x4e, 0xe0, 0x3c, 0x3b, 0x91, 0x36, 0x73, 0x90,
+	0x61, 0x52, 0x53, 0xfb, 0x4e, 0x83, 0xc2, 0x49, 0x30, 0xdc, 0xa3, 0x2e, 0x27, 0x68, 0x05, 0x26,
+	0xb9, 0x6f, 0xfa, 0x7d, 0xae, 0xf2, 0xba, 0x19, 0xaf, 0x76, 0x02, 0x64, 0x43, 0x18, 0x62, 0x05,
+	0x70, 0x8a, 0x4a, 0xc9, 0x17, 0x46, 0xa5, 0xef, 0x35, 0x78, 0x69, 0x24, 0xd1, 0xff, 0x4f, 0xe8,
+	0x4f, 0x35, 0xb8, 0x74, 0x8a, 0x51, 0x2a, 0xf8, 0xcf, 0xcf, 0x52, 0xea, 0xfd, 0x0b, 0x50, 0x4a,
+	0xa2, 0x8d, 0x70, 0xca, 0x1c, 0xcb, 0xa9, 0x0f, 0x2e, 0xc6, 0xa9, 0x08, 0x7f, 0x84, 0x54, 0x00,
+	0x59, 0xa6, 0x54, 0xb5, 0x1f, 0x34, 0x98, 0x92, 0x52, 0x93, 0x31, 0x87, 0xb0, 0x7f, 0xab, 0xc5,
+	0xb7, 0x00, 0x5a, 0xd2, 0x83, 0xe1, 0x73, 0x91, 0x54, 0xba, 0x79, 0xfb, 0x78, 0x50, 0x69, 0xfc,
+	0x35, 0xda, 0x99, 0x89, 0xae, 0x6f, 0x72, 0x9c, 0x53, 0x48, 0x9b, 0xbc, 0xf6, 0x93, 0x06, 0x99,
+	0x30, 0xf2, 0x2f, 0x61, 0x46, 0x46, 0xae, 0xd4, 0x01, 0xb1, 0x52, 0x0b, 0xf9, 0xc6, 0xbb, 0xb1,
+	0x6b, 0x37, 0x5c, 0x08, 0x3c, 0xed, 0x0f, 0x7d, 0x71, 0xd4, 0x82, 0xb9, 0x76, 0x97, 0xb6, 0xcc,
+	0xae, 0xf1, 0xc2, 0xf2, 0x98, 0x95, 0x80, 0xcd, 0x28, 0x9b, 0x1f, 0x93, 0x90, 0x5b, 0x26, 0x26,
+	0xf3, 0x5b, 0xc4, 0xf4, 0x03, 0x8e, 0x85, 0x27, 0x21, 0x53, 0x49, 0x35, 0xef, 0x1d, 0x0d, 0x2a,
+	0x59, 0x55, 0x5b, 0x7e, 0xde, 0xb3, 0xc8, 0xaa, 0xb3, 0xe0, 0xa8, 0x02, 0xf9, 0xe0, 0x62, 0xf1,
+	0xa9, 0x17, 0x18, 0xa9, 0x7b, 0x05, 0x1c, 0xbe, 0xa1, 0x24, 0xe8, 0x63, 0x98, 0x08, 0x46, 0x2a,
+	0x2f, 0xa6, 0xaa, 0xa9, 0x0b, 0x4d, 0x64, 0x69, 0x8e, 0xde, 0x80, 0x69, 0x8b, 0x76, 0xbb, 0xc4,
+	0xf2, 0x8d, 0xa0, 0x55, 0xb9, 0xb8, 0x10, 0xb3, 0x78, 0x4a, 0x09, 0x83, 0x36, 0xe6, 0xe8, 0x13,
+	0xc8, 0xa8, 0x92, 0x16, 0x27, 0x9e, 0xdf, 0xba, 0x63, 0x0f, 0x2c, 0x3c, 0xab, 0x10, 0xa0, 0xf6,
+	0xbb, 0x06, 0x73, 0x51, 0x05, 0xa3, 0x6e, 0x5d, 0x83, 0x49, 0x11, 0x63, 0xc8, 0x88, 0xf3, 0x8f,
+	0x1a, 0x95, 0x96, 0x82, 0x41, 0x0f, 0x21, 0xdb, 0x75, 0x76, 0x89, 0x4b, 0xb8, 0xe4, 0xc0, 0x44,
+	0xf3, 0xc6, 0xf1, 0xa0, 0xf2, 0x56, 0x9c, 0xd3, 0x78, 0xa8, 0xec, 0x70, 0x84, 0x80, 0x96, 0x21,
+	0xdd, 0xa5, 0xa6, 0xad, 0xae, 0xef, 0x5b, 0xb1, 0xb3, 0x5f, 0x34, 0x3d, 0xbf, 0xcf, 0xc8, 0x43,
+	0x6a, 0xda, 0x58, 0x20, 0x04, 0xb3, 0x36, 0x3f, 0x24, 0x45, 0x57, 0x21, 0x67, 0x79, 0x7d, 0xa3,
+	0xcf, 0xcd, 0xb6, 0x1c, 0x53, 0x5a, 0x73, 0x2a, 0xa0, 0xd0, 0xe2, 0xfa, 0xd6, 0x56, 0x20, 0xc3,
+	0x59, 0xcb, 0xeb, 0x8b, 0x55, 0xf0, 0xd8, 0xe8, 0x91, 0x1e, 0x65, 0xfb, 0x46, 0x6b, 0xdf, 0x27,
+	0x8a, 0xda, 0x38, 0x2f, 0x65, 0xcd, 0x40, 0x84, 0xae, 0xc1, 0x1c, 0xa7, 0xcc, 0x27, 0xcc, 0xb0,
+	0x1d, 0xbe, 0xa3, 0xf6, 0xa5, 0xc4, 0xbe, 0x59, 0xa9, 0x58, 0x72, 0xf8, 0x8e, 0xdc, 0x5b, 0x87,
+	0x79, 0xee, 0xb8, 0x3b, 0x06, 0xa3, 0x7b, 0xdc, 0xf0, 0x08, 0x53, 0xcf, 0x18, 0x41, 0x00, 0x0d,
+	0xcf, 0x05, 0x3a, 0x4c, 0xf7, 0xf8, 0x3a, 0x61, 0xf2, 0x31, 0x53, 0xbb, 0x0e, 0xd3, 0x6b, 0x7b,
+	0x2e, 0x61, 0x98, 0xec, 0x3a, 0xdc, 0xa1, 0x2e, 0x2a, 0x05, 0x53, 0x4a, 0xae, 0xe5, 0x20, 0xc2,
+	0xd1, 0x77, 0xed, 0x4d, 0x98, 0x59, 0x0f, 0x8f, 0xeb, 0x81, 0x47, 0xad, 0x0e, 0x9a, 0x87, 0x09,
+	0x12, 0x2c, 0xc4, 0xd6, 0x1c, 0x96, 0x1f, 0xb5, 0x2b, 0x30, 0xbb, 0xd8, 0x31, 0xdd, 0x36, 0xd9,
+	0x26, 0xc4, 0x1e, 0xb3, 0x31, 0x1d, 0x6e, 0x7c, 0x9a, 0x85, 0xcc, 0x2a, 0xe1, 0xa2, 0x12, 0x6b,
+	0x30, 0xd9, 0x21, 0xa6, 0x4d, 0x98, 0x1a, 0xec, 0xef, 0xc5, 0x3e, 0x10, 0x85, 0xa0, 0x2f, 0x0b,
+	0x73, 0xac, 0x60, 0xd0, 0x1a, 0x64, 0x7b, 0xbc, 0x6d, 0xf8, 0xfb, 0x9e, 0x1c, 0xe7, 0x33, 0x8d,
+	0x5b, 0xe7, 0x85, 0xdc, 0xdc, 0xf7, 0x08, 0xce, 0xf4, 0x78, 0x3b, 0x58, 0xa0, 0x07, 0x90, 0xde,
+	0x66, 0xb4, 0x27, 0x6a, 0x9f, 0x6b, 0xde, 0x3c, 0x1e, 0x54, 0xde, 0x8e, 0x43, 0x3d, 0xc5, 0x8c,
+	0x95, 0x25, 0x2c, 0xcc, 0xd1, 0x7d, 0x48, 0xfa, 0xb4, 0x98, 0xbe, 0x28, 0x48, 0xd2, 0xa7, 0x88,
+	0xc3, 0xcb, 0xb6, 0xba, 0x20, 0xe5, 0x7d, 0x65, 0xa8, 0xe7, 0x8a, 0x6a, 0xe5, 0x0f, 0x63, 0x27,
+	0x3a, 0xee, 0xe5, 0x86, 0xe7, 0xed, 0x31, 0x52, 0xb4, 0x0b, 0x97, 0xcf, 0x38, 0x95, 0x9d, 0x5e,
+	0x9c, 0x14, 0x5e, 0x3f, 0xba, 0xa8, 0x57, 0x89, 0x82, 0x2f, 0xd9, 0xe3, 0xc4, 0x68, 0x1d, 0x72,
+	0x9d, 0x70, 0xb6, 0x14, 0x33, 0xc2, 0x53, 0x23, 0xb6, 0xa7, 0x93, 0xa9, 0x74, 0x02, 0x82, 0x1c,
+	0x40, 0xd1, 0xc7, 0x49, 0x12, 0x59, 0x01, 0x7d, 0xf7, 0x02, 0xd0, 0x61, 0x02, 0x73, 0x9d, 0xd3,
+	0xa2, 0xd2, 0x1f, 0x49, 0x98, 0x94, 0xbc, 0x44, 0x45, 0xc8, 0xec, 0x12, 0x16, 0x35, 0x56, 0x0e,
+	0x87, 0x9f, 0xc8, 0x82, 0x19, 0x1a, 0x34, 0xa1, 0x11, 0x75, 0x9e, 0x7c, 0x7e, 0xdc, 0x8e, 0x1d,
+	0xcb, 0x48, 0x0f, 0xab, 0xa9, 0x39, 0x4d, 0x47, 0x1a, 0x7b, 0x1b, 0x66, 0xa3, 0x59, 0x6b, 0xc8,
+	0x5e, 0x4c, 0x9d, 0xb3, 0xd1, 0x46, 0x9b, 0x5f, 0xb9, 0x99, 0xf1, 0x46, 0xa4, 0xc8, 0x81, 0x82,
+	0x15, 0x35, 0xbf, 0x72, 0x94, 0x3e, 0xe7, 0xeb, 0xff, 0xd4, 0xf4, 0x50, 0x9e, 0x66, 0xad, 0x51,
+	0x31, 0x2a, 0x40, 0x8a, 0x93, 0x47, 0x82, 0xf3, 0x69, 0x1c, 0x2c, 0x03, 0x89, 0x69, 0xed, 0x08,
+	0x3e, 0xa6, 0x71, 0xb0, 0xbc, 0xf6, 0x9b, 0x06, 0xf9, 0xa1, 0x6e, 0x46, 0x65, 0x80, 0x55, 0xde,
+	0xde, 0x72, 0x77, 0x5c, 0xba, 0xe7, 0x16, 0x12, 0xa5, 0x99, 0x83, 0xc3, 0xea, 0x90, 0x04, 0xdd,
+	0x81, 0xcb, 0xab, 0xbc, 0x3d, 0xae, 0x2d, 0x0a, 0x5a, 0xe9, 0x95, 0x83, 0xc3, 0xea, 0xf3, 0xd4,
+	0xe8, 0x2e, 0x14, 0xcf, 0xaa, 0x24, 0x0d, 0x0a, 0xc9, 0xd2, 0xab, 0x07, 0x87, 0xd5, 0xe7, 0xea,
+	0x51, 0x0d, 0xa6, 0x56, 0x79, 0x3b, 0x62, 0x54, 0x21, 0x55, 0x2a, 0x1c, 0x1c, 0x56, 0x47, 0x64,
+	0xa8, 0x01, 0xf3, 0xc3, 0xdf, 0x11, 0x76, 0xba, 0x54, 0x3c, 0x38, 0xac, 0x8e, 0xd5, 0x35, 0xd7,
+	0x9f, 0xfc, 0x5a, 0x4e, 0x3c, 0x3e, 0x2a, 0x6b, 0x4f, 0x8e, 0xca, 0xda, 0xd3, 0xa3, 0xb2, 0xf6,
+	0xf5, 0xb3, 0x72, 0xe2, 0xc9, 0xb3, 0x72, 0xe2, 0xe7, 0x67, 0xe5, 0xc4, 0x17, 0x7f, 0xf3, 0x7a,
+	0x1a, 0xf7, 0x0f, 0x42, 0x6b, 0x52, 0xfc, 0xaa, 0x7f, 0xe7, 0xcf, 0x01, 0x00, 0xbb, 0x35, 0x11,
+	0xa7, 0x60, 0x10, 0x00, 0x00,
 }
 
 func (m *AddTableRequest) Marshal() (dAtA []byte, err error) {
@@ -1610,6 +1727,18 @@ func (m *HeartbeatResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Load != nil {
+		{
+			size, err := m.Load.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTableSchedule(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Liveness != 0 {
 		i = encodeVarintTableSchedule(dAtA, i, uint64(m.Liveness))
 		i--
@@ -1632,6 +1761,51 @@ func (m *HeartbeatResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CaptureLoad) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CaptureLoad) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CaptureLoad) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SinkRowsPerSecond != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.SinkRowsPerSecond))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.SorterDiskBytes != 0 {
+		i = encodeVarintTableSchedule(dAtA, i, uint64(m.SorterDiskBytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MemoryBytes != 0 {
+		i = encodeVarintTableSchedule(dAtA, i, uint64(m.MemoryBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.CPUUsage != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CPUUsage))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *OwnerRevision) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1840,6 +2014,16 @@ func (m *Message_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Ack != 0 {
+		i = encodeVarintTableSchedule(dAtA, i, uint64(m.Ack))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Seq != 0 {
+		i = encodeVarintTableSchedule(dAtA, i, uint64(m.Seq))
+		i--
+		dAtA[i] = 0x28
+	}
 	{
 		size, err := m.ChangefeedEpoch.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -2106,6 +2290,31 @@ func (m *HeartbeatResponse) Size() (n int) {
 	if m.Liveness != 0 {
 		n += 1 + sovTableSchedule(uint64(m.Liveness))
 	}
+	if m.Load != nil {
+		l = m.Load.Size()
+		n += 1 + l + sovTableSchedule(uint64(l))
+	}
+	return n
+}
+
+func (m *CaptureLoad) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CPUUsage != 0 {
+		n += 9
+	}
+	if m.MemoryBytes != 0 {
+		n += 1 + sovTableSchedule(uint64(m.MemoryBytes))
+	}
+	if m.SorterDiskBytes != 0 {
+		n += 1 + sovTableSchedule(uint64(m.SorterDiskBytes))
+	}
+	if m.SinkRowsPerSecond != 0 {
+		n += 9
+	}
 	return n
 }
 
@@ -2202,6 +2411,12 @@ func (m *Message_Header) Size() (n int) {
 	n += 1 + l + sovTableSchedule(uint64(l))
 	l = m.ChangefeedEpoch.Size()
 	n += 1 + l + sovTableSchedule(uint64(l))
+	if m.Seq != 0 {
+		n += 1 + sovTableSchedule(uint64(m.Seq))
+	}
+	if m.Ack != 0 {
+		n += 1 + sovTableSchedule(uint64(m.Ack))
+	}
 	return n
 }
 
@@ -3455,6 +3670,152 @@ func (m *HeartbeatResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Load", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTableSchedule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTableSchedule
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTableSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Load == nil {
+				m.Load = &CaptureLoad{}
+			}
+			if err := m.Load.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTableSchedule(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTableSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CaptureLoad) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTableSchedule
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CaptureLoad: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CaptureLoad: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CPUUsage", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.CPUUsage = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryBytes", wireType)
+			}
+			m.MemoryBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTableSchedule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SorterDiskBytes", wireType)
+			}
+			m.SorterDiskBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTableSchedule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SorterDiskBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SinkRowsPerSecond", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.SinkRowsPerSecond = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTableSchedule(dAtA[iNdEx:])
@@ -4169,6 +4530,44 @@ func (m *Message_Header) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTableSchedule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ack", wireType)
+			}
+			m.Ack = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTableSchedule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ack |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTableSchedule(dAtA[iNdEx:])
@@ -14,6 +14,7 @@
 package server
 
 import (
+	"github.com/pingcap/tiflow/cdc/capture"
 	"github.com/pingcap/tiflow/cdc/entry"
 	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/owner"
@@ -25,8 +26,11 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler"
 	"github.com/pingcap/tiflow/cdc/sink/metrics"
 	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/pingcap/tiflow/pkg/keyspace"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/p2p"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/pingcap/tiflow/pkg/sink/observer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -35,12 +39,16 @@ import (
 
 var registry = prometheus.NewRegistry()
 
+// gatherer labels metrics of changefeeds scoped to keyspaces with the keyspaces.
+var gatherer = keyspace.NewGatherer(registry)
+
 func init() {
 	registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 	registry.MustRegister(prometheus.NewGoCollector(
 		collectors.WithGoCollections(collectors.GoRuntimeMemStatsCollection | collectors.GoRuntimeMetricsCollection)))
 
 	initServerMetrics(registry)
+	capture.InitMetrics(registry)
 	kv.InitMetrics(registry)
 	puller.InitMetrics(registry)
 	metrics.InitMetrics(registry)
@@ -50,6 +58,8 @@ func init() {
 	etcd.InitMetrics(registry)
 	orchestrator.InitMetrics(registry)
 	p2p.InitMetrics(registry)
+	pdutil.InitMetrics(registry)
+	retry.InitMetrics(registry)
 	engine.InitMetrics(registry)
 	memorysorter.InitMetrics(registry)
 	redo.InitMetrics(registry)
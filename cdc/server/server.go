@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -31,16 +32,21 @@ import (
 	"github.com/pingcap/tiflow/cdc/capture"
 	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine/factory"
+	"github.com/pingcap/tiflow/cdc/subscription"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/pingcap/tiflow/pkg/eventsampling"
 	"github.com/pingcap/tiflow/pkg/fsutil"
 	clogutil "github.com/pingcap/tiflow/pkg/logutil"
 	"github.com/pingcap/tiflow/pkg/p2p"
 	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/preflight"
+	"github.com/pingcap/tiflow/pkg/remotewrite"
 	"github.com/pingcap/tiflow/pkg/tcpserver"
 	"github.com/pingcap/tiflow/pkg/util"
 	p2pProto "github.com/pingcap/tiflow/proto/p2p"
+	subscriptionProto "github.com/pingcap/tiflow/proto/subscription"
 	pd "github.com/tikv/pd/client"
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -186,6 +192,10 @@ func (s *server) prepare(ctx context.Context) error {
 		return errors.Trace(err)
 	}
 
+	if err := s.runPreflightChecks(ctx); err != nil {
+		return errors.Trace(err)
+	}
+
 	s.createSortEngineFactory()
 
 	if err := s.setMemoryLimit(); err != nil {
@@ -269,7 +279,7 @@ func (s *server) startStatusHTTP(lis net.Listener) error {
 	router.Use(gin.RecoveryWithWriter(logWritter))
 	// router.
 	// Register APIs.
-	cdc.RegisterRoutes(router, s.capture, registry)
+	cdc.RegisterRoutes(router, s.capture, gatherer)
 
 	// No need to configure TLS because it is already handled by `s.tcpServer`.
 	// Add ReadTimeout and WriteTimeout to avoid some abnormal connections never close.
@@ -289,6 +299,56 @@ func (s *server) startStatusHTTP(lis net.Listener) error {
 	return nil
 }
 
+// runPreflightChecks checks the environment of the capture before it starts.
+// Failures are only logged unless preflight.refuse-on-failure is set.
+func (s *server) runPreflightChecks(ctx context.Context) error {
+	conf := config.GetGlobalServerConfig()
+	if !conf.Preflight.Enable {
+		return nil
+	}
+
+	checks := []preflight.Check{
+		preflight.SortDirCheck(conf.Sorter.SortDir,
+			conf.Preflight.MinSortDirWriteThroughput,
+			time.Duration(conf.Preflight.MaxSortDirSyncLatency)),
+		preflight.OpenFilesLimitCheck(conf.Preflight.MinOpenFilesLimit),
+	}
+
+	// Upstreams are not initialized yet, so the clock skew is checked with a
+	// PD client of its own.
+	pdClient, err := pd.NewClientWithContext(ctx, s.pdEndpoints, conf.Security.PDSecurityOption())
+	if err != nil {
+		log.Warn("preflight: failed to create pd client, skip checking clock skew",
+			zap.Error(err))
+	} else {
+		defer pdClient.Close()
+		checks = append(checks, preflight.ClockSkewCheck(pdClient.GetTS,
+			time.Duration(conf.Preflight.MaxClockSkew)))
+	}
+
+	_, captures, err := s.etcdClient.GetCaptures(ctx)
+	if err != nil {
+		log.Warn("preflight: failed to get captures, skip checking peers",
+			zap.Error(err))
+	} else {
+		var addrs []string
+		for _, c := range captures {
+			if c.AdvertiseAddr != conf.AdvertiseAddr {
+				addrs = append(addrs, c.AdvertiseAddr)
+			}
+		}
+		checks = append(checks, preflight.PeersCheck(addrs,
+			time.Duration(conf.Preflight.PeerDialTimeout)))
+	}
+
+	report := preflight.Run(ctx, checks)
+	report.Log()
+	if failures := report.Failures(); len(failures) != 0 && conf.Preflight.RefuseOnFailure {
+		return cerror.ErrPreflightCheckFailed.GenWithStackByArgs(strings.Join(failures, ","))
+	}
+	return nil
+}
+
 func (s *server) etcdHealthChecker(ctx context.Context) error {
 	conf := config.GetGlobalServerConfig()
 	grpcClient, err := pd.NewClientWithContext(ctx, s.pdEndpoints, conf.Security.PDSecurityOption())
@@ -341,6 +401,16 @@ func (s *server) run(ctx context.Context) (err error) {
 
 	eg, egCtx := errgroup.WithContext(ctx)
 
+	// The exporter must be set before the capture creates any table sink.
+	if conf := config.GetGlobalServerConfig(); conf.EventSampling.Enabled() {
+		exporter := eventsampling.NewExporter(conf.EventSampling, conf.AdvertiseAddr)
+		eventsampling.SetGlobalExporter(exporter)
+		defer eventsampling.SetGlobalExporter(nil)
+		eg.Go(func() error {
+			return exporter.Run(egCtx)
+		})
+	}
+
 	eg.Go(func() error {
 		return s.capture.Run(egCtx)
 	})
@@ -357,8 +427,17 @@ func (s *server) run(ctx context.Context) (err error) {
 		return s.tcpServer.Run(egCtx)
 	})
 
+	if conf := config.GetGlobalServerConfig(); conf.MetricsRemoteWrite.Enabled() {
+		exporter := remotewrite.NewExporter(conf.MetricsRemoteWrite, gatherer, conf.AdvertiseAddr)
+		eg.Go(func() error {
+			return exporter.Run(egCtx)
+		})
+	}
+
 	grpcServer := grpc.NewServer(s.grpcService.ServerOptions()...)
 	p2pProto.RegisterCDCPeerToPeerServer(grpcServer, s.grpcService)
+	subscriptionProto.RegisterCDCSubscriptionServer(grpcServer,
+		subscription.NewService(subscription.GetGlobalRegistry()))
 
 	eg.Go(func() error {
 		return grpcServer.Serve(s.tcpServer.GrpcListener())
@@ -0,0 +1,98 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddlsink
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/meta/autoid"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/pingcap/tiflow/pkg/quotes"
+)
+
+// BootstrapDDLEvents returns the CREATE DATABASE and CREATE TABLE events of
+// tables at ts, which initialize the schemas of the downstream. Events are
+// ordered by schema and table names, and a CREATE DATABASE event precedes
+// the CREATE TABLE events of its tables.
+func BootstrapDDLEvents(tables []*model.TableInfo, ts model.Ts) ([]*model.DDLEvent, error) {
+	sorted := make([]*model.TableInfo, 0, len(tables))
+	for _, table := range tables {
+		if table.IsView() || table.IsSequence() {
+			continue
+		}
+		sorted = append(sorted, table)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].TableName.Schema != sorted[j].TableName.Schema {
+			return sorted[i].TableName.Schema < sorted[j].TableName.Schema
+		}
+		return sorted[i].TableName.Table < sorted[j].TableName.Table
+	})
+
+	events := make([]*model.DDLEvent, 0, len(sorted))
+	lastSchema := ""
+	for _, table := range sorted {
+		schema := table.TableName.Schema
+		if schema != lastSchema {
+			events = append(events, &model.DDLEvent{
+				StartTs:  ts,
+				CommitTs: ts,
+				Type:     timodel.ActionCreateSchema,
+				Query:    "CREATE DATABASE IF NOT EXISTS " + quotes.QuoteName(schema),
+				TableInfo: &model.TableInfo{
+					TableName: model.TableName{Schema: schema},
+					Version:   ts,
+				},
+			})
+			lastSchema = schema
+		}
+		query, err := CreateTableQuery(schema, table.TableInfo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		events = append(events, &model.DDLEvent{
+			StartTs:   ts,
+			CommitTs:  ts,
+			Type:      timodel.ActionCreateTable,
+			Query:     query,
+			TableInfo: table,
+		})
+	}
+	return events, nil
+}
+
+// CreateTableQuery returns the CREATE TABLE IF NOT EXISTS statement of a
+// table in the schema, which is built from the table definition in the same
+// way as SHOW CREATE TABLE.
+func CreateTableQuery(schema string, ti *timodel.TableInfo) (string, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 512))
+	err := executor.ConstructResultOfShowCreateTable(
+		utils.NewSessionCtx(nil), ti, autoid.Allocators{}, buf)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	query := buf.String()
+	prefix := "CREATE TABLE " + quotes.QuoteName(ti.Name.O) + " "
+	if !strings.HasPrefix(query, prefix) {
+		return "", errors.Errorf("unexpected create table statement %s", query)
+	}
+	return "CREATE TABLE IF NOT EXISTS " + quotes.QuoteSchema(schema, ti.Name.O) +
+		" " + query[len(prefix):], nil
+}
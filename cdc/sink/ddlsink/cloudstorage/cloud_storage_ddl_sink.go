@@ -86,21 +86,92 @@ func (d *DDLSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 		})
 	}
 
-	var def cloudstorage.TableDefinition
-	def.FromDDLEvent(ddl)
+	var ddlFile cloudstorage.DDLEventFile
+	ddlFile.FromDDLEvent(ddl)
+	ddlFilePath, err := d.writeDDLEventFile(ctx, &ddlFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	def := ddlFile.Table
 	if err := writeFile(def); err != nil {
 		return errors.Trace(err)
 	}
+	if err := d.updateSchemaManifest(ctx, def, ddlFilePath); err != nil {
+		return errors.Trace(err)
+	}
 
 	if ddl.Type == timodel.ActionExchangeTablePartition {
 		// For exchange partition, we need to write the schema of the source table.
 		var sourceTableDef cloudstorage.TableDefinition
 		sourceTableDef.FromTableInfo(ddl.PreTableInfo, ddl.TableInfo.Version)
-		return writeFile(sourceTableDef)
+		if err := writeFile(sourceTableDef); err != nil {
+			return errors.Trace(err)
+		}
+		return d.updateSchemaManifest(ctx, sourceTableDef, ddlFilePath)
 	}
 	return nil
 }
 
+// writeDDLEventFile writes the DDL event file, which contains the schema
+// before and after the DDL, and returns its path.
+func (d *DDLSink) writeDDLEventFile(
+	ctx context.Context, ddlFile *cloudstorage.DDLEventFile,
+) (string, error) {
+	data, err := ddlFile.Marshal()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	path := ddlFile.GenerateFilePath()
+	if err := d.storage.WriteFile(ctx, path, data); err != nil {
+		return "", errors.Trace(err)
+	}
+	return path, nil
+}
+
+// updateSchemaManifest adds the schema version of def to the schema manifest
+// of the table. Schemas of databases are not recorded in manifests.
+func (d *DDLSink) updateSchemaManifest(
+	ctx context.Context, def cloudstorage.TableDefinition, ddlFilePath string,
+) error {
+	if !def.IsTableSchema() {
+		return nil
+	}
+	schemaFilePath, err := def.GenerateSchemaFilePath()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	manifestPath := cloudstorage.GenerateSchemaManifestPath(def.Schema, def.Table)
+	manifest := &cloudstorage.TableSchemaManifest{Schema: def.Schema, Table: def.Table}
+	exists, err := d.storage.FileExists(ctx, manifestPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		data, err := d.storage.ReadFile(ctx, manifestPath)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if manifest, err = cloudstorage.UnmarshalTableSchemaManifest(data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	manifest.AddVersion(cloudstorage.SchemaVersion{
+		TableVersion: def.TableVersion,
+		SchemaFile:   schemaFilePath,
+		DDLFile:      ddlFilePath,
+		Query:        def.Query,
+		Type:         def.Type,
+	})
+
+	data, err := manifest.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(d.storage.WriteFile(ctx, manifestPath, data))
+}
+
 // WriteCheckpointTs writes the checkpoint ts to the cloud storage.
 func (d *DDLSink) WriteCheckpointTs(ctx context.Context,
 	ts uint64, tables []*model.TableInfo,
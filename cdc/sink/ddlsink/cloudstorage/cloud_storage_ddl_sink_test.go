@@ -15,6 +15,7 @@ package cloudstorage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
@@ -25,6 +26,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/types"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
 	"github.com/stretchr/testify/require"
 )
 
@@ -92,6 +94,76 @@ func TestWriteDDLEvent(t *testing.T) {
 	}`, string(tableSchema))
 }
 
+func TestWriteDDLEventFileAndSchemaManifest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	parentDir := t.TempDir()
+	uri := fmt.Sprintf("file:///%s", parentDir)
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	sink, err := NewDDLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI)
+	require.Nil(t, err)
+
+	col1 := &timodel.ColumnInfo{
+		Name:      timodel.NewCIStr("col1"),
+		FieldType: *types.NewFieldType(mysql.TypeLong),
+	}
+	col2 := &timodel.ColumnInfo{
+		Name:      timodel.NewCIStr("col2"),
+		FieldType: *types.NewFieldType(mysql.TypeVarchar),
+	}
+	tableName := model.TableName{Schema: "test", Table: "table1", TableID: 20}
+	createTable := &model.DDLEvent{
+		CommitTs: 90,
+		Type:     timodel.ActionCreateTable,
+		Query:    "create table test.table1 (col1 int)",
+		TableInfo: &model.TableInfo{
+			Version:   90,
+			TableName: tableName,
+			TableInfo: &timodel.TableInfo{Columns: []*timodel.ColumnInfo{col1}},
+		},
+	}
+	addColumn := &model.DDLEvent{
+		CommitTs: 100,
+		Type:     timodel.ActionAddColumn,
+		Query:    "alter table test.table1 add col2 varchar(64)",
+		TableInfo: &model.TableInfo{
+			Version:   100,
+			TableName: tableName,
+			TableInfo: &timodel.TableInfo{Columns: []*timodel.ColumnInfo{col1, col2}},
+		},
+		PreTableInfo: createTable.TableInfo,
+	}
+	require.Nil(t, sink.WriteDDLEvent(ctx, createTable))
+	require.Nil(t, sink.WriteDDLEvent(ctx, addColumn))
+	// Writing a DDL again doesn't add duplicated versions.
+	require.Nil(t, sink.WriteDDLEvent(ctx, addColumn))
+
+	tableDir := path.Join(parentDir, "test/table1/meta/")
+	data, err := os.ReadFile(path.Join(tableDir, "ddl_100.json"))
+	require.Nil(t, err)
+	var ddlFile cloudstorage.DDLEventFile
+	require.Nil(t, json.Unmarshal(data, &ddlFile))
+	require.Equal(t, addColumn.Query, ddlFile.Query)
+	require.Equal(t, 1, ddlFile.PreTable.TotalColumns)
+	require.Equal(t, 2, ddlFile.Table.TotalColumns)
+
+	data, err = os.ReadFile(path.Join(tableDir, "manifest.json"))
+	require.Nil(t, err)
+	manifest, err := cloudstorage.UnmarshalTableSchemaManifest(data)
+	require.Nil(t, err)
+	require.Len(t, manifest.Versions, 2)
+	require.Equal(t, uint64(90), manifest.Versions[0].TableVersion)
+	require.Equal(t, "test/table1/meta/ddl_90.json", manifest.Versions[0].DDLFile)
+	v, ok := manifest.SchemaAt(120)
+	require.True(t, ok)
+	require.Equal(t, uint64(100), v.TableVersion)
+	require.Equal(t, "test/table1/meta/schema_100_4192708364.json", v.SchemaFile)
+	require.Equal(t, addColumn.Query, v.Query)
+	_, err = os.Stat(path.Join(parentDir, v.SchemaFile))
+	require.Nil(t, err)
+}
+
 func TestWriteCheckpointTs(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
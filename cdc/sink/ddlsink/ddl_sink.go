@@ -31,3 +31,20 @@ type Sink interface {
 	// Close closes the sink.
 	Close()
 }
+
+// SchemaChecker is implemented by sinks that can check whether the schema of
+// downstream tables drifts from upstream.
+type SchemaChecker interface {
+	// CheckSchema compares tables, which are the upstream tables at the
+	// checkpoint ts, with downstream tables. It returns ErrDownstreamSchemaDrift
+	// if they drift.
+	CheckSchema(ctx context.Context, tables []*model.TableInfo) error
+}
+
+// TopicRemover is implemented by sinks that write to topics which can be
+// removed when the changefeed is done with them.
+type TopicRemover interface {
+	// RemoveTopics removes the topics written by the sink for tables, which
+	// are the upstream tables at the checkpoint ts.
+	RemoveTopics(ctx context.Context, tables []*model.TableInfo) error
+}
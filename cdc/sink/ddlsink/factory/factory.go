@@ -25,20 +25,71 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq"
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mysql"
+	"github.com/pingcap/tiflow/cdc/sink/routing"
+	"github.com/pingcap/tiflow/cdc/sink/verification"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	kafkav2 "github.com/pingcap/tiflow/pkg/sink/kafka/v2"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	"github.com/pingcap/tiflow/pkg/sink/nats"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
 	"github.com/pingcap/tiflow/pkg/util"
 )
 
-// New creates a new ddlsink.Sink by scheme.
+// New creates a new ddlsink.Sink by scheme. If the tee sink is configured,
+// the DDL events are written to both sinks.
 func New(
 	ctx context.Context,
 	changefeedID model.ChangeFeedID,
 	sinkURIStr string,
 	cfg *config.ReplicaConfig,
+) (ddlsink.Sink, error) {
+	s, err := newSink(ctx, changefeedID, sinkURIStr, cfg)
+	if err != nil {
+		return nil, err
+	}
+	teeCfg, err := cfg.TeeSinkReplicaConfig()
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	if teeCfg == nil {
+		return s, nil
+	}
+	teeSink, err := newSink(ctx, changefeedID, *cfg.Sink.TeeSinkURI, teeCfg)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	return ddlsink.NewTeeSink(s, teeSink), nil
+}
+
+// newSink creates a ddlsink.Sink by scheme, which routes the DDL events if
+// route rules are configured.
+func newSink(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURIStr string,
+	cfg *config.ReplicaConfig,
+) (ddlsink.Sink, error) {
+	router, err := routing.NewRouter(cfg.Sink.Routes)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSinkByScheme(ctx, changefeedID, sinkURIStr, cfg)
+	if err != nil || router == nil {
+		return s, err
+	}
+	return routing.NewDDLSink(s, router), nil
+}
+
+func newSinkByScheme(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURIStr string,
+	cfg *config.ReplicaConfig,
 ) (ddlsink.Sink, error) {
 	sinkURI, err := url.Parse(sinkURIStr)
 	if err != nil {
@@ -53,7 +104,24 @@ func New(
 		}
 		return mq.NewKafkaDDLSink(ctx, changefeedID, sinkURI, cfg,
 			factoryCreator, ddlproducer.NewKafkaDDLProducer)
+	case sink.NatsScheme:
+		return mq.NewNatsDDLSink(ctx, changefeedID, sinkURI, cfg, nats.NewJetStreamClient)
+	case sink.RabbitMQScheme, sink.RabbitMQSSLScheme:
+		return mq.NewRabbitMQDDLSink(ctx, changefeedID, sinkURI, cfg, rabbitmq.NewAMQPClient)
+	case sink.MQTTScheme, sink.MQTTSSLScheme:
+		return mq.NewMQTTDDLSink(ctx, changefeedID, sinkURI, cfg, mqtt.NewPahoClient)
 	case sink.BlackHoleScheme:
+		verify, err := verification.IsEnabled(sinkURI)
+		if err != nil {
+			return nil, err
+		}
+		if verify {
+			return verification.NewDDLSink(blackhole.NewDDLSink(),
+				verification.NewReporter(changefeedID)), nil
+		}
+		return blackhole.NewDDLSink(), nil
+	case sink.GRPCScheme:
+		// DDLs are not streamed to subscribers.
 		return blackhole.NewDDLSink(), nil
 	case sink.MySQLSSLScheme, sink.MySQLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
 		return mysql.NewDDLSink(ctx, changefeedID, sinkURI, cfg)
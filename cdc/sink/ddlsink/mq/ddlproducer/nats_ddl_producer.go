@@ -0,0 +1,94 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddlproducer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	pnats "github.com/pingcap/tiflow/pkg/sink/nats"
+	"go.uber.org/zap"
+)
+
+var _ DDLProducer = (*natsDDLProducer)(nil)
+
+// natsDDLProducer is used to send messages to NATS JetStream synchronously.
+type natsDDLProducer struct {
+	// id indicates this sink belongs to which processor(changefeed).
+	id model.ChangeFeedID
+	// client is used to publish messages to NATS JetStream.
+	client pnats.Client
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+}
+
+// NewNatsDDLProducer creates a new NATS producer for replicating DDL.
+func NewNatsDDLProducer(changefeedID model.ChangeFeedID, client pnats.Client) DDLProducer {
+	return &natsDDLProducer{
+		id:     changefeedID,
+		client: client,
+	}
+}
+
+// SyncBroadcastMessage publishes the message once, because NATS subjects
+// have no partitions.
+func (p *natsDDLProducer) SyncBroadcastMessage(ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	return p.SyncSendMessage(ctx, topic, 0, message)
+}
+
+func (p *natsDDLProducer) SyncSendMessage(ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	if p.closed {
+		return cerror.ErrNatsProducerClosed.GenWithStackByArgs()
+	}
+
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	default:
+		err := p.client.Publish(ctx, topic, message.Key, message.Value)
+		return cerror.WrapError(cerror.ErrNatsSendMessage, err)
+	}
+}
+
+func (p *natsDDLProducer) Close() {
+	// We have to hold the lock to prevent write to closed producer.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer was already closed, we should skip the close operation.
+	if p.closed {
+		log.Warn("Nats DDL producer already closed",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+		return
+	}
+	p.closed = true
+
+	p.client.Close()
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddlproducer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
+	"go.uber.org/zap"
+)
+
+var _ DDLProducer = (*rabbitmqDDLProducer)(nil)
+
+// rabbitmqDDLProducer is used to send messages to RabbitMQ synchronously.
+type rabbitmqDDLProducer struct {
+	// id indicates this sink belongs to which processor(changefeed).
+	id model.ChangeFeedID
+	// client is used to publish messages to RabbitMQ.
+	client rabbitmq.Client
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+}
+
+// NewRabbitMQDDLProducer creates a new RabbitMQ producer for replicating DDL.
+func NewRabbitMQDDLProducer(changefeedID model.ChangeFeedID, client rabbitmq.Client) DDLProducer {
+	return &rabbitmqDDLProducer{
+		id:     changefeedID,
+		client: client,
+	}
+}
+
+// SyncBroadcastMessage publishes the message once, because exchanges
+// have no partitions.
+func (p *rabbitmqDDLProducer) SyncBroadcastMessage(ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	return p.SyncSendMessage(ctx, topic, 0, message)
+}
+
+func (p *rabbitmqDDLProducer) SyncSendMessage(ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	if p.closed {
+		return cerror.ErrRabbitMQProducerClosed.GenWithStackByArgs()
+	}
+
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	default:
+		err := p.client.Publish(ctx, topic, message)
+		return cerror.WrapError(cerror.ErrRabbitMQSendMessage, err)
+	}
+}
+
+func (p *rabbitmqDDLProducer) Close() {
+	// We have to hold the lock to prevent write to closed producer.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer was already closed, we should skip the close operation.
+	if p.closed {
+		log.Warn("RabbitMQ DDL producer already closed",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+		return
+	}
+	p.closed = true
+
+	p.client.Close()
+}
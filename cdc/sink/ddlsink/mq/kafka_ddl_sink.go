@@ -116,7 +116,8 @@ func NewKafkaDDLSink(
 		return nil, errors.Trace(err)
 	}
 
-	s, err := newDDLSink(ctx, changefeedID, p, adminClient, topicManager, eventRouter, encoderConfig)
+	s, err := newDDLSink(ctx, changefeedID, p, adminClient, topicManager,
+		eventRouter, encoderConfig, replicaConfig.Sink.ResolvedTsSuppression)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -35,7 +35,10 @@ import (
 )
 
 // Assert Sink implementation
-var _ ddlsink.Sink = (*DDLSink)(nil)
+var (
+	_ ddlsink.Sink         = (*DDLSink)(nil)
+	_ ddlsink.TopicRemover = (*DDLSink)(nil)
+)
 
 // DDLSink is a sink that sends DDL events to the MQ system.
 type DDLSink struct {
@@ -57,6 +60,11 @@ type DDLSink struct {
 	statistics *metrics.Statistics
 	// admin is used to query kafka cluster information.
 	admin kafka.ClusterAdminClient
+	// resolvedTsSuppressor decides which topics the checkpoint ts is sent to.
+	resolvedTsSuppressor *resolvedTsSuppressor
+	// checkpointTopic is the dedicated topic the checkpoint ts is sent to.
+	// If it's empty, the checkpoint ts is sent to the topics of tables.
+	checkpointTopic string
 }
 
 func newDDLSink(ctx context.Context,
@@ -66,6 +74,7 @@ func newDDLSink(ctx context.Context,
 	topicManager manager.TopicManager,
 	eventRouter *dispatcher.EventRouter,
 	encoderConfig *common.Config,
+	suppressionConfig *config.ResolvedTsSuppressionConfig,
 ) (*DDLSink, error) {
 	encoderBuilder, err := builder.NewRowEventEncoderBuilder(ctx, changefeedID, encoderConfig)
 	if err != nil {
@@ -81,6 +90,8 @@ func newDDLSink(ctx context.Context,
 		producer:       producer,
 		statistics:     metrics.NewStatistics(ctx, changefeedID, sink.RowSink),
 		admin:          adminClient,
+
+		resolvedTsSuppressor: newResolvedTsSuppressor(suppressionConfig),
 	}
 
 	return s, nil
@@ -148,8 +159,15 @@ func (k *DDLSink) WriteCheckpointTs(ctx context.Context,
 	// NOTICE: When there are no tables to replicate,
 	// we need to send checkpoint ts to the default topic.
 	// This will be compatible with the old behavior.
-	if len(tables) == 0 {
+	// If there is a dedicated checkpoint topic, we only send it there.
+	if len(tables) == 0 || k.checkpointTopic != "" {
 		topic := k.eventRouter.GetDefaultTopic()
+		if k.checkpointTopic != "" {
+			topic = k.checkpointTopic
+		}
+		if len(k.resolvedTsSuppressor.filter([]string{topic}, ts)) == 0 {
+			return nil
+		}
 		partitionNum, err := k.topicManager.GetPartitionNum(ctx, topic)
 		if err != nil {
 			return errors.Trace(err)
@@ -157,13 +175,17 @@ func (k *DDLSink) WriteCheckpointTs(ctx context.Context,
 		log.Debug("Emit checkpointTs to default topic",
 			zap.String("topic", topic), zap.Uint64("checkpointTs", ts))
 		err = k.producer.SyncBroadcastMessage(ctx, topic, partitionNum, msg)
-		return errors.Trace(err)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		k.resolvedTsSuppressor.emitted(topic, ts)
+		return nil
 	}
 	var tableNames []model.TableName
 	for _, table := range tables {
 		tableNames = append(tableNames, table.TableName)
 	}
-	topics := k.eventRouter.GetActiveTopics(tableNames)
+	topics := k.resolvedTsSuppressor.filter(k.eventRouter.GetActiveTopics(tableNames), ts)
 	for _, topic := range topics {
 		partitionNum, err := k.topicManager.GetPartitionNum(ctx, topic)
 		if err != nil {
@@ -173,7 +195,35 @@ func (k *DDLSink) WriteCheckpointTs(ctx context.Context,
 		if err != nil {
 			return errors.Trace(err)
 		}
+		k.resolvedTsSuppressor.emitted(topic, ts)
+	}
+	return nil
+}
+
+// RemoveTopics removes all topics the sink writes to, including the topics of
+// tables, the default topic and the checkpoint topic.
+func (k *DDLSink) RemoveTopics(ctx context.Context, tables []*model.TableInfo) error {
+	if k.admin == nil {
+		log.Warn("Skip removing topics since the sink doesn't support it",
+			zap.String("namespace", k.id.Namespace),
+			zap.String("changefeed", k.id.ID))
+		return nil
+	}
+	tableNames := make([]model.TableName, 0, len(tables))
+	for _, table := range tables {
+		tableNames = append(tableNames, table.TableName)
 	}
+	topics := k.eventRouter.GetActiveTopics(tableNames)
+	if k.checkpointTopic != "" && k.checkpointTopic != k.eventRouter.GetDefaultTopic() {
+		topics = append(topics, k.checkpointTopic)
+	}
+	if err := k.admin.DeleteTopics(ctx, topics); err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("Topics removed",
+		zap.Strings("topics", topics),
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID))
 	return nil
 }
 
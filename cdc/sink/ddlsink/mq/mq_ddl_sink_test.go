@@ -268,6 +268,68 @@ func TestWriteCheckpointTsToTableTopics(t *testing.T) {
 	require.Len(t, s.producer.(*ddlproducer.MockDDLProducer).GetEvents("cdc_person2", 0), 1)
 }
 
+func TestRemoveTopics(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=true&compression=gzip" +
+		"&protocol=canal-json&enable-tidb-extension=true"
+	uri := fmt.Sprintf(uriTemplate, leader.Addr(), topic)
+
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:   []string{"*.*"},
+			TopicRule: "{schema}_{table}",
+		},
+	}
+
+	s, err := NewKafkaDDLSink(ctx, model.DefaultChangeFeedID("test"),
+		sinkURI, replicaConfig,
+		kafka.NewMockFactory,
+		ddlproducer.NewMockDDLProducer)
+	require.Nil(t, err)
+	require.NotNil(t, s)
+
+	tables := []*model.TableInfo{
+		{
+			TableName: model.TableName{
+				Schema: "cdc",
+				Table:  "person",
+			},
+		},
+		{
+			TableName: model.TableName{
+				Schema: "cdc",
+				Table:  "person1",
+			},
+		},
+	}
+	require.Nil(t, s.WriteCheckpointTs(ctx, 417318403368288260, tables))
+
+	topics := []string{"mock_topic", "cdc_person", "cdc_person1"}
+	meta, err := s.admin.GetTopicsMeta(ctx, topics, false)
+	require.Nil(t, err)
+	require.Len(t, meta, 3)
+
+	require.Nil(t, s.RemoveTopics(ctx, tables))
+	meta, err = s.admin.GetTopicsMeta(ctx, topics, false)
+	require.Nil(t, err)
+	require.Empty(t, meta)
+
+	// Removing topics again is harmless.
+	require.Nil(t, s.RemoveTopics(ctx, tables))
+}
+
 func TestWriteCheckpointTsWhenCanalJsonTiDBExtensionIsDisable(t *testing.T) {
 	t.Parallel()
 
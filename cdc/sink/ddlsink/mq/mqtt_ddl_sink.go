@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/manager"
+	"github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	cdcutil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// NewMQTTDDLSink will verify the config and create an MQTT DDL sink.
+// The checkpoint ts is only sent to the resolved topic, and it's retained
+// by the broker if retain-resolved is enabled.
+func NewMQTTDDLSink(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	clientCreator mqtt.Factory,
+) (_ *DDLSink, err error) {
+	options := mqtt.NewOptions()
+	useTLS := strings.ToLower(sinkURI.Scheme) == sink.MQTTSSLScheme
+	if err := options.Apply(sinkURI, useTLS); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMQTTInvalidConfig, err)
+	}
+
+	protocol, err := util.GetProtocol(
+		cdcutil.GetOrZero(replicaConfig.Sink.Protocol),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	log.Info("Try to create a DDL sink producer",
+		zap.String("broker", options.Broker),
+		zap.String("topic", options.Topic),
+		zap.String("resolvedTopic", options.ResolvedTopic),
+		zap.Bool("retainResolved", options.RetainResolved))
+	client, err := clientCreator(options, changefeedID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMQTTNewClient, err)
+	}
+	// The client is closed by the producer.
+	p := ddlproducer.NewMQTTDDLProducer(changefeedID, client)
+	// Preventing leaks when error occurs.
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+
+	topicManager := manager.NewMQTTTopicManager(changefeedID)
+	if _, err := topicManager.CreateTopicAndWaitUntilVisible(ctx, options.Topic); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, options.Topic)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		options.MaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s, err := newDDLSink(ctx, changefeedID, p, nil, topicManager,
+		eventRouter, encoderConfig, replicaConfig.Sink.ResolvedTsSuppression)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.checkpointTopic = options.ResolvedTopic
+
+	return s, nil
+}
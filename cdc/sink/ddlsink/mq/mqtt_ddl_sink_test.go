@@ -0,0 +1,86 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	mm "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMQTTWriteDDLEventAndCheckpointTs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("mqtt://127.0.0.1:1883/tidb/{schema}/{table}?protocol=open-protocol")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	client := mqtt.NewMockClient()
+	s, err := NewMQTTDDLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI,
+		replicaConfig, mqtt.NewMockFactory(client))
+	require.NoError(t, err)
+	defer s.Close()
+
+	ddl := &model.DDLEvent{
+		CommitTs: 417318403368288260,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema: "cdc", Table: "person",
+			},
+		},
+		Query: "create table person(id int, name varchar(32), primary key(id))",
+		Type:  mm.ActionCreateTable,
+	}
+	require.NoError(t, s.WriteDDLEvent(ctx, ddl))
+	messages := client.Messages("tidb/cdc/person")
+	require.Len(t, messages, 1)
+	require.False(t, messages[0].Retain)
+
+	// the checkpoint ts is only sent to the resolved topic.
+	tables := []*model.TableInfo{
+		{TableName: model.TableName{Schema: "cdc", Table: "person"}},
+		{TableName: model.TableName{Schema: "test", Table: "person"}},
+	}
+	require.NoError(t, s.WriteCheckpointTs(ctx, 417318403368288261, tables))
+	require.Len(t, client.Messages("tidb/cdc/person"), 1)
+	require.Len(t, client.Messages("tidb/resolved"), 1)
+	retained, ok := client.Retained("tidb/resolved")
+	require.True(t, ok)
+	require.Equal(t, model.MessageTypeResolved, retained.Message.Type)
+}
+
+func TestNewMQTTDDLSinkFailed(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("mqtt://127.0.0.1:1883/tidb/+?protocol=open-protocol")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	client := mqtt.NewMockClient()
+	s, err := NewMQTTDDLSink(context.Background(), model.DefaultChangeFeedID("test"),
+		sinkURI, replicaConfig, mqtt.NewMockFactory(client))
+	require.ErrorContains(t, err, "ErrMQTTInvalidConfig")
+	require.Nil(t, s)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/manager"
+	"github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	pnats "github.com/pingcap/tiflow/pkg/sink/nats"
+	cdcutil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// NewNatsDDLSink will verify the config and create a NATS JetStream DDL sink.
+// The checkpoint ts is sent to the dedicated resolved subject only.
+func NewNatsDDLSink(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	clientCreator pnats.Factory,
+) (_ *DDLSink, err error) {
+	subject, err := util.GetTopic(sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	options := pnats.NewOptions()
+	if err := options.Apply(sinkURI, subject); err != nil {
+		return nil, cerror.WrapError(cerror.ErrNatsInvalidConfig, err)
+	}
+
+	protocol, err := util.GetProtocol(
+		cdcutil.GetOrZero(replicaConfig.Sink.Protocol),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	log.Info("Try to create a DDL sink producer",
+		zap.Strings("servers", options.Servers),
+		zap.String("resolvedSubject", options.ResolvedSubject))
+	client, err := clientCreator(options, changefeedID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrNatsNewClient, err)
+	}
+	// The client is closed by the producer.
+	p := ddlproducer.NewNatsDDLProducer(changefeedID, client)
+	// Preventing leaks when error occurs.
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+
+	subjectManager := manager.NewNatsSubjectManager(changefeedID, client)
+	for _, s := range []string{subject, options.ResolvedSubject} {
+		if _, err := subjectManager.CreateTopicAndWaitUntilVisible(ctx, s); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, subject)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		options.MaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s, err := newDDLSink(ctx, changefeedID, p, nil, subjectManager,
+		eventRouter, encoderConfig, replicaConfig.Sink.ResolvedTsSuppression)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.checkpointTopic = options.ResolvedSubject
+
+	return s, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	mm "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	pnats "github.com/pingcap/tiflow/pkg/sink/nats"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNatsWriteDDLEventAndCheckpointTs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("nats://127.0.0.1:4222/tidb.default?protocol=open-protocol")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:   []string{"*.*"},
+			TopicRule: "tidb.{schema}.{table}",
+		},
+	}
+
+	client := pnats.NewMockClient()
+	client.AddStream("tidb", "tidb.>")
+	s, err := NewNatsDDLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI,
+		replicaConfig, pnats.NewMockFactory(client))
+	require.NoError(t, err)
+	defer s.Close()
+
+	ddl := &model.DDLEvent{
+		CommitTs: 417318403368288260,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema: "cdc", Table: "person",
+			},
+		},
+		Query: "create table person(id int, name varchar(32), primary key(id))",
+		Type:  mm.ActionCreateTable,
+	}
+	require.NoError(t, s.WriteDDLEvent(ctx, ddl))
+	require.Len(t, client.Messages("tidb.cdc.person"), 1)
+
+	tables := []*model.TableInfo{
+		{TableName: model.TableName{Schema: "cdc", Table: "person"}},
+		{TableName: model.TableName{Schema: "cdc", Table: "person1"}},
+	}
+	// the checkpoint ts is only sent to the resolved subject.
+	require.NoError(t, s.WriteCheckpointTs(ctx, 417318403368288261, tables))
+	require.NoError(t, s.WriteCheckpointTs(ctx, 417318403368288262, nil))
+	require.Len(t, client.Messages("tidb.default.resolved"), 2)
+	require.Len(t, client.Messages("tidb.cdc.person"), 1)
+	require.Len(t, client.Messages("tidb.cdc.person1"), 0)
+	require.Len(t, client.Messages("tidb.default"), 0)
+}
+
+func TestNewNatsDDLSinkResolvedSubjectNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("nats://127.0.0.1:4222/tidb.default?" +
+		"protocol=open-protocol&resolved-subject=resolved")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	client := pnats.NewMockClient()
+	client.AddStream("tidb", "tidb.>")
+	s, err := NewNatsDDLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI,
+		replicaConfig, pnats.NewMockFactory(client))
+	require.ErrorContains(t, err, "no jetstream stream captures subject resolved")
+	require.Nil(t, s)
+	require.True(t, client.Closed())
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/manager"
+	"github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
+	cdcutil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// NewRabbitMQDDLSink will verify the config and create a RabbitMQ DDL sink.
+// The checkpoint ts is sent to the exchanges of tables with the resolved
+// routing key.
+func NewRabbitMQDDLSink(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	clientCreator rabbitmq.Factory,
+) (_ *DDLSink, err error) {
+	exchange, err := util.GetTopic(sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	options := rabbitmq.NewOptions()
+	useTLS := strings.ToLower(sinkURI.Scheme) == sink.RabbitMQSSLScheme
+	if err := options.Apply(sinkURI, useTLS); err != nil {
+		return nil, cerror.WrapError(cerror.ErrRabbitMQInvalidConfig, err)
+	}
+
+	protocol, err := util.GetProtocol(
+		cdcutil.GetOrZero(replicaConfig.Sink.Protocol),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	log.Info("Try to create a DDL sink producer",
+		zap.String("routingKey", options.RoutingKey),
+		zap.String("resolvedRoutingKey", options.ResolvedRoutingKey))
+	client, err := clientCreator(options, changefeedID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRabbitMQNewClient, err)
+	}
+	// The client is closed by the producer.
+	p := ddlproducer.NewRabbitMQDDLProducer(changefeedID, client)
+	// Preventing leaks when error occurs.
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+
+	exchangeManager := manager.NewRabbitMQExchangeManager(changefeedID, client)
+	if _, err := exchangeManager.CreateTopicAndWaitUntilVisible(ctx, exchange); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, exchange)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		options.MaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s, err := newDDLSink(ctx, changefeedID, p, nil, exchangeManager,
+		eventRouter, encoderConfig, replicaConfig.Sink.ResolvedTsSuppression)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s, nil
+}
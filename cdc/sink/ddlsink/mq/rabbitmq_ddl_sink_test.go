@@ -0,0 +1,79 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	mm "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRabbitMQWriteDDLEventAndCheckpointTs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("rabbitmq://127.0.0.1:5672/tidb?" +
+		"protocol=open-protocol&resolved-routing-key=cdc.resolved")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:   []string{"cdc.*"},
+			TopicRule: "tidb_{schema}",
+		},
+	}
+
+	client := rabbitmq.NewMockClient()
+	client.AddExchange("tidb", "tidb_cdc")
+	s, err := NewRabbitMQDDLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI,
+		replicaConfig, rabbitmq.NewMockFactory(client))
+	require.NoError(t, err)
+	defer s.Close()
+
+	ddl := &model.DDLEvent{
+		CommitTs: 417318403368288260,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema: "cdc", Table: "person",
+			},
+		},
+		Query: "create table person(id int, name varchar(32), primary key(id))",
+		Type:  mm.ActionCreateTable,
+	}
+	require.NoError(t, s.WriteDDLEvent(ctx, ddl))
+	messages := client.Messages("tidb_cdc")
+	require.Len(t, messages, 1)
+	require.Equal(t, "cdc.person", messages[0].RoutingKey)
+
+	tables := []*model.TableInfo{
+		{TableName: model.TableName{Schema: "cdc", Table: "person"}},
+		{TableName: model.TableName{Schema: "test", Table: "person"}},
+	}
+	require.NoError(t, s.WriteCheckpointTs(ctx, 417318403368288261, tables))
+	messages = client.Messages("tidb_cdc")
+	require.Len(t, messages, 2)
+	require.Equal(t, "cdc.resolved", messages[1].RoutingKey)
+	messages = client.Messages("tidb")
+	require.Len(t, messages, 1)
+	require.Equal(t, "cdc.resolved", messages[0].RoutingKey)
+}
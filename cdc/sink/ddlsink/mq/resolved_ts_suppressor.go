@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// resolvedTsSuppressor decides whether a resolved ts event should be sent to
+// a topic. It reduces the resolved ts events of the changefeeds with lots of
+// quiet topics, for which the resolved ts events are the majority of the
+// messages.
+//
+// Since a resolved ts event is broadcast to all the partitions of a topic,
+// the states are tracked per topic, which is the same as per partition.
+type resolvedTsSuppressor struct {
+	minInterval time.Duration
+	minDelta    time.Duration
+	clock       clock.Clock
+
+	// lastEmitted is the last resolved ts event sent to each topic.
+	lastEmitted map[string]emittedResolvedTs
+}
+
+type emittedResolvedTs struct {
+	ts   uint64
+	time time.Time
+}
+
+func newResolvedTsSuppressor(cfg *config.ResolvedTsSuppressionConfig) *resolvedTsSuppressor {
+	return &resolvedTsSuppressor{
+		minInterval: cfg.GetMinInterval(),
+		minDelta:    cfg.GetMinDelta(),
+		clock:       clock.New(),
+		lastEmitted: make(map[string]emittedResolvedTs),
+	}
+}
+
+// filter returns the topics to which the resolved ts should be sent, and
+// forgets the topics which are no longer active.
+func (s *resolvedTsSuppressor) filter(topics []string, ts uint64) []string {
+	if s.minInterval == 0 && s.minDelta == 0 {
+		return topics
+	}
+	active := make(map[string]struct{}, len(topics))
+	res := make([]string, 0, len(topics))
+	now := s.clock.Now()
+	for _, topic := range topics {
+		active[topic] = struct{}{}
+		last, ok := s.lastEmitted[topic]
+		if ok {
+			if now.Sub(last.time) < s.minInterval {
+				continue
+			}
+			delta := oracle.GetTimeFromTS(ts).Sub(oracle.GetTimeFromTS(last.ts))
+			if delta < s.minDelta {
+				continue
+			}
+		}
+		res = append(res, topic)
+	}
+	for topic := range s.lastEmitted {
+		if _, ok := active[topic]; !ok {
+			delete(s.lastEmitted, topic)
+		}
+	}
+	return res
+}
+
+// emitted records that the resolved ts has been sent to the topic.
+func (s *resolvedTsSuppressor) emitted(topic string, ts uint64) {
+	if s.minInterval == 0 && s.minDelta == 0 {
+		return
+	}
+	s.lastEmitted[topic] = emittedResolvedTs{ts: ts, time: s.clock.Now()}
+}
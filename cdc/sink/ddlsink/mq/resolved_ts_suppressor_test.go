@@ -0,0 +1,67 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestResolvedTsSuppressor(t *testing.T) {
+	t.Parallel()
+
+	ts := func(second int64) uint64 {
+		return oracle.ComposeTS(second*1000, 0)
+	}
+	topics := []string{"t1", "t2"}
+
+	// Nothing is suppressed by default.
+	s := newResolvedTsSuppressor(nil)
+	for i := int64(1); i <= 3; i++ {
+		require.Equal(t, topics, s.filter(topics, ts(i)))
+		s.emitted("t1", ts(i))
+	}
+	require.Empty(t, s.lastEmitted)
+
+	s = newResolvedTsSuppressor(&config.ResolvedTsSuppressionConfig{
+		MinInterval: util.AddressOf("10s"),
+		MinDelta:    util.AddressOf("5s"),
+	})
+	mockClock := clock.NewMock()
+	s.clock = mockClock
+	require.Equal(t, topics, s.filter(topics, ts(1)))
+	s.emitted("t1", ts(1))
+	s.emitted("t2", ts(1))
+
+	// The min interval has not elapsed.
+	mockClock.Add(5 * time.Second)
+	require.Empty(t, s.filter(topics, ts(10)))
+	// The resolved ts has not advanced enough.
+	mockClock.Add(5 * time.Second)
+	require.Empty(t, s.filter(topics, ts(3)))
+	// A new topic is not suppressed.
+	require.Equal(t, []string{"t3"}, s.filter([]string{"t1", "t3"}, ts(3)))
+	s.emitted("t3", ts(3))
+	require.Equal(t, []string{"t1"}, s.filter([]string{"t1", "t3"}, ts(6)))
+
+	// The inactive topics are forgotten.
+	require.NotContains(t, s.lastEmitted, "t2")
+	require.Equal(t, []string{"t2"}, s.filter([]string{"t2"}, ts(6)))
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/parser/charset"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"go.uber.org/zap"
+)
+
+// createMissingTables creates the schemas and tables that exist in upstream
+// but not in downstream.
+func (m *DDLSink) createMissingTables(ctx context.Context, tables []*model.TableInfo) error {
+	bySchema := make(map[string][]*model.TableInfo)
+	for _, table := range tables {
+		if table.IsView() || table.IsSequence() {
+			continue
+		}
+		schema := table.TableName.Schema
+		bySchema[schema] = append(bySchema[schema], table)
+	}
+
+	for schema, tables := range bySchema {
+		existing, err := m.queryDownstreamTables(ctx, schema)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var missing []*model.TableInfo
+		for _, table := range tables {
+			if _, ok := existing[strings.ToLower(table.TableName.Table)]; !ok {
+				missing = append(missing, table)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		if err := m.createSchemaIfNotExists(ctx, schema); err != nil {
+			return errors.Trace(err)
+		}
+		for _, table := range missing {
+			query, err := createTableStmt(table, m.autoCreateTableConfig)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if _, err := m.db.ExecContext(ctx, query); err != nil {
+				return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+			}
+			log.Info("missing downstream table is created",
+				zap.String("namespace", m.id.Namespace),
+				zap.String("changefeed", m.id.ID),
+				zap.String("sql", query))
+		}
+	}
+	return nil
+}
+
+// queryDownstreamTables returns lower case names of downstream tables in a
+// schema.
+func (m *DDLSink) queryDownstreamTables(
+	ctx context.Context, schema string,
+) (map[string]struct{}, error) {
+	rows, err := m.db.QueryContext(ctx,
+		"SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = ?", schema)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	tables := make(map[string]struct{})
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		tables[strings.ToLower(table)] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return tables, nil
+}
+
+func (m *DDLSink) createSchemaIfNotExists(ctx context.Context, schema string) error {
+	_, err := m.db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS "+quotes.QuoteName(schema))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	return nil
+}
+
+// createTableStmt returns the CREATE TABLE IF NOT EXISTS statement of an
+// upstream table, with the engine and charsets mapped by cfg.
+func createTableStmt(table *model.TableInfo, cfg *config.AutoCreateTableConfig) (string, error) {
+	ti := table.TableInfo.Clone()
+	if cfg != nil {
+		mapTableCharsets(ti, cfg.CharsetMapping)
+	}
+
+	query, err := ddlsink.CreateTableQuery(table.TableName.Schema, ti)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if cfg != nil && cfg.Engine != nil {
+		query = strings.Replace(query, ") ENGINE=InnoDB", ") ENGINE="+*cfg.Engine, 1)
+	}
+	return query, nil
+}
+
+// mapTableCharsets maps the charsets and collations of a table and its
+// columns by mapping.
+func mapTableCharsets(ti *timodel.TableInfo, mapping map[string]string) {
+	if len(mapping) == 0 {
+		return
+	}
+	ti.Charset, ti.Collate = mapCharset(ti.Charset, ti.Collate, mapping)
+	for _, col := range ti.Columns {
+		cs, co := mapCharset(col.GetCharset(), col.GetCollate(), mapping)
+		col.SetCharset(cs)
+		col.SetCollate(co)
+	}
+}
+
+// mapCharset maps a charset by mapping. The collation is mapped to the one of
+// the new charset with the same suffix if it exists, or the default one.
+func mapCharset(cs, co string, mapping map[string]string) (string, string) {
+	to, ok := mapping[cs]
+	if !ok || to == cs {
+		return cs, co
+	}
+	if strings.HasPrefix(co, cs+"_") {
+		mapped := to + strings.TrimPrefix(co, cs)
+		if _, err := charset.GetCollationByName(mapped); err == nil {
+			return to, mapped
+		}
+	}
+	info, err := charset.GetCharsetInfo(to)
+	if err != nil {
+		// It should never happen since the mapping is validated.
+		return cs, co
+	}
+	return to, info.DefaultCollation
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTableStmt(t *testing.T) {
+	t.Parallel()
+
+	table := newTableInfo4Test(t, "test",
+		"CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR(20) COLLATE utf8mb4_general_ci, "+
+			"b BLOB) CHARSET=utf8mb4 COLLATE=utf8mb4_bin")
+
+	query, err := createTableStmt(table, nil)
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE IF NOT EXISTS `test`.`t` (\n"+
+		"  `id` int(11) NOT NULL,\n"+
+		"  `name` varchar(20) COLLATE utf8mb4_general_ci DEFAULT NULL,\n"+
+		"  `b` blob DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin", query)
+
+	query, err = createTableStmt(table, &config.AutoCreateTableConfig{
+		Engine:         util.AddressOf("RocksDB"),
+		CharsetMapping: map[string]string{"utf8mb4": "utf8"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE IF NOT EXISTS `test`.`t` (\n"+
+		"  `id` int(11) NOT NULL,\n"+
+		"  `name` varchar(20) COLLATE utf8_general_ci DEFAULT NULL,\n"+
+		"  `b` blob DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n"+
+		") ENGINE=RocksDB DEFAULT CHARSET=utf8 COLLATE=utf8_bin", query)
+	// The upstream table info is not modified.
+	require.Equal(t, "utf8mb4", table.Charset)
+}
+
+func TestMapCharset(t *testing.T) {
+	t.Parallel()
+
+	mapping := map[string]string{"utf8mb4": "utf8", "gbk": "utf8mb4"}
+	for _, c := range []struct {
+		cs, co           string
+		expectCs, expect string
+	}{
+		{"utf8mb4", "utf8mb4_bin", "utf8", "utf8_bin"},
+		{"utf8mb4", "utf8mb4_unicode_ci", "utf8", "utf8_unicode_ci"},
+		{"gbk", "gbk_chinese_ci", "utf8mb4", "utf8mb4_bin"},
+		{"binary", "binary", "binary", "binary"},
+	} {
+		cs, co := mapCharset(c.cs, c.co, mapping)
+		require.Equal(t, c.expectCs, cs, c.co)
+		require.Equal(t, c.expect, co, c.co)
+	}
+}
+
+func TestAutoCreateTables(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	sink := &DDLSink{
+		id:              model.DefaultChangeFeedID("test"),
+		db:              db,
+		cfg:             pmysql.NewConfig(),
+		autoCreateTable: true,
+	}
+	tables := []*model.TableInfo{
+		newTableInfo4Test(t, "test", "CREATE TABLE t1 (id INT PRIMARY KEY)"),
+		newTableInfo4Test(t, "test", "CREATE TABLE t2 (id INT PRIMARY KEY)"),
+	}
+	createT2 := "CREATE TABLE IF NOT EXISTS `test`.`t2` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"
+
+	// Missing tables are created only once.
+	mock.ExpectQuery("SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = ?").
+		WithArgs("test").WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME"}).AddRow("T1"))
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS `test`").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(createT2).WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, sink.WriteCheckpointTs(context.Background(), 1, tables))
+	require.NoError(t, sink.WriteCheckpointTs(context.Background(), 2, tables))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Tables are created with the upstream definition on CREATE TABLE DDLs.
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS `test`").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(createT2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	err = sink.execDDL(context.Background(), &model.DDLEvent{
+		CommitTs:  3,
+		Type:      timodel.ActionCreateTable,
+		Query:     "CREATE TABLE t2 (id INT PRIMARY KEY)",
+		TableInfo: tables[1],
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
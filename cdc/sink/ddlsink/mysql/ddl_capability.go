@@ -0,0 +1,210 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	tmysql "github.com/pingcap/tidb/parser/mysql"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+const (
+	unsupportedExpressionIndex = "expression index"
+	unsupportedIndexVisibility = "index visibility"
+)
+
+var (
+	// MySQL supports functional key parts since 8.0.13.
+	minExpressionIndexVersion = semver.New("8.0.13")
+	// MySQL supports invisible indexes since 8.0.0.
+	minIndexVisibilityVersion = semver.New("8.0.0")
+)
+
+// downstreamCapability describes the DDL constructs the downstream supports.
+type downstreamCapability struct {
+	version string
+	// sqlMode is the sql mode of the downstream session, DDLs are parsed with
+	// it so that a translated DDL is interpreted the same as the original one.
+	sqlMode tmysql.SQLMode
+
+	expressionIndex bool
+	indexVisibility bool
+}
+
+// probeDownstreamCapability queries the version and sql mode of the
+// downstream to find out the DDL constructs it supports.
+func probeDownstreamCapability(ctx context.Context, db *sql.DB) (*downstreamCapability, error) {
+	var version, sqlMode sql.NullString
+	row := db.QueryRowContext(ctx, "SELECT VERSION(), @@SESSION.sql_mode;")
+	if err := row.Scan(&version, &sqlMode); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return newDownstreamCapability(version.String, sqlMode.String), nil
+}
+
+func newDownstreamCapability(version, sqlMode string) *downstreamCapability {
+	c := &downstreamCapability{
+		version:         version,
+		expressionIndex: true,
+		indexVisibility: true,
+	}
+	mode, err := tmysql.GetSQLMode(tmysql.FormatSQLModeStr(sqlMode))
+	if err != nil {
+		log.Warn("downstream sql mode is partially recognized",
+			zap.String("sqlMode", sqlMode), zap.Error(err))
+	}
+	c.sqlMode = mode
+
+	switch {
+	case strings.Contains(version, "TiDB"):
+		// TiDB supports all constructs checked here, and TiDB specific ones
+		// are already wrapped in special comments.
+	case strings.Contains(strings.ToLower(version), "mariadb"):
+		c.expressionIndex = false
+		c.indexVisibility = false
+	default:
+		v, err := semver.NewVersion(strings.SplitN(version, "-", 2)[0])
+		if err != nil {
+			log.Warn("unknown downstream version, assume all DDL constructs are supported",
+				zap.String("version", version), zap.Error(err))
+			return c
+		}
+		c.expressionIndex = !v.LessThan(*minExpressionIndexVersion)
+		c.indexVisibility = !v.LessThan(*minIndexVisibilityVersion)
+	}
+	return c
+}
+
+// adjustDDL finds the constructs of a DDL the downstream doesn't support and
+// handles them according to the policy. It returns the query to execute,
+// which is empty if the DDL should be skipped, and the unsupported constructs.
+func (c *downstreamCapability) adjustDDL(
+	query, charset, collate, policy string,
+) (string, []string, error) {
+	p := parser.New()
+	p.SetSQLMode(c.sqlMode)
+	stmts, _, err := p.Parse(query, charset, collate)
+	if err != nil || len(stmts) != 1 {
+		// Leave the DDL to the downstream, which reports the error if any.
+		return query, nil, nil
+	}
+
+	a := &ddlAdjuster{capability: c}
+	keep := a.adjustStmt(stmts[0])
+	if len(a.unsupported) == 0 {
+		return query, nil, nil
+	}
+	if policy == pmysql.UnsupportedDDLPolicySkip || !keep {
+		return "", a.unsupported, nil
+	}
+
+	var sb strings.Builder
+	restoreFlags := format.RestoreTiDBSpecialComment |
+		format.RestoreNameBackQuotes |
+		format.RestoreKeyWordUppercase |
+		format.RestoreStringSingleQuotes
+	if err = stmts[0].Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return sb.String(), a.unsupported, nil
+}
+
+// ddlAdjuster removes the constructs the downstream doesn't support from a
+// DDL statement.
+type ddlAdjuster struct {
+	capability  *downstreamCapability
+	unsupported []string
+}
+
+// adjustStmt returns false if nothing is left in the statement after
+// unsupported constructs are removed.
+func (a *ddlAdjuster) adjustStmt(stmt ast.StmtNode) bool {
+	switch s := stmt.(type) {
+	case *ast.CreateTableStmt:
+		constraints := s.Constraints[:0]
+		for _, constraint := range s.Constraints {
+			if a.adjustIndex(constraint.Keys, constraint.Option) {
+				constraints = append(constraints, constraint)
+			}
+		}
+		s.Constraints = constraints
+	case *ast.CreateIndexStmt:
+		return a.adjustIndex(s.IndexPartSpecifications, s.IndexOption)
+	case *ast.AlterTableStmt:
+		specs := s.Specs[:0]
+		for _, spec := range s.Specs {
+			switch spec.Tp {
+			case ast.AlterTableAddConstraint:
+				if !a.adjustIndex(spec.Constraint.Keys, spec.Constraint.Option) {
+					continue
+				}
+			case ast.AlterTableIndexInvisible:
+				if !a.capability.indexVisibility {
+					a.unsupported = append(a.unsupported, unsupportedIndexVisibility)
+					continue
+				}
+			}
+			specs = append(specs, spec)
+		}
+		s.Specs = specs
+		return len(specs) > 0
+	}
+	return true
+}
+
+// adjustIndex returns false if the index should be removed.
+func (a *ddlAdjuster) adjustIndex(
+	keys []*ast.IndexPartSpecification, option *ast.IndexOption,
+) bool {
+	if !a.capability.expressionIndex {
+		for _, key := range keys {
+			if key.Expr != nil {
+				a.unsupported = append(a.unsupported, unsupportedExpressionIndex)
+				return false
+			}
+		}
+	}
+	if !a.capability.indexVisibility && option != nil &&
+		option.Visibility != ast.IndexVisibilityDefault {
+		a.unsupported = append(a.unsupported, unsupportedIndexVisibility)
+		option.Visibility = ast.IndexVisibilityDefault
+	}
+	return true
+}
+
+// isUnsupportedSyntaxError returns true if the error is reported by the
+// downstream for a DDL it can't parse or doesn't support.
+func isUnsupportedSyntaxError(err error) bool {
+	mysqlErr, ok := errors.Cause(err).(*dmysql.MySQLError)
+	if !ok {
+		return false
+	}
+	switch mysqlErr.Number {
+	case tmysql.ErrParse, tmysql.ErrSyntax, tmysql.ErrNotSupportedYet:
+		return true
+	}
+	return false
+}
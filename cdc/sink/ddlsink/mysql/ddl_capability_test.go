@@ -0,0 +1,141 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"testing"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	tmysql "github.com/pingcap/tidb/parser/mysql"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDownstreamCapability(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		version         string
+		expressionIndex bool
+		indexVisibility bool
+	}{
+		{"8.0.11-TiDB-v7.1.0", true, true},
+		{"8.0.33", true, true},
+		{"8.0.12-log", false, true},
+		{"5.7.38-log", false, false},
+		{"5.5.5-10.6.12-MariaDB", false, false},
+		{"unknown", true, true},
+	}
+	for _, tc := range testCases {
+		c := newDownstreamCapability(tc.version, "")
+		require.Equal(t, tc.expressionIndex, c.expressionIndex, tc.version)
+		require.Equal(t, tc.indexVisibility, c.indexVisibility, tc.version)
+	}
+
+	c := newDownstreamCapability("5.7.38", "ANSI_QUOTES,unknown_mode")
+	require.True(t, c.sqlMode.HasANSIQuotesMode())
+}
+
+func TestAdjustDDL(t *testing.T) {
+	t.Parallel()
+
+	mysql57 := newDownstreamCapability("5.7.38", "")
+	testCases := []struct {
+		query       string
+		policy      string
+		expected    string
+		unsupported []string
+	}{
+		{
+			query:    "CREATE TABLE `t` (`a` INT, KEY `idx` ((`a` + 1)))",
+			policy:   pmysql.UnsupportedDDLPolicyTranslate,
+			expected: "CREATE TABLE `t` (`a` INT)",
+			unsupported: []string{
+				unsupportedExpressionIndex,
+			},
+		},
+		{
+			query:    "CREATE TABLE `t` (`a` INT, KEY `idx` ((`a` + 1)))",
+			policy:   pmysql.UnsupportedDDLPolicySkip,
+			expected: "",
+			unsupported: []string{
+				unsupportedExpressionIndex,
+			},
+		},
+		{
+			query:    "CREATE INDEX `idx` ON `t` ((LOWER(`b`)))",
+			policy:   pmysql.UnsupportedDDLPolicyTranslate,
+			expected: "",
+			unsupported: []string{
+				unsupportedExpressionIndex,
+			},
+		},
+		{
+			// The emptied index option is restored as a trailing space.
+			query:    "ALTER TABLE `t` ADD INDEX `idx1`((`a` + 1)), ADD INDEX `idx2`(`b`) INVISIBLE",
+			policy:   pmysql.UnsupportedDDLPolicyTranslate,
+			expected: "ALTER TABLE `t` ADD INDEX `idx2`(`b`) ",
+			unsupported: []string{
+				unsupportedExpressionIndex, unsupportedIndexVisibility,
+			},
+		},
+		{
+			query:    "ALTER TABLE `t` ALTER INDEX `idx` INVISIBLE",
+			policy:   pmysql.UnsupportedDDLPolicyTranslate,
+			expected: "",
+			unsupported: []string{
+				unsupportedIndexVisibility,
+			},
+		},
+		{
+			// Supported DDLs are executed as they are.
+			query:    "ALTER TABLE `t` ADD INDEX `idx`(`b`)",
+			policy:   pmysql.UnsupportedDDLPolicyTranslate,
+			expected: "ALTER TABLE `t` ADD INDEX `idx`(`b`)",
+		},
+		{
+			// DDLs fail to be parsed are left to the downstream.
+			query:    "ALTER TABLE `t` ADD SOMETHING",
+			policy:   pmysql.UnsupportedDDLPolicyTranslate,
+			expected: "ALTER TABLE `t` ADD SOMETHING",
+		},
+	}
+	for _, tc := range testCases {
+		query, unsupported, err := mysql57.adjustDDL(tc.query, "", "", tc.policy)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, query, tc.query)
+		require.Equal(t, tc.unsupported, unsupported, tc.query)
+	}
+
+	// All constructs are supported by MySQL 8.0.
+	query := "CREATE TABLE `t` (`a` INT, KEY `idx` ((`a` + 1)) INVISIBLE)"
+	adjusted, unsupported, err := newDownstreamCapability("8.0.33", "").
+		adjustDDL(query, "", "", pmysql.UnsupportedDDLPolicyTranslate)
+	require.NoError(t, err)
+	require.Equal(t, query, adjusted)
+	require.Empty(t, unsupported)
+}
+
+func TestIsUnsupportedSyntaxError(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isUnsupportedSyntaxError(
+		errors.Trace(&dmysql.MySQLError{Number: tmysql.ErrParse})))
+	require.True(t, isUnsupportedSyntaxError(
+		&dmysql.MySQLError{Number: tmysql.ErrNotSupportedYet}))
+	require.False(t, isUnsupportedSyntaxError(
+		&dmysql.MySQLError{Number: tmysql.ErrDupKeyName}))
+	require.False(t, isUnsupportedSyntaxError(errors.New("test")))
+}
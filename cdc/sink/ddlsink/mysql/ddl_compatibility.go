@@ -0,0 +1,151 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+// checkDDLCompatibility checks whether DMLs after the DDL are applicable to
+// the downstream table before the DDL is executed. The downstream schema
+// after the DDL is simulated by applying the changes the DDL makes to the
+// upstream table to the current downstream table, and then it's compared
+// with the upstream table after the DDL, which DMLs are generated from.
+func (m *DDLSink) checkDDLCompatibility(ctx context.Context, ddl *model.DDLEvent) error {
+	check := m.cfg.DDLCompatibilityCheck
+	if (check != pmysql.DDLCompatibilityCheckWarn && check != pmysql.DDLCompatibilityCheckFail) ||
+		!needCheckDDLCompatibility(ddl) {
+		return nil
+	}
+
+	pre := ddl.PreTableInfo.TableName
+	tables, err := queryDownstreamSchema(ctx, m.db, pre.Schema, pre.Table)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	post := upstreamTableSchema(ddl.TableInfo)
+	simulated := simulateDDL(
+		upstreamTableSchema(ddl.PreTableInfo), post, tables[strings.ToLower(pre.Table)])
+	incompatible := checkTableSchema(post, simulated)
+	if len(incompatible) == 0 {
+		return nil
+	}
+
+	log.Warn("DMLs after DDL are not applicable to downstream",
+		zap.String("namespace", m.id.Namespace),
+		zap.String("changefeed", m.id.ID),
+		zap.Uint64("startTs", ddl.StartTs),
+		zap.String("ddl", ddl.Query),
+		zap.Strings("incompatible", incompatible),
+		zap.String("check", check))
+	if check == pmysql.DDLCompatibilityCheckFail {
+		return cerror.ErrDDLIncompatibleWithDownstream.GenWithStackByArgs(
+			ddl.Query, strings.Join(incompatible, ", "))
+	}
+	return nil
+}
+
+// needCheckDDLCompatibility returns true if the DDL changes an existing table
+// which is replicated after the DDL.
+func needCheckDDLCompatibility(ddl *model.DDLEvent) bool {
+	if ddl.PreTableInfo == nil || ddl.TableInfo == nil ||
+		ddl.PreTableInfo.IsView() || ddl.TableInfo.IsView() {
+		return false
+	}
+	switch ddl.Type {
+	case timodel.ActionCreateTable, timodel.ActionDropTable,
+		timodel.ActionCreateSchema, timodel.ActionDropSchema,
+		timodel.ActionCreateView, timodel.ActionDropView:
+		return false
+	}
+	return ddl.TableInfo.TableName.Table != ""
+}
+
+// simulateDDL returns the schema of the downstream table after the DDL, whose
+// effect is the differences between the upstream table before and after it.
+// The downstream table is nil if it does not exist.
+func simulateDDL(pre, post, downstream *tableSchema) *tableSchema {
+	if downstream == nil {
+		return nil
+	}
+	simulated := newTableSchema()
+	for col, tp := range downstream.columns {
+		simulated.columns[col] = tp
+	}
+	for key := range downstream.uniqueKeys {
+		simulated.uniqueKeys[key] = struct{}{}
+	}
+
+	for col := range pre.columns {
+		if _, ok := post.columns[col]; !ok {
+			delete(simulated.columns, col)
+		}
+	}
+	for col, tp := range post.columns {
+		if preTp, ok := pre.columns[col]; !ok || preTp != tp {
+			simulated.columns[col] = tp
+		}
+	}
+	for key := range pre.uniqueKeys {
+		if _, ok := post.uniqueKeys[key]; !ok {
+			delete(simulated.uniqueKeys, key)
+		}
+	}
+	for key := range post.uniqueKeys {
+		if _, ok := pre.uniqueKeys[key]; !ok {
+			simulated.uniqueKeys[key] = struct{}{}
+		}
+	}
+	return simulated
+}
+
+// checkTableSchema returns the reasons why DMLs of an upstream table are not
+// applicable to its downstream table, which is nil if it does not exist.
+// Columns and unique keys that only exist in downstream are not reported,
+// since they don't fail DMLs necessarily.
+func checkTableSchema(upstream, downstream *tableSchema) []string {
+	if downstream == nil {
+		return []string{"table does not exist in downstream"}
+	}
+	var incompatible []string
+	for _, col := range sortedKeys(upstream.columns) {
+		tp, ok := downstream.columns[col]
+		if !ok {
+			incompatible = append(incompatible, fmt.Sprintf(
+				"column %s does not exist in downstream", quotes.QuoteName(col)))
+		} else if tp != upstream.columns[col] {
+			incompatible = append(incompatible, fmt.Sprintf(
+				"column %s is %s in upstream but %s in downstream",
+				quotes.QuoteName(col), upstream.columns[col], tp))
+		}
+	}
+	for _, key := range sortedKeys(upstream.uniqueKeys) {
+		if _, ok := downstream.uniqueKeys[key]; !ok {
+			incompatible = append(incompatible,
+				fmt.Sprintf("unique key %s does not exist in downstream", key))
+		}
+	}
+	return incompatible
+}
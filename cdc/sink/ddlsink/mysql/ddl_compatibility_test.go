@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/metrics"
+	"github.com/pingcap/tiflow/pkg/sink"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateDDL(t *testing.T) {
+	t.Parallel()
+
+	pre := upstreamTableSchema(newTableInfo4Test(t, "test",
+		"CREATE TABLE t1 (id INT PRIMARY KEY, a INT, b INT, UNIQUE KEY uk(b))"))
+	post := upstreamTableSchema(newTableInfo4Test(t, "test",
+		"CREATE TABLE t1 (id INT PRIMARY KEY, a BIGINT, c INT, UNIQUE KEY uk(c))"))
+	downstream := newTableSchema()
+	downstream.columns = map[string]string{
+		"id": "int", "a": "int", "b": "int", "d": "varchar(10)",
+	}
+	downstream.uniqueKeys = map[string]struct{}{"(`id`)": {}, "(`b`)": {}}
+
+	// Columns and unique keys only in downstream are kept.
+	simulated := simulateDDL(pre, post, downstream)
+	require.Equal(t, map[string]string{
+		"id": "int", "a": "bigint", "c": "int", "d": "varchar(10)",
+	}, simulated.columns)
+	require.Equal(t, map[string]struct{}{"(`id`)": {}, "(`c`)": {}}, simulated.uniqueKeys)
+	require.Empty(t, checkTableSchema(post, simulated))
+
+	// Drifts of columns not changed by the DDL are reported.
+	downstream.columns["id"] = "bigint"
+	delete(downstream.uniqueKeys, "(`id`)")
+	require.Equal(t, []string{
+		"column `id` is int in upstream but bigint in downstream",
+		"unique key (`id`) does not exist in downstream",
+	}, checkTableSchema(post, simulateDDL(pre, post, downstream)))
+
+	require.Nil(t, simulateDDL(pre, post, nil))
+	require.Equal(t, []string{"table does not exist in downstream"},
+		checkTableSchema(post, nil))
+}
+
+func TestWriteDDLEventCheckCompatibility(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	cfg := pmysql.NewConfig()
+	cfg.DDLCompatibilityCheck = pmysql.DDLCompatibilityCheckFail
+	id := model.DefaultChangeFeedID("test")
+	ddlSink := &DDLSink{
+		id:         id,
+		db:         db,
+		cfg:        cfg,
+		statistics: metrics.NewStatistics(ctx, id, sink.TxnSink),
+	}
+	defer ddlSink.statistics.Close()
+
+	ddl := &model.DDLEvent{
+		StartTs:  1000,
+		CommitTs: 1010,
+		PreTableInfo: newTableInfo4Test(t, "test",
+			"CREATE TABLE t1 (id INT PRIMARY KEY, a INT)"),
+		TableInfo: newTableInfo4Test(t, "test",
+			"CREATE TABLE t1 (id INT PRIMARY KEY, a INT, b INT)"),
+		Type:  timodel.ActionAddColumn,
+		Query: "ALTER TABLE `t1` ADD COLUMN `b` INT",
+	}
+	expectDownstreamTable := func(aType string) {
+		mock.ExpectQuery("information_schema.COLUMNS").WithArgs("test", "t1").
+			WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "COLUMN_TYPE"}).
+				AddRow("t1", "id", "int(11)").
+				AddRow("t1", "a", aType))
+		mock.ExpectQuery("information_schema.STATISTICS").WithArgs("test", "t1").
+			WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "INDEX_NAME", "COLUMN_NAME"}).
+				AddRow("t1", "PRIMARY", "id"))
+	}
+
+	// The DDL is executed if DMLs after it are applicable.
+	expectDownstreamTable("int(11)")
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE `t1` ADD COLUMN `b` INT").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	require.NoError(t, ddlSink.WriteDDLEvent(ctx, ddl))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The DDL is not executed if they are not applicable.
+	expectDownstreamTable("varchar(10)")
+	err = ddlSink.WriteDDLEvent(ctx, ddl)
+	require.ErrorContains(t, err, "ErrDDLIncompatibleWithDownstream")
+	require.ErrorContains(t, err, "column `a` is int in upstream but varchar(10) in downstream")
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Incompatibilities are only logged in the warn mode.
+	cfg.DDLCompatibilityCheck = pmysql.DDLCompatibilityCheckWarn
+	expectDownstreamTable("varchar(10)")
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE `t1` ADD COLUMN `b` INT").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	require.NoError(t, ddlSink.WriteDDLEvent(ctx, ddl))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
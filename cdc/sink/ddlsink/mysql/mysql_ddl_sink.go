@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/pingcap/tiflow/pkg/sink"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
 
@@ -47,8 +48,11 @@ const (
 // Exported for testing.
 var GetDBConnImpl pmysql.Factory = pmysql.CreateMySQLDBConn
 
-// Assert Sink implementation
-var _ ddlsink.Sink = (*DDLSink)(nil)
+// Assert Sink and SchemaChecker implementation
+var (
+	_ ddlsink.Sink          = (*DDLSink)(nil)
+	_ ddlsink.SchemaChecker = (*DDLSink)(nil)
+)
 
 // DDLSink is a sink that writes DDL events to MySQL.
 type DDLSink struct {
@@ -60,6 +64,21 @@ type DDLSink struct {
 	// statistics is the statistics of this sink.
 	// We use it to record the DDL count.
 	statistics *metrics.Statistics
+	// schemaChecker checks whether downstream tables drift from upstream.
+	schemaChecker *schemaChecker
+
+	// autoCreateTable indicates whether missing downstream tables are
+	// created from the upstream definition.
+	autoCreateTable       bool
+	autoCreateTableConfig *config.AutoCreateTableConfig
+	// missingTablesCreated is true once missing tables are created after the
+	// sink is started.
+	missingTablesCreated bool
+
+	// capability is the DDL constructs supported by the downstream. It's
+	// probed lazily if unsupported-ddl-policy is not fail, and probed again
+	// once a DDL fails with a syntax error.
+	capability *downstreamCapability
 }
 
 // NewDDLSink creates a new DDLSink.
@@ -90,6 +109,11 @@ func NewDDLSink(
 		db:         db,
 		cfg:        cfg,
 		statistics: metrics.NewStatistics(ctx, changefeedID, sink.TxnSink),
+
+		schemaChecker: newSchemaChecker(cfg.SchemaCheckInterval),
+
+		autoCreateTable:       util.GetOrZero(replicaConfig.Sink.AutoCreateTable),
+		autoCreateTableConfig: replicaConfig.Sink.AutoCreateTableConfig,
 	}
 
 	log.Info("MySQL DDL sink is created",
@@ -111,6 +135,9 @@ func (m *DDLSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 func (m *DDLSink) execDDLWithMaxRetries(ctx context.Context, ddl *model.DDLEvent) error {
 	return retry.Do(ctx, func() error {
 		err := m.statistics.RecordDDLExecution(func() error { return m.execDDL(ctx, ddl) })
+		if err != nil && m.reprobeCapability(ctx, err) {
+			err = m.statistics.RecordDDLExecution(func() error { return m.execDDL(ctx, ddl) })
+		}
 		if err != nil {
 			if errorutil.IsIgnorableMySQLDDLError(err) {
 				// NOTE: don't change the log, some tests depend on it.
@@ -130,8 +157,10 @@ func (m *DDLSink) execDDLWithMaxRetries(ctx context.Context, ddl *model.DDLEvent
 			return err
 		}
 		return nil
-	}, retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
+	}, retry.WithName("mysql_sink_ddl"),
+		retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
 		retry.WithBackoffMaxDelay(pmysql.BackoffMaxDelay.Milliseconds()),
+		retry.WithDecorrelatedJitter(),
 		retry.WithMaxTries(defaultDDLMaxRetry),
 		retry.WithIsRetryableErr(errorutil.IsRetryableDDLError))
 }
@@ -170,6 +199,30 @@ func (m *DDLSink) execDDL(pctx context.Context, ddl *model.DDLEvent) error {
 	}
 
 	shouldSwitchDB := needSwitchDB(ddl)
+	query := ddl.Query
+	if m.autoCreateTable && ddl.Type == timodel.ActionCreateTable {
+		if err := m.createSchemaIfNotExists(ctx, ddl.TableInfo.TableName.Schema); err != nil {
+			return err
+		}
+		var err error
+		if query, err = createTableStmt(ddl.TableInfo, m.autoCreateTableConfig); err != nil {
+			return err
+		}
+	}
+	query, err := m.adjustUnsupportedDDL(ctx, ddl, query)
+	if err != nil {
+		return err
+	}
+	if query == "" {
+		log.Warn("Skip DDL unsupported by the downstream",
+			zap.Uint64("startTs", ddl.StartTs), zap.String("ddl", ddl.Query),
+			zap.String("namespace", m.id.Namespace),
+			zap.String("changefeed", m.id.ID))
+		return nil
+	}
+	if err := m.checkDDLCompatibility(ctx, ddl); err != nil {
+		return err
+	}
 
 	failpoint.Inject("MySQLSinkExecDDLDelay", func() {
 		select {
@@ -199,9 +252,9 @@ func (m *DDLSink) execDDL(pctx context.Context, ddl *model.DDLEvent) error {
 		}
 	}
 
-	if _, err = tx.ExecContext(ctx, ddl.Query); err != nil {
+	if _, err = tx.ExecContext(ctx, query); err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			log.Error("Failed to rollback", zap.String("sql", ddl.Query),
+			log.Error("Failed to rollback", zap.String("sql", query),
 				zap.String("namespace", m.id.Namespace),
 				zap.String("changefeed", m.id.ID), zap.Error(err))
 		}
@@ -209,20 +262,82 @@ func (m *DDLSink) execDDL(pctx context.Context, ddl *model.DDLEvent) error {
 	}
 
 	if err = tx.Commit(); err != nil {
-		log.Error("Failed to exec DDL", zap.String("sql", ddl.Query),
+		log.Error("Failed to exec DDL", zap.String("sql", query),
 			zap.Duration("duration", time.Since(start)),
 			zap.String("namespace", m.id.Namespace),
 			zap.String("changefeed", m.id.ID), zap.Error(err))
 		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
 	}
 
-	log.Info("Exec DDL succeeded", zap.String("sql", ddl.Query),
+	log.Info("Exec DDL succeeded", zap.String("sql", query),
 		zap.Duration("duration", time.Since(start)),
 		zap.String("namespace", m.id.Namespace),
 		zap.String("changefeed", m.id.ID))
 	return nil
 }
 
+// adjustUnsupportedDDL handles the constructs of a DDL the downstream doesn't
+// support according to unsupported-ddl-policy. An empty query is returned if
+// the DDL should be skipped.
+func (m *DDLSink) adjustUnsupportedDDL(
+	ctx context.Context, ddl *model.DDLEvent, query string,
+) (string, error) {
+	if m.cfg.UnsupportedDDLPolicy == pmysql.UnsupportedDDLPolicyFail {
+		return query, nil
+	}
+	if m.capability == nil {
+		capability, err := probeDownstreamCapability(ctx, m.db)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		m.capability = capability
+	}
+	adjusted, unsupported, err := m.capability.adjustDDL(
+		query, ddl.Charset, ddl.Collate, m.cfg.UnsupportedDDLPolicy)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(unsupported) > 0 {
+		log.Warn("DDL contains constructs unsupported by the downstream",
+			zap.String("namespace", m.id.Namespace),
+			zap.String("changefeed", m.id.ID),
+			zap.String("ddl", query),
+			zap.String("adjusted", adjusted),
+			zap.Strings("unsupported", unsupported),
+			zap.String("policy", m.cfg.UnsupportedDDLPolicy),
+			zap.String("downstreamVersion", m.capability.version))
+	}
+	return adjusted, nil
+}
+
+// reprobeCapability probes the capability of the downstream again after a
+// DDL fails with a syntax error, since the downstream may be changed after the
+// last probe, e.g. it fails over to another endpoint. It returns true if the
+// capability changes, and the DDL should be executed again.
+func (m *DDLSink) reprobeCapability(ctx context.Context, err error) bool {
+	if m.capability == nil || !isUnsupportedSyntaxError(err) {
+		return false
+	}
+	capability, probeErr := probeDownstreamCapability(ctx, m.db)
+	if probeErr != nil {
+		log.Warn("Probe downstream capability failed",
+			zap.String("namespace", m.id.Namespace),
+			zap.String("changefeed", m.id.ID),
+			zap.Error(probeErr))
+		return false
+	}
+	if *capability == *m.capability {
+		return false
+	}
+	log.Info("Downstream capability changed, retry the DDL",
+		zap.String("namespace", m.id.Namespace),
+		zap.String("changefeed", m.id.ID),
+		zap.String("oldVersion", m.capability.version),
+		zap.String("newVersion", capability.version))
+	m.capability = capability
+	return true
+}
+
 func needSwitchDB(ddl *model.DDLEvent) bool {
 	if len(ddl.TableInfo.TableName.Schema) == 0 {
 		return false
@@ -233,9 +348,16 @@ func needSwitchDB(ddl *model.DDLEvent) bool {
 	return true
 }
 
-// WriteCheckpointTs does nothing.
-func (m *DDLSink) WriteCheckpointTs(_ context.Context, _ uint64, _ []*model.TableInfo) error {
-	// Only for RowSink for now.
+// WriteCheckpointTs creates missing downstream tables when it's called for
+// the first time if auto-create-table is enabled, it does nothing otherwise.
+func (m *DDLSink) WriteCheckpointTs(ctx context.Context, _ uint64, tables []*model.TableInfo) error {
+	if !m.autoCreateTable || m.missingTablesCreated {
+		return nil
+	}
+	if err := m.createMissingTables(ctx, tables); err != nil {
+		return errors.Trace(err)
+	}
+	m.missingTablesCreated = true
 	return nil
 }
 
@@ -23,8 +23,11 @@ import (
 	dmysql "github.com/go-sql-driver/mysql"
 	"github.com/pingcap/tidb/infoschema"
 	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/metrics"
 	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/stretchr/testify/require"
 )
@@ -142,3 +145,62 @@ func TestNeedSwitchDB(t *testing.T) {
 		require.Equal(t, tc.needSwitch, needSwitchDB(tc.ddl))
 	}
 }
+
+func TestWriteDDLEventUnsupported(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	cfg := pmysql.NewConfig()
+	cfg.UnsupportedDDLPolicy = pmysql.UnsupportedDDLPolicyTranslate
+	id := model.DefaultChangeFeedID("test")
+	ddlSink := &DDLSink{
+		id:         id,
+		db:         db,
+		cfg:        cfg,
+		statistics: metrics.NewStatistics(ctx, id, sink.TxnSink),
+	}
+	defer ddlSink.statistics.Close()
+	newDDL := func(query string) *model.DDLEvent {
+		return &model.DDLEvent{
+			StartTs:  1000,
+			CommitTs: 1010,
+			TableInfo: &model.TableInfo{
+				TableName: model.TableName{Schema: "test", Table: "t1"},
+			},
+			Type:  timodel.ActionAddIndex,
+			Query: query,
+		}
+	}
+	probe := "SELECT VERSION(), @@SESSION.sql_mode;"
+
+	// Unsupported constructs are translated.
+	mock.ExpectQuery(probe).WillReturnRows(
+		sqlmock.NewRows([]string{"VERSION()", "@@SESSION.sql_mode"}).AddRow("5.7.38-log", ""))
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE `t1` ADD INDEX `idx2`(`b`)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	err = ddlSink.WriteDDLEvent(ctx, newDDL(
+		"ALTER TABLE `t1` ADD INDEX `idx1`((`a` + 1)), ADD INDEX `idx2`(`b`)"))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The capability is probed again once a DDL fails with a syntax error.
+	ddlSink.capability = newDownstreamCapability("8.0.33", "")
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE `t1` ADD INDEX `idx3`((`a` + 1))").
+		WillReturnError(&dmysql.MySQLError{Number: mysql.ErrParse})
+	mock.ExpectRollback()
+	mock.ExpectQuery(probe).WillReturnRows(
+		sqlmock.NewRows([]string{"VERSION()", "@@SESSION.sql_mode"}).AddRow("5.7.38-log", ""))
+	err = ddlSink.WriteDDLEvent(ctx, newDDL("ALTER TABLE `t1` ADD INDEX `idx3`((`a` + 1))"))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.False(t, ddlSink.capability.expressionIndex)
+}
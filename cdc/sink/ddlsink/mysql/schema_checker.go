@@ -0,0 +1,303 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"go.uber.org/zap"
+)
+
+// maxReportedDrifts is the max number of tables reported in a schema drift
+// warning, so that the warning does not become too large.
+const maxReportedDrifts = 10
+
+// intDisplayWidthRe matches the display width of integer types, which is
+// deprecated since MySQL 8.0 and not shown by it.
+var intDisplayWidthRe = regexp.MustCompile(`^(tinyint|smallint|mediumint|int|bigint|year)\(\d+\)`)
+
+// schemaChecker checks whether the schema of downstream tables drifts from
+// upstream. A drift is reported only if it's found by two consecutive checks,
+// because the upstream schema given to the checker lags behind downstream
+// shortly after a DDL is executed.
+type schemaChecker struct {
+	interval  time.Duration
+	lastCheck time.Time
+	// drifts are found by the last check, keyed by quoted table names.
+	drifts map[string]string
+}
+
+func newSchemaChecker(interval time.Duration) *schemaChecker {
+	return &schemaChecker{interval: interval}
+}
+
+// update records drifts found by the current check, and returns drifts that
+// are also found by the last check.
+func (c *schemaChecker) update(drifts map[string]string) []string {
+	var persisted []string
+	for table, diff := range drifts {
+		if c.drifts[table] == diff {
+			persisted = append(persisted, diff)
+		}
+	}
+	c.drifts = drifts
+	sort.Strings(persisted)
+	return persisted
+}
+
+// CheckSchema compares the schema of tables with downstream tables, and
+// returns ErrDownstreamSchemaDrift with the differences if they drift. It
+// checks at most once per schema check interval.
+// Note: it's not thread-safe.
+func (m *DDLSink) CheckSchema(ctx context.Context, tables []*model.TableInfo) error {
+	c := m.schemaChecker
+	if c.interval == 0 || time.Since(c.lastCheck) < c.interval {
+		return nil
+	}
+	c.lastCheck = time.Now()
+
+	drifts, err := diffDownstreamSchema(ctx, m.db, tables)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	persisted := c.update(drifts)
+	if len(persisted) == 0 {
+		return nil
+	}
+	log.Warn("downstream schema drifts from upstream",
+		zap.String("namespace", m.id.Namespace),
+		zap.String("changefeed", m.id.ID),
+		zap.Strings("drifts", persisted))
+	if len(persisted) > maxReportedDrifts {
+		more := len(persisted) - maxReportedDrifts
+		persisted = append(persisted[:maxReportedDrifts],
+			fmt.Sprintf("and %d more tables", more))
+	}
+	return cerror.ErrDownstreamSchemaDrift.GenWithStackByArgs(strings.Join(persisted, "; "))
+}
+
+// tableSchema is the part of a table schema that affects replication.
+type tableSchema struct {
+	// columns maps lower case column names to normalized column types.
+	columns map[string]string
+	// uniqueKeys are formatted column lists of primary and unique keys.
+	uniqueKeys map[string]struct{}
+}
+
+func newTableSchema() *tableSchema {
+	return &tableSchema{
+		columns:    make(map[string]string),
+		uniqueKeys: make(map[string]struct{}),
+	}
+}
+
+// diffDownstreamSchema returns differences between the schema of tables and
+// downstream tables, keyed by quoted table names.
+func diffDownstreamSchema(
+	ctx context.Context, db *sql.DB, tables []*model.TableInfo,
+) (map[string]string, error) {
+	bySchema := make(map[string][]*model.TableInfo)
+	for _, table := range tables {
+		if table.IsView() {
+			continue
+		}
+		schema := table.TableName.Schema
+		bySchema[schema] = append(bySchema[schema], table)
+	}
+
+	drifts := make(map[string]string)
+	for schema, tables := range bySchema {
+		downstream, err := queryDownstreamSchema(ctx, db, schema, "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, table := range tables {
+			name := quotes.QuoteSchema(schema, table.TableName.Table)
+			diff := diffTableSchema(
+				upstreamTableSchema(table),
+				downstream[strings.ToLower(table.TableName.Table)])
+			if len(diff) > 0 {
+				drifts[name] = name + ": " + strings.Join(diff, ", ")
+			}
+		}
+	}
+	return drifts, nil
+}
+
+// upstreamTableSchema returns the schema of an upstream table.
+func upstreamTableSchema(table *model.TableInfo) *tableSchema {
+	s := newTableSchema()
+	for _, col := range table.Columns {
+		if col.Hidden || col.State != timodel.StatePublic {
+			continue
+		}
+		s.columns[col.Name.L] = normalizeColumnType(col.FieldType.InfoSchemaStr())
+		if table.PKIsHandle && mysql.HasPriKeyFlag(col.GetFlag()) {
+			s.uniqueKeys[formatKey([]string{col.Name.L})] = struct{}{}
+		}
+	}
+	for _, idx := range table.Indices {
+		if !(idx.Primary || idx.Unique) || idx.State != timodel.StatePublic {
+			continue
+		}
+		cols := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			cols = append(cols, col.Name.L)
+		}
+		s.uniqueKeys[formatKey(cols)] = struct{}{}
+	}
+	return s
+}
+
+// queryDownstreamSchema returns the schema of downstream tables in a schema,
+// keyed by lower case table names. Only the given table is queried if it's
+// not empty.
+func queryDownstreamSchema(
+	ctx context.Context, db *sql.DB, schema, table string,
+) (map[string]*tableSchema, error) {
+	cond, args := "TABLE_SCHEMA = ?", []interface{}{schema}
+	if table != "" {
+		cond, args = cond+" AND TABLE_NAME = ?", append(args, table)
+	}
+	tables := make(map[string]*tableSchema)
+	getTable := func(name string) *tableSchema {
+		name = strings.ToLower(name)
+		if _, ok := tables[name]; !ok {
+			tables[name] = newTableSchema()
+		}
+		return tables[name]
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT TABLE_NAME, COLUMN_NAME, COLUMN_TYPE "+
+		"FROM information_schema.COLUMNS WHERE "+cond, args...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, column, tp string
+		if err := rows.Scan(&table, &column, &tp); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		getTable(table).columns[strings.ToLower(column)] = normalizeColumnType(tp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+
+	keyRows, err := db.QueryContext(ctx, "SELECT TABLE_NAME, INDEX_NAME, COLUMN_NAME "+
+		"FROM information_schema.STATISTICS WHERE "+cond+" AND NON_UNIQUE = 0 "+
+		"ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX", args...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer keyRows.Close()
+	var lastTable, lastIndex string
+	var cols []string
+	addKey := func() {
+		if len(cols) > 0 {
+			getTable(lastTable).uniqueKeys[formatKey(cols)] = struct{}{}
+		}
+		cols = nil
+	}
+	for keyRows.Next() {
+		var table, index, column string
+		if err := keyRows.Scan(&table, &index, &column); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		if table != lastTable || index != lastIndex {
+			addKey()
+			lastTable, lastIndex = table, index
+		}
+		cols = append(cols, strings.ToLower(column))
+	}
+	if err := keyRows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	addKey()
+	return tables, nil
+}
+
+// diffTableSchema returns the differences between the schema of an upstream
+// table and its downstream table, which is nil if it does not exist.
+func diffTableSchema(upstream, downstream *tableSchema) []string {
+	if downstream == nil {
+		return []string{"table does not exist in downstream"}
+	}
+	var diff []string
+	for _, col := range sortedKeys(upstream.columns) {
+		tp, ok := downstream.columns[col]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("column %s does not exist in downstream",
+				quotes.QuoteName(col)))
+		} else if tp != upstream.columns[col] {
+			diff = append(diff, fmt.Sprintf("column %s is %s in upstream but %s in downstream",
+				quotes.QuoteName(col), upstream.columns[col], tp))
+		}
+	}
+	for _, col := range sortedKeys(downstream.columns) {
+		if _, ok := upstream.columns[col]; !ok {
+			diff = append(diff, fmt.Sprintf("column %s does not exist in upstream",
+				quotes.QuoteName(col)))
+		}
+	}
+	for _, key := range sortedKeys(upstream.uniqueKeys) {
+		if _, ok := downstream.uniqueKeys[key]; !ok {
+			diff = append(diff, fmt.Sprintf("unique key %s does not exist in downstream", key))
+		}
+	}
+	for _, key := range sortedKeys(downstream.uniqueKeys) {
+		if _, ok := upstream.uniqueKeys[key]; !ok {
+			diff = append(diff, fmt.Sprintf("unique key %s does not exist in upstream", key))
+		}
+	}
+	return diff
+}
+
+// normalizeColumnType normalizes a column type shown by information_schema,
+// so that equivalent types of TiDB and MySQL are equal.
+func normalizeColumnType(tp string) string {
+	tp = strings.ReplaceAll(strings.ToLower(tp), " zerofill", "")
+	return intDisplayWidthRe.ReplaceAllString(tp, "$1")
+}
+
+func formatKey(cols []string) string {
+	quoted := make([]string, 0, len(cols))
+	for _, col := range cols {
+		quoted = append(quoted, quotes.QuoteName(col))
+	}
+	return "(" + strings.Join(quoted, ",") + ")"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
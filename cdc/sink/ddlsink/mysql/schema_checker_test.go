@@ -0,0 +1,124 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTableInfo4Test(t *testing.T, schema, createSQL string) *model.TableInfo {
+	stmt, err := parser.New().ParseOneStmt(createSQL, "", "")
+	require.NoError(t, err)
+	ti, err := ddl.BuildTableInfoFromAST(stmt.(*ast.CreateTableStmt))
+	require.NoError(t, err)
+	return model.WrapTableInfo(1, schema, 1, ti)
+}
+
+func expectDownstreamSchema(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("information_schema.COLUMNS").WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "COLUMN_TYPE"}).
+			AddRow("t1", "id", "int(11)").
+			AddRow("t1", "name", "varchar(20)").
+			AddRow("T2", "id", "bigint(20) unsigned").
+			AddRow("T2", "a", "varchar(10)").
+			AddRow("T2", "c", "int(11)"))
+	mock.ExpectQuery("information_schema.STATISTICS").WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "INDEX_NAME", "COLUMN_NAME"}).
+			AddRow("T2", "PRIMARY", "id").
+			AddRow("T2", "uk", "a").
+			AddRow("T2", "uk", "c").
+			AddRow("t1", "PRIMARY", "id"))
+}
+
+func TestDiffDownstreamSchema(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	expectDownstreamSchema(mock)
+
+	tables := []*model.TableInfo{
+		newTableInfo4Test(t, "test",
+			"CREATE TABLE t1 (id INT PRIMARY KEY, name VARCHAR(20))"),
+		newTableInfo4Test(t, "test",
+			"CREATE TABLE t2 (id BIGINT UNSIGNED PRIMARY KEY, a INT, b INT, UNIQUE KEY uk(a, b))"),
+		newTableInfo4Test(t, "test", "CREATE TABLE t3 (id INT)"),
+	}
+	drifts, err := diffDownstreamSchema(context.Background(), db, tables)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"`test`.`t2`": "`test`.`t2`: column `a` is int in upstream but varchar(10) in downstream, " +
+			"column `b` does not exist in downstream, column `c` does not exist in upstream, " +
+			"unique key (`a`,`b`) does not exist in downstream, " +
+			"unique key (`a`,`c`) does not exist in upstream",
+		"`test`.`t3`": "`test`.`t3`: table does not exist in downstream",
+	}, drifts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckSchema(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	sink := &DDLSink{
+		id:            model.DefaultChangeFeedID("test"),
+		db:            db,
+		schemaChecker: newSchemaChecker(time.Hour),
+	}
+	tables := []*model.TableInfo{
+		newTableInfo4Test(t, "test", "CREATE TABLE t3 (id INT)"),
+	}
+
+	// The drift is not reported by the first check.
+	expectDownstreamSchema(mock)
+	require.NoError(t, sink.CheckSchema(context.Background(), tables))
+	// Tables are not checked again within the interval.
+	require.NoError(t, sink.CheckSchema(context.Background(), tables))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	sink.schemaChecker.lastCheck = time.Time{}
+	expectDownstreamSchema(mock)
+	err = sink.CheckSchema(context.Background(), tables)
+	require.True(t, cerror.ErrDownstreamSchemaDrift.Equal(err))
+	require.Contains(t, err.Error(), "`test`.`t3`: table does not exist in downstream")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNormalizeColumnType(t *testing.T) {
+	t.Parallel()
+
+	for tp, expected := range map[string]string{
+		"int(11)":                   "int",
+		"INT(10) UNSIGNED ZEROFILL": "int unsigned",
+		"tinyint(1)":                "tinyint",
+		"year(4)":                   "year",
+		"varchar(20)":               "varchar(20)",
+		"decimal(10,2)":             "decimal(10,2)",
+	} {
+		require.Equal(t, expected, normalizeColumnType(tp), tp)
+	}
+}
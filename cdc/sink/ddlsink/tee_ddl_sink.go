@@ -0,0 +1,80 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddlsink
+
+import (
+	"context"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// Assert Sink and SchemaChecker implementation
+var (
+	_ Sink          = (*TeeSink)(nil)
+	_ SchemaChecker = (*TeeSink)(nil)
+)
+
+// TeeSink is a sink which writes all the DDL events and checkpoints to two
+// sinks. An event is written successfully only if both sinks succeed, so the
+// primary sink may receive an event more than once if the secondary sink
+// fails and the event is retried.
+type TeeSink struct {
+	primary   Sink
+	secondary Sink
+}
+
+// NewTeeSink creates a TeeSink.
+func NewTeeSink(primary, secondary Sink) *TeeSink {
+	return &TeeSink{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// WriteDDLEvent writes the DDL event to both sinks.
+func (t *TeeSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	if err := t.primary.WriteDDLEvent(ctx, ddl); err != nil {
+		return err
+	}
+	return t.secondary.WriteDDLEvent(ctx, ddl)
+}
+
+// WriteCheckpointTs writes the checkpoint ts to both sinks.
+func (t *TeeSink) WriteCheckpointTs(ctx context.Context,
+	ts uint64, tables []*model.TableInfo,
+) error {
+	if err := t.primary.WriteCheckpointTs(ctx, ts, tables); err != nil {
+		return err
+	}
+	return t.secondary.WriteCheckpointTs(ctx, ts, tables)
+}
+
+// CheckSchema checks the schema of downstream tables of both sinks, if they
+// support it.
+func (t *TeeSink) CheckSchema(ctx context.Context, tables []*model.TableInfo) error {
+	for _, s := range []Sink{t.primary, t.secondary} {
+		if checker, ok := s.(SchemaChecker); ok {
+			if err := checker.CheckSchema(ctx, tables); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes both sinks.
+func (t *TeeSink) Close() {
+	t.primary.Close()
+	t.secondary.Close()
+}
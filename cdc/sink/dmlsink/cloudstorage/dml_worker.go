@@ -15,7 +15,6 @@ package cloudstorage
 import (
 	"bytes"
 	"context"
-	"path"
 	"sync/atomic"
 	"time"
 
@@ -49,6 +48,9 @@ type dmlWorker struct {
 	filePathGenerator *cloudstorage.FilePathGenerator
 	metricWriteBytes  prometheus.Gauge
 	metricFileCount   prometheus.Gauge
+	// flushPolicies caches flush policies of tables, it's only accessed
+	// in dispatchFlushTasks.
+	flushPolicies map[model.TableName]cloudstorage.FlushPolicy
 }
 
 // dmlTask defines a task containing the tables to be flushed.
@@ -58,8 +60,11 @@ type dmlTask struct {
 
 type singleTableTask struct {
 	size      uint64
+	rows      int
 	tableInfo *model.TableInfo
 	msgs      []*common.Message
+	// meta is recorded in the index file if the index metadata is enabled.
+	meta cloudstorage.FileMeta
 }
 
 func newDMLTask() dmlTask {
@@ -68,7 +73,7 @@ func newDMLTask() dmlTask {
 	}
 }
 
-func (t *dmlTask) handleSingleTableEvent(event eventFragment) {
+func (t *dmlTask) handleSingleTableEvent(event eventFragment, indexMetadata bool) {
 	table := event.versionedTable
 	if _, ok := t.tasks[table]; !ok {
 		t.tasks[table] = &singleTableTask{
@@ -80,8 +85,13 @@ func (t *dmlTask) handleSingleTableEvent(event eventFragment) {
 	v := t.tasks[table]
 	for _, msg := range event.encodedMsgs {
 		v.size += uint64(len(msg.Value))
+		v.rows += msg.GetRowsCount()
 	}
 	v.msgs = append(v.msgs, event.encodedMsgs...)
+	if indexMetadata {
+		txn := event.event.Event
+		v.meta.Observe(txn.CommitTs, txn.Rows)
+	}
 }
 
 func (t *dmlTask) generateTaskByTable(table cloudstorage.VersionedTableName) dmlTask {
@@ -96,6 +106,25 @@ func (t *dmlTask) generateTaskByTable(table cloudstorage.VersionedTableName) dml
 	}
 }
 
+// generateTaskByTables moves tables matching the given function to a new task.
+func (t *dmlTask) generateTaskByTables(match func(cloudstorage.VersionedTableName) bool) dmlTask {
+	task := newDMLTask()
+	for table, v := range t.tasks {
+		if match(table) {
+			task.tasks[table] = v
+			delete(t.tasks, table)
+		}
+	}
+	return task
+}
+
+// merge moves all tables of other back to the task.
+func (t *dmlTask) merge(other dmlTask) {
+	for table, v := range other.tasks {
+		t.tasks[table] = v
+	}
+}
+
 func newDMLWorker(
 	id int,
 	changefeedID model.ChangeFeedID,
@@ -119,6 +148,7 @@ func newDMLWorker(
 			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 		metricFileCount: mcloudstorage.CloudStorageFileCountGauge.
 			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+		flushPolicies: make(map[model.TableName]cloudstorage.FlushPolicy),
 	}
 
 	return d
@@ -186,8 +216,16 @@ func (d *dmlWorker) flushMessages(ctx context.Context) error {
 				indexFilePath := d.filePathGenerator.GenerateIndexFilePath(table, date)
 
 				// first write the index file to external storage.
-				// the file content is simply the last element of the data file path
-				err = d.writeIndexFile(ctx, indexFilePath, path.Base(dataFilePath)+"\n")
+				// the file content is the last element of the data file path,
+				// followed by metadata of data files if it's enabled.
+				var meta *cloudstorage.FileMeta
+				if d.config.IndexMetadata {
+					meta = &task.meta
+				}
+				indexContent, err := d.filePathGenerator.GenerateIndexFileContent(table, meta)
+				if err == nil {
+					err = d.writeIndexFile(ctx, indexFilePath, indexContent)
+				}
 				if err != nil {
 					log.Error("failed to write index file to external storage",
 						zap.Int("workerID", d.id),
@@ -221,8 +259,8 @@ func (d *dmlWorker) flushMessages(ctx context.Context) error {
 	}
 }
 
-func (d *dmlWorker) writeIndexFile(ctx context.Context, path, content string) error {
-	err := d.storage.WriteFile(ctx, path, []byte(content))
+func (d *dmlWorker) writeIndexFile(ctx context.Context, path string, content []byte) error {
+	err := d.storage.WriteFile(ctx, path, content)
 	return err
 }
 
@@ -258,40 +296,75 @@ func (d *dmlWorker) writeDataFile(ctx context.Context, path string, task *single
 }
 
 // dispatchFlushTasks dispatches flush tasks in two conditions:
-// 1. the flush interval exceeds the upper limit.
-// 2. the file size exceeds the upper limit.
+// 1. the flush interval of a table exceeds the upper limit.
+// 2. the file size or row count of a table exceeds the upper limit.
+// Flush policies are decided by the table, see cloudstorage.Config.FlushPolicyOf.
 func (d *dmlWorker) dispatchFlushTasks(ctx context.Context,
 	ch *chann.DrainableChann[eventFragment],
 ) error {
 	flushTask := newDMLTask()
-	ticker := time.NewTicker(d.config.FlushInterval)
+	// The ticker runs with the smallest flush interval, and tables of each
+	// flush interval are flushed when their deadline is reached.
+	intervals := d.config.FlushIntervals()
+	start := time.Now()
+	deadlines := make(map[time.Duration]time.Time, len(intervals))
+	for _, interval := range intervals {
+		deadlines[interval] = start.Add(interval)
+	}
+	ticker := time.NewTicker(intervals[0])
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return errors.Trace(ctx.Err())
-		case <-ticker.C:
+		case now := <-ticker.C:
 			if atomic.LoadUint64(&d.isClosed) == 1 {
 				return nil
 			}
+			due := make(map[time.Duration]struct{}, len(intervals))
+			for interval, deadline := range deadlines {
+				if !now.Before(deadline) {
+					due[interval] = struct{}{}
+					for !now.Before(deadline) {
+						deadline = deadline.Add(interval)
+					}
+					deadlines[interval] = deadline
+				}
+			}
+			if len(due) == 0 {
+				continue
+			}
+			task := flushTask
+			if len(due) == len(intervals) {
+				flushTask = newDMLTask()
+			} else {
+				task = flushTask.generateTaskByTables(func(table cloudstorage.VersionedTableName) bool {
+					_, ok := due[d.flushPolicyOf(table).FlushInterval]
+					return ok
+				})
+			}
 			select {
 			case <-ctx.Done():
 				return errors.Trace(ctx.Err())
-			case d.flushNotifyCh <- flushTask:
+			case d.flushNotifyCh <- task:
 				log.Debug("flush task is emitted successfully when flush interval exceeds",
-					zap.Int("tablesLength", len(flushTask.tasks)))
-				flushTask = newDMLTask()
+					zap.Int("tablesLength", len(task.tasks)))
 			default:
+				flushTask.merge(task)
 			}
 		case frag, ok := <-ch.Out():
 			if !ok || atomic.LoadUint64(&d.isClosed) == 1 {
 				return nil
 			}
-			flushTask.handleSingleTableEvent(frag)
-			// if the file size exceeds the upper limit, emit the flush task containing the table
-			// as soon as possible.
+			flushTask.handleSingleTableEvent(frag, d.config.IndexMetadata)
+			// if the file size or row count exceeds the upper limit, emit the flush
+			// task containing the table as soon as possible.
 			table := frag.versionedTable
-			if flushTask.tasks[table].size >= uint64(d.config.FileSize) {
+			policy := d.flushPolicyOf(table)
+			v := flushTask.tasks[table]
+			if v.size >= uint64(policy.FileSize) ||
+				(policy.FileRowCount > 0 && v.rows >= policy.FileRowCount) {
 				task := flushTask.generateTaskByTable(table)
 				select {
 				case <-ctx.Done():
@@ -306,6 +379,16 @@ func (d *dmlWorker) dispatchFlushTasks(ctx context.Context,
 	}
 }
 
+func (d *dmlWorker) flushPolicyOf(table cloudstorage.VersionedTableName) cloudstorage.FlushPolicy {
+	name := table.TableNameWithPhysicTableID
+	policy, ok := d.flushPolicies[name]
+	if !ok {
+		policy = d.config.FlushPolicyOf(name.Schema, name.Table)
+		d.flushPolicies[name] = policy
+	}
+	return policy
+}
+
 func (d *dmlWorker) close() {
 	if !atomic.CompareAndSwapUint64(&d.isClosed, 0, 1) {
 		return
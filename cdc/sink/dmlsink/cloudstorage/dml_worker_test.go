@@ -137,3 +137,114 @@ func TestDMLWorkerRun(t *testing.T) {
 	wg.Wait()
 	fragCh.CloseAndDrain()
 }
+
+func TestDMLWorkerDispatchByFlushPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uri := fmt.Sprintf("file:///%s?flush-interval=2s", t.TempDir())
+	storage, err := util.GetExternalStorageFromURI(ctx, uri)
+	require.Nil(t, err)
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.CloudStorageConfig = &config.CloudStorageConfig{
+		FlushPolicies: []*config.CloudStorageFlushPolicy{
+			{Matcher: []string{"test.fact"}, FileRowCount: util.AddressOf(2)},
+			{Matcher: []string{"test.dim"}, FlushInterval: util.AddressOf("10m")},
+		},
+	}
+	cfg := cloudstorage.NewConfig()
+	require.Nil(t, cfg.Apply(ctx, sinkURI, replicaConfig))
+	statistics := metrics.NewStatistics(ctx, model.DefaultChangeFeedID("dml-worker-test"),
+		sink.TxnSink)
+	d := newDMLWorker(1, model.DefaultChangeFeedID("dml-worker-test"), storage,
+		cfg, ".json", chann.NewAutoDrainChann[eventFragment](), clock.New(), statistics)
+	defer d.inputCh.CloseAndDrain()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = d.dispatchFlushTasks(ctx, d.inputCh)
+	}()
+
+	newFragment := func(table string) eventFragment {
+		msg := &common.Message{Value: []byte("row")}
+		msg.SetRowsCount(1)
+		return eventFragment{
+			versionedTable: cloudstorage.VersionedTableName{
+				TableNameWithPhysicTableID: model.TableName{Schema: "test", Table: table},
+				TableInfoVersion:           99,
+			},
+			event: &dmlsink.TxnCallbackableEvent{
+				Event: &model.SingleTableTxn{TableInfo: &model.TableInfo{}},
+			},
+			encodedMsgs: []*common.Message{msg},
+		}
+	}
+	tableNames := func(task dmlTask) []string {
+		var names []string
+		for table, v := range task.tasks {
+			names = append(names, fmt.Sprintf("%s:%d", table.TableNameWithPhysicTableID.Table, v.rows))
+		}
+		return names
+	}
+
+	// test.fact is flushed once it has 2 rows.
+	for i := 0; i < 3; i++ {
+		d.inputCh.In() <- newFragment("fact")
+	}
+	task := <-d.flushNotifyCh
+	require.Equal(t, []string{"fact:2"}, tableNames(task))
+
+	// test.other is flushed by the global flush interval, while test.dim
+	// is not flushed until its own flush interval.
+	d.inputCh.In() <- newFragment("dim")
+	d.inputCh.In() <- newFragment("other")
+	require.Eventually(t, func() bool {
+		task = <-d.flushNotifyCh
+		return len(task.tasks) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	require.ElementsMatch(t, []string{"fact:1", "other:1"}, tableNames(task))
+
+	cancel()
+	wg.Wait()
+}
+
+func TestDMLTaskIndexMetadata(t *testing.T) {
+	t.Parallel()
+
+	table := cloudstorage.VersionedTableName{
+		TableNameWithPhysicTableID: model.TableName{Schema: "test", Table: "table1"},
+	}
+	fragment := func(commitTs uint64, id int) eventFragment {
+		return eventFragment{
+			versionedTable: table,
+			event: &dmlsink.TxnCallbackableEvent{
+				Event: &model.SingleTableTxn{
+					CommitTs: commitTs,
+					Rows: []*model.RowChangedEvent{{
+						CommitTs: commitTs,
+						Columns: []*model.Column{
+							{Name: "id", Value: id, Flag: model.HandleKeyFlag},
+						},
+					}},
+				},
+			},
+			encodedMsgs: []*common.Message{{Value: []byte("x")}},
+		}
+	}
+
+	task := newDMLTask()
+	task.handleSingleTableEvent(fragment(10, 2), false)
+	require.Equal(t, cloudstorage.FileMeta{}, task.tasks[table].meta)
+
+	task = newDMLTask()
+	task.handleSingleTableEvent(fragment(10, 2), true)
+	task.handleSingleTableEvent(fragment(12, 1), true)
+	require.Equal(t, cloudstorage.FileMeta{
+		MinCommitTs: 10, MaxCommitTs: 12, MinKey: "1", MaxKey: "2", Rows: 2,
+	}, task.tasks[table].meta)
+}
@@ -27,12 +27,20 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/txn"
+	"github.com/pingcap/tiflow/cdc/sink/generatedcolumn"
+	"github.com/pingcap/tiflow/cdc/sink/routing"
 	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/pingcap/tiflow/cdc/sink/verification"
+	"github.com/pingcap/tiflow/cdc/subscription"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/eventsampling"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	v2 "github.com/pingcap/tiflow/pkg/sink/kafka/v2"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	"github.com/pingcap/tiflow/pkg/sink/nats"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -45,8 +53,29 @@ import (
 type SinkFactory struct {
 	rowSink dmlsink.EventSink[*model.RowChangedEvent]
 	txnSink dmlsink.EventSink[*model.SingleTableTxn]
+	// teeSink is the factory of the tee sink, it's nil if the tee sink
+	// is not configured.
+	teeSink *SinkFactory
+	// verify is true if the events written to table sinks are verified,
+	// it's only supported by the blackhole sink.
+	verify bool
+	// subscriptionHub is the grpc sink, table sinks report their resolved
+	// ts to it. It's nil if the sink is not a grpc sink.
+	subscriptionHub *subscription.Hub
+	// router routes the table names of events written to table sinks,
+	// it's nil if no route rules are configured.
+	router *routing.Router
+	// generatedColumnPolicy is applied to events written to table sinks,
+	// it's empty if the sink handles generated columns by the policy natively.
+	generatedColumnPolicy string
 }
 
+// teeTotalRowsCounter is an unregistered counter for the table sinks of the
+// tee sink, so that the rows are not counted twice.
+var teeTotalRowsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "tee_table_sink_total_rows",
+})
+
 // New creates a new SinkFactory by schema.
 func New(
 	ctx context.Context,
@@ -60,8 +89,22 @@ func New(
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
 	}
 
-	s := &SinkFactory{}
+	router, err := routing.NewRouter(cfg.Sink.Routes)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SinkFactory{router: router}
 	schema := strings.ToLower(sinkURI.Scheme)
+	// DB sinks omit generated columns in DMLs, and other sinks encode them
+	// as normal columns.
+	nativePolicy := config.GeneratedColumnPolicyMaterialize
+	if sink.IsMySQLCompatibleScheme(schema) {
+		nativePolicy = config.GeneratedColumnPolicySkip
+	}
+	if policy := cfg.Sink.GetGeneratedColumnPolicy(schema); policy != nativePolicy {
+		s.generatedColumnPolicy = policy
+	}
 	switch schema {
 	case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
 		txnSink, err := txn.NewMySQLSink(ctx, changefeedID, sinkURI, cfg, errCh,
@@ -81,13 +124,45 @@ func New(
 			return nil, err
 		}
 		s.rowSink = mqs
+	case sink.NatsScheme:
+		mqs, err := mq.NewNatsDMLSink(ctx, changefeedID, sinkURI, cfg, errCh,
+			nats.NewJetStreamClient)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = mqs
+	case sink.RabbitMQScheme, sink.RabbitMQSSLScheme:
+		mqs, err := mq.NewRabbitMQDMLSink(ctx, changefeedID, sinkURI, cfg, errCh,
+			rabbitmq.NewAMQPClient)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = mqs
+	case sink.MQTTScheme, sink.MQTTSSLScheme:
+		mqs, err := mq.NewMQTTDMLSink(ctx, changefeedID, sinkURI, cfg, errCh,
+			mqtt.NewPahoClient)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = mqs
 	case sink.S3Scheme, sink.FileScheme, sink.GCSScheme, sink.GSScheme, sink.AzblobScheme, sink.AzureScheme, sink.CloudStorageNoopScheme:
 		storageSink, err := cloudstorage.NewDMLSink(ctx, changefeedID, sinkURI, cfg, errCh)
 		if err != nil {
 			return nil, err
 		}
 		s.txnSink = storageSink
+	case sink.GRPCScheme:
+		hub, err := subscription.NewHub(changefeedID, sinkURI, subscription.GetGlobalRegistry())
+		if err != nil {
+			return nil, err
+		}
+		s.subscriptionHub = hub
+		s.rowSink = hub
 	case sink.BlackHoleScheme:
+		s.verify, err = verification.IsEnabled(sinkURI)
+		if err != nil {
+			return nil, err
+		}
 		bs := blackhole.NewDMLSink()
 		s.rowSink = bs
 	default:
@@ -95,22 +170,68 @@ func New(
 			cerror.ErrSinkURIInvalid.GenWithStack("the sink scheme (%s) is not supported", schema)
 	}
 
+	teeCfg, err := cfg.TeeSinkReplicaConfig()
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	if teeCfg != nil {
+		s.teeSink, err = New(ctx, changefeedID, *cfg.Sink.TeeSinkURI, teeCfg, errCh)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
 	return s, nil
 }
 
-// CreateTableSink creates a TableSink by schema.
+// CreateTableSink creates a TableSink by schema. If the tee sink is
+// configured, the TableSink writes events to both sinks. If the event
+// sampling is enabled, the events are reported to the exporter.
+//
+// e2eLatencyHistogram can be nil if the end-to-end latency is not recorded,
+// the latency of the tee sink is never recorded.
 func (s *SinkFactory) CreateTableSink(
 	changefeedID model.ChangeFeedID,
 	span tablepb.Span, startTs model.Ts,
 	totalRowsCounter prometheus.Counter,
+	e2eLatencyHistogram prometheus.Observer,
 ) tablesink.TableSink {
-	if s.txnSink != nil {
-		return tablesink.New(changefeedID, span, startTs, s.txnSink,
-			&dmlsink.TxnEventAppender{TableSinkStartTs: startTs}, totalRowsCounter)
+	tableSink := s.createTableSink(changefeedID, span, startTs, totalRowsCounter, e2eLatencyHistogram)
+	if s.teeSink != nil {
+		tableSink = tablesink.NewTeeTableSink(tableSink,
+			s.teeSink.createTableSink(changefeedID, span, startTs, teeTotalRowsCounter, nil))
 	}
+	return eventsampling.NewTableSink(tableSink, changefeedID, eventsampling.GetGlobalExporter())
+}
 
-	return tablesink.New(changefeedID, span, startTs, s.rowSink,
-		&dmlsink.RowChangeEventAppender{}, totalRowsCounter)
+func (s *SinkFactory) createTableSink(
+	changefeedID model.ChangeFeedID,
+	span tablepb.Span, startTs model.Ts,
+	totalRowsCounter prometheus.Counter,
+	e2eLatencyHistogram prometheus.Observer,
+) tablesink.TableSink {
+	var tableSink tablesink.TableSink
+	if s.txnSink != nil {
+		tableSink = tablesink.New(changefeedID, span, startTs, s.txnSink,
+			&dmlsink.TxnEventAppender{TableSinkStartTs: startTs}, totalRowsCounter, e2eLatencyHistogram)
+	} else {
+		tableSink = tablesink.New(changefeedID, span, startTs, s.rowSink,
+			&dmlsink.RowChangeEventAppender{}, totalRowsCounter, e2eLatencyHistogram)
+		if s.verify {
+			tableSink = verification.NewTableSink(tableSink, span.String(), startTs,
+				verification.NewReporter(changefeedID))
+		} else if s.subscriptionHub != nil {
+			tableSink = subscription.NewTableSink(tableSink, s.subscriptionHub, startTs)
+		}
+	}
+	if s.generatedColumnPolicy != "" {
+		tableSink = generatedcolumn.NewTableSink(tableSink, s.generatedColumnPolicy)
+	}
+	if s.router != nil {
+		tableSink = routing.NewTableSink(tableSink, s.router)
+	}
+	return tableSink
 }
 
 // CreateTableSinkForConsumer creates a TableSink by schema for consumer.
@@ -127,11 +248,11 @@ func (s *SinkFactory) CreateTableSinkForConsumer(
 			// IgnoreStartTs is true because the consumer can
 			// **not** get the start ts of the row changed event.
 			&dmlsink.TxnEventAppender{TableSinkStartTs: startTs, IgnoreStartTs: true},
-			totalRowsCounter)
+			totalRowsCounter, nil)
 	}
 
 	return tablesink.New(changefeedID, span, startTs, s.rowSink,
-		&dmlsink.RowChangeEventAppender{}, totalRowsCounter)
+		&dmlsink.RowChangeEventAppender{}, totalRowsCounter, nil)
 }
 
 // Close closes the sink.
@@ -145,4 +266,7 @@ func (s *SinkFactory) Close() {
 	if s.txnSink != nil {
 		s.txnSink.Close()
 	}
+	if s.teeSink != nil {
+		s.teeSink.Close()
+	}
 }
@@ -24,10 +24,13 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/pingcap/tiflow/cdc/sink/verification"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 )
@@ -105,8 +108,60 @@ func TestSinkFactory(t *testing.T) {
 	require.NotNil(t, sinkFactory.rowSink)
 
 	tableSink := sinkFactory.CreateTableSink(model.DefaultChangeFeedID("1"),
-		spanz.TableIDToComparableSpan(1), 0, prometheus.NewCounter(prometheus.CounterOpts{}))
+		spanz.TableIDToComparableSpan(1), 0, prometheus.NewCounter(prometheus.CounterOpts{}), nil)
 	require.NotNil(t, tableSink, "table sink can be created")
 
 	sinkFactory.Close()
 }
+
+func TestSinkFactoryWithTeeSink(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("blackhole://")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.TeeSinkURI = util.AddressOf(
+		fmt.Sprintf("file://%s?protocol=csv", t.TempDir()))
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	sinkFactory, err := New(ctx, model.DefaultChangeFeedID("test"),
+		sinkURI.String(), replicaConfig, make(chan error, 1))
+	require.Nil(t, err)
+	require.NotNil(t, sinkFactory.rowSink)
+	require.NotNil(t, sinkFactory.teeSink)
+	require.NotNil(t, sinkFactory.teeSink.txnSink)
+
+	tableSink := sinkFactory.CreateTableSink(model.DefaultChangeFeedID("test"),
+		spanz.TableIDToComparableSpan(1), 0, prometheus.NewCounter(prometheus.CounterOpts{}), nil)
+	require.IsType(t, &tablesink.TeeTableSink{}, tableSink)
+
+	sinkFactory.Close()
+}
+
+func TestSinkFactoryWithVerification(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("blackhole://?verify=true")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	sinkFactory, err := New(ctx, model.DefaultChangeFeedID("test"),
+		sinkURI.String(), replicaConfig, make(chan error, 1))
+	require.Nil(t, err)
+	require.True(t, sinkFactory.verify)
+	tableSink := sinkFactory.CreateTableSink(model.DefaultChangeFeedID("test"),
+		spanz.TableIDToComparableSpan(1), 0, prometheus.NewCounter(prometheus.CounterOpts{}), nil)
+	require.IsType(t, &verification.TableSink{}, tableSink)
+	sinkFactory.Close()
+
+	_, err = New(ctx, model.DefaultChangeFeedID("test"),
+		"blackhole://?verify=xxx", replicaConfig, make(chan error, 1))
+	require.Regexp(t, ".*ErrSinkURIInvalid.*", err)
+}
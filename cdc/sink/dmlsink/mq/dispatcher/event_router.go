@@ -14,6 +14,7 @@
 package dispatcher
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/pingcap/log"
@@ -23,6 +24,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher/topic"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
@@ -45,6 +47,7 @@ const (
 	partitionDispatchRuleTS
 	partitionDispatchRuleTable
 	partitionDispatchRuleIndexValue
+	partitionDispatchRuleColumns
 )
 
 func (r *partitionDispatchRule) fromString(rule string) {
@@ -58,11 +61,14 @@ func (r *partitionDispatchRule) fromString(rule string) {
 	case "rowid":
 		*r = partitionDispatchRuleIndexValue
 		log.Warn("rowid is deprecated, please use index-value instead.")
-	case "index-value":
+	case "index-value", "table+index":
 		*r = partitionDispatchRuleIndexValue
+	case "columns":
+		*r = partitionDispatchRuleColumns
 	default:
 		*r = partitionDispatchRuleDefault
-		log.Warn("the partition dispatch rule is not default/ts/table/index-value," +
+		log.Warn("the partition dispatch rule is not " +
+			"default/ts/table/index-value/table+index/columns," +
 			" use the default rule instead.")
 	}
 }
@@ -152,7 +158,7 @@ func (s *EventRouter) GetTopicForDDL(ddl *model.DDLEvent) string {
 func (s *EventRouter) GetPartitionForRowChange(
 	row *model.RowChangedEvent,
 	partitionNum int32,
-) int32 {
+) (int32, error) {
 	_, partitionDispatcher := s.matchDispatcher(
 		row.Table.Schema, row.Table.Table,
 	)
@@ -162,6 +168,44 @@ func (s *EventRouter) GetPartitionForRowChange(
 	)
 }
 
+// VerifyTables checks whether all the tables can be dispatched, it returns
+// an error with the names of the tables rejected by the partition dispatchers.
+func (s *EventRouter) VerifyTables(tableInfos []*model.TableInfo) error {
+	var rejected []string
+	for _, tableInfo := range tableInfos {
+		_, partitionDispatcher := s.matchDispatcher(
+			tableInfo.TableName.Schema, tableInfo.TableName.Table,
+		)
+		d, ok := partitionDispatcher.(*partition.IndexValueDispatcher)
+		if ok && d.RejectTable(tableInfo) {
+			rejected = append(rejected, tableInfo.TableName.String())
+		}
+	}
+	if len(rejected) != 0 {
+		return cerror.ErrTableWithoutDispatchKey.GenWithStackByArgs(rejected)
+	}
+	return nil
+}
+
+// VerifyTables checks whether all the tables of a changefeed can be
+// dispatched by its dispatch rules, it's a no-op for non-MQ sinks.
+func VerifyTables(
+	cfg *config.ReplicaConfig, sinkURI string, tableInfos []*model.TableInfo,
+) error {
+	sinkURIParsed, err := url.Parse(sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !sink.IsMQScheme(strings.ToLower(sinkURIParsed.Scheme)) {
+		return nil
+	}
+	router, err := NewEventRouter(cfg, "")
+	if err != nil {
+		return err
+	}
+	return router.VerifyTables(tableInfos)
+}
+
 // GetDLLDispatchRuleByProtocol returns the DDL
 // distribution rule according to the protocol.
 func (s *EventRouter) GetDLLDispatchRuleByProtocol(
@@ -238,13 +282,16 @@ func getPartitionDispatcher(
 				"does not guarantee row-level orderliness when " +
 				"switching on the old value, so please use caution!")
 		}
-		d = partition.NewIndexValueDispatcher()
+		d = partition.NewIndexValueDispatcher(
+			ruleConfig.IndexName, ruleConfig.HashFunction, ruleConfig.NoKeyStrategy)
+	case partitionDispatchRuleColumns:
+		d = partition.NewColumnsDispatcher(ruleConfig.Columns, ruleConfig.HashFunction)
 	case partitionDispatchRuleTS:
 		d = partition.NewTsDispatcher()
 	case partitionDispatchRuleTable:
-		d = partition.NewTableDispatcher()
+		d = partition.NewTableDispatcher(ruleConfig.HashFunction)
 	case partitionDispatchRuleDefault:
-		d = partition.NewDefaultDispatcher(enableOldValue)
+		d = partition.NewDefaultDispatcher(enableOldValue, ruleConfig.HashFunction)
 	}
 
 	return d
@@ -17,6 +17,8 @@ import (
 	"testing"
 
 	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	parser_types "github.com/pingcap/tidb/parser/types"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher/partition"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher/topic"
@@ -55,6 +57,18 @@ func TestEventRouter(t *testing.T) {
 					PartitionRule: "index-value",
 					TopicRule:     "{schema}_world",
 				},
+				{
+					Matcher:       []string{"test_table_index.*"},
+					PartitionRule: "table+index",
+					IndexName:     "idx_a",
+					HashFunction:  "murmur2",
+				},
+				{
+					Matcher:       []string{"test_columns.*"},
+					PartitionRule: "columns",
+					Columns:       []string{"a", "b"},
+					HashFunction:  "xxhash",
+				},
 				{
 					Matcher:       []string{"test.*"},
 					PartitionRule: "rowid",
@@ -69,6 +83,11 @@ func TestEventRouter(t *testing.T) {
 		},
 	}, "")
 	require.Nil(t, err)
+	_, partitionDispatcher = d.matchDispatcher("test_table_index", "test")
+	require.IsType(t, &partition.IndexValueDispatcher{}, partitionDispatcher)
+	_, partitionDispatcher = d.matchDispatcher("test_columns", "test")
+	require.IsType(t, &partition.ColumnsDispatcher{}, partitionDispatcher)
+
 	topicDispatcher, partitionDispatcher = d.matchDispatcher("test", "table1")
 	require.IsType(t, &topic.DynamicTopicDispatcher{}, topicDispatcher)
 	require.IsType(t, &partition.IndexValueDispatcher{}, partitionDispatcher)
@@ -248,7 +267,7 @@ func TestGetPartitionForRowChange(t *testing.T) {
 	}, "test")
 	require.Nil(t, err)
 
-	p := d.GetPartitionForRowChange(&model.RowChangedEvent{
+	p, err := d.GetPartitionForRowChange(&model.RowChangedEvent{
 		Table: &model.TableName{Schema: "test_default1", Table: "table"},
 		Columns: []*model.Column{
 			{
@@ -259,8 +278,9 @@ func TestGetPartitionForRowChange(t *testing.T) {
 		},
 		IndexColumns: [][]int{{0}},
 	}, 16)
+	require.Nil(t, err)
 	require.Equal(t, int32(10), p)
-	p = d.GetPartitionForRowChange(&model.RowChangedEvent{
+	p, err = d.GetPartitionForRowChange(&model.RowChangedEvent{
 		Table: &model.TableName{Schema: "test_default2", Table: "table"},
 		Columns: []*model.Column{
 			{
@@ -271,14 +291,16 @@ func TestGetPartitionForRowChange(t *testing.T) {
 		},
 		IndexColumns: [][]int{{0}},
 	}, 16)
+	require.Nil(t, err)
 	require.Equal(t, int32(4), p)
 
-	p = d.GetPartitionForRowChange(&model.RowChangedEvent{
+	p, err = d.GetPartitionForRowChange(&model.RowChangedEvent{
 		Table:    &model.TableName{Schema: "test_table", Table: "table"},
 		CommitTs: 1,
 	}, 16)
+	require.Nil(t, err)
 	require.Equal(t, int32(15), p)
-	p = d.GetPartitionForRowChange(&model.RowChangedEvent{
+	p, err = d.GetPartitionForRowChange(&model.RowChangedEvent{
 		Table: &model.TableName{Schema: "test_index_value", Table: "table"},
 		Columns: []*model.Column{
 			{
@@ -292,11 +314,13 @@ func TestGetPartitionForRowChange(t *testing.T) {
 			},
 		},
 	}, 10)
+	require.Nil(t, err)
 	require.Equal(t, int32(1), p)
-	p = d.GetPartitionForRowChange(&model.RowChangedEvent{
+	p, err = d.GetPartitionForRowChange(&model.RowChangedEvent{
 		Table:    &model.TableName{Schema: "a", Table: "table"},
 		CommitTs: 1,
 	}, 2)
+	require.Nil(t, err)
 	require.Equal(t, int32(1), p)
 }
 
@@ -487,3 +511,52 @@ func TestGetTopicForDDL(t *testing.T) {
 		require.Equal(t, test.expectedTopic, d.GetTopicForDDL(test.ddl))
 	}
 }
+
+func TestVerifyTables(t *testing.T) {
+	t.Parallel()
+
+	newTableInfo := func(schema, table string, withPK bool) *model.TableInfo {
+		ft := parser_types.NewFieldType(mysql.TypeLong)
+		if withPK {
+			ft.SetFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+		}
+		return model.WrapTableInfo(1, schema, 0, &timodel.TableInfo{
+			Name:       timodel.NewCIStr(table),
+			PKIsHandle: withPK,
+			Columns: []*timodel.ColumnInfo{{
+				ID:        1,
+				Name:      timodel.NewCIStr("a"),
+				FieldType: *ft,
+				State:     timodel.StatePublic,
+			}},
+		})
+	}
+	tableInfos := []*model.TableInfo{
+		newTableInfo("test", "t1", true),
+		newTableInfo("test", "t2", false),
+		newTableInfo("test", "t3", false),
+		newTableInfo("other", "t1", false),
+	}
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:       []string{"test.t3"},
+			PartitionRule: "table",
+		},
+		{
+			Matcher:       []string{"test.*"},
+			PartitionRule: "index-value",
+			NoKeyStrategy: partition.NoKeyStrategyReject,
+		},
+	}
+	err := VerifyTables(cfg, "kafka://127.0.0.1:9092/test", tableInfos)
+	require.Regexp(t, "ErrTableWithoutDispatchKey", err)
+	require.Regexp(t, `\[test.t2\]`, err)
+
+	// The tables are not verified for non-MQ sinks.
+	require.Nil(t, VerifyTables(cfg, "blackhole://", tableInfos))
+
+	cfg.Sink.DispatchRules[1].NoKeyStrategy = partition.NoKeyStrategyRouteByAllColumns
+	require.Nil(t, VerifyTables(cfg, "kafka://127.0.0.1:9092/test", tableInfos))
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"strings"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// ColumnsDispatcher is a partition dispatcher which dispatches events based
+// on the values of the specified columns, i.e. a composite key.
+type ColumnsDispatcher struct {
+	columns []string
+	hasher  keyHasher
+}
+
+// NewColumnsDispatcher creates a ColumnsDispatcher.
+func NewColumnsDispatcher(columns []string, hashFunction string) *ColumnsDispatcher {
+	return &ColumnsDispatcher{
+		columns: columns,
+		hasher:  newKeyHasher(hashFunction),
+	}
+}
+
+// DispatchRowChangedEvent returns the target partition to which
+// a row changed event should be dispatched. The event is dispatched by its
+// table if any of the columns doesn't exist in the table.
+func (d *ColumnsDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) (int32, error) {
+	key := newTableKey(row)
+	dispatchCols := row.Columns
+	if len(row.Columns) == 0 {
+		dispatchCols = row.PreColumns
+	}
+	if cols, ok := findColumns(dispatchCols, d.columns); ok {
+		for _, col := range cols {
+			key.addColumn(col)
+		}
+	}
+	return d.hasher.partition(key, partitionNum), nil
+}
+
+// findColumns returns the columns with the names in order, names are case
+// insensitive. It returns false if any of the columns is not found.
+func findColumns(cols []*model.Column, names []string) ([]*model.Column, bool) {
+	res := make([]*model.Column, 0, len(names))
+	for _, name := range names {
+		var found *model.Column
+		for _, col := range cols {
+			if col != nil && strings.EqualFold(col.Name, name) {
+				found = col
+				break
+			}
+		}
+		if found == nil {
+			return nil, false
+		}
+		res = append(res, found)
+	}
+	return res, true
+}
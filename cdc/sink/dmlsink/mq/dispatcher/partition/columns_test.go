@@ -0,0 +1,58 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnsDispatcher(t *testing.T) {
+	t.Parallel()
+
+	newRow := func(a, b, c int) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			Table: &model.TableName{Schema: "test", Table: "t1"},
+			Columns: []*model.Column{
+				{Name: "a", Value: a, Flag: model.HandleKeyFlag},
+				{Name: "b", Value: b},
+				{Name: "c", Value: c},
+			},
+		}
+	}
+
+	dispatch := func(d Dispatcher, row *model.RowChangedEvent) int32 {
+		index, err := d.DispatchRowChangedEvent(row, 16)
+		require.Nil(t, err)
+		return index
+	}
+
+	p := NewColumnsDispatcher([]string{"B", "c"}, HashFunctionMurmur2)
+	// The partition is decided by the values of the columns.
+	require.Equal(t, int32(murmur2([]byte("2,3"))&0x7fffffff)%16,
+		dispatch(p, newRow(1, 2, 3)))
+	require.Equal(t, dispatch(p, newRow(1, 2, 3)), dispatch(p, newRow(4, 2, 3)))
+
+	// The pre columns are used for delete events.
+	row := newRow(1, 2, 3)
+	row.PreColumns, row.Columns = row.Columns, nil
+	require.Equal(t, dispatch(p, newRow(1, 2, 3)), dispatch(p, row))
+
+	// The event is dispatched by its table if a column doesn't exist.
+	p = NewColumnsDispatcher([]string{"b", "d"}, HashFunctionDefault)
+	require.Equal(t, dispatch(NewTableDispatcher(HashFunctionDefault), newRow(1, 2, 3)),
+		dispatch(p, newRow(1, 2, 3)))
+}
@@ -25,17 +25,17 @@ type DefaultDispatcher struct {
 }
 
 // NewDefaultDispatcher creates a DefaultDispatcher.
-func NewDefaultDispatcher(enableOldValue bool) *DefaultDispatcher {
+func NewDefaultDispatcher(enableOldValue bool, hashFunction string) *DefaultDispatcher {
 	return &DefaultDispatcher{
-		tbd:            NewTableDispatcher(),
-		ivd:            NewIndexValueDispatcher(),
+		tbd:            NewTableDispatcher(hashFunction),
+		ivd:            NewIndexValueDispatcher("", hashFunction, NoKeyStrategyRouteByTable),
 		enableOldValue: enableOldValue,
 	}
 }
 
 // DispatchRowChangedEvent returns the target partition to which
 // a row changed event should be dispatched.
-func (d *DefaultDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) int32 {
+func (d *DefaultDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) (int32, error) {
 	if d.enableOldValue {
 		return d.tbd.DispatchRowChangedEvent(row, partitionNum)
 	}
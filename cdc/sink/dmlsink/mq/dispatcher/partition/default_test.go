@@ -193,9 +193,11 @@ func TestDefaultDispatcher(t *testing.T) {
 			IndexColumns: [][]int{{0}, {1}},
 		}, expectPartition: 3},
 	}
-	p := NewDefaultDispatcher(false)
+	p := NewDefaultDispatcher(false, "")
 	for _, tc := range testCases {
-		require.Equal(t, tc.expectPartition, p.DispatchRowChangedEvent(tc.row, 16))
+		index, err := p.DispatchRowChangedEvent(tc.row, 16)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectPartition, index)
 	}
 }
 
@@ -221,6 +223,8 @@ func TestDefaultDispatcherWithOldValue(t *testing.T) {
 		IndexColumns: [][]int{{0}, {1}},
 	}
 
-	p := NewDefaultDispatcher(true)
-	require.Equal(t, int32(3), p.DispatchRowChangedEvent(row, 16))
+	p := NewDefaultDispatcher(true, "")
+	index, err := p.DispatchRowChangedEvent(row, 16)
+	require.Nil(t, err)
+	require.Equal(t, int32(3), index)
 }
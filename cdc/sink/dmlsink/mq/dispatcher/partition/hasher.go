@@ -0,0 +1,146 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/hash"
+)
+
+const (
+	// HashFunctionDefault hashes the table name and the names and values of
+	// the key columns with crc32.
+	HashFunctionDefault = "default"
+	// HashFunctionMurmur2 is the hash function of the default partitioner of
+	// the Java Kafka client.
+	HashFunctionMurmur2 = "murmur2"
+	// HashFunctionXXHash is the 64-bits xxhash.
+	HashFunctionXXHash = "xxhash"
+)
+
+// partitionKey is the key of a row to compute its partition.
+type partitionKey struct {
+	schema string
+	table  string
+	// names and values of the key columns, they are empty if the row is
+	// dispatched by its table.
+	names  []string
+	values []string
+}
+
+func newTableKey(row *model.RowChangedEvent) *partitionKey {
+	return &partitionKey{schema: row.Table.Schema, table: row.Table.Table}
+}
+
+func (k *partitionKey) addColumn(col *model.Column) {
+	k.names = append(k.names, col.Name)
+	k.values = append(k.values, model.ColumnValueString(col.Value))
+}
+
+// bytes returns the key as the message key of a Kafka client, i.e. the
+// values of the key columns joined by commas, or the table name if
+// there are no key columns.
+func (k *partitionKey) bytes() []byte {
+	if len(k.values) == 0 {
+		return []byte(k.schema + "." + k.table)
+	}
+	return []byte(strings.Join(k.values, ","))
+}
+
+// keyHasher computes partitions from partition keys.
+type keyHasher interface {
+	partition(key *partitionKey, partitionNum int32) int32
+}
+
+// newKeyHasher returns the hasher of the hash function, the default one is
+// returned for unknown hash functions.
+func newKeyHasher(hashFunction string) keyHasher {
+	switch strings.ToLower(hashFunction) {
+	case HashFunctionMurmur2:
+		return murmur2Hasher{}
+	case HashFunctionXXHash:
+		return xxhashHasher{}
+	default:
+		return &defaultHasher{hasher: hash.NewPositionInertia()}
+	}
+}
+
+type defaultHasher struct {
+	hasher *hash.PositionInertia
+	lock   sync.Mutex
+}
+
+func (h *defaultHasher) partition(key *partitionKey, partitionNum int32) int32 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.hasher.Reset()
+	h.hasher.Write([]byte(key.schema), []byte(key.table))
+	for i := range key.names {
+		h.hasher.Write([]byte(key.names[i]), []byte(key.values[i]))
+	}
+	return int32(h.hasher.Sum32() % uint32(partitionNum))
+}
+
+type murmur2Hasher struct{}
+
+func (murmur2Hasher) partition(key *partitionKey, partitionNum int32) int32 {
+	// It's the same as `toPositive(murmur2(key)) % numPartitions` in Java.
+	return int32(murmur2(key.bytes())&0x7fffffff) % partitionNum
+}
+
+// murmur2 is the murmur2 hash of the Java Kafka client.
+func murmur2(data []byte) uint32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+	length := len(data)
+	h := seed ^ uint32(length)
+	for i := 0; i+4 <= length; i += 4 {
+		k := uint32(data[i]) | uint32(data[i+1])<<8 |
+			uint32(data[i+2])<<16 | uint32(data[i+3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+	tail := data[length&^3:]
+	switch len(tail) {
+	case 3:
+		h ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(tail[0])
+		h *= m
+	}
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
+
+type xxhashHasher struct{}
+
+func (xxhashHasher) partition(key *partitionKey, partitionNum int32) int32 {
+	return int32(xxhash.Sum64(key.bytes()) % uint64(partitionNum))
+}
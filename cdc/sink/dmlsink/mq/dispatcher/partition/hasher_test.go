@@ -0,0 +1,71 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"testing"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMurmur2(t *testing.T) {
+	t.Parallel()
+
+	// The cases are the same as the ones of the Java Kafka client.
+	testCases := []struct {
+		data     string
+		expected int32
+	}{
+		{"21", -973932308},
+		{"foobar", -790332482},
+		{"a-little-bit-long-string", -985981536},
+		{"a-little-bit-longer-string", -1486304829},
+		{"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8", -58897971},
+		{"abc", 479470107},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, int32(murmur2([]byte(tc.data))), tc.data)
+	}
+}
+
+func TestKeyHasher(t *testing.T) {
+	t.Parallel()
+
+	row := &model.RowChangedEvent{
+		Table: &model.TableName{Schema: "test", Table: "t1"},
+	}
+	tableKey := newTableKey(row)
+	columnsKey := newTableKey(row)
+	columnsKey.addColumn(&model.Column{Name: "a", Value: 1})
+	columnsKey.addColumn(&model.Column{Name: "b", Value: []byte("x")})
+	require.Equal(t, []byte("test.t1"), tableKey.bytes())
+	require.Equal(t, []byte("1,x"), columnsKey.bytes())
+
+	hasher := newKeyHasher("MURMUR2")
+	require.Equal(t, int32(murmur2([]byte("1,x"))&0x7fffffff)%16,
+		hasher.partition(columnsKey, 16))
+	hasher = newKeyHasher("xxhash")
+	require.Equal(t, int32(xxhash.Sum64([]byte("test.t1"))%16),
+		hasher.partition(tableKey, 16))
+
+	// The default hasher is used for unknown hash functions.
+	require.IsType(t, &defaultHasher{}, newKeyHasher(""))
+	require.IsType(t, &defaultHasher{}, newKeyHasher("unknown"))
+	for _, fn := range []string{HashFunctionDefault, HashFunctionMurmur2, HashFunctionXXHash} {
+		p := newKeyHasher(fn).partition(columnsKey, 16)
+		require.True(t, p >= 0 && p < 16)
+	}
+}
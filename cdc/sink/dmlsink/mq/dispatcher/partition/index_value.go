@@ -14,32 +14,47 @@
 package partition
 
 import (
-	"sync"
+	"strings"
 
 	"github.com/pingcap/tiflow/cdc/model"
-	"github.com/pingcap/tiflow/pkg/hash"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// NoKeyStrategyRouteByTable dispatches events of the tables without keys
+	// by their table names.
+	NoKeyStrategyRouteByTable = "route-by-table"
+	// NoKeyStrategyRouteByAllColumns dispatches events of the tables without
+	// keys by the values of all their columns.
+	NoKeyStrategyRouteByAllColumns = "route-by-all-columns"
+	// NoKeyStrategyReject rejects the tables without keys.
+	NoKeyStrategyReject = "reject"
 )
 
 // IndexValueDispatcher is a partition dispatcher which dispatches events based on the index value.
 type IndexValueDispatcher struct {
-	hasher *hash.PositionInertia
-	lock   sync.Mutex
+	// indexName is the name of the index, the handle key is used if it's
+	// empty or the index doesn't exist in the table.
+	indexName string
+	// noKeyStrategy decides how to dispatch events of the tables having
+	// neither the index nor a handle key.
+	noKeyStrategy string
+	hasher        keyHasher
 }
 
 // NewIndexValueDispatcher creates a IndexValueDispatcher.
-func NewIndexValueDispatcher() *IndexValueDispatcher {
+func NewIndexValueDispatcher(indexName, hashFunction, noKeyStrategy string) *IndexValueDispatcher {
 	return &IndexValueDispatcher{
-		hasher: hash.NewPositionInertia(),
+		indexName:     indexName,
+		noKeyStrategy: strings.ToLower(noKeyStrategy),
+		hasher:        newKeyHasher(hashFunction),
 	}
 }
 
 // DispatchRowChangedEvent returns the target partition to which
 // a row changed event should be dispatched.
-func (r *IndexValueDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) int32 {
-	r.lock.Lock()
-	defer r.lock.Unlock()
-	r.hasher.Reset()
-	r.hasher.Write([]byte(row.Table.Schema), []byte(row.Table.Table))
+func (r *IndexValueDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) (int32, error) {
+	key := newTableKey(row)
 	// FIXME(leoppro): if the row events includes both pre-cols and cols
 	// the dispatch logic here is wrong
 
@@ -48,13 +63,67 @@ func (r *IndexValueDispatcher) DispatchRowChangedEvent(row *model.RowChangedEven
 	if len(row.Columns) == 0 {
 		dispatchCols = row.PreColumns
 	}
+	if names := r.indexColumnNames(row.TableInfo); len(names) != 0 {
+		if cols, ok := findColumns(dispatchCols, names); ok {
+			for _, col := range cols {
+				key.addColumn(col)
+			}
+			return r.hasher.partition(key, partitionNum), nil
+		}
+	}
 	for _, col := range dispatchCols {
 		if col == nil {
 			continue
 		}
 		if col.Flag.IsHandleKey() {
-			r.hasher.Write([]byte(col.Name), []byte(model.ColumnValueString(col.Value)))
+			key.addColumn(col)
+		}
+	}
+	if len(key.names) != 0 {
+		return r.hasher.partition(key, partitionNum), nil
+	}
+
+	switch r.noKeyStrategy {
+	case NoKeyStrategyRouteByAllColumns:
+		for _, col := range dispatchCols {
+			if col != nil {
+				key.addColumn(col)
+			}
+		}
+	case NoKeyStrategyReject:
+		return 0, cerror.ErrTableWithoutDispatchKey.GenWithStackByArgs(
+			[]string{row.Table.String()})
+	}
+	return r.hasher.partition(key, partitionNum), nil
+}
+
+// RejectTable returns whether the table is rejected by the dispatcher, that
+// is, the no-key strategy is reject and the table has neither the index nor
+// a primary key or a not null unique key.
+func (r *IndexValueDispatcher) RejectTable(tableInfo *model.TableInfo) bool {
+	if r.noKeyStrategy != NoKeyStrategyReject || tableInfo.IsView() {
+		return false
+	}
+	if len(r.indexColumnNames(tableInfo)) != 0 {
+		return false
+	}
+	return !tableInfo.ExistTableUniqueColumn()
+}
+
+// indexColumnNames returns the column names of the index.
+func (r *IndexValueDispatcher) indexColumnNames(tableInfo *model.TableInfo) []string {
+	if r.indexName == "" || tableInfo == nil || tableInfo.TableInfo == nil {
+		return nil
+	}
+	for _, index := range tableInfo.Indices {
+		if index.Name.L != strings.ToLower(r.indexName) {
+			continue
+		}
+		names := make([]string, 0, len(index.Columns))
+		for _, col := range index.Columns {
+			names = append(names, col.Name.O)
 		}
+		return names
 	}
-	return int32(r.hasher.Sum32() % uint32(partitionNum))
+	return nil
 }
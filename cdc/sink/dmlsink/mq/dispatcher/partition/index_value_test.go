@@ -16,6 +16,7 @@ package partition
 import (
 	"testing"
 
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
 )
@@ -147,8 +148,83 @@ func TestIndexValueDispatcher(t *testing.T) {
 			},
 		}, expectPartition: 2},
 	}
-	p := NewIndexValueDispatcher()
+	p := NewIndexValueDispatcher("", "", "")
 	for _, tc := range testCases {
-		require.Equal(t, tc.expectPartition, p.DispatchRowChangedEvent(tc.row, 16))
+		index, err := p.DispatchRowChangedEvent(tc.row, 16)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectPartition, index)
 	}
 }
+
+func TestIndexValueDispatcherWithIndexName(t *testing.T) {
+	t.Parallel()
+
+	tableInfo := &model.TableInfo{TableInfo: &timodel.TableInfo{
+		Indices: []*timodel.IndexInfo{{
+			Name: timodel.NewCIStr("idx_b"),
+			Columns: []*timodel.IndexColumn{
+				{Name: timodel.NewCIStr("b")},
+			},
+		}},
+	}}
+	newRow := func(a, b int) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			Table:     &model.TableName{Schema: "test", Table: "t1"},
+			TableInfo: tableInfo,
+			Columns: []*model.Column{
+				{Name: "a", Value: a, Flag: model.HandleKeyFlag},
+				{Name: "b", Value: b},
+			},
+		}
+	}
+
+	// The partition is decided by the values of the index.
+	p := NewIndexValueDispatcher("IDX_B", HashFunctionMurmur2, "")
+	index, err := p.DispatchRowChangedEvent(newRow(1, 2), 16)
+	require.Nil(t, err)
+	require.Equal(t, int32(murmur2([]byte("2"))&0x7fffffff)%16, index)
+
+	// The handle key is used if the index doesn't exist.
+	p = NewIndexValueDispatcher("idx_c", HashFunctionMurmur2, "")
+	index, err = p.DispatchRowChangedEvent(newRow(1, 2), 16)
+	require.Nil(t, err)
+	require.Equal(t, int32(murmur2([]byte("1"))&0x7fffffff)%16, index)
+}
+
+func TestIndexValueDispatcherNoKeyStrategy(t *testing.T) {
+	t.Parallel()
+
+	row := &model.RowChangedEvent{
+		Table: &model.TableName{Schema: "test", Table: "t1"},
+		Columns: []*model.Column{
+			{Name: "a", Value: 1},
+			{Name: "b", Value: 2},
+		},
+	}
+	tableIndex, err := NewTableDispatcher(HashFunctionMurmur2).DispatchRowChangedEvent(row, 16)
+	require.Nil(t, err)
+
+	// The event is dispatched by its table by default.
+	for _, strategy := range []string{"", NoKeyStrategyRouteByTable} {
+		p := NewIndexValueDispatcher("", HashFunctionMurmur2, strategy)
+		index, err := p.DispatchRowChangedEvent(row, 16)
+		require.Nil(t, err)
+		require.Equal(t, tableIndex, index)
+	}
+
+	p := NewIndexValueDispatcher("", HashFunctionMurmur2, NoKeyStrategyRouteByAllColumns)
+	index, err := p.DispatchRowChangedEvent(row, 16)
+	require.Nil(t, err)
+	require.Equal(t, int32(murmur2([]byte("1,2"))&0x7fffffff)%16, index)
+
+	p = NewIndexValueDispatcher("", HashFunctionMurmur2, "REJECT")
+	_, err = p.DispatchRowChangedEvent(row, 16)
+	require.Regexp(t, "ErrTableWithoutDispatchKey", err)
+	require.Regexp(t, "test.t1", err)
+
+	// The strategy doesn't take effect if the table has a handle key.
+	row.Columns[0].Flag = model.HandleKeyFlag
+	index, err = p.DispatchRowChangedEvent(row, 16)
+	require.Nil(t, err)
+	require.Equal(t, int32(murmur2([]byte("1"))&0x7fffffff)%16, index)
+}
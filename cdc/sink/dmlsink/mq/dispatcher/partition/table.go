@@ -14,33 +14,25 @@
 package partition
 
 import (
-	"sync"
-
 	"github.com/pingcap/tiflow/cdc/model"
-	"github.com/pingcap/tiflow/pkg/hash"
 )
 
 // TableDispatcher is a partition dispatcher which dispatches events
 // based on the schema and table name.
 type TableDispatcher struct {
-	hasher *hash.PositionInertia
-	lock   sync.Mutex
+	hasher keyHasher
 }
 
 // NewTableDispatcher creates a TableDispatcher.
-func NewTableDispatcher() *TableDispatcher {
+func NewTableDispatcher(hashFunction string) *TableDispatcher {
 	return &TableDispatcher{
-		hasher: hash.NewPositionInertia(),
+		hasher: newKeyHasher(hashFunction),
 	}
 }
 
 // DispatchRowChangedEvent returns the target partition to which
 // a row changed event should be dispatched.
-func (t *TableDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) int32 {
-	t.lock.Lock()
-	defer t.lock.Unlock()
-	t.hasher.Reset()
+func (t *TableDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) (int32, error) {
 	// distribute partition by table
-	t.hasher.Write([]byte(row.Table.Schema), []byte(row.Table.Table))
-	return int32(t.hasher.Sum32() % uint32(partitionNum))
+	return t.hasher.partition(newTableKey(row), partitionNum), nil
 }
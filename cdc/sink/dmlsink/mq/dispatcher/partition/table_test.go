@@ -77,8 +77,10 @@ func TestTableDispatcher(t *testing.T) {
 			CommitTs: 3,
 		}, expectPartition: 3},
 	}
-	p := NewTableDispatcher()
+	p := NewTableDispatcher("")
 	for _, tc := range testCases {
-		require.Equal(t, tc.expectPartition, p.DispatchRowChangedEvent(tc.row, 16))
+		index, err := p.DispatchRowChangedEvent(tc.row, 16)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectPartition, index)
 	}
 }
@@ -72,6 +72,8 @@ func TestTsDispatcher(t *testing.T) {
 	}
 	p := &TsDispatcher{}
 	for _, tc := range testCases {
-		require.Equal(t, tc.expectPartition, p.DispatchRowChangedEvent(tc.row, 16))
+		index, err := p.DispatchRowChangedEvent(tc.row, 16)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectPartition, index)
 	}
 }
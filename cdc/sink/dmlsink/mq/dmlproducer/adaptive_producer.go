@@ -0,0 +1,393 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/metrics/mq"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// targetAckLatency is the expected latency from sending a message to
+	// receiving its ack. Batching is tuned to keep the latency around it.
+	targetAckLatency = 100 * time.Millisecond
+	// adjustInterval is the interval of tuning the batching of a partition.
+	adjustInterval = time.Second
+	// ackLatencySmoothing is the weight of the latest ack latency in the
+	// moving average.
+	ackLatencySmoothing = 0.2
+
+	minMaxInFlight     = 128
+	initialMaxInFlight = 1024
+	maxMaxInFlight     = 16384
+	maxInFlightStep    = 256
+
+	lingerStep = time.Millisecond
+	maxLinger  = 20 * time.Millisecond
+
+	maxBatchSize = 512
+)
+
+var _ DMLProducer = (*adaptiveDMLProducer)(nil)
+
+// batchParams are the batching parameters of a partition.
+type batchParams struct {
+	// linger is the max time that a message waits in the batch.
+	linger time.Duration
+	// batchSize is the number of messages that triggers a flush.
+	batchSize int
+	// maxInFlight is the max number of messages that are batched or sent
+	// but not acked.
+	maxInFlight int
+}
+
+// adjust tunes the parameters by the ack latency. A slow broker gets fewer
+// but larger batches, and a fast one gets smaller batches and more
+// in-flight messages for lower latency.
+func (p batchParams) adjust(latency time.Duration) batchParams {
+	switch {
+	case latency > targetAckLatency:
+		p.maxInFlight /= 2
+		if p.maxInFlight < minMaxInFlight {
+			p.maxInFlight = minMaxInFlight
+		}
+		p.linger *= 2
+		if p.linger < lingerStep {
+			p.linger = lingerStep
+		}
+		if p.linger > maxLinger {
+			p.linger = maxLinger
+		}
+		p.batchSize *= 2
+		if p.batchSize > maxBatchSize {
+			p.batchSize = maxBatchSize
+		}
+	case latency < targetAckLatency/2:
+		p.maxInFlight += maxInFlightStep
+		if p.maxInFlight > maxMaxInFlight {
+			p.maxInFlight = maxMaxInFlight
+		}
+		p.linger /= 2
+		if p.linger < lingerStep {
+			p.linger = 0
+		}
+		p.batchSize /= 2
+		if p.batchSize < 1 {
+			p.batchSize = 1
+		}
+	}
+	if p.batchSize > p.maxInFlight {
+		p.batchSize = p.maxInFlight
+	}
+	return p
+}
+
+// adaptiveDMLProducer wraps a DMLProducer, it batches messages of every
+// partition and limits the in-flight messages of every partition. The
+// linger, batch size and in-flight limit are tuned per partition by the
+// observed ack latency, instead of a static global setting.
+type adaptiveDMLProducer struct {
+	id       model.ChangeFeedID
+	producer DMLProducer
+	errCh    chan error
+
+	mu         sync.Mutex
+	partitions map[partitionKey]*partitionBatcher
+
+	closed chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+// NewAdaptiveDMLProducer wraps the producer with adaptive batching.
+func NewAdaptiveDMLProducer(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	producer DMLProducer,
+	errCh chan error,
+) DMLProducer {
+	ctx, cancel := context.WithCancel(ctx)
+	return &adaptiveDMLProducer{
+		id:         changefeedID,
+		producer:   producer,
+		errCh:      errCh,
+		partitions: make(map[partitionKey]*partitionBatcher),
+		closed:     make(chan struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+func (p *adaptiveDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	partition int32, message *common.Message,
+) error {
+	b, err := p.getBatcher(topic, partition)
+	if err != nil {
+		return err
+	}
+	return b.add(ctx, message)
+}
+
+func (p *adaptiveDMLProducer) getBatcher(
+	topic string, partition int32,
+) (*partitionBatcher, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.partitions == nil {
+		return nil, cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	key := partitionKey{topic: topic, partition: partition}
+	b, ok := p.partitions[key]
+	if !ok {
+		b = newPartitionBatcher(p, topic, partition)
+		p.partitions[key] = b
+	}
+	return b, nil
+}
+
+func (p *adaptiveDMLProducer) Close() {
+	p.mu.Lock()
+	if p.partitions == nil {
+		p.mu.Unlock()
+		return
+	}
+	partitions := p.partitions
+	p.partitions = nil
+	p.mu.Unlock()
+
+	p.cancel()
+	close(p.closed)
+	p.producer.Close()
+	for _, b := range partitions {
+		b.close()
+	}
+}
+
+// reportError reports an error of a linger flush, which has no caller
+// to return the error to.
+func (p *adaptiveDMLProducer) reportError(err error) {
+	if errors.Cause(err) == context.Canceled {
+		return
+	}
+	select {
+	case p.errCh <- err:
+		log.Error("MQ adaptive DML producer flush error",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID),
+			zap.Error(err))
+	default:
+		log.Error("Error channel is full in MQ adaptive DML producer",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID),
+			zap.Error(err))
+	}
+}
+
+// partitionBatcher batches messages of a partition.
+//
+// sendMu serializes sending so that messages are sent in order, and mu
+// protects the in-flight and latency states. mu is never held when calling
+// the underlying producer, because acks may be blocked by sending.
+type partitionBatcher struct {
+	p         *adaptiveDMLProducer
+	topic     string
+	partition int32
+
+	sendMu  sync.Mutex
+	pending []*common.Message
+	timer   *time.Timer
+
+	mu       sync.Mutex
+	params   batchParams
+	inFlight int
+	// released is closed when an in-flight message is acked.
+	released chan struct{}
+	// latency is the moving average of ack latency.
+	latency    time.Duration
+	acked      int
+	lastAdjust time.Time
+
+	metricLinger      prometheus.Gauge
+	metricBatchSize   prometheus.Gauge
+	metricMaxInFlight prometheus.Gauge
+	metricAckLatency  prometheus.Gauge
+}
+
+func newPartitionBatcher(
+	p *adaptiveDMLProducer, topic string, partition int32,
+) *partitionBatcher {
+	labels := []string{p.id.Namespace, p.id.ID, topic, strconv.Itoa(int(partition))}
+	b := &partitionBatcher{
+		p:         p,
+		topic:     topic,
+		partition: partition,
+		params: batchParams{
+			batchSize:   1,
+			maxInFlight: initialMaxInFlight,
+		},
+		released:   make(chan struct{}),
+		lastAdjust: time.Now(),
+
+		metricLinger:      mq.PartitionLinger.WithLabelValues(labels...),
+		metricBatchSize:   mq.PartitionBatchSize.WithLabelValues(labels...),
+		metricMaxInFlight: mq.PartitionMaxInFlight.WithLabelValues(labels...),
+		metricAckLatency:  mq.PartitionAckLatency.WithLabelValues(labels...),
+	}
+	b.updateMetrics()
+	return b
+}
+
+// add batches the message, it blocks if the partition reaches the
+// in-flight limit.
+func (b *partitionBatcher) add(ctx context.Context, message *common.Message) error {
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+
+	b.mu.Lock()
+	// Messages are always allowed if nothing is in flight, so that a shrunk
+	// limit can't block the pending messages forever.
+	for b.inFlight > 0 && b.inFlight+len(b.pending) >= b.params.maxInFlight {
+		released := b.released
+		b.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-b.p.closed:
+			return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+		case <-released:
+		}
+		b.mu.Lock()
+	}
+	params := b.params
+	b.mu.Unlock()
+
+	b.pending = append(b.pending, message)
+	if len(b.pending) >= params.batchSize || params.linger == 0 {
+		return b.flushLocked(ctx)
+	}
+	if b.timer == nil {
+		b.timer = time.AfterFunc(params.linger, b.flushByTimer)
+	}
+	return nil
+}
+
+func (b *partitionBatcher) flushByTimer() {
+	select {
+	case <-b.p.closed:
+		return
+	default:
+	}
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+	if err := b.flushLocked(b.p.ctx); err != nil {
+		b.p.reportError(err)
+	}
+}
+
+// flushLocked sends all pending messages, b.sendMu must be held.
+func (b *partitionBatcher) flushLocked(ctx context.Context) error {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	pending := b.pending
+	b.pending = nil
+	for _, message := range pending {
+		callback := message.Callback
+		sentAt := time.Now()
+		message.Callback = func() {
+			b.onAck(sentAt)
+			if callback != nil {
+				callback()
+			}
+		}
+
+		b.mu.Lock()
+		b.inFlight++
+		b.mu.Unlock()
+		if err := b.p.producer.AsyncSendMessage(ctx, b.topic, b.partition, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *partitionBatcher) onAck(sentAt time.Time) {
+	now := time.Now()
+	latency := now.Sub(sentAt)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inFlight--
+	close(b.released)
+	b.released = make(chan struct{})
+
+	if b.acked == 0 && b.latency == 0 {
+		b.latency = latency
+	} else {
+		b.latency = time.Duration(ackLatencySmoothing*float64(latency) +
+			(1-ackLatencySmoothing)*float64(b.latency))
+	}
+	b.acked++
+	if now.Sub(b.lastAdjust) < adjustInterval {
+		return
+	}
+	b.params = b.params.adjust(b.latency)
+	b.acked = 0
+	b.lastAdjust = now
+	b.updateMetrics()
+}
+
+// updateMetrics reports the batching states, b.mu must be held.
+func (b *partitionBatcher) updateMetrics() {
+	b.metricLinger.Set(b.params.linger.Seconds())
+	b.metricBatchSize.Set(float64(b.params.batchSize))
+	b.metricMaxInFlight.Set(float64(b.params.maxInFlight))
+	b.metricAckLatency.Set(b.latency.Seconds())
+}
+
+// close drops pending messages, which is the same as what the underlying
+// producer does with its buffered messages when it's closed.
+func (b *partitionBatcher) close() {
+	b.sendMu.Lock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.pending = nil
+	b.sendMu.Unlock()
+
+	labels := []string{b.p.id.Namespace, b.p.id.ID, b.topic, strconv.Itoa(int(b.partition))}
+	mq.PartitionLinger.DeleteLabelValues(labels...)
+	mq.PartitionBatchSize.DeleteLabelValues(labels...)
+	mq.PartitionMaxInFlight.DeleteLabelValues(labels...)
+	mq.PartitionAckLatency.DeleteLabelValues(labels...)
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+// holdingDMLProducer holds messages without acking them.
+type holdingDMLProducer struct {
+	mu       sync.Mutex
+	messages []*common.Message
+}
+
+func (h *holdingDMLProducer) AsyncSendMessage(
+	_ context.Context, _ string, _ int32, message *common.Message,
+) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.messages = append(h.messages, message)
+	return nil
+}
+
+func (h *holdingDMLProducer) Close() {}
+
+func (h *holdingDMLProducer) sent() []*common.Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*common.Message(nil), h.messages...)
+}
+
+func TestBatchParamsAdjust(t *testing.T) {
+	t.Parallel()
+
+	params := batchParams{batchSize: 1, maxInFlight: initialMaxInFlight}
+	slow := params.adjust(2 * targetAckLatency)
+	require.Equal(t, batchParams{
+		linger: lingerStep, batchSize: 2, maxInFlight: initialMaxInFlight / 2,
+	}, slow)
+	for i := 0; i < 20; i++ {
+		slow = slow.adjust(2 * targetAckLatency)
+	}
+	require.Equal(t, batchParams{
+		linger: maxLinger, batchSize: minMaxInFlight, maxInFlight: minMaxInFlight,
+	}, slow)
+
+	// Latency around the target keeps the parameters.
+	require.Equal(t, slow, slow.adjust(targetAckLatency))
+
+	fast := slow
+	for i := 0; i < 100; i++ {
+		fast = fast.adjust(time.Millisecond)
+	}
+	require.Equal(t, batchParams{
+		linger: 0, batchSize: 1, maxInFlight: maxMaxInFlight,
+	}, fast)
+}
+
+func TestAdaptiveDMLProducerLinger(t *testing.T) {
+	t.Parallel()
+
+	inner := &holdingDMLProducer{}
+	p := NewAdaptiveDMLProducer(context.Background(),
+		model.DefaultChangeFeedID("test"), inner, make(chan error, 1)).(*adaptiveDMLProducer)
+	defer p.Close()
+
+	ctx := context.Background()
+	// Messages are sent at once by default.
+	require.Nil(t, p.AsyncSendMessage(ctx, "topic", 0, &common.Message{}))
+	require.Len(t, inner.sent(), 1)
+
+	b, err := p.getBatcher("topic", 0)
+	require.Nil(t, err)
+	b.mu.Lock()
+	b.params = batchParams{linger: 50 * time.Millisecond, batchSize: 3, maxInFlight: 100}
+	b.mu.Unlock()
+
+	// A full batch is sent at once.
+	for i := 0; i < 3; i++ {
+		require.Nil(t, p.AsyncSendMessage(ctx, "topic", 0, &common.Message{Ts: uint64(i)}))
+	}
+	sent := inner.sent()
+	require.Len(t, sent, 4)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, uint64(i), sent[i+1].Ts)
+	}
+
+	// Other messages are sent after the linger.
+	require.Nil(t, p.AsyncSendMessage(ctx, "topic", 0, &common.Message{}))
+	require.Len(t, inner.sent(), 4)
+	require.Eventually(t, func() bool {
+		return len(inner.sent()) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAdaptiveDMLProducerInFlightLimit(t *testing.T) {
+	t.Parallel()
+
+	inner := &holdingDMLProducer{}
+	p := NewAdaptiveDMLProducer(context.Background(),
+		model.DefaultChangeFeedID("test"), inner, make(chan error, 1)).(*adaptiveDMLProducer)
+	defer p.Close()
+
+	b, err := p.getBatcher("topic", 1)
+	require.Nil(t, err)
+	b.mu.Lock()
+	b.params.maxInFlight = 2
+	b.mu.Unlock()
+
+	ctx := context.Background()
+	acked := 0
+	for i := 0; i < 2; i++ {
+		require.Nil(t, p.AsyncSendMessage(ctx, "topic", 1, &common.Message{
+			Callback: func() { acked++ },
+		}))
+	}
+	// Other partitions are not limited.
+	require.Nil(t, p.AsyncSendMessage(ctx, "topic", 2, &common.Message{}))
+
+	done := make(chan error)
+	go func() {
+		done <- p.AsyncSendMessage(ctx, "topic", 1, &common.Message{})
+	}()
+	select {
+	case <-done:
+		require.FailNow(t, "send should be blocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	inner.sent()[0].Callback()
+	require.Nil(t, <-done)
+	require.Equal(t, 1, acked)
+
+	// A blocked send returns once the context is canceled.
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		done <- p.AsyncSendMessage(ctx, "topic", 1, &common.Message{})
+	}()
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	"go.uber.org/zap"
+)
+
+var _ DMLProducer = (*mqttDMLProducer)(nil)
+
+// unackedMessage is a message published to the MQTT broker but not acked yet.
+type unackedMessage struct {
+	topic    string
+	token    mqtt.Token
+	callback func()
+}
+
+// mqttDMLProducer is used to send messages to an MQTT broker.
+//
+// Messages are published with QoS 1, and the callbacks of messages are
+// called in the publish order once they are acked by the broker, so the
+// checkpoint only advances over the messages which are accepted.
+type mqttDMLProducer struct {
+	// id indicates which processor (changefeed) this sink belongs to.
+	id model.ChangeFeedID
+	// client is used to publish messages to the broker.
+	client     mqtt.Client
+	ackTimeout time.Duration
+	// unackedCh holds the messages which are waiting for acks, its capacity
+	// limits the number of unacked messages.
+	unackedCh chan unackedMessage
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+	// done is closed once the ack collecting loop exits.
+	done <-chan struct{}
+
+	cancel context.CancelFunc
+}
+
+// NewMQTTDMLProducer creates a new MQTT producer.
+func NewMQTTDMLProducer(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	client mqtt.Client,
+	options *mqtt.Options,
+	errCh chan error,
+) DMLProducer {
+	log.Info("Starting mqtt DML producer ...",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID))
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &mqttDMLProducer{
+		id:         changefeedID,
+		client:     client,
+		ackTimeout: options.AckTimeout,
+		unackedCh:  make(chan unackedMessage, options.MaxPendingAcks),
+		done:       ctx.Done(),
+		cancel:     cancel,
+	}
+
+	go func() {
+		err := p.run(ctx)
+		// Stop accepting messages once the loop exits, otherwise senders may
+		// be blocked forever.
+		cancel()
+		if err != nil && errors.Cause(err) != context.Canceled {
+			select {
+			case errCh <- err:
+				log.Error("MQTT DML producer run error",
+					zap.String("namespace", p.id.Namespace),
+					zap.String("changefeed", p.id.ID),
+					zap.Error(err))
+			default:
+				log.Error("Error channel is full in mqtt DML producer",
+					zap.String("namespace", p.id.Namespace),
+					zap.String("changefeed", p.id.ID),
+					zap.Error(err))
+			}
+		}
+	}()
+
+	return p
+}
+
+// AsyncSendMessage publishes the message to the topic template, the
+// partition is ignored because topics have no partitions.
+func (p *mqttDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	// We have to hold the lock to avoid writing to a closed producer.
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	// If the producer is closed, we should skip the message and return an error.
+	if p.closed {
+		return cerror.ErrMQTTProducerClosed.GenWithStackByArgs()
+	}
+	// Acks are not collected after the run loop exits.
+	select {
+	case <-p.done:
+		return cerror.ErrMQTTProducerClosed.GenWithStackByArgs()
+	default:
+	}
+	token, err := p.client.PublishAsync(ctx, topic, message)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-p.done:
+		return cerror.ErrMQTTProducerClosed.GenWithStackByArgs()
+	case p.unackedCh <- unackedMessage{
+		topic:    topic,
+		token:    token,
+		callback: message.Callback,
+	}:
+	}
+	return nil
+}
+
+func (p *mqttDMLProducer) Close() {
+	// We have to hold the lock to synchronize closing with writing.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer has already been closed, we should skip this close operation.
+	if p.closed {
+		// We need to guard against double closing the clients,
+		// which could lead to panic.
+		log.Warn("MQTT DML producer already closed",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+		return
+	}
+	p.cancel()
+	p.closed = true
+
+	p.client.Close()
+}
+
+// run waits for the acks of messages in the publish order.
+func (p *mqttDMLProducer) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case msg := <-p.unackedCh:
+			if err := p.waitAck(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *mqttDMLProducer) waitAck(ctx context.Context, msg unackedMessage) error {
+	timer := time.NewTimer(p.ackTimeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-msg.token.Done():
+		if err := msg.token.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		if msg.callback != nil {
+			msg.callback()
+		}
+		return nil
+	case <-timer.C:
+		return cerror.ErrMQTTSendMessage.GenWithStack(
+			"wait for the ack of the message to topic %s timeout", msg.topic)
+	}
+}
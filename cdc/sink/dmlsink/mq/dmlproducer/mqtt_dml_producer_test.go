@@ -0,0 +1,142 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestMQTTProducerAck(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := mqtt.NewMockClient()
+	client.SetManualAck(true)
+	errCh := make(chan error, 1)
+	p := NewMQTTDMLProducer(ctx, model.DefaultChangeFeedID("test"),
+		client, mqtt.NewOptions(), errCh)
+	defer p.Close()
+
+	var acked []int
+	var count atomic.Int64
+	schema, table := "test", "t"
+	for i := 0; i < 3; i++ {
+		i := i
+		err := p.AsyncSendMessage(ctx, "tidb/{schema}/{table}", 0, &common.Message{
+			Value:  []byte("value"),
+			Schema: &schema,
+			Table:  &table,
+			Callback: func() {
+				acked = append(acked, i)
+				count.Inc()
+			},
+		})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, client.Pending())
+	// callbacks are not called before the messages are acked.
+	time.Sleep(100 * time.Millisecond)
+	require.Zero(t, count.Load())
+
+	client.Ack(nil)
+	require.Eventually(t, func() bool {
+		return count.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []int{0, 1, 2}, acked)
+	messages := client.Messages("tidb/test/t")
+	require.Len(t, messages, 3)
+	require.False(t, messages[0].Retain)
+	require.Len(t, errCh, 0)
+}
+
+func TestMQTTProducerError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := mqtt.NewMockClient()
+	client.SetManualAck(true)
+	options := mqtt.NewOptions()
+	options.MaxMessageBytes = 5
+	_, err := mqtt.NewMockFactory(client)(options, model.DefaultChangeFeedID("test"))
+	require.NoError(t, err)
+	errCh := make(chan error, 1)
+	p := NewMQTTDMLProducer(ctx, model.DefaultChangeFeedID("test"), client, options, errCh)
+
+	// oversized messages are rejected before publishing.
+	err = p.AsyncSendMessage(ctx, "tidb", 0, &common.Message{Value: []byte("too large")})
+	require.ErrorContains(t, err, "ErrMQTTMessageTooLarge")
+	require.Zero(t, client.Pending())
+
+	called := false
+	err = p.AsyncSendMessage(ctx, "tidb", 0, &common.Message{
+		Value:    []byte("value"),
+		Callback: func() { called = true },
+	})
+	require.NoError(t, err)
+	client.Ack(errors.New("not authorized"))
+	select {
+	case err = <-errCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no error is reported")
+	}
+	require.ErrorContains(t, err, "not authorized")
+	require.False(t, called)
+
+	// the producer stops accepting messages after the error.
+	err = p.AsyncSendMessage(ctx, "tidb", 0, &common.Message{Value: []byte("value")})
+	require.True(t, cerror.ErrMQTTProducerClosed.Equal(err))
+
+	p.Close()
+	require.True(t, client.Closed())
+	// double close
+	p.Close()
+}
+
+func TestMQTTProducerAckTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := mqtt.NewMockClient()
+	client.SetManualAck(true)
+	options := mqtt.NewOptions()
+	options.AckTimeout = 100 * time.Millisecond
+	errCh := make(chan error, 1)
+	p := NewMQTTDMLProducer(ctx, model.DefaultChangeFeedID("test"), client, options, errCh)
+	defer p.Close()
+
+	err := p.AsyncSendMessage(ctx, "tidb", 0, &common.Message{Value: []byte("value")})
+	require.NoError(t, err)
+	select {
+	case err = <-errCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no error is reported")
+	}
+	require.ErrorContains(t, err, "timeout")
+}
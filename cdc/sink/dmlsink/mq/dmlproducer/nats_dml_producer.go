@@ -0,0 +1,195 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	pnats "github.com/pingcap/tiflow/pkg/sink/nats"
+	"go.uber.org/zap"
+)
+
+var _ DMLProducer = (*natsDMLProducer)(nil)
+
+// pendingMessage is a message published to NATS but not acked yet.
+type pendingMessage struct {
+	future   nats.PubAckFuture
+	callback func()
+}
+
+// natsDMLProducer is used to send messages to NATS JetStream.
+//
+// Messages are published asynchronously, and the callbacks of messages are
+// called in the publish order once they are acked by the stream, so the
+// checkpoint only advances over the messages which are persisted.
+type natsDMLProducer struct {
+	// id indicates which processor (changefeed) this sink belongs to.
+	id model.ChangeFeedID
+	// client is used to publish messages to NATS JetStream.
+	client     pnats.Client
+	ackTimeout time.Duration
+	// pendingCh holds the messages which are waiting for acks, its capacity
+	// limits the number of pending messages.
+	pendingCh chan pendingMessage
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+	// done is closed once the ack collecting loop exits.
+	done <-chan struct{}
+
+	cancel context.CancelFunc
+}
+
+// NewNatsDMLProducer creates a new NATS producer.
+func NewNatsDMLProducer(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	client pnats.Client,
+	options *pnats.Options,
+	errCh chan error,
+) DMLProducer {
+	log.Info("Starting nats DML producer ...",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID))
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &natsDMLProducer{
+		id:         changefeedID,
+		client:     client,
+		ackTimeout: options.AckTimeout,
+		pendingCh:  make(chan pendingMessage, options.MaxPendingAcks),
+		done:       ctx.Done(),
+		cancel:     cancel,
+	}
+
+	go func() {
+		err := p.run(ctx)
+		// Stop accepting messages once the loop exits, otherwise senders may
+		// be blocked forever.
+		cancel()
+		if err != nil && errors.Cause(err) != context.Canceled {
+			select {
+			case errCh <- err:
+				log.Error("Nats DML producer run error",
+					zap.String("namespace", p.id.Namespace),
+					zap.String("changefeed", p.id.ID),
+					zap.Error(err))
+			default:
+				log.Error("Error channel is full in nats DML producer",
+					zap.String("namespace", p.id.Namespace),
+					zap.String("changefeed", p.id.ID),
+					zap.Error(err))
+			}
+		}
+	}()
+
+	return p
+}
+
+// AsyncSendMessage publishes the message to the subject, the partition is
+// ignored because NATS subjects have no partitions.
+func (p *natsDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	// We have to hold the lock to avoid writing to a closed producer.
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	// If the producer is closed, we should skip the message and return an error.
+	if p.closed {
+		return cerror.ErrNatsProducerClosed.GenWithStackByArgs()
+	}
+	// Acks are not collected after the run loop exits.
+	select {
+	case <-p.done:
+		return cerror.ErrNatsProducerClosed.GenWithStackByArgs()
+	default:
+	}
+	future, err := p.client.PublishAsync(topic, message.Key, message.Value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-p.done:
+		return cerror.ErrNatsProducerClosed.GenWithStackByArgs()
+	case p.pendingCh <- pendingMessage{future: future, callback: message.Callback}:
+	}
+	return nil
+}
+
+func (p *natsDMLProducer) Close() {
+	// We have to hold the lock to synchronize closing with writing.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer has already been closed, we should skip this close operation.
+	if p.closed {
+		// We need to guard against double closing the clients,
+		// which could lead to panic.
+		log.Warn("Nats DML producer already closed",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+		return
+	}
+	p.cancel()
+	p.closed = true
+
+	p.client.Close()
+}
+
+// run waits for the acks of pending messages in the publish order.
+func (p *natsDMLProducer) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case msg := <-p.pendingCh:
+			if err := p.waitAck(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *natsDMLProducer) waitAck(ctx context.Context, msg pendingMessage) error {
+	timer := time.NewTimer(p.ackTimeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-msg.future.Ok():
+		if msg.callback != nil {
+			msg.callback()
+		}
+		return nil
+	case err := <-msg.future.Err():
+		return cerror.WrapError(cerror.ErrNatsSendMessage, err)
+	case <-timer.C:
+		return cerror.ErrNatsSendMessage.GenWithStack(
+			"wait for the ack of the message to subject %s timeout", msg.future.Msg().Subject)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	pnats "github.com/pingcap/tiflow/pkg/sink/nats"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestNatsProducerAck(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := pnats.NewMockClient()
+	client.AddStream("tidb", "tidb.>")
+	client.SetManualAck(true)
+	options := pnats.NewOptions()
+	errCh := make(chan error, 1)
+	p := NewNatsDMLProducer(ctx, model.DefaultChangeFeedID("test"), client, options, errCh)
+	defer p.Close()
+
+	var acked []int
+	var count atomic.Int64
+	for i := 0; i < 3; i++ {
+		i := i
+		err := p.AsyncSendMessage(ctx, "tidb.test.t", 0, &common.Message{
+			Key:   []byte("key"),
+			Value: []byte("value"),
+			Callback: func() {
+				acked = append(acked, i)
+				count.Inc()
+			},
+		})
+		require.NoError(t, err)
+	}
+	// callbacks are not called before the messages are acked.
+	time.Sleep(100 * time.Millisecond)
+	require.Zero(t, count.Load())
+
+	client.Ack(nil)
+	require.Eventually(t, func() bool {
+		return count.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []int{0, 1, 2}, acked)
+	messages := client.Messages("tidb.test.t")
+	require.Len(t, messages, 3)
+	require.Equal(t, "key", messages[0].Header.Get(pnats.KeyHeader))
+	require.Len(t, errCh, 0)
+}
+
+func TestNatsProducerAckError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := pnats.NewMockClient()
+	options := pnats.NewOptions()
+	errCh := make(chan error, 1)
+	p := NewNatsDMLProducer(ctx, model.DefaultChangeFeedID("test"), client, options, errCh)
+
+	// there is no stream which captures the subject.
+	called := false
+	err := p.AsyncSendMessage(ctx, "tidb.test.t", 0, &common.Message{
+		Value:    []byte("value"),
+		Callback: func() { called = true },
+	})
+	require.NoError(t, err)
+	select {
+	case err = <-errCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no error is reported")
+	}
+	require.ErrorContains(t, err, "ErrNatsSendMessage")
+	require.ErrorIs(t, err, nats.ErrNoStreamResponse)
+	require.False(t, called)
+
+	// the producer stops accepting messages after the error.
+	err = p.AsyncSendMessage(ctx, "tidb.test.t", 0, &common.Message{Value: []byte("value")})
+	require.True(t, cerror.ErrNatsProducerClosed.Equal(err))
+
+	p.Close()
+	require.True(t, client.Closed())
+	err = p.AsyncSendMessage(ctx, "tidb.test.t", 0, &common.Message{Value: []byte("value")})
+	require.True(t, cerror.ErrNatsProducerClosed.Equal(err))
+	// double close
+	p.Close()
+}
+
+func TestNatsProducerAckTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := pnats.NewMockClient()
+	client.AddStream("tidb", "tidb.>")
+	client.SetManualAck(true)
+	options := pnats.NewOptions()
+	options.AckTimeout = 100 * time.Millisecond
+	errCh := make(chan error, 1)
+	p := NewNatsDMLProducer(ctx, model.DefaultChangeFeedID("test"), client, options, errCh)
+	defer p.Close()
+
+	err := p.AsyncSendMessage(ctx, "tidb.test.t", 0, &common.Message{Value: []byte("value")})
+	require.NoError(t, err)
+	select {
+	case err = <-errCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no error is reported")
+	}
+	require.ErrorContains(t, err, "timeout")
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
+	"go.uber.org/zap"
+)
+
+var _ DMLProducer = (*rabbitmqDMLProducer)(nil)
+
+// unconfirmedMessage is a message published to RabbitMQ but not confirmed yet.
+type unconfirmedMessage struct {
+	exchange     string
+	confirmation rabbitmq.Confirmation
+	callback     func()
+}
+
+// rabbitmqDMLProducer is used to send messages to RabbitMQ.
+//
+// Messages are published with publisher confirms, and the callbacks of
+// messages are called in the publish order once they are acked by the broker,
+// so the checkpoint only advances over the messages which are accepted.
+type rabbitmqDMLProducer struct {
+	// id indicates which processor (changefeed) this sink belongs to.
+	id model.ChangeFeedID
+	// client is used to publish messages to RabbitMQ.
+	client         rabbitmq.Client
+	confirmTimeout time.Duration
+	// unconfirmedCh holds the messages which are waiting for confirms, its
+	// capacity limits the number of unconfirmed messages.
+	unconfirmedCh chan unconfirmedMessage
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+	// done is closed once the confirm collecting loop exits.
+	done <-chan struct{}
+
+	cancel context.CancelFunc
+}
+
+// NewRabbitMQDMLProducer creates a new RabbitMQ producer.
+func NewRabbitMQDMLProducer(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	client rabbitmq.Client,
+	options *rabbitmq.Options,
+	errCh chan error,
+) DMLProducer {
+	log.Info("Starting rabbitmq DML producer ...",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID))
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &rabbitmqDMLProducer{
+		id:             changefeedID,
+		client:         client,
+		confirmTimeout: options.ConfirmTimeout,
+		unconfirmedCh:  make(chan unconfirmedMessage, options.MaxPendingConfirms),
+		done:           ctx.Done(),
+		cancel:         cancel,
+	}
+
+	go func() {
+		err := p.run(ctx)
+		// Stop accepting messages once the loop exits, otherwise senders may
+		// be blocked forever.
+		cancel()
+		if err != nil && errors.Cause(err) != context.Canceled {
+			select {
+			case errCh <- err:
+				log.Error("RabbitMQ DML producer run error",
+					zap.String("namespace", p.id.Namespace),
+					zap.String("changefeed", p.id.ID),
+					zap.Error(err))
+			default:
+				log.Error("Error channel is full in rabbitmq DML producer",
+					zap.String("namespace", p.id.Namespace),
+					zap.String("changefeed", p.id.ID),
+					zap.Error(err))
+			}
+		}
+	}()
+
+	return p
+}
+
+// AsyncSendMessage publishes the message to the exchange, the partition is
+// ignored because exchanges have no partitions.
+func (p *rabbitmqDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	_ int32, message *common.Message,
+) error {
+	// We have to hold the lock to avoid writing to a closed producer.
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	// If the producer is closed, we should skip the message and return an error.
+	if p.closed {
+		return cerror.ErrRabbitMQProducerClosed.GenWithStackByArgs()
+	}
+	// Confirms are not collected after the run loop exits.
+	select {
+	case <-p.done:
+		return cerror.ErrRabbitMQProducerClosed.GenWithStackByArgs()
+	default:
+	}
+	confirmation, err := p.client.PublishWithConfirm(ctx, topic, message)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-p.done:
+		return cerror.ErrRabbitMQProducerClosed.GenWithStackByArgs()
+	case p.unconfirmedCh <- unconfirmedMessage{
+		exchange:     topic,
+		confirmation: confirmation,
+		callback:     message.Callback,
+	}:
+	}
+	return nil
+}
+
+func (p *rabbitmqDMLProducer) Close() {
+	// We have to hold the lock to synchronize closing with writing.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer has already been closed, we should skip this close operation.
+	if p.closed {
+		// We need to guard against double closing the clients,
+		// which could lead to panic.
+		log.Warn("RabbitMQ DML producer already closed",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+		return
+	}
+	p.cancel()
+	p.closed = true
+
+	p.client.Close()
+}
+
+// run waits for the confirms of messages in the publish order.
+func (p *rabbitmqDMLProducer) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case msg := <-p.unconfirmedCh:
+			if err := p.waitConfirm(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *rabbitmqDMLProducer) waitConfirm(ctx context.Context, msg unconfirmedMessage) error {
+	timer := time.NewTimer(p.confirmTimeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-msg.confirmation.Done():
+		if !msg.confirmation.Acked() {
+			return cerror.ErrRabbitMQSendMessage.GenWithStack(
+				"message to exchange %s is nacked by the broker", msg.exchange)
+		}
+		if msg.callback != nil {
+			msg.callback()
+		}
+		return nil
+	case <-timer.C:
+		return cerror.ErrRabbitMQSendMessage.GenWithStack(
+			"wait for the confirm of the message to exchange %s timeout", msg.exchange)
+	}
+}
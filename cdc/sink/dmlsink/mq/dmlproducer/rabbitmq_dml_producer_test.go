@@ -0,0 +1,136 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestRabbitMQProducerConfirm(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := rabbitmq.NewMockClient()
+	client.AddExchange("tidb")
+	client.SetManualConfirm(true)
+	errCh := make(chan error, 1)
+	p := NewRabbitMQDMLProducer(ctx, model.DefaultChangeFeedID("test"),
+		client, rabbitmq.NewOptions(), errCh)
+	defer p.Close()
+
+	var confirmed []int
+	var count atomic.Int64
+	schema, table := "test", "t"
+	for i := 0; i < 3; i++ {
+		i := i
+		err := p.AsyncSendMessage(ctx, "tidb", 0, &common.Message{
+			Value:  []byte("value"),
+			Schema: &schema,
+			Table:  &table,
+			Callback: func() {
+				confirmed = append(confirmed, i)
+				count.Inc()
+			},
+		})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, client.Pending())
+	// callbacks are not called before the messages are confirmed.
+	time.Sleep(100 * time.Millisecond)
+	require.Zero(t, count.Load())
+
+	client.Confirm(true)
+	require.Eventually(t, func() bool {
+		return count.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []int{0, 1, 2}, confirmed)
+	messages := client.Messages("tidb")
+	require.Len(t, messages, 3)
+	require.Equal(t, "test.t", messages[0].RoutingKey)
+	require.Len(t, errCh, 0)
+}
+
+func TestRabbitMQProducerNack(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := rabbitmq.NewMockClient()
+	client.AddExchange("tidb")
+	client.SetManualConfirm(true)
+	errCh := make(chan error, 1)
+	p := NewRabbitMQDMLProducer(ctx, model.DefaultChangeFeedID("test"),
+		client, rabbitmq.NewOptions(), errCh)
+
+	called := false
+	err := p.AsyncSendMessage(ctx, "tidb", 0, &common.Message{
+		Value:    []byte("value"),
+		Callback: func() { called = true },
+	})
+	require.NoError(t, err)
+	client.Confirm(false)
+	select {
+	case err = <-errCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no error is reported")
+	}
+	require.ErrorContains(t, err, "nacked")
+	require.False(t, called)
+
+	// the producer stops accepting messages after the error.
+	err = p.AsyncSendMessage(ctx, "tidb", 0, &common.Message{Value: []byte("value")})
+	require.True(t, cerror.ErrRabbitMQProducerClosed.Equal(err))
+
+	p.Close()
+	require.True(t, client.Closed())
+	// double close
+	p.Close()
+}
+
+func TestRabbitMQProducerConfirmTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := rabbitmq.NewMockClient()
+	client.AddExchange("tidb")
+	client.SetManualConfirm(true)
+	options := rabbitmq.NewOptions()
+	options.ConfirmTimeout = 100 * time.Millisecond
+	errCh := make(chan error, 1)
+	p := NewRabbitMQDMLProducer(ctx, model.DefaultChangeFeedID("test"), client, options, errCh)
+	defer p.Close()
+
+	err := p.AsyncSendMessage(ctx, "tidb", 0, &common.Message{Value: []byte("value")})
+	require.NoError(t, err)
+	select {
+	case err = <-errCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no error is reported")
+	}
+	require.ErrorContains(t, err, "timeout")
+}
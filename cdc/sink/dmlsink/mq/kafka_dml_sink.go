@@ -86,6 +86,9 @@ func NewKafkaDMLSink(
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
 	}
+	if options.AdaptiveBatching {
+		p = dmlproducer.NewAdaptiveDMLProducer(ctx, changefeedID, p, errCh)
+	}
 	// Preventing leaks when error occurs.
 	// This also closes the client in p.Close().
 	defer func() {
@@ -211,8 +211,10 @@ func (m *kafkaTopicManager) waitUntilTopicVisible(
 			zap.Int32("partitionNumber", meta[topicName].NumPartitions),
 			zap.Duration("duration", time.Since(start)))
 		return nil
-	}, retry.WithBackoffBaseDelay(500),
+	}, retry.WithName("kafka_wait_topic"),
+		retry.WithBackoffBaseDelay(500),
 		retry.WithBackoffMaxDelay(1000),
+		retry.WithDecorrelatedJitter(),
 		retry.WithMaxTries(6),
 	)
 
@@ -0,0 +1,73 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	"go.uber.org/zap"
+)
+
+// mqttTopicManager is a manager for MQTT topics.
+//
+// MQTT topics have no partitions and are never created, the broker delivers
+// messages to the matching subscriptions, so the manager only checks that
+// messages can be published to the topic template.
+type mqttTopicManager struct {
+	changefeedID model.ChangeFeedID
+
+	// topics are the topic templates which are valid.
+	topics sync.Map
+}
+
+// NewMQTTTopicManager creates a new topic manager.
+func NewMQTTTopicManager(changefeedID model.ChangeFeedID) TopicManager {
+	return &mqttTopicManager{changefeedID: changefeedID}
+}
+
+// GetPartitionNum always returns 1 because MQTT topics have no partitions.
+func (m *mqttTopicManager) GetPartitionNum(
+	ctx context.Context,
+	topic string,
+) (int32, error) {
+	if _, ok := m.topics.Load(topic); ok {
+		return 1, nil
+	}
+	return m.CreateTopicAndWaitUntilVisible(ctx, topic)
+}
+
+// CreateTopicAndWaitUntilVisible validates the topic template.
+func (m *mqttTopicManager) CreateTopicAndWaitUntilVisible(
+	_ context.Context,
+	topicName string,
+) (int32, error) {
+	if err := mqtt.ValidateTopic(topicName); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if _, loaded := m.topics.LoadOrStore(topicName, struct{}{}); !loaded {
+		log.Info("MQTT topic is valid",
+			zap.String("namespace", m.changefeedID.Namespace),
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.String("topic", topicName))
+	}
+	return 1, nil
+}
+
+// Close does nothing.
+func (m *mqttTopicManager) Close() {}
@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	pnats "github.com/pingcap/tiflow/pkg/sink/nats"
+	"go.uber.org/zap"
+)
+
+// natsSubjectManager is a manager for NATS subjects.
+//
+// NATS subjects have no partitions, and they are captured by JetStream
+// streams which are managed by users, so the manager only checks that the
+// subject is captured by a stream before using it.
+type natsSubjectManager struct {
+	changefeedID model.ChangeFeedID
+
+	client pnats.Client
+
+	// subjects are the subjects which are captured by streams.
+	subjects sync.Map
+}
+
+// NewNatsSubjectManager creates a new subject manager.
+func NewNatsSubjectManager(
+	changefeedID model.ChangeFeedID,
+	client pnats.Client,
+) TopicManager {
+	return &natsSubjectManager{
+		changefeedID: changefeedID,
+		client:       client,
+	}
+}
+
+// GetPartitionNum always returns 1 because NATS subjects have no partitions.
+func (m *natsSubjectManager) GetPartitionNum(
+	ctx context.Context,
+	topic string,
+) (int32, error) {
+	if _, ok := m.subjects.Load(topic); ok {
+		return 1, nil
+	}
+	return m.CreateTopicAndWaitUntilVisible(ctx, topic)
+}
+
+// CreateTopicAndWaitUntilVisible checks that the subject is captured by a
+// stream, streams are never created by TiCDC.
+func (m *natsSubjectManager) CreateTopicAndWaitUntilVisible(
+	ctx context.Context,
+	topicName string,
+) (int32, error) {
+	stream, err := m.client.StreamNameBySubject(ctx, topicName)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if _, loaded := m.subjects.LoadOrStore(topicName, stream); !loaded {
+		log.Info("Nats subject is captured by stream",
+			zap.String("namespace", m.changefeedID.Namespace),
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.String("subject", topicName),
+			zap.String("stream", stream))
+	}
+	return 1, nil
+}
+
+// Close does nothing, the client is closed by the producer.
+func (m *natsSubjectManager) Close() {}
@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
+	"go.uber.org/zap"
+)
+
+// rabbitmqExchangeManager is a manager for RabbitMQ exchanges.
+//
+// Exchanges have no partitions, and they are bound to queues by users, so the
+// manager only checks that the exchange exists before using it.
+type rabbitmqExchangeManager struct {
+	changefeedID model.ChangeFeedID
+
+	client rabbitmq.Client
+
+	// exchanges are the exchanges which exist.
+	exchanges sync.Map
+}
+
+// NewRabbitMQExchangeManager creates a new exchange manager.
+func NewRabbitMQExchangeManager(
+	changefeedID model.ChangeFeedID,
+	client rabbitmq.Client,
+) TopicManager {
+	return &rabbitmqExchangeManager{
+		changefeedID: changefeedID,
+		client:       client,
+	}
+}
+
+// GetPartitionNum always returns 1 because exchanges have no partitions.
+func (m *rabbitmqExchangeManager) GetPartitionNum(
+	ctx context.Context,
+	topic string,
+) (int32, error) {
+	if _, ok := m.exchanges.Load(topic); ok {
+		return 1, nil
+	}
+	return m.CreateTopicAndWaitUntilVisible(ctx, topic)
+}
+
+// CreateTopicAndWaitUntilVisible checks that the exchange exists, exchanges
+// are never declared by TiCDC.
+func (m *rabbitmqExchangeManager) CreateTopicAndWaitUntilVisible(
+	ctx context.Context,
+	topicName string,
+) (int32, error) {
+	if err := m.client.CheckExchange(ctx, topicName); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if _, loaded := m.exchanges.LoadOrStore(topicName, struct{}{}); !loaded {
+		log.Info("RabbitMQ exchange exists",
+			zap.String("namespace", m.changefeedID.Namespace),
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.String("exchange", topicName))
+	}
+	return 1, nil
+}
+
+// Close does nothing, the client is closed by the producer.
+func (m *rabbitmqExchangeManager) Close() {}
@@ -143,7 +143,10 @@ func (s *dmlSink) WriteEvents(rows ...*dmlsink.RowChangeCallbackableEvent) error
 		if err != nil {
 			return errors.Trace(err)
 		}
-		partition := s.alive.eventRouter.GetPartitionForRowChange(row.Event, partitionNum)
+		partition, err := s.alive.eventRouter.GetPartitionForRowChange(row.Event, partitionNum)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		// This never be blocked because this is an unbounded channel.
 		s.alive.worker.msgChan.In() <- mqEvent{
 			key: TopicPartitionKey{
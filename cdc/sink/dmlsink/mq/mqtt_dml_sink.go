@@ -0,0 +1,119 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/manager"
+	"github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	tiflowutil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// NewMQTTDMLSink will verify the config and create an MQTT sink.
+// The path of the sink URI is the default topic template, e.g.
+// `tidb/{schema}/{table}`, so edge consumers can subscribe to specific tables.
+func NewMQTTDMLSink(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	errCh chan error,
+	clientCreator mqtt.Factory,
+) (_ *dmlSink, err error) {
+	options := mqtt.NewOptions()
+	useTLS := strings.ToLower(sinkURI.Scheme) == sink.MQTTSSLScheme
+	if err := options.Apply(sinkURI, useTLS); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMQTTInvalidConfig, err)
+	}
+
+	protocol, err := util.GetProtocol(
+		tiflowutil.GetOrZero(replicaConfig.Sink.Protocol),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	log.Info("Try to create a DML sink producer",
+		zap.String("broker", options.Broker),
+		zap.String("topic", options.Topic),
+		zap.Duration("messageExpiry", options.MessageExpiry),
+		zap.Int("maxInflight", options.MaxInflight),
+		zap.Int("maxPendingAcks", options.MaxPendingAcks))
+	// The client may lower MaxMessageBytes to the limit of the broker, so it
+	// must be created before the encoder config.
+	client, err := clientCreator(options, changefeedID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMQTTNewClient, err)
+	}
+	// The client is closed by the producer.
+	p := dmlproducer.NewMQTTDMLProducer(ctx, changefeedID, client, options, errCh)
+	// Preventing leaks when error occurs.
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+
+	topicManager := manager.NewMQTTTopicManager(changefeedID)
+	if _, err := topicManager.CreateTopicAndWaitUntilVisible(ctx, options.Topic); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, options.Topic)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		options.MaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mqtt.IsTopicTemplated(options.Topic) {
+		switch protocol {
+		case config.ProtocolCanal, config.ProtocolMaxwell:
+			// Messages of these protocols don't carry the schema and table.
+			return nil, cerror.ErrMQTTInvalidConfig.GenWithStack(
+				"topic %s is not supported by protocol %s", options.Topic, protocol)
+		}
+		// Rows of different tables may be batched into one message, which can
+		// only be published to one topic.
+		encoderConfig.MaxBatchSize = 1
+	}
+
+	s, err := newDMLSink(
+		ctx, changefeedID, p, nil, topicManager,
+		eventRouter, encoderConfig,
+		tiflowutil.GetOrZero(replicaConfig.Sink.EncoderConcurrency),
+		errCh,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/mqtt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestNewMQTTDMLSinkFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// maxwell messages don't carry the schema and table.
+	changefeedID := model.DefaultChangeFeedID("test")
+	sinkURI, err := url.Parse("mqtt://127.0.0.1:1883/tidb/{schema}/{table}?protocol=maxwell")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	client := mqtt.NewMockClient()
+	s, err := NewMQTTDMLSink(ctx, changefeedID, sinkURI,
+		replicaConfig, make(chan error, 1), mqtt.NewMockFactory(client))
+	require.ErrorContains(t, err, "is not supported by protocol maxwell")
+	require.Nil(t, s)
+	require.True(t, client.Closed())
+}
+
+func TestMQTTWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("mqtt://127.0.0.1:1883/tidb/{schema}/{table}?protocol=open-protocol")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:   []string{"other.*"},
+			TopicRule: "other_{schema}",
+		},
+	}
+
+	client := mqtt.NewMockClient()
+	client.SetManualAck(true)
+	errCh := make(chan error, 1)
+	s, err := NewMQTTDMLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI,
+		replicaConfig, errCh, mqtt.NewMockFactory(client))
+	require.NoError(t, err)
+	defer s.Close()
+
+	tableStatus := state.TableSinkSinking
+	var acked atomic.Int64
+	var events []*dmlsink.RowChangeCallbackableEvent
+	for _, table := range []model.TableName{
+		{Schema: "test", Table: "t1"},
+		{Schema: "test", Table: "t2"},
+		{Schema: "other", Table: "t1"},
+	} {
+		table := table
+		events = append(events, &dmlsink.RowChangeCallbackableEvent{
+			Event: &model.RowChangedEvent{
+				CommitTs: 1,
+				Table:    &table,
+				Columns:  []*model.Column{{Name: "col1", Type: 1, Value: "aa"}},
+			},
+			Callback:  func() { acked.Inc() },
+			SinkState: &tableStatus,
+		})
+	}
+	require.NoError(t, s.WriteEvents(events...))
+
+	require.Eventually(t, func() bool {
+		return client.Pending() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	// the checkpoint advances only after messages are acked.
+	require.Zero(t, acked.Load())
+	client.Ack(nil)
+	require.Eventually(t, func() bool {
+		return acked.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Len(t, client.Messages("tidb/test/t1"), 1)
+	require.Len(t, client.Messages("tidb/test/t2"), 1)
+	require.Len(t, client.Messages("other_other"), 1)
+	require.Len(t, errCh, 0)
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/manager"
+	"github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	pnats "github.com/pingcap/tiflow/pkg/sink/nats"
+	tiflowutil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// NewNatsDMLSink will verify the config and create a NATS JetStream sink.
+// The subject in the sink URI is the default subject, and the topic
+// expressions in dispatchers are used as subject templates of tables.
+func NewNatsDMLSink(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	errCh chan error,
+	clientCreator pnats.Factory,
+) (_ *dmlSink, err error) {
+	subject, err := util.GetTopic(sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	options := pnats.NewOptions()
+	if err := options.Apply(sinkURI, subject); err != nil {
+		return nil, cerror.WrapError(cerror.ErrNatsInvalidConfig, err)
+	}
+
+	protocol, err := util.GetProtocol(
+		tiflowutil.GetOrZero(replicaConfig.Sink.Protocol),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	log.Info("Try to create a DML sink producer",
+		zap.Strings("servers", options.Servers),
+		zap.Int("maxMessageBytes", options.MaxMessageBytes),
+		zap.Int("maxPendingAcks", options.MaxPendingAcks),
+		zap.Duration("ackTimeout", options.AckTimeout))
+	client, err := clientCreator(options, changefeedID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrNatsNewClient, err)
+	}
+	// The client is closed by the producer.
+	p := dmlproducer.NewNatsDMLProducer(ctx, changefeedID, client, options, errCh)
+	// Preventing leaks when error occurs.
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+
+	subjectManager := manager.NewNatsSubjectManager(changefeedID, client)
+	if _, err := subjectManager.CreateTopicAndWaitUntilVisible(ctx, subject); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, subject)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		options.MaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s, err := newDMLSink(
+		ctx, changefeedID, p, nil, subjectManager,
+		eventRouter, encoderConfig,
+		tiflowutil.GetOrZero(replicaConfig.Sink.EncoderConcurrency),
+		errCh,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	pnats "github.com/pingcap/tiflow/pkg/sink/nats"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestNewNatsDMLSinkStreamNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("nats://127.0.0.1:4222/tidb.default?protocol=open-protocol")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	client := pnats.NewMockClient()
+	s, err := NewNatsDMLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI,
+		replicaConfig, make(chan error, 1), pnats.NewMockFactory(client))
+	require.ErrorContains(t, err, "no jetstream stream captures subject tidb.default")
+	require.Nil(t, s)
+	require.True(t, client.Closed())
+}
+
+func TestNatsWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("nats://127.0.0.1:4222/tidb.default?protocol=open-protocol")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:   []string{"test.*"},
+			TopicRule: "tidb.{schema}.{table}",
+		},
+	}
+
+	client := pnats.NewMockClient()
+	client.AddStream("tidb", "tidb.>")
+	client.SetManualAck(true)
+	errCh := make(chan error, 1)
+	s, err := NewNatsDMLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI,
+		replicaConfig, errCh, pnats.NewMockFactory(client))
+	require.NoError(t, err)
+	defer s.Close()
+
+	tableStatus := state.TableSinkSinking
+	var acked atomic.Int64
+	var events []*dmlsink.RowChangeCallbackableEvent
+	for _, table := range []model.TableName{
+		{Schema: "test", Table: "t1"},
+		{Schema: "test", Table: "t2"},
+		{Schema: "other", Table: "t1"},
+	} {
+		table := table
+		events = append(events, &dmlsink.RowChangeCallbackableEvent{
+			Event: &model.RowChangedEvent{
+				CommitTs: 1,
+				Table:    &table,
+				Columns:  []*model.Column{{Name: "col1", Type: 1, Value: "aa"}},
+			},
+			Callback:  func() { acked.Inc() },
+			SinkState: &tableStatus,
+		})
+	}
+	require.NoError(t, s.WriteEvents(events...))
+
+	require.Eventually(t, func() bool {
+		return client.Pending() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	// the checkpoint advances only after messages are acked.
+	require.Zero(t, acked.Load())
+	client.Ack(nil)
+	require.Eventually(t, func() bool {
+		return acked.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, client.Messages("tidb.test.t1"), 1)
+	require.Len(t, client.Messages("tidb.test.t2"), 1)
+	require.Len(t, client.Messages("tidb.default"), 1)
+	require.Len(t, errCh, 0)
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/manager"
+	"github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
+	tiflowutil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// NewRabbitMQDMLSink will verify the config and create a RabbitMQ sink.
+// The exchange in the sink URI is the default exchange, and the topic
+// expressions in dispatchers are used as exchange templates of tables.
+func NewRabbitMQDMLSink(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	errCh chan error,
+	clientCreator rabbitmq.Factory,
+) (_ *dmlSink, err error) {
+	exchange, err := util.GetTopic(sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	options := rabbitmq.NewOptions()
+	useTLS := strings.ToLower(sinkURI.Scheme) == sink.RabbitMQSSLScheme
+	if err := options.Apply(sinkURI, useTLS); err != nil {
+		return nil, cerror.WrapError(cerror.ErrRabbitMQInvalidConfig, err)
+	}
+
+	protocol, err := util.GetProtocol(
+		tiflowutil.GetOrZero(replicaConfig.Sink.Protocol),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	log.Info("Try to create a DML sink producer",
+		zap.String("routingKey", options.RoutingKey),
+		zap.Duration("messageTTL", options.MessageTTL),
+		zap.Bool("persistent", options.Persistent),
+		zap.Int("maxPendingConfirms", options.MaxPendingConfirms))
+	client, err := clientCreator(options, changefeedID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRabbitMQNewClient, err)
+	}
+	// The client is closed by the producer.
+	p := dmlproducer.NewRabbitMQDMLProducer(ctx, changefeedID, client, options, errCh)
+	// Preventing leaks when error occurs.
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+
+	exchangeManager := manager.NewRabbitMQExchangeManager(changefeedID, client)
+	if _, err := exchangeManager.CreateTopicAndWaitUntilVisible(ctx, exchange); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, exchange)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig,
+		options.MaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if options.IsRoutingKeyTemplated() {
+		switch protocol {
+		case config.ProtocolCanal, config.ProtocolMaxwell:
+			// Messages of these protocols don't carry the schema and table.
+			return nil, cerror.ErrRabbitMQInvalidConfig.GenWithStack(
+				"routing-key %s is not supported by protocol %s", options.RoutingKey, protocol)
+		}
+		// Rows of different tables may be batched into one message, which can
+		// only be routed by one routing key.
+		encoderConfig.MaxBatchSize = 1
+	}
+
+	s, err := newDMLSink(
+		ctx, changefeedID, p, nil, exchangeManager,
+		eventRouter, encoderConfig,
+		tiflowutil.GetOrZero(replicaConfig.Sink.EncoderConcurrency),
+		errCh,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/rabbitmq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestNewRabbitMQDMLSinkFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	sinkURI, err := url.Parse("rabbitmq://127.0.0.1:5672/tidb?protocol=open-protocol")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	client := rabbitmq.NewMockClient()
+	s, err := NewRabbitMQDMLSink(ctx, changefeedID, sinkURI,
+		replicaConfig, make(chan error, 1), rabbitmq.NewMockFactory(client))
+	require.ErrorContains(t, err, "rabbitmq exchange tidb not found")
+	require.Nil(t, s)
+	require.True(t, client.Closed())
+
+	// maxwell messages don't carry the schema and table.
+	sinkURI, err = url.Parse("rabbitmq://127.0.0.1:5672/tidb?protocol=maxwell")
+	require.NoError(t, err)
+	replicaConfig = config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	client = rabbitmq.NewMockClient()
+	client.AddExchange("tidb")
+	s, err = NewRabbitMQDMLSink(ctx, changefeedID, sinkURI,
+		replicaConfig, make(chan error, 1), rabbitmq.NewMockFactory(client))
+	require.ErrorContains(t, err, "is not supported by protocol maxwell")
+	require.Nil(t, s)
+}
+
+func TestRabbitMQWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("rabbitmq://127.0.0.1:5672/tidb?" +
+		"protocol=open-protocol&routing-key=cdc.{schema}.{table}")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:   []string{"test.*"},
+			TopicRule: "tidb_{schema}",
+		},
+	}
+
+	client := rabbitmq.NewMockClient()
+	client.AddExchange("tidb", "tidb_test")
+	client.SetManualConfirm(true)
+	errCh := make(chan error, 1)
+	s, err := NewRabbitMQDMLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI,
+		replicaConfig, errCh, rabbitmq.NewMockFactory(client))
+	require.NoError(t, err)
+	defer s.Close()
+
+	tableStatus := state.TableSinkSinking
+	var confirmed atomic.Int64
+	var events []*dmlsink.RowChangeCallbackableEvent
+	for _, table := range []model.TableName{
+		{Schema: "test", Table: "t1"},
+		{Schema: "test", Table: "t2"},
+		{Schema: "other", Table: "t1"},
+	} {
+		table := table
+		events = append(events, &dmlsink.RowChangeCallbackableEvent{
+			Event: &model.RowChangedEvent{
+				CommitTs: 1,
+				Table:    &table,
+				Columns:  []*model.Column{{Name: "col1", Type: 1, Value: "aa"}},
+			},
+			Callback:  func() { confirmed.Inc() },
+			SinkState: &tableStatus,
+		})
+	}
+	require.NoError(t, s.WriteEvents(events...))
+
+	require.Eventually(t, func() bool {
+		return client.Pending() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	// the checkpoint advances only after messages are confirmed.
+	require.Zero(t, confirmed.Load())
+	client.Confirm(true)
+	require.Eventually(t, func() bool {
+		return confirmed.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var routingKeys []string
+	for _, msg := range client.Messages("tidb_test") {
+		routingKeys = append(routingKeys, msg.RoutingKey)
+	}
+	require.ElementsMatch(t, []string{"cdc.test.t1", "cdc.test.t2"}, routingKeys)
+	messages := client.Messages("tidb")
+	require.Len(t, messages, 1)
+	require.Equal(t, "cdc.other.t1", messages[0].RoutingKey)
+	require.Len(t, errCh, 0)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/pkg/causality"
 	"go.uber.org/zap"
 )
 
@@ -40,32 +41,43 @@ func (e *txnEvent) OnConflictResolved() {
 }
 
 // ConflictKeys implements causality.txnEvent interface.
-func (e *txnEvent) ConflictKeys(numSlots uint64) []uint64 {
+func (e *txnEvent) ConflictKeys(numSlots uint64) []causality.ConflictKey {
 	keys := genTxnKeys(e.TxnCallbackableEvent.Event)
-	sort.Slice(keys, func(i, j int) bool { return keys[i]%numSlots < keys[j]%numSlots })
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Hash%numSlots < keys[j].Hash%numSlots })
 	return keys
 }
 
-// genTxnKeys returns hash keys for `txn`.
-func genTxnKeys(txn *model.SingleTableTxn) []uint64 {
+// WorkerGroup implements causality.groupedTxnEvent interface.
+func (e *txnEvent) WorkerGroup() int64 {
+	if table := e.TxnCallbackableEvent.Event.Table; table != nil {
+		return table.TableID
+	}
+	return 0
+}
+
+// genTxnKeys returns conflict keys for `txn`. Keys are compared by their
+// primary key and unique key values, so hash collisions don't introduce
+// false conflicts.
+func genTxnKeys(txn *model.SingleTableTxn) []causality.ConflictKey {
 	if len(txn.Rows) == 0 {
 		return nil
 	}
-	hashRes := make(map[uint64]struct{}, len(txn.Rows))
-	hasher := fnv.New32a()
+	keySet := make(map[string]struct{}, len(txn.Rows))
+	keys := make([]causality.ConflictKey, 0, len(txn.Rows))
+	hasher := fnv.New64a()
 	for _, row := range txn.Rows {
 		for _, key := range genRowKeys(row) {
+			if _, ok := keySet[string(key)]; ok {
+				continue
+			}
+			keySet[string(key)] = struct{}{}
 			if n, err := hasher.Write(key); n != len(key) || err != nil {
 				log.Panic("transaction key hash fail")
 			}
-			hashRes[uint64(hasher.Sum32())] = struct{}{}
+			keys = append(keys, causality.ConflictKey{Hash: hasher.Sum64(), Value: string(key)})
 			hasher.Reset()
 		}
 	}
-	keys := make([]uint64, 0, len(hashRes))
-	for key := range hashRes {
-		keys = append(keys, key)
-	}
 	return keys
 }
 
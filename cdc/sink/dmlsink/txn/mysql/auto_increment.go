@@ -0,0 +1,164 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"math"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+)
+
+// autoIncrementColumns returns names of the auto-increment and auto-random
+// columns of the table.
+func autoIncrementColumns(info *model.TableInfo) map[string]struct{} {
+	if info == nil || info.TableInfo == nil {
+		return nil
+	}
+	cols := make(map[string]struct{})
+	if col := info.GetAutoIncrementColInfo(); col != nil {
+		cols[col.Name.O] = struct{}{}
+	}
+	if info.PKIsHandle && info.ContainsAutoRandomBits() {
+		if col := info.GetPkColInfo(); col != nil {
+			cols[col.Name.O] = struct{}{}
+		}
+	}
+	return cols
+}
+
+// applyAutoIncrementRule applies the auto-increment rule to rows of the
+// transaction. Rows are shallow copied before they're modified, the original
+// rows may be shared with others, e.g. the redo log.
+func applyAutoIncrementRule(rule *pmysql.AutoIncrementRule, txn *model.SingleTableTxn) error {
+	if rule == nil || rule.Mode == pmysql.AutoIncrementModePreserve || len(txn.Rows) == 0 {
+		return nil
+	}
+	info := txn.TableInfo
+	if info == nil {
+		info = txn.Rows[0].TableInfo
+	}
+	cols := autoIncrementColumns(info)
+	if len(cols) == 0 {
+		return nil
+	}
+
+	rows := make([]*model.RowChangedEvent, 0, len(txn.Rows))
+	for _, row := range txn.Rows {
+		copied := *row
+		var err error
+		switch rule.Mode {
+		case pmysql.AutoIncrementModeStrip:
+			// Values are written by the downstream, so rows can't be located by
+			// them in updates and deletes.
+			if len(row.PreColumns) != 0 && !locatableWithout(row.PreColumns, cols) {
+				return cerror.ErrMySQLInvalidConfig.GenWithStack(
+					"auto-increment columns of table %s can't be stripped, "+
+						"since they're required to locate rows in updates and deletes",
+					txn.Table.String())
+			}
+			copied.Columns = stripColumns(row.Columns, cols)
+		case pmysql.AutoIncrementModeRemap:
+			if copied.Columns, err = remapColumns(row.Columns, cols, rule.Offset, txn.Table); err != nil {
+				return err
+			}
+			if copied.PreColumns, err = remapColumns(row.PreColumns, cols, rule.Offset, txn.Table); err != nil {
+				return err
+			}
+		}
+		rows = append(rows, &copied)
+	}
+	txn.Rows = rows
+	return nil
+}
+
+// locatableWithout returns true if rows can be located by the handle key
+// columns without the given columns.
+func locatableWithout(preCols []*model.Column, cols map[string]struct{}) bool {
+	hasHandleKey := false
+	for _, col := range preCols {
+		if col == nil || !col.Flag.IsHandleKey() {
+			continue
+		}
+		if _, ok := cols[col.Name]; ok {
+			return false
+		}
+		hasHandleKey = true
+	}
+	return hasHandleKey
+}
+
+// stripColumns marks the given columns as generated columns, whose values
+// are omitted in inserted and updated rows.
+func stripColumns(columns []*model.Column, cols map[string]struct{}) []*model.Column {
+	if len(columns) == 0 {
+		return columns
+	}
+	res := make([]*model.Column, 0, len(columns))
+	for _, col := range columns {
+		if col != nil {
+			if _, ok := cols[col.Name]; ok {
+				stripped := *col
+				stripped.Flag.SetIsGeneratedColumn()
+				col = &stripped
+			}
+		}
+		res = append(res, col)
+	}
+	return res
+}
+
+// remapColumns adds the offset to values of the given columns.
+func remapColumns(
+	columns []*model.Column, cols map[string]struct{}, offset int64, table *model.TableName,
+) ([]*model.Column, error) {
+	if len(columns) == 0 {
+		return columns, nil
+	}
+	res := make([]*model.Column, 0, len(columns))
+	for _, col := range columns {
+		if col == nil || col.Value == nil {
+			res = append(res, col)
+			continue
+		}
+		if _, ok := cols[col.Name]; !ok {
+			res = append(res, col)
+			continue
+		}
+		remapped := *col
+		switch v := col.Value.(type) {
+		case int64:
+			if v > math.MaxInt64-offset {
+				return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+					"value %d of column %s of table %s overflows by offset %d",
+					v, col.Name, table.String(), offset)
+			}
+			remapped.Value = v + offset
+		case uint64:
+			if v > math.MaxUint64-uint64(offset) {
+				return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+					"value %d of column %s of table %s overflows by offset %d",
+					v, col.Name, table.String(), offset)
+			}
+			remapped.Value = v + uint64(offset)
+		default:
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"column %s of table %s can't be remapped, its value %v isn't an integer",
+				col.Name, table.String(), col.Value)
+		}
+		res = append(res, &remapped)
+	}
+	return res, nil
+}
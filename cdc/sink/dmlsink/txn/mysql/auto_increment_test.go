@@ -0,0 +1,152 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func newAutoIncrementTableInfo() *model.TableInfo {
+	id := types.NewFieldType(mysql.TypeLonglong)
+	id.SetFlag(mysql.PriKeyFlag | mysql.AutoIncrementFlag | mysql.NotNullFlag)
+	name := types.NewFieldType(mysql.TypeVarchar)
+	return model.WrapTableInfo(1, "test", 1, &timodel.TableInfo{
+		ID:         100,
+		Name:       timodel.NewCIStr("t"),
+		PKIsHandle: true,
+		Columns: []*timodel.ColumnInfo{
+			{ID: 1, Name: timodel.NewCIStr("id"), FieldType: *id, State: timodel.StatePublic},
+			{ID: 2, Name: timodel.NewCIStr("name"), FieldType: *name, State: timodel.StatePublic},
+		},
+	})
+}
+
+func newAutoIncrementColumns(id interface{}, name string, handleKey bool) []*model.Column {
+	idFlag := model.PrimaryKeyFlag
+	if handleKey {
+		idFlag |= model.HandleKeyFlag
+	}
+	nameFlag := model.ColumnFlagType(0)
+	if !handleKey {
+		nameFlag = model.HandleKeyFlag | model.UniqueKeyFlag
+	}
+	return []*model.Column{
+		{Name: "id", Type: mysql.TypeLonglong, Flag: idFlag, Value: id},
+		{Name: "name", Type: mysql.TypeVarchar, Flag: nameFlag, Value: name},
+	}
+}
+
+func TestAutoIncrementColumns(t *testing.T) {
+	t.Parallel()
+
+	info := newAutoIncrementTableInfo()
+	require.Equal(t, map[string]struct{}{"id": {}}, autoIncrementColumns(info))
+
+	info.TableInfo.Columns[0].SetFlag(mysql.PriKeyFlag)
+	require.Empty(t, autoIncrementColumns(info))
+	info.TableInfo.AutoRandomBits = 5
+	require.Equal(t, map[string]struct{}{"id": {}}, autoIncrementColumns(info))
+	require.Nil(t, autoIncrementColumns(nil))
+}
+
+func TestApplyAutoIncrementRemap(t *testing.T) {
+	t.Parallel()
+
+	table := &model.TableName{Schema: "test", Table: "t"}
+	row := &model.RowChangedEvent{
+		Table:      table,
+		PreColumns: newAutoIncrementColumns(int64(1), "a", true),
+		Columns:    newAutoIncrementColumns(int64(2), "b", true),
+	}
+	txn := &model.SingleTableTxn{
+		Table: table, TableInfo: newAutoIncrementTableInfo(),
+		Rows: []*model.RowChangedEvent{row},
+	}
+	rule := &pmysql.AutoIncrementRule{Mode: pmysql.AutoIncrementModeRemap, Offset: 1000}
+	require.NoError(t, applyAutoIncrementRule(rule, txn))
+	require.Equal(t, int64(1001), txn.Rows[0].PreColumns[0].Value)
+	require.Equal(t, int64(1002), txn.Rows[0].Columns[0].Value)
+	require.Equal(t, "b", txn.Rows[0].Columns[1].Value)
+	// The original row is not modified.
+	require.Equal(t, int64(1), row.PreColumns[0].Value)
+	require.Equal(t, int64(2), row.Columns[0].Value)
+
+	txn.Rows = []*model.RowChangedEvent{{
+		Table: table, Columns: newAutoIncrementColumns(uint64(3), "c", true),
+	}}
+	require.NoError(t, applyAutoIncrementRule(rule, txn))
+	require.Equal(t, uint64(1003), txn.Rows[0].Columns[0].Value)
+
+	txn.Rows = []*model.RowChangedEvent{{
+		Table: table, Columns: newAutoIncrementColumns(int64(math.MaxInt64-1), "d", true),
+	}}
+	require.Regexp(t, "overflows", applyAutoIncrementRule(rule, txn))
+
+	// Rows are preserved without a rule or in the preserve mode.
+	txn.Rows = []*model.RowChangedEvent{row}
+	require.NoError(t, applyAutoIncrementRule(nil, txn))
+	require.NoError(t, applyAutoIncrementRule(
+		&pmysql.AutoIncrementRule{Mode: pmysql.AutoIncrementModePreserve}, txn))
+	require.Same(t, row, txn.Rows[0])
+}
+
+func TestApplyAutoIncrementStrip(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms := newMySQLBackendWithoutDB(ctx)
+	ms.cfg.SafeMode = false
+	ms.cfg.EnableOldValue = true
+
+	table := &model.TableName{Schema: "test", Table: "t"}
+	rule := &pmysql.AutoIncrementRule{Mode: pmysql.AutoIncrementModeStrip}
+	txn := &model.SingleTableTxn{
+		Table: table, TableInfo: newAutoIncrementTableInfo(),
+		Rows: []*model.RowChangedEvent{{
+			CommitTs: 2, ReplicatingTs: 1,
+			Table: table, Columns: newAutoIncrementColumns(int64(1), "a", true),
+		}, {
+			CommitTs: 2, ReplicatingTs: 1, Table: table,
+			PreColumns: newAutoIncrementColumns(int64(2), "b", false),
+			Columns:    newAutoIncrementColumns(int64(2), "c", false),
+		}},
+	}
+	require.NoError(t, applyAutoIncrementRule(rule, txn))
+	ms.events = []*dmlsink.TxnCallbackableEvent{{Event: txn}}
+	ms.rows = len(txn.Rows)
+	dmls := ms.prepareDMLs()
+	require.Equal(t, []string{
+		"INSERT INTO `test`.`t` (`name`) VALUES (?)",
+		"UPDATE `test`.`t` SET `name`=? WHERE `name`=? LIMIT 1",
+	}, dmls.sqls)
+	require.Equal(t, [][]interface{}{{"a"}, {"c", "b"}}, dmls.values)
+
+	// Rows can't be located without the stripped handle key.
+	txn.Rows = []*model.RowChangedEvent{{
+		Table:      table,
+		PreColumns: newAutoIncrementColumns(int64(3), "d", true),
+	}}
+	require.Regexp(t, "ErrMySQLInvalidConfig", applyAutoIncrementRule(rule, txn))
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/metrics/txn"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+// maxEndpointCheckFailures is the number of consecutive failed health checks
+// after which an endpoint is considered as unhealthy.
+const maxEndpointCheckFailures = 3
+
+// endpointChecker actively checks the health of downstream endpoints, and
+// reports connection pool stats of the endpoint that the sink writes to.
+//
+// If the active endpoint becomes unhealthy or read-only while another endpoint
+// is healthy and writable, the checker fails the sink, so that the sink is
+// recreated on a writable endpoint.
+type endpointChecker struct {
+	changefeedID  model.ChangeFeedID
+	dsn           *dmysql.Config
+	endpoints     []string
+	interval      time.Duration
+	dbConnFactory pmysql.Factory
+	// db is the connection pool of the active endpoint.
+	db *sql.DB
+
+	failures map[string]int
+	readOnly map[string]bool
+	probes   map[string]*sql.DB
+
+	mu  sync.Mutex
+	err error
+
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+func newEndpointChecker(
+	changefeedID model.ChangeFeedID,
+	dsn *dmysql.Config,
+	cfg *pmysql.Config,
+	dbConnFactory pmysql.Factory,
+	db *sql.DB,
+) *endpointChecker {
+	return &endpointChecker{
+		changefeedID:  changefeedID,
+		dsn:           dsn,
+		endpoints:     cfg.Endpoints,
+		interval:      cfg.HealthCheckInterval,
+		dbConnFactory: dbConnFactory,
+		db:            db,
+		failures:      make(map[string]int),
+		readOnly:      make(map[string]bool),
+		probes:        make(map[string]*sql.DB),
+	}
+}
+
+func (c *endpointChecker) start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.check(ctx); err != nil {
+					log.Warn("downstream endpoint failover is required",
+						zap.String("namespace", c.changefeedID.Namespace),
+						zap.String("changefeed", c.changefeedID.ID),
+						zap.Error(err))
+					c.mu.Lock()
+					c.err = err
+					c.mu.Unlock()
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Err returns the error if the sink needs to fail over to another endpoint.
+func (c *endpointChecker) Err() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *endpointChecker) close() {
+	if c == nil {
+		return
+	}
+	c.closeOnce.Do(func() {
+		if c.cancel != nil {
+			c.cancel()
+		}
+		c.wg.Wait()
+		for endpoint, db := range c.probes {
+			_ = db.Close()
+			delete(c.probes, endpoint)
+		}
+		for _, endpoint := range c.endpoints {
+			txn.EndpointHealth.DeleteLabelValues(
+				c.changefeedID.Namespace, c.changefeedID.ID, endpoint)
+			txn.EndpointCheckDuration.DeleteLabelValues(
+				c.changefeedID.Namespace, c.changefeedID.ID, endpoint)
+		}
+		for _, state := range []string{"open", "in_use", "idle", "wait"} {
+			txn.EndpointConnections.DeleteLabelValues(
+				c.changefeedID.Namespace, c.changefeedID.ID, c.dsn.Addr, state)
+		}
+	})
+}
+
+// check checks all endpoints, it returns an error if the sink should fail
+// over to another endpoint.
+func (c *endpointChecker) check(ctx context.Context) error {
+	c.observeConnections()
+	// Nothing to fail over to.
+	if len(c.endpoints) <= 1 {
+		return nil
+	}
+	for _, endpoint := range c.endpoints {
+		c.probe(ctx, endpoint)
+	}
+
+	active := c.dsn.Addr
+	if c.writable(active) {
+		return nil
+	}
+	for _, endpoint := range c.endpoints {
+		if endpoint != active && c.writable(endpoint) {
+			return cerror.ErrMySQLEndpointUnhealthy.GenWithStackByArgs(active, endpoint)
+		}
+	}
+	// All endpoints are unavailable, keep writing to the active one.
+	return nil
+}
+
+func (c *endpointChecker) writable(endpoint string) bool {
+	return c.failures[endpoint] < maxEndpointCheckFailures && !c.readOnly[endpoint]
+}
+
+func (c *endpointChecker) probe(ctx context.Context, endpoint string) {
+	start := time.Now()
+	readOnly, err := c.queryReadOnly(ctx, endpoint)
+	txn.EndpointCheckDuration.
+		WithLabelValues(c.changefeedID.Namespace, c.changefeedID.ID, endpoint).
+		Observe(time.Since(start).Seconds())
+	if err != nil {
+		c.failures[endpoint]++
+		log.Warn("downstream endpoint health check failed",
+			zap.String("namespace", c.changefeedID.Namespace),
+			zap.String("changefeed", c.changefeedID.ID),
+			zap.String("endpoint", endpoint),
+			zap.Int("failures", c.failures[endpoint]),
+			zap.Error(err))
+	} else {
+		c.failures[endpoint] = 0
+		c.readOnly[endpoint] = readOnly
+	}
+
+	health := 0.0
+	if c.writable(endpoint) {
+		health = 1
+	}
+	txn.EndpointHealth.
+		WithLabelValues(c.changefeedID.Namespace, c.changefeedID.ID, endpoint).
+		Set(health)
+}
+
+func (c *endpointChecker) queryReadOnly(ctx context.Context, endpoint string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+	db, ok := c.probes[endpoint]
+	if !ok {
+		dsn := c.dsn.Clone()
+		dsn.Addr = endpoint
+		var err error
+		db, err = c.dbConnFactory(ctx, dsn.FormatDSN())
+		if err != nil {
+			return false, err
+		}
+		db.SetMaxOpenConns(1)
+		c.probes[endpoint] = db
+	}
+	readOnly, err := pmysql.QueryReadOnly(ctx, db)
+	if err != nil {
+		// Reconnect in the next check.
+		_ = db.Close()
+		delete(c.probes, endpoint)
+		return false, err
+	}
+	return readOnly, nil
+}
+
+func (c *endpointChecker) observeConnections() {
+	stats := c.db.Stats()
+	for state, value := range map[string]float64{
+		"open":   float64(stats.OpenConnections),
+		"in_use": float64(stats.InUse),
+		"idle":   float64(stats.Idle),
+		"wait":   float64(stats.WaitCount),
+	} {
+		txn.EndpointConnections.
+			WithLabelValues(c.changefeedID.Namespace, c.changefeedID.ID, c.dsn.Addr, state).
+			Set(value)
+	}
+}
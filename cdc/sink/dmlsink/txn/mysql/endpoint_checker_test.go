@@ -0,0 +1,127 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointCheckerFailover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// down and readOnly are states of endpoints.
+	down := make(map[string]bool)
+	readOnly := make(map[string]int)
+	factory := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		dsn, err := dmysql.ParseDSN(dsnStr)
+		require.Nil(t, err)
+		if down[dsn.Addr] {
+			return nil, errors.New("connection refused")
+		}
+		db, mock, err := sqlmock.New()
+		require.Nil(t, err)
+		mock.ExpectQuery("SELECT @@GLOBAL.read_only;").
+			WillReturnRows(sqlmock.NewRows([]string{"@@GLOBAL.read_only"}).
+				AddRow(readOnly[dsn.Addr]))
+		return db, nil
+	}
+	pool, _, err := sqlmock.New()
+	require.Nil(t, err)
+	defer pool.Close()
+
+	dsn, err := dmysql.ParseDSN("root:@tcp(a:3306)/")
+	require.Nil(t, err)
+	cfg := pmysql.NewConfig()
+	cfg.Endpoints = []string{"a:3306", "b:3306"}
+	cfg.HealthCheckInterval = time.Second
+	checker := newEndpointChecker(model.DefaultChangeFeedID("test"), dsn, cfg, factory, pool)
+	defer checker.close()
+	check := func() error {
+		err := checker.check(ctx)
+		// Reconnect in every check, so that states of endpoints are refreshed.
+		for endpoint, db := range checker.probes {
+			_ = db.Close()
+			delete(checker.probes, endpoint)
+		}
+		return err
+	}
+
+	require.Nil(t, check())
+	require.True(t, checker.writable("a:3306"))
+	require.True(t, checker.writable("b:3306"))
+
+	// The active endpoint becomes read-only.
+	readOnly["a:3306"] = 1
+	require.Regexp(t, "ErrMySQLEndpointUnhealthy", check())
+	readOnly["a:3306"] = 0
+	require.Nil(t, check())
+
+	// The active endpoint is unreachable.
+	down["a:3306"] = true
+	for i := 0; i < maxEndpointCheckFailures-1; i++ {
+		require.Nil(t, check())
+	}
+	err = check()
+	require.Regexp(t, "ErrMySQLEndpointUnhealthy", err)
+	require.Contains(t, err.Error(), "failover to b:3306")
+
+	// Keep writing to the active endpoint if no endpoint is writable.
+	readOnly["b:3306"] = 1
+	require.Nil(t, check())
+	require.False(t, checker.writable("b:3306"))
+}
+
+func TestEndpointCheckerStart(t *testing.T) {
+	t.Parallel()
+
+	pool, _, err := sqlmock.New()
+	require.Nil(t, err)
+	defer pool.Close()
+	dsn, err := dmysql.ParseDSN("root:@tcp(a:3306)/")
+	require.Nil(t, err)
+	cfg := pmysql.NewConfig()
+	cfg.Endpoints = []string{"a:3306", "b:3306"}
+	cfg.HealthCheckInterval = 10 * time.Millisecond
+	factory := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		if dsn, _ := dmysql.ParseDSN(dsnStr); dsn.Addr == "a:3306" {
+			return nil, errors.New("connection refused")
+		}
+		db, mock, err := sqlmock.New()
+		require.Nil(t, err)
+		mock.ExpectQuery("SELECT @@GLOBAL.read_only;").
+			WillReturnRows(sqlmock.NewRows([]string{"@@GLOBAL.read_only"}).AddRow(0))
+		return db, nil
+	}
+	checker := newEndpointChecker(model.DefaultChangeFeedID("test"), dsn, cfg, factory, pool)
+	checker.start(context.Background())
+	defer checker.close()
+
+	require.Eventually(t, func() bool {
+		return checker.Err() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	// The error is reported to the backend.
+	backend := &mysqlBackend{checker: checker, rows: 1}
+	require.Regexp(t, "ErrMySQLEndpointUnhealthy", backend.Flush(context.Background()))
+}
@@ -59,7 +59,9 @@ const (
 )
 
 type mysqlBackend struct {
-	workerID    int
+	workerID int
+	// workerGroup is the worker group that the backend belongs to.
+	workerGroup string
 	changefeed  string
 	db          *sql.DB
 	cfg         *pmysql.Config
@@ -67,6 +69,12 @@ type mysqlBackend struct {
 
 	events []*dmlsink.TxnCallbackableEvent
 	rows   int
+	// maxTxnRow is the max number of rows of the buffered transaction, it's
+	// the smallest max-txn-row of its tables if txnRowSizer is enabled.
+	maxTxnRow int
+	// txnRowSizer is shared by backends of a worker group, it is nil if
+	// adaptive-txn-row is disabled.
+	txnRowSizer *txnRowSizer
 
 	statistics                      *metrics.Statistics
 	metricTxnSinkDMLBatchCommit     prometheus.Observer
@@ -78,6 +86,12 @@ type mysqlBackend struct {
 	// Indicate if the CachePrepStmts should be enabled or not
 	cachePrepStmts   bool
 	maxAllowedPacket int64
+
+	// checker is shared by all backends, it is nil if health checks are disabled.
+	checker *endpointChecker
+	// sessionVariables is shared by all backends, it is nil if there is no
+	// session variable rule.
+	sessionVariables *sessionVariables
 }
 
 // NewMySQLBackends creates a new MySQL sink using schema storage
@@ -130,8 +144,9 @@ func NewMySQLBackends(
 	// This issue is less likely to occur when the connection pool is larger,
 	// as there are more connections available for use.
 	// Adding an extra connection to the connection pool solves the connection exhaustion issue.
-	db.SetMaxIdleConns(cfg.WorkerCount + 1)
-	db.SetMaxOpenConns(cfg.WorkerCount + 1)
+	totalWorkerCount := cfg.TotalWorkerCount()
+	db.SetMaxIdleConns(totalWorkerCount + 1)
+	db.SetMaxOpenConns(totalWorkerCount + 1)
 
 	// Inherit the default value of the prepared statement cache from the SinkURI Options
 	cachePrepStmts := cfg.CachePrepStmts
@@ -147,10 +162,10 @@ func NewMySQLBackends(
 		}
 		// if maxPreparedStmtCount == 0,
 		// it means that the prepared statement cache is disabled on serverside.
-		// if maxPreparedStmtCount/(cfg.WorkerCount+1) == 0, for each single connection,
+		// if maxPreparedStmtCount/(totalWorkerCount+1) == 0, for each single connection,
 		// it means that the prepared statement cache is disabled on clientsize.
 		// Because each connection can not hold at lease one prepared statement.
-		if maxPreparedStmtCount == 0 || maxPreparedStmtCount/(cfg.WorkerCount+1) == 0 {
+		if maxPreparedStmtCount == 0 || maxPreparedStmtCount/(totalWorkerCount+1) == 0 {
 			cachePrepStmts = false
 		}
 	}
@@ -173,30 +188,61 @@ func NewMySQLBackends(
 		maxAllowedPacket = int64(variable.DefMaxAllowedPacket)
 	}
 
-	backends := make([]*mysqlBackend, 0, cfg.WorkerCount)
-	for i := 0; i < cfg.WorkerCount; i++ {
-		backends = append(backends, &mysqlBackend{
-			workerID:    i,
-			changefeed:  changefeed,
-			db:          db,
-			cfg:         cfg,
-			dmlMaxRetry: defaultDMLMaxRetry,
-			statistics:  statistics,
-
-			metricTxnSinkDMLBatchCommit:     txn.SinkDMLBatchCommit.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
-			metricTxnSinkDMLBatchCallback:   txn.SinkDMLBatchCallback.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
-			metricTxnPrepareStatementErrors: txn.PrepareStatementErrors.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
-			stmtCache:                       stmtCache,
-			cachePrepStmts:                  cachePrepStmts,
-			maxAllowedPacket:                maxAllowedPacket,
-		})
+	dsn, err := dmysql.ParseDSN(dsnStr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var checker *endpointChecker
+	if cfg.HealthCheckInterval > 0 {
+		checker = newEndpointChecker(changefeedID, dsn, cfg, dbConnFactory, db)
+		checker.start(ctx)
+	}
+	sessionVariables := newSessionVariables(cfg, dsn.Params)
+
+	backends := make([]*mysqlBackend, 0, totalWorkerCount)
+	addBackends := func(workerGroup string, cfg *pmysql.Config, workerCount int) {
+		var sizer *txnRowSizer
+		if cfg.AdaptiveTxnRow {
+			sizer = newTxnRowSizer(cfg.MaxTxnRow)
+		}
+		for i := 0; i < workerCount; i++ {
+			backends = append(backends, &mysqlBackend{
+				workerID:    len(backends),
+				workerGroup: workerGroup,
+				changefeed:  changefeed,
+				db:          db,
+				cfg:         cfg,
+				dmlMaxRetry: defaultDMLMaxRetry,
+				statistics:  statistics,
+
+				metricTxnSinkDMLBatchCommit:     txn.SinkDMLBatchCommit.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+				metricTxnSinkDMLBatchCallback:   txn.SinkDMLBatchCallback.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+				metricTxnPrepareStatementErrors: txn.PrepareStatementErrors.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+				stmtCache:                       stmtCache,
+				cachePrepStmts:                  cachePrepStmts,
+				maxAllowedPacket:                maxAllowedPacket,
+				checker:                         checker,
+				txnRowSizer:                     sizer,
+				sessionVariables:                sessionVariables,
+			})
+		}
+	}
+	addBackends(pmysql.DefaultWorkerGroup, cfg, cfg.WorkerCount)
+	for _, group := range cfg.WorkerGroups {
+		// Backends of the group batch transactions by its own max-txn-row.
+		groupCfg := *cfg
+		groupCfg.MaxTxnRow = group.MaxTxnRow
+		addBackends(group.Name, &groupCfg, group.WorkerCount)
 	}
 
 	log.Info("MySQL backends is created",
 		zap.String("changefeed", changefeed),
 		zap.Int("workerCount", cfg.WorkerCount),
+		zap.Int("workerGroupCount", len(cfg.WorkerGroups)),
+		zap.Strings("endpoints", cfg.Endpoints),
 		zap.Bool("forceReplicate", cfg.ForceReplicate),
-		zap.Bool("enableOldValue", cfg.EnableOldValue))
+		zap.Bool("enableOldValue", cfg.EnableOldValue),
+		zap.Bool("adaptiveTxnRow", cfg.AdaptiveTxnRow))
 	return backends, nil
 }
 
@@ -205,7 +251,15 @@ func NewMySQLBackends(
 func (s *mysqlBackend) OnTxnEvent(event *dmlsink.TxnCallbackableEvent) (needFlush bool) {
 	s.events = append(s.events, event)
 	s.rows += len(event.Event.Rows)
-	return event.Event.ToWaitFlush() || s.rows >= s.cfg.MaxTxnRow
+	if s.txnRowSizer == nil {
+		s.maxTxnRow = s.cfg.MaxTxnRow
+	} else {
+		maxTxnRow := s.txnRowSizer.maxTxnRow(event.Event.Table.TableID)
+		if len(s.events) == 1 || maxTxnRow < s.maxTxnRow {
+			s.maxTxnRow = maxTxnRow
+		}
+	}
+	return event.Event.ToWaitFlush() || s.rows >= s.maxTxnRow
 }
 
 // Flush implements interface backend.
@@ -213,6 +267,9 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 	if s.rows == 0 {
 		return
 	}
+	if err := s.checker.Err(); err != nil {
+		return errors.Trace(err)
+	}
 
 	failpoint.Inject("MySQLSinkExecDMLError", func() {
 		// Add a delay to ensure the sink worker with `MySQLSinkHangLongTime`
@@ -224,6 +281,16 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 	for _, event := range s.events {
 		s.statistics.ObserveRows(event.Event.Rows...)
 	}
+	// Events are applied only once, they're dropped if the flush fails.
+	if len(s.cfg.AutoIncrementRules) > 0 {
+		for _, event := range s.events {
+			txn := event.Event
+			rule := s.cfg.AutoIncrementRuleOf(txn.Table.Schema, txn.Table.Table)
+			if err := applyAutoIncrementRule(rule, txn); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 
 	dmls := s.prepareDMLs()
 	log.Debug("prepare DMLs", zap.Any("rows", s.rows),
@@ -242,6 +309,17 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 	}
 	s.metricTxnSinkDMLBatchCommit.Observe(startCallback.Sub(start).Seconds())
 	s.metricTxnSinkDMLBatchCallback.Observe(time.Since(startCallback).Seconds())
+	if s.txnRowSizer != nil {
+		tableIDs := make([]model.TableID, 0, len(s.events))
+		seen := make(map[model.TableID]struct{}, len(s.events))
+		for _, event := range s.events {
+			if _, ok := seen[event.Event.Table.TableID]; !ok {
+				seen[event.Event.Table.TableID] = struct{}{}
+				tableIDs = append(tableIDs, event.Event.Table.TableID)
+			}
+		}
+		s.txnRowSizer.observe(tableIDs, s.rows, startCallback.Sub(start))
+	}
 
 	// Be friently to GC.
 	for i := 0; i < len(s.events); i++ {
@@ -257,6 +335,7 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 
 // Close implements interface backend.
 func (s *mysqlBackend) Close() (err error) {
+	s.checker.close()
 	if s.stmtCache != nil {
 		s.stmtCache.Purge()
 	}
@@ -272,6 +351,23 @@ func (s *mysqlBackend) MaxFlushInterval() time.Duration {
 	return maxFlushInterval
 }
 
+// WorkerCountPerTable returns the max number of workers that transactions
+// of one table can be dispatched to, 0 means no limit.
+func (s *mysqlBackend) WorkerCountPerTable() int {
+	return s.cfg.WorkerCountPerTable
+}
+
+// WorkerGroup returns the worker group that the backend belongs to.
+func (s *mysqlBackend) WorkerGroup() string {
+	return s.workerGroup
+}
+
+// WorkerGroupOf returns the worker group that transactions of the given
+// table are dispatched to.
+func (s *mysqlBackend) WorkerGroupOf(schema, table string) string {
+	return s.cfg.WorkerGroupOf(schema, table)
+}
+
 type preparedDMLs struct {
 	startTs         []model.Ts
 	sqls            []string
@@ -362,10 +458,17 @@ func (s *mysqlBackend) groupRowsByType(
 	if preAllocateSize > s.cfg.MaxTxnRow {
 		preAllocateSize = s.cfg.MaxTxnRow
 	}
+	maxInsertRow := s.cfg.MaxTxnRow
+	if s.cfg.MaxMultiInsertRowCount > 0 && s.cfg.MaxMultiInsertRowCount < maxInsertRow {
+		maxInsertRow = s.cfg.MaxMultiInsertRowCount
+	}
 
 	insertRow := make([]*sqlmodel.RowChange, 0, preAllocateSize)
-	updateRow := make([]*sqlmodel.RowChange, 0, preAllocateSize)
 	deleteRow := make([]*sqlmodel.RowChange, 0, preAllocateSize)
+	// Update rows are grouped by changed columns, so that rows in a group
+	// can be merged into one UPDATE statement which only sets changed columns.
+	var updateGroups []*updateRowGroup
+	updateGroupIdx := make(map[string]int)
 
 	for _, row := range event.Event.Rows {
 		convertBinaryToString(row.Columns)
@@ -375,7 +478,7 @@ func (s *mysqlBackend) groupRowsByType(
 			insertRow = append(
 				insertRow,
 				convert2RowChanges(row, tableInfo, sqlmodel.RowChangeInsert))
-			if len(insertRow) >= s.cfg.MaxTxnRow {
+			if len(insertRow) >= maxInsertRow {
 				insertRows = append(insertRows, insertRow)
 				insertRow = make([]*sqlmodel.RowChange, 0, preAllocateSize)
 			}
@@ -403,17 +506,24 @@ func (s *mysqlBackend) groupRowsByType(
 				insertRow = append(
 					insertRow,
 					convert2RowChanges(row, tableInfo, sqlmodel.RowChangeInsert))
-				if len(insertRow) >= s.cfg.MaxTxnRow {
+				if len(insertRow) >= maxInsertRow {
 					insertRows = append(insertRows, insertRow)
 					insertRow = make([]*sqlmodel.RowChange, 0, preAllocateSize)
 				}
 			} else {
-				updateRow = append(
-					updateRow,
-					convert2RowChanges(row, tableInfo, sqlmodel.RowChangeUpdate))
-				if len(updateRow) >= s.cfg.MaxMultiUpdateRowCount {
-					updateRows = append(updateRows, updateRow)
-					updateRow = make([]*sqlmodel.RowChange, 0, preAllocateSize)
+				change := convert2RowChanges(row, tableInfo, sqlmodel.RowChangeUpdate)
+				signature := fmt.Sprint(change.ChangedColumns())
+				idx, ok := updateGroupIdx[signature]
+				if !ok {
+					idx = len(updateGroups)
+					updateGroupIdx[signature] = idx
+					updateGroups = append(updateGroups, &updateRowGroup{})
+				}
+				group := updateGroups[idx]
+				group.rows = append(group.rows, change)
+				if len(group.rows) >= s.cfg.MaxMultiUpdateRowCount {
+					updateRows = append(updateRows, group.rows)
+					group.rows = nil
 				}
 			}
 		}
@@ -422,8 +532,10 @@ func (s *mysqlBackend) groupRowsByType(
 	if len(insertRow) > 0 {
 		insertRows = append(insertRows, insertRow)
 	}
-	if len(updateRow) > 0 {
-		updateRows = append(updateRows, updateRow)
+	for _, group := range updateGroups {
+		if len(group.rows) > 0 {
+			updateRows = append(updateRows, group.rows)
+		}
 	}
 	if len(deleteRow) > 0 {
 		deleteRows = append(deleteRows, deleteRow)
@@ -432,6 +544,11 @@ func (s *mysqlBackend) groupRowsByType(
 	return
 }
 
+// updateRowGroup is a group of update rows with same changed columns.
+type updateRowGroup struct {
+	rows []*sqlmodel.RowChange
+}
+
 func (s *mysqlBackend) batchSingleTxnDmls(
 	event *dmlsink.TxnCallbackableEvent,
 	tableInfo *timodel.TableInfo,
@@ -495,8 +612,8 @@ func (s *mysqlBackend) genUpdateSQL(rows ...*sqlmodel.RowChange) ([]string, [][]
 		count++
 	}
 	if size < s.cfg.MaxMultiUpdateRowSize*count {
-		// use multi update in one SQL
-		sql, value := sqlmodel.GenUpdateSQL(rows...)
+		// use multi update in one SQL, rows have same changed columns.
+		sql, value := sqlmodel.GenUpdateChangedColumnsSQL(rows...)
 		return []string{sql}, [][]interface{}{value}
 	}
 	// each row has one independent update SQL.
@@ -536,12 +653,27 @@ func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
 
 	rowCount := 0
 	approximateSize := int64(0)
+	// The session of the connection may be left with variables of any rule,
+	// so variables are always set before the first table if there are rules.
+	var sessionVariableRule *pmysql.SessionVariableRule
+	sessionVariablesSet := false
 	for _, event := range s.events {
 		if len(event.Event.Rows) == 0 {
 			continue
 		}
 		rowCount += len(event.Event.Rows)
 
+		if s.sessionVariables != nil {
+			rule := s.cfg.SessionVariableRuleOf(event.Event.Table.Schema, event.Event.Table.Table)
+			if !sessionVariablesSet || rule != sessionVariableRule {
+				stmt := s.sessionVariables.stmts[rule]
+				sqls = append(sqls, stmt)
+				values = append(values, nil)
+				approximateSize += int64(len(stmt))
+				sessionVariableRule, sessionVariablesSet = rule, true
+			}
+		}
+
 		firstRow := event.Event.Rows[0]
 		if len(startTs) == 0 || startTs[len(startTs)-1] != firstRow.StartTs {
 			startTs = append(startTs, firstRow.StartTs)
@@ -636,6 +768,15 @@ func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
 		}
 	}
 
+	// Restore changefeed level values, so that other queries of the
+	// connection are not affected.
+	if sessionVariableRule != nil {
+		stmt := s.sessionVariables.stmts[nil]
+		sqls = append(sqls, stmt)
+		values = append(values, nil)
+		approximateSize += int64(len(stmt))
+	}
+
 	if len(callbacks) == 0 {
 		callbacks = nil
 	}
@@ -813,8 +954,10 @@ func (s *mysqlBackend) execDMLWithMaxRetries(pctx context.Context, dmls *prepare
 			zap.String("changefeed", s.changefeed),
 			zap.Int("numOfRows", dmls.rowCount))
 		return nil
-	}, retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
+	}, retry.WithName("mysql_sink_dml"),
+		retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
 		retry.WithBackoffMaxDelay(pmysql.BackoffMaxDelay.Milliseconds()),
+		retry.WithDecorrelatedJitter(),
 		retry.WithMaxTries(s.dmlMaxRetry),
 		retry.WithIsRetryableErr(isRetryableDMLError))
 }
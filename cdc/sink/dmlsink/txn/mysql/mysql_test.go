@@ -41,6 +41,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/sink"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
@@ -693,6 +694,61 @@ func TestNewMySQLBackend(t *testing.T) {
 	require.Nil(t, sink.Close())
 }
 
+func TestNewMySQLBackendsWithWorkerGroups(t *testing.T) {
+	dbIndex := 0
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() { dbIndex++ }()
+
+		if dbIndex == 0 {
+			// test db
+			db, err := pmysql.MockTestDB(true)
+			require.Nil(t, err)
+			return db, nil
+		}
+
+		// normal db
+		db, mock := newTestMockDB(t)
+		mock.ExpectClose()
+		return db, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changefeedID := model.DefaultChangeFeedID("test-changefeed")
+	statistics := metrics.NewStatistics(ctx, changefeedID, sink.TxnSink)
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000/?time-zone=UTC&worker-count=2" +
+		"&max-txn-row=10&cache-prep-stmts=false")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{
+		WorkerGroups: []*config.MySQLWorkerGroup{{
+			Name:        "hot",
+			Matcher:     []string{"test.hot"},
+			WorkerCount: util.AddressOf(3),
+			MaxTxnRow:   util.AddressOf(100),
+		}},
+	}
+	backends, err := NewMySQLBackends(ctx, changefeedID,
+		sinkURI, replicaConfig, mockGetDBConn, statistics)
+	require.Nil(t, err)
+	require.Len(t, backends, 5)
+	for i, backend := range backends {
+		require.Equal(t, i, backend.workerID)
+		if i < 2 {
+			require.Equal(t, pmysql.DefaultWorkerGroup, backend.WorkerGroup())
+			require.Equal(t, 10, backend.cfg.MaxTxnRow)
+		} else {
+			require.Equal(t, "hot", backend.WorkerGroup())
+			require.Equal(t, 100, backend.cfg.MaxTxnRow)
+		}
+	}
+	require.Equal(t, "hot", backends[0].WorkerGroupOf("test", "hot"))
+	require.Equal(t, pmysql.DefaultWorkerGroup, backends[0].WorkerGroupOf("test", "t1"))
+	for _, backend := range backends {
+		require.Nil(t, backend.Close())
+	}
+}
+
 func TestNewMySQLBackendWithIPv6Address(t *testing.T) {
 	dbIndex := 0
 	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
@@ -1899,14 +1955,14 @@ func TestBackendGenUpdateSQL(t *testing.T) {
 		{
 			[]*sqlmodel.RowChange{row1, row2},
 			ms.cfg.MaxMultiUpdateRowCount,
+			// Only changed columns are updated.
 			[]string{
 				"UPDATE `db`.`tb1` SET " +
-					"`id`=CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END, " +
 					"`name`=CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END " +
 					"WHERE (`id` = ?) OR (`id` = ?)",
 			},
 			[][]interface{}{
-				{1, 1, 2, 2, 1, "aa", 2, "bb", 1, 2},
+				{1, "aa", 2, "bb", 1, 2},
 			},
 		},
 		{
@@ -1929,3 +1985,63 @@ func TestBackendGenUpdateSQL(t *testing.T) {
 		require.Equal(t, tc.expectedValues, values)
 	}
 }
+
+func TestGroupRowsByChangedColumns(t *testing.T) {
+	ctx := context.Background()
+	ms := newMySQLBackendWithoutDB(ctx)
+	ms.cfg.MaxMultiInsertRowCount = 2
+
+	table := &model.TableName{Schema: "db", Table: "tb"}
+	columns := func(id, name, age int) []*model.Column {
+		return []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: id,
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "name", Type: mysql.TypeLong, Value: name},
+			{Name: "age", Type: mysql.TypeLong, Value: age},
+		}
+	}
+	rows := []*model.RowChangedEvent{
+		// updates
+		{Table: table, PreColumns: columns(1, 1, 1), Columns: columns(1, 2, 1)},
+		{Table: table, PreColumns: columns(2, 1, 1), Columns: columns(2, 1, 2)},
+		{Table: table, PreColumns: columns(3, 1, 1), Columns: columns(3, 3, 1)},
+		// inserts
+		{Table: table, Columns: columns(4, 1, 1)},
+		{Table: table, Columns: columns(5, 1, 1)},
+		{Table: table, Columns: columns(6, 1, 1)},
+	}
+	for _, row := range rows {
+		row.IndexColumns = [][]int{{0}}
+	}
+	event := &dmlsink.TxnCallbackableEvent{
+		Event: &model.SingleTableTxn{Rows: rows},
+	}
+	tableInfo := model.BuildTiDBTableInfo(rows[0].Columns, rows[0].IndexColumns)
+	inserts, updates, deletes := ms.groupRowsByType(event, tableInfo, false)
+	require.Len(t, deletes, 0)
+
+	// Inserts are split by max-multi-insert-row.
+	require.Len(t, inserts, 2)
+	require.Len(t, inserts[0], 2)
+	require.Len(t, inserts[1], 1)
+
+	// Updates are grouped by changed columns.
+	require.Len(t, updates, 2)
+	require.Len(t, updates[0], 2)
+	require.Equal(t, []interface{}{1, 2, 1}, updates[0][0].GetPostValues())
+	require.Equal(t, []interface{}{3, 3, 1}, updates[0][1].GetPostValues())
+	require.Len(t, updates[1], 1)
+	require.Equal(t, []interface{}{2, 1, 2}, updates[1][0].GetPostValues())
+
+	ms.cfg.IsTiDB = true
+	sqls, _ := ms.batchSingleTxnDmls(event, tableInfo, true)
+	require.Equal(t, []string{
+		"UPDATE `db`.`tb` SET `name`=CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END " +
+			"WHERE (`id` = ?) OR (`id` = ?)",
+		"UPDATE `db`.`tb` SET `age`=CASE WHEN `id` = ? THEN ? END WHERE (`id` = ?)",
+		"INSERT INTO `db`.`tb` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)",
+		"INSERT INTO `db`.`tb` (`id`,`name`,`age`) VALUES (?,?,?)",
+	}, sqls)
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+)
+
+// sessionVariables holds SET statements which switch session variables of
+// the downstream between tables of different session variable rules.
+type sessionVariables struct {
+	// stmts are statements of the rules, the one of the nil rule restores
+	// changefeed level values.
+	stmts map[*pmysql.SessionVariableRule]string
+}
+
+// newSessionVariables returns nil if there is no session variable rule.
+// dsnParams are parameters of the DSN that connections are opened with, they
+// are changefeed level values of the session variables. Variables which are
+// not in dsnParams are restored to their global values.
+func newSessionVariables(
+	cfg *pmysql.Config, dsnParams map[string]string,
+) *sessionVariables {
+	if len(cfg.SessionVariableRules) == 0 {
+		return nil
+	}
+	names := cfg.SessionVariableNames()
+	stmtOf := func(rule *pmysql.SessionVariableRule) string {
+		assignments := make([]string, 0, len(names))
+		for _, name := range names {
+			value := "DEFAULT"
+			if v, ok := dsnParams[name]; ok {
+				value = v
+				// Double quoted strings are identifiers with ANSI_QUOTES.
+				if unquoted, err := strconv.Unquote(v); err == nil {
+					value = pmysql.FormatSessionVariable(name, unquoted)
+				}
+			}
+			if rule != nil {
+				if v, ok := rule.Variables[name]; ok {
+					value = pmysql.FormatSessionVariable(name, v)
+				}
+			}
+			assignments = append(assignments, fmt.Sprintf("%s = %s", name, value))
+		}
+		return "SET SESSION " + strings.Join(assignments, ", ")
+	}
+
+	v := &sessionVariables{
+		stmts: make(map[*pmysql.SessionVariableRule]string, len(cfg.SessionVariableRules)+1),
+	}
+	v.stmts[nil] = stmtOf(nil)
+	for i := range cfg.SessionVariableRules {
+		rule := &cfg.SessionVariableRules[i]
+		v.stmts[rule] = stmtOf(rule)
+	}
+	return v
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	filter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareDMLsWithSessionVariables(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newMySQLBackendWithoutDB(ctx)
+	s.cfg.SessionVariables = map[string]string{pmysql.SessionVariableSQLMode: "NO_ZERO_DATE"}
+	orders, err := filter.Parse([]string{"test.orders"})
+	require.Nil(t, err)
+	all, err := filter.Parse([]string{"test.*"})
+	require.Nil(t, err)
+	s.cfg.SessionVariableRules = []pmysql.SessionVariableRule{
+		{
+			Filter:    orders,
+			Variables: map[string]string{pmysql.SessionVariableForeignKeyChecks: "ON"},
+		},
+		{
+			Filter:    all,
+			Variables: map[string]string{pmysql.SessionVariableSQLMode: ""},
+		},
+	}
+	s.sessionVariables = newSessionVariables(s.cfg, map[string]string{
+		pmysql.SessionVariableForeignKeyChecks: "0",
+		pmysql.SessionVariableSQLMode:          `"NO_ZERO_DATE"`,
+	})
+
+	prepare := func(tables ...string) []string {
+		s.events = s.events[:0]
+		s.rows = 0
+		for _, table := range tables {
+			tableName := &model.TableName{Schema: "test", Table: table}
+			if table == "" {
+				tableName = &model.TableName{Schema: "test1", Table: "t"}
+			}
+			row := &model.RowChangedEvent{
+				StartTs:  1,
+				CommitTs: 2,
+				Table:    tableName,
+				Columns: []*model.Column{{
+					Name:  "a",
+					Type:  mysql.TypeLong,
+					Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+					Value: 1,
+				}},
+			}
+			s.OnTxnEvent(&dmlsink.TxnCallbackableEvent{
+				Event: &model.SingleTableTxn{Table: tableName, Rows: []*model.RowChangedEvent{row}},
+			})
+		}
+		dmls := s.prepareDMLs()
+		require.Len(t, dmls.values, len(dmls.sqls))
+		var stmts []string
+		for _, sql := range dmls.sqls {
+			if strings.HasPrefix(sql, "SET SESSION") {
+				stmts = append(stmts, sql)
+			} else {
+				stmts = append(stmts, dmlTableName(sql))
+			}
+		}
+		return stmts
+	}
+
+	restore := "SET SESSION foreign_key_checks = 0, sql_mode = 'NO_ZERO_DATE'"
+	setOrders := "SET SESSION foreign_key_checks = ON, sql_mode = 'NO_ZERO_DATE'"
+	setAll := "SET SESSION foreign_key_checks = 0, sql_mode = ''"
+	require.Equal(t, []string{
+		setOrders, "orders", "orders", setAll, "t1", restore, "t", setOrders, "orders", restore,
+	}, prepare("orders", "orders", "t1", "", "orders"))
+	// Variables are always set before the first table.
+	require.Equal(t, []string{restore, "t"}, prepare(""))
+}
+
+// dmlTableName returns the table name of a DML.
+func dmlTableName(sql string) string {
+	start := strings.Index(sql, "`test")
+	end := strings.Index(sql[start:], "` ")
+	name := sql[start : start+end]
+	return name[strings.LastIndex(name, "`")+1:]
+}
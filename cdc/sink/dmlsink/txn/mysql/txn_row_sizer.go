@@ -0,0 +1,167 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+const (
+	// minAdaptiveTxnRow is the lower bound of adaptive max-txn-row.
+	minAdaptiveTxnRow = 16
+	// maxAdaptiveTxnRow is the upper bound of adaptive max-txn-row, it's the
+	// same as the upper limit of max-txn-row.
+	maxAdaptiveTxnRow = 2048
+	// txnRowSamplesPerStep is the number of full transactions observed
+	// before max-txn-row of a table is adjusted.
+	txnRowSamplesPerStep = 16
+	// txnRowIdleTimeout is the time after which max-txn-row of a table that
+	// has no transaction is forgotten, e.g. the table is removed from the sink.
+	txnRowIdleTimeout = 10 * time.Minute
+)
+
+// txnRowSizer tunes max-txn-row of every table. It observes the commit
+// throughput of transactions, and climbs towards the size with the highest
+// throughput: it keeps moving in one direction while the throughput grows,
+// and turns back once the throughput drops, so that the size oscillates
+// around the optimal one and follows the changes of the downstream.
+// Tables idle for txnRowIdleTimeout are pruned, so that tables removed from
+// the sink don't stay in memory.
+type txnRowSizer struct {
+	initRow int
+	minRow  int
+	maxRow  int
+
+	mu     sync.Mutex
+	tables map[model.TableID]*tableTxnRow
+	// lastPrune is the last time idle tables are pruned.
+	lastPrune time.Time
+	// now is for mocking time in unit tests.
+	now func() time.Time
+}
+
+type tableTxnRow struct {
+	maxTxnRow int
+	// lastObserved is the last time a transaction of the table is observed.
+	lastObserved time.Time
+	// growing is the direction of the next adjustment.
+	growing bool
+	// lastThroughput is the throughput of the previous step.
+	lastThroughput float64
+
+	samples     int
+	sampledRows int
+	sampledTime time.Duration
+}
+
+func newTxnRowSizer(initRow int) *txnRowSizer {
+	s := &txnRowSizer{
+		initRow: initRow,
+		minRow:  minAdaptiveTxnRow,
+		maxRow:  maxAdaptiveTxnRow,
+		tables:  make(map[model.TableID]*tableTxnRow),
+		now:     time.Now,
+	}
+	if s.minRow > initRow {
+		s.minRow = initRow
+	}
+	if s.maxRow < initRow {
+		s.maxRow = initRow
+	}
+	return s
+}
+
+// maxTxnRow returns the max number of rows in a transaction of the table.
+func (s *txnRowSizer) maxTxnRow(tableID model.TableID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.tables[tableID]; ok {
+		return t.maxTxnRow
+	}
+	return s.initRow
+}
+
+// observe records that a transaction with the given rows of the tables is
+// committed in the given duration. Only full transactions of a table are
+// taken into account, a smaller transaction tells nothing about the best
+// size because the table doesn't have enough rows to fill it.
+func (s *txnRowSizer) observe(tableIDs []model.TableID, rows int, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.maybePrune(now)
+	for _, tableID := range tableIDs {
+		t, ok := s.tables[tableID]
+		if !ok {
+			t = &tableTxnRow{maxTxnRow: s.initRow, growing: true}
+			s.tables[tableID] = t
+		}
+		t.lastObserved = now
+		if rows < t.maxTxnRow {
+			continue
+		}
+		t.samples++
+		t.sampledRows += rows
+		t.sampledTime += elapsed
+		if t.samples >= txnRowSamplesPerStep {
+			s.adjust(t)
+		}
+	}
+}
+
+// maybePrune removes tables idle for txnRowIdleTimeout. It scans tables at
+// most once per txnRowIdleTimeout.
+func (s *txnRowSizer) maybePrune(now time.Time) {
+	if now.Sub(s.lastPrune) < txnRowIdleTimeout {
+		return
+	}
+	s.lastPrune = now
+	for tableID, t := range s.tables {
+		if now.Sub(t.lastObserved) >= txnRowIdleTimeout {
+			delete(s.tables, tableID)
+		}
+	}
+}
+
+func (s *txnRowSizer) adjust(t *tableTxnRow) {
+	throughput := float64(t.sampledRows)
+	if t.sampledTime > 0 {
+		throughput /= t.sampledTime.Seconds()
+	}
+	if throughput < t.lastThroughput {
+		t.growing = !t.growing
+	}
+	t.lastThroughput = throughput
+	t.samples, t.sampledRows, t.sampledTime = 0, 0, 0
+
+	if t.growing && t.maxTxnRow >= s.maxRow {
+		t.growing = false
+	} else if !t.growing && t.maxTxnRow <= s.minRow {
+		t.growing = true
+	}
+	if t.growing {
+		t.maxTxnRow += t.maxTxnRow / 2
+		if t.maxTxnRow > s.maxRow {
+			t.maxTxnRow = s.maxRow
+		}
+	} else {
+		t.maxTxnRow -= t.maxTxnRow / 3
+		if t.maxTxnRow < s.minRow {
+			t.maxTxnRow = s.minRow
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/stretchr/testify/require"
+)
+
+// observeStep observes a step of full transactions of the table, the commit
+// latency of a transaction is given by latency.
+func observeStep(s *txnRowSizer, tableID model.TableID, latency func(rows int) time.Duration) {
+	for i := 0; i < txnRowSamplesPerStep; i++ {
+		rows := s.maxTxnRow(tableID)
+		s.observe([]model.TableID{tableID}, rows, latency(rows))
+	}
+}
+
+func TestTxnRowSizerConverges(t *testing.T) {
+	t.Parallel()
+
+	// The throughput is the highest with 512 rows per transaction.
+	latency := func(rows int) time.Duration {
+		d := 512 - rows
+		if d < 0 {
+			d = -d
+		}
+		return time.Duration(rows+d*d/64) * time.Millisecond
+	}
+	s := newTxnRowSizer(64)
+	for i := 0; i < 100; i++ {
+		observeStep(s, 1, latency)
+	}
+	rows := s.maxTxnRow(1)
+	require.GreaterOrEqual(t, rows, 256)
+	require.LessOrEqual(t, rows, 1024)
+
+	// Other tables are not affected.
+	require.Equal(t, 64, s.maxTxnRow(2))
+}
+
+func TestTxnRowSizerBounds(t *testing.T) {
+	t.Parallel()
+
+	s := newTxnRowSizer(256)
+	// Larger transactions are always better.
+	for i := 0; i < 100; i++ {
+		observeStep(s, 1, func(int) time.Duration { return time.Millisecond })
+	}
+	require.LessOrEqual(t, s.maxTxnRow(1), maxAdaptiveTxnRow)
+	require.GreaterOrEqual(t, s.maxTxnRow(1), maxAdaptiveTxnRow*2/3)
+
+	// Smaller transactions are always better.
+	for i := 0; i < 100; i++ {
+		observeStep(s, 1, func(rows int) time.Duration {
+			return time.Duration(rows*rows) * time.Millisecond
+		})
+	}
+	require.GreaterOrEqual(t, s.maxTxnRow(1), minAdaptiveTxnRow)
+	require.LessOrEqual(t, s.maxTxnRow(1), minAdaptiveTxnRow*3/2)
+}
+
+func TestTxnRowSizerIgnoresSmallTxns(t *testing.T) {
+	t.Parallel()
+
+	s := newTxnRowSizer(256)
+	for i := 0; i < 10*txnRowSamplesPerStep; i++ {
+		s.observe([]model.TableID{1}, 10, time.Second)
+	}
+	require.Equal(t, 256, s.maxTxnRow(1))
+}
+
+func TestTxnRowSizerPrunesIdleTables(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	s := newTxnRowSizer(256)
+	s.now = func() time.Time { return now }
+	s.observe([]model.TableID{1, 2}, 256, time.Second)
+	require.Len(t, s.tables, 2)
+
+	// Table 2 is removed from the sink, it's pruned once it's idle for
+	// txnRowIdleTimeout.
+	now = now.Add(txnRowIdleTimeout / 2)
+	s.observe([]model.TableID{1}, 256, time.Second)
+	require.Len(t, s.tables, 2)
+	now = now.Add(txnRowIdleTimeout / 2)
+	s.observe([]model.TableID{1}, 256, time.Second)
+	require.Len(t, s.tables, 1)
+	require.Contains(t, s.tables, model.TableID(1))
+	require.Equal(t, 256, s.maxTxnRow(2))
+}
+
+func TestOnTxnEventWithTxnRowSizer(t *testing.T) {
+	t.Parallel()
+
+	s := newMySQLBackendWithoutDB(context.Background())
+	s.txnRowSizer = newTxnRowSizer(4)
+	s.txnRowSizer.tables[2] = &tableTxnRow{maxTxnRow: 2}
+
+	newEvent := func(tableID model.TableID, rows int) *dmlsink.TxnCallbackableEvent {
+		txn := &model.SingleTableTxn{Table: &model.TableName{TableID: tableID}}
+		for i := 0; i < rows; i++ {
+			txn.Rows = append(txn.Rows, &model.RowChangedEvent{})
+		}
+		return &dmlsink.TxnCallbackableEvent{Event: txn}
+	}
+	require.False(t, s.OnTxnEvent(newEvent(1, 1)))
+	// The smallest max-txn-row of tables in the transaction is used.
+	require.True(t, s.OnTxnEvent(newEvent(2, 1)))
+}
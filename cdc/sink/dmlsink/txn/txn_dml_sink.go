@@ -43,9 +43,13 @@ var _ dmlsink.EventSink[*model.SingleTableTxn] = (*dmlSink)(nil)
 type dmlSink struct {
 	alive struct {
 		sync.RWMutex
-		conflictDetector *causality.ConflictDetector[*worker, *txnEvent]
-		isDead           bool
+		// groups are indexed by names of worker groups.
+		groups map[string]*workerGroup
+		isDead bool
 	}
+	// workerGroupOf returns the worker group of a table, it's nil if all
+	// tables share one worker group.
+	workerGroupOf func(schema, table string) string
 
 	workers []*worker
 	cancel  func()
@@ -80,11 +84,26 @@ func NewMySQLSink(
 		return nil, err
 	}
 
-	backends := make([]backend, 0, len(backendImpls))
+	var groups []backendGroup
+	groupIndexes := make(map[string]int)
+	workerCountPerTable := 0
+	var workerGroupOf func(schema, table string) string
 	for _, impl := range backendImpls {
-		backends = append(backends, impl)
+		i, ok := groupIndexes[impl.WorkerGroup()]
+		if !ok {
+			i = len(groups)
+			groupIndexes[impl.WorkerGroup()] = i
+			groups = append(groups, backendGroup{name: impl.WorkerGroup()})
+		}
+		groups[i].backends = append(groups[i].backends, impl)
+		workerCountPerTable = impl.WorkerCountPerTable()
+		workerGroupOf = impl.WorkerGroupOf
+	}
+	if len(groups) == 1 {
+		workerGroupOf = nil
 	}
-	sink := newSink(ctx, changefeedID, backends, errCh, conflictDetectorSlots)
+	sink := newGroupedSink(ctx, changefeedID, groups, workerGroupOf,
+		errCh, conflictDetectorSlots, workerCountPerTable)
 	sink.statistics = statistics
 	sink.cancel = cancel
 
@@ -95,23 +114,51 @@ func newSink(ctx context.Context,
 	changefeedID model.ChangeFeedID,
 	backends []backend,
 	errCh chan<- error, conflictDetectorSlots uint64,
+	workerCountPerTable int,
+) *dmlSink {
+	groups := []backendGroup{{name: pmysql.DefaultWorkerGroup, backends: backends}}
+	return newGroupedSink(ctx, changefeedID, groups, nil,
+		errCh, conflictDetectorSlots, workerCountPerTable)
+}
+
+// newGroupedSink creates a dmlSink whose transactions are dispatched to the
+// worker group returned by workerGroupOf.
+func newGroupedSink(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	groups []backendGroup,
+	workerGroupOf func(schema, table string) string,
+	errCh chan<- error, conflictDetectorSlots uint64,
+	workerCountPerTable int,
 ) *dmlSink {
 	ctx, cancel := context.WithCancel(ctx)
 	sink := &dmlSink{
-		workers: make([]*worker, 0, len(backends)),
-		cancel:  cancel,
-		dead:    make(chan struct{}),
+		workerGroupOf: workerGroupOf,
+		cancel:        cancel,
+		dead:          make(chan struct{}),
 	}
+	sink.alive.groups = make(map[string]*workerGroup, len(groups))
 
+	totalWorkerCount := 0
+	for _, group := range groups {
+		totalWorkerCount += len(group.backends)
+	}
 	g, ctx1 := errgroup.WithContext(ctx)
-	for i, backend := range backends {
-		w := newWorker(ctx1, changefeedID, i, backend, len(backends))
-		g.Go(func() error { return w.runLoop() })
-		sink.workers = append(sink.workers, w)
+	for _, group := range groups {
+		workers := make([]*worker, 0, len(group.backends))
+		for _, backend := range group.backends {
+			w := newWorker(ctx1, changefeedID, len(sink.workers), backend,
+				totalWorkerCount, group.name, len(group.backends))
+			g.Go(func() error { return w.runLoop() })
+			sink.workers = append(sink.workers, w)
+			workers = append(workers, w)
+		}
+		sink.alive.groups[group.name] = &workerGroup{
+			workers: workers,
+			conflictDetector: causality.NewGroupedConflictDetector[*worker, *txnEvent](
+				workers, conflictDetectorSlots, workerCountPerTable),
+		}
 	}
 
-	sink.alive.conflictDetector = causality.NewConflictDetector[*worker, *txnEvent](sink.workers, conflictDetectorSlots)
-
 	sink.wg.Add(1)
 	go func() {
 		defer sink.wg.Done()
@@ -119,7 +166,9 @@ func newSink(ctx context.Context,
 
 		sink.alive.Lock()
 		sink.alive.isDead = true
-		sink.alive.conflictDetector.Close()
+		for _, group := range sink.alive.groups {
+			group.conflictDetector.Close()
+		}
 		sink.alive.Unlock()
 		close(sink.dead)
 
@@ -149,11 +198,21 @@ func (s *dmlSink) WriteEvents(txnEvents ...*dmlsink.TxnCallbackableEvent) error
 			txn.Callback()
 			continue
 		}
-		s.alive.conflictDetector.Add(newTxnEvent(txn))
+		s.alive.groups[s.workerGroupOfTxn(txn.Event)].conflictDetector.Add(newTxnEvent(txn))
 	}
 	return nil
 }
 
+// workerGroupOfTxn returns the worker group that the transaction is
+// dispatched to. Transactions of one table are always dispatched to the same
+// group, so conflicting transactions are always detected by one group.
+func (s *dmlSink) workerGroupOfTxn(txn *model.SingleTableTxn) string {
+	if s.workerGroupOf == nil || txn.Table == nil {
+		return pmysql.DefaultWorkerGroup
+	}
+	return s.workerGroupOf(txn.Table.Schema, txn.Table.Table)
+}
+
 // Close closes the dmlSink. It won't wait for all pending items backend handled.
 func (s *dmlSink) Close() {
 	if s.cancel != nil {
@@ -173,3 +232,16 @@ func (s *dmlSink) Close() {
 func (s *dmlSink) Dead() <-chan struct{} {
 	return s.dead
 }
+
+// backendGroup is a group of backends dedicated to some tables.
+type backendGroup struct {
+	name     string
+	backends []backend
+}
+
+// workerGroup is a group of workers dedicated to some tables. Transactions of
+// different tables never conflict, so each group has its own conflict detector.
+type workerGroup struct {
+	workers          []*worker
+	conflictDetector *causality.ConflictDetector[*worker, *txnEvent]
+}
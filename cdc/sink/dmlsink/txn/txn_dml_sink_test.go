@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
 	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/stretchr/testify/require"
 )
 
@@ -70,7 +71,7 @@ func TestTxnSinkNolocking(t *testing.T) {
 	}
 	errCh := make(chan error, 1)
 	sink := newSink(context.Background(),
-		model.DefaultChangeFeedID("test"), bes, errCh, DefaultConflictDetectorSlots)
+		model.DefaultChangeFeedID("test"), bes, errCh, DefaultConflictDetectorSlots, 0)
 
 	// Test `WriteEvents` shouldn't be blocked by slow workers.
 	var handled uint32 = 0
@@ -106,6 +107,64 @@ func TestTxnSinkNolocking(t *testing.T) {
 	sink.Close()
 }
 
+type countingBackend struct {
+	blackhole
+	events int32
+}
+
+func (b *countingBackend) OnTxnEvent(e *dmlsink.TxnCallbackableEvent) bool {
+	atomic.AddInt32(&b.events, 1)
+	return b.blackhole.OnTxnEvent(e)
+}
+
+func TestTxnSinkWorkerGroups(t *testing.T) {
+	t.Parallel()
+
+	defaultBackend := &countingBackend{}
+	hotBackends := []*countingBackend{{}, {}}
+	groups := []backendGroup{
+		{name: pmysql.DefaultWorkerGroup, backends: []backend{defaultBackend}},
+		{name: "hot", backends: []backend{hotBackends[0], hotBackends[1]}},
+	}
+	workerGroupOf := func(schema, table string) string {
+		if table == "hot" {
+			return "hot"
+		}
+		return pmysql.DefaultWorkerGroup
+	}
+	sink := newGroupedSink(context.Background(), model.DefaultChangeFeedID("test"),
+		groups, workerGroupOf, make(chan error, 1), DefaultConflictDetectorSlots, 0)
+	defer sink.Close()
+	require.Len(t, sink.workers, 3)
+
+	var handled uint32
+	for i := 0; i < 15; i++ {
+		table := &model.TableName{Schema: "test", Table: "t1", TableID: 1}
+		if i%3 != 0 {
+			table = &model.TableName{Schema: "test", Table: "hot", TableID: 2}
+		}
+		sinkState := new(state.TableSinkState)
+		*sinkState = state.TableSinkSinking
+		sink.WriteEvents(&dmlsink.CallbackableEvent[*model.SingleTableTxn]{
+			Event: &model.SingleTableTxn{
+				Table: table,
+				Rows: []*model.RowChangedEvent{{
+					Table:   table,
+					Columns: []*model.Column{{Name: "a", Value: i}},
+				}},
+			},
+			Callback:  func() { atomic.AddUint32(&handled, 1) },
+			SinkState: sinkState,
+		})
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&handled) == 15
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(5), atomic.LoadInt32(&defaultBackend.events))
+	require.Equal(t, int32(10), atomic.LoadInt32(&hotBackends[0].events)+
+		atomic.LoadInt32(&hotBackends[1].events))
+}
+
 func TestGenKeys(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -152,7 +211,7 @@ func TestGenKeys(t *testing.T) {
 				},
 			},
 		},
-		expected: []uint64{2072713494, 3710968706},
+		expected: []uint64{7643877080156961154, 11789606483231845078},
 	}, {
 		txn: &model.SingleTableTxn{
 			Rows: []*model.RowChangedEvent{
@@ -191,7 +250,7 @@ func TestGenKeys(t *testing.T) {
 				},
 			},
 		},
-		expected: []uint64{318190470, 2109733718, 2658640457, 2989258527},
+		expected: []uint64{1759636778807833183, 4279769705396625654, 12104049077864989065, 12954044144632233094},
 	}, {
 		txn: &model.SingleTableTxn{
 			Rows: []*model.RowChangedEvent{
@@ -230,11 +289,15 @@ func TestGenKeys(t *testing.T) {
 				},
 			},
 		},
-		expected: []uint64{318190470, 2095136920, 2658640457},
+		expected: []uint64{12104049077864989065, 12161928128181699864, 12954044144632233094},
 	}}
 	for _, tc := range testCases {
 		keys := genTxnKeys(tc.txn)
-		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
-		require.Equal(t, tc.expected, keys)
+		var hashes []uint64
+		for _, key := range keys {
+			hashes = append(hashes, key.Hash)
+		}
+		sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+		require.Equal(t, tc.expected, hashes)
 	}
 }
@@ -36,6 +36,8 @@ type worker struct {
 	ctx         context.Context
 	changefeed  string
 	workerCount int
+	// groupWorkerCount is the number of workers of the worker group.
+	groupWorkerCount int
 
 	ID      int
 	txnCh   *chann.DrainableChann[txnWithNotifier]
@@ -47,6 +49,8 @@ type worker struct {
 	metricTxnWorkerFlushDuration prometheus.Observer
 	metricTxnWorkerBusyRatio     prometheus.Counter
 	metricTxnWorkerHandledRows   prometheus.Counter
+	metricWorkerGroupBusyRatio   prometheus.Counter
+	metricWorkerGroupHandledRows prometheus.Counter
 
 	// Fields only used in the background loop.
 	flushInterval     time.Duration
@@ -56,12 +60,14 @@ type worker struct {
 
 func newWorker(ctx context.Context, changefeedID model.ChangeFeedID,
 	ID int, backend backend, workerCount int,
+	group string, groupWorkerCount int,
 ) *worker {
 	wid := fmt.Sprintf("%d", ID)
 	return &worker{
-		ctx:         ctx,
-		changefeed:  fmt.Sprintf("%s.%s", changefeedID.Namespace, changefeedID.ID),
-		workerCount: workerCount,
+		ctx:              ctx,
+		changefeed:       fmt.Sprintf("%s.%s", changefeedID.Namespace, changefeedID.ID),
+		workerCount:      workerCount,
+		groupWorkerCount: groupWorkerCount,
 
 		ID:      ID,
 		txnCh:   chann.NewAutoDrainChann[txnWithNotifier](chann.Cap(-1 /*unbounded*/)),
@@ -72,6 +78,8 @@ func newWorker(ctx context.Context, changefeedID model.ChangeFeedID,
 		metricTxnWorkerFlushDuration: txn.WorkerFlushDuration.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 		metricTxnWorkerBusyRatio:     txn.WorkerBusyRatio.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 		metricTxnWorkerHandledRows:   txn.WorkerHandledRows.WithLabelValues(changefeedID.Namespace, changefeedID.ID, wid),
+		metricWorkerGroupBusyRatio:   txn.WorkerGroupBusyRatio.WithLabelValues(changefeedID.Namespace, changefeedID.ID, group),
+		metricWorkerGroupHandledRows: txn.WorkerGroupHandledRows.WithLabelValues(changefeedID.Namespace, changefeedID.ID, group),
 
 		flushInterval:     backend.MaxFlushInterval(),
 		hasPending:        false,
@@ -129,6 +137,7 @@ func (w *worker) runLoop() error {
 			totalTimeSlice = now.Sub(startToWork)
 			busyRatio := int(flushTimeSlice.Seconds() / totalTimeSlice.Seconds() * 1000)
 			w.metricTxnWorkerBusyRatio.Add(float64(busyRatio) / float64(w.workerCount))
+			w.metricWorkerGroupBusyRatio.Add(float64(busyRatio) / float64(w.groupWorkerCount))
 			startToWork = now
 			flushTimeSlice = 0
 		}
@@ -162,6 +171,7 @@ func (w *worker) onEvent(txn txnWithNotifier) bool {
 	w.metricConflictDetectDuration.Observe(txn.conflictResolved.Sub(txn.start).Seconds())
 	w.metricQueueDuration.Observe(time.Since(txn.start).Seconds())
 	w.metricTxnWorkerHandledRows.Add(float64(len(txn.Event.Rows)))
+	w.metricWorkerGroupHandledRows.Add(float64(len(txn.Event.Rows)))
 	w.wantMoreCallbacks = append(w.wantMoreCallbacks, txn.wantMore)
 	return w.backend.OnTxnEvent(txn.txnEvent.TxnCallbackableEvent)
 }
@@ -0,0 +1,107 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generatedcolumn
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+// Assert TableSink implementation
+var _ tablesink.TableSink = (*TableSink)(nil)
+
+// TableSink is a table sink which applies a generated column policy to events
+// before they're written to the underlying table sink. Events with generated
+// columns are shallow copied, the appended events are never modified.
+//
+// With the skip policy, generated columns are cleared, so they're omitted by
+// DB sinks and encoders. With the materialize policy, generated columns are
+// written as normal columns.
+type TableSink struct {
+	sink   tablesink.TableSink
+	policy string
+}
+
+// NewTableSink creates a TableSink.
+func NewTableSink(sink tablesink.TableSink, policy string) *TableSink {
+	return &TableSink{sink: sink, policy: policy}
+}
+
+// AppendRowChangedEvents applies the policy and appends row changed events.
+func (t *TableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	applied := make([]*model.RowChangedEvent, 0, len(rows))
+	for _, row := range rows {
+		applied = append(applied, t.applyRow(row))
+	}
+	t.sink.AppendRowChangedEvents(applied...)
+}
+
+// UpdateResolvedTs advances the resolved ts of the underlying table sink.
+func (t *TableSink) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
+	return t.sink.UpdateResolvedTs(resolvedTs)
+}
+
+// GetCheckpointTs returns the checkpoint ts of the underlying table sink.
+func (t *TableSink) GetCheckpointTs() model.ResolvedTs {
+	return t.sink.GetCheckpointTs()
+}
+
+// Close closes the underlying table sink.
+func (t *TableSink) Close() {
+	t.sink.Close()
+}
+
+// AsyncClose closes the underlying table sink asynchronously.
+func (t *TableSink) AsyncClose() bool {
+	return t.sink.AsyncClose()
+}
+
+func (t *TableSink) applyRow(row *model.RowChangedEvent) *model.RowChangedEvent {
+	if !hasGeneratedColumns(row.Columns) && !hasGeneratedColumns(row.PreColumns) {
+		return row
+	}
+	applied := *row
+	applied.Columns = t.applyColumns(row.Columns)
+	applied.PreColumns = t.applyColumns(row.PreColumns)
+	return &applied
+}
+
+func (t *TableSink) applyColumns(cols []*model.Column) []*model.Column {
+	if !hasGeneratedColumns(cols) {
+		return cols
+	}
+	applied := make([]*model.Column, len(cols))
+	for i, col := range cols {
+		if col == nil || !col.Flag.IsGeneratedColumn() {
+			applied[i] = col
+			continue
+		}
+		if t.policy == config.GeneratedColumnPolicyMaterialize {
+			materialized := *col
+			materialized.Flag.UnsetIsGeneratedColumn()
+			applied[i] = &materialized
+		}
+	}
+	return applied
+}
+
+func hasGeneratedColumns(cols []*model.Column) bool {
+	for _, col := range cols {
+		if col != nil && col.Flag.IsGeneratedColumn() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generatedcolumn
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTableSink struct {
+	tablesink.TableSink
+	rows []*model.RowChangedEvent
+}
+
+func (m *mockTableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	m.rows = append(m.rows, rows...)
+}
+
+func newTestRow() *model.RowChangedEvent {
+	columns := func(a, b int) []*model.Column {
+		return []*model.Column{
+			{Name: "a", Value: a, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+			{Name: "b", Value: b, Flag: model.GeneratedColumnFlag},
+			nil,
+		}
+	}
+	return &model.RowChangedEvent{
+		Table:      &model.TableName{Schema: "test", Table: "t"},
+		PreColumns: columns(1, 2),
+		Columns:    columns(1, 3),
+	}
+}
+
+func TestTableSinkSkip(t *testing.T) {
+	t.Parallel()
+
+	inner := &mockTableSink{}
+	s := NewTableSink(inner, config.GeneratedColumnPolicySkip)
+	row := newTestRow()
+	plain := &model.RowChangedEvent{
+		Columns: []*model.Column{{Name: "a", Value: 1}},
+	}
+	s.AppendRowChangedEvents(row, plain)
+	require.Len(t, inner.rows, 2)
+
+	skipped := inner.rows[0]
+	require.Equal(t, row.Table, skipped.Table)
+	require.Len(t, skipped.Columns, 3)
+	require.Equal(t, row.Columns[0], skipped.Columns[0])
+	require.Nil(t, skipped.Columns[1])
+	require.Nil(t, skipped.PreColumns[1])
+	// Rows without generated columns are not copied.
+	require.Same(t, plain, inner.rows[1])
+	// The appended row is not modified.
+	require.NotNil(t, row.Columns[1])
+	require.NotNil(t, row.PreColumns[1])
+}
+
+func TestTableSinkMaterialize(t *testing.T) {
+	t.Parallel()
+
+	inner := &mockTableSink{}
+	s := NewTableSink(inner, config.GeneratedColumnPolicyMaterialize)
+	row := newTestRow()
+	s.AppendRowChangedEvents(row)
+	require.Len(t, inner.rows, 1)
+
+	materialized := inner.rows[0]
+	require.Equal(t, 3, materialized.Columns[1].Value)
+	require.False(t, materialized.Columns[1].Flag.IsGeneratedColumn())
+	require.Equal(t, 2, materialized.PreColumns[1].Value)
+	require.False(t, materialized.PreColumns[1].Flag.IsGeneratedColumn())
+	require.Nil(t, materialized.Columns[2])
+	// The appended row is not modified.
+	require.True(t, row.Columns[1].Flag.IsGeneratedColumn())
+	require.True(t, row.PreColumns[1].Flag.IsGeneratedColumn())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/metrics/mq"
 	"github.com/pingcap/tiflow/cdc/sink/metrics/tablesink"
 	"github.com/pingcap/tiflow/cdc/sink/metrics/txn"
+	"github.com/pingcap/tiflow/cdc/sink/metrics/verification"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -77,4 +78,5 @@ func InitMetrics(registry *prometheus.Registry) {
 	txn.InitMetrics(registry)
 	mq.InitMetrics(registry)
 	cloudstorage.InitMetrics(registry)
+	verification.InitMetrics(registry)
 }
@@ -47,6 +47,40 @@ var (
 			Help:      "Batch duration for MQ worker.",
 			Buckets:   prometheus.ExponentialBuckets(0.004, 2, 10), // 4ms ~ 2s
 		}, []string{"namespace", "changefeed"})
+
+	// PartitionLinger records the linger chosen by adaptive batching.
+	PartitionLinger = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "mq_partition_linger_seconds",
+			Help:      "Linger of every partition chosen by adaptive batching.",
+		}, []string{"namespace", "changefeed", "topic", "partition"})
+	// PartitionBatchSize records the batch size chosen by adaptive batching.
+	PartitionBatchSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "mq_partition_batch_size",
+			Help:      "Batch size of every partition chosen by adaptive batching.",
+		}, []string{"namespace", "changefeed", "topic", "partition"})
+	// PartitionMaxInFlight records the in-flight limit chosen by adaptive batching.
+	PartitionMaxInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "mq_partition_max_in_flight_messages",
+			Help:      "In-flight message limit of every partition chosen by adaptive batching.",
+		}, []string{"namespace", "changefeed", "topic", "partition"})
+	// PartitionAckLatency records the moving average of ack latency observed
+	// by adaptive batching.
+	PartitionAckLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "mq_partition_ack_latency_seconds",
+			Help:      "Moving average of ack latency of every partition.",
+		}, []string{"namespace", "changefeed", "topic", "partition"})
 )
 
 // InitMetrics registers all metrics in this file.
@@ -54,6 +88,10 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(WorkerSendMessageDuration)
 	registry.MustRegister(WorkerBatchSize)
 	registry.MustRegister(WorkerBatchDuration)
+	registry.MustRegister(PartitionLinger)
+	registry.MustRegister(PartitionBatchSize)
+	registry.MustRegister(PartitionMaxInFlight)
+	registry.MustRegister(PartitionAckLatency)
 	codec.InitMetrics(registry)
 	kafka.InitMetrics(registry)
 }
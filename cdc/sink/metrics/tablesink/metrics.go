@@ -26,7 +26,19 @@ var TotalRowsCountCounter = prometheus.NewCounterVec(
 		Help:      "The total count of rows that are processed by table sink",
 	}, []string{"namespace", "changefeed"})
 
+// E2ELatencyHistogram is the end-to-end latency of events, which is from the
+// physical time of their commit ts to the time they are acked by the sink.
+var E2ELatencyHistogram = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "sink",
+		Name:      "table_sink_e2e_latency",
+		Help:      "End-to-end latency from the commit ts of events to being acked by the sink (seconds)",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 18), // 10ms~1310s
+	}, []string{"namespace", "changefeed", "table", "sink"})
+
 // InitMetrics registers all metrics in this file.
 func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(TotalRowsCountCounter)
+	registry.MustRegister(E2ELatencyHistogram)
 }
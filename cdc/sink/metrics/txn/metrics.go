@@ -62,6 +62,24 @@ var (
 			Help:      "Busy ratio (X ms in 1s) for all workers.",
 		}, []string{"namespace", "changefeed", "id"})
 
+	// WorkerGroupBusyRatio records the busy ratio of workers of a worker group.
+	WorkerGroupBusyRatio = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_worker_group_busy_ratio",
+			Help:      "Busy ratio (X ms in 1s) for workers of a worker group.",
+		}, []string{"namespace", "changefeed", "group"})
+
+	// WorkerGroupHandledRows records the rows handled by workers of a worker group.
+	WorkerGroupHandledRows = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_worker_group_handled_rows",
+			Help:      "Rows handled by workers of a worker group.",
+		}, []string{"namespace", "changefeed", "group"})
+
 	SinkDMLBatchCommit = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "ticdc",
@@ -87,6 +105,35 @@ var (
 			Name:      "txn_prepare_statement_errors",
 			Help:      "Prepare statement errors",
 		}, []string{"namespace", "changefeed"})
+
+	// EndpointHealth records whether a downstream endpoint is healthy and writable.
+	EndpointHealth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_endpoint_health",
+			Help:      "1 if the downstream endpoint is healthy and writable, otherwise 0",
+		}, []string{"namespace", "changefeed", "endpoint"})
+
+	// EndpointCheckDuration records the duration of checking a downstream endpoint.
+	EndpointCheckDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_endpoint_check_duration",
+			Help:      "Bucketed histogram of health check duration (s) of a downstream endpoint.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16), // 1ms~32s
+		}, []string{"namespace", "changefeed", "endpoint"})
+
+	// EndpointConnections records the connection pool stats of the endpoint
+	// that the sink writes to.
+	EndpointConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_endpoint_connections",
+			Help:      "Connection pool stats of the downstream endpoint",
+		}, []string{"namespace", "changefeed", "endpoint", "state"})
 )
 
 // InitMetrics registers all metrics in this file.
@@ -96,7 +143,12 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(WorkerFlushDuration)
 	registry.MustRegister(WorkerBusyRatio)
 	registry.MustRegister(WorkerHandledRows)
+	registry.MustRegister(WorkerGroupBusyRatio)
+	registry.MustRegister(WorkerGroupHandledRows)
 	registry.MustRegister(SinkDMLBatchCommit)
 	registry.MustRegister(SinkDMLBatchCallback)
 	registry.MustRegister(PrepareStatementErrors)
+	registry.MustRegister(EndpointHealth)
+	registry.MustRegister(EndpointCheckDuration)
+	registry.MustRegister(EndpointConnections)
 }
@@ -0,0 +1,133 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+)
+
+// Assert Sink implementation
+var (
+	_ ddlsink.Sink          = (*DDLSink)(nil)
+	_ ddlsink.SchemaChecker = (*DDLSink)(nil)
+	_ ddlsink.TopicRemover  = (*DDLSink)(nil)
+)
+
+// DDLSink is a DDL sink which routes the DDL events and tables before they're
+// written to the underlying DDL sink.
+type DDLSink struct {
+	sink   ddlsink.Sink
+	router *Router
+
+	mu sync.Mutex
+	// tables and routedTables cache the last routed tables, which are
+	// unchanged until DDLs are executed.
+	tables       []*model.TableInfo
+	routedTables []*model.TableInfo
+}
+
+// NewDDLSink creates a DDLSink.
+func NewDDLSink(sink ddlsink.Sink, router *Router) *DDLSink {
+	return &DDLSink{sink: sink, router: router}
+}
+
+// WriteDDLEvent routes and writes a DDL event.
+func (d *DDLSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	routed, err := d.router.RouteDDL(ddl)
+	if err != nil {
+		return err
+	}
+	return d.sink.WriteDDLEvent(ctx, routed)
+}
+
+// WriteCheckpointTs routes the tables and writes the checkpoint ts.
+func (d *DDLSink) WriteCheckpointTs(
+	ctx context.Context, ts uint64, tables []*model.TableInfo,
+) error {
+	routed, err := d.routeTables(tables)
+	if err != nil {
+		return err
+	}
+	return d.sink.WriteCheckpointTs(ctx, ts, routed)
+}
+
+// CheckSchema routes the tables and checks them if the underlying DDL sink
+// is a ddlsink.SchemaChecker.
+func (d *DDLSink) CheckSchema(ctx context.Context, tables []*model.TableInfo) error {
+	checker, ok := d.sink.(ddlsink.SchemaChecker)
+	if !ok {
+		return nil
+	}
+	routed, err := d.routeTables(tables)
+	if err != nil {
+		return err
+	}
+	return checker.CheckSchema(ctx, routed)
+}
+
+// RemoveTopics routes the tables and removes their topics if the underlying
+// DDL sink is a ddlsink.TopicRemover.
+func (d *DDLSink) RemoveTopics(ctx context.Context, tables []*model.TableInfo) error {
+	remover, ok := d.sink.(ddlsink.TopicRemover)
+	if !ok {
+		return nil
+	}
+	routed, err := d.routeTables(tables)
+	if err != nil {
+		return err
+	}
+	return remover.RemoveTopics(ctx, routed)
+}
+
+// Close closes the underlying DDL sink.
+func (d *DDLSink) Close() {
+	d.sink.Close()
+}
+
+// routeTables routes tables, it returns ErrSinkRouteConflict if two tables
+// are routed to one table.
+func (d *DDLSink) routeTables(tables []*model.TableInfo) ([]*model.TableInfo, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if sameTables(d.tables, tables) {
+		return d.routedTables, nil
+	}
+	names := make([]model.TableName, 0, len(tables))
+	routed := make([]*model.TableInfo, 0, len(tables))
+	for _, table := range tables {
+		names = append(names, table.TableName)
+		routed = append(routed, d.router.RouteTableInfo(table))
+	}
+	if err := d.router.Verify(names); err != nil {
+		return nil, err
+	}
+	d.tables, d.routedTables = tables, routed
+	return routed, nil
+}
+
+func sameTables(a, b []*model.TableInfo) bool {
+	if a == nil || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
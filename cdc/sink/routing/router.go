@@ -0,0 +1,305 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	timodel "github.com/pingcap/tidb/parser/model"
+	filter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+type rule struct {
+	filter filter.Filter
+	*config.RouteRule
+}
+
+// Router renames schemas and tables by route rules. The first matched rule
+// takes effect, and tables matched by no rule keep their names.
+// A nil Router routes nothing.
+type Router struct {
+	rules []rule
+}
+
+// NewRouter creates a Router, it returns nil if there are no rules.
+// The rules must have been validated.
+func NewRouter(rules []*config.RouteRule) (*Router, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	r := &Router{rules: make([]rule, 0, len(rules))}
+	for _, routeRule := range rules {
+		f, err := filter.Parse(routeRule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		r.rules = append(r.rules, rule{
+			filter:    filter.CaseInsensitive(f),
+			RouteRule: routeRule,
+		})
+	}
+	return r, nil
+}
+
+// Route returns the downstream schema and table name of an upstream table.
+// If table is empty, it returns the downstream name of the schema, which is
+// routed by rules matching all tables of the schema.
+func (r *Router) Route(schema, table string) (string, string) {
+	if r == nil {
+		return schema, table
+	}
+	for _, rule := range r.rules {
+		if table == "" {
+			if !rule.filter.MatchSchema(schema) || !matchAllTables(rule.filter, schema) {
+				continue
+			}
+		} else if !rule.filter.MatchTable(schema, table) {
+			continue
+		}
+		return rule.Target(schema, table)
+	}
+	return schema, table
+}
+
+// matchAllTables returns true if the filter matches any table of the schema,
+// which is guessed by a table name that can hardly exist.
+func matchAllTables(f filter.Filter, schema string) bool {
+	return f.MatchTable(schema, "\x00")
+}
+
+// RouteTableName returns the routed table name.
+func (r *Router) RouteTableName(name model.TableName) model.TableName {
+	name.Schema, name.Table = r.Route(name.Schema, name.Table)
+	return name
+}
+
+// RouteTableInfo returns a routed copy of the table info. The table info is
+// returned as is if it's not routed. The copy is wrapped again, so callers
+// should cache it rather than route the table info per event.
+func (r *Router) RouteTableInfo(info *model.TableInfo) *model.TableInfo {
+	if r == nil || info == nil {
+		return info
+	}
+	name := r.RouteTableName(info.TableName)
+	if name == info.TableName {
+		return info
+	}
+	if info.TableInfo == nil {
+		return &model.TableInfo{
+			SchemaID:  info.SchemaID,
+			TableName: name,
+			Version:   info.Version,
+		}
+	}
+	inner := info.TableInfo.Clone()
+	inner.Name = timodel.NewCIStr(name.Table)
+	routed := model.WrapTableInfo(info.SchemaID, name.Schema, info.Version, inner)
+	// The table ID may be a physical ID, which differs from the ID of inner.
+	routed.TableName = name
+	return routed
+}
+
+// RouteDDL returns a routed copy of the DDL event, both of its table infos and
+// its query are routed. The DDL event is returned as is if it's not routed.
+func (r *Router) RouteDDL(ddl *model.DDLEvent) (*model.DDLEvent, error) {
+	if r == nil {
+		return ddl, nil
+	}
+	tableInfo := r.RouteTableInfo(ddl.TableInfo)
+	preTableInfo := r.RouteTableInfo(ddl.PreTableInfo)
+	query, err := r.routeQuery(ddl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tableInfo == ddl.TableInfo && preTableInfo == ddl.PreTableInfo && query == ddl.Query {
+		return ddl, nil
+	}
+	return &model.DDLEvent{
+		StartTs:      ddl.StartTs,
+		CommitTs:     ddl.CommitTs,
+		Query:        query,
+		TableInfo:    tableInfo,
+		PreTableInfo: preTableInfo,
+		Type:         ddl.Type,
+		Charset:      ddl.Charset,
+		Collate:      ddl.Collate,
+	}, nil
+}
+
+// routeQuery rewrites the schema and table names in the query of the DDL.
+// The names are qualified with schemas after rewritten. The query is returned
+// as is if no name is routed.
+func (r *Router) routeQuery(ddl *model.DDLEvent) (string, error) {
+	if ddl.Query == "" {
+		return ddl.Query, nil
+	}
+	stmts, _, err := parser.New().Parse(ddl.Query, ddl.Charset, ddl.Collate)
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+	}
+	var schema, preSchema string
+	if ddl.TableInfo != nil {
+		schema = ddl.TableInfo.TableName.Schema
+	}
+	preSchema = schema
+	if ddl.PreTableInfo != nil {
+		preSchema = ddl.PreTableInfo.TableName.Schema
+	}
+
+	var sb strings.Builder
+	routed := false
+	for i, stmt := range stmts {
+		v := &nameRouter{router: r, schema: schema}
+		switch s := stmt.(type) {
+		case *ast.CreateDatabaseStmt:
+			s.Name = v.routeSchema(s.Name)
+		case *ast.DropDatabaseStmt:
+			s.Name = v.routeSchema(s.Name)
+		case *ast.AlterDatabaseStmt:
+			if s.Name.O != "" {
+				s.Name = v.routeSchema(s.Name)
+			}
+		case *ast.RenameTableStmt:
+			// The old tables are in the schema before renaming.
+			for _, t2t := range s.TableToTables {
+				v.schema = preSchema
+				t2t.OldTable.Accept(v)
+				v.schema = schema
+				t2t.NewTable.Accept(v)
+			}
+		default:
+			stmt.Accept(v)
+		}
+		routed = routed || v.routed
+
+		if i != 0 {
+			sb.WriteString("; ")
+		}
+		restoreFlags := format.RestoreTiDBSpecialComment |
+			format.RestoreNameBackQuotes |
+			format.RestoreKeyWordUppercase |
+			format.RestoreStringSingleQuotes
+		if err = stmt.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	if !routed {
+		return ddl.Query, nil
+	}
+	return sb.String(), nil
+}
+
+// nameRouter rewrites the schema and table names of a statement.
+type nameRouter struct {
+	router *Router
+	// schema is the schema of tables whose schemas are omitted.
+	schema string
+	routed bool
+}
+
+// Enter implements ast.Visitor.
+func (v *nameRouter) Enter(in ast.Node) (ast.Node, bool) {
+	name, ok := in.(*ast.TableName)
+	if !ok {
+		return in, false
+	}
+	schema := name.Schema.O
+	if schema == "" {
+		schema = v.schema
+	}
+	// Tables of other schemas are unknown, e.g. in the definitions of views.
+	if schema == "" {
+		return in, true
+	}
+	// Names are always qualified, since the DDL may be executed in the routed
+	// schema, where the unqualified names of unrouted tables are wrong.
+	name.Schema = timodel.NewCIStr(schema)
+	targetSchema, targetTable := v.router.Route(schema, name.Name.O)
+	if targetSchema != schema || targetTable != name.Name.O {
+		name.Schema = timodel.NewCIStr(targetSchema)
+		name.Name = timodel.NewCIStr(targetTable)
+		v.routed = true
+	}
+	return in, true
+}
+
+// Leave implements ast.Visitor.
+func (v *nameRouter) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (v *nameRouter) routeSchema(name timodel.CIStr) timodel.CIStr {
+	target, _ := v.router.Route(name.O, "")
+	if target == name.O {
+		return name
+	}
+	v.routed = true
+	return timodel.NewCIStr(target)
+}
+
+// Verify returns ErrSinkRouteConflict if two tables are routed to one table.
+func (r *Router) Verify(tables []model.TableName) error {
+	if r == nil {
+		return nil
+	}
+	sources := make(map[string]model.TableName, len(tables))
+	for _, table := range tables {
+		target := r.RouteTableName(table)
+		key := strings.ToLower(target.Schema + "." + target.Table)
+		source, ok := sources[key]
+		if !ok {
+			sources[key] = table
+			continue
+		}
+		// Partitions of a table have the same name.
+		if !strings.EqualFold(source.Schema, table.Schema) ||
+			!strings.EqualFold(source.Table, table.Table) {
+			return cerror.ErrSinkRouteConflict.GenWithStackByArgs(
+				source.String(), table.String(), target.String())
+		}
+	}
+	return nil
+}
+
+// VerifyTables routes tables by the route rules of the replica config. It
+// returns the routed tables, or ErrSinkRouteConflict if two tables are routed
+// to one table.
+func VerifyTables(
+	cfg *config.ReplicaConfig, tableInfos []*model.TableInfo,
+) ([]*model.TableInfo, error) {
+	if cfg == nil || cfg.Sink == nil {
+		return tableInfos, nil
+	}
+	router, err := NewRouter(cfg.Sink.Routes)
+	if err != nil || router == nil {
+		return tableInfos, err
+	}
+	names := make([]model.TableName, 0, len(tableInfos))
+	routed := make([]*model.TableInfo, 0, len(tableInfos))
+	for _, info := range tableInfos {
+		names = append(names, info.TableName)
+		routed = append(routed, router.RouteTableInfo(info))
+	}
+	if err := router.Verify(names); err != nil {
+		return nil, err
+	}
+	return routed, nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(t *testing.T) *Router {
+	router, err := NewRouter([]*config.RouteRule{
+		{Matcher: []string{"app.users"}, TargetSchema: "app_shadow", TargetTable: "users_v2"},
+		{Matcher: []string{"app.*"}, TargetSchema: "app_shadow"},
+		{Matcher: []string{"log_*.*"}, TargetSchema: "log", TargetTable: "{schema}_{table}"},
+	})
+	require.NoError(t, err)
+	return router
+}
+
+func newTableInfo(schema, table string, id int64) *model.TableInfo {
+	return model.WrapTableInfo(1, schema, 1, &timodel.TableInfo{
+		ID:   id,
+		Name: timodel.NewCIStr(table),
+	})
+}
+
+func TestRoute(t *testing.T) {
+	t.Parallel()
+
+	router, err := NewRouter(nil)
+	require.NoError(t, err)
+	require.Nil(t, router)
+	schema, table := router.Route("app", "users")
+	require.Equal(t, "app", schema)
+	require.Equal(t, "users", table)
+
+	router = newTestRouter(t)
+	for _, tc := range []struct {
+		schema, table             string
+		targetSchema, targetTable string
+	}{
+		{"app", "users", "app_shadow", "users_v2"},
+		{"APP", "Users", "app_shadow", "users_v2"},
+		{"app", "orders", "app_shadow", "orders"},
+		{"log_1", "t", "log", "log_1_t"},
+		{"test", "t", "test", "t"},
+		// schemas are routed only by the rules matching all of their tables.
+		{"app", "", "app_shadow", ""},
+		{"log_1", "", "log", ""},
+		{"test", "", "test", ""},
+	} {
+		schema, table := router.Route(tc.schema, tc.table)
+		require.Equal(t, tc.targetSchema, schema, tc)
+		require.Equal(t, tc.targetTable, table, tc)
+	}
+
+	name := router.RouteTableName(model.TableName{
+		Schema: "app", Table: "users", TableID: 10, IsPartition: true,
+	})
+	require.Equal(t, model.TableName{
+		Schema: "app_shadow", Table: "users_v2", TableID: 10, IsPartition: true,
+	}, name)
+
+	info := newTableInfo("app", "users", 10)
+	routed := router.RouteTableInfo(info)
+	require.Equal(t, "app_shadow.users_v2", routed.TableName.String())
+	require.Equal(t, int64(10), routed.TableName.TableID)
+	require.Equal(t, "users_v2", routed.Name.O)
+	// the table info is not modified.
+	require.Equal(t, "app.users", info.TableName.String())
+	require.Equal(t, "users", info.Name.O)
+	info = newTableInfo("test", "t", 11)
+	require.Same(t, info, router.RouteTableInfo(info))
+}
+
+func TestRouteDDL(t *testing.T) {
+	t.Parallel()
+
+	router := newTestRouter(t)
+	for _, tc := range []struct {
+		query         string
+		table         *model.TableInfo
+		preTable      *model.TableInfo
+		expectedQuery string
+	}{
+		{
+			query:         "alter table users add column c int",
+			table:         newTableInfo("app", "users", 10),
+			expectedQuery: "ALTER TABLE `app_shadow`.`users_v2` ADD COLUMN `c` INT",
+		},
+		{
+			query:         "create table `app`.`orders` like test.t",
+			table:         newTableInfo("app", "orders", 11),
+			expectedQuery: "CREATE TABLE `app_shadow`.`orders` LIKE `test`.`t`",
+		},
+		{
+			query:         "rename table t to app.users",
+			table:         newTableInfo("app", "users", 10),
+			preTable:      newTableInfo("test", "t", 10),
+			expectedQuery: "RENAME TABLE `test`.`t` TO `app_shadow`.`users_v2`",
+		},
+		{
+			query:         "create database app",
+			table:         &model.TableInfo{TableName: model.TableName{Schema: "app"}},
+			expectedQuery: "CREATE DATABASE `app_shadow`",
+		},
+		{
+			query:         "create table t (id int primary key)",
+			table:         newTableInfo("test", "t", 12),
+			expectedQuery: "create table t (id int primary key)",
+		},
+	} {
+		ddl := &model.DDLEvent{
+			CommitTs:     100,
+			Query:        tc.query,
+			TableInfo:    tc.table,
+			PreTableInfo: tc.preTable,
+		}
+		routed, err := router.RouteDDL(ddl)
+		require.NoError(t, err)
+		require.Equal(t, tc.expectedQuery, routed.Query)
+		require.Equal(t, uint64(100), routed.CommitTs)
+		require.Equal(t, tc.query, ddl.Query)
+	}
+
+	ddl := &model.DDLEvent{Query: "create table t (id int primary key)", TableInfo: newTableInfo("test", "t", 12)}
+	routed, err := router.RouteDDL(ddl)
+	require.NoError(t, err)
+	require.Same(t, ddl, routed)
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	router := newTestRouter(t)
+	require.NoError(t, router.Verify([]model.TableName{
+		{Schema: "app", Table: "users", TableID: 1},
+		{Schema: "app", Table: "orders", TableID: 2},
+		// partitions of a table are routed to one table.
+		{Schema: "log_1", Table: "t", TableID: 3, IsPartition: true},
+		{Schema: "log_1", Table: "t", TableID: 4, IsPartition: true},
+	}))
+	err := router.Verify([]model.TableName{
+		{Schema: "app", Table: "users", TableID: 1},
+		{Schema: "app_shadow", Table: "users_v2", TableID: 2},
+	})
+	require.Regexp(t, "ErrSinkRouteConflict", err)
+	err = router.Verify([]model.TableName{
+		{Schema: "log_1", Table: "t", TableID: 1},
+		{Schema: "log", Table: "log_1_t", TableID: 2},
+	})
+	require.Regexp(t, "ErrSinkRouteConflict", err)
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.Routes = []*config.RouteRule{
+		{Matcher: []string{"app.*"}, TargetSchema: "app_shadow"},
+	}
+	routed, err := VerifyTables(cfg, []*model.TableInfo{newTableInfo("app", "users", 1)})
+	require.NoError(t, err)
+	require.Equal(t, "app_shadow.users", routed[0].TableName.String())
+}
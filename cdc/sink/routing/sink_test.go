@@ -0,0 +1,120 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTableSink struct {
+	tablesink.TableSink
+	rows []*model.RowChangedEvent
+}
+
+func (m *mockTableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	m.rows = append(m.rows, rows...)
+}
+
+type mockDDLSink struct {
+	ddlsink.Sink
+	ddls   []*model.DDLEvent
+	tables []*model.TableInfo
+}
+
+func (m *mockDDLSink) WriteDDLEvent(_ context.Context, ddl *model.DDLEvent) error {
+	m.ddls = append(m.ddls, ddl)
+	return nil
+}
+
+func (m *mockDDLSink) WriteCheckpointTs(
+	_ context.Context, _ uint64, tables []*model.TableInfo,
+) error {
+	m.tables = tables
+	return nil
+}
+
+func TestTableSink(t *testing.T) {
+	t.Parallel()
+
+	inner := &mockTableSink{}
+	sink := NewTableSink(inner, newTestRouter(t))
+	info := newTableInfo("app", "users", 10)
+	name := info.TableName
+	rows := []*model.RowChangedEvent{
+		{CommitTs: 1, Table: &name, TableInfo: info},
+		{CommitTs: 2, Table: &name, TableInfo: info},
+	}
+	sink.AppendRowChangedEvents(rows...)
+	require.Len(t, inner.rows, 2)
+	for i, row := range inner.rows {
+		require.Equal(t, rows[i].CommitTs, row.CommitTs)
+		require.Equal(t, "app_shadow.users_v2", row.Table.String())
+		require.Equal(t, int64(10), row.Table.TableID)
+		require.Equal(t, "app_shadow.users_v2", row.TableInfo.TableName.String())
+	}
+	// routed names and table infos are cached.
+	require.Same(t, inner.rows[0].Table, inner.rows[1].Table)
+	require.Same(t, inner.rows[0].TableInfo, inner.rows[1].TableInfo)
+	// appended rows are not modified.
+	require.Equal(t, "app.users", rows[0].Table.String())
+	require.Same(t, info, rows[0].TableInfo)
+
+	info = newTableInfo("test", "t", 11)
+	name = info.TableName
+	row := &model.RowChangedEvent{CommitTs: 3, Table: &name, TableInfo: info}
+	sink.AppendRowChangedEvents(row)
+	require.Same(t, row, inner.rows[2])
+}
+
+func TestDDLSink(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	inner := &mockDDLSink{}
+	sink := NewDDLSink(inner, newTestRouter(t))
+
+	require.NoError(t, sink.WriteDDLEvent(ctx, &model.DDLEvent{
+		CommitTs:  100,
+		Query:     "truncate table users",
+		TableInfo: newTableInfo("app", "users", 10),
+	}))
+	require.Len(t, inner.ddls, 1)
+	require.Equal(t, "TRUNCATE TABLE `app_shadow`.`users_v2`", inner.ddls[0].Query)
+	require.Equal(t, "app_shadow.users_v2", inner.ddls[0].TableInfo.TableName.String())
+
+	tables := []*model.TableInfo{
+		newTableInfo("app", "users", 10),
+		newTableInfo("test", "t", 11),
+	}
+	require.NoError(t, sink.WriteCheckpointTs(ctx, 100, tables))
+	require.Equal(t, "app_shadow.users_v2", inner.tables[0].TableName.String())
+	require.Same(t, tables[1], inner.tables[1])
+	routed := inner.tables
+	require.NoError(t, sink.WriteCheckpointTs(ctx, 101, tables))
+	require.Same(t, routed[0], inner.tables[0])
+
+	tables = append(tables, newTableInfo("app_shadow", "users_v2", 12))
+	err := sink.WriteCheckpointTs(ctx, 102, tables)
+	require.Regexp(t, "ErrSinkRouteConflict", err)
+
+	// the underlying sink is neither a SchemaChecker nor a TopicRemover.
+	require.NoError(t, sink.CheckSchema(ctx, tables))
+	require.NoError(t, sink.RemoveTopics(ctx, tables))
+}
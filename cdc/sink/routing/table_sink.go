@@ -0,0 +1,105 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+)
+
+// Assert TableSink implementation
+var _ tablesink.TableSink = (*TableSink)(nil)
+
+// TableSink is a table sink which routes the table names of events before
+// they're written to the underlying table sink. Events are shallow copied,
+// the appended events are never modified.
+type TableSink struct {
+	sink   tablesink.TableSink
+	router *Router
+
+	// names and infos cache the routed table names and table infos. Only one
+	// table is written to a table sink, so they hardly grow.
+	names map[model.TableName]*model.TableName
+	infos map[*model.TableInfo]*model.TableInfo
+}
+
+// NewTableSink creates a TableSink.
+func NewTableSink(sink tablesink.TableSink, router *Router) *TableSink {
+	return &TableSink{
+		sink:   sink,
+		router: router,
+		names:  make(map[model.TableName]*model.TableName),
+		infos:  make(map[*model.TableInfo]*model.TableInfo),
+	}
+}
+
+// AppendRowChangedEvents routes and appends row changed events.
+func (t *TableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	routed := make([]*model.RowChangedEvent, 0, len(rows))
+	for _, row := range rows {
+		routed = append(routed, t.routeRow(row))
+	}
+	t.sink.AppendRowChangedEvents(routed...)
+}
+
+// UpdateResolvedTs advances the resolved ts of the underlying table sink.
+func (t *TableSink) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
+	return t.sink.UpdateResolvedTs(resolvedTs)
+}
+
+// GetCheckpointTs returns the checkpoint ts of the underlying table sink.
+func (t *TableSink) GetCheckpointTs() model.ResolvedTs {
+	return t.sink.GetCheckpointTs()
+}
+
+// Close closes the underlying table sink.
+func (t *TableSink) Close() {
+	t.sink.Close()
+}
+
+// AsyncClose closes the underlying table sink asynchronously.
+func (t *TableSink) AsyncClose() bool {
+	return t.sink.AsyncClose()
+}
+
+func (t *TableSink) routeRow(row *model.RowChangedEvent) *model.RowChangedEvent {
+	var table *model.TableName
+	if row.Table != nil {
+		var ok bool
+		if table, ok = t.names[*row.Table]; !ok {
+			name := t.router.RouteTableName(*row.Table)
+			table = &name
+			t.names[*row.Table] = table
+		}
+	}
+	info := row.TableInfo
+	if info != nil {
+		routedInfo, ok := t.infos[info]
+		if !ok {
+			// Table infos are replaced after DDLs, only the latest one is kept.
+			t.infos = map[*model.TableInfo]*model.TableInfo{
+				info: t.router.RouteTableInfo(info),
+			}
+			routedInfo = t.infos[info]
+		}
+		info = routedInfo
+	}
+	if (row.Table == nil || *table == *row.Table) && info == row.TableInfo {
+		return row
+	}
+	routed := *row
+	routed.Table = table
+	routed.TableInfo = info
+	return &routed
+}
@@ -15,6 +15,7 @@ package tablesink
 
 import (
 	"sort"
+	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -22,6 +23,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
 	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
@@ -48,6 +50,10 @@ type EventTableSink[E dmlsink.TableEvent, P dmlsink.Appender[E]] struct {
 
 	// For dataflow metrics.
 	metricsTableSinkTotalRows prometheus.Counter
+	// metricsE2ELatency is the end-to-end latency of events, which is from
+	// the physical time of their commit ts to the time they are acked by
+	// the backend sink. It's nil if the latency is not recorded.
+	metricsE2ELatency prometheus.Observer
 }
 
 // New an eventTableSink with given backendSink and event appender.
@@ -58,6 +64,7 @@ func New[E dmlsink.TableEvent, P dmlsink.Appender[E]](
 	backendSink dmlsink.EventSink[E],
 	appender P,
 	totalRowsCounter prometheus.Counter,
+	e2eLatencyHistogram prometheus.Observer,
 ) *EventTableSink[E, P] {
 	return &EventTableSink[E, P]{
 		changefeedID:              changefeedID,
@@ -70,6 +77,7 @@ func New[E dmlsink.TableEvent, P dmlsink.Appender[E]](
 		eventBuffer:               make([]E, 0, 1024),
 		state:                     state.TableSinkSinking,
 		metricsTableSinkTotalRows: totalRowsCounter,
+		metricsE2ELatency:         e2eLatencyHistogram,
 	}
 }
 
@@ -116,6 +124,14 @@ func (e *EventTableSink[E, P]) UpdateResolvedTs(resolvedTs model.ResolvedTs) err
 			Callback:  e.progressTracker.addEvent(),
 			SinkState: &e.state,
 		}
+		if e.metricsE2ELatency != nil {
+			callback, commitTs := ce.Callback, ev.GetCommitTs()
+			ce.Callback = func() {
+				callback()
+				e.metricsE2ELatency.Observe(
+					time.Since(oracle.GetTimeFromTS(commitTs)).Seconds())
+			}
+		}
 		resolvedCallbackableEvents = append(resolvedCallbackableEvents, ce)
 	}
 
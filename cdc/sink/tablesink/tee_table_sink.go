@@ -0,0 +1,103 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablesink
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// Assert TableSink implementation
+var _ TableSink = (*TeeTableSink)(nil)
+
+// TeeTableSink is a table sink which writes all the events to two table sinks.
+// Its checkpoint is the smaller one of the two table sinks, that is, the
+// events are acknowledged only after both table sinks acknowledge them.
+type TeeTableSink struct {
+	primary   TableSink
+	secondary TableSink
+}
+
+// NewTeeTableSink creates a TeeTableSink.
+func NewTeeTableSink(primary, secondary TableSink) *TeeTableSink {
+	return &TeeTableSink{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// AppendRowChangedEvents appends row changed events to both table sinks.
+// Sinks may modify columns of rows in place, e.g. the MySQL sink converts
+// binary values to strings, so the secondary table sink gets copies.
+func (t *TeeTableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	copied := make([]*model.RowChangedEvent, 0, len(rows))
+	for _, row := range rows {
+		copied = append(copied, copyRowChangedEvent(row))
+	}
+	t.primary.AppendRowChangedEvents(rows...)
+	t.secondary.AppendRowChangedEvents(copied...)
+}
+
+func copyRowChangedEvent(row *model.RowChangedEvent) *model.RowChangedEvent {
+	copied := *row
+	copied.Columns = copyColumns(row.Columns)
+	copied.PreColumns = copyColumns(row.PreColumns)
+	return &copied
+}
+
+func copyColumns(cols []*model.Column) []*model.Column {
+	if cols == nil {
+		return nil
+	}
+	copied := make([]*model.Column, len(cols))
+	for i, col := range cols {
+		if col == nil {
+			continue
+		}
+		c := *col
+		copied[i] = &c
+	}
+	return copied
+}
+
+// UpdateResolvedTs advances the resolved ts of both table sinks.
+func (t *TeeTableSink) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
+	if err := t.primary.UpdateResolvedTs(resolvedTs); err != nil {
+		return err
+	}
+	return t.secondary.UpdateResolvedTs(resolvedTs)
+}
+
+// GetCheckpointTs returns the smaller checkpoint ts of the two table sinks.
+func (t *TeeTableSink) GetCheckpointTs() model.ResolvedTs {
+	primary := t.primary.GetCheckpointTs()
+	secondary := t.secondary.GetCheckpointTs()
+	if secondary.Less(primary) {
+		return secondary
+	}
+	return primary
+}
+
+// Close closes both table sinks.
+func (t *TeeTableSink) Close() {
+	t.primary.Close()
+	t.secondary.Close()
+}
+
+// AsyncClose closes both table sinks asynchronously.
+// Returns true if both of them are closed.
+func (t *TeeTableSink) AsyncClose() bool {
+	primaryClosed := t.primary.AsyncClose()
+	secondaryClosed := t.secondary.AsyncClose()
+	return primaryClosed && secondaryClosed
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablesink
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/txn"
+	"github.com/pingcap/tiflow/pkg/config"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeeTableSink(t *testing.T) {
+	t.Parallel()
+
+	newTableSink := func(sink *mockEventSink) TableSink {
+		return New[*model.SingleTableTxn](
+			model.DefaultChangeFeedID("1"), spanz.TableIDToComparableSpan(1), model.Ts(0),
+			sink, &dmlsink.TxnEventAppender{}, prometheus.NewCounter(prometheus.CounterOpts{}), nil)
+	}
+	primarySink := &mockEventSink{dead: make(chan struct{})}
+	secondarySink := &mockEventSink{dead: make(chan struct{})}
+	primary, secondary := newTableSink(primarySink), newTableSink(secondarySink)
+	tb := NewTeeTableSink(primary, secondary)
+
+	tb.AppendRowChangedEvents(getTestRows()...)
+	require.Nil(t, tb.UpdateResolvedTs(model.NewResolvedTs(105)))
+	require.Len(t, primarySink.events, 7, "all txns should be written to the primary sink")
+	require.Len(t, secondarySink.events, 7, "all txns should be written to the secondary sink")
+
+	// The checkpoint only advances after both sinks acknowledge the events.
+	primarySink.acknowledge(105)
+	require.Equal(t, model.NewResolvedTs(105), primary.GetCheckpointTs())
+	require.Equal(t, secondary.GetCheckpointTs(), tb.GetCheckpointTs())
+	require.True(t, tb.GetCheckpointTs().Less(model.NewResolvedTs(105)))
+
+	secondarySink.acknowledge(103)
+	require.Equal(t, secondary.GetCheckpointTs(), tb.GetCheckpointTs())
+	require.True(t, tb.GetCheckpointTs().Less(model.NewResolvedTs(105)))
+
+	secondarySink.acknowledge(105)
+	require.Equal(t, model.NewResolvedTs(105), tb.GetCheckpointTs())
+	require.True(t, tb.AsyncClose())
+}
+
+// TestTeeTableSinkWithMySQLSink checks the MySQL sink doesn't modify rows
+// written to the other sink. Run it with -race to check data races.
+func TestTeeTableSinkWithMySQLSink(t *testing.T) {
+	dbIndex := 0
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() { dbIndex++ }()
+
+		if dbIndex == 0 {
+			// test db
+			db, err := pmysql.MockTestDB(true)
+			require.Nil(t, err)
+			return db, nil
+		}
+
+		// normal db
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.Nil(t, err)
+		for i := 0; i < 2; i++ {
+			mock.ExpectQuery("select tidb_version()").WillReturnError(&dmysql.MySQLError{
+				Number:  1305,
+				Message: "FUNCTION test.tidb_version does not exist",
+			})
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `test`.`t1` (`a`) VALUES (?)").
+			WithArgs("abc").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectClose()
+		return db, nil
+	}
+	getDBConnBak := txn.GetDBConnImpl
+	txn.GetDBConnImpl = mockGetDBConn
+	defer func() { txn.GetDBConnImpl = getDBConnBak }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000/?worker-count=1" +
+		"&safe-mode=false&batch-dml-enable=true&cache-prep-stmts=false")
+	require.Nil(t, err)
+	mysqlSink, err := txn.NewMySQLSink(ctx, model.DefaultChangeFeedID("1"), sinkURI,
+		config.GetDefaultReplicaConfig(), make(chan error, 1), txn.DefaultConflictDetectorSlots)
+	require.Nil(t, err)
+	defer mysqlSink.Close()
+
+	span := spanz.TableIDToComparableSpan(1)
+	primary := New[*model.SingleTableTxn](
+		model.DefaultChangeFeedID("1"), span, model.Ts(0),
+		mysqlSink, &dmlsink.TxnEventAppender{}, prometheus.NewCounter(prometheus.CounterOpts{}), nil)
+	secondarySink := &mockEventSink{dead: make(chan struct{})}
+	secondary := New[*model.SingleTableTxn](
+		model.DefaultChangeFeedID("1"), span, model.Ts(0),
+		secondarySink, &dmlsink.TxnEventAppender{}, prometheus.NewCounter(prometheus.CounterOpts{}), nil)
+	tb := NewTeeTableSink(primary, secondary)
+
+	row := &model.RowChangedEvent{
+		StartTs:       2,
+		CommitTs:      3,
+		ReplicatingTs: 1,
+		Table:         &model.TableName{Schema: "test", Table: "t1", TableID: 1},
+		Columns: []*model.Column{{
+			Name:    "a",
+			Type:    mysql.TypeVarchar,
+			Charset: charset.CharsetUTF8MB4,
+			Flag:    model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value:   []byte("abc"),
+		}},
+	}
+	tb.AppendRowChangedEvents(row)
+	require.Nil(t, tb.UpdateResolvedTs(model.NewResolvedTs(3)))
+	require.Len(t, secondarySink.events, 1)
+	secondaryRow := secondarySink.events[0].Event.Rows[0]
+	require.NotSame(t, row, secondaryRow)
+
+	// Read the row written to the secondary sink, like an encoder does,
+	// while the MySQL sink is writing the row.
+	done := make(chan struct{})
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if _, ok := secondaryRow.Columns[0].Value.([]byte); !ok {
+				t.Error("the row written to the secondary sink is modified")
+				return
+			}
+		}
+	}()
+	require.Eventually(t, func() bool {
+		return primary.GetCheckpointTs() == model.NewResolvedTs(3)
+	}, 5*time.Second, 10*time.Millisecond)
+	close(done)
+	<-readerDone
+
+	require.Equal(t, "abc", row.Columns[0].Value)
+	require.Equal(t, []byte("abc"), secondaryRow.Columns[0].Value)
+	secondarySink.acknowledge(3)
+	require.Equal(t, model.NewResolvedTs(3), tb.GetCheckpointTs())
+	tb.Close()
+}
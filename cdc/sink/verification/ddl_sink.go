@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verification
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+)
+
+// Assert Sink implementation
+var _ ddlsink.Sink = (*DDLSink)(nil)
+
+// DDLSink is a DDL sink which verifies the DDL events and checkpoint ts
+// before they're written to the underlying DDL sink. It asserts that:
+//   - DDL events are in the order of commit ts,
+//   - the checkpoint ts never goes backwards,
+//   - no DDL event is below the checkpoint ts.
+//
+// A DDL event is executed when the checkpoint ts reaches its commit ts - 1,
+// or exactly at the checkpoint ts if it's a bootstrap DDL, so only DDL
+// events below the checkpoint ts are violations.
+// Violations are only reported, they never fail the DDL sink.
+type DDLSink struct {
+	sink ddlsink.Sink
+
+	mu           sync.Mutex
+	checkpointTs model.Ts
+	lastDDLTs    model.Ts
+	report       Reporter
+}
+
+// NewDDLSink creates a DDLSink.
+func NewDDLSink(sink ddlsink.Sink, report Reporter) *DDLSink {
+	return &DDLSink{sink: sink, report: report}
+}
+
+// WriteDDLEvent verifies and writes a DDL event.
+func (d *DDLSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	d.mu.Lock()
+	var table string
+	if ddl.TableInfo != nil {
+		table = ddl.TableInfo.TableName.String()
+	}
+	if ddl.CommitTs < d.checkpointTs {
+		d.report(Violation{
+			Kind:  KindEventBelowCheckpoint,
+			Table: table,
+			Ts:    ddl.CommitTs,
+			Bound: d.checkpointTs,
+		})
+	}
+	if ddl.CommitTs < d.lastDDLTs {
+		d.report(Violation{
+			Kind:  KindCommitTsRegression,
+			Table: table,
+			Ts:    ddl.CommitTs,
+			Bound: d.lastDDLTs,
+		})
+	} else {
+		d.lastDDLTs = ddl.CommitTs
+	}
+	d.mu.Unlock()
+	return d.sink.WriteDDLEvent(ctx, ddl)
+}
+
+// WriteCheckpointTs verifies and writes the checkpoint ts.
+func (d *DDLSink) WriteCheckpointTs(
+	ctx context.Context, ts uint64, tables []*model.TableInfo,
+) error {
+	d.mu.Lock()
+	if ts < d.checkpointTs {
+		d.report(Violation{
+			Kind:  KindCheckpointTsRegression,
+			Ts:    ts,
+			Bound: d.checkpointTs,
+		})
+	} else {
+		d.checkpointTs = ts
+	}
+	d.mu.Unlock()
+	return d.sink.WriteCheckpointTs(ctx, ts, tables)
+}
+
+// Close closes the underlying DDL sink.
+func (d *DDLSink) Close() {
+	d.sink.Close()
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verification
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+)
+
+// Assert TableSink implementation
+var _ tablesink.TableSink = (*TableSink)(nil)
+
+// TableSink is a table sink which verifies the events appended to it before
+// they're written to the underlying table sink. It asserts that:
+//   - events of a row are in the order of commit ts,
+//   - the resolved ts never goes backwards,
+//   - no event is below the checkpoint ts or the resolved ts.
+//
+// Violations are only reported, they never fail the table sink.
+type TableSink struct {
+	sink  tablesink.TableSink
+	table string
+	// checkpointTs is the initial checkpoint ts of the table sink.
+	checkpointTs  model.Ts
+	maxResolvedTs model.ResolvedTs
+	// keys are the commit ts of the latest events of rows. Rows whose latest
+	// events are resolved are removed, any later event of them must be
+	// greater than the resolved ts, which is verified separately.
+	keys   map[string]model.Ts
+	report Reporter
+}
+
+// NewTableSink creates a TableSink.
+func NewTableSink(
+	sink tablesink.TableSink, table string, checkpointTs model.Ts, report Reporter,
+) *TableSink {
+	return &TableSink{
+		sink:          sink,
+		table:         table,
+		checkpointTs:  checkpointTs,
+		maxResolvedTs: model.NewResolvedTs(0),
+		keys:          make(map[string]model.Ts),
+		report:        report,
+	}
+}
+
+// AppendRowChangedEvents verifies and appends row changed events.
+func (t *TableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	for _, row := range rows {
+		t.verifyRow(row)
+	}
+	t.sink.AppendRowChangedEvents(rows...)
+}
+
+// UpdateResolvedTs verifies and advances the resolved ts.
+func (t *TableSink) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
+	if resolvedTs.Ts < t.maxResolvedTs.Ts {
+		t.report(Violation{
+			Kind:  KindResolvedTsRegression,
+			Table: t.table,
+			Ts:    resolvedTs.Ts,
+			Bound: t.maxResolvedTs.Ts,
+		})
+	} else if t.maxResolvedTs.Less(resolvedTs) {
+		t.maxResolvedTs = resolvedTs
+		mark := resolvedTs.ResolvedMark()
+		for key, commitTs := range t.keys {
+			if commitTs <= mark {
+				delete(t.keys, key)
+			}
+		}
+	}
+	return t.sink.UpdateResolvedTs(resolvedTs)
+}
+
+// GetCheckpointTs returns the checkpoint ts of the underlying table sink.
+func (t *TableSink) GetCheckpointTs() model.ResolvedTs {
+	return t.sink.GetCheckpointTs()
+}
+
+// Close closes the underlying table sink.
+func (t *TableSink) Close() {
+	t.sink.Close()
+}
+
+// AsyncClose closes the underlying table sink asynchronously.
+func (t *TableSink) AsyncClose() bool {
+	return t.sink.AsyncClose()
+}
+
+func (t *TableSink) verifyRow(row *model.RowChangedEvent) {
+	if row.CommitTs <= t.checkpointTs {
+		t.report(Violation{
+			Kind:  KindEventBelowCheckpoint,
+			Table: t.table,
+			Key:   handleKey(row.Columns, row.PreColumns),
+			Ts:    row.CommitTs,
+			Bound: t.checkpointTs + 1,
+		})
+	}
+	if mark := t.maxResolvedTs.ResolvedMark(); row.CommitTs <= mark {
+		t.report(Violation{
+			Kind:  KindEventBelowResolvedTs,
+			Table: t.table,
+			Key:   handleKey(row.Columns, row.PreColumns),
+			Ts:    row.CommitTs,
+			Bound: mark + 1,
+		})
+	}
+	// The handle key may be changed by an update, both of the old one and
+	// the new one are verified.
+	for _, cols := range [][]*model.Column{row.PreColumns, row.Columns} {
+		key := handleKey(cols)
+		if key == "" {
+			continue
+		}
+		if last, ok := t.keys[key]; ok && row.CommitTs < last {
+			t.report(Violation{
+				Kind:  KindCommitTsRegression,
+				Table: t.table,
+				Key:   key,
+				Ts:    row.CommitTs,
+				Bound: last,
+			})
+			continue
+		}
+		t.keys[key] = row.CommitTs
+	}
+}
+
+// handleKey returns the handle key values of the first non-empty columns,
+// it's empty if the table has no handle key.
+func handleKey(columns ...[]*model.Column) string {
+	for _, cols := range columns {
+		values := make([]string, 0, 1)
+		for _, col := range cols {
+			if col != nil && col.Flag.IsHandleKey() {
+				values = append(values, strconv.Quote(model.ColumnValueString(col.Value)))
+			}
+		}
+		if len(values) != 0 {
+			return strings.Join(values, ",")
+		}
+	}
+	return ""
+}
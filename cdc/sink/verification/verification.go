@@ -0,0 +1,98 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verification verifies the events written to sinks. It's used by
+// the verification mode of the blackhole sink, which consumes events like a
+// blackhole sink but asserts the ordering invariants of them, so that
+// violations can be found by integration tests and canary changefeeds.
+package verification
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	mverification "github.com/pingcap/tiflow/cdc/sink/metrics/verification"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// verifyParam is the sink uri parameter to enable the verification mode.
+const verifyParam = "verify"
+
+// Kinds of violations.
+const (
+	// KindCommitTsRegression means an event of a row has a smaller commit ts
+	// than the previous event of the same row.
+	KindCommitTsRegression = "commit-ts-regression"
+	// KindResolvedTsRegression means the resolved ts goes backwards.
+	KindResolvedTsRegression = "resolved-ts-regression"
+	// KindCheckpointTsRegression means the checkpoint ts goes backwards.
+	KindCheckpointTsRegression = "checkpoint-ts-regression"
+	// KindEventBelowCheckpoint means an event is not greater than the
+	// checkpoint ts, which should have been replicated.
+	KindEventBelowCheckpoint = "event-below-checkpoint"
+	// KindEventBelowResolvedTs means an event arrives after the resolved ts
+	// which covers it.
+	KindEventBelowResolvedTs = "event-below-resolved-ts"
+)
+
+// Violation is a violation of the invariants of events.
+type Violation struct {
+	Kind string
+	// Table is the table or the table span of the event, it's empty for
+	// violations of the changefeed, e.g. the checkpoint ts regression.
+	Table string
+	// Key is the handle key of the row, if any.
+	Key string
+	// Ts is the commit ts of the event or the resolved ts or checkpoint ts
+	// which violates the invariant.
+	Ts model.Ts
+	// Bound is the ts that Ts is expected to be greater than or equal to.
+	Bound model.Ts
+}
+
+// Reporter reports violations.
+type Reporter func(v Violation)
+
+// NewReporter returns a Reporter which logs and counts violations of
+// the changefeed.
+func NewReporter(changefeedID model.ChangeFeedID) Reporter {
+	return func(v Violation) {
+		// NOTE: don't change the log, some tests depend on it.
+		log.Error("verification sink found a violation",
+			zap.String("namespace", changefeedID.Namespace),
+			zap.String("changefeed", changefeedID.ID),
+			zap.String("kind", v.Kind),
+			zap.String("table", v.Table),
+			zap.String("key", v.Key),
+			zap.Uint64("ts", v.Ts),
+			zap.Uint64("bound", v.Bound))
+		mverification.ViolationCounter.WithLabelValues(
+			changefeedID.Namespace, changefeedID.ID, v.Kind).Inc()
+	}
+}
+
+// IsEnabled returns true if the verification mode is enabled by the sink uri.
+func IsEnabled(sinkURI *url.URL) (bool, error) {
+	s := sinkURI.Query().Get(verifyParam)
+	if s == "" {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	return enabled, nil
+}
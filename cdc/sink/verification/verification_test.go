@@ -0,0 +1,172 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verification
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/blackhole"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTableSink struct {
+	tablesink.TableSink
+	rows       []*model.RowChangedEvent
+	resolvedTs []model.ResolvedTs
+}
+
+func (m *mockTableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	m.rows = append(m.rows, rows...)
+}
+
+func (m *mockTableSink) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
+	m.resolvedTs = append(m.resolvedTs, resolvedTs)
+	return nil
+}
+
+func newRow(commitTs model.Ts, pre, post string) *model.RowChangedEvent {
+	row := &model.RowChangedEvent{CommitTs: commitTs}
+	if pre != "" {
+		row.PreColumns = []*model.Column{
+			{Name: "id", Value: pre, Flag: model.HandleKeyFlag},
+			{Name: "v", Value: 1},
+		}
+	}
+	if post != "" {
+		row.Columns = []*model.Column{
+			{Name: "id", Value: post, Flag: model.HandleKeyFlag},
+			{Name: "v", Value: 2},
+		}
+	}
+	return row
+}
+
+func TestIsEnabled(t *testing.T) {
+	t.Parallel()
+
+	for uri, expected := range map[string]bool{
+		"blackhole://":              false,
+		"blackhole://?verify=true":  true,
+		"blackhole://?verify=1":     true,
+		"blackhole://?verify=false": false,
+	} {
+		sinkURI, err := url.Parse(uri)
+		require.Nil(t, err)
+		enabled, err := IsEnabled(sinkURI)
+		require.Nil(t, err)
+		require.Equal(t, expected, enabled, uri)
+	}
+	sinkURI, err := url.Parse("blackhole://?verify=xxx")
+	require.Nil(t, err)
+	_, err = IsEnabled(sinkURI)
+	require.Error(t, err)
+}
+
+func TestTableSink(t *testing.T) {
+	t.Parallel()
+
+	var violations []Violation
+	report := func(v Violation) { violations = append(violations, v) }
+	inner := &mockTableSink{}
+	sink := NewTableSink(inner, "t1", 100, report)
+
+	// Events of different rows, and an update which changes the handle key.
+	sink.AppendRowChangedEvents(newRow(101, "", "1"), newRow(102, "", "2"))
+	sink.AppendRowChangedEvents(newRow(103, "1", "3"))
+	require.Empty(t, violations)
+	require.Len(t, inner.rows, 3)
+
+	// The old handle key of the update goes backwards.
+	sink.AppendRowChangedEvents(newRow(102, "", "1"))
+	require.Equal(t, []Violation{{
+		Kind: KindCommitTsRegression, Table: "t1", Key: `"1"`, Ts: 102, Bound: 103,
+	}}, violations)
+	violations = nil
+
+	// Below the checkpoint ts.
+	sink.AppendRowChangedEvents(newRow(100, "4", ""))
+	require.Equal(t, []Violation{{
+		Kind: KindEventBelowCheckpoint, Table: "t1", Key: `"4"`, Ts: 100, Bound: 101,
+	}}, violations)
+	violations = nil
+
+	require.Nil(t, sink.UpdateResolvedTs(model.NewResolvedTs(103)))
+	require.Empty(t, violations)
+	// Resolved rows are removed.
+	require.Empty(t, sink.keys)
+
+	// Below the resolved ts.
+	sink.AppendRowChangedEvents(newRow(103, "", "5"))
+	require.Equal(t, []Violation{{
+		Kind: KindEventBelowResolvedTs, Table: "t1", Key: `"5"`, Ts: 103, Bound: 104,
+	}}, violations)
+	violations = nil
+
+	// Resolved ts goes backwards.
+	require.Nil(t, sink.UpdateResolvedTs(model.NewResolvedTs(102)))
+	require.Equal(t, []Violation{{
+		Kind: KindResolvedTsRegression, Table: "t1", Ts: 102, Bound: 103,
+	}}, violations)
+	violations = nil
+
+	// Events of a big txn are split by batch resolved ts, they have the same
+	// commit ts as the resolved ts.
+	sink.AppendRowChangedEvents(newRow(105, "", "6"))
+	require.Nil(t, sink.UpdateResolvedTs(model.ResolvedTs{
+		Mode: model.BatchResolvedMode, Ts: 105, BatchID: 1,
+	}))
+	sink.AppendRowChangedEvents(newRow(105, "", "7"))
+	require.Nil(t, sink.UpdateResolvedTs(model.NewResolvedTs(105)))
+	require.Empty(t, violations)
+	require.Len(t, inner.resolvedTs, 4)
+	require.Len(t, inner.rows, 8)
+}
+
+func TestDDLSink(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var violations []Violation
+	report := func(v Violation) { violations = append(violations, v) }
+	sink := NewDDLSink(blackhole.NewDDLSink(), report)
+	defer sink.Close()
+	newDDL := func(commitTs model.Ts) *model.DDLEvent {
+		return &model.DDLEvent{
+			CommitTs: commitTs,
+			TableInfo: &model.TableInfo{
+				TableName: model.TableName{Schema: "test", Table: "t1"},
+			},
+		}
+	}
+
+	require.Nil(t, sink.WriteCheckpointTs(ctx, 100, nil))
+	// A bootstrap DDL is executed at the checkpoint ts.
+	require.Nil(t, sink.WriteDDLEvent(ctx, newDDL(100)))
+	require.Nil(t, sink.WriteDDLEvent(ctx, newDDL(101)))
+	require.Nil(t, sink.WriteDDLEvent(ctx, newDDL(101)))
+	require.Nil(t, sink.WriteCheckpointTs(ctx, 101, nil))
+	require.Empty(t, violations)
+
+	require.Nil(t, sink.WriteCheckpointTs(ctx, 99, nil))
+	require.Nil(t, sink.WriteDDLEvent(ctx, newDDL(100)))
+	require.Equal(t, []Violation{
+		{Kind: KindCheckpointTsRegression, Ts: 99, Bound: 101},
+		{Kind: KindEventBelowCheckpoint, Table: "test.t1", Ts: 100, Bound: 101},
+		{Kind: KindCommitTsRegression, Table: "test.t1", Ts: 100, Bound: 101},
+	}, violations)
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// defaultHistorySize is the default number of rows retained for subscribers.
+const defaultHistorySize = 8192
+
+// Assert EventSink[E event.TableEvent] implementation
+var _ dmlsink.EventSink[*model.RowChangedEvent] = (*Hub)(nil)
+
+// logEntry is a batch of events in the history, the events are sorted by
+// commit ts and they are all covered by the resolved ts.
+type logEntry struct {
+	events     []*model.RowChangedEvent
+	resolvedTs model.Ts
+}
+
+// Hub is the sink of a changefeed whose sink URI is `grpc://`.
+//
+// It collects the events of the table sinks on this capture, and once the
+// minimum resolved ts of the table sinks advances, the covered events are
+// sorted by commit ts and appended to a bounded history, which is read by
+// the subscribers of the subscription service.
+type Hub struct {
+	changefeedID model.ChangeFeedID
+	registry     *Registry
+	historySize  int
+
+	mu sync.Mutex
+	// tables are the resolved ts of the table sinks on this capture.
+	tables map[*TableSink]model.Ts
+	// pending are the events which are not covered by resolvedTs yet.
+	pending    []*model.RowChangedEvent
+	resolvedTs model.Ts
+	history    []*logEntry
+	// firstSeq is the sequence number of history[0].
+	firstSeq    uint64
+	historyRows int
+	// lowWatermark is the resolved ts before the first entry of the history,
+	// all the events whose commit ts are greater than it are retained.
+	lowWatermark model.Ts
+	// epoch is increased once the history is reset, subscriptions of the
+	// previous epochs are aborted.
+	epoch uint64
+	// notify is closed and replaced once the history is changed.
+	notify chan struct{}
+	closed bool
+}
+
+// NewHub creates a Hub and registers it to the registry. The sink URI
+// accepts `history-size`, the number of rows retained for subscribers.
+func NewHub(changefeedID model.ChangeFeedID, sinkURI *url.URL, registry *Registry) (*Hub, error) {
+	historySize := defaultHistorySize
+	if s := sinkURI.Query().Get("history-size"); s != "" {
+		size, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+		}
+		if size <= 0 {
+			return nil, cerror.ErrSinkURIInvalid.GenWithStack("invalid history-size %d", size)
+		}
+		historySize = size
+	}
+
+	h := &Hub{
+		changefeedID: changefeedID,
+		registry:     registry,
+		historySize:  historySize,
+		tables:       make(map[*TableSink]model.Ts),
+		notify:       make(chan struct{}),
+	}
+	registry.register(h)
+	log.Info("Subscription hub created",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.Int("historySize", historySize))
+	return h, nil
+}
+
+// WriteEvents buffers the events until they are covered by the resolved ts.
+// The events are acknowledged at once, because subscribers are not tracked.
+func (h *Hub) WriteEvents(rows ...*dmlsink.CallbackableEvent[*model.RowChangedEvent]) error {
+	h.mu.Lock()
+	for _, row := range rows {
+		h.pending = append(h.pending, row.Event)
+	}
+	h.mu.Unlock()
+	for _, row := range rows {
+		row.Callback()
+	}
+	return nil
+}
+
+// Close closes the hub, all the subscriptions are ended.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	close(h.notify)
+	h.mu.Unlock()
+
+	h.registry.unregister(h)
+	log.Info("Subscription hub closed",
+		zap.String("namespace", h.changefeedID.Namespace),
+		zap.String("changefeed", h.changefeedID.ID))
+}
+
+// Dead returns a checker.
+func (h *Hub) Dead() <-chan struct{} {
+	return make(chan struct{})
+}
+
+// addTable adds a table sink starting from startTs. If startTs is less than
+// the resolved ts, which happens once the hub is created or a table is moved
+// to this capture, the history is reset because the events of the table can
+// not be sorted into it.
+func (h *Hub) addTable(t *TableSink, startTs model.Ts) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tables[t] = startTs
+	if h.resolvedTs != 0 && startTs >= h.resolvedTs {
+		return
+	}
+	h.epoch++
+	h.firstSeq += uint64(len(h.history))
+	h.history = nil
+	h.historyRows = 0
+	h.resolvedTs = startTs
+	h.lowWatermark = startTs
+	h.broadcastLocked()
+	log.Info("Subscription hub history is reset",
+		zap.String("namespace", h.changefeedID.Namespace),
+		zap.String("changefeed", h.changefeedID.ID),
+		zap.Uint64("startTs", startTs),
+		zap.Uint64("epoch", h.epoch))
+}
+
+// updateTable advances the resolved ts of a table sink.
+func (h *Hub) updateTable(t *TableSink, resolvedTs model.Ts) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if old, ok := h.tables[t]; !ok || old >= resolvedTs {
+		return
+	}
+	h.tables[t] = resolvedTs
+	h.tryAdvanceLocked()
+}
+
+// removeTable removes a table sink, it can be called more than once.
+func (h *Hub) removeTable(t *TableSink) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.tables[t]; !ok {
+		return
+	}
+	delete(h.tables, t)
+	h.tryAdvanceLocked()
+}
+
+func (h *Hub) tryAdvanceLocked() {
+	if len(h.tables) == 0 || h.closed {
+		return
+	}
+	var minResolvedTs model.Ts
+	first := true
+	for _, ts := range h.tables {
+		if first || ts < minResolvedTs {
+			minResolvedTs = ts
+			first = false
+		}
+	}
+	if minResolvedTs <= h.resolvedTs {
+		return
+	}
+	h.resolvedTs = minResolvedTs
+
+	// Events of a table are in the order of commit ts, so the stable sort
+	// keeps the order of events in a transaction.
+	sort.SliceStable(h.pending, func(i, j int) bool {
+		return h.pending[i].CommitTs < h.pending[j].CommitTs
+	})
+	i := sort.Search(len(h.pending), func(i int) bool {
+		return h.pending[i].CommitTs > minResolvedTs
+	})
+	entry := &logEntry{events: h.pending[:i:i], resolvedTs: minResolvedTs}
+	h.pending = append(make([]*model.RowChangedEvent, 0, len(h.pending)-i), h.pending[i:]...)
+
+	h.history = append(h.history, entry)
+	h.historyRows += len(entry.events)
+	for h.historyRows > h.historySize && len(h.history) > 1 {
+		evicted := h.history[0]
+		h.history[0] = nil
+		h.history = h.history[1:]
+		h.firstSeq++
+		h.historyRows -= len(evicted.events)
+		h.lowWatermark = evicted.resolvedTs
+	}
+	h.broadcastLocked()
+}
+
+func (h *Hub) broadcastLocked() {
+	close(h.notify)
+	h.notify = make(chan struct{})
+}
+
+// subscription is a reader of the history of a hub.
+type subscription struct {
+	hub    *Hub
+	epoch  uint64
+	cursor uint64
+}
+
+// subscribe creates a subscription which reads the entries containing the
+// events whose commit ts are greater than startTs.
+func (h *Hub) subscribe(startTs model.Ts) (*subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, cerror.ErrSubscriptionClosed.GenWithStackByArgs(h.changefeedID.String())
+	}
+	if startTs < h.lowWatermark {
+		return nil, cerror.ErrSubscriptionStartTsTooOld.GenWithStackByArgs(startTs, h.lowWatermark)
+	}
+	i := sort.Search(len(h.history), func(i int) bool {
+		return h.history[i].resolvedTs > startTs
+	})
+	return &subscription{
+		hub:    h,
+		epoch:  h.epoch,
+		cursor: h.firstSeq + uint64(i),
+	}, nil
+}
+
+// next blocks until there are new entries, and returns them.
+func (s *subscription) next(ctx context.Context) ([]*logEntry, error) {
+	h := s.hub
+	for {
+		h.mu.Lock()
+		if h.closed {
+			h.mu.Unlock()
+			return nil, cerror.ErrSubscriptionClosed.GenWithStackByArgs(h.changefeedID.String())
+		}
+		if h.epoch != s.epoch {
+			h.mu.Unlock()
+			return nil, cerror.ErrSubscriptionAborted.GenWithStackByArgs(
+				h.changefeedID.String(), "tables are rescheduled")
+		}
+		if s.cursor < h.firstSeq {
+			h.mu.Unlock()
+			return nil, cerror.ErrSubscriptionLagged.GenWithStackByArgs(h.changefeedID.String())
+		}
+		if end := h.firstSeq + uint64(len(h.history)); s.cursor < end {
+			entries := append([]*logEntry(nil), h.history[s.cursor-h.firstSeq:]...)
+			s.cursor = end
+			h.mu.Unlock()
+			return entries, nil
+		}
+		notify := h.notify
+		h.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		case <-notify:
+		}
+	}
+}
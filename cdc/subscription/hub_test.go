@@ -0,0 +1,183 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHub(t *testing.T, registry *Registry, uri string) *Hub {
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	hub, err := NewHub(model.DefaultChangeFeedID("test"), sinkURI, registry)
+	require.NoError(t, err)
+	return hub
+}
+
+func newTestTableSink(hub *Hub, tableID model.TableID, startTs model.Ts) *TableSink {
+	sink := tablesink.New[*model.RowChangedEvent](
+		model.DefaultChangeFeedID("test"), spanz.TableIDToComparableSpan(tableID), startTs,
+		hub, &dmlsink.RowChangeEventAppender{}, prometheus.NewCounter(prometheus.CounterOpts{}), nil)
+	return NewTableSink(sink, hub, startTs)
+}
+
+func newTestRow(tableID model.TableID, commitTs model.Ts) *model.RowChangedEvent {
+	return &model.RowChangedEvent{
+		CommitTs: commitTs,
+		Table:    &model.TableName{Schema: "test", Table: "t", TableID: tableID},
+		Columns:  []*model.Column{{Name: "a", Value: int64(commitTs)}},
+	}
+}
+
+func commitTsOf(entries []*logEntry) []model.Ts {
+	var result []model.Ts
+	for _, entry := range entries {
+		for _, event := range entry.events {
+			result = append(result, event.CommitTs)
+		}
+	}
+	return result
+}
+
+func TestNewHubWithInvalidHistorySize(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	for _, uri := range []string{"grpc://?history-size=abc", "grpc://?history-size=0"} {
+		sinkURI, err := url.Parse(uri)
+		require.NoError(t, err)
+		_, err = NewHub(model.DefaultChangeFeedID("test"), sinkURI, registry)
+		require.ErrorContains(t, err, "ErrSinkURIInvalid")
+	}
+	require.Nil(t, registry.get(model.DefaultChangeFeedID("test")))
+}
+
+func TestHubSortsEventsByResolvedTs(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	hub := newTestHub(t, registry, "grpc://")
+	defer hub.Close()
+	require.Equal(t, hub, registry.get(model.DefaultChangeFeedID("test")))
+
+	t1 := newTestTableSink(hub, 1, 100)
+	t2 := newTestTableSink(hub, 2, 100)
+	sub, err := hub.subscribe(100)
+	require.NoError(t, err)
+
+	t1.AppendRowChangedEvents(newTestRow(1, 101), newTestRow(1, 104))
+	t2.AppendRowChangedEvents(newTestRow(2, 102), newTestRow(2, 103))
+	require.NoError(t, t1.UpdateResolvedTs(model.NewResolvedTs(105)))
+
+	// The resolved ts is held by the second table.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	_, err = sub.next(ctx)
+	cancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, t2.UpdateResolvedTs(model.NewResolvedTs(103)))
+	entries, err := sub.next(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, model.Ts(103), entries[0].resolvedTs)
+	require.Equal(t, []model.Ts{101, 102, 103}, commitTsOf(entries))
+
+	// The removed table no longer holds the resolved ts.
+	t2.Close()
+	entries, err = sub.next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.Ts(105), entries[0].resolvedTs)
+	require.Equal(t, []model.Ts{104}, commitTsOf(entries))
+
+	// A late subscriber reads from the history.
+	late, err := hub.subscribe(102)
+	require.NoError(t, err)
+	entries, err = late.next(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, []model.Ts{101, 102, 103, 104}, commitTsOf(entries))
+	t1.Close()
+}
+
+func TestHubEvictsHistory(t *testing.T) {
+	t.Parallel()
+
+	hub := newTestHub(t, NewRegistry(), "grpc://?history-size=2")
+	defer hub.Close()
+	table := newTestTableSink(hub, 1, 100)
+	defer table.Close()
+	sub, err := hub.subscribe(100)
+	require.NoError(t, err)
+
+	for ts := model.Ts(101); ts <= 103; ts++ {
+		table.AppendRowChangedEvents(newTestRow(1, ts))
+		require.NoError(t, table.UpdateResolvedTs(model.NewResolvedTs(ts)))
+	}
+	_, err = sub.next(context.Background())
+	require.True(t, cerror.ErrSubscriptionLagged.Equal(err))
+
+	_, err = hub.subscribe(100)
+	require.True(t, cerror.ErrSubscriptionStartTsTooOld.Equal(err))
+	sub, err = hub.subscribe(101)
+	require.NoError(t, err)
+	entries, err := sub.next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []model.Ts{102, 103}, commitTsOf(entries))
+}
+
+func TestHubResetsHistory(t *testing.T) {
+	t.Parallel()
+
+	hub := newTestHub(t, NewRegistry(), "grpc://")
+	t1 := newTestTableSink(hub, 1, 100)
+	defer t1.Close()
+	t1.AppendRowChangedEvents(newTestRow(1, 101))
+	require.NoError(t, t1.UpdateResolvedTs(model.NewResolvedTs(105)))
+	sub, err := hub.subscribe(100)
+	require.NoError(t, err)
+
+	// A table moved in with an older start ts resets the history.
+	t2 := newTestTableSink(hub, 2, 102)
+	defer t2.Close()
+	_, err = sub.next(context.Background())
+	require.True(t, cerror.ErrSubscriptionAborted.Equal(err))
+	_, err = hub.subscribe(101)
+	require.True(t, cerror.ErrSubscriptionStartTsTooOld.Equal(err))
+
+	sub, err = hub.subscribe(102)
+	require.NoError(t, err)
+	t2.AppendRowChangedEvents(newTestRow(2, 103))
+	require.NoError(t, t2.UpdateResolvedTs(model.NewResolvedTs(104)))
+	entries, err := sub.next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.Ts(104), entries[0].resolvedTs)
+	require.Equal(t, []model.Ts{103}, commitTsOf(entries))
+
+	hub.Close()
+	_, err = sub.next(context.Background())
+	require.True(t, cerror.ErrSubscriptionClosed.Equal(err))
+	_, err = hub.subscribe(102)
+	require.True(t, cerror.ErrSubscriptionClosed.Equal(err))
+}
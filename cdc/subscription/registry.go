@@ -0,0 +1,75 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"sync"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+var globalRegistry = NewRegistry()
+
+// GetGlobalRegistry returns the registry of the hubs on this capture, which
+// is shared by the sinks and the subscription service.
+func GetGlobalRegistry() *Registry {
+	return globalRegistry
+}
+
+// Registry is the registry of hubs.
+type Registry struct {
+	mu sync.Mutex
+	// hubs of a changefeed are in the order of registration. There may be
+	// more than one hub of a changefeed, e.g. the sink URI is validated
+	// while the changefeed is running, the latest one is used.
+	hubs map[model.ChangeFeedID][]*Hub
+}
+
+// NewRegistry creates a Registry.
+func NewRegistry() *Registry {
+	return &Registry{hubs: make(map[model.ChangeFeedID][]*Hub)}
+}
+
+func (r *Registry) register(h *Hub) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hubs[h.changefeedID] = append(r.hubs[h.changefeedID], h)
+}
+
+func (r *Registry) unregister(h *Hub) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hubs := r.hubs[h.changefeedID]
+	for i := range hubs {
+		if hubs[i] == h {
+			hubs = append(hubs[:i:i], hubs[i+1:]...)
+			break
+		}
+	}
+	if len(hubs) == 0 {
+		delete(r.hubs, h.changefeedID)
+		return
+	}
+	r.hubs[h.changefeedID] = hubs
+}
+
+func (r *Registry) get(changefeedID model.ChangeFeedID) *Hub {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hubs := r.hubs[changefeedID]
+	if len(hubs) == 0 {
+		return nil
+	}
+	return hubs[len(hubs)-1]
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	filter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	pb "github.com/pingcap/tiflow/proto/subscription"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ pb.CDCSubscriptionServer = (*Service)(nil)
+
+// Service is the subscription service, it streams the events in the hubs
+// on this capture to subscribers.
+type Service struct {
+	registry *Registry
+}
+
+// NewService creates a Service.
+func NewService(registry *Registry) *Service {
+	return &Service{registry: registry}
+}
+
+// Subscribe implements pb.CDCSubscriptionServer.
+func (s *Service) Subscribe(req *pb.SubscribeRequest, stream pb.CDCSubscription_SubscribeServer) error {
+	changefeedID := model.ChangeFeedID{Namespace: req.Namespace, ID: req.Changefeed}
+	if changefeedID.Namespace == "" {
+		changefeedID.Namespace = model.DefaultNamespace
+	}
+	hub := s.registry.get(changefeedID)
+	if hub == nil {
+		return status.Errorf(codes.NotFound,
+			"changefeed %s with grpc sink is not running on this capture", changefeedID.String())
+	}
+	rules := req.Tables
+	if len(rules) == 0 {
+		rules = []string{"*.*"}
+	}
+	tableFilter, err := filter.Parse(rules)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	sub, err := hub.subscribe(req.StartTs)
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	log.Info("Subscription started",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.Strings("tables", req.Tables),
+		zap.Uint64("startTs", req.StartTs))
+	err = s.serve(stream, sub, tableFilter, req.StartTs)
+	log.Info("Subscription ended",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.Error(err))
+	return toStatusError(err)
+}
+
+func (s *Service) serve(
+	stream pb.CDCSubscription_SubscribeServer,
+	sub *subscription, tableFilter filter.Filter, startTs model.Ts,
+) error {
+	for {
+		entries, err := sub.next(stream.Context())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Entries are merged into one response, so subscribers which can't
+		// catch up receive larger batches.
+		resp := &pb.SubscribeResponse{}
+		for _, entry := range entries {
+			for _, event := range entry.events {
+				if event.CommitTs <= startTs ||
+					!tableFilter.MatchTable(event.Table.Schema, event.Table.Table) {
+					continue
+				}
+				resp.Events = append(resp.Events, toRowEvent(event))
+			}
+			resp.ResolvedTs = entry.resolvedTs
+		}
+		if resp.ResolvedTs <= startTs && len(resp.Events) == 0 {
+			continue
+		}
+		if err := stream.Send(resp); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case cerror.ErrSubscriptionClosed.Equal(err):
+		return status.Error(codes.Unavailable, err.Error())
+	case cerror.ErrSubscriptionAborted.Equal(err):
+		return status.Error(codes.Aborted, err.Error())
+	case cerror.ErrSubscriptionLagged.Equal(err):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case cerror.ErrSubscriptionStartTsTooOld.Equal(err):
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch errors.Cause(err) {
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toRowEvent(event *model.RowChangedEvent) *pb.RowEvent {
+	row := &pb.RowEvent{
+		Schema:     event.Table.Schema,
+		Table:      event.Table.Table,
+		TableId:    event.Table.TableID,
+		StartTs:    event.StartTs,
+		CommitTs:   event.CommitTs,
+		Op:         pb.OpType_UPDATE,
+		Columns:    toColumns(event.Columns),
+		PreColumns: toColumns(event.PreColumns),
+	}
+	if event.IsInsert() {
+		row.Op = pb.OpType_INSERT
+	} else if event.IsDelete() {
+		row.Op = pb.OpType_DELETE
+	}
+	return row
+}
+
+func toColumns(columns []*model.Column) []*pb.Column {
+	if len(columns) == 0 {
+		return nil
+	}
+	result := make([]*pb.Column, 0, len(columns))
+	for _, c := range columns {
+		if c == nil {
+			continue
+		}
+		column := &pb.Column{
+			Name: c.Name,
+			Type: uint32(c.Type),
+			Flag: uint64(c.Flag),
+		}
+		switch v := c.Value.(type) {
+		case nil:
+			column.IsNull = true
+		case []byte:
+			column.Value = v
+		default:
+			column.Value = []byte(model.ColumnValueString(v))
+		}
+		result = append(result, column)
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	pb "github.com/pingcap/tiflow/proto/subscription"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockSubscribeServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *pb.SubscribeResponse
+}
+
+func newMockSubscribeServer(ctx context.Context) *mockSubscribeServer {
+	return &mockSubscribeServer{ctx: ctx, responses: make(chan *pb.SubscribeResponse, 16)}
+}
+
+func (s *mockSubscribeServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockSubscribeServer) Send(resp *pb.SubscribeResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+func TestServiceSubscribe(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	service := NewService(registry)
+	stream := newMockSubscribeServer(context.Background())
+	err := service.Subscribe(&pb.SubscribeRequest{Changefeed: "test"}, stream)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	hub := newTestHub(t, registry, "grpc://")
+	t1 := newTestTableSink(hub, 1, 100)
+	t2 := newTestTableSink(hub, 2, 100)
+	defer t1.Close()
+	defer t2.Close()
+
+	err = service.Subscribe(&pb.SubscribeRequest{
+		Changefeed: "test", Tables: []string{"["},
+	}, stream)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	insert := newTestRow(1, 101)
+	update := newTestRow(1, 102)
+	update.PreColumns = []*model.Column{{Name: "a", Value: nil}}
+	other := newTestRow(2, 102)
+	other.Table.Table = "other"
+	t1.AppendRowChangedEvents(insert, update)
+	t2.AppendRowChangedEvents(other)
+	require.NoError(t, t1.UpdateResolvedTs(model.NewResolvedTs(102)))
+	require.NoError(t, t2.UpdateResolvedTs(model.NewResolvedTs(102)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream = newMockSubscribeServer(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- service.Subscribe(&pb.SubscribeRequest{
+			Changefeed: "test", Tables: []string{"test.t"}, StartTs: 100,
+		}, stream)
+	}()
+	resp := <-stream.responses
+	require.Equal(t, uint64(102), resp.ResolvedTs)
+	require.Len(t, resp.Events, 2)
+	require.Equal(t, pb.OpType_INSERT, resp.Events[0].Op)
+	require.Equal(t, []byte("101"), resp.Events[0].Columns[0].Value)
+	require.Equal(t, pb.OpType_UPDATE, resp.Events[1].Op)
+	require.True(t, resp.Events[1].PreColumns[0].IsNull)
+
+	cancel()
+	require.Equal(t, codes.Canceled, status.Code(<-errCh))
+
+	// Subscribers are disconnected once the hub is closed.
+	stream = newMockSubscribeServer(context.Background())
+	go func() {
+		errCh <- service.Subscribe(&pb.SubscribeRequest{
+			Changefeed: "test", StartTs: 102,
+		}, stream)
+	}()
+	hub.Close()
+	code := status.Code(<-errCh)
+	require.True(t, code == codes.Unavailable || code == codes.NotFound)
+}
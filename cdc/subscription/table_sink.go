@@ -0,0 +1,70 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+)
+
+// Assert TableSink implementation
+var _ tablesink.TableSink = (*TableSink)(nil)
+
+// TableSink is a table sink which reports its resolved ts to the hub after
+// the resolved events are written to the hub by the underlying table sink.
+type TableSink struct {
+	sink tablesink.TableSink
+	hub  *Hub
+}
+
+// NewTableSink creates a TableSink, the underlying table sink must write
+// events to the hub.
+func NewTableSink(sink tablesink.TableSink, hub *Hub, startTs model.Ts) *TableSink {
+	t := &TableSink{sink: sink, hub: hub}
+	hub.addTable(t, startTs)
+	return t
+}
+
+// AppendRowChangedEvents appends row changed events to the underlying table sink.
+func (t *TableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	t.sink.AppendRowChangedEvents(rows...)
+}
+
+// UpdateResolvedTs advances the resolved ts of the underlying table sink,
+// and then reports it to the hub.
+func (t *TableSink) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
+	if err := t.sink.UpdateResolvedTs(resolvedTs); err != nil {
+		return err
+	}
+	t.hub.updateTable(t, resolvedTs.ResolvedMark())
+	return nil
+}
+
+// GetCheckpointTs returns the checkpoint ts of the underlying table sink.
+func (t *TableSink) GetCheckpointTs() model.ResolvedTs {
+	return t.sink.GetCheckpointTs()
+}
+
+// Close removes the table sink from the hub and closes the underlying table sink.
+func (t *TableSink) Close() {
+	t.hub.removeTable(t)
+	t.sink.Close()
+}
+
+// AsyncClose removes the table sink from the hub, so it no longer holds the
+// resolved ts of the hub, and closes the underlying table sink asynchronously.
+func (t *TableSink) AsyncClose() bool {
+	t.hub.removeTable(t)
+	return t.sink.AsyncClose()
+}
@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -51,31 +52,10 @@ func newMySQLSyncPointStore(
 	sinkURI *url.URL,
 	syncPointRetention time.Duration,
 ) (SyncPointStore, error) {
-	cfg := mysql.NewConfig()
-	err := cfg.Apply(config.GetGlobalServerConfig().TZ, id, sinkURI, config.GetDefaultReplicaConfig())
+	syncDB, err := openSyncPointDB(ctx, id, sinkURI)
 	if err != nil {
 		return nil, err
 	}
-	getTestDb := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
-		testDB, err := sql.Open("mysql", dsnStr)
-		if err != nil {
-			return nil, err
-		}
-
-		return testDB, nil
-	}
-	dsnStr, err := mysql.GenerateDSN(ctx, sinkURI, cfg, getTestDb)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	syncDB, err := sql.Open("mysql", dsnStr)
-	if err != nil {
-		return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
-	}
-	err = syncDB.PingContext(ctx)
-	if err != nil {
-		return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
-	}
 
 	log.Info("Start mysql syncpoint sink")
 
@@ -210,3 +190,73 @@ func (s *mysqlSyncPointStore) Close() error {
 	err := s.db.Close()
 	return cerror.WrapError(cerror.ErrMySQLConnectionError, err)
 }
+
+// queryMySQLSyncPoint queries a syncpoint of the changefeed, the latest one
+// is returned if primaryTs is 0.
+func queryMySQLSyncPoint(
+	ctx context.Context,
+	db *sql.DB,
+	id model.ChangeFeedID,
+	primaryTs uint64,
+) (*SyncPoint, error) {
+	query := "SELECT primary_ts, secondary_ts FROM " + schemaName + "." + syncPointTableName +
+		" WHERE changefeed = ?"
+	args := []interface{}{id.ID}
+	if primaryTs != 0 {
+		query += " AND primary_ts = ?"
+		args = append(args, strconv.FormatUint(primaryTs, 10))
+	}
+	// primary_ts is a varchar, so it must be ordered as a number.
+	query += " ORDER BY CAST(primary_ts AS UNSIGNED) DESC LIMIT 1"
+
+	var primary, secondary string
+	err := db.QueryRowContext(ctx, query, args...).Scan(&primary, &secondary)
+	if err == sql.ErrNoRows {
+		return nil, cerror.ErrSyncPointNotFound.GenWithStackByArgs(id.ID)
+	}
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	res := &SyncPoint{}
+	if res.PrimaryTs, err = strconv.ParseUint(primary, 10, 64); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if res.SecondaryTs, err = strconv.ParseUint(secondary, 10, 64); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
+
+// openSyncPointDB opens the downstream database where syncpoints sit.
+func openSyncPointDB(
+	ctx context.Context,
+	id model.ChangeFeedID,
+	sinkURI *url.URL,
+) (*sql.DB, error) {
+	cfg := mysql.NewConfig()
+	err := cfg.Apply(config.GetGlobalServerConfig().TZ, id, sinkURI, config.GetDefaultReplicaConfig())
+	if err != nil {
+		return nil, err
+	}
+	getTestDb := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		testDB, err := sql.Open("mysql", dsnStr)
+		if err != nil {
+			return nil, err
+		}
+
+		return testDB, nil
+	}
+	dsnStr, err := mysql.GenerateDSN(ctx, sinkURI, cfg, getTestDb)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	syncDB, err := sql.Open("mysql", dsnStr)
+	if err != nil {
+		return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
+	}
+	err = syncDB.PingContext(ctx)
+	if err != nil {
+		return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
+	}
+	return syncDB, nil
+}
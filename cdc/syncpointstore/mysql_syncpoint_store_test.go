@@ -0,0 +1,57 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncpointstore
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryMySQLSyncPoint(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.Nil(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	id := model.DefaultChangeFeedID("test")
+	latest := regexp.QuoteMeta("SELECT primary_ts, secondary_ts FROM tidb_cdc.syncpoint_v1 " +
+		"WHERE changefeed = ? ORDER BY CAST(primary_ts AS UNSIGNED) DESC LIMIT 1")
+	byTs := regexp.QuoteMeta("SELECT primary_ts, secondary_ts FROM tidb_cdc.syncpoint_v1 " +
+		"WHERE changefeed = ? AND primary_ts = ? ORDER BY CAST(primary_ts AS UNSIGNED) DESC LIMIT 1")
+
+	mock.ExpectQuery(latest).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"primary_ts", "secondary_ts"}).
+			AddRow("449530436497129473", "449530436890345473"))
+	sp, err := queryMySQLSyncPoint(ctx, db, id, 0)
+	require.Nil(t, err)
+	require.Equal(t, &SyncPoint{
+		PrimaryTs: 449530436497129473, SecondaryTs: 449530436890345473,
+	}, sp)
+
+	mock.ExpectQuery(byTs).WithArgs("test", "449530436497129400").
+		WillReturnError(sql.ErrNoRows)
+	_, err = queryMySQLSyncPoint(ctx, db, id, 449530436497129400)
+	require.True(t, cerror.ErrSyncPointNotFound.Equal(err))
+
+	require.Nil(t, mock.ExpectationsWereMet())
+}
@@ -55,3 +55,36 @@ func NewSyncPointStore(
 			GenWithStack("the sink scheme (%s) is not supported", sinkURI.Scheme)
 	}
 }
+
+// SyncPoint is a syncpoint recorded in the downstream, the downstream is
+// consistent with the upstream at PrimaryTs in the snapshot of SecondaryTs.
+type SyncPoint struct {
+	PrimaryTs   uint64
+	SecondaryTs uint64
+}
+
+// QuerySyncPoint queries the syncpoint of the changefeed recorded in the
+// downstream of sinkURIStr. It returns the latest syncpoint if primaryTs is 0.
+func QuerySyncPoint(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURIStr string,
+	primaryTs uint64,
+) (*SyncPoint, error) {
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	switch strings.ToLower(sinkURI.Scheme) {
+	case "mysql", "tidb", "mysql+ssl", "tidb+ssl":
+		db, err := openSyncPointDB(ctx, changefeedID, sinkURI)
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+		return queryMySQLSyncPoint(ctx, db, changefeedID, primaryTs)
+	default:
+		return nil, cerror.ErrSinkURIInvalid.
+			GenWithStack("the sink scheme (%s) is not supported", sinkURI.Scheme)
+	}
+}
@@ -632,7 +632,11 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 				}
 
 				if c.eventRouter != nil {
-					target := c.eventRouter.GetPartitionForRowChange(row, kafkaPartitionNum)
+					target, err := c.eventRouter.GetPartitionForRowChange(row, kafkaPartitionNum)
+					if err != nil {
+						log.Panic("dispatch RowChangedEvent failed",
+							zap.Any("row", row), zap.Error(err))
+					}
 					if partition != target {
 						log.Panic("RowChangedEvent dispatched to wrong partition",
 							zap.Int32("obtained", partition),
@@ -267,6 +267,8 @@ func (c *consumer) getNewFiles(
 				// skip handling this file
 				return nil
 			}
+		} else if cloudstorage.IsDDLFile(path) || cloudstorage.IsSchemaManifestFile(path) {
+			log.Debug("ignore handling ddl or schema manifest file", zap.String("path", path))
 		} else if strings.HasSuffix(path, c.fileExtension) {
 			err := c.parseDMLFilePath(ctx, path)
 			if err != nil {
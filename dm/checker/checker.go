@@ -216,7 +216,7 @@ func (c *Checker) getTablePairInfo(ctx context.Context) (info *tablePairInfo, er
 	}
 
 	if _, ok := c.checkingItems[config.LightningFreeSpaceChecking]; ok &&
-		c.stCfgs[0].LoaderConfig.ImportMode == config.LoadModePhysical &&
+		c.stCfgs[0].LoaderConfig.MayLoadPhysically() &&
 		c.stCfgs[0].Mode != config.ModeIncrement {
 		concurrency, err := checker.GetConcurrency(ctx, sourceIDs, dbs, c.stCfgs[0].MydumperConfig.Threads)
 		if err != nil {
@@ -432,7 +432,7 @@ func (c *Checker) Init(ctx context.Context) (err error) {
 	}
 
 	if instance.cfg.Mode != config.ModeIncrement &&
-		instance.cfg.LoaderConfig.ImportMode == config.LoadModePhysical &&
+		instance.cfg.LoaderConfig.MayLoadPhysically() &&
 		hasLightningPrecheck {
 		lCfg, err := loader.GetLightningConfig(loader.MakeGlobalConfig(instance.cfg), instance.cfg)
 		if err != nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/BurntSushi/toml"
 	"github.com/go-mysql-org/go-mysql/mysql"
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	bstorage "github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/dm/config/dbconfig"
 	"github.com/pingcap/tiflow/dm/pkg/conn"
 	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
@@ -65,6 +66,12 @@ type PurgeConfig struct {
 	RemainSpace int64 `yaml:"remain-space" toml:"remain-space" json:"remain-space"` // if remain space in @RelayBaseDir less than @RemainSpace (GB), then it can be purged
 }
 
+// RelayStorageConfig is the configuration for archiving relay log files to an external storage.
+type RelayStorageConfig struct {
+	URL       string `yaml:"url" toml:"url" json:"url"`                      // if specified, relay log files are archived to this external storage, like s3://bucket/prefix
+	CacheSize int64  `yaml:"cache-size" toml:"cache-size" json:"cache-size"` // archived relay log files are kept in @RelayDir as read cache at most @CacheSize (GB)
+}
+
 // SourceConfig is the configuration for source.
 type SourceConfig struct {
 	Enable     bool `yaml:"enable" toml:"enable" json:"enable"`
@@ -91,6 +98,9 @@ type SourceConfig struct {
 	// config items for purger
 	Purge PurgeConfig `yaml:"purge" toml:"purge" json:"purge"`
 
+	// config items for relay log storage
+	RelayStorage RelayStorageConfig `yaml:"relay-storage" toml:"relay-storage" json:"relay-storage"`
+
 	// config items for task status checker
 	Checker CheckerConfig `yaml:"checker" toml:"checker" json:"checker"`
 
@@ -120,6 +130,9 @@ func newSourceConfig() *SourceConfig {
 			Expires:     0,
 			RemainSpace: 15,
 		},
+		RelayStorage: RelayStorageConfig{
+			CacheSize: 10,
+		},
 		Checker: CheckerConfig{
 			CheckEnable:     true,
 			BackoffRollback: Duration{DefaultBackoffRollback},
@@ -248,6 +261,12 @@ func (c *SourceConfig) Verify() error {
 		return terror.ErrConfigBinlogEventFilter.Delegate(err)
 	}
 
+	if c.RelayStorage.URL != "" {
+		if _, err = bstorage.ParseBackend(c.RelayStorage.URL, nil); err != nil {
+			return terror.ErrConfigRelayStorageInvalid.Delegate(err, c.RelayStorage.URL)
+		}
+	}
+
 	if c.Checker.BackoffMax.Duration < c.Checker.BackoffMin.Duration {
 		return terror.ErrConfigCheckerMaxTooSmall.Generate(c.Checker.BackoffMax.Duration, c.Checker.BackoffMin.Duration)
 	}
@@ -435,6 +454,7 @@ type SourceConfigForDowngrade struct {
 	// any new config item, we mark it omitempty
 	CaseSensitive bool                  `yaml:"case-sensitive,omitempty"`
 	Filters       []*bf.BinlogEventRule `yaml:"filters,omitempty"`
+	RelayStorage  RelayStorageConfig    `yaml:"relay-storage,omitempty"`
 }
 
 // NewSourceConfigForDowngrade creates a new base config for downgrade.
@@ -457,6 +477,7 @@ func NewSourceConfigForDowngrade(sourceCfg *SourceConfig) *SourceConfigForDowngr
 		Tracer:          sourceCfg.Tracer,
 		CaseSensitive:   sourceCfg.CaseSensitive,
 		Filters:         sourceCfg.Filters,
+		RelayStorage:    sourceCfg.RelayStorage,
 	}
 }
 
@@ -465,6 +486,9 @@ func NewSourceConfigForDowngrade(sourceCfg *SourceConfig) *SourceConfigForDowngr
 // we should change it to empty.
 func (c *SourceConfigForDowngrade) omitDefaultVals() {
 	c.Enable = false
+	if c.RelayStorage.URL == "" {
+		c.RelayStorage = RelayStorageConfig{}
+	}
 }
 
 // Yaml returns YAML format representation of the config.
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -187,6 +188,12 @@ type SubTaskConfig struct {
 	// e.g., pulling binlog
 	DumpUUID         string         `toml:"-" json:"-"`
 	DumpIOTotalBytes *atomic.Uint64 `toml:"-" json:"-"`
+
+	// members below are injected by dataflow engine when tables of the source
+	// are split into multiple subtasks, the subtask only replicates tables in
+	// partition TablePartition of TablePartitions. see TablePartitionOf.
+	TablePartition  int `toml:"-" json:"-"`
+	TablePartitions int `toml:"-" json:"-"`
 }
 
 // SampleSubtaskConfig is the content of subtask.toml in current folder.
@@ -492,3 +499,43 @@ func (c *SubTaskConfig) Clone() (*SubTaskConfig, error) {
 
 	return clone, nil
 }
+
+// InTablePartition returns whether the table is replicated by this subtask.
+func (c *SubTaskConfig) InTablePartition(table *filter.Table) bool {
+	if c.TablePartitions <= 1 {
+		return true
+	}
+	return TablePartitionOf(table.Schema, table.Name, c.TablePartitions) == c.TablePartition
+}
+
+// TablePartitionID returns the ID of this subtask among subtasks of the same
+// source, it's used as the ID of checkpoints and other metadata.
+func (c *SubTaskConfig) TablePartitionID() string {
+	if c.TablePartitions <= 1 {
+		return c.SourceID
+	}
+	return TablePartitionID(c.SourceID, c.TablePartition)
+}
+
+// TablePartitionOf returns the partition of a table when tables are split into
+// `partitions` partitions. schema level statements whose table is empty always
+// belong to the first partition.
+func TablePartitionOf(schema, table string, partitions int) int {
+	if partitions <= 1 || table == "" {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(schema))
+	_, _ = h.Write([]byte{'.'})
+	_, _ = h.Write([]byte(table))
+	return int(h.Sum32() % uint32(partitions))
+}
+
+// TablePartitionID returns the ID of a table partition of the source, the
+// first partition uses the source ID for compatibility.
+func TablePartitionID(sourceID string, partition int) string {
+	if partition == 0 {
+		return sourceID
+	}
+	return fmt.Sprintf("%s#%d", sourceID, partition)
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	"github.com/pingcap/tidb/util/filter"
 	"github.com/pingcap/tiflow/dm/config/dbconfig"
 	"github.com/pingcap/tiflow/dm/config/security"
@@ -285,6 +286,26 @@ func TestSubTaskAdjustLoaderS3Dir(t *testing.T) {
 	require.Equal(t, "1invalid:", cfg.LoaderConfig.Dir)
 }
 
+func TestLoaderConfigAdjustAutoMode(t *testing.T) {
+	cfg := LoaderConfig{ImportMode: "AUTO"}
+	require.NoError(t, cfg.adjust())
+	require.Equal(t, LoadModeAuto, cfg.ImportMode)
+	require.Equal(t, defaultPhysicalThreshold, cfg.PhysicalThreshold)
+	require.True(t, cfg.MayLoadPhysically())
+
+	cfg = LoaderConfig{ImportMode: LoadModeAuto, PhysicalThreshold: 1024, LogicalTables: []string{"db.*"}}
+	require.NoError(t, cfg.adjust())
+	require.Equal(t, config.ByteSize(1024), cfg.PhysicalThreshold)
+
+	cfg = LoaderConfig{ImportMode: LoadModeAuto, LogicalTables: []string{"db.t["}}
+	require.True(t, terror.ErrConfigInvalidLogicalTables.Equal(cfg.adjust()))
+
+	cfg = LoaderConfig{ImportMode: LoadModeLogical}
+	require.NoError(t, cfg.adjust())
+	require.Zero(t, cfg.PhysicalThreshold)
+	require.False(t, cfg.MayLoadPhysically())
+}
+
 func TestDBConfigClone(t *testing.T) {
 	a := &dbconfig.DBConfig{
 		Host:     "127.0.0.1",
@@ -331,3 +352,27 @@ func TestFetchTZSetting(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "+01:00", tz)
 }
+
+func TestSubTaskTablePartition(t *testing.T) {
+	cfg := &SubTaskConfig{SourceID: "mysql-replica-01"}
+	table := &filter.Table{Schema: "db", Name: "tbl"}
+	require.True(t, cfg.InTablePartition(table))
+	require.Equal(t, "mysql-replica-01", cfg.TablePartitionID())
+
+	cfg.TablePartitions = 4
+	owners := 0
+	for i := 0; i < cfg.TablePartitions; i++ {
+		cfg.TablePartition = i
+		if cfg.InTablePartition(table) {
+			owners++
+		}
+		// schema level statements belong to the first partition
+		require.Equal(t, i == 0, cfg.InTablePartition(&filter.Table{Schema: "db"}))
+	}
+	require.Equal(t, 1, owners)
+	require.Equal(t, "mysql-replica-01#3", cfg.TablePartitionID())
+	cfg.TablePartition = 0
+	require.Equal(t, "mysql-replica-01", cfg.TablePartitionID())
+	require.Equal(t, TablePartitionOf("db", "tbl", 4), TablePartitionOf("db", "tbl", 4))
+	require.Equal(t, 0, TablePartitionOf("db", "tbl", 1))
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/util/filter"
+	tfilter "github.com/pingcap/tidb/util/table-filter"
 	router "github.com/pingcap/tidb/util/table-router"
 	"github.com/pingcap/tiflow/dm/config/dbconfig"
 	"github.com/pingcap/tiflow/dm/pkg/log"
@@ -96,8 +97,9 @@ var (
 	defaultChunkFilesize = "64"
 	defaultSkipTzUTC     = true
 	// LoaderConfig.
-	defaultPoolSize = 16
-	defaultDir      = "./dumped_data"
+	defaultPoolSize          = 16
+	defaultDir               = "./dumped_data"
+	defaultPhysicalThreshold = config.ByteSize(1 << 30) // 1GiB
 	// SyncerConfig.
 	defaultWorkerCount             = 16
 	defaultBatch                   = 100
@@ -251,6 +253,9 @@ const (
 	LoadModeLogical LoadMode = "logical"
 	// LoadModePhysical means use local backend of lightning to load data, which ingest SST files to load data.
 	LoadModePhysical LoadMode = "physical"
+	// LoadModeAuto means choose between LoadModePhysical and LoadModeLogical for each table, tables whose data
+	// size reach the physical threshold are loaded physically, and others are loaded logically.
+	LoadModeAuto LoadMode = "auto"
 )
 
 // LogicalDuplicateResolveType defines the duplication resolution when meet duplicate rows for logical import.
@@ -301,6 +306,16 @@ type LoaderConfig struct {
 	RangeConcurrency    int                          `yaml:"range-concurrency" toml:"range-concurrency" json:"range-concurrency"`
 	CompressKVPairs     string                       `yaml:"compress-kv-pairs" toml:"compress-kv-pairs" json:"compress-kv-pairs"`
 	PDAddr              string                       `yaml:"pd-addr" toml:"pd-addr" json:"pd-addr"`
+	// PhysicalThreshold is the data size of a target table in bytes, from which it's loaded physically in auto mode.
+	PhysicalThreshold config.ByteSize `yaml:"physical-threshold" toml:"physical-threshold" json:"physical-threshold"`
+	// LogicalTables are table filter rules of target tables which are always loaded logically in auto mode, since
+	// they should be available in downstream during the load.
+	LogicalTables []string `yaml:"logical-tables" toml:"logical-tables" json:"logical-tables"`
+}
+
+// MayLoadPhysically returns true if some tables may be loaded physically.
+func (m *LoaderConfig) MayLoadPhysically() bool {
+	return m.ImportMode == LoadModePhysical || m.ImportMode == LoadModeAuto
 }
 
 // DefaultLoaderConfig return default loader config for task.
@@ -336,11 +351,20 @@ func (m *LoaderConfig) adjust() error {
 	}
 	m.ImportMode = LoadMode(strings.ToLower(string(m.ImportMode)))
 	switch m.ImportMode {
-	case LoadModeLoader, LoadModeSQL, LoadModeLogical, LoadModePhysical:
+	case LoadModeLoader, LoadModeSQL, LoadModeLogical, LoadModePhysical, LoadModeAuto:
 	default:
 		return terror.ErrConfigInvalidLoadMode.Generate(m.ImportMode)
 	}
 
+	if m.ImportMode == LoadModeAuto && m.PhysicalThreshold == 0 {
+		m.PhysicalThreshold = defaultPhysicalThreshold
+	}
+	if len(m.LogicalTables) > 0 {
+		if _, err := tfilter.Parse(m.LogicalTables); err != nil {
+			return terror.ErrConfigInvalidLogicalTables.Delegate(err, m.LogicalTables)
+		}
+	}
+
 	if m.PoolSize == 0 {
 		m.PoolSize = defaultPoolSize
 	}
@@ -402,6 +426,12 @@ type SyncerConfig struct {
 	// TODO: add this two new config items for openapi.
 	Compact      bool `yaml:"compact" toml:"compact" json:"compact"`
 	MultipleRows bool `yaml:"multiple-rows" toml:"multiple-rows" json:"multiple-rows"`
+	// persist snapshots of downstream table structures with checkpoint, so
+	// that they needn't be fetched from downstream again when resuming.
+	SchemaSnapshot bool `yaml:"schema-snapshot" toml:"schema-snapshot" json:"schema-snapshot"`
+	// adaptively throttle the concurrency and batch size of DMLs by the
+	// execution latency, to avoid overloading downstream.
+	FlowControl bool `yaml:"flow-control" toml:"flow-control" json:"flow-control"`
 
 	// deprecated
 	MaxRetry int `yaml:"max-retry" toml:"max-retry" json:"max-retry"`
@@ -1177,6 +1207,8 @@ type SyncerConfigForDowngrade struct {
 	SafeModeDuration string `yaml:"safe-mode-duration,omitempty"`
 	Compact          bool   `yaml:"compact,omitempty"`
 	MultipleRows     bool   `yaml:"multipleRows,omitempty"`
+	SchemaSnapshot   bool   `yaml:"schema-snapshot,omitempty"`
+	FlowControl      bool   `yaml:"flow-control,omitempty"`
 }
 
 // NewSyncerConfigsForDowngrade converts SyncerConfig to SyncerConfigForDowngrade.
@@ -1197,6 +1229,8 @@ func NewSyncerConfigsForDowngrade(syncerConfigs map[string]*SyncerConfig) map[st
 			EnableANSIQuotes:        syncerConfig.EnableANSIQuotes,
 			Compact:                 syncerConfig.Compact,
 			MultipleRows:            syncerConfig.MultipleRows,
+			SchemaSnapshot:          syncerConfig.SchemaSnapshot,
+			FlowControl:             syncerConfig.FlowControl,
 		}
 		syncerConfigsForDowngrade[configName] = newSyncerConfig
 	}
@@ -31,6 +31,9 @@ type lightingLoadStatus int
 const (
 	lightningStatusInit lightingLoadStatus = iota
 	lightningStatusRunning
+	// lightningStatusPhysicalFinished means tables loaded physically in auto mode are finished,
+	// and tables loaded logically are not.
+	lightningStatusPhysicalFinished
 	lightningStatusFinished
 )
 
@@ -40,6 +43,8 @@ func (s lightingLoadStatus) String() string {
 		return "init"
 	case lightningStatusRunning:
 		return "running"
+	case lightningStatusPhysicalFinished:
+		return "physical"
 	case lightningStatusFinished:
 		return "finished"
 	default:
@@ -51,6 +56,8 @@ func parseLightningLoadStatus(s string) lightingLoadStatus {
 	switch s {
 	case "running":
 		return lightningStatusRunning
+	case "physical":
+		return lightningStatusPhysicalFinished
 	case "finished":
 		return lightningStatusFinished
 	case "init":
@@ -103,7 +110,7 @@ func (cp *LightningCheckpointList) Prepare(ctx context.Context) error {
 	createTable := `CREATE TABLE IF NOT EXISTS %s (
 		task_name varchar(255) NOT NULL,
 		source_name varchar(255) NOT NULL,
-		status varchar(10) NOT NULL DEFAULT 'init' COMMENT 'init,running,finished',
+		status varchar(10) NOT NULL DEFAULT 'init' COMMENT 'init,running,physical,finished',
 		PRIMARY KEY (task_name, source_name)
 	);
 `
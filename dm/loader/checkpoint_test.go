@@ -79,6 +79,16 @@ func (s *lightningCpListSuite) TestLightningCheckpointListStatusRunning(c *C) {
 	c.Assert(status, Equals, lightningStatusRunning)
 }
 
+func (s *lightningCpListSuite) TestLightningCheckpointListStatusPhysicalFinished(c *C) {
+	s.mock.ExpectQuery(fmt.Sprintf("SELECT status FROM %s WHERE `task_name` = \\? AND `source_name` = \\?", s.cpList.tableName)).
+		WithArgs(s.cpList.taskName, s.cpList.sourceName).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("physical"))
+	status, err := s.cpList.taskStatus(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(status, Equals, lightningStatusPhysicalFinished)
+	c.Assert(status < lightningStatusFinished, IsTrue)
+}
+
 func (s *lightningCpListSuite) TestLightningCheckpointListRegister(c *C) {
 	s.mock.ExpectBegin()
 	s.mock.ExpectExec(fmt.Sprintf("INSERT IGNORE INTO %s \\(`task_name`, `source_name`\\) VALUES \\(\\?, \\?\\)", s.cpList.tableName)).
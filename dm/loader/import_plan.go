@@ -0,0 +1,143 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"context"
+	"sort"
+
+	lcfg "github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/util/dbutil"
+	"github.com/pingcap/tidb/util/filter"
+	regexprrouter "github.com/pingcap/tidb/util/regexpr-router"
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
+)
+
+// importPlan decides which tables are loaded physically in auto mode. Tables are
+// decided by their target tables, so that tables merged into the same target
+// table are loaded by the same mode.
+type importPlan struct {
+	// physicalTables are source tables loaded physically, others are loaded logically.
+	physicalTables []*filter.Table
+}
+
+// tableSize is the data size of a source table in the dump files.
+type tableSize struct {
+	table *filter.Table
+	size  int64
+}
+
+// newImportPlan creates an importPlan by the data size of tables. Target tables whose
+// data size reach the physical threshold are loaded physically, unless they match
+// the logical tables rules.
+func newImportPlan(cfg *config.SubTaskConfig, sizes []tableSize) (*importPlan, error) {
+	r, err := regexprrouter.NewRegExprRouter(cfg.CaseSensitive, cfg.RouteRules)
+	if err != nil {
+		return nil, terror.ErrLoadUnitGenTableRouter.Delegate(err)
+	}
+	logicalFilter, err := tfilter.Parse(append([]string{}, cfg.LoaderConfig.LogicalTables...))
+	if err != nil {
+		return nil, terror.ErrConfigInvalidLogicalTables.Delegate(err, cfg.LoaderConfig.LogicalTables)
+	}
+	if !cfg.CaseSensitive {
+		logicalFilter = tfilter.CaseInsensitive(logicalFilter)
+	}
+
+	targetSizes := make(map[filter.Table]int64)
+	targets := make([]filter.Table, 0, len(sizes))
+	for _, s := range sizes {
+		targetSchema, targetTable, err := r.Route(s.table.Schema, s.table.Name)
+		if err != nil {
+			return nil, terror.ErrLoadUnitGenTableRouter.Delegate(err)
+		}
+		target := *s.table
+		if targetSchema != "" {
+			target.Schema = targetSchema
+			if targetTable != "" {
+				target.Name = targetTable
+			}
+		}
+		targets = append(targets, target)
+		targetSizes[target] += s.size
+	}
+
+	plan := &importPlan{}
+	for i, s := range sizes {
+		target := targets[i]
+		if targetSizes[target] < int64(cfg.LoaderConfig.PhysicalThreshold) ||
+			logicalFilter.MatchTable(target.Schema, target.Name) {
+			continue
+		}
+		plan.physicalTables = append(plan.physicalTables, s.table)
+	}
+	sort.Slice(plan.physicalTables, func(i, j int) bool {
+		return plan.physicalTables[i].String() < plan.physicalTables[j].String()
+	})
+	return plan, nil
+}
+
+// physicalFilter returns lightning filter rules of tables loaded physically.
+func (p *importPlan) physicalFilter() []string {
+	rules := make([]string, 0, len(p.physicalTables))
+	for _, table := range p.physicalTables {
+		rules = append(rules, dbutil.TableName(table.Schema, table.Name))
+	}
+	return rules
+}
+
+// logicalFilter returns lightning filter rules of tables loaded logically.
+func (p *importPlan) logicalFilter() []string {
+	rules := lcfg.GetDefaultFilter()
+	for _, table := range p.physicalTables {
+		rules = append(rules, "!"+dbutil.TableName(table.Schema, table.Name))
+	}
+	return rules
+}
+
+// getTableSizes returns the data size of source tables in dump files.
+func (l *LightningLoader) getTableSizes(ctx context.Context, cfg *lcfg.Config) ([]tableSize, error) {
+	// scan tables without routing, so that tables are in source names, as lightning
+	// filters tables by their source names.
+	scanCfg := *cfg
+	scanCfg.Routes = nil
+	if len(scanCfg.Mydumper.FileRouters) == 0 {
+		scanCfg.Mydumper.DefaultFileRules = true
+	}
+	var (
+		mdl *mydump.MDLoader
+		err error
+	)
+	if l.cfg.ExtStorage != nil {
+		mdl, err = mydump.NewMyDumpLoaderWithStore(ctx, &scanCfg, l.cfg.ExtStorage)
+	} else {
+		mdl, err = mydump.NewMyDumpLoader(ctx, &scanCfg)
+	}
+	if err != nil {
+		return nil, terror.ErrLoadLightningRuntime.Delegate(err)
+	}
+
+	var sizes []tableSize
+	for _, db := range mdl.GetDatabases() {
+		for _, table := range db.Tables {
+			sizes = append(sizes, tableSize{
+				table: &filter.Table{Schema: table.DB, Name: table.Name},
+				size:  table.TotalSize,
+			})
+		}
+	}
+	return sizes, nil
+}
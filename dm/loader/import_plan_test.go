@@ -0,0 +1,103 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/util/filter"
+	router "github.com/pingcap/tidb/util/table-router"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPlan(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile := func(name string, size int) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(strings.Repeat("x", size)), 0o644))
+	}
+	for _, db := range []string{"db1", "db2"} {
+		writeFile(db+"-schema-create.sql", 10)
+	}
+	for _, table := range []struct {
+		name string
+		size int
+	}{
+		{"db1.shard1", 60},
+		{"db1.shard2", 60},
+		{"db1.small", 10},
+		{"db1.large", 200},
+		{"db2.large", 200},
+		{"db2.empty", 0},
+	} {
+		writeFile(table.name+"-schema.sql", 10)
+		if table.size > 0 {
+			writeFile(table.name+".000000000.sql", table.size)
+		}
+	}
+
+	stCfg := &config.SubTaskConfig{
+		LoaderConfig: config.LoaderConfig{
+			Dir:               dir,
+			ImportMode:        config.LoadModeAuto,
+			PhysicalThreshold: 100,
+			LogicalTables:     []string{"DB2.*"},
+		},
+		RouteRules: []*router.TableRule{{
+			SchemaPattern: "db1", TablePattern: "shard*", TargetSchema: "db1", TargetTable: "merged",
+		}},
+	}
+	l := NewLightning(stCfg, nil, "")
+	cfg, err := l.getLightningConfig()
+	require.NoError(t, err)
+	sizes, err := l.getTableSizes(context.Background(), cfg)
+	require.NoError(t, err)
+	sort.Slice(sizes, func(i, j int) bool {
+		return sizes[i].table.String() < sizes[j].table.String()
+	})
+	require.Equal(t, []tableSize{
+		{&filter.Table{Schema: "db1", Name: "large"}, 200},
+		{&filter.Table{Schema: "db1", Name: "shard1"}, 60},
+		{&filter.Table{Schema: "db1", Name: "shard2"}, 60},
+		{&filter.Table{Schema: "db1", Name: "small"}, 10},
+		{&filter.Table{Schema: "db2", Name: "empty"}, 0},
+		{&filter.Table{Schema: "db2", Name: "large"}, 200},
+	}, sizes)
+
+	// shards are merged into a large table, and db2 tables should be available.
+	plan, err := newImportPlan(stCfg, sizes)
+	require.NoError(t, err)
+	require.Equal(t, []*filter.Table{
+		{Schema: "db1", Name: "large"},
+		{Schema: "db1", Name: "shard1"},
+		{Schema: "db1", Name: "shard2"},
+	}, plan.physicalTables)
+	require.Equal(t, []string{"`db1`.`large`", "`db1`.`shard1`", "`db1`.`shard2`"}, plan.physicalFilter())
+	require.Equal(t, append(
+		[]string{"*.*", "!mysql.*", "!sys.*", "!INFORMATION_SCHEMA.*", "!PERFORMANCE_SCHEMA.*", "!METRICS_SCHEMA.*", "!INSPECTION_SCHEMA.*"},
+		"!`db1`.`large`", "!`db1`.`shard1`", "!`db1`.`shard2`"), plan.logicalFilter())
+
+	// all tables are loaded logically if they're small.
+	stCfg.LoaderConfig.PhysicalThreshold = 1000
+	plan, err = newImportPlan(stCfg, sizes)
+	require.NoError(t, err)
+	require.Empty(t, plan.physicalTables)
+}
@@ -118,7 +118,7 @@ func MakeGlobalConfig(cfg *config.SubTaskConfig) *lcfg.GlobalConfig {
 		lightningCfg.TiDB.PdAddr = cfg.LoaderConfig.PDAddr
 	}
 	lightningCfg.TikvImporter.Backend = lcfg.BackendTiDB
-	if cfg.LoaderConfig.ImportMode == config.LoadModePhysical {
+	if cfg.LoaderConfig.MayLoadPhysically() {
 		lightningCfg.TikvImporter.Backend = lcfg.BackendLocal
 	}
 	lightningCfg.PostRestore.Checksum = lcfg.OpLevelOff
@@ -189,12 +189,8 @@ func (l *LightningLoader) Init(ctx context.Context) (err error) {
 	return nil
 }
 
-func (l *LightningLoader) ignoreCheckpointError(ctx context.Context, cfg *lcfg.Config) error {
-	status, err := l.checkPointList.taskStatus(ctx)
-	if err != nil {
-		return err
-	}
-	if status != lightningStatusRunning {
+func (l *LightningLoader) ignoreCheckpointError(ctx context.Context, cfg *lcfg.Config, status lightingLoadStatus) error {
+	if status != lightningStatusRunning && status != lightningStatusPhysicalFinished {
 		return nil
 	}
 	cpdb, err := checkpoints.OpenCheckpointsDB(ctx, cfg)
@@ -213,13 +209,20 @@ func (l *LightningLoader) runLightning(ctx context.Context, cfg *lcfg.Config) (e
 	l.cancel = cancel
 	l.Unlock()
 
+	status, err := l.checkPointList.taskStatus(ctx)
+	if err != nil {
+		l.logger.Warn("get lightning checkpoint status failed", log.ShortError(err))
+	}
 	// always try to skill all checkpoint errors so we can resume this phase.
-	err = l.ignoreCheckpointError(ctx, cfg)
+	err = l.ignoreCheckpointError(ctx, cfg, status)
 	if err != nil {
 		l.logger.Warn("check lightning checkpoint status failed, skip this error", log.ShortError(err))
 	}
-	if err = l.checkPointList.UpdateStatus(ctx, lightningStatusRunning); err != nil {
-		return err
+	// don't overwrite the status of auto mode, whose logical tables are loaded after physical tables.
+	if status != lightningStatusPhysicalFinished {
+		if err = l.checkPointList.UpdateStatus(ctx, lightningStatusRunning); err != nil {
+			return err
+		}
 	}
 
 	var opts []lightning.Option
@@ -264,7 +267,7 @@ func (l *LightningLoader) runLightning(ctx context.Context, cfg *lcfg.Config) (e
 	}
 
 	var hasDup atomic.Bool
-	if l.cfg.LoaderConfig.ImportMode == config.LoadModePhysical {
+	if cfg.TikvImporter.Backend == lcfg.BackendLocal {
 		opts = append(opts, lightning.WithDupIndicator(&hasDup))
 	}
 
@@ -292,6 +295,44 @@ func (l *LightningLoader) runLightning(ctx context.Context, cfg *lcfg.Config) (e
 	return nil
 }
 
+// runLightningAuto loads tables physically and logically by turns in auto mode.
+// Tables loaded physically are loaded first, and the status is updated to
+// lightningStatusPhysicalFinished after they finish, so that they're not loaded
+// again when resuming.
+func (l *LightningLoader) runLightningAuto(ctx context.Context, cfg *lcfg.Config, status lightingLoadStatus) error {
+	sizes, err := l.getTableSizes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	plan, err := newImportPlan(l.cfg, sizes)
+	if err != nil {
+		return err
+	}
+
+	if status < lightningStatusPhysicalFinished {
+		if len(plan.physicalTables) > 0 {
+			l.logger.Info("load tables physically", zap.Stringers("tables", plan.physicalTables))
+			cfg.TikvImporter.Backend = lcfg.BackendLocal
+			cfg.Mydumper.Filter = plan.physicalFilter()
+			if err = l.runLightning(ctx, cfg); err != nil {
+				return err
+			}
+		}
+		if err = l.checkPointList.UpdateStatus(ctx, lightningStatusPhysicalFinished); err != nil {
+			return err
+		}
+		// lightning adjusts the config in place, use a new one for the next run.
+		if cfg, err = l.getLightningConfig(); err != nil {
+			return err
+		}
+	}
+
+	l.logger.Info("load tables logically", zap.Int("physical tables", len(plan.physicalTables)))
+	cfg.TikvImporter.Backend = lcfg.BackendTiDB
+	cfg.Mydumper.Filter = plan.logicalFilter()
+	return l.runLightning(ctx, cfg)
+}
+
 var checksumErrorPattern = regexp.MustCompile(`total_kvs: (\d*) vs (\d*)`)
 
 func convertLightningError(err error) error {
@@ -429,16 +470,24 @@ func (l *LightningLoader) restore(ctx context.Context) error {
 	switch {
 	case terror.ErrLoadLightningHasDup.Equal(l.lastErr),
 		terror.ErrLoadLightningChecksum.Equal(l.lastErr):
-		l.logger.Info("manually resume from error, DM will skip the error and continue to next unit",
-			zap.Error(l.lastErr))
-
-		l.finish.Store(true)
-		err = l.checkPointList.UpdateStatus(ctx, lightningStatusFinished)
+		// these errors only happen when loading physically, so tables loaded logically
+		// are not finished yet in auto mode.
+		skippedStatus := lightningStatusFinished
+		if l.cfg.LoaderConfig.ImportMode == config.LoadModeAuto {
+			skippedStatus = lightningStatusPhysicalFinished
+			l.logger.Info("manually resume from error, DM will skip the error and continue to load tables logically",
+				zap.Error(l.lastErr))
+		} else {
+			l.logger.Info("manually resume from error, DM will skip the error and continue to next unit",
+				zap.Error(l.lastErr))
+			l.finish.Store(true)
+		}
+		err = l.checkPointList.UpdateStatus(ctx, skippedStatus)
 		if err != nil {
 			l.logger.Error("failed to update checkpoint status", zap.Error(err))
 			return err
 		}
-		status = lightningStatusFinished
+		status = skippedStatus
 	}
 
 	if status < lightningStatusFinished {
@@ -453,7 +502,11 @@ func (l *LightningLoader) restore(ctx context.Context) error {
 		if err2 := readyAndWait(ctx, l.cli, l.cfg); err2 != nil {
 			return err2
 		}
-		err = l.runLightning(ctx, cfg)
+		if l.cfg.LoaderConfig.ImportMode == config.LoadModeAuto {
+			err = l.runLightningAuto(ctx, cfg, status)
+		} else {
+			err = l.runLightning(ctx, cfg)
+		}
 		if err == nil {
 			l.finish.Store(true)
 			err = l.checkPointList.UpdateStatus(ctx, lightningStatusFinished)
@@ -241,7 +241,7 @@ func putAndWait(
 	putStatus string,
 	failFn func(string) bool,
 ) error {
-	if cli == nil || cfg.LoaderConfig.ImportMode != config.LoadModePhysical {
+	if cli == nil || !cfg.LoaderConfig.MayLoadPhysically() {
 		return nil
 	}
 	_, err := ha.PutLightningStatus(cli, cfg.Name, cfg.SourceID, putStatus)
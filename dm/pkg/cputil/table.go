@@ -51,6 +51,11 @@ func SyncerShardMeta(task string) string {
 	return task + "_syncer_sharding_meta"
 }
 
+// SyncerSchemaSnapshot returns syncer's snapshot table name of downstream table structures.
+func SyncerSchemaSnapshot(task string) string {
+	return task + "_syncer_schema_snapshot"
+}
+
 // SyncerOnlineDDL returns syncer's onlineddl checkpoint table name.
 func SyncerOnlineDDL(task string) string {
 	return task + "_onlineddl"
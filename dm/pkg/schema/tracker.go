@@ -75,6 +75,10 @@ type downstreamTracker struct {
 	downstreamConn *dbconn.DBConn                  // downstream connection
 	stmtParser     *parser.Parser                  // statement parser
 	tableInfos     map[string]*DownstreamTableInfo // downstream table infos
+	createSQLs     map[string]string               // create statements of tableInfos
+	// snapshot contains create statements restored from a snapshot, which
+	// are used instead of querying downstream when tables are initialized.
+	snapshot map[string]string
 }
 
 // DownstreamTableInfo contains tableinfo and index cache.
@@ -138,6 +142,8 @@ func (tr *Tracker) Init(
 		downstreamConn: downstreamConn,
 		se:             dsSession,
 		tableInfos:     make(map[string]*DownstreamTableInfo),
+		createSQLs:     make(map[string]string),
+		snapshot:       make(map[string]string),
 	}
 	// TODO: need to use upstream timezone to correctly check literal is in [1970, 2038]
 	se := executorContext{Context: mock.NewContext()}
@@ -384,6 +390,37 @@ func (tr *Tracker) GetDownStreamTableInfo(tctx *tcontext.Context, tableID string
 	return tr.downstreamTracker.getOrInit(tctx, tableID, originTI)
 }
 
+// DownstreamSnapshot returns create statements of downstream tables tracked
+// by downstreamTrack, keyed by table IDs. Tables restored from a snapshot but
+// not initialized yet are included.
+func (tr *Tracker) DownstreamSnapshot() map[string]string {
+	dt := tr.downstreamTracker
+	dt.RLock()
+	defer dt.RUnlock()
+	snapshot := make(map[string]string, len(dt.createSQLs)+len(dt.snapshot))
+	for tableID, createSQL := range dt.snapshot {
+		snapshot[tableID] = createSQL
+	}
+	for tableID, createSQL := range dt.createSQLs {
+		snapshot[tableID] = createSQL
+	}
+	return snapshot
+}
+
+// RestoreDownstreamSnapshot restores create statements of downstream tables,
+// so that downstreamTrack initializes these tables without querying downstream.
+// Tables that are already initialized are not affected.
+func (tr *Tracker) RestoreDownstreamSnapshot(snapshot map[string]string) {
+	dt := tr.downstreamTracker
+	dt.Lock()
+	defer dt.Unlock()
+	for tableID, createSQL := range snapshot {
+		if _, ok := dt.tableInfos[tableID]; !ok {
+			dt.snapshot[tableID] = createSQL
+		}
+	}
+}
+
 // RemoveDownstreamSchema just remove schema or table in downstreamTrack.
 func (tr *Tracker) RemoveDownstreamSchema(tctx *tcontext.Context, targetTables []*filter.Table) {
 	if len(targetTables) == 0 {
@@ -409,7 +446,11 @@ func (dt *downstreamTracker) getOrInit(tctx *tcontext.Context, tableID string, o
 	dti, ok = dt.tableInfos[tableID]
 	if !ok {
 		tctx.Logger.Info("Downstream schema tracker init. ", zap.String("tableID", tableID))
-		downstreamTI, err := dt.getTableInfoByCreateStmt(tctx, tableID)
+		var err error
+		downstreamTI, createStr := dt.getTableInfoFromSnapshot(tctx, tableID)
+		if downstreamTI == nil {
+			downstreamTI, createStr, err = dt.getTableInfoByCreateStmt(tctx, tableID)
+		}
 		if err != nil {
 			tctx.Logger.Error("Init dowstream schema info error. ", zap.String("tableID", tableID), zap.Error(err))
 			return nil, err
@@ -420,45 +461,93 @@ func (dt *downstreamTracker) getOrInit(tctx *tcontext.Context, tableID string, o
 			WhereHandle: sqlmodel.GetWhereHandle(originTI, downstreamTI),
 		}
 		dt.tableInfos[tableID] = dti
+		dt.createSQLs[tableID] = createStr
 	}
 	return dti, nil
 }
 
+// getTableInfoFromSnapshot gets downstream tableInfo by the create statement
+// restored from a snapshot. It returns nil if the table is not in the snapshot
+// or the statement is invalid, and the table should be queried from downstream.
+func (dt *downstreamTracker) getTableInfoFromSnapshot(tctx *tcontext.Context, tableID string) (*model.TableInfo, string) {
+	createStr, ok := dt.snapshot[tableID]
+	if !ok {
+		return nil, ""
+	}
+	delete(dt.snapshot, tableID)
+	ti, err := dt.buildTableInfo(tctx, createStr)
+	if err != nil {
+		tctx.Logger.Warn("invalid create table statement in snapshot, get it from downstream",
+			zap.String("tableID", tableID), zap.String("create string", createStr), zap.Error(err))
+		return nil, ""
+	}
+	if !strings.EqualFold(ti.Name.O, utils.UnpackTableID(tableID).Name) {
+		tctx.Logger.Warn("table name mismatches in snapshot, get it from downstream",
+			zap.String("tableID", tableID), zap.String("create string", createStr))
+		return nil, ""
+	}
+	return ti, createStr
+}
+
 func (dt *downstreamTracker) remove(tctx *tcontext.Context, targetTable *filter.Table) {
 	dt.Lock()
 	defer dt.Unlock()
 
 	tableID := utils.GenTableID(targetTable)
-	if _, ok := dt.tableInfos[tableID]; !ok {
-		// handle just have schema
-		if targetTable.Schema != "" && targetTable.Name == "" {
-			for k := range dt.tableInfos {
+	removeTable := func(k string) {
+		if _, ok := dt.tableInfos[k]; ok {
+			delete(dt.tableInfos, k)
+			tctx.Logger.Info("Remove downstream schema tracker", zap.String("tableID", k))
+		}
+		delete(dt.createSQLs, k)
+		// tables restored from a snapshot may not be initialized yet.
+		delete(dt.snapshot, k)
+	}
+	if _, ok := dt.tableInfos[tableID]; ok || targetTable.Name != "" {
+		removeTable(tableID)
+		return
+	}
+	// handle just have schema
+	if targetTable.Schema != "" {
+		for _, m := range []map[string]string{dt.createSQLs, dt.snapshot} {
+			for k := range m {
 				if strings.HasPrefix(k, tableID+".") {
-					delete(dt.tableInfos, k)
-					tctx.Logger.Info("Remove downstream schema tracker", zap.String("tableID", k))
+					removeTable(k)
 				}
 			}
 		}
-	} else {
-		delete(dt.tableInfos, tableID)
-		tctx.Logger.Info("Remove downstream schema tracker", zap.String("tableID", tableID))
 	}
 }
 
 // getTableInfoByCreateStmt get downstream tableInfo by "SHOW CREATE TABLE" stmt.
-func (dt *downstreamTracker) getTableInfoByCreateStmt(tctx *tcontext.Context, tableID string) (*model.TableInfo, error) {
+func (dt *downstreamTracker) getTableInfoByCreateStmt(tctx *tcontext.Context, tableID string) (*model.TableInfo, string, error) {
 	if dt.stmtParser == nil {
 		err := dt.initDownStreamSQLModeAndParser(tctx)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 	}
 	createStr, err := dbconn.GetTableCreateSQL(tctx, dt.downstreamConn, tableID)
 	if err != nil {
-		return nil, dmterror.ErrSchemaTrackerCannotFetchDownstreamCreateTableStmt.Delegate(err, tableID)
+		return nil, "", dmterror.ErrSchemaTrackerCannotFetchDownstreamCreateTableStmt.Delegate(err, tableID)
 	}
 
 	tctx.Logger.Info("Show create table info", zap.String("tableID", tableID), zap.String("create string", createStr))
+	ti, err := dt.buildTableInfo(tctx, createStr)
+	if err != nil {
+		return nil, "", err
+	}
+	return ti, createStr, nil
+}
+
+// buildTableInfo builds downstream tableInfo from the create table stmt.
+func (dt *downstreamTracker) buildTableInfo(tctx *tcontext.Context, createStr string) (*model.TableInfo, error) {
+	if dt.stmtParser == nil {
+		err := dt.initDownStreamSQLModeAndParser(tctx)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// parse create table stmt.
 	stmtNode, err := dt.stmtParser.ParseOneStmt(createStr, "", "")
 	if err != nil {
@@ -805,3 +805,70 @@ func TestNeedRestrictedSQLExecutor(t *testing.T) {
 	err = tracker.Exec(ctx, "testdb", parseSQL(t, p, `alter table testdb.t modify column a int not null;`))
 	require.NoError(t, err)
 }
+
+func TestDownstreamSnapshot(t *testing.T) {
+	p := parser.New()
+	se := timock.NewContext()
+	node, err := p.ParseOneStmt("create table t(a int, b int, c varchar(10))", "utf8mb4", "utf8mb4_bin")
+	require.NoError(t, err)
+	oriTi, err := ddl.MockTableInfo(se, node.(*ast.CreateTableStmt), 1)
+	require.NoError(t, err)
+
+	dbConn, mock := mockBaseConn(t)
+	tracker, err := NewTestTracker(context.Background(), "test-tracker", dbConn, dlog.L())
+	require.NoError(t, err)
+	defer tracker.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(fmt.Sprintf("SET SESSION SQL_MODE = '%s'", mysql.DefaultSQLMode)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	tableID1 := "`test`.`t1`"
+	tableID2 := "`test`.`t2`"
+	tableID3 := "`test2`.`t3`"
+	createSQL1 := "create table t1(a int, b int, c varchar(10), PRIMARY KEY (a,b))"
+	createSQL2 := "create table t2(a int primary key, b int, c varchar(10))"
+	createSQL3 := "create table t3(a int primary key, b int, c varchar(10))"
+	tracker.RestoreDownstreamSnapshot(map[string]string{
+		tableID1: createSQL1,
+		// table name mismatches.
+		tableID2: createSQL1,
+		tableID3: createSQL3,
+	})
+	require.Equal(t, map[string]string{
+		tableID1: createSQL1,
+		tableID2: createSQL1,
+		tableID3: createSQL3,
+	}, tracker.DownstreamSnapshot())
+
+	// table in snapshot is initialized without querying downstream.
+	dti, err := tracker.GetDownStreamTableInfo(tcontext.Background(), tableID1, oriTi)
+	require.NoError(t, err)
+	require.Equal(t, "t1", dti.TableInfo.Name.O)
+	require.Len(t, dti.TableInfo.Indices, 1)
+
+	// invalid table in snapshot is queried from downstream.
+	mock.ExpectQuery("SHOW CREATE TABLE " + tableID2).WillReturnRows(
+		sqlmock.NewRows([]string{"Table", "Create Table"}).AddRow("t2", createSQL2))
+	dti, err = tracker.GetDownStreamTableInfo(tcontext.Background(), tableID2, oriTi)
+	require.NoError(t, err)
+	require.Equal(t, "t2", dti.TableInfo.Name.O)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, map[string]string{
+		tableID1: createSQL1,
+		tableID2: createSQL2,
+		tableID3: createSQL3,
+	}, tracker.DownstreamSnapshot())
+
+	// initialized tables are not overwritten by snapshot.
+	tracker.RestoreDownstreamSnapshot(map[string]string{tableID1: createSQL2})
+	require.Equal(t, createSQL1, tracker.DownstreamSnapshot()[tableID1])
+
+	// removed tables are removed from snapshot, including uninitialized ones.
+	tracker.RemoveDownstreamSchema(tcontext.Background(), []*filter.Table{{Schema: "test", Name: "t1"}})
+	tracker.RemoveDownstreamSchema(tcontext.Background(), []*filter.Table{{Schema: "test2"}})
+	require.Equal(t, map[string]string{tableID2: createSQL2}, tracker.DownstreamSnapshot())
+	tracker.RemoveDownstreamSchema(tcontext.Background(), []*filter.Table{{Schema: "test"}})
+	require.Empty(t, tracker.DownstreamSnapshot())
+	require.Empty(t, tracker.downstreamTracker.tableInfos)
+}
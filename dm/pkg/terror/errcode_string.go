@@ -210,6 +210,8 @@ func _() {
 	_ = x[codeConfigColumnMappingDeprecated-20064]
 	_ = x[codeConfigInvalidLoadAnalyze-20065]
 	_ = x[codeConfigStrictOptimisticShardMode-20066]
+	_ = x[codeConfigInvalidLogicalTables-20067]
+	_ = x[codeConfigRelayStorageInvalid-20068]
 	_ = x[codeBinlogExtractPosition-22001]
 	_ = x[codeBinlogInvalidFilename-22002]
 	_ = x[codeBinlogParsePosFromStr-22003]
@@ -275,6 +277,7 @@ func _() {
 	_ = x[codeRelayPurgeArgsNotValid-30042]
 	_ = x[codePreviousGTIDsNotValid-30043]
 	_ = x[codeRotateEventWithDifferentServerID-30044]
+	_ = x[codeRelayStorageOperate-30045]
 	_ = x[codeDumpUnitRuntime-32001]
 	_ = x[codeDumpUnitGenTableRouter-32002]
 	_ = x[codeDumpUnitGenBAList-32003]
@@ -598,7 +601,7 @@ func _() {
 	_ = x[codeNotSet-50000]
 }
 
-const _ErrCode_name = "DBDriverErrorDBBadConnDBInvalidConnDBUnExpectDBQueryFailedDBExecuteFailedParseMydumperMetaGetFileSizeDropMultipleTablesRenameMultipleTablesAlterMultipleTablesParseSQLUnknownTypeDDLRestoreASTNodeParseGTIDNotSupportedFlavorNotMySQLGTIDNotMariaDBGTIDNotUUIDStringMariaDBDomainIDInvalidServerIDGetSQLModeFromStrVerifySQLOperateArgsStatFileSizeReaderAlreadyRunningReaderAlreadyStartedReaderStateCannotCloseReaderShouldStartSyncEmptyRelayDirReadDirBaseFileNotFoundBinFileCmpCondNotSupportBinlogFileNotValidBinlogFilesNotFoundGetRelayLogStatAddWatchForRelayLogDirWatcherStartWatcherChanClosedWatcherChanRecvErrorRelayLogFileSizeSmallerBinlogFileNotSpecifiedNoRelayLogMatchPosFirstRelayLogNotMatchPosParserParseRelayLogNoSubdirToSwitchNeedSyncAgainSyncClosedSchemaTableNameNotValidGenTableRouterEncryptSecretKeyNotValidEncryptGenCipherEncryptGenIVCiphertextLenNotValidCiphertextContextNotValidInvalidBinlogPosStrEncCipherTextBase64DecodeBinlogWriteBinaryDataBinlogWriteDataToBufferBinlogHeaderLengthNotValidBinlogEventDecodeBinlogEmptyNextBinNameBinlogParseSIDBinlogEmptyGTIDBinlogGTIDSetNotValidBinlogGTIDMySQLNotValidBinlogGTIDMariaDBNotValidBinlogMariaDBServerIDMismatchBinlogOnlyOneGTIDSupportBinlogOnlyOneIntervalInUUIDBinlogIntervalValueNotValidBinlogEmptyQueryBinlogTableMapEvNotValidBinlogExpectFormatDescEvBinlogExpectTableMapEvBinlogExpectRowsEvBinlogUnexpectedEvBinlogParseSingleEvBinlogEventTypeNotValidBinlogEventNoRowsBinlogEventNoColumnsBinlogEventRowLengthNotEqBinlogColumnTypeNotSupportBinlogGoMySQLTypeNotSupportBinlogColumnTypeMisMatchBinlogDummyEvSizeTooSmallBinlogFlavorNotSupportBinlogDMLEmptyDataBinlogLatestGTIDNotInPrevBinlogReadFileByGTIDBinlogWriterNotStateNewBinlogWriterStateCannotCloseBinlogWriterNeedStartBinlogWriterOpenFileBinlogWriterGetFileStatBinlogWriterWriteDataLenBinlogWriterFileNotOpenedBinlogWriterFileSyncBinlogPrevGTIDEvNotValidBinlogDecodeMySQLGTIDSetBinlogNeedMariaDBGTIDSetBinlogParseMariaDBGTIDSetBinlogMariaDBAddGTIDSetTracingEventDataNotValidTracingUploadDataTracingEventTypeNotValidTracingGetTraceCodeTracingDataChecksumTracingGetTSOBackoffArgsNotValidInitLoggerFailGTIDTruncateInvalidRelayLogGivenPosTooBigElectionCampaignFailElectionGetLeaderIDFailBinlogInvalidFilenameWithUUIDSuffixDecodeEtcdKeyFailShardDDLOptimismTrySyncFailConnInvalidTLSConfigConnRegistryTLSConfigUpgradeVersionEtcdFailInvalidV1WorkerMetaPathFailUpdateV1DBSchemaBinlogStatusVarsParseVerifyHandleErrorArgsRewriteSQLNoUUIDDirMatchGTIDNoRelayPosMatchGTIDReaderReachEndOfFileMetadataNoBinlogLocPreviousGTIDNotExistNoMasterStatusBinlogNotLogColumnShardDDLOptimismNeedSkipAndRedirectShardDDLOptimismAddNotFullyDroppedColumnSyncerCancelledDDLIncorrectReturnColumnsNumConfigCheckItemNotSupportConfigTomlTransformConfigYamlTransformConfigTaskNameEmptyConfigEmptySourceIDConfigTooLongSourceIDConfigOnlineSchemeNotSupportConfigInvalidTimezoneConfigParseFlagSetConfigDecryptDBPasswordConfigMetaInvalidConfigMySQLInstNotFoundConfigMySQLInstsAtLeastOneConfigMySQLInstSameSourceIDConfigMydumperCfgConflictConfigLoaderCfgConflictConfigSyncerCfgConflictConfigReadCfgFromFileConfigNeedUniqueTaskNameConfigInvalidTaskModeConfigNeedTargetDBConfigMetadataNotSetConfigRouteRuleNotFoundConfigFilterRuleNotFoundConfigColumnMappingNotFoundConfigBAListNotFoundConfigMydumperCfgNotFoundConfigMydumperPathNotValidConfigLoaderCfgNotFoundConfigSyncerCfgNotFoundConfigSourceIDNotFoundConfigDuplicateCfgItemConfigShardModeNotSupportConfigMoreThanOneConfigEtcdParseConfigMissingForBoundConfigBinlogEventFilterConfigGlobalConfigsUnusedConfigExprFilterManyExprConfigExprFilterNotFoundConfigExprFilterWrongGrammarConfigExprFilterEmptyNameConfigCheckerMaxTooSmallConfigGenBAListConfigGenTableRouterConfigGenColumnMappingConfigInvalidChunkFileSizeConfigOnlineDDLInvalidRegexConfigOnlineDDLMistakeRegexConfigOpenAPITaskConfigExistConfigOpenAPITaskConfigNotExistCollationCompatibleNotSupportConfigInvalidLoadModeConfigInvalidLoadDuplicateResolutionConfigValidationModeContinuousValidatorCfgNotFoundConfigStartTimeTooLateConfigLoaderDirInvalidConfigLoaderS3NotSupportConfigInvalidSafeModeDurationConfigConfictSafeModeDurationAndSafeModeConfigInvalidLoadPhysicalDuplicateResolutionConfigInvalidLoadPhysicalChecksumConfigColumnMappingDeprecatedConfigInvalidLoadAnalyzeConfigStrictOptimisticShardModeBinlogExtractPositionBinlogInvalidFilenameBinlogParsePosFromStrCheckpointInvalidTaskModeCheckpointSaveInvalidPosCheckpointInvalidTableFileCheckpointDBNotExistInFileCheckpointTableNotExistInFileCheckpointRestoreCountGreaterTaskCheckSameTableNameTaskCheckFailedOpenDBTaskCheckGenTableRouterTaskCheckGenColumnMappingTaskCheckSyncConfigErrorTaskCheckGenBAListSourceCheckGTIDRelayParseUUIDIndexRelayParseUUIDSuffixRelayUUIDWithSuffixNotFoundRelayGenFakeRotateEventRelayNoValidRelaySubDirRelayUUIDSuffixNotValidRelayUUIDSuffixLessThanPrevRelayLoadMetaDataRelayBinlogNameNotValidRelayNoCurrentUUIDRelayFlushLocalMetaRelayUpdateIndexFileRelayLogDirpathEmptyRelayReaderNotStateNewRelayReaderStateCannotCloseRelayReaderNeedStartRelayTCPReaderStartSyncRelayTCPReaderNilGTIDRelayTCPReaderStartSyncGTIDRelayTCPReaderGetEventRelayWriterNotStateNewRelayWriterStateCannotCloseRelayWriterNeedStartRelayWriterNotOpenedRelayWriterExpectRotateEvRelayWriterRotateEvWithNoWriterRelayWriterStatusNotValidRelayWriterGetFileStatRelayWriterLatestPosGTFileSizeRelayWriterFileOperateRelayCheckBinlogFileHeaderExistRelayCheckFormatDescEventExistRelayCheckFormatDescEventParseEvRelayCheckIsDuplicateEventRelayUpdateGTIDRelayNeedPrevGTIDEvBeforeGTIDEvRelayNeedMaGTIDListEvBeforeGTIDEvRelayMkdirRelaySwitchMasterNeedGTIDRelayThisStrategyIsPurgingRelayOtherStrategyIsPurgingRelayPurgeIsForbiddenRelayNoActiveRelayLogRelayPurgeRequestNotValidRelayTrimUUIDNotFoundRelayRemoveFileFailRelayPurgeArgsNotValidPreviousGTIDsNotValidRotateEventWithDifferentServerIDDumpUnitRuntimeDumpUnitGenTableRouterDumpUnitGenBAListDumpUnitGlobalLockLoadUnitCreateSchemaFileLoadUnitInvalidFileEndingLoadUnitParseQuoteValuesLoadUnitDoColumnMappingLoadUnitReadSchemaFileLoadUnitParseStatementLoadUnitNotCreateTableLoadUnitDispatchSQLFromFileLoadUnitInvalidInsertSQLLoadUnitGenTableRouterLoadUnitGenColumnMappingLoadUnitNoDBFileLoadUnitNoTableFileLoadUnitDumpDirNotFoundLoadUnitDuplicateTableFileLoadUnitGenBAListLoadTaskWorkerNotMatchLoadCheckPointNotMatchLoadLightningRuntimeLoadLightningHasDupLoadLightningChecksumSyncerUnitPanicSyncUnitInvalidTableNameSyncUnitTableNameQuerySyncUnitNotSupportedDMLSyncUnitAddTableInShardingSyncUnitDropSchemaTableInShardingSyncUnitInvalidShardMetaSyncUnitDDLWrongSequenceSyncUnitDDLActiveIndexLargerSyncUnitDupTableGroupSyncUnitShardingGroupNotFoundSyncUnitSafeModeSetCountSyncUnitCausalityConflictSyncUnitDMLStatementFoundSyncerUnitBinlogEventFilterSyncerUnitInvalidReplicaEventSyncerUnitParseStmtSyncerUnitUUIDNotLatestSyncerUnitDDLExecChanCloseOrBusySyncerUnitDDLChanDoneSyncerUnitDDLChanCanceledSyncerUnitDDLOnMultipleTableSyncerUnitInjectDDLOnlySyncerUnitInjectDDLWithoutSchemaSyncerUnitNotSupportedOperateSyncerUnitNilOperatorReqSyncerUnitDMLColumnNotMatchSyncerUnitDMLOldNewValueMismatchSyncerUnitDMLPruneColumnMismatchSyncerUnitGenBinlogEventFilterSyncerUnitGenTableRouterSyncerUnitGenColumnMappingSyncerUnitDoColumnMappingSyncerUnitCacheKeyNotFoundSyncerUnitHeartbeatCheckConfigSyncerUnitHeartbeatRecordExistsSyncerUnitHeartbeatRecordNotFoundSyncerUnitHeartbeatRecordNotValidSyncerUnitOnlineDDLInvalidMetaSyncerUnitOnlineDDLSchemeNotSupportSyncerUnitOnlineDDLOnMultipleTableSyncerUnitGhostApplyEmptyTableSyncerUnitGhostRenameTableNotValidSyncerUnitGhostRenameToGhostTableSyncerUnitGhostRenameGhostTblToOtherSyncerUnitGhostOnlineDDLOnGhostTblSyncerUnitPTApplyEmptyTableSyncerUnitPTRenameTableNotValidSyncerUnitPTRenameToPTTableSyncerUnitPTRenamePTTblToOtherSyncerUnitPTOnlineDDLOnPTTblSyncerUnitRemoteSteamerWithGTIDSyncerUnitRemoteSteamerStartSyncSyncerUnitGetTableFromDBSyncerUnitFirstEndPosNotFoundSyncerUnitResolveCasualityFailSyncerUnitReopenStreamNotSupportSyncerUnitUpdateConfigInShardingSyncerUnitExecWithNoBlockingDDLSyncerUnitGenBAListSyncerUnitHandleDDLFailedSyncerShardDDLConflictSyncerFailpointSyncerEventSyncerOperatorNotExistSyncerEventNotExistSyncerParseDDLSyncerUnsupportedStmtSyncerGetEventSyncerDownstreamTableNotFoundSyncerReprocessWithSafeModeFailMasterSQLOpNilRequestMasterSQLOpNotSupportMasterSQLOpWithoutShardingMasterGRPCCreateConnMasterGRPCSendOnCloseConnMasterGRPCClientCloseMasterGRPCInvalidReqTypeMasterGRPCRequestErrorMasterDeployMapperVerifyMasterConfigParseFlagSetMasterConfigUnknownItemMasterConfigInvalidFlagMasterConfigTomlTransformMasterConfigTimeoutParseMasterConfigUpdateCfgFileMasterShardingDDLDiffMasterStartServiceMasterNoEmitTokenMasterLockNotFoundMasterLockIsResolvingMasterWorkerCliNotFoundMasterWorkerNotWaitLockMasterHandleSQLReqFailMasterOwnerExecDDLMasterPartWorkerExecDDLFailMasterWorkerExistDDLLockMasterGetWorkerCfgExtractorMasterTaskConfigExtractorMasterWorkerArgsExtractorMasterQueryWorkerConfigMasterOperNotFoundMasterOperRespNotSuccessMasterOperRequestTimeoutMasterHandleHTTPApisMasterHostPortNotValidMasterGetHostnameFailMasterGenEmbedEtcdConfigFailMasterStartEmbedEtcdFailMasterParseURLFailMasterJoinEmbedEtcdFailMasterInvalidOperateOpMasterAdvertiseAddrNotValidMasterRequestIsNotForwardToLeaderMasterIsNotAsyncRequestMasterFailToGetExpectResultMasterPessimistNotStartedMasterOptimistNotStartedMasterMasterNameNotExistMasterInvalidOfflineTypeMasterAdvertisePeerURLsNotValidMasterTLSConfigNotValidMasterBoundChangingMasterFailToImportFromV10xMasterInconsistentOptimistDDLsAndInfoMasterOptimisticTableInfobeforeNotExistMasterOptimisticDownstreamMetaNotFoundMasterInvalidClusterIDMasterStartTaskWorkerParseFlagSetWorkerInvalidFlagWorkerDecodeConfigFromFileWorkerUndecodedItemFromFileWorkerNeedSourceIDWorkerTooLongSourceIDWorkerRelayBinlogNameWorkerWriteConfigFileWorkerLogInvalidHandlerWorkerLogPointerInvalidWorkerLogFetchPointerWorkerLogUnmarshalPointerWorkerLogClearPointerWorkerLogTaskKeyNotValidWorkerLogUnmarshalTaskKeyWorkerLogFetchLogIterWorkerLogGetTaskLogWorkerLogUnmarshalBinaryWorkerLogForwardPointerWorkerLogMarshalTaskWorkerLogSaveTaskWorkerLogDeleteKVWorkerLogDeleteKVIterWorkerLogUnmarshalTaskMetaWorkerLogFetchTaskFromMetaWorkerLogVerifyTaskMetaWorkerLogSaveTaskMetaWorkerLogGetTaskMetaWorkerLogDeleteTaskMetaWorkerMetaTomlTransformWorkerMetaOldFileStatWorkerMetaOldReadFileWorkerMetaEncodeTaskWorkerMetaRemoveOldDirWorkerMetaTaskLogNotFoundWorkerMetaHandleTaskOrderWorkerMetaOpenTxnWorkerMetaCommitTxnWorkerRelayStageNotValidWorkerRelayOperNotSupportWorkerOpenKVDBFileWorkerUpgradeCheckKVDirWorkerMarshalVerBinaryWorkerUnmarshalVerBinaryWorkerGetVersionFromKVWorkerSaveVersionToKVWorkerVerAutoDowngradeWorkerStartServiceWorkerAlreadyClosedWorkerNotRunningStageWorkerNotPausedStageWorkerUpdateTaskStageWorkerMigrateStopRelayWorkerSubTaskNotFoundWorkerSubTaskExistsWorkerOperSyncUnitOnlyWorkerRelayUnitStageWorkerNoSyncerRunningWorkerCannotUpdateSourceIDWorkerNoAvailUnitsWorkerDDLLockInfoNotFoundWorkerDDLLockInfoExistsWorkerCacheDDLInfoExistsWorkerExecSkipDDLConflictWorkerExecDDLSyncerOnlyWorkerExecDDLTimeoutWorkerWaitRelayCatchupTimeoutWorkerRelayIsPurgingWorkerHostPortNotValidWorkerNoStartWorkerAlreadyStartedWorkerSourceNotMatchWorkerFailToGetSubtaskConfigFromEtcdWorkerFailToGetSourceConfigFromEtcdWorkerDDLLockOpNotFoundWorkerTLSConfigNotValidWorkerFailConnectMasterWorkerWaitRelayCatchupGTIDWorkerRelayConfigChangingWorkerRouteTableDupMatchWorkerUpdateSubTaskConfigWorkerValidatorNotPausedWorkerServerClosedTracerParseFlagSetTracerConfigTomlTransformTracerConfigInvalidFlagTracerTraceEventNotFoundTracerTraceIDNotProvidedTracerParamNotValidTracerPostMethodOnlyTracerEventAssertionFailTracerEventTypeNotValidTracerStartServiceHAFailTxnOperationHAInvalidItemHAFailWatchEtcdHAFailLeaseOperationHAFailKeepaliveValidatorLoadPersistedDataValidatorPersistDataValidatorGetEventValidatorProcessRowEventValidatorValidateChangeValidatorNotFoundValidatorPanicValidatorTooMuchPendingSchemaTrackerInvalidJSONSchemaTrackerCannotCreateSchemaSchemaTrackerCannotCreateTableSchemaTrackerCannotSerializeSchemaTrackerCannotGetTableSchemaTrackerCannotExecDDLSchemaTrackerCannotFetchDownstreamTableSchemaTrackerCannotParseDownstreamTableSchemaTrackerInvalidCreateTableStmtSchemaTrackerRestoreStmtFailSchemaTrackerCannotDropTableSchemaTrackerInitSchemaTrackerMarshalJSONSchemaTrackerUnMarshalJSONSchemaTrackerUnSchemaNotExistSchemaTrackerCannotSetDownstreamSQLModeSchemaTrackerCannotInitDownstreamParserSchemaTrackerCannotMockDownstreamTableSchemaTrackerCannotFetchDownstreamCreateTableStmtSchemaTrackerIsClosedSchedulerNotStartedSchedulerStartedSchedulerWorkerExistSchedulerWorkerNotExistSchedulerWorkerOnlineSchedulerWorkerInvalidTransSchedulerSourceCfgExistSchedulerSourceCfgNotExistSchedulerSourcesUnboundSchedulerSourceOpTaskExistSchedulerRelayStageInvalidUpdateSchedulerRelayStageSourceNotExistSchedulerMultiTaskSchedulerSubTaskExistSchedulerSubTaskStageInvalidUpdateSchedulerSubTaskOpTaskNotExistSchedulerSubTaskOpSourceNotExistSchedulerTaskNotExistSchedulerRequireRunningTaskInSyncUnitSchedulerRelayWorkersBusySchedulerRelayWorkersBoundSchedulerRelayWorkersWrongRelaySchedulerSourceOpRelayExistSchedulerLatchInUseSchedulerSourceCfgUpdateSchedulerWrongWorkerInputSchedulerCantTransferToRelayWorkerSchedulerStartRelayOnSpecifiedSchedulerStopRelayOnSpecifiedSchedulerStartRelayOnBoundSchedulerStopRelayOnBoundSchedulerPauseTaskForTransferSourceSchedulerWorkerNotFreeSchedulerSubTaskNotExistSchedulerSubTaskCfgUpdateCtlGRPCCreateConnCtlInvalidTLSCfgCtlLoadTLSCfgOpenAPICommonOpenAPITaskSourceNotFoundNotSet"
+const _ErrCode_name = "DBDriverErrorDBBadConnDBInvalidConnDBUnExpectDBQueryFailedDBExecuteFailedParseMydumperMetaGetFileSizeDropMultipleTablesRenameMultipleTablesAlterMultipleTablesParseSQLUnknownTypeDDLRestoreASTNodeParseGTIDNotSupportedFlavorNotMySQLGTIDNotMariaDBGTIDNotUUIDStringMariaDBDomainIDInvalidServerIDGetSQLModeFromStrVerifySQLOperateArgsStatFileSizeReaderAlreadyRunningReaderAlreadyStartedReaderStateCannotCloseReaderShouldStartSyncEmptyRelayDirReadDirBaseFileNotFoundBinFileCmpCondNotSupportBinlogFileNotValidBinlogFilesNotFoundGetRelayLogStatAddWatchForRelayLogDirWatcherStartWatcherChanClosedWatcherChanRecvErrorRelayLogFileSizeSmallerBinlogFileNotSpecifiedNoRelayLogMatchPosFirstRelayLogNotMatchPosParserParseRelayLogNoSubdirToSwitchNeedSyncAgainSyncClosedSchemaTableNameNotValidGenTableRouterEncryptSecretKeyNotValidEncryptGenCipherEncryptGenIVCiphertextLenNotValidCiphertextContextNotValidInvalidBinlogPosStrEncCipherTextBase64DecodeBinlogWriteBinaryDataBinlogWriteDataToBufferBinlogHeaderLengthNotValidBinlogEventDecodeBinlogEmptyNextBinNameBinlogParseSIDBinlogEmptyGTIDBinlogGTIDSetNotValidBinlogGTIDMySQLNotValidBinlogGTIDMariaDBNotValidBinlogMariaDBServerIDMismatchBinlogOnlyOneGTIDSupportBinlogOnlyOneIntervalInUUIDBinlogIntervalValueNotValidBinlogEmptyQueryBinlogTableMapEvNotValidBinlogExpectFormatDescEvBinlogExpectTableMapEvBinlogExpectRowsEvBinlogUnexpectedEvBinlogParseSingleEvBinlogEventTypeNotValidBinlogEventNoRowsBinlogEventNoColumnsBinlogEventRowLengthNotEqBinlogColumnTypeNotSupportBinlogGoMySQLTypeNotSupportBinlogColumnTypeMisMatchBinlogDummyEvSizeTooSmallBinlogFlavorNotSupportBinlogDMLEmptyDataBinlogLatestGTIDNotInPrevBinlogReadFileByGTIDBinlogWriterNotStateNewBinlogWriterStateCannotCloseBinlogWriterNeedStartBinlogWriterOpenFileBinlogWriterGetFileStatBinlogWriterWriteDataLenBinlogWriterFileNotOpenedBinlogWriterFileSyncBinlogPrevGTIDEvNotValidBinlogDecodeMySQLGTIDSetBinlogNeedMariaDBGTIDSetBinlogParseMariaDBGTIDSetBinlogMariaDBAddGTIDSetTracingEventDataNotValidTracingUploadDataTracingEventTypeNotValidTracingGetTraceCodeTracingDataChecksumTracingGetTSOBackoffArgsNotValidInitLoggerFailGTIDTruncateInvalidRelayLogGivenPosTooBigElectionCampaignFailElectionGetLeaderIDFailBinlogInvalidFilenameWithUUIDSuffixDecodeEtcdKeyFailShardDDLOptimismTrySyncFailConnInvalidTLSConfigConnRegistryTLSConfigUpgradeVersionEtcdFailInvalidV1WorkerMetaPathFailUpdateV1DBSchemaBinlogStatusVarsParseVerifyHandleErrorArgsRewriteSQLNoUUIDDirMatchGTIDNoRelayPosMatchGTIDReaderReachEndOfFileMetadataNoBinlogLocPreviousGTIDNotExistNoMasterStatusBinlogNotLogColumnShardDDLOptimismNeedSkipAndRedirectShardDDLOptimismAddNotFullyDroppedColumnSyncerCancelledDDLIncorrectReturnColumnsNumConfigCheckItemNotSupportConfigTomlTransformConfigYamlTransformConfigTaskNameEmptyConfigEmptySourceIDConfigTooLongSourceIDConfigOnlineSchemeNotSupportConfigInvalidTimezoneConfigParseFlagSetConfigDecryptDBPasswordConfigMetaInvalidConfigMySQLInstNotFoundConfigMySQLInstsAtLeastOneConfigMySQLInstSameSourceIDConfigMydumperCfgConflictConfigLoaderCfgConflictConfigSyncerCfgConflictConfigReadCfgFromFileConfigNeedUniqueTaskNameConfigInvalidTaskModeConfigNeedTargetDBConfigMetadataNotSetConfigRouteRuleNotFoundConfigFilterRuleNotFoundConfigColumnMappingNotFoundConfigBAListNotFoundConfigMydumperCfgNotFoundConfigMydumperPathNotValidConfigLoaderCfgNotFoundConfigSyncerCfgNotFoundConfigSourceIDNotFoundConfigDuplicateCfgItemConfigShardModeNotSupportConfigMoreThanOneConfigEtcdParseConfigMissingForBoundConfigBinlogEventFilterConfigGlobalConfigsUnusedConfigExprFilterManyExprConfigExprFilterNotFoundConfigExprFilterWrongGrammarConfigExprFilterEmptyNameConfigCheckerMaxTooSmallConfigGenBAListConfigGenTableRouterConfigGenColumnMappingConfigInvalidChunkFileSizeConfigOnlineDDLInvalidRegexConfigOnlineDDLMistakeRegexConfigOpenAPITaskConfigExistConfigOpenAPITaskConfigNotExistCollationCompatibleNotSupportConfigInvalidLoadModeConfigInvalidLoadDuplicateResolutionConfigValidationModeContinuousValidatorCfgNotFoundConfigStartTimeTooLateConfigLoaderDirInvalidConfigLoaderS3NotSupportConfigInvalidSafeModeDurationConfigConfictSafeModeDurationAndSafeModeConfigInvalidLoadPhysicalDuplicateResolutionConfigInvalidLoadPhysicalChecksumConfigColumnMappingDeprecatedConfigInvalidLoadAnalyzeConfigStrictOptimisticShardModeConfigInvalidLogicalTablesConfigRelayStorageInvalidBinlogExtractPositionBinlogInvalidFilenameBinlogParsePosFromStrCheckpointInvalidTaskModeCheckpointSaveInvalidPosCheckpointInvalidTableFileCheckpointDBNotExistInFileCheckpointTableNotExistInFileCheckpointRestoreCountGreaterTaskCheckSameTableNameTaskCheckFailedOpenDBTaskCheckGenTableRouterTaskCheckGenColumnMappingTaskCheckSyncConfigErrorTaskCheckGenBAListSourceCheckGTIDRelayParseUUIDIndexRelayParseUUIDSuffixRelayUUIDWithSuffixNotFoundRelayGenFakeRotateEventRelayNoValidRelaySubDirRelayUUIDSuffixNotValidRelayUUIDSuffixLessThanPrevRelayLoadMetaDataRelayBinlogNameNotValidRelayNoCurrentUUIDRelayFlushLocalMetaRelayUpdateIndexFileRelayLogDirpathEmptyRelayReaderNotStateNewRelayReaderStateCannotCloseRelayReaderNeedStartRelayTCPReaderStartSyncRelayTCPReaderNilGTIDRelayTCPReaderStartSyncGTIDRelayTCPReaderGetEventRelayWriterNotStateNewRelayWriterStateCannotCloseRelayWriterNeedStartRelayWriterNotOpenedRelayWriterExpectRotateEvRelayWriterRotateEvWithNoWriterRelayWriterStatusNotValidRelayWriterGetFileStatRelayWriterLatestPosGTFileSizeRelayWriterFileOperateRelayCheckBinlogFileHeaderExistRelayCheckFormatDescEventExistRelayCheckFormatDescEventParseEvRelayCheckIsDuplicateEventRelayUpdateGTIDRelayNeedPrevGTIDEvBeforeGTIDEvRelayNeedMaGTIDListEvBeforeGTIDEvRelayMkdirRelaySwitchMasterNeedGTIDRelayThisStrategyIsPurgingRelayOtherStrategyIsPurgingRelayPurgeIsForbiddenRelayNoActiveRelayLogRelayPurgeRequestNotValidRelayTrimUUIDNotFoundRelayRemoveFileFailRelayPurgeArgsNotValidPreviousGTIDsNotValidRotateEventWithDifferentServerIDRelayStorageOperateDumpUnitRuntimeDumpUnitGenTableRouterDumpUnitGenBAListDumpUnitGlobalLockLoadUnitCreateSchemaFileLoadUnitInvalidFileEndingLoadUnitParseQuoteValuesLoadUnitDoColumnMappingLoadUnitReadSchemaFileLoadUnitParseStatementLoadUnitNotCreateTableLoadUnitDispatchSQLFromFileLoadUnitInvalidInsertSQLLoadUnitGenTableRouterLoadUnitGenColumnMappingLoadUnitNoDBFileLoadUnitNoTableFileLoadUnitDumpDirNotFoundLoadUnitDuplicateTableFileLoadUnitGenBAListLoadTaskWorkerNotMatchLoadCheckPointNotMatchLoadLightningRuntimeLoadLightningHasDupLoadLightningChecksumSyncerUnitPanicSyncUnitInvalidTableNameSyncUnitTableNameQuerySyncUnitNotSupportedDMLSyncUnitAddTableInShardingSyncUnitDropSchemaTableInShardingSyncUnitInvalidShardMetaSyncUnitDDLWrongSequenceSyncUnitDDLActiveIndexLargerSyncUnitDupTableGroupSyncUnitShardingGroupNotFoundSyncUnitSafeModeSetCountSyncUnitCausalityConflictSyncUnitDMLStatementFoundSyncerUnitBinlogEventFilterSyncerUnitInvalidReplicaEventSyncerUnitParseStmtSyncerUnitUUIDNotLatestSyncerUnitDDLExecChanCloseOrBusySyncerUnitDDLChanDoneSyncerUnitDDLChanCanceledSyncerUnitDDLOnMultipleTableSyncerUnitInjectDDLOnlySyncerUnitInjectDDLWithoutSchemaSyncerUnitNotSupportedOperateSyncerUnitNilOperatorReqSyncerUnitDMLColumnNotMatchSyncerUnitDMLOldNewValueMismatchSyncerUnitDMLPruneColumnMismatchSyncerUnitGenBinlogEventFilterSyncerUnitGenTableRouterSyncerUnitGenColumnMappingSyncerUnitDoColumnMappingSyncerUnitCacheKeyNotFoundSyncerUnitHeartbeatCheckConfigSyncerUnitHeartbeatRecordExistsSyncerUnitHeartbeatRecordNotFoundSyncerUnitHeartbeatRecordNotValidSyncerUnitOnlineDDLInvalidMetaSyncerUnitOnlineDDLSchemeNotSupportSyncerUnitOnlineDDLOnMultipleTableSyncerUnitGhostApplyEmptyTableSyncerUnitGhostRenameTableNotValidSyncerUnitGhostRenameToGhostTableSyncerUnitGhostRenameGhostTblToOtherSyncerUnitGhostOnlineDDLOnGhostTblSyncerUnitPTApplyEmptyTableSyncerUnitPTRenameTableNotValidSyncerUnitPTRenameToPTTableSyncerUnitPTRenamePTTblToOtherSyncerUnitPTOnlineDDLOnPTTblSyncerUnitRemoteSteamerWithGTIDSyncerUnitRemoteSteamerStartSyncSyncerUnitGetTableFromDBSyncerUnitFirstEndPosNotFoundSyncerUnitResolveCasualityFailSyncerUnitReopenStreamNotSupportSyncerUnitUpdateConfigInShardingSyncerUnitExecWithNoBlockingDDLSyncerUnitGenBAListSyncerUnitHandleDDLFailedSyncerShardDDLConflictSyncerFailpointSyncerEventSyncerOperatorNotExistSyncerEventNotExistSyncerParseDDLSyncerUnsupportedStmtSyncerGetEventSyncerDownstreamTableNotFoundSyncerReprocessWithSafeModeFailMasterSQLOpNilRequestMasterSQLOpNotSupportMasterSQLOpWithoutShardingMasterGRPCCreateConnMasterGRPCSendOnCloseConnMasterGRPCClientCloseMasterGRPCInvalidReqTypeMasterGRPCRequestErrorMasterDeployMapperVerifyMasterConfigParseFlagSetMasterConfigUnknownItemMasterConfigInvalidFlagMasterConfigTomlTransformMasterConfigTimeoutParseMasterConfigUpdateCfgFileMasterShardingDDLDiffMasterStartServiceMasterNoEmitTokenMasterLockNotFoundMasterLockIsResolvingMasterWorkerCliNotFoundMasterWorkerNotWaitLockMasterHandleSQLReqFailMasterOwnerExecDDLMasterPartWorkerExecDDLFailMasterWorkerExistDDLLockMasterGetWorkerCfgExtractorMasterTaskConfigExtractorMasterWorkerArgsExtractorMasterQueryWorkerConfigMasterOperNotFoundMasterOperRespNotSuccessMasterOperRequestTimeoutMasterHandleHTTPApisMasterHostPortNotValidMasterGetHostnameFailMasterGenEmbedEtcdConfigFailMasterStartEmbedEtcdFailMasterParseURLFailMasterJoinEmbedEtcdFailMasterInvalidOperateOpMasterAdvertiseAddrNotValidMasterRequestIsNotForwardToLeaderMasterIsNotAsyncRequestMasterFailToGetExpectResultMasterPessimistNotStartedMasterOptimistNotStartedMasterMasterNameNotExistMasterInvalidOfflineTypeMasterAdvertisePeerURLsNotValidMasterTLSConfigNotValidMasterBoundChangingMasterFailToImportFromV10xMasterInconsistentOptimistDDLsAndInfoMasterOptimisticTableInfobeforeNotExistMasterOptimisticDownstreamMetaNotFoundMasterInvalidClusterIDMasterStartTaskWorkerParseFlagSetWorkerInvalidFlagWorkerDecodeConfigFromFileWorkerUndecodedItemFromFileWorkerNeedSourceIDWorkerTooLongSourceIDWorkerRelayBinlogNameWorkerWriteConfigFileWorkerLogInvalidHandlerWorkerLogPointerInvalidWorkerLogFetchPointerWorkerLogUnmarshalPointerWorkerLogClearPointerWorkerLogTaskKeyNotValidWorkerLogUnmarshalTaskKeyWorkerLogFetchLogIterWorkerLogGetTaskLogWorkerLogUnmarshalBinaryWorkerLogForwardPointerWorkerLogMarshalTaskWorkerLogSaveTaskWorkerLogDeleteKVWorkerLogDeleteKVIterWorkerLogUnmarshalTaskMetaWorkerLogFetchTaskFromMetaWorkerLogVerifyTaskMetaWorkerLogSaveTaskMetaWorkerLogGetTaskMetaWorkerLogDeleteTaskMetaWorkerMetaTomlTransformWorkerMetaOldFileStatWorkerMetaOldReadFileWorkerMetaEncodeTaskWorkerMetaRemoveOldDirWorkerMetaTaskLogNotFoundWorkerMetaHandleTaskOrderWorkerMetaOpenTxnWorkerMetaCommitTxnWorkerRelayStageNotValidWorkerRelayOperNotSupportWorkerOpenKVDBFileWorkerUpgradeCheckKVDirWorkerMarshalVerBinaryWorkerUnmarshalVerBinaryWorkerGetVersionFromKVWorkerSaveVersionToKVWorkerVerAutoDowngradeWorkerStartServiceWorkerAlreadyClosedWorkerNotRunningStageWorkerNotPausedStageWorkerUpdateTaskStageWorkerMigrateStopRelayWorkerSubTaskNotFoundWorkerSubTaskExistsWorkerOperSyncUnitOnlyWorkerRelayUnitStageWorkerNoSyncerRunningWorkerCannotUpdateSourceIDWorkerNoAvailUnitsWorkerDDLLockInfoNotFoundWorkerDDLLockInfoExistsWorkerCacheDDLInfoExistsWorkerExecSkipDDLConflictWorkerExecDDLSyncerOnlyWorkerExecDDLTimeoutWorkerWaitRelayCatchupTimeoutWorkerRelayIsPurgingWorkerHostPortNotValidWorkerNoStartWorkerAlreadyStartedWorkerSourceNotMatchWorkerFailToGetSubtaskConfigFromEtcdWorkerFailToGetSourceConfigFromEtcdWorkerDDLLockOpNotFoundWorkerTLSConfigNotValidWorkerFailConnectMasterWorkerWaitRelayCatchupGTIDWorkerRelayConfigChangingWorkerRouteTableDupMatchWorkerUpdateSubTaskConfigWorkerValidatorNotPausedWorkerServerClosedTracerParseFlagSetTracerConfigTomlTransformTracerConfigInvalidFlagTracerTraceEventNotFoundTracerTraceIDNotProvidedTracerParamNotValidTracerPostMethodOnlyTracerEventAssertionFailTracerEventTypeNotValidTracerStartServiceHAFailTxnOperationHAInvalidItemHAFailWatchEtcdHAFailLeaseOperationHAFailKeepaliveValidatorLoadPersistedDataValidatorPersistDataValidatorGetEventValidatorProcessRowEventValidatorValidateChangeValidatorNotFoundValidatorPanicValidatorTooMuchPendingSchemaTrackerInvalidJSONSchemaTrackerCannotCreateSchemaSchemaTrackerCannotCreateTableSchemaTrackerCannotSerializeSchemaTrackerCannotGetTableSchemaTrackerCannotExecDDLSchemaTrackerCannotFetchDownstreamTableSchemaTrackerCannotParseDownstreamTableSchemaTrackerInvalidCreateTableStmtSchemaTrackerRestoreStmtFailSchemaTrackerCannotDropTableSchemaTrackerInitSchemaTrackerMarshalJSONSchemaTrackerUnMarshalJSONSchemaTrackerUnSchemaNotExistSchemaTrackerCannotSetDownstreamSQLModeSchemaTrackerCannotInitDownstreamParserSchemaTrackerCannotMockDownstreamTableSchemaTrackerCannotFetchDownstreamCreateTableStmtSchemaTrackerIsClosedSchedulerNotStartedSchedulerStartedSchedulerWorkerExistSchedulerWorkerNotExistSchedulerWorkerOnlineSchedulerWorkerInvalidTransSchedulerSourceCfgExistSchedulerSourceCfgNotExistSchedulerSourcesUnboundSchedulerSourceOpTaskExistSchedulerRelayStageInvalidUpdateSchedulerRelayStageSourceNotExistSchedulerMultiTaskSchedulerSubTaskExistSchedulerSubTaskStageInvalidUpdateSchedulerSubTaskOpTaskNotExistSchedulerSubTaskOpSourceNotExistSchedulerTaskNotExistSchedulerRequireRunningTaskInSyncUnitSchedulerRelayWorkersBusySchedulerRelayWorkersBoundSchedulerRelayWorkersWrongRelaySchedulerSourceOpRelayExistSchedulerLatchInUseSchedulerSourceCfgUpdateSchedulerWrongWorkerInputSchedulerCantTransferToRelayWorkerSchedulerStartRelayOnSpecifiedSchedulerStopRelayOnSpecifiedSchedulerStartRelayOnBoundSchedulerStopRelayOnBoundSchedulerPauseTaskForTransferSourceSchedulerWorkerNotFreeSchedulerSubTaskNotExistSchedulerSubTaskCfgUpdateCtlGRPCCreateConnCtlInvalidTLSCfgCtlLoadTLSCfgOpenAPICommonOpenAPITaskSourceNotFoundNotSet"
 
 var _ErrCode_map = map[ErrCode]string{
 	10001: _ErrCode_name[0:13],
@@ -803,392 +806,395 @@ var _ErrCode_map = map[ErrCode]string{
 	20064: _ErrCode_name[4188:4217],
 	20065: _ErrCode_name[4217:4241],
 	20066: _ErrCode_name[4241:4272],
-	22001: _ErrCode_name[4272:4293],
-	22002: _ErrCode_name[4293:4314],
-	22003: _ErrCode_name[4314:4335],
-	24001: _ErrCode_name[4335:4360],
-	24002: _ErrCode_name[4360:4384],
-	24003: _ErrCode_name[4384:4410],
-	24004: _ErrCode_name[4410:4436],
-	24005: _ErrCode_name[4436:4465],
-	24006: _ErrCode_name[4465:4494],
-	26001: _ErrCode_name[4494:4516],
-	26002: _ErrCode_name[4516:4537],
-	26003: _ErrCode_name[4537:4560],
-	26004: _ErrCode_name[4560:4585],
-	26005: _ErrCode_name[4585:4609],
-	26006: _ErrCode_name[4609:4627],
-	26007: _ErrCode_name[4627:4642],
-	28001: _ErrCode_name[4642:4661],
-	28002: _ErrCode_name[4661:4681],
-	28003: _ErrCode_name[4681:4708],
-	28004: _ErrCode_name[4708:4731],
-	28005: _ErrCode_name[4731:4754],
-	30001: _ErrCode_name[4754:4777],
-	30002: _ErrCode_name[4777:4804],
-	30003: _ErrCode_name[4804:4821],
-	30004: _ErrCode_name[4821:4844],
-	30005: _ErrCode_name[4844:4862],
-	30006: _ErrCode_name[4862:4881],
-	30007: _ErrCode_name[4881:4901],
-	30008: _ErrCode_name[4901:4921],
-	30009: _ErrCode_name[4921:4943],
-	30010: _ErrCode_name[4943:4970],
-	30011: _ErrCode_name[4970:4990],
-	30012: _ErrCode_name[4990:5013],
-	30013: _ErrCode_name[5013:5034],
-	30014: _ErrCode_name[5034:5061],
-	30015: _ErrCode_name[5061:5083],
-	30016: _ErrCode_name[5083:5105],
-	30017: _ErrCode_name[5105:5132],
-	30018: _ErrCode_name[5132:5152],
-	30019: _ErrCode_name[5152:5172],
-	30020: _ErrCode_name[5172:5197],
-	30021: _ErrCode_name[5197:5228],
-	30022: _ErrCode_name[5228:5253],
-	30023: _ErrCode_name[5253:5275],
-	30024: _ErrCode_name[5275:5305],
-	30025: _ErrCode_name[5305:5327],
-	30026: _ErrCode_name[5327:5358],
-	30027: _ErrCode_name[5358:5388],
-	30028: _ErrCode_name[5388:5420],
-	30029: _ErrCode_name[5420:5446],
-	30030: _ErrCode_name[5446:5461],
-	30031: _ErrCode_name[5461:5492],
-	30032: _ErrCode_name[5492:5525],
-	30033: _ErrCode_name[5525:5535],
-	30034: _ErrCode_name[5535:5560],
-	30035: _ErrCode_name[5560:5586],
-	30036: _ErrCode_name[5586:5613],
-	30037: _ErrCode_name[5613:5634],
-	30038: _ErrCode_name[5634:5655],
-	30039: _ErrCode_name[5655:5680],
-	30040: _ErrCode_name[5680:5701],
-	30041: _ErrCode_name[5701:5720],
-	30042: _ErrCode_name[5720:5742],
-	30043: _ErrCode_name[5742:5763],
-	30044: _ErrCode_name[5763:5795],
-	32001: _ErrCode_name[5795:5810],
-	32002: _ErrCode_name[5810:5832],
-	32003: _ErrCode_name[5832:5849],
-	32004: _ErrCode_name[5849:5867],
-	34001: _ErrCode_name[5867:5891],
-	34002: _ErrCode_name[5891:5916],
-	34003: _ErrCode_name[5916:5940],
-	34004: _ErrCode_name[5940:5963],
-	34005: _ErrCode_name[5963:5985],
-	34006: _ErrCode_name[5985:6007],
-	34007: _ErrCode_name[6007:6029],
-	34008: _ErrCode_name[6029:6056],
-	34009: _ErrCode_name[6056:6080],
-	34010: _ErrCode_name[6080:6102],
-	34011: _ErrCode_name[6102:6126],
-	34012: _ErrCode_name[6126:6142],
-	34013: _ErrCode_name[6142:6161],
-	34014: _ErrCode_name[6161:6184],
-	34015: _ErrCode_name[6184:6210],
-	34016: _ErrCode_name[6210:6227],
-	34017: _ErrCode_name[6227:6249],
-	34018: _ErrCode_name[6249:6271],
-	34019: _ErrCode_name[6271:6291],
-	34020: _ErrCode_name[6291:6310],
-	34021: _ErrCode_name[6310:6331],
-	36001: _ErrCode_name[6331:6346],
-	36002: _ErrCode_name[6346:6370],
-	36003: _ErrCode_name[6370:6392],
-	36004: _ErrCode_name[6392:6415],
-	36005: _ErrCode_name[6415:6441],
-	36006: _ErrCode_name[6441:6474],
-	36007: _ErrCode_name[6474:6498],
-	36008: _ErrCode_name[6498:6522],
-	36009: _ErrCode_name[6522:6550],
-	36010: _ErrCode_name[6550:6571],
-	36011: _ErrCode_name[6571:6600],
-	36012: _ErrCode_name[6600:6624],
-	36013: _ErrCode_name[6624:6649],
-	36014: _ErrCode_name[6649:6674],
-	36015: _ErrCode_name[6674:6701],
-	36016: _ErrCode_name[6701:6730],
-	36017: _ErrCode_name[6730:6749],
-	36018: _ErrCode_name[6749:6772],
-	36019: _ErrCode_name[6772:6804],
-	36020: _ErrCode_name[6804:6825],
-	36021: _ErrCode_name[6825:6850],
-	36022: _ErrCode_name[6850:6878],
-	36023: _ErrCode_name[6878:6901],
-	36024: _ErrCode_name[6901:6933],
-	36025: _ErrCode_name[6933:6962],
-	36026: _ErrCode_name[6962:6986],
-	36027: _ErrCode_name[6986:7013],
-	36028: _ErrCode_name[7013:7045],
-	36029: _ErrCode_name[7045:7077],
-	36030: _ErrCode_name[7077:7107],
-	36031: _ErrCode_name[7107:7131],
-	36032: _ErrCode_name[7131:7157],
-	36033: _ErrCode_name[7157:7182],
-	36034: _ErrCode_name[7182:7208],
-	36035: _ErrCode_name[7208:7238],
-	36036: _ErrCode_name[7238:7269],
-	36037: _ErrCode_name[7269:7302],
-	36038: _ErrCode_name[7302:7335],
-	36039: _ErrCode_name[7335:7365],
-	36040: _ErrCode_name[7365:7400],
-	36041: _ErrCode_name[7400:7434],
-	36042: _ErrCode_name[7434:7464],
-	36043: _ErrCode_name[7464:7498],
-	36044: _ErrCode_name[7498:7531],
-	36045: _ErrCode_name[7531:7567],
-	36046: _ErrCode_name[7567:7601],
-	36047: _ErrCode_name[7601:7628],
-	36048: _ErrCode_name[7628:7659],
-	36049: _ErrCode_name[7659:7686],
-	36050: _ErrCode_name[7686:7716],
-	36051: _ErrCode_name[7716:7744],
-	36052: _ErrCode_name[7744:7775],
-	36053: _ErrCode_name[7775:7807],
-	36054: _ErrCode_name[7807:7831],
-	36055: _ErrCode_name[7831:7860],
-	36056: _ErrCode_name[7860:7890],
-	36057: _ErrCode_name[7890:7922],
-	36058: _ErrCode_name[7922:7954],
-	36059: _ErrCode_name[7954:7985],
-	36060: _ErrCode_name[7985:8004],
-	36061: _ErrCode_name[8004:8029],
-	36062: _ErrCode_name[8029:8051],
-	36063: _ErrCode_name[8051:8066],
-	36064: _ErrCode_name[8066:8077],
-	36065: _ErrCode_name[8077:8099],
-	36066: _ErrCode_name[8099:8118],
-	36067: _ErrCode_name[8118:8132],
-	36068: _ErrCode_name[8132:8153],
-	36069: _ErrCode_name[8153:8167],
-	36070: _ErrCode_name[8167:8196],
-	36071: _ErrCode_name[8196:8227],
-	38001: _ErrCode_name[8227:8248],
-	38002: _ErrCode_name[8248:8269],
-	38003: _ErrCode_name[8269:8295],
-	38004: _ErrCode_name[8295:8315],
-	38005: _ErrCode_name[8315:8340],
-	38006: _ErrCode_name[8340:8361],
-	38007: _ErrCode_name[8361:8385],
-	38008: _ErrCode_name[8385:8407],
-	38009: _ErrCode_name[8407:8431],
-	38010: _ErrCode_name[8431:8455],
-	38011: _ErrCode_name[8455:8478],
-	38012: _ErrCode_name[8478:8501],
-	38013: _ErrCode_name[8501:8526],
-	38014: _ErrCode_name[8526:8550],
-	38015: _ErrCode_name[8550:8575],
-	38016: _ErrCode_name[8575:8596],
-	38017: _ErrCode_name[8596:8614],
-	38018: _ErrCode_name[8614:8631],
-	38019: _ErrCode_name[8631:8649],
-	38020: _ErrCode_name[8649:8670],
-	38021: _ErrCode_name[8670:8693],
-	38022: _ErrCode_name[8693:8716],
-	38023: _ErrCode_name[8716:8738],
-	38024: _ErrCode_name[8738:8756],
-	38025: _ErrCode_name[8756:8783],
-	38026: _ErrCode_name[8783:8807],
-	38027: _ErrCode_name[8807:8834],
-	38028: _ErrCode_name[8834:8859],
-	38029: _ErrCode_name[8859:8884],
-	38030: _ErrCode_name[8884:8907],
-	38031: _ErrCode_name[8907:8925],
-	38032: _ErrCode_name[8925:8949],
-	38033: _ErrCode_name[8949:8973],
-	38034: _ErrCode_name[8973:8993],
-	38035: _ErrCode_name[8993:9015],
-	38036: _ErrCode_name[9015:9036],
-	38037: _ErrCode_name[9036:9064],
-	38038: _ErrCode_name[9064:9088],
-	38039: _ErrCode_name[9088:9106],
-	38040: _ErrCode_name[9106:9129],
-	38041: _ErrCode_name[9129:9151],
-	38042: _ErrCode_name[9151:9178],
-	38043: _ErrCode_name[9178:9211],
-	38044: _ErrCode_name[9211:9234],
-	38045: _ErrCode_name[9234:9261],
-	38046: _ErrCode_name[9261:9286],
-	38047: _ErrCode_name[9286:9310],
-	38048: _ErrCode_name[9310:9334],
-	38049: _ErrCode_name[9334:9358],
-	38050: _ErrCode_name[9358:9389],
-	38051: _ErrCode_name[9389:9412],
-	38052: _ErrCode_name[9412:9431],
-	38053: _ErrCode_name[9431:9457],
-	38054: _ErrCode_name[9457:9494],
-	38055: _ErrCode_name[9494:9533],
-	38056: _ErrCode_name[9533:9571],
-	38057: _ErrCode_name[9571:9593],
-	38058: _ErrCode_name[9593:9608],
-	40001: _ErrCode_name[9608:9626],
-	40002: _ErrCode_name[9626:9643],
-	40003: _ErrCode_name[9643:9669],
-	40004: _ErrCode_name[9669:9696],
-	40005: _ErrCode_name[9696:9714],
-	40006: _ErrCode_name[9714:9735],
-	40007: _ErrCode_name[9735:9756],
-	40008: _ErrCode_name[9756:9777],
-	40009: _ErrCode_name[9777:9800],
-	40010: _ErrCode_name[9800:9823],
-	40011: _ErrCode_name[9823:9844],
-	40012: _ErrCode_name[9844:9869],
-	40013: _ErrCode_name[9869:9890],
-	40014: _ErrCode_name[9890:9914],
-	40015: _ErrCode_name[9914:9939],
-	40016: _ErrCode_name[9939:9960],
-	40017: _ErrCode_name[9960:9979],
-	40018: _ErrCode_name[9979:10003],
-	40019: _ErrCode_name[10003:10026],
-	40020: _ErrCode_name[10026:10046],
-	40021: _ErrCode_name[10046:10063],
-	40022: _ErrCode_name[10063:10080],
-	40023: _ErrCode_name[10080:10101],
-	40024: _ErrCode_name[10101:10127],
-	40025: _ErrCode_name[10127:10153],
-	40026: _ErrCode_name[10153:10176],
-	40027: _ErrCode_name[10176:10197],
-	40028: _ErrCode_name[10197:10217],
-	40029: _ErrCode_name[10217:10240],
-	40030: _ErrCode_name[10240:10263],
-	40031: _ErrCode_name[10263:10284],
-	40032: _ErrCode_name[10284:10305],
-	40033: _ErrCode_name[10305:10325],
-	40034: _ErrCode_name[10325:10347],
-	40035: _ErrCode_name[10347:10372],
-	40036: _ErrCode_name[10372:10397],
-	40037: _ErrCode_name[10397:10414],
-	40038: _ErrCode_name[10414:10433],
-	40039: _ErrCode_name[10433:10457],
-	40040: _ErrCode_name[10457:10482],
-	40041: _ErrCode_name[10482:10500],
-	40042: _ErrCode_name[10500:10523],
-	40043: _ErrCode_name[10523:10545],
-	40044: _ErrCode_name[10545:10569],
-	40045: _ErrCode_name[10569:10591],
-	40046: _ErrCode_name[10591:10612],
-	40047: _ErrCode_name[10612:10634],
-	40048: _ErrCode_name[10634:10652],
-	40049: _ErrCode_name[10652:10671],
-	40050: _ErrCode_name[10671:10692],
-	40051: _ErrCode_name[10692:10712],
-	40052: _ErrCode_name[10712:10733],
-	40053: _ErrCode_name[10733:10755],
-	40054: _ErrCode_name[10755:10776],
-	40055: _ErrCode_name[10776:10795],
-	40056: _ErrCode_name[10795:10817],
-	40057: _ErrCode_name[10817:10837],
-	40058: _ErrCode_name[10837:10858],
-	40059: _ErrCode_name[10858:10884],
-	40060: _ErrCode_name[10884:10902],
-	40061: _ErrCode_name[10902:10927],
-	40062: _ErrCode_name[10927:10950],
-	40063: _ErrCode_name[10950:10974],
-	40064: _ErrCode_name[10974:10999],
-	40065: _ErrCode_name[10999:11022],
-	40066: _ErrCode_name[11022:11042],
-	40067: _ErrCode_name[11042:11071],
-	40068: _ErrCode_name[11071:11091],
-	40069: _ErrCode_name[11091:11113],
-	40070: _ErrCode_name[11113:11126],
-	40071: _ErrCode_name[11126:11146],
-	40072: _ErrCode_name[11146:11166],
-	40073: _ErrCode_name[11166:11202],
-	40074: _ErrCode_name[11202:11237],
-	40075: _ErrCode_name[11237:11260],
-	40076: _ErrCode_name[11260:11283],
-	40077: _ErrCode_name[11283:11306],
-	40078: _ErrCode_name[11306:11332],
-	40079: _ErrCode_name[11332:11357],
-	40080: _ErrCode_name[11357:11381],
-	40081: _ErrCode_name[11381:11406],
-	40082: _ErrCode_name[11406:11430],
-	40083: _ErrCode_name[11430:11448],
-	42001: _ErrCode_name[11448:11466],
-	42002: _ErrCode_name[11466:11491],
-	42003: _ErrCode_name[11491:11514],
-	42004: _ErrCode_name[11514:11538],
-	42005: _ErrCode_name[11538:11562],
-	42006: _ErrCode_name[11562:11581],
-	42007: _ErrCode_name[11581:11601],
-	42008: _ErrCode_name[11601:11625],
-	42009: _ErrCode_name[11625:11648],
-	42010: _ErrCode_name[11648:11666],
-	42501: _ErrCode_name[11666:11684],
-	42502: _ErrCode_name[11684:11697],
-	42503: _ErrCode_name[11697:11712],
-	42504: _ErrCode_name[11712:11732],
-	42505: _ErrCode_name[11732:11747],
-	43001: _ErrCode_name[11747:11773],
-	43002: _ErrCode_name[11773:11793],
-	43003: _ErrCode_name[11793:11810],
-	43004: _ErrCode_name[11810:11834],
-	43005: _ErrCode_name[11834:11857],
-	43006: _ErrCode_name[11857:11874],
-	43007: _ErrCode_name[11874:11888],
-	43008: _ErrCode_name[11888:11911],
-	44001: _ErrCode_name[11911:11935],
-	44002: _ErrCode_name[11935:11966],
-	44003: _ErrCode_name[11966:11996],
-	44004: _ErrCode_name[11996:12024],
-	44005: _ErrCode_name[12024:12051],
-	44006: _ErrCode_name[12051:12077],
-	44007: _ErrCode_name[12077:12116],
-	44008: _ErrCode_name[12116:12155],
-	44009: _ErrCode_name[12155:12190],
-	44010: _ErrCode_name[12190:12218],
-	44011: _ErrCode_name[12218:12246],
-	44012: _ErrCode_name[12246:12263],
-	44013: _ErrCode_name[12263:12287],
-	44014: _ErrCode_name[12287:12313],
-	44015: _ErrCode_name[12313:12342],
-	44016: _ErrCode_name[12342:12381],
-	44017: _ErrCode_name[12381:12420],
-	44018: _ErrCode_name[12420:12458],
-	44019: _ErrCode_name[12458:12507],
-	44020: _ErrCode_name[12507:12528],
-	46001: _ErrCode_name[12528:12547],
-	46002: _ErrCode_name[12547:12563],
-	46003: _ErrCode_name[12563:12583],
-	46004: _ErrCode_name[12583:12606],
-	46005: _ErrCode_name[12606:12627],
-	46006: _ErrCode_name[12627:12654],
-	46007: _ErrCode_name[12654:12677],
-	46008: _ErrCode_name[12677:12703],
-	46009: _ErrCode_name[12703:12726],
-	46010: _ErrCode_name[12726:12752],
-	46011: _ErrCode_name[12752:12784],
-	46012: _ErrCode_name[12784:12817],
-	46013: _ErrCode_name[12817:12835],
-	46014: _ErrCode_name[12835:12856],
-	46015: _ErrCode_name[12856:12890],
-	46016: _ErrCode_name[12890:12920],
-	46017: _ErrCode_name[12920:12952],
-	46018: _ErrCode_name[12952:12973],
-	46019: _ErrCode_name[12973:13010],
-	46020: _ErrCode_name[13010:13035],
-	46021: _ErrCode_name[13035:13061],
-	46022: _ErrCode_name[13061:13092],
-	46023: _ErrCode_name[13092:13119],
-	46024: _ErrCode_name[13119:13138],
-	46025: _ErrCode_name[13138:13162],
-	46026: _ErrCode_name[13162:13187],
-	46027: _ErrCode_name[13187:13221],
-	46028: _ErrCode_name[13221:13251],
-	46029: _ErrCode_name[13251:13280],
-	46030: _ErrCode_name[13280:13306],
-	46031: _ErrCode_name[13306:13331],
-	46032: _ErrCode_name[13331:13366],
-	46033: _ErrCode_name[13366:13388],
-	46034: _ErrCode_name[13388:13412],
-	46035: _ErrCode_name[13412:13437],
-	48001: _ErrCode_name[13437:13454],
-	48002: _ErrCode_name[13454:13470],
-	48003: _ErrCode_name[13470:13483],
-	49001: _ErrCode_name[13483:13496],
-	49002: _ErrCode_name[13496:13521],
-	50000: _ErrCode_name[13521:13527],
+	20067: _ErrCode_name[4272:4298],
+	20068: _ErrCode_name[4298:4323],
+	22001: _ErrCode_name[4323:4344],
+	22002: _ErrCode_name[4344:4365],
+	22003: _ErrCode_name[4365:4386],
+	24001: _ErrCode_name[4386:4411],
+	24002: _ErrCode_name[4411:4435],
+	24003: _ErrCode_name[4435:4461],
+	24004: _ErrCode_name[4461:4487],
+	24005: _ErrCode_name[4487:4516],
+	24006: _ErrCode_name[4516:4545],
+	26001: _ErrCode_name[4545:4567],
+	26002: _ErrCode_name[4567:4588],
+	26003: _ErrCode_name[4588:4611],
+	26004: _ErrCode_name[4611:4636],
+	26005: _ErrCode_name[4636:4660],
+	26006: _ErrCode_name[4660:4678],
+	26007: _ErrCode_name[4678:4693],
+	28001: _ErrCode_name[4693:4712],
+	28002: _ErrCode_name[4712:4732],
+	28003: _ErrCode_name[4732:4759],
+	28004: _ErrCode_name[4759:4782],
+	28005: _ErrCode_name[4782:4805],
+	30001: _ErrCode_name[4805:4828],
+	30002: _ErrCode_name[4828:4855],
+	30003: _ErrCode_name[4855:4872],
+	30004: _ErrCode_name[4872:4895],
+	30005: _ErrCode_name[4895:4913],
+	30006: _ErrCode_name[4913:4932],
+	30007: _ErrCode_name[4932:4952],
+	30008: _ErrCode_name[4952:4972],
+	30009: _ErrCode_name[4972:4994],
+	30010: _ErrCode_name[4994:5021],
+	30011: _ErrCode_name[5021:5041],
+	30012: _ErrCode_name[5041:5064],
+	30013: _ErrCode_name[5064:5085],
+	30014: _ErrCode_name[5085:5112],
+	30015: _ErrCode_name[5112:5134],
+	30016: _ErrCode_name[5134:5156],
+	30017: _ErrCode_name[5156:5183],
+	30018: _ErrCode_name[5183:5203],
+	30019: _ErrCode_name[5203:5223],
+	30020: _ErrCode_name[5223:5248],
+	30021: _ErrCode_name[5248:5279],
+	30022: _ErrCode_name[5279:5304],
+	30023: _ErrCode_name[5304:5326],
+	30024: _ErrCode_name[5326:5356],
+	30025: _ErrCode_name[5356:5378],
+	30026: _ErrCode_name[5378:5409],
+	30027: _ErrCode_name[5409:5439],
+	30028: _ErrCode_name[5439:5471],
+	30029: _ErrCode_name[5471:5497],
+	30030: _ErrCode_name[5497:5512],
+	30031: _ErrCode_name[5512:5543],
+	30032: _ErrCode_name[5543:5576],
+	30033: _ErrCode_name[5576:5586],
+	30034: _ErrCode_name[5586:5611],
+	30035: _ErrCode_name[5611:5637],
+	30036: _ErrCode_name[5637:5664],
+	30037: _ErrCode_name[5664:5685],
+	30038: _ErrCode_name[5685:5706],
+	30039: _ErrCode_name[5706:5731],
+	30040: _ErrCode_name[5731:5752],
+	30041: _ErrCode_name[5752:5771],
+	30042: _ErrCode_name[5771:5793],
+	30043: _ErrCode_name[5793:5814],
+	30044: _ErrCode_name[5814:5846],
+	30045: _ErrCode_name[5846:5865],
+	32001: _ErrCode_name[5865:5880],
+	32002: _ErrCode_name[5880:5902],
+	32003: _ErrCode_name[5902:5919],
+	32004: _ErrCode_name[5919:5937],
+	34001: _ErrCode_name[5937:5961],
+	34002: _ErrCode_name[5961:5986],
+	34003: _ErrCode_name[5986:6010],
+	34004: _ErrCode_name[6010:6033],
+	34005: _ErrCode_name[6033:6055],
+	34006: _ErrCode_name[6055:6077],
+	34007: _ErrCode_name[6077:6099],
+	34008: _ErrCode_name[6099:6126],
+	34009: _ErrCode_name[6126:6150],
+	34010: _ErrCode_name[6150:6172],
+	34011: _ErrCode_name[6172:6196],
+	34012: _ErrCode_name[6196:6212],
+	34013: _ErrCode_name[6212:6231],
+	34014: _ErrCode_name[6231:6254],
+	34015: _ErrCode_name[6254:6280],
+	34016: _ErrCode_name[6280:6297],
+	34017: _ErrCode_name[6297:6319],
+	34018: _ErrCode_name[6319:6341],
+	34019: _ErrCode_name[6341:6361],
+	34020: _ErrCode_name[6361:6380],
+	34021: _ErrCode_name[6380:6401],
+	36001: _ErrCode_name[6401:6416],
+	36002: _ErrCode_name[6416:6440],
+	36003: _ErrCode_name[6440:6462],
+	36004: _ErrCode_name[6462:6485],
+	36005: _ErrCode_name[6485:6511],
+	36006: _ErrCode_name[6511:6544],
+	36007: _ErrCode_name[6544:6568],
+	36008: _ErrCode_name[6568:6592],
+	36009: _ErrCode_name[6592:6620],
+	36010: _ErrCode_name[6620:6641],
+	36011: _ErrCode_name[6641:6670],
+	36012: _ErrCode_name[6670:6694],
+	36013: _ErrCode_name[6694:6719],
+	36014: _ErrCode_name[6719:6744],
+	36015: _ErrCode_name[6744:6771],
+	36016: _ErrCode_name[6771:6800],
+	36017: _ErrCode_name[6800:6819],
+	36018: _ErrCode_name[6819:6842],
+	36019: _ErrCode_name[6842:6874],
+	36020: _ErrCode_name[6874:6895],
+	36021: _ErrCode_name[6895:6920],
+	36022: _ErrCode_name[6920:6948],
+	36023: _ErrCode_name[6948:6971],
+	36024: _ErrCode_name[6971:7003],
+	36025: _ErrCode_name[7003:7032],
+	36026: _ErrCode_name[7032:7056],
+	36027: _ErrCode_name[7056:7083],
+	36028: _ErrCode_name[7083:7115],
+	36029: _ErrCode_name[7115:7147],
+	36030: _ErrCode_name[7147:7177],
+	36031: _ErrCode_name[7177:7201],
+	36032: _ErrCode_name[7201:7227],
+	36033: _ErrCode_name[7227:7252],
+	36034: _ErrCode_name[7252:7278],
+	36035: _ErrCode_name[7278:7308],
+	36036: _ErrCode_name[7308:7339],
+	36037: _ErrCode_name[7339:7372],
+	36038: _ErrCode_name[7372:7405],
+	36039: _ErrCode_name[7405:7435],
+	36040: _ErrCode_name[7435:7470],
+	36041: _ErrCode_name[7470:7504],
+	36042: _ErrCode_name[7504:7534],
+	36043: _ErrCode_name[7534:7568],
+	36044: _ErrCode_name[7568:7601],
+	36045: _ErrCode_name[7601:7637],
+	36046: _ErrCode_name[7637:7671],
+	36047: _ErrCode_name[7671:7698],
+	36048: _ErrCode_name[7698:7729],
+	36049: _ErrCode_name[7729:7756],
+	36050: _ErrCode_name[7756:7786],
+	36051: _ErrCode_name[7786:7814],
+	36052: _ErrCode_name[7814:7845],
+	36053: _ErrCode_name[7845:7877],
+	36054: _ErrCode_name[7877:7901],
+	36055: _ErrCode_name[7901:7930],
+	36056: _ErrCode_name[7930:7960],
+	36057: _ErrCode_name[7960:7992],
+	36058: _ErrCode_name[7992:8024],
+	36059: _ErrCode_name[8024:8055],
+	36060: _ErrCode_name[8055:8074],
+	36061: _ErrCode_name[8074:8099],
+	36062: _ErrCode_name[8099:8121],
+	36063: _ErrCode_name[8121:8136],
+	36064: _ErrCode_name[8136:8147],
+	36065: _ErrCode_name[8147:8169],
+	36066: _ErrCode_name[8169:8188],
+	36067: _ErrCode_name[8188:8202],
+	36068: _ErrCode_name[8202:8223],
+	36069: _ErrCode_name[8223:8237],
+	36070: _ErrCode_name[8237:8266],
+	36071: _ErrCode_name[8266:8297],
+	38001: _ErrCode_name[8297:8318],
+	38002: _ErrCode_name[8318:8339],
+	38003: _ErrCode_name[8339:8365],
+	38004: _ErrCode_name[8365:8385],
+	38005: _ErrCode_name[8385:8410],
+	38006: _ErrCode_name[8410:8431],
+	38007: _ErrCode_name[8431:8455],
+	38008: _ErrCode_name[8455:8477],
+	38009: _ErrCode_name[8477:8501],
+	38010: _ErrCode_name[8501:8525],
+	38011: _ErrCode_name[8525:8548],
+	38012: _ErrCode_name[8548:8571],
+	38013: _ErrCode_name[8571:8596],
+	38014: _ErrCode_name[8596:8620],
+	38015: _ErrCode_name[8620:8645],
+	38016: _ErrCode_name[8645:8666],
+	38017: _ErrCode_name[8666:8684],
+	38018: _ErrCode_name[8684:8701],
+	38019: _ErrCode_name[8701:8719],
+	38020: _ErrCode_name[8719:8740],
+	38021: _ErrCode_name[8740:8763],
+	38022: _ErrCode_name[8763:8786],
+	38023: _ErrCode_name[8786:8808],
+	38024: _ErrCode_name[8808:8826],
+	38025: _ErrCode_name[8826:8853],
+	38026: _ErrCode_name[8853:8877],
+	38027: _ErrCode_name[8877:8904],
+	38028: _ErrCode_name[8904:8929],
+	38029: _ErrCode_name[8929:8954],
+	38030: _ErrCode_name[8954:8977],
+	38031: _ErrCode_name[8977:8995],
+	38032: _ErrCode_name[8995:9019],
+	38033: _ErrCode_name[9019:9043],
+	38034: _ErrCode_name[9043:9063],
+	38035: _ErrCode_name[9063:9085],
+	38036: _ErrCode_name[9085:9106],
+	38037: _ErrCode_name[9106:9134],
+	38038: _ErrCode_name[9134:9158],
+	38039: _ErrCode_name[9158:9176],
+	38040: _ErrCode_name[9176:9199],
+	38041: _ErrCode_name[9199:9221],
+	38042: _ErrCode_name[9221:9248],
+	38043: _ErrCode_name[9248:9281],
+	38044: _ErrCode_name[9281:9304],
+	38045: _ErrCode_name[9304:9331],
+	38046: _ErrCode_name[9331:9356],
+	38047: _ErrCode_name[9356:9380],
+	38048: _ErrCode_name[9380:9404],
+	38049: _ErrCode_name[9404:9428],
+	38050: _ErrCode_name[9428:9459],
+	38051: _ErrCode_name[9459:9482],
+	38052: _ErrCode_name[9482:9501],
+	38053: _ErrCode_name[9501:9527],
+	38054: _ErrCode_name[9527:9564],
+	38055: _ErrCode_name[9564:9603],
+	38056: _ErrCode_name[9603:9641],
+	38057: _ErrCode_name[9641:9663],
+	38058: _ErrCode_name[9663:9678],
+	40001: _ErrCode_name[9678:9696],
+	40002: _ErrCode_name[9696:9713],
+	40003: _ErrCode_name[9713:9739],
+	40004: _ErrCode_name[9739:9766],
+	40005: _ErrCode_name[9766:9784],
+	40006: _ErrCode_name[9784:9805],
+	40007: _ErrCode_name[9805:9826],
+	40008: _ErrCode_name[9826:9847],
+	40009: _ErrCode_name[9847:9870],
+	40010: _ErrCode_name[9870:9893],
+	40011: _ErrCode_name[9893:9914],
+	40012: _ErrCode_name[9914:9939],
+	40013: _ErrCode_name[9939:9960],
+	40014: _ErrCode_name[9960:9984],
+	40015: _ErrCode_name[9984:10009],
+	40016: _ErrCode_name[10009:10030],
+	40017: _ErrCode_name[10030:10049],
+	40018: _ErrCode_name[10049:10073],
+	40019: _ErrCode_name[10073:10096],
+	40020: _ErrCode_name[10096:10116],
+	40021: _ErrCode_name[10116:10133],
+	40022: _ErrCode_name[10133:10150],
+	40023: _ErrCode_name[10150:10171],
+	40024: _ErrCode_name[10171:10197],
+	40025: _ErrCode_name[10197:10223],
+	40026: _ErrCode_name[10223:10246],
+	40027: _ErrCode_name[10246:10267],
+	40028: _ErrCode_name[10267:10287],
+	40029: _ErrCode_name[10287:10310],
+	40030: _ErrCode_name[10310:10333],
+	40031: _ErrCode_name[10333:10354],
+	40032: _ErrCode_name[10354:10375],
+	40033: _ErrCode_name[10375:10395],
+	40034: _ErrCode_name[10395:10417],
+	40035: _ErrCode_name[10417:10442],
+	40036: _ErrCode_name[10442:10467],
+	40037: _ErrCode_name[10467:10484],
+	40038: _ErrCode_name[10484:10503],
+	40039: _ErrCode_name[10503:10527],
+	40040: _ErrCode_name[10527:10552],
+	40041: _ErrCode_name[10552:10570],
+	40042: _ErrCode_name[10570:10593],
+	40043: _ErrCode_name[10593:10615],
+	40044: _ErrCode_name[10615:10639],
+	40045: _ErrCode_name[10639:10661],
+	40046: _ErrCode_name[10661:10682],
+	40047: _ErrCode_name[10682:10704],
+	40048: _ErrCode_name[10704:10722],
+	40049: _ErrCode_name[10722:10741],
+	40050: _ErrCode_name[10741:10762],
+	40051: _ErrCode_name[10762:10782],
+	40052: _ErrCode_name[10782:10803],
+	40053: _ErrCode_name[10803:10825],
+	40054: _ErrCode_name[10825:10846],
+	40055: _ErrCode_name[10846:10865],
+	40056: _ErrCode_name[10865:10887],
+	40057: _ErrCode_name[10887:10907],
+	40058: _ErrCode_name[10907:10928],
+	40059: _ErrCode_name[10928:10954],
+	40060: _ErrCode_name[10954:10972],
+	40061: _ErrCode_name[10972:10997],
+	40062: _ErrCode_name[10997:11020],
+	40063: _ErrCode_name[11020:11044],
+	40064: _ErrCode_name[11044:11069],
+	40065: _ErrCode_name[11069:11092],
+	40066: _ErrCode_name[11092:11112],
+	40067: _ErrCode_name[11112:11141],
+	40068: _ErrCode_name[11141:11161],
+	40069: _ErrCode_name[11161:11183],
+	40070: _ErrCode_name[11183:11196],
+	40071: _ErrCode_name[11196:11216],
+	40072: _ErrCode_name[11216:11236],
+	40073: _ErrCode_name[11236:11272],
+	40074: _ErrCode_name[11272:11307],
+	40075: _ErrCode_name[11307:11330],
+	40076: _ErrCode_name[11330:11353],
+	40077: _ErrCode_name[11353:11376],
+	40078: _ErrCode_name[11376:11402],
+	40079: _ErrCode_name[11402:11427],
+	40080: _ErrCode_name[11427:11451],
+	40081: _ErrCode_name[11451:11476],
+	40082: _ErrCode_name[11476:11500],
+	40083: _ErrCode_name[11500:11518],
+	42001: _ErrCode_name[11518:11536],
+	42002: _ErrCode_name[11536:11561],
+	42003: _ErrCode_name[11561:11584],
+	42004: _ErrCode_name[11584:11608],
+	42005: _ErrCode_name[11608:11632],
+	42006: _ErrCode_name[11632:11651],
+	42007: _ErrCode_name[11651:11671],
+	42008: _ErrCode_name[11671:11695],
+	42009: _ErrCode_name[11695:11718],
+	42010: _ErrCode_name[11718:11736],
+	42501: _ErrCode_name[11736:11754],
+	42502: _ErrCode_name[11754:11767],
+	42503: _ErrCode_name[11767:11782],
+	42504: _ErrCode_name[11782:11802],
+	42505: _ErrCode_name[11802:11817],
+	43001: _ErrCode_name[11817:11843],
+	43002: _ErrCode_name[11843:11863],
+	43003: _ErrCode_name[11863:11880],
+	43004: _ErrCode_name[11880:11904],
+	43005: _ErrCode_name[11904:11927],
+	43006: _ErrCode_name[11927:11944],
+	43007: _ErrCode_name[11944:11958],
+	43008: _ErrCode_name[11958:11981],
+	44001: _ErrCode_name[11981:12005],
+	44002: _ErrCode_name[12005:12036],
+	44003: _ErrCode_name[12036:12066],
+	44004: _ErrCode_name[12066:12094],
+	44005: _ErrCode_name[12094:12121],
+	44006: _ErrCode_name[12121:12147],
+	44007: _ErrCode_name[12147:12186],
+	44008: _ErrCode_name[12186:12225],
+	44009: _ErrCode_name[12225:12260],
+	44010: _ErrCode_name[12260:12288],
+	44011: _ErrCode_name[12288:12316],
+	44012: _ErrCode_name[12316:12333],
+	44013: _ErrCode_name[12333:12357],
+	44014: _ErrCode_name[12357:12383],
+	44015: _ErrCode_name[12383:12412],
+	44016: _ErrCode_name[12412:12451],
+	44017: _ErrCode_name[12451:12490],
+	44018: _ErrCode_name[12490:12528],
+	44019: _ErrCode_name[12528:12577],
+	44020: _ErrCode_name[12577:12598],
+	46001: _ErrCode_name[12598:12617],
+	46002: _ErrCode_name[12617:12633],
+	46003: _ErrCode_name[12633:12653],
+	46004: _ErrCode_name[12653:12676],
+	46005: _ErrCode_name[12676:12697],
+	46006: _ErrCode_name[12697:12724],
+	46007: _ErrCode_name[12724:12747],
+	46008: _ErrCode_name[12747:12773],
+	46009: _ErrCode_name[12773:12796],
+	46010: _ErrCode_name[12796:12822],
+	46011: _ErrCode_name[12822:12854],
+	46012: _ErrCode_name[12854:12887],
+	46013: _ErrCode_name[12887:12905],
+	46014: _ErrCode_name[12905:12926],
+	46015: _ErrCode_name[12926:12960],
+	46016: _ErrCode_name[12960:12990],
+	46017: _ErrCode_name[12990:13022],
+	46018: _ErrCode_name[13022:13043],
+	46019: _ErrCode_name[13043:13080],
+	46020: _ErrCode_name[13080:13105],
+	46021: _ErrCode_name[13105:13131],
+	46022: _ErrCode_name[13131:13162],
+	46023: _ErrCode_name[13162:13189],
+	46024: _ErrCode_name[13189:13208],
+	46025: _ErrCode_name[13208:13232],
+	46026: _ErrCode_name[13232:13257],
+	46027: _ErrCode_name[13257:13291],
+	46028: _ErrCode_name[13291:13321],
+	46029: _ErrCode_name[13321:13350],
+	46030: _ErrCode_name[13350:13376],
+	46031: _ErrCode_name[13376:13401],
+	46032: _ErrCode_name[13401:13436],
+	46033: _ErrCode_name[13436:13458],
+	46034: _ErrCode_name[13458:13482],
+	46035: _ErrCode_name[13482:13507],
+	48001: _ErrCode_name[13507:13524],
+	48002: _ErrCode_name[13524:13540],
+	48003: _ErrCode_name[13540:13553],
+	49001: _ErrCode_name[13553:13566],
+	49002: _ErrCode_name[13566:13591],
+	50000: _ErrCode_name[13591:13597],
 }
 
 func (i ErrCode) String() string {
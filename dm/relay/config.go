@@ -40,6 +40,10 @@ type Config struct {
 
 	// for binlog reader retry
 	ReaderRetry ReaderRetryConfig `toml:"reader-retry" json:"reader-retry"`
+
+	// external storage to archive relay log files, and size (GB) of archived files cached in RelayDir
+	StorageURL       string `toml:"storage-url" json:"storage-url"`
+	StorageCacheSize int64  `toml:"storage-cache-size" json:"storage-cache-size"`
 }
 
 func (c *Config) String() string {
@@ -70,6 +74,8 @@ func FromSourceCfg(sourceCfg *config.SourceConfig) *Config {
 			BackoffJitter:   clone.Checker.BackoffJitter,
 			BackoffFactor:   clone.Checker.BackoffFactor,
 		},
+		StorageURL:       clone.RelayStorage.URL,
+		StorageCacheSize: clone.RelayStorage.CacheSize,
 	}
 	return cfg
 }
@@ -74,6 +74,8 @@ type BinlogReader struct {
 	currentSubDir string // current UUID(with suffix)
 
 	lastFileGracefulEnd bool
+
+	storage *relayStorage // nil if relay log files are not archived
 }
 
 // newBinlogReader creates a new BinlogReader.
@@ -111,6 +113,9 @@ func (r *BinlogReader) checkRelayPos(pos mysql.Position) error {
 		return terror.Annotatef(err, "parse relay dir with pos %s", pos)
 	}
 	pos = realPos
+	if err = r.fetchRelayLogFile(currentSubDir, pos.Name); err != nil {
+		return err
+	}
 	relayFilepath := path.Join(r.cfg.RelayDir, currentSubDir, pos.Name)
 	r.tctx.L().Info("start to check relay log file", zap.String("path", relayFilepath), zap.Stringer("position", pos))
 	fi, err := os.Stat(relayFilepath)
@@ -180,7 +185,7 @@ func (r *BinlogReader) getPosByGTID(gset mysql.GTIDSet) (*mysql.Position, error)
 		}
 
 		dir := path.Join(r.cfg.RelayDir, subDir)
-		allFiles, err := CollectAllBinlogFiles(dir)
+		allFiles, err := r.collectAllBinlogFiles(subDir)
 		if err != nil {
 			return nil, err
 		}
@@ -188,6 +193,9 @@ func (r *BinlogReader) getPosByGTID(gset mysql.GTIDSet) (*mysql.Position, error)
 		// iterate files from the newest one
 		for i := len(allFiles) - 1; i >= 0; i-- {
 			file := allFiles[i]
+			if err = r.fetchRelayLogFile(subDir, file); err != nil {
+				return nil, err
+			}
 			filePath := path.Join(dir, file)
 			// if input `gset` not contain previous_gtids_event's gset (complementary set of `gset` overlap with
 			// previous_gtids_event), that means there're some needed events in previous files.
@@ -366,6 +374,18 @@ func (r *BinlogReader) getSwitchPath() (*SwitchPath, error) {
 		return nil, nil
 	}
 
+	if r.storage != nil {
+		// the first binlog file may be evicted from the relay dir
+		files, err2 := r.collectAllBinlogFiles(nextSubDir)
+		if err2 != nil {
+			return nil, err2
+		}
+		if len(files) == 0 {
+			return nil, nil
+		}
+		return &SwitchPath{nextSubDir, files[0]}, nil
+	}
+
 	// try to get the first binlog file in next subdirectory
 	nextBinlogName, err := getFirstBinlogName(r.cfg.RelayDir, nextSubDir)
 	if err != nil {
@@ -391,7 +411,7 @@ func (r *BinlogReader) parseDirAsPossible(ctx context.Context, s *LocalStreamer,
 			return false, ctx.Err()
 		default:
 		}
-		files, err := CollectBinlogFilesCmp(dir, pos.Name, FileCmpBiggerEqual)
+		files, err := r.collectBinlogFilesFrom(r.currentSubDir, pos.Name)
 		if err != nil {
 			return false, terror.Annotatef(err, "parse relay dir %s with pos %s", dir, pos)
 		} else if len(files) == 0 {
@@ -461,6 +481,9 @@ type binlogFileParseState struct {
 func (r *BinlogReader) parseFileAsPossible(ctx context.Context, s *LocalStreamer, relayLogFile string, offset int64, relayLogDir string, firstParse bool, possibleLast bool) (bool, int64, error) {
 	r.tctx.L().Debug("start to parse relay log file", zap.String("file", relayLogFile), zap.Int64("position", offset), zap.String("directory", relayLogDir))
 
+	if err := r.fetchRelayLogFile(r.currentSubDir, relayLogFile); err != nil {
+		return false, 0, err
+	}
 	fullPath := filepath.Join(relayLogDir, relayLogFile)
 	f, err := os.Open(fullPath)
 	if err != nil {
@@ -765,6 +788,49 @@ func (r *BinlogReader) parseFormatDescEvent(state *binlogFileParseState) error {
 	return nil
 }
 
+// fetchRelayLogFile downloads the relay log file to the relay dir if it's archived and evicted.
+func (r *BinlogReader) fetchRelayLogFile(subDir, filename string) error {
+	if r.storage == nil {
+		return nil
+	}
+	return r.storage.fetch(r.tctx.Context(), subDir, filename)
+}
+
+// collectAllBinlogFiles collects all relay log files in the subdirectory, including archived ones.
+func (r *BinlogReader) collectAllBinlogFiles(subDir string) ([]string, error) {
+	files, err := CollectAllBinlogFiles(path.Join(r.cfg.RelayDir, subDir))
+	if err != nil || r.storage == nil {
+		return files, err
+	}
+	return mergeBinlogFiles(files, r.storage.archivedFiles(subDir)), nil
+}
+
+// collectBinlogFilesFrom collects relay log files not older than baseFile in the subdirectory,
+// including archived ones.
+func (r *BinlogReader) collectBinlogFilesFrom(subDir, baseFile string) ([]string, error) {
+	dir := path.Join(r.cfg.RelayDir, subDir)
+	if err := r.fetchRelayLogFile(subDir, baseFile); err != nil {
+		return nil, err
+	}
+	files, err := CollectBinlogFilesCmp(dir, baseFile, FileCmpBiggerEqual)
+	if err != nil || r.storage == nil {
+		return files, err
+	}
+
+	bf, err := utils.ParseFilename(baseFile)
+	if err != nil {
+		return nil, terror.Annotatef(err, "filename %s", baseFile)
+	}
+	var archived []string
+	for _, f := range r.storage.archivedFiles(subDir) {
+		parsed, err2 := utils.ParseFilename(f)
+		if err2 == nil && parsed.BaseName == bf.BaseName && parsed.GreaterThanOrEqualTo(bf) {
+			archived = append(archived, f)
+		}
+	}
+	return mergeBinlogFiles(files, archived), nil
+}
+
 // updateSubDirs re-parses UUID index file and updates subdirectory list.
 func (r *BinlogReader) updateSubDirs() error {
 	subDirs, err := utils.ParseUUIDIndex(r.indexPath)
@@ -131,6 +131,8 @@ type Relay struct {
 
 	writer    Writer
 	listeners map[Listener]struct{} // make it a set to make it easier to remove listener
+
+	storage *relayStorage // nil if relay log files are not archived
 }
 
 // NewRealRelay creates an instance of Relay.
@@ -148,7 +150,11 @@ func NewRealRelay(cfg *Config) Process {
 // Init implements the dm.Unit interface.
 // NOTE when Init encounters an error, it will make DM-worker exit when it boots up and assigned relay.
 func (r *Relay) Init(ctx context.Context) (err error) {
-	return reportRelayLogSpaceInBackground(ctx, r.cfg.RelayDir)
+	if err = reportRelayLogSpaceInBackground(ctx, r.cfg.RelayDir); err != nil {
+		return err
+	}
+	r.storage, err = newRelayStorage(ctx, r.logger, r.cfg)
+	return err
 }
 
 // Process implements the dm.Unit interface.
@@ -310,6 +316,9 @@ func (r *Relay) process(ctx context.Context) error {
 	}
 
 	go r.doIntervalOps(ctx)
+	if r.storage != nil {
+		go r.archiveRelayLogs(ctx)
+	}
 
 	// handles binlog events with retry mechanism.
 	// it only do the retry for some binlog reader error now.
@@ -357,6 +366,11 @@ func (r *Relay) PurgeRelayDir() error {
 			return err
 		}
 	}
+	if r.storage != nil {
+		if err = r.storage.clear(context.Background()); err != nil {
+			return err
+		}
+	}
 	r.logger.Info("relay dir is purged to be ready for new relay log", zap.String("relayDir", dir))
 	return nil
 }
@@ -915,6 +929,26 @@ func (r *Relay) doIntervalOps(ctx context.Context) {
 	}
 }
 
+// archiveRelayLogs archives completed relay log files to the external storage periodically.
+func (r *Relay) archiveRelayLogs(ctx context.Context) {
+	ticker := time.NewTicker(archiveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if r.closed.Load() {
+				return
+			}
+			if err := r.storage.archive(ctx, r.IsActive); err != nil {
+				r.logger.Warn("archive relay log files", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // setUpReader setups the underlying reader used to read binlog events from the upstream master server.
 func (r *Relay) setUpReader(ctx context.Context) (Reader, error) {
 	ctx2, cancel := context.WithTimeout(ctx, conn.DefaultDBTimeout)
@@ -1216,7 +1250,9 @@ func (r *Relay) notify(e *replication.BinlogEvent) {
 }
 
 func (r *Relay) NewReader(logger log.Logger, cfg *BinlogReaderConfig) *BinlogReader {
-	return newBinlogReader(logger, cfg, r)
+	reader := newBinlogReader(logger, cfg, r)
+	reader.storage = r.storage
+	return reader
 }
 
 // RegisterListener implements Process.RegisterListener.
@@ -0,0 +1,369 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	bstorage "github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	dmstorage "github.com/pingcap/tiflow/dm/pkg/storage"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	archiveInterval = 1 * time.Minute
+	// archive files by chunks, so large relay log files needn't be read into memory.
+	archiveChunkSize = 8 * 1024 * 1024
+	// the default size (GB) of archived relay log files cached in relay dir.
+	defaultStorageCacheSize = 10
+)
+
+/*
+ * relay storage archives relay log files to an external storage, so relay log
+ * can be retained for a long time without a large local disk.
+ *
+ *   a relay log file is archived after it's completed, which is any file
+ *   except the latest one in the latest sub directory. archived files are kept
+ *   in relay dir as a read-through cache, and the least recently used ones are
+ *   evicted when the cache exceeds its size. the binlog reader lists both local
+ *   and archived files, and downloads an evicted file before reading it.
+ *
+ *   archived files are laid out as `<sub dir>/<filename>` in the external
+ *   storage, and they are not purged by the relay purger, so they should be
+ *   expired by the lifecycle policy of the external storage.
+ */
+
+// relayStorage archives relay log files to an external storage and caches them locally.
+type relayStorage struct {
+	sync.Mutex
+
+	logger    log.Logger
+	relayDir  string
+	cacheSize int64 // in bytes
+	store     bstorage.ExternalStorage
+	// localDir is the path of the external storage if it's on local disk.
+	localDir string
+
+	// archived is sizes of archived relay log files, keyed by `<sub dir>/<filename>`.
+	archived map[string]int64
+	// accessed is the last accessed time of cached relay log files, keyed as archived.
+	accessed map[string]time.Time
+}
+
+// newRelayStorage creates a relayStorage if the external storage is specified.
+func newRelayStorage(ctx context.Context, logger log.Logger, cfg *Config) (*relayStorage, error) {
+	if cfg.StorageURL == "" {
+		return nil, nil
+	}
+	backend, err := bstorage.ParseBackend(cfg.StorageURL, nil)
+	if err != nil {
+		return nil, terror.ErrConfigRelayStorageInvalid.Delegate(err, cfg.StorageURL)
+	}
+	store, err := bstorage.New(ctx, backend, &bstorage.ExternalStorageOptions{})
+	if err != nil {
+		return nil, terror.ErrRelayStorageOperate.Delegate(err, "connect", cfg.StorageURL)
+	}
+	cacheSize := cfg.StorageCacheSize
+	if cacheSize <= 0 {
+		cacheSize = defaultStorageCacheSize
+	}
+	s := &relayStorage{
+		logger:    logger.WithFields(zap.String("component", "relay storage")),
+		relayDir:  cfg.RelayDir,
+		cacheSize: cacheSize * 1024 * 1024 * 1024,
+		store:     store,
+		localDir:  backend.GetLocal().GetPath(),
+		archived:  make(map[string]int64),
+		accessed:  make(map[string]time.Time),
+	}
+
+	err = store.WalkDir(ctx, &bstorage.WalkOption{}, func(filePath string, size int64) error {
+		subDir, filename := path.Split(filePath)
+		if !utils.VerifyFilename(filename) {
+			return nil
+		}
+		s.archived[path.Join(subDir, filename)] = size
+		return nil
+	})
+	if err != nil {
+		return nil, terror.ErrRelayStorageOperate.Delegate(err, "list", cfg.StorageURL)
+	}
+	s.logger.Info("relay storage initialized", zap.String("url", cfg.StorageURL), zap.Int("archived files", len(s.archived)))
+	return s, nil
+}
+
+// archive archives completed relay log files which are not archived yet, and
+// evicts cached files if the cache exceeds its size.
+func (s *relayStorage) archive(ctx context.Context, isActive func(uuid, filename string) (bool, int64)) error {
+	subDirs, err := utils.ParseUUIDIndex(filepath.Join(s.relayDir, utils.UUIDIndexFilename))
+	if err != nil {
+		return err
+	}
+	for i, subDir := range subDirs {
+		dir := filepath.Join(s.relayDir, subDir)
+		if !utils.IsDirExists(dir) {
+			continue
+		}
+		files, err := CollectAllBinlogFiles(dir)
+		if err != nil {
+			return err
+		}
+		for j, filename := range files {
+			if i == len(subDirs)-1 && j == len(files)-1 {
+				// the latest file may be still written.
+				break
+			}
+			if active, _ := isActive(subDir, filename); active {
+				continue
+			}
+			if s.isArchived(subDir, filename) {
+				continue
+			}
+			if err = s.upload(ctx, subDir, filename); err != nil {
+				return err
+			}
+		}
+	}
+	return s.evict()
+}
+
+func (s *relayStorage) isArchived(subDir, filename string) bool {
+	s.Lock()
+	defer s.Unlock()
+	_, ok := s.archived[path.Join(subDir, filename)]
+	return ok
+}
+
+func (s *relayStorage) upload(ctx context.Context, subDir, filename string) error {
+	key := path.Join(subDir, filename)
+	f, err := os.Open(filepath.Join(s.relayDir, subDir, filename))
+	if err != nil {
+		return terror.ErrRelayStorageOperate.Delegate(err, "upload", key)
+	}
+	defer f.Close()
+
+	if s.localDir != "" {
+		// local storage doesn't create parent directories when creating files.
+		if err = os.MkdirAll(filepath.Join(s.localDir, subDir), 0o700); err != nil {
+			return terror.ErrRelayStorageOperate.Delegate(err, "upload", key)
+		}
+	}
+	w, err := s.store.Create(ctx, key)
+	if err != nil {
+		return terror.ErrRelayStorageOperate.Delegate(err, "upload", key)
+	}
+	var (
+		size int64
+		buf  = make([]byte, archiveChunkSize)
+	)
+	for {
+		n, err2 := f.Read(buf)
+		if n > 0 {
+			if _, err = w.Write(ctx, buf[:n]); err != nil {
+				_ = w.Close(ctx)
+				return terror.ErrRelayStorageOperate.Delegate(err, "upload", key)
+			}
+			size += int64(n)
+		}
+		if err2 == io.EOF {
+			break
+		}
+		if err2 != nil {
+			_ = w.Close(ctx)
+			return terror.ErrRelayStorageOperate.Delegate(err2, "upload", key)
+		}
+	}
+	if err = w.Close(ctx); err != nil {
+		return terror.ErrRelayStorageOperate.Delegate(err, "upload", key)
+	}
+
+	s.Lock()
+	s.archived[key] = size
+	s.Unlock()
+	s.logger.Info("relay log file archived", zap.String("file", key), zap.Int64("size", size))
+	return nil
+}
+
+// evict removes least recently used archived files from relay dir, until the
+// size of cached files is not larger than the cache size.
+func (s *relayStorage) evict() error {
+	s.Lock()
+	defer s.Unlock()
+
+	type cachedFile struct {
+		key      string
+		size     int64
+		accessed time.Time
+	}
+	var (
+		cached []cachedFile
+		total  int64
+	)
+	for key := range s.archived {
+		fi, err := os.Stat(filepath.Join(s.relayDir, filepath.FromSlash(key)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				delete(s.accessed, key)
+				continue
+			}
+			return terror.ErrGetRelayLogStat.Delegate(err, key)
+		}
+		accessed, ok := s.accessed[key]
+		if !ok {
+			accessed = fi.ModTime()
+		}
+		cached = append(cached, cachedFile{key: key, size: fi.Size(), accessed: accessed})
+		total += fi.Size()
+	}
+	if total <= s.cacheSize {
+		return nil
+	}
+
+	sort.Slice(cached, func(i, j int) bool {
+		if !cached[i].accessed.Equal(cached[j].accessed) {
+			return cached[i].accessed.Before(cached[j].accessed)
+		}
+		return cached[i].key < cached[j].key
+	})
+	for _, f := range cached {
+		if total <= s.cacheSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(s.relayDir, filepath.FromSlash(f.key))); err != nil && !os.IsNotExist(err) {
+			return terror.ErrRelayRemoveFileFail.Delegate(err, "file", f.key)
+		}
+		delete(s.accessed, f.key)
+		total -= f.size
+		s.logger.Info("archived relay log file evicted from cache", zap.String("file", f.key))
+	}
+	return nil
+}
+
+// archivedFiles returns filenames of archived relay log files in the sub directory.
+func (s *relayStorage) archivedFiles(subDir string) []string {
+	s.Lock()
+	defer s.Unlock()
+	var files []string
+	prefix := subDir + "/"
+	for key := range s.archived {
+		if strings.HasPrefix(key, prefix) {
+			files = append(files, strings.TrimPrefix(key, prefix))
+		}
+	}
+	return files
+}
+
+// fetch makes sure the relay log file exists in relay dir, it downloads the
+// file from the external storage if it's evicted.
+func (s *relayStorage) fetch(ctx context.Context, subDir, filename string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	key := path.Join(subDir, filename)
+	fullPath := filepath.Join(s.relayDir, subDir, filename)
+	if _, ok := s.archived[key]; !ok {
+		return nil
+	}
+	s.accessed[key] = time.Now()
+	if utils.IsFileExists(fullPath) {
+		return nil
+	}
+
+	r, err := s.store.Open(ctx, key)
+	if err != nil {
+		return terror.ErrRelayStorageOperate.Delegate(err, "download", key)
+	}
+	defer r.Close()
+	tmpPath := fullPath + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return terror.ErrRelayStorageOperate.Delegate(err, "download", key)
+	}
+	_, err = io.Copy(f, r)
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, fullPath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return terror.ErrRelayStorageOperate.Delegate(err, "download", key)
+	}
+	s.logger.Info("archived relay log file downloaded", zap.String("file", key))
+	return nil
+}
+
+// clear removes all archived relay log files.
+func (s *relayStorage) clear(ctx context.Context) error {
+	s.Lock()
+	defer s.Unlock()
+	for key := range s.archived {
+		if err := s.store.DeleteFile(ctx, key); err != nil && !dmstorage.IsNotExistError(err) {
+			return terror.ErrRelayStorageOperate.Delegate(err, "delete", key)
+		}
+		delete(s.archived, key)
+	}
+	s.accessed = make(map[string]time.Time)
+	return nil
+}
+
+// mergeBinlogFiles merges local and archived relay log files, and returns
+// filenames in binlog ascending order.
+func mergeBinlogFiles(local, archived []string) []string {
+	if len(archived) == 0 {
+		return local
+	}
+	type tuple struct {
+		filename string
+		parsed   utils.Filename
+	}
+	seen := make(map[string]struct{}, len(local)+len(archived))
+	tmp := make([]tuple, 0, len(local)+len(archived))
+	for _, files := range [][]string{local, archived} {
+		for _, f := range files {
+			if _, ok := seen[f]; ok {
+				continue
+			}
+			parsed, err := utils.ParseFilename(f)
+			if err != nil {
+				continue
+			}
+			seen[f] = struct{}{}
+			tmp = append(tmp, tuple{filename: f, parsed: parsed})
+		}
+	}
+	sort.Slice(tmp, func(i, j int) bool {
+		if tmp[i].parsed.BaseName != tmp[j].parsed.BaseName {
+			return tmp[i].parsed.BaseName < tmp[j].parsed.BaseName
+		}
+		return tmp[i].parsed.LessThan(tmp[j].parsed)
+	})
+	ret := make([]string, len(tmp))
+	for i := range tmp {
+		ret[i] = tmp[i].filename
+	}
+	return ret
+}
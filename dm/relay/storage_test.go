@@ -0,0 +1,107 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relay
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+)
+
+var _ = Suite(&testRelayStorageSuite{})
+
+type testRelayStorageSuite struct{}
+
+func (t *testRelayStorageSuite) TestRelayStorage(c *C) {
+	var (
+		ctx        = context.Background()
+		relayDir   = c.MkDir()
+		storageDir = c.MkDir()
+		subDirs    = []string{"uuid.000001", "uuid.000002"}
+		files      = map[string][]string{
+			subDirs[0]: {"mysql-bin.000001", "mysql-bin.000002"},
+			subDirs[1]: {"mysql-bin.000003", "mysql-bin.000004"},
+		}
+		baseTime = time.Now().Add(-time.Hour)
+	)
+	c.Assert(os.WriteFile(filepath.Join(relayDir, utils.UUIDIndexFilename), []byte(strings.Join(subDirs, "\n")), 0o644), IsNil)
+	for i, subDir := range subDirs {
+		c.Assert(os.MkdirAll(filepath.Join(relayDir, subDir), 0o700), IsNil)
+		for j, f := range files[subDir] {
+			fp := filepath.Join(relayDir, subDir, f)
+			c.Assert(os.WriteFile(fp, []byte(strings.Repeat(f[len(f)-1:], 10)), 0o644), IsNil)
+			modTime := baseTime.Add(time.Duration(i*2+j) * time.Minute)
+			c.Assert(os.Chtimes(fp, modTime, modTime), IsNil)
+		}
+	}
+
+	s, err := newRelayStorage(ctx, log.L(), &Config{RelayDir: relayDir})
+	c.Assert(err, IsNil)
+	c.Assert(s, IsNil)
+	cfg := &Config{RelayDir: relayDir, StorageURL: storageDir}
+	s, err = newRelayStorage(ctx, log.L(), cfg)
+	c.Assert(err, IsNil)
+	c.Assert(s.cacheSize, Equals, int64(defaultStorageCacheSize*1024*1024*1024))
+
+	// the latest file and active files are not archived.
+	isActive := func(uuid, filename string) (bool, int64) {
+		return uuid == subDirs[1] && filename == "mysql-bin.000003", 10
+	}
+	c.Assert(s.archive(ctx, isActive), IsNil)
+	c.Assert(s.archived, DeepEquals, map[string]int64{
+		"uuid.000001/mysql-bin.000001": 10,
+		"uuid.000001/mysql-bin.000002": 10,
+	})
+	isActive = func(string, string) (bool, int64) { return false, 0 }
+	c.Assert(s.archive(ctx, isActive), IsNil)
+	c.Assert(s.archived, HasLen, 3)
+	c.Assert(utils.IsFileExists(filepath.Join(storageDir, "uuid.000002", "mysql-bin.000003")), IsTrue)
+	c.Assert(utils.IsFileExists(filepath.Join(storageDir, "uuid.000002", "mysql-bin.000004")), IsFalse)
+
+	// least recently used files are evicted.
+	s.cacheSize = 15
+	c.Assert(s.fetch(ctx, subDirs[0], "mysql-bin.000001"), IsNil)
+	c.Assert(s.evict(), IsNil)
+	c.Assert(utils.IsFileExists(filepath.Join(relayDir, "uuid.000001", "mysql-bin.000001")), IsTrue)
+	c.Assert(utils.IsFileExists(filepath.Join(relayDir, "uuid.000001", "mysql-bin.000002")), IsFalse)
+	c.Assert(utils.IsFileExists(filepath.Join(relayDir, "uuid.000002", "mysql-bin.000003")), IsFalse)
+	c.Assert(utils.IsFileExists(filepath.Join(relayDir, "uuid.000002", "mysql-bin.000004")), IsTrue)
+
+	// evicted files are listed and downloaded.
+	archived := s.archivedFiles(subDirs[0])
+	sort.Strings(archived)
+	c.Assert(archived, DeepEquals, files[subDirs[0]])
+	local, err := CollectAllBinlogFiles(filepath.Join(relayDir, subDirs[1]))
+	c.Assert(err, IsNil)
+	c.Assert(mergeBinlogFiles(local, s.archivedFiles(subDirs[1])), DeepEquals, files[subDirs[1]])
+	c.Assert(s.fetch(ctx, subDirs[1], "mysql-bin.000003"), IsNil)
+	data, err := os.ReadFile(filepath.Join(relayDir, "uuid.000002", "mysql-bin.000003"))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, strings.Repeat("3", 10))
+
+	// archived files are loaded from the external storage, and can be cleared.
+	s, err = newRelayStorage(ctx, log.L(), cfg)
+	c.Assert(err, IsNil)
+	c.Assert(s.archived, HasLen, 3)
+	c.Assert(s.clear(ctx), IsNil)
+	c.Assert(s.archived, HasLen, 0)
+	c.Assert(utils.IsFileExists(filepath.Join(storageDir, "uuid.000001", "mysql-bin.000001")), IsFalse)
+}
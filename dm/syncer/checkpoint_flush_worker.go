@@ -31,6 +31,9 @@ type checkpointFlushTask struct {
 	exceptTables  []*filter.Table
 	shardMetaSQLs []string
 	shardMetaArgs [][]interface{}
+	// downstream schema snapshot flushed with the checkpoint, whose SQLs are
+	// appended to shardMetaSQLs.
+	schemaSnapshot map[string]string
 	// async flush job
 	asyncflushJob *job
 	// error chan for sync flush
@@ -74,6 +74,7 @@ type DDLWorker struct {
 	charsetAndDefaultCollation map[string]string
 	idAndCollationMap          map[int]string
 	baList                     *tablefilter.Filter
+	inTablePartition           func(table *filter.Table) bool
 
 	recordSkipSQLsLocation func(ec *eventContext) error
 	trackDDL               func(usedSchema string, trackInfo *ddlInfo, ec *eventContext) error
@@ -101,6 +102,7 @@ func NewDDLWorker(pLogger *log.Logger, syncer *Syncer) *DDLWorker {
 		charsetAndDefaultCollation: syncer.charsetAndDefaultCollation,
 		idAndCollationMap:          syncer.idAndCollationMap,
 		baList:                     syncer.baList,
+		inTablePartition:           syncer.cfg.InTablePartition,
 		recordSkipSQLsLocation:     syncer.recordSkipSQLsLocation,
 		trackDDL:                   syncer.trackDDL,
 		saveTablePoint:             syncer.saveTablePoint,
@@ -977,7 +979,7 @@ func (ddl *DDLWorker) skipQueryEvent(qec *queryEventContext, ddlInfo *ddlInfo) (
 	}
 	for _, table := range realTables {
 		ddl.logger.Debug("query event info", zap.String("event", "query"), zap.String("origin sql", qec.originSQL), zap.Stringer("table", table), zap.Stringer("ddl info", ddlInfo))
-		if skipByTable(ddl.baList, table) {
+		if skipByTable(ddl.baList, table) || !ddl.inTablePartition(table) {
 			ddl.logger.Debug("skip event by balist")
 			return true, nil
 		}
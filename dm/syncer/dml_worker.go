@@ -31,15 +31,17 @@ import (
 
 // DMLWorker is used to sync dml.
 type DMLWorker struct {
-	compact       bool
-	batch         int
-	workerCount   int
-	chanSize      int
-	multipleRows  bool
-	toDBConns     []*dbconn.DBConn
-	syncCtx       *tcontext.Context
-	logger        log.Logger
-	metricProxies *metrics.Proxies
+	compact      bool
+	batch        int
+	workerCount  int
+	chanSize     int
+	multipleRows bool
+	toDBConns    []*dbconn.DBConn
+	syncCtx      *tcontext.Context
+	// nil if flow control is disabled
+	flowController *flowController
+	logger         log.Logger
+	metricProxies  *metrics.Proxies
 
 	// for MetricsProxies
 	task   string
@@ -84,6 +86,9 @@ func dmlWorkerWrap(inCh chan *job, syncer *Syncer) chan *job {
 		inCh:                 inCh,
 		flushCh:              make(chan *job),
 	}
+	if syncer.cfg.FlowControl {
+		dmlWorker.flowController = newFlowController(dmlWorker.logger, dmlWorker.workerCount, dmlWorker.batch)
+	}
 
 	go func() {
 		dmlWorker.run()
@@ -169,7 +174,7 @@ func (w *DMLWorker) executeJobs(queueID int, jobCh chan *job) {
 				w.lagFunc(j, workerJobIdx)
 			}
 			jobs = append(jobs, j)
-			if len(jobs) < w.batch && len(jobCh) > 0 {
+			if len(jobs) < w.batchSize() && len(jobCh) > 0 {
 				continue
 			}
 		}
@@ -196,6 +201,14 @@ func (w *DMLWorker) executeJobs(queueID int, jobCh chan *job) {
 	}
 }
 
+// batchSize returns the size limit of a batch.
+func (w *DMLWorker) batchSize() int {
+	if w.flowController != nil {
+		return w.flowController.batchSize()
+	}
+	return w.batch
+}
+
 // executeBatchJobs execute jobs with batch size.
 func (w *DMLWorker) executeBatchJobs(queueID int, jobs []*job) {
 	var (
@@ -256,6 +269,15 @@ func (w *DMLWorker) executeBatchJobs(queueID int, jobs []*job) {
 	// if users need to quit this asap, we can support pause-task/stop-task --force in the future
 	ctx, cancel := w.syncCtx.WithTimeout(maxDMLConnectionDuration)
 	defer cancel()
+	if w.flowController != nil {
+		if err = w.flowController.acquire(ctx.Context()); err != nil {
+			return
+		}
+		startTime := time.Now()
+		defer func() {
+			w.flowController.release(time.Since(startTime))
+		}()
+	}
 	affect, err = db.ExecuteSQL(ctx, w.metricProxies, queries, args...)
 	failpoint.Inject("SafeModeExit", func(val failpoint.Value) {
 		if intVal, ok := val.(int); ok && intVal == 4 && len(jobs) > 0 {
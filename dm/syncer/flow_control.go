@@ -0,0 +1,149 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"go.uber.org/zap"
+)
+
+const (
+	// a batch executed slower than this means downstream is overloaded. it
+	// also covers retries on errors like server busy, which wait for seconds.
+	flowControlSlowThreshold = time.Second
+	// limits are decreased at most once in this interval, so that concurrent
+	// slow batches caused by one overload don't decrease limits repeatedly.
+	flowControlDecreaseInterval = 5 * time.Second
+)
+
+/*
+ * flow controller throttles DML execution by the feedback of downstream in
+ * the AIMD (additive increase, multiplicative decrease) way.
+ *
+ *   it limits how many DML workers can execute batches at the same time, and
+ *   the size of batches. when a batch is executed slowly, which means the
+ *   downstream is overloaded, both limits are halved. after a round of batches
+ *   are executed fast, they are increased gradually until worker-count and
+ *   batch of the task.
+ */
+
+// flowController limits the concurrency and batch size of DML execution.
+type flowController struct {
+	sync.Mutex
+
+	logger log.Logger
+
+	maxConcurrency int
+	maxBatch       int
+
+	concurrency  int
+	batch        int
+	running      int
+	fastBatches  int
+	lastDecrease time.Time
+
+	// notifyCh is closed when a running batch finishes.
+	notifyCh chan struct{}
+}
+
+// newFlowController creates a flowController, which doesn't throttle at first.
+func newFlowController(logger log.Logger, workerCount, batch int) *flowController {
+	return &flowController{
+		logger:         logger.WithFields(zap.String("component", "flow controller")),
+		maxConcurrency: workerCount,
+		maxBatch:       batch,
+		concurrency:    workerCount,
+		batch:          batch,
+		notifyCh:       make(chan struct{}),
+	}
+}
+
+// batchSize returns the current limit of batch size.
+func (c *flowController) batchSize() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.batch
+}
+
+// acquire waits until a batch can be executed under the concurrency limit.
+func (c *flowController) acquire(ctx context.Context) error {
+	for {
+		c.Lock()
+		if c.running < c.concurrency {
+			c.running++
+			c.Unlock()
+			return nil
+		}
+		notifyCh := c.notifyCh
+		c.Unlock()
+
+		select {
+		case <-notifyCh:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// release finishes a batch acquired before, and adjusts limits by its execution time.
+func (c *flowController) release(cost time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.running--
+	close(c.notifyCh)
+	c.notifyCh = make(chan struct{})
+
+	if cost >= flowControlSlowThreshold {
+		c.fastBatches = 0
+		if time.Since(c.lastDecrease) < flowControlDecreaseInterval {
+			return
+		}
+		c.lastDecrease = time.Now()
+		if c.concurrency == 1 && c.batch == 1 {
+			return
+		}
+		c.concurrency = (c.concurrency + 1) / 2
+		c.batch = (c.batch + 1) / 2
+		c.logger.Info("downstream is overloaded, decrease limits",
+			zap.Duration("cost", cost), zap.Int("concurrency", c.concurrency), zap.Int("batch", c.batch))
+		return
+	}
+
+	c.fastBatches++
+	if c.fastBatches < c.concurrency {
+		return
+	}
+	c.fastBatches = 0
+	if c.concurrency == c.maxConcurrency && c.batch == c.maxBatch {
+		return
+	}
+	if c.concurrency < c.maxConcurrency {
+		c.concurrency++
+	}
+	step := c.maxBatch / 10
+	if step == 0 {
+		step = 1
+	}
+	c.batch += step
+	if c.batch > c.maxBatch {
+		c.batch = c.maxBatch
+	}
+	c.logger.Info("downstream is healthy, increase limits",
+		zap.Int("concurrency", c.concurrency), zap.Int("batch", c.batch))
+}
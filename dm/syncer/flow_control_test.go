@@ -0,0 +1,92 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowController(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newFlowController(log.L(), 4, 100)
+	require.Equal(t, 100, c.batchSize())
+
+	// limits are halved once for concurrent slow batches.
+	for i := 0; i < 4; i++ {
+		require.NoError(t, c.acquire(ctx))
+	}
+	for i := 0; i < 4; i++ {
+		c.release(flowControlSlowThreshold)
+	}
+	require.Equal(t, 2, c.concurrency)
+	require.Equal(t, 50, c.batchSize())
+
+	// the concurrency is limited.
+	require.NoError(t, c.acquire(ctx))
+	require.NoError(t, c.acquire(ctx))
+	acquired := make(chan struct{})
+	go func() {
+		require.NoError(t, c.acquire(ctx))
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired beyond the concurrency limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+	c.release(time.Millisecond)
+	<-acquired
+	c.release(time.Millisecond)
+	c.release(time.Millisecond)
+
+	require.Equal(t, 3, c.concurrency)
+	require.Equal(t, 60, c.batchSize())
+
+	// a canceled context stops waiting.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.acquire(ctx))
+	}
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, c.acquire(cancelCtx), context.Canceled)
+	for i := 0; i < 3; i++ {
+		c.release(time.Millisecond)
+	}
+
+	// limits are increased after a round of fast batches, until the maximum.
+	require.Equal(t, 4, c.concurrency)
+	require.Equal(t, 70, c.batchSize())
+	for i := 0; i < 100; i++ {
+		require.NoError(t, c.acquire(ctx))
+		c.release(time.Millisecond)
+	}
+	require.Equal(t, 4, c.concurrency)
+	require.Equal(t, 100, c.batchSize())
+
+	// limits are decreased at most once in an interval.
+	c.lastDecrease = time.Time{}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.acquire(ctx))
+		c.release(flowControlSlowThreshold)
+	}
+	require.Equal(t, 2, c.concurrency)
+	require.Equal(t, 50, c.batchSize())
+}
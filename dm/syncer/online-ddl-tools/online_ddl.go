@@ -123,7 +123,7 @@ func NewOnlineDDLStorage(
 		metricProxies: metricProxies,
 		schema:        dbutil.ColumnName(cfg.MetaSchema),
 		tableName:     dbutil.TableName(cfg.MetaSchema, cputil.SyncerOnlineDDL(cfg.Name)),
-		id:            cfg.SourceID,
+		id:            cfg.TablePartitionID(),
 		ddls:          make(map[string]map[string]*GhostDDLInfo),
 		logCtx:        logCtx,
 	}
@@ -0,0 +1,231 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"sync"
+
+	"github.com/pingcap/tidb/util/dbutil"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/config/dbconfig"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/cputil"
+	fr "github.com/pingcap/tiflow/dm/pkg/func-rollback"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
+	"github.com/pingcap/tiflow/dm/syncer/dbconn"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
+	"go.uber.org/zap"
+)
+
+/*
+ * schema snapshot persists create statements of downstream tables tracked by
+ * the downstream schema tracker, so that they needn't be fetched from
+ * downstream by "SHOW CREATE TABLE" one by one again after resuming.
+ *
+ *   the snapshot is written in the same transaction with checkpoint, and only
+ *   changed rows are written in each flush, so the snapshot table is compact.
+ *   each row has a checksum, and rows failing the check are dropped when
+ *   loading. Statements are also checked by the schema tracker, and tables
+ *   with invalid statements are fetched from downstream again.
+ */
+
+// schemaSnapshot keeps the persisted snapshot of downstream table structures.
+type schemaSnapshot struct {
+	sync.Mutex
+
+	cfg           *config.SubTaskConfig
+	metricProxies *metrics.Proxies
+
+	tableName string
+
+	// persisted is checksums of rows in the snapshot table, keyed by table IDs.
+	persisted map[string]uint32
+
+	db     *conn.BaseDB
+	dbConn *dbconn.DBConn
+
+	tctx *tcontext.Context
+}
+
+// newSchemaSnapshot creates a new schemaSnapshot.
+func newSchemaSnapshot(
+	tctx *tcontext.Context,
+	cfg *config.SubTaskConfig,
+	metricProxies *metrics.Proxies,
+) *schemaSnapshot {
+	return &schemaSnapshot{
+		cfg:           cfg,
+		metricProxies: metricProxies,
+		tableName:     dbutil.TableName(cfg.MetaSchema, cputil.SyncerSchemaSnapshot(cfg.Name)),
+		persisted:     make(map[string]uint32),
+		tctx:          tctx.WithLogger(tctx.L().WithFields(zap.String("component", "schema snapshot"))),
+	}
+}
+
+// Init initializes the connection and the snapshot table.
+func (s *schemaSnapshot) Init() (err error) {
+	rollbackHolder := fr.NewRollbackHolder("syncer")
+	defer func() {
+		if err != nil {
+			rollbackHolder.RollbackReverseOrder()
+		}
+	}()
+
+	snapshotDB := s.cfg.To
+	snapshotDB.RawDBCfg = dbconfig.DefaultRawDBConfig().SetReadTimeout(maxCheckPointTimeout)
+	db, dbConns, err := dbconn.CreateConns(s.tctx, s.cfg, conn.DownstreamDBConfig(&snapshotDB), 1, s.cfg.IOTotalBytes, s.cfg.UUID)
+	if err != nil {
+		return err
+	}
+	s.db = db
+	s.dbConn = dbConns[0]
+	rollbackHolder.Add(fr.FuncRollback{Name: "CloseSchemaSnapshot", Fn: s.Close})
+
+	return s.prepare()
+}
+
+// Close closes the connection.
+func (s *schemaSnapshot) Close() {
+	dbconn.CloseBaseDB(s.tctx, s.db)
+}
+
+func (s *schemaSnapshot) prepare() error {
+	stmts := []string{
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", dbutil.ColumnName(s.cfg.MetaSchema)),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		id VARCHAR(32) NOT NULL COMMENT 'replica source id, defined in task.yaml',
+		table_id VARCHAR(144) NOT NULL,
+		create_table TEXT NOT NULL,
+		checksum INT UNSIGNED NOT NULL,
+		create_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_id_table_id (id, table_id)
+	)`, s.tableName),
+	}
+	_, err := s.dbConn.ExecuteSQL(s.tctx, s.metricProxies, stmts)
+	s.tctx.L().Info("execute sql", zap.Strings("statements", stmts))
+	return terror.WithScope(err, terror.ScopeDownstream)
+}
+
+// load loads the snapshot from the snapshot table. Rows failing the integrity
+// check are deleted from the table.
+func (s *schemaSnapshot) load() (map[string]string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	query := fmt.Sprintf("SELECT `table_id`, `create_table`, `checksum` FROM %s WHERE `id` = ?", s.tableName)
+	rows, err := s.dbConn.QuerySQL(s.tctx, s.metricProxies, query, s.cfg.TablePartitionID())
+	if err != nil {
+		return nil, terror.WithScope(err, terror.ScopeDownstream)
+	}
+	defer rows.Close()
+
+	var (
+		tableID   string
+		createSQL string
+		checksum  uint32
+		snapshot  = make(map[string]string)
+		corrupted []string
+	)
+	s.persisted = make(map[string]uint32)
+	for rows.Next() {
+		err = rows.Scan(&tableID, &createSQL, &checksum)
+		if err != nil {
+			return nil, terror.DBErrorAdapt(err, s.dbConn.Scope(), terror.ErrDBDriverError)
+		}
+		if schemaSnapshotChecksum(tableID, createSQL) != checksum {
+			s.tctx.L().Warn("checksum of table structure mismatches in schema snapshot, drop it",
+				zap.String("table", tableID), zap.String("create string", createSQL), zap.Uint32("checksum", checksum))
+			corrupted = append(corrupted, tableID)
+			continue
+		}
+		snapshot[tableID] = createSQL
+		s.persisted[tableID] = checksum
+	}
+	if err = rows.Err(); err != nil {
+		return nil, terror.DBErrorAdapt(err, s.dbConn.Scope(), terror.ErrDBDriverError)
+	}
+
+	if len(corrupted) > 0 {
+		sqls := make([]string, 0, len(corrupted))
+		args := make([][]interface{}, 0, len(corrupted))
+		for _, id := range corrupted {
+			sqls = append(sqls, fmt.Sprintf("DELETE FROM %s WHERE `id` = ? AND `table_id` = ?", s.tableName))
+			args = append(args, []interface{}{s.cfg.TablePartitionID(), id})
+		}
+		if _, err = s.dbConn.ExecuteSQL(s.tctx, s.metricProxies, sqls, args...); err != nil {
+			return nil, terror.WithScope(err, terror.ScopeDownstream)
+		}
+	}
+	s.tctx.L().Info("load schema snapshot", zap.Int("tables", len(snapshot)), zap.Int("corrupted", len(corrupted)))
+	return snapshot, nil
+}
+
+// prepareFlushSQLs returns SQLs to update the snapshot table to the snapshot,
+// which only contain changed tables since the last flushed snapshot.
+func (s *schemaSnapshot) prepareFlushSQLs(snapshot map[string]string) ([]string, [][]interface{}) {
+	s.Lock()
+	defer s.Unlock()
+
+	var (
+		sqls []string
+		args [][]interface{}
+	)
+	tableIDs := make([]string, 0, len(snapshot))
+	for tableID := range snapshot {
+		tableIDs = append(tableIDs, tableID)
+	}
+	sort.Strings(tableIDs)
+	for _, tableID := range tableIDs {
+		checksum := schemaSnapshotChecksum(tableID, snapshot[tableID])
+		if persisted, ok := s.persisted[tableID]; ok && persisted == checksum {
+			continue
+		}
+		sqls = append(sqls, fmt.Sprintf("REPLACE INTO %s (`id`, `table_id`, `create_table`, `checksum`) VALUES (?, ?, ?, ?)", s.tableName))
+		args = append(args, []interface{}{s.cfg.TablePartitionID(), tableID, snapshot[tableID], checksum})
+	}
+
+	removed := make([]string, 0)
+	for tableID := range s.persisted {
+		if _, ok := snapshot[tableID]; !ok {
+			removed = append(removed, tableID)
+		}
+	}
+	sort.Strings(removed)
+	for _, tableID := range removed {
+		sqls = append(sqls, fmt.Sprintf("DELETE FROM %s WHERE `id` = ? AND `table_id` = ?", s.tableName))
+		args = append(args, []interface{}{s.cfg.TablePartitionID(), tableID})
+	}
+	return sqls, args
+}
+
+// flushed records the snapshot has been flushed to the snapshot table.
+func (s *schemaSnapshot) flushed(snapshot map[string]string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.persisted = make(map[string]uint32, len(snapshot))
+	for tableID, createSQL := range snapshot {
+		s.persisted[tableID] = schemaSnapshotChecksum(tableID, createSQL)
+	}
+}
+
+func schemaSnapshotChecksum(tableID, createSQL string) uint32 {
+	checksum := crc32.ChecksumIEEE([]byte(tableID))
+	return crc32.Update(checksum, crc32.IEEETable, []byte(createSQL))
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/retry"
+	"github.com/pingcap/tiflow/dm/syncer/dbconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaSnapshot(t *testing.T) {
+	cfg := &config.SubTaskConfig{
+		Name:       "snapshot_ut",
+		SourceID:   "source-01",
+		MetaSchema: "test",
+	}
+	s := newSchemaSnapshot(tcontext.Background(), cfg, nil)
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	dbConn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	s.db = conn.NewBaseDBForTest(db)
+	s.dbConn = dbconn.NewDBConn(cfg, conn.NewBaseConnForTest(dbConn, &retry.FiniteRetryStrategy{}))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE SCHEMA IF NOT EXISTS `test`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS `test`.`snapshot_ut_syncer_schema_snapshot`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, s.prepare())
+
+	tableID1 := "`db`.`t1`"
+	tableID2 := "`db`.`t2`"
+	tableID3 := "`db`.`t3`"
+	createSQL1 := "CREATE TABLE `t1` (`id` int PRIMARY KEY)"
+	createSQL2 := "CREATE TABLE `t2` (`id` int PRIMARY KEY)"
+	createSQL3 := "CREATE TABLE `t3` (`id` int PRIMARY KEY)"
+
+	// rows failing the checksum are deleted.
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `table_id`, `create_table`, `checksum` FROM `test`.`snapshot_ut_syncer_schema_snapshot` WHERE `id` = ?")).
+		WithArgs(cfg.SourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"table_id", "create_table", "checksum"}).
+			AddRow(tableID1, createSQL1, schemaSnapshotChecksum(tableID1, createSQL1)).
+			AddRow(tableID2, createSQL2, schemaSnapshotChecksum(tableID1, createSQL2)))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `test`.`snapshot_ut_syncer_schema_snapshot` WHERE `id` = ? AND `table_id` = ?")).
+		WithArgs(cfg.SourceID, tableID2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	snapshot, err := s.load()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{tableID1: createSQL1}, snapshot)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// only changed tables are flushed.
+	sqls, args := s.prepareFlushSQLs(map[string]string{tableID1: createSQL1})
+	require.Empty(t, sqls)
+	require.Empty(t, args)
+
+	snapshot = map[string]string{tableID2: createSQL2, tableID3: createSQL3}
+	sqls, args = s.prepareFlushSQLs(snapshot)
+	require.Equal(t, []string{
+		"REPLACE INTO `test`.`snapshot_ut_syncer_schema_snapshot` (`id`, `table_id`, `create_table`, `checksum`) VALUES (?, ?, ?, ?)",
+		"REPLACE INTO `test`.`snapshot_ut_syncer_schema_snapshot` (`id`, `table_id`, `create_table`, `checksum`) VALUES (?, ?, ?, ?)",
+		"DELETE FROM `test`.`snapshot_ut_syncer_schema_snapshot` WHERE `id` = ? AND `table_id` = ?",
+	}, sqls)
+	require.Equal(t, [][]interface{}{
+		{cfg.SourceID, tableID2, createSQL2, schemaSnapshotChecksum(tableID2, createSQL2)},
+		{cfg.SourceID, tableID3, createSQL3, schemaSnapshotChecksum(tableID3, createSQL3)},
+		{cfg.SourceID, tableID1},
+	}, args)
+
+	// the flushed snapshot is not flushed again.
+	s.flushed(snapshot)
+	sqls, _ = s.prepareFlushSQLs(snapshot)
+	require.Empty(t, sqls)
+	sqls, args = s.prepareFlushSQLs(map[string]string{tableID2: createSQL3, tableID3: createSQL3})
+	require.Len(t, sqls, 1)
+	require.Equal(t, []interface{}{cfg.SourceID, tableID2, createSQL3, schemaSnapshotChecksum(tableID2, createSQL3)}, args[0])
+}
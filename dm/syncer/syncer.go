@@ -130,6 +130,8 @@ type Syncer struct {
 	sgk  *ShardingGroupKeeper    // keeper to keep all sharding (sub) group in this syncer
 	osgk *OptShardingGroupKeeper // optimistic ddl's keeper to keep all sharding (sub) group in this syncer
 
+	schemaSnapshot *schemaSnapshot // persisted snapshot of downstream schema tracker, nil if disabled
+
 	pessimist *shardddl.Pessimist // shard DDL pessimist
 	optimist  *shardddl.Optimist  // shard DDL optimist
 	cli       *clientv3.Client
@@ -281,6 +283,9 @@ func NewSyncer(cfg *config.SubTaskConfig, etcdClient *clientv3.Client, relay rel
 	} else if cfg.ShardMode == config.ShardOptimistic {
 		syncer.osgk = NewOptShardingGroupKeeper(syncer.tctx, cfg)
 	}
+	if cfg.SchemaSnapshot {
+		syncer.schemaSnapshot = newSchemaSnapshot(syncer.tctx, cfg, syncer.metricsProxies)
+	}
 	syncer.recordedActiveRelayLog = false
 	syncer.workerJobTSArray = make([]*atomic.Int64, cfg.WorkerCount+workerJobTSArrayInitSize)
 	for i := range syncer.workerJobTSArray {
@@ -450,6 +455,14 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 		}
 	}
 
+	if s.schemaSnapshot != nil {
+		err = s.schemaSnapshot.Init()
+		if err != nil {
+			return err
+		}
+		rollbackHolder.Add(fr.FuncRollback{Name: "close-schema-snapshot", Fn: s.schemaSnapshot.Close})
+	}
+
 	err = s.checkpoint.Init(tctx)
 	if err != nil {
 		return err
@@ -659,6 +672,13 @@ func (s *Syncer) resetDBs(tctx *tcontext.Context) error {
 		}
 	}
 
+	if s.schemaSnapshot != nil {
+		err = s.schemaSnapshot.dbConn.ResetConn(tctx)
+		if err != nil {
+			return terror.WithScope(err, terror.ScopeDownstream)
+		}
+	}
+
 	err = s.ddlDBConn.ResetConn(tctx)
 	if err != nil {
 		return terror.WithScope(err, terror.ScopeDownstream)
@@ -1206,23 +1226,15 @@ func (s *Syncer) flushCheckPoints() error {
 		return nil
 	}
 
-	snapshotInfo, exceptTables, shardMetaSQLs, shardMetaArgs := s.createCheckpointSnapshot(true)
+	task := s.createCheckpointSnapshot(true)
 
-	if snapshotInfo == nil {
+	if task == nil {
 		s.tctx.L().Debug("checkpoint has no change, skip sync flush checkpoint")
 		return nil
 	}
 
 	syncFlushErrCh := make(chan error, 1)
-
-	task := &checkpointFlushTask{
-		snapshotInfo:   snapshotInfo,
-		exceptTables:   exceptTables,
-		shardMetaSQLs:  shardMetaSQLs,
-		shardMetaArgs:  shardMetaArgs,
-		asyncflushJob:  nil,
-		syncFlushErrCh: syncFlushErrCh,
-	}
+	task.syncFlushErrCh = syncFlushErrCh
 	s.checkpointFlushWorker.Add(task)
 
 	return <-syncFlushErrCh
@@ -1242,28 +1254,23 @@ func (s *Syncer) flushCheckPointsAsync(asyncFlushJob *job) {
 		return
 	}
 
-	snapshotInfo, exceptTables, shardMetaSQLs, shardMetaArgs := s.createCheckpointSnapshot(false)
+	task := s.createCheckpointSnapshot(false)
 
-	if snapshotInfo == nil {
+	if task == nil {
 		s.tctx.L().Debug("checkpoint has no change, skip async flush checkpoint", zap.Int64("job seq", asyncFlushJob.flushSeq))
 		return
 	}
 
-	task := &checkpointFlushTask{
-		snapshotInfo:   snapshotInfo,
-		exceptTables:   exceptTables,
-		shardMetaSQLs:  shardMetaSQLs,
-		shardMetaArgs:  shardMetaArgs,
-		asyncflushJob:  asyncFlushJob,
-		syncFlushErrCh: nil,
-	}
+	task.asyncflushJob = asyncFlushJob
 	s.checkpointFlushWorker.Add(task)
 }
 
-func (s *Syncer) createCheckpointSnapshot(isSyncFlush bool) (*SnapshotInfo, []*filter.Table, []string, [][]interface{}) {
+// createCheckpointSnapshot creates a flush task of the checkpoint snapshot,
+// which is nil if the checkpoint has no change.
+func (s *Syncer) createCheckpointSnapshot(isSyncFlush bool) *checkpointFlushTask {
 	snapshotInfo := s.checkpoint.Snapshot(isSyncFlush)
 	if snapshotInfo == nil {
-		return nil, nil, nil, nil
+		return nil
 	}
 
 	var (
@@ -1283,7 +1290,21 @@ func (s *Syncer) createCheckpointSnapshot(isSyncFlush bool) (*SnapshotInfo, []*f
 		s.tctx.L().Info("prepare flush sqls", zap.Strings("shard meta sqls", shardMetaSQLs), zap.Reflect("shard meta arguments", shardMetaArgs))
 	}
 
-	return snapshotInfo, exceptTables, shardMetaSQLs, shardMetaArgs
+	var schemaSnapshot map[string]string
+	if s.schemaSnapshot != nil {
+		schemaSnapshot = s.schemaTracker.DownstreamSnapshot()
+		sqls, args := s.schemaSnapshot.prepareFlushSQLs(schemaSnapshot)
+		shardMetaSQLs = append(shardMetaSQLs, sqls...)
+		shardMetaArgs = append(shardMetaArgs, args...)
+	}
+
+	return &checkpointFlushTask{
+		snapshotInfo:   snapshotInfo,
+		exceptTables:   exceptTables,
+		shardMetaSQLs:  shardMetaSQLs,
+		shardMetaArgs:  shardMetaArgs,
+		schemaSnapshot: schemaSnapshot,
+	}
 }
 
 func (s *Syncer) afterFlushCheckpoint(task *checkpointFlushTask) error {
@@ -1296,6 +1317,10 @@ func (s *Syncer) afterFlushCheckpoint(task *checkpointFlushTask) error {
 		s.addJob(newGCJob(math.MaxInt64))
 	}
 
+	if s.schemaSnapshot != nil && task.schemaSnapshot != nil {
+		s.schemaSnapshot.flushed(task.schemaSnapshot)
+	}
+
 	// update current active relay log after checkpoint flushed
 	err := s.updateActiveRelayLog(task.snapshotInfo.globalPos.Position)
 	if err != nil {
@@ -1782,6 +1807,16 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		return terror.ErrSchemaTrackerInit.Delegate(err)
 	}
 
+	// load the schema snapshot even in the fresh mode, so that stale tables in
+	// it are deleted in the next flush.
+	var schemaSnapshot map[string]string
+	if s.schemaSnapshot != nil {
+		schemaSnapshot, err = s.schemaSnapshot.load()
+		if err != nil {
+			return err
+		}
+	}
+
 	if freshAndAllMode {
 		err = s.loadTableStructureFromDump(ctx)
 		if err != nil {
@@ -1799,6 +1834,9 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		if err != nil {
 			return err
 		}
+		if schemaSnapshot != nil {
+			s.schemaTracker.RestoreDownstreamSnapshot(schemaSnapshot)
+		}
 	}
 
 	if cleanDumpFile {
@@ -3145,6 +3183,9 @@ func (s *Syncer) Close() {
 	if s.sgk != nil {
 		s.sgk.Close()
 	}
+	if s.schemaSnapshot != nil {
+		s.schemaSnapshot.Close()
+	}
 	s.closeOnlineDDL()
 	// when closing syncer by `stop-task`, remove active relay log from hub
 	s.removeActiveRelayLog()
@@ -3373,7 +3414,7 @@ func (s *Syncer) Update(ctx context.Context, cfg *config.SubTaskConfig) error {
 // checkpointID returns ID which used for checkpoint table.
 func (s *Syncer) checkpointID() string {
 	if len(s.cfg.SourceID) > 0 {
-		return s.cfg.SourceID
+		return s.cfg.TablePartitionID()
 	}
 	return strconv.FormatUint(uint64(s.cfg.ServerID), 10)
 }
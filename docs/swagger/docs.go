@@ -708,7 +708,7 @@ var doc = `{
         },
         "/api/v2/captures": {
             "get": {
-                "description": "list all captures in cdc cluster",
+                "description": "list all captures in cdc cluster, with the loads reported by\nthem in scheduler heartbeats",
                 "produces": [
                     "application/json"
                 ],
@@ -742,6 +742,215 @@ var doc = `{
                 }
             }
         },
+        "/api/v2/captures/{capture_id}/drain": {
+            "delete": {
+                "description": "stop draining a capture",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "capture",
+                    "v2"
+                ],
+                "summary": "Cancel draining a capture",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/captures/{capture_id}/graceful_drain": {
+            "post": {
+                "description": "drain all tables of a capture and wait until it can be shut down",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "capture",
+                    "v2"
+                ],
+                "summary": "Drain a capture gracefully",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "10m",
+                        "name": "timeout",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.DrainCaptureProgress"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/captures/{capture_id}/log": {
+            "post": {
+                "description": "change the log level of a capture globally or per module\ndynamically, the change can be reverted automatically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "capture",
+                    "v2"
+                ],
+                "summary": "Change the log level of a capture",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "log level",
+                        "name": "log_level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.LogLevelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/captures/{capture_id}/sorter/compact": {
+            "post": {
+                "description": "compact the sorter storage of a capture manually, so the\ndisk space held by deleted events, e.g. events of removed\nchangefeeds, can be reclaimed. It can be limited to a\nchangefeed or a table of the changefeed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "capture",
+                    "v2"
+                ],
+                "summary": "Compact the sorter storage of a capture",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "compaction scope",
+                        "name": "compact",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v2.SorterCompactReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.SorterCompactResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v2/changefeeds": {
             "get": {
                 "description": "list all changefeeds in cdc cluster",
@@ -992,12 +1201,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/changefeeds/{changefeed_id}/pause": {
-            "post": {
-                "description": "Pause a changefeed",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v2/changefeeds/{changefeed_id}/checkpoint_history": {
+            "get": {
+                "description": "get the checkpoint ts and resolved ts samples of a changefeed,\nwhich are recorded by the owner periodically. from and to are\nRFC3339 times, the last hour is returned by default.",
                 "produces": [
                     "application/json"
                 ],
@@ -1005,7 +1211,7 @@ var doc = `{
                     "changefeed",
                     "v2"
                 ],
-                "summary": "Pause a changefeed",
+                "summary": "Get the checkpoint history of a changefeed",
                 "parameters": [
                     {
                         "type": "string",
@@ -1019,13 +1225,28 @@ var doc = `{
                         "description": "default",
                         "name": "namespace",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02T15:04:05Z",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02T15:04:05Z",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.EmptyResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.CheckpointSample"
+                            }
                         }
                     },
                     "400": {
@@ -1043,9 +1264,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/changefeeds/{changefeed_id}/resume": {
+        "/api/v2/changefeeds/{changefeed_id}/pause": {
             "post": {
-                "description": "Resume a changefeed",
+                "description": "Pause a changefeed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1056,7 +1277,7 @@ var doc = `{
                     "changefeed",
                     "v2"
                 ],
-                "summary": "Resume a changefeed",
+                "summary": "Pause a changefeed",
                 "parameters": [
                     {
                         "type": "string",
@@ -1070,15 +1291,6 @@ var doc = `{
                         "description": "default",
                         "name": "namespace",
                         "in": "query"
-                    },
-                    {
-                        "description": "resume config",
-                        "name": "resumeConfig",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v2.ResumeChangefeedConfig"
-                        }
                     }
                 ],
                 "responses": {
@@ -1103,22 +1315,48 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/health": {
-            "get": {
-                "description": "Check the health status of a TiCDC cluster",
+        "/api/v2/changefeeds/{changefeed_id}/reanchor": {
+            "post": {
+                "description": "Resume a changefeed stopped in the upstream-rewound state,\ne.g. after a FLASHBACK CLUSTER or a BR restore, from a new\nstart ts. The current ts of the upstream is used if the\nstart ts is not specified.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "common",
+                    "changefeed",
                     "v2"
                 ],
-                "summary": "Check the health status of a TiCDC cluster",
+                "summary": "Re-anchor a rewound changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "re-anchor config",
+                        "name": "reanchorConfig",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ReanchorChangefeedConfig"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.EmptyResponse"
+                            "$ref": "#/definitions/v2.ReanchorChangefeedConfig"
                         }
                     },
                     "400": {
@@ -1136,9 +1374,901 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/log": {
+        "/api/v2/changefeeds/{changefeed_id}/resume": {
             "post": {
-                "description": "change TiCDC log level dynamically",
+                "description": "Resume a changefeed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Resume a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "resume config",
+                        "name": "resumeConfig",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.ResumeChangefeedConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/safepoints": {
+            "get": {
+                "description": "list auxiliary GC safepoint leases registered by external tools",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "List safepoint leases of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.SafePointLease"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "register an auxiliary GC safepoint tied to a changefeed, the\nsafepoint is the checkpoint of the changefeed if it is not\nspecified. Registering an existing service updates the lease.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Register a safepoint lease of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "safepoint lease config",
+                        "name": "lease",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.SafePointLeaseConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.SafePointLease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/safepoints/{service_id}": {
+            "put": {
+                "description": "renew an auxiliary GC safepoint lease with a new ttl",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Renew a safepoint lease of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "service_id",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "safepoint lease config, only ttl is used",
+                        "name": "lease",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.SafePointLeaseConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.SafePointLease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove an auxiliary GC safepoint lease",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Remove a safepoint lease of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "service_id",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/schedule_plan": {
+            "get": {
+                "description": "get the operations the scheduler of a changefeed would perform\non its current states, the operations are not performed.\nIf rebalance is true, a manual rebalance is planned as if it's\nrequested, to preview its impact before triggering it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Get the schedule operations planned for a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "plan a manual rebalance",
+                        "name": "rebalance",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.ScheduleOperation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/scheduler_snapshot": {
+            "get": {
+                "description": "dump a redacted snapshot of the in-memory states of the\nchangefeed scheduler in the owner, including captures,\nreplication sets, queued schedule requests and the\ncompatibility matrix. The snapshot can be replayed offline\nby ` + "`" + `cdc scheduler replay` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Dump the scheduler states of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/tables/pause": {
+            "post": {
+                "description": "Pause replication of specific tables of a changefeed at the\ncheckpoint ts of the changefeed. Paused tables are removed from\ncaptures, and the checkpoint ts of the changefeed does not\nadvance until they are resumed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Pause tables of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "tables to pause",
+                        "name": "pausedTablesConfig",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.PausedTablesConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/tables/resume": {
+            "post": {
+                "description": "Resume replication of paused tables of a changefeed. They are\nreplicated again from the checkpoint ts of the changefeed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Resume paused tables of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "tables to resume",
+                        "name": "pausedTablesConfig",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.PausedTablesConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/tables/{table_id}/events": {
+            "get": {
+                "description": "return the next events of a table which are mounted and\nready to be written to the downstream, so filters and\ntransformations can be verified without consuming the\ndownstream. The events are sampled from the capture\nreplicating the table. Column values are redacted unless\nredact is false.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Sample the events of a table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed ID",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "table ID",
+                        "name": "table_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of events, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "redact column values, true by default",
+                        "name": "redact",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.TableEvents"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/tables/{table_id}/regions": {
+            "get": {
+                "description": "list the regions of a table subscribed by the kv client of\nthe capture replicating the table, which can be used to find\nthe region holding back the resolved ts of the table, or to\ntrack the progress of the initial incremental scan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "List the subscribed regions of a table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed ID",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "table ID",
+                        "name": "table_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.RegionSubscriptions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/tables/{table_id}/reset": {
+            "post": {
+                "description": "Recreate replication of a table from the given ts. The table is\nremoved from its capture, which drops its sorted events, and\nthen added back from ts. The checkpoint ts of the changefeed is\nused if ts is not specified, and ts must not be smaller than it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Reset replication of a table of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "table ID",
+                        "name": "table_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the ts to replicate the table from",
+                        "name": "ts",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/warnings": {
+            "get": {
+                "description": "list the warnings of a changefeed seen in the last hour, the\nmost recently seen first. Repeated warnings with the same code\nand the same message except numbers are aggregated into one,\nwith the first seen time, the last seen time and the count.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "List the recent warnings of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.ChangefeedWarning"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/estimates": {
+            "post": {
+                "description": "estimate the events per second, the bandwidth to the sink and\nthe sorter disk space of a changefeed from the recent write\nstats of upstream regions, which helps capacity planning\nbefore the changefeed is created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Estimate the throughput and cost of a changefeed",
+                "parameters": [
+                    {
+                        "description": "estimate config",
+                        "name": "estimateConfig",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.EstimateConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedEstimate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/federation/changefeeds": {
+            "get": {
+                "description": "list changefeeds of the local cluster and the clusters of\nthe configured federation peers. A peer that can not be reached\nis returned with an error instead of failing the request.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "List changefeeds of all clusters in the federation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.FederatedCluster"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/health": {
+            "get": {
+                "description": "Check the health status of a TiCDC cluster. If deep is true,\netcd, PD, the sorter disk and changefeeds are checked as well,\nand a report of all checks is returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common",
+                    "v2"
+                ],
+                "summary": "Check the health status of a TiCDC cluster",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "deep",
+                        "name": "deep",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "10m",
+                        "name": "max_checkpoint_lag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/v2.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/log": {
+            "post": {
+                "description": "change TiCDC log level dynamically",
                 "consumes": [
                     "application/json"
                 ],
@@ -1177,6 +2307,63 @@ var doc = `{
                 }
             }
         },
+        "/api/v2/metrics/cluster_summary": {
+            "get": {
+                "description": "get the metrics summaries of all captures in the cluster.\nA capture that can not be reached is returned with an error\ninstead of failing the request.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common",
+                    "v2"
+                ],
+                "summary": "Get the metrics summaries of all captures",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.MetricsSummary"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/metrics/summary": {
+            "get": {
+                "description": "get the key health indicators of the capture, which are\ncomputed from the metrics of the capture, so that no\nPrometheus is required.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common",
+                    "v2"
+                ],
+                "summary": "Get the metrics summary of a capture",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.MetricsSummary"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v2/owner/resign": {
             "post": {
                 "description": "Notify the current owner to resign",
@@ -1304,6 +2491,79 @@ var doc = `{
                 }
             }
         },
+        "/api/v2/ready": {
+            "get": {
+                "description": "Check whether a TiCDC node is ready to accept tables, which\nis suitable for readiness probes. Unlike health, the request\nis not forwarded to the owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common",
+                    "v2"
+                ],
+                "summary": "Check whether a TiCDC node is ready",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sorter/compact": {
+            "post": {
+                "description": "compact the sorter storage of the capture handling the request\nmanually, so the disk space held by deleted events can be\nreclaimed. It can be limited to a changefeed or a table.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "capture",
+                    "v2"
+                ],
+                "summary": "Compact the sorter storage",
+                "parameters": [
+                    {
+                        "description": "compaction scope",
+                        "name": "compact",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v2.SorterCompactReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.SorterCompactResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v2/status": {
             "get": {
                 "description": "This API is a synchronous interface. If the request is successful,",
@@ -1339,9 +2599,45 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        }
+    },
+    "definitions": {
+        "config.AutoCreateTableConfig": {
+            "type": "object",
+            "properties": {
+                "charset-mapping": {
+                    "description": "CharsetMapping maps upstream charsets to the charsets of created\ntables. Collations are mapped to the ones of the new charsets with the\nsame suffix if they exist, or to the default ones.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "engine": {
+                    "description": "Engine is the storage engine of created tables, InnoDB is used if unset.",
+                    "type": "string"
+                }
+            }
+        },
+        "config.BurstAbsorptionConfig": {
+            "type": "object",
+            "properties": {
+                "batch-factor": {
+                    "type": "integer"
+                },
+                "cooldown": {
+                    "type": "string"
+                },
+                "enable": {
+                    "type": "boolean"
+                },
+                "min-events-per-second": {
+                    "type": "integer"
+                },
+                "ratio": {
+                    "type": "number"
+                }
+            }
+        },
         "config.CSVConfig": {
             "type": "object",
             "properties": {
@@ -1372,11 +2668,43 @@ var doc = `{
                 "flush-interval": {
                     "type": "string"
                 },
+                "flush-policies": {
+                    "description": "FlushPolicies overrides the flush policy of matched tables.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.CloudStorageFlushPolicy"
+                    }
+                },
+                "index-metadata": {
+                    "description": "IndexMetadata records the commit ts range and the key range of each\ndata file in the index file, so that consumers can prune data files\nby time range or key prefix.",
+                    "type": "boolean"
+                },
                 "worker-count": {
                     "type": "integer"
                 }
             }
         },
+        "config.CloudStorageFlushPolicy": {
+            "type": "object",
+            "properties": {
+                "file-row-count": {
+                    "description": "FileRowCount is the max number of rows in a data file, a file is\nflushed once it reaches the limit. 0 means no limit.",
+                    "type": "integer"
+                },
+                "file-size": {
+                    "type": "integer"
+                },
+                "flush-interval": {
+                    "type": "string"
+                },
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "config.CodecConfig": {
             "type": "object",
             "properties": {
@@ -1417,16 +2745,35 @@ var doc = `{
         "config.DispatchRule": {
             "type": "object",
             "properties": {
+                "columns": {
+                    "description": "Columns are the columns of the composite partition key, they are\nrequired if the partition rule is \"columns\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "dispatcher": {
                     "description": "Deprecated, please use PartitionRule.",
                     "type": "string"
                 },
+                "hash-function": {
+                    "description": "HashFunction is the function to compute partitions from partition keys,\nit's one of \"default\", \"murmur2\" and \"xxhash\". murmur2 and xxhash hash\nthe values of the key columns joined by commas, which is compatible\nwith the partitioners of Kafka clients.",
+                    "type": "string"
+                },
+                "index": {
+                    "description": "IndexName is the index whose values are the partition key if the\npartition rule is \"table+index\", the handle key is used if it's empty.",
+                    "type": "string"
+                },
                 "matcher": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "no-key-strategy": {
+                    "description": "NoKeyStrategy decides how to dispatch events of the tables without a\nprimary key or a not null unique key if the partition rule dispatches\nevents by index values, it's one of \"route-by-table\", \"route-by-all-columns\"\nand \"reject\". The default one is \"route-by-table\".",
+                    "type": "string"
+                },
                 "partition": {
                     "description": "PartitionRule is an alias added for DispatcherRule to mitigate confusions.\nIn the future release, the DispatcherRule is expected to be removed .",
                     "type": "string"
@@ -1439,6 +2786,9 @@ var doc = `{
         "config.KafkaConfig": {
             "type": "object",
             "properties": {
+                "adaptive-batching": {
+                    "type": "boolean"
+                },
                 "auto-create-topic": {
                     "type": "boolean"
                 },
@@ -1517,6 +2867,9 @@ var doc = `{
                 "sasl-oauth-audience": {
                     "type": "string"
                 },
+                "sasl-oauth-aws-region": {
+                    "type": "string"
+                },
                 "sasl-oauth-client-id": {
                     "type": "string"
                 },
@@ -1532,6 +2885,9 @@ var doc = `{
                         "type": "string"
                     }
                 },
+                "sasl-oauth-token-provider": {
+                    "type": "string"
+                },
                 "sasl-oauth-token-url": {
                     "type": "string"
                 },
@@ -1546,9 +2902,43 @@ var doc = `{
                 }
             }
         },
+        "config.MySQLAutoIncrementRule": {
+            "type": "object",
+            "properties": {
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mode": {
+                    "description": "Mode can be \"preserve\", \"strip\" or \"remap\".",
+                    "type": "string"
+                },
+                "offset": {
+                    "description": "Offset is added to the values in the remap mode.",
+                    "type": "integer"
+                }
+            }
+        },
         "config.MySQLConfig": {
             "type": "object",
             "properties": {
+                "adaptive-txn-row": {
+                    "description": "AdaptiveTxnRow tunes the max number of rows in a transaction of every\ntable by the observed commit throughput, max-txn-row is the initial value.",
+                    "type": "boolean"
+                },
+                "auto-increment-rules": {
+                    "description": "AutoIncrementRules decide how values of auto-increment and auto-random\ncolumns of matched tables are applied.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.MySQLAutoIncrementRule"
+                    }
+                },
+                "ddl-compatibility-check": {
+                    "description": "DDLCompatibilityCheck decides whether a DDL is checked against the\ndownstream schema before it's executed, it can be \"none\", \"warn\" or \"fail\".",
+                    "type": "string"
+                },
                 "enable-batch-dml": {
                     "type": "boolean"
                 },
@@ -1558,6 +2948,9 @@ var doc = `{
                 "enable-multi-statement": {
                     "type": "boolean"
                 },
+                "max-multi-insert-row": {
+                    "type": "integer"
+                },
                 "max-multi-update-row": {
                     "type": "integer"
                 },
@@ -1570,6 +2963,20 @@ var doc = `{
                 "read-timeout": {
                     "type": "string"
                 },
+                "session-variable-rules": {
+                    "description": "SessionVariableRules override session variables for matched tables.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.MySQLSessionVariableRule"
+                    }
+                },
+                "session-variables": {
+                    "description": "SessionVariables are session variables of the downstream used to write\nall tables, for example foreign_key_checks and sql_mode.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "ssl-ca": {
                     "type": "string"
                 },
@@ -1588,17 +2995,110 @@ var doc = `{
                 "timeout": {
                     "type": "string"
                 },
+                "unsupported-ddl-policy": {
+                    "description": "UnsupportedDDLPolicy decides how DDLs with constructs the downstream\ndoesn't support are handled, it can be \"fail\", \"skip\" or \"translate\".",
+                    "type": "string"
+                },
                 "worker-count": {
                     "type": "integer"
                 },
+                "worker-count-per-table": {
+                    "type": "integer"
+                },
+                "worker-groups": {
+                    "description": "WorkerGroups dedicate workers to matched tables.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.MySQLWorkerGroup"
+                    }
+                },
                 "write-timeout": {
                     "type": "string"
                 }
             }
         },
+        "config.MySQLSessionVariableRule": {
+            "type": "object",
+            "properties": {
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "config.MySQLWorkerGroup": {
+            "type": "object",
+            "properties": {
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max-txn-row": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "worker-count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "config.ResolvedTsSuppressionConfig": {
+            "type": "object",
+            "properties": {
+                "min-delta": {
+                    "type": "string"
+                },
+                "min-interval": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.RouteRule": {
+            "type": "object",
+            "properties": {
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target-schema": {
+                    "description": "TargetSchema is the downstream schema name, which can contain\nthe placeholder {schema}. Empty means the upstream schema name.",
+                    "type": "string"
+                },
+                "target-table": {
+                    "description": "TargetTable is the downstream table name, which can contain the\nplaceholders {schema} and {table}. Empty means the upstream table name.",
+                    "type": "string"
+                }
+            }
+        },
         "config.SinkConfig": {
             "type": "object",
             "properties": {
+                "auto-create-table": {
+                    "description": "AutoCreateTable makes the sink create missing downstream schemas and\ntables from the upstream definition at changefeed start and on CREATE\nTABLE DDLs. It is only available when the downstream is DB.",
+                    "type": "boolean"
+                },
+                "auto-create-table-config": {
+                    "description": "AutoCreateTableConfig controls how tables are created if AutoCreateTable\nis enabled.",
+                    "$ref": "#/definitions/config.AutoCreateTableConfig"
+                },
+                "burst-absorption": {
+                    "description": "BurstAbsorption controls how the sink absorbs sudden upstream write\nbursts of tables, e.g. batch jobs. It is available for all downstreams.",
+                    "$ref": "#/definitions/config.BurstAbsorptionConfig"
+                },
                 "cloud-storage-config": {
                     "$ref": "#/definitions/config.CloudStorageConfig"
                 },
@@ -1644,6 +3144,10 @@ var doc = `{
                     "description": "FileIndexWidth is only available when the downstream is Storage",
                     "type": "integer"
                 },
+                "generated-column-policy": {
+                    "description": "GeneratedColumnPolicy decides how stored generated columns are written\nto the sink, it can be \"skip\" or \"materialize\". By default they're\nskipped by DB sinks and materialized by other sinks. Values of virtual\ngenerated columns are not stored in TiKV, so they're always skipped.",
+                    "type": "string"
+                },
                 "kafka-config": {
                     "$ref": "#/definitions/config.KafkaConfig"
                 },
@@ -1662,6 +3166,17 @@ var doc = `{
                     "description": "Protocol is NOT available when the downstream is DB.",
                     "type": "string"
                 },
+                "resolved-ts-suppression": {
+                    "description": "ResolvedTsSuppression controls how often the resolved ts events are\nsent to the topics. It is only available when the downstream is MQ.",
+                    "$ref": "#/definitions/config.ResolvedTsSuppressionConfig"
+                },
+                "routes": {
+                    "description": "Routes rename the matched schemas and tables in the sink. Other rules\nof the sink, e.g. dispatchers, match the routed names.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.RouteRule"
+                    }
+                },
                 "safe-mode": {
                     "description": "SafeMode is only available when the downstream is DB.",
                     "type": "boolean"
@@ -1670,6 +3185,14 @@ var doc = `{
                     "description": "SchemaRegistry is only available when the downstream is MQ using avro protocol.",
                     "type": "string"
                 },
+                "slow-start": {
+                    "description": "SlowStart controls how the sink ramps up after the changefeed is\nresumed with a large backlog. It is available for all downstreams.",
+                    "$ref": "#/definitions/config.SlowStartConfig"
+                },
+                "tee-sink-uri": {
+                    "description": "TeeSinkURI is the URI of another sink to which all the events are\nwritten as well. The checkpoint of the changefeed only advances after\nboth sinks acknowledge the events. The protocol and the transaction\natomicity of the tee sink are taken from its own URI.",
+                    "type": "string"
+                },
                 "terminator": {
                     "description": "Terminator is NOT available when the downstream is DB.",
                     "type": "string"
@@ -1679,6 +3202,23 @@ var doc = `{
                 }
             }
         },
+        "config.SlowStartConfig": {
+            "type": "object",
+            "properties": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "max-flush-latency": {
+                    "type": "string"
+                },
+                "min-backlog": {
+                    "type": "string"
+                },
+                "step-interval": {
+                    "type": "string"
+                }
+            }
+        },
         "model.Capture": {
             "type": "object",
             "properties": {
@@ -1693,6 +3233,31 @@ var doc = `{
                 },
                 "is_owner": {
                     "type": "boolean"
+                },
+                "load": {
+                    "$ref": "#/definitions/model.CaptureLoad"
+                }
+            }
+        },
+        "model.CaptureLoad": {
+            "type": "object",
+            "properties": {
+                "changefeeds": {
+                    "description": "Changefeeds are sorted by namespace and ID.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ChangefeedLoad"
+                    }
+                },
+                "cpu_usage": {
+                    "description": "CPUUsage is the CPU usage of the capture process, 100 means one core\nis fully used.",
+                    "type": "number"
+                },
+                "memory_bytes": {
+                    "type": "integer"
+                },
+                "sorter_disk_bytes": {
+                    "type": "integer"
                 }
             }
         },
@@ -1855,6 +3420,24 @@ var doc = `{
                 }
             }
         },
+        "model.ChangefeedLoad": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "sink_rows_per_second": {
+                    "description": "SinkRowsPerSecond is the number of rows written to the sink per second.",
+                    "type": "number"
+                },
+                "table_count": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.HTTPError": {
             "type": "object",
             "properties": {
@@ -1920,47 +3503,104 @@ var doc = `{
                 }
             }
         },
-        "model.ServerStatus": {
+        "model.ServerStatus": {
+            "type": "object",
+            "properties": {
+                "cluster_id": {
+                    "type": "string"
+                },
+                "git_hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_owner": {
+                    "type": "boolean"
+                },
+                "liveness": {
+                    "type": "integer"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TableOperation": {
+            "type": "object",
+            "properties": {
+                "boundary_ts": {
+                    "description": "if the operation is a delete operation, BoundaryTs is checkpoint ts\nif the operation is an add operation, BoundaryTs is start ts",
+                    "type": "integer"
+                },
+                "delete": {
+                    "type": "boolean"
+                },
+                "flag": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.AffinityRule": {
+            "type": "object",
+            "properties": {
+                "captures": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v2.AutoCreateTableConfig": {
             "type": "object",
             "properties": {
-                "cluster_id": {
-                    "type": "string"
-                },
-                "git_hash": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_owner": {
-                    "type": "boolean"
-                },
-                "liveness": {
-                    "type": "integer"
-                },
-                "pid": {
-                    "type": "integer"
+                "charset_mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "version": {
+                "engine": {
                     "type": "string"
                 }
             }
         },
-        "model.TableOperation": {
+        "v2.BurstAbsorptionConfig": {
             "type": "object",
             "properties": {
-                "boundary_ts": {
-                    "description": "if the operation is a delete operation, BoundaryTs is checkpoint ts\nif the operation is an add operation, BoundaryTs is start ts",
+                "batch_factor": {
                     "type": "integer"
                 },
-                "delete": {
+                "cooldown": {
+                    "type": "string"
+                },
+                "enable": {
                     "type": "boolean"
                 },
-                "flag": {
+                "min_events_per_second": {
                     "type": "integer"
                 },
-                "status": {
-                    "type": "integer"
+                "ratio": {
+                    "type": "number"
                 }
             }
         },
@@ -1981,6 +3621,20 @@ var doc = `{
                 }
             }
         },
+        "v2.CanaryConfig": {
+            "type": "object",
+            "properties": {
+                "sample_ratio": {
+                    "type": "number"
+                },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "v2.Capture": {
             "type": "object",
             "properties": {
@@ -1995,6 +3649,17 @@ var doc = `{
                 },
                 "is_owner": {
                     "type": "boolean"
+                },
+                "labels": {
+                    "description": "Labels are used to match the capture in table affinity rules.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "load": {
+                    "description": "Load is the latest load reported by the capture in scheduler\nheartbeats, it's nil if the capture hasn't reported yet.",
+                    "$ref": "#/definitions/model.CaptureLoad"
                 }
             }
         },
@@ -2026,9 +3691,15 @@ var doc = `{
                 "id": {
                     "type": "string"
                 },
+                "keyspace": {
+                    "type": "string"
+                },
                 "namespace": {
                     "type": "string"
                 },
+                "remove_topics": {
+                    "type": "boolean"
+                },
                 "resolved_ts": {
                     "type": "integer"
                 },
@@ -2052,6 +3723,9 @@ var doc = `{
                         "$ref": "#/definitions/model.CaptureTaskStatus"
                     }
                 },
+                "ttl": {
+                    "type": "string"
+                },
                 "upstream_id": {
                     "type": "integer"
                 }
@@ -2072,6 +3746,9 @@ var doc = `{
                 "id": {
                     "type": "string"
                 },
+                "keyspace": {
+                    "type": "string"
+                },
                 "namespace": {
                     "type": "string"
                 },
@@ -2104,6 +3781,10 @@ var doc = `{
                 "key_path": {
                     "type": "string"
                 },
+                "keyspace": {
+                    "description": "Keyspace scopes the changefeed to a keyspace of the upstream cluster,\nit can not be changed after the changefeed is created.",
+                    "type": "string"
+                },
                 "namespace": {
                     "type": "string"
                 },
@@ -2113,6 +3794,10 @@ var doc = `{
                         "type": "string"
                     }
                 },
+                "remove_topics": {
+                    "description": "RemoveTopics removes the Kafka topics of the changefeed once it is\nfinished because of TTL or target ts.",
+                    "type": "boolean"
+                },
                 "replica_config": {
                     "$ref": "#/definitions/v2.ReplicaConfig"
                 },
@@ -2124,16 +3809,92 @@ var doc = `{
                 },
                 "target_ts": {
                     "type": "integer"
+                },
+                "ttl": {
+                    "description": "TTL removes the changefeed automatically after it elapses, all data\nbefore then are replicated before the changefeed is removed.",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.ChangefeedEstimate": {
+            "type": "object",
+            "properties": {
+                "events_per_second": {
+                    "description": "EventsPerSecond is the estimated number of row changed events per second.",
+                    "type": "number"
+                },
+                "regions": {
+                    "type": "integer"
+                },
+                "sink_bytes_per_second": {
+                    "description": "SinkBytesPerSecond is the estimated bandwidth to the sink.",
+                    "type": "number"
+                },
+                "sorter_disk_bytes": {
+                    "description": "SorterDiskBytes is the estimated disk space the sorter needs to buffer\nthe events in the window.",
+                    "type": "integer"
+                },
+                "tables": {
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.ChangefeedMetricsSummary": {
+            "type": "object",
+            "properties": {
+                "checkpoint_lag": {
+                    "description": "CheckpointLag is only reported by the owner.",
+                    "$ref": "#/definitions/v2.LagDistribution"
+                },
+                "e2e_latency_p99_seconds": {
+                    "description": "E2ELatencyP99 is the p99 latency from the commit ts of events to\nbeing acked by the sink, of all tables of the changefeed.",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "region_count": {
+                    "type": "integer"
+                },
+                "sink_flush_p99_seconds": {
+                    "type": "number"
+                },
+                "table_count": {
+                    "type": "integer"
                 }
             }
         },
         "v2.ChangefeedSchedulerConfig": {
             "type": "object",
             "properties": {
+                "affinity": {
+                    "description": "Affinity pins the matched tables to the matched captures.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.AffinityRule"
+                    }
+                },
+                "capture_labels": {
+                    "description": "CaptureLabels constrains tables to the captures carrying the labels.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "enable_table_across_nodes": {
                     "description": "EnableTableAcrossNodes set true to split one table to multiple spans and\ndistribute to multiple TiCDC nodes.",
                     "type": "boolean"
                 },
+                "policy": {
+                    "description": "Policy is the name of the policy which schedules tables automatically.",
+                    "type": "string"
+                },
                 "region_threshold": {
                     "description": "RegionThreshold is the region count threshold of splitting a table.",
                     "type": "integer"
@@ -2141,6 +3902,52 @@ var doc = `{
                 "write_key_threshold": {
                     "description": "WriteKeyThreshold is the written keys threshold of splitting a table.",
                     "type": "integer"
+                },
+                "zone_label": {
+                    "description": "ZoneLabel is the label name of availability zones of TiKV stores and\ncaptures, tables are preferably replicated in the zone of their leaders.",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.ChangefeedWarning": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "first_seen": {
+                    "type": "string"
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message and Address are the ones of the latest occurrence.",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.CheckpointSample": {
+            "type": "object",
+            "properties": {
+                "checkpoint_lag": {
+                    "description": "CheckpointLag is the lag of the checkpoint ts at the time of the\nsample in milliseconds.",
+                    "type": "integer"
+                },
+                "checkpoint_ts": {
+                    "type": "integer"
+                },
+                "resolved_ts": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
                 }
             }
         },
@@ -2153,11 +3960,40 @@ var doc = `{
                 "flush_interval": {
                     "type": "string"
                 },
+                "flush_policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.CloudStorageFlushPolicy"
+                    }
+                },
+                "index_metadata": {
+                    "type": "boolean"
+                },
                 "worker_count": {
                     "type": "integer"
                 }
             }
         },
+        "v2.CloudStorageFlushPolicy": {
+            "type": "object",
+            "properties": {
+                "file_row_count": {
+                    "type": "integer"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "flush_interval": {
+                    "type": "string"
+                },
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "v2.CodecConfig": {
             "type": "object",
             "properties": {
@@ -2210,6 +4046,12 @@ var doc = `{
                 "storage": {
                     "type": "string"
                 },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "use_file_backend": {
                     "type": "boolean"
                 }
@@ -2218,12 +4060,27 @@ var doc = `{
         "v2.DispatchRule": {
             "type": "object",
             "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hash_function": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "string"
+                },
                 "matcher": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "no_key_strategy": {
+                    "type": "string"
+                },
                 "partition": {
                     "type": "string"
                 },
@@ -2232,9 +4089,77 @@ var doc = `{
                 }
             }
         },
+        "v2.DrainCaptureProgress": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
+                },
+                "current_table_count": {
+                    "description": "CurrentTableCount is the count of tables left on the capture.",
+                    "type": "integer"
+                },
+                "forced": {
+                    "description": "Forced is true if the draining timed out and tables are moved out of\nthe capture forcibly.",
+                    "type": "boolean"
+                },
+                "ready": {
+                    "description": "Ready is true if the capture has no table and can be shut down.",
+                    "type": "boolean"
+                }
+            }
+        },
         "v2.EmptyResponse": {
             "type": "object"
         },
+        "v2.EstimateConfig": {
+            "type": "object",
+            "properties": {
+                "ca_path": {
+                    "type": "string"
+                },
+                "cert_allowed_cn": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cert_path": {
+                    "type": "string"
+                },
+                "key_path": {
+                    "type": "string"
+                },
+                "pd_addrs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "replica_config": {
+                    "$ref": "#/definitions/v2.ReplicaConfig"
+                },
+                "window": {
+                    "description": "Window is the duration of events the sorter buffers, e.g. how long\nthe changefeed is expected to fall behind at most.",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.EventColumn": {
+            "type": "object",
+            "properties": {
+                "handle_key": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value is nil if the value is NULL.",
+                    "type": "string"
+                }
+            }
+        },
         "v2.EventFilterRule": {
             "type": "object",
             "properties": {
@@ -2255,20 +4180,44 @@ var doc = `{
                     "description": "regular expression",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "string"
+                    }
+                },
+                "ignore_update_new_value_expr": {
+                    "type": "string"
+                },
+                "ignore_update_old_value_expr": {
+                    "type": "string"
+                },
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v2.FederatedCluster": {
+            "type": "object",
+            "properties": {
+                "changefeeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.ChangefeedCommonInfo"
                     }
                 },
-                "ignore_update_new_value_expr": {
+                "cluster_id": {
                     "type": "string"
                 },
-                "ignore_update_old_value_expr": {
+                "endpoint": {
                     "type": "string"
                 },
-                "matcher": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "error": {
+                    "description": "Error is set if the changefeeds of the cluster can not be fetched.",
+                    "type": "string"
+                },
+                "is_local": {
+                    "type": "boolean"
                 }
             }
         },
@@ -2315,6 +4264,12 @@ var doc = `{
                         "type": "integer"
                     }
                 },
+                "origin_filters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.OriginFilterRule"
+                    }
+                },
                 "rules": {
                     "type": "array",
                     "items": {
@@ -2323,6 +4278,34 @@ var doc = `{
                 }
             }
         },
+        "v2.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "healthy": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.HealthCheck"
+                    }
+                },
+                "healthy": {
+                    "type": "boolean"
+                }
+            }
+        },
         "v2.IntegrityConfig": {
             "type": "object",
             "properties": {
@@ -2337,6 +4320,9 @@ var doc = `{
         "v2.KafkaConfig": {
             "type": "object",
             "properties": {
+                "adaptive_batching": {
+                    "type": "boolean"
+                },
                 "auto_create_topic": {
                     "type": "boolean"
                 },
@@ -2415,6 +4401,9 @@ var doc = `{
                 "sasl_oauth_audience": {
                     "type": "string"
                 },
+                "sasl_oauth_aws_region": {
+                    "type": "string"
+                },
                 "sasl_oauth_client_id": {
                     "type": "string"
                 },
@@ -2430,109 +4419,352 @@ var doc = `{
                         "type": "string"
                     }
                 },
+                "sasl_oauth_token_provider": {
+                    "type": "string"
+                },
                 "sasl_oauth_token_url": {
                     "type": "string"
                 },
                 "sasl_password": {
                     "type": "string"
                 },
-                "sasl_user": {
+                "sasl_user": {
+                    "type": "string"
+                },
+                "write_timeout": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.LagDistribution": {
+            "type": "object",
+            "properties": {
+                "current_seconds": {
+                    "type": "number"
+                },
+                "p50_seconds": {
+                    "type": "number"
+                },
+                "p90_seconds": {
+                    "type": "number"
+                },
+                "p99_seconds": {
+                    "type": "number"
+                }
+            }
+        },
+        "v2.LogLevelReq": {
+            "type": "object",
+            "properties": {
+                "log_level": {
+                    "type": "string"
+                },
+                "module": {
+                    "description": "Module is one of scheduler, sink, kvclient and sorter, whose log level\nis changed separately. Empty means the global log level.",
+                    "type": "string"
+                },
+                "revert_after_minutes": {
+                    "description": "RevertAfterMinutes reverts the log level after the given minutes,\n0 means never.",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.MetricsSummary": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "capture_id": {
+                    "type": "string"
+                },
+                "changefeeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.ChangefeedMetricsSummary"
+                    }
+                },
+                "error": {
+                    "description": "Error is set if the summary of the capture can not be fetched.",
+                    "type": "string"
+                },
+                "is_owner": {
+                    "type": "boolean"
+                },
+                "sorter_backlog_bytes": {
+                    "description": "SorterBacklogBytes is the size of the data buffered in the sorter,\nboth in memory and on disk.",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.MounterConfig": {
+            "type": "object",
+            "properties": {
+                "worker_num": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.MySQLAutoIncrementRule": {
+            "type": "object",
+            "properties": {
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.MySQLConfig": {
+            "type": "object",
+            "properties": {
+                "adaptive_txn_row": {
+                    "type": "boolean"
+                },
+                "auto_increment_rules": {
+                    "description": "AutoIncrementRules decide how values of auto-increment columns of\nmatched tables are applied.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.MySQLAutoIncrementRule"
+                    }
+                },
+                "ddl_compatibility_check": {
+                    "type": "string"
+                },
+                "enable_batch_dml": {
+                    "type": "boolean"
+                },
+                "enable_cache_prepared_statement": {
+                    "type": "boolean"
+                },
+                "enable_multi_statement": {
+                    "type": "boolean"
+                },
+                "max_multi_insert_row_count": {
+                    "type": "integer"
+                },
+                "max_multi_update_row_count": {
+                    "type": "integer"
+                },
+                "max_multi_update_row_size": {
+                    "type": "integer"
+                },
+                "max_txn_row": {
+                    "type": "integer"
+                },
+                "read_timeout": {
+                    "type": "string"
+                },
+                "session_variable_rules": {
+                    "description": "SessionVariableRules override session variables for matched tables.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.MySQLSessionVariableRule"
+                    }
+                },
+                "session_variables": {
+                    "description": "SessionVariables are session variables of the downstream used to write\nall tables.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "ssl_ca": {
+                    "type": "string"
+                },
+                "ssl_cert": {
+                    "type": "string"
+                },
+                "ssl_key": {
+                    "type": "string"
+                },
+                "tidb_txn_mode": {
+                    "type": "string"
+                },
+                "time_zone": {
+                    "type": "string"
+                },
+                "timeout": {
+                    "type": "string"
+                },
+                "unsupported_ddl_policy": {
+                    "type": "string"
+                },
+                "worker_count": {
+                    "type": "integer"
+                },
+                "worker_count_per_table": {
+                    "type": "integer"
+                },
+                "worker_groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.MySQLWorkerGroup"
+                    }
+                },
+                "write_timeout": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.MySQLSessionVariableRule": {
+            "type": "object",
+            "properties": {
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v2.MySQLWorkerGroup": {
+            "type": "object",
+            "properties": {
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_txn_row": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "worker_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.OriginFilterRule": {
+            "type": "object",
+            "properties": {
+                "ignore_origins": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_column": {
+                    "type": "string"
+                },
+                "tracker": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.PausedTablesConfig": {
+            "type": "object",
+            "properties": {
+                "table_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "v2.ProcessorCommonInfo": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
+                },
+                "changefeed_id": {
                     "type": "string"
                 },
-                "write_timeout": {
+                "namespace": {
                     "type": "string"
                 }
             }
         },
-        "v2.LogLevelReq": {
+        "v2.ProcessorDetail": {
             "type": "object",
             "properties": {
-                "log_level": {
-                    "type": "string"
+                "table_ids": {
+                    "description": "All table ids that this processor are replicating.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "v2.MounterConfig": {
+        "v2.ReanchorChangefeedConfig": {
             "type": "object",
             "properties": {
-                "worker_num": {
+                "start_ts": {
+                    "description": "StartTs is the new start ts of the changefeed, the current ts of the\nupstream is used if it's 0.",
                     "type": "integer"
                 }
             }
         },
-        "v2.MySQLConfig": {
+        "v2.RegionSubscription": {
             "type": "object",
             "properties": {
-                "enable_batch_dml": {
+                "initialized": {
                     "type": "boolean"
                 },
-                "enable_cache_prepared_statement": {
-                    "type": "boolean"
+                "last_event_time": {
+                    "type": "string"
                 },
-                "enable_multi_statement": {
-                    "type": "boolean"
+                "leader_store_addr": {
+                    "type": "string"
                 },
-                "max_multi_update_row_count": {
+                "leader_store_id": {
                     "type": "integer"
                 },
-                "max_multi_update_row_size": {
+                "pending_scan_bytes": {
                     "type": "integer"
                 },
-                "max_txn_row": {
+                "region_id": {
                     "type": "integer"
                 },
-                "read_timeout": {
-                    "type": "string"
-                },
-                "ssl_ca": {
-                    "type": "string"
-                },
-                "ssl_cert": {
-                    "type": "string"
-                },
-                "ssl_key": {
-                    "type": "string"
-                },
-                "tidb_txn_mode": {
-                    "type": "string"
-                },
-                "time_zone": {
-                    "type": "string"
-                },
-                "timeout": {
-                    "type": "string"
-                },
-                "worker_count": {
+                "resolved_ts": {
                     "type": "integer"
-                },
-                "write_timeout": {
-                    "type": "string"
                 }
             }
         },
-        "v2.ProcessorCommonInfo": {
+        "v2.RegionSubscriptions": {
             "type": "object",
             "properties": {
                 "capture_id": {
                     "type": "string"
                 },
-                "changefeed_id": {
-                    "type": "string"
-                },
-                "namespace": {
-                    "type": "string"
-                }
-            }
-        },
-        "v2.ProcessorDetail": {
-            "type": "object",
-            "properties": {
-                "table_ids": {
-                    "description": "All table ids that this processor are replicating.",
+                "regions": {
+                    "description": "Regions are sorted by resolved ts in ascending order, so the first one\nis the region holding back the resolved ts of the table.",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/v2.RegionSubscription"
                     }
+                },
+                "snapshot_progress": {
+                    "description": "SnapshotProgress is the percentage of regions whose incremental scan\nis finished, the table keeps preparing until it reaches 100.",
+                    "type": "number"
+                },
+                "table_id": {
+                    "type": "integer"
                 }
             }
         },
@@ -2542,6 +4774,12 @@ var doc = `{
                 "bdr_mode": {
                     "type": "boolean"
                 },
+                "bootstrap_ddl": {
+                    "type": "boolean"
+                },
+                "canary": {
+                    "$ref": "#/definitions/v2.CanaryConfig"
+                },
                 "case_sensitive": {
                     "type": "boolean"
                 },
@@ -2557,6 +4795,12 @@ var doc = `{
                 "enable_sync_point": {
                     "type": "boolean"
                 },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "filter": {
                     "$ref": "#/definitions/v2.FilterConfig"
                 },
@@ -2575,6 +4819,15 @@ var doc = `{
                 "mounter": {
                     "$ref": "#/definitions/v2.MounterConfig"
                 },
+                "resolved_ts_intervals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.ResolvedTsIntervalRule"
+                    }
+                },
+                "resource_group": {
+                    "type": "string"
+                },
                 "scheduler": {
                     "$ref": "#/definitions/v2.ChangefeedSchedulerConfig"
                 },
@@ -2589,6 +4842,31 @@ var doc = `{
                 }
             }
         },
+        "v2.ResolvedTsIntervalRule": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "type": "string"
+                },
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v2.ResolvedTsSuppressionConfig": {
+            "type": "object",
+            "properties": {
+                "min_delta": {
+                    "type": "string"
+                },
+                "min_interval": {
+                    "type": "string"
+                }
+            }
+        },
         "v2.ResumeChangefeedConfig": {
             "type": "object",
             "properties": {
@@ -2618,6 +4896,23 @@ var doc = `{
                 }
             }
         },
+        "v2.RouteRule": {
+            "type": "object",
+            "properties": {
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target_schema": {
+                    "type": "string"
+                },
+                "target_table": {
+                    "type": "string"
+                }
+            }
+        },
         "v2.RunningError": {
             "type": "object",
             "properties": {
@@ -2635,6 +4930,64 @@ var doc = `{
                 }
             }
         },
+        "v2.SafePointLease": {
+            "type": "object",
+            "properties": {
+                "expire_at": {
+                    "type": "string"
+                },
+                "safe_point": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "string"
+                },
+                "ttl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.SafePointLeaseConfig": {
+            "type": "object",
+            "properties": {
+                "safe_point": {
+                    "description": "SafePoint is the checkpoint of the changefeed if it is not specified.",
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "string"
+                },
+                "ttl": {
+                    "description": "TTL is the lease ttl in seconds.",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.ScheduleOperation": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From and To are the captures the span is moved from and to, From is\nempty for adding and To is empty for removing.",
+                    "type": "string"
+                },
+                "scheduler": {
+                    "type": "string"
+                },
+                "span": {
+                    "type": "string"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is one of \"add\", \"remove\" and \"move\".",
+                    "type": "string"
+                }
+            }
+        },
         "v2.ServerStatus": {
             "type": "object",
             "properties": {
@@ -2664,6 +5017,15 @@ var doc = `{
         "v2.SinkConfig": {
             "type": "object",
             "properties": {
+                "auto_create_table": {
+                    "type": "boolean"
+                },
+                "auto_create_table_config": {
+                    "$ref": "#/definitions/v2.AutoCreateTableConfig"
+                },
+                "burst_absorption": {
+                    "$ref": "#/definitions/v2.BurstAbsorptionConfig"
+                },
                 "cloud_storage_config": {
                     "$ref": "#/definitions/v2.CloudStorageConfig"
                 },
@@ -2700,6 +5062,9 @@ var doc = `{
                 "file_index_width": {
                     "type": "integer"
                 },
+                "generated_column_policy": {
+                    "type": "string"
+                },
                 "kafka_config": {
                     "$ref": "#/definitions/v2.KafkaConfig"
                 },
@@ -2715,12 +5080,27 @@ var doc = `{
                 "protocol": {
                     "type": "string"
                 },
+                "resolved_ts_suppression": {
+                    "$ref": "#/definitions/v2.ResolvedTsSuppressionConfig"
+                },
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.RouteRule"
+                    }
+                },
                 "safe_mode": {
                     "type": "boolean"
                 },
                 "schema_registry": {
                     "type": "string"
                 },
+                "slow_start": {
+                    "$ref": "#/definitions/v2.SlowStartConfig"
+                },
+                "tee_sink_uri": {
+                    "type": "string"
+                },
                 "terminator": {
                     "type": "string"
                 },
@@ -2729,6 +5109,52 @@ var doc = `{
                 }
             }
         },
+        "v2.SlowStartConfig": {
+            "type": "object",
+            "properties": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "max_flush_latency": {
+                    "type": "string"
+                },
+                "min_backlog": {
+                    "type": "string"
+                },
+                "step_interval": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.SorterCompactReq": {
+            "type": "object",
+            "properties": {
+                "changefeed_id": {
+                    "description": "ChangefeedID limits the compaction to the changefeed if it's not empty.",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace is the namespace of ChangefeedID, \"default\" if it's empty.",
+                    "type": "string"
+                },
+                "table_id": {
+                    "description": "TableID limits the compaction to the table of the changefeed if it's\nnot nil, and ChangefeedID must be set then.",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.SorterCompactResp": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
+                },
+                "reclaimed_bytes": {
+                    "description": "ReclaimedBytes is the approximate disk space reclaimed by the\ncompaction.",
+                    "type": "integer"
+                }
+            }
+        },
         "v2.Table": {
             "type": "object",
             "properties": {
@@ -2741,6 +5167,60 @@ var doc = `{
                     "type": "string"
                 }
             }
+        },
+        "v2.TableEvent": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.EventColumn"
+                    }
+                },
+                "commit_ts": {
+                    "type": "integer"
+                },
+                "pre_columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.EventColumn"
+                    }
+                },
+                "schema": {
+                    "type": "string"
+                },
+                "start_ts": {
+                    "type": "integer"
+                },
+                "table": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is one of insert, update and delete.",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.TableEvents": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.TableEvent"
+                    }
+                },
+                "redacted": {
+                    "description": "Redacted is true if the column values are redacted.",
+                    "type": "boolean"
+                },
+                "table_id": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -127,7 +127,7 @@ func newDMWorker(
 		cfg:            dmSubtaskCfg,
 		stage:          metadata.StageInit,
 		workerType:     workerType,
-		taskID:         dmSubtaskCfg.SourceID,
+		taskID:         dmSubtaskCfg.TablePartitionID(),
 		masterID:       masterID,
 		unitHolder:     newUnitHolderImpl(workerType, dmSubtaskCfg),
 		autoResume:     autoResume,
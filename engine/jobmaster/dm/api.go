@@ -19,6 +19,7 @@ import (
 	"sync"
 	"time"
 
+	dmconfig "github.com/pingcap/tiflow/dm/config"
 	frameModel "github.com/pingcap/tiflow/engine/framework/model"
 	"github.com/pingcap/tiflow/engine/jobmaster/dm/config"
 	"github.com/pingcap/tiflow/engine/jobmaster/dm/metadata"
@@ -72,8 +73,10 @@ func (jm *JobMaster) QueryJobStatus(ctx context.Context, tasks []string) (*JobSt
 	job := jobState.(*metadata.Job)
 
 	if len(tasks) == 0 {
-		for task := range job.Tasks {
-			tasks = append(tasks, task)
+		for task, t := range job.Tasks {
+			for partition := 0; partition < t.SyncWorkerCount(); partition++ {
+				tasks = append(tasks, dmconfig.TablePartitionID(task, partition))
+			}
 		}
 	}
 
@@ -117,7 +120,8 @@ func (jm *JobMaster) QueryJobStatus(ctx context.Context, tasks []string) (*JobSt
 			)
 
 			// task not exist
-			if t, ok := job.Tasks[taskID]; !ok {
+			parentID, partition := splitWorkerTaskID(taskID)
+			if t, ok := job.Tasks[parentID]; !ok || partition >= t.SyncWorkerCount() {
 				queryStatusResp = &dmpkg.QueryStatusResponse{ErrorMsg: fmt.Sprintf("task %s for job not found", taskID)}
 			} else {
 				expectedStage = t.Stage
@@ -19,9 +19,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/coreos/go-semver/semver"
+	"github.com/go-mysql-org/go-mysql/mysql"
 	"github.com/pingcap/tidb-tools/pkg/dbutil"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/meta/autoid"
@@ -31,8 +33,10 @@ import (
 	regexprrouter "github.com/pingcap/tidb/util/regexpr-router"
 	router "github.com/pingcap/tidb/util/table-router"
 	dmconfig "github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/binlog"
 	"github.com/pingcap/tiflow/dm/pkg/conn"
 	"github.com/pingcap/tiflow/dm/pkg/cputil"
+	"github.com/pingcap/tiflow/dm/pkg/gtid"
 	"github.com/pingcap/tiflow/engine/framework"
 	frameModel "github.com/pingcap/tiflow/engine/framework/model"
 	"github.com/pingcap/tiflow/engine/jobmaster/dm/bootstrap"
@@ -80,6 +84,8 @@ type Agent interface {
 	FetchAllDoTables(ctx context.Context, cfg *config.JobCfg) (map[metadata.TargetTable][]metadata.SourceTable, error)
 	// FetchTableStmt fetch create table statement from checkpoint.
 	FetchTableStmt(ctx context.Context, jobID string, cfg *config.JobCfg, sourceTable metadata.SourceTable) (string, error)
+	// HandoffSyncCheckpoint hands off sync checkpoints of a task from `from` sync workers to `to` sync workers.
+	HandoffSyncCheckpoint(ctx context.Context, cfg *config.TaskCfg, from, to int) error
 }
 
 // AgentImpl implements Agent
@@ -269,6 +275,19 @@ func (c *AgentImpl) FetchTableStmt(ctx context.Context, jobID string, cfg *confi
 	return conn.CreateTableSQLToOneRow(result.String()), nil
 }
 
+// HandoffSyncCheckpoint implements Agent.HandoffSyncCheckpoint
+// It should be called after all old sync workers are stopped, and before new sync workers are created.
+func (c *AgentImpl) HandoffSyncCheckpoint(ctx context.Context, cfg *config.TaskCfg, from, to int) error {
+	c.logger.Info("hand off sync checkpoint", zap.String("task", cfg.Upstreams[0].SourceID), zap.Int("from", from), zap.Int("to", to))
+	db, err := conn.GetDownstreamDB(cfg.TargetDB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer db.Close()
+
+	return handoffSyncCheckpoint(ctx, c.jobID, cfg, db, from, to)
+}
+
 func createMetaDatabase(ctx context.Context, cfg *config.JobCfg, db *conn.BaseDB) error {
 	query := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", dbutil.ColumnName(cfg.MetaSchema))
 	_, err := db.DB.ExecContext(ctx, query)
@@ -341,7 +360,8 @@ func isSyncFresh(ctx context.Context, jobID string, taskCfg *config.TaskCfg, db
 	// nolint:gosec
 	query := fmt.Sprintf("SELECT 1 FROM %s WHERE `id` = ? AND `is_global` = true", syncTableName(jobID, taskCfg.ToJobCfg()))
 	var status string
-	err := db.DB.QueryRowContext(ctx, query, taskCfg.Upstreams[0].SourceID).Scan(&status)
+	id := dmconfig.TablePartitionID(taskCfg.Upstreams[0].SourceID, taskCfg.TablePartition)
+	err := db.DB.QueryRowContext(ctx, query, id).Scan(&status)
 	switch {
 	case err == nil:
 		return false, nil
@@ -351,3 +371,151 @@ func isSyncFresh(ctx context.Context, jobID string, taskCfg *config.TaskCfg, db
 		return false, err
 	}
 }
+
+type syncCheckpointRow struct {
+	id           string
+	schema       string
+	table        string
+	binlogName   sql.NullString
+	binlogPos    sql.NullInt64
+	binlogGTID   sql.NullString
+	exitSafeName sql.NullString
+	exitSafePos  sql.NullInt64
+	exitSafeGTID sql.NullString
+	tableInfo    []byte
+	isGlobal     sql.NullBool
+}
+
+// handoffSyncCheckpoint rewrites sync checkpoints of old sync workers to new ones.
+// Table checkpoints are moved to the sync worker which the table belongs to now.
+// All new sync workers start from the earliest global checkpoint of old ones, and
+// run in safe mode until the latest one, since events between them may be
+// replicated by some of old sync workers.
+func handoffSyncCheckpoint(ctx context.Context, jobID string, taskCfg *config.TaskCfg, db *conn.BaseDB, from, to int) error {
+	var (
+		upstream  = taskCfg.Upstreams[0]
+		tableName = syncTableName(jobID, taskCfg.ToJobCfg())
+		ids       = make([]interface{}, 0, from+to)
+	)
+	for i := 0; i < from || i < to; i++ {
+		ids = append(ids, dmconfig.TablePartitionID(upstream.SourceID, i))
+	}
+	placeholders := func(n int) string {
+		return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+	}
+
+	// nolint:gosec
+	query := fmt.Sprintf("SELECT `id`, `cp_schema`, `cp_table`, `binlog_name`, `binlog_pos`, `binlog_gtid`, "+
+		"`exit_safe_binlog_name`, `exit_safe_binlog_pos`, `exit_safe_binlog_gtid`, `table_info`, `is_global` FROM %s WHERE `id` IN (%s)",
+		tableName, placeholders(from))
+	rows, err := db.DB.QueryContext(ctx, query, ids[:from]...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer rows.Close()
+
+	var (
+		globals = make(map[string]*syncCheckpointRow, from)
+		tables  []*syncCheckpointRow
+	)
+	for rows.Next() {
+		r := &syncCheckpointRow{}
+		if err = rows.Scan(&r.id, &r.schema, &r.table, &r.binlogName, &r.binlogPos, &r.binlogGTID,
+			&r.exitSafeName, &r.exitSafePos, &r.exitSafeGTID, &r.tableInfo, &r.isGlobal); err != nil {
+			return errors.Trace(err)
+		}
+		if r.isGlobal.Bool {
+			globals[r.id] = r
+		} else {
+			tables = append(tables, r)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(globals) == 0 && len(tables) == 0 {
+		// old sync workers are fresh, nothing to hand off.
+		return nil
+	}
+	for _, id := range ids[:from] {
+		if _, ok := globals[id.(string)]; !ok {
+			return errors.Errorf("global sync checkpoint of %s not found", id)
+		}
+	}
+
+	var (
+		start            *syncCheckpointRow
+		startLoc, endLoc binlog.Location
+		// end is the location where new sync workers exit safe mode.
+		end struct {
+			name sql.NullString
+			pos  sql.NullInt64
+			gtid sql.NullString
+		}
+	)
+	for _, r := range globals {
+		loc, err2 := parseCheckpointLocation(upstream, r.binlogName, r.binlogPos, r.binlogGTID)
+		if err2 != nil {
+			return err2
+		}
+		first := start == nil
+		if first || binlog.CompareLocation(loc, startLoc, upstream.EnableGTID) < 0 {
+			start, startLoc = r, loc
+		}
+		if first || binlog.CompareLocation(loc, endLoc, upstream.EnableGTID) > 0 {
+			end.name, end.pos, end.gtid, endLoc = r.binlogName, r.binlogPos, r.binlogGTID, loc
+		}
+		if r.exitSafeName.String == "" {
+			continue
+		}
+		loc, err2 = parseCheckpointLocation(upstream, r.exitSafeName, r.exitSafePos, r.exitSafeGTID)
+		if err2 != nil {
+			return err2
+		}
+		if binlog.CompareLocation(loc, endLoc, upstream.EnableGTID) > 0 {
+			end.name, end.pos, end.gtid, endLoc = r.exitSafeName, r.exitSafePos, r.exitSafeGTID, loc
+		}
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// nolint:errcheck
+	defer tx.Rollback()
+	// nolint:gosec
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE `id` IN (%s)", tableName, placeholders(len(ids))), ids...); err != nil {
+		return errors.Trace(err)
+	}
+	// nolint:gosec
+	insert := fmt.Sprintf("INSERT INTO %s (`id`, `cp_schema`, `cp_table`, `binlog_name`, `binlog_pos`, `binlog_gtid`, "+
+		"`exit_safe_binlog_name`, `exit_safe_binlog_pos`, `exit_safe_binlog_gtid`, `table_info`, `is_global`) VALUES (?,?,?,?,?,?,?,?,?,?,?)", tableName)
+	for i := 0; i < to; i++ {
+		id := dmconfig.TablePartitionID(upstream.SourceID, i)
+		if _, err = tx.ExecContext(ctx, insert, id, start.schema, start.table, start.binlogName, start.binlogPos, start.binlogGTID,
+			end.name, end.pos, end.gtid, start.tableInfo, true); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, r := range tables {
+		id := dmconfig.TablePartitionID(upstream.SourceID, dmconfig.TablePartitionOf(r.schema, r.table, to))
+		if _, err = tx.ExecContext(ctx, insert, id, r.schema, r.table, r.binlogName, r.binlogPos, r.binlogGTID,
+			r.exitSafeName, r.exitSafePos, r.exitSafeGTID, r.tableInfo, false); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(tx.Commit())
+}
+
+func parseCheckpointLocation(upstream *config.UpstreamCfg, name sql.NullString, pos sql.NullInt64, gtidStr sql.NullString) (binlog.Location, error) {
+	loc := binlog.NewLocation(mysql.Position{Name: name.String, Pos: uint32(pos.Int64)}, nil)
+	if !upstream.EnableGTID {
+		return loc, nil
+	}
+	gset, err := gtid.ParserGTID(upstream.Flavor, gtidStr.String)
+	if err != nil {
+		return loc, errors.Trace(err)
+	}
+	err = loc.SetGTID(gset)
+	return loc, errors.Trace(err)
+}
@@ -273,6 +273,79 @@ func TestIsFresh(t *testing.T) {
 	isFresh, err = checkpointAgent.IsFresh(context.Background(), frameModel.WorkerDMSync, &metadata.Task{Cfg: taskCfg})
 	require.Error(t, err)
 	require.False(t, isFresh)
+
+	// sync worker of a table partition
+	partitionCfg := *taskCfg
+	partitionCfg.TablePartition, partitionCfg.TablePartitions = 1, 2
+	_, mock, err = conn.InitMockDBFull()
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(source1 + "#1").WillReturnError(sql.ErrNoRows)
+	isFresh, err = checkpointAgent.IsFresh(context.Background(), frameModel.WorkerDMSync, &metadata.Task{Cfg: &partitionCfg})
+	require.NoError(t, err)
+	require.True(t, isFresh)
+}
+
+func TestHandoffSyncCheckpoint(t *testing.T) {
+	source1 := "source1"
+	jobCfg := &config.JobCfg{
+		MetaSchema: "meta",
+		TaskMode:   dmconfig.ModeIncrement,
+		Upstreams: []*config.UpstreamCfg{
+			{
+				MySQLInstance: dmconfig.MySQLInstance{
+					SourceID: source1,
+				},
+				DBCfg: &dbconfig.DBConfig{},
+			},
+		},
+	}
+	taskCfg := jobCfg.ToTaskCfgs()[source1]
+	checkpointAgent := NewAgentImpl("test", log.L())
+
+	var (
+		columns = []string{"id", "cp_schema", "cp_table", "binlog_name", "binlog_pos", "binlog_gtid",
+			"exit_safe_binlog_name", "exit_safe_binlog_pos", "exit_safe_binlog_gtid", "table_info", "is_global"}
+		query  = "SELECT `id`, `cp_schema`, `cp_table`, `binlog_name`, `binlog_pos`, `binlog_gtid`, `exit_safe_binlog_name`, `exit_safe_binlog_pos`, `exit_safe_binlog_gtid`, `table_info`, `is_global` FROM `meta`.`test_syncer_checkpoint` WHERE `id` IN (?,?)"
+		del    = "DELETE FROM `meta`.`test_syncer_checkpoint` WHERE `id` IN (?,?,?)"
+		insert = "INSERT INTO `meta`.`test_syncer_checkpoint`"
+	)
+
+	// old sync workers are fresh
+	_, mock, err := conn.InitMockDBFull()
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(source1, source1+"#1").WillReturnRows(sqlmock.NewRows(columns))
+	require.NoError(t, checkpointAgent.HandoffSyncCheckpoint(context.Background(), taskCfg, 2, 3))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// global checkpoint of an old sync worker is missing
+	_, mock, err = conn.InitMockDBFull()
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(source1, source1+"#1").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(source1, "", "", "mysql-bin.000002", 100, "", "", 0, "", []byte("null"), true))
+	require.EqualError(t, checkpointAgent.HandoffSyncCheckpoint(context.Background(), taskCfg, 2, 3), "global sync checkpoint of source1#1 not found")
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// new sync workers start from the earliest location, and exit safe mode at the latest location.
+	_, mock, err = conn.InitMockDBFull()
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(source1, source1+"#1").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(source1, "", "", "mysql-bin.000003", 50, "", "mysql-bin.000004", 4, "", []byte("null"), true).
+		AddRow(source1, "db", "t1", "mysql-bin.000003", 40, "", "", 0, "", []byte("{}"), false).
+		AddRow(source1+"#1", "", "", "mysql-bin.000002", 100, "", "", 0, "", []byte("null"), true).
+		AddRow(source1+"#1", "db", "t2", "mysql-bin.000002", 90, "", "", 0, "", []byte("{}"), false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(del)).WithArgs(source1, source1+"#1", source1+"#2").WillReturnResult(sqlmock.NewResult(0, 4))
+	for i := 0; i < 3; i++ {
+		mock.ExpectExec(regexp.QuoteMeta(insert)).WithArgs(dmconfig.TablePartitionID(source1, i), "", "",
+			"mysql-bin.000002", 100, "", "mysql-bin.000004", 4, "", []byte("null"), true).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta(insert)).WithArgs(dmconfig.TablePartitionID(source1, dmconfig.TablePartitionOf("db", "t1", 3)), "db", "t1",
+		"mysql-bin.000003", 40, "", "", 0, "", []byte("{}"), false).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insert)).WithArgs(dmconfig.TablePartitionID(source1, dmconfig.TablePartitionOf("db", "t2", 3)), "db", "t2",
+		"mysql-bin.000002", 90, "", "", 0, "", []byte("{}"), false).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, checkpointAgent.HandoffSyncCheckpoint(context.Background(), taskCfg, 2, 3))
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFetchTableStmt(t *testing.T) {
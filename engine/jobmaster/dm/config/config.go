@@ -99,6 +99,9 @@ type JobCfg struct {
 	// remove source config, use db config instead.
 	Upstreams []*UpstreamCfg `yaml:"upstreams" toml:"upstreams" json:"upstreams"`
 
+	// Scaling is the config of scaling sync workers of each upstream by binlog lag.
+	Scaling ScalingCfg `yaml:"scaling" toml:"scaling" json:"scaling"`
+
 	// no need experimental features?
 	Experimental struct {
 		AsyncCheckpointFlush bool `yaml:"async-checkpoint-flush" toml:"async-checkpoint-flush" json:"async-checkpoint-flush"`
@@ -126,6 +129,59 @@ type JobCfg struct {
 	ModRevision uint64 `yaml:"mod-revision" toml:"mod-revision" json:"mod-revision"`
 }
 
+// default values of ScalingCfg.
+const (
+	DefaultMaxSyncWorkers = 4
+	DefaultScaleOutLag    = 300
+	DefaultScaleInLag     = 30
+)
+
+// ScalingCfg is the config of scaling sync workers of an upstream.
+// When binlog lag of an upstream is high, tables of the upstream are split
+// into more sync workers, and merged back when the lag is low.
+type ScalingCfg struct {
+	Enable bool `yaml:"enable" toml:"enable" json:"enable"`
+	// MaxWorkers is the max number of sync workers of an upstream.
+	MaxWorkers int `yaml:"max-workers" toml:"max-workers" json:"max-workers"`
+	// ScaleOutLag is the binlog lag in seconds above which an upstream is scaled out.
+	ScaleOutLag int64 `yaml:"scale-out-lag" toml:"scale-out-lag" json:"scale-out-lag"`
+	// ScaleInLag is the binlog lag in seconds below which an upstream is scaled in.
+	ScaleInLag int64 `yaml:"scale-in-lag" toml:"scale-in-lag" json:"scale-in-lag"`
+}
+
+// MaxSyncWorkers returns the max number of sync workers of an upstream.
+func (c *ScalingCfg) MaxSyncWorkers() int {
+	if !c.Enable {
+		return 1
+	}
+	return c.MaxWorkers
+}
+
+func (c *ScalingCfg) adjust(shardMode string) error {
+	if !c.Enable {
+		return nil
+	}
+	if shardMode != "" {
+		return errors.New("scaling is not supported in shard mode")
+	}
+	if c.MaxWorkers == 0 {
+		c.MaxWorkers = DefaultMaxSyncWorkers
+	}
+	if c.ScaleOutLag == 0 {
+		c.ScaleOutLag = DefaultScaleOutLag
+	}
+	if c.ScaleInLag == 0 {
+		c.ScaleInLag = DefaultScaleInLag
+	}
+	if c.MaxWorkers < 1 {
+		return errors.Errorf("max-workers of scaling should be positive, got %d", c.MaxWorkers)
+	}
+	if c.ScaleInLag >= c.ScaleOutLag {
+		return errors.Errorf("scale-in-lag %d of scaling should be less than scale-out-lag %d", c.ScaleInLag, c.ScaleOutLag)
+	}
+	return nil
+}
+
 // DecodeFile reads file content from a given path and decodes it.
 func (c *JobCfg) DecodeFile(fpath string) error {
 	bs, err := os.ReadFile(fpath)
@@ -227,6 +283,9 @@ func (c *JobCfg) adjust() error {
 	if err := c.verifySourceID(); err != nil {
 		return err
 	}
+	if err := c.Scaling.adjust(c.ShardMode); err != nil {
+		return err
+	}
 	dmTaskCfg, err := c.toDMTaskConfig()
 	if err != nil {
 		return err
@@ -268,6 +327,10 @@ type TaskCfg struct {
 
 	// FIXME: remove this item after fix https://github.com/pingcap/tiflow/issues/7304
 	NeedExtStorage bool
+	// TablePartition and TablePartitions are set when tables of the upstream
+	// are split into multiple sync workers, see dmconfig.TablePartitionOf.
+	TablePartition  int
+	TablePartitions int
 }
 
 // ToJobCfg converts TaskCfg to JobCfg.
@@ -312,7 +375,8 @@ func (c *TaskCfg) ToDMSubTaskCfg(jobID string) *dmconfig.SubTaskConfig {
 	cfg.SourceID = c.Upstreams[0].SourceID
 	cfg.Meta = c.Upstreams[0].Meta
 	cfg.From = *c.Upstreams[0].DBCfg
-	cfg.ServerID = c.Upstreams[0].ServerID
+	// sync workers of the same upstream should use different server ids.
+	cfg.ServerID = c.Upstreams[0].ServerID + uint32(c.TablePartition)
 	cfg.Flavor = c.Upstreams[0].Flavor
 	cfg.CaseSensitive = c.Upstreams[0].CaseSensitive
 
@@ -336,6 +400,8 @@ func (c *TaskCfg) ToDMSubTaskCfg(jobID string) *dmconfig.SubTaskConfig {
 	cfg.SyncerConfig = *c.Upstreams[0].Syncer
 	cfg.IOTotalBytes = atomic.NewUint64(0)
 	cfg.DumpIOTotalBytes = atomic.NewUint64(0)
+	cfg.TablePartition = c.TablePartition
+	cfg.TablePartitions = c.TablePartitions
 	cfg.UUID = uuid.NewString()
 	cfg.DumpUUID = uuid.NewString()
 
@@ -594,3 +594,10 @@ func (m *MockCheckpointAgent) FetchTableStmt(ctx context.Context, jobID string,
 	args := m.Called()
 	return args.Get(0).(string), args.Error(1)
 }
+
+func (m *MockCheckpointAgent) HandoffSyncCheckpoint(ctx context.Context, cfg *config.TaskCfg, from, to int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	args := m.Called(from, to)
+	return args.Error(0)
+}
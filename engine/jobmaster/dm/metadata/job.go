@@ -129,6 +129,32 @@ type Task struct {
 	Cfg              *config.TaskCfg
 	Stage            TaskStage
 	StageUpdatedTime time.Time
+
+	// SyncWorkers is the number of sync workers which tables of the task are
+	// split into, 0 means 1.
+	SyncWorkers int
+	// HandoffFrom is the number of sync workers before scaling. It's not 0
+	// until checkpoints of old sync workers are handed off to new ones.
+	HandoffFrom int
+}
+
+// SyncWorkerCount returns the number of sync workers of the task.
+func (t *Task) SyncWorkerCount() int {
+	if t.SyncWorkers <= 1 {
+		return 1
+	}
+	return t.SyncWorkers
+}
+
+// scaleSyncWorkers changes the number of sync workers of the task.
+func (t *Task) scaleSyncWorkers(count int) {
+	if t.HandoffFrom == 0 {
+		t.HandoffFrom = t.SyncWorkerCount()
+	}
+	t.SyncWorkers = count
+	if t.HandoffFrom == t.SyncWorkerCount() {
+		t.HandoffFrom = 0
+	}
 }
 
 // NewTask creates a new Task instance
@@ -226,16 +252,56 @@ func (jobStore *JobStore) UpdateConfig(ctx context.Context, jobCfg *config.JobCf
 	newJob := NewJob(jobCfg)
 
 	for taskID, newTask := range newJob.Tasks {
-		// task stage will not be updated.
+		// task stage and sync workers will not be updated.
 		if oldTask, ok := oldJob.Tasks[taskID]; ok {
 			newTask.Stage = oldTask.Stage
 			newTask.StageUpdatedTime = oldTask.StageUpdatedTime
+			newTask.SyncWorkers = oldTask.SyncWorkers
+			newTask.HandoffFrom = oldTask.HandoffFrom
+			if maxWorkers := newTask.Cfg.Scaling.MaxSyncWorkers(); newTask.SyncWorkerCount() > maxWorkers {
+				newTask.scaleSyncWorkers(maxWorkers)
+			}
 		}
 	}
 
 	return jobStore.Put(ctx, newJob)
 }
 
+// ScaleSyncWorkers changes the number of sync workers of a task, checkpoints
+// of old sync workers should be handed off before new ones are created.
+func (jobStore *JobStore) ScaleSyncWorkers(ctx context.Context, taskID string, count int) error {
+	return jobStore.updateTask(ctx, taskID, func(t *Task) {
+		t.scaleSyncWorkers(count)
+	})
+}
+
+// FinishHandoff marks checkpoints of old sync workers of a task are handed off.
+func (jobStore *JobStore) FinishHandoff(ctx context.Context, taskID string) error {
+	return jobStore.updateTask(ctx, taskID, func(t *Task) {
+		t.HandoffFrom = 0
+	})
+}
+
+func (jobStore *JobStore) updateTask(ctx context.Context, taskID string, fn func(t *Task)) error {
+	jobStore.mu.Lock()
+	defer jobStore.mu.Unlock()
+	state, err := jobStore.Get(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := state.(*Job)
+	if job.Deleting {
+		return errors.New("failed to update task because job is being deleted")
+	}
+	t, ok := job.Tasks[taskID]
+	if !ok {
+		return errors.Errorf("task %s not found", taskID)
+	}
+	fn(t)
+	return jobStore.Put(ctx, job)
+}
+
 // MarkDeleting marks the job as deleting.
 func (jobStore *JobStore) MarkDeleting(ctx context.Context) error {
 	jobStore.mu.Lock()
@@ -106,12 +106,32 @@ func TestJobStore(t *testing.T) {
 	require.Equal(t, job.Tasks[source1].Stage, StagePaused)
 	require.Equal(t, job.Tasks[source2].Stage, StageRunning)
 
+	require.EqualError(t, jobStore.ScaleSyncWorkers(context.Background(), "task-not-exist", 2), "task task-not-exist not found")
+	require.NoError(t, jobStore.ScaleSyncWorkers(context.Background(), source1, 2))
+	state, _ = jobStore.Get(context.Background())
+	job = state.(*Job)
+	require.Equal(t, 2, job.Tasks[source1].SyncWorkerCount())
+	require.Equal(t, 1, job.Tasks[source1].HandoffFrom)
+	require.Equal(t, 1, job.Tasks[source2].SyncWorkerCount())
+	require.NoError(t, jobStore.FinishHandoff(context.Background(), source1))
+	state, _ = jobStore.Get(context.Background())
+	job = state.(*Job)
+	require.Equal(t, 0, job.Tasks[source1].HandoffFrom)
+
 	require.NoError(t, jobStore.UpdateConfig(context.Background(), jobCfg))
 	state, err = jobStore.Get(context.Background())
 	require.NoError(t, err)
 	job = state.(*Job)
 	require.Equal(t, job.Tasks[source1].Stage, StagePaused)
 	require.Equal(t, job.Tasks[source2].Stage, StageRunning)
+	// scaling is disabled, so sync workers are merged back.
+	require.Equal(t, 1, job.Tasks[source1].SyncWorkerCount())
+	require.Equal(t, 2, job.Tasks[source1].HandoffFrom)
+	require.NoError(t, jobStore.ScaleSyncWorkers(context.Background(), source1, 2))
+	state, _ = jobStore.Get(context.Background())
+	job = state.(*Job)
+	require.Equal(t, 2, job.Tasks[source1].SyncWorkerCount())
+	require.Equal(t, 0, job.Tasks[source1].HandoffFrom)
 	require.Equal(t, job.Tasks[source1].Cfg.ModRevision, uint64(1))
 	require.Equal(t, job.Tasks[source2].Cfg.ModRevision, uint64(1))
 	require.False(t, job.Deleting)
@@ -0,0 +1,169 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dm
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	dmconfig "github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pb"
+	frameModel "github.com/pingcap/tiflow/engine/framework/model"
+	"github.com/pingcap/tiflow/engine/jobmaster/dm/metadata"
+	"github.com/pingcap/tiflow/engine/jobmaster/dm/runtime"
+	dmpkg "github.com/pingcap/tiflow/engine/pkg/dm"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ScalingCoolDown is the min interval between two scalings of a task, so that
+// the lag caused by restarting sync workers doesn't trigger another scaling.
+var ScalingCoolDown = 10 * time.Minute
+
+/*
+ * sync workers of a task are scaled by binlog lag of the upstream.
+ *
+ *   tables of the upstream are split into table partitions by hash, and each
+ *   partition is replicated by a sync worker, see dmconfig.TablePartitionOf.
+ *   when the lag exceeds scale-out-lag, the number of sync workers is doubled,
+ *   and when the lag falls below scale-in-lag, it's halved.
+ *
+ *   as tables are redistributed, sync workers are scaled in steps:
+ *   1. record the new number and the old number (HandoffFrom) of sync workers.
+ *   2. stop all sync workers of the task, which flush their checkpoints.
+ *   3. hand off checkpoints of old sync workers to new ones.
+ *   4. clear HandoffFrom, and create new sync workers.
+ */
+
+// checkAndScaleWorkers scales sync workers of tasks by binlog lag.
+func (wm *WorkerManager) checkAndScaleWorkers(ctx context.Context, job *metadata.Job) error {
+	var recordError error
+	for taskID, task := range job.Tasks {
+		scaling := task.Cfg.Scaling
+		if !scaling.Enable || task.HandoffFrom != 0 || task.Stage != metadata.StageRunning {
+			continue
+		}
+		if time.Since(wm.lastScaleTime[taskID]) < ScalingCoolDown {
+			continue
+		}
+		lag, ok, err := wm.syncLag(ctx, taskID, task)
+		if err != nil {
+			wm.logger.Error("get binlog lag failed", zap.String("task_id", taskID), zap.Error(err))
+			recordError = err
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		count := task.SyncWorkerCount()
+		target := count
+		switch {
+		case lag >= scaling.ScaleOutLag && count < scaling.MaxWorkers:
+			target = count * 2
+			if target > scaling.MaxWorkers {
+				target = scaling.MaxWorkers
+			}
+		case lag <= scaling.ScaleInLag && count > 1:
+			target = count / 2
+		}
+		if target == count {
+			continue
+		}
+
+		wm.logger.Info("scale sync workers", zap.String("task_id", taskID), zap.Int64("lag", lag), zap.Int("from", count), zap.Int("to", target))
+		if err := wm.jobStore.ScaleSyncWorkers(ctx, taskID, target); err != nil {
+			recordError = err
+			continue
+		}
+		wm.lastScaleTime[taskID] = time.Now()
+		wm.SetNextCheckTime(time.Now())
+	}
+	return recordError
+}
+
+// syncLag returns the max binlog lag in seconds of sync workers of a task.
+// ok is false if any sync worker is not running, we don't scale it in that case.
+func (wm *WorkerManager) syncLag(ctx context.Context, taskID string, task *metadata.Task) (lag int64, ok bool, err error) {
+	for partition := 0; partition < task.SyncWorkerCount(); partition++ {
+		workerTaskID := dmconfig.TablePartitionID(taskID, partition)
+		value, exist := wm.workerStatusMap.Load(workerTaskID)
+		if !exist {
+			return 0, false, nil
+		}
+		workerStatus := value.(runtime.WorkerStatus)
+		if workerStatus.Stage != runtime.WorkerOnline || workerStatus.Unit != frameModel.WorkerDMSync ||
+			workerStatus.CfgModRevision != task.Cfg.ModRevision {
+			return 0, false, nil
+		}
+		// new sync workers start from checkpoints of old ones, so old ones must have saved checkpoints.
+		isFresh, err := wm.checkpointAgent.IsFresh(ctx, frameModel.WorkerDMSync, partitionTask(task, partition))
+		if err != nil || isFresh {
+			return 0, false, err
+		}
+
+		req := &dmpkg.QueryStatusRequest{Task: workerTaskID}
+		resp, err := wm.messageAgent.SendRequest(ctx, workerTaskID, dmpkg.QueryStatus, req)
+		if err != nil {
+			return 0, false, err
+		}
+		status := resp.(*dmpkg.QueryStatusResponse)
+		if status.ErrorMsg != "" {
+			return 0, false, errors.New(status.ErrorMsg)
+		}
+		if status.Stage != metadata.StageRunning {
+			return 0, false, nil
+		}
+		var syncStatus pb.SyncStatus
+		if err := jsonpb.Unmarshal(bytes.NewReader(status.Status), &syncStatus); err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		if syncStatus.SecondsBehindMaster > lag {
+			lag = syncStatus.SecondsBehindMaster
+		}
+	}
+	return lag, true, nil
+}
+
+// handoff hands off checkpoints of old sync workers of a task to new ones after
+// all old sync workers are stopped.
+func (wm *WorkerManager) handoff(ctx context.Context, taskID string, task *metadata.Task) error {
+	stopping := false
+	wm.workerStatusMap.Range(func(key, value interface{}) bool {
+		workerStatus := value.(runtime.WorkerStatus)
+		if parentID, _ := splitWorkerTaskID(key.(string)); parentID == taskID && !workerStatus.IsTombStone() {
+			stopping = true
+			return false
+		}
+		return true
+	})
+	if stopping {
+		wm.logger.Info("wait for sync workers stopped before handoff", zap.String("task_id", taskID))
+		return nil
+	}
+
+	if err := wm.checkpointAgent.HandoffSyncCheckpoint(ctx, task.Cfg, task.HandoffFrom, task.SyncWorkerCount()); err != nil {
+		wm.logger.Error("hand off sync checkpoint failed", zap.String("task_id", taskID), zap.Error(err))
+		return err
+	}
+	if err := wm.jobStore.FinishHandoff(ctx, taskID); err != nil {
+		return err
+	}
+	wm.logger.Info("sync checkpoint handed off", zap.String("task_id", taskID), zap.Int("from", task.HandoffFrom), zap.Int("to", task.SyncWorkerCount()))
+	wm.lastScaleTime[taskID] = time.Now()
+	wm.SetNextCheckTime(time.Now())
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/dm/config"
+	frameModel "github.com/pingcap/tiflow/engine/framework/model"
+	jobcfg "github.com/pingcap/tiflow/engine/jobmaster/dm/config"
+	"github.com/pingcap/tiflow/engine/jobmaster/dm/metadata"
+	"github.com/pingcap/tiflow/engine/jobmaster/dm/runtime"
+	dmpkg "github.com/pingcap/tiflow/engine/pkg/dm"
+	resModel "github.com/pingcap/tiflow/engine/pkg/externalresource/model"
+	kvmock "github.com/pingcap/tiflow/engine/pkg/meta/mock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func (t *testDMJobmasterSuite) TestSplitWorkerTaskID() {
+	cases := []struct {
+		workerTaskID string
+		taskID       string
+		partition    int
+	}{
+		{"mysql-replica-01", "mysql-replica-01", 0},
+		{"mysql-replica-01#2", "mysql-replica-01", 2},
+		{"mysql-replica-01#0", "mysql-replica-01#0", 0},
+		{"mysql-replica-01#a", "mysql-replica-01#a", 0},
+		{"mysql#replica#3", "mysql#replica", 3},
+	}
+	for _, cs := range cases {
+		taskID, partition := splitWorkerTaskID(cs.workerTaskID)
+		require.Equal(t.T(), cs.taskID, taskID)
+		require.Equal(t.T(), cs.partition, partition)
+		if cs.taskID+"#0" != cs.workerTaskID {
+			require.Equal(t.T(), cs.workerTaskID, config.TablePartitionID(taskID, partition))
+		}
+	}
+}
+
+func (t *testDMJobmasterSuite) TestScaleWorkers() {
+	jobCfg := &jobcfg.JobCfg{}
+	require.NoError(t.T(), jobCfg.DecodeFile(jobTemplatePath))
+	jobCfg.TaskMode = config.ModeIncrement
+	jobCfg.ShardMode = ""
+	jobCfg.Scaling = jobcfg.ScalingCfg{Enable: true, MaxWorkers: 3, ScaleOutLag: 300, ScaleInLag: 30}
+	job := metadata.NewJob(jobCfg)
+	jobStore := metadata.NewJobStore(kvmock.NewMetaMock(), log.L())
+	require.NoError(t.T(), jobStore.Put(context.Background(), job))
+	messageAgent := &dmpkg.MockMessageAgent{}
+	checkpointAgent := &MockCheckpointAgent{}
+	workerManager := NewWorkerManager("job_id", nil, jobStore, nil, nil, messageAgent, checkpointAgent, log.L(), resModel.ResourceTypeLocalFile)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	source1 := jobCfg.Upstreams[0].SourceID
+	source2 := jobCfg.Upstreams[1].SourceID
+	mockLag := func(workerTaskID string, lag int) {
+		messageAgent.On("SendRequest", mock.Anything, workerTaskID, dmpkg.QueryStatus, mock.Anything).Return(&dmpkg.QueryStatusResponse{
+			Unit:   frameModel.WorkerDMSync,
+			Stage:  metadata.StageRunning,
+			Status: []byte(fmt.Sprintf(`{"secondsBehindMaster": "%d"}`, lag)),
+		}, nil).Once()
+	}
+	latestJob := func() *metadata.Job {
+		state, err := jobStore.Get(ctx)
+		require.NoError(t.T(), err)
+		return state.(*metadata.Job)
+	}
+	syncTask := func(taskID string) *metadata.Task {
+		return latestJob().Tasks[taskID]
+	}
+
+	// no sync worker, no scaling
+	require.NoError(t.T(), workerManager.checkAndScaleWorkers(ctx, job))
+	require.Equal(t.T(), 1, syncTask(source1).SyncWorkerCount())
+
+	// fresh sync worker, no scaling
+	workerManager.UpdateWorkerStatus(runtime.NewWorkerStatus(source1, frameModel.WorkerDMSync, "worker1", runtime.WorkerOnline, 0))
+	checkpointAgent.On("IsFresh").Return(true, nil).Once()
+	require.NoError(t.T(), workerManager.checkAndScaleWorkers(ctx, job))
+	require.Equal(t.T(), 1, syncTask(source1).SyncWorkerCount())
+
+	// lag is not large enough
+	checkpointAgent.On("IsFresh").Return(false, nil)
+	mockLag(source1, 100)
+	require.NoError(t.T(), workerManager.checkAndScaleWorkers(ctx, job))
+	require.Equal(t.T(), 1, syncTask(source1).SyncWorkerCount())
+
+	// scale out
+	mockLag(source1, 500)
+	require.NoError(t.T(), workerManager.checkAndScaleWorkers(ctx, job))
+	task := syncTask(source1)
+	require.Equal(t.T(), 2, task.SyncWorkerCount())
+	require.Equal(t.T(), 1, task.HandoffFrom)
+	require.Equal(t.T(), 1, syncTask(source2).SyncWorkerCount())
+
+	// cool down
+	require.NoError(t.T(), workerManager.checkAndScaleWorkers(ctx, latestJob()))
+	require.Equal(t.T(), 2, syncTask(source1).SyncWorkerCount())
+
+	// wait for old sync worker stopped before handoff
+	require.NoError(t.T(), workerManager.handoff(ctx, source1, task))
+	require.Equal(t.T(), 1, syncTask(source1).HandoffFrom)
+
+	// handoff failed
+	workerManager.removeWorkerStatusByWorkerID("worker1")
+	checkpointAgent.On("HandoffSyncCheckpoint", 1, 2).Return(context.DeadlineExceeded).Once()
+	require.Error(t.T(), workerManager.handoff(ctx, source1, task))
+	require.Equal(t.T(), 1, syncTask(source1).HandoffFrom)
+
+	// handoff
+	checkpointAgent.On("HandoffSyncCheckpoint", 1, 2).Return(nil).Once()
+	require.NoError(t.T(), workerManager.handoff(ctx, source1, task))
+	require.Equal(t.T(), 0, syncTask(source1).HandoffFrom)
+	require.Equal(t.T(), 2, syncTask(source1).SyncWorkerCount())
+
+	// scale out, limited by max workers
+	workerManager.lastScaleTime = make(map[string]time.Time)
+	source1Partition1 := config.TablePartitionID(source1, 1)
+	workerManager.UpdateWorkerStatus(runtime.NewWorkerStatus(source1, frameModel.WorkerDMSync, "worker2", runtime.WorkerOnline, 0))
+	workerManager.UpdateWorkerStatus(runtime.NewWorkerStatus(source1Partition1, frameModel.WorkerDMSync, "worker3", runtime.WorkerOnline, 0))
+	mockLag(source1, 100)
+	mockLag(source1Partition1, 400)
+	require.NoError(t.T(), workerManager.checkAndScaleWorkers(ctx, latestJob()))
+	task = syncTask(source1)
+	require.Equal(t.T(), 3, task.SyncWorkerCount())
+	require.Equal(t.T(), 2, task.HandoffFrom)
+
+	// scale in
+	workerManager.removeWorkerStatusByWorkerID("worker2")
+	workerManager.removeWorkerStatusByWorkerID("worker3")
+	checkpointAgent.On("HandoffSyncCheckpoint", 2, 3).Return(nil).Once()
+	require.NoError(t.T(), workerManager.handoff(ctx, source1, task))
+	workerManager.lastScaleTime = make(map[string]time.Time)
+	for i := 0; i < 3; i++ {
+		workerTaskID := config.TablePartitionID(source1, i)
+		workerManager.UpdateWorkerStatus(runtime.NewWorkerStatus(workerTaskID, frameModel.WorkerDMSync, fmt.Sprintf("worker-%d", i), runtime.WorkerOnline, 0))
+		mockLag(workerTaskID, 10)
+	}
+	require.NoError(t.T(), workerManager.checkAndScaleWorkers(ctx, latestJob()))
+	task = syncTask(source1)
+	require.Equal(t.T(), 1, task.SyncWorkerCount())
+	require.Equal(t.T(), 3, task.HandoffFrom)
+
+	messageAgent.AssertExpectations(t.T())
+	checkpointAgent.AssertExpectations(t.T())
+}
@@ -155,50 +155,61 @@ func (tm *TaskManager) TickImpl(ctx context.Context) error {
 }
 
 func (tm *TaskManager) checkAndOperateTasks(ctx context.Context, job *metadata.Job) error {
-	var (
-		runningTask runtime.TaskStatus
-		recordError error
-	)
+	var recordError error
 
 	// check and operate task
-	for taskID, persistentTask := range job.Tasks {
-		task, ok := tm.tasks.Load(taskID)
-		if ok {
-			runningTask = task.(runtime.TaskStatus)
-		}
-
-		// task unbounded or worker offline
-		if !ok || runningTask.Stage == metadata.StageUnscheduled {
-			recordError = errors.New("get task running status failed")
-			tm.logger.Error("failed to schedule task", zap.String("task_id", taskID), zap.Error(recordError))
+	for parentID, persistentTask := range job.Tasks {
+		// sync workers are being scaled, they will be recreated after handoff.
+		if persistentTask.HandoffFrom != 0 {
 			continue
 		}
-
-		op := genOp(runningTask.Stage, runningTask.StageUpdatedTime, persistentTask.Stage, persistentTask.StageUpdatedTime)
-		if op == dmpkg.None {
-			tm.logger.Debug(
-				"task status will not be changed",
-				zap.String("task_id", taskID),
-				zap.Stringer("stage", runningTask.Stage),
-			)
-			continue
+		for partition := 0; partition < persistentTask.SyncWorkerCount(); partition++ {
+			taskID := dmconfig.TablePartitionID(parentID, partition)
+			if err := tm.checkAndOperateTask(ctx, taskID, persistentTask); err != nil {
+				recordError = err
+			}
 		}
+	}
+	return recordError
+}
+
+func (tm *TaskManager) checkAndOperateTask(ctx context.Context, taskID string, persistentTask *metadata.Task) error {
+	var runningTask runtime.TaskStatus
+	task, ok := tm.tasks.Load(taskID)
+	if ok {
+		runningTask = task.(runtime.TaskStatus)
+	}
 
-		tm.logger.Info(
-			"unexpected task status",
+	// task unbounded or worker offline
+	if !ok || runningTask.Stage == metadata.StageUnscheduled {
+		err := errors.New("get task running status failed")
+		tm.logger.Error("failed to schedule task", zap.String("task_id", taskID), zap.Error(err))
+		return err
+	}
+
+	op := genOp(runningTask.Stage, runningTask.StageUpdatedTime, persistentTask.Stage, persistentTask.StageUpdatedTime)
+	if op == dmpkg.None {
+		tm.logger.Debug(
+			"task status will not be changed",
 			zap.String("task_id", taskID),
-			zap.Stringer("op", op),
-			zap.Stringer("expected_stage", persistentTask.Stage),
 			zap.Stringer("stage", runningTask.Stage),
 		)
-		// operateTaskMessage should be a asynchronous request
-		if err := tm.operateTaskMessage(ctx, taskID, op); err != nil {
-			recordError = err
-			tm.logger.Error("operate task failed", zap.Error(recordError))
-			continue
-		}
+		return nil
 	}
-	return recordError
+
+	tm.logger.Info(
+		"unexpected task status",
+		zap.String("task_id", taskID),
+		zap.Stringer("op", op),
+		zap.Stringer("expected_stage", persistentTask.Stage),
+		zap.Stringer("stage", runningTask.Stage),
+	)
+	// operateTaskMessage should be a asynchronous request
+	if err := tm.operateTaskMessage(ctx, taskID, op); err != nil {
+		tm.logger.Error("operate task failed", zap.Error(err))
+		return err
+	}
+	return nil
 }
 
 // remove all tasks, usually happened when delete jobs.
@@ -215,7 +226,8 @@ func (tm *TaskManager) onJobDel() {
 func (tm *TaskManager) removeTaskStatus(job *metadata.Job) {
 	tm.tasks.Range(func(key, value interface{}) bool {
 		taskID := key.(string)
-		if _, ok := job.Tasks[taskID]; !ok {
+		parentID, partition := splitWorkerTaskID(taskID)
+		if task, ok := job.Tasks[parentID]; !ok || partition >= task.SyncWorkerCount() {
 			tm.logger.Info("remove task status", zap.String("task_id", taskID))
 			tm.tasks.Delete(taskID)
 			tm.gaugeVec.DeleteLabelValues(tm.jobID, taskID)
@@ -14,6 +14,10 @@
 package dm
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tiflow/engine/jobmaster/dm/metadata"
 	resModel "github.com/pingcap/tiflow/engine/pkg/externalresource/model"
 )
 
@@ -31,3 +35,30 @@ func GetDMStorageType(bucketEnabled bool, bucketType resModel.ResourceType) resM
 func NewDMResourceID(taskName, sourceName string, resType resModel.ResourceType) resModel.ResourceID {
 	return "/" + string(resType) + "/" + taskName + "-" + sourceName
 }
+
+// splitWorkerTaskID splits the task ID of a worker into the task ID and the
+// table partition, see dmconfig.TablePartitionID.
+func splitWorkerTaskID(workerTaskID string) (string, int) {
+	idx := strings.LastIndexByte(workerTaskID, '#')
+	if idx == -1 {
+		return workerTaskID, 0
+	}
+	partition, err := strconv.Atoi(workerTaskID[idx+1:])
+	if err != nil || partition <= 0 {
+		return workerTaskID, 0
+	}
+	return workerTaskID[:idx], partition
+}
+
+// partitionTask returns the task of sync worker of a table partition.
+func partitionTask(task *metadata.Task, partition int) *metadata.Task {
+	partitions := task.SyncWorkerCount()
+	if partitions == 1 {
+		return task
+	}
+	cfg := *task.Cfg
+	cfg.TablePartition, cfg.TablePartitions = partition, partitions
+	t := *task
+	t.Cfg = &cfg
+	return &t
+}
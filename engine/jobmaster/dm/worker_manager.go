@@ -51,6 +51,7 @@ type WorkerAgent interface {
 // CheckpointAgent defines an interface for checkpoint.
 type CheckpointAgent interface {
 	IsFresh(ctx context.Context, workerType frameModel.WorkerType, taskCfg *metadata.Task) (bool, error)
+	HandoffSyncCheckpoint(ctx context.Context, cfg *config.TaskCfg, from, to int) error
 }
 
 // WorkerManager checks and schedules workers.
@@ -69,7 +70,12 @@ type WorkerManager struct {
 
 	// workerStatusMap record the runtime worker status
 	// taskID -> WorkerStatus
+	// taskID of sync workers of a table partition is dmconfig.TablePartitionID(taskID, partition)
 	workerStatusMap sync.Map
+
+	// lastScaleTime record the last time that sync workers of a task are scaled
+	// taskID -> time.Time
+	lastScaleTime map[string]time.Time
 }
 
 // NewWorkerManager creates a new WorkerManager instance
@@ -94,6 +100,7 @@ func NewWorkerManager(
 		checkpointAgent: checkpointAgent,
 		logger:          pLogger.With(zap.String("component", "worker_manager")),
 		storageType:     storageType,
+		lastScaleTime:   make(map[string]time.Time),
 	}
 
 	workerManager.DefaultTicker.Ticker = workerManager
@@ -123,6 +130,7 @@ func (wm *WorkerManager) WorkerStatus() map[string]runtime.WorkerStatus {
 // TickImpl remove offline workers.
 // TickImpl stop unneeded workers.
 // TickImpl create new workers if needed.
+// TickImpl scale sync workers if needed.
 func (wm *WorkerManager) TickImpl(ctx context.Context) error {
 	wm.logger.Info("start to schedule workers")
 	wm.removeOfflineWorkers()
@@ -147,6 +155,9 @@ func (wm *WorkerManager) TickImpl(ctx context.Context) error {
 	if err := wm.checkAndScheduleWorkers(ctx, job); err != nil {
 		recordError = err
 	}
+	if err := wm.checkAndScaleWorkers(ctx, job); err != nil {
+		recordError = err
+	}
 	return recordError
 }
 
@@ -182,12 +193,13 @@ func (wm *WorkerManager) onJobDel(ctx context.Context) error {
 	return recordError
 }
 
-// stop unneeded workers, usually happened when update-job delete some tasks.
+// stop unneeded workers, usually happened when update-job delete some tasks or sync workers are scaled in.
 func (wm *WorkerManager) stopUnneededWorkers(ctx context.Context, job *metadata.Job) error {
 	var recordError error
 	wm.workerStatusMap.Range(func(key, value interface{}) bool {
 		taskID := key.(string)
-		if _, ok := job.Tasks[taskID]; !ok {
+		parentID, partition := splitWorkerTaskID(taskID)
+		if task, ok := job.Tasks[parentID]; !ok || partition >= task.SyncWorkerCount() {
 			workerStatus := value.(runtime.WorkerStatus)
 			if workerStatus.IsTombStone() {
 				return true
@@ -202,21 +214,23 @@ func (wm *WorkerManager) stopUnneededWorkers(ctx context.Context, job *metadata.
 	return recordError
 }
 
-// stop outdated workers, usually happened when update job cfgs.
+// stop outdated workers, usually happened when update job cfgs or sync workers are scaled.
 func (wm *WorkerManager) stopOutdatedWorkers(ctx context.Context, job *metadata.Job) error {
 	var recordError error
 	wm.workerStatusMap.Range(func(key, value interface{}) bool {
 		taskID := key.(string)
 		workerStatus := value.(runtime.WorkerStatus)
-		task, ok := job.Tasks[taskID]
-		if !ok || task.Cfg.ModRevision == workerStatus.CfgModRevision {
+		parentID, _ := splitWorkerTaskID(taskID)
+		task, ok := job.Tasks[parentID]
+		if !ok || (task.Cfg.ModRevision == workerStatus.CfgModRevision && task.HandoffFrom == 0) {
 			return true
 		}
 		if workerStatus.IsTombStone() {
 			return true
 		}
 		wm.logger.Info("stop outdated worker", zap.String("task_id", taskID), zap.String(logutil.ConstFieldWorkerKey, value.(runtime.WorkerStatus).ID),
-			zap.Uint64("config_modify_revision", workerStatus.CfgModRevision), zap.Uint64("expected_config_modify_revision", task.Cfg.ModRevision))
+			zap.Uint64("config_modify_revision", workerStatus.CfgModRevision), zap.Uint64("expected_config_modify_revision", task.Cfg.ModRevision),
+			zap.Int("handoff_from", task.HandoffFrom), zap.Int("sync_workers", task.SyncWorkerCount()))
 		if err := wm.stopWorker(ctx, taskID, value.(runtime.WorkerStatus).ID); err != nil {
 			recordError = err
 		}
@@ -228,62 +242,74 @@ func (wm *WorkerManager) stopOutdatedWorkers(ctx context.Context, job *metadata.
 // checkAndScheduleWorkers check whether a task need a new worker.
 // If there is no related worker, create a new worker.
 // If task is finished, check whether need a new worker.
+// If sync workers of task are scaled, hand off checkpoints before creating new workers.
 // TODO: support incremental -> all mode switch.
 func (wm *WorkerManager) checkAndScheduleWorkers(ctx context.Context, job *metadata.Job) error {
+	var recordError error
+
+	// check and schedule workers
+	for taskID, persistentTask := range job.Tasks {
+		if persistentTask.HandoffFrom != 0 {
+			if err := wm.handoff(ctx, taskID, persistentTask); err != nil {
+				recordError = err
+			}
+			continue
+		}
+		for partition := 0; partition < persistentTask.SyncWorkerCount(); partition++ {
+			if err := wm.checkAndScheduleWorker(ctx, dmconfig.TablePartitionID(taskID, partition), partitionTask(persistentTask, partition)); err != nil {
+				recordError = err
+			}
+		}
+	}
+	return recordError
+}
+
+func (wm *WorkerManager) checkAndScheduleWorker(ctx context.Context, taskID string, persistentTask *metadata.Task) error {
 	var (
 		runningWorker runtime.WorkerStatus
 		nextUnit      frameModel.WorkerType
 		isFresh       bool
 		err           error
-		recordError   error
 	)
 
-	// check and schedule workers
-	for taskID, persistentTask := range job.Tasks {
-		worker, ok := wm.workerStatusMap.Load(taskID)
-		if ok {
-			runningWorker = worker.(runtime.WorkerStatus)
-			nextUnit = getNextUnit(persistentTask, runningWorker)
-			isFresh = nextUnit != runningWorker.Unit
-		} else if nextUnit, isFresh, err = wm.getCurrentUnit(ctx, persistentTask); err != nil {
-			wm.logger.Error("get current unit failed", zap.String("task", taskID), zap.Error(err))
-			recordError = err
-			continue
-		}
-
-		if ok && runningWorker.RunAsExpected() && nextUnit == runningWorker.Unit {
-			wm.logger.Debug("worker status as expected", zap.String("task_id", taskID), zap.Stringer("worker_stage", runningWorker.Stage), zap.Stringer("unit", runningWorker.Unit))
-			continue
-		} else if !ok {
-			wm.logger.Info("task has no worker", zap.String("task_id", taskID), zap.Stringer("unit", nextUnit))
-		} else if !runningWorker.RunAsExpected() {
-			wm.logger.Info("unexpected worker status", zap.String("task_id", taskID), zap.Stringer("worker_stage", runningWorker.Stage), zap.Stringer("unit", runningWorker.Unit), zap.Stringer("next_unit", nextUnit))
-		} else {
-			wm.logger.Info("switch to next unit", zap.String("task_id", taskID), zap.Stringer("next_unit", nextUnit))
-		}
+	worker, ok := wm.workerStatusMap.Load(taskID)
+	if ok {
+		runningWorker = worker.(runtime.WorkerStatus)
+		nextUnit = getNextUnit(persistentTask, runningWorker)
+		isFresh = nextUnit != runningWorker.Unit
+	} else if nextUnit, isFresh, err = wm.getCurrentUnit(ctx, persistentTask); err != nil {
+		wm.logger.Error("get current unit failed", zap.String("task", taskID), zap.Error(err))
+		return err
+	}
 
-		var resources []resModel.ResourceID
-		taskCfg := persistentTask.Cfg
-		// first worker don't need local resource.
-		// unfresh sync unit don't need local resource.(if we need to save table checkpoint for loadTableStructureFromDump in future, we can save it before saving global checkpoint.)
-		// TODO: storage should be created/discarded in jobmaster instead of worker.
-		if workerIdxInSeq(persistentTask.Cfg.TaskMode, nextUnit) != 0 && !(nextUnit == frameModel.WorkerDMSync && !isFresh) {
-			resID := NewDMResourceID(wm.jobID, persistentTask.Cfg.Upstreams[0].SourceID, wm.storageType)
-			resources = append(resources, resID)
-		}
+	if ok && runningWorker.RunAsExpected() && nextUnit == runningWorker.Unit {
+		wm.logger.Debug("worker status as expected", zap.String("task_id", taskID), zap.Stringer("worker_stage", runningWorker.Stage), zap.Stringer("unit", runningWorker.Unit))
+		return nil
+	} else if !ok {
+		wm.logger.Info("task has no worker", zap.String("task_id", taskID), zap.Stringer("unit", nextUnit))
+	} else if !runningWorker.RunAsExpected() {
+		wm.logger.Info("unexpected worker status", zap.String("task_id", taskID), zap.Stringer("worker_stage", runningWorker.Stage), zap.Stringer("unit", runningWorker.Unit), zap.Stringer("next_unit", nextUnit))
+	} else {
+		wm.logger.Info("switch to next unit", zap.String("task_id", taskID), zap.Stringer("next_unit", nextUnit))
+	}
 
-		// FIXME: remove this after fix https://github.com/pingcap/tiflow/issues/7304
-		if nextUnit != frameModel.WorkerDMSync || isFresh {
-			taskCfg.NeedExtStorage = true
-		}
+	var resources []resModel.ResourceID
+	taskCfg := persistentTask.Cfg
+	// first worker don't need local resource.
+	// unfresh sync unit don't need local resource.(if we need to save table checkpoint for loadTableStructureFromDump in future, we can save it before saving global checkpoint.)
+	// TODO: storage should be created/discarded in jobmaster instead of worker.
+	if workerIdxInSeq(persistentTask.Cfg.TaskMode, nextUnit) != 0 && !(nextUnit == frameModel.WorkerDMSync && !isFresh) {
+		resID := NewDMResourceID(wm.jobID, persistentTask.Cfg.Upstreams[0].SourceID, wm.storageType)
+		resources = append(resources, resID)
+	}
 
-		// createWorker should be an asynchronous operation
-		if err := wm.createWorker(ctx, taskID, nextUnit, taskCfg, resources...); err != nil {
-			recordError = err
-			continue
-		}
+	// FIXME: remove this after fix https://github.com/pingcap/tiflow/issues/7304
+	if nextUnit != frameModel.WorkerDMSync || isFresh {
+		taskCfg.NeedExtStorage = true
 	}
-	return recordError
+
+	// createWorker should be an asynchronous operation
+	return wm.createWorker(ctx, taskID, nextUnit, taskCfg, resources...)
 }
 
 var workerSeqMap = map[string][]frameModel.WorkerType{
@@ -31,6 +31,10 @@ const JobAPIPrefix = "/api/v1/jobs/"
 // the entire path is: /api/v1/jobs/${jobID}/status
 const JobDetailAPIFormat = JobAPIPrefix + "%s/status"
 
+// LineageAPIPrefix is the prefix of the lineage API, which declares
+// dependencies between jobs and queries the lineage DAG of jobs.
+const LineageAPIPrefix = "/api/v1/lineage/"
+
 // HTTPError is the error format for http response.
 type HTTPError struct {
 	Code    string `json:"code"`
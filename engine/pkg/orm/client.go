@@ -37,6 +37,7 @@ var globalModels = []interface{}{
 	&model.LogicEpoch{},
 	&model.JobOp{},
 	&model.Executor{},
+	&model.JobDependency{},
 }
 
 // TODO: retry and idempotent??
@@ -74,6 +75,8 @@ type Client interface {
 	JobOpClient
 	// ExecutorClient is the client to operate executor info.
 	ExecutorClient
+	// JobDependencyClient is the client to operate dependencies between jobs.
+	JobDependencyClient
 }
 
 // ProjectClient defines interface that manages project in metastore
@@ -153,6 +156,13 @@ type ExecutorClient interface {
 	QueryExecutors(ctx context.Context) ([]*model.Executor, error)
 }
 
+// JobDependencyClient defines interface that manages dependencies between jobs in metastore.
+type JobDependencyClient interface {
+	UpsertJobDependency(ctx context.Context, dependency *model.JobDependency) error
+	DeleteJobDependency(ctx context.Context, upstreamJobID, downstreamJobID string) (Result, error)
+	QueryJobDependencies(ctx context.Context) ([]*model.JobDependency, error)
+}
+
 // NewClient return the client to operate framework metastore
 func NewClient(cc metaModel.ClientConn) (Client, error) {
 	if cc == nil {
@@ -819,6 +829,45 @@ func (c *metaOpsClient) QueryExecutors(ctx context.Context) ([]*model.Executor,
 	return executors, nil
 }
 
+// UpsertJobDependency upserts a dependency between jobs in the metastore.
+func (c *metaOpsClient) UpsertJobDependency(ctx context.Context, dependency *model.JobDependency) error {
+	if dependency == nil {
+		return errors.ErrMetaParamsInvalid.GenWithStackByArgs("input job dependency is nil")
+	}
+
+	if err := c.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "upstream_job_id"}, {Name: "downstream_job_id"}},
+			DoUpdates: clause.AssignmentColumns(model.JobDependencyUpdateColumns),
+		}).Create(dependency).Error; err != nil {
+		return errors.ErrMetaOpFail.Wrap(err)
+	}
+	return nil
+}
+
+// DeleteJobDependency deletes a dependency between jobs in the metastore.
+func (c *metaOpsClient) DeleteJobDependency(
+	ctx context.Context, upstreamJobID, downstreamJobID string,
+) (Result, error) {
+	result := c.db.WithContext(ctx).
+		Where("upstream_job_id = ? AND downstream_job_id = ?", upstreamJobID, downstreamJobID).
+		Delete(&model.JobDependency{})
+	if result.Error != nil {
+		return nil, errors.ErrMetaOpFail.Wrap(result.Error)
+	}
+	return &ormResult{rowsAffected: result.RowsAffected}, nil
+}
+
+// QueryJobDependencies queries all dependencies between jobs in the metastore.
+func (c *metaOpsClient) QueryJobDependencies(ctx context.Context) ([]*model.JobDependency, error) {
+	var dependencies []*model.JobDependency
+	if err := c.db.WithContext(ctx).
+		Find(&dependencies).Error; err != nil {
+		return nil, errors.ErrMetaOpFail.Wrap(err)
+	}
+	return dependencies, nil
+}
+
 // Result defines a query result interface
 type Result interface {
 	RowsAffected() int64
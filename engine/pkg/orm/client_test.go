@@ -1707,6 +1707,88 @@ func TestExecutorClient(t *testing.T) {
 	}
 }
 
+func TestJobDependencyClient(t *testing.T) {
+	t.Parallel()
+
+	sqlDB, mock := mockGetDBConn(t)
+	defer sqlDB.Close()
+	defer mock.ExpectClose()
+	cli, err := newClient(sqlDB, defaultTestStoreType)
+	require.Nil(t, err)
+	require.NotNil(t, cli)
+
+	tm := time.Now()
+	createdAt := tm.Add(time.Duration(1))
+	updatedAt := tm.Add(time.Duration(1))
+
+	dependency := &model.JobDependency{
+		Model: model.Model{
+			SeqID:     1,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		},
+		UpstreamJobID:   "job-1",
+		DownstreamJobID: "job-2",
+		Hooks:           model.WatermarkHooks{{Threshold: 100, URL: "http://127.0.0.1:8080/hook"}},
+	}
+	hooks := "[{\"threshold\":100,\"url\":\"http://127.0.0.1:8080/hook\"}]"
+
+	testCases := []tCase{
+		{
+			fn: "UpsertJobDependency",
+			inputs: []interface{}{
+				dependency,
+			},
+			mockExpectResFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `job_dependencies` (`created_at`,`updated_at`,`upstream_job_id`,`downstream_job_id`,`hooks`,`seq_id`) VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `updated_at`=VALUES(`updated_at`),`hooks`=VALUES(`hooks`)")).
+					WithArgs(createdAt, updatedAt, dependency.UpstreamJobID, dependency.DownstreamJobID, hooks, dependency.SeqID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			fn: "UpsertJobDependency",
+			inputs: []interface{}{
+				dependency,
+			},
+			err: errors.ErrMetaOpFail.GenWithStackByArgs(),
+			mockExpectResFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `job_dependencies` (`created_at`,`updated_at`,`upstream_job_id`,`downstream_job_id`,`hooks`,`seq_id`) VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `updated_at`=VALUES(`updated_at`),`hooks`=VALUES(`hooks`)")).
+					WithArgs(createdAt, updatedAt, dependency.UpstreamJobID, dependency.DownstreamJobID, hooks, dependency.SeqID).
+					WillReturnError(errors.New("UpsertJobDependency error"))
+			},
+		},
+		{
+			fn: "DeleteJobDependency",
+			inputs: []interface{}{
+				dependency.UpstreamJobID,
+				dependency.DownstreamJobID,
+			},
+			output: &ormResult{
+				rowsAffected: 1,
+			},
+			mockExpectResFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `job_dependencies` WHERE upstream_job_id = ? AND downstream_job_id = ?")).
+					WithArgs(dependency.UpstreamJobID, dependency.DownstreamJobID).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			fn:     "QueryJobDependencies",
+			inputs: []interface{}{},
+			output: []*model.JobDependency{dependency},
+			mockExpectResFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_dependencies`")).
+					WillReturnRows(sqlmock.NewRows([]string{
+						"seq_id", "created_at", "updated_at", "upstream_job_id", "downstream_job_id", "hooks",
+					}).AddRow(1, createdAt, updatedAt, dependency.UpstreamJobID, dependency.DownstreamJobID, hooks))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		testInner(t, mock, cli, tc)
+	}
+}
+
 func testInner(t *testing.T, m sqlmock.Sqlmock, cli Client, c tCase) {
 	// set the mock expectation
 	c.mockExpectResFn(m)
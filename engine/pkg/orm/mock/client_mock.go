@@ -138,6 +138,21 @@ func (mr *MockClientMockRecorder) DeleteJob(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJob", reflect.TypeOf((*MockClient)(nil).DeleteJob), arg0, arg1)
 }
 
+// DeleteJobDependency mocks base method.
+func (m *MockClient) DeleteJobDependency(arg0 context.Context, arg1, arg2 string) (orm.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJobDependency", arg0, arg1, arg2)
+	ret0, _ := ret[0].(orm.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteJobDependency indicates an expected call of DeleteJobDependency.
+func (mr *MockClientMockRecorder) DeleteJobDependency(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJobDependency", reflect.TypeOf((*MockClient)(nil).DeleteJobDependency), arg0, arg1, arg2)
+}
+
 // DeleteProject mocks base method.
 func (m *MockClient) DeleteProject(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -321,6 +336,21 @@ func (mr *MockClientMockRecorder) QueryExecutors(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryExecutors", reflect.TypeOf((*MockClient)(nil).QueryExecutors), arg0)
 }
 
+// QueryJobDependencies mocks base method.
+func (m *MockClient) QueryJobDependencies(arg0 context.Context) ([]*model2.JobDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryJobDependencies", arg0)
+	ret0, _ := ret[0].([]*model2.JobDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryJobDependencies indicates an expected call of QueryJobDependencies.
+func (mr *MockClientMockRecorder) QueryJobDependencies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryJobDependencies", reflect.TypeOf((*MockClient)(nil).QueryJobDependencies), arg0)
+}
+
 // QueryJobOp mocks base method.
 func (m *MockClient) QueryJobOp(arg0 context.Context, arg1 string) (*model2.JobOp, error) {
 	m.ctrl.T.Helper()
@@ -655,6 +685,20 @@ func (mr *MockClientMockRecorder) UpsertJob(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertJob", reflect.TypeOf((*MockClient)(nil).UpsertJob), arg0, arg1)
 }
 
+// UpsertJobDependency mocks base method.
+func (m *MockClient) UpsertJobDependency(arg0 context.Context, arg1 *model2.JobDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertJobDependency", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertJobDependency indicates an expected call of UpsertJobDependency.
+func (mr *MockClientMockRecorder) UpsertJobDependency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertJobDependency", reflect.TypeOf((*MockClient)(nil).UpsertJobDependency), arg0, arg1)
+}
+
 // UpsertResource mocks base method.
 func (m *MockClient) UpsertResource(arg0 context.Context, arg1 *model1.ResourceMeta) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+// WatermarkHook is fired when the watermark of the upstream job of a
+// dependency passes the threshold.
+type WatermarkHook struct {
+	Threshold uint64 `json:"threshold"`
+	// URL is the endpoint the event is posted to.
+	URL string `json:"url"`
+}
+
+// WatermarkHooks is a list of WatermarkHook.
+// It adds some extra methods for gorm to scan and convert values.
+type WatermarkHooks []WatermarkHook
+
+// Value implements the driver.Valuer interface.
+func (h WatermarkHooks) Value() (driver.Value, error) {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal WatermarkHooks: %v", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (h *WatermarkHooks) Scan(rawInput interface{}) error {
+	*h = nil
+	if rawInput == nil {
+		return nil
+	}
+
+	// see LabelSet.Scan for the two cases.
+	var bytes []byte
+	switch input := rawInput.(type) {
+	case string:
+		bytes = []byte(input)
+	case []byte:
+		bytes = input
+	default:
+		return errors.Errorf("failed to scan WatermarkHooks. "+
+			"Expected string or []byte, got %s", reflect.TypeOf(rawInput))
+	}
+	if len(bytes) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(bytes, h); err != nil {
+		return errors.Annotate(err, "failed to unmarshal WatermarkHooks")
+	}
+	return nil
+}
+
+// JobDependency records that the downstream job consumes the output of the
+// upstream job, all dependencies of jobs make up the lineage DAG.
+type JobDependency struct {
+	Model
+	UpstreamJobID   string `json:"upstream-job-id" gorm:"column:upstream_job_id;type:varchar(128) not null;uniqueIndex:uk_job_dependency,priority:1"`
+	DownstreamJobID string `json:"downstream-job-id" gorm:"column:downstream_job_id;type:varchar(128) not null;uniqueIndex:uk_job_dependency,priority:2;index:idx_downstream_job_id"`
+
+	Hooks WatermarkHooks `json:"hooks" gorm:"column:hooks;type:json"`
+}
+
+// JobDependencyUpdateColumns is used in gorm update.
+var JobDependencyUpdateColumns = []string{
+	"updated_at",
+	"hooks",
+}
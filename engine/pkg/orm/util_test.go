@@ -143,6 +143,18 @@ func TestInitialize(t *testing.T) {
 			"UNIQUE INDEX `uni_id` (`id`))"),
 	).WillReturnResult(sqlmock.NewResult(1, 1))
 
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT SCHEMA_NAME from Information_schema.SCHEMATA " +
+			"where SCHEMA_NAME LIKE ? ORDER BY SCHEMA_NAME=? DESC limit 1"),
+	).WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}))
+	mock.ExpectExec(regexp.QuoteMeta(
+		"CREATE TABLE `job_dependencies` (`seq_id` bigint unsigned AUTO_INCREMENT,"+
+			"`created_at` datetime(3) NULL,`updated_at` datetime(3) NULL,"+
+			"`upstream_job_id` varchar(128) not null,`downstream_job_id` varchar(128) not null,"+
+			"`hooks` json,PRIMARY KEY (`seq_id`),") +
+		".*", // sequence of indexes are nondeterministic
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT SCHEMA_NAME from Information_schema.SCHEMATA " +
 		"where SCHEMA_NAME LIKE ? ORDER BY SCHEMA_NAME=? DESC limit 1")).WillReturnRows(
 		sqlmock.NewRows([]string{"SCHEMA_NAME"}))
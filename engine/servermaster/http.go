@@ -26,7 +26,10 @@ import (
 )
 
 // registerRoutes registers the routes for the HTTP server.
-func registerRoutes(router *http.ServeMux, grpcMux *runtime.ServeMux, forwardJobAPI http.HandlerFunc) {
+func registerRoutes(
+	router *http.ServeMux, grpcMux *runtime.ServeMux,
+	forwardJobAPI http.HandlerFunc, lineageAPI http.HandlerFunc,
+) {
 	// Swagger UI
 	router.HandleFunc("/swagger", openapi.SwaggerUI)
 	router.HandleFunc("/swagger/v1/openapiv2.json", openapi.SwaggerAPIv1)
@@ -48,6 +51,9 @@ func registerRoutes(router *http.ServeMux, grpcMux *runtime.ServeMux, forwardJob
 		}
 	})
 
+	// Lineage API, which is served by the leader.
+	router.HandleFunc(openapi.LineageAPIPrefix, lineageAPI)
+
 	// pprof debug API
 	router.HandleFunc("/debug/pprof/", pprof.Index)
 	router.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
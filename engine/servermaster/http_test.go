@@ -45,7 +45,10 @@ func TestRegisterRoutes(t *testing.T) {
 			http.NotFound(w, r)
 		}
 	})
-	registerRoutes(router, grpcMux, forwardJobAPI)
+	lineageAPI := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	registerRoutes(router, grpcMux, forwardJobAPI, lineageAPI)
 
 	testCases := []struct {
 		method       string
@@ -107,6 +110,11 @@ func TestRegisterRoutes(t *testing.T) {
 			path:         "/api/v1/jobs/job1/config",
 			expectedCode: http.StatusNotFound,
 		},
+		{
+			method:       http.MethodGet,
+			path:         "/api/v1/lineage/jobs/job1",
+			expectedCode: http.StatusOK,
+		},
 		{
 			method:       http.MethodGet,
 			path:         "/debug/pprof/",
@@ -0,0 +1,82 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineage
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/engine/pkg/openapi"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// WatermarkRequest is the request to report the watermark of a job.
+type WatermarkRequest struct {
+	Watermark uint64 `json:"watermark"`
+}
+
+// ServeHTTP serves the lineage API:
+//
+//	POST   /api/v1/lineage/dependencies                              add a dependency
+//	DELETE /api/v1/lineage/dependencies/{upstream_id}/{downstream_id} remove a dependency
+//	GET    /api/v1/lineage/jobs/{job_id}                             get the lineage DAG of a job
+//	PUT    /api/v1/lineage/jobs/{job_id}/watermark                   report the watermark of a job
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := m.serveHTTP(w, r); err != nil {
+		openapi.WriteHTTPError(w, err)
+	}
+}
+
+func (m *Manager) serveHTTP(w http.ResponseWriter, r *http.Request) error {
+	fields := strings.Split(strings.TrimPrefix(r.URL.Path, openapi.LineageAPIPrefix), "/")
+	switch {
+	case len(fields) == 1 && fields[0] == "dependencies" && r.Method == http.MethodPost:
+		var dependency Dependency
+		if err := json.NewDecoder(r.Body).Decode(&dependency); err != nil {
+			return errors.ErrInvalidArgument.GenWithStack("invalid dependency: %v", err)
+		}
+		if err := m.AddDependency(r.Context(), dependency); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusOK)
+	case len(fields) == 3 && fields[0] == "dependencies" && r.Method == http.MethodDelete:
+		if err := m.RemoveDependency(r.Context(), fields[1], fields[2]); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusOK)
+	case len(fields) == 2 && fields[0] == "jobs" && r.Method == http.MethodGet:
+		writeJSON(w, m.GetLineage(fields[1]))
+	case len(fields) == 3 && fields[0] == "jobs" && fields[2] == "watermark" && r.Method == http.MethodPut:
+		var req WatermarkRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return errors.ErrInvalidArgument.GenWithStack("invalid watermark: %v", err)
+		}
+		m.UpdateWatermark(r.Context(), fields[1], req.Watermark)
+		w.WriteHeader(http.StatusOK)
+	default:
+		return errors.ErrInvalidArgument.GenWithStack("invalid lineage api: %s %s", r.Method, r.URL.Path)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn("Failed to write response", zap.Error(err))
+	}
+}
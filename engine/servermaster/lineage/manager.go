@@ -0,0 +1,312 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineage
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pingcap/log"
+	pkgOrm "github.com/pingcap/tiflow/engine/pkg/orm"
+	ormModel "github.com/pingcap/tiflow/engine/pkg/orm/model"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Dependency is a dependency between jobs, the downstream job consumes the
+// output of the upstream job.
+type Dependency struct {
+	UpstreamJobID   string                   `json:"upstream_job_id"`
+	DownstreamJobID string                   `json:"downstream_job_id"`
+	Hooks           []ormModel.WatermarkHook `json:"hooks,omitempty"`
+}
+
+// Job is a job in the lineage DAG.
+type Job struct {
+	ID string `json:"id"`
+	// Watermark is the latest watermark reported by the job, zero if the job
+	// has not reported any watermark since the server master became leader.
+	Watermark uint64 `json:"watermark"`
+}
+
+// Lineage is the lineage DAG of a job, which consists of all upstream jobs
+// and downstream jobs of the job, directly or indirectly.
+type Lineage struct {
+	// Jobs are sorted in topological order.
+	Jobs         []Job        `json:"jobs"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// WatermarkEvent is posted to the hook when the watermark of the upstream job
+// passes the threshold of the hook.
+type WatermarkEvent struct {
+	UpstreamJobID   string `json:"upstream_job_id"`
+	DownstreamJobID string `json:"downstream_job_id"`
+	Threshold       uint64 `json:"threshold"`
+	Watermark       uint64 `json:"watermark"`
+}
+
+// Manager manages dependencies between jobs and fires watermark hooks.
+//
+// Watermarks are kept in memory only, so the hooks are fired at least once:
+// after the leader of server master changes, hooks whose thresholds are below
+// the first reported watermark of the upstream job are fired again.
+type Manager struct {
+	metaClient pkgOrm.Client
+	notifier   Notifier
+
+	mu sync.RWMutex
+	// upstream job ID -> downstream job ID -> dependency
+	downstreams map[string]map[string]*ormModel.JobDependency
+	// downstream job ID -> upstream job ID -> dependency
+	upstreams  map[string]map[string]*ormModel.JobDependency
+	watermarks map[string]uint64
+}
+
+// NewManager creates a new Manager.
+func NewManager(metaClient pkgOrm.Client, notifier Notifier) *Manager {
+	return &Manager{
+		metaClient:  metaClient,
+		notifier:    notifier,
+		downstreams: make(map[string]map[string]*ormModel.JobDependency),
+		upstreams:   make(map[string]map[string]*ormModel.JobDependency),
+		watermarks:  make(map[string]uint64),
+	}
+}
+
+// Load loads all dependencies from the metastore.
+func (m *Manager) Load(ctx context.Context) error {
+	dependencies, err := m.metaClient.QueryJobDependencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, dependency := range dependencies {
+		m.addLocked(dependency)
+	}
+	log.Info("job dependencies loaded", zap.Int("count", len(dependencies)))
+	return nil
+}
+
+// AddDependency adds or updates a dependency between jobs.
+func (m *Manager) AddDependency(ctx context.Context, dependency Dependency) error {
+	upstream, downstream := dependency.UpstreamJobID, dependency.DownstreamJobID
+	if upstream == "" || downstream == "" {
+		return errors.ErrInvalidArgument.GenWithStackByArgs("upstream job id and downstream job id must be specified")
+	}
+	for _, hook := range dependency.Hooks {
+		if hook.URL == "" {
+			return errors.ErrInvalidArgument.GenWithStackByArgs("url of watermark hook must be specified")
+		}
+	}
+	for _, jobID := range []string{upstream, downstream} {
+		if _, err := m.metaClient.GetJobByID(ctx, jobID); err != nil {
+			if pkgOrm.IsNotFoundError(err) {
+				return errors.ErrJobNotFound.GenWithStackByArgs(jobID)
+			}
+			return err
+		}
+	}
+
+	// hold the lock until the dependency is persisted, so that concurrent
+	// dependencies can't form a cycle.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if upstream == downstream || m.reachableLocked(downstream, upstream) {
+		return errors.ErrJobDependencyCycle.GenWithStackByArgs(upstream, downstream)
+	}
+	dep := &ormModel.JobDependency{
+		UpstreamJobID:   upstream,
+		DownstreamJobID: downstream,
+		Hooks:           dependency.Hooks,
+	}
+	if err := m.metaClient.UpsertJobDependency(ctx, dep); err != nil {
+		return err
+	}
+	m.addLocked(dep)
+	log.Info("job dependency added", zap.String("upstream", upstream), zap.String("downstream", downstream))
+	return nil
+}
+
+// RemoveDependency removes a dependency between jobs.
+func (m *Manager) RemoveDependency(ctx context.Context, upstream, downstream string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.downstreams[upstream][downstream]; !ok {
+		return errors.ErrJobDependencyNotFound.GenWithStackByArgs(upstream, downstream)
+	}
+	if _, err := m.metaClient.DeleteJobDependency(ctx, upstream, downstream); err != nil {
+		return err
+	}
+	removeEdge(m.downstreams, upstream, downstream)
+	removeEdge(m.upstreams, downstream, upstream)
+	log.Info("job dependency removed", zap.String("upstream", upstream), zap.String("downstream", downstream))
+	return nil
+}
+
+// GetLineage returns the lineage DAG of a job.
+func (m *Manager) GetLineage(jobID string) *Lineage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := map[string]struct{}{jobID: {}}
+	m.walkLocked(m.upstreams, jobID, jobs)
+	m.walkLocked(m.downstreams, jobID, jobs)
+
+	lineage := &Lineage{
+		Jobs:         make([]Job, 0, len(jobs)),
+		Dependencies: make([]Dependency, 0),
+	}
+	for _, id := range m.topoSortLocked(jobs) {
+		lineage.Jobs = append(lineage.Jobs, Job{ID: id, Watermark: m.watermarks[id]})
+		for _, downstream := range sortedKeys(m.downstreams[id]) {
+			if _, ok := jobs[downstream]; !ok {
+				continue
+			}
+			dep := m.downstreams[id][downstream]
+			lineage.Dependencies = append(lineage.Dependencies, Dependency{
+				UpstreamJobID:   dep.UpstreamJobID,
+				DownstreamJobID: dep.DownstreamJobID,
+				Hooks:           dep.Hooks,
+			})
+		}
+	}
+	return lineage
+}
+
+// UpdateWatermark updates the watermark of a job, and fires the hooks of
+// downstream dependencies whose thresholds are passed. The watermark of a job
+// never goes back.
+func (m *Manager) UpdateWatermark(ctx context.Context, jobID string, watermark uint64) {
+	var events []*WatermarkEvent
+	var urls []string
+
+	m.mu.Lock()
+	prev := m.watermarks[jobID]
+	if watermark <= prev {
+		m.mu.Unlock()
+		return
+	}
+	m.watermarks[jobID] = watermark
+	for _, downstream := range sortedKeys(m.downstreams[jobID]) {
+		for _, hook := range m.downstreams[jobID][downstream].Hooks {
+			if hook.Threshold <= prev || hook.Threshold > watermark {
+				continue
+			}
+			events = append(events, &WatermarkEvent{
+				UpstreamJobID:   jobID,
+				DownstreamJobID: downstream,
+				Threshold:       hook.Threshold,
+				Watermark:       watermark,
+			})
+			urls = append(urls, hook.URL)
+		}
+	}
+	m.mu.Unlock()
+
+	for i, event := range events {
+		if err := m.notifier.Notify(ctx, urls[i], event); err != nil {
+			log.Warn("fire watermark hook failed",
+				zap.String("url", urls[i]), zap.Any("event", event), zap.Error(err))
+		}
+	}
+}
+
+func (m *Manager) addLocked(dep *ormModel.JobDependency) {
+	addEdge(m.downstreams, dep.UpstreamJobID, dep.DownstreamJobID, dep)
+	addEdge(m.upstreams, dep.DownstreamJobID, dep.UpstreamJobID, dep)
+}
+
+// reachableLocked returns whether `to` is a downstream job of `from`.
+func (m *Manager) reachableLocked(from, to string) bool {
+	visited := make(map[string]struct{})
+	m.walkLocked(m.downstreams, from, visited)
+	_, ok := visited[to]
+	return ok
+}
+
+func (m *Manager) walkLocked(edges map[string]map[string]*ormModel.JobDependency, from string, visited map[string]struct{}) {
+	for to := range edges[from] {
+		if _, ok := visited[to]; ok {
+			continue
+		}
+		visited[to] = struct{}{}
+		m.walkLocked(edges, to, visited)
+	}
+}
+
+// topoSortLocked sorts jobs in topological order, jobs at the same depth are
+// sorted by ID to make the result stable.
+func (m *Manager) topoSortLocked(jobs map[string]struct{}) []string {
+	inDegree := make(map[string]int, len(jobs))
+	for id := range jobs {
+		for upstream := range m.upstreams[id] {
+			if _, ok := jobs[upstream]; ok {
+				inDegree[id]++
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(jobs))
+	var current []string
+	for id := range jobs {
+		if inDegree[id] == 0 {
+			current = append(current, id)
+		}
+	}
+	for len(current) > 0 {
+		sort.Strings(current)
+		sorted = append(sorted, current...)
+		var next []string
+		for _, id := range current {
+			for downstream := range m.downstreams[id] {
+				if _, ok := jobs[downstream]; !ok {
+					continue
+				}
+				inDegree[downstream]--
+				if inDegree[downstream] == 0 {
+					next = append(next, downstream)
+				}
+			}
+		}
+		current = next
+	}
+	return sorted
+}
+
+func addEdge(edges map[string]map[string]*ormModel.JobDependency, from, to string, dep *ormModel.JobDependency) {
+	if _, ok := edges[from]; !ok {
+		edges[from] = make(map[string]*ormModel.JobDependency)
+	}
+	edges[from][to] = dep
+}
+
+func removeEdge(edges map[string]map[string]*ormModel.JobDependency, from, to string) {
+	delete(edges[from], to)
+	if len(edges[from]) == 0 {
+		delete(edges, from)
+	}
+}
+
+func sortedKeys(m map[string]*ormModel.JobDependency) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,261 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	frameModel "github.com/pingcap/tiflow/engine/framework/model"
+	pkgOrm "github.com/pingcap/tiflow/engine/pkg/orm"
+	ormModel "github.com/pingcap/tiflow/engine/pkg/orm/model"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/stretchr/testify/require"
+)
+
+type mockNotifier struct {
+	mu     sync.Mutex
+	urls   []string
+	events []*WatermarkEvent
+}
+
+func (n *mockNotifier) Notify(ctx context.Context, url string, event *WatermarkEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.urls = append(n.urls, url)
+	n.events = append(n.events, event)
+	return nil
+}
+
+func newManagerForTest(t *testing.T, jobIDs ...string) (*Manager, pkgOrm.Client, *mockNotifier) {
+	metaClient, err := pkgOrm.NewMockClient()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, metaClient.Close())
+	})
+	for _, jobID := range jobIDs {
+		require.NoError(t, metaClient.UpsertJob(context.Background(), &frameModel.MasterMeta{
+			ID:    jobID,
+			Type:  frameModel.FakeJobMaster,
+			State: frameModel.MasterStateInit,
+		}))
+	}
+	notifier := &mockNotifier{}
+	return NewManager(metaClient, notifier), metaClient, notifier
+}
+
+func TestDependency(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m, metaClient, _ := newManagerForTest(t, "cdc", "compact", "report", "other")
+
+	require.True(t, errors.Is(m.AddDependency(ctx, Dependency{UpstreamJobID: "cdc"}), errors.ErrInvalidArgument))
+	require.True(t, errors.Is(m.AddDependency(ctx, Dependency{
+		UpstreamJobID: "cdc", DownstreamJobID: "compact", Hooks: []ormModel.WatermarkHook{{Threshold: 1}},
+	}), errors.ErrInvalidArgument))
+	require.True(t, errors.Is(m.AddDependency(ctx, Dependency{UpstreamJobID: "cdc", DownstreamJobID: "not-exist"}), errors.ErrJobNotFound))
+	require.True(t, errors.Is(m.AddDependency(ctx, Dependency{UpstreamJobID: "cdc", DownstreamJobID: "cdc"}), errors.ErrJobDependencyCycle))
+
+	// cdc -> compact -> report
+	require.NoError(t, m.AddDependency(ctx, Dependency{UpstreamJobID: "cdc", DownstreamJobID: "compact"}))
+	require.NoError(t, m.AddDependency(ctx, Dependency{UpstreamJobID: "compact", DownstreamJobID: "report"}))
+	require.True(t, errors.Is(m.AddDependency(ctx, Dependency{UpstreamJobID: "report", DownstreamJobID: "cdc"}), errors.ErrJobDependencyCycle))
+	// cdc -> report
+	require.NoError(t, m.AddDependency(ctx, Dependency{UpstreamJobID: "cdc", DownstreamJobID: "report"}))
+
+	expected := &Lineage{
+		Jobs: []Job{{ID: "cdc"}, {ID: "compact"}, {ID: "report"}},
+		Dependencies: []Dependency{
+			{UpstreamJobID: "cdc", DownstreamJobID: "compact"},
+			{UpstreamJobID: "cdc", DownstreamJobID: "report"},
+			{UpstreamJobID: "compact", DownstreamJobID: "report"},
+		},
+	}
+	require.Equal(t, expected, m.GetLineage("compact"))
+	require.Equal(t, expected, m.GetLineage("report"))
+	require.Equal(t, &Lineage{Jobs: []Job{{ID: "other"}}, Dependencies: []Dependency{}}, m.GetLineage("other"))
+
+	// dependencies are persisted
+	m2 := NewManager(metaClient, &mockNotifier{})
+	require.NoError(t, m2.Load(ctx))
+	require.Equal(t, expected, m2.GetLineage("cdc"))
+
+	require.True(t, errors.Is(m.RemoveDependency(ctx, "report", "cdc"), errors.ErrJobDependencyNotFound))
+	require.NoError(t, m.RemoveDependency(ctx, "cdc", "compact"))
+	require.Equal(t, &Lineage{
+		Jobs: []Job{{ID: "cdc"}, {ID: "report"}},
+		Dependencies: []Dependency{
+			{UpstreamJobID: "cdc", DownstreamJobID: "report"},
+		},
+	}, m.GetLineage("cdc"))
+	// report -> cdc is still a cycle
+	require.True(t, errors.Is(m.AddDependency(ctx, Dependency{UpstreamJobID: "report", DownstreamJobID: "cdc"}), errors.ErrJobDependencyCycle))
+	// compact -> cdc is valid now
+	require.NoError(t, m.AddDependency(ctx, Dependency{UpstreamJobID: "compact", DownstreamJobID: "cdc"}))
+
+	m2 = NewManager(metaClient, &mockNotifier{})
+	require.NoError(t, m2.Load(ctx))
+	require.Equal(t, m.GetLineage("report"), m2.GetLineage("report"))
+}
+
+func TestUpdateWatermark(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m, _, notifier := newManagerForTest(t, "cdc", "compact", "report")
+	require.NoError(t, m.AddDependency(ctx, Dependency{
+		UpstreamJobID:   "cdc",
+		DownstreamJobID: "compact",
+		Hooks: []ormModel.WatermarkHook{
+			{Threshold: 100, URL: "http://compact/100"},
+			{Threshold: 200, URL: "http://compact/200"},
+		},
+	}))
+	require.NoError(t, m.AddDependency(ctx, Dependency{
+		UpstreamJobID:   "cdc",
+		DownstreamJobID: "report",
+		Hooks: []ormModel.WatermarkHook{
+			{Threshold: 150, URL: "http://report/150"},
+		},
+	}))
+
+	m.UpdateWatermark(ctx, "cdc", 99)
+	require.Empty(t, notifier.urls)
+	m.UpdateWatermark(ctx, "cdc", 150)
+	require.Equal(t, []string{"http://compact/100", "http://report/150"}, notifier.urls)
+	require.Equal(t, &WatermarkEvent{
+		UpstreamJobID:   "cdc",
+		DownstreamJobID: "report",
+		Threshold:       150,
+		Watermark:       150,
+	}, notifier.events[1])
+	// watermark never goes back
+	m.UpdateWatermark(ctx, "cdc", 90)
+	m.UpdateWatermark(ctx, "cdc", 150)
+	require.Len(t, notifier.urls, 2)
+	m.UpdateWatermark(ctx, "cdc", 1000)
+	require.Equal(t, []string{"http://compact/100", "http://report/150", "http://compact/200"}, notifier.urls)
+	// jobs without downstream jobs
+	m.UpdateWatermark(ctx, "report", 1000)
+	require.Len(t, notifier.urls, 3)
+
+	require.Equal(t, []Job{{ID: "cdc", Watermark: 1000}, {ID: "compact"}, {ID: "report", Watermark: 1000}}, m.GetLineage("cdc").Jobs)
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	var received WatermarkEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received.Threshold == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	cli, err := httputil.NewClient(nil)
+	require.NoError(t, err)
+	defer cli.CloseIdleConnections()
+	notifier := NewWebhookNotifier(cli)
+	event := &WatermarkEvent{UpstreamJobID: "cdc", DownstreamJobID: "compact", Threshold: 100, Watermark: 120}
+	require.NoError(t, notifier.Notify(context.Background(), server.URL, event))
+	require.Equal(t, *event, received)
+	require.Error(t, notifier.Notify(context.Background(), server.URL, &WatermarkEvent{}))
+}
+
+func TestServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	m, _, notifier := newManagerForTest(t, "cdc", "compact")
+	testCases := []struct {
+		method       string
+		path         string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			method:       http.MethodPost,
+			path:         "/api/v1/lineage/dependencies",
+			body:         `{"upstream_job_id": "cdc", "downstream_job_id": "compact", "hooks": [{"threshold": 10, "url": "http://compact"}]}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			method:       http.MethodPost,
+			path:         "/api/v1/lineage/dependencies",
+			body:         `{"upstream_job_id": "compact", "downstream_job_id": "cdc"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			method:       http.MethodPost,
+			path:         "/api/v1/lineage/dependencies",
+			body:         `{"upstream_job_id": "cdc", "downstream_job_id": "not-exist"}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			method:       http.MethodPost,
+			path:         "/api/v1/lineage/dependencies",
+			body:         `invalid`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			method:       http.MethodPut,
+			path:         "/api/v1/lineage/jobs/cdc/watermark",
+			body:         `{"watermark": 20}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			method:       http.MethodGet,
+			path:         "/api/v1/lineage/jobs/compact",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"jobs":[{"id":"cdc","watermark":20},{"id":"compact","watermark":0}],` +
+				`"dependencies":[{"upstream_job_id":"cdc","downstream_job_id":"compact","hooks":[{"threshold":10,"url":"http://compact"}]}]}`,
+		},
+		{
+			method:       http.MethodDelete,
+			path:         "/api/v1/lineage/dependencies/cdc/compact",
+			expectedCode: http.StatusOK,
+		},
+		{
+			method:       http.MethodDelete,
+			path:         "/api/v1/lineage/dependencies/cdc/compact",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			method:       http.MethodGet,
+			path:         "/api/v1/lineage/dependencies",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, req)
+		require.Equal(t, tc.expectedCode, w.Code, "%s %s: %s", tc.method, tc.path, w.Body.String())
+		if tc.expectedBody != "" {
+			require.JSONEq(t, tc.expectedBody, w.Body.String())
+		}
+	}
+	require.Equal(t, []string{"http://compact"}, notifier.urls)
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+)
+
+// Notifier fires watermark hooks.
+type Notifier interface {
+	Notify(ctx context.Context, url string, event *WatermarkEvent) error
+}
+
+// webhookNotifier posts watermark events to hooks in json.
+type webhookNotifier struct {
+	cli *httputil.Client
+}
+
+// NewWebhookNotifier creates a Notifier which posts watermark events to hooks.
+func NewWebhookNotifier(cli *httputil.Client) Notifier {
+	return &webhookNotifier{cli: cli}
+}
+
+// Notify implements Notifier.Notify.
+func (n *webhookNotifier) Notify(ctx context.Context, url string, event *WatermarkEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	_, err = n.cli.DoRequest(ctx, url, http.MethodPost, header, bytes.NewReader(body))
+	return err
+}
@@ -46,15 +46,18 @@ import (
 	"github.com/pingcap/tiflow/engine/pkg/p2p"
 	"github.com/pingcap/tiflow/engine/pkg/rpcutil"
 	"github.com/pingcap/tiflow/engine/pkg/tenant"
+	"github.com/pingcap/tiflow/engine/servermaster/lineage"
 	"github.com/pingcap/tiflow/engine/servermaster/scheduler"
 	schedModel "github.com/pingcap/tiflow/engine/servermaster/scheduler/model"
 	"github.com/pingcap/tiflow/engine/servermaster/serverutil"
 	"github.com/pingcap/tiflow/pkg/errors"
+	pkgHTTPUtil "github.com/pingcap/tiflow/pkg/httputil"
 	"github.com/pingcap/tiflow/pkg/label"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/tcpserver"
 	p2pProtocol "github.com/pingcap/tiflow/proto/p2p"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
@@ -111,6 +114,9 @@ type Server struct {
 
 	metaStoreManager MetaStoreManager
 
+	// lineageManager is only available on the leader.
+	lineageManager atomic.Pointer[lineage.Manager]
+
 	leaderDegrader *featureDegrader
 	forwardChecker *forwardChecker
 
@@ -615,7 +621,7 @@ func (s *Server) createHTTPServer(conn *grpc.ClientConn) (*http.Server, error) {
 	}
 
 	router := http.NewServeMux()
-	registerRoutes(router, grpcMux, s.forwardJobAPI)
+	registerRoutes(router, grpcMux, s.forwardJobAPI, s.lineageAPI)
 
 	return &http.Server{
 		Handler:           router,
@@ -629,6 +635,31 @@ func (s *Server) forwardJobAPI(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *Server) lineageAPI(w http.ResponseWriter, r *http.Request) {
+	if s.elector.IsLeader() {
+		lineageManager := s.lineageManager.Load()
+		if lineageManager == nil {
+			openapi.WriteHTTPError(w, errors.ErrMasterNotReady.GenWithStackByArgs())
+			return
+		}
+		lineageManager.ServeHTTP(w, r)
+		return
+	}
+
+	leaderAddr, ok := s.leaderAddr()
+	if !ok {
+		openapi.WriteHTTPError(w, errors.New("no leader found"))
+		return
+	}
+	// TODO: Support TLS.
+	u, err := url.Parse("http://" + leaderAddr)
+	if err != nil {
+		openapi.WriteHTTPError(w, errors.Errorf("invalid leader address %s", leaderAddr))
+		return
+	}
+	httputil.NewSingleHostReverseProxy(u).ServeHTTP(w, r)
+}
+
 func (s *Server) handleForwardJobAPI(w http.ResponseWriter, r *http.Request) error {
 	apiPath := strings.TrimPrefix(r.URL.Path, openapi.JobAPIPrefix)
 	fields := strings.SplitN(apiPath, "/", 2)
@@ -806,6 +837,18 @@ func (s *Server) runLeaderService(ctx context.Context) (err error) {
 		log.Info("job manager exited")
 	}()
 
+	httpCli, err := pkgHTTPUtil.NewClient(nil)
+	if err != nil {
+		return
+	}
+	httpCli.SetTimeout(defaultHTTPTimeout)
+	lineageManager := lineage.NewManager(s.frameMetaClient, lineage.NewWebhookNotifier(httpCli))
+	if err = lineageManager.Load(ctx); err != nil {
+		return
+	}
+	s.lineageManager.Store(lineageManager)
+	defer s.lineageManager.Store(nil)
+
 	s.gcRunner = externRescManager.NewGCRunner(s.frameMetaClient, executorClients, &s.cfg.Storage)
 	s.gcCoordinator = externRescManager.NewGCCoordinator(s.executorManager, s.jobManager, s.frameMetaClient, s.gcRunner)
 
@@ -29,6 +29,8 @@ type APIV2Interface interface {
 	StatusGetter
 	CapturesGetter
 	ProcessorsGetter
+	FederationGetter
+	MetricsGetter
 }
 
 // APIV2Client implements APIV1Interface and it is used to interact with cdc owner http api.
@@ -90,6 +92,22 @@ func (c *APIV2Client) Processors() ProcessorInterface {
 	return newProcessors(c)
 }
 
+// Federation returns a FederationInterface to communicate with cdc api
+func (c *APIV2Client) Federation() FederationInterface {
+	if c == nil {
+		return nil
+	}
+	return newFederation(c)
+}
+
+// Metrics returns a MetricsInterface to communicate with cdc api
+func (c *APIV2Client) Metrics() MetricsInterface {
+	if c == nil {
+		return nil
+	}
+	return newMetrics(c)
+}
+
 // NewAPIClient creates a new APIV1Client.
 func NewAPIClient(serverAddr string, credential *security.Credential) (*APIV2Client, error) {
 	c := &rest.Config{}
@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	v2 "github.com/pingcap/tiflow/cdc/api/v2"
@@ -47,6 +48,8 @@ type ChangefeedInterface interface {
 	Get(ctx context.Context, namespace string, name string) (*v2.ChangeFeedInfo, error)
 	// List lists all changefeeds
 	List(ctx context.Context, namespace string, state string) ([]v2.ChangefeedCommonInfo, error)
+	// SchedulerSnapshot dumps a redacted snapshot of the scheduler states
+	SchedulerSnapshot(ctx context.Context, namespace string, name string) ([]byte, error)
 }
 
 // changefeeds implements ChangefeedInterface
@@ -157,3 +160,16 @@ func (c *changefeeds) List(ctx context.Context,
 		Into(result)
 	return result.Items, err
 }
+
+// SchedulerSnapshot dumps a redacted snapshot of the scheduler states
+func (c *changefeeds) SchedulerSnapshot(ctx context.Context,
+	namespace string, name string,
+) ([]byte, error) {
+	var result json.RawMessage
+	u := fmt.Sprintf("changefeeds/%s/scheduler_snapshot?namespace=%s", name, namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(&result)
+	return result, err
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/pkg/api/internal/rest"
+)
+
+// FederationGetter has a method to return a FederationInterface.
+type FederationGetter interface {
+	Federation() FederationInterface
+}
+
+// FederationInterface has methods to work with federation api
+type FederationInterface interface {
+	// List lists changefeeds of all clusters in the federation
+	List(ctx context.Context, namespace string, state string) ([]v2.FederatedCluster, error)
+}
+
+// federation implements FederationInterface
+type federation struct {
+	client rest.CDCRESTInterface
+}
+
+// newFederation returns federation
+func newFederation(c *APIV2Client) *federation {
+	return &federation{
+		client: c.RESTClient(),
+	}
+}
+
+// List lists changefeeds of all clusters in the federation
+func (c *federation) List(ctx context.Context,
+	namespace string, state string,
+) ([]v2.FederatedCluster, error) {
+	result := &v2.ListResponse[v2.FederatedCluster]{}
+	err := c.client.Get().
+		WithURI("federation/changefeeds?namespace="+namespace).
+		WithParam("state", state).
+		Do(ctx).
+		Into(result)
+	return result.Items, err
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/pkg/api/internal/rest"
+)
+
+// MetricsGetter has a method to return a MetricsInterface.
+type MetricsGetter interface {
+	Metrics() MetricsInterface
+}
+
+// MetricsInterface has methods to work with metrics summary api
+type MetricsInterface interface {
+	Summary(ctx context.Context) (*v2.MetricsSummary, error)
+	ClusterSummary(ctx context.Context) ([]v2.MetricsSummary, error)
+}
+
+// metrics implements MetricsInterface
+type metrics struct {
+	client rest.CDCRESTInterface
+}
+
+// newMetrics returns metrics
+func newMetrics(c *APIV2Client) *metrics {
+	return &metrics{
+		client: c.RESTClient(),
+	}
+}
+
+// Summary returns the metrics summary of the connected capture
+func (c *metrics) Summary(ctx context.Context) (*v2.MetricsSummary, error) {
+	result := new(v2.MetricsSummary)
+	err := c.client.Get().
+		WithURI("metrics/summary").
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// ClusterSummary returns the metrics summaries of all captures
+func (c *metrics) ClusterSummary(ctx context.Context) ([]v2.MetricsSummary, error) {
+	result := &v2.ListResponse[v2.MetricsSummary]{}
+	err := c.client.Get().
+		WithURI("metrics/cluster_summary").
+		Do(ctx).
+		Into(result)
+	return result.Items, err
+}
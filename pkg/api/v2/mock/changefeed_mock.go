@@ -160,6 +160,21 @@ func (mr *MockChangefeedInterfaceMockRecorder) Resume(ctx, cfg, namespace, name
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockChangefeedInterface)(nil).Resume), ctx, cfg, namespace, name)
 }
 
+// SchedulerSnapshot mocks base method.
+func (m *MockChangefeedInterface) SchedulerSnapshot(ctx context.Context, namespace, name string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulerSnapshot", ctx, namespace, name)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulerSnapshot indicates an expected call of SchedulerSnapshot.
+func (mr *MockChangefeedInterfaceMockRecorder) SchedulerSnapshot(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulerSnapshot", reflect.TypeOf((*MockChangefeedInterface)(nil).SchedulerSnapshot), ctx, namespace, name)
+}
+
 // Update mocks base method.
 func (m *MockChangefeedInterface) Update(ctx context.Context, cfg *v2.ChangefeedConfig, namespace, name string) (*v2.ChangeFeedInfo, error) {
 	m.ctrl.T.Helper()
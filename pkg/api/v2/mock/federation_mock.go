@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/api/v2/federation.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	v20 "github.com/pingcap/tiflow/pkg/api/v2"
+)
+
+// MockFederationGetter is a mock of FederationGetter interface.
+type MockFederationGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFederationGetterMockRecorder
+}
+
+// MockFederationGetterMockRecorder is the mock recorder for MockFederationGetter.
+type MockFederationGetterMockRecorder struct {
+	mock *MockFederationGetter
+}
+
+// NewMockFederationGetter creates a new mock instance.
+func NewMockFederationGetter(ctrl *gomock.Controller) *MockFederationGetter {
+	mock := &MockFederationGetter{ctrl: ctrl}
+	mock.recorder = &MockFederationGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFederationGetter) EXPECT() *MockFederationGetterMockRecorder {
+	return m.recorder
+}
+
+// Federation mocks base method.
+func (m *MockFederationGetter) Federation() v20.FederationInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Federation")
+	ret0, _ := ret[0].(v20.FederationInterface)
+	return ret0
+}
+
+// Federation indicates an expected call of Federation.
+func (mr *MockFederationGetterMockRecorder) Federation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Federation", reflect.TypeOf((*MockFederationGetter)(nil).Federation))
+}
+
+// MockFederationInterface is a mock of FederationInterface interface.
+type MockFederationInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFederationInterfaceMockRecorder
+}
+
+// MockFederationInterfaceMockRecorder is the mock recorder for MockFederationInterface.
+type MockFederationInterfaceMockRecorder struct {
+	mock *MockFederationInterface
+}
+
+// NewMockFederationInterface creates a new mock instance.
+func NewMockFederationInterface(ctrl *gomock.Controller) *MockFederationInterface {
+	mock := &MockFederationInterface{ctrl: ctrl}
+	mock.recorder = &MockFederationInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFederationInterface) EXPECT() *MockFederationInterfaceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockFederationInterface) List(ctx context.Context, namespace, state string) ([]v2.FederatedCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, namespace, state)
+	ret0, _ := ret[0].([]v2.FederatedCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFederationInterfaceMockRecorder) List(ctx, namespace, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFederationInterface)(nil).List), ctx, namespace, state)
+}
@@ -77,6 +77,10 @@ type RedoApplier struct {
 	tableSinks         map[model.TableID]tablesink.TableSink
 	tableResolvedTsMap map[model.TableID]*memquota.MemConsumeRecord
 	appliedLogCount    uint64
+	// coveredTables are the tables covered by the redo log with their
+	// flushed resolved ts. It's nil if all tables are covered.
+	coveredTables   map[model.TableID]model.Ts
+	skippedLogCount uint64
 
 	errCh chan error
 
@@ -162,6 +166,13 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 	log.Info("apply redo log starts",
 		zap.Uint64("checkpointTs", checkpointTs),
 		zap.Uint64("resolvedTs", resolvedTs))
+	if ra.coveredTables, err = ra.rd.ReadCoveredTables(ctx); err != nil {
+		return err
+	}
+	if ra.coveredTables != nil {
+		log.Info("redo log covers part of the tables, only these tables are recovered",
+			zap.Any("coveredTables", ra.coveredTables))
+	}
 	if err := ra.initSink(ctx); err != nil {
 		return err
 	}
@@ -199,8 +210,14 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 				return err
 			}
 		} else {
-			if err := ra.applyRow(row, checkpointTs); err != nil {
-				return err
+			if ra.coversTable(row.Table.TableID) {
+				if err := ra.applyRow(row, checkpointTs); err != nil {
+					return err
+				}
+			} else {
+				// The row can be written before the table is excluded from
+				// the redo log, the table can't be recovered consistently.
+				ra.skippedLogCount++
 			}
 			if row, err = ra.rd.ReadNextRow(ctx); err != nil {
 				return err
@@ -218,10 +235,20 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 	log.Info("apply redo log finishes",
 		zap.Uint64("appliedLogCount", ra.appliedLogCount),
 		zap.Uint64("appliedDDLCount", ra.appliedDDLCount),
+		zap.Uint64("skippedLogCount", ra.skippedLogCount),
 		zap.Uint64("currentCheckpoint", resolvedTs))
 	return errApplyFinished
 }
 
+// coversTable returns true if the table is covered by the redo log.
+func (ra *RedoApplier) coversTable(tableID model.TableID) bool {
+	if ra.coveredTables == nil {
+		return true
+	}
+	_, ok := ra.coveredTables[tableID]
+	return ok
+}
+
 func (ra *RedoApplier) resetQuota(rowSize uint64) error {
 	if rowSize >= config.DefaultChangefeedMemoryQuota || rowSize < ra.pendingQuota {
 		log.Panic("row size exceeds memory quota",
@@ -312,6 +339,7 @@ func (ra *RedoApplier) applyRow(
 			spanz.TableIDToComparableSpan(tableID),
 			checkpointTs,
 			prometheus.NewCounter(prometheus.CounterOpts{}),
+			nil,
 		)
 		ra.tableSinks[tableID] = tableSink
 	}
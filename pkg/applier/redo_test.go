@@ -39,6 +39,8 @@ type MockReader struct {
 	resolvedTs   uint64
 	redoLogCh    chan *model.RowChangedEvent
 	ddlEventCh   chan *model.DDLEvent
+	// coveredTables is nil if all tables are covered.
+	coveredTables map[model.TableID]model.Ts
 }
 
 // NewMockReader creates a new MockReader
@@ -86,7 +88,22 @@ func (br *MockReader) ReadMeta(ctx context.Context) (checkpointTs, resolvedTs ui
 	return br.checkpointTs, br.resolvedTs, nil
 }
 
+// ReadCoveredTables implements LogReader.ReadCoveredTables
+func (br *MockReader) ReadCoveredTables(ctx context.Context) (map[model.TableID]model.Ts, error) {
+	return br.coveredTables, nil
+}
+
 func TestApply(t *testing.T) {
+	testApply(t, false)
+}
+
+func TestApplyPartialCoverage(t *testing.T) {
+	testApply(t, true)
+}
+
+// testApply applies redo logs of table t1. If partialCoverage is true, the
+// redo log only covers t1, and rows of t2 in the redo log are skipped.
+func testApply(t *testing.T, partialCoverage bool) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -95,7 +112,11 @@ func TestApply(t *testing.T) {
 	redoLogCh := make(chan *model.RowChangedEvent, 1024)
 	ddlEventCh := make(chan *model.DDLEvent, 1024)
 	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
-		return NewMockReader(checkpointTs, resolvedTs, redoLogCh, ddlEventCh), nil
+		rd := NewMockReader(checkpointTs, resolvedTs, redoLogCh, ddlEventCh)
+		if partialCoverage {
+			rd.coveredTables = map[model.TableID]model.Ts{1: resolvedTs}
+		}
+		return rd, nil
 	}
 
 	dbIndex := 0
@@ -129,7 +150,7 @@ func TestApply(t *testing.T) {
 		{
 			StartTs:  1100,
 			CommitTs: 1200,
-			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			Table:    &model.TableName{Schema: "test", Table: "t1", TableID: 1},
 			Columns: []*model.Column{
 				{
 					Name:  "a",
@@ -145,7 +166,7 @@ func TestApply(t *testing.T) {
 		{
 			StartTs:  1200,
 			CommitTs: resolvedTs,
-			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			Table:    &model.TableName{Schema: "test", Table: "t1", TableID: 1},
 			PreColumns: []*model.Column{
 				{
 					Name:  "a",
@@ -170,6 +191,21 @@ func TestApply(t *testing.T) {
 			},
 		},
 	}
+	if partialCoverage {
+		// t2 isn't covered, its rows must not be applied.
+		dmls = append(dmls[:1], append([]*model.RowChangedEvent{{
+			StartTs:  1300,
+			CommitTs: 1400,
+			Table:    &model.TableName{Schema: "test", Table: "t2", TableID: 2},
+			Columns: []*model.Column{
+				{
+					Name:  "a",
+					Value: 1,
+					Flag:  model.HandleKeyFlag,
+				},
+			},
+		}}, dmls[1:]...)...)
+	}
 	for _, dml := range dmls {
 		redoLogCh <- dml
 	}
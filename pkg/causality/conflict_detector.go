@@ -35,6 +35,9 @@ type ConflictDetector[Worker worker[Txn], Txn txnEvent] struct {
 
 	// nextWorkerID is used to dispatch transactions round-robin.
 	nextWorkerID atomic.Int64
+	// workersPerGroup is the max number of workers that transactions of
+	// one group can be dispatched to, 0 means no limit.
+	workersPerGroup int
 
 	// Used to run a background goroutine to GC or notify nodes.
 	notifiedNodes *chann.DrainableChann[func()]
@@ -45,7 +48,7 @@ type ConflictDetector[Worker worker[Txn], Txn txnEvent] struct {
 
 type txnFinishedEvent struct {
 	node         *internal.Node
-	conflictKeys []uint64
+	conflictKeys []ConflictKey
 }
 
 // NewConflictDetector creates a new ConflictDetector.
@@ -71,6 +74,21 @@ func NewConflictDetector[Worker worker[Txn], Txn txnEvent](
 	return ret
 }
 
+// NewGroupedConflictDetector creates a new ConflictDetector which dispatches
+// non-conflicting transactions of one group to at most workersPerGroup workers.
+// Transactions must implement `WorkerGroup() int64` to be grouped.
+func NewGroupedConflictDetector[Worker worker[Txn], Txn txnEvent](
+	workers []Worker,
+	numSlots uint64,
+	workersPerGroup int,
+) *ConflictDetector[Worker, Txn] {
+	ret := NewConflictDetector[Worker, Txn](workers, numSlots)
+	if workersPerGroup > 0 && workersPerGroup < len(workers) {
+		ret.workersPerGroup = workersPerGroup
+	}
+	return ret
+}
+
 // Add pushes a transaction to the ConflictDetector.
 //
 // NOTE: if multiple threads access this concurrently, Txn.ConflictKeys must be sorted.
@@ -84,7 +102,7 @@ func (d *ConflictDetector[Worker, Txn]) Add(txn Txn) {
 		}
 		d.sendToWorker(txn, unlock, workerID)
 	}
-	node.RandWorkerID = func() int64 { return d.nextWorkerID.Add(1) % int64(len(d.workers)) }
+	node.RandWorkerID = d.randWorkerID(txn)
 	node.OnNotified = func(callback func()) { d.notifiedNodes.In() <- callback }
 	d.slots.Add(node, conflictKeys)
 }
@@ -116,6 +134,23 @@ func (d *ConflictDetector[Worker, Txn]) runBackgroundTasks() {
 	}
 }
 
+func (d *ConflictDetector[Worker, Txn]) randWorkerID(txn Txn) func() int64 {
+	numWorkers := int64(len(d.workers))
+	grouped, ok := any(txn).(groupedTxnEvent)
+	if d.workersPerGroup == 0 || !ok {
+		return func() int64 { return d.nextWorkerID.Add(1) % numWorkers }
+	}
+	// Workers of a group are consecutive, and start from `group % numWorkers`.
+	first := grouped.WorkerGroup() % numWorkers
+	if first < 0 {
+		first += numWorkers
+	}
+	workersPerGroup := int64(d.workersPerGroup)
+	return func() int64 {
+		return (first + d.nextWorkerID.Add(1)%workersPerGroup) % numWorkers
+	}
+}
+
 // sendToWorker should not call txn.Callback if it returns an error.
 func (d *ConflictDetector[Worker, Txn]) sendToWorker(txn Txn, unlock func(), workerID int64) {
 	if workerID < 0 {
@@ -18,8 +18,16 @@ import (
 	"sync"
 )
 
+// Key is a conflict key. Value identifies the key exactly, and Hash is used
+// to find the slot of the key. Keys with the same hash but different values
+// never conflict.
+type Key struct {
+	Hash  uint64
+	Value string
+}
+
 type slot[E SlotNode[E]] struct {
-	nodes map[uint64]E
+	nodes map[Key]E
 	mu    sync.Mutex
 }
 
@@ -47,7 +55,7 @@ type Slots[E SlotNode[E]] struct {
 func NewSlots[E SlotNode[E]](numSlots uint64) *Slots[E] {
 	slots := make([]slot[E], numSlots)
 	for i := uint64(0); i < numSlots; i++ {
-		slots[i].nodes = make(map[uint64]E, 8)
+		slots[i].nodes = make(map[Key]E, 8)
 	}
 	return &Slots[E]{
 		slots:    slots,
@@ -56,13 +64,13 @@ func NewSlots[E SlotNode[E]](numSlots uint64) *Slots[E] {
 }
 
 // Add adds an elem to the slots and calls DependOn for elem.
-func (s *Slots[E]) Add(elem E, keys []uint64) {
+func (s *Slots[E]) Add(elem E, keys []Key) {
 	unresolvedDeps := make(map[int64]E, len(keys))
 	resolvedDeps := 0
 
 	var lastSlot uint64 = math.MaxUint64
 	for _, key := range keys {
-		slotIdx := getSlot(key, s.numSlots)
+		slotIdx := getSlot(key.Hash, s.numSlots)
 		if lastSlot != slotIdx {
 			s.slots[slotIdx].mu.Lock()
 			lastSlot = slotIdx
@@ -81,7 +89,7 @@ func (s *Slots[E]) Add(elem E, keys []uint64) {
 	// we can avoid 2 transactions get executed interleaved.
 	lastSlot = math.MaxUint64
 	for _, key := range keys {
-		slotIdx := getSlot(key, s.numSlots)
+		slotIdx := getSlot(key.Hash, s.numSlots)
 		if lastSlot != slotIdx {
 			s.slots[slotIdx].mu.Unlock()
 			lastSlot = slotIdx
@@ -90,9 +98,9 @@ func (s *Slots[E]) Add(elem E, keys []uint64) {
 }
 
 // Free removes an element from the Slots.
-func (s *Slots[E]) Free(elem E, keys []uint64) {
+func (s *Slots[E]) Free(elem E, keys []Key) {
 	for _, key := range keys {
-		slotIdx := getSlot(key, s.numSlots)
+		slotIdx := getSlot(key.Hash, s.numSlots)
 		s.slots[slotIdx].mu.Lock()
 		if tail, ok := s.slots[slotIdx].nodes[key]; ok && tail.NodeID() == elem.NodeID() {
 			delete(s.slots[slotIdx].nodes, key)
@@ -32,12 +32,12 @@ func TestSlotsTrivial(t *testing.T) {
 	for i := 0; i < count; i++ {
 		node := NewNode()
 		node.RandWorkerID = func() workerID { return 100 }
-		slots.Add(node, []uint64{1, 2, 3, 4, 5})
+		slots.Add(node, keys(1, 2, 3, 4, 5))
 		nodes = append(nodes, node)
 	}
 
 	for i := 0; i < count; i++ {
-		slots.Free(nodes[i], []uint64{1, 2, 3, 4, 5})
+		slots.Free(nodes[i], keys(1, 2, 3, 4, 5))
 	}
 
 	require.Equal(t, 0, len(slots.slots[1].nodes))
@@ -77,7 +77,7 @@ func TestSlotsConcurrentOps(t *testing.T) {
 				return
 			case node := <-freeNodeChan:
 				// keys belong to the same slot after hash, since slot num is 8
-				slots.Add(node, []uint64{1, 9, 17, 25, 33})
+				slots.Add(node, keys(1, 9, 17, 25, 33))
 				inuseNodeChan <- node
 			}
 		}
@@ -92,7 +92,7 @@ func TestSlotsConcurrentOps(t *testing.T) {
 				return
 			case node := <-inuseNodeChan:
 				// keys belong to the same slot after hash, since slot num is 8
-				slots.Free(node, []uint64{1, 9, 17, 25, 33})
+				slots.Free(node, keys(1, 9, 17, 25, 33))
 				freeNodeChan <- newNode()
 			}
 		}
@@ -100,3 +100,32 @@ func TestSlotsConcurrentOps(t *testing.T) {
 
 	wg.Wait()
 }
+
+func keys(hashes ...uint64) []Key {
+	ret := make([]Key, 0, len(hashes))
+	for _, hash := range hashes {
+		ret = append(ret, Key{Hash: hash})
+	}
+	return ret
+}
+
+func TestSlotsExactKeys(t *testing.T) {
+	t.Parallel()
+
+	slots := NewSlots[*Node](8)
+	node1 := NewNode()
+	node1.RandWorkerID = func() workerID { return 100 }
+	slots.Add(node1, []Key{{Hash: 1, Value: "a"}})
+	require.Equal(t, int64(100), node1.assignedWorkerID())
+
+	// Keys with the same hash but different values don't conflict.
+	node2 := NewNode()
+	node2.RandWorkerID = func() workerID { return 200 }
+	slots.Add(node2, []Key{{Hash: 1, Value: "b"}})
+	require.Equal(t, int64(200), node2.assignedWorkerID())
+
+	// Keys with the same value conflict.
+	node3 := NewNode()
+	slots.Add(node3, []Key{{Hash: 1, Value: "a"}})
+	require.Equal(t, int64(100), node3.assignedWorkerID())
+}
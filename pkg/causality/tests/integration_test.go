@@ -15,10 +15,12 @@ package tests
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/causality"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 )
@@ -43,7 +45,7 @@ func TestConflictBasics(t *testing.T) {
 			for _, key := range txn.ConflictKeys(numSlots) {
 				// Access a position in the array without synchronization,
 				// so that if causality check is buggy, the Go race detection would fail.
-				conflictArray[key]++
+				conflictArray[key.Hash]++
 			}
 			return nil
 		})
@@ -53,6 +55,60 @@ func TestConflictBasics(t *testing.T) {
 	driver.Close()
 }
 
+func TestConflictGroupedWorkers(t *testing.T) {
+	const (
+		numWorkers      = 8
+		numSlots        = 4096
+		workersPerGroup = 2
+		numGroups       = 16
+		txnsPerGroup    = 100
+	)
+
+	var mu sync.Mutex
+	groupWorkers := make(map[int64]map[int]struct{}, numGroups)
+	workers := make([]*workerForTest, 0, numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		workerID := i
+		worker := newWorkerForTest()
+		worker.execFunc = func(txn *txnForTest) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if groupWorkers[txn.group] == nil {
+				groupWorkers[txn.group] = make(map[int]struct{})
+			}
+			groupWorkers[txn.group][workerID] = struct{}{}
+			return nil
+		}
+		workers = append(workers, worker)
+	}
+	detector := causality.NewGroupedConflictDetector[*workerForTest, *txnForTest](
+		workers, numSlots, workersPerGroup)
+
+	var wg sync.WaitGroup
+	for i := 0; i < numGroups*txnsPerGroup; i++ {
+		wg.Add(1)
+		detector.Add(&txnForTest{
+			keys:  []uint64{uint64(i)},
+			group: int64(i % numGroups),
+			done:  wg.Done,
+		})
+	}
+	wg.Wait()
+	detector.Close()
+	for _, worker := range workers {
+		worker.Close()
+	}
+
+	require.Len(t, groupWorkers, numGroups)
+	for group, workerIDs := range groupWorkers {
+		require.LessOrEqual(t, len(workerIDs), workersPerGroup)
+		for workerID := range workerIDs {
+			offset := (workerID - int(group)%numWorkers + numWorkers) % numWorkers
+			require.Less(t, offset, workersPerGroup)
+		}
+	}
+}
+
 func BenchmarkLowConflicts(b *testing.B) {
 	log.SetLevel(zapcore.WarnLevel)
 	defer log.SetLevel(zapcore.InfoLevel)
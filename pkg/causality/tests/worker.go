@@ -18,17 +18,27 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/engine/pkg/containers"
+	"github.com/pingcap/tiflow/pkg/causality"
 )
 
 type txnForTest struct {
-	keys []uint64
-	done func()
+	keys  []uint64
+	group int64
+	done  func()
 }
 
 func (t *txnForTest) OnConflictResolved() {}
 
-func (t *txnForTest) ConflictKeys(numSlots uint64) []uint64 {
-	return t.keys
+func (t *txnForTest) ConflictKeys(numSlots uint64) []causality.ConflictKey {
+	keys := make([]causality.ConflictKey, 0, len(t.keys))
+	for _, key := range t.keys {
+		keys = append(keys, causality.ConflictKey{Hash: key})
+	}
+	return keys
+}
+
+func (t *txnForTest) WorkerGroup() int64 {
+	return t.group
 }
 
 func (t *txnForTest) Finish(err error) {
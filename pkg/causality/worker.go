@@ -13,19 +13,29 @@
 
 package causality
 
-type (
-	conflictKey = uint64
-)
+import "github.com/pingcap/tiflow/pkg/causality/internal"
+
+// ConflictKey is a key used to detect conflicts between transactions.
+// Two keys conflict only if their values are equal, the hash is only
+// used to locate slots so hash collisions don't cause false conflicts.
+type ConflictKey = internal.Key
 
 type txnEvent interface {
 	// OnConflictResolved is called when the event leaves ConflictDetector.
 	OnConflictResolved()
 
-	// Keys are in range [0, math.MaxUint64) and must be deduped.
+	// Keys must be deduped.
 	//
 	// NOTE: if the conflict detector is accessed by multiple threads concurrently,
-	// ConflictKeys must also be sorted based on `key % numSlots`.
-	ConflictKeys(numSlots uint64) []conflictKey
+	// ConflictKeys must also be sorted based on `key.Hash % numSlots`.
+	ConflictKeys(numSlots uint64) []ConflictKey
+}
+
+// groupedTxnEvent is a txnEvent which belongs to a group, e.g. a table.
+// If the ConflictDetector limits the number of workers per group, a
+// non-conflicting groupedTxnEvent is only dispatched to workers of its group.
+type groupedTxnEvent interface {
+	WorkerGroup() int64
 }
 
 type worker[Txn txnEvent] interface {
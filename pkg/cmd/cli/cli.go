@@ -52,6 +52,7 @@ func NewCmdCli() *cobra.Command {
 
 	// Add subcommands.
 	cmds.AddCommand(newCmdCapture(f))
+	cmds.AddCommand(newCmdCluster(f))
 	cmds.AddCommand(newCmdChangefeed(f))
 	cmds.AddCommand(newCmdProcessor(f))
 	cmds.AddCommand(newCmdTso(f))
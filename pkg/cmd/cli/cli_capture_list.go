@@ -14,6 +14,11 @@
 package cli
 
 import (
+	"fmt"
+	"math"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pingcap/tiflow/cdc/model"
 	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
 	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
 	"github.com/pingcap/tiflow/pkg/cmd/factory"
@@ -23,10 +28,48 @@ import (
 
 // capture holds capture information.
 type capture struct {
-	ID            string `json:"id"`
-	IsOwner       bool   `json:"is-owner"`
-	AdvertiseAddr string `json:"address"`
-	ClusterID     string `json:"cluster-id"`
+	ID            string       `json:"id"`
+	IsOwner       bool         `json:"is-owner"`
+	AdvertiseAddr string       `json:"address"`
+	ClusterID     string       `json:"cluster-id"`
+	Load          *captureLoad `json:"load,omitempty"`
+}
+
+// captureLoad holds the load reported by a capture.
+type captureLoad struct {
+	CPUUsage        string            `json:"cpu-usage"`
+	Memory          string            `json:"memory"`
+	SorterDiskUsage string            `json:"sorter-disk-usage"`
+	Changefeeds     []*changefeedLoad `json:"changefeeds"`
+}
+
+// changefeedLoad holds the load of a changefeed on a capture.
+type changefeedLoad struct {
+	Namespace         string  `json:"namespace"`
+	ID                string  `json:"id"`
+	TableCount        int     `json:"table-count"`
+	SinkRowsPerSecond float64 `json:"sink-rows-per-second"`
+}
+
+func newCaptureLoad(load *model.CaptureLoad) *captureLoad {
+	if load == nil {
+		return nil
+	}
+	res := &captureLoad{
+		CPUUsage:        fmt.Sprintf("%.1f%%", load.CPUUsage),
+		Memory:          humanize.IBytes(load.MemoryBytes),
+		SorterDiskUsage: humanize.IBytes(load.SorterDiskBytes),
+		Changefeeds:     make([]*changefeedLoad, 0, len(load.Changefeeds)),
+	}
+	for _, cf := range load.Changefeeds {
+		res.Changefeeds = append(res.Changefeeds, &changefeedLoad{
+			Namespace:         cf.Namespace,
+			ID:                cf.ID,
+			TableCount:        cf.TableCount,
+			SinkRowsPerSecond: math.Round(cf.SinkRowsPerSecond*100) / 100,
+		})
+	}
+	return res
 }
 
 // listCaptureOptions defines flags for the `cli capture list` command.
@@ -65,6 +108,7 @@ func (o *listCaptureOptions) run(cmd *cobra.Command) error {
 				IsOwner:       c.IsOwner,
 				AdvertiseAddr: c.AdvertiseAddr,
 				ClusterID:     c.ClusterID,
+				Load:          newCaptureLoad(c.Load),
 			})
 	}
 
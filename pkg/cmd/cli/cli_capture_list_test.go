@@ -33,6 +33,9 @@ type mockAPIV2Client struct {
 	unsafes     apiv2client.UnsafeInterface
 	captures    apiv2client.CaptureInterface
 	processors  apiv2client.ProcessorInterface
+	status      apiv2client.StatusInterface
+	federation  apiv2client.FederationInterface
+	metrics     apiv2client.MetricsInterface
 }
 
 func (f *mockAPIV2Client) Changefeeds() apiv2client.ChangefeedInterface {
@@ -55,6 +58,18 @@ func (f *mockAPIV2Client) Processors() apiv2client.ProcessorInterface {
 	return f.processors
 }
 
+func (f *mockAPIV2Client) Status() apiv2client.StatusInterface {
+	return f.status
+}
+
+func (f *mockAPIV2Client) Federation() apiv2client.FederationInterface {
+	return f.federation
+}
+
+func (f *mockAPIV2Client) Metrics() apiv2client.MetricsInterface {
+	return f.metrics
+}
+
 type mockFactory struct {
 	factory.Factory
 	captures    *mock.MockCaptureInterface
@@ -63,6 +78,8 @@ type mockFactory struct {
 	status      *mock.MockStatusInterface
 	tso         *mock.MockTsoInterface
 	unsafes     *mock.MockUnsafeInterface
+	federation  *mock.MockFederationInterface
+	metrics     *mock.MockMetricsInterface
 }
 
 func newMockFactory(ctrl *gomock.Controller) *mockFactory {
@@ -72,6 +89,8 @@ func newMockFactory(ctrl *gomock.Controller) *mockFactory {
 	statuses := mock.NewMockStatusInterface(ctrl)
 	unsafes := mock.NewMockUnsafeInterface(ctrl)
 	tso := mock.NewMockTsoInterface(ctrl)
+	federation := mock.NewMockFederationInterface(ctrl)
+	metrics := mock.NewMockMetricsInterface(ctrl)
 	return &mockFactory{
 		captures:    cps,
 		changefeeds: cf,
@@ -79,6 +98,8 @@ func newMockFactory(ctrl *gomock.Controller) *mockFactory {
 		status:      statuses,
 		tso:         tso,
 		unsafes:     unsafes,
+		federation:  federation,
+		metrics:     metrics,
 	}
 }
 
@@ -89,6 +110,9 @@ func (f *mockFactory) APIV2Client() (apiv2client.APIV2Interface, error) {
 		tso:         f.tso,
 		unsafes:     f.unsafes,
 		processors:  f.processors,
+		status:      f.status,
+		federation:  f.federation,
+		metrics:     f.metrics,
 	}, nil
 }
 
@@ -113,3 +137,23 @@ func TestCaptureListCli(t *testing.T) {
 	o.complete(f)
 	require.NotNil(t, o.run(cmd))
 }
+
+func TestNewCaptureLoad(t *testing.T) {
+	require.Nil(t, newCaptureLoad(nil))
+	load := newCaptureLoad(&model.CaptureLoad{
+		CPUUsage:        123.45,
+		MemoryBytes:     3 << 30,
+		SorterDiskBytes: 512 << 20,
+		Changefeeds: []model.ChangefeedLoad{{
+			Namespace: "default", ID: "test", TableCount: 2, SinkRowsPerSecond: 10.125,
+		}},
+	})
+	require.Equal(t, &captureLoad{
+		CPUUsage:        "123.5%",
+		Memory:          "3.0 GiB",
+		SorterDiskUsage: "512 MiB",
+		Changefeeds: []*changefeedLoad{{
+			Namespace: "default", ID: "test", TableCount: 2, SinkRowsPerSecond: 10.13,
+		}},
+	}, load)
+}
@@ -34,6 +34,11 @@ func newCmdChangefeed(f factory.Factory) *cobra.Command {
 	cmds.AddCommand(newCmdQueryChangefeed(f))
 	cmds.AddCommand(newCmdRemoveChangefeed(f))
 	cmds.AddCommand(newCmdResumeChangefeed(f))
+	cmds.AddCommand(newCmdExportChangefeed(f))
+	cmds.AddCommand(newCmdImportChangefeed(f))
+	cmds.AddCommand(newCmdDumpSchedulerChangefeed(f))
+	cmds.AddCommand(newCmdWaitChangefeed(f))
+	cmds.AddCommand(newCmdReverseChangefeed(f))
 
 	return cmds
 }
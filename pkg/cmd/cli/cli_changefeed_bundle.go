@@ -0,0 +1,164 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+)
+
+const (
+	// changefeedBundleVersion is the format version of changefeed bundles.
+	changefeedBundleVersion = 1
+
+	// bundleAlgorithmSHA256 means the bundle only carries a digest, which
+	// detects corruption but not tampering.
+	bundleAlgorithmSHA256 = "sha256"
+	// bundleAlgorithmHMACSHA256 means the bundle is signed with a shared key.
+	bundleAlgorithmHMACSHA256 = "hmac-sha256"
+)
+
+// bundledChangefeed is the portable definition of a changefeed.
+type bundledChangefeed struct {
+	Namespace string `json:"namespace"`
+	ID        string `json:"id"`
+	// SinkURI is masked by the api server, the sensitive parts must be
+	// provided again when importing the bundle.
+	SinkURI  string            `json:"sink-uri"`
+	TargetTs uint64            `json:"target-ts,omitempty"`
+	Config   *v2.ReplicaConfig `json:"config"`
+	// CheckpointTs is only exported if it is requested explicitly.
+	CheckpointTs uint64 `json:"checkpoint-ts,omitempty"`
+}
+
+// changefeedBundle is the file format of an exported changefeed.
+// The changefeed is kept as raw json, so that the signature is verified
+// against exactly what was exported.
+type changefeedBundle struct {
+	Version    int             `json:"version"`
+	Changefeed json.RawMessage `json:"changefeed"`
+	Algorithm  string          `json:"algorithm"`
+	Signature  string          `json:"signature"`
+}
+
+// newChangefeedBundle creates a bundle for the changefeed and signs it with
+// the key. If the key is empty, only a digest of the changefeed is recorded.
+func newChangefeedBundle(cf *bundledChangefeed, key string) (*changefeedBundle, error) {
+	data, err := json.Marshal(cf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b := &changefeedBundle{
+		Version:    changefeedBundleVersion,
+		Changefeed: data,
+		Algorithm:  bundleAlgorithmSHA256,
+	}
+	if key != "" {
+		b.Algorithm = bundleAlgorithmHMACSHA256
+	}
+	if b.Signature, err = b.sign(key); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// sign returns the signature of the bundled changefeed.
+func (b *changefeedBundle) sign(key string) (string, error) {
+	// The changefeed may be indented when the bundle is written to a file.
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, b.Changefeed); err != nil {
+		return "", errors.Trace(err)
+	}
+	data := buf.Bytes()
+	switch b.Algorithm {
+	case bundleAlgorithmSHA256:
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), nil
+	case bundleAlgorithmHMACSHA256:
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(data)
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	default:
+		return "", errors.Errorf("unsupported bundle algorithm %s", b.Algorithm)
+	}
+}
+
+// verify checks the version and the signature of the bundle.
+func (b *changefeedBundle) verify(key string) error {
+	if b.Version != changefeedBundleVersion {
+		return errors.Errorf("unsupported bundle version %d", b.Version)
+	}
+	if len(b.Changefeed) == 0 {
+		return errors.New("bundle does not contain a changefeed")
+	}
+	if b.Algorithm == bundleAlgorithmHMACSHA256 && key == "" {
+		return errors.New("bundle is signed, please provide the sign key")
+	}
+	if b.Algorithm == bundleAlgorithmSHA256 && key != "" {
+		return errors.New("bundle is not signed, but a sign key is provided")
+	}
+	signature, err := b.sign(key)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(signature), []byte(b.Signature)) {
+		return errors.New("bundle signature mismatch, " +
+			"the bundle may be modified or the sign key is wrong")
+	}
+	return nil
+}
+
+// changefeed decodes the bundled changefeed.
+func (b *changefeedBundle) changefeed() (*bundledChangefeed, error) {
+	cf := &bundledChangefeed{}
+	if err := json.Unmarshal(b.Changefeed, cf); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cf.ID == "" {
+		return nil, errors.New("bundle does not contain a changefeed id")
+	}
+	return cf, nil
+}
+
+// writeChangefeedBundle writes the bundle to the file.
+func writeChangefeedBundle(path string, b *changefeedBundle) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.WriteFile(path, data, 0o600))
+}
+
+// readChangefeedBundle reads a bundle from the file and verifies it.
+func readChangefeedBundle(path string, key string) (*changefeedBundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b := &changefeedBundle{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, errors.Annotatef(err, "invalid changefeed bundle %s", path)
+	}
+	if err := b.verify(key); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
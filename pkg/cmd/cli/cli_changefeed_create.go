@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/pingcap/errors"
@@ -108,6 +109,8 @@ type createChangefeedOptions struct {
 	disableGCSafePointCheck bool
 	startTs                 uint64
 	timezone                string
+	ttl                     time.Duration
+	removeTopics            bool
 
 	cfg *config.ReplicaConfig
 }
@@ -128,6 +131,11 @@ func (o *createChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVarP(&o.disableGCSafePointCheck, "disable-gc-check", "", false, "Disable GC safe point check")
 	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0, "Start ts of changefeed")
 	cmd.PersistentFlags().StringVar(&o.timezone, "tz", "SYSTEM", "timezone used when checking sink uri (changefeed timezone is determined by cdc server)")
+	cmd.PersistentFlags().DurationVar(&o.ttl, "ttl", 0,
+		"Remove the changefeed automatically after the duration since it is created, "+
+			"or after it reaches the target ts, 0 means never")
+	cmd.PersistentFlags().BoolVar(&o.removeTopics, "remove-topics", false,
+		"Remove the Kafka topics of the changefeed once it is removed because of --ttl")
 	// we don't support specify these flags below when cdc version >= 6.2.0
 	_ = cmd.PersistentFlags().MarkHidden("tz")
 }
@@ -227,15 +235,20 @@ func (o *createChangefeedOptions) validate(cmd *cobra.Command) error {
 func (o *createChangefeedOptions) getChangefeedConfig() *v2.ChangefeedConfig {
 	replicaConfig := v2.ToAPIReplicaConfig(o.cfg)
 	upstreamConfig := o.getUpstreamConfig()
-	return &v2.ChangefeedConfig{
+	cfg := &v2.ChangefeedConfig{
 		ID:            o.changefeedID,
 		Namespace:     o.namespace,
 		StartTs:       o.startTs,
 		TargetTs:      o.commonChangefeedOptions.targetTs,
 		SinkURI:       o.commonChangefeedOptions.sinkURI,
 		ReplicaConfig: replicaConfig,
+		RemoveTopics:  o.removeTopics,
 		PDConfig:      upstreamConfig.PDConfig,
 	}
+	if o.ttl != 0 {
+		cfg.TTL = v2.NewJSONDuration(o.ttl)
+	}
+	return cfg
 }
 
 func (o *createChangefeedOptions) getUpstreamConfig() *v2.UpstreamConfig {
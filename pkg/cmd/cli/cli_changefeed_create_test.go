@@ -14,6 +14,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -148,6 +149,7 @@ func TestChangefeedCreateCli(t *testing.T) {
 		"--upstream-ca=ca",
 		"--upstream-cert=cer",
 		"--upstream-key=key",
+		"--ttl=1h",
 	}
 
 	path := filepath.Join(dir, "confirm.txt")
@@ -166,7 +168,12 @@ func TestChangefeedCreateCli(t *testing.T) {
 	f.changefeeds.EXPECT().VerifyTable(gomock.Any(), gomock.Any()).Return(&v2.Tables{
 		IneligibleTables: []v2.TableName{{}},
 	}, nil)
-	f.changefeeds.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&v2.ChangeFeedInfo{}, nil)
+	f.changefeeds.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, cfg *v2.ChangefeedConfig) (*v2.ChangeFeedInfo, error) {
+			require.Equal(t, time.Hour, cfg.TTL.Duration())
+			require.False(t, cfg.RemoveTopics)
+			return &v2.ChangeFeedInfo{}, nil
+		})
 	require.Nil(t, cmd.Execute())
 
 	cmd = newCmdCreateChangefeed(f)
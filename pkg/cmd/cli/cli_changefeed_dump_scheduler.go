@@ -0,0 +1,97 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+
+	"github.com/pingcap/errors"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// dumpSchedulerChangefeedOptions defines flags for the
+// `cli changefeed dump-scheduler` command.
+type dumpSchedulerChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID string
+	namespace    string
+	output       string
+}
+
+// newDumpSchedulerChangefeedOptions creates new options for the
+// `cli changefeed dump-scheduler` command.
+func newDumpSchedulerChangefeedOptions() *dumpSchedulerChangefeedOptions {
+	return &dumpSchedulerChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *dumpSchedulerChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVarP(&o.output, "output", "o", "", "Path of the snapshot file to write")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("output")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *dumpSchedulerChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// run the `cli changefeed dump-scheduler` command.
+func (o *dumpSchedulerChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+
+	snapshot, err := o.apiClient.Changefeeds().SchedulerSnapshot(
+		ctx, o.namespace, o.changefeedID)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(o.output, snapshot, 0o600); err != nil {
+		return errors.Trace(err)
+	}
+	cmd.Printf("Dump scheduler snapshot successfully!\nID: %s\nSnapshot: %s\n",
+		o.changefeedID, o.output)
+	return nil
+}
+
+// newCmdDumpSchedulerChangefeed creates the `cli changefeed dump-scheduler` command.
+func newCmdDumpSchedulerChangefeed(f factory.Factory) *cobra.Command {
+	o := newDumpSchedulerChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "dump-scheduler",
+		Short: "Dump a redacted snapshot of the scheduler states of a replication task (changefeed)",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
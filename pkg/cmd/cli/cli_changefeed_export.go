@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// exportChangefeedOptions defines flags for the `cli changefeed export` command.
+type exportChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID   string
+	namespace      string
+	output         string
+	signKey        string
+	withCheckpoint bool
+}
+
+// newExportChangefeedOptions creates new options for the `cli changefeed export` command.
+func newExportChangefeedOptions() *exportChangefeedOptions {
+	return &exportChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *exportChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVarP(&o.output, "output", "o", "", "Path of the bundle file to write")
+	cmd.PersistentFlags().StringVar(&o.signKey, "sign-key", "", "Key used to sign the bundle")
+	cmd.PersistentFlags().BoolVar(&o.withCheckpoint, "with-checkpoint", false, "Include the checkpoint of the changefeed in the bundle")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("output")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *exportChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// run the `cli changefeed export` command.
+func (o *exportChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+
+	info, err := o.apiClient.Changefeeds().Get(ctx, o.namespace, o.changefeedID)
+	if err != nil {
+		return err
+	}
+	cf := &bundledChangefeed{
+		Namespace: info.Namespace,
+		ID:        info.ID,
+		SinkURI:   info.SinkURI,
+		TargetTs:  info.TargetTs,
+		Config:    info.Config,
+	}
+	if o.withCheckpoint {
+		cf.CheckpointTs = info.CheckpointTs
+	}
+	bundle, err := newChangefeedBundle(cf, o.signKey)
+	if err != nil {
+		return err
+	}
+	if err := writeChangefeedBundle(o.output, bundle); err != nil {
+		return err
+	}
+	cmd.Printf("Export changefeed successfully!\nID: %s\nBundle: %s\n",
+		o.changefeedID, o.output)
+	return nil
+}
+
+// newCmdExportChangefeed creates the `cli changefeed export` command.
+func newCmdExportChangefeed(f factory.Factory) *cobra.Command {
+	o := newExportChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "export",
+		Short: "Export a replication task (changefeed) to a bundle file",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
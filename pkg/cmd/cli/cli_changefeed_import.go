@@ -0,0 +1,143 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// maskedPassword is how the api server masks the password of a sink uri.
+const maskedPassword = "xxxxx"
+
+// importChangefeedOptions defines flags for the `cli changefeed import` command.
+type importChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	file         string
+	signKey      string
+	changefeedID string
+	namespace    string
+	sinkURI      string
+	startTs      uint64
+}
+
+// newImportChangefeedOptions creates new options for the `cli changefeed import` command.
+func newImportChangefeedOptions() *importChangefeedOptions {
+	return &importChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *importChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.file, "file", "f", "", "Path of the bundle file to import")
+	cmd.PersistentFlags().StringVar(&o.signKey, "sign-key", "", "Key used to verify the bundle")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "", "Override the namespace of the bundle")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Override the changefeed ID of the bundle")
+	cmd.PersistentFlags().StringVar(&o.sinkURI, "sink-uri", "", "Override the sink URI of the bundle")
+	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0, "Override the start ts, "+
+		"the checkpoint in the bundle is used by default if there is one")
+	_ = cmd.MarkPersistentFlagRequired("file")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *importChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// getChangefeedConfig returns the config used to create the changefeed,
+// the bundled changefeed is overridden by the flags.
+func (o *importChangefeedOptions) getChangefeedConfig(
+	cf *bundledChangefeed,
+) *v2.ChangefeedConfig {
+	cfg := &v2.ChangefeedConfig{
+		Namespace:     cf.Namespace,
+		ID:            cf.ID,
+		StartTs:       cf.CheckpointTs,
+		TargetTs:      cf.TargetTs,
+		SinkURI:       cf.SinkURI,
+		ReplicaConfig: cf.Config,
+	}
+	if o.namespace != "" {
+		cfg.Namespace = o.namespace
+	}
+	if o.changefeedID != "" {
+		cfg.ID = o.changefeedID
+	}
+	if o.sinkURI != "" {
+		cfg.SinkURI = o.sinkURI
+	}
+	if o.startTs != 0 {
+		cfg.StartTs = o.startTs
+	}
+	return cfg
+}
+
+// run the `cli changefeed import` command.
+func (o *importChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+
+	bundle, err := readChangefeedBundle(o.file, o.signKey)
+	if err != nil {
+		return err
+	}
+	cf, err := bundle.changefeed()
+	if err != nil {
+		return err
+	}
+	cfg := o.getChangefeedConfig(cf)
+	if strings.Contains(cfg.SinkURI, maskedPassword) {
+		cmd.Printf(color.HiYellowString("[WARN] The sink URI of the bundle contains a masked password, " +
+			"please provide the real one by --sink-uri.\n"))
+	}
+
+	info, err := o.apiClient.Changefeeds().Create(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Import changefeed successfully!\nID: %s\nNamespace: %s\nStartTs: %d\n",
+		info.ID, info.Namespace, info.StartTs)
+	return nil
+}
+
+// newCmdImportChangefeed creates the `cli changefeed import` command.
+func newCmdImportChangefeed(f factory.Factory) *cobra.Command {
+	o := newImportChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "import",
+		Short: "Create a replication task (changefeed) from a bundle file",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/pingcap/tiflow/cdc/api/owner"
+	apiv2 "github.com/pingcap/tiflow/cdc/api/v2"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/api/v2"
 	"github.com/pingcap/tiflow/pkg/cmd/context"
@@ -72,10 +73,20 @@ func (o *listChangefeedOptions) run(cmd *cobra.Command) error {
 	if err != nil {
 		return err
 	}
-	cfs := make([]*changefeedCommonInfo, 0, len(raw))
+	cfs := toChangefeedCommonInfos(raw, o.listAll)
+
+	return util.JSONPrint(cmd, cfs)
+}
 
+// toChangefeedCommonInfos converts changefeeds returned by the api to the
+// cli output format, finished and removed changefeeds are skipped unless
+// listAll is set.
+func toChangefeedCommonInfos(
+	raw []apiv2.ChangefeedCommonInfo, listAll bool,
+) []*changefeedCommonInfo {
+	cfs := make([]*changefeedCommonInfo, 0, len(raw))
 	for _, cf := range raw {
-		if !o.listAll {
+		if !listAll {
 			if cf.FeedState == model.StateFinished ||
 				cf.FeedState == model.StateRemoved {
 				continue
@@ -93,8 +104,7 @@ func (o *listChangefeedOptions) run(cmd *cobra.Command) error {
 		}
 		cfs = append(cfs, cfci)
 	}
-
-	return util.JSONPrint(cmd, cfs)
+	return cfs
 }
 
 // newCmdListChangefeed creates the `cli changefeed list` command.
@@ -47,11 +47,42 @@ type cfMeta struct {
 	TaskStatus     []model.CaptureTaskStatus `json:"task_status,omitempty"`
 }
 
+// cfHealth holds the key health indicators of a changefeed, which are
+// computed from the metrics summaries of all captures.
+type cfHealth struct {
+	Namespace      string              `json:"namespace"`
+	ID             string              `json:"id"`
+	FeedState      model.FeedState     `json:"state"`
+	CheckpointTSO  uint64              `json:"checkpoint_tso"`
+	CheckpointTime model.JSONTime      `json:"checkpoint_time"`
+	RunningError   *v2.RunningError    `json:"error,omitempty"`
+	CheckpointLag  *v2.LagDistribution `json:"checkpoint_lag,omitempty"`
+	SinkFlushP99   float64             `json:"sink_flush_p99_seconds"`
+	E2ELatencyP99  float64             `json:"e2e_latency_p99_seconds"`
+	RegionCount    int64               `json:"region_count"`
+	TableCount     int64               `json:"table_count"`
+	Captures       []cfCaptureHealth   `json:"captures"`
+}
+
+// cfCaptureHealth holds the health indicators of a changefeed on a capture.
+type cfCaptureHealth struct {
+	CaptureID string `json:"capture_id"`
+	Address   string `json:"address"`
+	// SorterBacklogBytes is shared by all changefeeds on the capture.
+	SorterBacklogBytes int64   `json:"sorter_backlog_bytes"`
+	SinkFlushP99       float64 `json:"sink_flush_p99_seconds"`
+	E2ELatencyP99      float64 `json:"e2e_latency_p99_seconds"`
+	RegionCount        int64   `json:"region_count"`
+	TableCount         int64   `json:"table_count"`
+	Error              string  `json:"error,omitempty"`
+}
+
 // queryChangefeedOptions defines flags for the `cli changefeed query` command.
 type queryChangefeedOptions struct {
 	apiClientV2  apiv2client.APIV2Interface
 	changefeedID string
 	simplified   bool
+	health       bool
 	namespace    string
 }
 
@@ -65,6 +96,7 @@ func newQueryChangefeedOptions() *queryChangefeedOptions {
 func (o *queryChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
 	cmd.PersistentFlags().BoolVarP(&o.simplified, "simple", "s", false, "Output simplified replication status")
+	cmd.PersistentFlags().BoolVar(&o.health, "health", false, "Output health indicators computed from the metrics of all captures")
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
 }
@@ -99,6 +131,9 @@ func (o *queryChangefeedOptions) run(cmd *cobra.Command) error {
 	if err != nil && cerror.ErrChangeFeedNotExists.NotEqual(err) {
 		return err
 	}
+	if o.health {
+		return o.runHealth(ctx, cmd, detail)
+	}
 	meta := &cfMeta{
 		UpstreamID:     detail.UpstreamID,
 		Namespace:      detail.Namespace,
@@ -119,6 +154,60 @@ func (o *queryChangefeedOptions) run(cmd *cobra.Command) error {
 	return util.JSONPrint(cmd, meta)
 }
 
+// runHealth prints the health indicators of the changefeed.
+func (o *queryChangefeedOptions) runHealth(
+	ctx context.Context, cmd *cobra.Command, detail *v2.ChangeFeedInfo,
+) error {
+	summaries, err := o.apiClientV2.Metrics().ClusterSummary(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	health := &cfHealth{
+		Namespace:      detail.Namespace,
+		ID:             detail.ID,
+		FeedState:      detail.State,
+		CheckpointTSO:  detail.CheckpointTs,
+		CheckpointTime: detail.CheckpointTime,
+		RunningError:   detail.Error,
+		Captures:       make([]cfCaptureHealth, 0, len(summaries)),
+	}
+	for _, summary := range summaries {
+		capture := cfCaptureHealth{
+			CaptureID:          summary.CaptureID,
+			Address:            summary.Address,
+			SorterBacklogBytes: summary.SorterBacklogBytes,
+			Error:              summary.Error,
+		}
+		found := false
+		for _, cf := range summary.Changefeeds {
+			if cf.Namespace != o.namespace || cf.ID != o.changefeedID {
+				continue
+			}
+			found = true
+			if cf.CheckpointLag != nil {
+				health.CheckpointLag = cf.CheckpointLag
+			}
+			capture.SinkFlushP99 = cf.SinkFlushP99
+			capture.E2ELatencyP99 = cf.E2ELatencyP99
+			capture.RegionCount = cf.RegionCount
+			capture.TableCount = cf.TableCount
+			if cf.SinkFlushP99 > health.SinkFlushP99 {
+				health.SinkFlushP99 = cf.SinkFlushP99
+			}
+			if cf.E2ELatencyP99 > health.E2ELatencyP99 {
+				health.E2ELatencyP99 = cf.E2ELatencyP99
+			}
+			health.RegionCount += cf.RegionCount
+			health.TableCount += cf.TableCount
+		}
+		// Captures which do not run the changefeed are omitted.
+		if found || capture.Error != "" {
+			health.Captures = append(health.Captures, capture)
+		}
+	}
+	return util.JSONPrint(cmd, health)
+}
+
 // newCmdQueryChangefeed creates the `cli changefeed query` command.
 func newCmdQueryChangefeed(f factory.Factory) *cobra.Command {
 	o := newQueryChangefeedOptions()
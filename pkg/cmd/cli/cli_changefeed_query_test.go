@@ -15,6 +15,7 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"testing"
@@ -88,3 +89,73 @@ func TestChangefeedQueryCli(t *testing.T) {
 	os.Args = []string{"query", "--simple=false", "--changefeed-id=bcd"}
 	require.NotNil(t, o.run(cmd))
 }
+
+func TestChangefeedQueryHealthCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+
+	o := newQueryChangefeedOptions()
+	require.Nil(t, o.complete(f))
+	cmd := newCmdQueryChangefeed(f)
+
+	f.changefeeds.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{
+		Namespace: "default",
+		ID:        "abc",
+		State:     model.StateNormal,
+	}, nil)
+	f.metrics.EXPECT().ClusterSummary(gomock.Any()).Return([]v2.MetricsSummary{
+		{
+			CaptureID: "owner",
+			IsOwner:   true,
+			Changefeeds: []v2.ChangefeedMetricsSummary{{
+				Namespace:     "default",
+				ID:            "abc",
+				CheckpointLag: &v2.LagDistribution{Current: 3, P99: 5},
+				SinkFlushP99:  0.2,
+				E2ELatencyP99: 1.5,
+				RegionCount:   10,
+				TableCount:    2,
+			}},
+		},
+		{
+			CaptureID:          "processor",
+			SorterBacklogBytes: 1024,
+			Changefeeds: []v2.ChangefeedMetricsSummary{
+				{
+					Namespace: "default", ID: "abc", SinkFlushP99: 0.5, E2ELatencyP99: 0.8,
+					RegionCount: 20, TableCount: 3,
+				},
+				{Namespace: "default", ID: "other", SinkFlushP99: 9},
+			},
+		},
+		{CaptureID: "idle"},
+		{CaptureID: "down", Error: "connection refused"},
+	}, nil)
+
+	o.namespace = "default"
+	o.changefeedID = "abc"
+	o.health = true
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.Nil(t, o.run(cmd))
+
+	health := &cfHealth{}
+	require.Nil(t, json.Unmarshal(b.Bytes(), health))
+	require.Equal(t, model.StateNormal, health.FeedState)
+	require.Equal(t, &v2.LagDistribution{Current: 3, P99: 5}, health.CheckpointLag)
+	require.Equal(t, 0.5, health.SinkFlushP99)
+	require.Equal(t, 1.5, health.E2ELatencyP99)
+	require.Equal(t, int64(30), health.RegionCount)
+	require.Equal(t, int64(5), health.TableCount)
+	require.Len(t, health.Captures, 3)
+	require.Equal(t, "processor", health.Captures[1].CaptureID)
+	require.Equal(t, int64(1024), health.Captures[1].SorterBacklogBytes)
+	require.Equal(t, "down", health.Captures[2].CaptureID)
+	require.NotEmpty(t, health.Captures[2].Error)
+
+	// failed to get the metrics summaries
+	f.changefeeds.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{}, nil)
+	f.metrics.EXPECT().ClusterSummary(gomock.Any()).Return(nil, errors.New("test"))
+	require.NotNil(t, o.run(cmd))
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fatih/color"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/syncpointstore"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// reverseChangefeedOptions defines flags for the `cli changefeed reverse` command.
+type reverseChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+	// querySyncPoint is overridden in tests.
+	querySyncPoint func(ctx context.Context, id model.ChangeFeedID,
+		sinkURI string, primaryTs uint64) (*syncpointstore.SyncPoint, error)
+
+	changefeedID        string
+	namespace           string
+	reverseChangefeedID string
+	sinkURI             string
+	downstreamURI       string
+	syncPointTs         uint64
+	startTs             uint64
+	upstreamPDAddrs     string
+	upstreamCaPath      string
+	upstreamCertPath    string
+	upstreamKeyPath     string
+	create              bool
+}
+
+// newReverseChangefeedOptions creates new options for the `cli changefeed reverse` command.
+func newReverseChangefeedOptions() *reverseChangefeedOptions {
+	return &reverseChangefeedOptions{
+		querySyncPoint: syncpointstore.QuerySyncPoint,
+	}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *reverseChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID to reverse")
+	cmd.PersistentFlags().StringVar(&o.reverseChangefeedID, "reverse-changefeed-id", "",
+		"ID of the reverse changefeed, \"<changefeed-id>-reverse\" is used by default")
+	cmd.PersistentFlags().StringVar(&o.sinkURI, "sink-uri", "",
+		"Sink URI of the reverse changefeed, which is the upstream of the changefeed to reverse")
+	cmd.PersistentFlags().StringVar(&o.downstreamURI, "downstream-uri", "",
+		"URI of the downstream to read syncpoints from, the sink URI of the changefeed is used by default")
+	cmd.PersistentFlags().Uint64Var(&o.syncPointTs, "syncpoint-ts", 0,
+		"Primary ts of the syncpoint to start from, the latest syncpoint is used by default")
+	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0,
+		"Start ts of the reverse changefeed, syncpoints are not read if it is specified")
+	cmd.PersistentFlags().StringVar(&o.upstreamPDAddrs, "upstream-pd", "",
+		"PD address of the downstream of the changefeed to reverse, use ',' to separate multiple PDs")
+	cmd.PersistentFlags().StringVar(&o.upstreamCaPath, "upstream-ca", "",
+		"CA certificate path for TLS connection to upstream")
+	cmd.PersistentFlags().StringVar(&o.upstreamCertPath, "upstream-cert", "",
+		"Certificate path for TLS connection to upstream")
+	cmd.PersistentFlags().StringVar(&o.upstreamKeyPath, "upstream-key", "",
+		"Private key path for TLS connection to upstream")
+	cmd.PersistentFlags().BoolVar(&o.create, "create", false,
+		"Create the reverse changefeed instead of only printing its config")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("sink-uri")
+	_ = cmd.MarkPersistentFlagRequired("upstream-pd")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *reverseChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	if o.reverseChangefeedID == "" {
+		o.reverseChangefeedID = o.changefeedID + "-reverse"
+	}
+	return nil
+}
+
+// getStartTs returns the start ts of the reverse changefeed, which is the
+// secondary ts of a syncpoint of the changefeed to reverse. The downstream
+// is consistent with the upstream at the syncpoint, so nothing is lost or
+// replicated twice if the reverse changefeed starts from there.
+func (o *reverseChangefeedOptions) getStartTs(
+	ctx context.Context, cmd *cobra.Command, info *v2.ChangeFeedInfo,
+) (uint64, error) {
+	if o.startTs != 0 {
+		return o.startTs, nil
+	}
+	downstreamURI := o.downstreamURI
+	if downstreamURI == "" {
+		downstreamURI = info.SinkURI
+		if strings.Contains(downstreamURI, maskedPassword) {
+			cmd.Printf(color.HiYellowString("[WARN] The sink URI of the changefeed contains a masked password, " +
+				"please provide the real one by --downstream-uri.\n"))
+		}
+	}
+	sp, err := o.querySyncPoint(ctx, model.ChangeFeedID{Namespace: info.Namespace, ID: info.ID},
+		downstreamURI, o.syncPointTs)
+	if err != nil {
+		return 0, err
+	}
+	cmd.Printf("Use syncpoint, primary ts: %d, secondary ts: %d\n", sp.PrimaryTs, sp.SecondaryTs)
+	return sp.SecondaryTs, nil
+}
+
+// getChangefeedConfig returns the config of the reverse changefeed, it has
+// the same filters as the changefeed to reverse and runs in BDR mode, so that
+// rows replicated by the changefeed to reverse are not replicated back.
+func (o *reverseChangefeedOptions) getChangefeedConfig(
+	info *v2.ChangeFeedInfo, startTs uint64,
+) *v2.ChangefeedConfig {
+	replicaConfig := info.Config
+	if replicaConfig == nil {
+		replicaConfig = v2.GetDefaultReplicaConfig()
+	}
+	bdrMode := true
+	replicaConfig.BDRMode = &bdrMode
+	return &v2.ChangefeedConfig{
+		Namespace:     o.namespace,
+		ID:            o.reverseChangefeedID,
+		StartTs:       startTs,
+		SinkURI:       o.sinkURI,
+		ReplicaConfig: replicaConfig,
+		PDConfig: v2.PDConfig{
+			PDAddrs:  strings.Split(o.upstreamPDAddrs, ","),
+			CAPath:   o.upstreamCaPath,
+			CertPath: o.upstreamCertPath,
+			KeyPath:  o.upstreamKeyPath,
+		},
+	}
+}
+
+// run the `cli changefeed reverse` command.
+func (o *reverseChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	info, err := o.apiClient.Changefeeds().Get(ctx, o.namespace, o.changefeedID)
+	if err != nil {
+		return err
+	}
+	if info.Config == nil || info.Config.BDRMode == nil || !*info.Config.BDRMode {
+		cmd.Printf(color.HiYellowString("[WARN] The changefeed is not in BDR mode, rows replicated by " +
+			"the reverse changefeed will be replicated back if the changefeed is still running.\n"))
+	}
+	startTs, err := o.getStartTs(ctx, cmd, info)
+	if err != nil {
+		return err
+	}
+	cfg := o.getChangefeedConfig(info, startTs)
+	if !o.create {
+		return util.JSONPrint(cmd, cfg)
+	}
+
+	reverse, err := o.apiClient.Changefeeds().Create(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Create reverse changefeed successfully!\nID: %s\nNamespace: %s\nStartTs: %d\n",
+		reverse.ID, reverse.Namespace, reverse.StartTs)
+	return nil
+}
+
+// newCmdReverseChangefeed creates the `cli changefeed reverse` command.
+func newCmdReverseChangefeed(f factory.Factory) *cobra.Command {
+	o := newReverseChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "reverse",
+		Short: "Generate or create the reverse replication task (changefeed) of a changefeed for failback",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/syncpointstore"
+	"github.com/pingcap/tiflow/pkg/api/v2/mock"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedReverseCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfV2 := mock.NewMockChangefeedInterface(ctrl)
+
+	cmdcontext.SetDefaultContext(context.Background())
+	f := &mockFactory{changefeeds: cfV2}
+	cmd := newCmdReverseChangefeed(f)
+	o := newReverseChangefeedOptions()
+	o.changefeedID = "abc"
+	o.namespace = "default"
+	o.sinkURI = "mysql://root@127.0.0.1:3306/"
+	o.upstreamPDAddrs = "http://127.0.0.1:2379,http://127.0.0.2:2379"
+	o.create = true
+	require.Nil(t, o.complete(f))
+	require.Equal(t, "abc-reverse", o.reverseChangefeedID)
+
+	o.querySyncPoint = func(
+		_ context.Context, id model.ChangeFeedID, sinkURI string, primaryTs uint64,
+	) (*syncpointstore.SyncPoint, error) {
+		require.Equal(t, model.DefaultChangeFeedID("abc"), id)
+		require.Equal(t, "mysql://root@127.0.0.2:3306/", sinkURI)
+		require.Equal(t, uint64(0), primaryTs)
+		return &syncpointstore.SyncPoint{PrimaryTs: 100, SecondaryTs: 105}, nil
+	}
+	filter := &v2.FilterConfig{Rules: []string{"test.*"}}
+	cfV2.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{
+		Namespace: "default",
+		ID:        "abc",
+		SinkURI:   "mysql://root@127.0.0.2:3306/",
+		Config:    &v2.ReplicaConfig{Filter: filter},
+	}, nil)
+	cfV2.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, cfg *v2.ChangefeedConfig) (*v2.ChangeFeedInfo, error) {
+			require.Equal(t, "abc-reverse", cfg.ID)
+			require.Equal(t, uint64(105), cfg.StartTs)
+			require.Equal(t, "mysql://root@127.0.0.1:3306/", cfg.SinkURI)
+			require.Equal(t, filter, cfg.ReplicaConfig.Filter)
+			require.True(t, *cfg.ReplicaConfig.BDRMode)
+			require.Equal(t, []string{"http://127.0.0.1:2379", "http://127.0.0.2:2379"},
+				cfg.PDConfig.PDAddrs)
+			return &v2.ChangeFeedInfo{ID: cfg.ID, Namespace: cfg.Namespace, StartTs: cfg.StartTs}, nil
+		})
+	require.Nil(t, o.run(cmd))
+
+	// the start ts is specified, syncpoints are not read
+	o.startTs = 200
+	o.create = false
+	o.querySyncPoint = nil
+	cfV2.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{
+		Namespace: "default",
+		ID:        "abc",
+	}, nil)
+	require.Nil(t, o.run(cmd))
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// waitChangefeedOptions defines flags for the `cli changefeed wait` command.
+type waitChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID string
+	namespace    string
+	interval     time.Duration
+	timeout      time.Duration
+}
+
+// newWaitChangefeedOptions creates new options for the `cli changefeed wait` command.
+func newWaitChangefeedOptions() *waitChangefeedOptions {
+	return &waitChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *waitChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().DurationVar(&o.interval, "interval", 5*time.Second, "Interval of checking the changefeed state")
+	cmd.PersistentFlags().DurationVar(&o.timeout, "timeout", 0, "Max time to wait, 0 means no limit")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *waitChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// run the `cli changefeed wait` command. It returns nil once the changefeed
+// replicates all changes before its target ts and is finished, and returns
+// an error if the changefeed can't be finished without manual operations.
+func (o *waitChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+	if o.timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+	for {
+		info, err := o.apiClient.Changefeeds().Get(ctx, o.namespace, o.changefeedID)
+		if err != nil {
+			return err
+		}
+		if info.TargetTs == 0 {
+			return errors.Errorf("changefeed %s has no target ts, it never finishes",
+				o.changefeedID)
+		}
+		switch info.State {
+		case model.StateFinished:
+			cmd.Printf("Changefeed is finished!\nID: %s\nCheckpointTs: %d\nTargetTs: %d\n",
+				o.changefeedID, info.CheckpointTs, info.TargetTs)
+			return nil
+		case model.StateFailed, model.StateStopped, model.StateRemoved:
+			msg := ""
+			if info.Error != nil {
+				msg = info.Error.Message
+			}
+			return errors.Errorf("changefeed %s is %s before finished, checkpoint ts %d, error: %s",
+				o.changefeedID, info.State, info.CheckpointTs, msg)
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Annotatef(ctx.Err(),
+				"changefeed %s is not finished, checkpoint ts %d, target ts %d",
+				o.changefeedID, info.CheckpointTs, info.TargetTs)
+		case <-ticker.C:
+		}
+	}
+}
+
+// newCmdWaitChangefeed creates the `cli changefeed wait` command.
+func newCmdWaitChangefeed(f factory.Factory) *cobra.Command {
+	o := newWaitChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait until a replication task (changefeed) with a target ts is finished",
+		Long: "Wait until a replication task (changefeed) with a target ts is finished. " +
+			"It exits with a non-zero status if the changefeed fails, " +
+			"is paused or removed before finished.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
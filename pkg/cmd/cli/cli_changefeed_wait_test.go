@@ -0,0 +1,72 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/api/v2/mock"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedWaitCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfV2 := mock.NewMockChangefeedInterface(ctrl)
+
+	cmdcontext.SetDefaultContext(context.Background())
+	f := &mockFactory{changefeeds: cfV2}
+	cmd := newCmdWaitChangefeed(f)
+	o := newWaitChangefeedOptions()
+	require.Nil(t, o.complete(f))
+	o.changefeedID = "abc"
+	o.namespace = "default"
+	o.interval = time.Millisecond
+
+	// wait until the changefeed is finished
+	gomock.InOrder(
+		cfV2.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{
+			TargetTs: 10, CheckpointTs: 5, State: model.StateNormal,
+		}, nil),
+		cfV2.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{
+			TargetTs: 10, CheckpointTs: 10, State: model.StateFinished,
+		}, nil),
+	)
+	require.Nil(t, o.run(cmd))
+
+	// changefeed without target ts never finishes
+	cfV2.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{
+		State: model.StateNormal,
+	}, nil)
+	require.NotNil(t, o.run(cmd))
+
+	// changefeed is paused before finished
+	cfV2.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{
+		TargetTs: 10, CheckpointTs: 5, State: model.StateStopped,
+	}, nil)
+	require.NotNil(t, o.run(cmd))
+
+	// timeout
+	o.timeout = 10 * time.Millisecond
+	cfV2.EXPECT().Get(gomock.Any(), "default", "abc").Return(&v2.ChangeFeedInfo{
+		TargetTs: 10, CheckpointTs: 5, State: model.StateNormal,
+	}, nil).AnyTimes()
+	require.NotNil(t, o.run(cmd))
+}
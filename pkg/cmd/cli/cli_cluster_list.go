@@ -0,0 +1,119 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// cluster holds the changefeeds of a TiCDC cluster.
+type cluster struct {
+	Endpoint    string                  `json:"endpoint,omitempty"`
+	ClusterID   string                  `json:"cluster-id"`
+	IsLocal     bool                    `json:"is-local"`
+	Changefeeds []*changefeedCommonInfo `json:"changefeeds"`
+	Error       string                  `json:"error,omitempty"`
+}
+
+// listClusterOptions defines flags for the `cli cluster list` command.
+type listClusterOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	listAll    bool
+	namespace  string
+	federation bool
+}
+
+// newListClusterOptions creates new options for the `cli cluster list` command.
+func newListClusterOptions() *listClusterOptions {
+	return &listClusterOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *listClusterOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().BoolVarP(&o.listAll, "all", "a", false, "List all replication tasks(including removed and finished)")
+	cmd.PersistentFlags().BoolVar(&o.federation, "federation", false, "List changefeeds of all clusters in the federation")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *listClusterOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// run the `cli cluster list` command.
+func (o *listClusterOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	if !o.federation {
+		status, err := o.apiClient.Status().Get(ctx)
+		if err != nil {
+			return err
+		}
+		raw, err := o.apiClient.Changefeeds().List(ctx, o.namespace, "all")
+		if err != nil {
+			return err
+		}
+		return util.JSONPrint(cmd, []*cluster{{
+			ClusterID:   status.ClusterID,
+			IsLocal:     true,
+			Changefeeds: toChangefeedCommonInfos(raw, o.listAll),
+		}})
+	}
+
+	raw, err := o.apiClient.Federation().List(ctx, o.namespace, "all")
+	if err != nil {
+		return err
+	}
+	clusters := make([]*cluster, 0, len(raw))
+	for _, c := range raw {
+		clusters = append(clusters, &cluster{
+			Endpoint:    c.Endpoint,
+			ClusterID:   c.ClusterID,
+			IsLocal:     c.IsLocal,
+			Changefeeds: toChangefeedCommonInfos(c.Changefeeds, o.listAll),
+			Error:       c.Error,
+		})
+	}
+	return util.JSONPrint(cmd, clusters)
+}
+
+// newCmdListCluster creates the `cli cluster list` command.
+func newCmdListCluster(f factory.Factory) *cobra.Command {
+	o := newListClusterOptions()
+
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List the TiCDC cluster and its replication tasks (changefeeds)",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterListCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+	cmd := newCmdListCluster(f)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	// list the local cluster only.
+	f.status.EXPECT().Get(gomock.Any()).Return(&model.ServerStatus{ClusterID: "west"}, nil)
+	f.changefeeds.EXPECT().List(gomock.Any(), "default", "all").
+		Return([]v2.ChangefeedCommonInfo{
+			{Namespace: "default", ID: "west-cf", FeedState: model.StateNormal},
+			{Namespace: "default", ID: "west-removed", FeedState: model.StateRemoved},
+		}, nil)
+	os.Args = []string{"list"}
+	require.Nil(t, cmd.Execute())
+	out, err := io.ReadAll(b)
+	require.Nil(t, err)
+	require.Contains(t, string(out), "west-cf")
+	require.NotContains(t, string(out), "west-removed")
+
+	// list all clusters in the federation.
+	f.federation.EXPECT().List(gomock.Any(), "default", "all").
+		Return([]v2.FederatedCluster{
+			{
+				Endpoint:    "127.0.0.1:8300",
+				ClusterID:   "west",
+				IsLocal:     true,
+				Changefeeds: []v2.ChangefeedCommonInfo{{Namespace: "default", ID: "west-cf"}},
+			},
+			{
+				Endpoint:    "http://cdc-east:8300",
+				ClusterID:   "east",
+				Changefeeds: []v2.ChangefeedCommonInfo{{Namespace: "default", ID: "east-cf"}},
+			},
+			{
+				Endpoint: "http://cdc-north:8300",
+				Error:    "connection refused",
+			},
+		}, nil)
+	os.Args = []string{"list", "--federation"}
+	require.Nil(t, cmd.Execute())
+	out, err = io.ReadAll(b)
+	require.Nil(t, err)
+	require.Contains(t, string(out), "west-cf")
+	require.Contains(t, string(out), "east-cf")
+	require.Contains(t, string(out), "connection refused")
+
+	f.federation.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("federation list test error"))
+	o := newListClusterOptions()
+	require.NoError(t, o.complete(f))
+	o.federation = true
+	require.Contains(t, o.run(cmd).Error(), "federation list test error")
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/tiflow/pkg/cmd/cli"
 	"github.com/pingcap/tiflow/pkg/cmd/redo"
+	"github.com/pingcap/tiflow/pkg/cmd/scheduler"
 	"github.com/pingcap/tiflow/pkg/cmd/server"
 	"github.com/pingcap/tiflow/pkg/cmd/version"
 	"github.com/spf13/cobra"
@@ -46,6 +47,7 @@ func Run() {
 	cmd.AddCommand(cli.NewCmdCli())
 	cmd.AddCommand(version.NewCmdVersion())
 	cmd.AddCommand(redo.NewCmdRedo())
+	cmd.AddCommand(scheduler.NewCmdScheduler())
 
 	if err := cmd.Execute(); err != nil {
 		cmd.PrintErrln(err)
@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"os"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/scheduler"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// replayOptions defines flags for the `scheduler replay` command.
+type replayOptions struct {
+	snapshot string
+	ticks    int
+	seed     int64
+}
+
+// newReplayOptions creates new options for the `scheduler replay` command.
+func newReplayOptions() *replayOptions {
+	return &replayOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *replayOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.snapshot, "snapshot", "", "Path of the snapshot file dumped by `cli changefeed dump-scheduler`")
+	cmd.PersistentFlags().IntVar(&o.ticks, "ticks", 1, "Number of ticks to replay")
+	cmd.PersistentFlags().Int64Var(&o.seed, "seed", 0, "Seed of the random sources of schedulers")
+	_ = cmd.MarkPersistentFlagRequired("snapshot")
+}
+
+// run runs the `scheduler replay` command.
+func (o *replayOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	if o.ticks <= 0 {
+		return errors.Errorf("invalid ticks %d, it must be positive", o.ticks)
+	}
+	data, err := os.ReadFile(o.snapshot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snapshot, err := scheduler.LoadSnapshot(data)
+	if err != nil {
+		return err
+	}
+	ticks, err := scheduler.Replay(ctx, snapshot, o.ticks, o.seed)
+	if err != nil {
+		return err
+	}
+	return util.JSONPrint(cmd, ticks)
+}
+
+// newCmdReplay creates the `scheduler replay` command.
+func newCmdReplay() *cobra.Command {
+	o := newReplayOptions()
+
+	command := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay ticks of a scheduler from a snapshot, and print messages sent in each tick",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"os"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/scheduler"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// replayHistoryOptions defines flags for the `scheduler replay-history` command.
+type replayHistoryOptions struct {
+	snapshot string
+}
+
+// newReplayHistoryOptions creates new options for the `scheduler replay-history` command.
+func newReplayHistoryOptions() *replayHistoryOptions {
+	return &replayHistoryOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *replayHistoryOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.snapshot, "snapshot", "", "Path of the snapshot file dumped by `cli changefeed dump-scheduler`")
+	_ = cmd.MarkPersistentFlagRequired("snapshot")
+}
+
+// run runs the `scheduler replay-history` command.
+func (o *replayHistoryOptions) run(cmd *cobra.Command) error {
+	data, err := os.ReadFile(o.snapshot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snapshot, err := scheduler.LoadSnapshot(data)
+	if err != nil {
+		return err
+	}
+	if len(snapshot.History) == 0 {
+		return errors.New("no schedule history in the snapshot, " +
+			"set debug.scheduler.schedule-history-size to record it")
+	}
+	records, err := scheduler.ReplayHistory(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := util.JSONPrint(cmd, records); err != nil {
+		return err
+	}
+	// Fail on divergence, so that it works with `git bisect run`.
+	diverged := 0
+	for _, record := range records {
+		if !record.Matched {
+			diverged++
+		}
+	}
+	if diverged > 0 {
+		return errors.Errorf("%d of %d schedule rounds diverge", diverged, len(records))
+	}
+	return nil
+}
+
+// newCmdReplayHistory creates the `scheduler replay-history` command.
+func newCmdReplayHistory() *cobra.Command {
+	o := newReplayHistoryOptions()
+
+	command := &cobra.Command{
+		Use:   "replay-history",
+		Short: "Replay the schedule history in a snapshot, and check whether the schedule tasks are the same as recorded",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
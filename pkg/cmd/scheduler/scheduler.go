@@ -0,0 +1,69 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/logutil"
+	"github.com/spf13/cobra"
+)
+
+// options defines flags for the `scheduler` command.
+type options struct {
+	logLevel string
+}
+
+// newOptions creates new options for the `scheduler` command.
+func newOptions() *options {
+	return &options{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *options) addFlags(cmd *cobra.Command) {
+	// Scheduler logs are verbose, only warnings are printed by default to
+	// keep the outputs readable.
+	cmd.PersistentFlags().StringVar(&o.logLevel, "log-level", "warn", "log level (etc: debug|info|warn|error)")
+}
+
+// NewCmdScheduler creates the `scheduler` command.
+func NewCmdScheduler() *cobra.Command {
+	o := newOptions()
+
+	cmds := &cobra.Command{
+		Use:   "scheduler",
+		Short: "Debug the scheduler of TiCDC offline",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cancel := util.InitCmd(cmd, &logutil.Config{Level: o.logLevel})
+			// A notify that complete immediately, it skips the second signal essentially.
+			doneNotify := func() <-chan struct{} {
+				done := make(chan struct{})
+				close(done)
+				return done
+			}
+			util.InitSignalHandling(doneNotify, cancel)
+
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+		},
+	}
+	o.addFlags(cmds)
+
+	// Add subcommands.
+	cmds.AddCommand(newCmdReplay())
+	cmds.AddCommand(newCmdReplayHistory())
+
+	return cmds
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"go.uber.org/zap"
+)
+
+// configReloadInterval is the interval to check whether the config file is
+// changed.
+const configReloadInterval = 10 * time.Second
+
+// configWatcher reloads the hot-reloadable sections of the server config file.
+// Only the [grpc] section is reloaded, changes of other sections take effect
+// after the server is restarted.
+type configWatcher struct {
+	path    string
+	content []byte
+}
+
+func newConfigWatcher(path string) *configWatcher {
+	// The config file has been decoded on startup, errors are ignored here
+	// and the file is reloaded in the first round.
+	content, _ := os.ReadFile(path)
+	return &configWatcher{path: path, content: content}
+}
+
+func (w *configWatcher) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// check reloads the config file if it's changed, and returns true if the
+// global server config is updated.
+func (w *configWatcher) check() bool {
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		log.Warn("failed to read server config file",
+			zap.String("path", w.path), zap.Error(err))
+		return false
+	}
+	if bytes.Equal(content, w.content) {
+		return false
+	}
+	w.content = content
+
+	cfg := config.GetDefaultServerConfig()
+	err = util.StrictDecodeFile(w.path, "TiCDC server", cfg, config.DebugConfigurationItem)
+	if err == nil {
+		err = cfg.AdjustGRPCConfig()
+	}
+	if err != nil {
+		log.Warn("failed to reload server config file, the old config is still used",
+			zap.String("path", w.path), zap.Error(err))
+		return false
+	}
+
+	old := config.GetGlobalServerConfig()
+	if reflect.DeepEqual(old.GRPC, cfg.GRPC) {
+		return false
+	}
+	newCfg := old.Clone()
+	newCfg.GRPC = cfg.GRPC
+	config.StoreGlobalServerConfig(newCfg)
+	log.Info("grpc config is reloaded",
+		zap.Any("old", old.GRPC), zap.Any("new", newCfg.GRPC))
+	return true
+}
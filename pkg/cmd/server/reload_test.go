@@ -0,0 +1,69 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigWatcher(t *testing.T) {
+	old := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(old)
+
+	path := filepath.Join(t.TempDir(), "server.toml")
+	require.Nil(t, os.WriteFile(path, []byte(`
+log-level = "warn"
+[grpc.tikv]
+keep-alive-time = "20s"
+`), 0o600))
+	cfg := config.GetDefaultServerConfig()
+	cfg.LogLevel = "warn"
+	cfg.GRPC.TiKV.KeepAliveTime = config.TomlDuration(20 * time.Second)
+	config.StoreGlobalServerConfig(cfg)
+
+	w := newConfigWatcher(path)
+	require.False(t, w.check())
+
+	// Only the grpc section is reloaded.
+	require.Nil(t, os.WriteFile(path, []byte(`
+log-level = "info"
+[grpc.tikv]
+keep-alive-time = "30s"
+[grpc.p2p]
+max-recv-msg-size = 1024
+`), 0o600))
+	require.True(t, w.check())
+	cfg = config.GetGlobalServerConfig()
+	require.Equal(t, "warn", cfg.LogLevel)
+	require.Equal(t, config.TomlDuration(30*time.Second), cfg.GRPC.TiKV.KeepAliveTime)
+	require.Equal(t, 1024, cfg.GRPC.P2P.MaxRecvMsgSize)
+	require.False(t, w.check())
+
+	// Invalid configs are ignored.
+	require.Nil(t, os.WriteFile(path, []byte(`
+[grpc.tikv]
+keep-alive-time = "-1s"
+`), 0o600))
+	require.False(t, w.check())
+	require.Equal(t, config.TomlDuration(30*time.Second),
+		config.GetGlobalServerConfig().GRPC.TiKV.KeepAliveTime)
+	require.Nil(t, os.WriteFile(path, []byte(`unknown = 1`), 0o600))
+	require.False(t, w.check())
+}
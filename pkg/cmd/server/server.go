@@ -130,6 +130,9 @@ func (o *options) run(cmd *cobra.Command) error {
 		log.Error("create cdc server failed", zap.Error(err))
 		return errors.Trace(err)
 	}
+	if o.serverConfigFilePath != "" {
+		go newConfigWatcher(o.serverConfigFilePath).run(ctx, configReloadInterval)
+	}
 	// Drain the server before shutdown.
 	shutdownNotify := func() <-chan struct{} { return server.Drain() }
 	util.InitSignalHandling(shutdownNotify, cancel)
@@ -144,12 +144,13 @@ func TestParseCfg(t *testing.T) {
 			},
 			InternalErrOutput: "stderr",
 		},
-		DataDir:                dataDir,
-		GcTTL:                  10,
-		TZ:                     "UTC",
-		CaptureSessionTTL:      10,
-		OwnerFlushInterval:     config.TomlDuration(150 * time.Millisecond),
-		ProcessorFlushInterval: config.TomlDuration(150 * time.Millisecond),
+		DataDir:                 dataDir,
+		GcTTL:                   10,
+		TZ:                      "UTC",
+		CaptureSessionTTL:       10,
+		GracefulShutdownTimeout: config.TomlDuration(30 * time.Second),
+		OwnerFlushInterval:      config.TomlDuration(150 * time.Millisecond),
+		ProcessorFlushInterval:  config.TomlDuration(150 * time.Millisecond),
 		Sorter: &config.SorterConfig{
 			SortDir:             config.DefaultSortDir,
 			CacheSizeInMB:       128,
@@ -168,6 +169,13 @@ func TestParseCfg(t *testing.T) {
 			RegionScanLimit:      40,
 			RegionRetryDuration:  config.TomlDuration(time.Minute),
 		},
+		GRPC:               config.GetDefaultServerConfig().GRPC,
+		CheckpointHistory:  config.GetDefaultServerConfig().CheckpointHistory,
+		Preflight:          config.GetDefaultServerConfig().Preflight,
+		ClockSkewGuard:     config.GetDefaultServerConfig().ClockSkewGuard,
+		GoroutineBudget:    config.GetDefaultServerConfig().GoroutineBudget,
+		MetricsRemoteWrite: config.GetDefaultServerConfig().MetricsRemoteWrite,
+		EventSampling:      config.GetDefaultServerConfig().EventSampling,
 		Debug: &config.DebugConfig{
 			DB: &config.DBConfig{
 				Count:                       8,
@@ -182,6 +190,8 @@ func TestParseCfg(t *testing.T) {
 				CompactionPeriod:            1800,
 				IteratorMaxAliveDuration:    10000,
 				IteratorSlowReadDuration:    256,
+				ValueCompression:            "none",
+				ValueCompressionDictSize:    16384,
 			},
 			// We expect the default configuration here.
 			Messages: &config.MessagesConfig{
@@ -202,6 +212,8 @@ func TestParseCfg(t *testing.T) {
 				MaxTaskConcurrency:   10,
 				CheckBalanceInterval: 60000000000,
 				AddTableBatchSize:    50,
+				BalanceStrategy:      config.BalanceStrategyTableCount,
+				LoadBalanceThreshold: 0.2,
 			},
 		},
 		ClusterID:           "default",
@@ -241,6 +253,10 @@ max-memory-percentage = 3
 [kv-client]
 region-retry-duration = "3s"
 
+[grpc.tikv]
+keep-alive-time = "20s"
+max-recv-msg-size = 1024
+
 [debug]
 [debug.db]
 count = 5
@@ -298,12 +314,13 @@ check-balance-interval = "10s"
 			},
 			InternalErrOutput: "stderr",
 		},
-		DataDir:                dataDir,
-		GcTTL:                  500,
-		TZ:                     "US",
-		CaptureSessionTTL:      10,
-		OwnerFlushInterval:     config.TomlDuration(600 * time.Millisecond),
-		ProcessorFlushInterval: config.TomlDuration(600 * time.Millisecond),
+		DataDir:                 dataDir,
+		GcTTL:                   500,
+		TZ:                      "US",
+		CaptureSessionTTL:       10,
+		GracefulShutdownTimeout: config.TomlDuration(30 * time.Second),
+		OwnerFlushInterval:      config.TomlDuration(600 * time.Millisecond),
+		ProcessorFlushInterval:  config.TomlDuration(600 * time.Millisecond),
 		Sorter: &config.SorterConfig{
 			SortDir:             config.DefaultSortDir,
 			CacheSizeInMB:       8,
@@ -318,6 +335,20 @@ check-balance-interval = "10s"
 			RegionScanLimit:      40,
 			RegionRetryDuration:  config.TomlDuration(3 * time.Second),
 		},
+		GRPC: func() *config.GRPCConfig {
+			grpc := config.GetDefaultServerConfig().GRPC
+			grpc.TiKV.KeepAliveTime = config.TomlDuration(20 * time.Second)
+			grpc.TiKV.MaxRecvMsgSize = 1024
+			// Inherited from debug.messages.
+			grpc.P2P.MaxRecvMsgSize = 4
+			return grpc
+		}(),
+		CheckpointHistory:  config.GetDefaultServerConfig().CheckpointHistory,
+		Preflight:          config.GetDefaultServerConfig().Preflight,
+		ClockSkewGuard:     config.GetDefaultServerConfig().ClockSkewGuard,
+		GoroutineBudget:    config.GetDefaultServerConfig().GoroutineBudget,
+		MetricsRemoteWrite: config.GetDefaultServerConfig().MetricsRemoteWrite,
+		EventSampling:      config.GetDefaultServerConfig().EventSampling,
 		Debug: &config.DebugConfig{
 			DB: &config.DBConfig{
 				Count:                       5,
@@ -330,6 +361,8 @@ check-balance-interval = "10s"
 				WriteL0PauseTrigger:         13,
 				IteratorMaxAliveDuration:    10000,
 				IteratorSlowReadDuration:    256,
+				ValueCompression:            "none",
+				ValueCompressionDictSize:    16384,
 				CompactionDeletionThreshold: 15,
 				CompactionPeriod:            16,
 			},
@@ -351,6 +384,8 @@ check-balance-interval = "10s"
 				MaxTaskConcurrency:   11,
 				CheckBalanceInterval: config.TomlDuration(10 * time.Second),
 				AddTableBatchSize:    50,
+				BalanceStrategy:      config.BalanceStrategyTableCount,
+				LoadBalanceThreshold: 0.2,
 			},
 		},
 		ClusterID:           "default",
@@ -430,12 +465,13 @@ cert-allowed-cn = ["dd","ee"]
 			},
 			InternalErrOutput: "stderr",
 		},
-		DataDir:                dataDir,
-		GcTTL:                  10,
-		TZ:                     "UTC",
-		CaptureSessionTTL:      10,
-		OwnerFlushInterval:     config.TomlDuration(150 * time.Millisecond),
-		ProcessorFlushInterval: config.TomlDuration(150 * time.Millisecond),
+		DataDir:                 dataDir,
+		GcTTL:                   10,
+		TZ:                      "UTC",
+		CaptureSessionTTL:       10,
+		GracefulShutdownTimeout: config.TomlDuration(30 * time.Second),
+		OwnerFlushInterval:      config.TomlDuration(150 * time.Millisecond),
+		ProcessorFlushInterval:  config.TomlDuration(150 * time.Millisecond),
 		Sorter: &config.SorterConfig{
 			SortDir:             config.DefaultSortDir,
 			CacheSizeInMB:       8,
@@ -454,6 +490,13 @@ cert-allowed-cn = ["dd","ee"]
 			RegionScanLimit:      40,
 			RegionRetryDuration:  config.TomlDuration(time.Minute),
 		},
+		GRPC:               config.GetDefaultServerConfig().GRPC,
+		CheckpointHistory:  config.GetDefaultServerConfig().CheckpointHistory,
+		Preflight:          config.GetDefaultServerConfig().Preflight,
+		ClockSkewGuard:     config.GetDefaultServerConfig().ClockSkewGuard,
+		GoroutineBudget:    config.GetDefaultServerConfig().GoroutineBudget,
+		MetricsRemoteWrite: config.GetDefaultServerConfig().MetricsRemoteWrite,
+		EventSampling:      config.GetDefaultServerConfig().EventSampling,
 		Debug: &config.DebugConfig{
 			DB: &config.DBConfig{
 				Count:                       8,
@@ -468,6 +511,8 @@ cert-allowed-cn = ["dd","ee"]
 				CompactionPeriod:            1800,
 				IteratorMaxAliveDuration:    10000,
 				IteratorSlowReadDuration:    256,
+				ValueCompression:            "none",
+				ValueCompressionDictSize:    16384,
 			},
 			// We expect the default configuration here.
 			Messages: &config.MessagesConfig{
@@ -488,6 +533,8 @@ cert-allowed-cn = ["dd","ee"]
 				MaxTaskConcurrency:   10,
 				CheckBalanceInterval: 60000000000,
 				AddTableBatchSize:    50,
+				BalanceStrategy:      config.BalanceStrategyTableCount,
+				LoadBalanceThreshold: 0.2,
 			},
 		},
 		ClusterID:           "default",
@@ -531,6 +578,8 @@ unknown3 = 3
 			CompactionPeriod:            1800,
 			IteratorMaxAliveDuration:    10000,
 			IteratorSlowReadDuration:    256,
+			ValueCompression:            "none",
+			ValueCompressionDictSize:    16384,
 		},
 		// We expect the default configuration here.
 		Messages: &config.MessagesConfig{
@@ -551,6 +600,8 @@ unknown3 = 3
 			MaxTaskConcurrency:   10,
 			CheckBalanceInterval: 60000000000,
 			AddTableBatchSize:    50,
+			BalanceStrategy:      config.BalanceStrategyTableCount,
+			LoadBalanceThreshold: 0.2,
 		},
 	}, o.serverConfig.Debug)
 }
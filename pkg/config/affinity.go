@@ -0,0 +1,69 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	filter "github.com/pingcap/tidb/util/table-filter"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// AffinityRule pins the tables matched by Matcher to captures. A capture
+// matches the rule if its ID is listed in Captures, or if it carries all the
+// Labels. Pinned tables are replicated by other captures only when none of
+// the matched captures is alive, and they are moved back once a matched
+// capture is available again.
+type AffinityRule struct {
+	Matcher  []string          `toml:"matcher" json:"matcher"`
+	Captures []string          `toml:"captures" json:"captures,omitempty"`
+	Labels   map[string]string `toml:"labels" json:"labels,omitempty"`
+}
+
+func (r *AffinityRule) validate() error {
+	if len(r.Matcher) == 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"scheduler.affinity.matcher can not be empty")
+	}
+	if _, err := filter.Parse(r.Matcher); err != nil {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("invalid scheduler.affinity.matcher: %s", err))
+	}
+	if len(r.Captures) == 0 && len(r.Labels) == 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"scheduler.affinity.captures and scheduler.affinity.labels " +
+				"can not be both empty")
+	}
+	return nil
+}
+
+// MatchCapture returns true if the capture matches the rule.
+func (r *AffinityRule) MatchCapture(id string, labels map[string]string) bool {
+	for _, capture := range r.Captures {
+		if capture == id {
+			return true
+		}
+	}
+	return len(r.Labels) != 0 && MatchLabels(r.Labels, labels)
+}
+
+// MatchLabels returns true if labels carry all the labels in selector.
+func MatchLabels(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	filter "github.com/pingcap/tidb/util/table-filter"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// CanaryConfig turns a changefeed into a canary changefeed, which replicates
+// only a sample of the tables matched by the filter rules to the downstream.
+// All matched tables are still scheduled, so the changefeed checkpoint keeps
+// tracking the resolved ts of the complete table set. It can be used to
+// validate a new sink config or TiCDC version with a bounded blast radius.
+type CanaryConfig struct {
+	// Tables are table filter rules of the tables that are always sampled.
+	Tables []string `toml:"tables" json:"tables,omitempty"`
+	// SampleRatio is the ratio of the remaining matched tables to sample.
+	// Tables are sampled by the hash of their names, so the sample is
+	// stable across restarts of the changefeed.
+	SampleRatio float64 `toml:"sample-ratio" json:"sample-ratio"`
+}
+
+func (c *CanaryConfig) validate() error {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The canary.sample-ratio:%v must be in [0, 1]", c.SampleRatio))
+	}
+	if len(c.Tables) == 0 && c.SampleRatio == 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"canary.tables and canary.sample-ratio can not be both empty")
+	}
+	if _, err := filter.Parse(c.Tables); err != nil {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("invalid canary.tables: %s", err))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// CheckpointHistoryConfig configures the checkpoint history of changefeeds,
+// which is sampled by the owner periodically.
+type CheckpointHistoryConfig struct {
+	// SampleInterval is the interval to sample checkpoint ts and resolved ts.
+	SampleInterval TomlDuration `toml:"sample-interval" json:"sample-interval"`
+	// Retention is how long the samples are kept in the memory of the owner.
+	Retention TomlDuration `toml:"retention" json:"retention"`
+	// Storage is the uri of an external storage, e.g. s3://bucket/prefix.
+	// If it's set, samples are offloaded to it, so that they are kept after
+	// the owner is changed and beyond the retention.
+	Storage string `toml:"storage" json:"storage"`
+}
+
+// ValidateAndAdjust validates and adjusts the configs.
+func (c *CheckpointHistoryConfig) ValidateAndAdjust() error {
+	if c.SampleInterval <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"checkpoint-history.sample-interval must be positive")
+	}
+	if time.Duration(c.Retention) < time.Duration(c.SampleInterval) {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"checkpoint-history.retention must not be less than sample-interval")
+	}
+	if c.Storage != "" {
+		if _, err := storage.ParseBackend(c.Storage, nil); err != nil {
+			return cerrors.ErrInvalidServerOption.Wrap(err).GenWithStack(
+				"checkpoint-history.storage is invalid")
+		}
+	}
+	return nil
+}
+
+// Capacity returns the number of samples kept in memory per changefeed.
+func (c *CheckpointHistoryConfig) Capacity() int {
+	return int(time.Duration(c.Retention) / time.Duration(c.SampleInterval))
+}
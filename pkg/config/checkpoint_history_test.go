@@ -0,0 +1,42 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointHistoryConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+
+	c := GetDefaultServerConfig().CheckpointHistory
+	require.Nil(t, c.ValidateAndAdjust())
+	require.Equal(t, 1440, c.Capacity())
+
+	c.Storage = "s3://bucket/prefix"
+	require.Nil(t, c.ValidateAndAdjust())
+	c.Storage = "unknown://bucket"
+	require.Regexp(t, "checkpoint-history.storage is invalid", c.ValidateAndAdjust())
+
+	c = GetDefaultServerConfig().CheckpointHistory
+	c.SampleInterval = 0
+	require.Regexp(t, "sample-interval must be positive", c.ValidateAndAdjust())
+
+	c = GetDefaultServerConfig().CheckpointHistory
+	c.Retention = TomlDuration(time.Second)
+	require.Regexp(t, "retention must not be less than sample-interval", c.ValidateAndAdjust())
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// ClockSkewGuardConfig configures the continuous monitoring of the skew
+// between the local clock and PD TSO.
+type ClockSkewGuardConfig struct {
+	// Enable enables the clock skew guard, it's disabled by default.
+	Enable bool `toml:"enable" json:"enable"`
+	// CheckInterval is the interval to measure the clock skew.
+	CheckInterval TomlDuration `toml:"check-interval" json:"check-interval"`
+	// MaxClockSkew is the maximal skew. If it's exceeded, the capture refuses
+	// to own changefeeds until the skew recovers, unless no other capture can
+	// take over.
+	MaxClockSkew TomlDuration `toml:"max-clock-skew" json:"max-clock-skew"`
+}
+
+// ValidateAndAdjust validates and adjusts the configs.
+func (c *ClockSkewGuardConfig) ValidateAndAdjust() error {
+	if c.CheckInterval <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"clock-skew-guard.check-interval must be positive")
+	}
+	if c.MaxClockSkew <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"clock-skew-guard.max-clock-skew must be positive")
+	}
+	return nil
+}
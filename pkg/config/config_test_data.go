@@ -96,11 +96,16 @@ const (
   "gc-ttl": 86400,
   "tz": "System",
   "capture-session-ttl": 10,
+  "graceful-shutdown-timeout": 30000000000,
   "owner-flush-interval": 50000000,
   "processor-flush-interval": 50000000,
   "sorter": {
     "sort-dir": "/tmp/sorter",
     "cache-size-in-mb": 128,
+    "changefeed-disk-quota-in-mb": 0,
+    "changefeed-write-rate-in-mb": 0,
+    "io-budget-per-tick-in-mb": 0,
+    "io-deadline": 0,
     "max-memory-percentage": 10,
     "max-memory-consumption": 0,
     "num-workerpool-goroutine": 0,
@@ -122,6 +127,61 @@ const (
     "region-scan-limit": 40,
     "region-retry-duration": 60000000000
   },
+  "grpc": {
+    "tikv": {
+      "keep-alive-time": 10000000000,
+      "keep-alive-timeout": 3000000000,
+      "initial-window-size": 65535,
+      "initial-conn-window-size": 8388608,
+      "max-recv-msg-size": 268435456,
+      "max-send-msg-size": 2147483647
+    },
+    "p2p": {
+      "keep-alive-time": 30000000000,
+      "keep-alive-timeout": 10000000000,
+      "initial-window-size": 0,
+      "initial-conn-window-size": 0,
+      "max-recv-msg-size": 268435456,
+      "max-send-msg-size": 2147483647
+    }
+  },
+  "checkpoint-history": {
+    "sample-interval": 60000000000,
+    "retention": 86400000000000,
+    "storage": ""
+  },
+  "preflight": {
+    "enable": true,
+    "refuse-on-failure": false,
+    "min-sort-dir-write-throughput": 50,
+    "max-sort-dir-sync-latency": 100000000,
+    "max-clock-skew": 500000000,
+    "min-open-files-limit": 65536,
+    "peer-dial-timeout": 3000000000
+  },
+  "clock-skew-guard": {
+    "enable": false,
+    "check-interval": 10000000000,
+    "max-clock-skew": 1000000000
+  },
+  "goroutine-budget": {
+    "enable": true,
+    "check-interval": 60000000000,
+    "max-goroutines-per-changefeed": 0,
+    "restart-on-exceeded": false
+  },
+  "metrics-remote-write": {
+    "url": "",
+    "interval": 15000000000,
+    "timeout": 10000000000
+  },
+  "event-sampling": {
+    "endpoint": "",
+    "sample-ratio": 0.01,
+    "max-samples-per-flush": 1000,
+    "flush-interval": 10000000000,
+    "timeout": 10000000000
+  },
   "debug": {
     "db": {
       "count": 8,
@@ -135,7 +195,10 @@ const (
       "compaction-deletion-threshold": 10485760,
       "compaction-period": 1800,
       "iterator-max-alive-duration": 10000,
-      "iterator-slow-read-duration": 256
+      "iterator-slow-read-duration": 256,
+      "disable-wal": false,
+      "value-compression": "none",
+      "value-compression-dict-size": 16384
     },
     "messages": {
       "client-max-batch-interval": 10000000,
@@ -146,6 +209,7 @@ const (
       "server-ack-interval": 100000000,
       "server-worker-pool-size": 4,
       "max-recv-msg-size": 268435456,
+      "enable-checksum": false,
       "keep-alive-time": 30000000000,
       "keep-alive-timeout": 10000000000
     },
@@ -154,7 +218,14 @@ const (
       "collect-stats-tick": 200,
       "max-task-concurrency": 10,
       "check-balance-interval": 60000000000,
-      "add-table-batch-size": 50
+      "add-table-batch-size": 50,
+      "add-table-latency-target": 0,
+      "warm-standby": false,
+      "balance-strategy": "table-count",
+      "load-balance-threshold": 0.2,
+      "schedule-history-size": 0,
+      "max-moves-per-tick": 0,
+      "max-moves-per-capture": 0
     }
   },
   "cluster-id": "default",
@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/pingcap/tidb/br/pkg/storage"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/redo"
 )
@@ -28,6 +29,10 @@ type ConsistentConfig struct {
 	FlushIntervalInMs int64  `toml:"flush-interval" json:"flush-interval"`
 	Storage           string `toml:"storage" json:"storage"`
 	UseFileBackend    bool   `toml:"use-file-backend" json:"use-file-backend"`
+	// Tables are table filter rules of the tables whose changes are written
+	// to the redo log. Only these tables can be recovered to a consistent
+	// state by the redo log. Empty means all tables.
+	Tables []string `toml:"tables" json:"tables,omitempty"`
 }
 
 // ValidateAndAdjust validates the consistency config and adjusts it if necessary.
@@ -49,6 +54,11 @@ func (c *ConsistentConfig) ValidateAndAdjust() error {
 				c.FlushIntervalInMs, redo.MinFlushIntervalInMs))
 	}
 
+	if _, err := filter.Parse(c.Tables); err != nil {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("invalid consistent.tables: %s", err))
+	}
+
 	uri, err := storage.ParseRawURL(c.Storage)
 	if err != nil {
 		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
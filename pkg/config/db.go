@@ -75,6 +75,30 @@ type DBConfig struct {
 	//
 	// The default value is 256, 256ms.
 	IteratorSlowReadDuration int `toml:"iterator-slow-read-duration" json:"iterator-slow-read-duration"`
+
+	// DisableWAL disables the write-ahead log of db. Sorted data is always
+	// discarded when a capture restarts and is pulled from TiKV again, so
+	// the WAL is not required for correctness. Redo logs are written by the
+	// redo manager into their own storage, so they are not affected either.
+	// Disabling it reduces write amplification, but all tables need to be
+	// pulled again after a crash.
+	//
+	// The default value is false.
+	DisableWAL bool `toml:"disable-wal" json:"disable-wal"`
+
+	// ValueCompression is the compression algorithm of event values, they are
+	// compressed before written into db and decompressed on read. It trades
+	// CPU for disk I/O, which helps changefeeds with large text or JSON
+	// columns. Valid values are "none", "snappy" or "zstd".
+	//
+	// The default value is "none".
+	ValueCompression string `toml:"value-compression" json:"value-compression"`
+	// ValueCompressionDictSize is the size of the zstd dictionary of a table,
+	// which is built from the first values of the table. A dictionary costs
+	// about 1MB memory per table. 0 means dictionaries are not used.
+	//
+	// The default value is 16384, 16KB.
+	ValueCompressionDictSize int `toml:"value-compression-dict-size" json:"value-compression-dict-size"`
 }
 
 // ValidateAndAdjust validates and adjusts the db configuration
@@ -83,6 +107,15 @@ func (c *DBConfig) ValidateAndAdjust() error {
 		return errors.ErrIllegalSorterParameter.GenWithStackByArgs(
 			"sorter.leveldb.compression must be \"none\" or \"snappy\"")
 	}
+	if c.ValueCompression != "none" && c.ValueCompression != "snappy" &&
+		c.ValueCompression != "zstd" {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs(
+			"debug.db.value-compression must be \"none\", \"snappy\" or \"zstd\"")
+	}
+	if c.ValueCompressionDictSize < 0 {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs(
+			"debug.db.value-compression-dict-size must not be negative")
+	}
 
 	return nil
 }
@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// EventSamplingConfig configures exporting a sample of the change events as
+// OpenTelemetry logs, together with per-table volume and freshness metrics,
+// to an OTLP/HTTP endpoint. Only the metadata of events is exported, so data
+// observability platforms can monitor changefeeds without consuming the sink.
+type EventSamplingConfig struct {
+	// Endpoint is the base url of the OTLP/HTTP receiver, e.g.
+	// "http://otel-collector:4318". Empty means disabled.
+	Endpoint string `toml:"endpoint" json:"endpoint"`
+	// SampleRatio is the ratio of events exported as logs, in (0, 1].
+	SampleRatio float64 `toml:"sample-ratio" json:"sample-ratio"`
+	// MaxSamplesPerFlush limits the logs exported in a flush, the excess
+	// samples are dropped.
+	MaxSamplesPerFlush int `toml:"max-samples-per-flush" json:"max-samples-per-flush"`
+	// FlushInterval is the interval to export logs and metrics.
+	FlushInterval TomlDuration `toml:"flush-interval" json:"flush-interval"`
+	// Timeout is the timeout of an export request.
+	Timeout TomlDuration `toml:"timeout" json:"timeout"`
+	// Changefeeds filters the changefeeds whose events are sampled, each
+	// item is either "<namespace>/<changefeed-id>" or "<changefeed-id>" in
+	// the default namespace. Empty means all changefeeds.
+	Changefeeds []string `toml:"changefeeds" json:"changefeeds,omitempty"`
+	// Headers are attached to all export requests, e.g. for authorization.
+	Headers map[string]string `toml:"headers" json:"headers,omitempty"`
+}
+
+// Enabled returns true if the event sampling is enabled.
+func (c *EventSamplingConfig) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// ValidateAndAdjust validates and adjusts the configs.
+func (c *EventSamplingConfig) ValidateAndAdjust() error {
+	if !c.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"event-sampling.endpoint must be a valid http(s) url")
+	}
+	c.Endpoint = strings.TrimSuffix(c.Endpoint, "/")
+	if c.SampleRatio <= 0 || c.SampleRatio > 1 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"event-sampling.sample-ratio must be in (0, 1]")
+	}
+	if c.MaxSamplesPerFlush <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"event-sampling.max-samples-per-flush must be positive")
+	}
+	if c.FlushInterval <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"event-sampling.flush-interval must be positive")
+	}
+	if c.Timeout <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"event-sampling.timeout must be positive")
+	}
+	for _, cf := range c.Changefeeds {
+		if strings.TrimSpace(cf) == "" {
+			return cerrors.ErrInvalidServerOption.GenWithStack(
+				"empty changefeed in event-sampling.changefeeds")
+		}
+	}
+	return nil
+}
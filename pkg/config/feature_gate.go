@@ -0,0 +1,86 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/coreos/go-semver/semver"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// Feature is the name of a feature that can be enabled per changefeed.
+type Feature string
+
+const (
+	// FeatureSpanReplication splits tables into spans and replicates spans
+	// of a table on multiple captures.
+	FeatureSpanReplication Feature = "span-replication"
+)
+
+// FeatureSpec describes a feature gate.
+type FeatureSpec struct {
+	// MinVersion is the min version of captures that support the feature.
+	// The owner refuses to run a changefeed with the feature enabled until
+	// all alive captures are of the version or later.
+	MinVersion *semver.Version
+}
+
+// features are all known feature gates.
+var features = map[Feature]FeatureSpec{
+	FeatureSpanReplication: {MinVersion: semver.New("6.6.0-alpha")},
+}
+
+// GetFeatureSpec returns the spec of the feature, and false if the feature
+// is unknown.
+func GetFeatureSpec(f Feature) (FeatureSpec, bool) {
+	spec, ok := features[f]
+	return spec, ok
+}
+
+// HasFeature returns true if the feature is enabled in the config.
+func (c *ReplicaConfig) HasFeature(f Feature) bool {
+	for _, feature := range c.Features {
+		if feature == f {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ReplicaConfig) validateFeatures() error {
+	seen := make(map[Feature]struct{}, len(c.Features))
+	for _, f := range c.Features {
+		if _, ok := features[f]; !ok {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				fmt.Sprintf("unknown feature %s, known features are %v", f, knownFeatures()))
+		}
+		if _, ok := seen[f]; ok {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				fmt.Sprintf("feature %s is enabled more than once", f))
+		}
+		seen[f] = struct{}{}
+	}
+	return nil
+}
+
+func knownFeatures() []Feature {
+	res := make([]Feature, 0, len(features))
+	for f := range features {
+		res = append(res, f)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
@@ -24,6 +24,9 @@ type FilterConfig struct {
 	*filter.MySQLReplicationRules
 	IgnoreTxnStartTs []uint64           `toml:"ignore-txn-start-ts" json:"ignore-txn-start-ts"`
 	EventFilters     []*EventFilterRule `toml:"event-filters" json:"event-filters"`
+	// OriginFilters filter out rows written by external systems, so that
+	// they are not replicated back to them.
+	OriginFilters []*OriginFilterRule `toml:"origin-filters" json:"origin-filters,omitempty"`
 }
 
 // EventFilterRule is used by sql event filter and expression filter
@@ -38,3 +41,23 @@ type EventFilterRule struct {
 	IgnoreUpdateOldValueExpr string `toml:"ignore-update-old-value-expr" json:"ignore-update-old-value-expr"`
 	IgnoreDeleteValueExpr    string `toml:"ignore-delete-value-expr" json:"ignore-delete-value-expr"`
 }
+
+// OriginTrackerColumn is the origin tracker which tells the origin of a row
+// by the value of a source column.
+const OriginTrackerColumn = "column"
+
+// OriginFilterRule is used by origin filter. It filters out rows written by
+// external systems into the upstream, which is required by bidirectional
+// or three-way sync topologies to avoid replicating rows back to where
+// they come from.
+type OriginFilterRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	// Tracker is the name of the origin tracker, "column" by default.
+	Tracker string `toml:"tracker" json:"tracker"`
+	// SourceColumn is the column which records the origin of a row, it's
+	// used by the column tracker.
+	SourceColumn string `toml:"source-column" json:"source-column"`
+	// IgnoreOrigins are the origins whose rows are filtered out. Rows with
+	// any origin are filtered out if it's empty.
+	IgnoreOrigins []string `toml:"ignore-origins" json:"ignore-origins"`
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// GoroutineBudgetConfig configures the goroutine accounting of changefeeds.
+// Goroutines of a processor are labeled by its changefeed and counted
+// periodically. Goroutines left after the processor is closed are leaked.
+type GoroutineBudgetConfig struct {
+	// Enable enables the goroutine accounting.
+	Enable bool `toml:"enable" json:"enable"`
+	// CheckInterval is the interval to count goroutines. A closed processor
+	// has one interval to let its goroutines exit before they're leaked.
+	CheckInterval TomlDuration `toml:"check-interval" json:"check-interval"`
+	// MaxGoroutinesPerChangefeed is the budget of goroutines of a changefeed
+	// on a capture, including the leaked ones. 0 means no budget.
+	MaxGoroutinesPerChangefeed int `toml:"max-goroutines-per-changefeed" json:"max-goroutines-per-changefeed"`
+	// RestartOnExceeded restarts the changefeed if it exceeds the budget,
+	// otherwise it's only alarmed.
+	RestartOnExceeded bool `toml:"restart-on-exceeded" json:"restart-on-exceeded"`
+}
+
+// ValidateAndAdjust validates and adjusts the configs.
+func (c *GoroutineBudgetConfig) ValidateAndAdjust() error {
+	if c.CheckInterval <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"goroutine-budget.check-interval must be positive")
+	}
+	if c.MaxGoroutinesPerChangefeed < 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"goroutine-budget.max-goroutines-per-changefeed must not be negative")
+	}
+	return nil
+}
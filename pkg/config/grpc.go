@@ -0,0 +1,162 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"math"
+	"time"
+
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// GRPCConfig configures the gRPC connections to different kinds of peers.
+// It's reloaded without restarting the server once the config file is
+// changed, new settings take effect on new connections.
+type GRPCConfig struct {
+	// TiKV configures the streams to TiKV, which are used by the kv client.
+	TiKV *GRPCPeerConfig `toml:"tikv" json:"tikv"`
+	// P2P configures the peer messages between TiCDC nodes. The keepalive
+	// and max-recv-msg-size settings are also used by the message server,
+	// which take effect after the server is restarted.
+	P2P *GRPCPeerConfig `toml:"p2p" json:"p2p"`
+}
+
+// GRPCPeerConfig configures the gRPC connections to a kind of peers.
+type GRPCPeerConfig struct {
+	// After a duration of this time if no activity is seen, the transport is
+	// pinged to see if it's still alive.
+	KeepAliveTime TomlDuration `toml:"keep-alive-time" json:"keep-alive-time"`
+	// After having pinged for keepalive check, the connection is closed if no
+	// activity is seen in this duration.
+	KeepAliveTimeout TomlDuration `toml:"keep-alive-timeout" json:"keep-alive-timeout"`
+	// InitialWindowSize is the window size of a stream, values smaller than
+	// 65535 are ignored and the dynamic window of gRPC is used.
+	InitialWindowSize int32 `toml:"initial-window-size" json:"initial-window-size"`
+	// InitialConnWindowSize is the window size of a connection, values
+	// smaller than 65535 are ignored and the dynamic window of gRPC is used.
+	InitialConnWindowSize int32 `toml:"initial-conn-window-size" json:"initial-conn-window-size"`
+	// MaxRecvMsgSize is the maximum message size in bytes can be received.
+	MaxRecvMsgSize int `toml:"max-recv-msg-size" json:"max-recv-msg-size"`
+	// MaxSendMsgSize is the maximum message size in bytes can be sent.
+	MaxSendMsgSize int `toml:"max-send-msg-size" json:"max-send-msg-size"`
+}
+
+// read only
+var defaultGRPCConfig = &GRPCConfig{
+	TiKV: &GRPCPeerConfig{
+		KeepAliveTime:    TomlDuration(10 * time.Second),
+		KeepAliveTimeout: TomlDuration(3 * time.Second),
+		// TiCDC may open numerous gRPC streams,
+		// with 65535 bytes window size, 10K streams takes about 27GB memory.
+		//
+		// 65535 bytes, the initial window size in http2 spec.
+		InitialWindowSize: (1 << 16) - 1,
+		// 8 MB The value for initial window size on a connection
+		InitialConnWindowSize: 1 << 23,
+		// 256 MB The maximum message size the client can receive
+		MaxRecvMsgSize: 1 << 28,
+		MaxSendMsgSize: math.MaxInt32,
+	},
+	P2P: &GRPCPeerConfig{
+		KeepAliveTime:         defaultMessageConfig.KeepAliveTime,
+		KeepAliveTimeout:      defaultMessageConfig.KeepAliveTimeout,
+		InitialWindowSize:     0,
+		InitialConnWindowSize: 0,
+		MaxRecvMsgSize:        defaultMessageConfig.MaxRecvMsgSize,
+		MaxSendMsgSize:        math.MaxInt32,
+	},
+}
+
+// ValidateAndAdjust validates and adjusts the configs.
+func (c *GRPCConfig) ValidateAndAdjust() error {
+	if c.TiKV == nil {
+		c.TiKV = defaultGRPCConfig.TiKV.Clone()
+	}
+	if err := c.TiKV.validate("tikv"); err != nil {
+		return err
+	}
+	if c.P2P == nil {
+		c.P2P = defaultGRPCConfig.P2P.Clone()
+	}
+	return c.P2P.validate("p2p")
+}
+
+// Clone returns a deep copy of the configuration.
+func (c *GRPCConfig) Clone() *GRPCConfig {
+	return &GRPCConfig{TiKV: c.TiKV.Clone(), P2P: c.P2P.Clone()}
+}
+
+func (c *GRPCPeerConfig) validate(class string) error {
+	if c.KeepAliveTime <= 0 || c.KeepAliveTimeout <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"grpc.%s keep-alive-time and keep-alive-timeout must be positive", class)
+	}
+	if c.InitialWindowSize < 0 || c.InitialConnWindowSize < 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"grpc.%s initial-window-size and initial-conn-window-size "+
+				"must not be negative", class)
+	}
+	if c.MaxRecvMsgSize <= 0 || c.MaxSendMsgSize <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"grpc.%s max-recv-msg-size and max-send-msg-size must be positive", class)
+	}
+	return nil
+}
+
+// Clone returns a copy of the configuration.
+func (c *GRPCPeerConfig) Clone() *GRPCPeerConfig {
+	clone := *c
+	return &clone
+}
+
+// ToDialOptions returns the dial options of window sizes and message sizes.
+// Keepalive is not included, because the servers may reject frequent pings.
+func (c *GRPCPeerConfig) ToDialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(c.MaxRecvMsgSize),
+			grpc.MaxCallSendMsgSize(c.MaxSendMsgSize)),
+	}
+	if c.InitialWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialWindowSize(c.InitialWindowSize))
+	}
+	if c.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialConnWindowSize(c.InitialConnWindowSize))
+	}
+	return opts
+}
+
+// inheritMessagesConfig keeps the deprecated settings in debug.messages
+// working. They are used if the p2p settings are not changed, and then
+// debug.messages is synchronized with the p2p settings, which is used to
+// create the message server.
+func (c *GRPCPeerConfig) inheritMessagesConfig(m *MessagesConfig) {
+	def := defaultGRPCConfig.P2P
+	if m.MaxRecvMsgSize != defaultMessageConfig.MaxRecvMsgSize &&
+		c.MaxRecvMsgSize == def.MaxRecvMsgSize {
+		c.MaxRecvMsgSize = m.MaxRecvMsgSize
+	}
+	if m.KeepAliveTime != defaultMessageConfig.KeepAliveTime &&
+		c.KeepAliveTime == def.KeepAliveTime {
+		c.KeepAliveTime = m.KeepAliveTime
+	}
+	if m.KeepAliveTimeout != defaultMessageConfig.KeepAliveTimeout &&
+		c.KeepAliveTimeout == def.KeepAliveTimeout {
+		c.KeepAliveTimeout = m.KeepAliveTimeout
+	}
+	m.MaxRecvMsgSize = c.MaxRecvMsgSize
+	m.KeepAliveTime = c.KeepAliveTime
+	m.KeepAliveTimeout = c.KeepAliveTimeout
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+
+	c := &GRPCConfig{}
+	require.Nil(t, c.ValidateAndAdjust())
+	require.Equal(t, defaultGRPCConfig, c)
+
+	c = defaultGRPCConfig.Clone()
+	c.TiKV.KeepAliveTime = 0
+	require.Regexp(t, "grpc.tikv keep-alive-time", c.ValidateAndAdjust())
+
+	c = defaultGRPCConfig.Clone()
+	c.P2P.InitialWindowSize = -1
+	require.Regexp(t, "grpc.p2p initial-window-size", c.ValidateAndAdjust())
+
+	c = defaultGRPCConfig.Clone()
+	c.P2P.MaxSendMsgSize = 0
+	require.Regexp(t, "grpc.p2p max-recv-msg-size", c.ValidateAndAdjust())
+
+	// Clone is a deep copy.
+	c = defaultGRPCConfig.Clone()
+	c.TiKV.MaxRecvMsgSize = 1
+	require.NotEqual(t, 1, defaultGRPCConfig.TiKV.MaxRecvMsgSize)
+}
+
+func TestGRPCPeerConfigToDialOptions(t *testing.T) {
+	t.Parallel()
+
+	require.Len(t, defaultGRPCConfig.TiKV.ToDialOptions(), 3)
+	require.Len(t, defaultGRPCConfig.P2P.ToDialOptions(), 1)
+}
+
+func TestGRPCInheritMessagesConfig(t *testing.T) {
+	t.Parallel()
+
+	// The legacy settings are used if the p2p settings are not changed.
+	c := defaultGRPCConfig.P2P.Clone()
+	m := defaultMessageConfig.Clone()
+	m.MaxRecvMsgSize = 1024
+	m.KeepAliveTime = TomlDuration(time.Minute)
+	c.inheritMessagesConfig(m)
+	require.Equal(t, 1024, c.MaxRecvMsgSize)
+	require.Equal(t, TomlDuration(time.Minute), c.KeepAliveTime)
+	require.Equal(t, defaultMessageConfig.KeepAliveTimeout, c.KeepAliveTimeout)
+
+	// The p2p settings take precedence over the legacy ones.
+	c = defaultGRPCConfig.P2P.Clone()
+	c.MaxRecvMsgSize = 2048
+	m = defaultMessageConfig.Clone()
+	m.MaxRecvMsgSize = 1024
+	c.inheritMessagesConfig(m)
+	require.Equal(t, 2048, c.MaxRecvMsgSize)
+	require.Equal(t, 2048, m.MaxRecvMsgSize)
+}
@@ -36,6 +36,10 @@ type MessagesConfig struct {
 	// MaxRecvMsgSize is the maximum message size in bytes TiCDC can receive.
 	MaxRecvMsgSize int `toml:"max-recv-msg-size" json:"max-recv-msg-size"`
 
+	// EnableChecksum enables end-to-end checksums and sequence gap detection
+	// for peer messages. A connection carrying a corrupted message is reset.
+	EnableChecksum bool `toml:"enable-checksum" json:"enable-checksum"`
+
 	// After a duration of this time if the server doesn't see any activity it
 	// pings the client to see if the transport is still alive.
 	KeepAliveTime TomlDuration `toml:"keep-alive-time" json:"keep-alive-time"`
@@ -165,6 +169,7 @@ func (c *MessagesConfig) Clone() *MessagesConfig {
 		ServerAckInterval:            c.ServerAckInterval,
 		ServerWorkerPoolSize:         c.ServerWorkerPoolSize,
 		MaxRecvMsgSize:               c.MaxRecvMsgSize,
+		EnableChecksum:               c.EnableChecksum,
 		KeepAliveTime:                c.KeepAliveTime,
 		KeepAliveTimeout:             c.KeepAliveTimeout,
 	}
@@ -180,6 +185,7 @@ func (c *MessagesConfig) ToMessageClientConfig() *p2p.MessageClientConfig {
 		RetryRateLimitPerSecond: c.ClientRetryRateLimit,
 		DialTimeout:             clientDialTimeout,
 		MaxRecvMsgSize:          c.MaxRecvMsgSize,
+		EnableChecksum:          c.EnableChecksum,
 	}
 }
 
@@ -195,6 +201,7 @@ func (c *MessagesConfig) ToMessageServerConfig() *p2p.MessageServerConfig {
 		WaitUnregisterHandleTimeoutThreshold: unregisterHandleTimeout,
 		SendRateLimitPerStream:               serverSendRateLimit,
 		MaxRecvMsgSize:                       c.MaxRecvMsgSize,
+		EnableIntegrityCheck:                 c.EnableChecksum,
 		KeepAliveTimeout:                     time.Duration(c.KeepAliveTimeout),
 		KeepAliveTime:                        time.Duration(c.KeepAliveTime),
 	}
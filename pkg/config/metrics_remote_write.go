@@ -0,0 +1,73 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// MetricsRemoteWriteConfig configures pushing the metrics of a capture to a
+// Prometheus-compatible remote write endpoint. It's useful where the captures
+// can't be scraped, e.g. in serverless or edge deployments.
+type MetricsRemoteWriteConfig struct {
+	// URL is the remote write endpoint. Empty means disabled.
+	URL string `toml:"url" json:"url"`
+	// Interval is the interval to push metrics.
+	Interval TomlDuration `toml:"interval" json:"interval"`
+	// Timeout is the timeout of a push request.
+	Timeout TomlDuration `toml:"timeout" json:"timeout"`
+	// Changefeeds filters the per-changefeed metrics to be pushed, each item
+	// is either "<namespace>/<changefeed-id>" or "<changefeed-id>" in the
+	// default namespace. Empty means all changefeeds.
+	Changefeeds []string `toml:"changefeeds" json:"changefeeds,omitempty"`
+	// ExternalLabels are attached to all pushed series.
+	ExternalLabels map[string]string `toml:"external-labels" json:"external-labels,omitempty"`
+	// Headers are attached to all push requests, e.g. for authorization.
+	Headers map[string]string `toml:"headers" json:"headers,omitempty"`
+}
+
+// Enabled returns true if the remote write is enabled.
+func (c *MetricsRemoteWriteConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// ValidateAndAdjust validates and adjusts the configs.
+func (c *MetricsRemoteWriteConfig) ValidateAndAdjust() error {
+	if !c.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"metrics-remote-write.url must be a valid http(s) url")
+	}
+	if c.Interval <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"metrics-remote-write.interval must be positive")
+	}
+	if c.Timeout <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"metrics-remote-write.timeout must be positive")
+	}
+	for _, cf := range c.Changefeeds {
+		if strings.TrimSpace(cf) == "" {
+			return cerrors.ErrInvalidServerOption.GenWithStack(
+				"empty changefeed in metrics-remote-write.changefeeds")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// PreflightConfig configures the preflight checks at the start of a capture,
+// which validate the environment of the capture before it joins the cluster.
+type PreflightConfig struct {
+	// Enable enables the preflight checks.
+	Enable bool `toml:"enable" json:"enable"`
+	// RefuseOnFailure makes the capture refuse to join the cluster if any
+	// hard check fails, otherwise the failures are only logged.
+	RefuseOnFailure bool `toml:"refuse-on-failure" json:"refuse-on-failure"`
+	// MinSortDirWriteThroughput is the minimal write throughput of the sort
+	// dir in MiB/s.
+	MinSortDirWriteThroughput uint64 `toml:"min-sort-dir-write-throughput" json:"min-sort-dir-write-throughput"`
+	// MaxSortDirSyncLatency is the maximal latency of syncing a file in the
+	// sort dir.
+	MaxSortDirSyncLatency TomlDuration `toml:"max-sort-dir-sync-latency" json:"max-sort-dir-sync-latency"`
+	// MaxClockSkew is the maximal difference between the local clock and the
+	// physical time of PD TSO.
+	MaxClockSkew TomlDuration `toml:"max-clock-skew" json:"max-clock-skew"`
+	// MinOpenFilesLimit is the minimal soft limit of open files.
+	MinOpenFilesLimit uint64 `toml:"min-open-files-limit" json:"min-open-files-limit"`
+	// PeerDialTimeout is the timeout to connect to other captures.
+	PeerDialTimeout TomlDuration `toml:"peer-dial-timeout" json:"peer-dial-timeout"`
+}
+
+// ValidateAndAdjust validates and adjusts the configs.
+func (c *PreflightConfig) ValidateAndAdjust() error {
+	if c.MaxSortDirSyncLatency <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"preflight.max-sort-dir-sync-latency must be positive")
+	}
+	if c.MaxClockSkew <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"preflight.max-clock-skew must be positive")
+	}
+	if c.PeerDialTimeout <= 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStack(
+			"preflight.peer-dial-timeout must be positive")
+	}
+	return nil
+}
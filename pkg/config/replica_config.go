@@ -36,6 +36,9 @@ const (
 	minSyncPointInterval = time.Second * 30
 	// minSyncPointRetention is the minimum of SyncPointRetention can be set.
 	minSyncPointRetention = time.Hour * 1
+	// maxResourceGroupNameLength is the max length of resource group names
+	// allowed by TiDB.
+	maxResourceGroupNameLength = 32
 )
 
 var defaultReplicaConfig = &ReplicaConfig{
@@ -136,6 +139,23 @@ type replicaConfig struct {
 	Scheduler *ChangefeedSchedulerConfig `toml:"scheduler" json:"scheduler"`
 	// Integrity is only available when the downstream is MQ.
 	Integrity *integrity.Config `toml:"integrity" json:"integrity"`
+	// ResolvedTsIntervals sets the minimum resolved ts interval of matched
+	// tables. The first matched rule takes effect.
+	ResolvedTsIntervals []*ResolvedTsIntervalRule `toml:"resolved-ts-intervals" json:"resolved-ts-intervals,omitempty"`
+	// BootstrapDDL makes a newly created changefeed replay the CREATE
+	// DATABASE and CREATE TABLE statements of all replicated tables at the
+	// start ts to the downstream before replicating any changes.
+	BootstrapDDL *bool `toml:"bootstrap-ddl" json:"bootstrap-ddl,omitempty"`
+	// Canary makes the changefeed replicate only a sample of matched tables.
+	Canary *CanaryConfig `toml:"canary" json:"canary,omitempty"`
+	// ResourceGroup is the TiDB resource group that KV reads of the upstream
+	// and sessions of the downstream TiDB are bound to. Requests are governed
+	// by the default resource group if it is empty.
+	ResourceGroup string `toml:"resource-group" json:"resource-group,omitempty"`
+	// Features are feature gates enabled for the changefeed, so that risky
+	// features can roll out per changefeed. The owner runs the changefeed
+	// only if all captures support the features.
+	Features []Feature `toml:"features" json:"features,omitempty"`
 }
 
 // Marshal returns the json marshal format of a ReplicationConfig
@@ -193,6 +213,28 @@ func (c *replicaConfig) fillFromV1(v1 *outdated.ReplicaConfigV1) {
 	}
 }
 
+// TeeSinkReplicaConfig returns the replica config of the tee sink, or nil if
+// the tee sink is not configured. It's the same as the replica config except
+// that the protocol and the transaction atomicity are taken from the URI of
+// the tee sink.
+func (c *ReplicaConfig) TeeSinkReplicaConfig() (*ReplicaConfig, error) {
+	if c.Sink == nil || util.GetOrZero(c.Sink.TeeSinkURI) == "" {
+		return nil, nil
+	}
+	sinkURI, err := url.Parse(*c.Sink.TeeSinkURI)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	teeCfg := c.Clone()
+	teeCfg.Sink.TeeSinkURI = nil
+	teeCfg.Sink.Protocol = nil
+	teeCfg.Sink.TxnAtomicity = nil
+	if err := teeCfg.ValidateAndAdjust(sinkURI); err != nil {
+		return nil, err
+	}
+	return teeCfg, nil
+}
+
 // ValidateAndAdjust verifies and adjusts the replica configuration.
 func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sink uri
 	if c.Sink != nil {
@@ -205,6 +247,10 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 		if err != nil {
 			return err
 		}
+
+		if _, err := c.TeeSinkReplicaConfig(); err != nil {
+			return err
+		}
 	}
 
 	if c.Consistent != nil {
@@ -233,12 +279,40 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 						minSyncPointRetention.String()))
 		}
 	}
+	for _, rule := range c.ResolvedTsIntervals {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Canary != nil {
+		if err := c.Canary.validate(); err != nil {
+			return err
+		}
+	}
+	if len(c.ResourceGroup) > maxResourceGroupNameLength {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("the length of resource group %s must be less than or equal to %d",
+				c.ResourceGroup, maxResourceGroupNameLength))
+	}
+	if err := c.validateFeatures(); err != nil {
+		return err
+	}
 	if c.MemoryQuota == uint64(0) {
 		c.FixMemoryQuota()
 	}
 	if c.Scheduler == nil {
 		c.FixScheduler(false)
 	}
+	if err := c.Scheduler.validate(); err != nil {
+		return err
+	}
+	if c.HasFeature(FeatureSpanReplication) {
+		if !isSinkCompatibleWithSpanReplication(sinkURI) {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				fmt.Sprintf("feature %s is not supported by the sink", FeatureSpanReplication))
+		}
+		c.Scheduler.EnableTableAcrossNodes = true
+	}
 	// TODO: Remove the hack once span replication is compatible with all sinks.
 	if !isSinkCompatibleWithSpanReplication(sinkURI) {
 		c.Scheduler.EnableTableAcrossNodes = false
@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -252,6 +253,103 @@ func TestValidateAndAdjust(t *testing.T) {
 	cfg.Integrity.IntegrityCheckLevel = integrity.CheckLevelCorrectness
 	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
 	require.Equal(t, integrity.CheckLevelNone, cfg.Integrity.IntegrityCheckLevel)
+
+	// resolved ts interval overrides
+	cfg = GetDefaultReplicaConfig()
+	cfg.ResolvedTsIntervals = []*ResolvedTsIntervalRule{
+		{Matcher: []string{"test.fast"}, Interval: "200ms"},
+		{Matcher: []string{"test.*"}, Interval: "5s"},
+	}
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.ResolvedTsIntervals[0].Interval = "10ms"
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.ResolvedTsIntervals[0].Interval = "abc"
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.ResolvedTsIntervals[0].Interval = "1s"
+	cfg.ResolvedTsIntervals[0].Matcher = nil
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+
+	// canary changefeed
+	cfg = GetDefaultReplicaConfig()
+	cfg.Canary = &CanaryConfig{Tables: []string{"test.t1"}, SampleRatio: 0.1}
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Canary.SampleRatio = 1.5
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Canary.SampleRatio = 0
+	cfg.Canary.Tables = nil
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Canary.Tables = []string{"test.t1["}
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+
+	// table affinity
+	cfg = GetDefaultReplicaConfig()
+	cfg.Scheduler.Affinity = []*AffinityRule{
+		{Matcher: []string{"test.t1"}, Captures: []string{"capture-1"}},
+		{Matcher: []string{"test.*"}, Labels: map[string]string{"zone": "z1"}},
+	}
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Scheduler.Affinity[0].Matcher = []string{"test.t1["}
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Scheduler.Affinity[0].Matcher = nil
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Scheduler.Affinity[0].Matcher = []string{"test.t1"}
+	cfg.Scheduler.Affinity[0].Captures = nil
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+
+	// capture labels
+	cfg = GetDefaultReplicaConfig()
+	cfg.Scheduler.CaptureLabels = map[string]string{"tier": "ssd"}
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Scheduler.CaptureLabels[""] = "ssd"
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+
+	// resource group
+	cfg = GetDefaultReplicaConfig()
+	cfg.ResourceGroup = "rg1"
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.ResourceGroup = strings.Repeat("a", maxResourceGroupNameLength+1)
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+
+	// feature gates
+	cfg = GetDefaultReplicaConfig()
+	cfg.Features = []Feature{FeatureSpanReplication}
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
+	require.True(t, cfg.Scheduler.EnableTableAcrossNodes)
+	mysqlURL, err := url.Parse("mysql://127.0.0.1:3306")
+	require.NoError(t, err)
+	require.Error(t, cfg.ValidateAndAdjust(mysqlURL))
+	cfg.Features = []Feature{FeatureSpanReplication, FeatureSpanReplication}
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Features = []Feature{"unknown"}
+	require.Error(t, cfg.ValidateAndAdjust(sinkURL))
+}
+
+func TestTeeSinkReplicaConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := GetDefaultReplicaConfig()
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=canal-json")
+	require.NoError(t, err)
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURI))
+	teeCfg, err := cfg.TeeSinkReplicaConfig()
+	require.NoError(t, err)
+	require.Nil(t, teeCfg)
+
+	// The protocol of the tee sink is taken from its own URI.
+	cfg.Sink.TeeSinkURI = util.AddressOf("s3://bucket/prefix?protocol=csv")
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURI))
+	teeCfg, err = cfg.TeeSinkReplicaConfig()
+	require.NoError(t, err)
+	require.Equal(t, "csv", util.GetOrZero(teeCfg.Sink.Protocol))
+	require.Nil(t, teeCfg.Sink.TeeSinkURI)
+	require.Equal(t, "canal-json", util.GetOrZero(cfg.Sink.Protocol))
+
+	cfg.Sink.TeeSinkURI = util.AddressOf("s3://bucket/prefix")
+	require.Error(t, cfg.ValidateAndAdjust(sinkURI))
+	cfg.Sink.TeeSinkURI = util.AddressOf("mysql://root@127.0.0.1:3306?protocol=csv")
+	require.Error(t, cfg.ValidateAndAdjust(sinkURI))
+	cfg.Sink.TeeSinkURI = util.AddressOf("://invalid")
+	require.Regexp(t, "ErrSinkURIInvalid", cfg.ValidateAndAdjust(sinkURI))
 }
 
 func TestIsSinkCompatibleWithSpanReplication(t *testing.T) {
@@ -0,0 +1,65 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// minResolvedTsInterval is the minimum of ResolvedTsIntervalRule.Interval.
+	minResolvedTsInterval = 100 * time.Millisecond
+	// maxResolvedTsInterval is the maximum of ResolvedTsIntervalRule.Interval.
+	maxResolvedTsInterval = 10 * time.Minute
+)
+
+// ResolvedTsIntervalRule sets the minimum interval of advancing resolved ts
+// for the matched tables, for example bulk tables can use 5s to reduce the
+// overhead of resolved ts events. A rule can only slow down resolved ts, the
+// other tables advance resolved ts as soon as TiKV pushes it, so their latency
+// is bounded by the resolved ts advance interval configured in TiKV.
+type ResolvedTsIntervalRule struct {
+	Matcher  []string `toml:"matcher" json:"matcher"`
+	Interval string   `toml:"interval" json:"interval"`
+}
+
+// GetInterval returns the parsed interval of the rule.
+func (r *ResolvedTsIntervalRule) GetInterval() (time.Duration, error) {
+	interval, err := time.ParseDuration(r.Interval)
+	if err != nil {
+		return 0, cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("invalid resolved-ts-intervals.interval: %s", r.Interval))
+	}
+	return interval, nil
+}
+
+func (r *ResolvedTsIntervalRule) validate() error {
+	if len(r.Matcher) == 0 {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			"resolved-ts-intervals.matcher can not be empty")
+	}
+	interval, err := r.GetInterval()
+	if err != nil {
+		return err
+	}
+	if interval < minResolvedTsInterval || interval > maxResolvedTsInterval {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("The resolved-ts-intervals.interval:%s must be in [%s, %s]",
+				interval, minResolvedTsInterval, maxResolvedTsInterval))
+	}
+	return nil
+}
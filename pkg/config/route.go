@@ -0,0 +1,139 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strings"
+
+	filter "github.com/pingcap/tidb/util/table-filter"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// RouteSchemaPlaceholder is replaced by the upstream schema name in the
+	// targets of route rules.
+	RouteSchemaPlaceholder = "{schema}"
+	// RouteTablePlaceholder is replaced by the upstream table name in the
+	// targets of route rules.
+	RouteTablePlaceholder = "{table}"
+)
+
+// RouteRule renames the matched tables in the sink, e.g. routes the upstream
+// table `app`.`users` to the downstream table `app_shadow`.`users_v2`.
+// The first matched rule takes effect.
+type RouteRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	// TargetSchema is the downstream schema name, which can contain
+	// the placeholder {schema}. Empty means the upstream schema name.
+	TargetSchema string `toml:"target-schema" json:"target-schema"`
+	// TargetTable is the downstream table name, which can contain the
+	// placeholders {schema} and {table}. Empty means the upstream table name.
+	TargetTable string `toml:"target-table" json:"target-table"`
+}
+
+// Target returns the downstream schema and table name of an upstream table.
+// The table name is empty if the target of a schema is asked for.
+func (r *RouteRule) Target(schema, table string) (string, string) {
+	targetSchema, targetTable := schema, table
+	if r.TargetSchema != "" {
+		targetSchema = expandRouteTarget(r.TargetSchema, schema, table)
+	}
+	if r.TargetTable != "" && table != "" {
+		targetTable = expandRouteTarget(r.TargetTable, schema, table)
+	}
+	return targetSchema, targetTable
+}
+
+// isStatic returns true if the rule routes all matched tables to one table.
+func (r *RouteRule) isStatic() bool {
+	return r.TargetSchema != "" && r.TargetTable != "" &&
+		!strings.Contains(r.TargetSchema, "{") && !strings.Contains(r.TargetTable, "{")
+}
+
+func expandRouteTarget(target, schema, table string) string {
+	target = strings.ReplaceAll(target, RouteSchemaPlaceholder, schema)
+	return strings.ReplaceAll(target, RouteTablePlaceholder, table)
+}
+
+func (r *RouteRule) validate() error {
+	if len(r.Matcher) == 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"routes.matcher can not be empty")
+	}
+	if _, err := filter.Parse(r.Matcher); err != nil {
+		return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+	}
+	if r.TargetSchema == "" && r.TargetTable == "" {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"routes.target-schema and routes.target-table can not be both empty")
+	}
+	if strings.Contains(r.TargetSchema, RouteTablePlaceholder) {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"routes.target-schema %s can not contain %s",
+			r.TargetSchema, RouteTablePlaceholder)
+	}
+	for _, target := range []string{r.TargetSchema, r.TargetTable} {
+		if strings.ContainsAny(expandRouteTarget(target, "", ""), "{}") {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"routes target %s contains unknown placeholders", target)
+		}
+	}
+	return nil
+}
+
+// validateRoutes validates route rules. Besides each rule, it rejects
+//   - conflicts, i.e. two rules route tables to the same static target,
+//   - cycles, i.e. the static target of a rule is routed again by a rule.
+//
+// Conflicts of tables routed by dynamic targets can only be found with the
+// tables, they are verified on creating the changefeed and replicating.
+func validateRoutes(rules []*RouteRule) error {
+	filters := make([]filter.Filter, 0, len(rules))
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+		f, _ := filter.Parse(rule.Matcher)
+		filters = append(filters, filter.CaseInsensitive(f))
+	}
+
+	targets := make(map[string]*RouteRule)
+	for _, rule := range rules {
+		if !rule.isStatic() {
+			continue
+		}
+		schema, table := rule.TargetSchema, rule.TargetTable
+		key := strings.ToLower(schema + "." + table)
+		if _, ok := targets[key]; ok {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"routes conflict, tables are routed to %s.%s by multiple rules",
+				schema, table)
+		}
+		targets[key] = rule
+		for i, f := range filters {
+			if !f.MatchTable(schema, table) {
+				continue
+			}
+			targetSchema, targetTable := rules[i].Target(schema, table)
+			if !strings.EqualFold(targetSchema, schema) ||
+				!strings.EqualFold(targetTable, table) {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"routes cycle, the target %s.%s is routed again to %s.%s",
+					schema, table, targetSchema, targetTable)
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -30,8 +30,45 @@ type ChangefeedSchedulerConfig struct {
 	WriteKeyThreshold int `toml:"write-key-threshold" json:"write-key-threshold"`
 	// Deprecated.
 	RegionPerSpan int `toml:"region-per-span" json:"region-per-span"`
+	// Policy is the name of the registered policy which schedules tables of
+	// the changefeed automatically, empty means the default policy.
+	Policy string `toml:"policy" json:"policy,omitempty"`
+	// Affinity pins the matched tables to the matched captures. The first
+	// matched rule takes effect.
+	Affinity []*AffinityRule `toml:"affinity" json:"affinity,omitempty"`
+	// CaptureLabels constrains tables of the changefeed to the captures
+	// carrying all the labels, e.g. {"tier" = "ssd"}. Tables are scheduled
+	// to other captures only when none of the matched captures is alive.
+	CaptureLabels map[string]string `toml:"capture-labels" json:"capture-labels,omitempty"`
+	// ZoneLabel is the name of the label of TiKV stores and captures that
+	// tells their availability zones, e.g. "zone". If it's set, tables are
+	// preferably replicated by captures in the zone of the majority of
+	// their region leaders, to minimize cross-zone traffic.
+	ZoneLabel string `toml:"zone-label" json:"zone-label,omitempty"`
 }
 
+func (c *ChangefeedSchedulerConfig) validate() error {
+	for _, rule := range c.Affinity {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+	for k := range c.CaptureLabels {
+		if k == "" {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				"scheduler.capture-labels can not contain an empty label name")
+		}
+	}
+	return nil
+}
+
+const (
+	// BalanceStrategyTableCount balances the number of tables on each capture.
+	BalanceStrategyTableCount = "table-count"
+	// BalanceStrategyLoad balances the throughput of tables on each capture.
+	BalanceStrategyLoad = "load"
+)
+
 // SchedulerConfig configs TiCDC scheduler.
 type SchedulerConfig struct {
 	// HeartbeatTick is the number of owner tick to initial a heartbeat to captures.
@@ -50,9 +87,46 @@ type SchedulerConfig struct {
 	// When there are only 2 captures, and a large number of tables, this can be helpful to prevent
 	// oom caused by all tables dispatched to only one capture.
 	AddTableBatchSize int `toml:"add-table-batch-size" json:"add-table-batch-size"`
+	// AddTableLatencyTarget makes the batch size of adding tables adaptive if
+	// it is larger than 0. The batch size starts small, and grows until the
+	// latency of adding a table reaches the target. AddTableBatchSize is the
+	// upper bound of the batch size.
+	AddTableLatencyTarget TomlDuration `toml:"add-table-latency-target" json:"add-table-latency-target"`
+	// WarmStandby makes the secondary of a two-phase table move a warm
+	// standby. It reports prepared only after it has pulled and sorted the
+	// data up to the barrier of the changefeed, and creates its table sink in
+	// advance, so that the gap of the handoff is as short as possible.
+	WarmStandby bool `toml:"warm-standby" json:"warm-standby"`
+	// BalanceStrategy is the strategy of balancing tables among captures,
+	// it can be "table-count" or "load".
+	// "table-count" balances the number of tables on each capture.
+	// "load" balances the throughput of tables on each capture, which is
+	// derived from the table stats collected via heartbeats.
+	BalanceStrategy string `toml:"balance-strategy" json:"balance-strategy"`
+	// LoadBalanceThreshold is the tolerance of the "load" balance strategy.
+	// Tables are moved away from a capture only if its load exceeds the
+	// average load of all captures by more than the given ratio.
+	LoadBalanceThreshold float64 `toml:"load-balance-threshold" json:"load-balance-threshold"`
+	// ScheduleHistorySize is the number of recent schedule rounds that emit
+	// tasks recorded in the coordinator, along with their inputs. They are
+	// dumped in scheduler snapshots and can be replayed by
+	// `cdc scheduler replay-history` for postmortem. 0 disables the history.
+	ScheduleHistorySize int `toml:"schedule-history-size" json:"schedule-history-size"`
+	// MaxMovesPerTick is the maximum number of table moves dispatched by
+	// balancing in one tick, 0 means no limit. Moves exceeding the budget are
+	// queued and dispatched in the following ticks, so that rebalancing
+	// proceeds incrementally when many captures join at once.
+	MaxMovesPerTick int `toml:"max-moves-per-tick" json:"max-moves-per-tick"`
+	// MaxMovesPerCapture is the maximum number of in-flight table moves from
+	// or to a capture, 0 means no limit. Like MaxMovesPerTick, it applies to
+	// the moves of balancing only.
+	MaxMovesPerCapture int `toml:"max-moves-per-capture" json:"max-moves-per-capture"`
 
 	// ChangefeedSettings is setting by changefeed.
 	ChangefeedSettings *ChangefeedSchedulerConfig `toml:"-" json:"-"`
+	// KeyspacePrefix is the key prefix of the keyspace the changefeed is
+	// scoped to, it is set by changefeed.
+	KeyspacePrefix []byte `toml:"-" json:"-"`
 }
 
 // NewDefaultSchedulerConfig return the default scheduler configuration.
@@ -66,6 +140,8 @@ func NewDefaultSchedulerConfig() *SchedulerConfig {
 		// TODO: no need to check balance each minute, relax the interval.
 		CheckBalanceInterval: TomlDuration(time.Minute),
 		AddTableBatchSize:    50,
+		BalanceStrategy:      BalanceStrategyTableCount,
+		LoadBalanceThreshold: 0.2,
 	}
 }
 
@@ -91,6 +167,34 @@ func (c *SchedulerConfig) ValidateAndAdjust() error {
 		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
 			"add-table-batch-size must be large than 0")
 	}
+	if c.AddTableLatencyTarget < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"add-table-latency-target must not be negative")
+	}
+	if c.BalanceStrategy == "" {
+		c.BalanceStrategy = BalanceStrategyTableCount
+	}
+	if c.BalanceStrategy != BalanceStrategyTableCount &&
+		c.BalanceStrategy != BalanceStrategyLoad {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"balance-strategy must be \"table-count\" or \"load\"")
+	}
+	if c.LoadBalanceThreshold <= 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"load-balance-threshold must be larger than 0")
+	}
+	if c.ScheduleHistorySize < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"schedule-history-size must not be negative")
+	}
+	if c.MaxMovesPerTick < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"max-moves-per-tick must not be negative")
+	}
+	if c.MaxMovesPerCapture < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"max-moves-per-capture must not be negative")
+	}
 
 	return nil
 }
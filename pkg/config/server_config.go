@@ -102,9 +102,10 @@ var defaultServerConfig = &ServerConfig{
 	// which is calculated by `math.Ceil(3 * election-timeout / 2)`, we choose
 	// default capture session ttl to 10s to increase robust to PD jitter,
 	// however it will decrease RTO when single TiCDC node error happens.
-	CaptureSessionTTL:      10,
-	OwnerFlushInterval:     TomlDuration(50 * time.Millisecond),
-	ProcessorFlushInterval: TomlDuration(50 * time.Millisecond),
+	CaptureSessionTTL:       10,
+	GracefulShutdownTimeout: TomlDuration(30 * time.Second),
+	OwnerFlushInterval:      TomlDuration(50 * time.Millisecond),
+	ProcessorFlushInterval:  TomlDuration(50 * time.Millisecond),
 	Sorter: &SorterConfig{
 		SortDir:             DefaultSortDir,
 		CacheSizeInMB:       128, // By default use 128M memory as sorter cache.
@@ -121,6 +122,41 @@ var defaultServerConfig = &ServerConfig{
 		// Use 1 minute to cover region leader missing.
 		RegionRetryDuration: TomlDuration(time.Minute),
 	},
+	GRPC: defaultGRPCConfig.Clone(),
+	CheckpointHistory: &CheckpointHistoryConfig{
+		SampleInterval: TomlDuration(time.Minute),
+		Retention:      TomlDuration(24 * time.Hour),
+	},
+	Preflight: &PreflightConfig{
+		Enable:                    true,
+		RefuseOnFailure:           false,
+		MinSortDirWriteThroughput: 50,
+		MaxSortDirSyncLatency:     TomlDuration(100 * time.Millisecond),
+		MaxClockSkew:              TomlDuration(500 * time.Millisecond),
+		MinOpenFilesLimit:         65536,
+		PeerDialTimeout:           TomlDuration(3 * time.Second),
+	},
+	ClockSkewGuard: &ClockSkewGuardConfig{
+		Enable:        false,
+		CheckInterval: TomlDuration(10 * time.Second),
+		MaxClockSkew:  TomlDuration(time.Second),
+	},
+	GoroutineBudget: &GoroutineBudgetConfig{
+		Enable:                     true,
+		CheckInterval:              TomlDuration(time.Minute),
+		MaxGoroutinesPerChangefeed: 0,
+		RestartOnExceeded:          false,
+	},
+	MetricsRemoteWrite: &MetricsRemoteWriteConfig{
+		Interval: TomlDuration(15 * time.Second),
+		Timeout:  TomlDuration(10 * time.Second),
+	},
+	EventSampling: &EventSamplingConfig{
+		SampleRatio:        0.01,
+		MaxSamplesPerFlush: 1000,
+		FlushInterval:      TomlDuration(10 * time.Second),
+		Timeout:            TomlDuration(10 * time.Second),
+	},
 	Debug: &DebugConfig{
 		DB: &DBConfig{
 			Count: 8,
@@ -137,6 +173,8 @@ var defaultServerConfig = &ServerConfig{
 			CompactionPeriod:            1800,
 			IteratorMaxAliveDuration:    10000,
 			IteratorSlowReadDuration:    256,
+			ValueCompression:            "none",
+			ValueCompressionDictSize:    16384,
 		},
 		Messages: defaultMessageConfig.Clone(),
 
@@ -161,6 +199,10 @@ type ServerConfig struct {
 	TZ    string `toml:"tz" json:"tz"`
 
 	CaptureSessionTTL int `toml:"capture-session-ttl" json:"capture-session-ttl"`
+	// GracefulShutdownTimeout is the max time that a shutting down server
+	// waits for its tables to be handed off to other captures.
+	// 0 means exiting without waiting.
+	GracefulShutdownTimeout TomlDuration `toml:"graceful-shutdown-timeout" json:"graceful-shutdown-timeout"`
 
 	OwnerFlushInterval     TomlDuration `toml:"owner-flush-interval" json:"owner-flush-interval"`
 	ProcessorFlushInterval TomlDuration `toml:"processor-flush-interval" json:"processor-flush-interval"`
@@ -169,11 +211,24 @@ type ServerConfig struct {
 	Security *SecurityConfig `toml:"security" json:"security"`
 	// DEPRECATED: after using pull based sink, this config is useless.
 	// Because we do not control the memory usage by table anymore.
-	PerTableMemoryQuota uint64          `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
-	KVClient            *KVClientConfig `toml:"kv-client" json:"kv-client"`
-	Debug               *DebugConfig    `toml:"debug" json:"debug"`
-	ClusterID           string          `toml:"cluster-id" json:"cluster-id"`
-	MaxMemoryPercentage int             `toml:"max-memory-percentage" json:"max-memory-percentage"`
+	PerTableMemoryQuota uint64                    `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
+	KVClient            *KVClientConfig           `toml:"kv-client" json:"kv-client"`
+	GRPC                *GRPCConfig               `toml:"grpc" json:"grpc"`
+	CheckpointHistory   *CheckpointHistoryConfig  `toml:"checkpoint-history" json:"checkpoint-history"`
+	Preflight           *PreflightConfig          `toml:"preflight" json:"preflight"`
+	ClockSkewGuard      *ClockSkewGuardConfig     `toml:"clock-skew-guard" json:"clock-skew-guard"`
+	GoroutineBudget     *GoroutineBudgetConfig    `toml:"goroutine-budget" json:"goroutine-budget"`
+	MetricsRemoteWrite  *MetricsRemoteWriteConfig `toml:"metrics-remote-write" json:"metrics-remote-write"`
+	EventSampling       *EventSamplingConfig      `toml:"event-sampling" json:"event-sampling"`
+	Debug               *DebugConfig              `toml:"debug" json:"debug"`
+	ClusterID           string                    `toml:"cluster-id" json:"cluster-id"`
+	MaxMemoryPercentage int                       `toml:"max-memory-percentage" json:"max-memory-percentage"`
+	// FederationPeers are the addresses of TiCDC servers in other clusters,
+	// whose changefeeds are aggregated by the federation api.
+	FederationPeers []string `toml:"federation-peers" json:"federation-peers,omitempty"`
+	// Labels are attached to the capture, they are used to match captures in
+	// the table affinity rules of changefeeds.
+	Labels map[string]string `toml:"labels" json:"labels,omitempty"`
 }
 
 // Marshal returns the json marshal format of a ServerConfig
@@ -248,6 +303,9 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 		log.Warn("capture session ttl too small, set to default value 10s")
 		c.CaptureSessionTTL = 10
 	}
+	if c.GracefulShutdownTimeout < 0 {
+		return cerror.ErrInvalidServerOption.GenWithStack("graceful-shutdown-timeout should not be negative")
+	}
 
 	if c.Security != nil && c.Security.IsTLSEnabled() {
 		var err error
@@ -284,6 +342,50 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if err = c.Debug.ValidateAndAdjust(); err != nil {
 		return errors.Trace(err)
 	}
+	if err = c.AdjustGRPCConfig(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.CheckpointHistory == nil {
+		c.CheckpointHistory = defaultCfg.CheckpointHistory
+	}
+	if err = c.CheckpointHistory.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Preflight == nil {
+		c.Preflight = defaultCfg.Preflight
+	}
+	if err = c.Preflight.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.ClockSkewGuard == nil {
+		c.ClockSkewGuard = defaultCfg.ClockSkewGuard
+	}
+	if err = c.ClockSkewGuard.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.GoroutineBudget == nil {
+		c.GoroutineBudget = defaultCfg.GoroutineBudget
+	}
+	if err = c.GoroutineBudget.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.MetricsRemoteWrite == nil {
+		c.MetricsRemoteWrite = defaultCfg.MetricsRemoteWrite
+	}
+	if err = c.MetricsRemoteWrite.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.EventSampling == nil {
+		c.EventSampling = defaultCfg.EventSampling
+	}
+	if err = c.EventSampling.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, peer := range c.FederationPeers {
+		if strings.TrimSpace(peer) == "" {
+			return cerror.ErrInvalidServerOption.GenWithStack("empty federation peer address")
+		}
+	}
 	if c.MaxMemoryPercentage >= 100 {
 		log.Warn("server max-memory-percentage must be less than 100, set to default value")
 		c.MaxMemoryPercentage = DefaultMaxMemoryPercentage
@@ -292,6 +394,19 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	return nil
 }
 
+// AdjustGRPCConfig validates and adjusts the gRPC configs, it must be called
+// after the debug configs are adjusted.
+func (c *ServerConfig) AdjustGRPCConfig() error {
+	if c.GRPC == nil {
+		c.GRPC = GetDefaultServerConfig().GRPC
+	}
+	if err := c.GRPC.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
+	c.GRPC.P2P.inheritMessagesConfig(c.Debug.Messages)
+	return nil
+}
+
 // GetDefaultServerConfig returns the default server config
 func GetDefaultServerConfig() *ServerConfig {
 	return defaultServerConfig.Clone()
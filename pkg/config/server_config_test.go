@@ -75,6 +75,10 @@ func TestServerConfigValidateAndAdjust(t *testing.T) {
 	conf.Debug.Messages.ServerWorkerPoolSize = 0
 	require.Nil(t, conf.ValidateAndAdjust())
 	require.EqualValues(t, GetDefaultServerConfig().Debug.Messages.ServerWorkerPoolSize, conf.Debug.Messages.ServerWorkerPoolSize)
+	conf.FederationPeers = []string{"cdc-east:8300", " "}
+	require.Regexp(t, ".*empty federation peer address", conf.ValidateAndAdjust())
+	conf.FederationPeers = []string{"cdc-east:8300", "https://cdc-west:8300"}
+	require.Nil(t, conf.ValidateAndAdjust())
 }
 
 func TestDBConfigValidateAndAdjust(t *testing.T) {
@@ -88,6 +92,15 @@ func TestDBConfigValidateAndAdjust(t *testing.T) {
 	require.Nil(t, conf.ValidateAndAdjust())
 	conf.Compression = "invalid"
 	require.Error(t, conf.ValidateAndAdjust())
+
+	conf.Compression = "snappy"
+	conf.ValueCompression = "lz4"
+	require.Regexp(t, ".*value-compression must be.*", conf.ValidateAndAdjust())
+	conf.ValueCompression = "zstd"
+	conf.ValueCompressionDictSize = -1
+	require.Regexp(t, ".*value-compression-dict-size must not be negative.*", conf.ValidateAndAdjust())
+	conf.ValueCompressionDictSize = 0
+	require.Nil(t, conf.ValidateAndAdjust())
 }
 
 func TestKVClientConfigValidateAndAdjust(t *testing.T) {
@@ -101,6 +114,96 @@ func TestKVClientConfigValidateAndAdjust(t *testing.T) {
 	require.Error(t, conf.ValidateAndAdjust())
 }
 
+func TestPreflightConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultServerConfig().Clone().Preflight
+
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.MaxClockSkew = 0
+	require.Regexp(t, ".*max-clock-skew must be positive.*", conf.ValidateAndAdjust())
+	conf.MaxClockSkew = TomlDuration(time.Second)
+	conf.PeerDialTimeout = -TomlDuration(time.Second)
+	require.Regexp(t, ".*peer-dial-timeout must be positive.*", conf.ValidateAndAdjust())
+}
+
+func TestClockSkewGuardConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultServerConfig().Clone().ClockSkewGuard
+
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.CheckInterval = 0
+	require.Regexp(t, ".*check-interval must be positive.*", conf.ValidateAndAdjust())
+	conf.CheckInterval = TomlDuration(time.Second)
+	conf.MaxClockSkew = -TomlDuration(time.Second)
+	require.Regexp(t, ".*max-clock-skew must be positive.*", conf.ValidateAndAdjust())
+}
+
+func TestGoroutineBudgetConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultServerConfig().Clone().GoroutineBudget
+
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.CheckInterval = 0
+	require.Regexp(t, ".*check-interval must be positive.*", conf.ValidateAndAdjust())
+	conf.CheckInterval = TomlDuration(time.Second)
+	conf.MaxGoroutinesPerChangefeed = -1
+	require.Regexp(t, ".*must not be negative.*", conf.ValidateAndAdjust())
+}
+
+func TestMetricsRemoteWriteConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultServerConfig().Clone().MetricsRemoteWrite
+
+	// Disabled by default.
+	require.False(t, conf.Enabled())
+	conf.Interval = 0
+	require.Nil(t, conf.ValidateAndAdjust())
+
+	conf.URL = "127.0.0.1:9090/api/v1/write"
+	require.Regexp(t, ".*url must be a valid http.* url.*", conf.ValidateAndAdjust())
+	conf.URL = "http://127.0.0.1:9090/api/v1/write"
+	require.True(t, conf.Enabled())
+	require.Regexp(t, ".*interval must be positive.*", conf.ValidateAndAdjust())
+	conf.Interval = TomlDuration(time.Second)
+	conf.Timeout = 0
+	require.Regexp(t, ".*timeout must be positive.*", conf.ValidateAndAdjust())
+	conf.Timeout = TomlDuration(time.Second)
+	conf.Changefeeds = []string{"default/test", " "}
+	require.Regexp(t, ".*empty changefeed.*", conf.ValidateAndAdjust())
+	conf.Changefeeds = []string{"default/test", "test1"}
+	require.Nil(t, conf.ValidateAndAdjust())
+}
+
+func TestEventSamplingConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultServerConfig().Clone().EventSampling
+
+	// Disabled by default.
+	require.False(t, conf.Enabled())
+	conf.SampleRatio = 0
+	require.Nil(t, conf.ValidateAndAdjust())
+
+	conf.Endpoint = "127.0.0.1:4318"
+	require.Regexp(t, ".*endpoint must be a valid http.* url.*", conf.ValidateAndAdjust())
+	conf.Endpoint = "http://127.0.0.1:4318/"
+	require.True(t, conf.Enabled())
+	require.Regexp(t, ".*sample-ratio must be in.*", conf.ValidateAndAdjust())
+	require.Equal(t, "http://127.0.0.1:4318", conf.Endpoint)
+	conf.SampleRatio = 1.5
+	require.Regexp(t, ".*sample-ratio must be in.*", conf.ValidateAndAdjust())
+	conf.SampleRatio = 0.1
+	conf.MaxSamplesPerFlush = 0
+	require.Regexp(t, ".*max-samples-per-flush must be positive.*", conf.ValidateAndAdjust())
+	conf.MaxSamplesPerFlush = 10
+	conf.FlushInterval = 0
+	require.Regexp(t, ".*flush-interval must be positive.*", conf.ValidateAndAdjust())
+	conf.FlushInterval = TomlDuration(time.Second)
+	conf.Changefeeds = []string{" "}
+	require.Regexp(t, ".*empty changefeed.*", conf.ValidateAndAdjust())
+	conf.Changefeeds = []string{"default/test", "test1"}
+	require.Nil(t, conf.ValidateAndAdjust())
+}
+
 func TestSchedulerConfigValidateAndAdjust(t *testing.T) {
 	t.Parallel()
 	conf := GetDefaultServerConfig().Clone().Debug.Scheduler
@@ -133,6 +236,38 @@ func TestSchedulerConfigValidateAndAdjust(t *testing.T) {
 	conf = GetDefaultServerConfig().Clone().Debug.Scheduler
 	conf.AddTableBatchSize = 0
 	require.Error(t, conf.ValidateAndAdjust())
+
+	conf = GetDefaultServerConfig().Clone().Debug.Scheduler
+	conf.AddTableLatencyTarget = -1
+	require.Error(t, conf.ValidateAndAdjust())
+	conf.AddTableLatencyTarget = TomlDuration(10 * time.Second)
+	require.Nil(t, conf.ValidateAndAdjust())
+
+	conf = GetDefaultServerConfig().Clone().Debug.Scheduler
+	conf.BalanceStrategy = ""
+	require.Nil(t, conf.ValidateAndAdjust())
+	require.Equal(t, BalanceStrategyTableCount, conf.BalanceStrategy)
+	conf.BalanceStrategy = "unknown"
+	require.Regexp(t, ".*balance-strategy must be.*", conf.ValidateAndAdjust())
+	conf.BalanceStrategy = BalanceStrategyLoad
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.LoadBalanceThreshold = 0
+	require.Regexp(t, ".*load-balance-threshold must be.*", conf.ValidateAndAdjust())
+
+	conf = GetDefaultServerConfig().Clone().Debug.Scheduler
+	conf.ScheduleHistorySize = -1
+	require.Regexp(t, ".*schedule-history-size must not be negative.*", conf.ValidateAndAdjust())
+	conf.ScheduleHistorySize = 16
+	require.Nil(t, conf.ValidateAndAdjust())
+
+	conf = GetDefaultServerConfig().Clone().Debug.Scheduler
+	conf.MaxMovesPerTick = -1
+	require.Regexp(t, ".*max-moves-per-tick must not be negative.*", conf.ValidateAndAdjust())
+	conf.MaxMovesPerTick = 4
+	conf.MaxMovesPerCapture = -1
+	require.Regexp(t, ".*max-moves-per-capture must not be negative.*", conf.ValidateAndAdjust())
+	conf.MaxMovesPerCapture = 2
+	require.Nil(t, conf.ValidateAndAdjust())
 }
 
 func TestIsValidClusterID(t *testing.T) {
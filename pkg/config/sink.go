@@ -16,10 +16,13 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/parser/charset"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -154,6 +157,63 @@ type SinkConfig struct {
 	KafkaConfig        *KafkaConfig        `toml:"kafka-config" json:"kafka-config,omitempty"`
 	MySQLConfig        *MySQLConfig        `toml:"mysql-config" json:"mysql-config,omitempty"`
 	CloudStorageConfig *CloudStorageConfig `toml:"cloud-storage-config" json:"cloud-storage-config,omitempty"`
+
+	// SlowStart controls how the sink ramps up after the changefeed is
+	// resumed with a large backlog. It is available for all downstreams.
+	SlowStart *SlowStartConfig `toml:"slow-start" json:"slow-start,omitempty"`
+
+	// ResolvedTsSuppression controls how often the resolved ts events are
+	// sent to the topics. It is only available when the downstream is MQ.
+	ResolvedTsSuppression *ResolvedTsSuppressionConfig `toml:"resolved-ts-suppression" json:"resolved-ts-suppression,omitempty"`
+
+	// TeeSinkURI is the URI of another sink to which all the events are
+	// written as well. The checkpoint of the changefeed only advances after
+	// both sinks acknowledge the events. The protocol and the transaction
+	// atomicity of the tee sink are taken from its own URI.
+	TeeSinkURI *string `toml:"tee-sink-uri" json:"tee-sink-uri,omitempty"`
+
+	// AutoCreateTable makes the sink create missing downstream schemas and
+	// tables from the upstream definition at changefeed start and on CREATE
+	// TABLE DDLs. It is only available when the downstream is DB.
+	AutoCreateTable *bool `toml:"auto-create-table" json:"auto-create-table,omitempty"`
+	// AutoCreateTableConfig controls how tables are created if AutoCreateTable
+	// is enabled.
+	AutoCreateTableConfig *AutoCreateTableConfig `toml:"auto-create-table-config" json:"auto-create-table-config,omitempty"`
+
+	// Routes rename the matched schemas and tables in the sink. Other rules
+	// of the sink, e.g. dispatchers, match the routed names.
+	Routes []*RouteRule `toml:"routes" json:"routes,omitempty"`
+
+	// GeneratedColumnPolicy decides how stored generated columns are written
+	// to the sink, it can be "skip" or "materialize". By default they're
+	// skipped by DB sinks and materialized by other sinks. Values of virtual
+	// generated columns are not stored in TiKV, so they're always skipped.
+	GeneratedColumnPolicy *string `toml:"generated-column-policy" json:"generated-column-policy,omitempty"`
+
+	// BurstAbsorption controls how the sink absorbs sudden upstream write
+	// bursts of tables, e.g. batch jobs. It is available for all downstreams.
+	BurstAbsorption *BurstAbsorptionConfig `toml:"burst-absorption" json:"burst-absorption,omitempty"`
+}
+
+const (
+	// GeneratedColumnPolicySkip omits generated columns in DMLs and payloads,
+	// so that the downstream computes them.
+	GeneratedColumnPolicySkip = "skip"
+	// GeneratedColumnPolicyMaterialize writes values of generated columns as
+	// normal columns, the downstream columns must not be generated columns.
+	GeneratedColumnPolicyMaterialize = "materialize"
+)
+
+// GetGeneratedColumnPolicy returns the generated column policy of a sink
+// with the given scheme, the default policy is returned if it's unset.
+func (s *SinkConfig) GetGeneratedColumnPolicy(scheme string) string {
+	if policy := util.GetOrZero(s.GeneratedColumnPolicy); policy != "" {
+		return strings.ToLower(policy)
+	}
+	if sink.IsMySQLCompatibleScheme(scheme) {
+		return GeneratedColumnPolicySkip
+	}
+	return GeneratedColumnPolicyMaterialize
 }
 
 // CSVConfig defines a series of configuration items for csv codec.
@@ -257,6 +317,59 @@ type DispatchRule struct {
 	// In the future release, the DispatcherRule is expected to be removed .
 	PartitionRule string `toml:"partition" json:"partition"`
 	TopicRule     string `toml:"topic" json:"topic"`
+	// Columns are the columns of the composite partition key, they are
+	// required if the partition rule is "columns".
+	Columns []string `toml:"columns" json:"columns,omitempty"`
+	// IndexName is the index whose values are the partition key if the
+	// partition rule is "table+index", the handle key is used if it's empty.
+	IndexName string `toml:"index" json:"index,omitempty"`
+	// HashFunction is the function to compute partitions from partition keys,
+	// it's one of "default", "murmur2" and "xxhash". murmur2 and xxhash hash
+	// the values of the key columns joined by commas, which is compatible
+	// with the partitioners of Kafka clients.
+	HashFunction string `toml:"hash-function" json:"hash-function,omitempty"`
+	// NoKeyStrategy decides how to dispatch events of the tables without a
+	// primary key or a not null unique key if the partition rule dispatches
+	// events by index values, it's one of "route-by-table", "route-by-all-columns"
+	// and "reject". The default one is "route-by-table".
+	NoKeyStrategy string `toml:"no-key-strategy" json:"no-key-strategy,omitempty"`
+}
+
+func (r *DispatchRule) validate() error {
+	switch strings.ToLower(r.PartitionRule) {
+	case "columns":
+		if len(r.Columns) == 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"columns are required by the columns partition rule, rule: %v", r)
+		}
+	default:
+		if len(r.Columns) != 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"columns are only available for the columns partition rule, rule: %v", r)
+		}
+	}
+	switch strings.ToLower(r.HashFunction) {
+	case "", "default", "murmur2", "xxhash":
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"hash-function should be default, murmur2 or xxhash, but got %s", r.HashFunction)
+	}
+	switch strings.ToLower(r.NoKeyStrategy) {
+	case "":
+	case "route-by-table", "route-by-all-columns", "reject":
+		switch strings.ToLower(r.PartitionRule) {
+		case "index-value", "rowid", "table+index":
+		default:
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"no-key-strategy is only available for the partition rules "+
+					"dispatching events by index values, rule: %v", r)
+		}
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"no-key-strategy should be route-by-table, route-by-all-columns or reject, "+
+				"but got %s", r.NoKeyStrategy)
+	}
+	return nil
 }
 
 // ColumnSelector represents a column selector for a table.
@@ -304,11 +417,14 @@ type KafkaConfig struct {
 	SASLOAuthScopes              []string     `toml:"sasl-oauth-scopes" json:"sasl-oauth-scopes,omitempty"`
 	SASLOAuthGrantType           *string      `toml:"sasl-oauth-grant-type" json:"sasl-oauth-grant-type,omitempty"`
 	SASLOAuthAudience            *string      `toml:"sasl-oauth-audience" json:"sasl-oauth-audience,omitempty"`
+	SASLOAuthTokenProvider       *string      `toml:"sasl-oauth-token-provider" json:"sasl-oauth-token-provider,omitempty"`
+	SASLOAuthAWSRegion           *string      `toml:"sasl-oauth-aws-region" json:"sasl-oauth-aws-region,omitempty"`
 	EnableTLS                    *bool        `toml:"enable-tls" json:"enable-tls,omitempty"`
 	CA                           *string      `toml:"ca" json:"ca,omitempty"`
 	Cert                         *string      `toml:"cert" json:"cert,omitempty"`
 	Key                          *string      `toml:"key" json:"key,omitempty"`
 	InsecureSkipVerify           *bool        `toml:"insecure-skip-verify" json:"insecure-skip-verify,omitempty"`
+	AdaptiveBatching             *bool        `toml:"adaptive-batching" json:"adaptive-batching,omitempty"`
 	CodecConfig                  *CodecConfig `toml:"codec-config" json:"codec-config,omitempty"`
 }
 
@@ -318,6 +434,8 @@ type MySQLConfig struct {
 	MaxTxnRow                    *int    `toml:"max-txn-row" json:"max-txn-row,omitempty"`
 	MaxMultiUpdateRowSize        *int    `toml:"max-multi-update-row-size" json:"max-multi-update-row-size,omitempty"`
 	MaxMultiUpdateRowCount       *int    `toml:"max-multi-update-row" json:"max-multi-update-row,omitempty"`
+	MaxMultiInsertRowCount       *int    `toml:"max-multi-insert-row" json:"max-multi-insert-row,omitempty"`
+	WorkerCountPerTable          *int    `toml:"worker-count-per-table" json:"worker-count-per-table,omitempty"`
 	TiDBTxnMode                  *string `toml:"tidb-txn-mode" json:"tidb-txn-mode,omitempty"`
 	SSLCa                        *string `toml:"ssl-ca" json:"ssl-ca,omitempty"`
 	SSLCert                      *string `toml:"ssl-cert" json:"ssl-cert,omitempty"`
@@ -329,6 +447,58 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `toml:"enable-batch-dml" json:"enable-batch-dml,omitempty"`
 	EnableMultiStatement         *bool   `toml:"enable-multi-statement" json:"enable-multi-statement,omitempty"`
 	EnableCachePreparedStatement *bool   `toml:"enable-cache-prepared-statement" json:"enable-cache-prepared-statement,omitempty"`
+	// UnsupportedDDLPolicy decides how DDLs with constructs the downstream
+	// doesn't support are handled, it can be "fail", "skip" or "translate".
+	UnsupportedDDLPolicy *string `toml:"unsupported-ddl-policy" json:"unsupported-ddl-policy,omitempty"`
+	// AdaptiveTxnRow tunes the max number of rows in a transaction of every
+	// table by the observed commit throughput, max-txn-row is the initial value.
+	AdaptiveTxnRow *bool `toml:"adaptive-txn-row" json:"adaptive-txn-row,omitempty"`
+	// DDLCompatibilityCheck decides whether a DDL is checked against the
+	// downstream schema before it's executed, it can be "none", "warn" or "fail".
+	DDLCompatibilityCheck *string `toml:"ddl-compatibility-check" json:"ddl-compatibility-check,omitempty"`
+
+	// WorkerGroups dedicate workers to matched tables.
+	WorkerGroups []*MySQLWorkerGroup `toml:"worker-groups" json:"worker-groups,omitempty"`
+	// AutoIncrementRules decide how values of auto-increment and auto-random
+	// columns of matched tables are applied.
+	AutoIncrementRules []*MySQLAutoIncrementRule `toml:"auto-increment-rules" json:"auto-increment-rules,omitempty"`
+	// SessionVariables are session variables of the downstream used to write
+	// all tables, for example foreign_key_checks and sql_mode.
+	SessionVariables map[string]string `toml:"session-variables" json:"session-variables,omitempty"`
+	// SessionVariableRules override session variables for matched tables.
+	SessionVariableRules []*MySQLSessionVariableRule `toml:"session-variable-rules" json:"session-variable-rules,omitempty"`
+}
+
+// MySQLWorkerGroup is a group of workers of the MySQL sink dedicated to the
+// matched tables, for example hotspot tables can be given more workers while
+// long-tail tables share the default workers. The first matched group is used
+// for a table. Unset fields fall back to the changefeed level configuration.
+type MySQLWorkerGroup struct {
+	Name        string   `toml:"name" json:"name"`
+	Matcher     []string `toml:"matcher" json:"matcher"`
+	WorkerCount *int     `toml:"worker-count" json:"worker-count,omitempty"`
+	MaxTxnRow   *int     `toml:"max-txn-row" json:"max-txn-row,omitempty"`
+}
+
+// MySQLAutoIncrementRule decides how the MySQL sink applies values of the
+// auto-increment and auto-random columns of the matched tables, so that a
+// downstream which also takes writes doesn't collide with replicated rows.
+// The first matched rule is used for a table.
+type MySQLAutoIncrementRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	// Mode can be "preserve", "strip" or "remap".
+	Mode string `toml:"mode" json:"mode"`
+	// Offset is added to the values in the remap mode.
+	Offset int64 `toml:"offset" json:"offset,omitempty"`
+}
+
+// MySQLSessionVariableRule overrides session variables of the downstream
+// when the MySQL sink writes the matched tables, for example to bypass
+// constraints of some tables only. The first matched rule is used for a table,
+// and variables which aren't set by the rule keep changefeed level values.
+type MySQLSessionVariableRule struct {
+	Matcher   []string          `toml:"matcher" json:"matcher"`
+	Variables map[string]string `toml:"variables" json:"variables"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -336,6 +506,272 @@ type CloudStorageConfig struct {
 	WorkerCount   *int    `toml:"worker-count" json:"worker-count,omitempty"`
 	FlushInterval *string `toml:"flush-interval" json:"flush-interval,omitempty"`
 	FileSize      *int    `toml:"file-size" json:"file-size,omitempty"`
+
+	// FlushPolicies overrides the flush policy of matched tables.
+	FlushPolicies []*CloudStorageFlushPolicy `toml:"flush-policies" json:"flush-policies,omitempty"`
+	// IndexMetadata records the commit ts range and the key range of each
+	// data file in the index file, so that consumers can prune data files
+	// by time range or key prefix.
+	IndexMetadata *bool `toml:"index-metadata" json:"index-metadata,omitempty"`
+}
+
+// CloudStorageFlushPolicy overrides the flush policy of the cloud storage sink
+// for the matched tables, for example low-volume tables can be flushed by
+// interval while high-volume tables are flushed by file size. Unset fields
+// fall back to the changefeed level configuration.
+type CloudStorageFlushPolicy struct {
+	Matcher       []string `toml:"matcher" json:"matcher"`
+	FlushInterval *string  `toml:"flush-interval" json:"flush-interval,omitempty"`
+	FileSize      *int     `toml:"file-size" json:"file-size,omitempty"`
+	// FileRowCount is the max number of rows in a data file, a file is
+	// flushed once it reaches the limit. 0 means no limit.
+	FileRowCount *int `toml:"file-row-count" json:"file-row-count,omitempty"`
+}
+
+// SlowStartConfig represents the slow-start configuration of a sink.
+// When the changefeed starts with a backlog larger than MinBacklog, the sink
+// concurrency and batch size are increased step by step, and are decreased if
+// the downstream reports errors or flushes slower than MaxFlushLatency.
+type SlowStartConfig struct {
+	Enable          *bool   `toml:"enable" json:"enable,omitempty"`
+	StepInterval    *string `toml:"step-interval" json:"step-interval,omitempty"`
+	MaxFlushLatency *string `toml:"max-flush-latency" json:"max-flush-latency,omitempty"`
+	MinBacklog      *string `toml:"min-backlog" json:"min-backlog,omitempty"`
+}
+
+const (
+	// DefaultSlowStartStepInterval is the default interval to adjust the
+	// sink concurrency in slow-start.
+	DefaultSlowStartStepInterval = 30 * time.Second
+	// DefaultSlowStartMaxFlushLatency is the default flush latency above
+	// which slow-start backs off.
+	DefaultSlowStartMaxFlushLatency = 10 * time.Second
+	// DefaultSlowStartMinBacklog is the default backlog which triggers
+	// slow-start.
+	DefaultSlowStartMinBacklog = 10 * time.Minute
+)
+
+// GetStepInterval returns the step interval, or the default one if unset.
+func (c *SlowStartConfig) GetStepInterval() time.Duration {
+	return getDurationOrDefault(c.StepInterval, DefaultSlowStartStepInterval)
+}
+
+// GetMaxFlushLatency returns the max flush latency, or the default one if unset.
+func (c *SlowStartConfig) GetMaxFlushLatency() time.Duration {
+	return getDurationOrDefault(c.MaxFlushLatency, DefaultSlowStartMaxFlushLatency)
+}
+
+// GetMinBacklog returns the min backlog, or the default one if unset.
+func (c *SlowStartConfig) GetMinBacklog() time.Duration {
+	return getDurationOrDefault(c.MinBacklog, DefaultSlowStartMinBacklog)
+}
+
+func getDurationOrDefault(s *string, defaultValue time.Duration) time.Duration {
+	if s == nil {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(*s)
+	if err != nil {
+		return defaultValue
+	}
+	return d
+}
+
+func (c *SlowStartConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, item := range []struct {
+		name  string
+		value *string
+	}{
+		{"step-interval", c.StepInterval},
+		{"max-flush-latency", c.MaxFlushLatency},
+		{"min-backlog", c.MinBacklog},
+	} {
+		if item.value == nil {
+			continue
+		}
+		d, err := time.ParseDuration(*item.value)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if d <= 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"slow-start %s should be positive, but got %s", item.name, *item.value)
+		}
+	}
+	return nil
+}
+
+// BurstAbsorptionConfig represents the burst absorption configuration of a
+// sink. A table is in a burst if the rate of events received from the
+// upstream exceeds both Ratio times its baseline rate and MinEventsPerSecond.
+// Tables in a burst are sinked in a burst-absorption profile, which fetches
+// events from the sorter in bigger batches, advances the resolved ts of the
+// table sink less frequently, and sends larger batches to the sink, all by
+// BatchFactor. The profile is reverted once the rate stays under the
+// threshold for Cooldown.
+type BurstAbsorptionConfig struct {
+	Enable             *bool    `toml:"enable" json:"enable,omitempty"`
+	Ratio              *float64 `toml:"ratio" json:"ratio,omitempty"`
+	MinEventsPerSecond *uint64  `toml:"min-events-per-second" json:"min-events-per-second,omitempty"`
+	Cooldown           *string  `toml:"cooldown" json:"cooldown,omitempty"`
+	BatchFactor        *int     `toml:"batch-factor" json:"batch-factor,omitempty"`
+}
+
+const (
+	// DefaultBurstAbsorptionRatio is the default ratio of the rate to the
+	// baseline rate above which a table is in a burst.
+	DefaultBurstAbsorptionRatio = 4.0
+	// DefaultBurstAbsorptionMinEventsPerSecond is the default rate below
+	// which a table is never in a burst.
+	DefaultBurstAbsorptionMinEventsPerSecond = uint64(1000)
+	// DefaultBurstAbsorptionCooldown is the default duration the rate must
+	// stay under the threshold before a burst ends.
+	DefaultBurstAbsorptionCooldown = 30 * time.Second
+	// DefaultBurstAbsorptionBatchFactor is the default factor by which
+	// batches are enlarged in a burst.
+	DefaultBurstAbsorptionBatchFactor = 4
+)
+
+// GetRatio returns the burst ratio, or the default one if unset.
+func (c *BurstAbsorptionConfig) GetRatio() float64 {
+	if c.Ratio == nil {
+		return DefaultBurstAbsorptionRatio
+	}
+	return *c.Ratio
+}
+
+// GetMinEventsPerSecond returns the min events per second, or the default
+// one if unset.
+func (c *BurstAbsorptionConfig) GetMinEventsPerSecond() uint64 {
+	if c.MinEventsPerSecond == nil {
+		return DefaultBurstAbsorptionMinEventsPerSecond
+	}
+	return *c.MinEventsPerSecond
+}
+
+// GetCooldown returns the cooldown, or the default one if unset.
+func (c *BurstAbsorptionConfig) GetCooldown() time.Duration {
+	return getDurationOrDefault(c.Cooldown, DefaultBurstAbsorptionCooldown)
+}
+
+// GetBatchFactor returns the batch factor, or the default one if unset.
+func (c *BurstAbsorptionConfig) GetBatchFactor() int {
+	if c.BatchFactor == nil {
+		return DefaultBurstAbsorptionBatchFactor
+	}
+	return *c.BatchFactor
+}
+
+func (c *BurstAbsorptionConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Ratio != nil && *c.Ratio <= 1 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"burst-absorption ratio should be larger than 1, but got %v", *c.Ratio)
+	}
+	if c.Cooldown != nil {
+		d, err := time.ParseDuration(*c.Cooldown)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if d <= 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"burst-absorption cooldown should be positive, but got %s", *c.Cooldown)
+		}
+	}
+	if c.BatchFactor != nil && *c.BatchFactor < 1 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"burst-absorption batch-factor should be at least 1, but got %d", *c.BatchFactor)
+	}
+	return nil
+}
+
+// ResolvedTsSuppressionConfig represents the configuration to suppress the
+// resolved ts events of a MQ sink. A resolved ts event is sent to a topic
+// only if MinInterval has elapsed since the last one is sent to the topic,
+// and the resolved ts has advanced by at least MinDelta in physical time.
+// Both of them are zero by default, that is, nothing is suppressed.
+type ResolvedTsSuppressionConfig struct {
+	MinInterval *string `toml:"min-interval" json:"min-interval,omitempty"`
+	MinDelta    *string `toml:"min-delta" json:"min-delta,omitempty"`
+}
+
+// GetMinInterval returns the min interval, or zero if unset.
+func (c *ResolvedTsSuppressionConfig) GetMinInterval() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return getDurationOrDefault(c.MinInterval, 0)
+}
+
+// GetMinDelta returns the min delta, or zero if unset.
+func (c *ResolvedTsSuppressionConfig) GetMinDelta() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return getDurationOrDefault(c.MinDelta, 0)
+}
+
+func (c *ResolvedTsSuppressionConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, item := range []struct {
+		name  string
+		value *string
+	}{
+		{"min-interval", c.MinInterval},
+		{"min-delta", c.MinDelta},
+	} {
+		if item.value == nil {
+			continue
+		}
+		d, err := time.ParseDuration(*item.value)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if d < 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"resolved-ts-suppression %s should not be negative, but got %s",
+				item.name, *item.value)
+		}
+	}
+	return nil
+}
+
+// AutoCreateTableConfig represents how the MySQL sink creates downstream
+// tables from the upstream definition.
+type AutoCreateTableConfig struct {
+	// Engine is the storage engine of created tables, InnoDB is used if unset.
+	Engine *string `toml:"engine" json:"engine,omitempty"`
+	// CharsetMapping maps upstream charsets to the charsets of created
+	// tables. Collations are mapped to the ones of the new charsets with the
+	// same suffix if they exist, or to the default ones.
+	CharsetMapping map[string]string `toml:"charset-mapping" json:"charset-mapping,omitempty"`
+}
+
+var engineNameRe = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+func (c *AutoCreateTableConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Engine != nil && !engineNameRe.MatchString(*c.Engine) {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"auto-create-table-config engine %s is invalid", *c.Engine)
+	}
+	for from, to := range c.CharsetMapping {
+		for _, cs := range []string{from, to} {
+			if _, err := charset.GetCharsetInfo(cs); err != nil {
+				return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+			}
+		}
+	}
+	return nil
 }
 
 func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
@@ -343,6 +779,34 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 		return err
 	}
 
+	if err := s.SlowStart.validate(); err != nil {
+		return err
+	}
+
+	if err := s.ResolvedTsSuppression.validate(); err != nil {
+		return err
+	}
+
+	if err := s.BurstAbsorption.validate(); err != nil {
+		return err
+	}
+
+	if err := s.AutoCreateTableConfig.validate(); err != nil {
+		return err
+	}
+
+	if err := validateRoutes(s.Routes); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(util.GetOrZero(s.GeneratedColumnPolicy)) {
+	case "", GeneratedColumnPolicySkip, GeneratedColumnPolicyMaterialize:
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"invalid generated-column-policy %s, which must be skip or materialize",
+			util.GetOrZero(s.GeneratedColumnPolicy))
+	}
+
 	if sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
 		return nil
 	}
@@ -361,6 +825,9 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 			rule.PartitionRule = rule.DispatcherRule
 			rule.DispatcherRule = ""
 		}
+		if err := rule.validate(); err != nil {
+			return err
+		}
 	}
 
 	if util.GetOrZero(s.EncoderConcurrency) < 0 {
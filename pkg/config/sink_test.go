@@ -16,6 +16,7 @@ package config
 import (
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
@@ -368,3 +369,199 @@ func TestValidateAndAdjustStorageConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 16, util.GetOrZero(s.Sink.FileIndexWidth))
 }
+
+func TestValidateSlowStartConfig(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("mysql://root@127.0.0.1:3306")
+	require.NoError(t, err)
+	s := GetDefaultReplicaConfig()
+	s.Sink.SlowStart = &SlowStartConfig{Enable: util.AddressOf(true)}
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+	require.Equal(t, DefaultSlowStartStepInterval, s.Sink.SlowStart.GetStepInterval())
+	require.Equal(t, DefaultSlowStartMaxFlushLatency, s.Sink.SlowStart.GetMaxFlushLatency())
+	require.Equal(t, DefaultSlowStartMinBacklog, s.Sink.SlowStart.GetMinBacklog())
+
+	s.Sink.SlowStart.StepInterval = util.AddressOf("1m")
+	s.Sink.SlowStart.MinBacklog = util.AddressOf("1h")
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+	require.Equal(t, time.Minute, s.Sink.SlowStart.GetStepInterval())
+	require.Equal(t, time.Hour, s.Sink.SlowStart.GetMinBacklog())
+
+	s.Sink.SlowStart.MaxFlushLatency = util.AddressOf("abc")
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	s.Sink.SlowStart.MaxFlushLatency = util.AddressOf("-1s")
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+}
+
+func TestValidateBurstAbsorptionConfig(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("mysql://root@127.0.0.1:3306")
+	require.NoError(t, err)
+	s := GetDefaultReplicaConfig()
+	s.Sink.BurstAbsorption = &BurstAbsorptionConfig{Enable: util.AddressOf(true)}
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+	require.Equal(t, DefaultBurstAbsorptionRatio, s.Sink.BurstAbsorption.GetRatio())
+	require.Equal(t, DefaultBurstAbsorptionMinEventsPerSecond,
+		s.Sink.BurstAbsorption.GetMinEventsPerSecond())
+	require.Equal(t, DefaultBurstAbsorptionCooldown, s.Sink.BurstAbsorption.GetCooldown())
+	require.Equal(t, DefaultBurstAbsorptionBatchFactor, s.Sink.BurstAbsorption.GetBatchFactor())
+
+	s.Sink.BurstAbsorption.Cooldown = util.AddressOf("1m")
+	s.Sink.BurstAbsorption.BatchFactor = util.AddressOf(8)
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+	require.Equal(t, time.Minute, s.Sink.BurstAbsorption.GetCooldown())
+	require.Equal(t, 8, s.Sink.BurstAbsorption.GetBatchFactor())
+
+	s.Sink.BurstAbsorption.Ratio = util.AddressOf(1.0)
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	s.Sink.BurstAbsorption.Ratio = nil
+	s.Sink.BurstAbsorption.Cooldown = util.AddressOf("0s")
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	s.Sink.BurstAbsorption.Cooldown = nil
+	s.Sink.BurstAbsorption.BatchFactor = util.AddressOf(0)
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+}
+
+func TestValidateResolvedTsSuppressionConfig(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=canal-json&enable-tidb-extension=true")
+	require.NoError(t, err)
+	s := GetDefaultReplicaConfig()
+	require.Zero(t, s.Sink.ResolvedTsSuppression.GetMinInterval())
+	require.Zero(t, s.Sink.ResolvedTsSuppression.GetMinDelta())
+
+	s.Sink.ResolvedTsSuppression = &ResolvedTsSuppressionConfig{
+		MinInterval: util.AddressOf("30s"),
+	}
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+	require.Equal(t, 30*time.Second, s.Sink.ResolvedTsSuppression.GetMinInterval())
+	require.Zero(t, s.Sink.ResolvedTsSuppression.GetMinDelta())
+
+	s.Sink.ResolvedTsSuppression.MinDelta = util.AddressOf("abc")
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	s.Sink.ResolvedTsSuppression.MinDelta = util.AddressOf("-1s")
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+}
+
+func TestValidateAutoCreateTableConfig(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	s := GetDefaultReplicaConfig()
+	s.Sink.AutoCreateTable = util.AddressOf(true)
+	s.Sink.AutoCreateTableConfig = &AutoCreateTableConfig{
+		Engine:         util.AddressOf("RocksDB"),
+		CharsetMapping: map[string]string{"utf8mb4": "utf8"},
+	}
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+
+	s.Sink.AutoCreateTableConfig.Engine = util.AddressOf("InnoDB; DROP TABLE t")
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	s.Sink.AutoCreateTableConfig.Engine = nil
+	s.Sink.AutoCreateTableConfig.CharsetMapping["utf8mb4"] = "unknown"
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+}
+
+func TestValidateDispatchRules(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=open-protocol")
+	require.NoError(t, err)
+	s := GetDefaultReplicaConfig()
+	s.Sink.DispatchRules = []*DispatchRule{
+		{Matcher: []string{"test.t1"}, PartitionRule: "columns", Columns: []string{"a", "b"}},
+		{Matcher: []string{"test.t2"}, DispatcherRule: "table+index", IndexName: "idx_a"},
+		{Matcher: []string{"test.*"}, PartitionRule: "table", HashFunction: "MURMUR2"},
+	}
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+	require.Equal(t, "table+index", s.Sink.DispatchRules[1].PartitionRule)
+
+	// columns are required by the columns partition rule.
+	s.Sink.DispatchRules[0].Columns = nil
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	// columns are only available for the columns partition rule.
+	s.Sink.DispatchRules[0].Columns = []string{"a"}
+	s.Sink.DispatchRules[0].PartitionRule = "index-value"
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+
+	s.Sink.DispatchRules[0].Columns = nil
+	s.Sink.DispatchRules[2].HashFunction = "crc32"
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+
+	s.Sink.DispatchRules[2].HashFunction = ""
+	s.Sink.DispatchRules[1].NoKeyStrategy = "Reject"
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+	s.Sink.DispatchRules[1].NoKeyStrategy = "ignore"
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	// the no-key strategy is only available for the index-value partition rules.
+	s.Sink.DispatchRules[1].NoKeyStrategy = ""
+	s.Sink.DispatchRules[2].NoKeyStrategy = "route-by-all-columns"
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+}
+
+func TestValidateRoutes(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	s := GetDefaultReplicaConfig()
+	s.Sink.Routes = []*RouteRule{
+		{Matcher: []string{"app.users"}, TargetSchema: "app_shadow", TargetTable: "users_v2"},
+		{Matcher: []string{"app.*"}, TargetSchema: "app_shadow"},
+		{Matcher: []string{"log_*.*"}, TargetTable: "{schema}_{table}"},
+	}
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+
+	schema, table := s.Sink.Routes[2].Target("log_1", "t")
+	require.Equal(t, "log_1", schema)
+	require.Equal(t, "log_1_t", table)
+	schema, table = s.Sink.Routes[1].Target("app", "")
+	require.Equal(t, "app_shadow", schema)
+	require.Equal(t, "", table)
+
+	// the targets can not be both empty.
+	s.Sink.Routes[1].TargetSchema = ""
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	// the target schema can not contain the table placeholder.
+	s.Sink.Routes[1].TargetSchema = "{table}"
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	s.Sink.Routes[1].TargetSchema = "{db}"
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	s.Sink.Routes[1].TargetSchema = "app_shadow"
+	s.Sink.Routes[0].Matcher = nil
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+	s.Sink.Routes[0].Matcher = []string{"app.users"}
+
+	// two rules route tables to the same table.
+	s.Sink.Routes = append(s.Sink.Routes, &RouteRule{
+		Matcher: []string{"app2.users"}, TargetSchema: "App_Shadow", TargetTable: "Users_V2",
+	})
+	require.Regexp(t, "routes conflict", s.ValidateAndAdjust(sinkURI))
+
+	// the target is routed again.
+	s.Sink.Routes[3] = &RouteRule{
+		Matcher: []string{"app2.users"}, TargetSchema: "app", TargetTable: "orders",
+	}
+	require.Regexp(t, "routes cycle", s.ValidateAndAdjust(sinkURI))
+}
+
+func TestGeneratedColumnPolicy(t *testing.T) {
+	t.Parallel()
+
+	s := GetDefaultReplicaConfig()
+	require.Equal(t, GeneratedColumnPolicySkip, s.Sink.GetGeneratedColumnPolicy("mysql"))
+	require.Equal(t, GeneratedColumnPolicyMaterialize, s.Sink.GetGeneratedColumnPolicy("kafka"))
+
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/abc?protocol=canal-json")
+	require.NoError(t, err)
+	s.Sink.GeneratedColumnPolicy = util.AddressOf("Skip")
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+	require.Equal(t, GeneratedColumnPolicySkip, s.Sink.GetGeneratedColumnPolicy("kafka"))
+
+	s.Sink.GeneratedColumnPolicy = util.AddressOf("compute")
+	require.Regexp(t, "ErrSinkInvalidConfig", s.ValidateAndAdjust(sinkURI))
+}
@@ -15,10 +15,15 @@ package config
 
 import (
 	"math"
+	"time"
 
 	"github.com/pingcap/tiflow/pkg/errors"
 )
 
+// defaultSorterIODeadline is the default max time that a sorter I/O waits
+// for the budget if I/O scheduling is enabled.
+const defaultSorterIODeadline = time.Second
+
 // SorterConfig represents sorter config for a changefeed
 type SorterConfig struct {
 	// the directory used to store the temporary files generated by the sorter
@@ -27,6 +32,21 @@ type SorterConfig struct {
 	// Cache size of sorter in MB.
 	CacheSizeInMB uint64 `toml:"cache-size-in-mb" json:"cache-size-in-mb"`
 
+	// ChangefeedDiskQuotaInMB limits the size of sorted but unconsumed data
+	// of every changefeed in the shared sorter storage. 0 means no limit.
+	ChangefeedDiskQuotaInMB uint64 `toml:"changefeed-disk-quota-in-mb" json:"changefeed-disk-quota-in-mb"`
+	// ChangefeedWriteRateInMB limits the sorter write rate of every
+	// changefeed in MB per second. 0 means no limit.
+	ChangefeedWriteRateInMB uint64 `toml:"changefeed-write-rate-in-mb" json:"changefeed-write-rate-in-mb"`
+
+	// IOBudgetPerTickInMB is the size of events in MB that can be read from
+	// or written into a sorter DB every 100ms. The budget is shared by all
+	// tables in the DB fairly, so that a table scanning a huge amount of
+	// events can't starve other tables. 0 disables I/O scheduling.
+	IOBudgetPerTickInMB uint64 `toml:"io-budget-per-tick-in-mb" json:"io-budget-per-tick-in-mb"`
+	// IODeadline is the max time that a sorter I/O waits for the budget.
+	IODeadline TomlDuration `toml:"io-deadline" json:"io-deadline"`
+
 	// the maximum memory use percentage that allows in-memory sorting
 	// Deprecated: use CacheSizeInMB instead.
 	MaxMemoryPercentage int `toml:"max-memory-percentage" json:"max-memory-percentage"`
@@ -50,5 +70,20 @@ func (c *SorterConfig) ValidateAndAdjust() error {
 	if c.CacheSizeInMB < 8 || c.CacheSizeInMB*uint64(1<<20) > uint64(math.MaxInt64) {
 		return errors.ErrIllegalSorterParameter.GenWithStackByArgs("cache-size-in-mb should be greater than 8(MB)")
 	}
+	if c.ChangefeedDiskQuotaInMB*uint64(1<<20) > uint64(math.MaxInt64) {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs("changefeed-disk-quota-in-mb is too large")
+	}
+	if c.ChangefeedWriteRateInMB*uint64(1<<20) > uint64(math.MaxInt64) {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs("changefeed-write-rate-in-mb is too large")
+	}
+	if c.IOBudgetPerTickInMB*uint64(1<<20) > uint64(math.MaxInt64) {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs("io-budget-per-tick-in-mb is too large")
+	}
+	if c.IODeadline < 0 {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs("io-deadline should not be negative")
+	}
+	if c.IOBudgetPerTickInMB > 0 && c.IODeadline == 0 {
+		c.IODeadline = TomlDuration(defaultSorterIODeadline)
+	}
 	return nil
 }
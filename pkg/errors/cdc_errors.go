@@ -42,6 +42,10 @@ var (
 		"scheduler request failed, %s",
 		errors.RFCCodeText("CDC:ErrSchedulerRequestFailed"),
 	)
+	ErrSchedulerSnapshotInvalid = errors.Normalize(
+		"invalid scheduler snapshot, %s",
+		errors.RFCCodeText("CDC:ErrSchedulerSnapshotInvalid"),
+	)
 	ErrGetAllStoresFailed = errors.Normalize(
 		"get stores from pd failed",
 		errors.RFCCodeText("CDC:ErrGetAllStoresFailed"),
@@ -203,6 +207,66 @@ var (
 		"kafka config item not found",
 		errors.RFCCodeText("CDC:ErrKafkaConfigNotFound"),
 	)
+	ErrMQTTNewClient = errors.Normalize(
+		"new mqtt client",
+		errors.RFCCodeText("CDC:ErrMQTTNewClient"),
+	)
+	ErrMQTTInvalidConfig = errors.Normalize(
+		"mqtt config invalid",
+		errors.RFCCodeText("CDC:ErrMQTTInvalidConfig"),
+	)
+	ErrMQTTProducerClosed = errors.Normalize(
+		"mqtt producer closed",
+		errors.RFCCodeText("CDC:ErrMQTTProducerClosed"),
+	)
+	ErrMQTTSendMessage = errors.Normalize(
+		"mqtt send message failed",
+		errors.RFCCodeText("CDC:ErrMQTTSendMessage"),
+	)
+	ErrMQTTMessageTooLarge = errors.Normalize(
+		"mqtt message of %d bytes exceeds the limit %d bytes",
+		errors.RFCCodeText("CDC:ErrMQTTMessageTooLarge"),
+	)
+	ErrNatsNewClient = errors.Normalize(
+		"new nats client",
+		errors.RFCCodeText("CDC:ErrNatsNewClient"),
+	)
+	ErrNatsInvalidConfig = errors.Normalize(
+		"nats config invalid",
+		errors.RFCCodeText("CDC:ErrNatsInvalidConfig"),
+	)
+	ErrNatsProducerClosed = errors.Normalize(
+		"nats producer closed",
+		errors.RFCCodeText("CDC:ErrNatsProducerClosed"),
+	)
+	ErrNatsSendMessage = errors.Normalize(
+		"nats send message failed",
+		errors.RFCCodeText("CDC:ErrNatsSendMessage"),
+	)
+	ErrNatsStreamNotFound = errors.Normalize(
+		"no jetstream stream captures subject %s",
+		errors.RFCCodeText("CDC:ErrNatsStreamNotFound"),
+	)
+	ErrRabbitMQNewClient = errors.Normalize(
+		"new rabbitmq client",
+		errors.RFCCodeText("CDC:ErrRabbitMQNewClient"),
+	)
+	ErrRabbitMQInvalidConfig = errors.Normalize(
+		"rabbitmq config invalid",
+		errors.RFCCodeText("CDC:ErrRabbitMQInvalidConfig"),
+	)
+	ErrRabbitMQProducerClosed = errors.Normalize(
+		"rabbitmq producer closed",
+		errors.RFCCodeText("CDC:ErrRabbitMQProducerClosed"),
+	)
+	ErrRabbitMQSendMessage = errors.Normalize(
+		"rabbitmq send message failed",
+		errors.RFCCodeText("CDC:ErrRabbitMQSendMessage"),
+	)
+	ErrRabbitMQExchangeNotFound = errors.Normalize(
+		"rabbitmq exchange %s not found",
+		errors.RFCCodeText("CDC:ErrRabbitMQExchangeNotFound"),
+	)
 	ErrRedoConfigInvalid = errors.Normalize(
 		"redo log config invalid",
 		errors.RFCCodeText("CDC:ErrRedoConfigInvalid"),
@@ -276,6 +340,26 @@ var (
 		"MySQL worker panic",
 		errors.RFCCodeText("CDC:ErrMySQLWorkerPanic"),
 	)
+	ErrMySQLNoAvailableEndpoint = errors.Normalize(
+		"no writable MySQL endpoint is available in %v",
+		errors.RFCCodeText("CDC:ErrMySQLNoAvailableEndpoint"),
+	)
+	ErrMySQLEndpointUnhealthy = errors.Normalize(
+		"MySQL endpoint %s is unhealthy or read-only, failover to %s",
+		errors.RFCCodeText("CDC:ErrMySQLEndpointUnhealthy"),
+	)
+	ErrDownstreamSchemaDrift = errors.Normalize(
+		"downstream schema drifts from upstream: %s",
+		errors.RFCCodeText("CDC:ErrDownstreamSchemaDrift"),
+	)
+	ErrSinkRouteConflict = errors.Normalize(
+		"tables %s and %s are both routed to %s",
+		errors.RFCCodeText("CDC:ErrSinkRouteConflict"),
+	)
+	ErrDDLIncompatibleWithDownstream = errors.Normalize(
+		"DMLs after DDL %s are not applicable to downstream: %s",
+		errors.RFCCodeText("CDC:ErrDDLIncompatibleWithDownstream"),
+	)
 	ErrAvroToEnvelopeError = errors.Normalize(
 		"to envelope failed",
 		errors.RFCCodeText("CDC:ErrAvroToEnvelopeError"),
@@ -360,6 +444,26 @@ var (
 		"filename in storage sink is invalid",
 		errors.RFCCodeText("CDC:ErrStorageSinkInvalidFileName"),
 	)
+	ErrStorageSinkInvalidIndexFile = errors.Normalize(
+		"index file in storage sink is invalid",
+		errors.RFCCodeText("CDC:ErrStorageSinkInvalidIndexFile"),
+	)
+	ErrSubscriptionAborted = errors.Normalize(
+		"subscription of changefeed %s is aborted because %s",
+		errors.RFCCodeText("CDC:ErrSubscriptionAborted"),
+	)
+	ErrSubscriptionClosed = errors.Normalize(
+		"sink of changefeed %s is closed",
+		errors.RFCCodeText("CDC:ErrSubscriptionClosed"),
+	)
+	ErrSubscriptionLagged = errors.Normalize(
+		"subscriber of changefeed %s lags behind the retained history",
+		errors.RFCCodeText("CDC:ErrSubscriptionLagged"),
+	)
+	ErrSubscriptionStartTsTooOld = errors.Normalize(
+		"start ts %d is less than %d, the events before it are not retained",
+		errors.RFCCodeText("CDC:ErrSubscriptionStartTsTooOld"),
+	)
 
 	// utilities related errors
 	ErrToTLSConfigFailed = errors.Normalize(
@@ -405,6 +509,9 @@ var (
 	ErrDiskFull = errors.Normalize(
 		"failed to preallocate file because disk is full",
 		errors.RFCCodeText("CDC:ErrDiskFull"))
+	ErrLocalStorageDiskCapacity = errors.Normalize(
+		"the available disk space of %s is %.2f%%, less than the threshold %.2f%%",
+		errors.RFCCodeText("CDC:ErrLocalStorageDiskCapacity"))
 	ErrWaitFreeMemoryTimeout = errors.Normalize(
 		"wait free memory timeout",
 		errors.RFCCodeText("CDC:ErrWaitFreeMemoryTimeout"),
@@ -528,18 +635,46 @@ var (
 		"owner running unknown error",
 		errors.RFCCodeText("CDC:ErrOwnerUnknown"),
 	)
+	ErrTableNotReplicated = errors.Normalize(
+		"table %d is not replicated by changefeed %s",
+		errors.RFCCodeText("CDC:ErrTableNotReplicated"),
+	)
+	ErrTableNotPaused = errors.Normalize(
+		"table %d of changefeed %s is not paused",
+		errors.RFCCodeText("CDC:ErrTableNotPaused"),
+	)
+	ErrTableResetTsInvalid = errors.Normalize(
+		"reset ts %d of table %d is smaller than the checkpoint ts %d of changefeed %s",
+		errors.RFCCodeText("CDC:ErrTableResetTsInvalid"),
+	)
 	ErrProcessorTableNotFound = errors.Normalize(
 		"table not found in processor cache",
 		errors.RFCCodeText("CDC:ErrProcessorTableNotFound"),
 	)
+	ErrProcessorGoroutineBudgetExceeded = errors.Normalize(
+		"processor uses %d goroutines, exceeding the budget %d",
+		errors.RFCCodeText("CDC:ErrProcessorGoroutineBudgetExceeded"),
+	)
 	ErrInvalidServerOption = errors.Normalize(
 		"invalid server option",
 		errors.RFCCodeText("CDC:ErrInvalidServerOption"),
 	)
+	ErrPreflightCheckFailed = errors.Normalize(
+		"preflight checks failed: %s",
+		errors.RFCCodeText("CDC:ErrPreflightCheckFailed"),
+	)
 	ErrServeHTTP = errors.Normalize(
 		"serve http error",
 		errors.RFCCodeText("CDC:ErrServeHTTP"),
 	)
+	ErrMetricsRemoteWrite = errors.Normalize(
+		"failed to push metrics to remote write endpoint %s: %s",
+		errors.RFCCodeText("CDC:ErrMetricsRemoteWrite"),
+	)
+	ErrEventSamplingExport = errors.Normalize(
+		"failed to export sampled events to %s: %s",
+		errors.RFCCodeText("CDC:ErrEventSamplingExport"),
+	)
 	ErrCaptureCampaignOwner = errors.Normalize(
 		"campaign owner failed",
 		errors.RFCCodeText("CDC:ErrCaptureCampaignOwner"),
@@ -572,6 +707,11 @@ var (
 		"changefeed update error: %s",
 		errors.RFCCodeText("CDC:ErrChangefeedUpdateRefused"),
 	)
+	ErrChangefeedFeatureUnsupported = errors.Normalize(
+		"feature %s is not supported by capture %s of version %s, "+
+			"the feature requires captures of version %s or later",
+		errors.RFCCodeText("CDC:ErrChangefeedFeatureUnsupported"),
+	)
 	ErrChangefeedUpdateFailedTransaction = errors.Normalize(
 		"changefeed update failed due to unexpected etcd transaction failure: %s",
 		errors.RFCCodeText("CDC:ErrChangefeedUpdateFailed"),
@@ -594,6 +734,19 @@ var (
 			" caused by GC. checkpoint-ts %d is earlier than or equal to GC safepoint at %d",
 		errors.RFCCodeText("CDC:ErrSnapshotLostByGC"),
 	)
+	ErrSafePointBeforeGC = errors.Normalize(
+		"fail to register safepoint because safepoint %d "+
+			"is earlier than or equal to GC safepoint at %d",
+		errors.RFCCodeText("CDC:ErrSafePointBeforeGC"),
+	)
+	ErrSafePointLeaseNotFound = errors.Normalize(
+		"safepoint lease of service %s is not found in changefeed %s",
+		errors.RFCCodeText("CDC:ErrSafePointLeaseNotFound"),
+	)
+	ErrInvalidSafePointLease = errors.Normalize(
+		"invalid safepoint lease: %s",
+		errors.RFCCodeText("CDC:ErrInvalidSafePointLease"),
+	)
 	ErrNotOwner = errors.Normalize(
 		"this capture is not a owner",
 		errors.RFCCodeText("CDC:ErrNotOwner"),
@@ -607,6 +760,12 @@ var (
 			"if you want to ignore these tables, please set ignore_ineligible_table to true",
 		errors.RFCCodeText("CDC:ErrTableIneligible"),
 	)
+	ErrTableWithoutDispatchKey = errors.Normalize(
+		"some tables have neither a primary key nor a not null unique key "+
+			"to dispatch events by index values(%v), please add keys to these tables "+
+			"or change the no-key-strategy of the dispatch rules",
+		errors.RFCCodeText("CDC:ErrTableWithoutDispatchKey"),
+	)
 	ErrInvalidCheckpointTs = errors.Normalize(
 		"checkpointTs(%v) should not larger than resolvedTs(%v)",
 		errors.RFCCodeText("CDC:ErrInvalidCheckpointTs"),
@@ -699,6 +858,9 @@ var (
 	ErrReachMaxTry = errors.Normalize("reach maximum try: %s, error: %s",
 		errors.RFCCodeText("CDC:ErrReachMaxTry"),
 	)
+	ErrRetryBudgetExhausted = errors.Normalize("retry budget is exhausted, error: %s",
+		errors.RFCCodeText("CDC:ErrRetryBudgetExhausted"),
+	)
 
 	// tcp server error
 	ErrTCPServerClosed = errors.Normalize("The TCP server has been closed",
@@ -771,6 +933,14 @@ var (
 		"peer-to-peer message server injected error",
 		errors.RFCCodeText("CDC:ErrPeerMessageInjectedServerRestart"),
 	)
+	ErrPeerMessageChecksumMismatch = errors.Normalize(
+		"peer-to-peer message checksum mismatch, topic: %s, seq: %d, expected: %d, actual: %d",
+		errors.RFCCodeText("CDC:ErrPeerMessageChecksumMismatch"),
+	)
+	ErrPeerMessageSequenceGap = errors.Normalize(
+		"peer-to-peer message sequence gap, topic: %s, expected seq: %d, actual seq: %d",
+		errors.RFCCodeText("CDC:ErrPeerMessageSequenceGap"),
+	)
 
 	// RESTful client error
 	ErrRewindRequestBodyError = errors.Normalize(
@@ -799,6 +969,11 @@ var (
 		"upstream has been closed",
 		errors.RFCCodeText("CDC:ErrUpstreamClosed"),
 	)
+	ErrUpstreamRewound = errors.Normalize(
+		"upstream is rewound, %s. The replicated data may be ahead of the upstream, "+
+			"please verify the downstream and re-anchor the changefeed at a new start ts",
+		errors.RFCCodeText("CDC:ErrUpstreamRewound"),
+	)
 	ErrUpstreamHasRunningImport = errors.Normalize(
 		"upstream has running import tasks, upstream-id: %d",
 		errors.RFCCodeText("CDC:ErrUpstreamHasRunningImport"),
@@ -838,6 +1013,10 @@ var (
 		"command '%s' is aborted by user",
 		errors.RFCCodeText("CDC:ErrCliAborted"),
 	)
+	ErrSyncPointNotFound = errors.Normalize(
+		"syncpoint of changefeed %s is not found in the downstream",
+		errors.RFCCodeText("CDC:ErrSyncPointNotFound"),
+	)
 	// Filter error
 	ErrFailedToFilterDML = errors.Normalize(
 		"failed to filter dml event: %v, please report a bug",
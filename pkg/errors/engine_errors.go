@@ -370,6 +370,14 @@ var (
 		"job %s is not running",
 		errors.RFCCodeText("DFLOW:ErrJobNotRunning"),
 	)
+	ErrJobDependencyCycle = errors.Normalize(
+		"dependency from job %s to job %s forms a cycle",
+		errors.RFCCodeText("DFLOW:ErrJobDependencyCycle"),
+	)
+	ErrJobDependencyNotFound = errors.Normalize(
+		"dependency from job %s to job %s is not found",
+		errors.RFCCodeText("DFLOW:ErrJobDependencyNotFound"),
+	)
 
 	// metastore related errors
 	ErrMetaStoreNotExists = errors.Normalize(
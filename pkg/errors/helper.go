@@ -82,6 +82,8 @@ var changefeedUnRetryableErrors = []*errors.Error{
 	ErrKafkaInvalidConfig,
 	ErrMySQLInvalidConfig,
 	ErrStorageSinkInvalidConfig,
+	ErrTableWithoutDispatchKey,
+	ErrSinkRouteConflict,
 }
 
 // IsChangefeedUnRetryableError returns true if an error is a changefeed not retry error.
@@ -175,6 +177,8 @@ var httpStatusCodeMapping = map[errors.RFCErrorCode]int{
 	ErrJobAlreadyCanceled.RFCCode():    http.StatusBadRequest,
 	ErrJobNotTerminated.RFCCode():      http.StatusBadRequest,
 	ErrJobNotRunning.RFCCode():         http.StatusBadRequest,
+	ErrJobDependencyCycle.RFCCode():    http.StatusBadRequest,
+	ErrJobDependencyNotFound.RFCCode(): http.StatusNotFound,
 	ErrMetaStoreNotExists.RFCCode():    http.StatusNotFound,
 	ErrResourceAlreadyExists.RFCCode(): http.StatusConflict,
 	ErrIllegalResourcePath.RFCCode():   http.StatusBadRequest,
@@ -217,6 +221,8 @@ var gRPCStatusCodeMapping = map[errors.RFCErrorCode]codes.Code{
 	ErrJobAlreadyCanceled.RFCCode():    codes.FailedPrecondition,
 	ErrJobNotTerminated.RFCCode():      codes.FailedPrecondition,
 	ErrJobNotRunning.RFCCode():         codes.FailedPrecondition,
+	ErrJobDependencyCycle.RFCCode():    codes.FailedPrecondition,
+	ErrJobDependencyNotFound.RFCCode(): codes.NotFound,
 	ErrMetaStoreNotExists.RFCCode():    codes.NotFound,
 	ErrResourceAlreadyExists.RFCCode(): codes.AlreadyExists,
 	ErrIllegalResourcePath.RFCCode():   codes.InvalidArgument,
@@ -15,6 +15,7 @@ package etcd
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/benbjohnson/clock"
@@ -91,6 +92,8 @@ func retryRPC(rpcName string, metric prometheus.Counter, etcdRPC func() error) e
 	// Retry at least two election timeout to handle the case that two PDs restarted
 	// (the first election maybe failed).
 	// 16s = \sum_{n=0}^{6} 0.5*1.5^n
+	// The backoff is deterministic and retries are not bounded by a budget
+	// shared with other RPCs, otherwise the retry time can't be guaranteed.
 	return retry.Do(context.Background(), func() error {
 		err := etcdRPC()
 		if err != nil && errors.Cause(err) != context.Canceled {
@@ -100,7 +103,8 @@ func retryRPC(rpcName string, metric prometheus.Counter, etcdRPC func() error) e
 			metric.Inc()
 		}
 		return err
-	}, retry.WithBackoffBaseDelay(backoffBaseDelayInMs),
+	}, retry.WithName("etcd_"+strings.ToLower(rpcName)),
+		retry.WithBackoffBaseDelay(backoffBaseDelayInMs),
 		retry.WithBackoffMaxDelay(backoffMaxDelayInMs),
 		retry.WithMaxTries(maxTries),
 		retry.WithIsRetryableErr(isRetryableError(rpcName)))
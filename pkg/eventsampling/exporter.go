@@ -0,0 +1,339 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsampling
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+const (
+	logsPath    = "/v1/logs"
+	metricsPath = "/v1/metrics"
+
+	opInsert = "insert"
+	opUpdate = "update"
+	opDelete = "delete"
+
+	// maxErrorBodySize is the max size of the response body read to
+	// report a failed export.
+	maxErrorBodySize = 512
+)
+
+var globalExporter atomic.Pointer[Exporter]
+
+// SetGlobalExporter sets the exporter of this capture, nil means the
+// event sampling is disabled.
+func SetGlobalExporter(e *Exporter) {
+	globalExporter.Store(e)
+}
+
+// GetGlobalExporter returns the exporter of this capture, it's nil if the
+// event sampling is disabled.
+func GetGlobalExporter() *Exporter {
+	return globalExporter.Load()
+}
+
+type tableKey struct {
+	changefeed model.ChangeFeedID
+	schema     string
+	table      string
+}
+
+type tableStats struct {
+	inserts, updates, deletes uint64
+	bytes                     uint64
+	maxCommitTs               model.Ts
+}
+
+// Exporter samples the change events and exports the samples as OTLP logs,
+// the volume and freshness of all the events are exported as OTLP metrics
+// per table. Only the metadata of events is exported.
+type Exporter struct {
+	cfg    *config.EventSamplingConfig
+	client *http.Client
+	scope  scope
+	// changefeeds are the changefeeds whose events are sampled,
+	// nil means all changefeeds.
+	changefeeds map[model.ChangeFeedID]struct{}
+	startTime   time.Time
+
+	mu      sync.Mutex
+	rand    *rand.Rand
+	tables  map[tableKey]*tableStats
+	samples []logRecord
+	dropped uint64
+}
+
+// NewExporter creates an Exporter, instance is attached to all logs and
+// metrics as the "service.instance.id" resource attribute.
+func NewExporter(cfg *config.EventSamplingConfig, instance string) *Exporter {
+	var changefeeds map[model.ChangeFeedID]struct{}
+	if len(cfg.Changefeeds) != 0 {
+		changefeeds = make(map[model.ChangeFeedID]struct{}, len(cfg.Changefeeds))
+		for _, cf := range cfg.Changefeeds {
+			changefeeds[parseChangefeedID(cf)] = struct{}{}
+		}
+	}
+	return &Exporter{
+		cfg:    cfg,
+		client: &http.Client{Timeout: time.Duration(cfg.Timeout)},
+		scope: scope{
+			resource: []attribute{
+				{key: "service.name", value: "ticdc"},
+				{key: "service.instance.id", value: instance},
+				{key: "service.version", value: version.ReleaseVersion},
+			},
+			name:    "github.com/pingcap/tiflow/pkg/eventsampling",
+			version: version.ReleaseVersion,
+		},
+		changefeeds: changefeeds,
+		startTime:   time.Now(),
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		tables:      make(map[tableKey]*tableStats),
+	}
+}
+
+// Accept returns true if the events of the changefeed are sampled.
+func (e *Exporter) Accept(changefeedID model.ChangeFeedID) bool {
+	if e.changefeeds == nil {
+		return true
+	}
+	_, ok := e.changefeeds[changefeedID]
+	return ok
+}
+
+// Observe counts the events, and samples some of them.
+func (e *Exporter) Observe(changefeedID model.ChangeFeedID, rows ...*model.RowChangedEvent) {
+	if len(rows) == 0 {
+		return
+	}
+	now := uint64(time.Now().UnixNano())
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var (
+		stats   *tableStats
+		lastKey tableKey
+	)
+	for _, row := range rows {
+		key := tableKey{changefeed: changefeedID, schema: row.Table.Schema, table: row.Table.Table}
+		if stats == nil || key != lastKey {
+			lastKey = key
+			stats = e.tables[key]
+			if stats == nil {
+				stats = &tableStats{}
+				e.tables[key] = stats
+			}
+		}
+		op := opUpdate
+		switch {
+		case row.IsInsert():
+			op = opInsert
+			stats.inserts++
+		case row.IsDelete():
+			op = opDelete
+			stats.deletes++
+		default:
+			stats.updates++
+		}
+		size := row.ApproximateBytes()
+		stats.bytes += uint64(size)
+		if row.CommitTs > stats.maxCommitTs {
+			stats.maxCommitTs = row.CommitTs
+		}
+
+		if e.rand.Float64() >= e.cfg.SampleRatio {
+			continue
+		}
+		if len(e.samples) >= e.cfg.MaxSamplesPerFlush {
+			e.dropped++
+			continue
+		}
+		e.samples = append(e.samples, logRecord{
+			timeNs:         uint64(oracle.GetTimeFromTS(row.CommitTs).UnixNano()),
+			observedTimeNs: now,
+			body:           "row changed",
+			attributes: []attribute{
+				{key: "ticdc.namespace", value: changefeedID.Namespace},
+				{key: "ticdc.changefeed", value: changefeedID.ID},
+				{key: "db.name", value: row.Table.Schema},
+				{key: "db.sql.table", value: row.Table.Table},
+				{key: "ticdc.op", value: op},
+				{key: "ticdc.start_ts", value: int64(row.StartTs)},
+				{key: "ticdc.commit_ts", value: int64(row.CommitTs)},
+				{key: "ticdc.event.size", value: int64(size)},
+			},
+		})
+	}
+}
+
+// Run exports the samples and metrics periodically until the context is
+// canceled. Failed exports are only logged, and the samples are dropped.
+func (e *Exporter) Run(ctx context.Context) error {
+	log.Info("event sampling exporter started",
+		zap.String("endpoint", e.cfg.Endpoint),
+		zap.Float64("sampleRatio", e.cfg.SampleRatio),
+		zap.Duration("flushInterval", time.Duration(e.cfg.FlushInterval)),
+		zap.Strings("changefeeds", e.cfg.Changefeeds))
+	ticker := time.NewTicker(time.Duration(e.cfg.FlushInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := e.flush(ctx); err != nil {
+				log.Warn("failed to export sampled events",
+					zap.String("endpoint", e.cfg.Endpoint), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (e *Exporter) flush(ctx context.Context) error {
+	now := time.Now()
+	e.mu.Lock()
+	samples := e.samples
+	e.samples = nil
+	metrics := e.collectLocked(now)
+	e.mu.Unlock()
+
+	if len(samples) != 0 {
+		if err := e.post(ctx, logsPath, marshalLogsRequest(&e.scope, samples)); err != nil {
+			return err
+		}
+	}
+	if len(metrics) != 0 {
+		body := marshalMetricsRequest(&e.scope, metrics,
+			uint64(e.startTime.UnixNano()), uint64(now.UnixNano()))
+		if err := e.post(ctx, metricsPath, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectLocked returns the metrics of all the tables, which are sorted so
+// the requests are stable.
+func (e *Exporter) collectLocked(now time.Time) []metric {
+	if len(e.tables) == 0 {
+		return nil
+	}
+	keys := make([]tableKey, 0, len(e.tables))
+	for key := range e.tables {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.changefeed != b.changefeed {
+			if a.changefeed.Namespace != b.changefeed.Namespace {
+				return a.changefeed.Namespace < b.changefeed.Namespace
+			}
+			return a.changefeed.ID < b.changefeed.ID
+		}
+		if a.schema != b.schema {
+			return a.schema < b.schema
+		}
+		return a.table < b.table
+	})
+
+	rows := metric{
+		name: "ticdc.table.rows", unit: "{row}", sum: true,
+		description: "The number of change events of the table.",
+	}
+	bytes := metric{
+		name: "ticdc.table.bytes", unit: "By", sum: true,
+		description: "The approximate size of the change events of the table.",
+	}
+	freshness := metric{
+		name: "ticdc.table.freshness", unit: "s",
+		description: "The seconds since the commit time of the latest change event of the table.",
+	}
+	for _, key := range keys {
+		stats := e.tables[key]
+		attrs := []attribute{
+			{key: "ticdc.namespace", value: key.changefeed.Namespace},
+			{key: "ticdc.changefeed", value: key.changefeed.ID},
+			{key: "db.name", value: key.schema},
+			{key: "db.sql.table", value: key.table},
+		}
+		for _, op := range []struct {
+			name  string
+			count uint64
+		}{{opInsert, stats.inserts}, {opUpdate, stats.updates}, {opDelete, stats.deletes}} {
+			opAttrs := append(attrs[:len(attrs):len(attrs)], attribute{key: "ticdc.op", value: op.name})
+			rows.points = append(rows.points, dataPoint{attributes: opAttrs, value: float64(op.count)})
+		}
+		bytes.points = append(bytes.points, dataPoint{attributes: attrs, value: float64(stats.bytes)})
+		freshness.points = append(freshness.points, dataPoint{
+			attributes: attrs,
+			value:      now.Sub(oracle.GetTimeFromTS(stats.maxCommitTs)).Seconds(),
+		})
+	}
+	dropped := metric{
+		name: "ticdc.event_sampling.dropped", unit: "{log}", sum: true,
+		description: "The number of samples dropped because of max-samples-per-flush.",
+		points:      []dataPoint{{value: float64(e.dropped)}},
+	}
+	return []metric{rows, bytes, freshness, dropped}
+}
+
+func (e *Exporter) post(ctx context.Context, path string, body []byte) error {
+	url := e.cfg.Endpoint + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "TiCDC/"+version.ReleaseVersion)
+	for name, value := range e.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return cerror.ErrEventSamplingExport.GenWithStackByArgs(url, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return cerror.ErrEventSamplingExport.GenWithStackByArgs(url,
+			resp.Status+" "+strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func parseChangefeedID(s string) model.ChangeFeedID {
+	if idx := strings.Index(s, "/"); idx >= 0 {
+		return model.ChangeFeedID{Namespace: s[:idx], ID: s[idx+1:]}
+	}
+	return model.DefaultChangeFeedID(s)
+}
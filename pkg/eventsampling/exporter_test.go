@@ -0,0 +1,246 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsampling
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// message is a decoded protobuf message, the values are either []byte or
+// uint64 depending on the wire types.
+type message map[protowire.Number][]interface{}
+
+func unmarshal(t *testing.T, buf []byte) message {
+	m := make(message)
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		require.GreaterOrEqual(t, n, 0)
+		buf = buf[n:]
+		var v interface{}
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(buf)
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(buf)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(buf)
+		default:
+			require.FailNow(t, "unexpected wire type", "%v", typ)
+		}
+		require.GreaterOrEqual(t, n, 0)
+		buf = buf[n:]
+		m[num] = append(m[num], v)
+	}
+	return m
+}
+
+func (m message) messages(t *testing.T, num protowire.Number) []message {
+	var result []message
+	for _, v := range m[num] {
+		result = append(result, unmarshal(t, v.([]byte)))
+	}
+	return result
+}
+
+func (m message) string(num protowire.Number) string {
+	if len(m[num]) == 0 {
+		return ""
+	}
+	return string(m[num][0].([]byte))
+}
+
+func (m message) attributes(t *testing.T, num protowire.Number) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, kv := range m.messages(t, num) {
+		value := kv.messages(t, fieldKeyValueValue)[0]
+		switch {
+		case len(value[fieldAnyValueInt]) != 0:
+			result[kv.string(fieldKeyValueKey)] = int64(value[fieldAnyValueInt][0].(uint64))
+		case len(value[fieldAnyValueDouble]) != 0:
+			result[kv.string(fieldKeyValueKey)] = math.Float64frombits(value[fieldAnyValueDouble][0].(uint64))
+		default:
+			result[kv.string(fieldKeyValueKey)] = value.string(fieldAnyValueString)
+		}
+	}
+	return result
+}
+
+// unmarshalRequest returns the resource attributes and the items of the
+// only scope of the request.
+func unmarshalRequest(t *testing.T, buf []byte) (map[string]interface{}, []message) {
+	resources := unmarshal(t, buf).messages(t, fieldRequestResources)
+	require.Len(t, resources, 1)
+	resource := resources[0].messages(t, fieldResource)[0].attributes(t, fieldResourceAttributes)
+	scopes := resources[0].messages(t, fieldResourceScopes)
+	require.Len(t, scopes, 1)
+	scope := scopes[0].messages(t, fieldScope)[0]
+	require.Equal(t, "github.com/pingcap/tiflow/pkg/eventsampling", scope.string(fieldScopeName))
+	return resource, scopes[0].messages(t, fieldScopeItems)
+}
+
+func newRow(schema, table string, commitTs model.Ts, columns, preColumns int) *model.RowChangedEvent {
+	row := &model.RowChangedEvent{
+		StartTs:  commitTs - 1,
+		CommitTs: commitTs,
+		Table:    &model.TableName{Schema: schema, Table: table},
+	}
+	for i := 0; i < columns; i++ {
+		row.Columns = append(row.Columns, &model.Column{Name: "a", Value: 1, ApproximateBytes: 10})
+	}
+	for i := 0; i < preColumns; i++ {
+		row.PreColumns = append(row.PreColumns, &model.Column{Name: "a", Value: 1, ApproximateBytes: 10})
+	}
+	return row
+}
+
+type mockCollector struct {
+	mu       sync.Mutex
+	requests map[string][]byte
+	status   int
+}
+
+func (c *mockCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r.Header.Get("Content-Type") == "application/x-protobuf" &&
+		r.Header.Get("Authorization") == "Bearer token" {
+		c.requests[r.URL.Path] = body
+	}
+	w.WriteHeader(c.status)
+	_, _ = w.Write([]byte("unavailable"))
+}
+
+func TestFlush(t *testing.T) {
+	t.Parallel()
+
+	collector := &mockCollector{requests: make(map[string][]byte), status: http.StatusOK}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	cfg := config.GetDefaultServerConfig().EventSampling
+	cfg.Endpoint = server.URL
+	cfg.SampleRatio = 1
+	cfg.MaxSamplesPerFlush = 2
+	cfg.Headers = map[string]string{"Authorization": "Bearer token"}
+	e := NewExporter(cfg, "127.0.0.1:8300")
+	defer e.client.CloseIdleConnections()
+
+	commitTs := oracle.GoTimeToTS(time.Now().Add(-time.Minute))
+	changefeedID := model.DefaultChangeFeedID("test")
+	e.Observe(changefeedID,
+		newRow("test", "t1", commitTs, 2, 0),
+		newRow("test", "t1", commitTs+1, 2, 2),
+		newRow("test", "t1", commitTs+2, 0, 2))
+	e.Observe(changefeedID, newRow("test", "t2", commitTs+3, 1, 0))
+	require.Nil(t, e.flush(context.Background()))
+
+	resource, logs := unmarshalRequest(t, collector.requests[logsPath])
+	require.Equal(t, "ticdc", resource["service.name"])
+	require.Equal(t, "127.0.0.1:8300", resource["service.instance.id"])
+	require.Len(t, logs, 2)
+	require.Equal(t, uint64(oracle.GetTimeFromTS(commitTs).UnixNano()), logs[0][fieldLogTime][0])
+	require.Equal(t, uint64(severityNumberInfo), logs[0][fieldLogSeverityNumber][0])
+	require.Equal(t, map[string]interface{}{
+		"ticdc.namespace":  "default",
+		"ticdc.changefeed": "test",
+		"db.name":          "test",
+		"db.sql.table":     "t1",
+		"ticdc.op":         "insert",
+		"ticdc.start_ts":   int64(commitTs - 1),
+		"ticdc.commit_ts":  int64(commitTs),
+		"ticdc.event.size": int64(newRow("test", "t1", commitTs, 2, 0).ApproximateBytes()),
+	}, logs[0].attributes(t, fieldLogAttributes))
+	require.Equal(t, "update", logs[1].attributes(t, fieldLogAttributes)["ticdc.op"])
+
+	_, metrics := unmarshalRequest(t, collector.requests[metricsPath])
+	values := make(map[string]float64)
+	for _, m := range metrics {
+		field := protowire.Number(fieldMetricGauge)
+		if len(m[fieldMetricSum]) != 0 {
+			field = fieldMetricSum
+		}
+		for _, p := range m.messages(t, field)[0].messages(t, fieldDataPoints) {
+			attrs := p.attributes(t, fieldPointAttributes)
+			key := m.string(fieldMetricName)
+			if table, ok := attrs["db.sql.table"]; ok {
+				key += "," + table.(string)
+			}
+			if op, ok := attrs["ticdc.op"]; ok {
+				key += "," + op.(string)
+			}
+			values[key] = math.Float64frombits(p[fieldPointValue][0].(uint64))
+		}
+	}
+	require.Equal(t, float64(1), values["ticdc.table.rows,t1,insert"])
+	require.Equal(t, float64(1), values["ticdc.table.rows,t1,update"])
+	require.Equal(t, float64(1), values["ticdc.table.rows,t1,delete"])
+	require.Equal(t, float64(1), values["ticdc.table.rows,t2,insert"])
+	require.Equal(t, float64(0), values["ticdc.table.rows,t2,delete"])
+	require.Equal(t, float64(2), values["ticdc.event_sampling.dropped"])
+	require.InDelta(t, time.Minute.Seconds(), values["ticdc.table.freshness,t1"], 10)
+
+	// Samples are exported once, and failures are reported.
+	collector.status = http.StatusServiceUnavailable
+	delete(collector.requests, logsPath)
+	err := e.flush(context.Background())
+	require.Regexp(t, ".*503 Service Unavailable unavailable.*", err)
+	require.NotContains(t, collector.requests, logsPath)
+}
+
+type mockTableSink struct {
+	tablesink.TableSink
+	rows []*model.RowChangedEvent
+}
+
+func (s *mockTableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	s.rows = append(s.rows, rows...)
+}
+
+func TestTableSink(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultServerConfig().EventSampling
+	cfg.Changefeeds = []string{"test1", "ns/test2"}
+	e := NewExporter(cfg, "127.0.0.1:8300")
+	require.True(t, e.Accept(model.DefaultChangeFeedID("test1")))
+	require.True(t, e.Accept(model.ChangeFeedID{Namespace: "ns", ID: "test2"}))
+	require.False(t, e.Accept(model.DefaultChangeFeedID("test2")))
+
+	sink := &mockTableSink{}
+	require.Equal(t, sink, NewTableSink(sink, model.DefaultChangeFeedID("test1"), nil))
+	require.Equal(t, sink, NewTableSink(sink, model.DefaultChangeFeedID("test2"), e))
+
+	wrapped := NewTableSink(sink, model.DefaultChangeFeedID("test1"), e)
+	require.IsType(t, &TableSink{}, wrapped)
+	wrapped.AppendRowChangedEvents(newRow("test", "t1", 100, 1, 0))
+	require.Len(t, sink.rows, 1)
+	stats := e.tables[tableKey{changefeed: model.DefaultChangeFeedID("test1"), schema: "test", table: "t1"}]
+	require.Equal(t, uint64(1), stats.inserts)
+	require.Equal(t, model.Ts(100), stats.maxCommitTs)
+}
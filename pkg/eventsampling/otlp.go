@@ -0,0 +1,250 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsampling
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of the OTLP logs and metrics protocols, see
+// https://github.com/open-telemetry/opentelemetry-proto. Only the fields
+// used by the exporter are listed, they're encoded by hand to avoid
+// depending on the OpenTelemetry SDK.
+//
+//	message ExportLogsServiceRequest { repeated ResourceLogs resource_logs = 1; }
+//	message ResourceLogs { Resource resource = 1; repeated ScopeLogs scope_logs = 2; }
+//	message ScopeLogs { InstrumentationScope scope = 1; repeated LogRecord log_records = 2; }
+//	message LogRecord {
+//	  fixed64 time_unix_nano = 1; SeverityNumber severity_number = 2;
+//	  string severity_text = 3; AnyValue body = 5; repeated KeyValue attributes = 6;
+//	  fixed64 observed_time_unix_nano = 11;
+//	}
+//
+//	message ExportMetricsServiceRequest { repeated ResourceMetrics resource_metrics = 1; }
+//	message ResourceMetrics { Resource resource = 1; repeated ScopeMetrics scope_metrics = 2; }
+//	message ScopeMetrics { InstrumentationScope scope = 1; repeated Metric metrics = 2; }
+//	message Metric { string name = 1; string description = 2; string unit = 3; Gauge gauge = 5; Sum sum = 7; }
+//	message Gauge { repeated NumberDataPoint data_points = 1; }
+//	message Sum {
+//	  repeated NumberDataPoint data_points = 1;
+//	  AggregationTemporality aggregation_temporality = 2; bool is_monotonic = 3;
+//	}
+//	message NumberDataPoint {
+//	  fixed64 start_time_unix_nano = 2; fixed64 time_unix_nano = 3;
+//	  double as_double = 4; repeated KeyValue attributes = 7;
+//	}
+//
+//	message Resource { repeated KeyValue attributes = 1; }
+//	message InstrumentationScope { string name = 1; string version = 2; }
+//	message KeyValue { string key = 1; AnyValue value = 2; }
+//	message AnyValue { string string_value = 1; int64 int_value = 3; double double_value = 4; }
+const (
+	fieldRequestResources = 1
+	fieldResource         = 1
+	fieldResourceScopes   = 2
+	fieldScope            = 1
+	fieldScopeItems       = 2
+
+	fieldLogTime           = 1
+	fieldLogSeverityNumber = 2
+	fieldLogSeverityText   = 3
+	fieldLogBody           = 5
+	fieldLogAttributes     = 6
+	fieldLogObservedTime   = 11
+
+	fieldMetricName        = 1
+	fieldMetricDescription = 2
+	fieldMetricUnit        = 3
+	fieldMetricGauge       = 5
+	fieldMetricSum         = 7
+	fieldDataPoints        = 1
+	fieldSumTemporality    = 2
+	fieldSumIsMonotonic    = 3
+	fieldPointStartTime    = 2
+	fieldPointTime         = 3
+	fieldPointValue        = 4
+	fieldPointAttributes   = 7
+
+	fieldResourceAttributes = 1
+	fieldScopeName          = 1
+	fieldScopeVersion       = 2
+	fieldKeyValueKey        = 1
+	fieldKeyValueValue      = 2
+	fieldAnyValueString     = 1
+	fieldAnyValueInt        = 3
+	fieldAnyValueDouble     = 4
+
+	severityNumberInfo               = 9
+	aggregationTemporalityCumulative = 2
+)
+
+// attribute is a key value pair, value is either a string, an int64 or
+// a float64.
+type attribute struct {
+	key   string
+	value interface{}
+}
+
+// logRecord is a log of a sampled event.
+type logRecord struct {
+	// timeNs is the commit time of the event.
+	timeNs uint64
+	// observedTimeNs is the time the event is sampled.
+	observedTimeNs uint64
+	body           string
+	attributes     []attribute
+}
+
+// dataPoint is a data point of a metric.
+type dataPoint struct {
+	attributes []attribute
+	value      float64
+}
+
+// metric is either a cumulative monotonic sum or a gauge.
+type metric struct {
+	name        string
+	description string
+	unit        string
+	sum         bool
+	points      []dataPoint
+}
+
+// scope is the resource and the instrumentation scope of the exported logs
+// and metrics.
+type scope struct {
+	resource []attribute
+	name     string
+	version  string
+}
+
+func marshalLogsRequest(s *scope, logs []logRecord) []byte {
+	return s.marshalRequest(func(buf []byte) []byte {
+		for i := range logs {
+			buf = appendMessage(buf, fieldScopeItems, logs[i].marshal)
+		}
+		return buf
+	})
+}
+
+func marshalMetricsRequest(s *scope, metrics []metric, startNs, nowNs uint64) []byte {
+	return s.marshalRequest(func(buf []byte) []byte {
+		for i := range metrics {
+			m := &metrics[i]
+			buf = appendMessage(buf, fieldScopeItems, func(buf []byte) []byte {
+				return m.marshal(buf, startNs, nowNs)
+			})
+		}
+		return buf
+	})
+}
+
+// marshalRequest marshals a request of a single resource and scope, items
+// appends the logs or metrics of the scope.
+func (s *scope) marshalRequest(items func([]byte) []byte) []byte {
+	return appendMessage(nil, fieldRequestResources, func(buf []byte) []byte {
+		buf = appendMessage(buf, fieldResource, func(buf []byte) []byte {
+			return appendAttributes(buf, fieldResourceAttributes, s.resource)
+		})
+		return appendMessage(buf, fieldResourceScopes, func(buf []byte) []byte {
+			buf = appendMessage(buf, fieldScope, func(buf []byte) []byte {
+				buf = appendString(buf, fieldScopeName, s.name)
+				return appendString(buf, fieldScopeVersion, s.version)
+			})
+			return items(buf)
+		})
+	})
+}
+
+func (l *logRecord) marshal(buf []byte) []byte {
+	buf = appendFixed64(buf, fieldLogTime, l.timeNs)
+	buf = protowire.AppendTag(buf, fieldLogSeverityNumber, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, severityNumberInfo)
+	buf = appendString(buf, fieldLogSeverityText, "INFO")
+	buf = appendMessage(buf, fieldLogBody, func(buf []byte) []byte {
+		return appendString(buf, fieldAnyValueString, l.body)
+	})
+	buf = appendAttributes(buf, fieldLogAttributes, l.attributes)
+	return appendFixed64(buf, fieldLogObservedTime, l.observedTimeNs)
+}
+
+func (m *metric) marshal(buf []byte, startNs, nowNs uint64) []byte {
+	buf = appendString(buf, fieldMetricName, m.name)
+	buf = appendString(buf, fieldMetricDescription, m.description)
+	buf = appendString(buf, fieldMetricUnit, m.unit)
+	field := protowire.Number(fieldMetricGauge)
+	if m.sum {
+		field = fieldMetricSum
+	}
+	return appendMessage(buf, field, func(buf []byte) []byte {
+		for i := range m.points {
+			p := &m.points[i]
+			buf = appendMessage(buf, fieldDataPoints, func(buf []byte) []byte {
+				if m.sum {
+					buf = appendFixed64(buf, fieldPointStartTime, startNs)
+				}
+				buf = appendFixed64(buf, fieldPointTime, nowNs)
+				buf = appendFixed64(buf, fieldPointValue, math.Float64bits(p.value))
+				return appendAttributes(buf, fieldPointAttributes, p.attributes)
+			})
+		}
+		if m.sum {
+			buf = protowire.AppendTag(buf, fieldSumTemporality, protowire.VarintType)
+			buf = protowire.AppendVarint(buf, aggregationTemporalityCumulative)
+			buf = protowire.AppendTag(buf, fieldSumIsMonotonic, protowire.VarintType)
+			buf = protowire.AppendVarint(buf, 1)
+		}
+		return buf
+	})
+}
+
+func appendAttributes(buf []byte, num protowire.Number, attributes []attribute) []byte {
+	for _, a := range attributes {
+		a := a
+		buf = appendMessage(buf, num, func(buf []byte) []byte {
+			buf = appendString(buf, fieldKeyValueKey, a.key)
+			return appendMessage(buf, fieldKeyValueValue, func(buf []byte) []byte {
+				switch v := a.value.(type) {
+				case int64:
+					buf = protowire.AppendTag(buf, fieldAnyValueInt, protowire.VarintType)
+					buf = protowire.AppendVarint(buf, uint64(v))
+				case float64:
+					buf = appendFixed64(buf, fieldAnyValueDouble, math.Float64bits(v))
+				default:
+					buf = appendString(buf, fieldAnyValueString, v.(string))
+				}
+				return buf
+			})
+		})
+	}
+	return buf
+}
+
+// appendMessage appends an embedded message, whose fields are appended by f.
+func appendMessage(buf []byte, num protowire.Number, f func([]byte) []byte) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, f(nil))
+}
+
+func appendString(buf []byte, num protowire.Number, s string) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendString(buf, s)
+}
+
+func appendFixed64(buf []byte, num protowire.Number, v uint64) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(buf, v)
+}
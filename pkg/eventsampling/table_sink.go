@@ -0,0 +1,47 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsampling
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+)
+
+// Assert TableSink implementation
+var _ tablesink.TableSink = (*TableSink)(nil)
+
+// TableSink is a table sink which reports the events appended to it to the
+// exporter before they're appended to the underlying table sink.
+type TableSink struct {
+	tablesink.TableSink
+	changefeedID model.ChangeFeedID
+	exporter     *Exporter
+}
+
+// NewTableSink wraps the table sink if the exporter is not nil and the events
+// of the changefeed are sampled, otherwise the table sink is returned as is.
+func NewTableSink(
+	sink tablesink.TableSink, changefeedID model.ChangeFeedID, exporter *Exporter,
+) tablesink.TableSink {
+	if exporter == nil || !exporter.Accept(changefeedID) {
+		return sink
+	}
+	return &TableSink{TableSink: sink, changefeedID: changefeedID, exporter: exporter}
+}
+
+// AppendRowChangedEvents reports and appends row changed events.
+func (t *TableSink) AppendRowChangedEvents(rows ...*model.RowChangedEvent) {
+	t.exporter.Observe(t.changefeedID, rows...)
+	t.TableSink.AppendRowChangedEvents(rows...)
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"hash/fnv"
+	"strings"
+
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// canarySampleBuckets is the number of buckets table names are hashed into,
+// which limits the precision of the sample ratio.
+const canarySampleBuckets = 10000
+
+// canaryFilter decides which tables are replicated by a canary changefeed.
+// Tables that are not sampled are still scheduled, but their events are
+// not sent to the downstream.
+type canaryFilter struct {
+	// tables matches the tables that are always sampled.
+	tables tfilter.Filter
+	// threshold is the number of sampled buckets.
+	threshold     uint64
+	caseSensitive bool
+}
+
+// newCanaryFilter returns nil if the changefeed is not a canary changefeed.
+func newCanaryFilter(cfg *config.CanaryConfig, caseSensitive bool) (*canaryFilter, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	f, err := tfilter.Parse(cfg.Tables)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, cfg.Tables)
+	}
+	if !caseSensitive {
+		f = tfilter.CaseInsensitive(f)
+	}
+	return &canaryFilter{
+		tables:        f,
+		threshold:     uint64(cfg.SampleRatio * canarySampleBuckets),
+		caseSensitive: caseSensitive,
+	}, nil
+}
+
+// isSampled returns true if the table should be replicated to the downstream.
+func (c *canaryFilter) isSampled(schema, table string) bool {
+	if c == nil {
+		return true
+	}
+	if c.tables.MatchTable(schema, table) {
+		return true
+	}
+	if c.threshold == 0 {
+		return false
+	}
+	name := schema + "." + table
+	if !c.caseSensitive {
+		name = strings.ToLower(name)
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return h.Sum64()%canarySampleBuckets < c.threshold
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanaryFilterIsSampled(t *testing.T) {
+	t.Parallel()
+
+	// A nil canary filter samples all tables.
+	c, err := newCanaryFilter(nil, false)
+	require.NoError(t, err)
+	require.True(t, c.isSampled("test", "t1"))
+
+	c, err = newCanaryFilter(&config.CanaryConfig{Tables: []string{"test.t1"}}, false)
+	require.NoError(t, err)
+	require.True(t, c.isSampled("test", "t1"))
+	require.True(t, c.isSampled("TEST", "T1"))
+	require.False(t, c.isSampled("test", "t2"))
+
+	c, err = newCanaryFilter(&config.CanaryConfig{SampleRatio: 1}, false)
+	require.NoError(t, err)
+	require.True(t, c.isSampled("test", "t2"))
+
+	// The sample is stable and roughly follows the ratio.
+	c, err = newCanaryFilter(&config.CanaryConfig{SampleRatio: 0.2}, false)
+	require.NoError(t, err)
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		table := fmt.Sprintf("t%d", i)
+		if c.isSampled("test", table) {
+			sampled++
+		}
+		require.Equal(t, c.isSampled("test", table), c.isSampled("TEST", table))
+	}
+	require.InDelta(t, 200, sampled, 50)
+
+	_, err = newCanaryFilter(&config.CanaryConfig{Tables: []string{"test.t1["}}, false)
+	require.Error(t, err)
+}
+
+func TestCanaryFilterIgnoreEvents(t *testing.T) {
+	t.Parallel()
+
+	f, err := NewFilter(&config.ReplicaConfig{
+		Filter: &config.FilterConfig{Rules: []string{"test.*"}},
+		Canary: &config.CanaryConfig{Tables: []string{"test.canary"}},
+	}, "")
+	require.NoError(t, err)
+
+	// Tables not sampled are still replicated by the changefeed.
+	require.False(t, f.ShouldIgnoreTable("test", "other"))
+
+	for _, tc := range []struct {
+		table  string
+		ignore bool
+	}{
+		{"canary", false},
+		{"other", true},
+	} {
+		dml := &model.RowChangedEvent{
+			Table: &model.TableName{Schema: "test", Table: tc.table},
+		}
+		ignore, err := f.ShouldIgnoreDMLEvent(dml, model.RowChangedDatums{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, tc.ignore, ignore)
+
+		ddl := &model.DDLEvent{
+			Type:      timodel.ActionAddColumn,
+			Query:     fmt.Sprintf("alter table test.%s add column c int", tc.table),
+			TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test", Table: tc.table}},
+		}
+		ignore, err = f.ShouldIgnoreDDLEvent(ddl)
+		require.NoError(t, err)
+		require.Equal(t, tc.ignore, ignore)
+		// The DDL is still applied to the schema storage.
+		require.False(t, f.ShouldDiscardDDL(ddl.Type, "test", tc.table))
+	}
+
+	// Schema DDLs are always replicated.
+	ignore, err := f.ShouldIgnoreDDLEvent(&model.DDLEvent{
+		Type:      timodel.ActionCreateSchema,
+		Query:     "create database test",
+		TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test"}},
+	})
+	require.NoError(t, err)
+	require.False(t, ignore)
+}
@@ -91,6 +91,12 @@ type filter struct {
 	sqlEventFilter *sqlEventFilter
 	// ignoreTxnStartTs is used to filter out dml/ddl event by its starsTs.
 	ignoreTxnStartTs []uint64
+	// canaryFilter is used to filter out dml/ddl event of tables that are not
+	// sampled by a canary changefeed. It is nil for a normal changefeed.
+	canaryFilter *canaryFilter
+	// originFilter is used to filter out dml event written by external
+	// systems into the upstream.
+	originFilter *originFilter
 }
 
 // NewFilter creates a filter.
@@ -112,19 +118,31 @@ func NewFilter(cfg *config.ReplicaConfig, tz string) (Filter, error) {
 	if err != nil {
 		return nil, err
 	}
+	canaryFilter, err := newCanaryFilter(cfg.Canary, cfg.CaseSensitive)
+	if err != nil {
+		return nil, err
+	}
+	originFilter, err := newOriginFilter(cfg.Filter, cfg.CaseSensitive)
+	if err != nil {
+		return nil, err
+	}
 	return &filter{
 		tableFilter:      f,
 		dmlExprFilter:    dmlExprFilter,
 		sqlEventFilter:   sqlEventFilter,
 		ignoreTxnStartTs: cfg.Filter.IgnoreTxnStartTs,
+		canaryFilter:     canaryFilter,
+		originFilter:     originFilter,
 	}, nil
 }
 
 // ShouldIgnoreDMLEvent checks if a DML event should be ignore by conditions below:
 // 0. By startTs.
 // 1. By table name.
-// 2. By type.
-// 3. By columns value.
+// 2. By canary sampling.
+// 3. By type.
+// 4. By origin.
+// 5. By columns value.
 func (f *filter) ShouldIgnoreDMLEvent(
 	dml *model.RowChangedEvent,
 	rawRow model.RowChangedDatums,
@@ -138,6 +156,10 @@ func (f *filter) ShouldIgnoreDMLEvent(
 		return true, nil
 	}
 
+	if !f.canaryFilter.isSampled(dml.Table.Schema, dml.Table.Table) {
+		return true, nil
+	}
+
 	ignoreByEventType, err := f.sqlEventFilter.shouldSkipDML(dml)
 	if err != nil {
 		return false, err
@@ -145,6 +167,9 @@ func (f *filter) ShouldIgnoreDMLEvent(
 	if ignoreByEventType {
 		return true, nil
 	}
+	if f.originFilter.shouldSkipDML(dml) {
+		return true, nil
+	}
 	return f.dmlExprFilter.shouldSkipDML(dml, rawRow, ti)
 }
 
@@ -152,8 +177,9 @@ func (f *filter) ShouldIgnoreDMLEvent(
 // 0. By startTs.
 // 1. By schema name.
 // 2. By table name.
-// 3. By type.
-// 4. By query.
+// 3. By canary sampling.
+// 4. By type.
+// 5. By query.
 func (f *filter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
 	if f.shouldIgnoreStartTs(ddl.StartTs) {
 		return true, nil
@@ -165,9 +191,13 @@ func (f *filter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
 		timodel.ActionModifySchemaCharsetAndCollate:
 		shouldIgnoreTableOrSchema = !f.tableFilter.MatchSchema(ddl.TableInfo.TableName.Schema)
 	case timodel.ActionRenameTable:
-		shouldIgnoreTableOrSchema = f.ShouldIgnoreTable(ddl.PreTableInfo.TableName.Schema, ddl.PreTableInfo.TableName.Table)
+		schema, table := ddl.PreTableInfo.TableName.Schema, ddl.PreTableInfo.TableName.Table
+		shouldIgnoreTableOrSchema = f.ShouldIgnoreTable(schema, table) ||
+			!f.canaryFilter.isSampled(schema, table)
 	default:
-		shouldIgnoreTableOrSchema = f.ShouldIgnoreTable(ddl.TableInfo.TableName.Schema, ddl.TableInfo.TableName.Table)
+		schema, table := ddl.TableInfo.TableName.Schema, ddl.TableInfo.TableName.Table
+		shouldIgnoreTableOrSchema = f.ShouldIgnoreTable(schema, table) ||
+			!f.canaryFilter.isSampled(schema, table)
 	}
 	if shouldIgnoreTableOrSchema {
 		return true, nil
@@ -0,0 +1,190 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// OriginTracker tells the origin of a row, i.e. which external system wrote
+// the row into the upstream.
+type OriginTracker interface {
+	// Origin returns the origin of the row, and false if the row is not
+	// written by an external system.
+	Origin(row *model.RowChangedEvent) (string, bool)
+}
+
+// OriginTrackerCreator creates an OriginTracker by an origin filter rule.
+type OriginTrackerCreator func(rule *config.OriginFilterRule) (OriginTracker, error)
+
+var originTrackers = struct {
+	sync.RWMutex
+	creators map[string]OriginTrackerCreator
+}{
+	creators: map[string]OriginTrackerCreator{
+		config.OriginTrackerColumn: newColumnOriginTracker,
+	},
+}
+
+// RegisterOriginTracker registers an origin tracker, so that it can be used
+// by its name in origin filter rules. For example, an ingestion tool which
+// marks its sessions can provide a tracker which recognizes the mark.
+func RegisterOriginTracker(name string, creator OriginTrackerCreator) {
+	originTrackers.Lock()
+	defer originTrackers.Unlock()
+	originTrackers.creators[name] = creator
+}
+
+func getOriginTrackerCreator(name string) (OriginTrackerCreator, bool) {
+	originTrackers.RLock()
+	defer originTrackers.RUnlock()
+	creator, ok := originTrackers.creators[name]
+	return creator, ok
+}
+
+// columnOriginTracker tells the origin of a row by the value of a source
+// column. Rows with a NULL or empty source column have no origin.
+type columnOriginTracker struct {
+	column string
+}
+
+func newColumnOriginTracker(rule *config.OriginFilterRule) (OriginTracker, error) {
+	if rule.SourceColumn == "" {
+		return nil, cerror.ErrFilterRuleInvalid.GenWithStackByArgs(
+			"source-column is required by the column origin tracker")
+	}
+	return &columnOriginTracker{column: rule.SourceColumn}, nil
+}
+
+func (t *columnOriginTracker) Origin(row *model.RowChangedEvent) (string, bool) {
+	// The origin of a delete is the origin of the deleted row.
+	cols := row.Columns
+	if row.IsDelete() {
+		cols = row.PreColumns
+	}
+	for _, col := range cols {
+		if col == nil || !strings.EqualFold(col.Name, t.column) {
+			continue
+		}
+		var origin string
+		switch v := col.Value.(type) {
+		case nil:
+			return "", false
+		case string:
+			origin = v
+		case []byte:
+			origin = string(v)
+		default:
+			origin = fmt.Sprint(v)
+		}
+		return origin, origin != ""
+	}
+	return "", false
+}
+
+// originFilterRule only be used by originFilter.
+type originFilterRule struct {
+	tf      tfilter.Filter
+	tracker OriginTracker
+	// ignoreOrigins is nil if rows with any origin are ignored.
+	ignoreOrigins map[string]struct{}
+}
+
+func newOriginFilterRule(
+	cfg *config.OriginFilterRule, caseSensitive bool,
+) (*originFilterRule, error) {
+	tf, err := tfilter.Parse(cfg.Matcher)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, cfg.Matcher)
+	}
+	if !caseSensitive {
+		tf = tfilter.CaseInsensitive(tf)
+	}
+
+	name := cfg.Tracker
+	if name == "" {
+		name = config.OriginTrackerColumn
+	}
+	creator, ok := getOriginTrackerCreator(name)
+	if !ok {
+		return nil, cerror.ErrFilterRuleInvalid.GenWithStackByArgs(
+			fmt.Sprintf("unknown origin tracker %s", name))
+	}
+	tracker, err := creator(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res := &originFilterRule{tf: tf, tracker: tracker}
+	if len(cfg.IgnoreOrigins) != 0 {
+		res.ignoreOrigins = make(map[string]struct{}, len(cfg.IgnoreOrigins))
+		for _, origin := range cfg.IgnoreOrigins {
+			res.ignoreOrigins[origin] = struct{}{}
+		}
+	}
+	return res, nil
+}
+
+func (r *originFilterRule) shouldSkipDML(dml *model.RowChangedEvent) bool {
+	if !r.tf.MatchTable(dml.Table.Schema, dml.Table.Table) {
+		return false
+	}
+	origin, ok := r.tracker.Origin(dml)
+	if !ok {
+		return false
+	}
+	if r.ignoreOrigins == nil {
+		return true
+	}
+	_, ok = r.ignoreOrigins[origin]
+	return ok
+}
+
+// originFilter filters out DML events written by external systems into the
+// upstream, so that they are not replicated back in bidirectional or
+// three-way sync topologies.
+type originFilter struct {
+	rules []*originFilterRule
+}
+
+func newOriginFilter(cfg *config.FilterConfig, caseSensitive bool) (*originFilter, error) {
+	res := &originFilter{}
+	for _, ruleCfg := range cfg.OriginFilters {
+		rule, err := newOriginFilterRule(ruleCfg, caseSensitive)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res.rules = append(res.rules, rule)
+	}
+	return res, nil
+}
+
+// shouldSkipDML returns true if the row is written by an external system
+// and matches any rule.
+func (f *originFilter) shouldSkipDML(dml *model.RowChangedEvent) bool {
+	for _, rule := range f.rules {
+		if rule.shouldSkipDML(dml) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newOriginRow(table string, origin interface{}, isDelete bool) *model.RowChangedEvent {
+	cols := []*model.Column{
+		{Name: "id", Value: 1},
+		{Name: "_origin", Value: origin},
+	}
+	row := &model.RowChangedEvent{
+		Table: &model.TableName{Schema: "test", Table: table},
+	}
+	if isDelete {
+		row.PreColumns = cols
+	} else {
+		row.Columns = cols
+	}
+	return row
+}
+
+func TestOriginFilterColumnTracker(t *testing.T) {
+	t.Parallel()
+
+	f, err := newOriginFilter(&config.FilterConfig{
+		OriginFilters: []*config.OriginFilterRule{{
+			Matcher:       []string{"test.t1"},
+			SourceColumn:  "_ORIGIN",
+			IgnoreOrigins: []string{"etl"},
+		}, {
+			Matcher:      []string{"test.t2"},
+			Tracker:      config.OriginTrackerColumn,
+			SourceColumn: "_origin",
+		}},
+	}, false)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		row    *model.RowChangedEvent
+		ignore bool
+	}{
+		{newOriginRow("t1", "etl", false), true},
+		{newOriginRow("T1", []byte("etl"), false), true},
+		{newOriginRow("t1", "etl", true), true},
+		{newOriginRow("t1", "dm", false), false},
+		{newOriginRow("t1", nil, false), false},
+		// Rows with any origin are ignored if no origin is specified.
+		{newOriginRow("t2", "dm", false), true},
+		{newOriginRow("t2", "", false), false},
+		{newOriginRow("t2", nil, true), false},
+		// Rows of unmatched tables are never ignored.
+		{newOriginRow("t3", "etl", false), false},
+	} {
+		require.Equal(t, tc.ignore, f.shouldSkipDML(tc.row), "%v", tc.row)
+	}
+
+	// Filters are case sensitive if the changefeed is.
+	f, err = newOriginFilter(&config.FilterConfig{
+		OriginFilters: []*config.OriginFilterRule{{
+			Matcher:      []string{"test.t1"},
+			SourceColumn: "_origin",
+		}},
+	}, true)
+	require.NoError(t, err)
+	require.True(t, f.shouldSkipDML(newOriginRow("t1", "etl", false)))
+	require.False(t, f.shouldSkipDML(newOriginRow("T1", "etl", false)))
+}
+
+type markOriginTracker struct{}
+
+func (markOriginTracker) Origin(row *model.RowChangedEvent) (string, bool) {
+	return "mark", row.StartTs == 1
+}
+
+func TestOriginFilterRegisterTracker(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.ReplicaConfig{
+		Filter: &config.FilterConfig{
+			Rules: []string{"test.*"},
+			OriginFilters: []*config.OriginFilterRule{{
+				Matcher: []string{"test.*"},
+				Tracker: "test-mark",
+			}},
+		},
+	}
+	_, err := NewFilter(cfg, "")
+	require.ErrorContains(t, err, "unknown origin tracker test-mark")
+
+	RegisterOriginTracker("test-mark",
+		func(*config.OriginFilterRule) (OriginTracker, error) {
+			return markOriginTracker{}, nil
+		})
+	f, err := NewFilter(cfg, "")
+	require.NoError(t, err)
+	row := newOriginRow("t1", nil, false)
+	row.StartTs = 1
+	ignore, err := f.ShouldIgnoreDMLEvent(row, model.RowChangedDatums{}, nil)
+	require.NoError(t, err)
+	require.True(t, ignore)
+	row.StartTs = 2
+	ignore, err = f.ShouldIgnoreDMLEvent(row, model.RowChangedDatums{}, nil)
+	require.NoError(t, err)
+	require.False(t, ignore)
+}
+
+func TestOriginFilterInvalidRule(t *testing.T) {
+	t.Parallel()
+
+	for _, rule := range []*config.OriginFilterRule{
+		{Matcher: []string{"test.t1["}, SourceColumn: "_origin"},
+		{Matcher: []string{"test.*"}},
+		{Matcher: []string{"test.*"}, Tracker: "unknown"},
+	} {
+		_, err := newOriginFilter(&config.FilterConfig{
+			OriginFilters: []*config.OriginFilterRule{rule},
+		}, false)
+		require.Error(t, err)
+	}
+}
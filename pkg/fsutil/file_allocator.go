@@ -18,10 +18,12 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
-
-	"github.com/pingcap/tiflow/pkg/redo"
 )
 
+// allocFileMode is the mode of the allocated files, which is the same as
+// allocFileMode. pkg/redo is not imported to avoid an import cycle.
+const allocFileMode = 0o644
+
 // FileAllocator has two functionalities:
 //  1. create new file or reuse the existing file (the existing tmp file will be cleared)
 //     beforehand for file write.
@@ -84,7 +86,7 @@ func (fl *FileAllocator) alloc() (f *os.File, err error) {
 	}
 
 	filePath := filepath.Join(fl.dir, fmt.Sprintf("%s_%d.tmp", fl.prefix, fl.count%2))
-	f, err = os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY, redo.DefaultFileMode)
+	f, err = os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY, allocFileMode)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,138 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	labelKeyspace   = "keyspace"
+	labelNamespace  = "namespace"
+	labelChangefeed = "changefeed"
+)
+
+type registration struct {
+	keyspace string
+	// refs is the number of components, e.g. the owner and the processor,
+	// that registered the changefeed in this capture.
+	refs int
+}
+
+var changefeeds = struct {
+	sync.RWMutex
+	m map[model.ChangeFeedID]*registration
+}{m: make(map[model.ChangeFeedID]*registration)}
+
+// Register records the keyspace a changefeed is scoped to, so that metrics
+// of the changefeed are labeled with the keyspace.
+// It does nothing if the keyspace is empty.
+func Register(id model.ChangeFeedID, keyspace string) {
+	if keyspace == "" {
+		return
+	}
+	changefeeds.Lock()
+	defer changefeeds.Unlock()
+	r, ok := changefeeds.m[id]
+	if !ok {
+		r = &registration{keyspace: keyspace}
+		changefeeds.m[id] = r
+	}
+	r.refs++
+}
+
+// Unregister is the reverse of Register.
+func Unregister(id model.ChangeFeedID, keyspace string) {
+	if keyspace == "" {
+		return
+	}
+	changefeeds.Lock()
+	defer changefeeds.Unlock()
+	r, ok := changefeeds.m[id]
+	if !ok {
+		return
+	}
+	r.refs--
+	if r.refs <= 0 {
+		delete(changefeeds.m, id)
+	}
+}
+
+// Get returns the keyspace a changefeed is scoped to, or an empty string
+// if the changefeed is not registered.
+func Get(id model.ChangeFeedID) string {
+	changefeeds.RLock()
+	defer changefeeds.RUnlock()
+	if r, ok := changefeeds.m[id]; ok {
+		return r.keyspace
+	}
+	return ""
+}
+
+// Gatherer wraps a prometheus.Gatherer and labels metrics of changefeeds
+// that are scoped to a keyspace with the keyspace.
+type Gatherer struct {
+	prometheus.Gatherer
+}
+
+// NewGatherer returns a new Gatherer.
+func NewGatherer(g prometheus.Gatherer) *Gatherer {
+	return &Gatherer{Gatherer: g}
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *Gatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.Gatherer.Gather()
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			addKeyspaceLabel(m)
+		}
+	}
+	return families, err
+}
+
+func addKeyspaceLabel(m *dto.Metric) {
+	var id model.ChangeFeedID
+	for _, pair := range m.GetLabel() {
+		switch pair.GetName() {
+		case labelKeyspace:
+			return
+		case labelNamespace:
+			id.Namespace = pair.GetValue()
+		case labelChangefeed:
+			id.ID = pair.GetValue()
+		}
+	}
+	if id.ID == "" {
+		return
+	}
+	keyspace := Get(id)
+	if keyspace == "" {
+		return
+	}
+	m.Label = append(m.Label, &dto.LabelPair{
+		Name:  proto.String(labelKeyspace),
+		Value: proto.String(keyspace),
+	})
+	// Labels must be sorted by name, as the registry does.
+	sort.Slice(m.Label, func(i, j int) bool {
+		return m.Label[i].GetName() < m.Label[j].GetName()
+	})
+}
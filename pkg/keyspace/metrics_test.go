@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	id := model.DefaultChangeFeedID("test-register")
+	Register(id, "")
+	require.Equal(t, "", Get(id))
+
+	// Both the owner and the processor register the changefeed.
+	Register(id, "ks1")
+	Register(id, "ks1")
+	require.Equal(t, "ks1", Get(id))
+	Unregister(id, "ks1")
+	require.Equal(t, "ks1", Get(id))
+	Unregister(id, "ks1")
+	require.Equal(t, "", Get(id))
+	// Unregister an unknown changefeed.
+	Unregister(id, "ks1")
+	require.Equal(t, "", Get(id))
+}
+
+func TestGatherer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	changefeedGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "changefeed_gauge",
+	}, []string{"namespace", "changefeed"})
+	captureGauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "capture_gauge",
+	})
+	registry.MustRegister(changefeedGauge, captureGauge)
+
+	id1 := model.DefaultChangeFeedID("test-gatherer-1")
+	id2 := model.DefaultChangeFeedID("test-gatherer-2")
+	changefeedGauge.WithLabelValues(id1.Namespace, id1.ID).Set(1)
+	changefeedGauge.WithLabelValues(id2.Namespace, id2.ID).Set(2)
+	captureGauge.Set(3)
+	Register(id1, "ks1")
+	defer Unregister(id1, "ks1")
+
+	families, err := NewGatherer(registry).Gather()
+	require.Nil(t, err)
+	labels := make(map[string]map[string]string)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			pairs := make(map[string]string)
+			names := make([]string, 0, len(m.GetLabel()))
+			for _, pair := range m.GetLabel() {
+				pairs[pair.GetName()] = pair.GetValue()
+				names = append(names, pair.GetName())
+			}
+			require.IsIncreasing(t, names)
+			key := family.GetName() + "/" + pairs["changefeed"]
+			labels[key] = pairs
+		}
+	}
+	require.Equal(t, map[string]map[string]string{
+		"changefeed_gauge/test-gatherer-1": {
+			"namespace": "default", "changefeed": id1.ID, "keyspace": "ks1",
+		},
+		"changefeed_gauge/test-gatherer-2": {
+			"namespace": "default", "changefeed": id2.ID,
+		},
+		"capture_gauge/": {},
+	}, labels)
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leakutil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"regexp"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+)
+
+// ChangefeedLabel is the pprof label key of the changefeed which a goroutine
+// works for.
+const ChangefeedLabel = "changefeed"
+
+// DoWithLabel calls f with the goroutine labeled by the key and the value.
+// Goroutines created by f inherit the label, so they can be counted by
+// CountGoroutinesByLabel. The caller must not be labeled.
+func DoWithLabel(key, value string, f func()) {
+	pprof.Do(context.Background(), pprof.Labels(key, value), func(context.Context) {
+		f()
+	})
+}
+
+// DoWithoutLabels calls f in a new goroutine without labels and waits for it.
+// It's for creating shared resources, whose goroutines shouldn't inherit the
+// labels of the caller.
+func DoWithoutLabels(f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pprof.SetGoroutineLabels(context.Background())
+		f()
+	}()
+	<-done
+}
+
+var goroutineRecordHeader = regexp.MustCompile(`^(\d+) @`)
+
+// CountGoroutinesByLabel returns the number of goroutines for each value of
+// the pprof label key. Goroutines without the key are skipped.
+func CountGoroutinesByLabel(key string) (map[string]int, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return nil, err
+	}
+	// Records of the text profile look like:
+	//   2 @ 0x43b3b6 0x40729e ...
+	//   # labels: {"changefeed":"default/test"}
+	//   #	0x43b3b5	runtime.gopark+0x115	...
+	labelPattern := regexp.MustCompile(`"` + regexp.QuoteMeta(key) + `":("(?:[^"\\]|\\.)*")`)
+	counts := make(map[string]int)
+	count := 0
+	scanner := bufio.NewScanner(&buf)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if matches := goroutineRecordHeader.FindStringSubmatch(line); matches != nil {
+			count, _ = strconv.Atoi(matches[1])
+			continue
+		}
+		if !strings.HasPrefix(line, "# labels:") {
+			continue
+		}
+		matches := labelPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		value, err := strconv.Unquote(matches[1])
+		if err != nil {
+			return nil, err
+		}
+		counts[value] += count
+	}
+	return counts, scanner.Err()
+}
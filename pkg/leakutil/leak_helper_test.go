@@ -14,8 +14,11 @@
 package leakutil
 
 import (
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 )
 
@@ -34,3 +37,36 @@ func TestMain(m *testing.M) {
 
 	SetUpLeakTest(m, opts...)
 }
+
+func TestCountGoroutinesByLabel(t *testing.T) {
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	DoWithLabel(ChangefeedLabel, "default/test", func() {
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-stop
+			}()
+		}
+		DoWithoutLabels(func() {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-stop
+			}()
+		})
+	})
+
+	counts, err := CountGoroutinesByLabel(ChangefeedLabel)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"default/test": 3}, counts)
+
+	close(stop)
+	wg.Wait()
+	// The goroutines may not exit right after calling wg.Done.
+	require.Eventually(t, func() bool {
+		counts, err := CountGoroutinesByLabel(ChangefeedLabel)
+		return err == nil && len(counts) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
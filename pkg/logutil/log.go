@@ -78,13 +78,7 @@ func SetLogLevel(level string) error {
 	if strings.EqualFold(oldLevel.String(), level) {
 		return nil
 	}
-	var lv zapcore.Level
-	err := lv.UnmarshalText([]byte(level))
-	if err != nil {
-		return errors.Trace(err)
-	}
-	log.SetLevel(lv)
-	return nil
+	return SetModuleLogLevel("", level, 0)
 }
 
 // loggerOp is the op for logger control
@@ -162,7 +156,7 @@ func InitLogger(cfg *Config, opts ...LoggerOpt) error {
 
 	// Do not log stack traces at all, as we'll get the stack trace from the
 	// error itself.
-	lg = lg.WithOptions(zap.AddStacktrace(zap.DPanicLevel))
+	lg = lg.WithOptions(zap.AddStacktrace(zap.DPanicLevel), zap.WrapCore(wrapModuleCore))
 	log.ReplaceGlobals(lg, globalP)
 
 	return initOptionalComponent(&op, cfg)
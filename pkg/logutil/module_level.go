@@ -0,0 +1,220 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Modules whose log level can be changed separately from the global one.
+const (
+	ModuleScheduler = "scheduler"
+	ModuleSink      = "sink"
+	ModuleKVClient  = "kvclient"
+	ModuleSorter    = "sorter"
+)
+
+// modulePaths are the source paths of modules, a log entry belongs to the
+// module if its caller is in one of the paths.
+var modulePaths = map[string][]string{
+	ModuleScheduler: {"/cdc/scheduler/"},
+	ModuleSink:      {"/cdc/sink/", "/pkg/sink/", "/cdc/processor/sinkmanager/"},
+	ModuleKVClient:  {"/cdc/kv/"},
+	ModuleSorter:    {"/cdc/processor/sourcemanager/engine/", "/cdc/puller/memorysorter/"},
+}
+
+// moduleLevels are the log levels of modules.
+type moduleLevels struct {
+	mu     sync.RWMutex
+	levels map[string]zapcore.Level
+	// versions are bumped every time the level of a module is changed, so
+	// that a scheduled revert does not override a newer change.
+	// The empty module is the global log level.
+	versions map[string]uint64
+}
+
+// afterFunc schedules reverting log levels, it's replaced in tests.
+var afterFunc = time.AfterFunc
+
+var globalModuleLevels = &moduleLevels{
+	levels:   make(map[string]zapcore.Level),
+	versions: make(map[string]uint64),
+}
+
+// IsLogModule returns true if the log level of the module can be changed
+// separately.
+func IsLogModule(module string) bool {
+	_, ok := modulePaths[module]
+	return ok
+}
+
+// SetModuleLogLevel changes the log level of a module dynamically, the empty
+// module means the global log level. The change is reverted to the previous
+// level after revertAfter if it's positive.
+func SetModuleLogLevel(module, level string, revertAfter time.Duration) error {
+	var lv zapcore.Level
+	if err := lv.UnmarshalText([]byte(level)); err != nil {
+		return errors.Trace(err)
+	}
+	if module != "" && !IsLogModule(module) {
+		return errors.Errorf("unknown module %s", module)
+	}
+
+	m := globalModuleLevels
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var prev zapcore.Level
+	var hasPrev bool
+	if module == "" {
+		prev, hasPrev = log.GetLevel(), true
+		log.SetLevel(lv)
+	} else {
+		prev, hasPrev = m.levels[module]
+		m.levels[module] = lv
+	}
+	m.versions[module]++
+	if revertAfter <= 0 {
+		return nil
+	}
+
+	version := m.versions[module]
+	afterFunc(revertAfter, func() {
+		if lv, ok := m.revert(module, version, prev, hasPrev); ok {
+			log.Warn("log level reverted",
+				zap.String("module", module), zap.Stringer("level", lv))
+		}
+	})
+	return nil
+}
+
+// revert reverts the log level of the module to prev if it's not changed
+// since the given version, and returns the reverted log level. A module
+// without prev follows the global log level after reverted.
+func (m *moduleLevels) revert(
+	module string, version uint64, prev zapcore.Level, hasPrev bool,
+) (zapcore.Level, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.versions[module] != version {
+		return prev, false
+	}
+	if module == "" {
+		log.SetLevel(prev)
+	} else if hasPrev {
+		m.levels[module] = prev
+	} else {
+		delete(m.levels, module)
+		prev = log.GetLevel()
+	}
+	m.versions[module]++
+	return prev, true
+}
+
+// GetModuleLogLevels returns the log levels of modules which are set
+// separately from the global one.
+func GetModuleLogLevels() map[string]zapcore.Level {
+	m := globalModuleLevels
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	levels := make(map[string]zapcore.Level, len(m.levels))
+	for module, lv := range m.levels {
+		levels[module] = lv
+	}
+	return levels
+}
+
+// enabled returns true if any module enables the level.
+func (m *moduleLevels) enabled(lvl zapcore.Level) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, lv := range m.levels {
+		if lv.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty returns true if no module has its own log level.
+func (m *moduleLevels) isEmpty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.levels) == 0
+}
+
+// levelOf returns the log level of the module which the file belongs to.
+func (m *moduleLevels) levelOf(file string) (zapcore.Level, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for module, lv := range m.levels {
+		for _, path := range modulePaths[module] {
+			if strings.Contains(file, path) {
+				return lv, true
+			}
+		}
+	}
+	return zapcore.InfoLevel, false
+}
+
+// moduleCore wraps a core to apply the log levels of modules. It's a no-op
+// unless some module has its own log level.
+type moduleCore struct {
+	zapcore.Core
+	levels *moduleLevels
+}
+
+func wrapModuleCore(core zapcore.Core) zapcore.Core {
+	return &moduleCore{Core: core, levels: globalModuleLevels}
+}
+
+// Enabled implements zapcore.Core.
+func (c *moduleCore) Enabled(lvl zapcore.Level) bool {
+	return c.Core.Enabled(lvl) || c.levels.enabled(lvl)
+}
+
+// With implements zapcore.Core.
+func (c *moduleCore) With(fields []zapcore.Field) zapcore.Core {
+	return &moduleCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+// Check implements zapcore.Core. The caller of the entry is unknown until
+// it's written, so the entry is filtered by its module in Write.
+func (c *moduleCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.levels.isEmpty() {
+		return c.Core.Check(ent, ce)
+	}
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (c *moduleCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if lv, ok := c.levels.levelOf(ent.Caller.File); ok {
+		if !lv.Enabled(ent.Level) {
+			return nil
+		}
+	} else if !c.Core.Enabled(ent.Level) {
+		return nil
+	}
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSetModuleLogLevel(t *testing.T) {
+	// Logs of this file belong to the test module.
+	const moduleTest = "test"
+	modulePaths[moduleTest] = []string{"/pkg/logutil/module_level_test.go"}
+	// Reverts are triggered manually.
+	var reverts []func()
+	afterFunc = func(d time.Duration, f func()) *time.Timer {
+		reverts = append(reverts, f)
+		return nil
+	}
+	defer func() {
+		afterFunc = time.AfterFunc
+		globalModuleLevels.levels = make(map[string]zapcore.Level)
+		delete(modulePaths, moduleTest)
+	}()
+
+	var buffer zaptest.Buffer
+	err := InitLogger(&Config{Level: "info"}, WithOutputWriteSyncer(&buffer))
+	require.NoError(t, err)
+
+	log.Debug("debug 1")
+	require.NotContains(t, buffer.Stripped(), "debug 1")
+
+	// Other modules are not affected.
+	require.NoError(t, SetModuleLogLevel(ModuleScheduler, "debug", 0))
+	log.Debug("debug 2")
+	require.NotContains(t, buffer.Stripped(), "debug 2")
+	log.Info("info 2")
+	require.Contains(t, buffer.Stripped(), "info 2")
+
+	require.NoError(t, SetModuleLogLevel(moduleTest, "debug", 0))
+	log.Debug("debug 3")
+	require.Contains(t, buffer.Stripped(), "debug 3")
+	require.Equal(t, zapcore.InfoLevel, log.GetLevel())
+
+	// A module can be less verbose than the global log level.
+	require.NoError(t, SetModuleLogLevel(moduleTest, "warn", 0))
+	log.Info("info 4")
+	require.NotContains(t, buffer.Stripped(), "info 4")
+	log.Warn("warn 4")
+	require.Contains(t, buffer.Stripped(), "warn 4")
+
+	// Revert to the previous level.
+	require.NoError(t, SetModuleLogLevel(moduleTest, "debug", time.Minute))
+	require.Equal(t, zapcore.DebugLevel, GetModuleLogLevels()[moduleTest])
+	require.Len(t, reverts, 1)
+	reverts[0]()
+	require.Equal(t, zapcore.WarnLevel, GetModuleLogLevels()[moduleTest])
+
+	// A newer change is not reverted by an older one.
+	require.NoError(t, SetModuleLogLevel(moduleTest, "debug", time.Minute))
+	require.NoError(t, SetModuleLogLevel(moduleTest, "error", 0))
+	require.Len(t, reverts, 2)
+	reverts[1]()
+	require.Equal(t, zapcore.ErrorLevel, GetModuleLogLevels()[moduleTest])
+
+	// Revert the global log level, a module without its own level is
+	// removed after reverted.
+	require.NoError(t, SetModuleLogLevel("", "debug", time.Minute))
+	require.NoError(t, SetModuleLogLevel(ModuleSink, "debug", time.Minute))
+	require.Equal(t, zapcore.DebugLevel, log.GetLevel())
+	require.Len(t, reverts, 4)
+	reverts[2]()
+	reverts[3]()
+	require.Equal(t, zapcore.InfoLevel, log.GetLevel())
+	require.NotContains(t, GetModuleLogLevels(), ModuleSink)
+	require.Contains(t, buffer.Stripped(), `"log level reverted"] [module=sink] [level=info]`)
+
+	require.Error(t, SetModuleLogLevel("unknown", "debug", 0))
+	require.Error(t, SetModuleLogLevel(moduleTest, "badlevel", 0))
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2p
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/proto/p2p"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	corruptionReasonChecksum    = "checksum"
+	corruptionReasonSequenceGap = "sequence_gap"
+)
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// messageChecksum computes the CRC32C checksum of a message entry,
+// covering its topic, sequence and content.
+// It never returns zero, because a zero checksum on the wire means
+// the sender has not computed one.
+func messageChecksum(entry *p2p.MessageEntry) uint32 {
+	var seqBuf [8]byte
+	binary.BigEndian.PutUint64(seqBuf[:], uint64(entry.GetSequence()))
+
+	sum := crc32.Update(0, castagnoliTable, []byte(entry.GetTopic()))
+	sum = crc32.Update(sum, castagnoliTable, seqBuf[:])
+	sum = crc32.Update(sum, castagnoliTable, entry.GetContent())
+	if sum == 0 {
+		sum = 1
+	}
+	return sum
+}
+
+// streamVerifier verifies the integrity of the messages received
+// from one gRPC stream. It is not thread-safe.
+type streamVerifier struct {
+	// lastSeqs stores the sequence of the last message received
+	// for each topic on the stream.
+	lastSeqs map[Topic]Seq
+
+	metricsChecksumMismatch prometheus.Counter
+	metricsSequenceGap      prometheus.Counter
+}
+
+func newStreamVerifier(senderAddr string) *streamVerifier {
+	return &streamVerifier{
+		lastSeqs: make(map[Topic]Seq),
+		metricsChecksumMismatch: serverMessageCorruptionCount.With(prometheus.Labels{
+			"from":   senderAddr,
+			"reason": corruptionReasonChecksum,
+		}),
+		metricsSequenceGap: serverMessageCorruptionCount.With(prometheus.Labels{
+			"from":   senderAddr,
+			"reason": corruptionReasonSequenceGap,
+		}),
+	}
+}
+
+// Verify checks the checksums of the entries, if present, and that
+// sequences within each topic are continuous on the stream.
+// The first message of a topic is accepted with any sequence, because
+// the client resends unacknowledged messages when a stream is established.
+// An error is returned on the first corrupted entry, in which case
+// the whole batch must be discarded and the stream reset.
+func (v *streamVerifier) Verify(entries []*p2p.MessageEntry) error {
+	for _, entry := range entries {
+		if expected := entry.GetChecksum(); expected != 0 {
+			if actual := messageChecksum(entry); actual != expected {
+				v.metricsChecksumMismatch.Inc()
+				log.Warn("peer message checksum mismatch",
+					zap.String("topic", entry.GetTopic()),
+					zap.Int64("seq", entry.GetSequence()),
+					zap.Uint32("expected", expected),
+					zap.Uint32("actual", actual))
+				return cerror.ErrPeerMessageChecksumMismatch.GenWithStackByArgs(
+					entry.GetTopic(), entry.GetSequence(), expected, actual)
+			}
+		}
+
+		lastSeq, ok := v.lastSeqs[entry.GetTopic()]
+		if ok && entry.GetSequence() != lastSeq+1 {
+			v.metricsSequenceGap.Inc()
+			log.Warn("peer message sequence gap",
+				zap.String("topic", entry.GetTopic()),
+				zap.Int64("expectedSeq", lastSeq+1),
+				zap.Int64("actualSeq", entry.GetSequence()))
+			return cerror.ErrPeerMessageSequenceGap.GenWithStackByArgs(
+				entry.GetTopic(), lastSeq+1, entry.GetSequence())
+		}
+		v.lastSeqs[entry.GetTopic()] = entry.GetSequence()
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/proto/p2p"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageChecksum(t *testing.T) {
+	t.Parallel()
+
+	entry := &p2p.MessageEntry{
+		Topic:    "test-topic",
+		Content:  []byte("test-content"),
+		Sequence: 1,
+	}
+	sum := messageChecksum(entry)
+	require.NotZero(t, sum)
+	require.Equal(t, sum, messageChecksum(entry))
+
+	// Any change to the topic, sequence or content changes the checksum.
+	require.NotEqual(t, sum, messageChecksum(&p2p.MessageEntry{
+		Topic: "test-topib", Content: []byte("test-content"), Sequence: 1,
+	}))
+	require.NotEqual(t, sum, messageChecksum(&p2p.MessageEntry{
+		Topic: "test-topic", Content: []byte("test-content"), Sequence: 2,
+	}))
+	require.NotEqual(t, sum, messageChecksum(&p2p.MessageEntry{
+		Topic: "test-topic", Content: []byte("test-contenu"), Sequence: 1,
+	}))
+
+	// The checksum survives a round trip through protobuf.
+	entry.Checksum = sum
+	data, err := entry.Marshal()
+	require.NoError(t, err)
+	var decoded p2p.MessageEntry
+	require.NoError(t, decoded.Unmarshal(data))
+	require.Equal(t, *entry, decoded)
+}
+
+func newChecksummedEntry(topic string, seq int64, content string) *p2p.MessageEntry {
+	entry := &p2p.MessageEntry{
+		Topic:    topic,
+		Content:  []byte(content),
+		Sequence: seq,
+	}
+	entry.Checksum = messageChecksum(entry)
+	return entry
+}
+
+func TestStreamVerifierChecksum(t *testing.T) {
+	t.Parallel()
+
+	verifier := newStreamVerifier("test-addr")
+	err := verifier.Verify([]*p2p.MessageEntry{
+		newChecksummedEntry("topic-1", 1, "a"),
+		// Entries without a checksum are accepted.
+		{Topic: "topic-1", Content: []byte("b"), Sequence: 2},
+	})
+	require.NoError(t, err)
+
+	corrupted := newChecksummedEntry("topic-1", 3, "c")
+	corrupted.Content[0] ^= 0x1
+	err = verifier.Verify([]*p2p.MessageEntry{corrupted})
+	require.Regexp(t, "CDC:ErrPeerMessageChecksumMismatch", err)
+}
+
+func TestStreamVerifierSequenceGap(t *testing.T) {
+	t.Parallel()
+
+	verifier := newStreamVerifier("test-addr")
+	// The first message of each topic can have any sequence.
+	err := verifier.Verify([]*p2p.MessageEntry{
+		newChecksummedEntry("topic-1", 5, "a"),
+		newChecksummedEntry("topic-2", 1, "a"),
+		newChecksummedEntry("topic-1", 6, "b"),
+		newChecksummedEntry("topic-2", 2, "b"),
+	})
+	require.NoError(t, err)
+
+	err = verifier.Verify([]*p2p.MessageEntry{
+		newChecksummedEntry("topic-1", 8, "c"),
+	})
+	require.Regexp(t, "CDC:ErrPeerMessageSequenceGap", err)
+
+	verifier = newStreamVerifier("test-addr")
+	err = verifier.Verify([]*p2p.MessageEntry{
+		newChecksummedEntry("topic-1", 1, "a"),
+		newChecksummedEntry("topic-1", 1, "a"),
+	})
+	require.Regexp(t, "CDC:ErrPeerMessageSequenceGap", err)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/security"
+	"google.golang.org/grpc"
 )
 
 var _ MessageClient = &grpcMessageClient{}
@@ -62,6 +63,13 @@ type MessageClientConfig struct {
 	ClientVersion string
 	// MaxRecvMsgSize is the maximum message size in bytes TiCDC can receive.
 	MaxRecvMsgSize int
+	// EnableChecksum enables attaching a checksum to every message sent,
+	// so that the server can detect corrupted messages.
+	EnableChecksum bool
+	// DialOptions returns extra gRPC dial options, it's called every time the
+	// client connects to the server, so that changed options take effect on
+	// reconnection. It can be nil.
+	DialOptions func() []grpc.DialOption
 }
 
 type localMessageClient struct {
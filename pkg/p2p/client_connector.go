@@ -37,6 +37,8 @@ type clientConnectOptions struct {
 	// timeout specifies the DialTimeout of the connection.
 	timeout        time.Duration
 	maxRecvMsgSize int
+	// dialOptions are appended to the default dial options.
+	dialOptions []grpc.DialOption
 }
 
 type cancelFn = func()
@@ -61,15 +63,16 @@ func (c *clientConnectorImpl) Connect(opts clientConnectOptions) (proto.CDCPeerT
 		return nil, nil, errors.Trace(err)
 	}
 
-	conn, err := grpc.Dial(
-		opts.addr,
+	dialOptions := append([]grpc.DialOption{
 		securityOption,
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(opts.maxRecvMsgSize)),
 		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
 			return net.DialTimeout(opts.network, s, opts.timeout)
 		}),
 		// We do not need a unary interceptor since we are not making any unary calls.
-		grpc.WithStreamInterceptor(grpcClientMetrics.StreamClientInterceptor()))
+		grpc.WithStreamInterceptor(grpcClientMetrics.StreamClientInterceptor()),
+	}, opts.dialOptions...)
+	conn, err := grpc.Dial(opts.addr, dialOptions...)
 	if err != nil {
 		log.Warn("gRPC dial error", zap.Error(err))
 		return nil, nil, errors.Trace(err)
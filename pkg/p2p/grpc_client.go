@@ -31,6 +31,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
 	gRPCPeer "google.golang.org/grpc/peer"
 )
 
@@ -117,12 +118,17 @@ func (c *grpcMessageClient) Run(
 			return errors.Trace(err)
 		}
 
+		var dialOptions []grpc.DialOption
+		if c.config.DialOptions != nil {
+			dialOptions = c.config.DialOptions()
+		}
 		gRPCClient, release, err := c.connector.Connect(clientConnectOptions{
 			network:        network,
 			addr:           addr,
 			credential:     credential,
 			timeout:        c.config.DialTimeout,
 			maxRecvMsgSize: c.config.MaxRecvMsgSize,
+			dialOptions:    dialOptions,
 		})
 		if err != nil {
 			log.Warn("peer-message client: failed to connect to server",
@@ -237,6 +243,10 @@ func (c *grpcMessageClient) runTx(ctx context.Context, stream MessageClientStrea
 				zap.Int64("seq", msg.Sequence))
 		}
 
+		if c.config.EnableChecksum {
+			msg.Checksum = messageChecksum(msg)
+		}
+
 		tpk.sentMessageMu.Lock()
 		tpk.sentMessages.Push(msg)
 		tpk.sentMessageMu.Unlock()
@@ -288,6 +298,7 @@ func (c *grpcMessageClient) retrySending(ctx context.Context, stream MessageClie
 				Topic:    msg.Topic,
 				Content:  msg.Content,
 				Sequence: msg.Sequence,
+				Checksum: msg.Checksum,
 			})
 			if err != nil {
 				tpk.sentMessageMu.Unlock()
@@ -75,6 +75,13 @@ var (
 		Help:      "count of received repeated messages",
 	}, []string{"from", "topic"})
 
+	serverMessageCorruptionCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "message_server",
+		Name:      "corruption_count",
+		Help:      "count of received messages detected as corrupted",
+	}, []string{"from", "reason"})
+
 	grpcClientMetrics = grpc_prometheus.NewClientMetrics(func(opts *prometheus.CounterOpts) {
 		opts.Namespace = "ticdc"
 		opts.Subsystem = "message_client"
@@ -111,6 +118,7 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(serverMessageBatchBytesHistogram)
 	registry.MustRegister(serverAckCount)
 	registry.MustRegister(serverRepeatedMessageCount)
+	registry.MustRegister(serverMessageCorruptionCount)
 	registry.MustRegister(grpcClientMetrics)
 	registry.MustRegister(clientCount)
 	registry.MustRegister(clientMessageCount)
@@ -59,6 +59,9 @@ type MessageServerConfig struct {
 	ServerVersion string
 	// MaxRecvMsgSize is the maximum message size in bytes TiCDC can receive.
 	MaxRecvMsgSize int
+	// EnableIntegrityCheck enables verifying message checksums and detecting
+	// sequence gaps on each stream. A stream carrying a corrupted message is reset.
+	EnableIntegrityCheck bool
 
 	// After a duration of this time if the server doesn't see any activity it
 	// pings the client to see if the transport is still alive.
@@ -808,6 +811,11 @@ func (m *MessageServer) receive(
 		"from": streamHandle.GetStreamMeta().SenderAdvertisedAddr,
 	})
 
+	var verifier *streamVerifier
+	if m.config.EnableIntegrityCheck {
+		verifier = newStreamVerifier(streamHandle.GetStreamMeta().SenderAdvertisedAddr)
+	}
+
 	for {
 		failpoint.Inject("ServerInjectServerRestart", func() {
 			_ = stream.Send(&p2p.SendMessageResponse{
@@ -831,6 +839,13 @@ func (m *MessageServer) receive(
 		metricsServerMessageCount.Add(float64(batchSize))
 
 		entries := packet.GetEntries()
+		if verifier != nil {
+			// Returning an error resets the stream, and the client
+			// will resend all unacknowledged messages on a new one.
+			if err := verifier.Verify(entries); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		if batchSize > 0 {
 			if messageServerReportsIndividualMessageSize /* true for now */ {
 				// Note that this can be costly if the number of messages is huge.
@@ -863,6 +863,78 @@ func TestReceiverIDMismatch(t *testing.T) {
 	wg.Wait()
 }
 
+func TestServerChecksumMismatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), defaultTimeout)
+	defer cancel()
+
+	server, newClient, closer := newServerForTesting(t, "test-server-1")
+	defer closer()
+	server.config.EnableIntegrityCheck = true
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := server.Run(ctx, nil)
+		require.Regexp(t, ".*context canceled.*", err.Error())
+	}()
+
+	var lastIndex int64
+	_ = mustAddHandler(ctx, t, server, "test-topic-1",
+		&testTopicContent{}, func(senderID string, i interface{}) error {
+			atomic.StoreInt64(&lastIndex, i.(*testTopicContent).Index)
+			return nil
+		})
+
+	client, closeClient := newClient()
+	defer closeClient()
+
+	stream, err := client.SendMessage(ctx)
+	require.NoError(t, err)
+
+	err = stream.Send(&p2p.MessagePacket{
+		Meta: &p2p.StreamMeta{
+			SenderId:   "test-client-1",
+			ReceiverId: "test-server-1",
+			Epoch:      0,
+		},
+	})
+	require.NoError(t, err)
+
+	var entries []*p2p.MessageEntry
+	for i := 1; i <= 2; i++ {
+		content, err := json.Marshal(&testTopicContent{Index: int64(i)})
+		require.NoError(t, err)
+		entries = append(entries, newChecksummedEntry("test-topic-1", int64(i), string(content)))
+	}
+	// Simulates a bit flip on the wire.
+	entries[1].Content[len(entries[1].Content)-2] ^= 0x1
+
+	err = stream.Send(&p2p.MessagePacket{Entries: entries[:1]})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&lastIndex) == 1
+	}, defaultTimeout, time.Millisecond*10)
+
+	err = stream.Send(&p2p.MessagePacket{Entries: entries[1:]})
+	require.NoError(t, err)
+
+	for {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		if resp.ExitReason == p2p.ExitReason_OK {
+			continue
+		}
+		require.Equal(t, p2p.ExitReason_UNKNOWN, resp.ExitReason)
+		require.Regexp(t, "ErrPeerMessageChecksumMismatch", resp.ErrorMessage)
+		break
+	}
+	require.Equal(t, int64(1), atomic.LoadInt64(&lastIndex))
+
+	cancel()
+	wg.Wait()
+}
+
 func TestServerDataLossAfterUnregisterHandle(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
 	defer cancel()
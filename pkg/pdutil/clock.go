@@ -22,7 +22,6 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/tikv/client-go/v2/oracle"
-	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
 )
 
@@ -38,8 +37,8 @@ type Clock interface {
 
 // clock cache time get from PD periodically and cache it
 type clock struct {
-	pdClient pd.Client
-	mu       struct {
+	tsoClient TSOClient
+	mu        struct {
 		sync.RWMutex
 		// The time encoded in PD ts.
 		tsEventTime time.Time
@@ -53,13 +52,13 @@ type clock struct {
 }
 
 // NewClock return a new clock
-func NewClock(ctx context.Context, pdClient pd.Client) (*clock, error) {
+func NewClock(ctx context.Context, tsoClient TSOClient) (*clock, error) {
 	ret := &clock{
-		pdClient:       pdClient,
+		tsoClient:      tsoClient,
 		stopCh:         make(chan struct{}, 1),
 		updateInterval: pdTimeUpdateInterval,
 	}
-	physical, _, err := pdClient.GetTS(ctx)
+	physical, _, err := tsoClient.GetTS(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -83,14 +82,17 @@ func (c *clock) Run(ctx context.Context) {
 			return
 		case <-ticker.C:
 			err := retry.Do(ctx, func() error {
-				physical, _, err := c.pdClient.GetTS(ctx)
+				// Timestamps requested by others within the interval are
+				// reused, which are accurate enough for the clock.
+				physical, _, fetchedAt, err := c.tsoClient.GetRecentTS(ctx, c.updateInterval)
 				if err != nil {
 					log.Info("get time from pd failed, retry later", zap.Error(err))
 					return err
 				}
 				c.mu.Lock()
 				c.mu.tsEventTime = oracle.GetTimeFromTS(oracle.ComposeTS(physical, 0))
-				c.mu.tsProcessingTime = time.Now()
+				// The timestamp may be cached, it's received at fetchedAt.
+				c.mu.tsProcessingTime = fetchedAt
 				c.mu.err = nil
 				c.mu.Unlock()
 				return nil
@@ -0,0 +1,100 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdutil
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/preflight"
+	"go.uber.org/zap"
+)
+
+// ClockSkewGuard measures the skew between the local clock and PD TSO
+// periodically. A skewed local clock silently inflates or deflates lags and
+// breaks the ts math based on the local time, so the capture refuses to own
+// changefeeds while the skew exceeds the threshold.
+type ClockSkewGuard struct {
+	getTS    preflight.GetTSFunc
+	interval time.Duration
+	maxSkew  time.Duration
+	// onExceeded is called after each check which finds the skew exceeded.
+	onExceeded func()
+
+	exceeded atomic.Bool
+}
+
+// NewClockSkewGuard returns a new ClockSkewGuard.
+func NewClockSkewGuard(
+	tsoClient TSOClient, interval, maxSkew time.Duration, onExceeded func(),
+) *ClockSkewGuard {
+	return &ClockSkewGuard{
+		getTS:      tsoClient.GetTS,
+		interval:   interval,
+		maxSkew:    maxSkew,
+		onExceeded: onExceeded,
+	}
+}
+
+// Run measures the clock skew periodically until the ctx is canceled.
+func (g *ClockSkewGuard) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	for {
+		g.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Exceeded returns whether the clock skew exceeds the threshold.
+func (g *ClockSkewGuard) Exceeded() bool {
+	return g.exceeded.Load()
+}
+
+func (g *ClockSkewGuard) check(ctx context.Context) {
+	skew, err := preflight.MeasureClockSkew(ctx, g.getTS)
+	if err != nil {
+		// Keep the last state, since it's unknown whether the clock recovers.
+		if ctx.Err() == nil {
+			log.Warn("failed to measure clock skew", zap.Error(err))
+		}
+		return
+	}
+	clockSkewGauge.Set(skew.Seconds())
+
+	exceeded := skew > g.maxSkew || skew < -g.maxSkew
+	if exceeded {
+		clockSkewExceededGauge.Set(1)
+	} else {
+		clockSkewExceededGauge.Set(0)
+	}
+	if g.exceeded.Swap(exceeded) != exceeded {
+		if exceeded {
+			log.Warn("clock skew exceeds the threshold, refuse to own changefeeds",
+				zap.Duration("skew", skew), zap.Duration("maxSkew", g.maxSkew))
+		} else {
+			log.Info("clock skew recovers",
+				zap.Duration("skew", skew), zap.Duration("maxSkew", g.maxSkew))
+		}
+	}
+	if exceeded && g.onExceeded != nil {
+		g.onExceeded()
+	}
+}
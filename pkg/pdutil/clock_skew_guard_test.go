@@ -0,0 +1,89 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkewGuard(t *testing.T) {
+	ctx := context.Background()
+
+	var offset time.Duration
+	var getTSErr error
+	exceededCount := 0
+	g := &ClockSkewGuard{
+		getTS: func(ctx context.Context) (int64, int64, error) {
+			if getTSErr != nil {
+				return 0, 0, getTSErr
+			}
+			return time.Now().Add(offset).UnixMilli(), 0, nil
+		},
+		interval:   time.Second,
+		maxSkew:    time.Second,
+		onExceeded: func() { exceededCount++ },
+	}
+
+	g.check(ctx)
+	require.False(t, g.Exceeded())
+	require.Equal(t, float64(0), testutil.ToFloat64(clockSkewExceededGauge))
+	require.Equal(t, 0, exceededCount)
+
+	// The local clock is behind PD.
+	offset = time.Minute
+	g.check(ctx)
+	require.True(t, g.Exceeded())
+	require.Equal(t, float64(1), testutil.ToFloat64(clockSkewExceededGauge))
+	require.InDelta(t, -60, testutil.ToFloat64(clockSkewGauge), 1)
+	require.Equal(t, 1, exceededCount)
+
+	// Keep the last state if PD is unavailable.
+	getTSErr = errors.New("pd is unavailable")
+	g.check(ctx)
+	require.True(t, g.Exceeded())
+	require.Equal(t, 1, exceededCount)
+
+	getTSErr = nil
+	offset = 0
+	g.check(ctx)
+	require.False(t, g.Exceeded())
+	require.Equal(t, float64(0), testutil.ToFloat64(clockSkewExceededGauge))
+	require.Equal(t, 1, exceededCount)
+}
+
+func TestClockSkewGuardRun(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewClockSkewGuard(NewTSOClient(&MockPDClient{}),
+		10*time.Millisecond, time.Hour, nil)
+	done := make(chan struct{})
+	go func() {
+		g.Run(ctx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout")
+	}
+	require.False(t, g.Exceeded())
+}
@@ -36,7 +36,7 @@ func (m *MockPDClient) GetTS(ctx context.Context) (int64, int64, error) {
 func TestTimeFromPD(t *testing.T) {
 	t.Parallel()
 	mockPDClient := &MockPDClient{}
-	clock, err := NewClock(context.Background(), mockPDClient)
+	clock, err := NewClock(context.Background(), NewTSOClient(mockPDClient))
 	require.NoError(t, err)
 
 	go clock.Run(context.Background())
@@ -60,7 +60,7 @@ func TestEventTimeAndProcessingTime(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	mockPDClient := &MockPDClient{}
-	clock, err := NewClock(ctx, mockPDClient)
+	clock, err := NewClock(ctx, NewTSOClient(mockPDClient))
 	require.NoError(t, err)
 
 	// Disable update in test by setting a very long update interval.
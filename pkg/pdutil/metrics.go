@@ -0,0 +1,64 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdutil
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// tsoRequestCounter counts TSO requests by type: "get" for calls of
+	// GetTS, "pd" for requests sent to PD and "cached" for calls served by
+	// the cache.
+	tsoRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "pd",
+			Name:      "tso_request_count",
+			Help:      "The number of TSO requests",
+		}, []string{"type"})
+
+	tsoBatchSizeHistogram = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "pd",
+			Name:      "tso_batch_size",
+			Help:      "The number of callers sharing a TSO request sent to PD",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		})
+
+	// clockSkewGauge is the skew between the local clock and PD TSO,
+	// it's positive if the local clock is ahead of PD.
+	clockSkewGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "pd",
+			Name:      "clock_skew_seconds",
+			Help:      "The skew between the local clock and PD TSO in seconds",
+		})
+
+	clockSkewExceededGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "pd",
+			Name:      "clock_skew_exceeded",
+			Help:      "Whether the clock skew exceeds the threshold, 1 for exceeded",
+		})
+)
+
+// InitMetrics registers all metrics in this file
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(tsoRequestCounter)
+	registry.MustRegister(tsoBatchSizeHistogram)
+	registry.MustRegister(clockSkewGauge)
+	registry.MustRegister(clockSkewExceededGauge)
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	pd "github.com/tikv/pd/client"
+)
+
+// tsoRequestTimeout is the timeout of a TSO request sent to PD. Callers are
+// not blocked by it as they can give up waiting with their own contexts.
+const tsoRequestTimeout = 10 * time.Second
+
+// TSOClient is a TSO client shared by all changefeeds of an upstream on a
+// capture, it reduces TSO requests sent to PD.
+type TSOClient interface {
+	// GetTS returns a timestamp allocated by PD after the call is made.
+	// Concurrent calls are batched into a single request to PD, so they may
+	// get the same timestamp.
+	GetTS(ctx context.Context) (physical int64, logical int64, err error)
+	// GetRecentTS returns a timestamp allocated by PD within maxStaleness
+	// and the local time it's received, a new timestamp is requested if the
+	// cached one is older than that. It's only for non-critical uses, e.g.
+	// metrics and lag estimation.
+	GetRecentTS(
+		ctx context.Context, maxStaleness time.Duration,
+	) (physical int64, logical int64, fetchedAt time.Time, err error)
+}
+
+type tsoBatch struct {
+	// waiters is the number of callers waiting for the batch.
+	waiters   int
+	done      chan struct{}
+	physical  int64
+	logical   int64
+	fetchedAt time.Time
+	err       error
+}
+
+type tsoClient struct {
+	pdClient pd.Client
+
+	mu struct {
+		sync.Mutex
+		// inflight is true if a request is being sent to PD.
+		inflight bool
+		// next is the batch of callers arriving while a request is in
+		// flight, they share the request sent after the in-flight one.
+		next *tsoBatch
+		// The latest timestamp and the time it's received.
+		physical  int64
+		logical   int64
+		fetchedAt time.Time
+	}
+}
+
+// NewTSOClient returns a new TSOClient.
+func NewTSOClient(pdClient pd.Client) TSOClient {
+	return &tsoClient{pdClient: pdClient}
+}
+
+// GetTS implements TSOClient.GetTS.
+func (c *tsoClient) GetTS(ctx context.Context) (int64, int64, error) {
+	tsoRequestCounter.WithLabelValues("get").Inc()
+	batch, err := c.join(ctx)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	return batch.physical, batch.logical, batch.err
+}
+
+// join joins the next batch and waits for it to be done.
+func (c *tsoClient) join(ctx context.Context) (*tsoBatch, error) {
+	c.mu.Lock()
+	if c.mu.next == nil {
+		c.mu.next = &tsoBatch{done: make(chan struct{})}
+	}
+	batch := c.mu.next
+	batch.waiters++
+	if !c.mu.inflight {
+		c.mu.inflight = true
+		c.mu.next = nil
+		go c.send(batch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	case <-batch.done:
+		return batch, nil
+	}
+}
+
+// send requests timestamps for the batch, and then for the batches arriving
+// in the meantime until there are none.
+func (c *tsoClient) send(batch *tsoBatch) {
+	for batch != nil {
+		tsoRequestCounter.WithLabelValues("pd").Inc()
+		tsoBatchSizeHistogram.Observe(float64(batch.waiters))
+		ctx, cancel := context.WithTimeout(context.Background(), tsoRequestTimeout)
+		batch.physical, batch.logical, batch.err = c.pdClient.GetTS(ctx)
+		batch.err = errors.Trace(batch.err)
+		cancel()
+		batch.fetchedAt = time.Now()
+		close(batch.done)
+
+		c.mu.Lock()
+		if batch.err == nil {
+			c.mu.physical, c.mu.logical = batch.physical, batch.logical
+			c.mu.fetchedAt = batch.fetchedAt
+		}
+		batch = c.mu.next
+		c.mu.next = nil
+		if batch == nil {
+			c.mu.inflight = false
+		}
+		c.mu.Unlock()
+	}
+}
+
+// GetRecentTS implements TSOClient.GetRecentTS.
+func (c *tsoClient) GetRecentTS(
+	ctx context.Context, maxStaleness time.Duration,
+) (int64, int64, time.Time, error) {
+	c.mu.Lock()
+	physical, logical, fetchedAt := c.mu.physical, c.mu.logical, c.mu.fetchedAt
+	c.mu.Unlock()
+	if !fetchedAt.IsZero() && time.Since(fetchedAt) <= maxStaleness {
+		tsoRequestCounter.WithLabelValues("cached").Inc()
+		return physical, logical, fetchedAt, nil
+	}
+	tsoRequestCounter.WithLabelValues("get").Inc()
+	batch, err := c.join(ctx)
+	if err != nil {
+		return 0, 0, time.Time{}, errors.Trace(err)
+	}
+	return batch.physical, batch.logical, batch.fetchedAt, batch.err
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdutil
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
+)
+
+// blockingPDClient returns increasing physical timestamps, and blocks until
+// unblocked if block is set.
+type blockingPDClient struct {
+	pd.Client
+	block chan struct{}
+	calls atomic.Int64
+	err   error
+}
+
+func (m *blockingPDClient) GetTS(ctx context.Context) (int64, int64, error) {
+	n := m.calls.Add(1)
+	if m.block != nil {
+		select {
+		case <-ctx.Done():
+			return 0, 0, ctx.Err()
+		case <-m.block:
+		}
+	}
+	if m.err != nil {
+		return 0, 0, m.err
+	}
+	return n, 0, nil
+}
+
+func waiters(c *tsoClient) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.next == nil {
+		return 0
+	}
+	return c.mu.next.waiters
+}
+
+func TestTSOClientBatch(t *testing.T) {
+	t.Parallel()
+
+	pdClient := &blockingPDClient{block: make(chan struct{})}
+	c := NewTSOClient(pdClient).(*tsoClient)
+	ctx := context.Background()
+
+	var first int64
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		physical, _, err := c.GetTS(ctx)
+		require.Nil(t, err)
+		first = physical
+	}()
+	require.Eventually(t, func() bool {
+		return pdClient.calls.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Callers arriving while a request is in flight share the next request.
+	const callers = 10
+	results := make([]int64, callers)
+	for i := 0; i < callers; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			physical, _, err := c.GetTS(ctx)
+			require.Nil(t, err)
+			results[i] = physical
+		}()
+	}
+	require.Eventually(t, func() bool {
+		return waiters(c) == callers
+	}, 5*time.Second, 10*time.Millisecond)
+	close(pdClient.block)
+	wg.Wait()
+
+	require.Equal(t, int64(2), pdClient.calls.Load())
+	require.Equal(t, int64(1), first)
+	for _, physical := range results {
+		require.Equal(t, int64(2), physical)
+	}
+
+	// A new request is sent once all requests are finished.
+	physical, _, err := c.GetTS(ctx)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), physical)
+}
+
+func TestTSOClientCancel(t *testing.T) {
+	t.Parallel()
+
+	pdClient := &blockingPDClient{block: make(chan struct{})}
+	c := NewTSOClient(pdClient)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := c.GetTS(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The request in flight is not affected by the canceled caller.
+	close(pdClient.block)
+	physical, _, err := c.GetTS(context.Background())
+	require.Nil(t, err)
+	require.Greater(t, physical, int64(0))
+}
+
+func TestTSOClientGetRecentTS(t *testing.T) {
+	t.Parallel()
+
+	pdClient := &blockingPDClient{err: errors.New("pd is unavailable")}
+	c := NewTSOClient(pdClient)
+	ctx := context.Background()
+
+	// Failed requests are not cached.
+	_, _, _, err := c.GetRecentTS(ctx, time.Hour)
+	require.Regexp(t, "pd is unavailable", err)
+	pdClient.err = nil
+	before := time.Now()
+	physical, _, fetchedAt, err := c.GetRecentTS(ctx, time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), physical)
+	require.False(t, fetchedAt.Before(before))
+
+	// The cached timestamp is returned within the staleness, with the time
+	// it's received.
+	time.Sleep(10 * time.Millisecond)
+	physical, _, cachedAt, err := c.GetRecentTS(ctx, time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), physical)
+	require.Equal(t, fetchedAt, cachedAt)
+	require.Equal(t, int64(2), pdClient.calls.Load())
+
+	physical, _, refetchedAt, err := c.GetRecentTS(ctx, 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), physical)
+	require.True(t, refetchedAt.After(fetchedAt))
+}
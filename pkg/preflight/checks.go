@@ -0,0 +1,148 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	diskCheckFileSize  = 16 * 1024 * 1024
+	diskCheckBlockSize = 1024 * 1024
+	diskCheckSyncCount = 8
+)
+
+// SortDirCheck checks the write throughput and the sync latency of the sort
+// dir. It fails if the sort dir can't be written.
+func SortDirCheck(dir string, minThroughput uint64, maxSyncLatency time.Duration) Check {
+	return Check{
+		Name: "sort-dir",
+		Run: func(ctx context.Context) (Level, string) {
+			throughput, syncLatency, err := measureDisk(dir)
+			if err != nil {
+				return LevelFail, fmt.Sprintf("failed to write %s: %s", dir, err)
+			}
+			message := fmt.Sprintf("%s: write throughput %.1fMiB/s, sync latency %s",
+				dir, throughput, syncLatency)
+			if throughput < float64(minThroughput) || syncLatency > maxSyncLatency {
+				return LevelWarn, message
+			}
+			return LevelPass, message
+		},
+	}
+}
+
+// measureDisk writes a temporary file in the dir, and returns the write
+// throughput in MiB/s and the average latency of writing a block and syncing.
+func measureDisk(dir string) (float64, time.Duration, error) {
+	f, err := os.CreateTemp(dir, "preflight-*")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	buf := make([]byte, diskCheckBlockSize)
+	start := time.Now()
+	for written := 0; written < diskCheckFileSize; written += len(buf) {
+		if _, err := f.Write(buf); err != nil {
+			return 0, 0, err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return 0, 0, err
+	}
+	throughput := float64(diskCheckFileSize) / (1024 * 1024) / time.Since(start).Seconds()
+
+	start = time.Now()
+	for i := 0; i < diskCheckSyncCount; i++ {
+		if _, err := f.Write(buf[:4096]); err != nil {
+			return 0, 0, err
+		}
+		if err := f.Sync(); err != nil {
+			return 0, 0, err
+		}
+	}
+	syncLatency := time.Since(start) / diskCheckSyncCount
+	return throughput, syncLatency, nil
+}
+
+// GetTSFunc gets a timestamp from PD TSO.
+type GetTSFunc func(ctx context.Context) (physical int64, logical int64, err error)
+
+// MeasureClockSkew returns the difference between the local clock and the
+// physical time of PD TSO. It's positive if the local clock is ahead of PD.
+// The local time is taken as the middle of the request to cancel the network
+// latency out.
+func MeasureClockSkew(ctx context.Context, getTS GetTSFunc) (time.Duration, error) {
+	before := time.Now()
+	physical, _, err := getTS(ctx)
+	if err != nil {
+		return 0, err
+	}
+	after := time.Now()
+	local := before.Add(after.Sub(before) / 2)
+	return local.Sub(time.UnixMilli(physical)), nil
+}
+
+// ClockSkewCheck checks the skew between the local clock and PD TSO. It fails
+// if the skew exceeds maxSkew, since the skew inflates or deflates the lags
+// and breaks the time based calculations on timestamps.
+func ClockSkewCheck(getTS GetTSFunc, maxSkew time.Duration) Check {
+	return Check{
+		Name: "clock-skew",
+		Run: func(ctx context.Context) (Level, string) {
+			skew, err := MeasureClockSkew(ctx, getTS)
+			if err != nil {
+				return LevelWarn, fmt.Sprintf("failed to get TSO from PD: %s", err)
+			}
+			message := fmt.Sprintf("skew %s, max %s", skew, maxSkew)
+			if skew > maxSkew || skew < -maxSkew {
+				return LevelFail, message
+			}
+			return LevelPass, message
+		},
+	}
+}
+
+// PeersCheck checks the connectivity to other captures.
+func PeersCheck(addrs []string, timeout time.Duration) Check {
+	return Check{
+		Name: "peers",
+		Run: func(ctx context.Context) (Level, string) {
+			var unreachable []string
+			dialer := &net.Dialer{Timeout: timeout}
+			for _, addr := range addrs {
+				conn, err := dialer.DialContext(ctx, "tcp", addr)
+				if err != nil {
+					unreachable = append(unreachable, addr)
+					continue
+				}
+				_ = conn.Close()
+			}
+			if len(unreachable) != 0 {
+				return LevelWarn, fmt.Sprintf("%d of %d peers are unreachable: %s",
+					len(unreachable), len(addrs), strings.Join(unreachable, ","))
+			}
+			return LevelPass, fmt.Sprintf("%d peers are reachable", len(addrs))
+		},
+	}
+}
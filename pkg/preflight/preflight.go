@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// Level is the level of a check result.
+type Level int
+
+const (
+	// LevelPass means the check passes.
+	LevelPass Level = iota
+	// LevelWarn means the check finds a problem which may affect the
+	// performance of the capture.
+	LevelWarn
+	// LevelFail means the check finds a problem which may affect the
+	// correctness of the capture, it's a hard failure.
+	LevelFail
+)
+
+// String implements fmt.Stringer.
+func (l Level) String() string {
+	switch l {
+	case LevelPass:
+		return "pass"
+	case LevelWarn:
+		return "warn"
+	case LevelFail:
+		return "fail"
+	default:
+		return "unknown"
+	}
+}
+
+// Result is the result of a check.
+type Result struct {
+	Name    string
+	Level   Level
+	Message string
+}
+
+// Check is a preflight check.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) (Level, string)
+}
+
+// Report is the report of the preflight checks.
+type Report struct {
+	Results []Result
+}
+
+// Run runs the checks one by one and returns the report.
+func Run(ctx context.Context, checks []Check) *Report {
+	report := &Report{Results: make([]Result, 0, len(checks))}
+	for _, check := range checks {
+		start := time.Now()
+		level, message := check.Run(ctx)
+		report.Results = append(report.Results, Result{
+			Name:    check.Name,
+			Level:   level,
+			Message: message,
+		})
+		log.Debug("preflight check finished",
+			zap.String("check", check.Name),
+			zap.Duration("duration", time.Since(start)))
+	}
+	return report
+}
+
+// Failures returns the names of the failed checks.
+func (r *Report) Failures() []string {
+	var failures []string
+	for _, result := range r.Results {
+		if result.Level == LevelFail {
+			failures = append(failures, result.Name)
+		}
+	}
+	return failures
+}
+
+// Log logs the report, each result is a structured field.
+func (r *Report) Log() {
+	fields := make([]zap.Field, 0, len(r.Results))
+	level := LevelPass
+	for _, result := range r.Results {
+		fields = append(fields, zap.String(result.Name,
+			result.Level.String()+": "+result.Message))
+		if result.Level > level {
+			level = result.Level
+		}
+	}
+	fields = append(fields, zap.String("failures", strings.Join(r.Failures(), ",")))
+	switch level {
+	case LevelPass:
+		log.Info("preflight checks passed", fields...)
+	default:
+		log.Warn("preflight checks found problems", fields...)
+	}
+}
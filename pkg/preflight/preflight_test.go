@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	newCheck := func(name string, level Level) Check {
+		return Check{Name: name, Run: func(ctx context.Context) (Level, string) {
+			return level, name
+		}}
+	}
+	report := Run(context.Background(), []Check{
+		newCheck("a", LevelPass),
+		newCheck("b", LevelFail),
+		newCheck("c", LevelWarn),
+		newCheck("d", LevelFail),
+	})
+	require.Len(t, report.Results, 4)
+	require.Equal(t, Result{Name: "c", Level: LevelWarn, Message: "c"}, report.Results[2])
+	require.Equal(t, []string{"b", "d"}, report.Failures())
+	report.Log()
+
+	report = Run(context.Background(), []Check{newCheck("a", LevelPass)})
+	require.Empty(t, report.Failures())
+}
+
+func TestSortDirCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	level, _ := SortDirCheck(dir, 0, time.Hour).Run(ctx)
+	require.Equal(t, LevelPass, level)
+	level, _ = SortDirCheck(dir, 1<<40, time.Hour).Run(ctx)
+	require.Equal(t, LevelWarn, level)
+
+	level, _ = SortDirCheck(dir+"/not-exist", 0, time.Hour).Run(ctx)
+	require.Equal(t, LevelFail, level)
+}
+
+func TestClockSkewCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newGetTS := func(offset time.Duration) GetTSFunc {
+		return func(ctx context.Context) (int64, int64, error) {
+			return time.Now().Add(offset).UnixMilli(), 0, nil
+		}
+	}
+
+	skew, err := MeasureClockSkew(ctx, newGetTS(-time.Minute))
+	require.NoError(t, err)
+	require.InDelta(t, time.Minute, skew, float64(time.Second))
+
+	level, _ := ClockSkewCheck(newGetTS(0), time.Second).Run(ctx)
+	require.Equal(t, LevelPass, level)
+	level, _ = ClockSkewCheck(newGetTS(time.Minute), time.Second).Run(ctx)
+	require.Equal(t, LevelFail, level)
+	level, _ = ClockSkewCheck(newGetTS(-time.Minute), time.Second).Run(ctx)
+	require.Equal(t, LevelFail, level)
+
+	level, _ = ClockSkewCheck(func(ctx context.Context) (int64, int64, error) {
+		return 0, 0, errors.New("pd is unavailable")
+	}, time.Second).Run(ctx)
+	require.Equal(t, LevelWarn, level)
+}
+
+func TestPeersCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	reachable := l.Addr().String()
+
+	level, _ := PeersCheck(nil, time.Second).Run(ctx)
+	require.Equal(t, LevelPass, level)
+	level, _ = PeersCheck([]string{reachable}, time.Second).Run(ctx)
+	require.Equal(t, LevelPass, level)
+
+	l2, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := l2.Addr().String()
+	require.NoError(t, l2.Close())
+	level, message := PeersCheck([]string{reachable, unreachable}, time.Second).Run(ctx)
+	require.Equal(t, LevelWarn, level)
+	require.Contains(t, message, unreachable)
+	require.NoError(t, l.Close())
+}
+
+func TestOpenFilesLimitCheck(t *testing.T) {
+	t.Parallel()
+
+	level, _ := OpenFilesLimitCheck(0).Run(context.Background())
+	require.Equal(t, LevelPass, level)
+}
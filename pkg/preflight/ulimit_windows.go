@@ -0,0 +1,30 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//
+//go:build windows
+
+package preflight
+
+import "context"
+
+// OpenFilesLimitCheck checks the soft limit of open files.
+// It always passes on Windows.
+func OpenFilesLimitCheck(minLimit uint64) Check {
+	return Check{
+		Name: "open-files-limit",
+		Run: func(ctx context.Context) (Level, string) {
+			return LevelPass, "not supported on windows"
+		},
+	}
+}
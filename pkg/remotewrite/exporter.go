@@ -0,0 +1,266 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+)
+
+const (
+	labelName       = "__name__"
+	labelInstance   = "instance"
+	labelNamespace  = "namespace"
+	labelChangefeed = "changefeed"
+
+	// maxErrorBodySize is the max size of the response body read to
+	// report a failed push.
+	maxErrorBodySize = 512
+)
+
+// Exporter pushes the metrics gathered from a prometheus.Gatherer to a
+// Prometheus-compatible remote write endpoint periodically.
+type Exporter struct {
+	cfg      *config.MetricsRemoteWriteConfig
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	// labels are attached to all series unless the series already has
+	// a label with the same name.
+	labels []label
+	// changefeeds are the changefeeds whose metrics are pushed,
+	// nil means all changefeeds.
+	changefeeds map[model.ChangeFeedID]struct{}
+}
+
+// NewExporter creates an Exporter, instance is attached to all series as
+// the "instance" label.
+func NewExporter(
+	cfg *config.MetricsRemoteWriteConfig, gatherer prometheus.Gatherer, instance string,
+) *Exporter {
+	labels := make([]label, 0, len(cfg.ExternalLabels)+1)
+	if _, ok := cfg.ExternalLabels[labelInstance]; !ok {
+		labels = append(labels, label{name: labelInstance, value: instance})
+	}
+	for name, value := range cfg.ExternalLabels {
+		labels = append(labels, label{name: name, value: value})
+	}
+	var changefeeds map[model.ChangeFeedID]struct{}
+	if len(cfg.Changefeeds) != 0 {
+		changefeeds = make(map[model.ChangeFeedID]struct{}, len(cfg.Changefeeds))
+		for _, cf := range cfg.Changefeeds {
+			changefeeds[parseChangefeedID(cf)] = struct{}{}
+		}
+	}
+	return &Exporter{
+		cfg:         cfg,
+		gatherer:    gatherer,
+		client:      &http.Client{Timeout: time.Duration(cfg.Timeout)},
+		labels:      labels,
+		changefeeds: changefeeds,
+	}
+}
+
+// Run pushes metrics periodically until the context is canceled.
+// Failed pushes are only logged.
+func (e *Exporter) Run(ctx context.Context) error {
+	log.Info("metrics remote write exporter started",
+		zap.String("url", e.cfg.URL),
+		zap.Duration("interval", time.Duration(e.cfg.Interval)),
+		zap.Strings("changefeeds", e.cfg.Changefeeds))
+	ticker := time.NewTicker(time.Duration(e.cfg.Interval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := e.push(ctx); err != nil {
+				log.Warn("failed to push metrics", zap.String("url", e.cfg.URL), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (e *Exporter) push(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		// Gather returns as many metrics as possible even if some
+		// collectors fail, so push them anyway.
+		log.Warn("failed to gather some metrics", zap.Error(err))
+	}
+	series := e.convert(families, time.Now().UnixMilli())
+	if len(series) == 0 {
+		return nil
+	}
+	body := snappy.Encode(nil, marshalWriteRequest(series))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "TiCDC/"+version.ReleaseVersion)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	for name, value := range e.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return cerror.ErrMetricsRemoteWrite.GenWithStackByArgs(e.cfg.URL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return cerror.ErrMetricsRemoteWrite.GenWithStackByArgs(e.cfg.URL,
+			resp.Status+" "+strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// convert converts the metric families to time series, each of them has
+// exactly one sample. Histograms and summaries are expanded the same way
+// as the Prometheus text format does.
+func (e *Exporter) convert(families []*dto.MetricFamily, nowMs int64) []timeSeries {
+	var series []timeSeries
+	for _, family := range families {
+		name := family.GetName()
+		for _, m := range family.GetMetric() {
+			if !e.accept(m) {
+				continue
+			}
+			ts := nowMs
+			if m.TimestampMs != nil {
+				ts = m.GetTimestampMs()
+			}
+			add := func(name string, value float64, extra ...label) {
+				series = append(series, timeSeries{
+					labels: e.buildLabels(name, m.GetLabel(), extra...),
+					value:  value,
+					ts:     ts,
+				})
+			}
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, m.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add(name, q.GetValue(),
+						label{name: "quantile", value: formatFloat(q.GetQuantile())})
+				}
+				add(name+"_sum", s.GetSampleSum())
+				add(name+"_count", float64(s.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				hasInf := false
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), +1) {
+						hasInf = true
+					}
+					add(name+"_bucket", float64(b.GetCumulativeCount()),
+						label{name: "le", value: formatFloat(b.GetUpperBound())})
+				}
+				if !hasInf {
+					add(name+"_bucket", float64(h.GetSampleCount()),
+						label{name: "le", value: "+Inf"})
+				}
+				add(name+"_sum", h.GetSampleSum())
+				add(name+"_count", float64(h.GetSampleCount()))
+			}
+		}
+	}
+	return series
+}
+
+// accept returns false if the metric belongs to a changefeed which is not
+// in the filter.
+func (e *Exporter) accept(m *dto.Metric) bool {
+	if e.changefeeds == nil {
+		return true
+	}
+	id := model.ChangeFeedID{Namespace: model.DefaultNamespace}
+	for _, l := range m.GetLabel() {
+		switch l.GetName() {
+		case labelNamespace:
+			id.Namespace = l.GetValue()
+		case labelChangefeed:
+			id.ID = l.GetValue()
+		}
+	}
+	if id.ID == "" {
+		return true
+	}
+	_, ok := e.changefeeds[id]
+	return ok
+}
+
+// buildLabels returns the labels sorted by name, as required by the remote
+// write protocol.
+func (e *Exporter) buildLabels(name string, pairs []*dto.LabelPair, extra ...label) []label {
+	labels := make([]label, 0, len(pairs)+len(extra)+len(e.labels)+1)
+	labels = append(labels, label{name: labelName, value: name})
+	for _, p := range pairs {
+		labels = append(labels, label{name: p.GetName(), value: p.GetValue()})
+	}
+	labels = append(labels, extra...)
+	for _, l := range e.labels {
+		exists := false
+		for _, p := range pairs {
+			if p.GetName() == l.name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			labels = append(labels, l)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+	return labels
+}
+
+func parseChangefeedID(s string) model.ChangeFeedID {
+	if idx := strings.Index(s, "/"); idx >= 0 {
+		return model.ChangeFeedID{Namespace: s[:idx], ID: s[idx+1:]}
+	}
+	return model.DefaultChangeFeedID(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
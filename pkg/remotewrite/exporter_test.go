@@ -0,0 +1,222 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotewrite
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// unmarshalWriteRequest decodes a WriteRequest encoded by marshalWriteRequest.
+func unmarshalWriteRequest(t *testing.T, buf []byte) []timeSeries {
+	var series []timeSeries
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		require.Equal(t, protowire.Number(fieldWriteRequestTimeSeries), num)
+		require.Equal(t, protowire.BytesType, typ)
+		buf = buf[n:]
+		tsBuf, n := protowire.ConsumeBytes(buf)
+		require.Greater(t, n, 0)
+		buf = buf[n:]
+
+		var s timeSeries
+		for len(tsBuf) > 0 {
+			num, _, n := protowire.ConsumeTag(tsBuf)
+			tsBuf = tsBuf[n:]
+			msg, n := protowire.ConsumeBytes(tsBuf)
+			require.Greater(t, n, 0)
+			tsBuf = tsBuf[n:]
+			switch num {
+			case fieldTimeSeriesLabels:
+				var l label
+				for len(msg) > 0 {
+					num, _, n := protowire.ConsumeTag(msg)
+					msg = msg[n:]
+					v, n := protowire.ConsumeString(msg)
+					msg = msg[n:]
+					if num == fieldLabelName {
+						l.name = v
+					} else {
+						l.value = v
+					}
+				}
+				s.labels = append(s.labels, l)
+			case fieldTimeSeriesSamples:
+				for len(msg) > 0 {
+					num, _, n := protowire.ConsumeTag(msg)
+					msg = msg[n:]
+					if num == fieldSampleValue {
+						v, n := protowire.ConsumeFixed64(msg)
+						msg = msg[n:]
+						s.value = math.Float64frombits(v)
+					} else {
+						v, n := protowire.ConsumeVarint(msg)
+						msg = msg[n:]
+						s.ts = int64(v)
+					}
+				}
+			}
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+// seriesByName indexes the series by their name and labels except the
+// "instance" label.
+func seriesByName(series []timeSeries) map[string]timeSeries {
+	m := make(map[string]timeSeries, len(series))
+	for _, s := range series {
+		key := ""
+		for _, l := range s.labels {
+			switch l.name {
+			case labelName:
+				key = l.value + key
+			case labelInstance:
+			default:
+				key += "," + l.name + "=" + l.value
+			}
+		}
+		m[key] = s
+	}
+	return m
+}
+
+func newRegistry4Test() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "test_counter",
+	}, []string{"namespace", "changefeed"})
+	counter.WithLabelValues("default", "test1").Add(1)
+	counter.WithLabelValues("default", "test2").Add(2)
+	counter.WithLabelValues("ns", "test1").Add(3)
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge"})
+	gauge.Set(4)
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_histogram",
+		Buckets: []float64{1, 2},
+	})
+	histogram.Observe(0.5)
+	histogram.Observe(1.5)
+	histogram.Observe(3)
+	summary := prometheus.NewSummary(prometheus.SummaryOpts{
+		Name:       "test_summary",
+		Objectives: map[float64]float64{0.5: 0.05},
+	})
+	summary.Observe(5)
+	registry.MustRegister(counter, gauge, histogram, summary)
+	return registry
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultServerConfig().MetricsRemoteWrite
+	cfg.ExternalLabels = map[string]string{"cluster": "c1", "namespace": "ignored"}
+	e := NewExporter(cfg, nil, "127.0.0.1:8300")
+	families, err := newRegistry4Test().Gather()
+	require.Nil(t, err)
+	series := e.convert(families, 100)
+
+	m := seriesByName(series)
+	require.Len(t, m, 12)
+	for key, value := range map[string]float64{
+		"test_counter,changefeed=test1,cluster=c1,namespace=default": 1,
+		"test_counter,changefeed=test2,cluster=c1,namespace=default": 2,
+		"test_counter,changefeed=test1,cluster=c1,namespace=ns":      3,
+		"test_gauge,cluster=c1,namespace=ignored":                    4,
+		"test_histogram_bucket,cluster=c1,le=1,namespace=ignored":    1,
+		"test_histogram_bucket,cluster=c1,le=2,namespace=ignored":    2,
+		"test_histogram_bucket,cluster=c1,le=+Inf,namespace=ignored": 3,
+		"test_histogram_sum,cluster=c1,namespace=ignored":            5,
+		"test_histogram_count,cluster=c1,namespace=ignored":          3,
+		"test_summary,cluster=c1,namespace=ignored,quantile=0.5":     5,
+		"test_summary_sum,cluster=c1,namespace=ignored":              5,
+		"test_summary_count,cluster=c1,namespace=ignored":            1,
+	} {
+		require.Contains(t, m, key)
+		require.Equal(t, value, m[key].value, key)
+	}
+	for _, s := range series {
+		require.Equal(t, int64(100), s.ts)
+		require.Contains(t, s.labels, label{name: labelInstance, value: "127.0.0.1:8300"})
+		for i := 1; i < len(s.labels); i++ {
+			require.Less(t, s.labels[i-1].name, s.labels[i].name)
+		}
+	}
+}
+
+func TestFilterChangefeeds(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultServerConfig().MetricsRemoteWrite
+	cfg.Changefeeds = []string{"test1", "ns/test2"}
+	e := NewExporter(cfg, nil, "127.0.0.1:8300")
+	families, err := newRegistry4Test().Gather()
+	require.Nil(t, err)
+	m := seriesByName(e.convert(families, 100))
+	require.Contains(t, m, "test_counter,changefeed=test1,namespace=default")
+	require.NotContains(t, m, "test_counter,changefeed=test2,namespace=default")
+	require.NotContains(t, m, "test_counter,changefeed=test1,namespace=ns")
+	// Metrics not belonging to any changefeed are always pushed.
+	require.Contains(t, m, "test_gauge")
+}
+
+func TestPush(t *testing.T) {
+	t.Parallel()
+
+	var received []timeSeries
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		require.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		require.Equal(t, "0.1.0", r.Header.Get("X-Prometheus-Remote-Write-Version"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		buf, err := snappy.Decode(nil, body)
+		require.Nil(t, err)
+		received = unmarshalWriteRequest(t, buf)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("out of order sample"))
+	}))
+	defer server.Close()
+
+	cfg := config.GetDefaultServerConfig().MetricsRemoteWrite
+	cfg.URL = server.URL
+	cfg.Headers = map[string]string{"Authorization": "Bearer token"}
+	e := NewExporter(cfg, newRegistry4Test(), "127.0.0.1:8300")
+	defer e.client.CloseIdleConnections()
+
+	require.Nil(t, e.push(context.Background()))
+	m := seriesByName(received)
+	require.Len(t, m, 12)
+	require.Equal(t, float64(3), m["test_counter,changefeed=test1,namespace=ns"].value)
+	require.Equal(t, float64(3), m["test_histogram_bucket,le=+Inf"].value)
+
+	status = http.StatusBadRequest
+	err := e.push(context.Background())
+	require.Regexp(t, ".*400 Bad Request out of order sample.*", err)
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotewrite
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of the Prometheus remote write protocol, see
+// https://github.com/prometheus/prometheus/blob/main/prompb/remote.proto.
+// They're encoded by hand to avoid depending on the prometheus server.
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+const (
+	fieldWriteRequestTimeSeries = 1
+	fieldTimeSeriesLabels       = 1
+	fieldTimeSeriesSamples      = 2
+	fieldLabelName              = 1
+	fieldLabelValue             = 2
+	fieldSampleValue            = 1
+	fieldSampleTimestamp        = 2
+)
+
+type label struct {
+	name  string
+	value string
+}
+
+// timeSeries is a time series with a single sample.
+type timeSeries struct {
+	labels []label
+	value  float64
+	// ts is the timestamp of the sample in milliseconds.
+	ts int64
+}
+
+func marshalWriteRequest(series []timeSeries) []byte {
+	var buf, tsBuf []byte
+	for _, s := range series {
+		tsBuf = s.marshal(tsBuf[:0])
+		buf = protowire.AppendTag(buf, fieldWriteRequestTimeSeries, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, tsBuf)
+	}
+	return buf
+}
+
+func (s *timeSeries) marshal(buf []byte) []byte {
+	for _, l := range s.labels {
+		size := protowire.SizeTag(fieldLabelName) + protowire.SizeBytes(len(l.name)) +
+			protowire.SizeTag(fieldLabelValue) + protowire.SizeBytes(len(l.value))
+		buf = protowire.AppendTag(buf, fieldTimeSeriesLabels, protowire.BytesType)
+		buf = protowire.AppendVarint(buf, uint64(size))
+		buf = protowire.AppendTag(buf, fieldLabelName, protowire.BytesType)
+		buf = protowire.AppendString(buf, l.name)
+		buf = protowire.AppendTag(buf, fieldLabelValue, protowire.BytesType)
+		buf = protowire.AppendString(buf, l.value)
+	}
+	size := protowire.SizeTag(fieldSampleValue) + protowire.SizeFixed64() +
+		protowire.SizeTag(fieldSampleTimestamp) + protowire.SizeVarint(uint64(s.ts))
+	buf = protowire.AppendTag(buf, fieldTimeSeriesSamples, protowire.BytesType)
+	buf = protowire.AppendVarint(buf, uint64(size))
+	buf = protowire.AppendTag(buf, fieldSampleValue, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(s.value))
+	buf = protowire.AppendTag(buf, fieldSampleTimestamp, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, uint64(s.ts))
+	return buf
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import "golang.org/x/time/rate"
+
+// Budget bounds the retries of the calls sharing it. Each retry takes a token
+// and tokens are refilled at a constant rate up to a burst, a call gives up
+// once no token is left. So retries are bounded no matter how many calls fail
+// at the same time, e.g. when a dependency is down.
+type Budget struct {
+	limiter *rate.Limiter
+}
+
+// NewBudget creates a Budget which allows retriesPerSecond retries per
+// second in the long run and burst retries at most at once. A budget with
+// retriesPerSecond 0 allows burst retries in total.
+func NewBudget(retriesPerSecond float64, burst int) *Budget {
+	return &Budget{limiter: rate.NewLimiter(rate.Limit(retriesPerSecond), burst)}
+}
+
+func (b *Budget) take() bool {
+	return b.limiter.Allow()
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	giveUpReasonMaxTries    = "max-tries"
+	giveUpReasonMaxDuration = "max-duration"
+	giveUpReasonBudget      = "budget"
+)
+
+var (
+	retryCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "retry",
+			Name:      "retry_count",
+			Help:      "The number of retries by the class of errors",
+		}, []string{"name", "class"})
+
+	giveUpCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "retry",
+			Name:      "give_up_count",
+			Help:      "The number of calls giving up retrying by the reason",
+		}, []string{"name", "reason"})
+
+	backoffHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "retry",
+			Name:      "backoff_duration_seconds",
+			Help:      "The backoff duration before retries",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14), // 10ms~82s
+		}, []string{"name"})
+)
+
+// InitMetrics registers all metrics in this file
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(retryCounter)
+	registry.MustRegister(giveUpCounter)
+	registry.MustRegister(backoffHistogram)
+}
+
+func (o *retryOptions) observeRetry(err error, backoff time.Duration) {
+	if o.name == "" {
+		return
+	}
+	retryCounter.WithLabelValues(o.name, o.classifyError(err)).Inc()
+	backoffHistogram.WithLabelValues(o.name).Observe(backoff.Seconds())
+}
+
+func (o *retryOptions) observeGiveUp(reason string) {
+	if o.name == "" {
+		return
+	}
+	giveUpCounter.WithLabelValues(o.name, reason).Inc()
+}
+
+// classifyError classifies errors by the RFC code, or the gRPC status code if
+// the error comes from a gRPC call.
+func classifyError(err error) string {
+	if code, ok := cerror.RFCCode(err); ok {
+		return string(code)
+	}
+	if s, ok := status.FromError(errors.Cause(err)); ok {
+		return "gRPC:" + s.Code().String()
+	}
+	return "unknown"
+}
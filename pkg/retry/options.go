@@ -33,12 +33,23 @@ type Option func(*retryOptions)
 // IsRetryable checks the error is safe or worth to retry, eg. "context.Canceled" better not retry
 type IsRetryable func(error) bool
 
+// ClassifyError returns the class of an error, which labels the retry
+// metrics. The number of classes must be bounded.
+type ClassifyError func(error) string
+
 type retryOptions struct {
 	totalRetryDuration time.Duration
 	maxTries           uint64
 	backoffBaseInMs    float64
 	backoffCapInMs     float64
 	isRetryable        IsRetryable
+
+	// name identifies the retried operation in metrics, retries of an
+	// unnamed operation are not observed.
+	name               string
+	decorrelatedJitter bool
+	budget             *Budget
+	classifyError      ClassifyError
 }
 
 func newRetryOptions() *retryOptions {
@@ -48,6 +59,7 @@ func newRetryOptions() *retryOptions {
 		backoffBaseInMs:    defaultBackoffBaseInMs,
 		backoffCapInMs:     defaultBackoffCapInMs,
 		isRetryable:        func(err error) bool { return true },
+		classifyError:      classifyError,
 	}
 }
 
@@ -94,3 +106,39 @@ func WithIsRetryableErr(f IsRetryable) Option {
 		}
 	}
 }
+
+// WithName configures the name of the retried operation, retries of named
+// operations are recorded in metrics.
+func WithName(name string) Option {
+	return func(o *retryOptions) {
+		o.name = name
+	}
+}
+
+// WithDecorrelatedJitter makes each backoff grow randomly from the previous
+// one instead of from the number of tries, which spreads the retries of
+// concurrent callers better.
+func WithDecorrelatedJitter() Option {
+	return func(o *retryOptions) {
+		o.decorrelatedJitter = true
+	}
+}
+
+// WithBudget configures the budget that retries take tokens from, the call
+// gives up once the budget is exhausted. A budget can be dedicated to a call
+// or shared by calls of a module.
+func WithBudget(budget *Budget) Option {
+	return func(o *retryOptions) {
+		o.budget = budget
+	}
+}
+
+// WithErrorClassifier configures how errors are classified in metrics, the
+// RFC code of the error is used by default.
+func WithErrorClassifier(f ClassifyError) Option {
+	return func(o *retryOptions) {
+		if f != nil {
+			o.classifyError = f
+		}
+	}
+}
@@ -20,7 +20,11 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestDoShouldRetryAtMostSpecifiedTimes(t *testing.T) {
@@ -202,3 +206,85 @@ func TestRetryError(t *testing.T) {
 	require.Regexp(t, ".*some error info.*", err.Error())
 	require.Regexp(t, ".*CDC:ErrReachMaxTry.*", err.Error())
 }
+
+func TestDecorrelatedBackoff(t *testing.T) {
+	t.Parallel()
+
+	last := time.Duration(0)
+	for i := 0; i < 100; i++ {
+		backoff := getDecorrelatedBackoff(10, 1000, last)
+		require.GreaterOrEqual(t, backoff, 10*time.Millisecond)
+		require.LessOrEqual(t, backoff, 1000*time.Millisecond)
+		if last >= 10*time.Millisecond {
+			require.LessOrEqual(t, backoff, 3*last)
+		}
+		last = backoff
+	}
+
+	var callCount int
+	f := func() error {
+		callCount++
+		return errors.New("test")
+	}
+	err := Do(context.Background(), f, WithMaxTries(3), WithDecorrelatedJitter())
+	require.Regexp(t, "test", errors.Cause(err))
+	require.Equal(t, 3, callCount)
+}
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+
+	var callCount int
+	f := func() error {
+		callCount++
+		return errors.New("test")
+	}
+
+	// A dedicated budget bounds the retries of a call.
+	err := Do(context.Background(), f, WithBudget(NewBudget(0, 2)))
+	require.Regexp(t, "CDC:ErrRetryBudgetExhausted", err)
+	require.Regexp(t, "test", errors.Cause(err))
+	require.Equal(t, 3, callCount)
+
+	// A shared budget bounds the retries of all calls.
+	budget := NewBudget(0, 3)
+	callCount = 0
+	err = Do(context.Background(), f, WithBudget(budget), WithMaxTries(3))
+	require.Regexp(t, "CDC:ErrReachMaxTry", err)
+	err = Do(context.Background(), f, WithBudget(budget), WithMaxTries(3))
+	require.Regexp(t, "CDC:ErrRetryBudgetExhausted", err)
+	require.Equal(t, 5, callCount)
+}
+
+func TestRetryMetrics(t *testing.T) {
+	t.Parallel()
+
+	f := func() error {
+		return cerror.ErrPDEtcdAPIError.GenWithStackByArgs()
+	}
+	err := Do(context.Background(), f, WithName("test_metrics"), WithMaxTries(3))
+	require.Regexp(t, "CDC:ErrReachMaxTry", err)
+	require.Equal(t, float64(2), testutil.ToFloat64(
+		retryCounter.WithLabelValues("test_metrics", "CDC:ErrPDEtcdAPIError")))
+	require.Equal(t, float64(1), testutil.ToFloat64(
+		giveUpCounter.WithLabelValues("test_metrics", giveUpReasonMaxTries)))
+
+	err = Do(context.Background(), f, WithName("test_metrics"),
+		WithErrorClassifier(func(error) string { return "etcd" }),
+		WithBudget(NewBudget(0, 1)))
+	require.Regexp(t, "CDC:ErrRetryBudgetExhausted", err)
+	require.Equal(t, float64(1), testutil.ToFloat64(
+		retryCounter.WithLabelValues("test_metrics", "etcd")))
+	require.Equal(t, float64(1), testutil.ToFloat64(
+		giveUpCounter.WithLabelValues("test_metrics", giveUpReasonBudget)))
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "CDC:ErrReachMaxTry", classifyError(
+		cerror.WrapError(cerror.ErrReachMaxTry, errors.New("test"))))
+	require.Equal(t, "gRPC:Unavailable", classifyError(
+		errors.Trace(status.Error(codes.Unavailable, "test"))))
+	require.Equal(t, "unknown", classifyError(errors.New("test")))
+}
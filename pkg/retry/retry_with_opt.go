@@ -65,6 +65,7 @@ func run(ctx context.Context, op Operation, retryOption *retryOptions) error {
 
 		try++
 		if try >= retryOption.maxTries {
+			retryOption.observeGiveUp(giveUpReasonMaxTries)
 			return cerror.ErrReachMaxTry.
 				Wrap(err).GenWithStackByArgs(strconv.Itoa(int(retryOption.maxTries)), err)
 		}
@@ -72,12 +73,23 @@ func run(ctx context.Context, op Operation, retryOption *retryOptions) error {
 			if start.IsZero() {
 				start = time.Now()
 			} else if time.Since(start) > retryOption.totalRetryDuration {
+				retryOption.observeGiveUp(giveUpReasonMaxDuration)
 				return cerror.ErrReachMaxTry.
 					Wrap(err).GenWithStackByArgs(retryOption.totalRetryDuration, err)
 			}
 		}
+		if retryOption.budget != nil && !retryOption.budget.take() {
+			retryOption.observeGiveUp(giveUpReasonBudget)
+			return cerror.ErrRetryBudgetExhausted.Wrap(err).GenWithStackByArgs(err)
+		}
 
-		backOff = getBackoffInMs(retryOption.backoffBaseInMs, retryOption.backoffCapInMs, float64(try))
+		if retryOption.decorrelatedJitter {
+			backOff = getDecorrelatedBackoff(
+				retryOption.backoffBaseInMs, retryOption.backoffCapInMs, backOff)
+		} else {
+			backOff = getBackoffInMs(retryOption.backoffBaseInMs, retryOption.backoffCapInMs, float64(try))
+		}
+		retryOption.observeRetry(err, backOff)
 		if t == nil {
 			t = time.NewTimer(backOff)
 			defer t.Stop()
@@ -107,3 +119,14 @@ func getBackoffInMs(backoffBaseInMs, backoffCapInMs, try float64) time.Duration
 	backOff := math.Min(backoffCapInMs, float64(rand.Int63n(sleep))+backoffBaseInMs)
 	return time.Duration(backOff) * time.Millisecond
 }
+
+// getDecorrelatedBackoff returns the duration to wait before next try, which
+// is a random duration between the base and three times the last backoff.
+// See https://www.awsarchitectureblog.com/2015/03/backoff.html
+func getDecorrelatedBackoff(
+	backoffBaseInMs, backoffCapInMs float64, last time.Duration,
+) time.Duration {
+	lastInMs := math.Max(backoffBaseInMs, float64(last)/float64(time.Millisecond))
+	sleep := backoffBaseInMs + rand.Float64()*(lastInMs*3-backoffBaseInMs)
+	return time.Duration(math.Min(backoffCapInMs, sleep) * float64(time.Millisecond))
+}
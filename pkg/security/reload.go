@@ -0,0 +1,223 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// DefaultCredentialReloadInterval is the default interval to check whether
+// the files of a credential are changed.
+const DefaultCredentialReloadInterval = 30 * time.Second
+
+// CredentialWatcher watches the CA, certificate and key files of a credential,
+// and reloads them once they are changed, so that certificates can be rotated
+// without restarting the process.
+//
+// The files are polled instead of watched by inotify, because certificates are
+// usually rotated by replacing symbolic links, e.g. secrets in Kubernetes.
+type CredentialWatcher struct {
+	credential *Credential
+	interval   time.Duration
+
+	// creds holds the latest transport credentials, it's shared by all the
+	// clones of the reloadable transport credentials.
+	creds *atomic.Value
+
+	mu        sync.Mutex
+	checksum  []byte
+	callbacks []func()
+}
+
+// NewCredentialWatcher creates a CredentialWatcher. The credential files are
+// loaded immediately, an error is returned if they are invalid.
+func NewCredentialWatcher(
+	credential *Credential, interval time.Duration,
+) (*CredentialWatcher, error) {
+	w := &CredentialWatcher{
+		credential: credential,
+		interval:   interval,
+		creds:      new(atomic.Value),
+	}
+	if !w.enabled() {
+		return w, nil
+	}
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+	checksum, err := w.fileChecksum()
+	if err != nil {
+		return nil, err
+	}
+	w.checksum = checksum
+	return w, nil
+}
+
+// enabled returns true if TLS is used, which is the same as
+// Credential.ToGRPCDialOption.
+func (w *CredentialWatcher) enabled() bool {
+	return len(w.credential.CAPath) != 0
+}
+
+// OnChange registers a callback which is called after the credential is
+// reloaded.
+func (w *CredentialWatcher) OnChange(fn func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callbacks = append(w.callbacks, fn)
+}
+
+// ToGRPCDialOption returns a gRPC dial option, the handshakes of the
+// connections dialed with it always use the latest credential.
+func (w *CredentialWatcher) ToGRPCDialOption() grpc.DialOption {
+	if !w.enabled() {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(&reloadableCredentials{creds: w.creds})
+}
+
+// Run checks the credential files periodically until the context is done.
+func (w *CredentialWatcher) Run(ctx context.Context) error {
+	if !w.enabled() {
+		return nil
+	}
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// check reloads the credential if any file is changed. The callbacks are
+// called only if the new credential is valid, as the files may be partially
+// updated, in which case the credential is reloaded in the next round.
+func (w *CredentialWatcher) check() bool {
+	checksum, err := w.fileChecksum()
+	if err != nil {
+		log.Warn("failed to read credential files", zap.Error(err))
+		return false
+	}
+	w.mu.Lock()
+	if bytes.Equal(checksum, w.checksum) {
+		w.mu.Unlock()
+		return false
+	}
+	if err := w.load(); err != nil {
+		w.mu.Unlock()
+		log.Warn("failed to reload credential, the old one is still used",
+			zap.String("caPath", w.credential.CAPath),
+			zap.String("certPath", w.credential.CertPath),
+			zap.String("keyPath", w.credential.KeyPath),
+			zap.Error(err))
+		return false
+	}
+	w.checksum = checksum
+	callbacks := append([]func(){}, w.callbacks...)
+	w.mu.Unlock()
+
+	log.Info("credential is reloaded",
+		zap.String("caPath", w.credential.CAPath),
+		zap.String("certPath", w.credential.CertPath),
+		zap.String("keyPath", w.credential.KeyPath))
+	for _, fn := range callbacks {
+		fn()
+	}
+	return true
+}
+
+func (w *CredentialWatcher) load() error {
+	// The key pair is loaded lazily in handshakes, check it in advance.
+	if len(w.credential.CertPath) != 0 && len(w.credential.KeyPath) != 0 {
+		_, err := tls.LoadX509KeyPair(w.credential.CertPath, w.credential.KeyPath)
+		if err != nil {
+			return errors.WrapError(errors.ErrToTLSConfigFailed, err)
+		}
+	}
+	tlsCfg, err := w.credential.ToTLSConfig()
+	if err != nil {
+		return err
+	}
+	w.creds.Store(credentials.NewTLS(tlsCfg))
+	return nil
+}
+
+func (w *CredentialWatcher) fileChecksum() ([]byte, error) {
+	h := sha256.New()
+	for _, path := range []string{
+		w.credential.CAPath, w.credential.CertPath, w.credential.KeyPath,
+	} {
+		if len(path) == 0 {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		h.Write(data)
+	}
+	return h.Sum(nil), nil
+}
+
+// reloadableCredentials is a credentials.TransportCredentials which delegates
+// handshakes to the latest credential of a CredentialWatcher.
+type reloadableCredentials struct {
+	creds *atomic.Value
+}
+
+func (r *reloadableCredentials) get() credentials.TransportCredentials {
+	return r.creds.Load().(credentials.TransportCredentials)
+}
+
+func (r *reloadableCredentials) ClientHandshake(
+	ctx context.Context, authority string, rawConn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+	return r.get().ClientHandshake(ctx, authority, rawConn)
+}
+
+func (r *reloadableCredentials) ServerHandshake(
+	rawConn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+	return r.get().ServerHandshake(rawConn)
+}
+
+func (r *reloadableCredentials) Info() credentials.ProtocolInfo {
+	return r.get().Info()
+}
+
+func (r *reloadableCredentials) Clone() credentials.TransportCredentials {
+	return &reloadableCredentials{creds: r.creds}
+}
+
+func (r *reloadableCredentials) OverrideServerName(string) error {
+	return errors.New("overriding server name is not supported")
+}
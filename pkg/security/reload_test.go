@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialWatcher(t *testing.T) {
+	_, clientCred, err := NewServerCredential4Test("client")
+	require.Nil(t, err)
+	// The server certificate is signed by another CA.
+	serverCA, serverCred, err := NewServerCredential4Test("")
+	require.Nil(t, err)
+	serverTLS, err := serverCred.ToTLSConfig()
+	require.Nil(t, err)
+
+	w, err := NewCredentialWatcher(clientCred, time.Hour)
+	require.Nil(t, err)
+	changed := 0
+	w.OnChange(func() { changed++ })
+	creds := &reloadableCredentials{creds: w.creds}
+	handshake := func() error {
+		client, server := net.Pipe()
+		defer client.Close()
+		defer server.Close()
+		go func() {
+			_ = tls.Server(server, serverTLS).Handshake()
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, _, err := creds.Clone().ClientHandshake(ctx, "127.0.0.1", client)
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	require.Error(t, handshake())
+	require.False(t, w.check())
+
+	// Rotate the client credential to the server CA.
+	certPEM, keyPEM, err := serverCA.GenerateCerts("client")
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(clientCred.CAPath, serverCA.CAPEM, 0o600))
+	require.Nil(t, os.WriteFile(clientCred.CertPath, certPEM, 0o600))
+	// The key is not updated yet, the old credential is still used.
+	require.False(t, w.check())
+	require.Equal(t, 0, changed)
+	require.Nil(t, os.WriteFile(clientCred.KeyPath, keyPEM, 0o600))
+	require.True(t, w.check())
+	require.Equal(t, 1, changed)
+	require.Nil(t, handshake())
+	require.False(t, w.check())
+}
+
+func TestCredentialWatcherWithoutTLS(t *testing.T) {
+	w, err := NewCredentialWatcher(&Credential{}, time.Millisecond)
+	require.Nil(t, err)
+	require.Nil(t, w.Run(context.Background()))
+	require.NotNil(t, w.ToGRPCDialOption())
+
+	_, err = NewCredentialWatcher(&Credential{CAPath: "not-exist"}, time.Millisecond)
+	require.Regexp(t, "ErrToTLSConfigFailed", err)
+}
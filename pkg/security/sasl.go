@@ -74,6 +74,12 @@ type OAuth2 struct {
 	Scopes       []string
 	GrantType    string
 	Audience     string
+	// TokenProvider is the name of the provider which generates tokens,
+	// the OAuth2 client credentials flow is used if it's empty.
+	TokenProvider string
+	// AWSRegion is the region of the AWS MSK cluster, which is used by
+	// the AWS MSK IAM token provider.
+	AWSRegion string
 }
 
 // Validate validates the parameters of OAuth2.
@@ -18,12 +18,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin/binding"
 	"github.com/imdario/mergo"
 	"github.com/pingcap/log"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	psink "github.com/pingcap/tiflow/pkg/sink"
@@ -64,6 +66,24 @@ type Config struct {
 	FileIndexWidth           int
 	DateSeparator            string
 	EnablePartitionSeparator bool
+	// IndexMetadata records metadata of data files in index files.
+	IndexMetadata bool
+
+	// flushPolicies are the flush policies of matched tables.
+	flushPolicies []tableFlushPolicy
+}
+
+// FlushPolicy decides when the buffered messages of a table are flushed.
+type FlushPolicy struct {
+	FlushInterval time.Duration
+	FileSize      int
+	// FileRowCount is the max number of rows in a data file, 0 means no limit.
+	FileRowCount int
+}
+
+type tableFlushPolicy struct {
+	filter.Filter
+	FlushPolicy
 }
 
 // NewConfig returns the default cloud storage sink config.
@@ -119,9 +139,86 @@ func (c *Config) Apply(
 		c.FileIndexWidth = config.DefaultFileIndexWidth
 	}
 
+	if replicaConfig.Sink.CloudStorageConfig != nil {
+		c.IndexMetadata = util.GetOrZero(replicaConfig.Sink.CloudStorageConfig.IndexMetadata)
+		c.flushPolicies, err = c.getFlushPolicies(
+			replicaConfig.Sink.CloudStorageConfig.FlushPolicies, replicaConfig.CaseSensitive)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// FlushPolicyOf returns the flush policy of the given table. The first matched
+// flush policy is used, and the global one is used if no policy matches.
+func (c *Config) FlushPolicyOf(schema, table string) FlushPolicy {
+	for _, policy := range c.flushPolicies {
+		if policy.MatchTable(schema, table) {
+			return policy.FlushPolicy
+		}
+	}
+	return FlushPolicy{FlushInterval: c.FlushInterval, FileSize: c.FileSize}
+}
+
+// FlushIntervals returns all distinct flush intervals in ascending order.
+func (c *Config) FlushIntervals() []time.Duration {
+	intervals := []time.Duration{c.FlushInterval}
+	for _, policy := range c.flushPolicies {
+		found := false
+		for _, interval := range intervals {
+			if interval == policy.FlushInterval {
+				found = true
+				break
+			}
+		}
+		if !found {
+			intervals = append(intervals, policy.FlushInterval)
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	return intervals
+}
+
+func (c *Config) getFlushPolicies(
+	policies []*config.CloudStorageFlushPolicy, caseSensitive bool,
+) ([]tableFlushPolicy, error) {
+	var res []tableFlushPolicy
+	for _, policy := range policies {
+		if len(policy.Matcher) == 0 {
+			return nil, cerror.ErrStorageSinkInvalidConfig.GenWithStack(
+				"flush-policies.matcher can not be empty")
+		}
+		f, err := filter.Parse(policy.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+		}
+		if !caseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+
+		p := FlushPolicy{FlushInterval: c.FlushInterval, FileSize: c.FileSize}
+		values := &urlConfig{FlushInterval: policy.FlushInterval, FileSize: policy.FileSize}
+		if err := getFlushInterval(values, &p.FlushInterval); err != nil {
+			return nil, err
+		}
+		if err := getFileSize(values, &p.FileSize); err != nil {
+			return nil, err
+		}
+		if policy.FileRowCount != nil {
+			if *policy.FileRowCount < 0 {
+				return nil, cerror.ErrStorageSinkInvalidConfig.GenWithStack(
+					"invalid flush-policies.file-row-count %d, it must not be negative",
+					*policy.FileRowCount)
+			}
+			p.FileRowCount = *policy.FileRowCount
+		}
+		res = append(res, tableFlushPolicy{Filter: f, FlushPolicy: p})
+	}
+	return res, nil
+}
+
 func mergeConfig(
 	replicaConfig *config.ReplicaConfig,
 	urlParameters *urlConfig,
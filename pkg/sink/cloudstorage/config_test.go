@@ -138,6 +138,7 @@ func TestMergeConfig(t *testing.T) {
 		WorkerCount:   aws.Int(12),
 		FileSize:      aws.Int(1485760),
 		FlushInterval: aws.String("1m2s"),
+		IndexMetadata: aws.Bool(true),
 	}
 	c := NewConfig()
 	err = c.Apply(context.TODO(), sinkURI, replicaConfig)
@@ -145,6 +146,7 @@ func TestMergeConfig(t *testing.T) {
 	require.Equal(t, 12, c.WorkerCount)
 	require.Equal(t, 1485760, c.FileSize)
 	require.Equal(t, "1m2s", c.FlushInterval.String())
+	require.True(t, c.IndexMetadata)
 
 	// test override
 	uri = "s3://bucket/prefix?worker-count=64&flush-interval=2m2s&file-size=33554432"
@@ -162,3 +164,61 @@ func TestMergeConfig(t *testing.T) {
 	require.Equal(t, 33554432, c.FileSize)
 	require.Equal(t, "2m2s", c.FlushInterval.String())
 }
+
+func TestConfigFlushPolicies(t *testing.T) {
+	uri := "s3://bucket/prefix?flush-interval=10s&file-size=16777216&protocol=csv"
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.CloudStorageConfig = &config.CloudStorageConfig{
+		FlushPolicies: []*config.CloudStorageFlushPolicy{
+			{
+				Matcher:       []string{"test.dim_*"},
+				FlushInterval: aws.String("1m"),
+			},
+			{
+				Matcher:      []string{"test.fact_*"},
+				FileSize:     aws.Int(128 * 1024 * 1024),
+				FileRowCount: aws.Int(10000),
+			},
+		},
+	}
+	err = replicaConfig.ValidateAndAdjust(sinkURI)
+	require.NoError(t, err)
+	cfg := NewConfig()
+	err = cfg.Apply(context.TODO(), sinkURI, replicaConfig)
+	require.Nil(t, err)
+
+	require.Equal(t, FlushPolicy{
+		FlushInterval: time.Minute,
+		FileSize:      16 * 1024 * 1024,
+	}, cfg.FlushPolicyOf("test", "dim_user"))
+	require.Equal(t, FlushPolicy{
+		FlushInterval: 10 * time.Second,
+		FileSize:      128 * 1024 * 1024,
+		FileRowCount:  10000,
+	}, cfg.FlushPolicyOf("test", "fact_order"))
+	require.Equal(t, FlushPolicy{
+		FlushInterval: 10 * time.Second,
+		FileSize:      16 * 1024 * 1024,
+	}, cfg.FlushPolicyOf("test", "other"))
+	require.Equal(t, []time.Duration{10 * time.Second, time.Minute}, cfg.FlushIntervals())
+
+	// invalid policies
+	replicaConfig.Sink.CloudStorageConfig.FlushPolicies = []*config.CloudStorageFlushPolicy{
+		{FlushInterval: aws.String("1m")},
+	}
+	err = NewConfig().Apply(context.TODO(), sinkURI, replicaConfig)
+	require.Regexp(t, "matcher can not be empty", err)
+	replicaConfig.Sink.CloudStorageConfig.FlushPolicies = []*config.CloudStorageFlushPolicy{
+		{Matcher: []string{"test.*"}, FlushInterval: aws.String("1x")},
+	}
+	err = NewConfig().Apply(context.TODO(), sinkURI, replicaConfig)
+	require.Regexp(t, "ErrStorageSinkInvalidConfig", err)
+	replicaConfig.Sink.CloudStorageConfig.FlushPolicies = []*config.CloudStorageFlushPolicy{
+		{Matcher: []string{"test.*"}, FileRowCount: aws.Int(-1)},
+	}
+	err = NewConfig().Apply(context.TODO(), sinkURI, replicaConfig)
+	require.Regexp(t, "file-row-count", err)
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+// FileMeta is the metadata of a data file recorded in the index file.
+type FileMeta struct {
+	// File is the name of the data file, e.g. CDC000001.csv.
+	File        string `json:"file"`
+	MinCommitTs uint64 `json:"min-commit-ts"`
+	MaxCommitTs uint64 `json:"max-commit-ts"`
+	// MinKey and MaxKey are the smallest and the largest keys of rows in the
+	// data file, see RowKey. They're empty if some rows have no key.
+	MinKey string `json:"min-key,omitempty"`
+	MaxKey string `json:"max-key,omitempty"`
+	Rows   int    `json:"rows"`
+}
+
+// IndexFile is the content of an index file. The first line is the name of
+// the latest data file in the directory, which is followed by the metadata of
+// data files in JSON lines if the index metadata is enabled.
+//
+// The index file is written before the data file, so the latest data file
+// may not exist, and its metadata may be replaced by the one of a data file
+// written with the same name later.
+type IndexFile struct {
+	Latest string
+	Files  []FileMeta
+}
+
+// Marshal encodes the index file.
+func (i *IndexFile) Marshal() ([]byte, error) {
+	buf := bytes.NewBufferString(i.Latest)
+	buf.WriteByte('\n')
+	for _, meta := range i.Files {
+		data, err := json.Marshal(meta)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrStorageSinkInvalidIndexFile, err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseIndexFile decodes the index file, index files written without
+// metadata only have the name of the latest data file.
+func ParseIndexFile(data []byte) (*IndexFile, error) {
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	index := &IndexFile{Latest: lines[0]}
+	for _, line := range lines[1:] {
+		var meta FileMeta
+		if err := json.Unmarshal([]byte(line), &meta); err != nil {
+			return nil, errors.WrapError(errors.ErrStorageSinkInvalidIndexFile, err)
+		}
+		index.Files = append(index.Files, meta)
+	}
+	return index, nil
+}
+
+// ReadIndexFile reads and decodes the index file at the path.
+func ReadIndexFile(
+	ctx context.Context, storage storage.ExternalStorage, path string,
+) (*IndexFile, error) {
+	data, err := storage.ReadFile(ctx, path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseIndexFile(data)
+}
+
+// setFile records the metadata of a data file, it replaces the one with
+// the same name.
+func (i *IndexFile) setFile(meta FileMeta) {
+	for j := range i.Files {
+		if i.Files[j].File == meta.File {
+			i.Files[j] = meta
+			return
+		}
+	}
+	i.Files = append(i.Files, meta)
+}
+
+// RowKey returns the key of the row recorded in the index file, which is the
+// values of the handle key columns joined by commas. It returns false if
+// the row has no handle key.
+func RowKey(row *model.RowChangedEvent) (string, bool) {
+	cols := row.Columns
+	if row.IsDelete() {
+		cols = row.PreColumns
+	}
+	var values []string
+	for _, col := range cols {
+		if col != nil && col.Flag.IsHandleKey() {
+			values = append(values, model.ColumnValueString(col.Value))
+		}
+	}
+	if len(values) == 0 {
+		return "", false
+	}
+	return strings.Join(values, ","), true
+}
+
+// Observe extends the metadata with rows committed at commitTs. Keys are
+// dropped if some rows have no key.
+func (m *FileMeta) Observe(commitTs uint64, rows []*model.RowChangedEvent) {
+	first := m.Rows == 0
+	if first || commitTs < m.MinCommitTs {
+		m.MinCommitTs = commitTs
+	}
+	if commitTs > m.MaxCommitTs {
+		m.MaxCommitTs = commitTs
+	}
+	keyless := !first && m.MinKey == "" && m.MaxKey == ""
+	for _, row := range rows {
+		m.Rows++
+		if keyless {
+			continue
+		}
+		key, ok := RowKey(row)
+		if !ok {
+			keyless = true
+			m.MinKey, m.MaxKey = "", ""
+			continue
+		}
+		if m.Rows == 1 || key < m.MinKey {
+			m.MinKey = key
+		}
+		if key > m.MaxKey {
+			m.MaxKey = key
+		}
+	}
+}
+
+// IndexQuery selects data files by their metadata in index files.
+type IndexQuery struct {
+	// StartTs and EndTs select data files with rows committed in
+	// [StartTs, EndTs], an EndTs of 0 means no upper bound.
+	StartTs uint64
+	EndTs   uint64
+	// KeyPrefix selects data files which may have rows with keys starting
+	// with it. Data files without keys are always selected.
+	KeyPrefix string
+}
+
+// Match returns true if the data file may have rows selected by the query.
+func (q *IndexQuery) Match(meta *FileMeta) bool {
+	if meta.MaxCommitTs < q.StartTs {
+		return false
+	}
+	if q.EndTs != 0 && meta.MinCommitTs > q.EndTs {
+		return false
+	}
+	if q.KeyPrefix == "" || (meta.MinKey == "" && meta.MaxKey == "") {
+		return true
+	}
+	// Keys starting with the prefix are not less than the prefix, and keys
+	// greater than the prefix either start with it or are greater than all
+	// keys starting with it.
+	return meta.MaxKey >= q.KeyPrefix &&
+		(meta.MinKey <= q.KeyPrefix || strings.HasPrefix(meta.MinKey, q.KeyPrefix))
+}
+
+// Select returns names of data files in the index selected by the query, in
+// the order they are recorded. Data files without metadata are not covered.
+func (i *IndexFile) Select(q *IndexQuery) []string {
+	var files []string
+	for j := range i.Files {
+		if q.Match(&i.Files[j]) {
+			files = append(files, i.Files[j].File)
+		}
+	}
+	return files
+}
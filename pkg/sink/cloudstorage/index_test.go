@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexFileMarshal(t *testing.T) {
+	t.Parallel()
+
+	index := &IndexFile{Latest: "CDC000002.csv"}
+	data, err := index.Marshal()
+	require.NoError(t, err)
+	// Index files without metadata are the same as the legacy ones.
+	require.Equal(t, "CDC000002.csv\n", string(data))
+
+	index.Files = []FileMeta{
+		{File: "CDC000001.csv", MinCommitTs: 1, MaxCommitTs: 5, MinKey: "1", MaxKey: "9", Rows: 3},
+		{File: "CDC000002.csv", MinCommitTs: 6, MaxCommitTs: 6, Rows: 1},
+	}
+	data, err = index.Marshal()
+	require.NoError(t, err)
+	parsed, err := ParseIndexFile(data)
+	require.NoError(t, err)
+	require.Equal(t, index, parsed)
+
+	_, err = ParseIndexFile([]byte("CDC000002.csv\n{"))
+	require.Regexp(t, "ErrStorageSinkInvalidIndexFile", err)
+}
+
+func TestFileMetaObserve(t *testing.T) {
+	t.Parallel()
+
+	row := func(id interface{}) *model.RowChangedEvent {
+		return &model.RowChangedEvent{Columns: []*model.Column{
+			{Name: "id", Value: id, Flag: model.HandleKeyFlag},
+			{Name: "v", Value: "x"},
+		}}
+	}
+	meta := &FileMeta{}
+	meta.Observe(10, []*model.RowChangedEvent{row(5), row(3)})
+	meta.Observe(8, []*model.RowChangedEvent{row(7)})
+	require.Equal(t, &FileMeta{
+		MinCommitTs: 8, MaxCommitTs: 10, MinKey: "3", MaxKey: "7", Rows: 3,
+	}, meta)
+
+	// Keys are dropped once a row has no key.
+	meta.Observe(12, []*model.RowChangedEvent{{Columns: []*model.Column{{Name: "v"}}}})
+	meta.Observe(13, []*model.RowChangedEvent{row(9)})
+	require.Equal(t, &FileMeta{MinCommitTs: 8, MaxCommitTs: 13, Rows: 5}, meta)
+}
+
+func TestIndexQuery(t *testing.T) {
+	t.Parallel()
+
+	index := &IndexFile{Files: []FileMeta{
+		{File: "CDC000001.csv", MinCommitTs: 1, MaxCommitTs: 5, MinKey: "a", MaxKey: "c"},
+		{File: "CDC000002.csv", MinCommitTs: 6, MaxCommitTs: 9, MinKey: "ab", MaxKey: "b"},
+		{File: "CDC000003.csv", MinCommitTs: 10, MaxCommitTs: 12},
+	}}
+	cases := []struct {
+		query    IndexQuery
+		expected []string
+	}{
+		{IndexQuery{}, []string{"CDC000001.csv", "CDC000002.csv", "CDC000003.csv"}},
+		{IndexQuery{StartTs: 6}, []string{"CDC000002.csv", "CDC000003.csv"}},
+		{IndexQuery{StartTs: 5, EndTs: 6}, []string{"CDC000001.csv", "CDC000002.csv"}},
+		{IndexQuery{EndTs: 3}, []string{"CDC000001.csv"}},
+		{IndexQuery{StartTs: 13}, nil},
+		// Files without keys are always selected.
+		{IndexQuery{KeyPrefix: "b"}, []string{"CDC000001.csv", "CDC000002.csv", "CDC000003.csv"}},
+		{IndexQuery{KeyPrefix: "ab"}, []string{"CDC000001.csv", "CDC000002.csv", "CDC000003.csv"}},
+		{IndexQuery{KeyPrefix: "ac"}, []string{"CDC000001.csv", "CDC000002.csv", "CDC000003.csv"}},
+		{IndexQuery{KeyPrefix: "aa"}, []string{"CDC000001.csv", "CDC000003.csv"}},
+		{IndexQuery{KeyPrefix: "d"}, []string{"CDC000003.csv"}},
+		{IndexQuery{StartTs: 6, KeyPrefix: "c"}, []string{"CDC000003.csv"}},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.expected, index.Select(&c.query), "%+v", c.query)
+	}
+}
@@ -46,15 +46,34 @@ const (
 	// The table schema is stored in the following path:
 	// <schema>/<table>/meta/schema_{tableVersion}_{checksum}.json
 	tableSchemaPrefix = "%s/%s/meta/"
+	// The DDL event file is stored in the meta directory of the database or
+	// the table, named as ddl_{commitTs}.json.
+	ddlFileNameFormat = "ddl_%d.json"
+	// The schema manifest is stored in <schema>/<table>/meta/manifest.json.
+	schemaManifestFileName = "manifest.json"
 )
 
-var schemaRE = regexp.MustCompile(`meta/schema_\d+_\d{10}\.json$`)
+var (
+	schemaRE         = regexp.MustCompile(`meta/schema_\d+_\d{10}\.json$`)
+	ddlRE            = regexp.MustCompile(`meta/ddl_\d+\.json$`)
+	schemaManifestRE = regexp.MustCompile(`meta/manifest\.json$`)
+)
 
 // IsSchemaFile checks whether the file is a schema file.
 func IsSchemaFile(path string) bool {
 	return schemaRE.MatchString(path)
 }
 
+// IsDDLFile checks whether the file is a DDL event file.
+func IsDDLFile(path string) bool {
+	return ddlRE.MatchString(path)
+}
+
+// IsSchemaManifestFile checks whether the file is a schema manifest file.
+func IsSchemaManifestFile(path string) bool {
+	return schemaManifestRE.MatchString(path)
+}
+
 // mustParseSchemaName parses the version from the schema file name.
 func mustParseSchemaName(path string) (uint64, uint32) {
 	reportErr := func(err error) {
@@ -103,6 +122,22 @@ func generateSchemaFilePath(
 	return path.Join(dir, name)
 }
 
+func generateMetaDir(schema, table string) string {
+	if table == "" {
+		return fmt.Sprintf(dbSchemaPrefix, schema)
+	}
+	return fmt.Sprintf(tableSchemaPrefix, schema, table)
+}
+
+func generateDDLFilePath(schema, table string, commitTs uint64) string {
+	return path.Join(generateMetaDir(schema, table), fmt.Sprintf(ddlFileNameFormat, commitTs))
+}
+
+// GenerateSchemaManifestPath generates the schema manifest path of a table.
+func GenerateSchemaManifestPath(schema, table string) string {
+	return path.Join(generateMetaDir(schema, table), schemaManifestFileName)
+}
+
 func generateDataFileName(index uint64, extension string, fileIndexWidth int) string {
 	indexFmt := "%0" + strconv.Itoa(fileIndexWidth) + "d"
 	return fmt.Sprintf("CDC"+indexFmt+"%s", index, extension)
@@ -111,6 +146,8 @@ func generateDataFileName(index uint64, extension string, fileIndexWidth int) st
 type indexWithDate struct {
 	index              uint64
 	currDate, prevDate string
+	// files are the metadata of data files in the directory of currDate.
+	files []FileMeta
 }
 
 // VersionedTableName is used to wrap TableNameWithPhysicTableID with a version.
@@ -270,6 +307,31 @@ func (f *FilePathGenerator) GenerateIndexFilePath(tbl VersionedTableName, date s
 	return path.Join(dir, name)
 }
 
+// GenerateIndexFileContent generates the content of the index file for the
+// latest data file generated by GenerateDataFilePath. If meta is not nil,
+// it's recorded as the metadata of the latest data file, along with the
+// ones of previous data files in the same directory.
+func (f *FilePathGenerator) GenerateIndexFileContent(
+	tbl VersionedTableName, meta *FileMeta,
+) ([]byte, error) {
+	idx, ok := f.fileIndex[tbl]
+	if !ok {
+		log.Panic("data file path must be generated before the index file",
+			zap.Any("table", tbl))
+	}
+	index := &IndexFile{
+		Latest: generateDataFileName(idx.index, f.extension, f.config.FileIndexWidth),
+	}
+	if meta != nil {
+		index.Files = idx.files
+		m := *meta
+		m.File = index.Latest
+		index.setFile(m)
+		idx.files = index.Files
+	}
+	return index.Marshal()
+}
+
 // GenerateDataFilePath generates a canonical path for data file.
 func (f *FilePathGenerator) GenerateDataFilePath(
 	ctx context.Context, tbl VersionedTableName, date string,
@@ -304,7 +366,7 @@ func (f *FilePathGenerator) generateDataFileName(
 	ctx context.Context, tbl VersionedTableName, date string,
 ) (string, error) {
 	if idx, ok := f.fileIndex[tbl]; !ok {
-		fileIdx, err := f.getNextFileIdxFromIndexFile(ctx, tbl, date)
+		fileIdx, files, err := f.getNextFileIdxFromIndexFile(ctx, tbl, date)
 		if err != nil {
 			return "", err
 		}
@@ -312,6 +374,7 @@ func (f *FilePathGenerator) generateDataFileName(
 			prevDate: date,
 			currDate: date,
 			index:    fileIdx,
+			files:    files,
 		}
 	} else {
 		idx.currDate = date
@@ -321,31 +384,33 @@ func (f *FilePathGenerator) generateDataFileName(
 	if f.fileIndex[tbl].prevDate != f.fileIndex[tbl].currDate {
 		f.fileIndex[tbl].prevDate = f.fileIndex[tbl].currDate
 		f.fileIndex[tbl].index = 0
+		f.fileIndex[tbl].files = nil
 	}
 	f.fileIndex[tbl].index++
 	return generateDataFileName(f.fileIndex[tbl].index, f.extension, f.config.FileIndexWidth), nil
 }
 
+// getNextFileIdxFromIndexFile returns the index of the last data file and
+// the metadata of data files recorded in the index file.
 func (f *FilePathGenerator) getNextFileIdxFromIndexFile(
 	ctx context.Context, tbl VersionedTableName, date string,
-) (uint64, error) {
+) (uint64, []FileMeta, error) {
 	indexFile := f.GenerateIndexFilePath(tbl, date)
 	exist, err := f.storage.FileExists(ctx, indexFile)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 	if !exist {
-		return 0, nil
+		return 0, nil, nil
 	}
 
-	data, err := f.storage.ReadFile(ctx, indexFile)
+	index, err := ReadIndexFile(ctx, f.storage, indexFile)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
-	fileName := strings.TrimSuffix(string(data), "\n")
-	maxFileIdx, err := f.fetchIndexFromFileName(fileName)
+	maxFileIdx, err := f.fetchIndexFromFileName(index.Latest)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	lastFilePath := path.Join(
@@ -355,21 +420,21 @@ func (f *FilePathGenerator) getNextFileIdxFromIndexFile(
 	var lastFileExists, lastFileIsEmpty bool
 	lastFileExists, err = f.storage.FileExists(ctx, lastFilePath)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	if lastFileExists {
 		fileReader, err := f.storage.Open(ctx, lastFilePath)
 		if err != nil {
-			return 0, err
+			return 0, nil, err
 		}
 		readBytes, err := fileReader.Read(make([]byte, 1))
 		if err != nil && err != io.EOF {
-			return 0, err
+			return 0, nil, err
 		}
 		lastFileIsEmpty = readBytes == 0
 		if err := fileReader.Close(); err != nil {
-			return 0, err
+			return 0, nil, err
 		}
 	}
 
@@ -380,7 +445,7 @@ func (f *FilePathGenerator) getNextFileIdxFromIndexFile(
 		// Reuse the old index number if the last file does not exist.
 		fileIdx = maxFileIdx - 1
 	}
-	return fileIdx, nil
+	return fileIdx, index.Files, nil
 }
 
 func (f *FilePathGenerator) fetchIndexFromFileName(fileName string) (uint64, error) {
@@ -242,6 +242,68 @@ func TestGenerateDataFilePathWithIndexFile(t *testing.T) {
 	require.Equal(t, "test/table1/5/2023-03-09/CDC000006.json", dataFilePath)
 }
 
+func TestGenerateIndexFileContent(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	dir := t.TempDir()
+	f := testFilePathGenerator(ctx, t, dir)
+	table := VersionedTableName{
+		TableNameWithPhysicTableID: model.TableName{
+			Schema: "test",
+			Table:  "table1",
+		},
+		TableInfoVersion: 5,
+	}
+	f.versionMap[table] = table.TableInfoVersion
+	date := f.GenerateDateStr()
+
+	// Without metadata, only the latest data file is recorded.
+	_, err := f.GenerateDataFilePath(ctx, table, date)
+	require.NoError(t, err)
+	content, err := f.GenerateIndexFileContent(table, nil)
+	require.NoError(t, err)
+	require.Equal(t, "CDC000001.json\n", string(content))
+
+	_, err = f.GenerateDataFilePath(ctx, table, date)
+	require.NoError(t, err)
+	content, err = f.GenerateIndexFileContent(table, &FileMeta{MinCommitTs: 1, MaxCommitTs: 2})
+	require.NoError(t, err)
+	indexFilePath := f.GenerateIndexFilePath(table, date)
+	require.NoError(t, f.storage.WriteFile(ctx, indexFilePath, content))
+	dataFilePath, err := f.GenerateDataFilePath(ctx, table, date)
+	require.NoError(t, err)
+	content, err = f.GenerateIndexFileContent(table, &FileMeta{MinCommitTs: 3, MaxCommitTs: 4})
+	require.NoError(t, err)
+	require.NoError(t, f.storage.WriteFile(ctx, indexFilePath, content))
+	index, err := ParseIndexFile(content)
+	require.NoError(t, err)
+	require.Equal(t, &IndexFile{
+		Latest: "CDC000003.json",
+		Files: []FileMeta{
+			{File: "CDC000002.json", MinCommitTs: 1, MaxCommitTs: 2},
+			{File: "CDC000003.json", MinCommitTs: 3, MaxCommitTs: 4},
+		},
+	}, index)
+
+	// Metadata is restored from the index file, and the one of the latest
+	// data file is replaced as the data file is not written.
+	delete(f.fileIndex, table)
+	reused, err := f.GenerateDataFilePath(ctx, table, date)
+	require.NoError(t, err)
+	require.Equal(t, dataFilePath, reused)
+	content, err = f.GenerateIndexFileContent(table, &FileMeta{MinCommitTs: 5, MaxCommitTs: 6})
+	require.NoError(t, err)
+	index, err = ParseIndexFile(content)
+	require.NoError(t, err)
+	require.Equal(t, []FileMeta{
+		{File: "CDC000002.json", MinCommitTs: 1, MaxCommitTs: 2},
+		{File: "CDC000003.json", MinCommitTs: 5, MaxCommitTs: 6},
+	}, index.Files)
+}
+
 func TestIsSchemaFile(t *testing.T) {
 	t.Parallel()
 
@@ -275,3 +337,21 @@ func TestIsSchemaFile(t *testing.T) {
 			"testCase: %s, path: %v", tt.name, tt.path)
 	}
 }
+
+func TestIsDDLAndSchemaManifestFile(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsDDLFile("schema1/table1/meta/ddl_123.json"))
+	require.True(t, IsDDLFile("schema1/meta/ddl_123.json"))
+	require.False(t, IsDDLFile("schema1/table1/meta/ddl_abc.json"))
+	require.False(t, IsDDLFile("schema1/table1/123/ddl_123.json"))
+	require.False(t, IsDDLFile("schema1/table1/meta/schema_123_0123456789.json"))
+
+	require.True(t, IsSchemaManifestFile("schema1/table1/meta/manifest.json"))
+	require.False(t, IsSchemaManifestFile("schema1/table1/123/manifest.json"))
+	require.False(t, IsSchemaFile("schema1/table1/meta/manifest.json"))
+
+	require.Equal(t, "schema1/table1/meta/ddl_100.json", generateDDLFilePath("schema1", "table1", 100))
+	require.Equal(t, "schema1/meta/ddl_100.json", generateDDLFilePath("schema1", "", 100))
+	require.Equal(t, "schema1/table1/meta/manifest.json", GenerateSchemaManifestPath("schema1", "table1"))
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"encoding/json"
+	"sort"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+// DDLEventFile is the content of a DDL event file, which records the schema
+// of the table before and after the DDL.
+type DDLEventFile struct {
+	CommitTs uint64             `json:"CommitTs"`
+	Type     timodel.ActionType `json:"Type"`
+	Query    string             `json:"Query"`
+	// PreTable is the table schema before the DDL, it's nil if the table
+	// doesn't exist before the DDL, e.g. CREATE TABLE.
+	PreTable *TableDefinition `json:"PreTable,omitempty"`
+	Table    TableDefinition  `json:"Table"`
+}
+
+// FromDDLEvent converts from DDLEvent to DDLEventFile.
+func (f *DDLEventFile) FromDDLEvent(event *model.DDLEvent) {
+	f.CommitTs = event.CommitTs
+	f.Type = event.Type
+	f.Query = event.Query
+	f.Table.FromDDLEvent(event)
+	if event.PreTableInfo != nil && event.PreTableInfo.TableInfo != nil {
+		f.PreTable = &TableDefinition{}
+		f.PreTable.FromTableInfo(event.PreTableInfo, event.PreTableInfo.Version)
+	}
+}
+
+// Marshal marshals the DDLEventFile.
+func (f *DDLEventFile) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(f, marshalPrefix, marshalIndent)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrMarshalFailed, err)
+	}
+	return data, nil
+}
+
+// GenerateFilePath generates the path of the DDL event file.
+func (f *DDLEventFile) GenerateFilePath() string {
+	return generateDDLFilePath(f.Table.Schema, f.Table.Table, f.CommitTs)
+}
+
+// SchemaVersion is a version of a table schema in the schema manifest.
+type SchemaVersion struct {
+	TableVersion uint64             `json:"TableVersion"`
+	SchemaFile   string             `json:"SchemaFile"`
+	DDLFile      string             `json:"DDLFile,omitempty"`
+	Query        string             `json:"Query,omitempty"`
+	Type         timodel.ActionType `json:"Type,omitempty"`
+}
+
+// TableSchemaManifest records the schema version history of a table.
+// Data files in <schema>/<table>/<tableVersion>/ are written with the
+// latest schema version whose TableVersion is not greater than tableVersion.
+type TableSchemaManifest struct {
+	Schema   string          `json:"Schema"`
+	Table    string          `json:"Table"`
+	Versions []SchemaVersion `json:"Versions"`
+}
+
+// UnmarshalTableSchemaManifest unmarshals a TableSchemaManifest.
+func UnmarshalTableSchemaManifest(data []byte) (*TableSchemaManifest, error) {
+	m := &TableSchemaManifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, errors.WrapError(errors.ErrUnmarshalFailed, err)
+	}
+	return m, nil
+}
+
+// Marshal marshals the TableSchemaManifest.
+func (m *TableSchemaManifest) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(m, marshalPrefix, marshalIndent)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrMarshalFailed, err)
+	}
+	return data, nil
+}
+
+// AddVersion adds a schema version to the manifest. If the version exists,
+// it's replaced, so adding a version is idempotent.
+func (m *TableSchemaManifest) AddVersion(version SchemaVersion) {
+	for i := range m.Versions {
+		if m.Versions[i].TableVersion == version.TableVersion {
+			m.Versions[i] = version
+			return
+		}
+	}
+	m.Versions = append(m.Versions, version)
+	sort.Slice(m.Versions, func(i, j int) bool {
+		return m.Versions[i].TableVersion < m.Versions[j].TableVersion
+	})
+}
+
+// SchemaAt returns the schema version which applies to data files written
+// with the given table version.
+func (m *TableSchemaManifest) SchemaAt(tableVersion uint64) (SchemaVersion, bool) {
+	idx := sort.Search(len(m.Versions), func(i int) bool {
+		return m.Versions[i].TableVersion > tableVersion
+	})
+	if idx == 0 {
+		return SchemaVersion{}, false
+	}
+	return m.Versions[idx-1], true
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableSchemaManifest(t *testing.T) {
+	t.Parallel()
+
+	m := &TableSchemaManifest{Schema: "test", Table: "table1"}
+	_, ok := m.SchemaAt(100)
+	require.False(t, ok)
+
+	m.AddVersion(SchemaVersion{TableVersion: 200, SchemaFile: "schema_200"})
+	m.AddVersion(SchemaVersion{TableVersion: 100, SchemaFile: "schema_100"})
+	m.AddVersion(SchemaVersion{TableVersion: 300, SchemaFile: "schema_300"})
+	// Adding an existing version replaces it.
+	m.AddVersion(SchemaVersion{TableVersion: 200, SchemaFile: "schema_200_new"})
+	require.Len(t, m.Versions, 3)
+	require.Equal(t, uint64(100), m.Versions[0].TableVersion)
+	require.Equal(t, uint64(300), m.Versions[2].TableVersion)
+
+	_, ok = m.SchemaAt(99)
+	require.False(t, ok)
+	v, ok := m.SchemaAt(100)
+	require.True(t, ok)
+	require.Equal(t, "schema_100", v.SchemaFile)
+	v, ok = m.SchemaAt(250)
+	require.True(t, ok)
+	require.Equal(t, "schema_200_new", v.SchemaFile)
+	v, ok = m.SchemaAt(1000)
+	require.True(t, ok)
+	require.Equal(t, "schema_300", v.SchemaFile)
+
+	data, err := m.Marshal()
+	require.NoError(t, err)
+	m1, err := UnmarshalTableSchemaManifest(data)
+	require.NoError(t, err)
+	require.Equal(t, m, m1)
+
+	_, err = UnmarshalTableSchemaManifest([]byte("{"))
+	require.Regexp(t, "ErrUnmarshalFailed", err)
+}
+
+func TestDDLEventFile(t *testing.T) {
+	t.Parallel()
+
+	col1 := &timodel.ColumnInfo{
+		Name:      timodel.NewCIStr("col1"),
+		FieldType: *types.NewFieldType(mysql.TypeLong),
+	}
+	col2 := &timodel.ColumnInfo{
+		Name:      timodel.NewCIStr("col2"),
+		FieldType: *types.NewFieldType(mysql.TypeVarchar),
+	}
+	tableName := model.TableName{Schema: "test", Table: "table1", TableID: 20}
+	event := &model.DDLEvent{
+		CommitTs: 100,
+		Type:     timodel.ActionAddColumn,
+		Query:    "alter table test.table1 add col2 varchar(64)",
+		TableInfo: &model.TableInfo{
+			Version:   100,
+			TableName: tableName,
+			TableInfo: &timodel.TableInfo{Columns: []*timodel.ColumnInfo{col1, col2}},
+		},
+		PreTableInfo: &model.TableInfo{
+			Version:   90,
+			TableName: tableName,
+			TableInfo: &timodel.TableInfo{Columns: []*timodel.ColumnInfo{col1}},
+		},
+	}
+
+	var f DDLEventFile
+	f.FromDDLEvent(event)
+	require.Equal(t, "test/table1/meta/ddl_100.json", f.GenerateFilePath())
+	require.Equal(t, 2, f.Table.TotalColumns)
+	require.NotNil(t, f.PreTable)
+	require.Equal(t, 1, f.PreTable.TotalColumns)
+	require.Equal(t, uint64(90), f.PreTable.TableVersion)
+
+	// CREATE TABLE has no pre-table.
+	event.Type = timodel.ActionCreateTable
+	event.PreTableInfo = nil
+	f = DDLEventFile{}
+	f.FromDDLEvent(event)
+	require.Nil(t, f.PreTable)
+	data, err := f.Marshal()
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "PreTable")
+}
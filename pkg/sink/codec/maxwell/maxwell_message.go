@@ -68,7 +68,7 @@ func rowChangeToMaxwellMsg(e *model.RowChangedEvent, onlyHandleKeyColumns bool)
 	if e.IsDelete() {
 		value.Type = "delete"
 		for _, v := range e.PreColumns {
-			if onlyHandleKeyColumns && !v.Flag.IsHandleKey() {
+			if v == nil || (onlyHandleKeyColumns && !v.Flag.IsHandleKey()) {
 				continue
 			}
 			switch v.Type {
@@ -86,6 +86,9 @@ func rowChangeToMaxwellMsg(e *model.RowChangedEvent, onlyHandleKeyColumns bool)
 		}
 	} else {
 		for _, v := range e.Columns {
+			if v == nil {
+				continue
+			}
 			switch v.Type {
 			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
 				if v.Value == nil {
@@ -104,6 +107,9 @@ func rowChangeToMaxwellMsg(e *model.RowChangedEvent, onlyHandleKeyColumns bool)
 		} else {
 			value.Type = "update"
 			for _, v := range e.PreColumns {
+				if v == nil {
+					continue
+				}
 				switch v.Type {
 				case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
 					if v.Value == nil {
@@ -58,3 +58,25 @@ func TestEncodeBinaryToMaxwell(t *testing.T) {
 	require.NotNil(t, key)
 	require.NotNil(t, msg)
 }
+
+func TestSkipNilColumnsToMaxwell(t *testing.T) {
+	t.Parallel()
+
+	columns := func(v int) []*model.Column {
+		return []*model.Column{{Name: "a", Type: mysql.TypeLong, Value: v}, nil}
+	}
+	e := &model.RowChangedEvent{
+		Table:      &model.TableName{Schema: "a", Table: "b"},
+		PreColumns: columns(1),
+		Columns:    columns(2),
+	}
+	_, msg := rowChangeToMaxwellMsg(e, false)
+	require.Equal(t, "update", msg.Type)
+	require.Equal(t, map[string]interface{}{"a": 2}, msg.Data)
+	require.Equal(t, map[string]interface{}{"a": 1}, msg.Old)
+
+	e.Columns = nil
+	_, msg = rowChangeToMaxwellMsg(e, false)
+	require.Equal(t, "delete", msg.Type)
+	require.Equal(t, map[string]interface{}{"a": 1}, msg.Old)
+}
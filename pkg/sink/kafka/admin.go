@@ -113,7 +113,10 @@ func (a *saramaAdminClient) queryClusterWithRetry(ctx context.Context, query fun
 		}
 
 		return a.reset()
-	}, retry.WithBackoffBaseDelay(defaultRetryBackoff), retry.WithMaxTries(defaultRetryMaxTries))
+	}, retry.WithName("kafka_admin_query"),
+		retry.WithBackoffBaseDelay(defaultRetryBackoff),
+		retry.WithDecorrelatedJitter(),
+		retry.WithMaxTries(defaultRetryMaxTries))
 	return err
 }
 
@@ -295,6 +298,25 @@ func (a *saramaAdminClient) CreateTopic(
 	return a.queryClusterWithRetry(ctx, query)
 }
 
+func (a *saramaAdminClient) DeleteTopics(ctx context.Context, topics []string) error {
+	for _, topic := range topics {
+		topic := topic
+		query := func() error {
+			err := a.admin.DeleteTopic(topic)
+			// Ignore the unknown topic error because the topic may be deleted
+			// by a previous attempt.
+			if err != nil && !strings.Contains(err.Error(), sarama.ErrUnknownTopicOrPartition.Error()) {
+				return err
+			}
+			return nil
+		}
+		if err := a.queryClusterWithRetry(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *saramaAdminClient) Close() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
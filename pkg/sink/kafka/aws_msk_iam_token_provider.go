@@ -0,0 +1,114 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	awsMSKIAMService   = "kafka-cluster"
+	awsMSKIAMAction    = "kafka-cluster:Connect"
+	awsMSKIAMUserAgent = "TiCDC"
+	// awsMSKIAMTokenExpiry is how long a signed token is valid.
+	awsMSKIAMTokenExpiry = 15 * time.Minute
+	// awsMSKIAMRefreshBefore is how long before the expiry a token is
+	// refreshed, so that a token is never expired when it reaches brokers.
+	awsMSKIAMRefreshBefore = 3 * time.Minute
+)
+
+// awsMSKIAMTokenProvider generates tokens for SASL/OAUTHBEARER auth of
+// AWS MSK clusters with IAM access control. A token is a presigned URL of
+// the kafka-cluster:Connect action, which is signed by the credentials
+// from the default AWS credential chain, so no static password is needed.
+type awsMSKIAMTokenProvider struct {
+	region string
+	signer *v4.Signer
+	now    func() time.Time
+
+	mu       sync.Mutex
+	token    string
+	expireAt time.Time
+}
+
+var _ sarama.AccessTokenProvider = (*awsMSKIAMTokenProvider)(nil)
+
+func newAWSMSKIAMTokenProvider(_ context.Context, o *Options) (sarama.AccessTokenProvider, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+	region := o.SASL.OAuth2.AWSRegion
+	if region == "" && sess.Config.Region != nil {
+		region = *sess.Config.Region
+	}
+	if region == "" {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"AWS region is required by the AWS MSK IAM token provider")
+	}
+	return &awsMSKIAMTokenProvider{
+		region: region,
+		signer: v4.NewSigner(sess.Config.Credentials),
+		now:    time.Now,
+	}, nil
+}
+
+// Token implements the sarama.AccessTokenProvider interface. The token is
+// reused until it's about to expire.
+func (p *awsMSKIAMTokenProvider) Token() (*sarama.AccessToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.token == "" || !now.Add(awsMSKIAMRefreshBefore).Before(p.expireAt) {
+		token, err := p.sign(now)
+		if err != nil {
+			return nil, err
+		}
+		p.token = token
+		p.expireAt = now.Add(awsMSKIAMTokenExpiry)
+	}
+	return &sarama.AccessToken{Token: p.token}, nil
+}
+
+func (p *awsMSKIAMTokenProvider) sign(now time.Time) (string, error) {
+	endpoint := fmt.Sprintf("https://kafka.%s.amazonaws.com/?Action=%s",
+		p.region, url.QueryEscape(awsMSKIAMAction))
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := p.signer.Presign(req, nil, awsMSKIAMService, p.region,
+		awsMSKIAMTokenExpiry, now); err != nil {
+		return "", errors.Trace(err)
+	}
+	query := req.URL.Query()
+	query.Set("User-Agent", awsMSKIAMUserAgent)
+	req.URL.RawQuery = query.Encode()
+	return base64.RawURLEncoding.EncodeToString([]byte(req.URL.String())), nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSMSKIAMTokenProvider(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	p := &awsMSKIAMTokenProvider{
+		region: "us-west-2",
+		signer: v4.NewSigner(credentials.NewStaticCredentials("AKID", "SECRET", "")),
+		now:    func() time.Time { return now },
+	}
+
+	token, err := p.Token()
+	require.NoError(t, err)
+	raw, err := base64.RawURLEncoding.DecodeString(token.Token)
+	require.NoError(t, err)
+	u, err := url.Parse(string(raw))
+	require.NoError(t, err)
+	require.Equal(t, "kafka.us-west-2.amazonaws.com", u.Host)
+	query := u.Query()
+	require.Equal(t, "kafka-cluster:Connect", query.Get("Action"))
+	require.Equal(t, "900", query.Get("X-Amz-Expires"))
+	require.Equal(t, "20230601T000000Z", query.Get("X-Amz-Date"))
+	require.Contains(t, query.Get("X-Amz-Credential"), "AKID/20230601/us-west-2/kafka-cluster/")
+	require.NotEmpty(t, query.Get("X-Amz-Signature"))
+	require.Equal(t, "TiCDC", query.Get("User-Agent"))
+
+	// The token is reused until it's about to expire.
+	now = now.Add(10 * time.Minute)
+	reused, err := p.Token()
+	require.NoError(t, err)
+	require.Equal(t, token.Token, reused.Token)
+
+	now = now.Add(3 * time.Minute)
+	refreshed, err := p.Token()
+	require.NoError(t, err)
+	require.NotEqual(t, token.Token, refreshed.Token)
+}
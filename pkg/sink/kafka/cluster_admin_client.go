@@ -52,6 +52,9 @@ type ClusterAdminClient interface {
 	// CreateTopic creates a new topic.
 	CreateTopic(ctx context.Context, detail *TopicDetail, validateOnly bool) error
 
+	// DeleteTopics deletes the topics, topics which don't exist are ignored.
+	DeleteTopics(ctx context.Context, topics []string) error
+
 	// Close shuts down the admin client.
 	Close()
 }
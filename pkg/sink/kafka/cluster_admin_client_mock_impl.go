@@ -195,6 +195,14 @@ func (c *ClusterAdminClientMockImpl) DeleteTopic(topicName string) {
 	delete(c.topics, topicName)
 }
 
+// DeleteTopics deletes the topics.
+func (c *ClusterAdminClientMockImpl) DeleteTopics(_ context.Context, topics []string) error {
+	for _, topic := range topics {
+		c.DeleteTopic(topic)
+	}
+	return nil
+}
+
 // Close do nothing.
 func (c *ClusterAdminClientMockImpl) Close() {}
 
@@ -16,14 +16,69 @@ package kafka
 import (
 	"context"
 	"net/url"
+	"sync"
 
 	"github.com/Shopify/sarama"
 	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
-// tsokenProvider is a user-defined callback for generating
+const (
+	// TokenProviderOAuth2 is the name of the token provider which gets
+	// tokens by the OAuth2 client credentials flow, it's used by default.
+	TokenProviderOAuth2 = "oauth2"
+	// TokenProviderAWSMSKIAM is the name of the token provider which signs
+	// tokens by AWS MSK IAM.
+	TokenProviderAWSMSKIAM = "aws-msk-iam"
+)
+
+// TokenProviderCreator creates a token provider for SASL/OAUTHBEARER auth.
+type TokenProviderCreator func(ctx context.Context, o *Options) (sarama.AccessTokenProvider, error)
+
+var tokenProviders = struct {
+	sync.RWMutex
+	creators map[string]TokenProviderCreator
+}{
+	creators: map[string]TokenProviderCreator{
+		TokenProviderOAuth2:    newOAuth2TokenProvider,
+		TokenProviderAWSMSKIAM: newAWSMSKIAMTokenProvider,
+	},
+}
+
+// RegisterTokenProvider registers a token provider for SASL/OAUTHBEARER
+// auth, so that it can be used by its name in sasl-oauth-token-provider.
+// For example, a managed Kafka service with its own token service can
+// provide a token provider which talks to the service.
+func RegisterTokenProvider(name string, creator TokenProviderCreator) {
+	tokenProviders.Lock()
+	defer tokenProviders.Unlock()
+	tokenProviders.creators[name] = creator
+}
+
+func getTokenProviderCreator(name string) (TokenProviderCreator, bool) {
+	tokenProviders.RLock()
+	defer tokenProviders.RUnlock()
+	creator, ok := tokenProviders.creators[name]
+	return creator, ok
+}
+
+// newTokenProvider creates the token provider configured by the options.
+func newTokenProvider(ctx context.Context, o *Options) (sarama.AccessTokenProvider, error) {
+	name := o.SASL.OAuth2.TokenProvider
+	if name == "" {
+		name = TokenProviderOAuth2
+	}
+	creator, ok := getTokenProviderCreator(name)
+	if !ok {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"unknown OAuth token provider %s", name)
+	}
+	return creator(ctx, o)
+}
+
+// tokenProvider is a user-defined callback for generating
 // access tokens for SASL/OAUTHBEARER auth.
 type tokenProvider struct {
 	tokenSource oauth2.TokenSource
@@ -51,7 +106,7 @@ func (t *tokenProvider) Token() (*sarama.AccessToken, error) {
 	return &sarama.AccessToken{Token: token.AccessToken}, nil
 }
 
-func newTokenProvider(ctx context.Context, o *Options) (sarama.AccessTokenProvider, error) {
+func newOAuth2TokenProvider(ctx context.Context, o *Options) (sarama.AccessTokenProvider, error) {
 	// grant_type is by default going to be set to 'client_credentials' by the
 	// clientcredentials library as defined by the spec, however non-compliant
 	// auth server implementations may want a custom type
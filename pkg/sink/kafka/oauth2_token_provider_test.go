@@ -17,6 +17,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/stretchr/testify/require"
 )
@@ -72,3 +73,28 @@ func TestNewTokenProvider(t *testing.T) {
 		})
 	}
 }
+
+type staticTokenProvider struct{}
+
+func (staticTokenProvider) Token() (*sarama.AccessToken, error) {
+	return &sarama.AccessToken{Token: "static"}, nil
+}
+
+func TestRegisterTokenProvider(t *testing.T) {
+	t.Parallel()
+
+	o := &Options{SASL: &security.SASL{OAuth2: security.OAuth2{TokenProvider: "test-static"}}}
+	_, err := newTokenProvider(context.TODO(), o)
+	require.ErrorContains(t, err, "unknown OAuth token provider test-static")
+
+	RegisterTokenProvider("test-static", func(
+		context.Context, *Options,
+	) (sarama.AccessTokenProvider, error) {
+		return staticTokenProvider{}, nil
+	})
+	p, err := newTokenProvider(context.TODO(), o)
+	require.NoError(t, err)
+	token, err := p.Token()
+	require.NoError(t, err)
+	require.Equal(t, "static", token.Token)
+}
@@ -131,6 +131,7 @@ type urlConfig struct {
 	Cert                         *string `form:"cert"`
 	Key                          *string `form:"key"`
 	InsecureSkipVerify           *bool   `form:"insecure-skip-verify"`
+	AdaptiveBatching             *bool   `form:"adaptive-batching"`
 }
 
 // Options stores user specified configurations
@@ -161,6 +162,10 @@ type Options struct {
 	DialTimeout  time.Duration
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+
+	// AdaptiveBatching indicates whether to tune the linger, batch size and
+	// in-flight limit of every partition by the observed broker latency.
+	AdaptiveBatching bool
 }
 
 // NewOptions returns a default Kafka configuration
@@ -298,6 +303,10 @@ func (o *Options) Apply(changefeedID model.ChangeFeedID,
 		o.RequiredAcks = r
 	}
 
+	if urlParameter.AdaptiveBatching != nil {
+		o.AdaptiveBatching = *urlParameter.AdaptiveBatching
+	}
+
 	err = o.applySASL(urlParameter, replicaConfig)
 	if err != nil {
 		return err
@@ -345,6 +354,7 @@ func mergeConfig(
 		dest.Cert = fileConifg.Cert
 		dest.Key = fileConifg.Key
 		dest.InsecureSkipVerify = fileConifg.InsecureSkipVerify
+		dest.AdaptiveBatching = fileConifg.AdaptiveBatching
 	}
 	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride); err != nil {
 		return nil, err
@@ -512,6 +522,24 @@ func (o *Options) applySASL(urlParameter *urlConfig, replicaConfig *config.Repli
 		if replicaConfig.Sink.KafkaConfig.SASLOAuthAudience != nil {
 			o.SASL.OAuth2.Audience = *replicaConfig.Sink.KafkaConfig.SASLOAuthAudience
 		}
+
+		if replicaConfig.Sink.KafkaConfig.SASLOAuthTokenProvider != nil {
+			name := *replicaConfig.Sink.KafkaConfig.SASLOAuthTokenProvider
+			if _, ok := getTokenProviderCreator(name); !ok {
+				return cerror.ErrKafkaInvalidConfig.GenWithStack(
+					"unknown OAuth token provider %s", name)
+			}
+			if o.SASL.SASLMechanism != security.OAuthMechanism {
+				return cerror.ErrKafkaInvalidConfig.GenWithStack(
+					"OAuth token provider is only supported with SASL mechanism type OAUTHBEARER, but got %s",
+					o.SASL.SASLMechanism)
+			}
+			o.SASL.OAuth2.TokenProvider = name
+		}
+
+		if replicaConfig.Sink.KafkaConfig.SASLOAuthAWSRegion != nil {
+			o.SASL.OAuth2.AWSRegion = *replicaConfig.Sink.KafkaConfig.SASLOAuthAWSRegion
+		}
 	}
 
 	return nil
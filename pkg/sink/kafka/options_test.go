@@ -51,6 +51,7 @@ func TestCompleteOptions(t *testing.T) {
 	require.Equal(t, "2.6.0", options.Version)
 	require.Equal(t, 4096, options.MaxMessageBytes)
 	require.Equal(t, WaitForLocal, options.RequiredAcks)
+	require.False(t, options.AdaptiveBatching)
 
 	// multiple kafka broker endpoints
 	uri = "kafka://127.0.0.1:9092,127.0.0.1:9091,127.0.0.1:9090/kafka-test?"
@@ -659,6 +660,7 @@ func TestMerge(t *testing.T) {
 		CA:                        aws.String("ca.pem"),
 		Cert:                      aws.String("cert.pem"),
 		Key:                       aws.String("key.pem"),
+		AdaptiveBatching:          aws.Bool(true),
 	}
 	c := NewOptions()
 	err = c.Apply(model.DefaultChangeFeedID("test"), sinkURI, replicaConfig)
@@ -687,6 +689,7 @@ func TestMerge(t *testing.T) {
 	require.Equal(t, "ca.pem", c.Credential.CAPath)
 	require.Equal(t, "cert.pem", c.Credential.CertPath)
 	require.Equal(t, "key.pem", c.Credential.KeyPath)
+	require.True(t, c.AdaptiveBatching)
 
 	// test override
 	uri = "kafka://topic?partition-num=12" +
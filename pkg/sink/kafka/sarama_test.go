@@ -253,6 +253,51 @@ func TestApplySASL(t *testing.T) {
 			},
 			exceptErr: "OAuth2 is only supported with SASL mechanism type OAUTHBEARER",
 		},
+		{
+			name: "valid OAUTHBEARER SASL: AWS MSK IAM token provider",
+			URI:  "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&partition-num=0&sasl-mechanism=OAUTHBEARER",
+			replicaConfig: func() *config.ReplicaConfig {
+				cfg := config.GetDefaultReplicaConfig()
+				oauthMechanism := string(security.OAuthMechanism)
+				tokenProvider := TokenProviderAWSMSKIAM
+				region := "us-east-1"
+				cfg.Sink.KafkaConfig = &config.KafkaConfig{
+					SASLMechanism:          &oauthMechanism,
+					SASLOAuthTokenProvider: &tokenProvider,
+					SASLOAuthAWSRegion:     &region,
+				}
+				return cfg
+			},
+			exceptErr: "",
+		},
+		{
+			name: "invalid OAUTHBEARER SASL: unknown token provider",
+			URI:  "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&partition-num=0&sasl-mechanism=OAUTHBEARER",
+			replicaConfig: func() *config.ReplicaConfig {
+				cfg := config.GetDefaultReplicaConfig()
+				oauthMechanism := string(security.OAuthMechanism)
+				tokenProvider := "unknown"
+				cfg.Sink.KafkaConfig = &config.KafkaConfig{
+					SASLMechanism:          &oauthMechanism,
+					SASLOAuthTokenProvider: &tokenProvider,
+				}
+				return cfg
+			},
+			exceptErr: "unknown OAuth token provider unknown",
+		},
+		{
+			name: "invalid OAUTHBEARER SASL: token provider with wrong mechanism",
+			URI:  "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&partition-num=0&sasl-mechanism=PLAIN",
+			replicaConfig: func() *config.ReplicaConfig {
+				cfg := config.GetDefaultReplicaConfig()
+				tokenProvider := TokenProviderAWSMSKIAM
+				cfg.Sink.KafkaConfig = &config.KafkaConfig{
+					SASLOAuthTokenProvider: &tokenProvider,
+				}
+				return cfg
+			},
+			exceptErr: "OAuth token provider is only supported with SASL mechanism type OAUTHBEARER",
+		},
 	}
 
 	for _, test := range tests {
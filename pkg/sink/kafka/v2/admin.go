@@ -220,6 +220,26 @@ func (a *admin) CreateTopic(
 	return nil
 }
 
+func (a *admin) DeleteTopics(ctx context.Context, topics []string) error {
+	if len(topics) == 0 {
+		return nil
+	}
+	response, err := a.client.DeleteTopics(ctx, &kafka.DeleteTopicsRequest{
+		Topics: topics,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, err := range response.Errors {
+		if err != nil && !errors.Is(err, kafka.UnknownTopicOrPartition) {
+			return errors.Trace(err)
+		}
+	}
+
+	return nil
+}
+
 func (a *admin) Close() {
 	log.Info("admin client start closing",
 		zap.String("namespace", a.changefeedID.Namespace),
@@ -358,3 +358,36 @@ func TestAdminClose(t *testing.T) {
 	client.EXPECT().Destroy()
 	ad.Close()
 }
+
+func TestDeleteTopics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	admin, client := newClusterAdminClientWithMock(t)
+
+	require.NoError(t, admin.DeleteTopics(ctx, nil))
+
+	client.EXPECT().DeleteTopics(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("kafka.(*Client).DeleteTopics"))
+	err := admin.DeleteTopics(ctx, []string{"topic-1"})
+	require.Error(t, err)
+
+	client.EXPECT().DeleteTopics(gomock.Any(), gomock.Any()).
+		Return(&kafka.DeleteTopicsResponse{
+			Errors: map[string]error{
+				"topic-1": errors.New("topic-1 error"),
+			},
+		}, nil)
+	err = admin.DeleteTopics(ctx, []string{"topic-1"})
+	require.Error(t, err, "topic-1 error")
+
+	client.EXPECT().DeleteTopics(gomock.Any(), gomock.Any()).
+		Return(&kafka.DeleteTopicsResponse{
+			Errors: map[string]error{
+				"topic-1": kafka.UnknownTopicOrPartition,
+				"topic-2": nil,
+			},
+		}, nil)
+	err = admin.DeleteTopics(ctx, []string{"topic-1", "topic-2"})
+	require.NoError(t, err)
+}
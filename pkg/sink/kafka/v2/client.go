@@ -30,4 +30,7 @@ type Client interface {
 	CreateTopics(
 		ctx context.Context, req *kafka.CreateTopicsRequest,
 	) (*kafka.CreateTopicsResponse, error)
+	DeleteTopics(
+		ctx context.Context, req *kafka.DeleteTopicsRequest,
+	) (*kafka.DeleteTopicsResponse, error)
 }
@@ -50,6 +50,21 @@ func (mr *MockClientMockRecorder) CreateTopics(ctx, req interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopics", reflect.TypeOf((*MockClient)(nil).CreateTopics), ctx, req)
 }
 
+// DeleteTopics mocks base method.
+func (m *MockClient) DeleteTopics(ctx context.Context, req *kafka.DeleteTopicsRequest) (*kafka.DeleteTopicsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTopics", ctx, req)
+	ret0, _ := ret[0].(*kafka.DeleteTopicsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTopics indicates an expected call of DeleteTopics.
+func (mr *MockClientMockRecorder) DeleteTopics(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopics", reflect.TypeOf((*MockClient)(nil).DeleteTopics), ctx, req)
+}
+
 // DescribeConfigs mocks base method.
 func (m *MockClient) DescribeConfigs(ctx context.Context, req *kafka.DescribeConfigsRequest) (*kafka.DescribeConfigsResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,272 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"hash/fnv"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+const (
+	// KeyProperty is the user property which carries the key of a message,
+	// because MQTT messages have no key.
+	KeyProperty = "ticdc-message-key"
+
+	keepAlive   = 30 * time.Second
+	dialTimeout = 10 * time.Second
+	// packetOverhead is reserved in the maximum packet size of the broker for
+	// the fixed header, topic and properties of a PUBLISH packet.
+	packetOverhead = 4096
+)
+
+// Token is the acknowledgement of a message published with QoS 1.
+type Token interface {
+	// Done returns a channel which is closed once the message is acked or
+	// fails to be published.
+	Done() <-chan struct{}
+	// Err returns the error of publishing, it's only valid after Done is closed.
+	Err() error
+}
+
+// Client is the interface of an MQTT client.
+type Client interface {
+	// PublishAsync publishes a message to the topic template asynchronously,
+	// the returned token is done once the broker acks the message.
+	// Messages of the same topic are acked in the publish order.
+	PublishAsync(ctx context.Context, topic string, message *common.Message) (Token, error)
+	// Publish publishes a message to the topic template and waits for the ack.
+	Publish(ctx context.Context, topic string, message *common.Message) error
+	// Close closes the client, messages which are not acked fail.
+	Close()
+}
+
+// Factory is a function to create an MQTT client.
+type Factory func(o *Options, changefeedID model.ChangeFeedID) (Client, error)
+
+type token struct {
+	done chan struct{}
+	err  error
+}
+
+func newToken() *token {
+	return &token{done: make(chan struct{})}
+}
+
+func (t *token) finish(err error) {
+	t.err = err
+	close(t.done)
+}
+
+func (t *token) Done() <-chan struct{} { return t.done }
+
+func (t *token) Err() error { return t.err }
+
+type publishRequest struct {
+	publish *paho.Publish
+	token   *token
+}
+
+type pahoClient struct {
+	client  *paho.Client
+	options *Options
+	// workers publish messages one by one, messages of a topic are always
+	// sent to the same worker, so they are delivered in order.
+	workers []chan publishRequest
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPahoClient creates a new MQTT v5 client. Each client connects with a
+// unique client identifier, because the DML sinks of a changefeed run on all
+// captures and brokers kick out the old connection of a reused identifier.
+func NewPahoClient(o *Options, changefeedID model.ChangeFeedID) (Client, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	var err error
+	if o.UseTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", o.Broker, &tls.Config{})
+	} else {
+		conn, err = dialer.Dial("tcp", o.Broker)
+	}
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMQTTNewClient, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &pahoClient{
+		options: o,
+		workers: make([]chan publishRequest, o.MaxInflight),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	c.client = paho.NewClient(paho.ClientConfig{
+		// Writes of tls.Conn are not thread safe.
+		Conn:          packets.NewThreadSafeConn(conn),
+		PacketTimeout: o.AckTimeout,
+		OnClientError: func(err error) {
+			log.Warn("mqtt client error",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.Error(err))
+		},
+		OnServerDisconnect: func(d *paho.Disconnect) {
+			log.Warn("mqtt broker disconnected",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.Uint8("reasonCode", d.ReasonCode))
+		},
+	})
+
+	connect := &paho.Connect{
+		ClientID:   "ticdc_" + changefeedID.Namespace + "_" + changefeedID.ID + "_" + uuid.NewString(),
+		KeepAlive:  uint16(keepAlive / time.Second),
+		CleanStart: true,
+	}
+	if o.User != "" {
+		connect.UsernameFlag = true
+		connect.Username = o.User
+	}
+	if o.Password != "" {
+		connect.PasswordFlag = true
+		connect.Password = []byte(o.Password)
+	}
+	connectCtx, connectCancel := context.WithTimeout(ctx, dialTimeout)
+	connack, err := c.client.Connect(connectCtx, connect)
+	connectCancel()
+	if err != nil {
+		cancel()
+		_ = conn.Close()
+		return nil, cerror.WrapError(cerror.ErrMQTTNewClient, err)
+	}
+	if connack.Properties != nil {
+		if o.RetainResolved && !connack.Properties.RetainAvailable {
+			c.Close()
+			return nil, cerror.ErrMQTTInvalidConfig.GenWithStack(
+				"broker doesn't support retained messages, set retain-resolved=false")
+		}
+		if size := connack.Properties.MaximumPacketSize; size != nil &&
+			int(*size)-packetOverhead < o.MaxMessageBytes {
+			log.Info("max-message-bytes is lowered to the maximum packet size of the broker",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.Int("maxMessageBytes", o.MaxMessageBytes),
+				zap.Uint32("maximumPacketSize", *size))
+			o.MaxMessageBytes = int(*size) - packetOverhead
+			if o.MaxMessageBytes <= 0 {
+				c.Close()
+				return nil, cerror.ErrMQTTInvalidConfig.GenWithStack(
+					"maximum packet size %d of the broker is too small", *size)
+			}
+		}
+	}
+
+	for i := range c.workers {
+		// The number of pending messages is bounded by the producer.
+		c.workers[i] = make(chan publishRequest, o.MaxPendingAcks)
+		c.wg.Add(1)
+		go c.runWorker(c.workers[i])
+	}
+	return c, nil
+}
+
+func (c *pahoClient) newPublish(topic string, message *common.Message) *paho.Publish {
+	publish := &paho.Publish{
+		QoS:     1,
+		Retain:  c.options.retain(message),
+		Topic:   GetTopic(topic, message),
+		Payload: message.Value,
+		Properties: &paho.PublishProperties{
+			MessageExpiry: c.options.messageExpiry(),
+		},
+	}
+	if len(message.Key) > 0 {
+		publish.Properties.User.Add(KeyProperty, string(message.Key))
+	}
+	return publish
+}
+
+// PublishAsync implements Client.PublishAsync.
+func (c *pahoClient) PublishAsync(
+	ctx context.Context, topic string, message *common.Message,
+) (Token, error) {
+	if err := c.options.checkMessageSize(message); err != nil {
+		return nil, errors.Trace(err)
+	}
+	publish := c.newPublish(topic, message)
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(publish.Topic))
+	worker := c.workers[h.Sum32()%uint32(len(c.workers))]
+
+	req := publishRequest{publish: publish, token: newToken()}
+	select {
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	case <-c.ctx.Done():
+		return nil, cerror.ErrMQTTSendMessage.GenWithStack("client is closed")
+	case worker <- req:
+	}
+	return req.token, nil
+}
+
+// Publish implements Client.Publish.
+func (c *pahoClient) Publish(ctx context.Context, topic string, message *common.Message) error {
+	if err := c.options.checkMessageSize(message); err != nil {
+		return errors.Trace(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.options.AckTimeout)
+	defer cancel()
+	_, err := c.client.Publish(ctx, c.newPublish(topic, message))
+	return errors.Trace(err)
+}
+
+func (c *pahoClient) runWorker(requests <-chan publishRequest) {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case req := <-requests:
+			_, err := c.client.Publish(c.ctx, req.publish)
+			if err != nil {
+				err = cerror.WrapError(cerror.ErrMQTTSendMessage, err)
+			}
+			req.token.finish(err)
+		}
+	}
+}
+
+// Close implements Client.Close.
+func (c *pahoClient) Close() {
+	c.cancel()
+	c.wg.Wait()
+	if err := c.client.Disconnect(&paho.Disconnect{ReasonCode: 0}); err != nil {
+		log.Warn("close mqtt connection failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+)
+
+var _ Client = (*MockClient)(nil)
+
+// MockMessage is a message published to the MockClient.
+type MockMessage struct {
+	Retain  bool
+	Message *common.Message
+}
+
+type pendingMockMessage struct {
+	topic   string
+	message MockMessage
+	token   *token
+}
+
+// MockClient is a mock implementation of Client interface, it keeps all
+// published messages in memory.
+type MockClient struct {
+	mu       sync.Mutex
+	options  *Options
+	messages map[string][]MockMessage
+	// retained is the last retained message of topics.
+	retained map[string]MockMessage
+	// manualAck indicates messages are not acked until Ack is called.
+	manualAck bool
+	pending   []pendingMockMessage
+	closed    bool
+}
+
+// NewMockClient creates a new MockClient.
+func NewMockClient() *MockClient {
+	return &MockClient{
+		options:  NewOptions(),
+		messages: make(map[string][]MockMessage),
+		retained: make(map[string]MockMessage),
+	}
+}
+
+// NewMockFactory returns a Factory which always returns the client.
+func NewMockFactory(client *MockClient) Factory {
+	return func(o *Options, _ model.ChangeFeedID) (Client, error) {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		client.options = o
+		return client, nil
+	}
+}
+
+// SetManualAck sets whether messages are acked manually.
+func (c *MockClient) SetManualAck(manualAck bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.manualAck = manualAck
+}
+
+// Ack acks all pending messages, or fails them if err is not nil.
+func (c *MockClient) Ack(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, pending := range c.pending {
+		c.ackLocked(pending, err)
+	}
+	c.pending = nil
+}
+
+// Pending returns the number of messages which are not acked.
+func (c *MockClient) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pending)
+}
+
+// Messages returns the messages published to the topic.
+func (c *MockClient) Messages(topic string) []MockMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]MockMessage(nil), c.messages[topic]...)
+}
+
+// Retained returns the retained message of the topic.
+func (c *MockClient) Retained(topic string) (MockMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	message, ok := c.retained[topic]
+	return message, ok
+}
+
+// Closed returns whether the client is closed.
+func (c *MockClient) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// PublishAsync implements Client.PublishAsync.
+func (c *MockClient) PublishAsync(
+	_ context.Context, topic string, message *common.Message,
+) (Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, cerror.ErrMQTTSendMessage.GenWithStack("client is closed")
+	}
+	if err := c.options.checkMessageSize(message); err != nil {
+		return nil, errors.Trace(err)
+	}
+	pending := pendingMockMessage{
+		topic: GetTopic(topic, message),
+		message: MockMessage{
+			Retain:  c.options.retain(message),
+			Message: message,
+		},
+		token: newToken(),
+	}
+	if c.manualAck {
+		c.pending = append(c.pending, pending)
+	} else {
+		c.ackLocked(pending, nil)
+	}
+	return pending.token, nil
+}
+
+// Publish implements Client.Publish.
+func (c *MockClient) Publish(_ context.Context, topic string, message *common.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return cerror.ErrMQTTSendMessage.GenWithStack("client is closed")
+	}
+	if err := c.options.checkMessageSize(message); err != nil {
+		return errors.Trace(err)
+	}
+	c.ackLocked(pendingMockMessage{
+		topic: GetTopic(topic, message),
+		message: MockMessage{
+			Retain:  c.options.retain(message),
+			Message: message,
+		},
+		token: newToken(),
+	}, nil)
+	return nil
+}
+
+// Close implements Client.Close.
+func (c *MockClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+func (c *MockClient) ackLocked(pending pendingMockMessage, err error) {
+	if err == nil {
+		c.messages[pending.topic] = append(c.messages[pending.topic], pending.message)
+		if pending.message.Retain {
+			c.retained[pending.topic] = pending.message
+		}
+	}
+	pending.token.finish(err)
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+)
+
+const (
+	// defaultMaxMessageBytes is the default max size of a payload, which is
+	// the default limit of most brokers.
+	defaultMaxMessageBytes = 1024 * 1024
+	// defaultMaxInflight is the default number of topics which are published
+	// concurrently.
+	defaultMaxInflight = 16
+	// defaultMaxPendingAcks is the default number of messages which are
+	// published but not acked yet.
+	defaultMaxPendingAcks = 4096
+	// defaultAckTimeout is the default timeout to wait for the PUBACK of a message.
+	defaultAckTimeout = 30 * time.Second
+	// defaultResolvedTopicLevel is the last level of the default resolved topic.
+	defaultResolvedTopicLevel = "resolved"
+	// maxTopicLength is the max length of a topic, which is a UTF-8 string
+	// with a two-byte length prefix.
+	maxTopicLength = 65535
+)
+
+var (
+	// topicRE is used to match a valid topic template, wildcards are only
+	// allowed in topic filters of subscriptions.
+	topicRE  = regexp.MustCompile(`^([^{}+#\x00]|\{schema\}|\{table\})+$`)
+	schemaRE = regexp.MustCompile(`\{schema\}`)
+	tableRE  = regexp.MustCompile(`\{table\}`)
+	// levelForbidRE is used to replace the characters in schema and table
+	// names which change the levels of a topic.
+	levelForbidRE = regexp.MustCompile(`[/+#\x00]`)
+)
+
+type urlConfig struct {
+	ResolvedTopic   *string `form:"resolved-topic"`
+	RetainResolved  *bool   `form:"retain-resolved"`
+	MessageExpiry   *string `form:"message-expiry"`
+	MaxMessageBytes *int    `form:"max-message-bytes"`
+	MaxInflight     *int    `form:"max-inflight"`
+	MaxPendingAcks  *int    `form:"max-pending-acks"`
+	AckTimeout      *string `form:"ack-timeout"`
+}
+
+// Options stores user specified configurations of the MQTT sink.
+type Options struct {
+	// Broker is the address of the broker.
+	Broker   string
+	UseTLS   bool
+	User     string
+	Password string
+	// Topic is the topic template of messages, `{schema}` and `{table}` are
+	// replaced by the schema and table of the message.
+	Topic string
+	// ResolvedTopic is the topic of resolved ts messages.
+	ResolvedTopic string
+	// RetainResolved indicates whether resolved ts messages are retained by
+	// the broker, so new subscribers get the latest resolved ts at once.
+	RetainResolved bool
+	// MessageExpiry is the expiry interval of messages, zero means messages
+	// never expire.
+	MessageExpiry time.Duration
+
+	// MaxMessageBytes is the max size of a payload, it's lowered to the
+	// maximum packet size of the broker after connecting.
+	MaxMessageBytes int
+	// MaxInflight is the max number of topics which are published concurrently,
+	// messages of a topic are always published one by one to keep the order.
+	MaxInflight int
+	// MaxPendingAcks is the max number of messages which are published but
+	// not acked by the broker yet.
+	MaxPendingAcks int
+	AckTimeout     time.Duration
+}
+
+// NewOptions returns a default Options.
+func NewOptions() *Options {
+	return &Options{
+		RetainResolved:  true,
+		MaxMessageBytes: defaultMaxMessageBytes,
+		MaxInflight:     defaultMaxInflight,
+		MaxPendingAcks:  defaultMaxPendingAcks,
+		AckTimeout:      defaultAckTimeout,
+	}
+}
+
+// Apply applies the sink URI to the options, the path of the sink URI is
+// the topic template.
+func (o *Options) Apply(sinkURI *url.URL, useTLS bool) error {
+	if sinkURI.Host == "" {
+		return cerror.ErrMQTTInvalidConfig.GenWithStack("no broker is specified in sink-uri")
+	}
+	o.Broker = sinkURI.Host
+	o.UseTLS = useTLS
+	if sinkURI.User != nil {
+		o.User = sinkURI.User.Username()
+		o.Password, _ = sinkURI.User.Password()
+	}
+
+	req := &http.Request{URL: sinkURI}
+	urlParameter := &urlConfig{}
+	if err := binding.Query.Bind(req, urlParameter); err != nil {
+		return cerror.WrapError(cerror.ErrMQTTInvalidConfig, err)
+	}
+
+	o.Topic = strings.Trim(sinkURI.Path, "/")
+	if err := ValidateTopic(o.Topic); err != nil {
+		return err
+	}
+	o.ResolvedTopic = defaultResolvedTopic(o.Topic)
+	if urlParameter.ResolvedTopic != nil {
+		o.ResolvedTopic = *urlParameter.ResolvedTopic
+	}
+	if err := ValidateTopic(o.ResolvedTopic); err != nil {
+		return err
+	}
+	if IsTopicTemplated(o.ResolvedTopic) {
+		return cerror.ErrMQTTInvalidConfig.GenWithStack(
+			"resolved-topic %s must not contain placeholders", o.ResolvedTopic)
+	}
+	if urlParameter.RetainResolved != nil {
+		o.RetainResolved = *urlParameter.RetainResolved
+	}
+	if urlParameter.MessageExpiry != nil {
+		expiry, err := time.ParseDuration(*urlParameter.MessageExpiry)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrMQTTInvalidConfig, err)
+		}
+		// The expiry interval is in seconds.
+		if expiry < 0 || (expiry > 0 && expiry < time.Second) {
+			return cerror.ErrMQTTInvalidConfig.GenWithStack(
+				"invalid message-expiry %s", *urlParameter.MessageExpiry)
+		}
+		o.MessageExpiry = expiry
+	}
+	if urlParameter.MaxMessageBytes != nil {
+		o.MaxMessageBytes = *urlParameter.MaxMessageBytes
+		if o.MaxMessageBytes <= 0 {
+			return cerror.ErrMQTTInvalidConfig.GenWithStack(
+				"invalid max-message-bytes %d", o.MaxMessageBytes)
+		}
+	}
+	if urlParameter.MaxInflight != nil {
+		o.MaxInflight = *urlParameter.MaxInflight
+		if o.MaxInflight <= 0 {
+			return cerror.ErrMQTTInvalidConfig.GenWithStack(
+				"invalid max-inflight %d", o.MaxInflight)
+		}
+	}
+	if urlParameter.MaxPendingAcks != nil {
+		o.MaxPendingAcks = *urlParameter.MaxPendingAcks
+		if o.MaxPendingAcks <= 0 {
+			return cerror.ErrMQTTInvalidConfig.GenWithStack(
+				"invalid max-pending-acks %d", o.MaxPendingAcks)
+		}
+	}
+	if urlParameter.AckTimeout != nil {
+		timeout, err := time.ParseDuration(*urlParameter.AckTimeout)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrMQTTInvalidConfig, err)
+		}
+		if timeout <= 0 {
+			return cerror.ErrMQTTInvalidConfig.GenWithStack(
+				"invalid ack-timeout %s", *urlParameter.AckTimeout)
+		}
+		o.AckTimeout = timeout
+	}
+	return nil
+}
+
+// ValidateTopic checks whether the topic template can be published to.
+func ValidateTopic(topic string) error {
+	if len(topic) > maxTopicLength {
+		return cerror.ErrMQTTInvalidConfig.GenWithStack(
+			"topic is longer than %d bytes", maxTopicLength)
+	}
+	// Topics starting with `$` are reserved by brokers.
+	if !topicRE.MatchString(topic) || strings.HasPrefix(topic, "$") {
+		return cerror.ErrMQTTInvalidConfig.GenWithStack(
+			"invalid topic %s, wildcards are not allowed and only {schema} "+
+				"and {table} placeholders are supported", topic)
+	}
+	return nil
+}
+
+// IsTopicTemplated returns whether the topic depends on the schema or table
+// of messages.
+func IsTopicTemplated(topic string) bool {
+	return schemaRE.MatchString(topic) || tableRE.MatchString(topic)
+}
+
+// defaultResolvedTopic returns the `resolved` level under the static levels
+// of the topic template, e.g. `tidb/resolved` for `tidb/{schema}/{table}`.
+func defaultResolvedTopic(topic string) string {
+	levels := strings.Split(topic, "/")
+	static := levels[:0]
+	for _, level := range levels {
+		if IsTopicTemplated(level) {
+			break
+		}
+		static = append(static, level)
+	}
+	return strings.Join(append(static, defaultResolvedTopicLevel), "/")
+}
+
+// GetTopic returns the topic of the message by the topic template.
+func GetTopic(topic string, message *common.Message) string {
+	var schema, table string
+	if message.Schema != nil {
+		schema = *message.Schema
+	}
+	if message.Table != nil {
+		table = *message.Table
+	}
+	// Use literal replacement, `$` in names must not be expanded.
+	topic = schemaRE.ReplaceAllLiteralString(topic, levelForbidRE.ReplaceAllString(schema, "_"))
+	topic = tableRE.ReplaceAllLiteralString(topic, levelForbidRE.ReplaceAllString(table, "_"))
+	if len(topic) > maxTopicLength {
+		topic = topic[:maxTopicLength]
+	}
+	return topic
+}
+
+// retain returns whether the message is retained by the broker.
+func (o *Options) retain(message *common.Message) bool {
+	return o.RetainResolved && message.Type == model.MessageTypeResolved
+}
+
+// messageExpiry returns the message expiry interval in seconds, nil means
+// messages never expire.
+func (o *Options) messageExpiry() *uint32 {
+	if o.MessageExpiry == 0 {
+		return nil
+	}
+	expiry := uint32(o.MessageExpiry / time.Second)
+	return &expiry
+}
+
+// checkMessageSize checks the payload of the message against MaxMessageBytes.
+func (o *Options) checkMessageSize(message *common.Message) error {
+	if len(message.Value) > o.MaxMessageBytes {
+		return cerror.ErrMQTTMessageTooLarge.GenWithStackByArgs(
+			len(message.Value), o.MaxMessageBytes)
+	}
+	return nil
+}
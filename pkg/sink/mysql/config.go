@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,6 +26,8 @@ import (
 	"github.com/imdario/mergo"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	tmysql "github.com/pingcap/tidb/parser/mysql"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -77,6 +80,60 @@ const (
 
 	// defaultcachePrepStmts is the default value of cachePrepStmts
 	defaultCachePrepStmts = true
+
+	// defaultHealthCheckInterval is the default interval of checking the
+	// health of downstream endpoints.
+	defaultHealthCheckInterval = 10 * time.Second
+	// defaultSchemaCheckInterval is the default interval of checking whether
+	// the schema of downstream tables drifts from upstream.
+	defaultSchemaCheckInterval = 10 * time.Minute
+
+	// DefaultWorkerGroup is the name of the worker group of tables which
+	// don't match any configured worker group.
+	DefaultWorkerGroup = "default"
+)
+
+const (
+	// UnsupportedDDLPolicyFail executes DDLs as they are, a DDL the downstream
+	// doesn't support fails the changefeed.
+	UnsupportedDDLPolicyFail = "fail"
+	// UnsupportedDDLPolicySkip skips DDLs with constructs the downstream
+	// doesn't support.
+	UnsupportedDDLPolicySkip = "skip"
+	// UnsupportedDDLPolicyTranslate rewrites DDLs to drop or replace the
+	// constructs the downstream doesn't support.
+	UnsupportedDDLPolicyTranslate = "translate"
+)
+
+const (
+	// AutoIncrementModePreserve writes values of auto-increment columns as
+	// they are.
+	AutoIncrementModePreserve = "preserve"
+	// AutoIncrementModeStrip omits values of auto-increment columns in
+	// inserted and updated rows, so that the downstream generates them.
+	AutoIncrementModeStrip = "strip"
+	// AutoIncrementModeRemap adds an offset to values of auto-increment
+	// columns, so that replicated rows are in a separate key range.
+	AutoIncrementModeRemap = "remap"
+)
+
+// Session variables of the downstream that can be configured. Triggers can't
+// be disabled by session variables of MySQL or TiDB, so they're not included.
+const (
+	SessionVariableForeignKeyChecks = "foreign_key_checks"
+	SessionVariableUniqueChecks     = "unique_checks"
+	SessionVariableSQLMode          = "sql_mode"
+)
+
+const (
+	// DDLCompatibilityCheckNone executes DDLs without checking them.
+	DDLCompatibilityCheckNone = "none"
+	// DDLCompatibilityCheckWarn logs a warning if DMLs after a DDL are not
+	// applicable to the downstream table after the DDL is executed.
+	DDLCompatibilityCheckWarn = "warn"
+	// DDLCompatibilityCheckFail fails the changefeed before executing a DDL
+	// if DMLs after it are not applicable to the downstream table.
+	DDLCompatibilityCheckFail = "fail"
 )
 
 type urlConfig struct {
@@ -84,6 +141,8 @@ type urlConfig struct {
 	MaxTxnRow                    *int    `form:"max-txn-row"`
 	MaxMultiUpdateRowSize        *int    `form:"max-multi-update-row-size"`
 	MaxMultiUpdateRowCount       *int    `form:"max-multi-update-row"`
+	MaxMultiInsertRowCount       *int    `form:"max-multi-insert-row"`
+	WorkerCountPerTable          *int    `form:"worker-count-per-table"`
 	TiDBTxnMode                  *string `form:"tidb-txn-mode"`
 	SSLCa                        *string `form:"ssl-ca"`
 	SSLCert                      *string `form:"ssl-cert"`
@@ -96,6 +155,12 @@ type urlConfig struct {
 	EnableBatchDML               *bool   `form:"batch-dml-enable"`
 	EnableMultiStatement         *bool   `form:"multi-stmt-enable"`
 	EnableCachePreparedStatement *bool   `form:"cache-prep-stmts"`
+	HealthCheckInterval          *string `form:"health-check-interval"`
+	SchemaCheckInterval          *string `form:"schema-check-interval"`
+	ReadYourWrites               *bool   `form:"read-your-writes"`
+	UnsupportedDDLPolicy         *string `form:"unsupported-ddl-policy"`
+	AdaptiveTxnRow               *bool   `form:"adaptive-txn-row"`
+	DDLCompatibilityCheck        *string `form:"ddl-compatibility-check"`
 }
 
 // Config is the configs for MySQL backend.
@@ -104,6 +169,9 @@ type Config struct {
 	MaxTxnRow              int
 	MaxMultiUpdateRowCount int
 	MaxMultiUpdateRowSize  int
+	// MaxMultiInsertRowCount is the max number of rows in a single multi-row
+	// INSERT or REPLACE statement, 0 means it is limited by MaxTxnRow only.
+	MaxMultiInsertRowCount int
 	tidbTxnMode            string
 	ReadTimeout            string
 	WriteTimeout           string
@@ -123,6 +191,70 @@ type Config struct {
 	BatchDMLEnable  bool
 	MultiStmtEnable bool
 	CachePrepStmts  bool
+
+	// Endpoints are the downstream addresses in the sink URI. The sink writes
+	// to the first writable one, and fails over to others if it is unhealthy.
+	Endpoints []string
+	// HealthCheckInterval is the interval of checking the health of
+	// endpoints, 0 disables health checks.
+	HealthCheckInterval time.Duration
+	// SchemaCheckInterval is the interval of comparing the schema of
+	// downstream tables with upstream, 0 disables schema checks.
+	SchemaCheckInterval time.Duration
+	// ReadYourWrites makes reads of the sink served by the node it writes to,
+	// which is required if the downstream is behind a read/write splitting
+	// proxy or uses follower read.
+	ReadYourWrites bool
+	// WorkerCountPerTable is the max number of workers that transactions of
+	// one table can be dispatched to, 0 means no limit.
+	WorkerCountPerTable int
+	// ResourceGroup is the resource group that sessions of the downstream
+	// TiDB are bound to, empty means the default resource group.
+	ResourceGroup string
+	// WorkerGroups are groups of workers dedicated to matched tables,
+	// WorkerCount workers of the default group are shared by other tables.
+	WorkerGroups []WorkerGroup
+	// AutoIncrementRules decide how values of auto-increment and auto-random
+	// columns of matched tables are applied.
+	AutoIncrementRules []AutoIncrementRule
+	// SessionVariables are session variables of the downstream used to write
+	// all tables, values are normalized.
+	SessionVariables map[string]string
+	// SessionVariableRules override session variables for matched tables.
+	SessionVariableRules []SessionVariableRule
+	// UnsupportedDDLPolicy decides how DDLs with constructs the downstream
+	// doesn't support are handled.
+	UnsupportedDDLPolicy string
+	// AdaptiveTxnRow tunes the max number of rows in a transaction of every
+	// table by the observed commit throughput, MaxTxnRow is the initial value.
+	AdaptiveTxnRow bool
+	// DDLCompatibilityCheck decides whether a DDL is checked against the
+	// downstream schema before it's executed, and how incompatibilities are
+	// handled.
+	DDLCompatibilityCheck string
+}
+
+// WorkerGroup is a group of workers dedicated to the matched tables.
+type WorkerGroup struct {
+	filter.Filter
+	Name        string
+	WorkerCount int
+	MaxTxnRow   int
+}
+
+// AutoIncrementRule decides how values of auto-increment and auto-random
+// columns of the matched tables are applied.
+type AutoIncrementRule struct {
+	filter.Filter
+	Mode   string
+	Offset int64
+}
+
+// SessionVariableRule overrides session variables of the downstream when the
+// matched tables are written.
+type SessionVariableRule struct {
+	filter.Filter
+	Variables map[string]string
 }
 
 // NewConfig returns the default mysql backend config.
@@ -140,6 +272,10 @@ func NewConfig() *Config {
 		BatchDMLEnable:         defaultBatchDMLEnable,
 		MultiStmtEnable:        defaultMultiStmtEnable,
 		CachePrepStmts:         defaultCachePrepStmts,
+		HealthCheckInterval:    defaultHealthCheckInterval,
+		SchemaCheckInterval:    defaultSchemaCheckInterval,
+		UnsupportedDDLPolicy:   UnsupportedDDLPolicyFail,
+		DDLCompatibilityCheck:  DDLCompatibilityCheckNone,
 	}
 }
 
@@ -178,6 +314,12 @@ func (c *Config) Apply(
 	if err = getMaxMultiUpdateRowSize(urlParameter, &c.MaxMultiUpdateRowSize); err != nil {
 		return err
 	}
+	if err = getMaxMultiInsertRowCount(urlParameter, &c.MaxMultiInsertRowCount); err != nil {
+		return err
+	}
+	if err = getWorkerCountPerTable(urlParameter, &c.WorkerCountPerTable); err != nil {
+		return err
+	}
 	getTiDBTxnMode(urlParameter, &c.tidbTxnMode)
 	if err = getSSLCA(urlParameter, changefeedID, &c.TLS); err != nil {
 		return err
@@ -198,13 +340,284 @@ func (c *Config) Apply(
 	getBatchDMLEnable(urlParameter, &c.BatchDMLEnable)
 	getMultiStmtEnable(urlParameter, &c.MultiStmtEnable)
 	getCachePrepStmts(urlParameter, &c.CachePrepStmts)
+	if c.Endpoints, err = ParseEndpoints(sinkURI); err != nil {
+		return err
+	}
+	if err = getInterval("health-check-interval",
+		urlParameter.HealthCheckInterval, &c.HealthCheckInterval); err != nil {
+		return err
+	}
+	if err = getInterval("schema-check-interval",
+		urlParameter.SchemaCheckInterval, &c.SchemaCheckInterval); err != nil {
+		return err
+	}
+	if urlParameter.ReadYourWrites != nil {
+		c.ReadYourWrites = *urlParameter.ReadYourWrites
+	}
+	if err = getUnsupportedDDLPolicy(urlParameter, &c.UnsupportedDDLPolicy); err != nil {
+		return err
+	}
+	if urlParameter.AdaptiveTxnRow != nil {
+		c.AdaptiveTxnRow = *urlParameter.AdaptiveTxnRow
+	}
+	if err = getDDLCompatibilityCheck(urlParameter, &c.DDLCompatibilityCheck); err != nil {
+		return err
+	}
 	c.EnableOldValue = replicaConfig.EnableOldValue
 	c.ForceReplicate = replicaConfig.ForceReplicate
 	c.SourceID = replicaConfig.Sink.TiDBSourceID
+	c.ResourceGroup = replicaConfig.ResourceGroup
+	if replicaConfig.Sink.MySQLConfig != nil {
+		c.WorkerGroups, err = c.getWorkerGroups(
+			replicaConfig.Sink.MySQLConfig.WorkerGroups, replicaConfig.CaseSensitive)
+		if err != nil {
+			return err
+		}
+		c.AutoIncrementRules, err = getAutoIncrementRules(
+			replicaConfig.Sink.MySQLConfig.AutoIncrementRules, replicaConfig.CaseSensitive)
+		if err != nil {
+			return err
+		}
+		c.SessionVariables, err = normalizeSessionVariables(
+			replicaConfig.Sink.MySQLConfig.SessionVariables)
+		if err != nil {
+			return err
+		}
+		c.SessionVariableRules, err = getSessionVariableRules(
+			replicaConfig.Sink.MySQLConfig.SessionVariableRules, replicaConfig.CaseSensitive)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// WorkerGroupOf returns the name of the worker group of the given table.
+// The first matched group is used, and DefaultWorkerGroup is returned if
+// no group matches.
+func (c *Config) WorkerGroupOf(schema, table string) string {
+	for _, group := range c.WorkerGroups {
+		if group.MatchTable(schema, table) {
+			return group.Name
+		}
+	}
+	return DefaultWorkerGroup
+}
+
+// TotalWorkerCount returns the number of workers of all worker groups.
+func (c *Config) TotalWorkerCount() int {
+	count := c.WorkerCount
+	for _, group := range c.WorkerGroups {
+		count += group.WorkerCount
+	}
+	return count
+}
+
+// AutoIncrementRuleOf returns the auto-increment rule of the given table,
+// it's nil if no rule matches.
+func (c *Config) AutoIncrementRuleOf(schema, table string) *AutoIncrementRule {
+	for i := range c.AutoIncrementRules {
+		if c.AutoIncrementRules[i].MatchTable(schema, table) {
+			return &c.AutoIncrementRules[i]
+		}
+	}
+	return nil
+}
+
+func getAutoIncrementRules(
+	rules []*config.MySQLAutoIncrementRule, caseSensitive bool,
+) ([]AutoIncrementRule, error) {
+	var res []AutoIncrementRule
+	for _, rule := range rules {
+		if len(rule.Matcher) == 0 {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"auto-increment-rules.matcher can not be empty")
+		}
+		f, err := filter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		if !caseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+		mode := strings.ToLower(rule.Mode)
+		switch mode {
+		case AutoIncrementModePreserve, AutoIncrementModeStrip:
+			if rule.Offset != 0 {
+				return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+					"auto-increment-rules.offset is only available for the remap mode")
+			}
+		case AutoIncrementModeRemap:
+			if rule.Offset <= 0 {
+				return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+					"auto-increment-rules.offset %d must be positive", rule.Offset)
+			}
+		default:
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"invalid auto-increment-rules.mode %s, "+
+					"which must be one of preserve, strip and remap", rule.Mode)
+		}
+		res = append(res, AutoIncrementRule{Filter: f, Mode: mode, Offset: rule.Offset})
+	}
+	return res, nil
+}
+
+// SessionVariableRuleOf returns the session variable rule of the given table,
+// it's nil if no rule matches.
+func (c *Config) SessionVariableRuleOf(schema, table string) *SessionVariableRule {
+	for i := range c.SessionVariableRules {
+		if c.SessionVariableRules[i].MatchTable(schema, table) {
+			return &c.SessionVariableRules[i]
+		}
+	}
+	return nil
+}
+
+// SessionVariableNames returns sorted names of all configured session
+// variables, including the ones of session variable rules.
+func (c *Config) SessionVariableNames() []string {
+	seen := make(map[string]struct{})
+	var names []string
+	add := func(variables map[string]string) {
+		for name := range variables {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+	add(c.SessionVariables)
+	for _, rule := range c.SessionVariableRules {
+		add(rule.Variables)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatSessionVariable returns the literal of a normalized session variable
+// value, which can be used in SET statements and DSN parameters.
+func FormatSessionVariable(name, value string) string {
+	if name == SessionVariableSQLMode {
+		// Normalized SQL modes only contain letters, underscores and commas.
+		return "'" + value + "'"
+	}
+	return value
+}
+
+func getSessionVariableRules(
+	rules []*config.MySQLSessionVariableRule, caseSensitive bool,
+) ([]SessionVariableRule, error) {
+	var res []SessionVariableRule
+	for _, rule := range rules {
+		if len(rule.Matcher) == 0 {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"session-variable-rules.matcher can not be empty")
+		}
+		if len(rule.Variables) == 0 {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"session-variable-rules.variables can not be empty")
+		}
+		f, err := filter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		if !caseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+		variables, err := normalizeSessionVariables(rule.Variables)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, SessionVariableRule{Filter: f, Variables: variables})
+	}
+	return res, nil
+}
+
+func normalizeSessionVariables(variables map[string]string) (map[string]string, error) {
+	if len(variables) == 0 {
+		return nil, nil
+	}
+	res := make(map[string]string, len(variables))
+	for name, value := range variables {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case SessionVariableForeignKeyChecks, SessionVariableUniqueChecks:
+			switch strings.ToUpper(strings.TrimSpace(value)) {
+			case "ON", "1", "TRUE":
+				value = "ON"
+			case "OFF", "0", "FALSE":
+				value = "OFF"
+			default:
+				return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+					"invalid value %s of session variable %s, which must be ON or OFF",
+					value, name)
+			}
+		case SessionVariableSQLMode:
+			value = tmysql.FormatSQLModeStr(value)
+			if _, err := tmysql.GetSQLMode(value); err != nil {
+				return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+			}
+		default:
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"unsupported session variable %s, which must be one of %s, %s and %s",
+				name, SessionVariableForeignKeyChecks, SessionVariableUniqueChecks,
+				SessionVariableSQLMode)
+		}
+		if _, ok := res[name]; ok {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"duplicated session variable %s", name)
+		}
+		res[name] = value
+	}
+	return res, nil
+}
+
+func (c *Config) getWorkerGroups(
+	groups []*config.MySQLWorkerGroup, caseSensitive bool,
+) ([]WorkerGroup, error) {
+	var res []WorkerGroup
+	names := map[string]struct{}{DefaultWorkerGroup: {}}
+	for _, group := range groups {
+		if group.Name == "" {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"worker-groups.name can not be empty")
+		}
+		if _, ok := names[group.Name]; ok {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"duplicated or reserved worker group name %s", group.Name)
+		}
+		names[group.Name] = struct{}{}
+		if len(group.Matcher) == 0 {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"worker-groups.matcher of worker group %s can not be empty", group.Name)
+		}
+		f, err := filter.Parse(group.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		if !caseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+
+		g := WorkerGroup{
+			Filter:      f,
+			Name:        group.Name,
+			WorkerCount: c.WorkerCount,
+			MaxTxnRow:   c.MaxTxnRow,
+		}
+		values := &urlConfig{WorkerCount: group.WorkerCount, MaxTxnRow: group.MaxTxnRow}
+		if err := getWorkerCount(values, &g.WorkerCount); err != nil {
+			return nil, err
+		}
+		if err := getMaxTxnRow(values, &g.MaxTxnRow); err != nil {
+			return nil, err
+		}
+		res = append(res, g)
+	}
+	return res, nil
+}
+
 func mergeConfig(
 	replicaConfig *config.ReplicaConfig,
 	urlParameters *urlConfig,
@@ -217,6 +630,8 @@ func mergeConfig(
 		dest.MaxTxnRow = mConfig.MaxTxnRow
 		dest.MaxMultiUpdateRowCount = mConfig.MaxMultiUpdateRowCount
 		dest.MaxMultiUpdateRowSize = mConfig.MaxMultiUpdateRowSize
+		dest.MaxMultiInsertRowCount = mConfig.MaxMultiInsertRowCount
+		dest.WorkerCountPerTable = mConfig.WorkerCountPerTable
 		dest.TiDBTxnMode = mConfig.TiDBTxnMode
 		dest.SSLCa = mConfig.SSLCa
 		dest.SSLCert = mConfig.SSLCert
@@ -228,10 +643,18 @@ func mergeConfig(
 		dest.EnableBatchDML = mConfig.EnableBatchDML
 		dest.EnableMultiStatement = mConfig.EnableMultiStatement
 		dest.EnableCachePreparedStatement = mConfig.EnableCachePreparedStatement
+		dest.UnsupportedDDLPolicy = mConfig.UnsupportedDDLPolicy
+		dest.AdaptiveTxnRow = mConfig.AdaptiveTxnRow
+		dest.DDLCompatibilityCheck = mConfig.DDLCompatibilityCheck
 	}
 	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride); err != nil {
 		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
 	}
+	// mergo doesn't override a value with false, so the sink URI parameter
+	// is applied explicitly.
+	if urlParameters.AdaptiveTxnRow != nil {
+		dest.AdaptiveTxnRow = urlParameters.AdaptiveTxnRow
+	}
 	return dest, nil
 }
 
@@ -312,6 +735,44 @@ func getMaxMultiUpdateRowSize(values *urlConfig, maxMultiUpdateRowSize *int) err
 	return nil
 }
 
+func getMaxMultiInsertRowCount(values *urlConfig, maxMultiInsertRow *int) error {
+	if values.MaxMultiInsertRowCount == nil {
+		return nil
+	}
+
+	c := *values.MaxMultiInsertRowCount
+	if c <= 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid max-multi-insert-row %d, which must be greater than 0", c))
+	}
+	if c > maxMaxTxnRow {
+		log.Warn("max-multi-insert-row too large",
+			zap.Int("original", c), zap.Int("override", maxMaxTxnRow))
+		c = maxMaxTxnRow
+	}
+	*maxMultiInsertRow = c
+	return nil
+}
+
+func getWorkerCountPerTable(values *urlConfig, workerCountPerTable *int) error {
+	if values.WorkerCountPerTable == nil {
+		return nil
+	}
+
+	c := *values.WorkerCountPerTable
+	if c < 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid worker-count-per-table %d, which must not be negative", c))
+	}
+	if c > maxWorkerCount {
+		log.Warn("worker-count-per-table too large",
+			zap.Int("original", c), zap.Int("override", maxWorkerCount))
+		c = maxWorkerCount
+	}
+	*workerCountPerTable = c
+	return nil
+}
+
 func getTiDBTxnMode(values *urlConfig, mode *string) {
 	if values.TiDBTxnMode == nil || len(*values.TiDBTxnMode) == 0 {
 		return
@@ -325,6 +786,38 @@ func getTiDBTxnMode(values *urlConfig, mode *string) {
 	}
 }
 
+func getUnsupportedDDLPolicy(values *urlConfig, policy *string) error {
+	if values.UnsupportedDDLPolicy == nil || len(*values.UnsupportedDDLPolicy) == 0 {
+		return nil
+	}
+	s := strings.ToLower(*values.UnsupportedDDLPolicy)
+	switch s {
+	case UnsupportedDDLPolicyFail, UnsupportedDDLPolicySkip, UnsupportedDDLPolicyTranslate:
+		*policy = s
+		return nil
+	default:
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid unsupported-ddl-policy %s, "+
+				"which must be one of fail, skip and translate", s))
+	}
+}
+
+func getDDLCompatibilityCheck(values *urlConfig, check *string) error {
+	if values.DDLCompatibilityCheck == nil || len(*values.DDLCompatibilityCheck) == 0 {
+		return nil
+	}
+	s := strings.ToLower(*values.DDLCompatibilityCheck)
+	switch s {
+	case DDLCompatibilityCheckNone, DDLCompatibilityCheckWarn, DDLCompatibilityCheckFail:
+		*check = s
+		return nil
+	default:
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid ddl-compatibility-check %s, "+
+				"which must be one of none, warn and fail", s))
+	}
+}
+
 func getSSLCA(values *urlConfig, changefeedID model.ChangeFeedID, tls *string) error {
 	if values.SSLCa == nil || len(*values.SSLCa) == 0 {
 		return nil
@@ -444,3 +937,20 @@ func getCachePrepStmts(values *urlConfig, cachePrepStmts *bool) {
 		*cachePrepStmts = *values.EnableCachePreparedStatement
 	}
 }
+
+func getInterval(name string, value *string, interval *time.Duration) error {
+	if value == nil {
+		return nil
+	}
+	d, err := time.ParseDuration(*value)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if d < 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid %s %s, "+
+				"which must be greater than or equal to 0", name, d))
+	}
+	*interval = d
+	return nil
+}
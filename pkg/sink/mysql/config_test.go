@@ -24,6 +24,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/aws/aws-sdk-go/aws"
 	dmysql "github.com/go-sql-driver/mysql"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -191,7 +192,10 @@ func TestApplySinkURIParamsToConfig(t *testing.T) {
 	expected.tidbTxnMode = "pessimistic"
 	expected.EnableOldValue = true
 	expected.CachePrepStmts = true
+	expected.Endpoints = []string{"127.0.0.1:3306"}
+	expected.WorkerCountPerTable = 4
 	uriStr := "mysql://127.0.0.1:3306/?worker-count=64&max-txn-row=20" +
+		"&worker-count-per-table=4" +
 		"&max-multi-update-row=80&max-multi-update-row-size=512" +
 		"&safe-mode=false" +
 		"&tidb-txn-mode=pessimistic" +
@@ -205,6 +209,33 @@ func TestApplySinkURIParamsToConfig(t *testing.T) {
 	require.Equal(t, expected, cfg)
 }
 
+func TestApplyEndpointsToConfig(t *testing.T) {
+	t.Parallel()
+
+	uri, err := url.Parse("mysql://root@127.0.0.1:3306,127.0.0.2,[::1]:3306/" +
+		"?health-check-interval=5s&read-your-writes=true&schema-check-interval=0s")
+	require.Nil(t, err)
+	cfg := NewConfig()
+	err = cfg.Apply("UTC", model.ChangeFeedID{}, uri, config.GetDefaultReplicaConfig())
+	require.Nil(t, err)
+	require.Equal(t, []string{"127.0.0.1:3306", "127.0.0.2:4000", "[::1]:3306"}, cfg.Endpoints)
+	require.Equal(t, 5*time.Second, cfg.HealthCheckInterval)
+	require.Equal(t, time.Duration(0), cfg.SchemaCheckInterval)
+	require.True(t, cfg.ReadYourWrites)
+
+	for _, uriStr := range []string{
+		"mysql://root@127.0.0.1:3306,,127.0.0.2:3306/",
+		"mysql://root@127.0.0.1:3306/?health-check-interval=-1s",
+		"mysql://root@127.0.0.1:3306/?health-check-interval=abc",
+		"mysql://root@127.0.0.1:3306/?schema-check-interval=-1m",
+	} {
+		uri, err := url.Parse(uriStr)
+		require.Nil(t, err)
+		err = NewConfig().Apply("UTC", model.ChangeFeedID{}, uri, config.GetDefaultReplicaConfig())
+		require.Regexp(t, "ErrMySQLInvalidConfig", err, uriStr)
+	}
+}
+
 func TestParseSinkURIOverride(t *testing.T) {
 	t.Parallel()
 
@@ -216,6 +247,11 @@ func TestParseSinkURIOverride(t *testing.T) {
 		checker: func(sp *Config) {
 			require.EqualValues(t, sp.WorkerCount, maxWorkerCount)
 		},
+	}, {
+		uri: "mysql://127.0.0.1:3306/?max-multi-insert-row=2147483648", // int32 max
+		checker: func(sp *Config) {
+			require.EqualValues(t, sp.MaxMultiInsertRowCount, maxMaxTxnRow)
+		},
 	}, {
 		uri: "mysql://127.0.0.1:3306/?max-txn-row=2147483648", // int32 max
 		checker: func(sp *Config) {
@@ -269,6 +305,7 @@ func TestParseSinkURIBadQueryString(t *testing.T) {
 		"mysql://127.0.0.1:3306/?worker-count=not-number",
 		"mysql://127.0.0.1:3306/?worker-count=-1",
 		"mysql://127.0.0.1:3306/?worker-count=0",
+		"mysql://127.0.0.1:3306/?worker-count-per-table=-1",
 		"mysql://127.0.0.1:3306/?max-txn-row=not-number",
 		"mysql://127.0.0.1:3306/?max-txn-row=-1",
 		"mysql://127.0.0.1:3306/?max-txn-row=0",
@@ -489,3 +526,365 @@ func TestMergeConfig(t *testing.T) {
 	require.Equal(t, true, c.MultiStmtEnable)
 	require.Equal(t, true, c.CachePrepStmts)
 }
+
+func TestGenerateDSNReadYourWrites(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.Nil(t, err)
+	defer db.Close()
+	columns := []string{"Variable_name", "Value"}
+	for _, variable := range []string{
+		"allow_auto_random_explicit_insert", "tidb_txn_mode", "transaction_isolation",
+		"tidb_placement_mode", "tidb_enable_external_ts_read", "tidb_replica_read",
+	} {
+		mock.ExpectQuery("show session variables like '" + variable + "';").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(variable, "any"))
+	}
+
+	dsn, err := dmysql.ParseDSN("root:123456@tcp(127.0.0.1:4000)/")
+	require.Nil(t, err)
+	cfg := NewConfig()
+	cfg.ReadYourWrites = true
+	dsnStr, err := generateDSNByConfig(context.TODO(), dsn, cfg, db)
+	require.Nil(t, err)
+	require.Contains(t, dsnStr, "tidb_replica_read=%22leader%22")
+	require.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGenerateDSNResourceGroup(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.ResourceGroup = "rg1"
+	uri, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.Nil(t, err)
+	cfg := NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("123"), uri, replicaConfig)
+	require.Nil(t, err)
+	require.Equal(t, "rg1", cfg.ResourceGroup)
+
+	generate := func(resourceControl bool) string {
+		db, mock, err := sqlmock.New()
+		require.Nil(t, err)
+		defer db.Close()
+		columns := []string{"Variable_name", "Value"}
+		for _, variable := range []string{
+			"allow_auto_random_explicit_insert", "tidb_txn_mode", "transaction_isolation",
+			"tidb_placement_mode", "tidb_enable_external_ts_read",
+		} {
+			mock.ExpectQuery("show session variables like '" + variable + "';").
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(variable, "any"))
+		}
+		rows := sqlmock.NewRows(columns)
+		if resourceControl {
+			rows.AddRow("tidb_enable_resource_control", "ON")
+		}
+		mock.ExpectQuery("show session variables like 'tidb_enable_resource_control';").
+			WillReturnRows(rows)
+
+		dsn, err := dmysql.ParseDSN("root:123456@tcp(127.0.0.1:4000)/")
+		require.Nil(t, err)
+		dsnStr, err := generateDSNByConfig(context.TODO(), dsn, cfg, db)
+		require.Nil(t, err)
+		require.Nil(t, mock.ExpectationsWereMet())
+		return dsnStr
+	}
+	require.Contains(t, generate(true), resourceGroupParam+"=rg1")
+	// The resource group is ignored if resource control is not supported.
+	require.NotContains(t, generate(false), resourceGroupParam)
+}
+
+func TestApplyWorkerGroups(t *testing.T) {
+	t.Parallel()
+
+	uri, err := url.Parse("mysql://127.0.0.1:3306/?worker-count=8&max-txn-row=100")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{
+		WorkerGroups: []*config.MySQLWorkerGroup{{
+			Name:        "hot",
+			Matcher:     []string{"test.hot*"},
+			WorkerCount: util.AddressOf(32),
+			MaxTxnRow:   util.AddressOf(1000),
+		}, {
+			Name:    "cold",
+			Matcher: []string{"test.*"},
+		}},
+	}
+	cfg := NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+	require.Nil(t, err)
+	require.Len(t, cfg.WorkerGroups, 2)
+	require.Equal(t, 32, cfg.WorkerGroups[0].WorkerCount)
+	require.Equal(t, 1000, cfg.WorkerGroups[0].MaxTxnRow)
+	// Unset fields fall back to the changefeed level configuration.
+	require.Equal(t, 8, cfg.WorkerGroups[1].WorkerCount)
+	require.Equal(t, 100, cfg.WorkerGroups[1].MaxTxnRow)
+	require.Equal(t, 48, cfg.TotalWorkerCount())
+
+	require.Equal(t, "hot", cfg.WorkerGroupOf("test", "hot1"))
+	require.Equal(t, "cold", cfg.WorkerGroupOf("test", "t1"))
+	require.Equal(t, DefaultWorkerGroup, cfg.WorkerGroupOf("test1", "hot1"))
+	// Table names are case-sensitive by default.
+	require.Equal(t, "cold", cfg.WorkerGroupOf("test", "HOT1"))
+
+	for _, groups := range [][]*config.MySQLWorkerGroup{
+		{{Matcher: []string{"test.*"}}},
+		{{Name: DefaultWorkerGroup, Matcher: []string{"test.*"}}},
+		{{Name: "g1", Matcher: []string{"test.*"}}, {Name: "g1", Matcher: []string{"test1.*"}}},
+		{{Name: "g1"}},
+		{{Name: "g1", Matcher: []string{"test.t1["}}},
+		{{Name: "g1", Matcher: []string{"test.*"}, WorkerCount: util.AddressOf(0)}},
+		{{Name: "g1", Matcher: []string{"test.*"}, MaxTxnRow: util.AddressOf(-1)}},
+	} {
+		replicaConfig.Sink.MySQLConfig.WorkerGroups = groups
+		err = NewConfig().Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+		require.Regexp(t, "ErrMySQLInvalidConfig", err)
+	}
+}
+
+func TestApplyAutoIncrementRules(t *testing.T) {
+	t.Parallel()
+
+	uri, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{
+		AutoIncrementRules: []*config.MySQLAutoIncrementRule{
+			{Matcher: []string{"test.orders"}, Mode: "REMAP", Offset: 1 << 40},
+			{Matcher: []string{"test.*"}, Mode: AutoIncrementModeStrip},
+		},
+	}
+	cfg := NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+	require.Nil(t, err)
+	require.Len(t, cfg.AutoIncrementRules, 2)
+
+	rule := cfg.AutoIncrementRuleOf("test", "orders")
+	require.Equal(t, AutoIncrementModeRemap, rule.Mode)
+	require.Equal(t, int64(1<<40), rule.Offset)
+	require.Equal(t, AutoIncrementModeStrip, cfg.AutoIncrementRuleOf("test", "t1").Mode)
+	require.Nil(t, cfg.AutoIncrementRuleOf("test1", "t1"))
+
+	for _, rules := range [][]*config.MySQLAutoIncrementRule{
+		{{Mode: AutoIncrementModeStrip}},
+		{{Matcher: []string{"test.t1["}, Mode: AutoIncrementModeStrip}},
+		{{Matcher: []string{"test.*"}, Mode: "unknown"}},
+		{{Matcher: []string{"test.*"}, Mode: AutoIncrementModeRemap}},
+		{{Matcher: []string{"test.*"}, Mode: AutoIncrementModeRemap, Offset: -1}},
+		{{Matcher: []string{"test.*"}, Mode: AutoIncrementModePreserve, Offset: 1}},
+	} {
+		replicaConfig.Sink.MySQLConfig.AutoIncrementRules = rules
+		err = NewConfig().Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+		require.Regexp(t, "ErrMySQLInvalidConfig", err)
+	}
+}
+
+func TestApplySessionVariables(t *testing.T) {
+	t.Parallel()
+
+	uri, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{
+		SessionVariables: map[string]string{
+			"FOREIGN_KEY_CHECKS": "1",
+			"sql_mode":           "strict_trans_tables,no_zero_date",
+		},
+		SessionVariableRules: []*config.MySQLSessionVariableRule{
+			{
+				Matcher:   []string{"test.orders"},
+				Variables: map[string]string{"foreign_key_checks": "off"},
+			},
+			{
+				Matcher:   []string{"test.*"},
+				Variables: map[string]string{"unique_checks": "false", "sql_mode": ""},
+			},
+		},
+	}
+	cfg := NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{
+		SessionVariableForeignKeyChecks: "ON",
+		SessionVariableSQLMode:          "STRICT_TRANS_TABLES,NO_ZERO_DATE",
+	}, cfg.SessionVariables)
+	require.Len(t, cfg.SessionVariableRules, 2)
+	require.Equal(t, []string{
+		SessionVariableForeignKeyChecks, SessionVariableSQLMode, SessionVariableUniqueChecks,
+	}, cfg.SessionVariableNames())
+
+	rule := cfg.SessionVariableRuleOf("test", "orders")
+	require.Equal(t, map[string]string{SessionVariableForeignKeyChecks: "OFF"}, rule.Variables)
+	rule = cfg.SessionVariableRuleOf("test", "t1")
+	require.Equal(t, map[string]string{
+		SessionVariableUniqueChecks: "OFF", SessionVariableSQLMode: "",
+	}, rule.Variables)
+	require.Nil(t, cfg.SessionVariableRuleOf("test1", "t1"))
+	require.Equal(t, "'NO_ZERO_DATE'", FormatSessionVariable(SessionVariableSQLMode, "NO_ZERO_DATE"))
+	require.Equal(t, "OFF", FormatSessionVariable(SessionVariableUniqueChecks, "OFF"))
+
+	for _, variables := range []map[string]string{
+		{"triggers": "OFF"},
+		{"foreign_key_checks": "2"},
+		{"sql_mode": "STRICT_TRANS_TABLES, NO_ZERO_DATE"},
+		{"sql_mode": "UNKNOWN_MODE"},
+		{"sql_mode": "", "SQL_MODE": ""},
+	} {
+		replicaConfig.Sink.MySQLConfig.SessionVariables = variables
+		err = NewConfig().Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+		require.Regexp(t, "ErrMySQLInvalidConfig", err)
+	}
+	replicaConfig.Sink.MySQLConfig.SessionVariables = nil
+	for _, rules := range [][]*config.MySQLSessionVariableRule{
+		{{Variables: map[string]string{"unique_checks": "OFF"}}},
+		{{Matcher: []string{"test.*"}}},
+		{{Matcher: []string{"test.t1["}, Variables: map[string]string{"unique_checks": "OFF"}}},
+		{{Matcher: []string{"test.*"}, Variables: map[string]string{"triggers": "OFF"}}},
+	} {
+		replicaConfig.Sink.MySQLConfig.SessionVariableRules = rules
+		err = NewConfig().Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+		require.Regexp(t, "ErrMySQLInvalidConfig", err)
+	}
+}
+
+func TestGenerateDSNSessionVariables(t *testing.T) {
+	t.Parallel()
+
+	cfg := NewConfig()
+	cfg.SessionVariables = map[string]string{
+		SessionVariableForeignKeyChecks: "ON",
+		SessionVariableSQLMode:          "NO_ZERO_DATE",
+	}
+	cfg.SessionVariableRules = []SessionVariableRule{{
+		Filter:    filter.All(),
+		Variables: map[string]string{SessionVariableUniqueChecks: "OFF"},
+	}}
+
+	generate := func(uniqueChecks bool) (string, error) {
+		db, mock, err := sqlmock.New()
+		require.Nil(t, err)
+		defer db.Close()
+		columns := []string{"Variable_name", "Value"}
+		for _, variable := range []string{
+			"allow_auto_random_explicit_insert", "tidb_txn_mode", "transaction_isolation",
+			SessionVariableForeignKeyChecks, SessionVariableSQLMode,
+		} {
+			mock.ExpectQuery("show session variables like '" + variable + "';").
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(variable, "any"))
+		}
+		rows := sqlmock.NewRows(columns)
+		if uniqueChecks {
+			rows.AddRow(SessionVariableUniqueChecks, "ON")
+		}
+		mock.ExpectQuery("show session variables like 'unique_checks';").WillReturnRows(rows)
+		if uniqueChecks {
+			for _, variable := range []string{
+				"tidb_placement_mode", "tidb_enable_external_ts_read",
+			} {
+				mock.ExpectQuery("show session variables like '" + variable + "';").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(variable, "any"))
+			}
+		}
+
+		dsn, err := dmysql.ParseDSN("root:123456@tcp(127.0.0.1:4000)/")
+		require.Nil(t, err)
+		dsnStr, err := generateDSNByConfig(context.TODO(), dsn, cfg, db)
+		require.Nil(t, mock.ExpectationsWereMet())
+		return dsnStr, err
+	}
+	dsnStr, err := generate(true)
+	require.Nil(t, err)
+	require.Contains(t, dsnStr, "foreign_key_checks=ON")
+	require.Contains(t, dsnStr, "sql_mode=%27NO_ZERO_DATE%27")
+	// Session variables which are not supported by the downstream are rejected.
+	_, err = generate(false)
+	require.Regexp(t, "ErrMySQLInvalidConfig", err)
+}
+
+func TestApplyUnsupportedDDLPolicy(t *testing.T) {
+	t.Parallel()
+
+	apply := func(uriStr string, policy *string) (*Config, error) {
+		uri, err := url.Parse(uriStr)
+		require.NoError(t, err)
+		rc := config.GetDefaultReplicaConfig()
+		rc.Sink.MySQLConfig = &config.MySQLConfig{UnsupportedDDLPolicy: policy}
+		cfg := NewConfig()
+		return cfg, cfg.Apply("UTC", model.ChangeFeedID{}, uri, rc)
+	}
+
+	cfg, err := apply("mysql://127.0.0.1:3306/", nil)
+	require.NoError(t, err)
+	require.Equal(t, UnsupportedDDLPolicyFail, cfg.UnsupportedDDLPolicy)
+
+	cfg, err = apply("mysql://127.0.0.1:3306/", aws.String("Skip"))
+	require.NoError(t, err)
+	require.Equal(t, UnsupportedDDLPolicySkip, cfg.UnsupportedDDLPolicy)
+
+	// The sink URI parameter overrides the config file.
+	cfg, err = apply("mysql://127.0.0.1:3306/?unsupported-ddl-policy=translate",
+		aws.String("skip"))
+	require.NoError(t, err)
+	require.Equal(t, UnsupportedDDLPolicyTranslate, cfg.UnsupportedDDLPolicy)
+
+	_, err = apply("mysql://127.0.0.1:3306/?unsupported-ddl-policy=ignore", nil)
+	require.Regexp(t, "invalid unsupported-ddl-policy", err)
+}
+
+func TestApplyDDLCompatibilityCheck(t *testing.T) {
+	t.Parallel()
+
+	apply := func(uriStr string, check *string) (*Config, error) {
+		uri, err := url.Parse(uriStr)
+		require.NoError(t, err)
+		rc := config.GetDefaultReplicaConfig()
+		rc.Sink.MySQLConfig = &config.MySQLConfig{DDLCompatibilityCheck: check}
+		cfg := NewConfig()
+		return cfg, cfg.Apply("UTC", model.ChangeFeedID{}, uri, rc)
+	}
+
+	cfg, err := apply("mysql://127.0.0.1:3306/", nil)
+	require.NoError(t, err)
+	require.Equal(t, DDLCompatibilityCheckNone, cfg.DDLCompatibilityCheck)
+
+	cfg, err = apply("mysql://127.0.0.1:3306/", aws.String("Warn"))
+	require.NoError(t, err)
+	require.Equal(t, DDLCompatibilityCheckWarn, cfg.DDLCompatibilityCheck)
+
+	// The sink URI parameter overrides the config file.
+	cfg, err = apply("mysql://127.0.0.1:3306/?ddl-compatibility-check=fail",
+		aws.String("warn"))
+	require.NoError(t, err)
+	require.Equal(t, DDLCompatibilityCheckFail, cfg.DDLCompatibilityCheck)
+
+	_, err = apply("mysql://127.0.0.1:3306/?ddl-compatibility-check=skip", nil)
+	require.Regexp(t, "invalid ddl-compatibility-check", err)
+}
+
+func TestApplyAdaptiveTxnRow(t *testing.T) {
+	t.Parallel()
+
+	apply := func(uriStr string, adaptive *bool) (*Config, error) {
+		uri, err := url.Parse(uriStr)
+		require.NoError(t, err)
+		rc := config.GetDefaultReplicaConfig()
+		rc.Sink.MySQLConfig = &config.MySQLConfig{AdaptiveTxnRow: adaptive}
+		cfg := NewConfig()
+		return cfg, cfg.Apply("UTC", model.ChangeFeedID{}, uri, rc)
+	}
+
+	cfg, err := apply("mysql://127.0.0.1:3306/", nil)
+	require.NoError(t, err)
+	require.False(t, cfg.AdaptiveTxnRow)
+
+	cfg, err = apply("mysql://127.0.0.1:3306/", aws.Bool(true))
+	require.NoError(t, err)
+	require.True(t, cfg.AdaptiveTxnRow)
+
+	// The sink URI parameter overrides the config file.
+	cfg, err = apply("mysql://127.0.0.1:3306/?adaptive-txn-row=false", aws.Bool(true))
+	require.NoError(t, err)
+	require.False(t, cfg.AdaptiveTxnRow)
+}
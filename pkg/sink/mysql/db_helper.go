@@ -18,7 +18,6 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
-	"net"
 	"net/url"
 	"strconv"
 
@@ -34,7 +33,7 @@ import (
 
 // CreateMySQLDBConn creates a mysql database connection with the given dsn.
 func CreateMySQLDBConn(ctx context.Context, dsnStr string) (*sql.DB, error) {
-	db, err := sql.Open("mysql", dsnStr)
+	db, err := openDB(dsnStr)
 	if err != nil {
 		return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
 	}
@@ -60,8 +59,14 @@ func GenerateDSN(ctx context.Context, sinkURI *url.URL, cfg *Config, dbConnFacto
 		return "", err
 	}
 
+	endpoints := cfg.Endpoints
+	if len(endpoints) == 0 {
+		if endpoints, err = ParseEndpoints(sinkURI); err != nil {
+			return "", err
+		}
+	}
 	var testDB *sql.DB
-	testDB, err = GetTestDB(ctx, dsn, dbConnFactory)
+	testDB, err = connectEndpoint(ctx, dsn, endpoints, dbConnFactory)
 	if err != nil {
 		return
 	}
@@ -155,6 +160,28 @@ func generateDSNByConfig(
 	// disable foreign_key_checks
 	dsnCfg.Params["foreign_key_checks"] = "0"
 
+	// Configured session variables override the ones above, and all of them
+	// must be supported by the downstream, otherwise constraints which are
+	// expected to be bypassed could be silently enforced.
+	for _, name := range cfg.SessionVariableNames() {
+		supported, err := checkTiDBVariable(ctx, testDB, name, name)
+		if err != nil {
+			return "", err
+		}
+		if supported == "" {
+			return "", cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"session variable %s is not supported by the downstream", name)
+		}
+	}
+	for name, value := range cfg.SessionVariables {
+		dsnCfg.Params[name] = FormatSessionVariable(name, value)
+	}
+	if len(cfg.SessionVariables) > 0 || len(cfg.SessionVariableRules) > 0 {
+		log.Info("session variables of the downstream are configured",
+			zap.Any("sessionVariables", cfg.SessionVariables),
+			zap.Int("sessionVariableRuleCount", len(cfg.SessionVariableRules)))
+	}
+
 	tidbPlacementMode, err := checkTiDBVariable(ctx, testDB, "tidb_placement_mode", "ignore")
 	if err != nil {
 		return "", err
@@ -170,6 +197,30 @@ func generateDSNByConfig(
 		// set the `tidb_enable_external_ts_read` to `OFF`, so cdc could write to the sink
 		dsnCfg.Params["tidb_enable_external_ts_read"] = fmt.Sprintf(`"%s"`, tidbEnableExternalTSRead)
 	}
+	if cfg.ReadYourWrites {
+		// Reads of TiDB may be served by followers, which are not guaranteed
+		// to see the latest writes.
+		replicaRead, err := checkTiDBVariable(ctx, testDB, "tidb_replica_read", "leader")
+		if err != nil {
+			return "", err
+		}
+		if replicaRead != "" {
+			dsnCfg.Params["tidb_replica_read"] = fmt.Sprintf(`"%s"`, replicaRead)
+		}
+	}
+	if cfg.ResourceGroup != "" {
+		resourceGroup, err := checkTiDBVariable(
+			ctx, testDB, "tidb_enable_resource_control", cfg.ResourceGroup)
+		if err != nil {
+			return "", err
+		}
+		if resourceGroup != "" {
+			dsnCfg.Params[resourceGroupParam] = resourceGroup
+		} else {
+			log.Warn("resource control is not supported by the downstream, "+
+				"ignore the resource group", zap.String("resourceGroup", cfg.ResourceGroup))
+		}
+	}
 	dsnClone := dsnCfg.Clone()
 	dsnClone.Passwd = "******"
 	log.Info("sink uri is configured", zap.String("dsn", dsnClone.FormatDSN()))
@@ -259,16 +310,17 @@ func GenBasicDSN(sinkURI *url.URL, cfg *Config) (*dmysql.Config, error) {
 	}
 	password, _ := sinkURI.User.Password()
 
-	hostName := sinkURI.Hostname()
-	port := sinkURI.Port()
-	if port == "" {
-		port = "4000"
+	endpoints := cfg.Endpoints
+	if len(endpoints) == 0 {
+		var err error
+		if endpoints, err = ParseEndpoints(sinkURI); err != nil {
+			return nil, err
+		}
 	}
 
-	// This will handle the IPv6 address format.
 	var dsn *dmysql.Config
 	var err error
-	host := net.JoinHostPort(hostName, port)
+	host := endpoints[0]
 	dsnStr := fmt.Sprintf("%s:%s@tcp(%s)/%s", username, password, host, cfg.TLS)
 	if dsn, err = dmysql.ParseDSN(dsnStr); err != nil {
 		return nil, errors.Trace(err)
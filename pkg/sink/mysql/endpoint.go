@@ -0,0 +1,104 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/url"
+	"strings"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const defaultPort = "4000"
+
+// ParseEndpoints parses downstream addresses from the host of the sink URI.
+// Multiple hosts are separated by commas, for example,
+// mysql://root@127.0.0.1:3306,127.0.0.2:3306/.
+func ParseEndpoints(sinkURI *url.URL) ([]string, error) {
+	hosts := strings.Split(sinkURI.Host, ",")
+	endpoints := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		host = strings.TrimSpace(host)
+		if host == "" && len(hosts) > 1 {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"empty host in sink uri %s", sinkURI.Host)
+		}
+		u := &url.URL{Host: host}
+		port := u.Port()
+		if port == "" {
+			port = defaultPort
+		}
+		// This will handle the IPv6 address format.
+		endpoints = append(endpoints, net.JoinHostPort(u.Hostname(), port))
+	}
+	return endpoints, nil
+}
+
+// QueryReadOnly returns whether the downstream is read-only. Replicas behind
+// a primary are usually read-only, so the sink never writes to them.
+func QueryReadOnly(ctx context.Context, db *sql.DB) (bool, error) {
+	var readOnly sql.NullInt64
+	err := db.QueryRowContext(ctx, "SELECT @@GLOBAL.read_only;").Scan(&readOnly)
+	if err != nil {
+		return false, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return readOnly.Valid && readOnly.Int64 != 0, nil
+}
+
+// connectEndpoint connects to the first available endpoint and sets the
+// address of dsn to it. If there are multiple endpoints, unreachable and
+// read-only endpoints are skipped.
+func connectEndpoint(
+	ctx context.Context, dsn *dmysql.Config, endpoints []string, dbConnFactory Factory,
+) (*sql.DB, error) {
+	if len(endpoints) <= 1 {
+		return GetTestDB(ctx, dsn, dbConnFactory)
+	}
+	var lastErr error
+	for _, endpoint := range endpoints {
+		dsn.Addr = endpoint
+		db, err := GetTestDB(ctx, dsn, dbConnFactory)
+		if err != nil {
+			log.Warn("downstream endpoint is unavailable",
+				zap.String("endpoint", endpoint), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		readOnly, err := QueryReadOnly(ctx, db)
+		if err != nil || readOnly {
+			log.Warn("downstream endpoint is not writable",
+				zap.String("endpoint", endpoint),
+				zap.Bool("readOnly", readOnly), zap.Error(err))
+			if err != nil {
+				lastErr = err
+			}
+			_ = db.Close()
+			continue
+		}
+		log.Info("downstream endpoint is selected",
+			zap.String("endpoint", endpoint), zap.Strings("endpoints", endpoints))
+		return db, nil
+	}
+	if lastErr != nil {
+		return nil, cerror.ErrMySQLNoAvailableEndpoint.Wrap(lastErr).
+			GenWithStackByArgs(endpoints)
+	}
+	return nil, cerror.ErrMySQLNoAvailableEndpoint.GenWithStackByArgs(endpoints)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEndpoints(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		uri      string
+		expected []string
+	}{
+		{"mysql://root@127.0.0.1:3306/", []string{"127.0.0.1:3306"}},
+		{"mysql://root@127.0.0.1/", []string{"127.0.0.1:4000"}},
+		{"mysql://root@[::1]:3306/", []string{"[::1]:3306"}},
+		{"tidb://root@/", []string{":4000"}},
+		{
+			"mysql://root@a,b:3306/",
+			[]string{"a:4000", "b:3306"},
+		},
+	}
+	for _, c := range cases {
+		uri, err := url.Parse(c.uri)
+		require.Nil(t, err)
+		endpoints, err := ParseEndpoints(uri)
+		require.Nil(t, err)
+		require.Equal(t, c.expected, endpoints, c.uri)
+	}
+
+	uri, err := url.Parse("mysql://root@a,,b:3306/")
+	require.Nil(t, err)
+	_, err = ParseEndpoints(uri)
+	require.True(t, cerror.ErrMySQLInvalidConfig.Equal(err))
+}
+
+func TestConnectEndpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	readOnlyDB := func(readOnly int) *sql.DB {
+		db, mock, err := sqlmock.New()
+		require.Nil(t, err)
+		mock.ExpectQuery("SELECT @@GLOBAL.read_only;").
+			WillReturnRows(sqlmock.NewRows([]string{"@@GLOBAL.read_only"}).AddRow(readOnly))
+		mock.ExpectClose()
+		return db
+	}
+	dbs := map[string]*sql.DB{
+		"replica:3306": readOnlyDB(1),
+		"primary:3306": readOnlyDB(0),
+	}
+	var connected []string
+	factory := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		dsn, err := dmysql.ParseDSN(dsnStr)
+		require.Nil(t, err)
+		connected = append(connected, dsn.Addr)
+		if db, ok := dbs[dsn.Addr]; ok {
+			return db, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+
+	// The unreachable and read-only endpoints are skipped.
+	dsn, err := dmysql.ParseDSN("root:@tcp(down:3306)/")
+	require.Nil(t, err)
+	db, err := connectEndpoint(ctx, dsn,
+		[]string{"down:3306", "replica:3306", "primary:3306"}, factory)
+	require.Nil(t, err)
+	require.Equal(t, dbs["primary:3306"], db)
+	require.Equal(t, "primary:3306", dsn.Addr)
+	require.Equal(t, []string{"down:3306", "replica:3306", "primary:3306"}, connected)
+	require.Nil(t, db.Close())
+
+	// No writable endpoint.
+	dbs["replica:3306"] = readOnlyDB(1)
+	_, err = connectEndpoint(ctx, dsn, []string{"down:3306", "replica:3306"}, factory)
+	require.Regexp(t, "ErrMySQLNoAvailableEndpoint", err)
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/quotes"
+)
+
+// resourceGroupParam is the DSN parameter of the TiDB resource group that
+// sessions are bound to. Unlike other parameters, it's not a system variable,
+// so it's handled by openDB instead of the driver.
+const resourceGroupParam = "cdc_resource_group"
+
+// resourceGroupConnector binds every new session to a resource group.
+type resourceGroupConnector struct {
+	driver.Connector
+	resourceGroup string
+}
+
+// Connect implements driver.Connector.
+func (c *resourceGroupConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		_ = conn.Close()
+		return nil, errors.New("driver connection does not support executing statements")
+	}
+	_, err = execer.ExecContext(ctx, "SET RESOURCE GROUP "+quotes.QuoteName(c.resourceGroup), nil)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// openDB opens a database of the DSN, sessions of which are bound to the
+// resource group in the DSN if any.
+func openDB(dsnStr string) (*sql.DB, error) {
+	dsnCfg, err := dmysql.ParseDSN(dsnStr)
+	if err != nil {
+		return nil, err
+	}
+	resourceGroup, ok := dsnCfg.Params[resourceGroupParam]
+	if !ok {
+		return sql.Open("mysql", dsnStr)
+	}
+	delete(dsnCfg.Params, resourceGroupParam)
+	connector, err := dmysql.NewConnector(dsnCfg)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(&resourceGroupConnector{
+		Connector:     connector,
+		resourceGroup: resourceGroup,
+	}), nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockConnector struct {
+	driver driver.Driver
+	dsn    string
+}
+
+func (c *mockConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *mockConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func TestResourceGroupConnector(t *testing.T) {
+	t.Parallel()
+
+	mockDB, mock, err := sqlmock.NewWithDSN("resource-group-connector")
+	require.Nil(t, err)
+	defer mockDB.Close()
+	mock.ExpectExec("SET RESOURCE GROUP `rg1`").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	db := sql.OpenDB(&resourceGroupConnector{
+		Connector: &mockConnector{
+			driver: mockDB.Driver(),
+			dsn:    "resource-group-connector",
+		},
+		resourceGroup: "rg1",
+	})
+	defer db.Close()
+	conn, err := db.Conn(context.Background())
+	require.Nil(t, err)
+	require.Nil(t, conn.Close())
+	require.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestOpenDB(t *testing.T) {
+	t.Parallel()
+
+	db, err := openDB("root:123456@tcp(127.0.0.1:4000)/?" + resourceGroupParam + "=rg1")
+	require.Nil(t, err)
+	require.Nil(t, db.Close())
+
+	_, err = openDB("root:123456@tcp(127.0.0.1:4000")
+	require.Error(t, err)
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// KeyHeader is the header which carries the key of a message, because NATS
+// messages have no key.
+const KeyHeader = "Ticdc-Message-Key"
+
+// Client is the interface of a NATS JetStream client.
+type Client interface {
+	// PublishAsync publishes a message to the subject asynchronously, the
+	// returned future is resolved once the message is acked by the stream.
+	PublishAsync(subject string, key, value []byte) (nats.PubAckFuture, error)
+	// Publish publishes a message to the subject and waits for the ack.
+	Publish(ctx context.Context, subject string, key, value []byte) error
+	// StreamNameBySubject returns the name of the stream which captures the
+	// subject.
+	StreamNameBySubject(ctx context.Context, subject string) (string, error)
+	// Close flushes pending messages and closes the client.
+	Close()
+}
+
+// Factory is a function to create a NATS client.
+type Factory func(o *Options, changefeedID model.ChangeFeedID) (Client, error)
+
+type jetStreamClient struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	options *Options
+}
+
+// NewJetStreamClient creates a new NATS JetStream client.
+func NewJetStreamClient(o *Options, changefeedID model.ChangeFeedID) (Client, error) {
+	name := o.ClientName
+	if name == "" {
+		name = "ticdc_" + changefeedID.Namespace + "_" + changefeedID.ID
+	}
+	opts := []nats.Option{nats.Name(name), nats.MaxReconnects(-1)}
+	if o.User != "" {
+		opts = append(opts, nats.UserInfo(o.User, o.Password))
+	}
+	if o.Token != "" {
+		opts = append(opts, nats.Token(o.Token))
+	}
+	conn, err := nats.Connect(strings.Join(o.Servers, ","), opts...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrNatsNewClient, err)
+	}
+	js, err := conn.JetStream(nats.PublishAsyncMaxPending(o.MaxPendingAcks))
+	if err != nil {
+		conn.Close()
+		return nil, cerror.WrapError(cerror.ErrNatsNewClient, err)
+	}
+	if maxPayload := conn.MaxPayload(); int64(o.MaxMessageBytes) > maxPayload {
+		log.Warn("max-message-bytes is larger than max_payload of nats server, use max_payload instead",
+			zap.Int("maxMessageBytes", o.MaxMessageBytes),
+			zap.Int64("maxPayload", maxPayload))
+		o.MaxMessageBytes = int(maxPayload)
+	}
+	return &jetStreamClient{conn: conn, js: js, options: o}, nil
+}
+
+func newMsg(subject string, key, value []byte) *nats.Msg {
+	msg := nats.NewMsg(subject)
+	if len(key) > 0 {
+		msg.Header.Set(KeyHeader, string(key))
+	}
+	msg.Data = value
+	return msg
+}
+
+// PublishAsync implements Client.PublishAsync.
+func (c *jetStreamClient) PublishAsync(subject string, key, value []byte) (nats.PubAckFuture, error) {
+	// Wait for the ack timeout at most if there are too many pending messages.
+	future, err := c.js.PublishMsgAsync(newMsg(subject, key, value), nats.StallWait(c.options.AckTimeout))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrNatsSendMessage, err)
+	}
+	return future, nil
+}
+
+// Publish implements Client.Publish.
+func (c *jetStreamClient) Publish(ctx context.Context, subject string, key, value []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, c.options.AckTimeout)
+	defer cancel()
+	_, err := c.js.PublishMsg(newMsg(subject, key, value), nats.Context(ctx))
+	return errors.Trace(err)
+}
+
+// StreamNameBySubject implements Client.StreamNameBySubject.
+func (c *jetStreamClient) StreamNameBySubject(ctx context.Context, subject string) (string, error) {
+	name, err := c.js.StreamNameBySubject(subject, nats.Context(ctx))
+	if err != nil {
+		if errors.Cause(err) == nats.ErrNoMatchingStream {
+			return "", cerror.ErrNatsStreamNotFound.GenWithStackByArgs(subject)
+		}
+		return "", errors.Trace(err)
+	}
+	return name, nil
+}
+
+// Close implements Client.Close.
+func (c *jetStreamClient) Close() {
+	if err := c.conn.Drain(); err != nil {
+		log.Warn("drain nats connection failed", zap.Error(err))
+		c.conn.Close()
+	}
+}
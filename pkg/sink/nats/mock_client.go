@@ -0,0 +1,212 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+var _ Client = (*MockClient)(nil)
+
+type mockPubAckFuture struct {
+	msg *nats.Msg
+	ok  chan *nats.PubAck
+	err chan error
+}
+
+func newMockPubAckFuture(msg *nats.Msg) *mockPubAckFuture {
+	return &mockPubAckFuture{
+		msg: msg,
+		ok:  make(chan *nats.PubAck, 1),
+		err: make(chan error, 1),
+	}
+}
+
+func (f *mockPubAckFuture) resolve(stream string, err error) {
+	if err != nil {
+		f.err <- err
+		return
+	}
+	f.ok <- &nats.PubAck{Stream: stream}
+}
+
+func (f *mockPubAckFuture) Ok() <-chan *nats.PubAck { return f.ok }
+
+func (f *mockPubAckFuture) Err() <-chan error { return f.err }
+
+func (f *mockPubAckFuture) Msg() *nats.Msg { return f.msg }
+
+// MockClient is a mock implementation of Client interface, it keeps all
+// published messages in memory.
+type MockClient struct {
+	mu sync.Mutex
+	// subject pattern -> stream name
+	streams  map[string]string
+	messages map[string][]*nats.Msg
+	// manualAck indicates async published messages are not acked until
+	// Ack is called.
+	manualAck bool
+	pending   []*mockPubAckFuture
+	closed    bool
+}
+
+// NewMockClient creates a new MockClient.
+func NewMockClient() *MockClient {
+	return &MockClient{
+		streams:  make(map[string]string),
+		messages: make(map[string][]*nats.Msg),
+	}
+}
+
+// NewMockFactory returns a Factory which always returns the client.
+func NewMockFactory(client *MockClient) Factory {
+	return func(_ *Options, _ model.ChangeFeedID) (Client, error) {
+		return client, nil
+	}
+}
+
+// AddStream adds a stream which captures the subjects, the subjects can
+// contain wildcards.
+func (c *MockClient) AddStream(name string, subjects ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, subject := range subjects {
+		c.streams[subject] = name
+	}
+}
+
+// SetManualAck sets whether async published messages are acked manually.
+func (c *MockClient) SetManualAck(manualAck bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.manualAck = manualAck
+}
+
+// Ack resolves all pending async published messages with the err.
+func (c *MockClient) Ack(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, future := range c.pending {
+		c.resolveLocked(future, err)
+	}
+	c.pending = nil
+}
+
+// Pending returns the number of async published messages which are not acked.
+func (c *MockClient) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pending)
+}
+
+// Messages returns the messages published to the subject.
+func (c *MockClient) Messages(subject string) []*nats.Msg {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*nats.Msg(nil), c.messages[subject]...)
+}
+
+// Closed returns whether the client is closed.
+func (c *MockClient) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// PublishAsync implements Client.PublishAsync.
+func (c *MockClient) PublishAsync(subject string, key, value []byte) (nats.PubAckFuture, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, cerror.ErrNatsSendMessage.GenWithStack("client is closed")
+	}
+	future := newMockPubAckFuture(newMsg(subject, key, value))
+	if c.manualAck {
+		c.pending = append(c.pending, future)
+	} else {
+		c.resolveLocked(future, nil)
+	}
+	return future, nil
+}
+
+// Publish implements Client.Publish.
+func (c *MockClient) Publish(_ context.Context, subject string, key, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.streamLocked(subject); !ok {
+		return nats.ErrNoStreamResponse
+	}
+	c.messages[subject] = append(c.messages[subject], newMsg(subject, key, value))
+	return nil
+}
+
+// StreamNameBySubject implements Client.StreamNameBySubject.
+func (c *MockClient) StreamNameBySubject(_ context.Context, subject string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stream, ok := c.streamLocked(subject)
+	if !ok {
+		return "", cerror.ErrNatsStreamNotFound.GenWithStackByArgs(subject)
+	}
+	return stream, nil
+}
+
+// Close implements Client.Close.
+func (c *MockClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+func (c *MockClient) resolveLocked(future *mockPubAckFuture, err error) {
+	stream, ok := c.streamLocked(future.msg.Subject)
+	if err == nil && !ok {
+		err = nats.ErrNoStreamResponse
+	}
+	if err == nil {
+		c.messages[future.msg.Subject] = append(c.messages[future.msg.Subject], future.msg)
+	}
+	future.resolve(stream, err)
+}
+
+func (c *MockClient) streamLocked(subject string) (string, bool) {
+	for pattern, stream := range c.streams {
+		if subjectMatches(pattern, subject) {
+			return stream, true
+		}
+	}
+	return "", false
+}
+
+// subjectMatches returns whether the subject matches the pattern, `*` matches
+// a single token and `>` matches one or more trailing tokens.
+func subjectMatches(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+	for i, token := range patternTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+	return len(patternTokens) == len(subjectTokens)
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// defaultMaxMessageBytes is the default max_payload of the NATS server.
+	defaultMaxMessageBytes = 1024 * 1024
+	// defaultMaxPendingAcks is the default number of messages which are
+	// published but not acked yet.
+	defaultMaxPendingAcks = 4096
+	// defaultAckTimeout is the default timeout to wait for the ack of a message.
+	defaultAckTimeout = 30 * time.Second
+	// resolvedSubjectSuffix is appended to the default subject to make the
+	// default resolved subject.
+	resolvedSubjectSuffix = ".resolved"
+)
+
+type urlConfig struct {
+	MaxMessageBytes *int    `form:"max-message-bytes"`
+	MaxPendingAcks  *int    `form:"max-pending-acks"`
+	AckTimeout      *string `form:"ack-timeout"`
+	ResolvedSubject *string `form:"resolved-subject"`
+	Token           *string `form:"token"`
+	ClientName      *string `form:"client-name"`
+}
+
+// Options stores user specified configurations of the NATS sink.
+type Options struct {
+	Servers    []string
+	User       string
+	Password   string
+	Token      string
+	ClientName string
+
+	MaxMessageBytes int
+	// MaxPendingAcks is the max number of messages which are published but
+	// not acked by the server yet.
+	MaxPendingAcks int
+	AckTimeout     time.Duration
+	// ResolvedSubject is the dedicated subject resolved ts messages are sent to.
+	ResolvedSubject string
+}
+
+// NewOptions returns a default Options.
+func NewOptions() *Options {
+	return &Options{
+		MaxMessageBytes: defaultMaxMessageBytes,
+		MaxPendingAcks:  defaultMaxPendingAcks,
+		AckTimeout:      defaultAckTimeout,
+	}
+}
+
+// Apply applies the sink URI to the options, defaultSubject is the subject
+// specified in the path of the sink URI.
+func (o *Options) Apply(sinkURI *url.URL, defaultSubject string) error {
+	if sinkURI.Host == "" {
+		return cerror.ErrNatsInvalidConfig.GenWithStack("no server is specified in sink-uri")
+	}
+	if !isValidSubject(defaultSubject) {
+		return cerror.ErrNatsInvalidConfig.GenWithStack("invalid subject %s", defaultSubject)
+	}
+	for _, host := range strings.Split(sinkURI.Host, ",") {
+		o.Servers = append(o.Servers, "nats://"+host)
+	}
+	if sinkURI.User != nil {
+		o.User = sinkURI.User.Username()
+		o.Password, _ = sinkURI.User.Password()
+	}
+
+	req := &http.Request{URL: sinkURI}
+	urlParameter := &urlConfig{}
+	if err := binding.Query.Bind(req, urlParameter); err != nil {
+		return cerror.WrapError(cerror.ErrNatsInvalidConfig, err)
+	}
+	if urlParameter.MaxMessageBytes != nil {
+		o.MaxMessageBytes = *urlParameter.MaxMessageBytes
+		if o.MaxMessageBytes <= 0 {
+			return cerror.ErrNatsInvalidConfig.GenWithStack(
+				"invalid max-message-bytes %d", o.MaxMessageBytes)
+		}
+	}
+	if urlParameter.MaxPendingAcks != nil {
+		o.MaxPendingAcks = *urlParameter.MaxPendingAcks
+		if o.MaxPendingAcks <= 0 {
+			return cerror.ErrNatsInvalidConfig.GenWithStack(
+				"invalid max-pending-acks %d", o.MaxPendingAcks)
+		}
+	}
+	if urlParameter.AckTimeout != nil {
+		timeout, err := time.ParseDuration(*urlParameter.AckTimeout)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrNatsInvalidConfig, err)
+		}
+		if timeout <= 0 {
+			return cerror.ErrNatsInvalidConfig.GenWithStack(
+				"invalid ack-timeout %s", *urlParameter.AckTimeout)
+		}
+		o.AckTimeout = timeout
+	}
+	if urlParameter.Token != nil {
+		o.Token = *urlParameter.Token
+	}
+	if urlParameter.ClientName != nil {
+		o.ClientName = *urlParameter.ClientName
+	}
+
+	o.ResolvedSubject = defaultSubject + resolvedSubjectSuffix
+	if urlParameter.ResolvedSubject != nil {
+		o.ResolvedSubject = *urlParameter.ResolvedSubject
+	}
+	if !isValidSubject(o.ResolvedSubject) {
+		return cerror.ErrNatsInvalidConfig.GenWithStack(
+			"invalid resolved-subject %s", o.ResolvedSubject)
+	}
+	return nil
+}
+
+// isValidSubject checks whether messages can be published to the subject,
+// wildcards and empty tokens are not allowed.
+func isValidSubject(subject string) bool {
+	if strings.ContainsAny(subject, "*> \t\r\n") {
+		return false
+	}
+	for _, token := range strings.Split(subject, ".") {
+		if token == "" {
+			return false
+		}
+	}
+	return true
+}
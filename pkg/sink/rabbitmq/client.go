@@ -0,0 +1,164 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmq
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+const (
+	// KeyHeader is the header which carries the key of a message, because
+	// AMQP messages have no key.
+	KeyHeader = "ticdc-message-key"
+
+	heartbeat = 10 * time.Second
+	locale    = "en_US"
+)
+
+// Confirmation is the publisher confirm of a message.
+type Confirmation interface {
+	// Done returns a channel which is closed once the message is confirmed.
+	Done() <-chan struct{}
+	// Acked returns whether the message is acked by the broker, it's only
+	// valid after Done is closed.
+	Acked() bool
+}
+
+// Client is the interface of a RabbitMQ client.
+type Client interface {
+	// PublishWithConfirm publishes a message to the exchange asynchronously,
+	// the returned confirmation is done once the broker confirms the message.
+	PublishWithConfirm(ctx context.Context, exchange string, message *common.Message) (Confirmation, error)
+	// Publish publishes a message to the exchange and waits for the confirm.
+	Publish(ctx context.Context, exchange string, message *common.Message) error
+	// CheckExchange checks whether the exchange exists.
+	CheckExchange(ctx context.Context, exchange string) error
+	// Close closes the client, messages which are not confirmed are nacked.
+	Close()
+}
+
+// Factory is a function to create a RabbitMQ client.
+type Factory func(o *Options, changefeedID model.ChangeFeedID) (Client, error)
+
+type amqpClient struct {
+	conn *amqp.Connection
+	// ch is in confirm mode.
+	ch      *amqp.Channel
+	options *Options
+}
+
+// NewAMQPClient creates a new AMQP 0.9.1 client with publisher confirms.
+func NewAMQPClient(o *Options, changefeedID model.ChangeFeedID) (Client, error) {
+	properties := amqp.NewConnectionProperties()
+	properties.SetClientConnectionName("ticdc_" + changefeedID.Namespace + "_" + changefeedID.ID)
+	conn, err := amqp.DialConfig(o.URI, amqp.Config{
+		Heartbeat:  heartbeat,
+		Locale:     locale,
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRabbitMQNewClient, err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return nil, cerror.WrapError(cerror.ErrRabbitMQNewClient, err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		_ = conn.Close()
+		return nil, cerror.WrapError(cerror.ErrRabbitMQNewClient, err)
+	}
+	return &amqpClient{conn: conn, ch: ch, options: o}, nil
+}
+
+func (c *amqpClient) newPublishing(message *common.Message) amqp.Publishing {
+	publishing := amqp.Publishing{
+		Body:         message.Value,
+		Expiration:   c.options.expiration(),
+		DeliveryMode: amqp.Transient,
+	}
+	if c.options.Persistent {
+		publishing.DeliveryMode = amqp.Persistent
+	}
+	if len(message.Key) > 0 {
+		publishing.Headers = amqp.Table{KeyHeader: string(message.Key)}
+	}
+	return publishing
+}
+
+// PublishWithConfirm implements Client.PublishWithConfirm.
+func (c *amqpClient) PublishWithConfirm(
+	ctx context.Context, exchange string, message *common.Message,
+) (Confirmation, error) {
+	confirmation, err := c.ch.PublishWithDeferredConfirmWithContext(ctx, exchange,
+		c.options.GetRoutingKey(message), false, false, c.newPublishing(message))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRabbitMQSendMessage, err)
+	}
+	return confirmation, nil
+}
+
+// Publish implements Client.Publish.
+func (c *amqpClient) Publish(ctx context.Context, exchange string, message *common.Message) error {
+	confirmation, err := c.ch.PublishWithDeferredConfirmWithContext(ctx, exchange,
+		c.options.GetRoutingKey(message), false, false, c.newPublishing(message))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.options.ConfirmTimeout)
+	defer cancel()
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !acked {
+		return errors.Errorf("message to exchange %s is nacked by the broker", exchange)
+	}
+	return nil
+}
+
+// CheckExchange implements Client.CheckExchange.
+func (c *amqpClient) CheckExchange(_ context.Context, exchange string) error {
+	// The channel is closed by the broker if the exchange doesn't exist, so
+	// use a dedicated channel.
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		_ = ch.Close()
+	}()
+	// The kind is ignored by passive declarations.
+	err = ch.ExchangeDeclarePassive(exchange, amqp.ExchangeTopic, true, false, false, false, nil)
+	if amqpErr, ok := err.(*amqp.Error); ok && amqpErr.Code == amqp.NotFound {
+		return cerror.ErrRabbitMQExchangeNotFound.GenWithStackByArgs(exchange)
+	}
+	return errors.Trace(err)
+}
+
+// Close implements Client.Close.
+func (c *amqpClient) Close() {
+	if err := c.conn.Close(); err != nil {
+		log.Warn("close rabbitmq connection failed", zap.Error(err))
+	}
+}
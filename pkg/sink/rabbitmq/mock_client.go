@@ -0,0 +1,199 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmq
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+)
+
+var _ Client = (*MockClient)(nil)
+
+type mockConfirmation struct {
+	done  chan struct{}
+	acked bool
+}
+
+func (c *mockConfirmation) confirm(acked bool) {
+	c.acked = acked
+	close(c.done)
+}
+
+func (c *mockConfirmation) Done() <-chan struct{} { return c.done }
+
+func (c *mockConfirmation) Acked() bool { return c.acked }
+
+// MockMessage is a message published to the MockClient.
+type MockMessage struct {
+	RoutingKey string
+	Message    *common.Message
+}
+
+type pendingMockMessage struct {
+	exchange     string
+	message      MockMessage
+	confirmation *mockConfirmation
+}
+
+// MockClient is a mock implementation of Client interface, it keeps all
+// published messages in memory.
+type MockClient struct {
+	mu        sync.Mutex
+	options   *Options
+	exchanges map[string]struct{}
+	messages  map[string][]MockMessage
+	// manualConfirm indicates messages are not confirmed until Confirm is called.
+	manualConfirm bool
+	pending       []pendingMockMessage
+	closed        bool
+}
+
+// NewMockClient creates a new MockClient.
+func NewMockClient() *MockClient {
+	return &MockClient{
+		options:   NewOptions(),
+		exchanges: make(map[string]struct{}),
+		messages:  make(map[string][]MockMessage),
+	}
+}
+
+// NewMockFactory returns a Factory which always returns the client.
+func NewMockFactory(client *MockClient) Factory {
+	return func(o *Options, _ model.ChangeFeedID) (Client, error) {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		client.options = o
+		return client, nil
+	}
+}
+
+// AddExchange adds an exchange.
+func (c *MockClient) AddExchange(exchanges ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, exchange := range exchanges {
+		c.exchanges[exchange] = struct{}{}
+	}
+}
+
+// SetManualConfirm sets whether messages are confirmed manually.
+func (c *MockClient) SetManualConfirm(manualConfirm bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.manualConfirm = manualConfirm
+}
+
+// Confirm confirms all pending messages.
+func (c *MockClient) Confirm(acked bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, pending := range c.pending {
+		c.confirmLocked(pending, acked)
+	}
+	c.pending = nil
+}
+
+// Pending returns the number of messages which are not confirmed.
+func (c *MockClient) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pending)
+}
+
+// Messages returns the messages published to the exchange.
+func (c *MockClient) Messages(exchange string) []MockMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]MockMessage(nil), c.messages[exchange]...)
+}
+
+// Closed returns whether the client is closed.
+func (c *MockClient) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// PublishWithConfirm implements Client.PublishWithConfirm.
+func (c *MockClient) PublishWithConfirm(
+	_ context.Context, exchange string, message *common.Message,
+) (Confirmation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, cerror.ErrRabbitMQSendMessage.GenWithStack("client is closed")
+	}
+	pending := pendingMockMessage{
+		exchange: exchange,
+		message: MockMessage{
+			RoutingKey: c.options.GetRoutingKey(message),
+			Message:    message,
+		},
+		confirmation: &mockConfirmation{done: make(chan struct{})},
+	}
+	if c.manualConfirm {
+		c.pending = append(c.pending, pending)
+	} else {
+		c.confirmLocked(pending, true)
+	}
+	return pending.confirmation, nil
+}
+
+// Publish implements Client.Publish.
+func (c *MockClient) Publish(_ context.Context, exchange string, message *common.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.exchanges[exchange]; !ok {
+		return errors.Errorf("exchange %s not found", exchange)
+	}
+	c.messages[exchange] = append(c.messages[exchange], MockMessage{
+		RoutingKey: c.options.GetRoutingKey(message),
+		Message:    message,
+	})
+	return nil
+}
+
+// CheckExchange implements Client.CheckExchange.
+func (c *MockClient) CheckExchange(_ context.Context, exchange string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.exchanges[exchange]; !ok {
+		return cerror.ErrRabbitMQExchangeNotFound.GenWithStackByArgs(exchange)
+	}
+	return nil
+}
+
+// Close implements Client.Close.
+func (c *MockClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+func (c *MockClient) confirmLocked(pending pendingMockMessage, acked bool) {
+	// Messages to unknown exchanges are dropped by the broker, and the
+	// channel is closed, so they are nacked.
+	if _, ok := c.exchanges[pending.exchange]; !ok {
+		acked = false
+	}
+	if acked {
+		c.messages[pending.exchange] = append(c.messages[pending.exchange], pending.message)
+	}
+	pending.confirmation.confirm(acked)
+}
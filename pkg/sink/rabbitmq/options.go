@@ -0,0 +1,209 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmq
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+)
+
+const (
+	// defaultMaxMessageBytes is the default max size of a message, which is
+	// far less than max_message_size of the broker.
+	defaultMaxMessageBytes = 16 * 1024 * 1024
+	// defaultMaxPendingConfirms is the default number of messages which are
+	// published but not confirmed yet.
+	defaultMaxPendingConfirms = 4096
+	// defaultConfirmTimeout is the default timeout to wait for the publisher
+	// confirm of a message.
+	defaultConfirmTimeout = 30 * time.Second
+	// defaultRoutingKey routes messages by the schema and table.
+	defaultRoutingKey = "{schema}.{table}"
+	// defaultResolvedRoutingKey is the routing key of resolved ts messages.
+	defaultResolvedRoutingKey = "resolved"
+	// maxRoutingKeyLength is the max length of a routing key, which is a
+	// short string in AMQP 0.9.1.
+	maxRoutingKeyLength = 255
+)
+
+var (
+	// routingKeyRE is used to match a valid routing key template.
+	routingKeyRE = regexp.MustCompile(`^([^{}]|\{schema\}|\{table\})*$`)
+	schemaRE     = regexp.MustCompile(`\{schema\}`)
+	tableRE      = regexp.MustCompile(`\{table\}`)
+)
+
+type urlConfig struct {
+	Vhost              *string `form:"vhost"`
+	RoutingKey         *string `form:"routing-key"`
+	ResolvedRoutingKey *string `form:"resolved-routing-key"`
+	MessageTTL         *string `form:"message-ttl"`
+	Persistent         *bool   `form:"persistent"`
+	MaxMessageBytes    *int    `form:"max-message-bytes"`
+	MaxPendingConfirms *int    `form:"max-pending-confirms"`
+	ConfirmTimeout     *string `form:"confirm-timeout"`
+}
+
+// Options stores user specified configurations of the RabbitMQ sink.
+type Options struct {
+	// URI is the AMQP URI of the broker.
+	URI string
+	// RoutingKey is the routing key template of messages, `{schema}` and
+	// `{table}` are replaced by the schema and table of the message.
+	RoutingKey         string
+	ResolvedRoutingKey string
+	// MessageTTL is the per-message TTL, zero means messages never expire.
+	MessageTTL time.Duration
+	// Persistent indicates whether messages are persisted by the broker.
+	Persistent bool
+
+	MaxMessageBytes int
+	// MaxPendingConfirms is the max number of messages which are published
+	// but not confirmed by the broker yet.
+	MaxPendingConfirms int
+	ConfirmTimeout     time.Duration
+}
+
+// NewOptions returns a default Options.
+func NewOptions() *Options {
+	return &Options{
+		RoutingKey:         defaultRoutingKey,
+		ResolvedRoutingKey: defaultResolvedRoutingKey,
+		Persistent:         true,
+		MaxMessageBytes:    defaultMaxMessageBytes,
+		MaxPendingConfirms: defaultMaxPendingConfirms,
+		ConfirmTimeout:     defaultConfirmTimeout,
+	}
+}
+
+// Apply applies the sink URI to the options.
+func (o *Options) Apply(sinkURI *url.URL, useTLS bool) error {
+	if sinkURI.Host == "" {
+		return cerror.ErrRabbitMQInvalidConfig.GenWithStack("no broker is specified in sink-uri")
+	}
+
+	req := &http.Request{URL: sinkURI}
+	urlParameter := &urlConfig{}
+	if err := binding.Query.Bind(req, urlParameter); err != nil {
+		return cerror.WrapError(cerror.ErrRabbitMQInvalidConfig, err)
+	}
+
+	brokerURI := &url.URL{Scheme: "amqp", Host: sinkURI.Host, User: sinkURI.User, Path: "/"}
+	if useTLS {
+		brokerURI.Scheme = "amqps"
+	}
+	if urlParameter.Vhost != nil {
+		brokerURI.Path = "/" + url.PathEscape(*urlParameter.Vhost)
+	}
+	o.URI = brokerURI.String()
+
+	if urlParameter.RoutingKey != nil {
+		o.RoutingKey = *urlParameter.RoutingKey
+	}
+	if !routingKeyRE.MatchString(o.RoutingKey) {
+		return cerror.ErrRabbitMQInvalidConfig.GenWithStack(
+			"invalid routing-key %s, only {schema} and {table} placeholders are supported", o.RoutingKey)
+	}
+	if urlParameter.ResolvedRoutingKey != nil {
+		o.ResolvedRoutingKey = *urlParameter.ResolvedRoutingKey
+	}
+	if len(o.ResolvedRoutingKey) > maxRoutingKeyLength {
+		return cerror.ErrRabbitMQInvalidConfig.GenWithStack(
+			"resolved-routing-key is longer than %d bytes", maxRoutingKeyLength)
+	}
+	if urlParameter.MessageTTL != nil {
+		ttl, err := time.ParseDuration(*urlParameter.MessageTTL)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrRabbitMQInvalidConfig, err)
+		}
+		if ttl < 0 {
+			return cerror.ErrRabbitMQInvalidConfig.GenWithStack(
+				"invalid message-ttl %s", *urlParameter.MessageTTL)
+		}
+		o.MessageTTL = ttl
+	}
+	if urlParameter.Persistent != nil {
+		o.Persistent = *urlParameter.Persistent
+	}
+	if urlParameter.MaxMessageBytes != nil {
+		o.MaxMessageBytes = *urlParameter.MaxMessageBytes
+		if o.MaxMessageBytes <= 0 {
+			return cerror.ErrRabbitMQInvalidConfig.GenWithStack(
+				"invalid max-message-bytes %d", o.MaxMessageBytes)
+		}
+	}
+	if urlParameter.MaxPendingConfirms != nil {
+		o.MaxPendingConfirms = *urlParameter.MaxPendingConfirms
+		if o.MaxPendingConfirms <= 0 {
+			return cerror.ErrRabbitMQInvalidConfig.GenWithStack(
+				"invalid max-pending-confirms %d", o.MaxPendingConfirms)
+		}
+	}
+	if urlParameter.ConfirmTimeout != nil {
+		timeout, err := time.ParseDuration(*urlParameter.ConfirmTimeout)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrRabbitMQInvalidConfig, err)
+		}
+		if timeout <= 0 {
+			return cerror.ErrRabbitMQInvalidConfig.GenWithStack(
+				"invalid confirm-timeout %s", *urlParameter.ConfirmTimeout)
+		}
+		o.ConfirmTimeout = timeout
+	}
+	return nil
+}
+
+// IsRoutingKeyTemplated returns whether the routing key depends on the
+// schema or table of messages.
+func (o *Options) IsRoutingKeyTemplated() bool {
+	return schemaRE.MatchString(o.RoutingKey) || tableRE.MatchString(o.RoutingKey)
+}
+
+// GetRoutingKey returns the routing key of the message.
+func (o *Options) GetRoutingKey(message *common.Message) string {
+	if message.Type == model.MessageTypeResolved {
+		return o.ResolvedRoutingKey
+	}
+	var schema, table string
+	if message.Schema != nil {
+		schema = *message.Schema
+	}
+	if message.Table != nil {
+		table = *message.Table
+	}
+	// Use literal replacement, `$` in names must not be expanded.
+	routingKey := schemaRE.ReplaceAllLiteralString(o.RoutingKey, schema)
+	routingKey = tableRE.ReplaceAllLiteralString(routingKey, table)
+	if len(routingKey) > maxRoutingKeyLength {
+		routingKey = routingKey[:maxRoutingKeyLength]
+	}
+	return routingKey
+}
+
+// expiration converts the message TTL to the expiration property of
+// messages, which is in milliseconds.
+func (o *Options) expiration() string {
+	if o.MessageTTL == 0 {
+		return ""
+	}
+	return strconv.FormatInt(o.MessageTTL.Milliseconds(), 10)
+}
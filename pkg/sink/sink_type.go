@@ -39,6 +39,21 @@ const (
 	KafkaScheme = "kafka"
 	// KafkaSSLScheme indicates the scheme is kafka+ssl.
 	KafkaSSLScheme = "kafka+ssl"
+	// NatsScheme indicates the scheme is nats, which sends messages to NATS JetStream.
+	NatsScheme = "nats"
+	// RabbitMQScheme indicates the scheme is rabbitmq, which sends messages
+	// to RabbitMQ over AMQP 0.9.1.
+	RabbitMQScheme = "rabbitmq"
+	// RabbitMQSSLScheme indicates the scheme is rabbitmq+ssl.
+	RabbitMQSSLScheme = "rabbitmq+ssl"
+	// MQTTScheme indicates the scheme is mqtt, which sends messages to an
+	// MQTT v5 broker.
+	MQTTScheme = "mqtt"
+	// MQTTSSLScheme indicates the scheme is mqtt+ssl.
+	MQTTSSLScheme = "mqtt+ssl"
+	// GRPCScheme indicates the scheme is grpc, the events are streamed to
+	// the subscribers of the capture's subscription service.
+	GRPCScheme = "grpc"
 	// BlackHoleScheme indicates the scheme is blackhole.
 	BlackHoleScheme = "blackhole"
 	// MySQLScheme indicates the scheme is MySQL.
@@ -67,7 +82,9 @@ const (
 
 // IsMQScheme returns true if the scheme belong to mq scheme.
 func IsMQScheme(scheme string) bool {
-	return scheme == KafkaScheme || scheme == KafkaSSLScheme
+	return scheme == KafkaScheme || scheme == KafkaSSLScheme || scheme == NatsScheme ||
+		scheme == RabbitMQScheme || scheme == RabbitMQSSLScheme ||
+		scheme == MQTTScheme || scheme == MQTTSSLScheme
 }
 
 // IsMySQLCompatibleScheme returns true if the scheme is compatible with MySQL.
@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanz
+
+import (
+	"bytes"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"go.uber.org/zap"
+)
+
+// AddKeyspacePrefix returns a span whose keys are prefixed with the key prefix
+// of a keyspace. An empty end key is mapped to the end of the keyspace.
+// The span is returned as is if the prefix is empty.
+// Note that keys of the span must be encoded in memcomparable format.
+func AddKeyspacePrefix(prefix []byte, span tablepb.Span) tablepb.Span {
+	if len(prefix) == 0 {
+		return span
+	}
+	start := append(append([]byte{}, prefix...), decodeComparableKey(span.StartKey)...)
+	var end []byte
+	if len(span.EndKey) == 0 {
+		end = kv.Key(prefix).PrefixNext()
+	} else {
+		end = append(append([]byte{}, prefix...), decodeComparableKey(span.EndKey)...)
+	}
+	return tablepb.Span{
+		TableID:  span.TableID,
+		StartKey: ToComparableKey(start),
+		EndKey:   ToComparableKey(end),
+	}
+}
+
+// TrimKeyspacePrefix is the reverse of AddKeyspacePrefix.
+// The span is returned as is if the prefix is empty.
+func TrimKeyspacePrefix(prefix []byte, span tablepb.Span) tablepb.Span {
+	if len(prefix) == 0 {
+		return span
+	}
+	start := decodeComparableKey(span.StartKey)
+	end := decodeComparableKey(span.EndKey)
+	if !bytes.HasPrefix(start, prefix) {
+		log.Panic("span is out of the keyspace",
+			zap.Binary("prefix", prefix), zap.Stringer("span", &span))
+	}
+	res := tablepb.Span{
+		TableID:  span.TableID,
+		StartKey: ToComparableKey(start[len(prefix):]),
+	}
+	switch {
+	case bytes.HasPrefix(end, prefix):
+		res.EndKey = ToComparableKey(end[len(prefix):])
+	case bytes.Equal(end, kv.Key(prefix).PrefixNext()):
+		// The span ends at the end of the keyspace.
+	default:
+		log.Panic("span is out of the keyspace",
+			zap.Binary("prefix", prefix), zap.Stringer("span", &span))
+	}
+	return res
+}
+
+func decodeComparableKey(key tablepb.Key) []byte {
+	if len(key) == 0 {
+		return nil
+	}
+	_, raw, err := codec.DecodeBytes(key, nil)
+	if err != nil {
+		log.Panic("invalid comparable key", zap.Binary("key", key), zap.Error(err))
+	}
+	return raw
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanz
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyspacePrefix(t *testing.T) {
+	t.Parallel()
+
+	prefix := []byte{'x', 0, 0, 1}
+	span := TableIDToComparableSpan(100)
+
+	// Spans are not changed without a keyspace.
+	require.Equal(t, span, AddKeyspacePrefix(nil, span))
+	require.Equal(t, span, TrimKeyspacePrefix(nil, span))
+
+	prefixed := AddKeyspacePrefix(prefix, span)
+	require.Equal(t, span.TableID, prefixed.TableID)
+	start, end := GetTableRange(100)
+	require.Equal(t, ToComparableKey(append([]byte{'x', 0, 0, 1}, start...)), prefixed.StartKey)
+	require.Equal(t, ToComparableKey(append([]byte{'x', 0, 0, 1}, end...)), prefixed.EndKey)
+	require.Equal(t, span, TrimKeyspacePrefix(prefix, prefixed))
+
+	// An unbounded span covers the whole keyspace.
+	whole := AddKeyspacePrefix(prefix, tablepb.Span{})
+	require.Equal(t, ToComparableKey([]byte{'x', 0, 0, 1}), whole.StartKey)
+	require.Equal(t, ToComparableKey([]byte{'x', 0, 0, 2}), whole.EndKey)
+	require.Equal(t, tablepb.Span{StartKey: ToComparableKey(nil)}, TrimKeyspacePrefix(prefix, whole))
+
+	// Spans of other keyspaces are rejected.
+	require.Panics(t, func() {
+		TrimKeyspacePrefix([]byte{'x', 0, 0, 2}, prefixed)
+	})
+}
@@ -213,6 +213,78 @@ func GenUpdateSQL(changes ...*RowChange) (string, []any) {
 	return buf.String(), args
 }
 
+// GenUpdateChangedColumnsSQL generates the UPDATE SQL and its arguments, only
+// columns changed by `changes` are updated.
+// Input `changes` should have same target table, same columns for WHERE and
+// same ChangedColumns, otherwise the behaviour is undefined.
+func GenUpdateChangedColumnsSQL(changes ...*RowChange) (string, []any) {
+	if len(changes) == 0 {
+		log.L().DPanic("row changes is empty")
+		return "", nil
+	}
+	var buf strings.Builder
+	buf.Grow(1024)
+
+	// Generate UPDATE `db`.`table` SET
+	first := changes[0]
+	buf.WriteString("UPDATE ")
+	buf.WriteString(first.targetTable.QuoteString())
+	buf.WriteString(" SET ")
+
+	// Pre-generate essential sub statements used after WHEN, WHERE.
+	var (
+		whenCaseStmts = make([]string, len(changes))
+		whenCaseArgs  = make([][]interface{}, len(changes))
+		whereArgsLen  int
+	)
+	var whereBuf strings.Builder
+	for i, c := range changes {
+		whereBuf.Reset()
+		whereBuf.Grow(128)
+		whenCaseArgs[i] = c.genWhere(&whereBuf)
+		whenCaseStmts[i] = whereBuf.String()
+		whereArgsLen += len(whenCaseArgs[i])
+	}
+
+	// Generate `ColumnName`=CASE WHEN .. THEN .. END
+	columns := first.ChangedColumns()
+	for i, idx := range columns {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(quotes.QuoteName(first.sourceTableInfo.Columns[idx].Name.O) + "=CASE")
+		for j := range changes {
+			buf.WriteString(" WHEN ")
+			buf.WriteString(whenCaseStmts[j])
+			buf.WriteString(" THEN ?")
+		}
+		buf.WriteString(" END")
+	}
+
+	// Generate WHERE (...) OR (...)
+	buf.WriteString(" WHERE (")
+	for i, s := range whenCaseStmts {
+		if i > 0 {
+			buf.WriteString(") OR (")
+		}
+		buf.WriteString(s)
+	}
+	buf.WriteString(")")
+
+	// Build args of the UPDATE SQL
+	args := make([]any, 0, (whereArgsLen+len(changes))*len(columns)+whereArgsLen)
+	for _, idx := range columns {
+		for j, change := range changes {
+			args = append(args, whenCaseArgs[j]...)
+			args = append(args, change.postValues[idx])
+		}
+	}
+	for _, whereArgs := range whenCaseArgs {
+		args = append(args, whereArgs...)
+	}
+	return buf.String(), args
+}
+
 // GenInsertSQL generates the INSERT SQL and its arguments.
 // Input `changes` should have same target table and same modifiable columns,
 // otherwise the behaviour is undefined.
@@ -62,6 +62,34 @@ func TestGenUpdateMultiRowsWithStoredGeneratedColumn(t *testing.T) {
 	testGenUpdateMultiRowsWithStoredGeneratedColumn(t, GenUpdateSQL)
 }
 
+func TestGenUpdateChangedColumns(t *testing.T) {
+	t.Parallel()
+
+	// All columns are changed.
+	testGenUpdateMultiRows(t, GenUpdateChangedColumnsSQL)
+	testGenUpdateMultiRowsOneColPK(t, GenUpdateChangedColumnsSQL)
+	testGenUpdateMultiRowsWithVirtualGeneratedColumn(t, GenUpdateChangedColumnsSQL)
+	testGenUpdateMultiRowsWithVirtualGeneratedColumns(t, GenUpdateChangedColumnsSQL)
+	testGenUpdateMultiRowsWithStoredGeneratedColumn(t, GenUpdateChangedColumnsSQL)
+
+	source := &cdcmodel.TableName{Schema: "db", Table: "tb"}
+	ti := mockTableInfo(t, "CREATE TABLE tb (c INT PRIMARY KEY, c1 INT AS (c+100), c2 INT, c3 VARCHAR(10))")
+
+	change1 := NewRowChange(source, nil, []interface{}{1, 101, 2, "a"}, []interface{}{1, 101, 20, "a"}, ti, nil, nil)
+	change2 := NewRowChange(source, nil, []interface{}{4, 104, 5, "b"}, []interface{}{4, 104, 50, "b"}, ti, nil, nil)
+	require.Equal(t, []int{2}, change1.ChangedColumns())
+	require.Equal(t, change1.ChangedColumns(), change2.ChangedColumns())
+	sql, args := GenUpdateChangedColumnsSQL(change1, change2)
+	require.Equal(t, "UPDATE `db`.`tb` SET "+
+		"`c2`=CASE WHEN `c` = ? THEN ? WHEN `c` = ? THEN ? END "+
+		"WHERE (`c` = ?) OR (`c` = ?)", sql)
+	require.Equal(t, []interface{}{1, 20, 4, 50, 1, 4}, args)
+
+	// No column is changed, all columns are updated.
+	change3 := NewRowChange(source, nil, []interface{}{1, 101, 2, "a"}, []interface{}{1, 101, 2, "a"}, ti, nil, nil)
+	require.Equal(t, []int{0, 2, 3}, change3.ChangedColumns())
+}
+
 func testGenUpdateMultiRows(t *testing.T, genUpdate genSQLFunc) {
 	source1 := &cdcmodel.TableName{Schema: "db", Table: "tb1"}
 	source2 := &cdcmodel.TableName{Schema: "db", Table: "tb2"}
@@ -15,6 +15,7 @@ package sqlmodel
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/pingcap/failpoint"
@@ -330,6 +331,28 @@ func (r *RowChange) genUpdateSQL() (string, []interface{}) {
 	return buf.String(), args
 }
 
+// ChangedColumns returns the indexes of source columns whose values are
+// changed by the UPDATE, generated columns are excluded. If the pre-image is
+// unknown or no column is changed, all columns are returned.
+func (r *RowChange) ChangedColumns() []int {
+	all := make([]int, 0, len(r.sourceTableInfo.Columns))
+	changed := make([]int, 0, len(r.sourceTableInfo.Columns))
+	for i, col := range r.sourceTableInfo.Columns {
+		if isGenerated(r.targetTableInfo.Columns, col.Name) {
+			continue
+		}
+		all = append(all, i)
+		if i >= len(r.preValues) || i >= len(r.postValues) ||
+			!reflect.DeepEqual(r.preValues[i], r.postValues[i]) {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return all
+	}
+	return changed
+}
+
 func (r *RowChange) genInsertSQL(tp DMLType) (string, []interface{}) {
 	return GenInsertSQL(tp, r)
 }
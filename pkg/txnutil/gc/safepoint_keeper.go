@@ -0,0 +1,219 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/zap"
+)
+
+// MaxSafePointLeaseTTL is the max TTL of a safepoint lease, a lease must be
+// renewed before it expires.
+const MaxSafePointLeaseTTL = 24 * time.Hour
+
+// SafePointLease is an auxiliary service GC safepoint registered by an
+// external tool, such as BR or Dumpling, on behalf of a changefeed.
+type SafePointLease struct {
+	ChangefeedID model.ChangeFeedID
+	ServiceID    string
+	SafePoint    uint64
+	TTL          time.Duration
+	ExpireAt     time.Time
+}
+
+// SafePointKeeper manages auxiliary service GC safepoints of changefeeds.
+// The TTL of a lease is enforced by PD, so a lease expires even if TiCDC
+// crashes. The keeper only remembers leases to renew and clean them up.
+type SafePointKeeper struct {
+	pdClient          pd.Client
+	gcServiceIDPrefix string
+	clock             clock.Clock
+
+	mu     sync.Mutex
+	leases map[model.ChangeFeedID]map[string]*SafePointLease
+}
+
+// NewSafePointKeeper creates a SafePointKeeper. The service safepoints in PD
+// are prefixed with gcServiceIDPrefix.
+func NewSafePointKeeper(gcServiceIDPrefix string, pdClient pd.Client) *SafePointKeeper {
+	return &SafePointKeeper{
+		pdClient:          pdClient,
+		gcServiceIDPrefix: gcServiceIDPrefix,
+		clock:             clock.New(),
+		leases:            make(map[model.ChangeFeedID]map[string]*SafePointLease),
+	}
+}
+
+// Register registers or updates the safepoint lease of a service.
+func (k *SafePointKeeper) Register(
+	ctx context.Context, changefeedID model.ChangeFeedID,
+	serviceID string, safePoint uint64, ttl time.Duration,
+) (*SafePointLease, error) {
+	if serviceID == "" {
+		return nil, cerrors.ErrInvalidSafePointLease.GenWithStackByArgs("empty service id")
+	}
+	if safePoint == 0 {
+		return nil, cerrors.ErrInvalidSafePointLease.GenWithStackByArgs("empty safepoint")
+	}
+	if ttl < time.Second || ttl > MaxSafePointLeaseTTL {
+		return nil, cerrors.ErrInvalidSafePointLease.GenWithStackByArgs(
+			"ttl must be in [1s, " + MaxSafePointLeaseTTL.String() + "]")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	minServiceGCTs, err := SetServiceGCSafepoint(ctx, k.pdClient,
+		k.pdServiceID(changefeedID, serviceID), int64(ttl/time.Second), safePoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// PD does not update the safepoint if it is earlier than the GC safepoint.
+	if safePoint < minServiceGCTs {
+		return nil, cerrors.ErrSafePointBeforeGC.GenWithStackByArgs(safePoint, minServiceGCTs)
+	}
+
+	lease := &SafePointLease{
+		ChangefeedID: changefeedID,
+		ServiceID:    serviceID,
+		SafePoint:    safePoint,
+		TTL:          ttl,
+		ExpireAt:     k.clock.Now().Add(ttl),
+	}
+	if _, ok := k.leases[changefeedID]; !ok {
+		k.leases[changefeedID] = make(map[string]*SafePointLease)
+	}
+	k.leases[changefeedID][serviceID] = lease
+	log.Info("safepoint lease registered",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.String("serviceID", serviceID),
+		zap.Uint64("safePoint", safePoint),
+		zap.Duration("ttl", ttl))
+	return lease, nil
+}
+
+// Renew renews the safepoint lease of a service with a new TTL.
+func (k *SafePointKeeper) Renew(
+	ctx context.Context, changefeedID model.ChangeFeedID,
+	serviceID string, ttl time.Duration,
+) (*SafePointLease, error) {
+	lease, ok := k.get(changefeedID, serviceID)
+	if !ok {
+		return nil, cerrors.ErrSafePointLeaseNotFound.GenWithStackByArgs(
+			serviceID, changefeedID.String())
+	}
+	return k.Register(ctx, changefeedID, serviceID, lease.SafePoint, ttl)
+}
+
+// Remove removes the safepoint lease of a service.
+func (k *SafePointKeeper) Remove(
+	ctx context.Context, changefeedID model.ChangeFeedID, serviceID string,
+) error {
+	if _, ok := k.get(changefeedID, serviceID); !ok {
+		return cerrors.ErrSafePointLeaseNotFound.GenWithStackByArgs(
+			serviceID, changefeedID.String())
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.removeLocked(ctx, changefeedID, serviceID)
+}
+
+// RemoveChangefeed removes all safepoint leases of a changefeed, it should
+// be called when the changefeed is removed.
+func (k *SafePointKeeper) RemoveChangefeed(
+	ctx context.Context, changefeedID model.ChangeFeedID,
+) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for serviceID := range k.leases[changefeedID] {
+		if err := k.removeLocked(ctx, changefeedID, serviceID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// List returns all unexpired safepoint leases of a changefeed, sorted by
+// service id.
+func (k *SafePointKeeper) List(changefeedID model.ChangeFeedID) []*SafePointLease {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.gcExpiredLocked(changefeedID)
+	leases := make([]*SafePointLease, 0, len(k.leases[changefeedID]))
+	for _, lease := range k.leases[changefeedID] {
+		leases = append(leases, lease)
+	}
+	sort.Slice(leases, func(i, j int) bool {
+		return leases[i].ServiceID < leases[j].ServiceID
+	})
+	return leases
+}
+
+func (k *SafePointKeeper) get(
+	changefeedID model.ChangeFeedID, serviceID string,
+) (*SafePointLease, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.gcExpiredLocked(changefeedID)
+	lease, ok := k.leases[changefeedID][serviceID]
+	return lease, ok
+}
+
+func (k *SafePointKeeper) removeLocked(
+	ctx context.Context, changefeedID model.ChangeFeedID, serviceID string,
+) error {
+	err := RemoveServiceGCSafepoint(ctx, k.pdClient, k.pdServiceID(changefeedID, serviceID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	delete(k.leases[changefeedID], serviceID)
+	if len(k.leases[changefeedID]) == 0 {
+		delete(k.leases, changefeedID)
+	}
+	log.Info("safepoint lease removed",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.String("serviceID", serviceID))
+	return nil
+}
+
+// gcExpiredLocked forgets expired leases, PD has already dropped them.
+func (k *SafePointKeeper) gcExpiredLocked(changefeedID model.ChangeFeedID) {
+	now := k.clock.Now()
+	for serviceID, lease := range k.leases[changefeedID] {
+		if !now.Before(lease.ExpireAt) {
+			delete(k.leases[changefeedID], serviceID)
+		}
+	}
+	if len(k.leases[changefeedID]) == 0 {
+		delete(k.leases, changefeedID)
+	}
+}
+
+func (k *SafePointKeeper) pdServiceID(
+	changefeedID model.ChangeFeedID, serviceID string,
+) string {
+	return k.gcServiceIDPrefix + "-aux-" + changefeedID.Namespace + "_" +
+		changefeedID.ID + "_" + serviceID
+}
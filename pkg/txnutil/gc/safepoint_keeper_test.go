@@ -0,0 +1,126 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServiceSafePoints records service safepoints like PD.
+type fakeServiceSafePoints struct {
+	gcSafePoint uint64
+	safePoints  map[string]uint64
+	ttls        map[string]int64
+}
+
+func (f *fakeServiceSafePoints) update(
+	_ context.Context, serviceID string, ttl int64, safePoint uint64,
+) (uint64, error) {
+	if ttl <= 0 {
+		delete(f.safePoints, serviceID)
+		delete(f.ttls, serviceID)
+	} else if safePoint >= f.gcSafePoint {
+		f.safePoints[serviceID] = safePoint
+		f.ttls[serviceID] = ttl
+	}
+	min := uint64(math.MaxUint64)
+	for _, ts := range f.safePoints {
+		if ts < min {
+			min = ts
+		}
+	}
+	if f.gcSafePoint < min {
+		min = f.gcSafePoint
+	}
+	return min, nil
+}
+
+func TestSafePointKeeper(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake := &fakeServiceSafePoints{
+		gcSafePoint: 10,
+		safePoints:  make(map[string]uint64),
+		ttls:        make(map[string]int64),
+	}
+	keeper := NewSafePointKeeper("ticdc-default", &MockPDClient{
+		UpdateServiceGCSafePointFunc: fake.update,
+	})
+	mockClock := clock.NewMock()
+	keeper.clock = mockClock
+	cf1 := model.DefaultChangeFeedID("cf1")
+	cf2 := model.DefaultChangeFeedID("cf2")
+
+	// invalid leases
+	_, err := keeper.Register(ctx, cf1, "", 100, time.Minute)
+	require.True(t, cerrors.ErrInvalidSafePointLease.Equal(err))
+	_, err = keeper.Register(ctx, cf1, "br", 100, 0)
+	require.True(t, cerrors.ErrInvalidSafePointLease.Equal(err))
+	_, err = keeper.Register(ctx, cf1, "br", 100, 2*MaxSafePointLeaseTTL)
+	require.True(t, cerrors.ErrInvalidSafePointLease.Equal(err))
+	_, err = keeper.Register(ctx, cf1, "br", 5, time.Minute)
+	require.True(t, cerrors.ErrSafePointBeforeGC.Equal(err))
+	require.Empty(t, keeper.List(cf1))
+
+	lease, err := keeper.Register(ctx, cf1, "br", 100, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, mockClock.Now().Add(time.Minute), lease.ExpireAt)
+	require.Equal(t, uint64(100), fake.safePoints["ticdc-default-aux-default_cf1_br"])
+	require.Equal(t, int64(60), fake.ttls["ticdc-default-aux-default_cf1_br"])
+	_, err = keeper.Register(ctx, cf1, "dumpling", 200, time.Hour)
+	require.Nil(t, err)
+	_, err = keeper.Register(ctx, cf2, "br", 300, time.Hour)
+	require.Nil(t, err)
+	leases := keeper.List(cf1)
+	require.Len(t, leases, 2)
+	require.Equal(t, "br", leases[0].ServiceID)
+	require.Equal(t, "dumpling", leases[1].ServiceID)
+
+	// renew keeps the safepoint and extends the ttl.
+	mockClock.Add(30 * time.Second)
+	lease, err = keeper.Renew(ctx, cf1, "br", 2*time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, uint64(100), lease.SafePoint)
+	require.Equal(t, mockClock.Now().Add(2*time.Minute), lease.ExpireAt)
+	require.Equal(t, int64(120), fake.ttls["ticdc-default-aux-default_cf1_br"])
+
+	// expired leases can not be renewed.
+	mockClock.Add(3 * time.Minute)
+	_, err = keeper.Renew(ctx, cf1, "br", time.Minute)
+	require.True(t, cerrors.ErrSafePointLeaseNotFound.Equal(err))
+	require.Len(t, keeper.List(cf1), 1)
+
+	// remove a lease
+	require.Nil(t, keeper.Remove(ctx, cf1, "dumpling"))
+	require.NotContains(t, fake.safePoints, "ticdc-default-aux-default_cf1_dumpling")
+	require.True(t, cerrors.ErrSafePointLeaseNotFound.Equal(keeper.Remove(ctx, cf1, "dumpling")))
+	require.Empty(t, keeper.List(cf1))
+
+	// remove all leases of a changefeed
+	_, err = keeper.Register(ctx, cf2, "dumpling", 300, time.Hour)
+	require.Nil(t, err)
+	require.Nil(t, keeper.RemoveChangefeed(ctx, cf2))
+	require.Empty(t, keeper.List(cf2))
+	require.NotContains(t, fake.safePoints, "ticdc-default-aux-default_cf2_br")
+	require.NotContains(t, fake.safePoints, "ticdc-default-aux-default_cf2_dumpling")
+}
@@ -16,6 +16,7 @@ package upstream
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -27,8 +28,10 @@ import (
 	tidbkv "github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/etcd"
 	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/version"
 	"github.com/tikv/client-go/v2/tikv"
@@ -52,12 +55,15 @@ const (
 	maxIdleDuration = time.Minute * 30
 )
 
+// pdEndpointsRefreshInterval is the interval to refresh the PD endpoints from
+// the PD membership.
+var pdEndpointsRefreshInterval = 30 * time.Second
+
 // Upstream holds resources of a TiDB cluster, it can be shared by many changefeeds
 // and processors. All public fields and method of an upstream should be thread-safe.
 // Please be careful that never change any exported field of an Upstream.
 type Upstream struct {
 	ID             uint64
-	PdEndpoints    []string
 	SecurityConfig *config.SecurityConfig
 
 	PDClient    pd.Client
@@ -65,7 +71,11 @@ type Upstream struct {
 	GrpcPool    kv.GrpcPool
 	RegionCache *tikv.RegionCache
 	PDClock     pdutil.Clock
-	GCManager   gc.Manager
+	// TSOClient batches and caches TSO requests of all changefeeds.
+	TSOClient pdutil.TSOClient
+	GCManager gc.Manager
+	// SafePointKeeper manages auxiliary GC safepoints of changefeeds.
+	SafePointKeeper *gc.SafePointKeeper
 	// Only use in Close().
 	cancel func()
 	mu     sync.Mutex
@@ -78,15 +88,32 @@ type Upstream struct {
 
 	err               uatomic.Error
 	isDefaultUpstream bool
+
+	// pdEndpoints are refreshed from the PD membership periodically, so that
+	// new PD clients can connect to the cluster after the PD members given on
+	// startup are replaced.
+	pdEndpoints struct {
+		sync.RWMutex
+		v []string
+	}
+
+	// keyspaceStorages are storages scoped to keyspaces of the upstream,
+	// they are created on demand and shared by changefeeds of a keyspace.
+	keyspaceStorages struct {
+		sync.Mutex
+		m map[string]tidbkv.Storage
+	}
 }
 
 func newUpstream(pdEndpoints []string,
 	securityConfig *config.SecurityConfig,
 ) *Upstream {
-	return &Upstream{
-		PdEndpoints: pdEndpoints, status: uninit,
-		SecurityConfig: securityConfig, wg: new(sync.WaitGroup), clock: clock.New(),
+	up := &Upstream{
+		status: uninit, SecurityConfig: securityConfig,
+		wg: new(sync.WaitGroup), clock: clock.New(),
 	}
+	up.pdEndpoints.v = pdEndpoints
+	return up
 }
 
 // NewUpstream4Test new an upstream for unit test.
@@ -96,15 +123,17 @@ func NewUpstream4Test(pdClient pd.Client) *Upstream {
 		etcd.GcServiceIDForTest(),
 		pdClient, pdClock)
 	res := &Upstream{
-		ID:             testUpstreamID,
-		PDClient:       pdClient,
-		PDClock:        pdClock,
-		GCManager:      gcManager,
-		status:         normal,
-		wg:             new(sync.WaitGroup),
-		clock:          clock.New(),
-		SecurityConfig: &config.SecurityConfig{},
-		cancel:         func() {},
+		ID:              testUpstreamID,
+		PDClient:        pdClient,
+		PDClock:         pdClock,
+		TSOClient:       pdutil.NewTSOClient(pdClient),
+		GCManager:       gcManager,
+		SafePointKeeper: gc.NewSafePointKeeper(etcd.GcServiceIDForTest(), pdClient),
+		status:          normal,
+		wg:              new(sync.WaitGroup),
+		clock:           clock.New(),
+		SecurityConfig:  &config.SecurityConfig{},
+		cancel:          func() {},
 	}
 
 	return res
@@ -114,14 +143,19 @@ func NewUpstream4Test(pdClient pd.Client) *Upstream {
 func initUpstream(ctx context.Context, up *Upstream, gcServiceID string) error {
 	ctx, cancel := context.WithCancel(ctx)
 	up.cancel = cancel
-	grpcTLSOption, err := up.SecurityConfig.ToGRPCDialOption()
+	// The credential is reloaded once its files are changed, so that the
+	// certificates can be rotated without restarting the server.
+	credentialWatcher, err := security.NewCredentialWatcher(
+		up.SecurityConfig, security.DefaultCredentialReloadInterval)
 	if err != nil {
 		up.err.Store(err)
 		return errors.Trace(err)
 	}
+	grpcTLSOption := credentialWatcher.ToGRPCDialOption()
 
+	pdEndpoints := up.GetPDEndpoints()
 	up.PDClient, err = pd.NewClientWithContext(
-		ctx, up.PdEndpoints, up.SecurityConfig.PDSecurityOption(),
+		ctx, pdEndpoints, up.SecurityConfig.PDSecurityOption(),
 		// the default `timeout` is 3s, maybe too small if the pd is busy,
 		// set to 10s to avoid frequent timeout.
 		pd.WithCustomTimeoutOption(10*time.Second),
@@ -144,8 +178,11 @@ func initUpstream(ctx context.Context, up *Upstream, gcServiceID string) error {
 	}
 	clusterID := up.PDClient.GetClusterID(ctx)
 	if up.ID != 0 && up.ID != clusterID {
-		err := fmt.Errorf("upstream id missmatch expected %d, actual: %d",
-			up.ID, clusterID)
+		// The cluster is rebuilt, e.g. it's restored by BR into new PDs with
+		// the same endpoints.
+		err := cerror.ErrUpstreamRewound.GenWithStackByArgs(
+			fmt.Sprintf("cluster id of the upstream is changed from %d to %d",
+				up.ID, clusterID))
 		up.err.Store(err)
 		return errors.Trace(err)
 	}
@@ -155,30 +192,36 @@ func initUpstream(ctx context.Context, up *Upstream, gcServiceID string) error {
 	// when TiKV is incompatible.
 	errorTiKVIncompatible := false
 	err = version.CheckClusterVersion(ctx, up.PDClient,
-		up.PdEndpoints, up.SecurityConfig, errorTiKVIncompatible)
+		pdEndpoints, up.SecurityConfig, errorTiKVIncompatible)
 	if err != nil {
 		up.err.Store(err)
 		log.Error("init upstream error", zap.Error(err))
 		return errors.Trace(err)
 	}
 
-	up.KVStorage, err = kv.CreateTiStore(strings.Join(up.PdEndpoints, ","), up.SecurityConfig)
+	up.KVStorage, err = kv.CreateTiStore(strings.Join(pdEndpoints, ","), up.SecurityConfig)
 	if err != nil {
 		up.err.Store(err)
 		return errors.Trace(err)
 	}
 
-	up.GrpcPool = kv.NewGrpcPoolImpl(ctx, up.SecurityConfig)
+	grpcPool := kv.NewGrpcPoolImpl(ctx, up.SecurityConfig)
+	// Streams to TiKV are long-lived, re-establish connections so that new
+	// streams use the new credential.
+	credentialWatcher.OnChange(grpcPool.Rotate)
+	up.GrpcPool = grpcPool
 
 	up.RegionCache = tikv.NewRegionCache(up.PDClient)
 
-	up.PDClock, err = pdutil.NewClock(ctx, up.PDClient)
+	up.TSOClient = pdutil.NewTSOClient(up.PDClient)
+	up.PDClock, err = pdutil.NewClock(ctx, up.TSOClient)
 	if err != nil {
 		up.err.Store(err)
 		return errors.Trace(err)
 	}
 
 	up.GCManager = gc.NewManager(gcServiceID, up.PDClient, up.PDClock)
+	up.SafePointKeeper = gc.NewSafePointKeeper(gcServiceID, up.PDClient)
 
 	// Update meta-region label to ensure that meta region isolated from data regions.
 	pc, err := pdutil.NewPDAPIClient(up.PDClient, up.SecurityConfig)
@@ -193,7 +236,7 @@ func initUpstream(ctx context.Context, up *Upstream, gcServiceID string) error {
 		log.Warn("Fail to verify region label rule",
 			zap.Error(err),
 			zap.Uint64("upstreamID", up.ID),
-			zap.Strings("upstreamEndpoints", up.PdEndpoints))
+			zap.Strings("upstreamEndpoints", pdEndpoints))
 	}
 
 	up.wg.Add(1)
@@ -206,12 +249,103 @@ func initUpstream(ctx context.Context, up *Upstream, gcServiceID string) error {
 		defer up.wg.Done()
 		up.GrpcPool.RecycleConn(ctx)
 	}()
+	up.wg.Add(1)
+	go func() {
+		defer up.wg.Done()
+		_ = credentialWatcher.Run(ctx)
+	}()
+	up.wg.Add(1)
+	go func() {
+		defer up.wg.Done()
+		up.runPDEndpointsRefresher(ctx, pdEndpointsRefreshInterval)
+	}()
 
 	log.Info("upstream initialize successfully", zap.Uint64("upstreamID", up.ID))
 	atomic.StoreInt32(&up.status, normal)
 	return nil
 }
 
+// GetPDEndpoints returns the client urls of PD members of the upstream.
+func (up *Upstream) GetPDEndpoints() []string {
+	up.pdEndpoints.RLock()
+	defer up.pdEndpoints.RUnlock()
+	return append([]string(nil), up.pdEndpoints.v...)
+}
+
+func (up *Upstream) runPDEndpointsRefresher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			up.refreshPDEndpoints(ctx)
+		}
+	}
+}
+
+// refreshPDEndpoints updates the PD endpoints with the client urls of all PD
+// members. The PD client fails over to other members by itself, the endpoints
+// are used to create new clients, e.g. verifying a changefeed in the api.
+func (up *Upstream) refreshPDEndpoints(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	members, err := up.PDClient.GetAllMembers(ctx)
+	if err != nil {
+		log.Warn("failed to get pd members, pd endpoints are not refreshed",
+			zap.Uint64("upstreamID", up.ID), zap.Error(err))
+		return false
+	}
+	endpoints := make([]string, 0, len(members))
+	for _, m := range members {
+		if urls := m.GetClientUrls(); len(urls) > 0 {
+			endpoints = append(endpoints, urls[0])
+		}
+	}
+	if len(endpoints) == 0 {
+		return false
+	}
+	sort.Strings(endpoints)
+
+	up.pdEndpoints.Lock()
+	defer up.pdEndpoints.Unlock()
+	old := up.pdEndpoints.v
+	if strings.Join(old, ",") == strings.Join(endpoints, ",") {
+		return false
+	}
+	up.pdEndpoints.v = endpoints
+	log.Info("pd endpoints are refreshed",
+		zap.Uint64("upstreamID", up.ID),
+		zap.Strings("old", old), zap.Strings("new", endpoints))
+	return true
+}
+
+// GetKVStorage returns the storage scoped to the keyspace, or the storage of
+// the whole cluster if the keyspace is empty.
+func (up *Upstream) GetKVStorage(keyspace string) (tidbkv.Storage, error) {
+	if keyspace == "" {
+		return up.KVStorage, nil
+	}
+	up.keyspaceStorages.Lock()
+	defer up.keyspaceStorages.Unlock()
+	if storage, ok := up.keyspaceStorages.m[keyspace]; ok {
+		return storage, nil
+	}
+	storage, err := kv.CreateKeyspaceStore(
+		up.KVStorage, up.PDClient, up.GetPDEndpoints(), up.SecurityConfig, keyspace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if up.keyspaceStorages.m == nil {
+		up.keyspaceStorages.m = make(map[string]tidbkv.Storage)
+	}
+	up.keyspaceStorages.m[keyspace] = storage
+	log.Info("keyspace kv store created",
+		zap.Uint64("upstreamID", up.ID), zap.String("keyspace", keyspace))
+	return storage, nil
+}
+
 // Close all resources.
 func (up *Upstream) Close() {
 	up.mu.Lock()
@@ -223,6 +357,16 @@ func (up *Upstream) Close() {
 	}
 	atomic.StoreInt32(&up.status, closing)
 
+	up.keyspaceStorages.Lock()
+	for keyspace, storage := range up.keyspaceStorages.m {
+		if err := storage.Close(); err != nil {
+			log.Warn("keyspace kv store close failed",
+				zap.String("keyspace", keyspace), zap.Error(err))
+		}
+	}
+	up.keyspaceStorages.m = nil
+	up.keyspaceStorages.Unlock()
+
 	if up.PDClient != nil {
 		up.PDClient.Close()
 	}
@@ -14,11 +14,14 @@
 package upstream
 
 import (
+	"context"
 	"testing"
 
 	"github.com/benbjohnson/clock"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
 )
 
 func TestUpstreamShouldClose(t *testing.T) {
@@ -66,3 +69,40 @@ func TestTrySetIdleTime(t *testing.T) {
 	up.resetIdleTime()
 	require.True(t, up.idleTime.IsZero())
 }
+
+type mockMembersPDClient struct {
+	pd.Client
+	members []*pdpb.Member
+	err     error
+}
+
+func (m *mockMembersPDClient) GetAllMembers(context.Context) ([]*pdpb.Member, error) {
+	return m.members, m.err
+}
+
+func TestRefreshPDEndpoints(t *testing.T) {
+	pdClient := &mockMembersPDClient{}
+	up := newUpstream([]string{"http://pd1:2379"}, nil)
+	up.PDClient = pdClient
+	ctx := context.Background()
+
+	// The endpoints are kept if pd members are not available.
+	pdClient.err = errors.New("pd is unavailable")
+	require.False(t, up.refreshPDEndpoints(ctx))
+	pdClient.err = nil
+	require.False(t, up.refreshPDEndpoints(ctx))
+	require.Equal(t, []string{"http://pd1:2379"}, up.GetPDEndpoints())
+
+	pdClient.members = []*pdpb.Member{
+		{Name: "pd3", ClientUrls: []string{"http://pd3:2379"}},
+		{Name: "pd2", ClientUrls: []string{"http://pd2:2379", "http://pd2-2:2379"}},
+		{Name: "pd4"},
+	}
+	require.True(t, up.refreshPDEndpoints(ctx))
+	require.Equal(t, []string{"http://pd2:2379", "http://pd3:2379"}, up.GetPDEndpoints())
+	require.False(t, up.refreshPDEndpoints(ctx))
+
+	// The returned endpoints are copies.
+	up.GetPDEndpoints()[0] = "http://pd5:2379"
+	require.Equal(t, []string{"http://pd2:2379", "http://pd3:2379"}, up.GetPDEndpoints())
+}
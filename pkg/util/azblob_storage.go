@@ -0,0 +1,368 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	// azblobManagedIdentityParam enables authenticating to Azure Blob Storage
+	// with the managed identity of the host, e.g. an Azure VM or an AKS pod.
+	azblobManagedIdentityParam = "use-managed-identity"
+	// azblobManagedIdentityClientIDParam is the client ID of a user-assigned
+	// managed identity. The system-assigned identity is used if it's empty.
+	azblobManagedIdentityClientIDParam = "managed-identity-client-id"
+
+	azblobRetryTimes = 5
+)
+
+// azblobManagedIdentityOptions is the options to access Azure Blob Storage
+// with a managed identity.
+type azblobManagedIdentityOptions struct {
+	container   string
+	prefix      string
+	accountName string
+	endpoint    string
+	accessTier  string
+	clientID    string
+}
+
+// parseAzblobManagedIdentityOptions returns the managed identity options of
+// an Azure Blob Storage uri. False is returned if the uri is not an Azure Blob
+// Storage uri or the managed identity is not enabled.
+func parseAzblobManagedIdentityOptions(uri string) (*azblobManagedIdentityOptions, bool, error) {
+	u, err := storage.ParseRawURL(uri)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "azure" && scheme != "azblob" {
+		return nil, false, nil
+	}
+	query := u.Query()
+	if enabled := query.Get(azblobManagedIdentityParam); enabled == "" {
+		return nil, false, nil
+	} else if ok, err := strconv.ParseBool(enabled); err != nil {
+		return nil, false, errors.Annotatef(err, "invalid %s", azblobManagedIdentityParam)
+	} else if !ok {
+		return nil, false, nil
+	}
+
+	opts := &azblobManagedIdentityOptions{
+		container:   u.Host,
+		prefix:      strings.Trim(u.Path, "/"),
+		accountName: query.Get("account-name"),
+		endpoint:    query.Get("endpoint"),
+		accessTier:  query.Get("access-tier"),
+		clientID:    query.Get(azblobManagedIdentityClientIDParam),
+	}
+	if opts.container == "" {
+		return nil, false, errors.New("bucket(container) cannot be empty to access azure blob storage")
+	}
+	if opts.accountName == "" {
+		opts.accountName = os.Getenv("AZURE_STORAGE_ACCOUNT")
+	}
+	if opts.accountName == "" && opts.endpoint == "" {
+		return nil, false, errors.New("account name cannot be empty to access azure blob storage")
+	}
+	if opts.endpoint == "" {
+		opts.endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", opts.accountName)
+	}
+	return opts, true, nil
+}
+
+// azblobStorage is an Azure Blob Storage which authenticates with the
+// managed identity of the host.
+type azblobStorage struct {
+	opts            *azblobManagedIdentityOptions
+	containerClient azblob.ContainerClient
+	accessTier      azblob.AccessTier
+}
+
+func newAzblobStorageWithManagedIdentity(
+	ctx context.Context, opts *azblobManagedIdentityOptions,
+) (*azblobStorage, error) {
+	credOpts := &azidentity.ManagedIdentityCredentialOptions{}
+	if opts.clientID != "" {
+		credOpts.ID = azidentity.ClientID(opts.clientID)
+	}
+	cred, err := azidentity.NewManagedIdentityCredential(credOpts)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to get azure managed identity credential")
+	}
+	serviceClient, err := azblob.NewServiceClient(opts.endpoint, cred, &azblob.ClientOptions{
+		Retry: policy.RetryOptions{MaxRetries: azblobRetryTimes},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to create azure service client")
+	}
+
+	containerClient := serviceClient.NewContainerClient(opts.container)
+	if _, err = containerClient.Create(ctx, nil); err != nil {
+		var errResp *azblob.StorageError
+		if internalErr, ok := err.(*azblob.InternalError); !(ok && internalErr.As(&errResp)) ||
+			errResp.ErrorCode != azblob.StorageErrorCodeContainerAlreadyExists {
+			return nil, errors.Annotate(err, "failed to create the container")
+		}
+	}
+	log.Info("azure blob storage is accessed with managed identity",
+		zap.String("endpoint", opts.endpoint),
+		zap.String("container", opts.container),
+		zap.Bool("userAssigned", opts.clientID != ""))
+
+	return &azblobStorage{
+		opts:            opts,
+		containerClient: containerClient,
+		accessTier:      parseAzblobAccessTier(opts.accessTier),
+	}, nil
+}
+
+func parseAzblobAccessTier(tier string) azblob.AccessTier {
+	switch strings.ToLower(tier) {
+	case "archive":
+		return azblob.AccessTierArchive
+	case "cool":
+		return azblob.AccessTierCool
+	case "hot":
+		return azblob.AccessTierHot
+	default:
+		return azblob.AccessTier(tier)
+	}
+}
+
+func (s *azblobStorage) withPrefix(name string) string {
+	return path.Join(s.opts.prefix, name)
+}
+
+// WriteFile implements storage.ExternalStorage.
+func (s *azblobStorage) WriteFile(ctx context.Context, name string, data []byte) error {
+	client := s.containerClient.NewBlockBlobClient(s.withPrefix(name))
+	resp, err := client.UploadBufferToBlockBlob(ctx, data,
+		azblob.HighLevelUploadToBlockBlobOption{AccessTier: &s.accessTier})
+	if err != nil {
+		return errors.Annotatef(err, "failed to write azure blob file %s", s.withPrefix(name))
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// ReadFile implements storage.ExternalStorage.
+func (s *azblobStorage) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	client := s.containerClient.NewBlockBlobClient(s.withPrefix(name))
+	resp, err := client.Download(ctx, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to download azure blob file %s", s.withPrefix(name))
+	}
+	defer resp.RawResponse.Body.Close()
+	data, err := io.ReadAll(resp.Body(azblob.RetryReaderOptions{MaxRetryRequests: azblobRetryTimes}))
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to read azure blob file %s", s.withPrefix(name))
+	}
+	return data, nil
+}
+
+// FileExists implements storage.ExternalStorage.
+func (s *azblobStorage) FileExists(ctx context.Context, name string) (bool, error) {
+	client := s.containerClient.NewBlockBlobClient(s.withPrefix(name))
+	if _, err := client.GetProperties(ctx, nil); err != nil {
+		var errResp *azblob.StorageError
+		if internalErr, ok := err.(*azblob.InternalError); ok && internalErr.As(&errResp) &&
+			errResp.ErrorCode == azblob.StorageErrorCodeBlobNotFound {
+			return false, nil
+		}
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// DeleteFile implements storage.ExternalStorage.
+func (s *azblobStorage) DeleteFile(ctx context.Context, name string) error {
+	client := s.containerClient.NewBlockBlobClient(s.withPrefix(name))
+	if _, err := client.Delete(ctx, nil); err != nil {
+		return errors.Annotatef(err, "failed to delete azure blob file %s", s.withPrefix(name))
+	}
+	return nil
+}
+
+// Open implements storage.ExternalStorage.
+func (s *azblobStorage) Open(ctx context.Context, name string) (storage.ExternalFileReader, error) {
+	return &azblobReader{
+		ctx:        ctx,
+		blobClient: s.containerClient.NewBlockBlobClient(s.withPrefix(name)),
+	}, nil
+}
+
+// WalkDir implements storage.ExternalStorage. Blobs are listed with a flat
+// listing of the prefix, so there is no request per virtual directory.
+func (s *azblobStorage) WalkDir(
+	ctx context.Context, opt *storage.WalkOption, fn func(path string, size int64) error,
+) error {
+	if opt == nil {
+		opt = &storage.WalkOption{}
+	}
+	prefix := path.Join(s.opts.prefix, opt.SubDir)
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	prefix += opt.ObjPrefix
+
+	listOption := &azblob.ContainerListBlobFlatSegmentOptions{Prefix: &prefix}
+	for {
+		pager := s.containerClient.ListBlobsFlat(listOption)
+		if !pager.NextPage(ctx) {
+			if err := pager.Err(); err != nil {
+				return errors.Annotatef(err, "failed to list azure blobs in %s", s.opts.container)
+			}
+			return nil
+		}
+		for _, blob := range pager.PageResponse().Segment.BlobItems {
+			name := strings.TrimPrefix(*blob.Name, s.opts.prefix)
+			name = strings.TrimPrefix(name, "/")
+			if err := fn(name, *blob.Properties.ContentLength); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		listOption.Marker = pager.PageResponse().NextMarker
+		if listOption.Marker == nil || len(*listOption.Marker) == 0 {
+			return nil
+		}
+	}
+}
+
+// URI implements storage.ExternalStorage.
+func (s *azblobStorage) URI() string {
+	return "azure://" + s.opts.container + "/" + s.opts.prefix
+}
+
+// Create implements storage.ExternalStorage. Data is uploaded as staged
+// blocks, which are committed when the writer is closed.
+func (s *azblobStorage) Create(_ context.Context, name string) (storage.ExternalFileWriter, error) {
+	uploader := &azblobUploader{
+		blobClient: s.containerClient.NewBlockBlobClient(s.withPrefix(name)),
+		accessTier: s.accessTier,
+	}
+	return storage.NewUploaderWriter(uploader, azblob.BlockBlobMaxUploadBlobBytes, storage.NoCompression), nil
+}
+
+// Rename implements storage.ExternalStorage.
+func (s *azblobStorage) Rename(ctx context.Context, oldFileName, newFileName string) error {
+	data, err := s.ReadFile(ctx, oldFileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.WriteFile(ctx, newFileName, data); err != nil {
+		return errors.Trace(err)
+	}
+	return s.DeleteFile(ctx, oldFileName)
+}
+
+type azblobReader struct {
+	ctx        context.Context
+	blobClient azblob.BlockBlobClient
+	pos        int64
+}
+
+// Read implements io.Reader.
+func (r *azblobReader) Read(p []byte) (int, error) {
+	count := int64(len(p))
+	resp, err := r.blobClient.Download(r.ctx, &azblob.DownloadBlobOptions{Offset: &r.pos, Count: &count})
+	if err != nil {
+		return 0, errors.Annotatef(err, "failed to read azure blob at %d", r.pos)
+	}
+	n, err := resp.Body(azblob.RetryReaderOptions{}).Read(p)
+	if err != nil && err != io.EOF {
+		return 0, errors.Annotatef(err, "failed to read azure blob at %d", r.pos)
+	}
+	r.pos += int64(n)
+	return n, nil
+}
+
+// Seek implements io.Seeker.
+func (r *azblobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		r.pos = offset
+	case io.SeekCurrent:
+		r.pos += offset
+	case io.SeekEnd:
+		resp, err := r.blobClient.GetProperties(r.ctx, nil)
+		if err != nil {
+			return 0, errors.Annotate(err, "failed to get properties of the azure blob")
+		}
+		r.pos = *resp.ContentLength + offset
+	default:
+		return 0, errors.Errorf("invalid whence %d", whence)
+	}
+	if r.pos < 0 {
+		return 0, errors.Errorf("invalid seek offset %d", r.pos)
+	}
+	return r.pos, nil
+}
+
+// Close implements io.Closer.
+func (*azblobReader) Close() error {
+	return nil
+}
+
+type azblobUploader struct {
+	blobClient  azblob.BlockBlobClient
+	blockIDList []string
+	accessTier  azblob.AccessTier
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+// Write implements storage.ExternalFileWriter.
+func (u *azblobUploader) Write(ctx context.Context, data []byte) (int, error) {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	blockID := base64.StdEncoding.EncodeToString([]byte(id.String()))
+	_, err = u.blobClient.StageBlock(ctx, blockID, nopReadSeekCloser{bytes.NewReader(data)}, nil)
+	if err != nil {
+		return 0, errors.Annotate(err, "failed to upload block to azure blob")
+	}
+	u.blockIDList = append(u.blockIDList, blockID)
+	return len(data), nil
+}
+
+// Close implements storage.ExternalFileWriter.
+func (u *azblobUploader) Close(ctx context.Context) error {
+	_, err := u.blobClient.CommitBlockList(ctx, u.blockIDList,
+		&azblob.CommitBlockListOptions{Tier: &u.accessTier})
+	return errors.Trace(err)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAzblobManagedIdentityOptions(t *testing.T) {
+	t.Setenv("AZURE_STORAGE_ACCOUNT", "")
+
+	// Not azure blob storage or managed identity is not enabled.
+	for _, uri := range []string{
+		"s3://bucket/prefix?use-managed-identity=true",
+		"gcs://bucket/prefix",
+		"azure://container/prefix?account-name=test",
+		"azure://container/prefix?account-name=test&use-managed-identity=false",
+	} {
+		_, ok, err := parseAzblobManagedIdentityOptions(uri)
+		require.NoError(t, err)
+		require.False(t, ok, uri)
+	}
+
+	opts, ok, err := parseAzblobManagedIdentityOptions(
+		"azblob://container/redo/cf1?account-name=test&use-managed-identity=true&access-tier=Cool")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, &azblobManagedIdentityOptions{
+		container:   "container",
+		prefix:      "redo/cf1",
+		accountName: "test",
+		endpoint:    "https://test.blob.core.windows.net",
+		accessTier:  "Cool",
+	}, opts)
+	require.Equal(t, azblob.AccessTierCool, parseAzblobAccessTier(opts.accessTier))
+
+	// The account name can be read from the environment.
+	t.Setenv("AZURE_STORAGE_ACCOUNT", "env")
+	opts, ok, err = parseAzblobManagedIdentityOptions(
+		"azure://container?use-managed-identity=1&managed-identity-client-id=abc")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "https://env.blob.core.windows.net", opts.endpoint)
+	require.Equal(t, "abc", opts.clientID)
+
+	// invalid uris
+	t.Setenv("AZURE_STORAGE_ACCOUNT", "")
+	_, _, err = parseAzblobManagedIdentityOptions("azure://container?use-managed-identity=x")
+	require.Error(t, err)
+	_, _, err = parseAzblobManagedIdentityOptions("azure://container?use-managed-identity=true")
+	require.Regexp(t, "account name cannot be empty", err)
+	_, _, err = parseAzblobManagedIdentityOptions("azure:///prefix?use-managed-identity=true&account-name=a")
+	require.Regexp(t, "container", err)
+}
@@ -57,11 +57,30 @@ func GetExternalStorageWithTimeout(
 }
 
 // GetExternalStorage creates a new storage.ExternalStorage based on the uri and options.
+// GCS uses the application default credentials, e.g. the GKE workload identity,
+// if no credentials-file is given, and Azure Blob Storage uses the managed
+// identity of the host if use-managed-identity is set. Local and NFS storages
+// can be tuned by fsync-policy, fsync-interval, preallocate,
+// min-disk-avail-percentage and disk-full-action.
 func GetExternalStorage(
 	ctx context.Context, uri string,
 	opts *storage.BackendOptions,
 	retryer request.Retryer,
 ) (storage.ExternalStorage, error) {
+	// Azure Blob Storage with managed identity is not supported by
+	// storage.New, so it is created separately.
+	if azblobOpts, ok, err := parseAzblobManagedIdentityOptions(uri); err != nil {
+		return nil, errors.ErrFailToCreateExternalStorage.Wrap(err).
+			GenWithStackByArgs("parsing azure blob storage uri")
+	} else if ok {
+		ret, err := newAzblobStorageWithManagedIdentity(ctx, azblobOpts)
+		if err != nil {
+			return nil, errors.ErrFailToCreateExternalStorage.Wrap(err).
+				GenWithStackByArgs("creating ExternalStorage for azure blob")
+		}
+		return ret, nil
+	}
+
 	backEnd, err := storage.ParseBackend(uri, opts)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -75,6 +94,18 @@ func GetExternalStorage(
 		retErr := errors.ErrFailToCreateExternalStorage.Wrap(errors.Trace(err))
 		return nil, retErr.GenWithStackByArgs("creating ExternalStorage for s3")
 	}
+	if local := backEnd.GetLocal(); local != nil {
+		u, err := storage.ParseRawURL(uri)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		localOpts, err := parseLocalStorageOptions(u.Query())
+		if err != nil {
+			return nil, errors.ErrFailToCreateExternalStorage.Wrap(err).
+				GenWithStackByArgs("parsing local storage uri")
+		}
+		return newLocalStorage(ret, local.Path, localOpts), nil
+	}
 	return ret, nil
 }
 
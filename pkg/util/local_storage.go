@@ -0,0 +1,407 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/fsutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// localFsyncPolicyParam controls when the written files are fsynced,
+	// it's one of none, always and batch.
+	localFsyncPolicyParam = "fsync-policy"
+	// localFsyncIntervalParam is the interval to fsync written files in
+	// batch if the fsync policy is batch.
+	localFsyncIntervalParam = "fsync-interval"
+	// localPreallocateParam enables preallocating the disk space of a file
+	// before writing it, so that a full disk is detected before any data is
+	// written.
+	localPreallocateParam = "preallocate"
+	// localMinAvailPercentageParam is the minimum available disk space in
+	// percentage, 0 disables the disk capacity watchdog.
+	localMinAvailPercentageParam = "min-disk-avail-percentage"
+	// localDiskFullActionParam is the action to take if the available disk
+	// space is less than min-disk-avail-percentage, it's one of warn and pause.
+	localDiskFullActionParam = "disk-full-action"
+
+	localFsyncNone   = "none"
+	localFsyncAlways = "always"
+	localFsyncBatch  = "batch"
+
+	localDiskFullWarn  = "warn"
+	localDiskFullPause = "pause"
+
+	defaultLocalFsyncInterval = time.Second
+	localDiskCheckInterval    = 5 * time.Second
+	// localStaleTmpFileAge is the age after which a temporary file is regarded
+	// as left by a crashed writer.
+	localStaleTmpFileAge = 10 * time.Minute
+
+	localDirPerm  os.FileMode = 0o755
+	localFilePerm os.FileMode = 0o644
+)
+
+// localTmpFileRegex matches the temporary files, which are named as
+// <name>.tmp.<uuid> and renamed to <name> once completely written.
+var localTmpFileRegex = regexp.MustCompile(
+	`\.tmp\.[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// localStorageOptions is the options of a local or NFS storage.
+type localStorageOptions struct {
+	fsyncPolicy        string
+	fsyncInterval      time.Duration
+	preallocate        bool
+	minAvailPercentage float64
+	diskFullAction     string
+}
+
+// parseLocalStorageOptions parses the options of a local storage from the
+// query of its uri.
+func parseLocalStorageOptions(query url.Values) (*localStorageOptions, error) {
+	opts := &localStorageOptions{
+		fsyncPolicy:    localFsyncNone,
+		fsyncInterval:  defaultLocalFsyncInterval,
+		diskFullAction: localDiskFullWarn,
+	}
+	if s := query.Get(localFsyncPolicyParam); s != "" {
+		switch s {
+		case localFsyncNone, localFsyncAlways, localFsyncBatch:
+			opts.fsyncPolicy = s
+		default:
+			return nil, errors.Errorf("invalid %s: %s", localFsyncPolicyParam, s)
+		}
+	}
+	if s := query.Get(localFsyncIntervalParam); s != "" {
+		interval, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid %s", localFsyncIntervalParam)
+		}
+		if interval <= 0 {
+			return nil, errors.Errorf("invalid %s: %s", localFsyncIntervalParam, s)
+		}
+		opts.fsyncInterval = interval
+	}
+	if s := query.Get(localPreallocateParam); s != "" {
+		preallocate, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid %s", localPreallocateParam)
+		}
+		opts.preallocate = preallocate
+	}
+	if s := query.Get(localMinAvailPercentageParam); s != "" {
+		percentage, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid %s", localMinAvailPercentageParam)
+		}
+		if percentage < 0 || percentage >= 100 {
+			return nil, errors.Errorf("invalid %s: %s", localMinAvailPercentageParam, s)
+		}
+		opts.minAvailPercentage = percentage
+	}
+	if s := query.Get(localDiskFullActionParam); s != "" {
+		switch s {
+		case localDiskFullWarn, localDiskFullPause:
+			opts.diskFullAction = s
+		default:
+			return nil, errors.Errorf("invalid %s: %s", localDiskFullActionParam, s)
+		}
+	}
+	return opts, nil
+}
+
+// localStorage wraps the local storage of br to harden it for local disks and
+// NFS volumes:
+//   - files are written to temporary files first and renamed once complete,
+//     so partially written files are never visible to readers, and stale
+//     temporary files left by crashed writers are removed on creation.
+//   - written files are fsynced according to the fsync policy.
+//   - writes are warned or rejected if the available disk space is low, so
+//     that the changefeed is paused before the volume fills.
+type localStorage struct {
+	storage.ExternalStorage
+
+	base        string
+	opts        *localStorageOptions
+	getDiskInfo func(dir string) (*fsutil.DiskInfo, error)
+
+	mu sync.Mutex
+	// dirty is the files which are written but not fsynced yet if the fsync
+	// policy is batch.
+	dirty         map[string]struct{}
+	lastSync      time.Time
+	lastDiskCheck time.Time
+	diskErr       error
+}
+
+func newLocalStorage(
+	inner storage.ExternalStorage, base string, opts *localStorageOptions,
+) *localStorage {
+	s := &localStorage{
+		ExternalStorage: inner,
+		base:            base,
+		opts:            opts,
+		getDiskInfo:     fsutil.GetDiskInfo,
+		dirty:           make(map[string]struct{}),
+		lastSync:        time.Now(),
+	}
+	s.removeStaleTmpFiles(localStaleTmpFileAge)
+	return s
+}
+
+// removeStaleTmpFiles removes the temporary files older than maxAge, which
+// are left by writers crashed in the middle of writing.
+func (s *localStorage) removeStaleTmpFiles(maxAge time.Duration) {
+	_ = filepath.WalkDir(s.base, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !localTmpFileRegex.MatchString(path) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || time.Since(info.ModTime()) < maxAge {
+			return nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Warn("failed to remove stale temporary file",
+				zap.String("path", path), zap.Error(err))
+			return nil
+		}
+		log.Info("stale temporary file is removed", zap.String("path", path))
+		return nil
+	})
+}
+
+// WriteFile writes a complete file to storage atomically.
+func (s *localStorage) WriteFile(_ context.Context, name string, data []byte) error {
+	if err := s.checkDiskCapacity(); err != nil {
+		return err
+	}
+	f, tmpPath, err := s.createTmpFile(name)
+	if err != nil {
+		return err
+	}
+	err = s.writeTmpFile(f, data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Trace(err)
+	}
+	return s.commit(tmpPath, filepath.Join(s.base, name))
+}
+
+func (s *localStorage) writeTmpFile(f *os.File, data []byte) error {
+	if s.opts.preallocate {
+		if err := fsutil.PreAllocate(f, int64(len(data))); err != nil {
+			return err
+		}
+	}
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	if s.opts.fsyncPolicy == localFsyncAlways {
+		return f.Sync()
+	}
+	return nil
+}
+
+// Create creates a file writer, the file is visible only after the writer
+// is closed successfully.
+func (s *localStorage) Create(_ context.Context, name string) (storage.ExternalFileWriter, error) {
+	if err := s.checkDiskCapacity(); err != nil {
+		return nil, err
+	}
+	f, tmpPath, err := s.createTmpFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &localFileWriter{
+		storage: s,
+		file:    f,
+		buf:     bufio.NewWriter(f),
+		tmpPath: tmpPath,
+		path:    filepath.Join(s.base, name),
+	}, nil
+}
+
+// WalkDir traverses all the files in a dir, temporary files are skipped.
+func (s *localStorage) WalkDir(
+	ctx context.Context, opt *storage.WalkOption, fn func(path string, size int64) error,
+) error {
+	return s.ExternalStorage.WalkDir(ctx, opt, func(path string, size int64) error {
+		if localTmpFileRegex.MatchString(path) {
+			return nil
+		}
+		return fn(path, size)
+	})
+}
+
+func (s *localStorage) createTmpFile(name string) (*os.File, string, error) {
+	path := filepath.Join(s.base, name)
+	if err := os.MkdirAll(filepath.Dir(path), localDirPerm); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	tmpPath := path + ".tmp." + uuid.NewString()
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, localFilePerm)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return f, tmpPath, nil
+}
+
+// commit renames a completely written temporary file to its final path.
+func (s *localStorage) commit(tmpPath, path string) error {
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Trace(err)
+	}
+	switch s.opts.fsyncPolicy {
+	case localFsyncAlways:
+		return syncDir(filepath.Dir(path))
+	case localFsyncBatch:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.dirty[path] = struct{}{}
+		if time.Since(s.lastSync) < s.opts.fsyncInterval {
+			return nil
+		}
+		return s.syncDirtyLocked()
+	}
+	return nil
+}
+
+// syncDirtyLocked fsyncs all dirty files and their directories.
+func (s *localStorage) syncDirtyLocked() error {
+	dirs := make(map[string]struct{})
+	for path := range s.dirty {
+		if err := syncFile(path); err != nil {
+			return err
+		}
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+	for dir := range dirs {
+		if err := syncDir(dir); err != nil {
+			return err
+		}
+	}
+	s.dirty = make(map[string]struct{})
+	s.lastSync = time.Now()
+	return nil
+}
+
+// checkDiskCapacity checks the available disk space of the storage at most
+// once every localDiskCheckInterval. An error is returned if the space is
+// low and the disk full action is pause.
+func (s *localStorage) checkDiskCapacity() error {
+	if s.opts.minAvailPercentage <= 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.lastDiskCheck) < localDiskCheckInterval {
+		return s.diskErr
+	}
+	s.lastDiskCheck = time.Now()
+	s.diskErr = nil
+
+	info, err := s.getDiskInfo(s.base)
+	if err != nil {
+		log.Warn("failed to get disk info of local storage",
+			zap.String("path", s.base), zap.Error(err))
+		return nil
+	}
+	// the disk info is in GB, so it's meaningless for a volume less than 1GB.
+	if info.All == 0 || float64(info.AvailPercentage) >= s.opts.minAvailPercentage {
+		return nil
+	}
+	log.Warn("available disk space of local storage is low",
+		zap.String("path", s.base),
+		zap.Stringer("diskInfo", info),
+		zap.Float64("minAvailPercentage", s.opts.minAvailPercentage),
+		zap.String("action", s.opts.diskFullAction))
+	if s.opts.diskFullAction == localDiskFullPause {
+		s.diskErr = errors.ErrLocalStorageDiskCapacity.GenWithStackByArgs(
+			s.base, info.AvailPercentage, s.opts.minAvailPercentage)
+	}
+	return s.diskErr
+}
+
+// localFileWriter writes a file to a temporary file, and renames it to the
+// final path on Close.
+type localFileWriter struct {
+	storage *localStorage
+	file    *os.File
+	buf     *bufio.Writer
+	tmpPath string
+	path    string
+}
+
+// Write writes to the temporary file.
+func (w *localFileWriter) Write(_ context.Context, p []byte) (int, error) {
+	n, err := w.buf.Write(p)
+	return n, errors.Trace(err)
+}
+
+// Close flushes the temporary file and renames it to the final path.
+func (w *localFileWriter) Close(_ context.Context) error {
+	err := w.buf.Flush()
+	if err == nil && w.storage.opts.fsyncPolicy == localFsyncAlways {
+		err = w.file.Sync()
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(w.tmpPath)
+		return errors.Trace(err)
+	}
+	return w.storage.commit(w.tmpPath, w.path)
+}
+
+func syncFile(path string) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the file is deleted after written.
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	return errors.Trace(f.Sync())
+}
+
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	return errors.Trace(f.Sync())
+}
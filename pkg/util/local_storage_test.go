@@ -0,0 +1,171 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/fsutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocalStorageOptions(t *testing.T) {
+	t.Parallel()
+
+	opts, err := parseLocalStorageOptions(url.Values{})
+	require.NoError(t, err)
+	require.Equal(t, &localStorageOptions{
+		fsyncPolicy:    localFsyncNone,
+		fsyncInterval:  defaultLocalFsyncInterval,
+		diskFullAction: localDiskFullWarn,
+	}, opts)
+
+	query, err := url.ParseQuery("fsync-policy=batch&fsync-interval=200ms&preallocate=true" +
+		"&min-disk-avail-percentage=10&disk-full-action=pause")
+	require.NoError(t, err)
+	opts, err = parseLocalStorageOptions(query)
+	require.NoError(t, err)
+	require.Equal(t, &localStorageOptions{
+		fsyncPolicy:        localFsyncBatch,
+		fsyncInterval:      200 * time.Millisecond,
+		preallocate:        true,
+		minAvailPercentage: 10,
+		diskFullAction:     localDiskFullPause,
+	}, opts)
+
+	for _, q := range []string{
+		"fsync-policy=sometimes",
+		"fsync-interval=0s",
+		"fsync-interval=abc",
+		"preallocate=abc",
+		"min-disk-avail-percentage=100",
+		"min-disk-avail-percentage=-1",
+		"disk-full-action=stop",
+	} {
+		query, err := url.ParseQuery(q)
+		require.NoError(t, err)
+		_, err = parseLocalStorageOptions(query)
+		require.Error(t, err, q)
+	}
+}
+
+func TestLocalStorageWriteFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	extStorage, err := GetExternalStorageFromURI(ctx,
+		fmt.Sprintf("file://%s?fsync-policy=always&preallocate=true", dir))
+	require.NoError(t, err)
+	s, ok := extStorage.(*localStorage)
+	require.True(t, ok)
+
+	require.NoError(t, s.WriteFile(ctx, "a/b/data.json", []byte("data")))
+	data, err := s.ReadFile(ctx, "a/b/data.json")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+
+	w, err := s.Create(ctx, "a/c/data.log")
+	require.NoError(t, err)
+	_, err = w.Write(ctx, []byte("log"))
+	require.NoError(t, err)
+	// the file is invisible before the writer is closed.
+	exists, err := s.FileExists(ctx, "a/c/data.log")
+	require.NoError(t, err)
+	require.False(t, exists)
+	require.NoError(t, w.Close(ctx))
+	data, err = s.ReadFile(ctx, "a/c/data.log")
+	require.NoError(t, err)
+	require.Equal(t, []byte("log"), data)
+
+	// temporary files are skipped by WalkDir.
+	tmpFile := filepath.Join(dir, "a", "b", "data2.json.tmp.123e4567-e89b-12d3-a456-426614174000")
+	require.NoError(t, os.WriteFile(tmpFile, []byte("partial"), 0o644))
+	var files []string
+	require.NoError(t, s.WalkDir(ctx, nil, func(path string, _ int64) error {
+		files = append(files, path)
+		return nil
+	}))
+	require.ElementsMatch(t, []string{"a/b/data.json", "a/c/data.log"}, files)
+
+	// stale temporary files are removed.
+	s.removeStaleTmpFiles(time.Hour)
+	require.FileExists(t, tmpFile)
+	s.removeStaleTmpFiles(0)
+	require.NoFileExists(t, tmpFile)
+	require.FileExists(t, filepath.Join(dir, "a", "b", "data.json"))
+}
+
+func TestLocalStorageFsyncBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	extStorage, err := GetExternalStorageFromURI(ctx,
+		fmt.Sprintf("file://%s?fsync-policy=batch&fsync-interval=1h", dir))
+	require.NoError(t, err)
+	s := extStorage.(*localStorage)
+
+	require.NoError(t, s.WriteFile(ctx, "1.json", []byte("1")))
+	require.NoError(t, s.WriteFile(ctx, "2.json", []byte("2")))
+	require.Len(t, s.dirty, 2)
+
+	// all dirty files are synced once the interval elapses.
+	s.lastSync = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, s.WriteFile(ctx, "3.json", []byte("3")))
+	require.Len(t, s.dirty, 0)
+}
+
+func TestLocalStorageDiskCapacity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	for _, action := range []string{localDiskFullWarn, localDiskFullPause} {
+		dir := t.TempDir()
+		extStorage, err := GetExternalStorageFromURI(ctx, fmt.Sprintf(
+			"file://%s?min-disk-avail-percentage=10&disk-full-action=%s", dir, action))
+		require.NoError(t, err)
+		s := extStorage.(*localStorage)
+
+		availPercentage := float32(50)
+		s.getDiskInfo = func(string) (*fsutil.DiskInfo, error) {
+			return &fsutil.DiskInfo{All: 100, AvailPercentage: availPercentage}, nil
+		}
+		require.NoError(t, s.WriteFile(ctx, "1.json", []byte("1")))
+
+		availPercentage = 5
+		s.lastDiskCheck = time.Time{}
+		err = s.WriteFile(ctx, "2.json", []byte("2"))
+		w, createErr := s.Create(ctx, "3.json")
+		if action == localDiskFullPause {
+			require.Regexp(t, "ErrLocalStorageDiskCapacity", err)
+			require.Regexp(t, "ErrLocalStorageDiskCapacity", createErr)
+		} else {
+			require.NoError(t, err)
+			require.NoError(t, createErr)
+			require.NoError(t, w.Close(ctx))
+		}
+
+		// writes are resumed once the disk space is freed.
+		availPercentage = 50
+		s.lastDiskCheck = time.Time{}
+		require.NoError(t, s.WriteFile(ctx, "2.json", []byte("2")))
+	}
+}
@@ -76,6 +76,9 @@ type MessageEntry struct {
 	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	// monotonically increase.
 	Sequence int64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// optional CRC32C checksum of topic, sequence and content.
+	// Zero means the sender has not computed a checksum.
+	Checksum uint32 `protobuf:"varint,4,opt,name=checksum,proto3" json:"checksum,omitempty"`
 }
 
 func (m *MessageEntry) Reset()         { *m = MessageEntry{} }
@@ -132,6 +135,13 @@ func (m *MessageEntry) GetSequence() int64 {
 	return 0
 }
 
+func (m *MessageEntry) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
+	}
+	return 0
+}
+
 // Metadata associated with one client-server bidirectional stream.
 type StreamMeta struct {
 	// fields required for correctness
@@ -391,44 +401,45 @@ func init() {
 func init() { proto.RegisterFile("CDCPeerToPeer.proto", fileDescriptor_6560df28dddfd2cc) }
 
 var fileDescriptor_6560df28dddfd2cc = []byte{
-	// 578 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xae, 0x9b, 0x6d, 0x6d, 0x5f, 0xd7, 0x2d, 0xb8, 0x15, 0x84, 0x22, 0x95, 0xaa, 0x13, 0x52,
-	0x05, 0xd2, 0x98, 0x0a, 0xe2, 0x8a, 0x42, 0x1a, 0x41, 0xb4, 0x35, 0xad, 0x92, 0x14, 0x24, 0x2e,
-	0x51, 0x48, 0x9e, 0x46, 0xd4, 0x2d, 0xce, 0x6c, 0x33, 0x8d, 0x7f, 0xc0, 0x09, 0xf1, 0x87, 0xb8,
-	0x73, 0xdc, 0x91, 0x23, 0xda, 0xfe, 0x08, 0xaa, 0x93, 0x6e, 0x9d, 0x10, 0x17, 0xcb, 0xdf, 0xf7,
-	0x3d, 0xbf, 0xcf, 0xfe, 0x9e, 0x0c, 0x6d, 0x6b, 0x6c, 0xcd, 0x10, 0x79, 0xc0, 0x96, 0xeb, 0x7e,
-	0xce, 0x99, 0x64, 0x54, 0xcb, 0x47, 0x79, 0xb7, 0x73, 0xcc, 0x8e, 0x99, 0xc2, 0xcf, 0x97, 0xbb,
-	0x42, 0x1a, 0x7c, 0x84, 0xed, 0x09, 0x0a, 0x11, 0x1d, 0xa3, 0x9d, 0x49, 0xfe, 0x95, 0x76, 0x60,
-	0x53, 0xb2, 0x3c, 0x8d, 0x0d, 0xd2, 0x27, 0xc3, 0x86, 0x57, 0x00, 0x6a, 0x40, 0x2d, 0x66, 0x99,
-	0xc4, 0x4c, 0x1a, 0xd5, 0x3e, 0x19, 0x6e, 0x7b, 0x2b, 0x48, 0xbb, 0x50, 0x17, 0x78, 0xf6, 0x05,
-	0xb3, 0x18, 0x0d, 0xad, 0x4f, 0x86, 0x9a, 0x77, 0x83, 0x07, 0x3f, 0x09, 0x80, 0x2f, 0x39, 0x46,
-	0xa7, 0x13, 0x94, 0x11, 0x7d, 0x04, 0x0d, 0x81, 0x59, 0x82, 0x3c, 0x4c, 0x93, 0xb2, 0x7d, 0xbd,
-	0x20, 0x9c, 0x84, 0x3e, 0x86, 0x26, 0xc7, 0x18, 0xd3, 0xf3, 0x42, 0xae, 0x2a, 0x19, 0x56, 0x94,
-	0x93, 0x2c, 0x2f, 0x86, 0x39, 0x8b, 0x3f, 0x97, 0x2e, 0x05, 0xa0, 0x4f, 0x60, 0x27, 0x3e, 0x49,
-	0x31, 0x93, 0xe1, 0x39, 0x72, 0x91, 0xb2, 0xcc, 0x18, 0xa9, 0x93, 0xad, 0x82, 0x7d, 0x5f, 0x90,
-	0xf4, 0x25, 0xdc, 0x2f, 0xad, 0xa3, 0xe4, 0x1c, 0xb9, 0x4c, 0x05, 0x26, 0x61, 0x94, 0x24, 0xdc,
-	0x48, 0x54, 0x79, 0xa7, 0x50, 0xcd, 0x1b, 0xd1, 0x4c, 0x12, 0x3e, 0x88, 0xa0, 0x55, 0x66, 0x33,
-	0x8b, 0xe2, 0x05, 0x4a, 0xba, 0x07, 0x1b, 0xa7, 0x28, 0x23, 0x75, 0xf9, 0xe6, 0x68, 0x77, 0x3f,
-	0x1f, 0xe5, 0xfb, 0xb7, 0x0f, 0xf4, 0x94, 0x48, 0x9f, 0x41, 0x0d, 0x33, 0xc9, 0x53, 0x14, 0x46,
-	0xb5, 0xaf, 0x0d, 0x9b, 0xa3, 0x7b, 0xaa, 0x6e, 0x3d, 0x65, 0x6f, 0x55, 0x31, 0x78, 0x05, 0x9a,
-	0x19, 0x2f, 0xfe, 0x93, 0xfa, 0x43, 0xa8, 0x9f, 0x44, 0x42, 0x86, 0x02, 0xcf, 0x54, 0x20, 0x9a,
-	0x57, 0x5b, 0x62, 0x1f, 0xcf, 0x06, 0xdf, 0x08, 0xb4, 0x7d, 0xcc, 0x92, 0xb2, 0xab, 0x87, 0x22,
-	0x67, 0x99, 0x40, 0xda, 0x05, 0x2d, 0x8a, 0x17, 0x06, 0x51, 0xc6, 0x75, 0x65, 0x6c, 0xc6, 0x0b,
-	0x6f, 0x49, 0xd2, 0x03, 0x68, 0xe2, 0x45, 0x2a, 0x43, 0x8e, 0x91, 0x60, 0x99, 0xea, 0xb8, 0x53,
-	0x3e, 0xc2, 0xbe, 0x48, 0xa5, 0xa7, 0x68, 0x0f, 0xf0, 0x66, 0x4f, 0xf7, 0xa0, 0x85, 0x9c, 0x33,
-	0x1e, 0x9e, 0x16, 0x36, 0x2a, 0xfb, 0x86, 0xb7, 0xad, 0xc8, 0xd2, 0xfa, 0xe9, 0x77, 0x02, 0x70,
-	0x7b, 0x9e, 0x36, 0xa1, 0x36, 0x77, 0x0f, 0xdd, 0xe9, 0x07, 0x57, 0xaf, 0xd0, 0x2d, 0xa8, 0x4e,
-	0x0f, 0x75, 0x42, 0x5b, 0xd0, 0xb0, 0xa6, 0xee, 0x5b, 0xdb, 0x0f, 0xec, 0xb1, 0x5e, 0xa5, 0x6d,
-	0xd8, 0xb5, 0xcc, 0x59, 0x30, 0xf7, 0xec, 0xd0, 0x9f, 0x3b, 0x96, 0x33, 0xb6, 0x75, 0x8d, 0x76,
-	0x40, 0xf7, 0x03, 0xf3, 0xc8, 0x0e, 0xad, 0xa9, 0xeb, 0xda, 0x56, 0xe0, 0x4c, 0x5d, 0x7d, 0x83,
-	0x1a, 0xd0, 0x19, 0xcf, 0x67, 0x47, 0x8e, 0x65, 0x06, 0x77, 0x94, 0x4d, 0xfa, 0x00, 0xda, 0xab,
-	0x26, 0xce, 0x38, 0x9c, 0x38, 0xfe, 0xc4, 0x0c, 0xac, 0x77, 0xfa, 0xd6, 0x68, 0x06, 0xad, 0x3b,
-	0x9f, 0x80, 0xbe, 0x86, 0xe6, 0x5a, 0x56, 0x94, 0xae, 0xcf, 0xa3, 0x98, 0x6c, 0xd7, 0x28, 0x66,
-	0xf9, 0x6f, 0xa2, 0x43, 0x72, 0x40, 0xde, 0x18, 0xbf, 0xae, 0x7a, 0xe4, 0xf2, 0xaa, 0x47, 0xfe,
-	0x5c, 0xf5, 0xc8, 0x8f, 0xeb, 0x5e, 0xe5, 0xf2, 0xba, 0x57, 0xf9, 0x7d, 0xdd, 0xab, 0x7c, 0xda,
-	0x52, 0xbf, 0xe8, 0xc5, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe3, 0x85, 0x98, 0x36, 0x77, 0x03,
-	0x00, 0x00,
+	// 593 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x41, 0x6f, 0xd3, 0x4c,
+	0x10, 0xcd, 0xc6, 0x6d, 0x93, 0x4c, 0x9a, 0xd6, 0xdf, 0x26, 0xfa, 0x30, 0x45, 0x0a, 0x51, 0x2a,
+	0xa4, 0x08, 0xa4, 0x52, 0x05, 0xc4, 0x15, 0x19, 0xc7, 0x02, 0xab, 0x8d, 0x13, 0xd9, 0x0e, 0x1c,
+	0x2d, 0x63, 0x8f, 0x5a, 0x2b, 0x8d, 0xed, 0xee, 0x6e, 0xa3, 0xf2, 0x0f, 0x38, 0x21, 0xfe, 0x10,
+	0x77, 0x8e, 0x3d, 0x72, 0x44, 0xed, 0x1f, 0x41, 0x5e, 0x3b, 0x69, 0x2a, 0xc4, 0xc5, 0xf2, 0x7b,
+	0x6f, 0x76, 0xde, 0xec, 0x3c, 0x2d, 0xb4, 0x8d, 0x91, 0x31, 0x45, 0x64, 0x5e, 0x9a, 0x7f, 0x8f,
+	0x32, 0x96, 0x8a, 0x94, 0x2a, 0xd9, 0x30, 0x3b, 0xe8, 0x9c, 0xa5, 0x67, 0xa9, 0xc4, 0x2f, 0xf3,
+	0xbf, 0x42, 0xea, 0x2f, 0x61, 0x77, 0x8c, 0x9c, 0x07, 0x67, 0x68, 0x26, 0x82, 0x7d, 0xa1, 0x1d,
+	0xd8, 0x16, 0x69, 0x16, 0x87, 0x1a, 0xe9, 0x91, 0x41, 0xc3, 0x29, 0x00, 0xd5, 0xa0, 0x16, 0xa6,
+	0x89, 0xc0, 0x44, 0x68, 0xd5, 0x1e, 0x19, 0xec, 0x3a, 0x2b, 0x48, 0x0f, 0xa0, 0xce, 0xf1, 0xf2,
+	0x0a, 0x93, 0x10, 0x35, 0xa5, 0x47, 0x06, 0x8a, 0xb3, 0xc6, 0xb9, 0x16, 0x9e, 0x63, 0x38, 0xe7,
+	0x57, 0x0b, 0x6d, 0xab, 0x47, 0x06, 0x2d, 0x67, 0x8d, 0xfb, 0x3f, 0x08, 0x80, 0x2b, 0x18, 0x06,
+	0x8b, 0x31, 0x8a, 0x80, 0x3e, 0x81, 0x06, 0xc7, 0x24, 0x42, 0xe6, 0xc7, 0x51, 0x69, 0x5d, 0x2f,
+	0x08, 0x2b, 0xa2, 0x4f, 0xa1, 0xc9, 0x30, 0xc4, 0x78, 0x59, 0xc8, 0x55, 0x29, 0xc3, 0x8a, 0xb2,
+	0xa2, 0x7c, 0x68, 0xcc, 0xd2, 0xf0, 0xbc, 0x9c, 0xa0, 0x00, 0xf4, 0x19, 0xec, 0x85, 0x17, 0x31,
+	0x26, 0xc2, 0x5f, 0x22, 0xe3, 0x71, 0x9a, 0x68, 0x43, 0x79, 0xb2, 0x55, 0xb0, 0x1f, 0x0b, 0x92,
+	0xbe, 0x86, 0xff, 0x4b, 0xeb, 0x20, 0x5a, 0x22, 0x13, 0x31, 0xc7, 0xc8, 0x0f, 0xa2, 0x88, 0x69,
+	0x91, 0x2c, 0xef, 0x14, 0xaa, 0xbe, 0x16, 0xf5, 0x28, 0x62, 0xfd, 0x00, 0x5a, 0xe5, 0xde, 0xa6,
+	0x41, 0x38, 0x47, 0x41, 0x0f, 0x61, 0x6b, 0x81, 0x22, 0x90, 0xc3, 0x37, 0x87, 0xfb, 0x47, 0xd9,
+	0x30, 0x3b, 0xba, 0xbf, 0xa0, 0x23, 0x45, 0xfa, 0x02, 0x6a, 0x98, 0x08, 0x16, 0x23, 0xd7, 0xaa,
+	0x3d, 0x65, 0xd0, 0x1c, 0xfe, 0x27, 0xeb, 0x36, 0x13, 0x70, 0x56, 0x15, 0xfd, 0x37, 0xa0, 0xe8,
+	0xe1, 0xfc, 0x1f, 0x89, 0x3c, 0x86, 0xfa, 0x45, 0xc0, 0x85, 0xcf, 0xf1, 0x52, 0x2e, 0x44, 0x71,
+	0x6a, 0x39, 0x76, 0xf1, 0xb2, 0xff, 0x95, 0x40, 0xdb, 0xc5, 0x24, 0x2a, 0xbb, 0x3a, 0xc8, 0xb3,
+	0x34, 0xe1, 0x79, 0x1c, 0x4a, 0x10, 0xce, 0x35, 0x22, 0x8d, 0xeb, 0xd2, 0x58, 0x0f, 0xe7, 0x4e,
+	0x4e, 0xd2, 0x63, 0x68, 0xe2, 0x75, 0x2c, 0x7c, 0x86, 0x01, 0x4f, 0x13, 0xd9, 0x71, 0xaf, 0xbc,
+	0x84, 0x79, 0x1d, 0x0b, 0x47, 0xd2, 0x0e, 0xe0, 0xfa, 0x9f, 0x1e, 0x42, 0x0b, 0x19, 0x4b, 0x99,
+	0xbf, 0x28, 0x6c, 0xe4, 0xee, 0x1b, 0xce, 0xae, 0x24, 0x4b, 0xeb, 0xe7, 0xdf, 0x08, 0xc0, 0xfd,
+	0x79, 0xda, 0x84, 0xda, 0xcc, 0x3e, 0xb1, 0x27, 0x9f, 0x6c, 0xb5, 0x42, 0x77, 0xa0, 0x3a, 0x39,
+	0x51, 0x09, 0x6d, 0x41, 0xc3, 0x98, 0xd8, 0xef, 0x4d, 0xd7, 0x33, 0x47, 0x6a, 0x95, 0xb6, 0x61,
+	0xdf, 0xd0, 0xa7, 0xde, 0xcc, 0x31, 0x7d, 0x77, 0x66, 0x19, 0xd6, 0xc8, 0x54, 0x15, 0xda, 0x01,
+	0xd5, 0xf5, 0xf4, 0x53, 0xd3, 0x37, 0x26, 0xb6, 0x6d, 0x1a, 0x9e, 0x35, 0xb1, 0xd5, 0x2d, 0xaa,
+	0x41, 0x67, 0x34, 0x9b, 0x9e, 0x5a, 0x86, 0xee, 0x3d, 0x50, 0xb6, 0xe9, 0x23, 0x68, 0xaf, 0x9a,
+	0x58, 0x23, 0x7f, 0x6c, 0xb9, 0x63, 0xdd, 0x33, 0x3e, 0xa8, 0x3b, 0xc3, 0x29, 0xb4, 0x1e, 0x3c,
+	0x10, 0xfa, 0x16, 0x9a, 0x1b, 0xbb, 0xa2, 0x74, 0x33, 0x8f, 0x22, 0xd9, 0x03, 0xad, 0xc8, 0xf2,
+	0xef, 0x8d, 0x0e, 0xc8, 0x31, 0x79, 0xa7, 0xfd, 0xbc, 0xed, 0x92, 0x9b, 0xdb, 0x2e, 0xf9, 0x7d,
+	0xdb, 0x25, 0xdf, 0xef, 0xba, 0x95, 0x9b, 0xbb, 0x6e, 0xe5, 0xd7, 0x5d, 0xb7, 0xf2, 0x79, 0x47,
+	0xbe, 0xb0, 0x57, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x48, 0x6e, 0xe7, 0x01, 0x93, 0x03, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -569,6 +580,11 @@ func (m *MessageEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Checksum != 0 {
+		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(m.Checksum))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Sequence != 0 {
 		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(m.Sequence))
 		i--
@@ -812,6 +828,9 @@ func (m *MessageEntry) Size() (n int) {
 	if m.Sequence != 0 {
 		n += 1 + sovCDCPeerToPeer(uint64(m.Sequence))
 	}
+	if m.Checksum != 0 {
+		n += 1 + sovCDCPeerToPeer(uint64(m.Checksum))
+	}
 	return n
 }
 
@@ -1020,6 +1039,25 @@ func (m *MessageEntry) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			m.Checksum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCDCPeerToPeer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Checksum |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCDCPeerToPeer(dAtA[iNdEx:])